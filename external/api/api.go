@@ -12,6 +12,7 @@ import (
 	"github.com/pagpeter/typescript-go/external/astnav"
 	"github.com/pagpeter/typescript-go/external/checker"
 	"github.com/pagpeter/typescript-go/external/core"
+	"github.com/pagpeter/typescript-go/external/diagnostics"
 	"github.com/pagpeter/typescript-go/external/lsp/lsproto"
 	"github.com/pagpeter/typescript-go/external/project"
 	"github.com/pagpeter/typescript-go/external/tsoptions"
@@ -121,6 +122,11 @@ func (api *API) PositionEncoding() lsproto.PositionEncodingKind {
 	return lsproto.PositionEncodingKindUTF8
 }
 
+// Locale implements ProjectHost.
+func (api *API) Locale() *diagnostics.Locale {
+	return nil
+}
+
 // Client implements ProjectHost.
 func (api *API) Client() project.Client {
 	return nil
@@ -2054,7 +2054,9 @@ func (node *Token) computeSubtreeFacts() SubtreeFacts {
 		return SubtreeContainsClassFields
 	case KindAsteriskAsteriskToken, KindAsteriskAsteriskEqualsToken:
 		return SubtreeContainsExponentiationOperator
-	case KindQuestionQuestionToken, KindQuestionDotToken:
+	case KindQuestionQuestionToken:
+		return SubtreeContainsNullishCoalescing
+	case KindQuestionDotToken:
 		return SubtreeContainsOptionalChaining
 	case KindQuestionQuestionEqualsToken, KindBarBarEqualsToken, KindAmpersandAmpersandEqualsToken:
 		return SubtreeContainsLogicalAssignments
@@ -2592,6 +2594,10 @@ func (node *BreakStatement) Clone(f NodeFactoryCoercible) *Node {
 	return cloneNode(f.AsNodeFactory().NewBreakStatement(node.Label), node.AsNode(), f.AsNodeFactory().hooks)
 }
 
+func IsBreakStatement(node *Node) bool {
+	return node.Kind == KindBreakStatement
+}
+
 // ContinueStatement
 
 type ContinueStatement struct {
@@ -2739,6 +2745,10 @@ func (node *SwitchStatement) Clone(f NodeFactoryCoercible) *Node {
 	return cloneNode(f.AsNodeFactory().NewSwitchStatement(node.Expression, node.CaseBlock), node.AsNode(), f.AsNodeFactory().hooks)
 }
 
+func IsSwitchStatement(node *Node) bool {
+	return node.Kind == KindSwitchStatement
+}
+
 func (node *SwitchStatement) computeSubtreeFacts() SubtreeFacts {
 	return propagateSubtreeFacts(node.Expression) |
 		propagateSubtreeFacts(node.CaseBlock)
@@ -2863,6 +2873,10 @@ func (node *ThrowStatement) Clone(f NodeFactoryCoercible) *Node {
 	return cloneNode(f.AsNodeFactory().NewThrowStatement(node.Expression), node.AsNode(), f.AsNodeFactory().hooks)
 }
 
+func IsThrowStatement(node *Node) bool {
+	return node.Kind == KindThrowStatement
+}
+
 func (node *ThrowStatement) computeSubtreeFacts() SubtreeFacts {
 	return propagateSubtreeFacts(node.Expression)
 }
@@ -3540,6 +3554,7 @@ func (node *FunctionDeclaration) computeSubtreeFacts() SubtreeFacts {
 			propagateNodeListSubtreeFacts(node.Parameters, propagateSubtreeFacts) |
 			propagateEraseableSyntaxSubtreeFacts(node.Type) |
 			propagateSubtreeFacts(node.Body) |
+			core.IfElse(isGenerator, SubtreeContainsGenerator, SubtreeFactsNone) |
 			core.IfElse(isAsync && isGenerator, SubtreeContainsForAwaitOrAsyncGenerator, SubtreeFactsNone) |
 			core.IfElse(isAsync && !isGenerator, SubtreeContainsAnyAwait, SubtreeFactsNone)
 	}
@@ -5243,6 +5258,7 @@ func (node *MethodDeclaration) computeSubtreeFacts() SubtreeFacts {
 			propagateNodeListSubtreeFacts(node.Parameters, propagateSubtreeFacts) |
 			propagateSubtreeFacts(node.Body) |
 			propagateEraseableSyntaxSubtreeFacts(node.Type) |
+			core.IfElse(isGenerator, SubtreeContainsGenerator, SubtreeFactsNone) |
 			core.IfElse(isAsync && isGenerator, SubtreeContainsForAwaitOrAsyncGenerator, SubtreeFactsNone) |
 			core.IfElse(isAsync && !isGenerator, SubtreeContainsAnyAwait, SubtreeFactsNone)
 	}
@@ -5735,6 +5751,10 @@ func (node *PostfixUnaryExpression) computeSubtreeFacts() SubtreeFacts {
 	return propagateSubtreeFacts(node.Operand)
 }
 
+func IsPostfixUnaryExpression(node *Node) bool {
+	return node.Kind == KindPostfixUnaryExpression
+}
+
 // YieldExpression
 
 type YieldExpression struct {
@@ -5769,8 +5789,12 @@ func (node *YieldExpression) Clone(f NodeFactoryCoercible) *Node {
 	return cloneNode(f.AsNodeFactory().NewYieldExpression(node.AsteriskToken, node.Expression), node.AsNode(), f.AsNodeFactory().hooks)
 }
 
+func IsYieldExpression(node *Node) bool {
+	return node.Kind == KindYieldExpression
+}
+
 func (node *YieldExpression) computeSubtreeFacts() SubtreeFacts {
-	return propagateSubtreeFacts(node.Expression) | SubtreeContainsES2018
+	return propagateSubtreeFacts(node.Expression) | SubtreeContainsES2018 | SubtreeContainsYield
 }
 
 // ArrowFunction
@@ -5896,6 +5920,7 @@ func (node *FunctionExpression) computeSubtreeFacts() SubtreeFacts {
 		propagateNodeListSubtreeFacts(node.Parameters, propagateSubtreeFacts) |
 		propagateEraseableSyntaxSubtreeFacts(node.Type) |
 		propagateSubtreeFacts(node.Body) |
+		core.IfElse(isGenerator, SubtreeContainsGenerator, SubtreeFactsNone) |
 		core.IfElse(isAsync && isGenerator, SubtreeContainsForAwaitOrAsyncGenerator, SubtreeFactsNone) |
 		core.IfElse(isAsync && !isGenerator, SubtreeContainsAnyAwait, SubtreeFactsNone)
 }
@@ -6093,7 +6118,8 @@ func (node *PropertyAccessExpression) Name() *DeclarationName { return node.name
 func (node *PropertyAccessExpression) computeSubtreeFacts() SubtreeFacts {
 	return propagateSubtreeFacts(node.Expression) |
 		propagateSubtreeFacts(node.QuestionDotToken) |
-		propagateSubtreeFacts(node.name)
+		propagateSubtreeFacts(node.name) |
+		core.IfElse(node.Expression.Kind == KindSuperKeyword, SubtreeContainsES2017, SubtreeFactsNone) // super property access may need to be rewritten inside of async methods
 }
 
 func (node *PropertyAccessExpression) propagateSubtreeFacts() SubtreeFacts {
@@ -6147,7 +6173,8 @@ func (node *ElementAccessExpression) Clone(f NodeFactoryCoercible) *Node {
 func (node *ElementAccessExpression) computeSubtreeFacts() SubtreeFacts {
 	return propagateSubtreeFacts(node.Expression) |
 		propagateSubtreeFacts(node.QuestionDotToken) |
-		propagateSubtreeFacts(node.ArgumentExpression)
+		propagateSubtreeFacts(node.ArgumentExpression) |
+		core.IfElse(node.Expression.Kind == KindSuperKeyword, SubtreeContainsES2017, SubtreeFactsNone) // super element access may need to be rewritten inside of async methods
 }
 
 func (node *ElementAccessExpression) propagateSubtreeFacts() SubtreeFacts {
@@ -21,6 +21,10 @@ type Diagnostic struct {
 	relatedInformation []*Diagnostic
 	reportsUnnecessary bool
 	reportsDeprecated  bool
+
+	// the message and arguments the diagnostic was created from, used to localize it
+	messageTemplate *diagnostics.Message
+	messageArgs     []any
 }
 
 func (d *Diagnostic) File() *SourceFile                 { return d.file }
@@ -36,6 +40,15 @@ func (d *Diagnostic) RelatedInformation() []*Diagnostic { return d.relatedInform
 func (d *Diagnostic) ReportsUnnecessary() bool          { return d.reportsUnnecessary }
 func (d *Diagnostic) ReportsDeprecated() bool           { return d.reportsDeprecated }
 
+// Localize returns the message of the diagnostic in the given locale. Diagnostics that were not
+// created from a message template, such as those read back from a .tsbuildinfo file, stay in English.
+func (d *Diagnostic) Localize(locale *diagnostics.Locale) string {
+	if locale == nil || d.messageTemplate == nil {
+		return d.message
+	}
+	return d.messageTemplate.Localize(locale, d.messageArgs...)
+}
+
 func (d *Diagnostic) SetFile(file *SourceFile)                  { d.file = file }
 func (d *Diagnostic) SetLocation(loc core.TextRange)            { d.loc = loc }
 func (d *Diagnostic) SetCategory(category diagnostics.Category) { d.category = category }
@@ -78,6 +91,34 @@ func NewDiagnostic(file *SourceFile, loc core.TextRange, message *diagnostics.Me
 		message:            message.Format(args...),
 		reportsUnnecessary: message.ReportsUnnecessary(),
 		reportsDeprecated:  message.ReportsDeprecated(),
+		messageTemplate:    message,
+		messageArgs:        args,
+	}
+}
+
+// NewDiagnosticFromSerialized recreates a diagnostic whose message has already been formatted,
+// such as one read back from a .tsbuildinfo file.
+func NewDiagnosticFromSerialized(
+	file *SourceFile,
+	loc core.TextRange,
+	code int32,
+	category diagnostics.Category,
+	message string,
+	messageChain []*Diagnostic,
+	relatedInformation []*Diagnostic,
+	reportsUnnecessary bool,
+	reportsDeprecated bool,
+) *Diagnostic {
+	return &Diagnostic{
+		file:               file,
+		loc:                loc,
+		code:               code,
+		category:           category,
+		message:            message,
+		messageChain:       messageChain,
+		relatedInformation: relatedInformation,
+		reportsUnnecessary: reportsUnnecessary,
+		reportsDeprecated:  reportsDeprecated,
 	}
 }
 
@@ -24,6 +24,7 @@ const (
 	SubtreeContainsForAwaitOrAsyncGenerator
 	SubtreeContainsAnyAwait
 	SubtreeContainsExponentiationOperator
+	SubtreeContainsGenerator
 
 	// Markers
 	// - Flags used to indicate that a node or subtree contains a particular kind of syntax.
@@ -33,6 +34,7 @@ const (
 	SubtreeContainsRest
 	SubtreeContainsObjectRestOrSpread
 	SubtreeContainsAwait
+	SubtreeContainsYield
 	SubtreeContainsDynamicImport
 	SubtreeContainsClassFields
 	SubtreeContainsDecorators
@@ -62,10 +64,10 @@ const (
 	SubtreeExclusionsPropertyAccess          = SubtreeExclusionsNode
 	SubtreeExclusionsElementAccess           = SubtreeExclusionsNode
 	SubtreeExclusionsArrowFunction           = SubtreeExclusionsNode | SubtreeContainsAwait | SubtreeContainsObjectRestOrSpread
-	SubtreeExclusionsFunction                = SubtreeExclusionsNode | SubtreeContainsLexicalThis | SubtreeContainsLexicalSuper | SubtreeContainsAwait | SubtreeContainsObjectRestOrSpread
-	SubtreeExclusionsConstructor             = SubtreeExclusionsNode | SubtreeContainsLexicalThis | SubtreeContainsLexicalSuper | SubtreeContainsAwait | SubtreeContainsObjectRestOrSpread
-	SubtreeExclusionsMethod                  = SubtreeExclusionsNode | SubtreeContainsLexicalThis | SubtreeContainsLexicalSuper | SubtreeContainsAwait | SubtreeContainsObjectRestOrSpread
-	SubtreeExclusionsAccessor                = SubtreeExclusionsNode | SubtreeContainsLexicalThis | SubtreeContainsLexicalSuper | SubtreeContainsAwait | SubtreeContainsObjectRestOrSpread
+	SubtreeExclusionsFunction                = SubtreeExclusionsNode | SubtreeContainsLexicalThis | SubtreeContainsLexicalSuper | SubtreeContainsAwait | SubtreeContainsYield | SubtreeContainsObjectRestOrSpread
+	SubtreeExclusionsConstructor             = SubtreeExclusionsNode | SubtreeContainsLexicalThis | SubtreeContainsLexicalSuper | SubtreeContainsAwait | SubtreeContainsYield | SubtreeContainsObjectRestOrSpread
+	SubtreeExclusionsMethod                  = SubtreeExclusionsNode | SubtreeContainsLexicalThis | SubtreeContainsLexicalSuper | SubtreeContainsAwait | SubtreeContainsYield | SubtreeContainsObjectRestOrSpread
+	SubtreeExclusionsAccessor                = SubtreeExclusionsNode | SubtreeContainsLexicalThis | SubtreeContainsLexicalSuper | SubtreeContainsAwait | SubtreeContainsYield | SubtreeContainsObjectRestOrSpread
 	SubtreeExclusionsProperty                = SubtreeExclusionsNode | SubtreeContainsLexicalThis | SubtreeContainsLexicalSuper
 	SubtreeExclusionsClass                   = SubtreeExclusionsNode
 	SubtreeExclusionsModule                  = SubtreeExclusionsNode | SubtreeContainsLexicalThis | SubtreeContainsLexicalSuper
@@ -96,7 +98,7 @@ func propagateObjectBindingElementSubtreeFacts(child *BindingElementNode) Subtre
 	facts := propagateSubtreeFacts(child)
 	if facts&SubtreeContainsRest != 0 {
 		facts &= ^SubtreeContainsRest
-		facts |= SubtreeContainsObjectRestOrSpread
+		facts |= SubtreeContainsESObjectRestOrSpread | SubtreeContainsObjectRestOrSpread
 	}
 	return facts
 }
@@ -1414,7 +1414,7 @@ func GetNameOfDeclaration(declaration *Node) *Node {
 		return nonAssignedName
 	}
 	if IsFunctionExpression(declaration) || IsArrowFunction(declaration) || IsClassExpression(declaration) {
-		return getAssignedName(declaration)
+		return GetAssignedName(declaration)
 	}
 	return nil
 }
@@ -1443,7 +1443,7 @@ func GetNonAssignedNameOfDeclaration(declaration *Node) *Node {
 	return declaration.Name()
 }
 
-func getAssignedName(node *Node) *Node {
+func GetAssignedName(node *Node) *Node {
 	parent := node.Parent
 	if parent != nil {
 		switch parent.Kind {
@@ -3633,3 +3633,149 @@ func GetSemanticJsxChildren(children []*JsxChild) []*JsxChild {
 		}
 	})
 }
+
+// Determines whether a node is a declaration that can contain a binding pattern, i.e. a `VariableDeclaration`,
+// `Parameter`, or `BindingElement`.
+func IsDeclarationBindingElement(node *Node) bool {
+	switch node.Kind {
+	case KindVariableDeclaration, KindParameter, KindBindingElement:
+		return true
+	}
+	return false
+}
+
+func IsObjectBindingOrAssignmentPattern(node *Node) bool {
+	return node.Kind == KindObjectBindingPattern || node.Kind == KindObjectLiteralExpression
+}
+
+func IsArrayBindingOrAssignmentPattern(node *Node) bool {
+	return node.Kind == KindArrayBindingPattern || node.Kind == KindArrayLiteralExpression
+}
+
+func IsBindingOrAssignmentPattern(node *Node) bool {
+	return IsObjectBindingOrAssignmentPattern(node) || IsArrayBindingOrAssignmentPattern(node)
+}
+
+func IsEmptyObjectLiteral(node *Node) bool {
+	return node.Kind == KindObjectLiteralExpression && len(node.AsObjectLiteralExpression().Properties.Nodes) == 0
+}
+
+func IsEmptyArrayLiteral(node *Node) bool {
+	return node.Kind == KindArrayLiteralExpression && len(node.AsArrayLiteralExpression().Elements.Nodes) == 0
+}
+
+// Gets the elements of a BindingOrAssignmentPattern
+func GetElementsOfBindingOrAssignmentPattern(name *Node) []*Node {
+	switch name.Kind {
+	case KindObjectBindingPattern, KindArrayBindingPattern:
+		return name.AsBindingPattern().Elements.Nodes
+	case KindArrayLiteralExpression:
+		return name.AsArrayLiteralExpression().Elements.Nodes
+	case KindObjectLiteralExpression:
+		return name.AsObjectLiteralExpression().Properties.Nodes
+	}
+	return nil
+}
+
+// Gets the name of a BindingOrAssignmentElement, which is the identifier, pattern, or assignment target that receives
+// the destructured value:
+//
+//	[a]             -> a
+//	[a = 1]         -> a
+//	[...a]          -> a
+//	{ a }           -> a
+//	{ a = 1 }       -> a
+//	{ p: a }        -> a
+//	{ p: a = 1 }    -> a
+//	{ ...a }        -> a
+//	[a.b] = ...     -> a.b
+func GetTargetOfBindingOrAssignmentElement(bindingElement *Node) *Node {
+	switch bindingElement.Kind {
+	case KindVariableDeclaration, KindParameter, KindBindingElement:
+		return bindingElement.Name()
+	case KindPropertyAssignment:
+		return GetTargetOfBindingOrAssignmentElement(bindingElement.AsPropertyAssignment().Initializer)
+	case KindShorthandPropertyAssignment:
+		return bindingElement.Name()
+	case KindSpreadAssignment:
+		return GetTargetOfBindingOrAssignmentElement(bindingElement.AsSpreadAssignment().Expression)
+	case KindSpreadElement:
+		return GetTargetOfBindingOrAssignmentElement(bindingElement.AsSpreadElement().Expression)
+	}
+	if IsAssignmentExpression(bindingElement, true /*excludeCompoundAssignment*/) {
+		return GetTargetOfBindingOrAssignmentElement(bindingElement.AsBinaryExpression().Left)
+	}
+	// `[a.b] = ...`, `[...a.b] = ...`, `{ p: a.b } = ...`, etc.
+	return bindingElement
+}
+
+// Gets the initializer of a BindingOrAssignmentElement, if any:
+//
+//	[a = 1]         -> 1
+//	{ a = 1 }       -> 1
+//	{ p: a = 1 }    -> 1
+func GetInitializerOfBindingOrAssignmentElement(bindingElement *Node) *Expression {
+	switch bindingElement.Kind {
+	case KindVariableDeclaration, KindParameter, KindBindingElement:
+		return bindingElement.Initializer()
+	case KindPropertyAssignment:
+		initializer := bindingElement.AsPropertyAssignment().Initializer
+		if IsAssignmentExpression(initializer, true /*excludeCompoundAssignment*/) {
+			return initializer.AsBinaryExpression().Right
+		}
+		return nil
+	case KindShorthandPropertyAssignment:
+		return bindingElement.AsShorthandPropertyAssignment().ObjectAssignmentInitializer
+	case KindSpreadElement:
+		return GetInitializerOfBindingOrAssignmentElement(bindingElement.AsSpreadElement().Expression)
+	}
+	if IsAssignmentExpression(bindingElement, true /*excludeCompoundAssignment*/) {
+		return bindingElement.AsBinaryExpression().Right
+	}
+	return nil
+}
+
+// Determines whether a BindingOrAssignmentElement is a rest element, returning the `...` token or spread node, if any.
+func GetRestIndicatorOfBindingOrAssignmentElement(bindingElement *Node) *Node {
+	switch bindingElement.Kind {
+	case KindParameter:
+		return bindingElement.AsParameterDeclaration().DotDotDotToken
+	case KindBindingElement:
+		return bindingElement.AsBindingElement().DotDotDotToken
+	case KindSpreadElement, KindSpreadAssignment:
+		return bindingElement
+	}
+	return nil
+}
+
+// Gets the property name of a BindingOrAssignmentElement, if any:
+//
+//	{ a }           -> a
+//	{ p: a }        -> p
+//	{ ["p"]: a }    -> "p"
+//	{ [p]: a }      -> [p]
+func TryGetPropertyNameOfBindingOrAssignmentElement(bindingElement *Node) *Node {
+	switch bindingElement.Kind {
+	case KindBindingElement:
+		if propertyName := bindingElement.AsBindingElement().PropertyName; propertyName != nil {
+			if IsComputedPropertyName(propertyName) && IsStringOrNumericLiteralLike(propertyName.Expression()) {
+				return propertyName.Expression()
+			}
+			return propertyName
+		}
+	case KindPropertyAssignment:
+		if name := bindingElement.Name(); name != nil {
+			if IsComputedPropertyName(name) && IsStringOrNumericLiteralLike(name.Expression()) {
+				return name.Expression()
+			}
+			return name
+		}
+	case KindSpreadAssignment:
+		return nil
+	}
+	target := GetTargetOfBindingOrAssignmentElement(bindingElement)
+	if target != nil && IsPropertyName(target) {
+		return target
+	}
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/pagpeter/typescript-go/external/bundled"
+	"github.com/pagpeter/typescript-go/external/diagnostics"
 	"github.com/pagpeter/typescript-go/external/tspath"
 	"github.com/pagpeter/typescript-go/external/vfs"
 	"github.com/pagpeter/typescript-go/external/vfs/osvfs"
@@ -37,7 +38,12 @@ func TestEmbeddedLibs(t *testing.T) {
 		if err != nil {
 			return err
 		}
-		if !d.IsDir() {
+		if d.IsDir() {
+			// Skip the directories of the diagnostic message catalogs.
+			if path != bundled.LibPath() {
+				return vfs.SkipDir
+			}
+		} else {
 			files = append(files, tspath.GetBaseFileName(path))
 		}
 		return nil
@@ -46,3 +52,16 @@ func TestEmbeddedLibs(t *testing.T) {
 
 	assert.DeepEqual(t, files, bundled.LibNames)
 }
+
+func TestEmbeddedLocales(t *testing.T) {
+	t.Parallel()
+
+	fs := bundled.WrapFS(osvfs.FS())
+
+	for _, name := range bundled.LocaleNames {
+		locale, err := diagnostics.LoadLocale(fs, bundled.LibPath(), name)
+		assert.NilError(t, err)
+		assert.Assert(t, locale != nil, "missing catalog for %s", name)
+		assert.Equal(t, locale.Name(), name)
+	}
+}
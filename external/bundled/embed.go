@@ -22,6 +22,14 @@ func libPath() string {
 	return scheme + "libs"
 }
 
+// localeDirectory returns the locale whose diagnostic message catalog is in the directory at rest.
+func localeDirectory(rest string) (locale string, ok bool) {
+	if locale, ok = strings.CutPrefix(rest, "libs/"); ok {
+		_, ok = localeEntries[locale]
+	}
+	return locale, ok
+}
+
 // wrappedFS is implemented directly rather than going through [io/fs.FS].
 // Our vfs.FS works with file contents in terms of strings, and that's
 // what go:embed does under the hood, but going through fs.FS will cause
@@ -59,7 +67,8 @@ func (vfs *wrappedFS) ReadFile(path string) (contents string, ok bool) {
 
 func (vfs *wrappedFS) DirectoryExists(path string) bool {
 	if rest, ok := splitPath(path); ok {
-		return rest == "libs"
+		_, isLocale := localeDirectory(rest)
+		return rest == "libs" || isLocale
 	}
 	return vfs.fs.DirectoryExists(path)
 }
@@ -70,6 +79,9 @@ func (vfs *wrappedFS) GetAccessibleEntries(path string) (result vfs.Entries) {
 			result.Directories = []string{"libs"}
 		} else if rest == "libs" {
 			result.Files = LibNames
+			result.Directories = LocaleNames
+		} else if _, ok := localeDirectory(rest); ok {
+			result.Files = []string{"diagnosticMessages.generated.json"}
 		}
 		return result
 	}
@@ -82,12 +94,11 @@ var rootEntries = []fs.DirEntry{
 
 func (vfs *wrappedFS) Stat(path string) vfs.FileInfo {
 	if rest, ok := splitPath(path); ok {
-		if rest == "" || rest == "libs" {
-			return &fileInfo{name: rest, mode: fs.ModeDir}
+		if _, isLocale := localeDirectory(rest); rest == "" || rest == "libs" || isLocale {
+			return &fileInfo{name: rest[strings.LastIndexByte(rest, '/')+1:], mode: fs.ModeDir}
 		}
-		if lib, ok := embeddedContents[rest]; ok {
-			libName, _ := strings.CutPrefix(rest, "libs/")
-			return &fileInfo{name: libName, size: int64(len(lib))}
+		if contents, ok := embeddedContents[rest]; ok {
+			return &fileInfo{name: rest[strings.LastIndexByte(rest, '/')+1:], size: int64(len(contents))}
 		}
 		return nil
 	}
@@ -115,7 +126,11 @@ func (vfs *wrappedFS) walkDir(rest string, walkFn vfs.WalkDirFunc) error {
 	case "libs":
 		entries = libsEntries
 	default:
-		return nil
+		locale, ok := localeDirectory(rest)
+		if !ok {
+			return nil
+		}
+		entries = localeEntries[locale]
 	}
 
 	for _, entry := range entries {
@@ -410,3 +410,5 @@ var libsEntries = []fs.DirEntry{
 	&fileInfo{name: "lib.webworker.importscripts.d.ts", size: int64(len(libs_lib_webworker_importscripts_d_ts))},
 	&fileInfo{name: "lib.webworker.iterable.d.ts", size: int64(len(libs_lib_webworker_iterable_d_ts))},
 }
+
+var localeEntries = map[string][]fs.DirEntry{}
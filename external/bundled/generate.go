@@ -4,6 +4,8 @@ package main
 
 import (
 	"bytes"
+	"encoding/json"
+	"encoding/xml"
 	"fmt"
 	"go/format"
 	"log"
@@ -21,6 +23,7 @@ import (
 
 var (
 	libInputDir     = filepath.Join(repo.TypeScriptSubmodulePath, "src", "lib")
+	locInputDir     = filepath.Join(repo.TypeScriptSubmodulePath, "src", "loc", "lcl")
 	copyrightNotice = filepath.Join(repo.TypeScriptSubmodulePath, "scripts", "CopyrightNotice.txt")
 )
 
@@ -29,8 +32,9 @@ func main() {
 
 	libs := readLibs()
 	generateLibs(libs)
-	generateLibList(libs)
-	generateEmbedded(libs)
+	locales := generateLocales()
+	generateLibList(libs, locales)
+	generateEmbedded(libs, locales)
 }
 
 type lib struct {
@@ -73,7 +77,106 @@ func generateLibs(libs []lib) {
 	}
 }
 
-func generateLibList(libs []lib) {
+// The diagnosticMessages.generated.json.lcl files holding the translations of diagnostic messages, in the
+// format produced by the localization team.
+type lclFile struct {
+	TgtCul string    `xml:"TgtCul,attr"`
+	Items  []lclItem `xml:"Item>Item"`
+}
+
+type lclItem struct {
+	ItemId string  `xml:"ItemId,attr"`
+	Val    string  `xml:"Str>Val"`
+	Tgt    *string `xml:"Str>Tgt>Val"`
+}
+
+// generateLocales converts the translated diagnostic messages of each locale to the catalog read by
+// diagnostics.LoadLocale, libs/<locale>/diagnosticMessages.generated.json, the same layout as the lib
+// directory of the TypeScript package. It returns the names of the locales, sorted.
+func generateLocales() []string {
+	entries, err := os.ReadDir(locInputDir)
+	if err != nil {
+		log.Fatalf("failed to read %s (is the TypeScript submodule checked out?): %v", locInputDir, err)
+	}
+
+	var locales []string
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		lclPath := filepath.Join(locInputDir, entry.Name(), "diagnosticMessages", "diagnosticMessages.generated.json.lcl")
+		b, err := os.ReadFile(lclPath)
+		if err != nil {
+			log.Fatalf("failed to read %s: %v", lclPath, err)
+		}
+
+		var lcl lclFile
+		if err := xml.Unmarshal(b, &lcl); err != nil {
+			log.Fatalf("failed to parse %s: %v", lclPath, err)
+		}
+
+		messages := make(map[string]string, len(lcl.Items))
+		for _, item := range lcl.Items {
+			key, ok := strings.CutPrefix(item.ItemId, ";")
+			if !ok {
+				log.Fatalf("unexpected item %q in %s", item.ItemId, lclPath)
+			}
+			text := item.Val
+			if item.Tgt != nil {
+				text = *item.Tgt
+			}
+			if _, ok := messages[key]; ok {
+				log.Fatalf("multiple definitions of %s in %s", key, lclPath)
+			}
+			messages[key] = strings.Replace(text, "]5D;", "]", 1)
+		}
+
+		var output bytes.Buffer
+		encoder := json.NewEncoder(&output)
+		encoder.SetEscapeHTML(false)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(messages); err != nil {
+			log.Fatalf("failed to encode messages of %s: %v", lclPath, err)
+		}
+
+		locale := getPreferredLocaleName(lcl.TgtCul)
+		outputDir := filepath.Join("libs", locale)
+		if err := os.MkdirAll(outputDir, 0o755); err != nil {
+			log.Fatalf("failed to create %s: %v", outputDir, err)
+		}
+		outputPath := filepath.Join(outputDir, "diagnosticMessages.generated.json")
+		if err := os.WriteFile(outputPath, output.Bytes(), 0o644); err != nil {
+			log.Fatalf("failed to write %s: %v", outputPath, err)
+		}
+		locales = append(locales, locale)
+	}
+
+	// Without any catalog, the embedded build would silently report every diagnostic in English.
+	if len(locales) == 0 {
+		log.Fatalf("no diagnostic message translations found in %s", locInputDir)
+	}
+
+	slices.Sort(locales)
+	return locales
+}
+
+// getPreferredLocaleName returns the name of the catalog directory for a culture: the language alone,
+// except for the cultures whose translations differ by territory.
+func getPreferredLocaleName(culture string) string {
+	culture = strings.ToLower(culture)
+	switch culture {
+	case "zh-cn", "zh-tw", "pt-br":
+		return culture
+	}
+	language, _, _ := strings.Cut(culture, "-")
+	return language
+}
+
+func localeCatalogPath(locale string) string {
+	return "libs/" + locale + "/diagnosticMessages.generated.json"
+}
+
+func generateLibList(libs []lib, locales []string) {
 	var code bytes.Buffer
 	code.WriteString("// Code generated by generate.go; DO NOT EDIT.\n\n")
 	code.WriteString("package bundled\n\n")
@@ -84,16 +187,27 @@ func generateLibList(libs []lib) {
 	for _, lib := range libs {
 		code.WriteString("\t\"" + lib.target + "\",\n")
 	}
+	code.WriteString("}\n\n")
+
+	code.WriteString("// LocaleNames is the list of all locales with a bundled diagnostic message catalog, sorted by name.\n")
+	code.WriteString("var LocaleNames = []string{\n")
+	for _, locale := range locales {
+		code.WriteString("\t\"" + locale + "\",\n")
+	}
 	code.WriteString("}\n")
 
 	writeCode("libs_generated.go", code.Bytes())
 }
 
-func generateEmbedded(libs []lib) {
+func generateEmbedded(libs []lib, locales []string) {
 	libVarNames := make([]string, len(libs))
 	for i, lib := range libs {
 		libVarNames[i] = "libs_" + strings.ReplaceAll(lib.target, ".", "_")
 	}
+	localeVarNames := make([]string, len(locales))
+	for i, locale := range locales {
+		localeVarNames[i] = "libs_" + strings.ReplaceAll(locale, "-", "_") + "_diagnosticMessages_generated_json"
+	}
 
 	var code bytes.Buffer
 	code.WriteString("//go:build !noembed\n\n")
@@ -110,6 +224,10 @@ func generateEmbedded(libs []lib) {
 		code.WriteString("//go:embed libs/" + lib.target + "\n")
 		code.WriteString("" + varName + " string\n")
 	}
+	for i, locale := range locales {
+		code.WriteString("//go:embed " + localeCatalogPath(locale) + "\n")
+		code.WriteString(localeVarNames[i] + " string\n")
+	}
 	code.WriteString(")\n\n")
 
 	code.WriteString("var embeddedContents = map[string]string{\n")
@@ -117,6 +235,9 @@ func generateEmbedded(libs []lib) {
 		varName := libVarNames[i]
 		code.WriteString("\t\"libs/" + lib.target + "\": " + varName + ",\n")
 	}
+	for i, locale := range locales {
+		fmt.Fprintf(&code, "\t%q: %s,\n", localeCatalogPath(locale), localeVarNames[i])
+	}
 	code.WriteString("}\n\n")
 
 	code.WriteString("var libsEntries = []fs.DirEntry{\n")
@@ -124,6 +245,15 @@ func generateEmbedded(libs []lib) {
 		varName := libVarNames[i]
 		fmt.Fprintf(&code, "\t&fileInfo{name: %q, size: int64(len(%s))},\n", lib.target, varName)
 	}
+	for _, locale := range locales {
+		fmt.Fprintf(&code, "\t&fileInfo{name: %q, mode: fs.ModeDir},\n", locale)
+	}
+	code.WriteString("}\n\n")
+
+	code.WriteString("var localeEntries = map[string][]fs.DirEntry{\n")
+	for i, locale := range locales {
+		fmt.Fprintf(&code, "\t%q: {&fileInfo{name: \"diagnosticMessages.generated.json\", size: int64(len(%s))}},\n", locale, localeVarNames[i])
+	}
 	code.WriteString("}\n")
 
 	writeCode("embed_generated.go", code.Bytes())
@@ -104,3 +104,6 @@ var LibNames = []string{
 	"lib.webworker.importscripts.d.ts",
 	"lib.webworker.iterable.d.ts",
 }
+
+// LocaleNames is the list of all locales with a bundled diagnostic message catalog, sorted by name.
+var LocaleNames = []string{}
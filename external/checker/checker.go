@@ -25,6 +25,7 @@ import (
 	"github.com/pagpeter/typescript-go/external/printer"
 	"github.com/pagpeter/typescript-go/external/scanner"
 	"github.com/pagpeter/typescript-go/external/stringutil"
+	"github.com/pagpeter/typescript-go/external/tracing"
 	"github.com/pagpeter/typescript-go/external/tsoptions"
 	"github.com/pagpeter/typescript-go/external/tspath"
 )
@@ -543,6 +544,7 @@ type Program interface {
 	GetSourceAndProjectReference(path tspath.Path) *tsoptions.SourceAndProjectReference
 	GetRedirectForResolution(file ast.HasFileName) *tsoptions.ParsedCommandLine
 	CommonSourceDirectory() string
+	Tracing() *tracing.Tracing
 }
 
 type Host interface {
@@ -556,6 +558,8 @@ var nextCheckerID atomic.Uint32
 type Checker struct {
 	id                                          uint32
 	program                                     Program
+	tracer                                      *tracing.Thread
+	tracedTypes                                 []*Type
 	compilerOptions                             *core.CompilerOptions
 	files                                       []*ast.SourceFile
 	fileIndexMap                                map[*ast.SourceFile]int
@@ -864,6 +868,7 @@ func NewChecker(program Program) *Checker {
 	c := &Checker{}
 	c.id = nextCheckerID.Add(1)
 	c.program = program
+	c.startTracing()
 	c.compilerOptions = program.Options()
 	c.files = program.SourceFiles()
 	c.fileIndexMap = createFileIndexMap(c.files)
@@ -2114,6 +2119,8 @@ func (c *Checker) checkSourceFile(ctx context.Context, sourceFile *ast.SourceFil
 	c.checkNotCanceled()
 	links := c.sourceFileLinks.Get(sourceFile)
 	if !links.typeChecked {
+		c.tracer.Push(tracing.PhaseCheck, "checkSourceFile", tracing.Args{"path": sourceFile.Path()}, true /*separateBeginAndEnd*/)
+		defer c.tracer.Pop()
 		c.ctx = ctx
 		// Grammar checking
 		c.checkGrammarSourceFile(sourceFile)
@@ -2322,6 +2329,10 @@ func (c *Checker) checkDeferredNodes(context *ast.SourceFile) {
 }
 
 func (c *Checker) checkDeferredNode(node *ast.Node) {
+	if c.tracer != nil {
+		c.tracer.Push(tracing.PhaseCheck, "checkDeferredNode", traceNodeArgs(node), false /*separateBeginAndEnd*/)
+		defer c.tracer.Pop()
+	}
 	saveCurrentNode := c.currentNode
 	c.currentNode = node
 	c.instantiationCount = 0
@@ -5489,6 +5500,10 @@ func (c *Checker) checkVariableDeclarationList(node *ast.Node) {
 }
 
 func (c *Checker) checkVariableDeclaration(node *ast.Node) {
+	if c.tracer != nil {
+		c.tracer.Push(tracing.PhaseCheck, "checkVariableDeclaration", traceNodeArgs(node), false /*separateBeginAndEnd*/)
+		defer c.tracer.Pop()
+	}
 	c.checkGrammarVariableDeclaration(node.AsVariableDeclaration())
 	c.checkVariableLikeDeclaration(node)
 }
@@ -7173,6 +7188,10 @@ func (c *Checker) checkExpression(node *ast.Node) *Type {
 }
 
 func (c *Checker) checkExpressionEx(node *ast.Node, checkMode CheckMode) *Type {
+	if c.tracer != nil {
+		c.tracer.Push(tracing.PhaseCheck, "checkExpression", traceNodeArgs(node), false /*separateBeginAndEnd*/)
+		defer c.tracer.Pop()
+	}
 	saveCurrentNode := c.currentNode
 	c.currentNode = node
 	c.instantiationCount = 0
@@ -21171,6 +21190,9 @@ func (c *Checker) instantiateTypeWithAlias(t *Type, m *TypeMapper, alias *TypeAl
 		// We have reached 100 recursive type instantiations, or 5M type instantiations caused by the same statement
 		// or expression. There is a very high likelihood we're dealing with a combination of infinite generic types
 		// that perpetually generate new type identities, so we stop the recursion here by yielding the error type.
+		if c.tracer != nil {
+			c.tracer.Instant(tracing.PhaseCheckTypes, "instantiateType_DepthLimit", tracing.Args{"typeId": c.traceTypeId(t), "instantiationDepth": c.instantiationDepth, "instantiationCount": c.instantiationCount})
+		}
 		c.error(c.currentNode, diagnostics.Type_instantiation_is_excessively_deep_and_possibly_infinite)
 		return c.errorType
 	}
@@ -23941,6 +23963,9 @@ func (c *Checker) newType(flags TypeFlags, objectFlags ObjectFlags, data TypeDat
 	t.id = TypeId(c.TypeCount)
 	t.checker = c
 	t.data = data
+	if c.tracer != nil {
+		c.tracedTypes = append(c.tracedTypes, t)
+	}
 	return t
 }
 
@@ -27285,7 +27310,124 @@ func (c *Checker) markExportSpecifierAliasReferenced(location *ast.ExportSpecifi
 }
 
 func (c *Checker) markDecoratorAliasReferenced(node *ast.Node /*HasDecorators*/) {
-	// !!! Implement if/when we support emitDecoratorMetadata
+	if !c.compilerOptions.EmitDecoratorMetadata.IsTrue() {
+		return
+	}
+	switch node.Kind {
+	case ast.KindClassDeclaration:
+		for _, member := range node.Members() {
+			if ast.IsConstructorDeclaration(member) && member.Body() != nil {
+				for _, parameter := range member.Parameters() {
+					c.markDecoratorMetadataTypeNodeAsReferenced(getParameterTypeNodeForDecoratorCheck(parameter))
+				}
+				break
+			}
+		}
+	case ast.KindGetAccessor, ast.KindSetAccessor:
+		otherKind := core.IfElse(node.Kind == ast.KindGetAccessor, ast.KindSetAccessor, ast.KindGetAccessor)
+		typeNode := c.getAnnotatedAccessorTypeNode(node)
+		if typeNode == nil {
+			typeNode = c.getAnnotatedAccessorTypeNode(ast.GetDeclarationOfKind(c.getSymbolOfDeclaration(node), otherKind))
+		}
+		c.markDecoratorMetadataTypeNodeAsReferenced(typeNode)
+	case ast.KindMethodDeclaration:
+		for _, parameter := range node.Parameters() {
+			c.markDecoratorMetadataTypeNodeAsReferenced(getParameterTypeNodeForDecoratorCheck(parameter))
+		}
+		c.markDecoratorMetadataTypeNodeAsReferenced(node.Type())
+	case ast.KindPropertyDeclaration:
+		c.markDecoratorMetadataTypeNodeAsReferenced(node.Type())
+	case ast.KindParameter:
+		c.markDecoratorMetadataTypeNodeAsReferenced(getParameterTypeNodeForDecoratorCheck(node))
+		containingSignature := node.Parent
+		for _, parameter := range containingSignature.Parameters() {
+			c.markDecoratorMetadataTypeNodeAsReferenced(getParameterTypeNodeForDecoratorCheck(parameter))
+		}
+		c.markDecoratorMetadataTypeNodeAsReferenced(containingSignature.Type())
+	}
+}
+
+func (c *Checker) markDecoratorMetadataTypeNodeAsReferenced(node *ast.Node) {
+	entityName := c.getEntityNameForDecoratorMetadata(node)
+	if entityName != nil && ast.IsEntityName(entityName) {
+		c.markEntityNameOrEntityExpressionAsReference(entityName, true /*forDecoratorMetadata*/)
+	}
+}
+
+// Gets the entity name that is serialized as the runtime type of a type node by decorator metadata, if any.
+func (c *Checker) getEntityNameForDecoratorMetadata(node *ast.Node) *ast.Node {
+	if node != nil {
+		switch node.Kind {
+		case ast.KindIntersectionType:
+			return c.getEntityNameForDecoratorMetadataFromTypeList(node.AsIntersectionTypeNode().Types.Nodes)
+		case ast.KindUnionType:
+			return c.getEntityNameForDecoratorMetadataFromTypeList(node.AsUnionTypeNode().Types.Nodes)
+		case ast.KindConditionalType:
+			n := node.AsConditionalTypeNode()
+			return c.getEntityNameForDecoratorMetadataFromTypeList([]*ast.Node{n.TrueType, n.FalseType})
+		case ast.KindParenthesizedType, ast.KindNamedTupleMember:
+			return c.getEntityNameForDecoratorMetadata(node.Type())
+		case ast.KindTypeReference:
+			return node.AsTypeReferenceNode().TypeName
+		}
+	}
+	return nil
+}
+
+func (c *Checker) getEntityNameForDecoratorMetadataFromTypeList(types []*ast.Node) *ast.Node {
+	var commonEntityName *ast.Node
+	for _, typeNode := range types {
+		for typeNode.Kind == ast.KindParenthesizedType || typeNode.Kind == ast.KindNamedTupleMember {
+			typeNode = typeNode.Type() // Skip parens if need be
+		}
+		if typeNode.Kind == ast.KindNeverKeyword {
+			continue // Always elide `never` from the union/intersection if possible
+		}
+		if !c.strictNullChecks && (typeNode.Kind == ast.KindLiteralType && typeNode.AsLiteralTypeNode().Literal.Kind == ast.KindNullKeyword || typeNode.Kind == ast.KindUndefinedKeyword) {
+			continue // Elide null and undefined from unions for metadata, just like what we did prior to the implementation of strict null checks
+		}
+		individualEntityName := c.getEntityNameForDecoratorMetadata(typeNode)
+		if individualEntityName == nil {
+			// Individual is something like string number
+			// So it would be serialized to either that type or object
+			// Safe to return here
+			return nil
+		}
+		if commonEntityName != nil {
+			// Note this is in sync with the transformation that happens for type node.
+			// Keep this in sync with serializeUnionOrIntersectionType
+			// Verify if they refer to same entity and is identifier
+			// return undefined if they dont match because we would emit object
+			if !ast.IsIdentifier(commonEntityName) || !ast.IsIdentifier(individualEntityName) || commonEntityName.Text() != individualEntityName.Text() {
+				return nil
+			}
+		} else {
+			commonEntityName = individualEntityName
+		}
+	}
+	return commonEntityName
+}
+
+func getParameterTypeNodeForDecoratorCheck(node *ast.Node) *ast.Node {
+	typeNode := node.Type()
+	if isRestParameter(node) {
+		return getRestParameterElementType(typeNode)
+	}
+	return typeNode
+}
+
+func getRestParameterElementType(node *ast.Node) *ast.Node {
+	if node != nil {
+		switch node.Kind {
+		case ast.KindArrayType:
+			return node.AsArrayTypeNode().ElementType
+		case ast.KindTypeReference:
+			if typeArguments := node.TypeArguments(); len(typeArguments) == 1 {
+				return typeArguments[0]
+			}
+		}
+	}
+	return nil
 }
 
 func (c *Checker) markAliasReferenced(symbol *ast.Symbol, location *ast.Node) {
@@ -27343,6 +27485,84 @@ func (c *Checker) markExportAsReferenced(node *ast.Node /*ImportEqualsDeclaratio
 	}
 }
 
+func (c *Checker) getTypeReferenceSerializationKind(typeName *ast.Node, location *ast.Node) printer.TypeReferenceSerializationKind {
+	// Resolve the symbol as a value to ensure the type can be reached at runtime during emit.
+	isTypeOnly := false
+	if ast.IsQualifiedName(typeName) {
+		rootValueSymbol := c.resolveEntityName(ast.GetFirstIdentifier(typeName), ast.SymbolFlagsValue, true /*ignoreErrors*/, true /*dontResolveAlias*/, location)
+		isTypeOnly = rootValueSymbol != nil && len(rootValueSymbol.Declarations) != 0 && core.Every(rootValueSymbol.Declarations, ast.IsTypeOnlyImportOrExportDeclaration)
+	}
+	valueSymbol := c.resolveEntityName(typeName, ast.SymbolFlagsValue, true /*ignoreErrors*/, true /*dontResolveAlias*/, location)
+	resolvedValueSymbol := valueSymbol
+	if valueSymbol != nil && valueSymbol.Flags&ast.SymbolFlagsAlias != 0 {
+		resolvedValueSymbol = c.resolveAlias(valueSymbol)
+	}
+	isTypeOnly = isTypeOnly || valueSymbol != nil && c.getTypeOnlyAliasDeclarationEx(valueSymbol, ast.SymbolFlagsValue) != nil
+
+	// Resolve the symbol as a type so that we can provide a more useful hint for the type serializer.
+	typeSymbol := c.resolveEntityName(typeName, ast.SymbolFlagsType, true /*ignoreErrors*/, true /*dontResolveAlias*/, location)
+	resolvedTypeSymbol := typeSymbol
+	if typeSymbol != nil && typeSymbol.Flags&ast.SymbolFlagsAlias != 0 {
+		resolvedTypeSymbol = c.resolveAlias(typeSymbol)
+	}
+
+	// In case the value symbol can't be resolved (e.g. because of missing declarations), use type symbol for reachability check.
+	if valueSymbol == nil {
+		isTypeOnly = isTypeOnly || typeSymbol != nil && c.getTypeOnlyAliasDeclarationEx(typeSymbol, ast.SymbolFlagsType) != nil
+	}
+
+	if resolvedValueSymbol != nil && resolvedValueSymbol == resolvedTypeSymbol {
+		if globalPromiseSymbol := c.getGlobalPromiseConstructorSymbolOrNil(); globalPromiseSymbol != nil && resolvedValueSymbol == globalPromiseSymbol {
+			return printer.TypeReferenceSerializationKindPromise
+		}
+		constructorType := c.getTypeOfSymbol(resolvedValueSymbol)
+		if constructorType != nil && c.isConstructorType(constructorType) {
+			if isTypeOnly {
+				return printer.TypeReferenceSerializationKindTypeWithCallSignature
+			}
+			return printer.TypeReferenceSerializationKindTypeWithConstructSignatureAndValue
+		}
+	}
+
+	// We might not be able to resolve type symbol so use unknown type in that case (eg error case)
+	if resolvedTypeSymbol == nil {
+		if isTypeOnly {
+			return printer.TypeReferenceSerializationKindObjectType
+		}
+		return printer.TypeReferenceSerializationKindUnknown
+	}
+	t := c.getDeclaredTypeOfSymbol(resolvedTypeSymbol)
+	switch {
+	case c.isErrorType(t):
+		if isTypeOnly {
+			return printer.TypeReferenceSerializationKindObjectType
+		}
+		return printer.TypeReferenceSerializationKindUnknown
+	case t.flags&TypeFlagsAnyOrUnknown != 0:
+		return printer.TypeReferenceSerializationKindObjectType
+	case c.isTypeAssignableToKind(t, TypeFlagsVoid|TypeFlagsNullable|TypeFlagsNever):
+		return printer.TypeReferenceSerializationKindVoidNullableOrNeverType
+	case c.isTypeAssignableToKind(t, TypeFlagsBooleanLike):
+		return printer.TypeReferenceSerializationKindBooleanType
+	case c.isTypeAssignableToKind(t, TypeFlagsNumberLike):
+		return printer.TypeReferenceSerializationKindNumberLikeType
+	case c.isTypeAssignableToKind(t, TypeFlagsBigIntLike):
+		return printer.TypeReferenceSerializationKindBigIntLikeType
+	case c.isTypeAssignableToKind(t, TypeFlagsStringLike):
+		return printer.TypeReferenceSerializationKindStringLikeType
+	case isTupleType(t):
+		return printer.TypeReferenceSerializationKindArrayLikeType
+	case c.isTypeAssignableToKind(t, TypeFlagsESSymbolLike):
+		return printer.TypeReferenceSerializationKindESSymbolType
+	case c.isFunctionType(t):
+		return printer.TypeReferenceSerializationKindTypeWithCallSignature
+	case c.isArrayType(t):
+		return printer.TypeReferenceSerializationKindArrayLikeType
+	default:
+		return printer.TypeReferenceSerializationKindObjectType
+	}
+}
+
 func (c *Checker) markEntityNameOrEntityExpressionAsReference(typeName *ast.Node /*EntityNameOrEntityNameExpression | nil*/, forDecoratorMetadata bool) {
 	if typeName == nil {
 		return
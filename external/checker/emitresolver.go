@@ -999,6 +999,16 @@ func (r *emitResolver) CreateLateBoundIndexSignatures(emitContext *printer.EmitC
 	return result
 }
 
+func (r *emitResolver) GetTypeReferenceSerializationKind(typeName *ast.Node, location *ast.Node) printer.TypeReferenceSerializationKind {
+	// typeName = emitContext.ParseNode(typeName)
+	if !ast.IsParseTreeNode(typeName) || location != nil && !ast.IsParseTreeNode(location) {
+		return printer.TypeReferenceSerializationKindUnknown
+	}
+	r.checkerMu.Lock()
+	defer r.checkerMu.Unlock()
+	return r.checker.getTypeReferenceSerializationKind(typeName, location)
+}
+
 func (r *emitResolver) GetEffectiveDeclarationFlags(node *ast.Node, flags ast.ModifierFlags) ast.ModifierFlags {
 	// node = emitContext.ParseNode(node)
 	r.checkerMu.Lock()
@@ -142,3 +142,19 @@ func (c *Checker) GetTypeOfPropertyOfType(t *Type, name string) *Type {
 func (c *Checker) GetContextualTypeForArgumentAtIndex(node *ast.Node, argIndex int) *Type {
 	return c.getContextualTypeForArgumentAtIndex(node, argIndex)
 }
+
+func (c *Checker) GetSignatureFromDeclaration(declaration *ast.Node) *Signature {
+	return c.getSignatureFromDeclaration(declaration)
+}
+
+func HasContextSensitiveParameters(node *ast.Node) bool {
+	return hasContextSensitiveParameters(node)
+}
+
+func (c *Checker) GetTypeArguments(t *Type) []*Type {
+	return c.getTypeArguments(t)
+}
+
+func IsWriteAccess(node *ast.Node) bool {
+	return isWriteAccess(node)
+}
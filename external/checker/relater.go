@@ -12,6 +12,7 @@ import (
 	"github.com/pagpeter/typescript-go/external/diagnostics"
 	"github.com/pagpeter/typescript-go/external/jsnum"
 	"github.com/pagpeter/typescript-go/external/scanner"
+	"github.com/pagpeter/typescript-go/external/tracing"
 )
 
 type SignatureCheckMode uint32
@@ -372,6 +373,9 @@ func (c *Checker) checkTypeRelatedToEx(
 	r.relationCount = (16_000_000 - relation.size()) / 8
 	result := r.isRelatedToEx(source, target, RecursionFlagsBoth, errorNode != nil /*reportErrors*/, headMessage, IntersectionStateNone)
 	if r.overflow {
+		if c.tracer != nil {
+			c.tracer.Instant(tracing.PhaseCheckTypes, "checkTypeRelatedTo_DepthLimit", tracing.Args{"sourceId": c.traceTypeId(source), "targetId": c.traceTypeId(target), "depth": len(r.sourceStack), "targetDepth": len(r.targetStack)})
+		}
 		// Record this relation as having failed such that we don't attempt the overflowing operation again.
 		id := getRelationKey(source, target, IntersectionStateNone, relation == c.identityRelation, false /*ignoreConstraints*/)
 		relation.set(id, RelationComparisonResultFailed|core.IfElse(r.relationCount <= 0, RelationComparisonResultComplexityOverflow, RelationComparisonResultStackDepthOverflow))
@@ -1334,6 +1338,10 @@ func (c *Checker) getAliasVariances(symbol *ast.Symbol) []VarianceFlags {
 func (c *Checker) getVariancesWorker(symbol *ast.Symbol, typeParameters []*Type) []VarianceFlags {
 	links := c.varianceLinks.Get(symbol)
 	if links.variances == nil {
+		if c.tracer != nil {
+			c.tracer.Push(tracing.PhaseCheckTypes, "getVariancesWorker", tracing.Args{"arity": len(typeParameters), "id": c.traceTypeId(c.getDeclaredTypeOfSymbol(symbol))}, false /*separateBeginAndEnd*/)
+			defer c.tracer.Pop()
+		}
 		oldVarianceComputation := c.inVarianceComputation
 		saveResolutionStart := c.resolutionStart
 		if !c.inVarianceComputation {
@@ -3053,6 +3061,16 @@ func (r *Relater) recursiveTypeRelatedTo(source *Type, target *Type, reportError
 		}
 	}
 	if len(r.sourceStack) == 100 || len(r.targetStack) == 100 {
+		if r.c.tracer != nil {
+			r.c.tracer.Instant(tracing.PhaseCheckTypes, "recursiveTypeRelatedTo_DepthLimit", tracing.Args{
+				"sourceId":      r.c.traceTypeId(source),
+				"sourceIdStack": r.c.traceTypeIds(r.sourceStack),
+				"targetId":      r.c.traceTypeId(target),
+				"targetIdStack": r.c.traceTypeIds(r.targetStack),
+				"depth":         len(r.sourceStack),
+				"targetDepth":   len(r.targetStack),
+			})
+		}
 		r.overflow = true
 		return TernaryFalse
 	}
@@ -3078,7 +3096,9 @@ func (r *Relater) recursiveTypeRelatedTo(source *Type, target *Type, reportError
 	if r.expandingFlags == ExpandingFlagsBoth {
 		result = TernaryMaybe
 	} else {
+		r.c.tracer.Push(tracing.PhaseCheckTypes, "structuredTypeRelatedTo", tracing.Args{"sourceId": r.c.traceTypeId(source), "targetId": r.c.traceTypeId(target)}, false /*separateBeginAndEnd*/)
 		result = r.structuredTypeRelatedTo(source, target, reportErrors, intersectionState)
+		r.c.tracer.Pop()
 	}
 	propagatingVarianceFlags := r.c.reliabilityFlags
 	r.c.reliabilityFlags |= saveReliabilityFlags
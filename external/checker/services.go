@@ -426,6 +426,7 @@ func (c *Checker) GetExportSpecifierLocalTargetSymbol(node *ast.Node) *ast.Symbo
 			// Skip for invalid syntax like this: export { "x" }
 			return nil
 		}
+		node = name
 	case ast.KindIdentifier:
 		// do nothing (don't panic)
 	default:
@@ -618,3 +619,76 @@ func (c *Checker) GetTypeParameterAtPosition(s *Signature, pos int) *Type {
 	}
 	return t
 }
+
+func (c *Checker) GetSuggestedSymbolForNonexistentProperty(name *ast.Node, containingType *Type) *ast.Symbol {
+	return c.getSuggestedSymbolForNonexistentProperty(name, containingType)
+}
+
+func (c *Checker) GetSuggestedSymbolForNonexistentSymbol(location *ast.Node, name string, meaning ast.SymbolFlags) *ast.Symbol {
+	return c.getSuggestedSymbolForNonexistentSymbol(location, name, meaning)
+}
+
+func (c *Checker) GetSuggestedSymbolForNonexistentModule(name *ast.Node, targetModule *ast.Symbol) *ast.Symbol {
+	return c.getSuggestedSymbolForNonexistentModule(name, targetModule)
+}
+
+type ParameterIdentifierInfo struct {
+	Parameter       *ast.Node // Identifier naming the parameter or labeled tuple element
+	ParameterName   string
+	IsRestParameter bool
+}
+
+// GetParameterIdentifierInfoAtPosition returns the identifier naming the parameter that receives the argument
+// at position pos of a call to the given signature, or nil when the parameter is not named by an identifier.
+func (c *Checker) GetParameterIdentifierInfoAtPosition(signature *Signature, pos int) *ParameterIdentifierInfo {
+	paramCount := len(signature.parameters)
+	if signatureHasRestParameter(signature) {
+		paramCount--
+	}
+	if pos < paramCount {
+		param := signature.parameters[pos]
+		if paramIdent := getParameterDeclarationIdentifier(param); paramIdent != nil {
+			return &ParameterIdentifierInfo{Parameter: paramIdent, ParameterName: param.Name}
+		}
+		return nil
+	}
+	restParameter := c.unknownSymbol
+	if paramCount < len(signature.parameters) {
+		restParameter = signature.parameters[paramCount]
+	}
+	restIdent := getParameterDeclarationIdentifier(restParameter)
+	if restIdent == nil {
+		return nil
+	}
+	restType := c.getTypeOfSymbol(restParameter)
+	if isTupleType(restType) {
+		elementInfos := restType.TargetTupleType().elementInfos
+		index := pos - paramCount
+		if index >= len(elementInfos) {
+			return nil
+		}
+		associatedName := elementInfos[index].labeledDeclaration
+		if associatedName == nil || !ast.IsIdentifier(associatedName.Name()) {
+			return nil
+		}
+		var isRestTupleElement bool
+		if ast.IsNamedTupleMember(associatedName) {
+			isRestTupleElement = associatedName.AsNamedTupleMember().DotDotDotToken != nil
+		} else {
+			isRestTupleElement = associatedName.AsParameterDeclaration().DotDotDotToken != nil
+		}
+		return &ParameterIdentifierInfo{Parameter: associatedName.Name(), ParameterName: associatedName.Name().Text(), IsRestParameter: isRestTupleElement}
+	}
+	if pos == paramCount {
+		return &ParameterIdentifierInfo{Parameter: restIdent, ParameterName: restParameter.Name, IsRestParameter: true}
+	}
+	return nil
+}
+
+func getParameterDeclarationIdentifier(symbol *ast.Symbol) *ast.Node {
+	declaration := symbol.ValueDeclaration
+	if declaration != nil && ast.IsParameter(declaration) && ast.IsIdentifier(declaration.Name()) {
+		return declaration.Name()
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+package checker
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pagpeter/typescript-go/external/ast"
+	"github.com/pagpeter/typescript-go/external/scanner"
+	"github.com/pagpeter/typescript-go/external/tracing"
+)
+
+func (c *Checker) startTracing() {
+	c.tracer = c.program.Tracing().NewThread("Checker " + strconv.FormatUint(uint64(c.id), 10))
+	c.tracer.SetTypeDescriber(c.describeTracedTypes)
+}
+
+func (c *Checker) traceTypeId(t *Type) tracing.TypeId {
+	if t == nil {
+		return tracing.TypeId{}
+	}
+	return c.tracer.TypeId(uint32(t.id))
+}
+
+func (c *Checker) traceTypeIds(types []*Type) []tracing.TypeId {
+	if types == nil {
+		return nil
+	}
+	result := make([]tracing.TypeId, len(types))
+	for i, t := range types {
+		result[i] = c.traceTypeId(t)
+	}
+	return result
+}
+
+func traceNodeArgs(node *ast.Node) tracing.Args {
+	args := tracing.Args{"kind": node.Kind, "pos": node.Pos(), "end": node.End()}
+	if file := ast.GetSourceFileOfNode(node); file != nil {
+		args["path"] = file.Path()
+	}
+	return args
+}
+
+// describeTracedTypes describes every type created by the checker, in order of type id, for the
+// types.json file of --generateTrace.
+func (c *Checker) describeTracedTypes() []*tracing.TypeDescriptor {
+	recursionIdentities := make(map[RecursionId]int)
+	result := make([]*tracing.TypeDescriptor, 0, len(c.tracedTypes))
+	// Describing types can create new types, which are appended to c.tracedTypes as we go.
+	for i := 0; i < len(c.tracedTypes); i++ {
+		t := c.tracedTypes[i]
+		descriptor := &tracing.TypeDescriptor{
+			Id:    c.traceTypeId(t),
+			Flags: formatTypeFlags(t.flags),
+		}
+		if t.flags&TypeFlagsIntrinsic != 0 {
+			if intrinsic, ok := t.data.(*IntrinsicType); ok {
+				descriptor.IntrinsicName = intrinsic.intrinsicName
+			}
+		}
+		if t.symbol != nil {
+			descriptor.SymbolName = traceSymbolName(t.symbol)
+			if len(t.symbol.Declarations) != 0 {
+				descriptor.FirstDeclaration = traceLocation(t.symbol.Declarations[0])
+			}
+		}
+		if t.flags&TypeFlagsObject != 0 || t.flags&TypeFlagsUnionOrIntersection != 0 {
+			identity := getRecursionIdentity(t)
+			token, ok := recursionIdentities[identity]
+			if !ok {
+				token = len(recursionIdentities)
+				recursionIdentities[identity] = token
+			}
+			descriptor.RecursionId = &token
+		}
+		if t.alias != nil {
+			descriptor.AliasTypeArguments = c.traceTypeIds(t.alias.typeArguments)
+		}
+		switch {
+		case t.flags&TypeFlagsUnion != 0:
+			descriptor.UnionTypes = c.traceTypeIds(t.Types())
+		case t.flags&TypeFlagsIntersection != 0:
+			descriptor.IntersectionTypes = c.traceTypeIds(t.Types())
+		case t.flags&TypeFlagsIndex != 0:
+			descriptor.KeyofType = c.traceTypeId(t.AsIndexType().target)
+		case t.flags&TypeFlagsIndexedAccess != 0:
+			descriptor.IndexedAccessObjectType = c.traceTypeId(t.AsIndexedAccessType().objectType)
+			descriptor.IndexedAccessIndexType = c.traceTypeId(t.AsIndexedAccessType().indexType)
+		case t.flags&TypeFlagsConditional != 0:
+			d := t.AsConditionalType()
+			descriptor.ConditionalCheckType = c.traceTypeId(d.checkType)
+			descriptor.ConditionalExtendsType = c.traceTypeId(d.extendsType)
+			descriptor.ConditionalTrueType = c.traceTypeId(d.resolvedTrueType)
+			descriptor.ConditionalFalseType = c.traceTypeId(d.resolvedFalseType)
+		case t.flags&TypeFlagsSubstitution != 0:
+			descriptor.SubstitutionBaseType = c.traceTypeId(t.AsSubstitutionType().baseType)
+			descriptor.ConstraintType = c.traceTypeId(t.AsSubstitutionType().constraint)
+		case t.flags&TypeFlagsObject != 0:
+			switch {
+			case t.objectFlags&ObjectFlagsReference != 0:
+				d := t.AsTypeReference()
+				descriptor.IsTuple = t.objectFlags&ObjectFlagsTuple != 0
+				descriptor.InstantiatedType = c.traceTypeId(d.target)
+				descriptor.TypeArguments = c.traceTypeIds(d.resolvedTypeArguments)
+				if d.node != nil {
+					descriptor.ReferenceLocation = traceLocation(d.node)
+				}
+			case t.objectFlags&ObjectFlagsReverseMapped != 0:
+				d := t.AsReverseMappedType()
+				descriptor.ReverseMappedSourceType = c.traceTypeId(d.source)
+				descriptor.ReverseMappedMappedType = c.traceTypeId(d.mappedType)
+				descriptor.ReverseMappedConstraintType = c.traceTypeId(d.constraintType)
+			case t.objectFlags&ObjectFlagsEvolvingArray != 0:
+				d := t.AsEvolvingArrayType()
+				descriptor.EvolvingArrayElementType = c.traceTypeId(d.elementType)
+				descriptor.EvolvingArrayFinalType = c.traceTypeId(d.finalArrayType)
+			}
+		}
+		if t.objectFlags&ObjectFlagsAnonymous != 0 || t.flags&TypeFlagsLiteral != 0 {
+			descriptor.Display = c.TypeToString(t)
+		}
+		result = append(result, descriptor)
+	}
+	return result
+}
+
+// traceSymbolName returns the name of a symbol as tsc would write it, with internal names such as
+// __type spelled out rather than marked with InternalSymbolNamePrefix.
+func traceSymbolName(symbol *ast.Symbol) string {
+	if name, ok := strings.CutPrefix(symbol.Name, ast.InternalSymbolNamePrefix); ok {
+		return "__" + name
+	}
+	return symbol.Name
+}
+
+func traceLocation(node *ast.Node) *tracing.Location {
+	file := ast.GetSourceFileOfNode(node)
+	if file == nil {
+		return nil
+	}
+	position := func(pos int) tracing.LineAndCharacter {
+		line, character := scanner.GetLineAndCharacterOfPosition(file, pos)
+		return tracing.LineAndCharacter{Line: line + 1, Character: character + 1}
+	}
+	return &tracing.Location{
+		Path:  string(file.Path()),
+		Start: position(node.Pos()),
+		End:   position(node.End()),
+	}
+}
+
+var typeFlagNames = []string{
+	"Any", "Unknown", "Undefined", "Null", "Void", "String", "Number", "BigInt", "Boolean", "ESSymbol",
+	"StringLiteral", "NumberLiteral", "BigIntLiteral", "BooleanLiteral", "UniqueESSymbol", "EnumLiteral", "Enum",
+	"NonPrimitive", "Never", "TypeParameter", "Object", "Index", "TemplateLiteral", "StringMapping",
+	"Substitution", "IndexedAccess", "Conditional", "Union", "Intersection",
+}
+
+func formatTypeFlags(flags TypeFlags) []string {
+	var result []string
+	for i, name := range typeFlagNames {
+		if flags&(1<<i) != 0 {
+			result = append(result, name)
+		}
+	}
+	if result == nil {
+		result = []string{"None"}
+	}
+	return result
+}
@@ -1220,6 +1220,10 @@ type TypePredicate struct {
 	t              *Type
 }
 
+func (p *TypePredicate) Type() *Type {
+	return p.t
+}
+
 // IndexInfo
 
 type IndexInfo struct {
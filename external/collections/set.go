@@ -1,5 +1,7 @@
 package collections
 
+import "maps"
+
 type Set[T comparable] struct {
 	M map[T]struct{}
 }
@@ -35,6 +37,13 @@ func (s *Set[T]) Keys() map[T]struct{} {
 	return s.M
 }
 
+func (s *Set[T]) Clone() *Set[T] {
+	if s == nil {
+		return nil
+	}
+	return &Set[T]{M: maps.Clone(s.M)}
+}
+
 func (s *Set[T]) Clear() {
 	clear(s.M)
 }
@@ -0,0 +1,236 @@
+package compiler
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/pagpeter/typescript-go/external/ast"
+	"github.com/pagpeter/typescript-go/external/core"
+	"github.com/pagpeter/typescript-go/external/diagnostics"
+	"github.com/pagpeter/typescript-go/external/vfs"
+)
+
+// BuildInfo is the content of a .tsbuildinfo file.
+//
+// Every program with a build info file records the compiler version and whether it had errors.
+// Incremental programs additionally record the state needed by the next compilation to only
+// recheck and re-emit the files affected by changes since.
+type BuildInfo struct {
+	Version string `json:"version"`
+	Errors  bool   `json:"errors,omitzero"`
+
+	// File names are relative to the directory of the build info file.
+	FileNames   []string             `json:"fileNames,omitzero"`
+	FileInfos   []*BuildInfoFileInfo `json:"fileInfos,omitzero"`
+	FileIdsList [][]BuildInfoFileId  `json:"fileIdsList,omitzero"`
+	Options     map[string]any       `json:"options,omitzero"`
+	// ReferencedMap maps a file to the files it imports or references.
+	ReferencedMap []*BuildInfoReferenceMapEntry `json:"referencedMap,omitzero"`
+	// Files that have no semantic diagnostics are omitted.
+	SemanticDiagnosticsPerFile []*BuildInfoSemanticDiagnostic `json:"semanticDiagnosticsPerFile,omitzero"`
+	AffectedFilesPendingEmit   []BuildInfoFileId              `json:"affectedFilesPendingEmit,omitzero"`
+}
+
+// BuildInfoFileId is a 1-based index into BuildInfo.FileNames.
+type BuildInfoFileId int
+
+// BuildInfoFileIdListId is a 1-based index into BuildInfo.FileIdsList.
+type BuildInfoFileIdListId int
+
+type BuildInfoFileInfo struct {
+	// Hash of the text of the file.
+	Version string `json:"version"`
+	// Hash of the declaration emit of the file. Omitted when it is the same as the version.
+	Signature          string              `json:"signature,omitzero"`
+	AffectsGlobalScope bool                `json:"affectsGlobalScope,omitzero"`
+	ImpliedNodeFormat  core.ResolutionMode `json:"impliedNodeFormat,omitzero"`
+}
+
+func (f *BuildInfoFileInfo) signature() string {
+	if f.Signature == "" {
+		return f.Version
+	}
+	return f.Signature
+}
+
+// BuildInfoReferenceMapEntry is serialized as a `[fileId, fileIdListId]` tuple.
+type BuildInfoReferenceMapEntry struct {
+	FileId       BuildInfoFileId
+	FileIdListId BuildInfoFileIdListId
+}
+
+func (e *BuildInfoReferenceMapEntry) MarshalJSON() ([]byte, error) {
+	return json.Marshal([2]int{int(e.FileId), int(e.FileIdListId)})
+}
+
+func (e *BuildInfoReferenceMapEntry) UnmarshalJSON(data []byte) error {
+	var tuple [2]int
+	if err := json.Unmarshal(data, &tuple); err != nil {
+		return err
+	}
+	e.FileId = BuildInfoFileId(tuple[0])
+	e.FileIdListId = BuildInfoFileIdListId(tuple[1])
+	return nil
+}
+
+// BuildInfoSemanticDiagnostic is serialized as a bare file id when the file still needs to be
+// checked, and as a `[fileId, diagnostics]` tuple otherwise.
+type BuildInfoSemanticDiagnostic struct {
+	FileId BuildInfoFileId
+	// Nil when the file was not checked.
+	Diagnostics []*BuildInfoDiagnostic
+}
+
+func (d *BuildInfoSemanticDiagnostic) MarshalJSON() ([]byte, error) {
+	if d.Diagnostics == nil {
+		return json.Marshal(d.FileId)
+	}
+	return json.Marshal([2]any{d.FileId, d.Diagnostics})
+}
+
+func (d *BuildInfoSemanticDiagnostic) UnmarshalJSON(data []byte) error {
+	var fileId BuildInfoFileId
+	if err := json.Unmarshal(data, &fileId); err == nil {
+		d.FileId = fileId
+		d.Diagnostics = nil
+		return nil
+	}
+	var tuple []json.RawMessage
+	if err := json.Unmarshal(data, &tuple); err != nil {
+		return err
+	}
+	if len(tuple) != 2 {
+		return fmt.Errorf("invalid semantic diagnostics entry: %s", data)
+	}
+	if err := json.Unmarshal(tuple[0], &d.FileId); err != nil {
+		return err
+	}
+	d.Diagnostics = []*BuildInfoDiagnostic{}
+	return json.Unmarshal(tuple[1], &d.Diagnostics)
+}
+
+type BuildInfoDiagnostic struct {
+	// Omitted for diagnostics in the file they are recorded for, and for diagnostics without a file.
+	File               BuildInfoFileId        `json:"file,omitzero"`
+	NoFile             bool                   `json:"noFile,omitzero"`
+	Pos                int                    `json:"pos"`
+	End                int                    `json:"end"`
+	Code               int32                  `json:"code"`
+	Category           diagnostics.Category   `json:"category"`
+	Message            string                 `json:"message"`
+	MessageChain       []*BuildInfoDiagnostic `json:"messageChain,omitzero"`
+	RelatedInformation []*BuildInfoDiagnostic `json:"relatedInformation,omitzero"`
+	ReportsUnnecessary bool                   `json:"reportsUnnecessary,omitzero"`
+	ReportsDeprecated  bool                   `json:"reportsDeprecated,omitzero"`
+}
+
+func toBuildInfoDiagnostics(diagnostics []*ast.Diagnostic, file *ast.SourceFile, toFileId func(*ast.SourceFile) BuildInfoFileId) []*BuildInfoDiagnostic {
+	return core.Map(diagnostics, func(d *ast.Diagnostic) *BuildInfoDiagnostic {
+		result := &BuildInfoDiagnostic{
+			Pos:                d.Pos(),
+			End:                d.End(),
+			Code:               d.Code(),
+			Category:           d.Category(),
+			Message:            d.Message(),
+			MessageChain:       toBuildInfoDiagnostics(d.MessageChain(), file, toFileId),
+			RelatedInformation: toBuildInfoDiagnostics(d.RelatedInformation(), file, toFileId),
+			ReportsUnnecessary: d.ReportsUnnecessary(),
+			ReportsDeprecated:  d.ReportsDeprecated(),
+		}
+		switch d.File() {
+		case file:
+		case nil:
+			result.NoFile = true
+		default:
+			result.File = toFileId(d.File())
+		}
+		return result
+	})
+}
+
+func fromBuildInfoDiagnostics(diagnostics []*BuildInfoDiagnostic, file *ast.SourceFile, toFile func(BuildInfoFileId) *ast.SourceFile) []*ast.Diagnostic {
+	return core.Map(diagnostics, func(d *BuildInfoDiagnostic) *ast.Diagnostic {
+		diagnosticFile := file
+		if d.NoFile {
+			diagnosticFile = nil
+		} else if d.File != 0 {
+			diagnosticFile = toFile(d.File)
+		}
+		return ast.NewDiagnosticFromSerialized(
+			diagnosticFile,
+			core.NewTextRange(d.Pos, d.End),
+			d.Code,
+			d.Category,
+			d.Message,
+			fromBuildInfoDiagnostics(d.MessageChain, file, toFile),
+			fromBuildInfoDiagnostics(d.RelatedInformation, file, toFile),
+			d.ReportsUnnecessary,
+			d.ReportsDeprecated,
+		)
+	})
+}
+
+// ReadBuildInfo reads the build info file with the given name, returning nil if it does not
+// exist, cannot be parsed, or refers to files or file lists it does not contain.
+func ReadBuildInfo(fs vfs.FS, buildInfoFileName string) *BuildInfo {
+	text, ok := fs.ReadFile(buildInfoFileName)
+	if !ok {
+		return nil
+	}
+	var buildInfo BuildInfo
+	if err := json.Unmarshal([]byte(text), &buildInfo); err != nil {
+		return nil
+	}
+	if !buildInfo.isValid() {
+		return nil
+	}
+	return &buildInfo
+}
+
+// isValid reports whether every file id and file id list id in the build info is in range, so
+// that a truncated or hand-edited file is treated as missing rather than trusted.
+func (b *BuildInfo) isValid() bool {
+	isValidFileId := func(id BuildInfoFileId) bool {
+		return id >= 1 && int(id) <= len(b.FileNames)
+	}
+	if slices.Contains(b.FileInfos, nil) {
+		return false
+	}
+	for _, ids := range b.FileIdsList {
+		if !core.Every(ids, isValidFileId) {
+			return false
+		}
+	}
+	for _, entry := range b.ReferencedMap {
+		if entry == nil || !isValidFileId(entry.FileId) || entry.FileIdListId < 1 || int(entry.FileIdListId) > len(b.FileIdsList) {
+			return false
+		}
+	}
+	var isValidDiagnostic func(d *BuildInfoDiagnostic) bool
+	isValidDiagnostic = func(d *BuildInfoDiagnostic) bool {
+		return d != nil &&
+			(d.File == 0 || isValidFileId(d.File)) &&
+			core.Every(d.MessageChain, isValidDiagnostic) &&
+			core.Every(d.RelatedInformation, isValidDiagnostic)
+	}
+	for _, entry := range b.SemanticDiagnosticsPerFile {
+		if entry == nil || !isValidFileId(entry.FileId) || !core.Every(entry.Diagnostics, isValidDiagnostic) {
+			return false
+		}
+	}
+	return core.Every(b.AffectedFilesPendingEmit, isValidFileId)
+}
+
+// IsIncremental returns true if the build info records the state of an incremental program.
+func (b *BuildInfo) IsIncremental() bool {
+	return len(b.FileNames) > 0
+}
+
+// HasPendingChanges returns true if the program the build info was written for did not check or
+// emit all of the files affected by changes.
+func (b *BuildInfo) HasPendingChanges() bool {
+	return len(b.AffectedFilesPendingEmit) > 0 || core.Some(b.SemanticDiagnosticsPerFile, func(d *BuildInfoSemanticDiagnostic) bool {
+		return d.Diagnostics == nil
+	})
+}
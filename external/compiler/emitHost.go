@@ -120,7 +120,15 @@ func (host *emitHost) IsEmitBlocked(file string) bool {
 	return false
 }
 
-func (host *emitHost) WriteFile(fileName string, text string, writeByteOrderMark bool, _ []*ast.SourceFile, _ *printer.WriteFileData) error {
+func (host *emitHost) WriteFile(fileName string, text string, writeByteOrderMark bool, sourceFiles []*ast.SourceFile, data *printer.WriteFileData) error {
+	if host.program.incremental != nil && len(sourceFiles) == 1 && tspath.IsDeclarationFileName(fileName) {
+		// The declaration file is the signature of its source file; the source map URL is not part of it.
+		signatureText := text
+		if data != nil && data.SourceMapUrlPos >= 0 {
+			signatureText = text[:data.SourceMapUrlPos]
+		}
+		host.program.incremental.recordEmitSignature(sourceFiles[0], signatureText)
+	}
 	return host.program.Host().FS().WriteFile(fileName, text, writeByteOrderMark)
 }
 
@@ -1,7 +1,9 @@
 package compiler
 
 import (
+	"context"
 	"encoding/base64"
+	"encoding/json"
 
 	"github.com/pagpeter/typescript-go/external/ast"
 	"github.com/pagpeter/typescript-go/external/binder"
@@ -11,6 +13,7 @@ import (
 	"github.com/pagpeter/typescript-go/external/printer"
 	"github.com/pagpeter/typescript-go/external/sourcemap"
 	"github.com/pagpeter/typescript-go/external/stringutil"
+	"github.com/pagpeter/typescript-go/external/tracing"
 	"github.com/pagpeter/typescript-go/external/transformers"
 	"github.com/pagpeter/typescript-go/external/transformers/declarations"
 	"github.com/pagpeter/typescript-go/external/transformers/estransforms"
@@ -40,10 +43,11 @@ type emitter struct {
 	writer             printer.EmitTextWriter
 	paths              *outputpaths.OutputPaths
 	sourceFile         *ast.SourceFile
+	buildInfo          *BuildInfo
+	tracing            *tracing.Tracing
 }
 
 func (e *emitter) emit() {
-	// !!! tracing
 	e.emitJSFile(e.sourceFile, e.paths.JsFilePath(), e.paths.SourceMapFilePath())
 	e.emitDeclarationFile(e.sourceFile, e.paths.DeclarationFilePath(), e.paths.DeclarationMapPath())
 	e.emitBuildInfo(e.paths.BuildInfoPath())
@@ -70,6 +74,15 @@ func getModuleTransformer(emitContext *printer.EmitContext, options *core.Compil
 		core.ModuleKindCommonJS:
 		return moduletransforms.NewImpliedModuleTransformer(emitContext, options, resolver, getEmitModuleFormatOfFile)
 
+	case core.ModuleKindAMD:
+		return moduletransforms.NewAMDModuleTransformer(emitContext, options, resolver, getEmitModuleFormatOfFile)
+
+	case core.ModuleKindUMD:
+		return moduletransforms.NewUMDModuleTransformer(emitContext, options, resolver, getEmitModuleFormatOfFile)
+
+	case core.ModuleKindSystem:
+		return moduletransforms.NewSystemModuleTransformer(emitContext, options, resolver, getEmitModuleFormatOfFile)
+
 	default:
 		return moduletransforms.NewCommonJSModuleTransformer(emitContext, options, resolver, getEmitModuleFormatOfFile)
 	}
@@ -84,7 +97,7 @@ func getScriptTransformers(emitContext *printer.EmitContext, host printer.EmitHo
 
 	var emitResolver printer.EmitResolver
 	var referenceResolver binder.ReferenceResolver
-	if importElisionEnabled || options.GetJSXTransformEnabled() {
+	if importElisionEnabled || options.GetJSXTransformEnabled() || options.ExperimentalDecorators.IsTrue() && options.EmitDecoratorMetadata.IsTrue() {
 		emitResolver = host.GetEmitResolver()
 		emitResolver.MarkLinkedReferencesRecursively(sourceFile)
 		referenceResolver = emitResolver
@@ -106,7 +119,12 @@ func getScriptTransformers(emitContext *printer.EmitContext, host printer.EmitHo
 		tx = append(tx, tstransforms.NewRuntimeSyntaxTransformer(emitContext, options, referenceResolver))
 	}
 
-	// !!! transform legacy decorator syntax
+	// transform legacy decorator syntax
+	if options.ExperimentalDecorators.IsTrue() {
+		tx = append(tx, tstransforms.NewLegacyDecoratorsTransformer(emitContext, options, emitResolver, referenceResolver))
+	}
+
+	// transform JSX syntax
 	if options.GetJSXTransformEnabled() {
 		tx = append(tx, jsxtransforms.NewJSXTransformer(emitContext, options, emitResolver))
 	}
@@ -128,6 +146,9 @@ func (e *emitter) emitJSFile(sourceFile *ast.SourceFile, jsFilePath string, sour
 		return
 	}
 
+	span := e.tracing.Begin(tracing.PhaseEmit, "emitJsFileOrBundle", tracing.Args{"jsFilePath": jsFilePath})
+	defer span.End()
+
 	if options.NoEmit == core.TSTrue || e.host.IsEmitBlocked(jsFilePath) {
 		return
 	}
@@ -171,6 +192,9 @@ func (e *emitter) emitDeclarationFile(sourceFile *ast.SourceFile, declarationFil
 		return
 	}
 
+	span := e.tracing.Begin(tracing.PhaseEmit, "emitDeclarationFileOrBundle", tracing.Args{"declarationFilePath": declarationFilePath})
+	defer span.End()
+
 	if options.NoEmit == core.TSTrue || e.host.IsEmitBlocked(declarationFilePath) {
 		return
 	}
@@ -207,7 +231,54 @@ func (e *emitter) emitDeclarationFile(sourceFile *ast.SourceFile, declarationFil
 }
 
 func (e *emitter) emitBuildInfo(buildInfoPath string) {
-	// !!!
+	if e.buildInfo == nil || e.emitOnly != emitAll && e.emitOnly != emitOnlyBuildInfo || len(buildInfoPath) == 0 {
+		return
+	}
+
+	span := e.tracing.Begin(tracing.PhaseEmit, "emitBuildInfo", tracing.Args{"buildInfoPath": buildInfoPath})
+	defer span.End()
+
+	if e.host.IsEmitBlocked(buildInfoPath) {
+		e.emitSkipped = true
+		return
+	}
+
+	data, err := json.Marshal(e.buildInfo)
+	if err != nil {
+		e.emitSkipped = true
+		e.emitterDiagnostics.Add(ast.NewCompilerDiagnostic(diagnostics.Could_not_write_file_0_Colon_1, buildInfoPath, err.Error()))
+		return
+	}
+	if err := e.host.WriteFile(buildInfoPath, string(data), false /*writeByteOrderMark*/, nil /*sourceFiles*/, nil /*data*/); err != nil {
+		e.emitterDiagnostics.Add(ast.NewCompilerDiagnostic(diagnostics.Could_not_write_file_0_Colon_1, buildInfoPath, err.Error()))
+	}
+
+	if e.emittedFilesList != nil {
+		e.emittedFilesList = append(e.emittedFilesList, buildInfoPath)
+	}
+}
+
+// getDeclarationText returns the text that declaration emit would produce for the file, without writing it.
+func getDeclarationText(ctx context.Context, program *Program, sourceFile *ast.SourceFile) string {
+	options := program.Options()
+	host, done := newEmitHost(ctx, program, sourceFile)
+	defer done()
+
+	emitContext, putEmitContext := printer.GetEmitContext()
+	defer putEmitContext()
+
+	declarationFilePath := outputpaths.GetDeclarationEmitOutputFilePath(sourceFile.FileName(), options, host)
+	transform := declarations.NewDeclarationTransformer(host, emitContext, options, declarationFilePath, "" /*declarationMapPath*/)
+	sourceFile = transform.TransformSourceFile(sourceFile)
+
+	printer_ := printer.NewPrinter(printer.PrinterOptions{
+		RemoveComments: options.RemoveComments.IsTrue(),
+		NewLine:        options.NewLine,
+		NoEmitHelpers:  options.NoEmitHelpers.IsTrue(),
+	}, printer.PrintHandlers{}, emitContext)
+	writer := printer.NewTextWriter(options.NewLine.GetNewLineCharacter())
+	printer_.Write(sourceFile.AsNode(), sourceFile, writer, nil /*sourceMapGenerator*/)
+	return writer.String()
 }
 
 func (e *emitter) printSourceFile(jsFilePath string, sourceMapFilePath string, sourceFile *ast.SourceFile, printer_ *printer.Printer, shouldEmitSourceMaps bool) bool {
@@ -0,0 +1,223 @@
+package compiler
+
+import (
+	"github.com/pagpeter/typescript-go/external/ast"
+	"github.com/pagpeter/typescript-go/external/collections"
+	"github.com/pagpeter/typescript-go/external/core"
+	"github.com/pagpeter/typescript-go/external/diagnostics"
+	"github.com/pagpeter/typescript-go/external/module"
+	"github.com/pagpeter/typescript-go/external/scanner"
+	"github.com/pagpeter/typescript-go/external/tsoptions"
+	"github.com/pagpeter/typescript-go/external/tspath"
+)
+
+type FileIncludeKind int
+
+const (
+	FileIncludeKindRootFile FileIncludeKind = iota
+	FileIncludeKindSourceFromProjectReference
+	FileIncludeKindOutputFromProjectReference
+	FileIncludeKindImport
+	FileIncludeKindReferenceFile
+	FileIncludeKindTypeReferenceDirective
+	FileIncludeKindLibFile
+	FileIncludeKindLibReferenceDirective
+	FileIncludeKindAutomaticTypeDirectiveFile
+)
+
+// FileIncludeReason records why a file is part of a program.
+type FileIncludeReason struct {
+	Kind FileIncludeKind
+	// For references, the file containing the reference.
+	file tspath.Path
+	// For root files, the index in the root file names; for references, the index of the reference
+	// directive in the referencing file; for lib files, the index in compilerOptions.lib, or -1 for
+	// the default library.
+	Index int
+	// For imports, the module specifier.
+	specifier *ast.Node
+	// For automatic type directives, the name of the type library.
+	typeReference string
+	// For files included from project references, the config file of the referenced project.
+	projectReference string
+	packageId        module.PackageId
+}
+
+// collectFileIncludeReasons walks the loaded files in the order they were reached from the roots,
+// recording every reference that includes each file, and reporting the trace messages of each
+// file's resolutions in the same order. Lib resolutions are cached, so their trace messages are
+// reported only where each lib is first reached.
+func (p *fileLoader) collectFileIncludeReasons(trace func(msg string)) map[tspath.Path][]*FileIncludeReason {
+	reasons := make(map[tspath.Path][]*FileIncludeReason)
+	var seen collections.Set[*parseTask]
+	var seenLibs collections.Set[*resolvedLib]
+	traceLib := func(lib *resolvedLib) {
+		if seenLibs.AddIfAbsent(lib) {
+			for _, msg := range lib.traces {
+				trace(msg)
+			}
+		}
+	}
+	var visit func(task *parseTask, reason *FileIncludeReason)
+	visit = func(task *parseTask, reason *FileIncludeReason) {
+		if task.rootLib != nil {
+			traceLib(task.rootLib)
+		}
+		for task.isLoaded() && task.isRedirected {
+			task = task.subTasks[0]
+		}
+		if !task.isLoaded() {
+			return
+		}
+		if reason != nil {
+			reasons[task.path] = append(reasons[task.path], reason)
+		}
+		if seen.Has(task) {
+			return
+		}
+		seen.Add(task)
+		libTraces := task.libTraces
+		for i, msg := range task.traces {
+			for len(libTraces) != 0 && libTraces[0].index == i {
+				traceLib(libTraces[0].lib)
+				libTraces = libTraces[1:]
+			}
+			trace(msg)
+		}
+		for _, libTrace := range libTraces {
+			traceLib(libTrace.lib)
+		}
+		for i, subTask := range task.subTasks {
+			visit(subTask, task.subTaskReasons[i])
+		}
+	}
+	for i, task := range p.rootTasks {
+		visit(task, p.rootTaskReasons[i])
+	}
+	return reasons
+}
+
+// GetFileIncludeReasons returns, for each file in the program, the reasons it was included.
+func (p *Program) GetFileIncludeReasons() map[tspath.Path][]*FileIncludeReason {
+	return p.fileIncludeReasons
+}
+
+// FileIncludeReasonToDiagnostic describes why a file was included as a message-only diagnostic.
+// File names in the message are passed through fileNameConvertor.
+func (p *Program) FileIncludeReasonToDiagnostic(reason *FileIncludeReason, fileNameConvertor func(fileName string) string) *ast.Diagnostic {
+	options := p.Options()
+	switch reason.Kind {
+	case FileIncludeKindImport, FileIncludeKindReferenceFile, FileIncludeKindTypeReferenceDirective, FileIncludeKindLibReferenceDirective:
+		file := p.GetSourceFileByPath(reason.file)
+		fileName := fileNameConvertor(file.FileName())
+		var packageId string
+		if reason.packageId.Name != "" {
+			packageId = reason.packageId.String()
+		}
+		switch reason.Kind {
+		case FileIncludeKindImport:
+			if reason.specifier.Pos() < 0 {
+				// A synthesized import of the import helpers or the JSX runtime
+				referenceText := `"` + reason.specifier.Text() + `"`
+				if reason.specifier.Text() == externalHelpersModuleNameText {
+					if packageId != "" {
+						return ast.NewCompilerDiagnostic(diagnostics.Imported_via_0_from_file_1_with_packageId_2_to_import_importHelpers_as_specified_in_compilerOptions, referenceText, fileName, packageId)
+					}
+					return ast.NewCompilerDiagnostic(diagnostics.Imported_via_0_from_file_1_to_import_importHelpers_as_specified_in_compilerOptions, referenceText, fileName)
+				}
+				if packageId != "" {
+					return ast.NewCompilerDiagnostic(diagnostics.Imported_via_0_from_file_1_with_packageId_2_to_import_jsx_and_jsxs_factory_functions, referenceText, fileName, packageId)
+				}
+				return ast.NewCompilerDiagnostic(diagnostics.Imported_via_0_from_file_1_to_import_jsx_and_jsxs_factory_functions, referenceText, fileName)
+			}
+			referenceText := file.Text()[scanner.SkipTrivia(file.Text(), reason.specifier.Pos()):reason.specifier.End()]
+			if packageId != "" {
+				return ast.NewCompilerDiagnostic(diagnostics.Imported_via_0_from_file_1_with_packageId_2, referenceText, fileName, packageId)
+			}
+			return ast.NewCompilerDiagnostic(diagnostics.Imported_via_0_from_file_1, referenceText, fileName)
+		case FileIncludeKindReferenceFile:
+			return ast.NewCompilerDiagnostic(diagnostics.Referenced_via_0_from_file_1, fileReferenceText(file, file.ReferencedFiles[reason.Index]), fileName)
+		case FileIncludeKindTypeReferenceDirective:
+			referenceText := fileReferenceText(file, file.TypeReferenceDirectives[reason.Index])
+			if packageId != "" {
+				return ast.NewCompilerDiagnostic(diagnostics.Type_library_referenced_via_0_from_file_1_with_packageId_2, referenceText, fileName, packageId)
+			}
+			return ast.NewCompilerDiagnostic(diagnostics.Type_library_referenced_via_0_from_file_1, referenceText, fileName)
+		default:
+			return ast.NewCompilerDiagnostic(diagnostics.Library_referenced_via_0_from_file_1, fileReferenceText(file, file.LibReferenceDirectives[reason.Index]), fileName)
+		}
+	case FileIncludeKindRootFile:
+		config := p.CommandLine()
+		if config.ConfigFile == nil {
+			return ast.NewCompilerDiagnostic(diagnostics.Root_file_specified_for_compilation)
+		}
+		fileName := tspath.GetNormalizedAbsolutePath(config.FileNames()[reason.Index], p.GetCurrentDirectory())
+		if config.GetMatchedFileSpec(fileName) != "" {
+			return ast.NewCompilerDiagnostic(diagnostics.Part_of_files_list_in_tsconfig_json)
+		}
+		if spec, isDefault := config.GetMatchedIncludeSpec(fileName); spec != "" {
+			return ast.NewCompilerDiagnostic(diagnostics.Matched_by_include_pattern_0_in_1, spec, fileNameConvertor(config.ConfigFile.SourceFile.FileName()))
+		} else if isDefault {
+			return ast.NewCompilerDiagnostic(diagnostics.Matched_by_default_include_pattern_Asterisk_Asterisk_Slash_Asterisk)
+		}
+		// Additional files specified as roots
+		return ast.NewCompilerDiagnostic(diagnostics.Root_file_specified_for_compilation)
+	case FileIncludeKindSourceFromProjectReference:
+		return ast.NewCompilerDiagnostic(diagnostics.Source_from_referenced_project_0_included_because_module_is_specified_as_none, fileNameConvertor(reason.projectReference))
+	case FileIncludeKindOutputFromProjectReference:
+		return ast.NewCompilerDiagnostic(diagnostics.Output_from_referenced_project_0_included_because_module_is_specified_as_none, fileNameConvertor(reason.projectReference))
+	case FileIncludeKindAutomaticTypeDirectiveFile:
+		if options.Types != nil {
+			if reason.packageId.Name != "" {
+				return ast.NewCompilerDiagnostic(diagnostics.Entry_point_of_type_library_0_specified_in_compilerOptions_with_packageId_1, reason.typeReference, reason.packageId.String())
+			}
+			return ast.NewCompilerDiagnostic(diagnostics.Entry_point_of_type_library_0_specified_in_compilerOptions, reason.typeReference)
+		}
+		if reason.packageId.Name != "" {
+			return ast.NewCompilerDiagnostic(diagnostics.Entry_point_for_implicit_type_library_0_with_packageId_1, reason.typeReference, reason.packageId.String())
+		}
+		return ast.NewCompilerDiagnostic(diagnostics.Entry_point_for_implicit_type_library_0, reason.typeReference)
+	case FileIncludeKindLibFile:
+		if reason.Index >= 0 {
+			return ast.NewCompilerDiagnostic(diagnostics.Library_0_specified_in_compilerOptions, options.Lib[reason.Index])
+		}
+		if target := tsoptions.GetNameOfScriptTarget(options.GetEmitScriptTarget()); target != "" {
+			return ast.NewCompilerDiagnostic(diagnostics.Default_library_for_target_0, target)
+		}
+		return ast.NewCompilerDiagnostic(diagnostics.Default_library)
+	default:
+		panic("unhandled file include kind")
+	}
+}
+
+// ExplainFileFormat describes where a file comes from when it is the output of a project reference,
+// and how its module format was determined, as message-only diagnostics.
+func (p *Program) ExplainFileFormat(file *ast.SourceFile, fileNameConvertor func(fileName string) string) []*ast.Diagnostic {
+	var result []*ast.Diagnostic
+	if source := p.GetSourceAndProjectReference(file.Path()); source != nil {
+		result = append(result, ast.NewCompilerDiagnostic(diagnostics.File_is_output_of_project_reference_source_0, fileNameConvertor(source.Source)))
+	}
+	if !ast.IsExternalOrCommonJSModule(file) {
+		return result
+	}
+	meta := p.GetSourceFileMetaData(file.Path())
+	if meta.PackageJsonDirectory == "" {
+		return result
+	}
+	packageJsonFileName := fileNameConvertor(tspath.CombinePaths(meta.PackageJsonDirectory, "package.json"))
+	switch ast.GetImpliedNodeFormatForEmitWorker(file.FileName(), p.Options().GetEmitModuleKind(), meta) {
+	case core.ModuleKindESNext:
+		result = append(result, ast.NewCompilerDiagnostic(diagnostics.File_is_ECMAScript_module_because_0_has_field_type_with_value_module, packageJsonFileName))
+	case core.ModuleKindCommonJS:
+		if meta.PackageJsonType != "" {
+			result = append(result, ast.NewCompilerDiagnostic(diagnostics.File_is_CommonJS_module_because_0_has_field_type_whose_value_is_not_module, packageJsonFileName))
+		} else {
+			result = append(result, ast.NewCompilerDiagnostic(diagnostics.File_is_CommonJS_module_because_0_does_not_have_field_type, packageJsonFileName))
+		}
+	}
+	return result
+}
+
+func fileReferenceText(file *ast.SourceFile, ref *ast.FileReference) string {
+	return file.Text()[ref.Pos():ref.End()]
+}
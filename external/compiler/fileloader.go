@@ -11,6 +11,7 @@ import (
 	"github.com/pagpeter/typescript-go/external/collections"
 	"github.com/pagpeter/typescript-go/external/core"
 	"github.com/pagpeter/typescript-go/external/module"
+	"github.com/pagpeter/typescript-go/external/tracing"
 	"github.com/pagpeter/typescript-go/external/tsoptions"
 	"github.com/pagpeter/typescript-go/external/tspath"
 )
@@ -25,6 +26,7 @@ type fileLoader struct {
 	parseTasks                 *fileLoaderWorker[*parseTask]
 	projectReferenceParseTasks *fileLoaderWorker[*projectReferenceParseTask]
 	rootTasks                  []*parseTask
+	rootTaskReasons            []*FileIncludeReason
 
 	totalFileCount atomic.Int32
 	libFileCount   atomic.Int32
@@ -35,7 +37,7 @@ type fileLoader struct {
 	projectReferenceFileMapper *projectReferenceFileMapper
 	dtsDirectories             collections.Set[tspath.Path]
 
-	pathForLibFileCache       collections.SyncMap[string, string]
+	pathForLibFileCache       collections.SyncMap[string, *resolvedLib]
 	pathForLibFileResolutions collections.SyncMap[tspath.Path, module.ModeAwareCache[*module.ResolvedModule]]
 }
 
@@ -51,6 +53,7 @@ type processedFiles struct {
 	jsxRuntimeImportSpecifiers    map[tspath.Path]*jsxRuntimeImportSpecifier
 	importHelpersImportSpecifiers map[tspath.Path]*ast.Node
 	libFiles                      collections.Set[tspath.Path]
+	fileIncludeReasons            map[tspath.Path][]*FileIncludeReason
 	// List of present unsupported extensions
 	unsupportedExtensions                []string
 	sourceFilesFoundSearchingNodeModules collections.Set[tspath.Path]
@@ -92,26 +95,33 @@ func processAllProgramFiles(
 	loader.addProjectReferenceTasks()
 	loader.resolver = module.NewResolver(loader.projectReferenceFileMapper.host, compilerOptions, opts.TypingsLocation, opts.ProjectName)
 
-	var libs []string
+	for index, fileName := range rootFiles {
+		loader.addRootTask(fileName, nil, &FileIncludeReason{Kind: FileIncludeKindRootFile, Index: index})
+	}
+
 	if compilerOptions.NoLib.IsFalseOrUnknown() {
 		if compilerOptions.Lib == nil {
 			name := tsoptions.GetDefaultLibFileName(compilerOptions)
-			libs = append(libs, loader.pathForLibFile(name))
+			libFile := loader.pathForLibFile(name)
+			loader.addRootTask(libFile.path, libFile, &FileIncludeReason{Kind: FileIncludeKindLibFile, Index: -1})
 		} else {
-			for _, lib := range compilerOptions.Lib {
+			for index, lib := range compilerOptions.Lib {
 				if name, ok := tsoptions.GetLibFileName(lib); ok {
-					libs = append(libs, loader.pathForLibFile(name))
+					libFile := loader.pathForLibFile(name)
+					loader.addRootTask(libFile.path, libFile, &FileIncludeReason{Kind: FileIncludeKindLibFile, Index: index})
 				}
 				// !!! error on unknown name
 			}
 		}
 	}
 
-	loader.addRootTasks(rootFiles, false)
-	loader.addRootTasks(libs, true)
 	loader.addAutomaticTypeDirectiveTasks()
 
 	loader.parseTasks.runAndWait(&loader, loader.rootTasks)
+
+	// Report the traces of resolutions made while loading in a deterministic order.
+	fileIncludeReasons := loader.collectFileIncludeReasons(opts.Host.Trace)
+
 	// Clear out loader and host to ensure its not used post program creation
 	loader.projectReferenceFileMapper.loader = nil
 	loader.projectReferenceFileMapper.host = nil
@@ -201,6 +211,7 @@ func processAllProgramFiles(
 		unsupportedExtensions:                unsupportedExtensions,
 		sourceFilesFoundSearchingNodeModules: sourceFilesFoundSearchingNodeModules,
 		libFiles:                             libFileSet,
+		fileIncludeReasons:                   fileIncludeReasons,
 	}
 }
 
@@ -208,12 +219,11 @@ func (p *fileLoader) toPath(file string) tspath.Path {
 	return tspath.ToPath(file, p.opts.Host.GetCurrentDirectory(), p.opts.Host.FS().UseCaseSensitiveFileNames())
 }
 
-func (p *fileLoader) addRootTasks(files []string, isLib bool) {
-	for _, fileName := range files {
-		absPath := tspath.GetNormalizedAbsolutePath(fileName, p.opts.Host.GetCurrentDirectory())
-		if core.Tristate.IsTrue(p.opts.Config.CompilerOptions().AllowNonTsExtensions) || slices.Contains(p.supportedExtensions, tspath.TryGetExtensionFromPath(absPath)) {
-			p.rootTasks = append(p.rootTasks, &parseTask{normalizedFilePath: absPath, isLib: isLib, root: true})
-		}
+func (p *fileLoader) addRootTask(fileName string, lib *resolvedLib, reason *FileIncludeReason) {
+	absPath := tspath.GetNormalizedAbsolutePath(fileName, p.opts.Host.GetCurrentDirectory())
+	if core.Tristate.IsTrue(p.opts.Config.CompilerOptions().AllowNonTsExtensions) || slices.Contains(p.supportedExtensions, tspath.TryGetExtensionFromPath(absPath)) {
+		p.rootTasks = append(p.rootTasks, &parseTask{normalizedFilePath: absPath, isLib: lib != nil, rootLib: lib, root: true})
+		p.rootTaskReasons = append(p.rootTaskReasons, reason)
 	}
 }
 
@@ -227,11 +237,13 @@ func (p *fileLoader) addAutomaticTypeDirectiveTasks() {
 	}
 	containingFileName := tspath.CombinePaths(containingDirectory, module.InferredTypesContainingFile)
 	p.rootTasks = append(p.rootTasks, &parseTask{normalizedFilePath: containingFileName, isLib: false, isForAutomaticTypeDirective: true})
+	p.rootTaskReasons = append(p.rootTaskReasons, nil)
 }
 
 func (p *fileLoader) resolveAutomaticTypeDirectives(containingFileName string) (
 	toParse []resolvedRef,
 	typeResolutionsInFile module.ModeAwareCache[*module.ResolvedTypeReferenceDirective],
+	traces []string,
 ) {
 	automaticTypeDirectiveNames := module.GetAutomaticTypeDirectiveNames(p.opts.Config.CompilerOptions(), p.opts.Host)
 	if len(automaticTypeDirectiveNames) != 0 {
@@ -239,18 +251,20 @@ func (p *fileLoader) resolveAutomaticTypeDirectives(containingFileName string) (
 		typeResolutionsInFile = make(module.ModeAwareCache[*module.ResolvedTypeReferenceDirective], len(automaticTypeDirectiveNames))
 		for _, name := range automaticTypeDirectiveNames {
 			resolutionMode := core.ModuleKindNodeNext
-			resolved := p.resolver.ResolveTypeReferenceDirective(name, containingFileName, resolutionMode, nil)
+			resolved, resolutionTraces := p.resolver.ResolveTypeReferenceDirective(name, containingFileName, resolutionMode, nil)
+			traces = append(traces, resolutionTraces...)
 			typeResolutionsInFile[module.ModeAwareCacheKey{Name: name, Mode: resolutionMode}] = resolved
 			if resolved.IsResolved() {
 				toParse = append(toParse, resolvedRef{
 					fileName:      resolved.ResolvedFileName,
 					increaseDepth: resolved.IsExternalLibraryImport,
 					elideOnDepth:  false,
+					reason:        &FileIncludeReason{Kind: FileIncludeKindAutomaticTypeDirectiveFile, typeReference: name, packageId: resolved.PackageId},
 				})
 			}
 		}
 	}
-	return toParse, typeResolutionsInFile
+	return toParse, typeResolutionsInFile, traces
 }
 
 func (p *fileLoader) addProjectReferenceTasks() {
@@ -279,11 +293,13 @@ func (p *fileLoader) addProjectReferenceTasks() {
 			if p.opts.canUseProjectReferenceSource() {
 				for _, fileName := range resolved.FileNames() {
 					p.rootTasks = append(p.rootTasks, &parseTask{normalizedFilePath: fileName, isLib: false})
+					p.rootTaskReasons = append(p.rootTaskReasons, &FileIncludeReason{Kind: FileIncludeKindSourceFromProjectReference, projectReference: resolved.ConfigName()})
 				}
 			} else {
 				for outputDts := range resolved.GetOutputDeclarationFileNames() {
 					if outputDts != "" {
 						p.rootTasks = append(p.rootTasks, &parseTask{normalizedFilePath: outputDts, isLib: false})
+						p.rootTaskReasons = append(p.rootTaskReasons, &FileIncludeReason{Kind: FileIncludeKindOutputFromProjectReference, projectReference: resolved.ConfigName()})
 					}
 				}
 			}
@@ -317,8 +333,10 @@ func (p *fileLoader) getDefaultLibFilePriority(a *ast.SourceFile) int {
 	return len(tsoptions.Libs) + 2
 }
 
-func (p *fileLoader) loadSourceFileMetaData(fileName string) ast.SourceFileMetaData {
-	packageJsonScope := p.resolver.GetPackageJsonScopeIfApplicable(fileName)
+func (p *fileLoader) loadSourceFileMetaData(t *parseTask) ast.SourceFileMetaData {
+	fileName := t.normalizedFilePath
+	packageJsonScope, traces := p.resolver.GetPackageJsonScopeIfApplicable(fileName)
+	t.traces = append(t.traces, traces...)
 	var packageJsonType, packageJsonDirectory string
 	if packageJsonScope.Exists() {
 		packageJsonDirectory = packageJsonScope.PackageDirectory
@@ -335,6 +353,8 @@ func (p *fileLoader) loadSourceFileMetaData(fileName string) ast.SourceFileMetaD
 }
 
 func (p *fileLoader) parseSourceFile(t *parseTask) *ast.SourceFile {
+	span := p.opts.Tracing.Begin(tracing.PhaseParse, "createSourceFile", tracing.Args{"path": t.normalizedFilePath})
+	defer span.End()
 	path := p.toPath(t.normalizedFilePath)
 	options := p.projectReferenceFileMapper.getCompilerOptionsForFile(t)
 	sourceFile := p.opts.Host.GetSourceFile(ast.SourceFileParseOptions{
@@ -367,10 +387,11 @@ func (p *fileLoader) resolveTypeReferenceDirectives(t *parseTask) {
 	meta := t.metadata
 
 	typeResolutionsInFile := make(module.ModeAwareCache[*module.ResolvedTypeReferenceDirective], len(file.TypeReferenceDirectives))
-	for _, ref := range file.TypeReferenceDirectives {
+	for index, ref := range file.TypeReferenceDirectives {
 		redirect := p.projectReferenceFileMapper.getRedirectForResolution(file)
 		resolutionMode := getModeForTypeReferenceDirectiveInFile(ref, file, meta, module.GetCompilerOptionsWithRedirect(p.opts.Config.CompilerOptions(), redirect))
-		resolved := p.resolver.ResolveTypeReferenceDirective(ref.FileName, file.FileName(), resolutionMode, redirect)
+		resolved, traces := p.resolver.ResolveTypeReferenceDirective(ref.FileName, file.FileName(), resolutionMode, redirect)
+		t.traces = append(t.traces, traces...)
 		typeResolutionsInFile[module.ModeAwareCacheKey{Name: ref.FileName, Mode: resolutionMode}] = resolved
 		if resolved.IsResolved() {
 			t.addSubTask(resolvedRef{
@@ -378,6 +399,7 @@ func (p *fileLoader) resolveTypeReferenceDirectives(t *parseTask) {
 				increaseDepth:         resolved.IsExternalLibraryImport,
 				elideOnDepth:          false,
 				isFromExternalLibrary: resolved.IsExternalLibraryImport,
+				reason:                &FileIncludeReason{Kind: FileIncludeKindTypeReferenceDirective, file: t.path, Index: index, packageId: resolved.PackageId},
 			}, false)
 		}
 	}
@@ -436,7 +458,8 @@ func (p *fileLoader) resolveImportsAndModuleAugmentations(t *parseTask) {
 			}
 
 			mode := getModeForUsageLocation(file.FileName(), meta, entry, optionsForFile)
-			resolvedModule := p.resolver.ResolveModuleName(moduleName, file.FileName(), mode, redirect)
+			resolvedModule, traces := p.resolver.ResolveModuleName(moduleName, file.FileName(), mode, redirect)
+			t.traces = append(t.traces, traces...)
 			resolutionsInFile[module.ModeAwareCacheKey{Name: moduleName, Mode: mode}] = resolvedModule
 
 			if !resolvedModule.IsResolved() {
@@ -469,6 +492,7 @@ func (p *fileLoader) resolveImportsAndModuleAugmentations(t *parseTask) {
 					increaseDepth:         resolvedModule.IsExternalLibraryImport,
 					elideOnDepth:          isJsFileFromNodeModules,
 					isFromExternalLibrary: resolvedModule.IsExternalLibraryImport,
+					reason:                &FileIncludeReason{Kind: FileIncludeKindImport, file: t.path, specifier: entry, packageId: resolvedModule.PackageId},
 				}, false)
 			}
 		}
@@ -490,26 +514,33 @@ func (p *fileLoader) createSyntheticImport(text string, file *ast.SourceFile) *a
 	return externalHelpersModuleReference
 }
 
-func (p *fileLoader) pathForLibFile(name string) string {
+type resolvedLib struct {
+	path string
+	// trace messages of the lib replacement resolution, reported only where the lib is first reached
+	traces []string
+}
+
+func (p *fileLoader) pathForLibFile(name string) *resolvedLib {
 	if cached, ok := p.pathForLibFileCache.Load(name); ok {
 		return cached
 	}
 
-	path := tspath.CombinePaths(p.defaultLibraryPath, name)
+	lib := &resolvedLib{path: tspath.CombinePaths(p.defaultLibraryPath, name)}
 	if p.opts.Config.CompilerOptions().LibReplacement.IsTrue() {
 		libraryName := getLibraryNameFromLibFileName(name)
 		resolveFrom := getInferredLibraryNameResolveFrom(p.opts.Config.CompilerOptions(), p.opts.Host.GetCurrentDirectory(), name)
-		resolution := p.resolver.ResolveModuleName(libraryName, resolveFrom, core.ModuleKindCommonJS, nil)
+		resolution, traces := p.resolver.ResolveModuleName(libraryName, resolveFrom, core.ModuleKindCommonJS, nil)
+		lib.traces = traces
 		if resolution.IsResolved() {
-			path = resolution.ResolvedFileName
+			lib.path = resolution.ResolvedFileName
 			p.pathForLibFileResolutions.LoadOrStore(p.toPath(resolveFrom), module.ModeAwareCache[*module.ResolvedModule]{
 				module.ModeAwareCacheKey{Name: libraryName, Mode: core.ModuleKindCommonJS}: resolution,
 			})
 		}
 	}
 
-	path, _ = p.pathForLibFileCache.LoadOrStore(name, path)
-	return path
+	lib, _ = p.pathForLibFileCache.LoadOrStore(name, lib)
+	return lib
 }
 
 func getLibraryNameFromLibFileName(libFileName string) string {
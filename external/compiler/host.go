@@ -34,6 +34,7 @@ type compilerHost struct {
 	fs                  vfs.FS
 	defaultLibraryPath  string
 	extendedConfigCache *collections.SyncMap[tspath.Path, *tsoptions.ExtendedConfigCacheEntry]
+	trace               func(msg string)
 }
 
 func NewCachedFSCompilerHost(
@@ -42,8 +43,9 @@ func NewCachedFSCompilerHost(
 	fs vfs.FS,
 	defaultLibraryPath string,
 	extendedConfigCache *collections.SyncMap[tspath.Path, *tsoptions.ExtendedConfigCacheEntry],
+	trace func(msg string),
 ) CompilerHost {
-	return NewCompilerHost(options, currentDirectory, cachedvfs.From(fs), defaultLibraryPath, extendedConfigCache)
+	return NewCompilerHost(options, currentDirectory, cachedvfs.From(fs), defaultLibraryPath, extendedConfigCache, trace)
 }
 
 func NewCompilerHost(
@@ -52,6 +54,7 @@ func NewCompilerHost(
 	fs vfs.FS,
 	defaultLibraryPath string,
 	extendedConfigCache *collections.SyncMap[tspath.Path, *tsoptions.ExtendedConfigCacheEntry],
+	trace func(msg string),
 ) CompilerHost {
 	return &compilerHost{
 		options:             options,
@@ -59,6 +62,7 @@ func NewCompilerHost(
 		fs:                  fs,
 		defaultLibraryPath:  defaultLibraryPath,
 		extendedConfigCache: extendedConfigCache,
+		trace:               trace,
 	}
 }
 
@@ -86,7 +90,9 @@ func (h *compilerHost) NewLine() string {
 }
 
 func (h *compilerHost) Trace(msg string) {
-	//!!! TODO: implement
+	if h.trace != nil {
+		h.trace(msg)
+	}
 }
 
 func (h *compilerHost) GetSourceFile(opts ast.SourceFileParseOptions) *ast.SourceFile {
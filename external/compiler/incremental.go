@@ -0,0 +1,414 @@
+package compiler
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/pagpeter/typescript-go/external/ast"
+	"github.com/pagpeter/typescript-go/external/collections"
+	"github.com/pagpeter/typescript-go/external/core"
+	"github.com/pagpeter/typescript-go/external/outputpaths"
+	"github.com/pagpeter/typescript-go/external/tsoptions"
+	"github.com/pagpeter/typescript-go/external/tspath"
+)
+
+// incrementalState tracks which files of an incremental program are affected by changes made
+// since the previous compilation, using the state that compilation saved in its .tsbuildinfo file.
+type incrementalState struct {
+	program             *Program
+	buildInfoFileName   string
+	buildInfoDirectory  string
+	comparePathsOptions tspath.ComparePathsOptions
+	oldBuildInfo        *BuildInfo
+
+	fileInfos     map[tspath.Path]*BuildInfoFileInfo
+	referencedMap map[tspath.Path][]tspath.Path
+	options       map[string]any
+	// The error that prevented the options from being recorded, if any.
+	optionsErr error
+
+	affectedOnce sync.Once
+	// Files whose semantic diagnostics cannot be reused from the previous compilation.
+	pendingCheck collections.Set[tspath.Path]
+	// Files whose outputs are out of date.
+	pendingEmit collections.Set[tspath.Path]
+
+	// Semantic diagnostics, both reused from the previous compilation and computed by this one.
+	semanticDiagnostics collections.SyncMap[tspath.Path, []*ast.Diagnostic]
+	// Signatures computed from declaration files written during emit.
+	emitSignatures collections.SyncMap[tspath.Path, string]
+	// Files that were emitted by this compilation without errors.
+	emitted collections.Set[tspath.Path]
+}
+
+func newIncrementalState(p *Program) *incrementalState {
+	comparePathsOptions := tspath.ComparePathsOptions{
+		UseCaseSensitiveFileNames: p.UseCaseSensitiveFileNames(),
+		CurrentDirectory:          p.GetCurrentDirectory(),
+	}
+	buildInfoFileName := outputpaths.GetBuildInfoFileName(p.Options(), comparePathsOptions)
+	if buildInfoFileName == "" {
+		return nil
+	}
+	s := &incrementalState{
+		program:             p,
+		buildInfoFileName:   buildInfoFileName,
+		buildInfoDirectory:  tspath.GetDirectoryPath(tspath.GetNormalizedAbsolutePath(buildInfoFileName, p.GetCurrentDirectory())),
+		comparePathsOptions: comparePathsOptions,
+		fileInfos:           make(map[tspath.Path]*BuildInfoFileInfo, len(p.files)),
+		referencedMap:       make(map[tspath.Path][]tspath.Path),
+	}
+	s.options, s.optionsErr = getBuildInfoOptions(p.Options())
+	for _, file := range p.files {
+		s.fileInfos[file.Path()] = &BuildInfoFileInfo{
+			Version:            computeHash(file.Text()),
+			AffectsGlobalScope: isFileAffectingGlobalScope(file),
+			ImpliedNodeFormat:  p.sourceFileMetaDatas[file.Path()].ImpliedNodeFormat,
+		}
+		if references := p.getReferencedFiles(file); len(references) > 0 {
+			s.referencedMap[file.Path()] = references
+		}
+	}
+	if buildInfo := ReadBuildInfo(p.Host().FS(), buildInfoFileName); buildInfo != nil && buildInfo.IsIncremental() && buildInfo.Version == core.Version() {
+		s.oldBuildInfo = buildInfo
+	}
+	return s
+}
+
+func computeHash(text string) string {
+	hash := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(hash[:])
+}
+
+func isFileAffectingGlobalScope(file *ast.SourceFile) bool {
+	return !ast.IsExternalOrCommonJSModule(file) && !ast.IsJsonSourceFile(file) ||
+		core.Some(file.ModuleAugmentations, func(name *ast.ModuleName) bool { return ast.IsGlobalScopeAugmentation(name.Parent) })
+}
+
+// getReferencedFiles returns the paths of the files in the program that the given file imports or references.
+func (p *Program) getReferencedFiles(file *ast.SourceFile) []tspath.Path {
+	var references collections.Set[tspath.Path]
+	addReference := func(referenced *ast.SourceFile) {
+		if referenced != nil && referenced != file {
+			references.Add(referenced.Path())
+		}
+	}
+	for _, resolved := range p.resolvedModules[file.Path()] {
+		if resolved.IsResolved() {
+			addReference(p.GetSourceFileForResolvedModule(resolved.ResolvedFileName))
+		}
+	}
+	for _, resolved := range p.typeResolutionsInFile[file.Path()] {
+		if resolved.IsResolved() {
+			addReference(p.GetSourceFile(resolved.ResolvedFileName))
+		}
+	}
+	for _, ref := range file.ReferencedFiles {
+		addReference(p.GetSourceFileFromReference(file, ref))
+	}
+	return slices.Sorted(maps.Keys(references.Keys()))
+}
+
+// getBuildInfoOptions returns the compiler options that are recorded in the build info, as they
+// would be read back from it.
+func getBuildInfoOptions(options *core.CompilerOptions) (map[string]any, error) {
+	data, err := json.Marshal(options)
+	if err != nil {
+		return nil, err
+	}
+	var allOptions map[string]any
+	if err := json.Unmarshal(data, &allOptions); err != nil {
+		return nil, err
+	}
+	result := map[string]any{}
+	for name, value := range allOptions {
+		if decl := tsoptions.CompilerNameMap.Get(name); decl != nil && decl.AffectsBuildInfo {
+			result[name] = value
+		}
+	}
+	return result, nil
+}
+
+func (s *incrementalState) toPath(fileName string) tspath.Path {
+	return tspath.ToPath(fileName, s.buildInfoDirectory, s.comparePathsOptions.UseCaseSensitiveFileNames)
+}
+
+// ensureAffectedFiles determines which files need to be checked and emitted, comparing the
+// program against the state recorded by the previous compilation.
+func (s *incrementalState) ensureAffectedFiles(ctx context.Context) {
+	s.affectedOnce.Do(func() {
+		s.computeAffectedFiles(ctx)
+	})
+}
+
+func (s *incrementalState) computeAffectedFiles(ctx context.Context) {
+	p := s.program
+	old := s.oldBuildInfo
+	if old == nil || s.optionsErr != nil {
+		s.markAllAffected(true /*check*/, true /*emit*/)
+		return
+	}
+
+	oldPaths := core.Map(old.FileNames, s.toPath)
+	oldFileInfos := make(map[tspath.Path]*BuildInfoFileInfo, len(oldPaths))
+	for i, path := range oldPaths {
+		if i < len(old.FileInfos) {
+			oldFileInfos[path] = old.FileInfos[i]
+		}
+	}
+	oldReferencedMap := make(map[tspath.Path][]tspath.Path, len(old.ReferencedMap))
+	for _, entry := range old.ReferencedMap {
+		references := core.Map(old.FileIdsList[entry.FileIdListId-1], func(id BuildInfoFileId) tspath.Path { return oldPaths[id-1] })
+		slices.Sort(references)
+		oldReferencedMap[oldPaths[entry.FileId-1]] = references
+	}
+
+	checkAll, emitAll := s.compareOptions(old.Options)
+	if checkAll && emitAll {
+		s.markAllAffected(true /*check*/, true /*emit*/)
+		return
+	}
+
+	// A file changed if its text changed, or if its imports now resolve differently.
+	var changedFiles []tspath.Path
+	for _, file := range p.files {
+		path := file.Path()
+		info := s.fileInfos[path]
+		oldInfo := oldFileInfos[path]
+		if oldInfo == nil ||
+			oldInfo.Version != info.Version ||
+			oldInfo.ImpliedNodeFormat != info.ImpliedNodeFormat ||
+			!slices.Equal(oldReferencedMap[path], s.referencedMap[path]) {
+			changedFiles = append(changedFiles, path)
+		} else {
+			info.Signature = oldInfo.Signature
+		}
+	}
+	for path, oldInfo := range oldFileInfos {
+		if _, ok := s.fileInfos[path]; !ok && oldInfo.AffectsGlobalScope {
+			s.markAllAffected(true /*check*/, true /*emit*/)
+			return
+		}
+	}
+
+	referencedBy := map[tspath.Path][]tspath.Path{}
+	for path, references := range s.referencedMap {
+		for _, reference := range references {
+			referencedBy[reference] = append(referencedBy[reference], path)
+		}
+	}
+
+	// Files that import a file whose declaration (its signature) changed are affected as well.
+	// The change propagates to their importers in turn only if their own signature changed too.
+	var affected collections.Set[tspath.Path]
+	var queue []tspath.Path
+	updateSignature := func(path tspath.Path) {
+		info := s.fileInfos[path]
+		info.Signature = s.computeSignature(ctx, p.filesByPath[path], info)
+		if oldInfo := oldFileInfos[path]; oldInfo == nil || oldInfo.signature() != info.signature() {
+			queue = append(queue, path)
+		}
+	}
+	for _, path := range changedFiles {
+		if s.fileInfos[path].AffectsGlobalScope || oldFileInfos[path] != nil && oldFileInfos[path].AffectsGlobalScope {
+			s.markAllAffected(true /*check*/, true /*emit*/)
+			return
+		}
+		if affected.AddIfAbsent(path) {
+			updateSignature(path)
+		}
+	}
+	for len(queue) > 0 {
+		path := queue[0]
+		queue = queue[1:]
+		for _, referencing := range referencedBy[path] {
+			if affected.AddIfAbsent(referencing) {
+				updateSignature(referencing)
+			}
+		}
+	}
+
+	s.markAllAffected(checkAll, emitAll)
+	for path := range affected.Keys() {
+		s.pendingCheck.Add(path)
+		s.addPendingEmit(path)
+	}
+
+	// Work left over by the previous compilation is still pending.
+	for _, entry := range old.SemanticDiagnosticsPerFile {
+		if entry.Diagnostics == nil {
+			s.pendingCheck.Add(oldPaths[entry.FileId-1])
+		}
+	}
+	for _, id := range old.AffectedFilesPendingEmit {
+		s.addPendingEmit(oldPaths[id-1])
+	}
+
+	if !checkAll {
+		oldDiagnostics := make(map[tspath.Path][]*BuildInfoDiagnostic, len(old.SemanticDiagnosticsPerFile))
+		for _, entry := range old.SemanticDiagnosticsPerFile {
+			oldDiagnostics[oldPaths[entry.FileId-1]] = entry.Diagnostics
+		}
+		toFile := func(id BuildInfoFileId) *ast.SourceFile {
+			return p.filesByPath[oldPaths[id-1]]
+		}
+		for _, file := range p.files {
+			if !s.pendingCheck.Has(file.Path()) {
+				s.semanticDiagnostics.Store(file.Path(), fromBuildInfoDiagnostics(oldDiagnostics[file.Path()], file, toFile))
+			}
+		}
+	}
+}
+
+// compareOptions reports whether changes in the compiler options since the previous compilation
+// require all files to be checked or emitted again.
+func (s *incrementalState) compareOptions(oldOptions map[string]any) (checkAll bool, emitAll bool) {
+	var names collections.Set[string]
+	for name := range oldOptions {
+		names.Add(name)
+	}
+	for name := range s.options {
+		names.Add(name)
+	}
+	for name := range names.Keys() {
+		if reflect.DeepEqual(oldOptions[name], s.options[name]) {
+			continue
+		}
+		decl := tsoptions.CompilerNameMap.Get(name)
+		if decl == nil {
+			checkAll, emitAll = true, true
+			continue
+		}
+		if decl.AffectsSemanticDiagnostics {
+			checkAll = true
+		}
+		if decl.AffectsEmit || decl.AffectsDeclarationPath {
+			emitAll = true
+		}
+	}
+	return checkAll, emitAll
+}
+
+func (s *incrementalState) markAllAffected(check bool, emit bool) {
+	for _, file := range s.program.files {
+		if check {
+			s.pendingCheck.Add(file.Path())
+		}
+		if emit {
+			s.addPendingEmit(file.Path())
+		}
+	}
+}
+
+func (s *incrementalState) addPendingEmit(path tspath.Path) {
+	if file := s.program.filesByPath[path]; file != nil && sourceFileMayBeEmitted(file, s.program, false /*forceDtsEmit*/) {
+		s.pendingEmit.Add(path)
+	}
+}
+
+// computeSignature returns the hash of the declaration emit of the file, which changes only when
+// the shape of the file that other files can observe changes.
+func (s *incrementalState) computeSignature(ctx context.Context, file *ast.SourceFile, info *BuildInfoFileInfo) string {
+	if file.IsDeclarationFile || ast.IsJsonSourceFile(file) {
+		return info.Version
+	}
+	return computeSignatureHash(getDeclarationText(ctx, s.program, file))
+}
+
+func computeSignatureHash(declarationText string) string {
+	return computeHash(strings.TrimRight(declarationText, "\r\n"))
+}
+
+func (s *incrementalState) needsCheck(ctx context.Context, file *ast.SourceFile) bool {
+	s.ensureAffectedFiles(ctx)
+	return s.pendingCheck.Has(file.Path())
+}
+
+func (s *incrementalState) needsEmit(ctx context.Context, file *ast.SourceFile) bool {
+	s.ensureAffectedFiles(ctx)
+	return s.pendingEmit.Has(file.Path())
+}
+
+func (s *incrementalState) recordEmitSignature(file *ast.SourceFile, declarationText string) {
+	s.emitSignatures.Store(file.Path(), computeSignatureHash(declarationText))
+}
+
+func (s *incrementalState) recordEmit(file *ast.SourceFile, hasDiagnostics bool) {
+	if !hasDiagnostics {
+		s.emitted.Add(file.Path())
+	}
+}
+
+func (s *incrementalState) getBuildInfo(hasErrors bool) (*BuildInfo, error) {
+	if s.optionsErr != nil {
+		return nil, s.optionsErr
+	}
+	p := s.program
+	buildInfo := &BuildInfo{
+		Version: core.Version(),
+		Errors:  hasErrors,
+		Options: s.options,
+	}
+	fileIds := make(map[tspath.Path]BuildInfoFileId, len(p.files))
+	for i, file := range p.files {
+		fileIds[file.Path()] = BuildInfoFileId(i + 1)
+		buildInfo.FileNames = append(buildInfo.FileNames, tspath.GetRelativePathFromDirectory(s.buildInfoDirectory, file.FileName(), s.comparePathsOptions))
+		info := *s.fileInfos[file.Path()]
+		if signature, ok := s.emitSignatures.Load(file.Path()); ok {
+			info.Signature = signature
+		}
+		if info.Signature == info.Version {
+			info.Signature = ""
+		}
+		buildInfo.FileInfos = append(buildInfo.FileInfos, &info)
+	}
+	toFileId := func(file *ast.SourceFile) BuildInfoFileId {
+		return fileIds[file.Path()]
+	}
+
+	fileIdListIds := map[string]BuildInfoFileIdListId{}
+	for _, file := range p.files {
+		references := s.referencedMap[file.Path()]
+		if len(references) == 0 {
+			continue
+		}
+		ids := core.Map(references, func(path tspath.Path) BuildInfoFileId { return fileIds[path] })
+		slices.Sort(ids)
+		key := fmt.Sprint(ids)
+		listId, ok := fileIdListIds[key]
+		if !ok {
+			buildInfo.FileIdsList = append(buildInfo.FileIdsList, ids)
+			listId = BuildInfoFileIdListId(len(buildInfo.FileIdsList))
+			fileIdListIds[key] = listId
+		}
+		buildInfo.ReferencedMap = append(buildInfo.ReferencedMap, &BuildInfoReferenceMapEntry{FileId: fileIds[file.Path()], FileIdListId: listId})
+	}
+
+	for _, file := range p.files {
+		diagnostics, ok := s.semanticDiagnostics.Load(file.Path())
+		switch {
+		case !ok:
+			buildInfo.SemanticDiagnosticsPerFile = append(buildInfo.SemanticDiagnosticsPerFile, &BuildInfoSemanticDiagnostic{FileId: fileIds[file.Path()]})
+		case len(diagnostics) > 0:
+			buildInfo.SemanticDiagnosticsPerFile = append(buildInfo.SemanticDiagnosticsPerFile, &BuildInfoSemanticDiagnostic{
+				FileId:      fileIds[file.Path()],
+				Diagnostics: toBuildInfoDiagnostics(diagnostics, file, toFileId),
+			})
+		}
+	}
+
+	for _, file := range p.files {
+		if s.pendingEmit.Has(file.Path()) && !s.emitted.Has(file.Path()) {
+			buildInfo.AffectedFilesPendingEmit = append(buildInfo.AffectedFilesPendingEmit, fileIds[file.Path()])
+		}
+	}
+	return buildInfo, nil
+}
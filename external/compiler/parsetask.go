@@ -27,6 +27,15 @@ type parseTask struct {
 	increaseDepth                bool
 	elideOnDepth                 bool
 
+	// why each of subTasks is included
+	subTaskReasons []*FileIncludeReason
+	// trace messages of the resolutions made while loading this file
+	traces []string
+	// lib resolutions made while loading this file, whose trace messages are cached with the lib
+	libTraces []libTrace
+	// for libs included by compilerOptions, the resolution of the lib
+	rootLib *resolvedLib
+
 	// Track if this file is from an external library (node_modules)
 	// This mirrors the TypeScript currentNodeModulesDepth > 0 check
 	fromExternalLibrary bool
@@ -58,7 +67,7 @@ func (t *parseTask) load(loader *fileLoader) {
 		loader.libFileCount.Add(1)
 	}
 
-	t.metadata = loader.loadSourceFileMetaData(t.normalizedFilePath)
+	t.metadata = loader.loadSourceFileMetaData(t)
 	file := loader.parseSourceFile(t)
 	if file == nil {
 		return
@@ -67,8 +76,9 @@ func (t *parseTask) load(loader *fileLoader) {
 	t.file = file
 	t.subTasks = make([]*parseTask, 0, len(file.ReferencedFiles)+len(file.Imports())+len(file.ModuleAugmentations))
 
-	for _, ref := range file.ReferencedFiles {
+	for index, ref := range file.ReferencedFiles {
 		resolvedPath := loader.resolveTripleslashPathReference(ref.FileName, file.FileName())
+		resolvedPath.reason = &FileIncludeReason{Kind: FileIncludeKindReferenceFile, file: t.path, Index: index}
 		t.addSubTask(resolvedPath, false)
 	}
 
@@ -76,9 +86,14 @@ func (t *parseTask) load(loader *fileLoader) {
 	loader.resolveTypeReferenceDirectives(t)
 
 	if compilerOptions.NoLib != core.TSTrue {
-		for _, lib := range file.LibReferenceDirectives {
+		for index, lib := range file.LibReferenceDirectives {
 			if name, ok := tsoptions.GetLibFileName(lib.FileName); ok {
-				t.addSubTask(resolvedRef{fileName: loader.pathForLibFile(name)}, true)
+				libFile := loader.pathForLibFile(name)
+				t.libTraces = append(t.libTraces, libTrace{lib: libFile, index: len(t.traces)})
+				t.addSubTask(resolvedRef{
+					fileName: libFile.path,
+					reason:   &FileIncludeReason{Kind: FileIncludeKindLibReferenceDirective, file: t.path, Index: index},
+				}, true)
 			}
 		}
 	}
@@ -93,18 +108,26 @@ func (t *parseTask) redirect(loader *fileLoader, fileName string) {
 }
 
 func (t *parseTask) loadAutomaticTypeDirectives(loader *fileLoader) {
-	toParseTypeRefs, typeResolutionsInFile := loader.resolveAutomaticTypeDirectives(t.normalizedFilePath)
+	toParseTypeRefs, typeResolutionsInFile, traces := loader.resolveAutomaticTypeDirectives(t.normalizedFilePath)
 	t.typeResolutionsInFile = typeResolutionsInFile
+	t.traces = traces
 	for _, typeResolution := range toParseTypeRefs {
 		t.addSubTask(typeResolution, false)
 	}
 }
 
+type libTrace struct {
+	lib *resolvedLib
+	// the number of trace messages of the file that precede the lib resolution
+	index int
+}
+
 type resolvedRef struct {
 	fileName              string
 	increaseDepth         bool
 	elideOnDepth          bool
 	isFromExternalLibrary bool
+	reason                *FileIncludeReason
 }
 
 func (t *parseTask) addSubTask(ref resolvedRef, isLib bool) {
@@ -117,6 +140,7 @@ func (t *parseTask) addSubTask(ref resolvedRef, isLib bool) {
 		fromExternalLibrary: ref.isFromExternalLibrary,
 	}
 	t.subTasks = append(t.subTasks, subTask)
+	t.subTaskReasons = append(t.subTaskReasons, ref.reason)
 }
 
 func (t *parseTask) getSubTasks() []*parseTask {
@@ -18,6 +18,7 @@ import (
 	"github.com/pagpeter/typescript-go/external/printer"
 	"github.com/pagpeter/typescript-go/external/scanner"
 	"github.com/pagpeter/typescript-go/external/sourcemap"
+	"github.com/pagpeter/typescript-go/external/tracing"
 	"github.com/pagpeter/typescript-go/external/tsoptions"
 	"github.com/pagpeter/typescript-go/external/tspath"
 )
@@ -31,6 +32,12 @@ type ProgramOptions struct {
 	TypingsLocation             string
 	ProjectName                 string
 	JSDocParsingMode            ast.JSDocParsingMode
+	// Incremental enables incremental compilation when the compiler options ask for it: the program
+	// reuses the state saved to .tsbuildinfo by the previous compilation to only check and emit the
+	// files affected by changes since, and saves its own state when emitting.
+	Incremental bool
+	// Tracing records the events of the compilation for --generateTrace, when non-nil.
+	Tracing *tracing.Tracing
 }
 
 func (p *ProgramOptions) canUseProjectReferenceSource() bool {
@@ -52,6 +59,8 @@ type Program struct {
 	commonSourceDirectoryOnce sync.Once
 
 	declarationDiagnosticCache collections.SyncMap[*ast.SourceFile, []*ast.Diagnostic]
+
+	incremental *incrementalState
 }
 
 // FileExists implements checker.Program.
@@ -71,7 +80,7 @@ func (p *Program) GetGlobalTypingsCacheLocation() string {
 
 // GetNearestAncestorDirectoryWithPackageJson implements checker.Program.
 func (p *Program) GetNearestAncestorDirectoryWithPackageJson(dirname string) string {
-	scoped := p.resolver.GetPackageScopeForPath(dirname)
+	scoped, _ := p.resolver.GetPackageScopeForPath(dirname)
 	if scoped != nil && scoped.Exists() {
 		return scoped.PackageDirectory
 	}
@@ -80,7 +89,7 @@ func (p *Program) GetNearestAncestorDirectoryWithPackageJson(dirname string) str
 
 // GetPackageJsonInfo implements checker.Program.
 func (p *Program) GetPackageJsonInfo(pkgJsonPath string) modulespecifiers.PackageJsonInfo {
-	scoped := p.resolver.GetPackageScopeForPath(pkgJsonPath)
+	scoped, _ := p.resolver.GetPackageScopeForPath(pkgJsonPath)
 	if scoped != nil && scoped.Exists() && scoped.PackageDirectory == tspath.GetDirectoryPath(pkgJsonPath) {
 		return scoped
 	}
@@ -173,8 +182,11 @@ func (p *Program) GetSourceFileFromReference(origin *ast.SourceFile, ref *ast.Fi
 
 func NewProgram(opts ProgramOptions) *Program {
 	p := &Program{opts: opts}
+	opts.Tracing.Main().Push(tracing.PhaseProgram, "createProgram", tracing.Args{"configFilePath": opts.Config.CompilerOptions().ConfigFilePath, "rootDir": opts.Host.GetCurrentDirectory()}, true /*separateBeginAndEnd*/)
+	defer opts.Tracing.Main().Pop()
 	p.initCheckerPool()
 	p.processedFiles = processAllProgramFiles(p.opts, p.singleThreaded())
+	p.initIncrementalState()
 	return p
 }
 
@@ -199,6 +211,7 @@ func (p *Program) UpdateProgram(changedFilePath tspath.Path) (*Program, bool) {
 	result.files[index] = newFile
 	result.filesByPath = maps.Clone(result.filesByPath)
 	result.filesByPath[newFile.Path()] = newFile
+	result.initIncrementalState()
 	return result, true
 }
 
@@ -210,6 +223,12 @@ func (p *Program) initCheckerPool() {
 	}
 }
 
+func (p *Program) initIncrementalState() {
+	if p.opts.Incremental && p.Options().IsIncremental() {
+		p.incremental = newIncrementalState(p)
+	}
+}
+
 func canReplaceFileInProgram(file1 *ast.SourceFile, file2 *ast.SourceFile) bool {
 	return file2 != nil &&
 		file1.ParseOptions() == file2.ParseOptions() &&
@@ -242,6 +261,10 @@ func equalCheckJSDirectives(d1 *ast.CheckJsDirective, d2 *ast.CheckJsDirective)
 func (p *Program) SourceFiles() []*ast.SourceFile { return p.files }
 func (p *Program) Options() *core.CompilerOptions { return p.opts.Config.CompilerOptions() }
 func (p *Program) Host() CompilerHost             { return p.opts.Host }
+func (p *Program) Tracing() *tracing.Tracing      { return p.opts.Tracing }
+func (p *Program) CommandLine() *tsoptions.ParsedCommandLine {
+	return p.opts.Config
+}
 func (p *Program) GetConfigFileParsingDiagnostics() []*ast.Diagnostic {
 	return slices.Clip(p.opts.Config.GetConfigFileParsingDiagnostics())
 }
@@ -255,20 +278,32 @@ func (p *Program) BindSourceFiles() {
 	for _, file := range p.files {
 		if !file.IsBound() {
 			wg.Queue(func() {
-				binder.BindSourceFile(file)
+				p.bindSourceFile(file)
 			})
 		}
 	}
 	wg.RunAndWait()
 }
 
+func (p *Program) bindSourceFile(file *ast.SourceFile) {
+	span := p.opts.Tracing.Begin(tracing.PhaseBind, "bindSourceFile", tracing.Args{"path": file.Path()})
+	defer span.End()
+	binder.BindSourceFile(file)
+}
+
 func (p *Program) CheckSourceFiles(ctx context.Context) {
+	if p.incremental != nil {
+		p.incremental.ensureAffectedFiles(ctx)
+	}
 	wg := core.NewWorkGroup(p.singleThreaded())
 	checkers, done := p.checkerPool.GetAllCheckers(ctx)
 	defer done()
 	for _, checker := range checkers {
 		wg.Queue(func() {
 			for file := range p.checkerPool.Files(checker) {
+				if p.incremental != nil && !p.incremental.needsCheck(ctx, file) {
+					continue
+				}
 				checker.CheckSourceFile(ctx, file)
 			}
 		})
@@ -371,6 +406,21 @@ func (p *Program) getBindDiagnosticsForFile(ctx context.Context, sourceFile *ast
 }
 
 func (p *Program) getSemanticDiagnosticsForFile(ctx context.Context, sourceFile *ast.SourceFile) []*ast.Diagnostic {
+	if p.incremental == nil {
+		return p.getSemanticDiagnosticsForFileNoCache(ctx, sourceFile)
+	}
+	p.incremental.ensureAffectedFiles(ctx)
+	if cached, ok := p.incremental.semanticDiagnostics.Load(sourceFile.Path()); ok {
+		return cached
+	}
+	diagnostics := p.getSemanticDiagnosticsForFileNoCache(ctx, sourceFile)
+	if ctx.Err() == nil {
+		diagnostics, _ = p.incremental.semanticDiagnostics.LoadOrStore(sourceFile.Path(), diagnostics)
+	}
+	return diagnostics
+}
+
+func (p *Program) getSemanticDiagnosticsForFileNoCache(ctx context.Context, sourceFile *ast.SourceFile) []*ast.Diagnostic {
 	compilerOptions := p.Options()
 	if checker.SkipTypeChecking(sourceFile, compilerOptions, p) {
 		return nil
@@ -549,7 +599,7 @@ func compactAndMergeRelatedInfos(diagnostics []*ast.Diagnostic) []*ast.Diagnosti
 func (p *Program) getDiagnosticsHelper(ctx context.Context, sourceFile *ast.SourceFile, ensureBound bool, ensureChecked bool, getDiagnostics func(context.Context, *ast.SourceFile) []*ast.Diagnostic) []*ast.Diagnostic {
 	if sourceFile != nil {
 		if ensureBound {
-			binder.BindSourceFile(sourceFile)
+			p.bindSourceFile(sourceFile)
 		}
 		return SortAndDeduplicateDiagnostics(getDiagnostics(ctx, sourceFile))
 	}
@@ -668,7 +718,10 @@ func (p *Program) CommonSourceDirectory() string {
 
 type EmitOptions struct {
 	TargetSourceFile *ast.SourceFile // Single file to emit. If `nil`, emits all files
-	forceDtsEmit     bool
+	// HasErrors records in the build info that diagnostics other than emit diagnostics were
+	// reported for the program, so that a later build does not consider it up to date.
+	HasErrors    bool
+	forceDtsEmit bool
 }
 
 type EmitResult struct {
@@ -684,8 +737,14 @@ type SourceMapEmitResult struct {
 	GeneratedFile        string
 }
 
-func (p *Program) Emit(options EmitOptions) *EmitResult {
+func (p *Program) Emit(ctx context.Context, options EmitOptions) *EmitResult {
 	// !!! performance measurement
+	args := tracing.Args{}
+	if options.TargetSourceFile != nil {
+		args["path"] = options.TargetSourceFile.Path()
+	}
+	p.opts.Tracing.Main().Push(tracing.PhaseEmit, "emit", args, true /*separateBeginAndEnd*/)
+	defer p.opts.Tracing.Main().Pop()
 	p.BindSourceFiles()
 
 	writerPool := &sync.Pool{
@@ -697,16 +756,24 @@ func (p *Program) Emit(options EmitOptions) *EmitResult {
 	var emitters []*emitter
 	sourceFiles := getSourceFilesToEmit(p, options.TargetSourceFile, options.forceDtsEmit)
 
+	if p.incremental != nil && options.TargetSourceFile == nil {
+		// Outputs of files not affected by changes since the previous compilation are up to date.
+		sourceFiles = core.Filter(sourceFiles, func(file *ast.SourceFile) bool {
+			return p.incremental.needsEmit(ctx, file)
+		})
+	}
+
 	for _, sourceFile := range sourceFiles {
 		emitter := &emitter{
 			emittedFilesList:  nil,
 			sourceMapDataList: nil,
 			writer:            nil,
 			sourceFile:        sourceFile,
+			tracing:           p.opts.Tracing,
 		}
 		emitters = append(emitters, emitter)
 		wg.Queue(func() {
-			host, done := newEmitHost(context.TODO(), p, sourceFile)
+			host, done := newEmitHost(ctx, p, sourceFile)
 			defer done()
 			emitter.host = host
 
@@ -741,10 +808,54 @@ func (p *Program) Emit(options EmitOptions) *EmitResult {
 		if emitter.sourceMapDataList != nil {
 			result.SourceMaps = append(result.SourceMaps, emitter.sourceMapDataList...)
 		}
+		if p.incremental != nil && !p.Options().NoEmit.IsTrue() {
+			p.incremental.recordEmit(emitter.sourceFile, len(emitter.emitterDiagnostics.GetDiagnostics()) > 0)
+		}
+	}
+
+	if options.TargetSourceFile == nil {
+		p.emitBuildInfo(result, options.HasErrors)
 	}
 	return result
 }
 
+func (p *Program) emitBuildInfo(result *EmitResult, hasErrors bool) {
+	paths := outputpaths.GetBuildInfoOutputPaths(p.Options(), tspath.ComparePathsOptions{
+		UseCaseSensitiveFileNames: p.UseCaseSensitiveFileNames(),
+		CurrentDirectory:          p.GetCurrentDirectory(),
+	})
+	if paths == nil {
+		return
+	}
+
+	hasErrors = hasErrors || len(result.Diagnostics) > 0
+	var buildInfo *BuildInfo
+	if p.incremental != nil {
+		var err error
+		buildInfo, err = p.incremental.getBuildInfo(hasErrors)
+		if err != nil {
+			result.EmitSkipped = true
+			result.Diagnostics = append(result.Diagnostics, ast.NewCompilerDiagnostic(diagnostics.Could_not_write_file_0_Colon_1, paths.BuildInfoPath(), err.Error()))
+			return
+		}
+	} else {
+		buildInfo = &BuildInfo{Version: core.Version(), Errors: hasErrors}
+	}
+
+	emitter := &emitter{
+		host:      &emitHost{program: p},
+		emitOnly:  emitOnlyBuildInfo,
+		paths:     paths,
+		buildInfo: buildInfo,
+		tracing:   p.opts.Tracing,
+	}
+	emitter.emit()
+	if emitter.emitSkipped {
+		result.EmitSkipped = true
+	}
+	result.Diagnostics = append(result.Diagnostics, emitter.emitterDiagnostics.GetDiagnostics()...)
+}
+
 func (p *Program) GetSourceFile(filename string) *ast.SourceFile {
 	path := tspath.ToPath(filename, p.GetCurrentDirectory(), p.UseCaseSensitiveFileNames())
 	return p.GetSourceFileByPath(path)
@@ -804,25 +915,6 @@ func (p *Program) IsSourceFileFromExternalLibrary(file *ast.SourceFile) bool {
 	return p.sourceFilesFoundSearchingNodeModules.Has(file.Path())
 }
 
-type FileIncludeKind int
-
-const (
-	FileIncludeKindRootFile FileIncludeKind = iota
-	FileIncludeKindSourceFromProjectReference
-	FileIncludeKindOutputFromProjectReference
-	FileIncludeKindImport
-	FileIncludeKindReferenceFile
-	FileIncludeKindTypeReferenceDirective
-	FileIncludeKindLibFile
-	FileIncludeKindLibReferenceDirective
-	FileIncludeKindAutomaticTypeDirectiveFile
-)
-
-type FileIncludeReason struct {
-	Kind  FileIncludeKind
-	Index int
-}
-
 // UnsupportedExtensions returns a list of all present "unsupported" extensions,
 // e.g. extensions that are not yet supported by the port.
 func (p *Program) UnsupportedExtensions() []string {
@@ -328,6 +328,10 @@ func (options *CompilerOptions) GetEmitDeclarations() bool {
 	return options.Declaration.IsTrue() || options.Composite.IsTrue()
 }
 
+func (options *CompilerOptions) IsIncremental() bool {
+	return options.Incremental.IsTrue() || options.Composite.IsTrue()
+}
+
 func (options *CompilerOptions) GetAreDeclarationMapsEnabled() bool {
 	return options.DeclarationMap == TSTrue && options.GetEmitDeclarations()
 }
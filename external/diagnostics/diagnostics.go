@@ -38,6 +38,9 @@ type Message struct {
 	reportsUnnecessary           bool
 	elidedInCompatibilityPyramid bool
 	reportsDeprecated            bool
+
+	// arguments already substituted into text by FormatMessage, reapplied to translations
+	args []any
 }
 
 func (m *Message) Code() int32                        { return m.code }
@@ -56,8 +59,29 @@ func (m *Message) Format(args ...any) string {
 	return text
 }
 
+// Localize returns the text of the message in the given locale, falling back to English when the
+// locale does not translate it.
+func (m *Message) Localize(locale *Locale, args ...any) string {
+	text, ok := locale.lookup(m.key)
+	if m.args != nil {
+		// The message comes from FormatMessage: its English text already has the arguments substituted,
+		// so they are only substituted into the translation.
+		if !ok {
+			return m.text
+		}
+		args = m.args
+	} else if !ok {
+		return m.Format(args...)
+	}
+	if len(args) != 0 {
+		text = stringutil.Format(text, args)
+	}
+	return text
+}
+
 func FormatMessage(m *Message, args ...any) *Message {
 	result := *m
 	result.text = stringutil.Format(m.text, args)
+	result.args = args
 	return &result
 }
@@ -0,0 +1,95 @@
+package diagnostics
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/go-json-experiment/json"
+	"github.com/pagpeter/typescript-go/external/tspath"
+	"github.com/pagpeter/typescript-go/external/vfs"
+)
+
+// Locale is a catalog of translated diagnostic messages. A nil *Locale is valid and
+// leaves every message in English.
+type Locale struct {
+	name     string
+	messages map[string]string
+}
+
+func (l *Locale) Name() string {
+	if l == nil {
+		return "en"
+	}
+	return l.name
+}
+
+func (l *Locale) lookup(key string) (string, bool) {
+	if l == nil {
+		return "", false
+	}
+	text, ok := l.messages[key]
+	return text, ok
+}
+
+// ParseLocale parses a catalog in the format of TypeScript's diagnosticMessages.generated.json
+// files: a JSON object mapping message keys, such as "Unterminated_string_literal_1002", to their
+// translated text.
+func ParseLocale(name string, data []byte) (*Locale, error) {
+	var messages map[string]string
+	if err := json.Unmarshal(data, &messages); err != nil {
+		return nil, err
+	}
+	return &Locale{name: name, messages: messages}, nil
+}
+
+// The locales for which TypeScript ships translated messages.
+var supportedLocaleDirectories = []string{"cs", "de", "es", "fr", "it", "ja", "ko", "pl", "pt-br", "ru", "tr", "zh-cn", "zh-tw"}
+
+var localePattern = regexp.MustCompile(`^([a-z]+)(?:[_-]([a-z]+))?$`)
+
+// LocaleError reports why a locale could not be loaded, as a message and its arguments.
+type LocaleError struct {
+	Message *Message
+	Args    []any
+}
+
+func (e *LocaleError) Error() string {
+	return e.Message.Format(e.Args...)
+}
+
+// LoadLocale loads the catalog for a locale of the form `language` or `language-territory` from
+// `<directory>/<locale>/diagnosticMessages.generated.json`, first trying the full locale and then
+// the language alone. It returns a nil *Locale, meaning English, when there is no catalog for the
+// locale, and a *LocaleError when the locale is malformed or its catalog cannot be read.
+func LoadLocale(fs vfs.FS, directory string, locale string) (*Locale, error) {
+	lowerCaseLocale := strings.ToLower(locale)
+	match := localePattern.FindStringSubmatch(lowerCaseLocale)
+	if match == nil {
+		return nil, &LocaleError{Message: Locale_must_be_of_the_form_language_or_language_territory_For_example_0_or_1, Args: []any{"en", "ja-jp"}}
+	}
+	language, territory := match[1], match[2]
+	candidates := []string{language}
+	if territory != "" {
+		candidates = []string{language + "-" + territory, language}
+	}
+	for _, candidate := range candidates {
+		if !slices.Contains(supportedLocaleDirectories, candidate) {
+			continue
+		}
+		fileName := tspath.CombinePaths(directory, candidate, "diagnosticMessages.generated.json")
+		if !fs.FileExists(fileName) {
+			continue
+		}
+		contents, ok := fs.ReadFile(fileName)
+		if !ok {
+			return nil, &LocaleError{Message: Unable_to_open_file_0, Args: []any{fileName}}
+		}
+		result, err := ParseLocale(lowerCaseLocale, []byte(contents))
+		if err != nil {
+			return nil, &LocaleError{Message: Corrupted_locale_file_0, Args: []any{fileName}}
+		}
+		return result, nil
+	}
+	return nil, nil
+}
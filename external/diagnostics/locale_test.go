@@ -0,0 +1,82 @@
+package diagnostics_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/pagpeter/typescript-go/external/diagnostics"
+	"github.com/pagpeter/typescript-go/external/vfs/vfstest"
+	"gotest.tools/v3/assert"
+)
+
+func TestLoadLocale(t *testing.T) {
+	t.Parallel()
+
+	fs := vfstest.FromMap(map[string]string{
+		"/lib/de/diagnosticMessages.generated.json":    `{ "Cannot_find_name_0_2304": "Der Name \"{0}\" wurde nicht gefunden." }`,
+		"/lib/zh-cn/diagnosticMessages.generated.json": `{ "Version_0_6029": "版本 {0}" }`,
+		"/lib/ja/diagnosticMessages.generated.json":    `{ "Version_0_6029": `,
+	}, false /*useCaseSensitiveFileNames*/)
+
+	t.Run("language", func(t *testing.T) {
+		t.Parallel()
+		locale, err := diagnostics.LoadLocale(fs, "/lib", "de")
+		assert.NilError(t, err)
+		assert.Equal(t, locale.Name(), "de")
+		assert.Equal(t, diagnostics.Cannot_find_name_0.Localize(locale, "x"), `Der Name "x" wurde nicht gefunden.`)
+		// messages without a translation fall back to English
+		assert.Equal(t, diagnostics.Version_0.Localize(locale, "1.0"), "Version 1.0")
+	})
+
+	t.Run("languageTerritory", func(t *testing.T) {
+		t.Parallel()
+		locale, err := diagnostics.LoadLocale(fs, "/lib", "zh_CN")
+		assert.NilError(t, err)
+		assert.Equal(t, diagnostics.Version_0.Localize(locale, "1.0"), "版本 1.0")
+	})
+
+	t.Run("territoryFallsBackToLanguage", func(t *testing.T) {
+		t.Parallel()
+		locale, err := diagnostics.LoadLocale(fs, "/lib", "de-AT")
+		assert.NilError(t, err)
+		assert.Equal(t, locale.Name(), "de-at")
+		assert.Equal(t, diagnostics.Cannot_find_name_0.Localize(locale, "x"), `Der Name "x" wurde nicht gefunden.`)
+	})
+
+	t.Run("missingCatalog", func(t *testing.T) {
+		t.Parallel()
+		locale, err := diagnostics.LoadLocale(fs, "/lib", "fr")
+		assert.NilError(t, err)
+		assert.Assert(t, locale == nil)
+		assert.Equal(t, locale.Name(), "en")
+		assert.Equal(t, diagnostics.Version_0.Localize(locale, "1.0"), "Version 1.0")
+	})
+
+	t.Run("malformedLocale", func(t *testing.T) {
+		t.Parallel()
+		_, err := diagnostics.LoadLocale(fs, "/lib", "de-at-x")
+		var localeError *diagnostics.LocaleError
+		assert.Assert(t, errors.As(err, &localeError))
+		assert.Equal(t, localeError.Message, diagnostics.Locale_must_be_of_the_form_language_or_language_territory_For_example_0_or_1)
+	})
+
+	t.Run("corruptedCatalog", func(t *testing.T) {
+		t.Parallel()
+		_, err := diagnostics.LoadLocale(fs, "/lib", "ja")
+		assert.Error(t, err, "Corrupted locale file /lib/ja/diagnosticMessages.generated.json.")
+	})
+}
+
+func TestLocalizeFormattedMessage(t *testing.T) {
+	t.Parallel()
+
+	locale, err := diagnostics.ParseLocale("de", []byte(`{ "Cannot_find_name_0_2304": "Der Name \"{0}\" wurde nicht gefunden." }`))
+	assert.NilError(t, err)
+	message := diagnostics.FormatMessage(diagnostics.Cannot_find_name_0, "x")
+	assert.Equal(t, message.Localize(nil), `Cannot find name 'x'.`)
+	assert.Equal(t, message.Localize(locale), `Der Name "x" wurde nicht gefunden.`)
+
+	// the arguments of a formatted message are substituted once, even when it is reported with others
+	message = diagnostics.FormatMessage(diagnostics.Cannot_find_name_0, `"{0}"`)
+	assert.Equal(t, message.Localize(locale, "x"), `Der Name ""{0}"" wurde nicht gefunden.`)
+}
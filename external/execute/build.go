@@ -0,0 +1,87 @@
+package execute
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pagpeter/typescript-go/external/ast"
+	"github.com/pagpeter/typescript-go/external/core"
+	"github.com/pagpeter/typescript-go/external/diagnostics"
+	"github.com/pagpeter/typescript-go/external/diagnosticwriter"
+	"github.com/pagpeter/typescript-go/external/pprof"
+	"github.com/pagpeter/typescript-go/external/tsoptions"
+)
+
+func executeBuildCommandLine(sys System, cb cbType, buildCommand *tsoptions.ParsedBuildCommandLine) ExitStatus {
+	locale, localeError := loadLocale(sys, buildCommand.CompilerOptions)
+	reportDiagnostic := createDiagnosticReporter(sys, locale, buildCommand.CompilerOptions)
+
+	if len(buildCommand.Errors) > 0 || localeError != nil {
+		for _, e := range buildCommand.Errors {
+			reportDiagnostic(e)
+		}
+		if localeError != nil {
+			reportDiagnostic(localeError)
+		}
+		return ExitStatusDiagnosticsPresent_OutputsSkipped
+	}
+
+	if pprofDir := buildCommand.CompilerOptions.PprofDir; pprofDir != "" {
+		// !!! stderr?
+		profileSession := pprof.BeginProfiling(pprofDir, sys.Writer())
+		defer profileSession.Stop()
+	}
+
+	if buildCommand.CompilerOptions.Help.IsTrue() {
+		printBuildHelp(sys, locale, tsoptions.BuildOpts)
+		return ExitStatusSuccess
+	}
+
+	if buildCommand.CompilerOptions.Watch.IsTrue() {
+		// !!! build watch mode
+		fmt.Fprint(sys.Writer(), "Build mode with --watch is currently unsupported."+sys.NewLine())
+		sys.EndWrite()
+		return ExitStatusNotImplementedWatch
+	}
+
+	builder := newSolutionBuilder(sys, cb, locale, buildCommand, reportDiagnostic)
+	if buildCommand.BuildOptions.Clean.IsTrue() {
+		return builder.clean()
+	}
+	return builder.build()
+}
+
+// bufferedSystem captures everything written by a single project build so that the output
+// of projects built in parallel can be flushed in build order.
+type bufferedSystem struct {
+	System
+	writer strings.Builder
+}
+
+var _ System = (*bufferedSystem)(nil)
+
+func (s *bufferedSystem) Writer() io.Writer {
+	return &s.writer
+}
+
+func (s *bufferedSystem) EndWrite() {}
+
+func (s *bufferedSystem) flushTo(sys System) {
+	if s.writer.Len() == 0 {
+		return
+	}
+	fmt.Fprint(sys.Writer(), s.writer.String())
+	sys.EndWrite()
+	s.writer.Reset()
+}
+
+func createBuilderStatusReporter(sys System, locale *diagnostics.Locale, options *core.CompilerOptions) diagnosticReporter {
+	if options.Quiet.IsTrue() {
+		return func(diagnostic *ast.Diagnostic) {}
+	}
+	return func(diagnostic *ast.Diagnostic) {
+		fmt.Fprint(sys.Writer(), diagnosticwriter.FlattenDiagnosticMessage(diagnostic, sys.NewLine(), locale), sys.NewLine(), sys.NewLine())
+		sys.EndWrite()
+	}
+}
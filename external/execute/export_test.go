@@ -11,6 +11,11 @@ func CommandLineTest(sys System, cb cbType, commandLineArgs []string) (*tsoption
 	return parsedCommandLine, e
 }
 
+func CommandLineTestBuild(sys System, cb cbType, commandLineArgs []string) (*tsoptions.ParsedBuildCommandLine, ExitStatus) {
+	buildCommand := tsoptions.ParseBuildCommandLine(commandLineArgs[1:], sys)
+	return buildCommand, executeBuildCommandLine(sys, cb, buildCommand)
+}
+
 func CommandLineTestWatch(sys System, cb cbType, commandLineArgs []string) (*tsoptions.ParsedCommandLine, *watcher) {
 	parsedCommandLine := tsoptions.ParseCommandLine(commandLineArgs, sys)
 	_, w := executeCommandLineWorker(sys, cb, parsedCommandLine)
@@ -30,8 +35,9 @@ func RunWatchCycle(w *watcher) {
 	}
 	// todo: updateProgram()
 	w.program = compiler.NewProgram(compiler.ProgramOptions{
-		Config: w.options,
-		Host:   w.host,
+		Config:      w.options,
+		Host:        w.host,
+		Incremental: true,
 	})
 	if w.hasBeenModified(w.program) {
 		w.compileAndEmit()
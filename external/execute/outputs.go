@@ -1,6 +1,7 @@
 package execute
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"runtime"
@@ -19,9 +20,10 @@ import (
 	"github.com/pagpeter/typescript-go/external/tspath"
 )
 
-func getFormatOptsOfSys(sys System) *diagnosticwriter.FormattingOptions {
+func getFormatOptsOfSys(sys System, locale *diagnostics.Locale) *diagnosticwriter.FormattingOptions {
 	return &diagnosticwriter.FormattingOptions{
 		NewLine: "\n",
+		Locale:  locale,
 		ComparePathsOptions: tspath.ComparePathsOptions{
 			CurrentDirectory:          sys.GetCurrentDirectory(),
 			UseCaseSensitiveFileNames: sys.FS().UseCaseSensitiveFileNames(),
@@ -29,14 +31,32 @@ func getFormatOptsOfSys(sys System) *diagnosticwriter.FormattingOptions {
 	}
 }
 
+// loadLocale loads the message catalog named by --locale from beside the default library files.
+// Without a catalog for the locale, messages are left in English; an invalid locale is returned
+// as a diagnostic.
+func loadLocale(sys System, options *core.CompilerOptions) (*diagnostics.Locale, *ast.Diagnostic) {
+	if options.Locale == "" {
+		return nil, nil
+	}
+	locale, err := diagnostics.LoadLocale(sys.FS(), sys.DefaultLibraryPath(), options.Locale)
+	if err != nil {
+		var localeError *diagnostics.LocaleError
+		if errors.As(err, &localeError) {
+			return nil, ast.NewCompilerDiagnostic(localeError.Message, localeError.Args...)
+		}
+		return nil, ast.NewCompilerDiagnostic(diagnostics.Corrupted_locale_file_0, options.Locale)
+	}
+	return locale, nil
+}
+
 type diagnosticReporter = func(*ast.Diagnostic)
 
-func createDiagnosticReporter(sys System, options *core.CompilerOptions) diagnosticReporter {
+func createDiagnosticReporter(sys System, locale *diagnostics.Locale, options *core.CompilerOptions) diagnosticReporter {
 	if options.Quiet.IsTrue() {
 		return func(diagnostic *ast.Diagnostic) {}
 	}
 
-	formatOpts := getFormatOptsOfSys(sys)
+	formatOpts := getFormatOptsOfSys(sys, locale)
 	if !shouldBePretty(sys, options) {
 		return func(diagnostic *ast.Diagnostic) {
 			diagnosticwriter.WriteFormatDiagnostic(sys.Writer(), diagnostic, formatOpts)
@@ -57,9 +77,9 @@ func shouldBePretty(sys System, options *core.CompilerOptions) bool {
 	return options.Pretty.IsTrue()
 }
 
-func createReportErrorSummary(sys System, options *core.CompilerOptions) func(diagnostics []*ast.Diagnostic) {
+func createReportErrorSummary(sys System, locale *diagnostics.Locale, options *core.CompilerOptions) func(diagnostics []*ast.Diagnostic) {
 	if shouldBePretty(sys, options) {
-		formatOpts := getFormatOptsOfSys(sys)
+		formatOpts := getFormatOptsOfSys(sys, locale)
 		return func(diagnostics []*ast.Diagnostic) {
 			diagnosticwriter.WriteErrorSummaryText(sys.Writer(), diagnostics, formatOpts)
 			sys.EndWrite()
@@ -97,14 +117,14 @@ func reportStatistics(sys System, program *compiler.Program, result compileAndEm
 	stats.print(sys.Writer())
 }
 
-func printVersion(sys System) {
-	fmt.Fprint(sys.Writer(), diagnostics.Version_0.Format(core.Version())+sys.NewLine())
+func printVersion(sys System, locale *diagnostics.Locale) {
+	fmt.Fprint(sys.Writer(), diagnostics.Version_0.Localize(locale, core.Version())+sys.NewLine())
 	sys.EndWrite()
 }
 
-func printHelp(sys System, commandLine *tsoptions.ParsedCommandLine) {
+func printHelp(sys System, locale *diagnostics.Locale, commandLine *tsoptions.ParsedCommandLine) {
 	if commandLine.CompilerOptions().All.IsFalseOrUnknown() {
-		printEasyHelp(sys, getOptionsForHelp(commandLine))
+		printEasyHelp(sys, locale, getOptionsForHelp(commandLine))
 	} else {
 		// !!! printAllHelp(sys, getOptionsForHelp(commandLine))
 	}
@@ -149,7 +169,7 @@ func getHeader(sys System, message string) []string {
 	return header
 }
 
-func printEasyHelp(sys System, simpleOptions []*tsoptions.CommandLineOption) {
+func printEasyHelp(sys System, locale *diagnostics.Locale, simpleOptions []*tsoptions.CommandLineOption) {
 	// !!! const colors = createColors(sys);
 	var output []string
 	example := func(examples []string, desc *diagnostics.Message) {
@@ -158,13 +178,13 @@ func printEasyHelp(sys System, simpleOptions []*tsoptions.CommandLineOption) {
 			// output.push("  " + colors.blue(example) + sys.newLine);
 			output = append(output, "  ", example, sys.NewLine())
 		}
-		output = append(output, "  ", desc.Format(), sys.NewLine(), sys.NewLine())
+		output = append(output, "  ", desc.Localize(locale), sys.NewLine(), sys.NewLine())
 	}
 
-	msg := diagnostics.X_tsc_Colon_The_TypeScript_Compiler.Format() + " - " + diagnostics.Version_0.Format(core.Version())
+	msg := diagnostics.X_tsc_Colon_The_TypeScript_Compiler.Localize(locale) + " - " + diagnostics.Version_0.Localize(locale, core.Version())
 	output = append(output, getHeader(sys, msg)...)
 
-	output = append(output /*colors.bold(*/, diagnostics.COMMON_COMMANDS.Format() /*)*/, sys.NewLine(), sys.NewLine())
+	output = append(output /*colors.bold(*/, diagnostics.COMMON_COMMANDS.Localize(locale) /*)*/, sys.NewLine(), sys.NewLine())
 
 	example([]string{"tsc"}, diagnostics.Compiles_the_current_project_tsconfig_json_in_the_working_directory)
 	example([]string{"tsc app.ts util.ts"}, diagnostics.Ignoring_tsconfig_json_compiles_the_specified_files_with_default_compiler_options)
@@ -184,11 +204,10 @@ func printEasyHelp(sys System, simpleOptions []*tsoptions.CommandLineOption) {
 		}
 	}
 
-	output = append(output, generateSectionOptionsOutput(sys, diagnostics.COMMAND_LINE_FLAGS.Format(), cliCommands /*subCategory*/, false /*beforeOptionsDescription*/, nil /*afterOptionsDescription*/, nil)...)
+	output = append(output, generateSectionOptionsOutput(sys, locale, diagnostics.COMMAND_LINE_FLAGS.Localize(locale), cliCommands /*subCategory*/, false /*beforeOptionsDescription*/, nil /*afterOptionsDescription*/, nil)...)
 
-	after := diagnostics.You_can_learn_about_all_of_the_compiler_options_at_0.Format("https://aka.ms/tsc")
-	output = append(output, generateSectionOptionsOutput(sys, diagnostics.COMMON_COMPILER_OPTIONS.Format(), configOpts /*subCategory*/, false /*beforeOptionsDescription*/, nil,
-		// !!! locale formatMessage(Diagnostics.You_can_learn_about_all_of_the_compiler_options_at_0, "https://aka.ms/tsc")),
+	after := diagnostics.You_can_learn_about_all_of_the_compiler_options_at_0.Localize(locale, "https://aka.ms/tsc")
+	output = append(output, generateSectionOptionsOutput(sys, locale, diagnostics.COMMON_COMPILER_OPTIONS.Localize(locale), configOpts /*subCategory*/, false /*beforeOptionsDescription*/, nil,
 		&after)...)
 
 	for _, chunk := range output {
@@ -197,8 +216,26 @@ func printEasyHelp(sys System, simpleOptions []*tsoptions.CommandLineOption) {
 	sys.EndWrite()
 }
 
+func printBuildHelp(sys System, locale *diagnostics.Locale, buildOptions []*tsoptions.CommandLineOption) {
+	var output []string
+	msg := diagnostics.X_tsc_Colon_The_TypeScript_Compiler.Localize(locale) + " - " + diagnostics.Version_0.Localize(locale, core.Version())
+	output = append(output, getHeader(sys, msg)...)
+
+	before := diagnostics.Using_build_b_will_make_tsc_behave_more_like_a_build_orchestrator_than_a_compiler_This_is_used_to_trigger_building_composite_projects_which_you_can_learn_more_about_at_0.Localize(locale, "https://aka.ms/tsc-composite-builds")
+	options := core.Filter(buildOptions, func(opt *tsoptions.CommandLineOption) bool {
+		return opt != &tsoptions.TscBuildOption
+	})
+	output = append(output, generateSectionOptionsOutput(sys, locale, diagnostics.BUILD_OPTIONS.Localize(locale), options /*subCategory*/, false, &before, nil)...)
+
+	for _, chunk := range output {
+		fmt.Fprint(sys.Writer(), chunk)
+	}
+	sys.EndWrite()
+}
+
 func generateSectionOptionsOutput(
 	sys System,
+	locale *diagnostics.Locale,
 	sectionName string,
 	options []*tsoptions.CommandLineOption,
 	subCategory bool,
@@ -212,7 +249,7 @@ func generateSectionOptionsOutput(
 		output = append(output, *beforeOptionsDescription, sys.NewLine(), sys.NewLine())
 	}
 	if !subCategory {
-		output = append(output, generateGroupOptionOutput(sys, options)...)
+		output = append(output, generateGroupOptionOutput(sys, locale, options)...)
 		if afterOptionsDescription != nil {
 			output = append(output, *afterOptionsDescription, sys.NewLine(), sys.NewLine())
 		}
@@ -223,12 +260,12 @@ func generateSectionOptionsOutput(
 		if option.Category == nil {
 			continue
 		}
-		curCategory := option.Category.Format()
+		curCategory := option.Category.Localize(locale)
 		categoryMap[curCategory] = append(categoryMap[curCategory], option)
 	}
 	for key, value := range categoryMap {
 		output = append(output, "### ", key, sys.NewLine(), sys.NewLine())
-		output = append(output, generateGroupOptionOutput(sys, value)...)
+		output = append(output, generateGroupOptionOutput(sys, locale, value)...)
 	}
 	if afterOptionsDescription != nil {
 		output = append(output, *afterOptionsDescription, sys.NewLine(), sys.NewLine())
@@ -237,7 +274,7 @@ func generateSectionOptionsOutput(
 	return output
 }
 
-func generateGroupOptionOutput(sys System, optionsList []*tsoptions.CommandLineOption) []string {
+func generateGroupOptionOutput(sys System, locale *diagnostics.Locale, optionsList []*tsoptions.CommandLineOption) []string {
 	var maxLength int
 	for _, option := range optionsList {
 		curLenght := len(getDisplayNameTextOfOption(option))
@@ -253,7 +290,7 @@ func generateGroupOptionOutput(sys System, optionsList []*tsoptions.CommandLineO
 
 	var lines []string
 	for _, option := range optionsList {
-		tmp := generateOptionOutput(sys, option, rightAlignOfLeftPart, leftAlignOfRightPart)
+		tmp := generateOptionOutput(sys, locale, option, rightAlignOfLeftPart, leftAlignOfRightPart)
 		lines = append(lines, tmp...)
 	}
 
@@ -267,6 +304,7 @@ func generateGroupOptionOutput(sys System, optionsList []*tsoptions.CommandLineO
 
 func generateOptionOutput(
 	sys System,
+	locale *diagnostics.Locale,
 	option *tsoptions.CommandLineOption,
 	rightAlignOfLeftPart, leftAlignOfRightPart int,
 ) []string {
@@ -277,11 +315,11 @@ func generateOptionOutput(
 	name := getDisplayNameTextOfOption(option)
 
 	// value type and possible value
-	valueCandidates := getValueCandidate(option)
+	valueCandidates := getValueCandidate(locale, option)
 
 	var defaultValueDescription string
 	if msg, ok := option.DefaultValueDescription.(*diagnostics.Message); ok && msg != nil {
-		defaultValueDescription = msg.Format()
+		defaultValueDescription = msg.Localize(locale)
 	} else {
 		defaultValueDescription = formatDefaultValue(
 			option.DefaultValueDescription,
@@ -314,7 +352,7 @@ func generateOptionOutput(
 	} else {
 		text = append(text /* !!! colors.blue(name) */, name, sys.NewLine())
 		if option.Description != nil {
-			text = append(text, option.Description.Format())
+			text = append(text, option.Description.Localize(locale))
 		}
 		text = append(text, sys.NewLine())
 		if showAdditionalInfoOutput(valueCandidates, option) {
@@ -325,7 +363,7 @@ func generateOptionOutput(
 				if valueCandidates != nil {
 					text = append(text, sys.NewLine())
 				}
-				text = append(text, diagnostics.X_default_Colon.Format(), " ", defaultValueDescription)
+				text = append(text, diagnostics.X_default_Colon.Localize(locale), " ", defaultValueDescription)
 			}
 
 			text = append(text, sys.NewLine())
@@ -373,7 +411,7 @@ func showAdditionalInfoOutput(valueCandidates *valueCandidate, option *tsoptions
 	return true
 }
 
-func getValueCandidate(option *tsoptions.CommandLineOption) *valueCandidate {
+func getValueCandidate(locale *diagnostics.Locale, option *tsoptions.CommandLineOption) *valueCandidate {
 	// option.type might be "string" | "number" | "boolean" | "object" | "list" | Map<string, number | string>
 	// string -- any of: string
 	// number -- any of: number
@@ -395,11 +433,11 @@ func getValueCandidate(option *tsoptions.CommandLineOption) *valueCandidate {
 	case tsoptions.CommandLineOptionTypeString,
 		tsoptions.CommandLineOptionTypeNumber,
 		tsoptions.CommandLineOptionTypeBoolean:
-		res.valueType = diagnostics.X_type_Colon.Format()
+		res.valueType = diagnostics.X_type_Colon.Localize(locale)
 	case tsoptions.CommandLineOptionTypeList:
-		res.valueType = diagnostics.X_one_or_more_Colon.Format()
+		res.valueType = diagnostics.X_one_or_more_Colon.Localize(locale)
 	default:
-		res.valueType = diagnostics.X_one_of_Colon.Format()
+		res.valueType = diagnostics.X_one_of_Colon.Localize(locale)
 	}
 
 	res.possibleValues = getPossibleValues(option)
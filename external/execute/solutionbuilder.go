@@ -0,0 +1,479 @@
+package execute
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pagpeter/typescript-go/external/ast"
+	"github.com/pagpeter/typescript-go/external/collections"
+	"github.com/pagpeter/typescript-go/external/compiler"
+	"github.com/pagpeter/typescript-go/external/core"
+	"github.com/pagpeter/typescript-go/external/diagnostics"
+	"github.com/pagpeter/typescript-go/external/outputpaths"
+	"github.com/pagpeter/typescript-go/external/tracing"
+	"github.com/pagpeter/typescript-go/external/tsoptions"
+	"github.com/pagpeter/typescript-go/external/tspath"
+)
+
+type buildProject struct {
+	configFileName string
+	path           tspath.Path
+	config         *tsoptions.ParsedCommandLine
+	configErrors   []*ast.Diagnostic
+	upstream       []*buildProject
+
+	// Output of the project is buffered and flushed in build order, so that projects
+	// built in parallel still produce deterministic output.
+	sys  *bufferedSystem
+	done chan struct{}
+
+	status      *upToDateStatus
+	diagnostics []*ast.Diagnostic
+	built       bool
+}
+
+type solutionBuilder struct {
+	sys                 System
+	cb                  cbType
+	locale              *diagnostics.Locale
+	opts                *tsoptions.ParsedBuildCommandLine
+	reportDiagnostic    diagnosticReporter
+	comparePathsOptions tspath.ComparePathsOptions
+	extendedConfigCache collections.SyncMap[tspath.Path, *tsoptions.ExtendedConfigCacheEntry]
+	traceLegend         tracing.Legend
+
+	projects          map[tspath.Path]*buildProject
+	buildOrder        []*buildProject
+	circularityErrors []*ast.Diagnostic
+}
+
+func newSolutionBuilder(sys System, cb cbType, locale *diagnostics.Locale, opts *tsoptions.ParsedBuildCommandLine, reportDiagnostic diagnosticReporter) *solutionBuilder {
+	return &solutionBuilder{
+		sys:              sys,
+		cb:               cb,
+		locale:           locale,
+		opts:             opts,
+		reportDiagnostic: reportDiagnostic,
+		comparePathsOptions: tspath.ComparePathsOptions{
+			CurrentDirectory:          sys.GetCurrentDirectory(),
+			UseCaseSensitiveFileNames: sys.FS().UseCaseSensitiveFileNames(),
+		},
+		projects: map[tspath.Path]*buildProject{},
+	}
+}
+
+func (b *solutionBuilder) relName(fileName string) string {
+	return tspath.ConvertToRelativePath(fileName, b.comparePathsOptions)
+}
+
+func (b *solutionBuilder) resolveProjectName(name string) string {
+	fileName := tspath.GetNormalizedAbsolutePath(name, b.sys.GetCurrentDirectory())
+	return core.ResolveProjectReferencePath(&core.ProjectReference{Path: fileName})
+}
+
+func (b *solutionBuilder) getProject(configFileName string) *buildProject {
+	path := tspath.ToPath(configFileName, b.sys.GetCurrentDirectory(), b.sys.FS().UseCaseSensitiveFileNames())
+	if project, ok := b.projects[path]; ok {
+		return project
+	}
+	project := &buildProject{
+		configFileName: configFileName,
+		path:           path,
+		sys:            &bufferedSystem{System: b.sys},
+		done:           make(chan struct{}),
+	}
+	compilerOptions := b.opts.CompilerOptions.Clone()
+	compilerOptions.TscBuild = core.TSTrue
+	project.config, project.configErrors = tsoptions.GetParsedCommandLineOfConfigFilePath(configFileName, path, compilerOptions, b.sys, &b.extendedConfigCache)
+	b.projects[path] = project
+	return project
+}
+
+func (b *solutionBuilder) createBuildOrder() {
+	temporaryMarks := map[tspath.Path]bool{}
+	permanentMarks := map[tspath.Path]bool{}
+	var circularityStack []string
+
+	var visit func(configFileName string, inCircularContext bool) *buildProject
+	visit = func(configFileName string, inCircularContext bool) *buildProject {
+		project := b.getProject(configFileName)
+		if permanentMarks[project.path] {
+			return project
+		}
+		if temporaryMarks[project.path] {
+			if !inCircularContext {
+				b.circularityErrors = append(b.circularityErrors, ast.NewCompilerDiagnostic(
+					diagnostics.Project_references_may_not_form_a_circular_graph_Cycle_detected_Colon_0,
+					strings.Join(circularityStack, b.sys.NewLine()),
+				))
+			}
+			return project
+		}
+
+		temporaryMarks[project.path] = true
+		circularityStack = append(circularityStack, configFileName)
+		if project.config != nil {
+			for i, ref := range project.config.ProjectReferences() {
+				upstream := visit(project.config.ResolvedProjectReferencePaths()[i], inCircularContext || ref.Circular)
+				// References that are part of a cycle are not yet built; waiting on them would never finish.
+				if permanentMarks[upstream.path] {
+					project.upstream = append(project.upstream, upstream)
+				}
+			}
+		}
+		circularityStack = circularityStack[:len(circularityStack)-1]
+		permanentMarks[project.path] = true
+		b.buildOrder = append(b.buildOrder, project)
+		return project
+	}
+
+	for _, name := range b.opts.Projects {
+		visit(b.resolveProjectName(name), false /*inCircularContext*/)
+	}
+}
+
+func (b *solutionBuilder) reportCircularityErrors() bool {
+	for _, err := range b.circularityErrors {
+		b.reportDiagnostic(err)
+	}
+	return len(b.circularityErrors) > 0
+}
+
+func (b *solutionBuilder) build() ExitStatus {
+	b.createBuildOrder()
+	if b.reportCircularityErrors() {
+		return ExitStatusProjectReferenceCycle_OutputsSkipped
+	}
+
+	if b.opts.BuildOptions.Verbose.IsTrue() {
+		var projectList strings.Builder
+		for _, project := range b.buildOrder {
+			projectList.WriteString(b.sys.NewLine())
+			projectList.WriteString("    * ")
+			projectList.WriteString(b.relName(project.configFileName))
+		}
+		createBuilderStatusReporter(b.sys, b.locale, b.opts.CompilerOptions)(ast.NewCompilerDiagnostic(diagnostics.Projects_in_this_build_Colon_0, projectList.String()))
+	}
+
+	if b.opts.CompilerOptions.SingleThreaded.IsTrue() {
+		// The build order is topologically sorted, so every upstream project is done before it is needed.
+		for _, project := range b.buildOrder {
+			b.buildProject(project)
+			project.sys.flushTo(b.sys)
+		}
+	} else {
+		wg := core.NewWorkGroup(false /*singleThreaded*/)
+		for _, project := range b.buildOrder {
+			wg.Queue(func() {
+				b.buildProject(project)
+			})
+		}
+		// The queued builds may not start until RunAndWait is called, so the output of each project
+		// is flushed in build order while it runs.
+		built := make(chan struct{})
+		go func() {
+			defer close(built)
+			wg.RunAndWait()
+		}()
+		for _, project := range b.buildOrder {
+			<-project.done
+			project.sys.flushTo(b.sys)
+		}
+		<-built
+	}
+
+	var allDiagnostics []*ast.Diagnostic
+	successfulProjects := 0
+	projectsWithErrors := 0
+	for _, project := range b.buildOrder {
+		allDiagnostics = append(allDiagnostics, project.configErrors...)
+		allDiagnostics = append(allDiagnostics, project.diagnostics...)
+		if len(project.configErrors) > 0 || len(project.diagnostics) > 0 {
+			projectsWithErrors++
+		} else if project.built || project.status.isUpToDate() {
+			successfulProjects++
+		}
+	}
+	createReportErrorSummary(b.sys, b.locale, b.opts.CompilerOptions)(allDiagnostics)
+
+	switch {
+	case projectsWithErrors == 0:
+		return ExitStatusSuccess
+	case successfulProjects > 0:
+		return ExitStatusDiagnosticsPresent_OutputsGenerated
+	default:
+		return ExitStatusDiagnosticsPresent_OutputsSkipped
+	}
+}
+
+func (b *solutionBuilder) buildProject(project *buildProject) {
+	defer close(project.done)
+	for _, upstream := range project.upstream {
+		<-upstream.done
+	}
+
+	reportStatus := createBuilderStatusReporter(project.sys, b.locale, b.opts.CompilerOptions)
+	verbose := b.opts.BuildOptions.Verbose.IsTrue()
+	relName := b.relName(project.configFileName)
+
+	project.status = b.getUpToDateStatus(project)
+	if verbose {
+		if d := project.status.diagnostic(b.relName, project.configFileName); d != nil {
+			reportStatus(d)
+		}
+	}
+
+	if project.config == nil {
+		reportDiagnostic := createDiagnosticReporter(project.sys, b.locale, b.opts.CompilerOptions)
+		for _, err := range project.configErrors {
+			reportDiagnostic(err)
+		}
+		return
+	}
+
+	switch project.status.kind {
+	case upToDateStatusTypeUpstreamBlocked:
+		if verbose {
+			reportStatus(ast.NewCompilerDiagnostic(diagnostics.Skipping_build_of_project_0_because_its_dependency_1_was_not_built, relName, b.relName(project.status.upstreamProjectName)))
+		}
+		return
+	case upToDateStatusTypeUpstreamErrors:
+		if verbose {
+			reportStatus(ast.NewCompilerDiagnostic(diagnostics.Skipping_build_of_project_0_because_its_dependency_1_has_errors, relName, b.relName(project.status.upstreamProjectName)))
+		}
+		return
+	case upToDateStatusTypeUpToDate:
+		if b.opts.BuildOptions.Dry.IsTrue() {
+			reportStatus(ast.NewCompilerDiagnostic(diagnostics.Project_0_is_up_to_date, relName))
+		}
+		return
+	case upToDateStatusTypeContainerOnly:
+		return
+	}
+
+	if b.opts.BuildOptions.Dry.IsTrue() {
+		reportStatus(ast.NewCompilerDiagnostic(diagnostics.A_non_dry_build_would_build_project_0, relName))
+		return
+	}
+
+	if verbose {
+		reportStatus(ast.NewCompilerDiagnostic(diagnostics.Building_project_0, relName))
+	}
+
+	performCompilation(
+		project.sys,
+		b.locale,
+		b.cb,
+		project.config,
+		createDiagnosticReporter(project.sys, b.locale, b.opts.CompilerOptions),
+		func(diagnostics []*ast.Diagnostic) {
+			// The error summary is reported once for the whole build.
+			project.diagnostics = diagnostics
+		},
+		&b.extendedConfigCache,
+		0, /*configTime*/
+		&b.traceLegend,
+	)
+	project.built = true
+}
+
+func (b *solutionBuilder) getUpToDateStatus(project *buildProject) *upToDateStatus {
+	config := project.config
+	if config == nil {
+		return &upToDateStatus{kind: upToDateStatusTypeUnbuildable}
+	}
+
+	if len(config.FileNames()) == 0 && len(config.ProjectReferences()) > 0 {
+		return &upToDateStatus{kind: upToDateStatusTypeContainerOnly}
+	}
+
+	for _, upstream := range project.upstream {
+		switch {
+		case upstream.status.isBlocked():
+			return &upToDateStatus{kind: upToDateStatusTypeUpstreamBlocked, upstreamProjectName: upstream.configFileName}
+		case len(upstream.diagnostics) > 0 && b.opts.BuildOptions.StopBuildOnErrors.IsTrue():
+			return &upToDateStatus{kind: upToDateStatusTypeUpstreamErrors, upstreamProjectName: upstream.configFileName}
+		case b.opts.BuildOptions.Dry.IsTrue() && upstream.status.needsBuild():
+			return &upToDateStatus{kind: upToDateStatusTypeUpstreamOutOfDate, upstreamProjectName: upstream.configFileName}
+		}
+	}
+
+	if b.opts.BuildOptions.Force.IsTrue() {
+		return &upToDateStatus{kind: upToDateStatusTypeForceBuild}
+	}
+
+	fs := b.sys.FS()
+	buildInfoFileName := outputpaths.GetBuildInfoFileName(config.CompilerOptions(), b.comparePathsOptions)
+	if buildInfoFileName != "" {
+		buildInfo := compiler.ReadBuildInfo(fs, buildInfoFileName)
+		switch {
+		case buildInfo == nil:
+			return &upToDateStatus{kind: upToDateStatusTypeOutputMissing, fileName: buildInfoFileName}
+		case buildInfo.Version != core.Version():
+			return &upToDateStatus{kind: upToDateStatusTypeTsVersionOutputOfDate, version: buildInfo.Version}
+		case buildInfo.Errors:
+			return &upToDateStatus{kind: upToDateStatusTypeOutOfDateBuildInfoWithErrors, fileName: buildInfoFileName}
+		case buildInfo.HasPendingChanges() && !config.CompilerOptions().NoEmit.IsTrue():
+			return &upToDateStatus{kind: upToDateStatusTypeOutOfDateBuildInfoWithPendingEmit, fileName: buildInfoFileName}
+		}
+	}
+
+	var newestInputFileName string
+	var newestInputFileTime time.Time
+	for _, inputFileName := range slices.Concat([]string{config.ConfigName()}, config.ExtendedSourceFiles(), config.FileNames()) {
+		stat := fs.Stat(inputFileName)
+		if stat == nil {
+			return &upToDateStatus{kind: upToDateStatusTypeErrorReadingFile, fileName: inputFileName}
+		}
+		if newestInputFileName == "" || stat.ModTime().After(newestInputFileTime) {
+			newestInputFileName = inputFileName
+			newestInputFileTime = stat.ModTime()
+		}
+	}
+
+	outputFileNames := b.getAllProjectOutputs(config)
+	if buildInfoFileName != "" && config.CompilerOptions().IsIncremental() {
+		// Incremental builds only rewrite the outputs of affected files, but always write the build info.
+		outputFileNames = []string{buildInfoFileName}
+	}
+
+	var oldestOutputFileName string
+	var oldestOutputFileTime time.Time
+	for _, outputFileName := range outputFileNames {
+		stat := fs.Stat(outputFileName)
+		if stat == nil {
+			return &upToDateStatus{kind: upToDateStatusTypeOutputMissing, fileName: outputFileName}
+		}
+		if oldestOutputFileName == "" || stat.ModTime().Before(oldestOutputFileTime) {
+			oldestOutputFileName = outputFileName
+			oldestOutputFileTime = stat.ModTime()
+		}
+	}
+
+	if newestInputFileTime.After(oldestOutputFileTime) {
+		return &upToDateStatus{
+			kind:                 upToDateStatusTypeOutOfDateWithSelf,
+			newestInputFileName:  newestInputFileName,
+			newestInputFileTime:  newestInputFileTime,
+			oldestOutputFileName: oldestOutputFileName,
+		}
+	}
+
+	for _, upstream := range project.upstream {
+		if b.getNewestDeclarationOutputTime(upstream).After(oldestOutputFileTime) {
+			return &upToDateStatus{
+				kind:                 upToDateStatusTypeOutOfDateWithUpstream,
+				oldestOutputFileName: oldestOutputFileName,
+				upstreamProjectName:  upstream.configFileName,
+			}
+		}
+	}
+
+	return &upToDateStatus{
+		kind:                 upToDateStatusTypeUpToDate,
+		newestInputFileName:  newestInputFileName,
+		newestInputFileTime:  newestInputFileTime,
+		oldestOutputFileName: oldestOutputFileName,
+	}
+}
+
+func (b *solutionBuilder) getNewestDeclarationOutputTime(project *buildProject) time.Time {
+	var newest time.Time
+	if project.config == nil {
+		return newest
+	}
+	for _, outputFileName := range b.getAllProjectOutputs(project.config) {
+		if !tspath.IsDeclarationFileName(outputFileName) {
+			continue
+		}
+		if stat := b.sys.FS().Stat(outputFileName); stat != nil && stat.ModTime().After(newest) {
+			newest = stat.ModTime()
+		}
+	}
+	return newest
+}
+
+// getAllProjectOutputs returns the names of all files that building the project would produce.
+func (b *solutionBuilder) getAllProjectOutputs(config *tsoptions.ParsedCommandLine) []string {
+	options := config.CompilerOptions()
+	var outputs []string
+	addOutput := func(inputFileName string, outputFileName string) {
+		// Outputs that would overwrite their input are never emitted.
+		if tspath.ComparePaths(inputFileName, outputFileName, b.comparePathsOptions) != 0 {
+			outputs = append(outputs, outputFileName)
+		}
+	}
+	if !options.NoEmit.IsTrue() {
+		for _, inputFileName := range config.FileNames() {
+			if tspath.IsDeclarationFileName(inputFileName) {
+				continue
+			}
+			isJsonFile := tspath.FileExtensionIs(inputFileName, tspath.ExtensionJson)
+			if !options.EmitDeclarationOnly.IsTrue() {
+				jsFileName := outputpaths.GetOutputJSFileNameWorker(inputFileName, options, config)
+				addOutput(inputFileName, jsFileName)
+				if !isJsonFile && options.SourceMap.IsTrue() && !options.InlineSourceMap.IsTrue() {
+					addOutput(inputFileName, jsFileName+".map")
+				}
+			}
+			if options.GetEmitDeclarations() && !isJsonFile {
+				declarationFileName := outputpaths.GetDeclarationEmitOutputFilePath(inputFileName, options, config)
+				addOutput(inputFileName, declarationFileName)
+				if options.GetAreDeclarationMapsEnabled() {
+					addOutput(inputFileName, declarationFileName+".map")
+				}
+			}
+		}
+	}
+	if buildInfoFileName := outputpaths.GetBuildInfoFileName(options, b.comparePathsOptions); buildInfoFileName != "" {
+		outputs = append(outputs, buildInfoFileName)
+	}
+	return outputs
+}
+
+func (b *solutionBuilder) clean() ExitStatus {
+	b.createBuildOrder()
+	if b.reportCircularityErrors() {
+		return ExitStatusProjectReferenceCycle_OutputsSkipped
+	}
+
+	fs := b.sys.FS()
+	var filesToDelete []string
+	var deleteErrors []*ast.Diagnostic
+	for _, project := range b.buildOrder {
+		if project.config == nil {
+			for _, err := range project.configErrors {
+				b.reportDiagnostic(err)
+			}
+			continue
+		}
+		for _, outputFileName := range b.getAllProjectOutputs(project.config) {
+			if !fs.FileExists(outputFileName) {
+				continue
+			}
+			if b.opts.BuildOptions.Dry.IsTrue() {
+				filesToDelete = append(filesToDelete, outputFileName)
+			} else if err := fs.Remove(outputFileName); err != nil {
+				diagnostic := ast.NewCompilerDiagnostic(diagnostics.Could_not_delete_file_0_Colon_1, outputFileName, err.Error())
+				b.reportDiagnostic(diagnostic)
+				deleteErrors = append(deleteErrors, diagnostic)
+			}
+		}
+	}
+
+	if b.opts.BuildOptions.Dry.IsTrue() {
+		var fileList strings.Builder
+		for _, fileName := range filesToDelete {
+			fileList.WriteString(b.sys.NewLine())
+			fileList.WriteString(" * ")
+			fileList.WriteString(fileName)
+		}
+		createBuilderStatusReporter(b.sys, b.locale, b.opts.CompilerOptions)(ast.NewCompilerDiagnostic(diagnostics.A_non_dry_build_would_delete_the_following_files_Colon_0, fileList.String()))
+	}
+	if len(deleteErrors) > 0 {
+		createReportErrorSummary(b.sys, b.locale, b.opts.CompilerOptions)(deleteErrors)
+		return ExitStatusDiagnosticsPresent_OutputsSkipped
+	}
+	return ExitStatusSuccess
+}
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/pagpeter/typescript-go/external/bundled"
+	"github.com/pagpeter/typescript-go/external/tspath"
 	"github.com/pagpeter/typescript-go/external/vfs"
 	"github.com/pagpeter/typescript-go/external/vfs/vfstest"
 )
@@ -163,3 +164,40 @@ func (s *testSys) printOutputs(baseline io.Writer) {
 	// todo sanitize sys output
 	fmt.Fprint(baseline, strings.Join(s.output, "\n"))
 }
+
+// removeFailingFS is a file system on which nothing can be deleted.
+type removeFailingFS struct {
+	vfs.FS
+}
+
+func (f *removeFailingFS) Remove(path string) error {
+	return &fs.PathError{Op: "remove", Path: path, Err: fs.ErrPermission}
+}
+
+// catalogFS adds a diagnostic message catalog beside the bundled libs, where the catalogs shipped
+// with the compiler are found.
+type catalogFS struct {
+	vfs.FS
+	path     string
+	contents string
+}
+
+func withLocaleCatalog(sys *testSys, locale string, contents string) *testSys {
+	sys.fs = &catalogFS{
+		FS:       sys.fs,
+		path:     tspath.CombinePaths(bundled.LibPath(), locale, "diagnosticMessages.generated.json"),
+		contents: contents,
+	}
+	return sys
+}
+
+func (f *catalogFS) FileExists(path string) bool {
+	return path == f.path || f.FS.FileExists(path)
+}
+
+func (f *catalogFS) ReadFile(path string) (string, bool) {
+	if path == f.path {
+		return f.contents, true
+	}
+	return f.FS.ReadFile(path)
+}
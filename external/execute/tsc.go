@@ -3,6 +3,7 @@ package execute
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"runtime"
 	"slices"
@@ -17,6 +18,7 @@ import (
 	"github.com/pagpeter/typescript-go/external/format"
 	"github.com/pagpeter/typescript-go/external/parser"
 	"github.com/pagpeter/typescript-go/external/pprof"
+	"github.com/pagpeter/typescript-go/external/tracing"
 	"github.com/pagpeter/typescript-go/external/tsoptions"
 	"github.com/pagpeter/typescript-go/external/tspath"
 )
@@ -42,16 +44,8 @@ func applyBulkEdits(text string, edits []core.TextChange) string {
 }
 
 func CommandLine(sys System, cb cbType, commandLineArgs []string) ExitStatus {
-	if len(commandLineArgs) > 0 {
-		// !!! build mode
-		switch strings.ToLower(commandLineArgs[0]) {
-		case "-b", "--b", "-build", "--build":
-			fmt.Fprint(sys.Writer(), "Build mode is currently unsupported."+sys.NewLine())
-			sys.EndWrite()
-			return ExitStatusNotImplemented
-			// case "-f":
-			// 	return fmtMain(sys, commandLineArgs[1], commandLineArgs[1])
-		}
+	if isBuildCommand(commandLineArgs) {
+		return executeBuildCommandLine(sys, cb, tsoptions.ParseBuildCommandLine(commandLineArgs[1:], sys))
 	}
 
 	parsedCommandLine := tsoptions.ParseCommandLine(commandLineArgs, sys)
@@ -90,13 +84,16 @@ func fmtMain(sys System, input, output string) ExitStatus {
 
 func executeCommandLineWorker(sys System, cb cbType, commandLine *tsoptions.ParsedCommandLine) (ExitStatus, *watcher) {
 	configFileName := ""
-	reportDiagnostic := createDiagnosticReporter(sys, commandLine.CompilerOptions())
-	// if commandLine.Options().Locale != nil
+	locale, localeError := loadLocale(sys, commandLine.CompilerOptions())
+	reportDiagnostic := createDiagnosticReporter(sys, locale, commandLine.CompilerOptions())
 
-	if len(commandLine.Errors) > 0 {
+	if len(commandLine.Errors) > 0 || localeError != nil {
 		for _, e := range commandLine.Errors {
 			reportDiagnostic(e)
 		}
+		if localeError != nil {
+			reportDiagnostic(localeError)
+		}
 		return ExitStatusDiagnosticsPresent_OutputsSkipped, nil
 	}
 
@@ -107,16 +104,17 @@ func executeCommandLineWorker(sys System, cb cbType, commandLine *tsoptions.Pars
 	}
 
 	if commandLine.CompilerOptions().Init.IsTrue() {
-		return ExitStatusNotImplemented, nil
+		writeConfigFile(sys, locale, reportDiagnostic, commandLine.CompilerOptions(), commandLine.FileNames())
+		return ExitStatusSuccess, nil
 	}
 
 	if commandLine.CompilerOptions().Version.IsTrue() {
-		printVersion(sys)
+		printVersion(sys, locale)
 		return ExitStatusSuccess, nil
 	}
 
 	if commandLine.CompilerOptions().Help.IsTrue() || commandLine.CompilerOptions().All.IsTrue() {
-		printHelp(sys, commandLine)
+		printHelp(sys, locale, commandLine)
 		return ExitStatusSuccess, nil
 	}
 
@@ -153,8 +151,8 @@ func executeCommandLineWorker(sys System, cb cbType, commandLine *tsoptions.Pars
 		if commandLine.CompilerOptions().ShowConfig.IsTrue() {
 			reportDiagnostic(ast.NewCompilerDiagnostic(diagnostics.Cannot_find_a_tsconfig_json_file_at_the_current_directory_Colon_0, tspath.NormalizePath(sys.GetCurrentDirectory())))
 		} else {
-			printVersion(sys)
-			printHelp(sys, commandLine)
+			printVersion(sys, locale)
+			printHelp(sys, locale, commandLine)
 		}
 		return ExitStatusDiagnosticsPresent_OutputsSkipped, nil
 	}
@@ -175,44 +173,75 @@ func executeCommandLineWorker(sys System, cb cbType, commandLine *tsoptions.Pars
 			return ExitStatusDiagnosticsPresent_OutputsGenerated, nil
 		}
 		if compilerOptionsFromCommandLine.ShowConfig.IsTrue() {
-			showConfig(sys, configParseResult.CompilerOptions())
+			if len(configParseResult.Errors) != 0 {
+				for _, e := range configParseResult.Errors {
+					reportDiagnostic(e)
+				}
+				return ExitStatusDiagnosticsPresent_OutputsSkipped, nil
+			}
+			showConfig(sys, configParseResult, configFileName)
 			return ExitStatusSuccess, nil
 		}
 		// updateReportDiagnostic
 		if isWatchSet(configParseResult.CompilerOptions()) {
-			return ExitStatusSuccess, createWatcher(sys, configParseResult, reportDiagnostic)
+			return ExitStatusSuccess, createWatcher(sys, locale, configParseResult, reportDiagnostic)
 		}
-		// !!! incremental
 		return performCompilation(
 			sys,
+			locale,
 			cb,
 			configParseResult,
 			reportDiagnostic,
+			createReportErrorSummary(sys, locale, configParseResult.CompilerOptions()),
 			&extendedConfigCache,
 			configTime,
+			nil, /*traceLegend*/
 		), nil
 	} else {
 		if compilerOptionsFromCommandLine.ShowConfig.IsTrue() {
-			showConfig(sys, compilerOptionsFromCommandLine)
+			showConfig(sys, commandLine, tspath.CombinePaths(sys.GetCurrentDirectory(), "tsconfig.json"))
 			return ExitStatusSuccess, nil
 		}
 		// todo update reportDiagnostic
 		if isWatchSet(compilerOptionsFromCommandLine) {
 			// !!! reportWatchModeWithoutSysSupport
-			return ExitStatusSuccess, createWatcher(sys, commandLine, reportDiagnostic)
+			return ExitStatusSuccess, createWatcher(sys, locale, commandLine, reportDiagnostic)
 		}
-		// !!! incremental
 	}
 	return performCompilation(
 		sys,
+		locale,
 		cb,
 		commandLine,
 		reportDiagnostic,
+		createReportErrorSummary(sys, locale, commandLine.CompilerOptions()),
 		nil,
-		0, /*configTime*/
+		0,   /*configTime*/
+		nil, /*traceLegend*/
 	), nil
 }
 
+func writeConfigFile(sys System, locale *diagnostics.Locale, reportDiagnostic diagnosticReporter, options *core.CompilerOptions, fileNames []string) {
+	currentDirectory := sys.GetCurrentDirectory()
+	file := tspath.NormalizePath(tspath.CombinePaths(currentDirectory, "tsconfig.json"))
+	if sys.FS().FileExists(file) {
+		reportDiagnostic(ast.NewCompilerDiagnostic(diagnostics.A_tsconfig_json_file_is_already_defined_at_Colon_0, file))
+		return
+	}
+	if err := sys.FS().WriteFile(file, tsoptions.GenerateTSConfig(options, fileNames, currentDirectory, sys.NewLine(), locale), false); err != nil {
+		reportDiagnostic(ast.NewCompilerDiagnostic(diagnostics.Could_not_write_file_0_Colon_1, file, err.Error()))
+		return
+	}
+	output := []string{sys.NewLine()}
+	output = append(output, getHeader(sys, "Created a new tsconfig.json with:")...)
+	output = append(output, tsoptions.GetCompilerOptionsDiffValue(options, currentDirectory, sys.NewLine())+sys.NewLine()+sys.NewLine())
+	output = append(output, "You can learn more at https://aka.ms/tsconfig"+sys.NewLine())
+	for _, chunk := range output {
+		fmt.Fprint(sys.Writer(), chunk)
+	}
+	sys.EndWrite()
+}
+
 func findConfigFile(searchPath string, fileExists func(string) bool, configName string) string {
 	result, ok := tspath.ForEachAncestorDirectory(searchPath, func(ancestor string) (string, bool) {
 		fullConfigName := tspath.CombinePaths(ancestor, configName)
@@ -229,23 +258,38 @@ func findConfigFile(searchPath string, fileExists func(string) bool, configName
 
 func performCompilation(
 	sys System,
+	locale *diagnostics.Locale,
 	cb cbType,
 	config *tsoptions.ParsedCommandLine,
 	reportDiagnostic diagnosticReporter,
+	reportErrorSummary func(diagnostics []*ast.Diagnostic),
 	extendedConfigCache *collections.SyncMap[tspath.Path, *tsoptions.ExtendedConfigCacheEntry],
 	configTime time.Duration,
+	traceLegend *tracing.Legend,
 ) ExitStatus {
-	host := compiler.NewCachedFSCompilerHost(config.CompilerOptions(), sys.GetCurrentDirectory(), sys.FS(), sys.DefaultLibraryPath(), extendedConfigCache)
-	// todo: cache, statistics, tracing
+	host := compiler.NewCachedFSCompilerHost(config.CompilerOptions(), sys.GetCurrentDirectory(), sys.FS(), sys.DefaultLibraryPath(), extendedConfigCache, getTraceFromSys(sys))
+	// todo: cache, statistics
+	var trace *tracing.Tracing
+	if traceDir := config.CompilerOptions().GenerateTrace; traceDir != "" {
+		trace = tracing.Start(sys.FS(), tspath.GetNormalizedAbsolutePath(traceDir, sys.GetCurrentDirectory()), config.CompilerOptions().ConfigFilePath, traceLegend)
+	}
 	parseStart := sys.Now()
 	program := compiler.NewProgram(compiler.ProgramOptions{
 		Config:           config,
 		Host:             host,
 		JSDocParsingMode: ast.JSDocParsingModeParseForTypeErrors,
+		Incremental:      true,
+		Tracing:          trace,
 	})
 	parseTime := sys.Now().Sub(parseStart)
 
-	result := emitFilesAndReportErrors(sys, program, reportDiagnostic)
+	result := emitFilesAndReportErrors(sys, locale, program, reportDiagnostic, reportErrorSummary)
+	if err := trace.Stop(); err != nil {
+		var writeError *tracing.WriteError
+		if errors.As(err, &writeError) {
+			reportDiagnostic(ast.NewCompilerDiagnostic(diagnostics.Could_not_write_file_0_Colon_1, writeError.Path, writeError.Err.Error()))
+		}
+	}
 	if result.status != ExitStatusSuccess {
 		// compile exited early
 		return result.status
@@ -289,7 +333,7 @@ type compileAndEmitResult struct {
 	emitTime    time.Duration
 }
 
-func emitFilesAndReportErrors(sys System, program *compiler.Program, reportDiagnostic diagnosticReporter) (result compileAndEmitResult) {
+func emitFilesAndReportErrors(sys System, locale *diagnostics.Locale, program *compiler.Program, reportDiagnostic diagnosticReporter, reportErrorSummary func(diagnostics []*ast.Diagnostic)) (result compileAndEmitResult) {
 	ctx := context.Background()
 	options := program.Options()
 	allDiagnostics := slices.Clip(program.GetConfigFileParsingDiagnostics())
@@ -325,7 +369,7 @@ func emitFilesAndReportErrors(sys System, program *compiler.Program, reportDiagn
 	emitResult := &compiler.EmitResult{EmitSkipped: true, Diagnostics: []*ast.Diagnostic{}}
 	if !options.ListFilesOnly.IsTrue() {
 		emitStart := sys.Now()
-		emitResult = program.Emit(compiler.EmitOptions{})
+		emitResult = program.Emit(ctx, compiler.EmitOptions{HasErrors: len(allDiagnostics) > 0})
 		result.emitTime = sys.Now().Sub(emitStart)
 	}
 	allDiagnostics = append(allDiagnostics, emitResult.Diagnostics...)
@@ -339,41 +383,79 @@ func emitFilesAndReportErrors(sys System, program *compiler.Program, reportDiagn
 		for _, file := range emitResult.EmittedFiles {
 			fmt.Fprint(sys.Writer(), "TSFILE: ", tspath.GetNormalizedAbsolutePath(file, sys.GetCurrentDirectory()))
 		}
-		listFiles(sys, program)
+		listFiles(sys, locale, program)
 	}
 
-	createReportErrorSummary(sys, program.Options())(allDiagnostics)
+	reportErrorSummary(allDiagnostics)
 	result.diagnostics = allDiagnostics
 	result.emitResult = emitResult
 	result.status = ExitStatusSuccess
 	return result
 }
 
-// func isBuildCommand(args []string) bool {
-// 	return len(args) > 0 && args[0] == "build"
-// }
+func isBuildCommand(args []string) bool {
+	if len(args) == 0 {
+		return false
+	}
+	switch strings.ToLower(args[0]) {
+	case "-b", "--b", "-build", "--build":
+		return true
+	}
+	return false
+}
 
 func isWatchSet(options *core.CompilerOptions) bool {
 	return options.Watch.IsTrue()
 }
 
 func isIncrementalCompilation(options *core.CompilerOptions) bool {
-	return options.Incremental.IsTrue()
+	return options.IsIncremental()
 }
 
-func showConfig(sys System, config *core.CompilerOptions) {
-	// !!!
-	enc := json.NewEncoder(sys.Writer())
+func showConfig(sys System, config *tsoptions.ParsedCommandLine, configFileName string) {
+	var b strings.Builder
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
 	enc.SetIndent("", "    ")
-	enc.Encode(config) //nolint:errcheck,errchkjson
+	enc.Encode(tsoptions.ConvertToTSConfig(config, configFileName, sys)) //nolint:errcheck,errchkjson
+	fmt.Fprint(sys.Writer(), strings.TrimSuffix(b.String(), "\n"), sys.NewLine())
+	sys.EndWrite()
 }
 
-func listFiles(sys System, program *compiler.Program) {
+func listFiles(sys System, locale *diagnostics.Locale, program *compiler.Program) {
 	options := program.Options()
-	// !!! explainFiles
-	if options.ListFiles.IsTrue() || options.ListFilesOnly.IsTrue() {
+	if options.ExplainFiles.IsTrue() {
+		explainFiles(sys, locale, program)
+	} else if options.ListFiles.IsTrue() || options.ListFilesOnly.IsTrue() {
 		for _, file := range program.GetSourceFiles() {
 			fmt.Fprintf(sys.Writer(), "%s%s", file.FileName(), sys.NewLine())
 		}
 	}
 }
+
+func explainFiles(sys System, locale *diagnostics.Locale, program *compiler.Program) {
+	comparePathsOptions := tspath.ComparePathsOptions{
+		CurrentDirectory:          sys.GetCurrentDirectory(),
+		UseCaseSensitiveFileNames: sys.FS().UseCaseSensitiveFileNames(),
+	}
+	relativeFileName := func(fileName string) string {
+		return tspath.ConvertToRelativePath(fileName, comparePathsOptions)
+	}
+	reasons := program.GetFileIncludeReasons()
+	for _, file := range program.GetSourceFiles() {
+		fmt.Fprintf(sys.Writer(), "%s%s", relativeFileName(file.FileName()), sys.NewLine())
+		for _, reason := range reasons[file.Path()] {
+			fmt.Fprintf(sys.Writer(), "  %s%s", program.FileIncludeReasonToDiagnostic(reason, relativeFileName).Localize(locale), sys.NewLine())
+		}
+		for _, diagnostic := range program.ExplainFileFormat(file, relativeFileName) {
+			fmt.Fprintf(sys.Writer(), "  %s%s", diagnostic.Localize(locale), sys.NewLine())
+		}
+	}
+}
+
+// getTraceFromSys writes --traceResolution output to the writer of sys.
+func getTraceFromSys(sys System) func(msg string) {
+	return func(msg string) {
+		fmt.Fprint(sys.Writer(), msg, sys.NewLine())
+	}
+}
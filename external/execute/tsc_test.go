@@ -115,6 +115,26 @@ func TestTsc(t *testing.T) {
 			sys:             newTestSys(nil, ""),
 			commandLineArgs: []string{"-w", "--watchInterval", "1000"},
 		},
+		{
+			subScenario:     "Parse invalid locale",
+			sys:             newTestSys(nil, ""),
+			commandLineArgs: []string{"--locale", "en-us-x", "first.ts"},
+		},
+		{
+			subScenario:     "Parse locale without translated messages",
+			sys:             newTestSys(nil, ""),
+			commandLineArgs: []string{"--locale", "fr", "--version"},
+		},
+		{
+			subScenario:     "Parse locale and report localized diagnostics",
+			sys:             withLocaleCatalog(newTestSys(FileMap{"/home/src/workspaces/project/first.ts": `const a: number = b;`}, ""), "de", germanCatalog),
+			commandLineArgs: []string{"--locale", "de-DE", "first.ts"},
+		},
+		{
+			subScenario:     "Parse locale and report localized diagnostics without pretty",
+			sys:             withLocaleCatalog(newTestSys(FileMap{"/home/src/workspaces/project/first.ts": `const a: number = b;`}, ""), "de", germanCatalog),
+			commandLineArgs: []string{"--locale", "de-DE", "--pretty", "false", "first.ts"},
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -122,6 +142,15 @@ func TestTsc(t *testing.T) {
 	}
 }
 
+// A catalog in the format of the diagnosticMessages.generated.json files shipped for each locale.
+const germanCatalog = `{
+  "Cannot_find_name_0_2304": "Der Name \"{0}\" wurde nicht gefunden.",
+  "Found_1_error_6216": "1 Fehler gefunden.",
+  "Found_1_error_in_0_6259": "1 Fehler in {0} gefunden.",
+  "Library_0_specified_in_compilerOptions_1422": "Die Bibliothek \"{0}\" wurde in \"compilerOptions\" angegeben.",
+  "Root_file_specified_for_compilation_1427": "Für die Kompilierung angegebene Stammdatei."
+}`
+
 func TestNoEmit(t *testing.T) {
 	t.Parallel()
 	if !bundled.Embedded {
@@ -222,6 +251,218 @@ func TestExtends(t *testing.T) {
 	}
 }
 
+func TestExplainFiles(t *testing.T) {
+	t.Parallel()
+	if !bundled.Embedded {
+		// Without embedding, we'd need to read all of the lib files out from disk into the MapFS.
+		// Just skip this for now.
+		t.Skip("bundled files are not embedded")
+	}
+
+	cases := []tscInput{{
+		subScenario: "root file",
+		sys: newTestSys(FileMap{
+			"/home/src/workspaces/project/main.ts": `export const x = 10;`,
+		}, ""),
+		commandLineArgs: []string{"--explainFiles", "--lib", "es5", "main.ts"},
+	}, {
+		subScenario:     "localized",
+		sys:             withLocaleCatalog(newTestSys(FileMap{"/home/src/workspaces/project/main.ts": `export const x = 10;`}, ""), "de", germanCatalog),
+		commandLineArgs: []string{"--explainFiles", "--locale", "de-DE", "--lib", "es5", "main.ts"},
+	}, {
+		subScenario: "import",
+		sys: newTestSys(FileMap{
+			"/home/src/workspaces/project/tsconfig.json": `{
+	"compilerOptions": {
+		"lib": ["es5"],
+	},
+	"files": ["main.ts"],
+}`,
+			"/home/src/workspaces/project/main.ts":   `import { x } from "./helper";`,
+			"/home/src/workspaces/project/helper.ts": `export const x = 10;`,
+			"/home/src/workspaces/project/unused.ts": `export const y = 10;`,
+		}, ""),
+		commandLineArgs: []string{"--explainFiles"},
+	}, {
+		subScenario: "type reference directive",
+		sys: newTestSys(FileMap{
+			"/home/src/workspaces/project/tsconfig.json": `{
+	"compilerOptions": {
+		"lib": ["es5"],
+		"types": [],
+	},
+	"files": ["main.ts"],
+}`,
+			"/home/src/workspaces/project/main.ts": `/// <reference types="pkg" />
+export const x = 10;`,
+			"/home/src/workspaces/project/node_modules/pkg/package.json": `{ "name": "pkg", "version": "1.0.0", "types": "index.d.ts" }`,
+			"/home/src/workspaces/project/node_modules/pkg/index.d.ts":   `declare const pkg: number;`,
+		}, ""),
+		commandLineArgs: []string{"--explainFiles"},
+	}, {
+		subScenario: "lib reference directive",
+		sys: newTestSys(FileMap{
+			"/home/src/workspaces/project/tsconfig.json": `{
+	"compilerOptions": {
+		"lib": ["es5"],
+	},
+	"files": ["main.ts"],
+}`,
+			"/home/src/workspaces/project/main.ts": `/// <reference lib="es2015.core" />
+export const x = 10;`,
+		}, ""),
+		commandLineArgs: []string{"--explainFiles"},
+	}, {
+		subScenario: "automatic type directive",
+		sys: newTestSys(FileMap{
+			"/home/src/workspaces/project/tsconfig.json": `{
+	"compilerOptions": {
+		"lib": ["es5"],
+	},
+	"include": ["src"],
+}`,
+			"/home/src/workspaces/project/src/main.ts":                        `export const x = 10;`,
+			"/home/src/workspaces/project/node_modules/@types/pkg/index.d.ts": `declare const pkg: number;`,
+		}, ""),
+		commandLineArgs: []string{"--explainFiles"},
+	}, {
+		subScenario: "types in compilerOptions",
+		sys: newTestSys(FileMap{
+			"/home/src/workspaces/project/tsconfig.json": `{
+	"compilerOptions": {
+		"lib": ["es5"],
+		"types": ["pkg"],
+	},
+}`,
+			"/home/src/workspaces/project/main.ts":                              `export const x = 10;`,
+			"/home/src/workspaces/project/node_modules/@types/pkg/package.json": `{ "name": "@types/pkg", "version": "1.0.0" }`,
+			"/home/src/workspaces/project/node_modules/@types/pkg/index.d.ts":   `declare const pkg: number;`,
+			"/home/src/workspaces/project/node_modules/@types/other/index.d.ts": `declare const other: number;`,
+		}, ""),
+		commandLineArgs: []string{"--explainFiles"},
+	}}
+
+	for _, c := range cases {
+		c.verify(t, "explainFiles")
+	}
+}
+
+func TestTraceResolution(t *testing.T) {
+	t.Parallel()
+	if !bundled.Embedded {
+		// Without embedding, we'd need to read all of the lib files out from disk into the MapFS.
+		// Just skip this for now.
+		t.Skip("bundled files are not embedded")
+	}
+
+	cases := []tscInput{{
+		subScenario: "lib replacement resolved once",
+		sys: newTestSys(FileMap{
+			"/home/src/workspaces/project/main.ts": `/// <reference lib="es5" />
+import { x } from "./helper";`,
+			"/home/src/workspaces/project/helper.ts": `/// <reference lib="es5" />
+export const x = 10;`,
+		}, ""),
+		commandLineArgs: []string{"--traceResolution", "--libReplacement", "--lib", "es5", "main.ts"},
+	}}
+
+	for _, c := range cases {
+		c.verify(t, "traceResolution")
+	}
+}
+
+func TestShowConfig(t *testing.T) {
+	t.Parallel()
+	if !bundled.Embedded {
+		// Without embedding, we'd need to read all of the lib files out from disk into the MapFS.
+		// Just skip this for now.
+		t.Skip("bundled files are not embedded")
+	}
+
+	cases := []tscInput{{
+		subScenario: "enum, lib and implied options",
+		sys: newTestSys(FileMap{
+			"/home/src/workspaces/project/tsconfig.json": `{
+	"compilerOptions": {
+		"module": "nodenext",
+		"strict": true,
+		"lib": ["es2017", "dom"],
+		"jsx": "react-jsx",
+		"newLine": "lf",
+		"composite": true,
+		"outDir": "dist",
+		"rootDirs": ["src", "generated"],
+	},
+	"references": [{ "path": "../shared" }],
+}`,
+			"/home/src/workspaces/project/src/index.ts": `export const a = 1;`,
+			"/home/src/workspaces/shared/tsconfig.json": `{ "compilerOptions": { "composite": true } }`,
+		}, ""),
+		commandLineArgs: []string{"--showConfig"},
+	}, {
+		subScenario: "include and exclude",
+		sys: newTestSys(FileMap{
+			"/home/src/workspaces/project/tsconfig.json": `{
+	"compilerOptions": { "target": "es2020" },
+	"include": ["src"],
+	"exclude": ["src/**/*.test.ts"],
+}`,
+			"/home/src/workspaces/project/src/index.ts":     `export const a = 1;`,
+			"/home/src/workspaces/project/src/util.ts":      `export const b = 1;`,
+			"/home/src/workspaces/project/src/util.test.ts": `export const c = 1;`,
+			"/home/src/workspaces/project/scripts/build.ts": `export const d = 1;`,
+		}, ""),
+		commandLineArgs: []string{"--showConfig", "--declaration"},
+	}, {
+		subScenario: "without tsconfig",
+		sys: newTestSys(FileMap{
+			"/home/src/workspaces/project/first.ts": `export const a = 1;`,
+		}, ""),
+		commandLineArgs: []string{"--showConfig", "--target", "es2015", "--moduleResolution", "bundler", "--watchInterval", "1000", "first.ts"},
+	}}
+
+	for _, c := range cases {
+		c.verify(t, "showConfig")
+	}
+}
+
+func TestInit(t *testing.T) {
+	t.Parallel()
+	if !bundled.Embedded {
+		// Without embedding, we'd need to read all of the lib files out from disk into the MapFS.
+		// Just skip this for now.
+		t.Skip("bundled files are not embedded")
+	}
+
+	cases := []tscInput{{
+		subScenario:     "default",
+		sys:             newTestSys(nil, ""),
+		commandLineArgs: []string{"--init"},
+	}, {
+		subScenario:     "with files",
+		sys:             newTestSys(nil, ""),
+		commandLineArgs: []string{"--init", "file0.st", "file1.ts", "file2.ts"},
+	}, {
+		subScenario:     "with boolean and enum options",
+		sys:             newTestSys(nil, ""),
+		commandLineArgs: []string{"--init", "--noUnusedLocals", "--target", "es5", "--jsx", "react", "--strict", "false"},
+	}, {
+		subScenario:     "with list and path options",
+		sys:             newTestSys(nil, ""),
+		commandLineArgs: []string{"--init", "--types", "jquery,mocha", "--lib", "es5,es2015.promise", "--declaration", "--declarationDir", "lib", "--noErrorTruncation"},
+	}, {
+		subScenario: "when tsconfig exists",
+		sys: newTestSys(FileMap{
+			"/home/src/workspaces/project/tsconfig.json": `{}`,
+		}, ""),
+		commandLineArgs: []string{"--init"},
+	}}
+
+	for _, c := range cases {
+		c.verify(t, "init")
+	}
+}
+
 func TestTypeAcquisition(t *testing.T) {
 	t.Parallel()
 	if !bundled.Embedded {
@@ -0,0 +1,142 @@
+package execute_test
+
+import (
+	"testing"
+
+	"github.com/pagpeter/typescript-go/external/bundled"
+	"github.com/pagpeter/typescript-go/external/execute"
+)
+
+func getSampleSolution() FileMap {
+	return FileMap{
+		"/home/src/workspaces/solution/core/index.ts": `export function add(a: number, b: number) { return a + b; }`,
+		"/home/src/workspaces/solution/core/tsconfig.json": `{
+	"compilerOptions": {
+		"composite": true,
+	},
+}`,
+		"/home/src/workspaces/solution/logic/index.ts": `import { add } from "../core/index";
+export function inc(a: number) { return add(a, 1); }`,
+		"/home/src/workspaces/solution/logic/tsconfig.json": `{
+	"compilerOptions": {
+		"composite": true,
+	},
+	"references": [
+		{ "path": "../core" },
+	],
+}`,
+		"/home/src/workspaces/solution/tests/index.ts": `import { add } from "../core/index";
+import { inc } from "../logic/index";
+export const result = inc(add(1, 2));`,
+		"/home/src/workspaces/solution/tests/tsconfig.json": `{
+	"compilerOptions": {
+		"composite": true,
+	},
+	"references": [
+		{ "path": "../core" },
+		{ "path": "../logic" },
+	],
+}`,
+		"/home/src/workspaces/solution/tsconfig.json": `{
+	"files": [],
+	"references": [
+		{ "path": "./tests" },
+	],
+}`,
+	}
+}
+
+func TestBuild(t *testing.T) {
+	t.Parallel()
+	if !bundled.Embedded {
+		// Without embedding, we'd need to read all of the lib files out from disk into the MapFS.
+		// Just skip this for now.
+		t.Skip("bundled files are not embedded")
+	}
+
+	testCases := []*tscInput{
+		{
+			subScenario:     "builds referenced projects in order",
+			sys:             newTestSys(getSampleSolution(), "/home/src/workspaces/solution"),
+			commandLineArgs: []string{"--b", "--verbose", "--singleThreaded"},
+			edits: []*testTscEdit{
+				newTscEdit("no change", nil),
+				newTscEdit("change core", func(sys execute.System) {
+					sys.FS().WriteFile("/home/src/workspaces/solution/core/index.ts", `export function add(a: number, b: number) { return a + b; }
+export function sub(a: number, b: number) { return a - b; }`, false) //nolint:errcheck
+				}),
+				{caption: "force", commandLineArgs: []string{"--b", "--verbose", "--singleThreaded", "--force"}},
+			},
+		},
+		{
+			subScenario:     "builds in parallel",
+			sys:             newTestSys(getSampleSolution(), "/home/src/workspaces/solution"),
+			commandLineArgs: []string{"--b", "tests", "--verbose"},
+		},
+		{
+			subScenario:     "dry",
+			sys:             newTestSys(getSampleSolution(), "/home/src/workspaces/solution"),
+			commandLineArgs: []string{"--b", "--dry", "--singleThreaded"},
+		},
+		{
+			subScenario:     "clean",
+			sys:             newTestSys(getSampleSolution(), "/home/src/workspaces/solution"),
+			commandLineArgs: []string{"--b", "--singleThreaded"},
+			edits: []*testTscEdit{
+				{caption: "clean dry", commandLineArgs: []string{"--b", "--clean", "--dry"}},
+				{caption: "clean", commandLineArgs: []string{"--b", "--clean"}},
+			},
+		},
+		{
+			subScenario:     "clean reports outputs that cannot be deleted",
+			sys:             newTestSys(getSampleSolution(), "/home/src/workspaces/solution"),
+			commandLineArgs: []string{"--b", "--singleThreaded"},
+			edits: []*testTscEdit{
+				{
+					caption:         "clean",
+					commandLineArgs: []string{"--b", "--clean"},
+					edit: func(sys execute.System) {
+						sys.(*testSys).fs = &removeFailingFS{FS: sys.FS()}
+					},
+				},
+			},
+		},
+		{
+			subScenario:     "clean and force cannot be combined",
+			sys:             newTestSys(getSampleSolution(), "/home/src/workspaces/solution"),
+			commandLineArgs: []string{"--b", "--clean", "--force"},
+		},
+		{
+			subScenario: "reports circular references",
+			sys: newTestSys(FileMap{
+				"/home/src/workspaces/solution/a/index.ts":      `export const a = 1;`,
+				"/home/src/workspaces/solution/a/tsconfig.json": `{ "compilerOptions": { "composite": true }, "references": [{ "path": "../b" }] }`,
+				"/home/src/workspaces/solution/b/index.ts":      `export const b = 1;`,
+				"/home/src/workspaces/solution/b/tsconfig.json": `{ "compilerOptions": { "composite": true }, "references": [{ "path": "../a" }] }`,
+			}, "/home/src/workspaces/solution"),
+			commandLineArgs: []string{"--b", "a"},
+		},
+		{
+			subScenario: "upstream errors with stopBuildOnErrors",
+			sys: newTestSys(FileMap{
+				"/home/src/workspaces/solution/a/index.ts":      `export const a: number = "hello";`,
+				"/home/src/workspaces/solution/a/tsconfig.json": `{ "compilerOptions": { "composite": true } }`,
+				"/home/src/workspaces/solution/b/index.ts":      `import { a } from "../a"; export const b = a;`,
+				"/home/src/workspaces/solution/b/tsconfig.json": `{ "compilerOptions": { "composite": true }, "references": [{ "path": "../a" }] }`,
+			}, "/home/src/workspaces/solution"),
+			commandLineArgs: []string{"--b", "b", "--verbose", "--stopBuildOnErrors", "--singleThreaded"},
+			edits: []*testTscEdit{
+				{caption: "without stopBuildOnErrors", commandLineArgs: []string{"--b", "b", "--verbose", "--singleThreaded"}},
+			},
+		},
+		{
+			subScenario:     "missing project",
+			sys:             newTestSys(nil, "/home/src/workspaces/solution"),
+			commandLineArgs: []string{"--b", "missing"},
+		},
+	}
+
+	for _, test := range testCases {
+		test.verify(t, "build")
+	}
+}
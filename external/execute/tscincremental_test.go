@@ -0,0 +1,107 @@
+package execute_test
+
+import (
+	"testing"
+
+	"github.com/pagpeter/typescript-go/external/bundled"
+	"github.com/pagpeter/typescript-go/external/core"
+	"github.com/pagpeter/typescript-go/external/execute"
+)
+
+func getIncrementalProject(compilerOptions string) FileMap {
+	return FileMap{
+		"/home/src/workspaces/project/a.ts": `export function a() { return 1; }`,
+		"/home/src/workspaces/project/b.ts": `import { a } from "./a";
+export const b = a();`,
+		"/home/src/workspaces/project/c.ts": `export const c: number = "hello";`,
+		"/home/src/workspaces/project/tsconfig.json": `{
+	"compilerOptions": {
+		` + compilerOptions + `
+	},
+}`,
+	}
+}
+
+func TestIncremental(t *testing.T) {
+	t.Parallel()
+	if !bundled.Embedded {
+		// Without embedding, we'd need to read all of the lib files out from disk into the MapFS.
+		// Just skip this for now.
+		t.Skip("bundled files are not embedded")
+	}
+
+	//nolint:errcheck
+	testCases := []*tscInput{
+		{
+			subScenario:     "reuses state from tsbuildinfo",
+			sys:             newTestSys(getIncrementalProject(`"incremental": true, "declaration": true`), "/home/src/workspaces/project"),
+			commandLineArgs: []string{},
+			edits: []*testTscEdit{
+				newTscEdit("no change", nil),
+				newTscEdit("change body of a, and tamper with the output of b", func(sys execute.System) {
+					sys.FS().WriteFile("/home/src/workspaces/project/a.ts", `export function a() { return 2; }`, false)
+					sys.FS().WriteFile("/home/src/workspaces/project/b.js", `// not re-emitted`, false)
+				}),
+				newTscEdit("change shape of a", func(sys execute.System) {
+					sys.FS().WriteFile("/home/src/workspaces/project/a.ts", `export function a() { return "2"; }`, false)
+				}),
+				newTscEdit("fix error in c", func(sys execute.System) {
+					sys.FS().WriteFile("/home/src/workspaces/project/c.ts", `export const c: number = 1;`, false)
+				}),
+			},
+		},
+		{
+			subScenario:     "without declaration emit",
+			sys:             newTestSys(getIncrementalProject(`"incremental": true`), "/home/src/workspaces/project"),
+			commandLineArgs: []string{},
+			edits: []*testTscEdit{
+				newTscEdit("change shape of a", func(sys execute.System) {
+					sys.FS().WriteFile("/home/src/workspaces/project/a.ts", `export function a() { return "2"; }`, false)
+				}),
+			},
+		},
+		{
+			subScenario:     "with noEmit",
+			sys:             newTestSys(getIncrementalProject(`"incremental": true, "noEmit": true`), "/home/src/workspaces/project"),
+			commandLineArgs: []string{},
+			edits: []*testTscEdit{
+				{caption: "emit", commandLineArgs: []string{"--noEmit", "false"}},
+			},
+		},
+		{
+			subScenario:     "with syntax errors",
+			sys:             newTestSys(getIncrementalProject(`"incremental": true`), "/home/src/workspaces/project"),
+			commandLineArgs: []string{},
+			edits: []*testTscEdit{
+				newTscEdit("introduce syntax error", func(sys execute.System) {
+					sys.FS().WriteFile("/home/src/workspaces/project/b.ts", `export const b = ;`, false)
+				}),
+				newTscEdit("fix syntax error", func(sys execute.System) {
+					sys.FS().WriteFile("/home/src/workspaces/project/b.ts", `export const b = 1;`, false)
+				}),
+			},
+		},
+		{
+			subScenario:     "with corrupt tsbuildinfo",
+			sys:             newTestSys(getIncrementalProject(`"incremental": true`), "/home/src/workspaces/project"),
+			commandLineArgs: []string{},
+			edits: []*testTscEdit{
+				newTscEdit("file ids out of range", func(sys execute.System) {
+					sys.FS().WriteFile("/home/src/workspaces/project/tsconfig.tsbuildinfo", `{"version":"`+core.Version()+`","fileNames":["./a.ts"],"fileInfos":[{"version":"0"}],"fileIdsList":[[1,7]],"referencedMap":[[1,3]],"semanticDiagnosticsPerFile":[5],"affectedFilesPendingEmit":[9]}`, false)
+				}),
+			},
+		},
+		{
+			subScenario:     "change to option affecting semantic diagnostics",
+			sys:             newTestSys(getIncrementalProject(`"incremental": true`), "/home/src/workspaces/project"),
+			commandLineArgs: []string{},
+			edits: []*testTscEdit{
+				{caption: "strict", commandLineArgs: []string{"--strict"}},
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		test.verify(t, "incremental")
+	}
+}
@@ -0,0 +1,101 @@
+package execute
+
+import (
+	"time"
+
+	"github.com/pagpeter/typescript-go/external/ast"
+	"github.com/pagpeter/typescript-go/external/core"
+	"github.com/pagpeter/typescript-go/external/diagnostics"
+)
+
+type upToDateStatusType uint16
+
+const (
+	// Config file could not be read or has errors; the project cannot be built.
+	upToDateStatusTypeUnbuildable upToDateStatusType = iota
+	upToDateStatusTypeUpToDate
+	upToDateStatusTypeOutputMissing
+	upToDateStatusTypeErrorReadingFile
+	upToDateStatusTypeOutOfDateWithSelf
+	upToDateStatusTypeOutOfDateWithUpstream
+	upToDateStatusTypeOutOfDateBuildInfoWithErrors
+	upToDateStatusTypeOutOfDateBuildInfoWithPendingEmit
+	upToDateStatusTypeTsVersionOutputOfDate
+	upToDateStatusTypeUpstreamOutOfDate
+	upToDateStatusTypeUpstreamBlocked
+	upToDateStatusTypeUpstreamErrors
+	// Project has no inputs of its own and only references other projects.
+	upToDateStatusTypeContainerOnly
+	upToDateStatusTypeForceBuild
+)
+
+type upToDateStatus struct {
+	kind upToDateStatusType
+
+	// Newest input and oldest output, for up-to-date and out-of-date-with-self reporting.
+	newestInputFileName  string
+	newestInputFileTime  time.Time
+	oldestOutputFileName string
+
+	// The output or input file that caused the project to be out of date.
+	fileName string
+
+	// The upstream project that caused this project to be out of date or blocked.
+	upstreamProjectName string
+
+	// The tsc version recorded in the buildinfo file.
+	version string
+}
+
+func (s *upToDateStatus) isUpToDate() bool {
+	return s.kind == upToDateStatusTypeUpToDate
+}
+
+func (s *upToDateStatus) isBlocked() bool {
+	switch s.kind {
+	case upToDateStatusTypeUnbuildable, upToDateStatusTypeUpstreamBlocked, upToDateStatusTypeUpstreamErrors:
+		return true
+	}
+	return false
+}
+
+func (s *upToDateStatus) needsBuild() bool {
+	return !s.isUpToDate() && !s.isBlocked() && s.kind != upToDateStatusTypeContainerOnly
+}
+
+// diagnostic returns the verbose-mode message describing the status of the project,
+// or nil if there is nothing worth reporting.
+func (s *upToDateStatus) diagnostic(relName func(string) string, configFileName string) *ast.Diagnostic {
+	projectName := relName(configFileName)
+	switch s.kind {
+	case upToDateStatusTypeUpToDate:
+		if s.newestInputFileName == "" {
+			return ast.NewCompilerDiagnostic(diagnostics.Project_0_is_up_to_date, projectName)
+		}
+		return ast.NewCompilerDiagnostic(diagnostics.Project_0_is_up_to_date_because_newest_input_1_is_older_than_output_2, projectName, relName(s.newestInputFileName), relName(s.oldestOutputFileName))
+	case upToDateStatusTypeOutputMissing:
+		return ast.NewCompilerDiagnostic(diagnostics.Project_0_is_out_of_date_because_output_file_1_does_not_exist, projectName, relName(s.fileName))
+	case upToDateStatusTypeErrorReadingFile:
+		return ast.NewCompilerDiagnostic(diagnostics.Project_0_is_out_of_date_because_there_was_error_reading_file_1, projectName, relName(s.fileName))
+	case upToDateStatusTypeOutOfDateWithSelf:
+		return ast.NewCompilerDiagnostic(diagnostics.Project_0_is_out_of_date_because_output_1_is_older_than_input_2, projectName, relName(s.oldestOutputFileName), relName(s.newestInputFileName))
+	case upToDateStatusTypeOutOfDateWithUpstream:
+		return ast.NewCompilerDiagnostic(diagnostics.Project_0_is_out_of_date_because_output_1_is_older_than_input_2, projectName, relName(s.oldestOutputFileName), relName(s.upstreamProjectName))
+	case upToDateStatusTypeOutOfDateBuildInfoWithErrors:
+		return ast.NewCompilerDiagnostic(diagnostics.Project_0_is_out_of_date_because_buildinfo_file_1_indicates_that_program_needs_to_report_errors, projectName, relName(s.fileName))
+	case upToDateStatusTypeOutOfDateBuildInfoWithPendingEmit:
+		return ast.NewCompilerDiagnostic(diagnostics.Project_0_is_out_of_date_because_buildinfo_file_1_indicates_that_some_of_the_changes_were_not_emitted, projectName, relName(s.fileName))
+	case upToDateStatusTypeTsVersionOutputOfDate:
+		return ast.NewCompilerDiagnostic(diagnostics.Project_0_is_out_of_date_because_output_for_it_was_generated_with_version_1_that_differs_with_current_version_2, projectName, s.version, core.Version())
+	case upToDateStatusTypeUpstreamOutOfDate:
+		return ast.NewCompilerDiagnostic(diagnostics.Project_0_is_out_of_date_because_its_dependency_1_is_out_of_date, projectName, relName(s.upstreamProjectName))
+	case upToDateStatusTypeUpstreamBlocked:
+		return ast.NewCompilerDiagnostic(diagnostics.Project_0_can_t_be_built_because_its_dependency_1_was_not_built, projectName, relName(s.upstreamProjectName))
+	case upToDateStatusTypeUpstreamErrors:
+		return ast.NewCompilerDiagnostic(diagnostics.Project_0_can_t_be_built_because_its_dependency_1_has_errors, projectName, relName(s.upstreamProjectName))
+	case upToDateStatusTypeForceBuild:
+		return ast.NewCompilerDiagnostic(diagnostics.Project_0_is_being_forcibly_rebuilt, projectName)
+	}
+	// Unbuildable projects report their own errors, and container projects have nothing to say.
+	return nil
+}
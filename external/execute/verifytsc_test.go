@@ -24,6 +24,9 @@ type tscInput struct {
 
 	// for watch tests
 	data map[string]string
+
+	// commands run after the initial one, each preceded by its edit
+	edits []*testTscEdit
 }
 
 func (test *tscInput) verify(t *testing.T, scenario string) {
@@ -35,30 +38,68 @@ func (test *tscInput) verify(t *testing.T, scenario string) {
 			// initial test tsc compile
 			baselineBuilder := test.startBaseline()
 
-			parsedCommandLine, exit := execute.CommandLineTest(test.sys, nil, test.commandLineArgs)
-			baselineBuilder.WriteString("ExitStatus:: " + fmt.Sprint(exit))
-
-			compilerOptionsString, _ := json.MarshalIndent(parsedCommandLine.CompilerOptions(), "", "    ")
-			baselineBuilder.WriteString("\n\nCompilerOptions::")
-			baselineBuilder.Write(compilerOptionsString)
-
-			test.sys.serializeState(baselineBuilder)
+			test.run(baselineBuilder, test.commandLineArgs)
+			for _, edit := range test.edits {
+				if edit.edit != nil {
+					edit.edit(test.sys)
+				}
+				commandLineArgs := edit.commandLineArgs
+				if len(commandLineArgs) == 0 {
+					commandLineArgs = test.commandLineArgs
+				}
+				baselineBuilder.WriteString("\n\nEdit:: " + edit.caption + "\n")
+				baselineBuilder.WriteString("Input::" + strings.Join(commandLineArgs, " ") + "\n")
+				test.run(baselineBuilder, commandLineArgs)
+			}
 			options, name := test.getBaselineName(scenario, false, "")
 			baseline.Run(t, name, baselineBuilder.String(), options)
 		})
 	})
 }
 
+func (test *tscInput) run(baselineBuilder *strings.Builder, commandLineArgs []string) {
+	if isBuildCommand(commandLineArgs) {
+		buildCommand, exit := execute.CommandLineTestBuild(test.sys, nil, commandLineArgs)
+		baselineBuilder.WriteString("ExitStatus:: " + fmt.Sprint(exit))
+
+		buildOptionsString, _ := json.MarshalIndent(buildCommand.BuildOptions, "", "    ")
+		baselineBuilder.WriteString("\n\nBuildOptions::")
+		baselineBuilder.Write(buildOptionsString)
+
+		compilerOptionsString, _ := json.MarshalIndent(buildCommand.CompilerOptions, "", "    ")
+		baselineBuilder.WriteString("\n\nCompilerOptions::")
+		baselineBuilder.Write(compilerOptionsString)
+	} else {
+		parsedCommandLine, exit := execute.CommandLineTest(test.sys, nil, commandLineArgs)
+		baselineBuilder.WriteString("ExitStatus:: " + fmt.Sprint(exit))
+
+		compilerOptionsString, _ := json.MarshalIndent(parsedCommandLine.CompilerOptions(), "", "    ")
+		baselineBuilder.WriteString("\n\nCompilerOptions::")
+		baselineBuilder.Write(compilerOptionsString)
+	}
+	test.sys.serializeState(baselineBuilder)
+}
+
+func isBuildCommand(args []string) bool {
+	if len(args) == 0 {
+		return false
+	}
+	switch strings.ToLower(args[0]) {
+	case "-b", "--b", "-build", "--build":
+		return true
+	}
+	return false
+}
+
 func (test *tscInput) getTestName(scenario string) string {
 	return "tsc " + strings.Join(test.commandLineArgs, " ") + " " + scenario + ":: " + test.subScenario
 }
 
 func (test *tscInput) getBaselineName(scenario string, watch bool, suffix string) (baseline.Options, string) {
 	commandName := "tsc"
-	// todo build
-	// if isBuildCommand(v.data.commandLineArgs) {
-	// 	commandName = "tsbuild"
-	// }
+	if isBuildCommand(test.commandLineArgs) {
+		commandName = "tsbuild"
+	}
 	w := ""
 	if watch {
 		w = "Watch"
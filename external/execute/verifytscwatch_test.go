@@ -110,10 +110,10 @@ func noEmitWatchTestInput(
 		"aText":        aText,
 	}
 	return &tscInput{
-		subScenario,
-		commandLineArgs,
-		sys,
-		data,
+		subScenario:     subScenario,
+		commandLineArgs: commandLineArgs,
+		sys:             sys,
+		data:            data,
 	}
 }
 
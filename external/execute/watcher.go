@@ -7,12 +7,14 @@ import (
 	"github.com/pagpeter/typescript-go/external/collections"
 	"github.com/pagpeter/typescript-go/external/compiler"
 	"github.com/pagpeter/typescript-go/external/core"
+	"github.com/pagpeter/typescript-go/external/diagnostics"
 	"github.com/pagpeter/typescript-go/external/tsoptions"
 	"github.com/pagpeter/typescript-go/external/tspath"
 )
 
 type watcher struct {
 	sys              System
+	locale           *diagnostics.Locale
 	configFileName   string
 	options          *tsoptions.ParsedCommandLine
 	reportDiagnostic diagnosticReporter
@@ -23,9 +25,10 @@ type watcher struct {
 	configModified bool
 }
 
-func createWatcher(sys System, configParseResult *tsoptions.ParsedCommandLine, reportDiagnostic diagnosticReporter) *watcher {
+func createWatcher(sys System, locale *diagnostics.Locale, configParseResult *tsoptions.ParsedCommandLine, reportDiagnostic diagnosticReporter) *watcher {
 	w := &watcher{
 		sys:              sys,
+		locale:           locale,
 		options:          configParseResult,
 		reportDiagnostic: reportDiagnostic,
 		// reportWatchStatus: createWatchStatusReporter(sys, configParseResult.CompilerOptions().Pretty),
@@ -39,7 +42,7 @@ func createWatcher(sys System, configParseResult *tsoptions.ParsedCommandLine, r
 func (w *watcher) compileAndEmit() {
 	// !!! output/error reporting is currently the same as non-watch mode
 	// diagnostics, emitResult, exitStatus :=
-	emitFilesAndReportErrors(w.sys, w.program, w.reportDiagnostic)
+	emitFilesAndReportErrors(w.sys, w.locale, w.program, w.reportDiagnostic, createReportErrorSummary(w.sys, w.locale, w.program.Options()))
 }
 
 func (w *watcher) hasErrorsInTsConfig() bool {
@@ -60,7 +63,7 @@ func (w *watcher) hasErrorsInTsConfig() bool {
 			w.configModified = true
 		}
 		w.options = configParseResult
-		w.host = compiler.NewCompilerHost(w.options.CompilerOptions(), w.sys.GetCurrentDirectory(), w.sys.FS(), w.sys.DefaultLibraryPath(), &extendedConfigCache)
+		w.host = compiler.NewCompilerHost(w.options.CompilerOptions(), w.sys.GetCurrentDirectory(), w.sys.FS(), w.sys.DefaultLibraryPath(), &extendedConfigCache, getTraceFromSys(w.sys))
 	}
 	return false
 }
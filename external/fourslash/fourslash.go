@@ -549,6 +549,62 @@ func getExpectedLabel(t *testing.T, item CompletionsExpectedItem) string {
 	}
 }
 
+// Configure sends editor settings to the server, as a client does when its configuration changes.
+func (f *FourslashTest) Configure(t *testing.T, settings map[string]any) {
+	f.sendNotification(t, lsproto.MethodWorkspaceDidChangeConfiguration, &lsproto.DidChangeConfigurationParams{
+		Settings: settings,
+	})
+}
+
+func (f *FourslashTest) VerifyInlayHints(t *testing.T, fileName string, expected []*lsproto.InlayHint) {
+	f.ensureActiveFile(t, fileName)
+	file := core.Find(f.testData.Files, func(file *TestFileInfo) bool {
+		return file.fileName == fileName
+	})
+	lines := strings.Split(file.Content, "\n")
+	params := &lsproto.InlayHintParams{
+		TextDocument: lsproto.TextDocumentIdentifier{
+			Uri: ls.FileNameToDocumentURI(fileName),
+		},
+		Range: lsproto.Range{
+			End: lsproto.Position{Line: uint32(len(lines) - 1), Character: uint32(len(lines[len(lines)-1]))},
+		},
+	}
+	resMsg := f.sendRequest(t, lsproto.MethodTextDocumentInlayHint, params)
+	if resMsg == nil {
+		t.Fatalf("Nil response received for inlay hint request in file %s", fileName)
+	}
+	result := resMsg.AsResponse().Result
+	switch result := result.(type) {
+	case []*lsproto.InlayHint:
+		assertDeepEqual(t, result, expected, "Inlay hints mismatch in file "+fileName)
+	default:
+		t.Fatalf("Unexpected response type for inlay hint request in file %s: %v", fileName, result)
+	}
+}
+
+func (f *FourslashTest) VerifyWillRenameFiles(t *testing.T, oldFileName string, newFileName string, expected map[lsproto.DocumentUri][]*lsproto.TextEdit) {
+	params := &lsproto.RenameFilesParams{
+		Files: []*lsproto.FileRename{
+			{
+				OldUri: string(ls.FileNameToDocumentURI(oldFileName)),
+				NewUri: string(ls.FileNameToDocumentURI(newFileName)),
+			},
+		},
+	}
+	resMsg := f.sendRequest(t, lsproto.MethodWorkspaceWillRenameFiles, params)
+	if resMsg == nil {
+		t.Fatalf("Nil response received for will rename files request for %s", oldFileName)
+	}
+	result := resMsg.AsResponse().Result
+	switch result := result.(type) {
+	case *lsproto.WorkspaceEdit:
+		assertDeepEqual(t, *result.Changes, expected, "File rename edits mismatch for "+oldFileName)
+	default:
+		t.Fatalf("Unexpected response type for will rename files request for %s: %v", oldFileName, result)
+	}
+}
+
 func assertDeepEqual(t *testing.T, actual any, expected any, prefix string, opts ...cmp.Option) {
 	t.Helper()
 
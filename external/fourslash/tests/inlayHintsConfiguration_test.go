@@ -0,0 +1,48 @@
+package fourslash_test
+
+import (
+	"testing"
+
+	"github.com/pagpeter/typescript-go/external/fourslash"
+	"github.com/pagpeter/typescript-go/external/lsp/lsproto"
+	"github.com/pagpeter/typescript-go/external/testutil"
+)
+
+func TestInlayHintsConfiguration(t *testing.T) {
+	t.Parallel()
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `// @Filename: /a.ts
+function greet(name: string, times: number) {}
+greet("world", 2);
+const count = 1 + 1;`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	// Every kind of inlay hint is disabled until the client enables it.
+	f.VerifyInlayHints(t, "/a.ts", nil)
+	f.Configure(t, map[string]any{
+		"typescript": map[string]any{
+			"inlayHints": map[string]any{
+				"parameterNames": map[string]any{"enabled": "literals"},
+				"variableTypes":  map[string]any{"enabled": true},
+			},
+		},
+	})
+	f.VerifyInlayHints(t, "/a.ts", []*lsproto.InlayHint{
+		{
+			Position:     lsproto.Position{Line: 1, Character: 6},
+			Label:        lsproto.StringOrInlayHintLabelParts{String: ptrTo("name:")},
+			Kind:         ptrTo(lsproto.InlayHintKindParameter),
+			PaddingRight: ptrTo(true),
+		},
+		{
+			Position:     lsproto.Position{Line: 1, Character: 15},
+			Label:        lsproto.StringOrInlayHintLabelParts{String: ptrTo("times:")},
+			Kind:         ptrTo(lsproto.InlayHintKindParameter),
+			PaddingRight: ptrTo(true),
+		},
+		{
+			Position: lsproto.Position{Line: 2, Character: 11},
+			Label:    lsproto.StringOrInlayHintLabelParts{String: ptrTo(": number")},
+			Kind:     ptrTo(lsproto.InlayHintKindType),
+		},
+	})
+}
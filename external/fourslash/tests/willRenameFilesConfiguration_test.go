@@ -0,0 +1,37 @@
+package fourslash_test
+
+import (
+	"testing"
+
+	"github.com/pagpeter/typescript-go/external/fourslash"
+	"github.com/pagpeter/typescript-go/external/lsp/lsproto"
+	"github.com/pagpeter/typescript-go/external/testutil"
+)
+
+func TestWillRenameFilesConfiguration(t *testing.T) {
+	t.Parallel()
+	defer testutil.RecoverAndFail(t, "Panic on fourslash test")
+	const content = `// @Filename: /src/a.ts
+import { b } from "@/b";
+// @Filename: /src/b.ts
+export const b = 1;
+// @Filename: /tsconfig.json
+{ "compilerOptions": { "paths": { "@/*": ["./src/*"] } }, "include": ["src"] }`
+	f := fourslash.NewFourslash(t, nil /*capabilities*/, content)
+	importRange := lsproto.Range{
+		Start: lsproto.Position{Line: 0, Character: 19},
+		End:   lsproto.Position{Line: 0, Character: 22},
+	}
+	// The existing import uses a path mapping, so the updated one does too.
+	f.VerifyWillRenameFiles(t, "/src/b.ts", "/src/lib/b.ts", map[lsproto.DocumentUri][]*lsproto.TextEdit{
+		"file:///src/a.ts": {{Range: importRange, NewText: "@/lib/b"}},
+	})
+	f.Configure(t, map[string]any{
+		"typescript": map[string]any{
+			"preferences": map[string]any{"importModuleSpecifier": "relative"},
+		},
+	})
+	f.VerifyWillRenameFiles(t, "/src/b.ts", "/src/lib/b.ts", map[lsproto.DocumentUri][]*lsproto.TextEdit{
+		"file:///src/a.ts": {{Range: importRange, NewText: "./lib/b"}},
+	})
+}
@@ -0,0 +1,622 @@
+package ls
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/pagpeter/typescript-go/external/ast"
+	"github.com/pagpeter/typescript-go/external/astnav"
+	"github.com/pagpeter/typescript-go/external/checker"
+	"github.com/pagpeter/typescript-go/external/compiler"
+	"github.com/pagpeter/typescript-go/external/core"
+	"github.com/pagpeter/typescript-go/external/lsp/lsproto"
+	"github.com/pagpeter/typescript-go/external/scanner"
+)
+
+// A call hierarchy declaration is one of:
+//   - a source file
+//   - a namespace declaration with an identifier name
+//   - a function, class, class static block, method or accessor declaration
+//   - a named function or class expression
+//   - a function, arrow function or class expression assigned to a `const` variable or to a property declaration
+
+func (l *LanguageService) ProvidePrepareCallHierarchy(ctx context.Context, documentURI lsproto.DocumentUri, position lsproto.Position) ([]*lsproto.CallHierarchyItem, error) {
+	program, file := l.getProgramAndFile(documentURI)
+	node := astnav.GetTouchingPropertyName(file, int(l.converters.LineAndCharacterToPosition(file, position)))
+	checker, done := program.GetTypeCheckerForFile(ctx, file)
+	defer done()
+	declarations := resolveCallHierarchyDeclaration(checker, node)
+	items := make([]*lsproto.CallHierarchyItem, len(declarations))
+	for i, declaration := range declarations {
+		items[i] = l.createCallHierarchyItem(checker, declaration)
+	}
+	return items, nil
+}
+
+func (l *LanguageService) ProvideCallHierarchyIncomingCalls(ctx context.Context, item *lsproto.CallHierarchyItem) ([]*lsproto.CallHierarchyIncomingCall, error) {
+	program, file := l.getProgramAndFile(item.Uri)
+	declaration := l.getCallHierarchyDeclarationOfItem(ctx, program, file, item)
+	// Source files and modules have no incoming calls.
+	if declaration == nil || ast.IsSourceFile(declaration) || ast.IsModuleDeclaration(declaration) || ast.IsClassStaticBlockDeclaration(declaration) {
+		return []*lsproto.CallHierarchyIncomingCall{}, nil
+	}
+	location := getCallHierarchyDeclarationReferenceNode(declaration)
+	position := scanner.GetTokenPosOfNode(location, ast.GetSourceFileOfNode(location), false /*includeJSDoc*/)
+	symbolsAndEntries := l.getReferencedSymbolsForNode(position, location, program, program.GetSourceFiles(), refOptions{use: referenceUseReferences}, nil)
+	var callSites []*callSite
+	for _, symbolAndEntries := range symbolsAndEntries {
+		for _, entry := range symbolAndEntries.references {
+			if site := convertEntryToCallSite(entry); site != nil {
+				callSites = append(callSites, site)
+			}
+		}
+	}
+
+	checker, done := program.GetTypeChecker(ctx)
+	defer done()
+	calls := []*lsproto.CallHierarchyIncomingCall{}
+	for _, group := range groupCallSitesByDeclaration(callSites) {
+		calls = append(calls, &lsproto.CallHierarchyIncomingCall{
+			From:       l.createCallHierarchyItem(checker, group[0].declaration),
+			FromRanges: l.getCallSiteRanges(group),
+		})
+	}
+	return calls, nil
+}
+
+func (l *LanguageService) ProvideCallHierarchyOutgoingCalls(ctx context.Context, item *lsproto.CallHierarchyItem) ([]*lsproto.CallHierarchyOutgoingCall, error) {
+	program, file := l.getProgramAndFile(item.Uri)
+	declaration := l.getCallHierarchyDeclarationOfItem(ctx, program, file, item)
+	if declaration == nil || declaration.Flags&ast.NodeFlagsAmbient != 0 || ast.IsMethodSignatureDeclaration(declaration) {
+		return []*lsproto.CallHierarchyOutgoingCall{}, nil
+	}
+	checker, done := program.GetTypeCheckerForFile(ctx, file)
+	defer done()
+	calls := []*lsproto.CallHierarchyOutgoingCall{}
+	for _, group := range groupCallSitesByDeclaration(collectCallSites(checker, declaration)) {
+		calls = append(calls, &lsproto.CallHierarchyOutgoingCall{
+			To:         l.createCallHierarchyItem(checker, group[0].declaration),
+			FromRanges: l.getCallSiteRanges(group),
+		})
+	}
+	return calls, nil
+}
+
+// getCallHierarchyDeclarationOfItem resolves an item returned by an earlier request back to its declaration.
+func (l *LanguageService) getCallHierarchyDeclarationOfItem(ctx context.Context, program *compiler.Program, file *ast.SourceFile, item *lsproto.CallHierarchyItem) *ast.Node {
+	if item.Kind == lsproto.SymbolKindFile {
+		return file.AsNode()
+	}
+	node := astnav.GetTouchingPropertyName(file, int(l.converters.LineAndCharacterToPosition(file, item.SelectionRange.Start)))
+	checker, done := program.GetTypeCheckerForFile(ctx, file)
+	defer done()
+	return core.FirstOrNil(resolveCallHierarchyDeclaration(checker, node))
+}
+
+func (l *LanguageService) getCallSiteRanges(callSites []*callSite) []lsproto.Range {
+	ranges := make([]lsproto.Range, len(callSites))
+	for i, site := range callSites {
+		ranges[i] = l.converters.ToLSPRange(site.sourceFile, site.textRange)
+	}
+	return ranges
+}
+
+func (l *LanguageService) createCallHierarchyItem(c *checker.Checker, node *ast.Node) *lsproto.CallHierarchyItem {
+	file := ast.GetSourceFileOfNode(node)
+	name, nameRange := getCallHierarchyItemName(c, node)
+	start := scanner.SkipTriviaEx(file.Text(), node.Pos(), &scanner.SkipTriviaOptions{StopAtComments: true})
+	item := &lsproto.CallHierarchyItem{
+		Name:           name,
+		Kind:           getCallHierarchyItemKind(node),
+		Uri:            FileNameToDocumentURI(file.FileName()),
+		Range:          l.converters.ToLSPRange(file, core.NewTextRange(start, node.End())),
+		SelectionRange: l.converters.ToLSPRange(file, nameRange),
+	}
+	if containerName := getCallHierarchyItemContainerName(node); containerName != "" {
+		item.Detail = &containerName
+	}
+	declaration := node
+	if isAssignedExpression(node) {
+		declaration = node.Parent
+	}
+	if !ast.IsSourceFile(node) && ast.GetCombinedNodeFlags(declaration)&ast.NodeFlagsDeprecated != 0 {
+		item.Tags = &[]lsproto.SymbolTag{lsproto.SymbolTagDeprecated}
+	}
+	return item
+}
+
+func getCallHierarchyItemKind(node *ast.Node) lsproto.SymbolKind {
+	switch node.Kind {
+	case ast.KindSourceFile:
+		return lsproto.SymbolKindFile
+	case ast.KindArrowFunction:
+		return lsproto.SymbolKindFunction
+	case ast.KindClassStaticBlockDeclaration:
+		return lsproto.SymbolKindMethod
+	}
+	return getSymbolKindFromNode(node)
+}
+
+func getCallHierarchyItemName(c *checker.Checker, node *ast.Node) (string, core.TextRange) {
+	file := ast.GetSourceFileOfNode(node)
+	if ast.IsSourceFile(node) {
+		return file.FileName(), core.NewTextRange(0, 0)
+	}
+	if (ast.IsFunctionDeclaration(node) || ast.IsClassDeclaration(node)) && node.Name() == nil {
+		if defaultModifier := findDefaultModifier(node); defaultModifier != nil {
+			return "default", core.NewTextRange(scanner.GetTokenPosOfNode(defaultModifier, file, false /*includeJSDoc*/), defaultModifier.End())
+		}
+	}
+	if ast.IsClassStaticBlockDeclaration(node) {
+		pos := scanner.GetTokenPosOfNode(node, file, false /*includeJSDoc*/)
+		var prefix string
+		if className := node.Parent.Name(); className != nil {
+			prefix = scanner.GetTextOfNode(className) + " "
+		}
+		return prefix + "static {}", core.NewTextRange(pos, pos+len("static"))
+	}
+
+	var declarationName *ast.Node
+	if isAssignedExpression(node) {
+		declarationName = node.Parent.Name()
+	} else {
+		declarationName = ast.GetNameOfDeclaration(node)
+	}
+	var text string
+	switch {
+	case ast.IsIdentifier(declarationName) || ast.IsStringOrNumericLiteralLike(declarationName):
+		text = declarationName.Text()
+	case ast.IsComputedPropertyName(declarationName) && ast.IsStringOrNumericLiteralLike(declarationName.Expression()):
+		text = declarationName.Expression().Text()
+	default:
+		if symbol := c.GetSymbolAtLocation(declarationName); symbol != nil {
+			text = c.SymbolToString(symbol)
+		} else {
+			text = scanner.GetTextOfNode(declarationName)
+		}
+	}
+	return text, core.NewTextRange(scanner.GetTokenPosOfNode(declarationName, file, false /*includeJSDoc*/), declarationName.End())
+}
+
+func getCallHierarchyItemContainerName(node *ast.Node) string {
+	if isAssignedExpression(node) {
+		declaration := node.Parent
+		if ast.IsPropertyDeclaration(declaration) && ast.IsClassLike(declaration.Parent) {
+			var className *ast.Node
+			if ast.IsClassExpression(declaration.Parent) {
+				className = ast.GetAssignedName(declaration.Parent)
+			} else {
+				className = declaration.Parent.Name()
+			}
+			return getTextOfOptionalNode(className)
+		}
+		// const f = () => {} in a namespace block: VariableDeclaration > VariableDeclarationList > VariableStatement > ModuleBlock > ModuleDeclaration
+		if moduleBlock := declaration.Parent.Parent.Parent; moduleBlock != nil && ast.IsModuleBlock(moduleBlock) && ast.IsIdentifier(moduleBlock.Parent.Name()) {
+			return moduleBlock.Parent.Name().Text()
+		}
+		return ""
+	}
+	switch node.Kind {
+	case ast.KindGetAccessor, ast.KindSetAccessor, ast.KindMethodDeclaration:
+		if ast.IsObjectLiteralExpression(node.Parent) {
+			return getTextOfOptionalNode(ast.GetAssignedName(node.Parent))
+		}
+		return getTextOfOptionalNode(ast.GetNameOfDeclaration(node.Parent))
+	case ast.KindFunctionDeclaration, ast.KindClassDeclaration, ast.KindModuleDeclaration:
+		if ast.IsModuleBlock(node.Parent) && ast.IsIdentifier(node.Parent.Parent.Name()) {
+			return node.Parent.Parent.Name().Text()
+		}
+	}
+	return ""
+}
+
+func getTextOfOptionalNode(node *ast.Node) string {
+	if node == nil {
+		return ""
+	}
+	return scanner.GetTextOfNode(node)
+}
+
+func isNamedExpression(node *ast.Node) bool {
+	return (ast.IsFunctionExpression(node) || ast.IsClassExpression(node)) && node.Name() != nil
+}
+
+func isPropertyOrVariableDeclaration(node *ast.Node) bool {
+	return ast.IsPropertyDeclaration(node) || ast.IsVariableDeclaration(node)
+}
+
+// isAssignedExpression reports whether node is a function, arrow function or class expression that initializes
+// a `const` variable or a property declaration with an identifier name.
+func isAssignedExpression(node *ast.Node) bool {
+	if !(ast.IsFunctionExpression(node) || ast.IsArrowFunction(node) || ast.IsClassExpression(node)) {
+		return false
+	}
+	parent := node.Parent
+	return isPropertyOrVariableDeclaration(parent) && parent.Initializer() == node && ast.IsIdentifier(parent.Name()) &&
+		(ast.GetCombinedNodeFlags(parent)&ast.NodeFlagsConst != 0 || ast.IsPropertyDeclaration(parent))
+}
+
+func isPossibleCallHierarchyDeclaration(node *ast.Node) bool {
+	switch node.Kind {
+	case ast.KindSourceFile, ast.KindModuleDeclaration, ast.KindFunctionDeclaration, ast.KindFunctionExpression, ast.KindClassDeclaration,
+		ast.KindClassExpression, ast.KindClassStaticBlockDeclaration, ast.KindMethodDeclaration, ast.KindMethodSignature,
+		ast.KindGetAccessor, ast.KindSetAccessor:
+		return true
+	}
+	return false
+}
+
+func isValidCallHierarchyDeclaration(node *ast.Node) bool {
+	switch node.Kind {
+	case ast.KindSourceFile, ast.KindFunctionDeclaration, ast.KindClassDeclaration, ast.KindClassStaticBlockDeclaration,
+		ast.KindMethodDeclaration, ast.KindMethodSignature, ast.KindGetAccessor, ast.KindSetAccessor:
+		return true
+	case ast.KindModuleDeclaration:
+		return ast.IsIdentifier(node.Name())
+	}
+	return isNamedExpression(node) || isAssignedExpression(node)
+}
+
+// getCallHierarchyDeclarationReferenceNode returns the node to search for references of a declaration.
+func getCallHierarchyDeclarationReferenceNode(node *ast.Node) *ast.Node {
+	if ast.IsSourceFile(node) {
+		return node
+	}
+	if name := node.Name(); name != nil {
+		return name
+	}
+	if isAssignedExpression(node) {
+		return node.Parent.Name()
+	}
+	return findDefaultModifier(node)
+}
+
+func findDefaultModifier(node *ast.Node) *ast.Node {
+	return core.Find(getModifiersOfNode(node), func(modifier *ast.Node) bool { return modifier.Kind == ast.KindDefaultKeyword })
+}
+
+func getSymbolOfCallHierarchyDeclaration(c *checker.Checker, node *ast.Node) *ast.Symbol {
+	if location := getCallHierarchyDeclarationReferenceNode(node); location != nil {
+		return c.GetSymbolAtLocation(location)
+	}
+	return nil
+}
+
+// findImplementation returns the declaration with a body among the overloads of a function or method, or nil
+// when there is none.
+func findImplementation(c *checker.Checker, node *ast.Node) *ast.Node {
+	if node.Body() != nil {
+		return node
+	}
+	if ast.IsConstructorDeclaration(node) {
+		return getFirstConstructorWithBody(node.Parent)
+	}
+	if ast.IsFunctionDeclaration(node) || ast.IsMethodDeclaration(node) {
+		if symbol := getSymbolOfCallHierarchyDeclaration(c, node); symbol != nil && symbol.ValueDeclaration != nil &&
+			ast.IsFunctionLikeDeclaration(symbol.ValueDeclaration) && symbol.ValueDeclaration.Body() != nil {
+			return symbol.ValueDeclaration
+		}
+		return nil
+	}
+	return node
+}
+
+func getFirstConstructorWithBody(node *ast.Node) *ast.Node {
+	for _, member := range node.Members() {
+		if ast.IsConstructorDeclaration(member) && member.Body() != nil {
+			return member
+		}
+	}
+	return nil
+}
+
+// findAllInitialDeclarations returns the first declaration of each run of adjacent declarations of the symbol
+// of node, such as the overloads of a function without an implementation.
+func findAllInitialDeclarations(c *checker.Checker, node *ast.Node) []*ast.Node {
+	symbol := getSymbolOfCallHierarchyDeclaration(c, node)
+	if symbol == nil {
+		return nil
+	}
+	sortedDeclarations := slices.Clone(symbol.Declarations)
+	slices.SortStableFunc(sortedDeclarations, func(a, b *ast.Node) int {
+		if c := strings.Compare(ast.GetSourceFileOfNode(a).FileName(), ast.GetSourceFileOfNode(b).FileName()); c != 0 {
+			return c
+		}
+		return a.Pos() - b.Pos()
+	})
+	var declarations []*ast.Node
+	var lastDeclaration *ast.Node
+	for _, declaration := range sortedDeclarations {
+		if isValidCallHierarchyDeclaration(declaration) {
+			if lastDeclaration == nil || lastDeclaration.Parent != declaration.Parent || lastDeclaration.End() != declaration.Pos() {
+				declarations = append(declarations, declaration)
+			}
+			lastDeclaration = declaration
+		}
+	}
+	return declarations
+}
+
+func findImplementationOrAllInitialDeclarations(c *checker.Checker, node *ast.Node) []*ast.Node {
+	if ast.IsClassStaticBlockDeclaration(node) {
+		return []*ast.Node{node}
+	}
+	if ast.IsFunctionLikeDeclaration(node) {
+		if implementation := findImplementation(c, node); implementation != nil {
+			return []*ast.Node{implementation}
+		}
+	}
+	if declarations := findAllInitialDeclarations(c, node); declarations != nil {
+		return declarations
+	}
+	return []*ast.Node{node}
+}
+
+func findAncestorCallHierarchyDeclaration(c *checker.Checker, node *ast.Node) []*ast.Node {
+	if ancestor := ast.FindAncestor(node, isValidCallHierarchyDeclaration); ancestor != nil {
+		return findImplementationOrAllInitialDeclarations(c, ancestor)
+	}
+	return nil
+}
+
+// resolveCallHierarchyDeclaration resolves the declarations of the call hierarchy items at a location, which may
+// be a declaration, its name, or a reference to it.
+func resolveCallHierarchyDeclaration(c *checker.Checker, location *ast.Node) []*ast.Node {
+	followingSymbol := false
+	for {
+		if isValidCallHierarchyDeclaration(location) {
+			return findImplementationOrAllInitialDeclarations(c, location)
+		}
+		if isPossibleCallHierarchyDeclaration(location) {
+			return findAncestorCallHierarchyDeclaration(c, location)
+		}
+		if ast.IsDeclarationName(location) {
+			if isValidCallHierarchyDeclaration(location.Parent) {
+				return findImplementationOrAllInitialDeclarations(c, location.Parent)
+			}
+			if isPossibleCallHierarchyDeclaration(location.Parent) {
+				return findAncestorCallHierarchyDeclaration(c, location.Parent)
+			}
+			if isPropertyOrVariableDeclaration(location.Parent) && location.Parent.Initializer() != nil && isAssignedExpression(location.Parent.Initializer()) {
+				return []*ast.Node{location.Parent.Initializer()}
+			}
+			return nil
+		}
+		if ast.IsConstructorDeclaration(location) {
+			if isValidCallHierarchyDeclaration(location.Parent) {
+				return []*ast.Node{location.Parent}
+			}
+			return nil
+		}
+		if location.Kind == ast.KindStaticKeyword && ast.IsClassStaticBlockDeclaration(location.Parent) {
+			location = location.Parent
+			continue
+		}
+		if ast.IsVariableDeclaration(location) && location.Initializer() != nil && isAssignedExpression(location.Initializer()) {
+			return []*ast.Node{location.Initializer()}
+		}
+		if !followingSymbol {
+			if symbol := c.GetSymbolAtLocation(location); symbol != nil {
+				if symbol.Flags&ast.SymbolFlagsAlias != 0 {
+					symbol = c.GetAliasedSymbol(symbol)
+				}
+				if symbol.ValueDeclaration != nil {
+					followingSymbol = true
+					location = symbol.ValueDeclaration
+					continue
+				}
+			}
+		}
+		return nil
+	}
+}
+
+type callSite struct {
+	declaration *ast.Node
+	textRange   core.TextRange
+	sourceFile  *ast.SourceFile
+}
+
+// groupCallSitesByDeclaration groups call sites by the declaration that contains or is called by them, in order of
+// first occurrence. A call through a property access, such as `this.m()`, is recorded for both the call and the
+// access, so a call site at the same range as an earlier one in its group is dropped.
+func groupCallSitesByDeclaration(callSites []*callSite) [][]*callSite {
+	var groups [][]*callSite
+	indices := map[*ast.Node]int{}
+	for _, site := range callSites {
+		if index, ok := indices[site.declaration]; ok {
+			if !slices.ContainsFunc(groups[index], func(other *callSite) bool { return other.textRange == site.textRange }) {
+				groups[index] = append(groups[index], site)
+			}
+			continue
+		}
+		indices[site.declaration] = len(groups)
+		groups = append(groups, []*callSite{site})
+	}
+	return groups
+}
+
+func createCallSite(node *ast.Node, declaration *ast.Node) *callSite {
+	file := ast.GetSourceFileOfNode(node)
+	return &callSite{
+		declaration: declaration,
+		textRange:   core.NewTextRange(scanner.GetTokenPosOfNode(node, file, false /*includeJSDoc*/), node.End()),
+		sourceFile:  file,
+	}
+}
+
+func convertEntryToCallSite(entry *referenceEntry) *callSite {
+	if entry.kind != entryKindNode {
+		return nil
+	}
+	node := entry.node
+	if isCallOrNewExpressionTarget(node) || isTaggedTemplateTag(node) || isDecoratorTarget(node) || isJsxOpeningLikeElementTagName(node) ||
+		isRightSideOfPropertyAccess(node) || isArgumentOfElementAccessExpression(node) {
+		declaration := ast.FindAncestor(node, isValidCallHierarchyDeclaration)
+		if declaration == nil {
+			declaration = ast.GetSourceFileOfNode(node).AsNode()
+		}
+		return createCallSite(node, declaration)
+	}
+	return nil
+}
+
+func isCallOrNewExpressionTarget(node *ast.Node) bool {
+	target := getCalleeTarget(node)
+	return target.Parent != nil && ast.IsCallOrNewExpression(target.Parent) && target.Parent.Expression() == target
+}
+
+func isTaggedTemplateTag(node *ast.Node) bool {
+	target := getCalleeTarget(node)
+	return target.Parent != nil && ast.IsTaggedTemplateExpression(target.Parent) && target.Parent.AsTaggedTemplateExpression().Tag == target
+}
+
+func isDecoratorTarget(node *ast.Node) bool {
+	target := getCalleeTarget(node)
+	return target.Parent != nil && ast.IsDecorator(target.Parent) && target.Parent.Expression() == target
+}
+
+func isJsxOpeningLikeElementTagName(node *ast.Node) bool {
+	target := getCalleeTarget(node)
+	return target.Parent != nil && ast.IsJsxOpeningLikeElement(target.Parent) && target.Parent.TagName() == target
+}
+
+// getCalleeTarget climbs from a name past the property or element access it is the name or argument of, and
+// past any parentheses or assertions.
+func getCalleeTarget(node *ast.Node) *ast.Node {
+	if isRightSideOfPropertyAccess(node) || isArgumentOfElementAccessExpression(node) {
+		node = node.Parent
+	}
+	return ast.SkipOuterExpressions(node, ast.OEKAll)
+}
+
+// collectCallSites returns the calls made directly by a declaration, without descending into nested declarations.
+func collectCallSites(c *checker.Checker, node *ast.Node) []*callSite {
+	var callSites []*callSite
+	recordCallSite := func(node *ast.Node) {
+		var target *ast.Node
+		switch {
+		case ast.IsTaggedTemplateExpression(node):
+			target = node.AsTaggedTemplateExpression().Tag
+		case ast.IsJsxOpeningLikeElement(node):
+			target = node.TagName()
+		case ast.IsAccessExpression(node), ast.IsClassStaticBlockDeclaration(node):
+			target = node
+		default:
+			target = node.Expression()
+		}
+		for _, declaration := range resolveCallHierarchyDeclaration(c, target) {
+			callSites = append(callSites, createCallSite(target, declaration))
+		}
+	}
+
+	var collect func(node *ast.Node)
+	visit := func(node *ast.Node) bool {
+		collect(node)
+		return false
+	}
+	collectList := func(nodes []*ast.Node) {
+		for _, node := range nodes {
+			collect(node)
+		}
+	}
+	collect = func(node *ast.Node) {
+		// Do not descend into ambient nodes.
+		if node == nil || node.Flags&ast.NodeFlagsAmbient != 0 {
+			return
+		}
+		if isValidCallHierarchyDeclaration(node) {
+			// Do not descend into other call site declarations, other than class member names.
+			if ast.IsClassLike(node) {
+				for _, member := range node.Members() {
+					if name := member.Name(); name != nil && ast.IsComputedPropertyName(name) {
+						collect(name.Expression())
+					}
+				}
+			}
+			return
+		}
+		switch node.Kind {
+		case ast.KindIdentifier, ast.KindImportEqualsDeclaration, ast.KindImportDeclaration, ast.KindExportDeclaration,
+			ast.KindInterfaceDeclaration, ast.KindTypeAliasDeclaration:
+			// Do not descend into nodes that cannot contain callable nodes.
+			return
+		case ast.KindClassStaticBlockDeclaration:
+			recordCallSite(node)
+			return
+		case ast.KindTypeAssertionExpression, ast.KindAsExpression, ast.KindSatisfiesExpression:
+			// Do not descend into the type side of an assertion.
+			collect(node.Expression())
+			return
+		case ast.KindVariableDeclaration, ast.KindParameter:
+			// Do not descend into the type of a variable or parameter declaration.
+			collect(node.Name())
+			collect(node.Initializer())
+			return
+		case ast.KindCallExpression, ast.KindNewExpression:
+			// Do not descend into the type arguments of a call or new expression.
+			recordCallSite(node)
+			collect(node.Expression())
+			collectList(node.Arguments())
+			return
+		case ast.KindTaggedTemplateExpression:
+			recordCallSite(node)
+			collect(node.AsTaggedTemplateExpression().Tag)
+			collect(node.AsTaggedTemplateExpression().Template)
+			return
+		case ast.KindJsxOpeningElement, ast.KindJsxSelfClosingElement:
+			recordCallSite(node)
+			collect(node.TagName())
+			collect(node.Attributes())
+			return
+		case ast.KindDecorator:
+			recordCallSite(node)
+			collect(node.Expression())
+			return
+		case ast.KindPropertyAccessExpression, ast.KindElementAccessExpression:
+			recordCallSite(node)
+		}
+		if ast.IsPartOfTypeNode(node) {
+			return
+		}
+		node.ForEachChild(visit)
+	}
+
+	switch node.Kind {
+	case ast.KindSourceFile:
+		collectList(node.AsSourceFile().Statements.Nodes)
+	case ast.KindModuleDeclaration:
+		if body := node.Body(); !ast.HasSyntacticModifier(node, ast.ModifierFlagsAmbient) && body != nil && ast.IsModuleBlock(body) {
+			collectList(body.AsModuleBlock().Statements.Nodes)
+		}
+	case ast.KindFunctionDeclaration, ast.KindFunctionExpression, ast.KindArrowFunction, ast.KindMethodDeclaration,
+		ast.KindGetAccessor, ast.KindSetAccessor:
+		if implementation := findImplementation(c, node); implementation != nil {
+			collectList(implementation.Parameters())
+			collect(implementation.Body())
+		}
+	case ast.KindClassDeclaration, ast.KindClassExpression:
+		collectList(getModifiersOfNode(node))
+		if heritage := ast.GetClassExtendsHeritageElement(node); heritage != nil {
+			collect(heritage.Expression())
+		}
+		for _, member := range node.Members() {
+			if ast.CanHaveModifiers(member) {
+				collectList(getModifiersOfNode(member))
+			}
+			switch {
+			case ast.IsPropertyDeclaration(member):
+				collect(member.Initializer())
+			case ast.IsConstructorDeclaration(member) && member.Body() != nil:
+				collectList(member.Parameters())
+				collect(member.Body())
+			case ast.IsClassStaticBlockDeclaration(member):
+				collect(member)
+			}
+		}
+	case ast.KindClassStaticBlockDeclaration:
+		collect(node.Body())
+	}
+	return callSites
+}
@@ -0,0 +1,157 @@
+package ls_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/pagpeter/typescript-go/external/bundled"
+	"github.com/pagpeter/typescript-go/external/fourslash"
+	"github.com/pagpeter/typescript-go/external/ls"
+	"github.com/pagpeter/typescript-go/external/lsp/lsproto"
+	"github.com/pagpeter/typescript-go/external/testutil/projecttestutil"
+	"gotest.tools/v3/assert"
+)
+
+func TestCallHierarchy(t *testing.T) {
+	t.Parallel()
+	if !bundled.Embedded {
+		t.Skip("bundled files are not embedded")
+	}
+
+	testCases := []struct {
+		title string
+		input string
+		// items as "name (detail)", calls as "name (detail): text of each range"
+		expectedItems    []string
+		expectedIncoming []string
+		expectedOutgoing []string
+	}{
+		{
+			title: "functions",
+			input: `function /*1*/foo() {
+    bar();
+    baz();
+    bar();
+}
+function bar() {}
+const baz = () => { bar(); };
+function caller() { foo(); }
+foo();`,
+			expectedItems:    []string{"foo"},
+			expectedIncoming: []string{"caller: foo", "/file1.ts: foo"},
+			expectedOutgoing: []string{"bar: bar, bar", "baz: baz"},
+		},
+		{
+			title: "methodFromReference",
+			input: `class C {
+    m() { this.n(); helper(); }
+    n() {}
+}
+function helper() {}
+function use(c: C) { c./*1*/m(); }`,
+			expectedItems:    []string{"m (C)"},
+			expectedIncoming: []string{"use: m"},
+			expectedOutgoing: []string{"n (C): this.n", "helper: helper"},
+		},
+		{
+			title: "constructor",
+			input: `class C {
+    /*1*/constructor() { init(); }
+}
+function init() {}
+function make() { return new C(); }`,
+			expectedItems:    []string{"C"},
+			expectedIncoming: []string{"make: C"},
+			expectedOutgoing: []string{"init: init"},
+		},
+		{
+			title: "propertyArrowFunction",
+			input: `class C {
+    /*1*/p = () => { helper(); };
+    m() { this.p(); }
+}
+function helper() {}`,
+			expectedItems:    []string{"p (C)"},
+			expectedIncoming: []string{"m (C): p"},
+			expectedOutgoing: []string{"helper: helper"},
+		},
+		{
+			title: "accessors",
+			input: `class C {
+    get /*1*/g() { return helper(); }
+    set s(v: number) { helper(); }
+}
+function helper() { return 1; }
+function use(c: C) { c.s = c.g; }`,
+			expectedItems:    []string{"g (C)"},
+			expectedIncoming: []string{"use: g"},
+			expectedOutgoing: []string{"helper: helper"},
+		},
+		{
+			title: "namespace",
+			input: `namespace N {
+    export function /*1*/f() {}
+}
+N.f();`,
+			expectedItems:    []string{"f (N)"},
+			expectedIncoming: []string{"/file1.ts: f"},
+			expectedOutgoing: []string{},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			t.Parallel()
+			testData := fourslash.ParseTestData(t, testCase.input, "/file1.ts")
+			file := testData.Files[0].FileName()
+			content := testData.Files[0].Content
+			ctx := projecttestutil.WithRequestID(t.Context())
+			service, done := createLanguageService(ctx, file, map[string]any{
+				file: content,
+			})
+			defer done()
+
+			items, err := service.ProvidePrepareCallHierarchy(ctx, ls.FileNameToDocumentURI(file), testData.MarkerPositions["1"].LSPosition)
+			assert.NilError(t, err)
+			itemNames := make([]string, len(items))
+			for i, item := range items {
+				itemNames[i] = formatCallHierarchyItem(item)
+			}
+			assert.DeepEqual(t, itemNames, testCase.expectedItems)
+
+			incomingCalls, err := service.ProvideCallHierarchyIncomingCalls(ctx, items[0])
+			assert.NilError(t, err)
+			incoming := make([]string, len(incomingCalls))
+			for i, call := range incomingCalls {
+				incoming[i] = formatCallHierarchyCall(content, call.From, call.FromRanges)
+			}
+			assert.DeepEqual(t, incoming, testCase.expectedIncoming)
+
+			outgoingCalls, err := service.ProvideCallHierarchyOutgoingCalls(ctx, items[0])
+			assert.NilError(t, err)
+			outgoing := make([]string, len(outgoingCalls))
+			for i, call := range outgoingCalls {
+				outgoing[i] = formatCallHierarchyCall(content, call.To, call.FromRanges)
+			}
+			assert.DeepEqual(t, outgoing, testCase.expectedOutgoing)
+		})
+	}
+}
+
+func formatCallHierarchyItem(item *lsproto.CallHierarchyItem) string {
+	if item.Detail != nil {
+		return fmt.Sprintf("%s (%s)", item.Name, *item.Detail)
+	}
+	return item.Name
+}
+
+func formatCallHierarchyCall(text string, item *lsproto.CallHierarchyItem, ranges []lsproto.Range) string {
+	lines := strings.Split(text, "\n")
+	texts := make([]string, len(ranges))
+	for i, r := range ranges {
+		// call ranges never span lines in these tests
+		texts[i] = lines[r.Start.Line][r.Start.Character:r.End.Character]
+	}
+	return formatCallHierarchyItem(item) + ": " + strings.Join(texts, ", ")
+}
@@ -0,0 +1,112 @@
+package ls
+
+import (
+	"github.com/pagpeter/typescript-go/external/ast"
+	"github.com/pagpeter/typescript-go/external/core"
+	"github.com/pagpeter/typescript-go/external/diagnostics"
+	"github.com/pagpeter/typescript-go/external/scanner"
+)
+
+const fixIdAddMissingAwait = "addMissingAwait"
+
+var addMissingAwaitCodeFixProvider = &codeFixProvider{
+	errorCodes: []int32{
+		diagnostics.An_arithmetic_operand_must_be_of_type_any_number_bigint_or_an_enum_type.Code(),
+		diagnostics.The_left_hand_side_of_an_arithmetic_operation_must_be_of_type_any_number_bigint_or_an_enum_type.Code(),
+		diagnostics.The_right_hand_side_of_an_arithmetic_operation_must_be_of_type_any_number_bigint_or_an_enum_type.Code(),
+		diagnostics.Operator_0_cannot_be_applied_to_type_1.Code(),
+		diagnostics.Operator_0_cannot_be_applied_to_types_1_and_2.Code(),
+		diagnostics.This_comparison_appears_to_be_unintentional_because_the_types_0_and_1_have_no_overlap.Code(),
+		diagnostics.This_condition_will_always_return_true_since_this_0_is_always_defined.Code(),
+		diagnostics.Type_0_is_not_an_array_type.Code(),
+		diagnostics.Type_0_is_not_an_array_type_or_a_string_type.Code(),
+		diagnostics.Type_0_can_only_be_iterated_through_when_using_the_downlevelIteration_flag_or_with_a_target_of_es2015_or_higher.Code(),
+		diagnostics.Type_0_is_not_an_array_type_or_a_string_type_or_does_not_have_a_Symbol_iterator_method_that_returns_an_iterator.Code(),
+		diagnostics.Type_0_is_not_an_array_type_or_does_not_have_a_Symbol_iterator_method_that_returns_an_iterator.Code(),
+		diagnostics.Type_0_must_have_a_Symbol_iterator_method_that_returns_an_iterator.Code(),
+		diagnostics.Type_0_must_have_a_Symbol_asyncIterator_method_that_returns_an_async_iterator.Code(),
+		diagnostics.Argument_of_type_0_is_not_assignable_to_parameter_of_type_1.Code(),
+		diagnostics.This_expression_is_not_callable.Code(),
+		diagnostics.This_expression_is_not_constructable.Code(),
+		diagnostics.Property_0_does_not_exist_on_type_1.Code(),
+	},
+	getCodeActions: getAddMissingAwaitCodeActions,
+}
+
+func getAddMissingAwaitCodeActions(fixContext *codeFixContext) []*codeFixAction {
+	expression := getAwaitErrorSpanExpression(fixContext)
+	if expression == nil {
+		return nil
+	}
+	changes := getAddMissingAwaitChanges(fixContext, expression)
+	if len(changes) == 0 {
+		return nil
+	}
+	return []*codeFixAction{{
+		description:       diagnostics.Add_await.Message(),
+		changes:           changes,
+		fixId:             fixIdAddMissingAwait,
+		fixAllDescription: diagnostics.Fix_all_expressions_possibly_missing_await.Message(),
+	}}
+}
+
+func getAwaitErrorSpanExpression(fixContext *codeFixContext) *ast.Node {
+	expression := getFixableErrorSpanExpression(fixContext.sourceFile, fixContext.span)
+	if expression == nil || !isMissingAwaitError(fixContext) || !isInsideAwaitableBody(expression) {
+		return nil
+	}
+	return expression
+}
+
+// The checker has already determined that an `await` might be missing and attached related information to
+// the diagnostic, so find the expression that exactly matches the diagnostic range.
+func getFixableErrorSpanExpression(file *ast.SourceFile, span core.TextRange) *ast.Node {
+	return ast.FindAncestorOrQuit(getTokenAtSpan(file, span), func(node *ast.Node) ast.FindAncestorResult {
+		start := scanner.GetTokenPosOfNode(node, file, false /*includeJSDoc*/)
+		if start < span.Pos() || node.End() > span.End() {
+			return ast.FindAncestorQuit
+		}
+		return ast.ToFindAncestorResult(ast.IsExpression(node) && start == span.Pos() && node.End() == span.End())
+	})
+}
+
+func isMissingAwaitError(fixContext *codeFixContext) bool {
+	return core.Some(fixContext.program.GetSemanticDiagnostics(fixContext.ctx, fixContext.sourceFile), func(diagnostic *ast.Diagnostic) bool {
+		return diagnostic.Code() == fixContext.errorCode &&
+			diagnostic.Loc() == fixContext.span &&
+			core.Some(diagnostic.RelatedInformation(), func(related *ast.Diagnostic) bool {
+				return related.Code() == diagnostics.Did_you_forget_to_use_await.Code()
+			})
+	})
+}
+
+func isInsideAwaitableBody(node *ast.Node) bool {
+	if node.Flags&ast.NodeFlagsAwaitContext != 0 {
+		return true
+	}
+	container := ast.FindAncestor(node.Parent, ast.IsFunctionLike)
+	return container != nil && ast.HasSyntacticModifier(container, ast.ModifierFlagsAsync)
+}
+
+func getAddMissingAwaitChanges(fixContext *codeFixContext, insertionSite *ast.Node) []core.TextChange {
+	if ast.IsBinaryExpression(insertionSite) {
+		var changes []core.TextChange
+		binary := insertionSite.AsBinaryExpression()
+		for _, side := range []*ast.Node{binary.Left, binary.Right} {
+			if fixContext.checker.GetPromisedTypeOfPromise(fixContext.checker.GetTypeAtLocation(side)) != nil {
+				changes = append(changes, getInsertAwaitChange(fixContext.sourceFile, side))
+			}
+		}
+		return changes
+	}
+	return []core.TextChange{getInsertAwaitChange(fixContext.sourceFile, insertionSite)}
+}
+
+func getInsertAwaitChange(file *ast.SourceFile, expression *ast.Node) core.TextChange {
+	text := "await " + scanner.GetTextOfNode(expression)
+	// `x.y`, `x[y]`, `x()` and `new x()` need parentheses around the awaited expression.
+	if parent := expression.Parent; (ast.IsAccessExpression(parent) || ast.IsCallExpression(parent) || ast.IsNewExpression(parent)) && parent.Expression() == expression {
+		text = "(" + text + ")"
+	}
+	return newReplaceNodeTextChange(file, expression, text)
+}
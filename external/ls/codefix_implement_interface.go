@@ -0,0 +1,147 @@
+package ls
+
+import (
+	"strings"
+
+	"github.com/pagpeter/typescript-go/external/ast"
+	"github.com/pagpeter/typescript-go/external/checker"
+	"github.com/pagpeter/typescript-go/external/collections"
+	"github.com/pagpeter/typescript-go/external/core"
+	"github.com/pagpeter/typescript-go/external/diagnostics"
+	"github.com/pagpeter/typescript-go/external/scanner"
+)
+
+const fixIdImplementInterface = "fixClassIncorrectlyImplementsInterface"
+
+var implementInterfaceCodeFixProvider = &codeFixProvider{
+	errorCodes: []int32{
+		diagnostics.Class_0_incorrectly_implements_interface_1.Code(),
+		diagnostics.Class_0_incorrectly_implements_class_1_Did_you_mean_to_extend_1_and_inherit_its_members_as_a_subclass.Code(),
+	},
+	getCodeActions:    getImplementInterfaceCodeActions,
+	getAllCodeActions: getAllImplementInterfaceCodeActions,
+}
+
+func getImplementInterfaceCodeActions(fixContext *codeFixContext) []*codeFixAction {
+	file := fixContext.sourceFile
+	classDeclaration := ast.FindAncestor(getTokenAtSpan(file, fixContext.span), ast.IsClassLike)
+	if classDeclaration == nil {
+		return nil
+	}
+	var actions []*codeFixAction
+	for _, typeNode := range ast.GetImplementsTypeNodes(classDeclaration) {
+		members := getMissingInterfaceMembers(fixContext.checker, classDeclaration, []*ast.Node{typeNode})
+		if len(members) == 0 {
+			continue
+		}
+		actions = append(actions, &codeFixAction{
+			description:       diagnostics.Implement_interface_0.Format(scanner.GetTextOfNode(typeNode)),
+			changes:           []core.TextChange{getInsertClassMembersChange(file, classDeclaration, members)},
+			fixId:             fixIdImplementInterface,
+			fixAllDescription: diagnostics.Implement_all_unimplemented_interfaces.Message(),
+		})
+	}
+	return actions
+}
+
+// getAllImplementInterfaceCodeActions implements every interface of each class with a diagnostic, adding a
+// member that is declared by more than one interface only once.
+func getAllImplementInterfaceCodeActions(fixContext *codeFixAllContext) []core.TextChange {
+	file := fixContext.sourceFile
+	var changes []core.TextChange
+	seenClasses := collections.Set[*ast.Node]{}
+	for _, diagnostic := range fixContext.diagnostics {
+		classDeclaration := ast.FindAncestor(getTokenAtSpan(file, diagnostic.Loc()), ast.IsClassLike)
+		if classDeclaration == nil || !seenClasses.AddIfAbsent(classDeclaration) {
+			continue
+		}
+		members := getMissingInterfaceMembers(fixContext.checker, classDeclaration, ast.GetImplementsTypeNodes(classDeclaration))
+		if len(members) != 0 {
+			changes = append(changes, getInsertClassMembersChange(file, classDeclaration, members))
+		}
+	}
+	return combineTextChanges(changes)
+}
+
+// getMissingInterfaceMembers returns the text of a declaration for each member of the implemented types
+// that the class neither declares nor inherits.
+// !!! index signatures, computed property names
+func getMissingInterfaceMembers(c *checker.Checker, classDeclaration *ast.Node, typeNodes []*ast.Node) []string {
+	classType := c.GetDeclaredTypeOfSymbol(classDeclaration.Symbol())
+	seenNames := collections.Set[string]{}
+	for _, property := range c.GetPropertiesOfType(classType) {
+		seenNames.Add(property.Name)
+	}
+	var members []string
+	for _, typeNode := range typeNodes {
+		for _, property := range c.GetPropertiesOfType(c.GetTypeAtLocation(typeNode)) {
+			if strings.HasPrefix(property.Name, ast.InternalSymbolNamePrefix) || isPrivateMemberSymbol(property) || !seenNames.AddIfAbsent(property.Name) {
+				continue
+			}
+			members = append(members, getInterfaceMemberText(c, classDeclaration, property)...)
+		}
+	}
+	return members
+}
+
+func isPrivateMemberSymbol(symbol *ast.Symbol) bool {
+	declaration := symbol.ValueDeclaration
+	return declaration != nil && (ast.HasSyntacticModifier(declaration, ast.ModifierFlagsPrivate) || declaration.Name() != nil && ast.IsPrivateIdentifier(declaration.Name()))
+}
+
+// getInterfaceMemberText returns the declarations implementing a property or method, one per line. A method
+// with several signatures is implemented with an overload for each signature followed by an implementation
+// that accepts any arguments.
+func getInterfaceMemberText(c *checker.Checker, classDeclaration *ast.Node, property *ast.Symbol) []string {
+	name := property.Name
+	if !scanner.IsIdentifierText(name, ast.GetSourceFileOfNode(classDeclaration).LanguageVariant) {
+		name = quote(ast.GetSourceFileOfNode(classDeclaration), nil /*preferences*/, name)
+	}
+	if property.Flags&ast.SymbolFlagsOptional != 0 {
+		name += "?"
+	}
+	propertyType := c.GetTypeOfSymbol(property)
+	signatures := c.GetSignaturesOfType(propertyType, checker.SignatureKindCall)
+	if property.Flags&ast.SymbolFlagsMethod == 0 || len(signatures) == 0 {
+		return []string{name + ": " + c.TypeToStringEx(propertyType, classDeclaration, checker.TypeFormatFlagsNoTruncation) + ";"}
+	}
+	const body = " {\n" + lsIndent + `throw new Error("Method not implemented.");` + "\n}"
+	if len(signatures) == 1 {
+		return []string{name + c.SignatureToStringEx(signatures[0], classDeclaration, checker.TypeFormatFlagsNoTruncation|checker.TypeFormatFlagsWriteCallStyleSignature) + body}
+	}
+	var lines []string
+	for _, signature := range signatures {
+		lines = append(lines, name+c.SignatureToStringEx(signature, classDeclaration, checker.TypeFormatFlagsNoTruncation|checker.TypeFormatFlagsWriteCallStyleSignature)+";")
+	}
+	return append(lines, name+"(...args: any[]): any"+body)
+}
+
+const lsIndent = "    "
+
+// getInsertClassMembersChange inserts members before the closing brace of a class, indenting each line of a
+// member like the existing members of the class.
+func getInsertClassMembersChange(file *ast.SourceFile, classDeclaration *ast.Node, members []string) core.TextChange {
+	text := file.Text()
+	memberList := classDeclaration.MemberList()
+	var memberIndentation string
+	if len(memberList.Nodes) != 0 {
+		memberIndentation = getIndentationOfLine(text, scanner.GetTokenPosOfNode(memberList.Nodes[0], file, false /*includeJSDoc*/))
+	} else {
+		memberIndentation = getIndentationOfLine(text, scanner.GetTokenPosOfNode(classDeclaration, file, false /*includeJSDoc*/)) + lsIndent
+	}
+	var b strings.Builder
+	for _, member := range members {
+		for line := range strings.SplitSeq(member, "\n") {
+			b.WriteString("\n")
+			b.WriteString(memberIndentation)
+			b.WriteString(line)
+		}
+	}
+	closeBracePos := scanner.SkipTrivia(text, memberList.End())
+	if !strings.ContainsAny(text[memberList.End():closeBracePos], "\r\n") {
+		// class C implements I {}
+		b.WriteString("\n")
+		b.WriteString(getIndentationOfLine(text, scanner.GetTokenPosOfNode(classDeclaration, file, false /*includeJSDoc*/)))
+	}
+	return newInsertTextChange(memberList.End(), b.String())
+}
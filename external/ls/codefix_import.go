@@ -0,0 +1,158 @@
+package ls
+
+import (
+	"strings"
+
+	"github.com/pagpeter/typescript-go/external/ast"
+	"github.com/pagpeter/typescript-go/external/checker"
+	"github.com/pagpeter/typescript-go/external/compiler"
+	"github.com/pagpeter/typescript-go/external/core"
+	"github.com/pagpeter/typescript-go/external/diagnostics"
+	"github.com/pagpeter/typescript-go/external/scanner"
+	"github.com/pagpeter/typescript-go/external/tspath"
+)
+
+const fixIdMissingImport = "fixMissingImport"
+
+var importCodeFixProvider = &codeFixProvider{
+	errorCodes: []int32{
+		diagnostics.Cannot_find_name_0.Code(),
+		diagnostics.Cannot_find_name_0_Did_you_mean_1.Code(),
+		diagnostics.Cannot_find_namespace_0.Code(),
+	},
+	getCodeActions:    getImportCodeActions,
+	getAllCodeActions: getAllImportCodeActions,
+}
+
+type importFixInfo struct {
+	name            string
+	moduleSpecifier string
+	// existingImport is the named imports of an import declaration of moduleSpecifier, if there is one.
+	existingImport *ast.Node
+}
+
+func getImportCodeActions(fixContext *codeFixContext) []*codeFixAction {
+	var actions []*codeFixAction
+	for _, info := range getImportFixInfos(fixContext.program, fixContext.checker, fixContext.sourceFile, fixContext.span) {
+		var description string
+		if info.existingImport != nil {
+			description = diagnostics.Update_import_from_0.Format(info.moduleSpecifier)
+		} else {
+			description = diagnostics.Add_import_from_0.Format(info.moduleSpecifier)
+		}
+		actions = append(actions, &codeFixAction{
+			description:       description,
+			changes:           []core.TextChange{getImportTextChange(fixContext.sourceFile, info.moduleSpecifier, info.existingImport, []string{info.name})},
+			fixId:             fixIdMissingImport,
+			fixAllDescription: diagnostics.Add_all_missing_imports.Message(),
+		})
+	}
+	return actions
+}
+
+// getAllImportCodeActions adds every missing name from the same module with a single import, using the
+// first module that exports each name.
+func getAllImportCodeActions(fixContext *codeFixAllContext) []core.TextChange {
+	var moduleSpecifiers []string
+	existingImports := map[string]*ast.Node{}
+	namesByModuleSpecifier := map[string][]string{}
+	for _, diagnostic := range fixContext.diagnostics {
+		infos := getImportFixInfos(fixContext.program, fixContext.checker, fixContext.sourceFile, diagnostic.Loc())
+		if len(infos) == 0 {
+			continue
+		}
+		info := infos[0]
+		names, ok := namesByModuleSpecifier[info.moduleSpecifier]
+		if !ok {
+			moduleSpecifiers = append(moduleSpecifiers, info.moduleSpecifier)
+			existingImports[info.moduleSpecifier] = info.existingImport
+		}
+		if !core.Some(names, func(name string) bool { return name == info.name }) {
+			namesByModuleSpecifier[info.moduleSpecifier] = append(names, info.name)
+		}
+	}
+	var changes []core.TextChange
+	for _, moduleSpecifier := range moduleSpecifiers {
+		changes = append(changes, getImportTextChange(fixContext.sourceFile, moduleSpecifier, existingImports[moduleSpecifier], namesByModuleSpecifier[moduleSpecifier]))
+	}
+	return combineTextChanges(changes)
+}
+
+// getImportFixInfos finds the modules of the program that export the unresolved name at the span.
+// !!! node_modules packages, default exports, namespace imports
+func getImportFixInfos(program *compiler.Program, checker *checker.Checker, file *ast.SourceFile, span core.TextRange) []*importFixInfo {
+	token := getTokenAtSpan(file, span)
+	if !ast.IsIdentifier(token) {
+		return nil
+	}
+	name := token.Text()
+	var infos []*importFixInfo
+	for _, moduleFile := range program.GetSourceFiles() {
+		if moduleFile == file || !ast.IsExternalModule(moduleFile) ||
+			program.IsSourceFileDefaultLibrary(moduleFile.Path()) || program.IsSourceFileFromExternalLibrary(moduleFile) {
+			continue
+		}
+		moduleSymbol := checker.GetMergedSymbol(moduleFile.Symbol)
+		if moduleSymbol == nil || !core.Some(checker.GetExportsOfModule(moduleSymbol), func(symbol *ast.Symbol) bool { return symbol.Name == name }) {
+			continue
+		}
+		moduleSpecifier := getRelativeModuleSpecifier(program, file, moduleFile)
+		infos = append(infos, &importFixInfo{
+			name:            name,
+			moduleSpecifier: moduleSpecifier,
+			existingImport:  findNamedImportsForModuleSpecifier(file, moduleSpecifier),
+		})
+	}
+	return infos
+}
+
+func getRelativeModuleSpecifier(program *compiler.Program, importingFile *ast.SourceFile, moduleFile *ast.SourceFile) string {
+	relativePath := tspath.GetRelativePathFromFile(importingFile.FileName(), moduleFile.FileName(), tspath.ComparePathsOptions{
+		UseCaseSensitiveFileNames: program.UseCaseSensitiveFileNames(),
+		CurrentDirectory:          program.GetCurrentDirectory(),
+	})
+	return tspath.EnsurePathIsNonModuleName(tspath.RemoveFileExtension(relativePath))
+}
+
+func findNamedImportsForModuleSpecifier(file *ast.SourceFile, moduleSpecifier string) *ast.Node {
+	for _, statement := range file.Statements.Nodes {
+		if !ast.IsImportDeclaration(statement) {
+			continue
+		}
+		importDeclaration := statement.AsImportDeclaration()
+		if !ast.IsStringLiteral(importDeclaration.ModuleSpecifier) || importDeclaration.ModuleSpecifier.Text() != moduleSpecifier {
+			continue
+		}
+		importClause := importDeclaration.ImportClause
+		if importClause == nil || importClause.AsImportClause().IsTypeOnly {
+			continue
+		}
+		namedBindings := importClause.AsImportClause().NamedBindings
+		if namedBindings != nil && ast.IsNamedImports(namedBindings) && len(namedBindings.AsNamedImports().Elements.Nodes) != 0 {
+			return namedBindings
+		}
+	}
+	return nil
+}
+
+func getImportTextChange(file *ast.SourceFile, moduleSpecifier string, existingImport *ast.Node, names []string) core.TextChange {
+	if existingImport != nil {
+		elements := existingImport.AsNamedImports().Elements.Nodes
+		return newInsertTextChange(elements[len(elements)-1].End(), ", "+strings.Join(names, ", "))
+	}
+	importText := "import { " + strings.Join(names, ", ") + " } from " + quote(file, nil /*preferences*/, moduleSpecifier)
+	if probablyUsesSemicolons(file) {
+		importText += ";"
+	}
+	// Insert after the last import declaration, or before the first statement.
+	var lastImport *ast.Node
+	for _, statement := range file.Statements.Nodes {
+		if ast.IsAnyImportSyntax(statement) {
+			lastImport = statement
+		}
+	}
+	if lastImport != nil {
+		return newInsertTextChange(lastImport.End(), "\n"+importText)
+	}
+	return newInsertTextChange(scanner.GetTokenPosOfNode(file.Statements.Nodes[0], file, false /*includeJSDoc*/), importText+"\n")
+}
@@ -0,0 +1,99 @@
+package ls
+
+import (
+	"github.com/pagpeter/typescript-go/external/ast"
+	"github.com/pagpeter/typescript-go/external/core"
+	"github.com/pagpeter/typescript-go/external/diagnostics"
+	"github.com/pagpeter/typescript-go/external/scanner"
+)
+
+const (
+	fixIdAddOverrideModifier    = "fixAddOverrideModifier"
+	fixIdRemoveOverrideModifier = "fixRemoveOverrideModifier"
+)
+
+var addOverrideModifierCodeFixProvider = &codeFixProvider{
+	errorCodes: []int32{
+		diagnostics.This_member_must_have_an_override_modifier_because_it_overrides_a_member_in_the_base_class_0.Code(),
+		diagnostics.This_parameter_property_must_have_an_override_modifier_because_it_overrides_a_member_in_base_class_0.Code(),
+		diagnostics.This_member_must_have_an_override_modifier_because_it_overrides_an_abstract_method_that_is_declared_in_the_base_class_0.Code(),
+	},
+	getCodeActions: getAddOverrideModifierCodeActions,
+}
+
+var removeOverrideModifierCodeFixProvider = &codeFixProvider{
+	errorCodes: []int32{
+		diagnostics.This_member_cannot_have_an_override_modifier_because_its_containing_class_0_does_not_extend_another_class.Code(),
+		diagnostics.This_member_cannot_have_an_override_modifier_because_it_is_not_declared_in_the_base_class_0.Code(),
+		diagnostics.This_member_cannot_have_an_override_modifier_because_it_is_not_declared_in_the_base_class_0_Did_you_mean_1.Code(),
+	},
+	getCodeActions: getRemoveOverrideModifierCodeActions,
+}
+
+func getAddOverrideModifierCodeActions(fixContext *codeFixContext) []*codeFixAction {
+	file := fixContext.sourceFile
+	classElement := findContainerClassElementLike(file, fixContext.span)
+	if classElement == nil {
+		return nil
+	}
+	// `override` goes after any accessibility, `static` or `abstract` modifier and before any `readonly` or `async`.
+	var precedingModifier, lastDecorator *ast.Node
+	for _, modifier := range getModifiersOfNode(classElement) {
+		switch modifier.Kind {
+		case ast.KindPublicKeyword, ast.KindPrivateKeyword, ast.KindProtectedKeyword, ast.KindStaticKeyword, ast.KindAbstractKeyword:
+			precedingModifier = modifier
+		case ast.KindDecorator:
+			lastDecorator = modifier
+		}
+	}
+	var change core.TextChange
+	switch {
+	case precedingModifier != nil:
+		change = newInsertTextChange(precedingModifier.End(), " override")
+	case lastDecorator != nil:
+		change = newInsertTextChange(scanner.SkipTrivia(file.Text(), lastDecorator.End()), "override ")
+	default:
+		change = newInsertTextChange(scanner.GetTokenPosOfNode(classElement, file, false /*includeJSDoc*/), "override ")
+	}
+	return []*codeFixAction{{
+		description:       diagnostics.Add_override_modifier.Message(),
+		changes:           []core.TextChange{change},
+		fixId:             fixIdAddOverrideModifier,
+		fixAllDescription: diagnostics.Add_all_missing_override_modifiers.Message(),
+	}}
+}
+
+func getRemoveOverrideModifierCodeActions(fixContext *codeFixContext) []*codeFixAction {
+	file := fixContext.sourceFile
+	classElement := findContainerClassElementLike(file, fixContext.span)
+	if classElement == nil {
+		return nil
+	}
+	overrideModifier := core.Find(getModifiersOfNode(classElement), func(modifier *ast.Node) bool { return modifier.Kind == ast.KindOverrideKeyword })
+	if overrideModifier == nil {
+		return nil
+	}
+	start := scanner.GetTokenPosOfNode(overrideModifier, file, false /*includeJSDoc*/)
+	return []*codeFixAction{{
+		description:       diagnostics.Remove_override_modifier.Message(),
+		changes:           []core.TextChange{{TextRange: core.NewTextRange(start, scanner.SkipTrivia(file.Text(), overrideModifier.End()))}},
+		fixId:             fixIdRemoveOverrideModifier,
+		fixAllDescription: diagnostics.Remove_all_unnecessary_override_modifiers.Message(),
+	}}
+}
+
+func findContainerClassElementLike(file *ast.SourceFile, span core.TextRange) *ast.Node {
+	return ast.FindAncestorOrQuit(getTokenAtSpan(file, span), func(node *ast.Node) ast.FindAncestorResult {
+		if ast.IsClassLike(node) {
+			return ast.FindAncestorQuit
+		}
+		return ast.ToFindAncestorResult(ast.IsClassElement(node) || ast.IsParameterPropertyDeclaration(node, node.Parent))
+	})
+}
+
+func getModifiersOfNode(node *ast.Node) []*ast.Node {
+	if modifiers := node.Modifiers(); modifiers != nil {
+		return modifiers.Nodes
+	}
+	return nil
+}
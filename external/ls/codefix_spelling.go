@@ -0,0 +1,83 @@
+package ls
+
+import (
+	"github.com/pagpeter/typescript-go/external/ast"
+	"github.com/pagpeter/typescript-go/external/checker"
+	"github.com/pagpeter/typescript-go/external/core"
+	"github.com/pagpeter/typescript-go/external/diagnostics"
+	"github.com/pagpeter/typescript-go/external/scanner"
+)
+
+const fixIdSpelling = "fixSpelling"
+
+var spellingCodeFixProvider = &codeFixProvider{
+	errorCodes: []int32{
+		diagnostics.Property_0_does_not_exist_on_type_1_Did_you_mean_2.Code(),
+		diagnostics.Property_0_may_not_exist_on_type_1_Did_you_mean_2.Code(),
+		diagnostics.Cannot_find_name_0_Did_you_mean_1.Code(),
+		diagnostics.Could_not_find_name_0_Did_you_mean_1.Code(),
+		diagnostics.Cannot_find_namespace_0_Did_you_mean_1.Code(),
+		diagnostics.X_0_has_no_exported_member_named_1_Did_you_mean_2.Code(),
+	},
+	getCodeActions: getSpellingCodeActions,
+}
+
+func getSpellingCodeActions(fixContext *codeFixContext) []*codeFixAction {
+	node, suggestedSymbol := getSpellingSuggestion(fixContext.checker, fixContext.sourceFile, fixContext.span)
+	if suggestedSymbol == nil {
+		return nil
+	}
+	suggestion := ast.SymbolName(suggestedSymbol)
+	return []*codeFixAction{{
+		description:       diagnostics.Change_spelling_to_0.Format(suggestion),
+		changes:           []core.TextChange{getSpellingTextChange(fixContext.sourceFile, node, suggestedSymbol, suggestion)},
+		fixId:             fixIdSpelling,
+		fixAllDescription: diagnostics.Fix_all_detected_spelling_errors.Message(),
+	}}
+}
+
+// getSpellingSuggestion returns the misspelled name at the span along with the symbol it most likely refers to.
+// !!! private identifiers in `in` expressions, JSX attributes, override modifiers
+func getSpellingSuggestion(checker *checker.Checker, file *ast.SourceFile, span core.TextRange) (*ast.Node, *ast.Symbol) {
+	node := getTokenAtSpan(file, span)
+	parent := node.Parent
+	var suggestedSymbol *ast.Symbol
+	switch {
+	case ast.IsPropertyAccessExpression(parent) && parent.Name() == node:
+		containingType := checker.GetTypeAtLocation(parent.Expression())
+		if parent.Flags&ast.NodeFlagsOptionalChain != 0 {
+			containingType = checker.GetNonNullableType(containingType)
+		}
+		suggestedSymbol = checker.GetSuggestedSymbolForNonexistentProperty(node, containingType)
+	case ast.IsQualifiedName(parent) && parent.AsQualifiedName().Right == node:
+		symbol := checker.GetSymbolAtLocation(parent.AsQualifiedName().Left)
+		if symbol != nil && symbol.Flags&ast.SymbolFlagsModule != 0 {
+			suggestedSymbol = checker.GetSuggestedSymbolForNonexistentModule(node, symbol)
+		}
+	case ast.IsImportSpecifier(parent) && parent.Name() == node:
+		importDeclaration := ast.FindAncestor(node, ast.IsImportDeclaration)
+		if importDeclaration == nil {
+			return nil, nil
+		}
+		if moduleSymbol := checker.GetSymbolAtLocation(importDeclaration.AsImportDeclaration().ModuleSpecifier); moduleSymbol != nil {
+			suggestedSymbol = checker.GetSuggestedSymbolForNonexistentModule(node, moduleSymbol)
+		}
+	case ast.IsIdentifier(node):
+		meaning := getMeaningFromLocation(node)
+		suggestedSymbol = checker.GetSuggestedSymbolForNonexistentSymbol(node, node.Text(), convertSemanticMeaningToSymbolFlags(meaning))
+	}
+	return node, suggestedSymbol
+}
+
+func getSpellingTextChange(file *ast.SourceFile, node *ast.Node, suggestedSymbol *ast.Symbol, suggestion string) core.TextChange {
+	parent := node.Parent
+	if !scanner.IsIdentifierText(suggestion, file.LanguageVariant) && ast.IsPropertyAccessExpression(parent) {
+		valueDeclaration := suggestedSymbol.ValueDeclaration
+		if valueDeclaration == nil || valueDeclaration.Name() == nil || !ast.IsPrivateIdentifier(valueDeclaration.Name()) {
+			// o.ab -> o["a b"]
+			expression := parent.Expression()
+			return newReplaceNodeTextChange(file, parent, scanner.GetTextOfNode(expression)+"["+quote(file, nil /*preferences*/, suggestion)+"]")
+		}
+	}
+	return newReplaceNodeTextChange(file, node, suggestion)
+}
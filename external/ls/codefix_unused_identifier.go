@@ -0,0 +1,156 @@
+package ls
+
+import (
+	"github.com/pagpeter/typescript-go/external/ast"
+	"github.com/pagpeter/typescript-go/external/core"
+	"github.com/pagpeter/typescript-go/external/diagnostics"
+	"github.com/pagpeter/typescript-go/external/scanner"
+)
+
+const (
+	fixIdDeleteUnused = "unusedIdentifier_delete"
+	fixIdPrefixUnused = "unusedIdentifier_prefix"
+)
+
+var unusedIdentifierCodeFixProvider = &codeFixProvider{
+	errorCodes: []int32{
+		diagnostics.X_0_is_declared_but_its_value_is_never_read.Code(),
+		diagnostics.X_0_is_declared_but_never_used.Code(),
+		diagnostics.All_imports_in_import_declaration_are_unused.Code(),
+		diagnostics.All_variables_are_unused.Code(),
+		diagnostics.All_type_parameters_are_unused.Code(),
+	},
+	getCodeActions: getUnusedIdentifierCodeActions,
+}
+
+func getUnusedIdentifierCodeActions(fixContext *codeFixContext) []*codeFixAction {
+	file := fixContext.sourceFile
+	token := getTokenAtSpan(file, fixContext.span)
+	switch fixContext.errorCode {
+	case diagnostics.All_imports_in_import_declaration_are_unused.Code():
+		if importDeclaration := ast.FindAncestor(token, ast.IsImportDeclaration); importDeclaration != nil {
+			moduleSpecifier := importDeclaration.AsImportDeclaration().ModuleSpecifier
+			return []*codeFixAction{newDeleteUnusedCodeFixAction(
+				diagnostics.Remove_import_from_0.Format(moduleSpecifier.Text()),
+				newDeleteNodeTextChange(file, importDeclaration),
+			)}
+		}
+		return nil
+	case diagnostics.All_variables_are_unused.Code():
+		if statement := ast.FindAncestor(token, ast.IsVariableStatement); statement != nil {
+			return []*codeFixAction{newDeleteUnusedCodeFixAction(diagnostics.Remove_variable_statement.Message(), newDeleteNodeTextChange(file, statement))}
+		}
+		return nil
+	case diagnostics.All_type_parameters_are_unused.Code():
+		return []*codeFixAction{newDeleteUnusedCodeFixAction(diagnostics.Remove_type_parameters.Message(), core.TextChange{TextRange: fixContext.span})}
+	}
+
+	declaration := getUnusedDeclarationOfName(token)
+	if declaration == nil {
+		return nil
+	}
+	if ast.IsParameter(declaration) {
+		// Parameters are prefixed rather than removed, since callers may still pass arguments for them.
+		if !ast.IsIdentifier(token) || !canPrefixUnusedParameter(declaration) {
+			return nil
+		}
+		return []*codeFixAction{{
+			description:       diagnostics.Prefix_0_with_an_underscore.Format(token.Text()),
+			changes:           []core.TextChange{newInsertTextChange(scanner.GetTokenPosOfNode(token, file, false /*includeJSDoc*/), "_")},
+			fixId:             fixIdPrefixUnused,
+			fixAllDescription: diagnostics.Prefix_all_unused_declarations_with_where_possible.Message(),
+		}}
+	}
+	change, ok := getDeleteUnusedDeclarationChange(file, declaration)
+	if !ok {
+		return nil
+	}
+	return []*codeFixAction{newDeleteUnusedCodeFixAction(diagnostics.Remove_unused_declaration_for_Colon_0.Format(token.Text()), change)}
+}
+
+func newDeleteUnusedCodeFixAction(description string, change core.TextChange) *codeFixAction {
+	return &codeFixAction{
+		description:       description,
+		changes:           []core.TextChange{change},
+		fixId:             fixIdDeleteUnused,
+		fixAllDescription: diagnostics.Delete_all_unused_declarations.Message(),
+	}
+}
+
+// getUnusedDeclarationOfName returns the declaration named by the token of an unused identifier diagnostic.
+func getUnusedDeclarationOfName(token *ast.Node) *ast.Node {
+	if ast.IsTypeParameterDeclaration(token.Parent) {
+		return token.Parent
+	}
+	if !ast.IsIdentifier(token) && !ast.IsPrivateIdentifier(token) || token.Parent.Name() != token {
+		return nil
+	}
+	return token.Parent
+}
+
+func canPrefixUnusedParameter(parameter *ast.Node) bool {
+	name := parameter.Name()
+	return ast.IsIdentifier(name) && name.Text() != "" && name.Text()[0] != '_' && !ast.IsParameterPropertyDeclaration(parameter, parameter.Parent)
+}
+
+func getDeleteUnusedDeclarationChange(file *ast.SourceFile, declaration *ast.Node) (core.TextChange, bool) {
+	switch declaration.Kind {
+	case ast.KindImportSpecifier:
+		namedImports := declaration.Parent
+		elements := namedImports.AsNamedImports().Elements.Nodes
+		if len(elements) > 1 {
+			return newDeleteListElementTextChange(file, declaration, elements), true
+		}
+		importClause := namedImports.Parent
+		if importClause.Name() == nil {
+			return newDeleteNodeTextChange(file, importClause.Parent), true
+		}
+		// import d, { x } from "m";
+		return core.TextChange{TextRange: core.NewTextRange(importClause.Name().End(), namedImports.End())}, true
+	case ast.KindNamespaceImport:
+		importClause := declaration.Parent
+		if importClause.Name() == nil {
+			return newDeleteNodeTextChange(file, importClause.Parent), true
+		}
+		// import d, * as ns from "m";
+		return core.TextChange{TextRange: core.NewTextRange(importClause.Name().End(), declaration.End())}, true
+	case ast.KindImportClause:
+		namedBindings := declaration.AsImportClause().NamedBindings
+		if namedBindings == nil {
+			return newDeleteNodeTextChange(file, declaration.Parent), true
+		}
+		// import d, { x } from "m";
+		return core.TextChange{TextRange: core.NewTextRange(scanner.GetTokenPosOfNode(declaration, file, false /*includeJSDoc*/), scanner.GetTokenPosOfNode(namedBindings, file, false /*includeJSDoc*/))}, true
+	case ast.KindImportEqualsDeclaration:
+		return newDeleteNodeTextChange(file, declaration), true
+	case ast.KindVariableDeclaration:
+		declarationList := declaration.Parent
+		if ast.IsForInOrOfStatement(declarationList.Parent) {
+			return core.TextChange{}, false
+		}
+		declarations := declarationList.AsVariableDeclarationList().Declarations.Nodes
+		if len(declarations) > 1 {
+			return newDeleteListElementTextChange(file, declaration, declarations), true
+		}
+		if ast.IsVariableStatement(declarationList.Parent) {
+			return newDeleteNodeTextChange(file, declarationList.Parent), true
+		}
+		return core.TextChange{}, false
+	case ast.KindTypeParameter:
+		typeParameters := declaration.Parent.TypeParameterList()
+		if typeParameters == nil {
+			// infer T
+			return core.TextChange{}, false
+		}
+		if len(typeParameters.Nodes) > 1 {
+			return newDeleteListElementTextChange(file, declaration, typeParameters.Nodes), true
+		}
+		text := file.Text()
+		return core.TextChange{TextRange: core.NewTextRange(typeParameters.Pos()-1, min(len(text), scanner.SkipTrivia(text, typeParameters.End())+1))}, true
+	case ast.KindPropertyDeclaration, ast.KindMethodDeclaration, ast.KindGetAccessor, ast.KindSetAccessor,
+		ast.KindFunctionDeclaration, ast.KindClassDeclaration, ast.KindInterfaceDeclaration, ast.KindTypeAliasDeclaration,
+		ast.KindEnumDeclaration, ast.KindModuleDeclaration:
+		return newDeleteNodeTextChange(file, declaration), true
+	}
+	return core.TextChange{}, false
+}
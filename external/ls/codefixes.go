@@ -0,0 +1,275 @@
+package ls
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/pagpeter/typescript-go/external/ast"
+	"github.com/pagpeter/typescript-go/external/astnav"
+	"github.com/pagpeter/typescript-go/external/checker"
+	"github.com/pagpeter/typescript-go/external/collections"
+	"github.com/pagpeter/typescript-go/external/compiler"
+	"github.com/pagpeter/typescript-go/external/core"
+	"github.com/pagpeter/typescript-go/external/lsp/lsproto"
+	"github.com/pagpeter/typescript-go/external/scanner"
+)
+
+// A codeFixProvider offers edits that resolve diagnostics with one of its error codes.
+type codeFixProvider struct {
+	errorCodes []int32
+	// getCodeActions returns the fixes for a single diagnostic.
+	getCodeActions func(fixContext *codeFixContext) []*codeFixAction
+	// getAllCodeActions, when set, computes the changes for fixing every diagnostic of the provider in
+	// a file. Otherwise the changes of each diagnostic's fix with the requested fix id are combined.
+	getAllCodeActions func(fixContext *codeFixAllContext) []core.TextChange
+}
+
+type codeFixContext struct {
+	ctx        context.Context
+	program    *compiler.Program
+	checker    *checker.Checker
+	sourceFile *ast.SourceFile
+	errorCode  int32
+	span       core.TextRange
+}
+
+type codeFixAllContext struct {
+	ctx         context.Context
+	program     *compiler.Program
+	checker     *checker.Checker
+	sourceFile  *ast.SourceFile
+	fixId       string
+	diagnostics []*ast.Diagnostic
+}
+
+// A codeFixAction is a set of changes to the file containing the diagnostic.
+type codeFixAction struct {
+	description string
+	changes     []core.TextChange
+	// fixId identifies fixes that can be combined into a single "fix all in file" action.
+	fixId             string
+	fixAllDescription string
+}
+
+var codeFixProviders = []*codeFixProvider{
+	addMissingAwaitCodeFixProvider,
+	importCodeFixProvider,
+	unusedIdentifierCodeFixProvider,
+	spellingCodeFixProvider,
+	addOverrideModifierCodeFixProvider,
+	removeOverrideModifierCodeFixProvider,
+	implementInterfaceCodeFixProvider,
+}
+
+var getCodeFixProvidersByErrorCode = sync.OnceValue(func() map[int32][]*codeFixProvider {
+	providers := map[int32][]*codeFixProvider{}
+	for _, provider := range codeFixProviders {
+		for _, errorCode := range provider.errorCodes {
+			providers[errorCode] = append(providers[errorCode], provider)
+		}
+	}
+	return providers
+})
+
+func (l *LanguageService) ProvideCodeActions(ctx context.Context, documentURI lsproto.DocumentUri, actionContext *lsproto.CodeActionContext) ([]*lsproto.CodeAction, error) {
+	if actionContext == nil || !includesCodeActionKind(actionContext.Only, lsproto.CodeActionKindQuickFix) {
+		return nil, nil
+	}
+	program, file := l.getProgramAndFile(documentURI)
+	checker, done := program.GetTypeCheckerForFile(ctx, file)
+	defer done()
+
+	var fileDiagnostics []*ast.Diagnostic
+	var actions []*lsproto.CodeAction
+	seenFixIds := collections.Set[string]{}
+	for _, diagnostic := range actionContext.Diagnostics {
+		if diagnostic.Code == nil || diagnostic.Code.Integer == nil {
+			continue
+		}
+		errorCode := *diagnostic.Code.Integer
+		fixContext := &codeFixContext{
+			ctx:        ctx,
+			program:    program,
+			checker:    checker,
+			sourceFile: file,
+			errorCode:  errorCode,
+			span:       l.converters.FromLSPRange(file, diagnostic.Range),
+		}
+		for _, provider := range getCodeFixProvidersByErrorCode()[errorCode] {
+			for _, action := range provider.getCodeActions(fixContext) {
+				actions = append(actions, l.createCodeAction(file, action.description, action.changes, diagnostic))
+				if action.fixId == "" || !seenFixIds.AddIfAbsent(action.fixId) {
+					continue
+				}
+				if fileDiagnostics == nil {
+					fileDiagnostics = getCodeFixDiagnostics(ctx, program, file)
+				}
+				diagnostics := core.Filter(fileDiagnostics, func(d *ast.Diagnostic) bool { return slices.Contains(provider.errorCodes, d.Code()) })
+				// Only offer to fix all when there is more than one diagnostic to fix.
+				if len(diagnostics) < 2 {
+					continue
+				}
+				changes := getAllCodeFixChanges(provider, &codeFixAllContext{
+					ctx:         ctx,
+					program:     program,
+					checker:     checker,
+					sourceFile:  file,
+					fixId:       action.fixId,
+					diagnostics: diagnostics,
+				})
+				if len(changes) != 0 {
+					actions = append(actions, l.createCodeAction(file, action.fixAllDescription, changes, nil))
+				}
+			}
+		}
+	}
+	return actions, nil
+}
+
+func includesCodeActionKind(only *[]lsproto.CodeActionKind, kind lsproto.CodeActionKind) bool {
+	if only == nil {
+		return true
+	}
+	return core.Some(*only, func(requested lsproto.CodeActionKind) bool {
+		return requested == kind || requested == lsproto.CodeActionKindEmpty
+	})
+}
+
+func getCodeFixDiagnostics(ctx context.Context, program *compiler.Program, file *ast.SourceFile) []*ast.Diagnostic {
+	return slices.Concat(program.GetSemanticDiagnostics(ctx, file), program.GetSuggestionDiagnostics(ctx, file))
+}
+
+func getAllCodeFixChanges(provider *codeFixProvider, fixContext *codeFixAllContext) []core.TextChange {
+	if provider.getAllCodeActions != nil {
+		return provider.getAllCodeActions(fixContext)
+	}
+	var changes []core.TextChange
+	for _, diagnostic := range fixContext.diagnostics {
+		actions := provider.getCodeActions(&codeFixContext{
+			ctx:        fixContext.ctx,
+			program:    fixContext.program,
+			checker:    fixContext.checker,
+			sourceFile: fixContext.sourceFile,
+			errorCode:  diagnostic.Code(),
+			span:       diagnostic.Loc(),
+		})
+		if action := core.Find(actions, func(action *codeFixAction) bool { return action.fixId == fixContext.fixId }); action != nil {
+			changes = append(changes, action.changes...)
+		}
+	}
+	return combineTextChanges(changes)
+}
+
+// combineTextChanges sorts changes by position, dropping duplicates and changes that overlap an earlier change.
+func combineTextChanges(changes []core.TextChange) []core.TextChange {
+	slices.SortStableFunc(changes, func(a, b core.TextChange) int { return a.Pos() - b.Pos() })
+	result := make([]core.TextChange, 0, len(changes))
+	for _, change := range changes {
+		if len(result) != 0 {
+			last := result[len(result)-1]
+			if change == last || change.Pos() < last.End() {
+				continue
+			}
+		}
+		result = append(result, change)
+	}
+	return result
+}
+
+func (l *LanguageService) createCodeAction(file *ast.SourceFile, title string, changes []core.TextChange, diagnostic *lsproto.Diagnostic) *lsproto.CodeAction {
+	var diagnostics *[]*lsproto.Diagnostic
+	if diagnostic != nil {
+		diagnostics = &[]*lsproto.Diagnostic{diagnostic}
+	}
+	return &lsproto.CodeAction{
+		Title:       title,
+		Kind:        ptrTo(lsproto.CodeActionKindQuickFix),
+		Diagnostics: diagnostics,
+		Edit: &lsproto.WorkspaceEdit{
+			Changes: &map[lsproto.DocumentUri][]*lsproto.TextEdit{
+				FileNameToDocumentURI(file.FileName()): l.toLSProtoTextEdits(file, changes),
+			},
+		},
+	}
+}
+
+// === text change helpers ===
+
+func newInsertTextChange(pos int, text string) core.TextChange {
+	return core.TextChange{TextRange: core.NewTextRange(pos, pos), NewText: text}
+}
+
+func newReplaceNodeTextChange(file *ast.SourceFile, node *ast.Node, text string) core.TextChange {
+	return core.TextChange{TextRange: core.NewTextRange(scanner.GetTokenPosOfNode(node, file, false /*includeJSDoc*/), node.End()), NewText: text}
+}
+
+// newDeleteNodeTextChange deletes a node, along with its line when nothing else is on it.
+// Comments on the lines before the node are kept.
+func newDeleteNodeTextChange(file *ast.SourceFile, node *ast.Node) core.TextChange {
+	text := file.Text()
+	start := scanner.GetTokenPosOfNode(node, file, false /*includeJSDoc*/)
+	end := node.End()
+	lineStart := getStartOfLine(text, start)
+	if isWhiteSpaceOnly(text[lineStart:start]) {
+		lineEnd := end
+		for lineEnd < len(text) && (text[lineEnd] == ' ' || text[lineEnd] == '\t') {
+			lineEnd++
+		}
+		if lineEnd == len(text) || text[lineEnd] == '\r' || text[lineEnd] == '\n' {
+			start = lineStart
+			end = lineEnd
+			if end < len(text) && text[end] == '\r' {
+				end++
+			}
+			if end < len(text) && text[end] == '\n' {
+				end++
+			}
+		}
+	}
+	return core.TextChange{TextRange: core.NewTextRange(start, end)}
+}
+
+// newDeleteListElementTextChange deletes an element of a comma-separated list along with one adjacent comma.
+func newDeleteListElementTextChange(file *ast.SourceFile, node *ast.Node, list []*ast.Node) core.TextChange {
+	index := slices.Index(list, node)
+	start := scanner.GetTokenPosOfNode(node, file, false /*includeJSDoc*/)
+	end := node.End()
+	switch {
+	case index < len(list)-1:
+		end = scanner.GetTokenPosOfNode(list[index+1], file, false /*includeJSDoc*/)
+	case index > 0:
+		start = list[index-1].End()
+	}
+	return core.TextChange{TextRange: core.NewTextRange(start, end)}
+}
+
+func getStartOfLine(text string, pos int) int {
+	for pos > 0 && text[pos-1] != '\n' && text[pos-1] != '\r' {
+		pos--
+	}
+	return pos
+}
+
+func getIndentationOfLine(text string, pos int) string {
+	lineStart := getStartOfLine(text, pos)
+	end := lineStart
+	for end < len(text) && (text[end] == ' ' || text[end] == '\t') {
+		end++
+	}
+	return text[lineStart:end]
+}
+
+func isWhiteSpaceOnly(text string) bool {
+	for i := range len(text) {
+		if text[i] != ' ' && text[i] != '\t' {
+			return false
+		}
+	}
+	return true
+}
+
+// getTokenAtSpan returns the token starting the span of a diagnostic.
+func getTokenAtSpan(file *ast.SourceFile, span core.TextRange) *ast.Node {
+	return astnav.GetTokenAtPosition(file, span.Pos())
+}
@@ -0,0 +1,270 @@
+package ls_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/pagpeter/typescript-go/external/bundled"
+	"github.com/pagpeter/typescript-go/external/core"
+	"github.com/pagpeter/typescript-go/external/ls"
+	"github.com/pagpeter/typescript-go/external/lsp/lsproto"
+	"github.com/pagpeter/typescript-go/external/testutil/projecttestutil"
+	"gotest.tools/v3/assert"
+)
+
+func TestCodeFixes(t *testing.T) {
+	t.Parallel()
+	if !bundled.Embedded {
+		t.Skip("bundled files are not embedded")
+	}
+
+	testCases := []struct {
+		title           string
+		files           map[string]string
+		compilerOptions string
+		// title of the code action to apply
+		action   string
+		expected string
+	}{
+		{
+			title: "addMissingAwait",
+			files: map[string]string{
+				defaultMainFileName: `async function f(p: Promise<number>) {
+    return p * 2;
+}`,
+			},
+			action: "Add 'await'",
+			expected: `async function f(p: Promise<number>) {
+    return await p * 2;
+}`,
+		},
+		{
+			title: "addMissingImport",
+			files: map[string]string{
+				defaultMainFileName: `foo;`,
+				"/a.ts":             `export const foo = 1;`,
+			},
+			action: `Add import from "./a"`,
+			expected: `import { foo } from "./a";
+foo;`,
+		},
+		{
+			title: "updateExistingImport",
+			files: map[string]string{
+				defaultMainFileName: `import { foo } from "./a";
+foo;
+bar;`,
+				"/a.ts": `export const foo = 1;
+export const bar = 2;`,
+			},
+			action: `Update import from "./a"`,
+			expected: `import { foo, bar } from "./a";
+foo;
+bar;`,
+		},
+		{
+			title: "removeUnusedVariable",
+			files: map[string]string{
+				defaultMainFileName: `export function f() {
+    const x = 1;
+    return 2;
+}`,
+			},
+			compilerOptions: `"noUnusedLocals": true`,
+			action:          "Remove unused declaration for: 'x'",
+			expected: `export function f() {
+    return 2;
+}`,
+		},
+		{
+			title: "removeUnusedImportSpecifier",
+			files: map[string]string{
+				defaultMainFileName: `import { foo, bar } from "./a";
+foo;`,
+				"/a.ts": `export const foo = 1;
+export const bar = 2;`,
+			},
+			compilerOptions: `"noUnusedLocals": true`,
+			action:          "Remove unused declaration for: 'bar'",
+			expected: `import { foo } from "./a";
+foo;`,
+		},
+		{
+			title: "prefixUnusedParameter",
+			files: map[string]string{
+				defaultMainFileName: `export function f(x: number) {}`,
+			},
+			compilerOptions: `"noUnusedParameters": true`,
+			action:          "Prefix 'x' with an underscore",
+			expected:        `export function f(_x: number) {}`,
+		},
+		{
+			title: "deleteAllUnused",
+			files: map[string]string{
+				defaultMainFileName: `export function f() {
+    const x = 1;
+    const y = 2;
+    return 3;
+}`,
+			},
+			compilerOptions: `"noUnusedLocals": true`,
+			action:          "Delete all unused declarations",
+			expected: `export function f() {
+    return 3;
+}`,
+		},
+		{
+			title: "fixSpelling",
+			files: map[string]string{
+				defaultMainFileName: `const value = 1;
+valeu;`,
+			},
+			action: "Change spelling to 'value'",
+			expected: `const value = 1;
+value;`,
+		},
+		{
+			title: "fixPropertySpelling",
+			files: map[string]string{
+				defaultMainFileName: `declare const o: { length: number };
+o.lenght;`,
+			},
+			action: "Change spelling to 'length'",
+			expected: `declare const o: { length: number };
+o.length;`,
+		},
+		{
+			title: "addOverrideModifier",
+			files: map[string]string{
+				defaultMainFileName: `class A { m() {} }
+class B extends A {
+    public m() {}
+}`,
+			},
+			compilerOptions: `"noImplicitOverride": true`,
+			action:          "Add 'override' modifier",
+			expected: `class A { m() {} }
+class B extends A {
+    public override m() {}
+}`,
+		},
+		{
+			title: "removeOverrideModifier",
+			files: map[string]string{
+				defaultMainFileName: `class A {}
+class B extends A {
+    override m() {}
+}`,
+			},
+			action: "Remove 'override' modifier",
+			expected: `class A {}
+class B extends A {
+    m() {}
+}`,
+		},
+		{
+			title: "implementInterface",
+			files: map[string]string{
+				defaultMainFileName: `interface I {
+    x: number;
+    m(a: string): void;
+}
+class C implements I {
+    y = 1;
+}`,
+			},
+			action: "Implement interface 'I'",
+			expected: `interface I {
+    x: number;
+    m(a: string): void;
+}
+class C implements I {
+    y = 1;
+    x: number;
+    m(a: string): void {
+        throw new Error("Method not implemented.");
+    }
+}`,
+		},
+		{
+			title: "implementInterfaceEmptyClass",
+			files: map[string]string{
+				defaultMainFileName: `interface I {
+    x: string;
+}
+class C implements I {}`,
+			},
+			action: "Implement interface 'I'",
+			expected: `interface I {
+    x: string;
+}
+class C implements I {
+    x: string;
+}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			t.Parallel()
+			runCodeFixTest(t, testCase.files, testCase.compilerOptions, testCase.action, testCase.expected)
+		})
+	}
+}
+
+func runCodeFixTest(t *testing.T, files map[string]string, compilerOptions string, action string, expected string) {
+	parsedFiles := map[string]any{
+		defaultTsconfigFileName: `{ "compilerOptions": { "strict": true, "target": "esnext"` + core.IfElse(compilerOptions != "", ", "+compilerOptions, "") + ` } }`,
+	}
+	for fileName, content := range files {
+		parsedFiles[fileName] = content
+	}
+	ctx := projecttestutil.WithRequestID(t.Context())
+	service, done := createLanguageService(ctx, defaultMainFileName, parsedFiles)
+	defer done()
+
+	uri := ls.FileNameToDocumentURI(defaultMainFileName)
+	report, err := service.GetDocumentDiagnostics(ctx, uri)
+	assert.NilError(t, err)
+	diagnostics := report.RelatedFullDocumentDiagnosticReport.Items
+	assert.Assert(t, len(diagnostics) != 0)
+
+	actions, err := service.ProvideCodeActions(ctx, uri, &lsproto.CodeActionContext{Diagnostics: diagnostics})
+	assert.NilError(t, err)
+	index := slices.IndexFunc(actions, func(codeAction *lsproto.CodeAction) bool { return codeAction.Title == action })
+	if index < 0 {
+		titles := make([]string, len(actions))
+		for i, codeAction := range actions {
+			titles[i] = codeAction.Title
+		}
+		t.Fatalf("No code action %q, got %q", action, titles)
+	}
+
+	edits := (*actions[index].Edit.Changes)[uri]
+	assert.Equal(t, applyTextEdits(files[defaultMainFileName], edits), expected)
+}
+
+// applyTextEdits applies non-overlapping edits to ASCII text.
+func applyTextEdits(text string, edits []*lsproto.TextEdit) string {
+	lineStarts := []int{0}
+	for i := range len(text) {
+		if text[i] == '\n' {
+			lineStarts = append(lineStarts, i+1)
+		}
+	}
+	toOffset := func(position lsproto.Position) int {
+		return lineStarts[position.Line] + int(position.Character)
+	}
+	edits = slices.Clone(edits)
+	slices.SortFunc(edits, func(a, b *lsproto.TextEdit) int { return ls.CompareRanges(&b.Range, &a.Range) })
+	var b strings.Builder
+	for _, edit := range edits {
+		b.Reset()
+		b.WriteString(text[:toOffset(edit.Range.Start)])
+		b.WriteString(edit.NewText)
+		b.WriteString(text[toOffset(edit.Range.End):])
+		text = b.String()
+	}
+	return text
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/pagpeter/typescript-go/external/ast"
 	"github.com/pagpeter/typescript-go/external/astnav"
 	"github.com/pagpeter/typescript-go/external/checker"
+	"github.com/pagpeter/typescript-go/external/collections"
 	"github.com/pagpeter/typescript-go/external/core"
 	"github.com/pagpeter/typescript-go/external/lsp/lsproto"
 	"github.com/pagpeter/typescript-go/external/scanner"
@@ -41,6 +42,123 @@ func (l *LanguageService) ProvideDefinition(ctx context.Context, documentURI lsp
 	return nil, nil
 }
 
+func (l *LanguageService) ProvideTypeDefinition(ctx context.Context, documentURI lsproto.DocumentUri, position lsproto.Position) (*lsproto.Definition, error) {
+	program, file := l.getProgramAndFile(documentURI)
+	node := astnav.GetTouchingPropertyName(file, int(l.converters.LineAndCharacterToPosition(file, position)))
+	if node.Kind == ast.KindSourceFile {
+		return nil, nil
+	}
+
+	c, done := program.GetTypeCheckerForFile(ctx, file)
+	defer done()
+
+	if ast.IsImportMeta(node.Parent) && node.Parent.Name() == node {
+		return l.createLocationsFromDeclarations(getDeclarationsFromType(c.GetTypeAtLocation(node.Parent)))
+	}
+
+	symbol := c.GetSymbolAtLocation(node)
+	if symbol == nil {
+		return nil, nil
+	}
+	if symbol.Flags&ast.SymbolFlagsAlias != 0 {
+		if resolved, ok := c.ResolveAlias(symbol); ok && len(resolved.Declarations) != 0 {
+			symbol = resolved
+		}
+	}
+
+	t := c.GetTypeOfSymbolAtLocation(symbol, node)
+	var declarations []*ast.Node
+	// If a function returns 'void' or some other type with no definition, just return the function definition.
+	if returnType := tryGetReturnTypeOfFunction(c, symbol, t); returnType != nil {
+		if declarations = getDeclarationsFromType(returnType); len(declarations) != 0 {
+			t = returnType
+		}
+	}
+	if len(declarations) == 0 {
+		declarations = getDeclarationsFromType(t)
+	}
+
+	if len(declarations) != 0 {
+		return l.createLocationsFromDeclarations(append(getFirstTypeArgumentDeclarations(c, t), declarations...))
+	}
+	if symbol.Flags&ast.SymbolFlagsValue == 0 && symbol.Flags&ast.SymbolFlagsType != 0 {
+		return l.createLocationsFromDeclarations(symbol.Declarations)
+	}
+	return nil, nil
+}
+
+func getDeclarationsFromType(t *checker.Type) []*ast.Node {
+	types := []*checker.Type{t}
+	// Union enums are unions of their members, but should still go to the enum itself.
+	if t.IsUnion() && !(t.Flags()&checker.TypeFlagsEnumLiteral != 0 && t.Symbol() != nil) {
+		types = t.Types()
+	}
+	return core.FlatMap(types, func(t *checker.Type) []*ast.Node {
+		if t.Symbol() == nil {
+			return nil
+		}
+		return t.Symbol().Declarations
+	})
+}
+
+// If the type is just a function's inferred type, go to the return type instead,
+// since go-to-definition takes you to the function anyway.
+func tryGetReturnTypeOfFunction(c *checker.Checker, symbol *ast.Symbol, t *checker.Type) *checker.Type {
+	if t.Symbol() == nil {
+		return nil
+	}
+	// At `const f = () => {}`, the symbol is `f` and the type symbol is at `() => {}`.
+	if t.Symbol() == symbol || symbol.ValueDeclaration != nil && ast.IsVariableDeclaration(symbol.ValueDeclaration) &&
+		symbol.ValueDeclaration.Initializer() != nil && symbol.ValueDeclaration.Initializer() == t.Symbol().ValueDeclaration {
+		if signatures := c.GetCallSignatures(t); len(signatures) == 1 {
+			return c.GetReturnTypeOfSignature(signatures[0])
+		}
+	}
+	return nil
+}
+
+var typesWithUnwrappedTypeArguments = collections.NewSetFromItems(
+	"Array",
+	"ArrayLike",
+	"ReadonlyArray",
+	"Promise",
+	"PromiseLike",
+	"Iterable",
+	"IterableIterator",
+	"AsyncIterable",
+	"Set",
+	"WeakSet",
+	"ReadonlySet",
+	"Map",
+	"WeakMap",
+	"ReadonlyMap",
+	"Partial",
+	"Required",
+	"Readonly",
+	"Pick",
+	"Omit",
+)
+
+// Returns the declarations of the element type of arrays, promises and similar global containers,
+// so that the type definition of a `Promise<Foo>` also includes `Foo`.
+func getFirstTypeArgumentDeclarations(c *checker.Checker, t *checker.Type) []*ast.Node {
+	if t.Flags()&checker.TypeFlagsObject == 0 || t.ObjectFlags()&checker.ObjectFlagsReference == 0 || t.Symbol() == nil {
+		return nil
+	}
+	name := t.Symbol().Name
+	if !typesWithUnwrappedTypeArguments.Has(name) {
+		return nil
+	}
+	globalSymbol := c.GetGlobalSymbol(name, ast.SymbolFlagsType, nil /*diagnostic*/)
+	if globalSymbol == nil || t.Target().Symbol() != globalSymbol {
+		return nil
+	}
+	if typeArguments := c.GetTypeArguments(t); len(typeArguments) != 0 {
+		return getDeclarationsFromType(typeArguments[0])
+	}
+	return nil
+}
+
 func (l *LanguageService) createLocationsFromDeclarations(declarations []*ast.Node) (*lsproto.Definition, error) {
 	locations := make([]lsproto.Location, 0, len(declarations))
 	for _, decl := range declarations {
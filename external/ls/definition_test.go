@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/pagpeter/typescript-go/external/bundled"
+	"github.com/pagpeter/typescript-go/external/core"
 	"github.com/pagpeter/typescript-go/external/fourslash"
 	"github.com/pagpeter/typescript-go/external/ls"
 	"github.com/pagpeter/typescript-go/external/lsp/lsproto"
@@ -72,3 +73,81 @@ func runDefinitionTest(t *testing.T, input string, expected map[string]lsproto.D
 		assert.DeepEqual(t, *locations, expectedResult)
 	}
 }
+
+func TestTypeDefinition(t *testing.T) {
+	t.Parallel()
+	if !bundled.Embedded {
+		t.Skip("bundled files are not embedded")
+	}
+
+	// Every range in the input is expected as a type definition of the marker, in order.
+	// Declarations in the default library are not checked.
+	testCases := []struct {
+		title string
+		input string
+	}{
+		{
+			title: "variable",
+			input: `[|interface Foo { a: string }|]
+const /*1*/x: Foo = { a: "" };`,
+		},
+		{
+			title: "union",
+			input: `[|class A {}|]
+[|class B {}|]
+declare const /*1*/x: A | B;`,
+		},
+		{
+			title: "array",
+			input: `[|interface Foo {}|]
+declare const /*1*/xs: Foo[];`,
+		},
+		{
+			title: "promiseReturnType",
+			input: `[|interface Foo {}|]
+declare function /*1*/f(): Promise<Foo>;`,
+		},
+		{
+			title: "inferredFunction",
+			input: `[|class Foo {}|]
+const /*1*/make = () => new Foo();`,
+		},
+		{
+			title: "property",
+			input: `[|enum E { A, B }|]
+interface I { e: E }
+declare const i: I;
+i./*1*/e;`,
+		},
+		{
+			title: "typeAlias",
+			input: `[|type T = { a: number };|]
+let x: /*1*/T;`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			t.Parallel()
+			testData := fourslash.ParseTestData(t, testCase.input, "/file1.ts")
+			file := testData.Files[0].FileName()
+			ctx := projecttestutil.WithRequestID(t.Context())
+			languageService, done := createLanguageService(ctx, file, map[string]any{
+				file: testData.Files[0].Content,
+			})
+			defer done()
+
+			definition, err := languageService.ProvideTypeDefinition(ctx, ls.FileNameToDocumentURI(file), testData.MarkerPositions["1"].LSPosition)
+			assert.NilError(t, err)
+			assert.Assert(t, definition != nil)
+			var expected []lsproto.Location
+			for _, r := range testData.Ranges {
+				expected = append(expected, lsproto.Location{Uri: ls.FileNameToDocumentURI(file), Range: r.LSRange})
+			}
+			locations := core.Filter(*definition.Locations, func(location lsproto.Location) bool {
+				return location.Uri == ls.FileNameToDocumentURI(file)
+			})
+			assert.DeepEqual(t, locations, expected)
+		})
+	}
+}
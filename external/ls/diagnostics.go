@@ -29,13 +29,13 @@ func (l *LanguageService) GetDocumentDiagnostics(ctx context.Context, documentUR
 	return &lsproto.DocumentDiagnosticReport{
 		RelatedFullDocumentDiagnosticReport: &lsproto.RelatedFullDocumentDiagnosticReport{
 			FullDocumentDiagnosticReport: lsproto.FullDocumentDiagnosticReport{
-				Items: toLSPDiagnostics(l.converters, diagnostics...),
+				Items: toLSPDiagnostics(l.converters, l.host.GetLocale(), diagnostics...),
 			},
 		},
 	}, nil
 }
 
-func toLSPDiagnostics(converters *Converters, diagnostics ...[]*ast.Diagnostic) []*lsproto.Diagnostic {
+func toLSPDiagnostics(converters *Converters, locale *diagnostics.Locale, diagnostics ...[]*ast.Diagnostic) []*lsproto.Diagnostic {
 	size := 0
 	for _, diagSlice := range diagnostics {
 		size += len(diagSlice)
@@ -43,13 +43,13 @@ func toLSPDiagnostics(converters *Converters, diagnostics ...[]*ast.Diagnostic)
 	lspDiagnostics := make([]*lsproto.Diagnostic, 0, size)
 	for _, diagSlice := range diagnostics {
 		for _, diag := range diagSlice {
-			lspDiagnostics = append(lspDiagnostics, toLSPDiagnostic(converters, diag))
+			lspDiagnostics = append(lspDiagnostics, toLSPDiagnostic(converters, locale, diag))
 		}
 	}
 	return lspDiagnostics
 }
 
-func toLSPDiagnostic(converters *Converters, diagnostic *ast.Diagnostic) *lsproto.Diagnostic {
+func toLSPDiagnostic(converters *Converters, locale *diagnostics.Locale, diagnostic *ast.Diagnostic) *lsproto.Diagnostic {
 	var severity lsproto.DiagnosticSeverity
 	switch diagnostic.Category() {
 	case diagnostics.CategorySuggestion:
@@ -69,7 +69,7 @@ func toLSPDiagnostic(converters *Converters, diagnostic *ast.Diagnostic) *lsprot
 				Uri:   FileNameToDocumentURI(related.File().FileName()),
 				Range: converters.ToLSPRange(related.File(), related.Loc()),
 			},
-			Message: related.Message(),
+			Message: related.Localize(locale),
 		})
 	}
 
@@ -90,19 +90,19 @@ func toLSPDiagnostic(converters *Converters, diagnostic *ast.Diagnostic) *lsprot
 			Integer: ptrTo(diagnostic.Code()),
 		},
 		Severity:           &severity,
-		Message:            messageChainToString(diagnostic),
+		Message:            messageChainToString(diagnostic, locale),
 		Source:             ptrTo("ts"),
 		RelatedInformation: ptrToSliceIfNonEmpty(relatedInformation),
 		Tags:               ptrToSliceIfNonEmpty(tags),
 	}
 }
 
-func messageChainToString(diagnostic *ast.Diagnostic) string {
+func messageChainToString(diagnostic *ast.Diagnostic, locale *diagnostics.Locale) string {
 	if len(diagnostic.MessageChain()) == 0 {
-		return diagnostic.Message()
+		return diagnostic.Localize(locale)
 	}
 	var b strings.Builder
-	diagnosticwriter.WriteFlattenedDiagnosticMessage(&b, diagnostic, "\n")
+	diagnosticwriter.WriteFlattenedDiagnosticMessage(&b, diagnostic, "\n", locale)
 	return b.String()
 }
 
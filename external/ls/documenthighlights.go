@@ -0,0 +1,531 @@
+package ls
+
+import (
+	"context"
+	"slices"
+
+	"github.com/pagpeter/typescript-go/external/ast"
+	"github.com/pagpeter/typescript-go/external/astnav"
+	"github.com/pagpeter/typescript-go/external/checker"
+	"github.com/pagpeter/typescript-go/external/compiler"
+	"github.com/pagpeter/typescript-go/external/core"
+	"github.com/pagpeter/typescript-go/external/lsp/lsproto"
+	"github.com/pagpeter/typescript-go/external/scanner"
+	"github.com/pagpeter/typescript-go/external/stringutil"
+)
+
+func (l *LanguageService) ProvideDocumentHighlights(ctx context.Context, documentURI lsproto.DocumentUri, position lsproto.Position) ([]*lsproto.DocumentHighlight, error) {
+	program, file := l.getProgramAndFile(documentURI)
+	pos := int(l.converters.LineAndCharacterToPosition(file, position))
+	node := astnav.GetTouchingPropertyName(file, pos)
+	if node.Kind == ast.KindSourceFile {
+		return nil, nil
+	}
+
+	if ast.IsJsxOpeningElement(node.Parent) && node.Parent.TagName() == node || ast.IsJsxClosingElement(node.Parent) {
+		// For a JSX element, just highlight the matching tag, not all references.
+		element := node.Parent.Parent.AsJsxElement()
+		return []*lsproto.DocumentHighlight{
+			l.createDocumentHighlight(file, element.OpeningElement.TagName(), lsproto.DocumentHighlightKindText),
+			l.createDocumentHighlight(file, element.ClosingElement.TagName(), lsproto.DocumentHighlightKindText),
+		}, nil
+	}
+
+	if highlights := l.getSemanticDocumentHighlights(pos, node, program, file); len(highlights) != 0 {
+		return highlights, nil
+	}
+	return l.getSyntacticDocumentHighlights(node, file), nil
+}
+
+func (l *LanguageService) getSemanticDocumentHighlights(position int, node *ast.Node, program *compiler.Program, file *ast.SourceFile) []*lsproto.DocumentHighlight {
+	symbolsAndEntries := l.getReferencedSymbolsForNode(position, node, program, []*ast.SourceFile{file}, refOptions{}, nil)
+	var highlights []*lsproto.DocumentHighlight
+	for _, symbolAndEntries := range symbolsAndEntries {
+		for _, entry := range symbolAndEntries.references {
+			kind := lsproto.DocumentHighlightKindRead
+			if entry.kind != entryKindRange {
+				if ast.GetSourceFileOfNode(entry.node) != file {
+					continue
+				}
+				if isWriteAccessForReference(entry.node) {
+					kind = lsproto.DocumentHighlightKindWrite
+				}
+			} else if entry.fileName != file.FileName() {
+				continue
+			}
+			highlights = append(highlights, &lsproto.DocumentHighlight{
+				Range: *l.getRangeOfEntry(entry),
+				Kind:  ptrTo(kind),
+			})
+		}
+	}
+	return highlights
+}
+
+func (l *LanguageService) getSyntacticDocumentHighlights(node *ast.Node, file *ast.SourceFile) []*lsproto.DocumentHighlight {
+	highlightSpans := func(nodes []*ast.Node) []*lsproto.DocumentHighlight {
+		return core.Map(nodes, func(node *ast.Node) *lsproto.DocumentHighlight {
+			return l.createDocumentHighlight(file, node, lsproto.DocumentHighlightKindText)
+		})
+	}
+	useParent := func(node *ast.Node, nodeTest func(*ast.Node) bool, getNodes func(*ast.Node, *ast.SourceFile) []*ast.Node) []*lsproto.DocumentHighlight {
+		if node == nil || !nodeTest(node) {
+			return nil
+		}
+		return highlightSpans(getNodes(node, file))
+	}
+	getFromAllDeclarations := func(nodeTest func(*ast.Node) bool, keywords ...ast.Kind) []*lsproto.DocumentHighlight {
+		return useParent(node.Parent, nodeTest, func(decl *ast.Node, file *ast.SourceFile) []*ast.Node {
+			if decl.Symbol() == nil {
+				return nil
+			}
+			return core.MapNonNil(decl.Symbol().Declarations, func(d *ast.Node) *ast.Node {
+				if !nodeTest(d) {
+					return nil
+				}
+				for _, keyword := range keywords {
+					if child := findChildOfKind(d, keyword, file); child != nil {
+						return child
+					}
+				}
+				return nil
+			})
+		})
+	}
+
+	switch node.Kind {
+	case ast.KindIfKeyword, ast.KindElseKeyword:
+		if ast.IsIfStatement(node.Parent) {
+			return l.getIfElseOccurrences(node.Parent, file)
+		}
+		return nil
+	case ast.KindReturnKeyword:
+		return useParent(node.Parent, ast.IsReturnStatement, getReturnOccurrences)
+	case ast.KindThrowKeyword:
+		return useParent(node.Parent, ast.IsThrowStatement, getThrowOccurrences)
+	case ast.KindTryKeyword, ast.KindCatchKeyword, ast.KindFinallyKeyword:
+		tryStatement := node.Parent
+		if node.Kind == ast.KindCatchKeyword {
+			tryStatement = tryStatement.Parent
+		}
+		return useParent(tryStatement, ast.IsTryStatement, getTryCatchFinallyOccurrences)
+	case ast.KindSwitchKeyword:
+		return useParent(node.Parent, ast.IsSwitchStatement, getSwitchCaseDefaultOccurrences)
+	case ast.KindCaseKeyword, ast.KindDefaultKeyword:
+		if ast.IsDefaultClause(node.Parent) || ast.IsCaseClause(node.Parent) {
+			return useParent(node.Parent.Parent.Parent, ast.IsSwitchStatement, getSwitchCaseDefaultOccurrences)
+		}
+		return nil
+	case ast.KindBreakKeyword, ast.KindContinueKeyword:
+		return useParent(node.Parent, ast.IsBreakOrContinueStatement, getBreakOrContinueStatementOccurrences)
+	case ast.KindForKeyword, ast.KindWhileKeyword, ast.KindDoKeyword:
+		return useParent(node.Parent, func(n *ast.Node) bool { return ast.IsIterationStatement(n, true /*lookInLabeledStatements*/) }, getLoopBreakContinueOccurrences)
+	case ast.KindConstructorKeyword:
+		return getFromAllDeclarations(ast.IsConstructorDeclaration, ast.KindConstructorKeyword)
+	case ast.KindGetKeyword, ast.KindSetKeyword:
+		return getFromAllDeclarations(ast.IsAccessor, ast.KindGetKeyword, ast.KindSetKeyword)
+	case ast.KindAwaitKeyword:
+		return useParent(node.Parent, ast.IsAwaitExpression, getAsyncAndAwaitOccurrences)
+	case ast.KindAsyncKeyword:
+		return highlightSpans(getAsyncAndAwaitOccurrences(node, file))
+	case ast.KindYieldKeyword:
+		return highlightSpans(getYieldOccurrences(node, file))
+	case ast.KindInKeyword, ast.KindOutKeyword:
+		return nil
+	default:
+		if ast.IsModifierKind(node.Kind) && (ast.IsDeclaration(node.Parent) || ast.IsVariableStatement(node.Parent)) {
+			return highlightSpans(getModifierOccurrences(node.Kind, node.Parent))
+		}
+		return nil
+	}
+}
+
+func (l *LanguageService) createDocumentHighlight(file *ast.SourceFile, node *ast.Node, kind lsproto.DocumentHighlightKind) *lsproto.DocumentHighlight {
+	return &lsproto.DocumentHighlight{
+		Range: *l.createLspRangeFromBounds(scanner.GetTokenPosOfNode(node, file, false /*includeJSDoc*/), node.End(), file),
+		Kind:  ptrTo(kind),
+	}
+}
+
+func isWriteAccessForReference(node *ast.Node) bool {
+	decl := getDeclarationFromName(node)
+	return decl != nil && declarationIsWriteAccess(decl) || node.Kind == ast.KindDefaultKeyword || checker.IsWriteAccess(node)
+}
+
+func getDeclarationFromName(name *ast.Node) *ast.Node {
+	parent := name.Parent
+	switch name.Kind {
+	case ast.KindStringLiteral, ast.KindNoSubstitutionTemplateLiteral, ast.KindNumericLiteral:
+		if ast.IsComputedPropertyName(parent) {
+			return parent.Parent
+		}
+		fallthrough
+	case ast.KindIdentifier, ast.KindPrivateIdentifier:
+		if ast.IsDeclaration(parent) && parent.Name() == name {
+			return parent
+		}
+	}
+	return nil
+}
+
+func declarationIsWriteAccess(decl *ast.Node) bool {
+	// Consider anything in an ambient declaration to be a write access since it may be coming from JS.
+	if decl.Flags&ast.NodeFlagsAmbient != 0 {
+		return true
+	}
+	switch decl.Kind {
+	case ast.KindBinaryExpression, ast.KindBindingElement, ast.KindClassDeclaration, ast.KindClassExpression, ast.KindDefaultKeyword,
+		ast.KindEnumDeclaration, ast.KindEnumMember, ast.KindExportSpecifier, ast.KindImportClause, ast.KindImportEqualsDeclaration,
+		ast.KindImportSpecifier, ast.KindInterfaceDeclaration, ast.KindJSDocCallbackTag, ast.KindJSDocTypedefTag, ast.KindJsxAttribute,
+		ast.KindModuleDeclaration, ast.KindNamespaceExportDeclaration, ast.KindNamespaceImport, ast.KindNamespaceExport, ast.KindParameter,
+		ast.KindShorthandPropertyAssignment, ast.KindTypeAliasDeclaration, ast.KindJSTypeAliasDeclaration, ast.KindTypeParameter:
+		return true
+	case ast.KindPropertyAssignment:
+		// In `({ x: y } = 0);`, `x` is not a write access.
+		return !isArrayLiteralOrObjectLiteralDestructuringPattern(decl.Parent)
+	case ast.KindFunctionDeclaration, ast.KindFunctionExpression, ast.KindConstructor, ast.KindMethodDeclaration, ast.KindGetAccessor, ast.KindSetAccessor:
+		return decl.Body() != nil
+	case ast.KindVariableDeclaration, ast.KindPropertyDeclaration:
+		return decl.Initializer() != nil || ast.IsCatchClause(decl.Parent)
+	}
+	return false
+}
+
+func (l *LanguageService) getIfElseOccurrences(ifStatement *ast.Node, file *ast.SourceFile) []*lsproto.DocumentHighlight {
+	keywords := getIfElseKeywords(ifStatement, file)
+	var result []*lsproto.DocumentHighlight
+	// We'd like to highlight else/ifs together if they are only separated by whitespace
+	// (i.e. the keywords are separated by no comments, no newlines).
+	for i := 0; i < len(keywords); i++ {
+		if keywords[i].Kind == ast.KindElseKeyword && i < len(keywords)-1 {
+			elseKeyword := keywords[i]
+			ifKeyword := keywords[i+1] // this *should* always be an 'if' keyword.
+			ifStart := scanner.GetTokenPosOfNode(ifKeyword, file, false /*includeJSDoc*/)
+			shouldCombineElseAndIf := true
+			for j := ifStart - 1; j >= elseKeyword.End(); j-- {
+				if !stringutil.IsWhiteSpaceSingleLine(rune(file.Text()[j])) {
+					shouldCombineElseAndIf = false
+					break
+				}
+			}
+			if shouldCombineElseAndIf {
+				result = append(result, &lsproto.DocumentHighlight{
+					Range: *l.createLspRangeFromBounds(scanner.GetTokenPosOfNode(elseKeyword, file, false /*includeJSDoc*/), ifKeyword.End(), file),
+					Kind:  ptrTo(lsproto.DocumentHighlightKindText),
+				})
+				i++ // skip the next keyword
+				continue
+			}
+		}
+		// Ordinary case: just highlight the keyword.
+		result = append(result, l.createDocumentHighlight(file, keywords[i], lsproto.DocumentHighlightKindText))
+	}
+	return result
+}
+
+func getIfElseKeywords(ifStatement *ast.Node, file *ast.SourceFile) []*ast.Node {
+	var keywords []*ast.Node
+	// Traverse upwards through all parent if-statements linked by their else-branches.
+	for ast.IsIfStatement(ifStatement.Parent) && ifStatement.Parent.AsIfStatement().ElseStatement == ifStatement {
+		ifStatement = ifStatement.Parent
+	}
+	// Now traverse back down through the else branches, aggregating if/else keywords of if-statements.
+	for {
+		keywords = appendKeywordIf(keywords, findChildOfKind(ifStatement, ast.KindIfKeyword, file), ast.KindIfKeyword)
+		elseStatement := ifStatement.AsIfStatement().ElseStatement
+		if elseStatement != nil {
+			keywords = appendKeywordIf(keywords, findChildOfKind(ifStatement, ast.KindElseKeyword, file), ast.KindElseKeyword)
+		}
+		if elseStatement == nil || !ast.IsIfStatement(elseStatement) {
+			break
+		}
+		ifStatement = elseStatement
+	}
+	return keywords
+}
+
+func appendKeywordIf(keywords []*ast.Node, token *ast.Node, expected ...ast.Kind) []*ast.Node {
+	if token != nil && core.Some(expected, func(kind ast.Kind) bool { return token.Kind == kind }) {
+		return append(keywords, token)
+	}
+	return keywords
+}
+
+func getReturnOccurrences(returnStatement *ast.Node, file *ast.SourceFile) []*ast.Node {
+	fn := ast.FindAncestor(returnStatement.Parent, ast.IsFunctionLike)
+	if fn == nil || fn.Body() == nil || !ast.IsBlock(fn.Body()) {
+		return nil
+	}
+	var keywords []*ast.Node
+	ast.ForEachReturnStatement(fn.Body(), func(returnStatement *ast.Node) bool {
+		keywords = appendKeywordIf(keywords, findChildOfKind(returnStatement, ast.KindReturnKeyword, file), ast.KindReturnKeyword)
+		return false
+	})
+	// Include 'throw' statements that do not occur within a try block.
+	for _, throwStatement := range aggregateOwnedThrowStatements(fn.Body()) {
+		keywords = appendKeywordIf(keywords, findChildOfKind(throwStatement, ast.KindThrowKeyword, file), ast.KindThrowKeyword)
+	}
+	return keywords
+}
+
+func getThrowOccurrences(throwStatement *ast.Node, file *ast.SourceFile) []*ast.Node {
+	owner := getThrowStatementOwner(throwStatement)
+	if owner == nil {
+		return nil
+	}
+	var keywords []*ast.Node
+	for _, throwStatement := range aggregateOwnedThrowStatements(owner) {
+		keywords = appendKeywordIf(keywords, findChildOfKind(throwStatement, ast.KindThrowKeyword, file), ast.KindThrowKeyword)
+	}
+	// If the "owner" is a function, then we equate 'return' and 'throw' statements in their
+	// ability to "jump out" of the function, and include occurrences for both.
+	if ast.IsFunctionBlock(owner) {
+		ast.ForEachReturnStatement(owner, func(returnStatement *ast.Node) bool {
+			keywords = appendKeywordIf(keywords, findChildOfKind(returnStatement, ast.KindReturnKeyword, file), ast.KindReturnKeyword)
+			return false
+		})
+	}
+	return keywords
+}
+
+// Aggregates all throw-statements within this node *without* crossing
+// into function boundaries and try-blocks with catch-clauses.
+func aggregateOwnedThrowStatements(node *ast.Node) []*ast.Node {
+	if ast.IsThrowStatement(node) {
+		return []*ast.Node{node}
+	}
+	if ast.IsTryStatement(node) {
+		// Exceptions thrown within a try block lacking a catch clause are "owned" in the current context.
+		tryStatement := node.AsTryStatement()
+		var result []*ast.Node
+		if tryStatement.CatchClause != nil {
+			result = aggregateOwnedThrowStatements(tryStatement.CatchClause)
+		} else if tryStatement.TryBlock != nil {
+			result = aggregateOwnedThrowStatements(tryStatement.TryBlock)
+		}
+		if tryStatement.FinallyBlock != nil {
+			result = append(result, aggregateOwnedThrowStatements(tryStatement.FinallyBlock)...)
+		}
+		return result
+	}
+	// Do not cross function boundaries.
+	if ast.IsFunctionLike(node) {
+		return nil
+	}
+	return flatMapChildren(node, aggregateOwnedThrowStatements)
+}
+
+// Takes a throw statement and returns the nearest ancestor that is a try-block
+// (whose try statement has a catch clause), function-block, or source file.
+func getThrowStatementOwner(throwStatement *ast.Node) *ast.Node {
+	child := throwStatement
+	for child.Parent != nil {
+		parent := child.Parent
+		if ast.IsFunctionBlock(parent) || parent.Kind == ast.KindSourceFile {
+			return parent
+		}
+		// A throw-statement is only owned by a try-statement if the try-statement has
+		// a catch clause, and if the throw-statement occurs within the try block.
+		if ast.IsTryStatement(parent) && parent.AsTryStatement().TryBlock == child && parent.AsTryStatement().CatchClause != nil {
+			return child
+		}
+		child = parent
+	}
+	return nil
+}
+
+func getTryCatchFinallyOccurrences(tryStatement *ast.Node, file *ast.SourceFile) []*ast.Node {
+	keywords := appendKeywordIf(nil, findChildOfKind(tryStatement, ast.KindTryKeyword, file), ast.KindTryKeyword)
+	if catchClause := tryStatement.AsTryStatement().CatchClause; catchClause != nil {
+		keywords = appendKeywordIf(keywords, findChildOfKind(catchClause, ast.KindCatchKeyword, file), ast.KindCatchKeyword)
+	}
+	if tryStatement.AsTryStatement().FinallyBlock != nil {
+		keywords = appendKeywordIf(keywords, findChildOfKind(tryStatement, ast.KindFinallyKeyword, file), ast.KindFinallyKeyword)
+	}
+	return keywords
+}
+
+func getSwitchCaseDefaultOccurrences(switchStatement *ast.Node, file *ast.SourceFile) []*ast.Node {
+	keywords := appendKeywordIf(nil, findChildOfKind(switchStatement, ast.KindSwitchKeyword, file), ast.KindSwitchKeyword)
+	// Go through each clause in the switch statement, collecting the 'case'/'default' keywords.
+	for _, clause := range switchStatement.AsSwitchStatement().CaseBlock.AsCaseBlock().Clauses.Nodes {
+		keyword := core.IfElse(ast.IsDefaultClause(clause), ast.KindDefaultKeyword, ast.KindCaseKeyword)
+		keywords = appendKeywordIf(keywords, findChildOfKind(clause, keyword, file), keyword)
+		for _, statement := range aggregateAllBreakAndContinueStatements(clause) {
+			if getBreakOrContinueOwner(statement) == switchStatement {
+				keywords = appendKeywordIf(keywords, findChildOfKind(statement, ast.KindBreakKeyword, file), ast.KindBreakKeyword)
+			}
+		}
+	}
+	return keywords
+}
+
+func getLoopBreakContinueOccurrences(loopNode *ast.Node, file *ast.SourceFile) []*ast.Node {
+	var keywords []*ast.Node
+	switch loopNode.Kind {
+	case ast.KindForStatement, ast.KindForInStatement, ast.KindForOfStatement:
+		keywords = appendKeywordIf(keywords, findChildOfKind(loopNode, ast.KindForKeyword, file), ast.KindForKeyword)
+	case ast.KindWhileStatement:
+		keywords = appendKeywordIf(keywords, findChildOfKind(loopNode, ast.KindWhileKeyword, file), ast.KindWhileKeyword)
+	case ast.KindDoStatement:
+		keywords = appendKeywordIf(keywords, findChildOfKind(loopNode, ast.KindDoKeyword, file), ast.KindDoKeyword)
+		keywords = appendKeywordIf(keywords, findChildOfKind(loopNode, ast.KindWhileKeyword, file), ast.KindWhileKeyword)
+	}
+	for _, statement := range aggregateAllBreakAndContinueStatements(loopNode.Statement()) {
+		if getBreakOrContinueOwner(statement) == loopNode {
+			keywords = appendKeywordIf(keywords, findChildOfKind(statement, core.IfElse(ast.IsBreakStatement(statement), ast.KindBreakKeyword, ast.KindContinueKeyword), file), ast.KindBreakKeyword, ast.KindContinueKeyword)
+		}
+	}
+	return keywords
+}
+
+func getBreakOrContinueStatementOccurrences(statement *ast.Node, file *ast.SourceFile) []*ast.Node {
+	owner := getBreakOrContinueOwner(statement)
+	if owner == nil {
+		return nil
+	}
+	switch owner.Kind {
+	case ast.KindForStatement, ast.KindForInStatement, ast.KindForOfStatement, ast.KindDoStatement, ast.KindWhileStatement:
+		return getLoopBreakContinueOccurrences(owner, file)
+	case ast.KindSwitchStatement:
+		return getSwitchCaseDefaultOccurrences(owner, file)
+	}
+	return nil
+}
+
+func aggregateAllBreakAndContinueStatements(node *ast.Node) []*ast.Node {
+	if ast.IsBreakOrContinueStatement(node) {
+		return []*ast.Node{node}
+	}
+	if ast.IsFunctionLike(node) {
+		return nil
+	}
+	return flatMapChildren(node, aggregateAllBreakAndContinueStatements)
+}
+
+func flatMapChildren(node *ast.Node, cb func(child *ast.Node) []*ast.Node) []*ast.Node {
+	var result []*ast.Node
+	node.ForEachChild(func(child *ast.Node) bool {
+		result = append(result, cb(child)...)
+		return false
+	})
+	return result
+}
+
+func getBreakOrContinueOwner(statement *ast.Node) *ast.Node {
+	label := statement.Label()
+	return ast.FindAncestorOrQuit(statement, func(node *ast.Node) ast.FindAncestorResult {
+		switch node.Kind {
+		case ast.KindSwitchStatement, ast.KindForStatement, ast.KindForInStatement, ast.KindForOfStatement, ast.KindWhileStatement, ast.KindDoStatement:
+			if node.Kind == ast.KindSwitchStatement && statement.Kind == ast.KindContinueStatement {
+				return ast.FindAncestorFalse
+			}
+			if label == nil || isLabeledBy(node, label.Text()) {
+				return ast.FindAncestorTrue
+			}
+			return ast.FindAncestorFalse
+		default:
+			// Don't cross function boundaries.
+			if ast.IsFunctionLike(node) {
+				return ast.FindAncestorQuit
+			}
+			return ast.FindAncestorFalse
+		}
+	})
+}
+
+func isLabeledBy(node *ast.Node, labelName string) bool {
+	return ast.FindAncestorOrQuit(node.Parent, func(owner *ast.Node) ast.FindAncestorResult {
+		if !ast.IsLabeledStatement(owner) {
+			return ast.FindAncestorQuit
+		}
+		return core.IfElse(owner.Label().Text() == labelName, ast.FindAncestorTrue, ast.FindAncestorFalse)
+	}) != nil
+}
+
+func getAsyncAndAwaitOccurrences(node *ast.Node, file *ast.SourceFile) []*ast.Node {
+	fn := ast.FindAncestor(node.Parent, ast.IsFunctionLike)
+	if fn == nil {
+		return nil
+	}
+	var keywords []*ast.Node
+	for _, modifier := range fn.ModifierNodes() {
+		keywords = appendKeywordIf(keywords, modifier, ast.KindAsyncKeyword)
+	}
+	fn.ForEachChild(func(child *ast.Node) bool {
+		traverseWithoutCrossingFunction(child, func(node *ast.Node) {
+			if ast.IsAwaitExpression(node) {
+				keywords = appendKeywordIf(keywords, findChildOfKind(node, ast.KindAwaitKeyword, file), ast.KindAwaitKeyword)
+			}
+		})
+		return false
+	})
+	return keywords
+}
+
+func getYieldOccurrences(node *ast.Node, file *ast.SourceFile) []*ast.Node {
+	fn := ast.FindAncestor(node.Parent, ast.IsFunctionLike)
+	if fn == nil {
+		return nil
+	}
+	var keywords []*ast.Node
+	fn.ForEachChild(func(child *ast.Node) bool {
+		traverseWithoutCrossingFunction(child, func(node *ast.Node) {
+			if ast.IsYieldExpression(node) {
+				keywords = appendKeywordIf(keywords, findChildOfKind(node, ast.KindYieldKeyword, file), ast.KindYieldKeyword)
+			}
+		})
+		return false
+	})
+	return keywords
+}
+
+// Do not cross function/class/interface/module/type boundaries.
+func traverseWithoutCrossingFunction(node *ast.Node, cb func(*ast.Node)) {
+	cb(node)
+	if !ast.IsFunctionLike(node) && !ast.IsClassLike(node) && !ast.IsInterfaceDeclaration(node) && !ast.IsModuleDeclaration(node) && !ast.IsTypeAliasDeclaration(node) && !ast.IsTypeNode(node) {
+		node.ForEachChild(func(child *ast.Node) bool {
+			traverseWithoutCrossingFunction(child, cb)
+			return false
+		})
+	}
+}
+
+func getModifierOccurrences(kind ast.Kind, declaration *ast.Node) []*ast.Node {
+	return core.MapNonNil(getNodesToSearchForModifier(declaration, ast.ModifierToFlag(kind)), func(node *ast.Node) *ast.Node {
+		return core.Find(node.ModifierNodes(), func(modifier *ast.Node) bool { return modifier.Kind == kind })
+	})
+}
+
+func getNodesToSearchForModifier(declaration *ast.Node, modifierFlag ast.ModifierFlags) []*ast.Node {
+	// Types of node whose children might have modifiers.
+	container := declaration.Parent
+	switch container.Kind {
+	case ast.KindModuleBlock, ast.KindSourceFile, ast.KindBlock, ast.KindCaseClause, ast.KindDefaultClause:
+		// Container is either a class declaration or the declaration is a classDeclaration
+		if modifierFlag&ast.ModifierFlagsAbstract != 0 && ast.IsClassDeclaration(declaration) {
+			return append(slices.Clone(declaration.Members()), declaration)
+		}
+		return container.Statements()
+	case ast.KindConstructor, ast.KindMethodDeclaration, ast.KindFunctionDeclaration:
+		nodes := slices.Clone(container.Parameters())
+		if ast.IsClassLike(container.Parent) {
+			nodes = append(nodes, container.Parent.Members()...)
+		}
+		return nodes
+	case ast.KindClassDeclaration, ast.KindClassExpression, ast.KindInterfaceDeclaration, ast.KindTypeLiteral:
+		nodes := container.Members()
+		// If we're an accessibility modifier, we're in an instance member and should search
+		// the constructor's parameter list for instance members as well.
+		if modifierFlag&(ast.ModifierFlagsAccessibilityModifier|ast.ModifierFlagsReadonly) != 0 {
+			if constructor := core.Find(nodes, ast.IsConstructorDeclaration); constructor != nil {
+				return append(slices.Clone(nodes), constructor.Parameters()...)
+			}
+		} else if modifierFlag&ast.ModifierFlagsAbstract != 0 {
+			return append(slices.Clone(nodes), container)
+		}
+		return nodes
+	}
+	// Syntactically invalid positions that the parser might produce anyway
+	return nil
+}
@@ -0,0 +1,180 @@
+package ls_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/pagpeter/typescript-go/external/bundled"
+	"github.com/pagpeter/typescript-go/external/fourslash"
+	"github.com/pagpeter/typescript-go/external/ls"
+	"github.com/pagpeter/typescript-go/external/lsp/lsproto"
+	"github.com/pagpeter/typescript-go/external/testutil/projecttestutil"
+	"gotest.tools/v3/assert"
+)
+
+func TestDocumentHighlights(t *testing.T) {
+	t.Parallel()
+	if !bundled.Embedded {
+		t.Skip("bundled files are not embedded")
+	}
+
+	text := lsproto.DocumentHighlightKindText
+	read := lsproto.DocumentHighlightKindRead
+	write := lsproto.DocumentHighlightKindWrite
+
+	testCases := []struct {
+		title    string
+		fileName string
+		input    string
+		// the kind of each range, in the order the ranges end; highlights are compared in document order
+		expected []lsproto.DocumentHighlightKind
+	}{
+		{
+			title:    "readsAndWrites",
+			fileName: "/file1.ts",
+			input: `let [|/*1*/x|] = 1;
+[|x|] = 2;
+[|x|]++;
+console.log([|x|]);
+const o = { [|x|] };`,
+			expected: []lsproto.DocumentHighlightKind{write, write, write, read, write},
+		},
+		{
+			title:    "declarations",
+			fileName: "/file1.ts",
+			input: `function [|f|]() {}
+[|/*1*/f|]();`,
+			expected: []lsproto.DocumentHighlightKind{write, read},
+		},
+		{
+			title:    "members",
+			fileName: "/file1.ts",
+			input: `interface I { [|m|](): void }
+class C implements I { [|m|]() {} }
+declare const i: I;
+i.[|/*1*/m|]();`,
+			expected: []lsproto.DocumentHighlightKind{read, write, read},
+		},
+		{
+			title:    "ifElse",
+			fileName: "/file1.ts",
+			input: `declare const a: boolean, b: boolean;
+[|if|] (a) {
+    if (b) {} else {}
+} [|else if|] (b) {
+} [|/*1*/else|] {
+}`,
+			expected: []lsproto.DocumentHighlightKind{text, text, text},
+		},
+		{
+			title:    "tryCatchFinally",
+			fileName: "/file1.ts",
+			input: `[|try|] {
+    try {} catch {}
+} [|/*1*/catch|] (e) {
+} [|finally|] {
+}`,
+			expected: []lsproto.DocumentHighlightKind{text, text, text},
+		},
+		{
+			title:    "switch",
+			fileName: "/file1.ts",
+			input: `declare const x: number;
+for (;;) {
+    [|switch|] (x) {
+        [|case|] 1:
+            for (;;) { break; }
+            [|/*1*/break|];
+        [|case|] 2:
+            continue;
+        [|default|]:
+            [|break|];
+    }
+}`,
+			expected: []lsproto.DocumentHighlightKind{text, text, text, text, text, text},
+		},
+		{
+			title:    "loop",
+			fileName: "/file1.ts",
+			input: `outer: [|/*1*/do|] {
+    for (;;) {
+        [|continue|] outer;
+        break;
+    }
+    [|break|];
+} [|while|] (true);`,
+			expected: []lsproto.DocumentHighlightKind{text, text, text, text},
+		},
+		{
+			title:    "returnAndThrow",
+			fileName: "/file1.ts",
+			input: `function f(x: number) {
+    if (x) [|/*1*/return|] 1;
+    function g() { return 2; }
+    try { throw 0; } catch {}
+    [|throw|] new Error();
+    [|return|] 3;
+}`,
+			expected: []lsproto.DocumentHighlightKind{text, text, text},
+		},
+		{
+			title:    "asyncAwait",
+			fileName: "/file1.ts",
+			input: `[|async|] function f() {
+    [|await|] 1;
+    async function g() { await 2; }
+    [|/*1*/await|] 3;
+}`,
+			expected: []lsproto.DocumentHighlightKind{text, text, text},
+		},
+		{
+			title:    "yield",
+			fileName: "/file1.ts",
+			input: `function* f() {
+    [|/*1*/yield|] 1;
+    function* g() { yield 2; }
+    [|yield|]* [];
+}`,
+			expected: []lsproto.DocumentHighlightKind{text, text},
+		},
+		{
+			title:    "modifiers",
+			fileName: "/file1.ts",
+			input: `class C {
+    [|/*1*/private|] a = 1;
+    public b = 2;
+    constructor([|private|] c: number) {}
+}`,
+			expected: []lsproto.DocumentHighlightKind{text, text},
+		},
+		{
+			title:    "jsxTags",
+			fileName: "/file1.tsx",
+			input: `declare namespace JSX { interface IntrinsicElements { div: {} } }
+const a = <[|/*1*/div|]><div></div></[|div|]>;`,
+			expected: []lsproto.DocumentHighlightKind{text, text},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			t.Parallel()
+			testData := fourslash.ParseTestData(t, testCase.input, testCase.fileName)
+			file := testData.Files[0].FileName()
+			ctx := projecttestutil.WithRequestID(t.Context())
+			service, done := createLanguageService(ctx, file, map[string]any{
+				file: testData.Files[0].Content,
+			})
+			defer done()
+
+			highlights, err := service.ProvideDocumentHighlights(ctx, ls.FileNameToDocumentURI(file), testData.MarkerPositions["1"].LSPosition)
+			assert.NilError(t, err)
+			slices.SortFunc(highlights, func(a, b *lsproto.DocumentHighlight) int { return ls.CompareRanges(&a.Range, &b.Range) })
+			var expected []*lsproto.DocumentHighlight
+			for i, r := range testData.Ranges {
+				expected = append(expected, &lsproto.DocumentHighlight{Range: r.LSRange, Kind: &testCase.expected[i]})
+			}
+			assert.DeepEqual(t, highlights, expected)
+		})
+	}
+}
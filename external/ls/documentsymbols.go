@@ -0,0 +1,450 @@
+package ls
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pagpeter/typescript-go/external/ast"
+	"github.com/pagpeter/typescript-go/external/core"
+	"github.com/pagpeter/typescript-go/external/lsp/lsproto"
+	"github.com/pagpeter/typescript-go/external/scanner"
+)
+
+// Item names longer than this are truncated, matching the navigation tree of tsserver.
+const maxNavigationTreeNameLength = 150
+
+func (l *LanguageService) ProvideDocumentSymbols(ctx context.Context, documentURI lsproto.DocumentUri) ([]*lsproto.DocumentSymbol, error) {
+	_, file := l.getProgramAndFile(documentURI)
+	root := newNavigationTree(file)
+	return l.getDocumentSymbols(file, root.children), nil
+}
+
+func (l *LanguageService) getDocumentSymbols(file *ast.SourceFile, items []*navigationTreeNode) []*lsproto.DocumentSymbol {
+	symbols := make([]*lsproto.DocumentSymbol, 0, len(items))
+	for _, item := range items {
+		children := l.getDocumentSymbols(file, item.children)
+		name := getNavigationTreeItemName(file, item.node, item.name)
+		// Anonymous functions and classes only show up in the outline when they have something in them.
+		if (name == "<function>" || name == "<class>") && len(children) == 0 {
+			continue
+		}
+		start, end := getNodeStartAndEnd(file, item.node)
+		for _, node := range item.additionalNodes {
+			nodeStart, nodeEnd := getNodeStartAndEnd(file, node)
+			start = min(start, nodeStart)
+			end = max(end, nodeEnd)
+		}
+		lspRange := l.converters.ToLSPRange(file, core.NewTextRange(start, end))
+		selectionRange := lspRange
+		if item.name != nil {
+			nameStart, nameEnd := getNodeStartAndEnd(file, item.name)
+			selectionRange = l.converters.ToLSPRange(file, core.NewTextRange(nameStart, nameEnd))
+		}
+		symbols = append(symbols, &lsproto.DocumentSymbol{
+			Name:           name,
+			Kind:           getNavigationTreeSymbolKind(item.node),
+			Range:          lspRange,
+			SelectionRange: selectionRange,
+			Children:       &children,
+		})
+	}
+	return symbols
+}
+
+func getNodeStartAndEnd(file *ast.SourceFile, node *ast.Node) (int, int) {
+	return scanner.GetTokenPosOfNode(node, file, false /*includeJSDoc*/), node.End()
+}
+
+// navigationTreeNode is an item of the outline of a file. Declarations that merge, such as the
+// declarations of a namespace, are represented by a single item.
+type navigationTreeNode struct {
+	node            *ast.Node
+	name            *ast.Node
+	additionalNodes []*ast.Node
+	children        []*navigationTreeNode
+}
+
+type navigationTreeBuilder struct {
+	file    *ast.SourceFile
+	parent  *navigationTreeNode
+	parents []*navigationTreeNode
+}
+
+func newNavigationTree(file *ast.SourceFile) *navigationTreeNode {
+	root := &navigationTreeNode{node: file.AsNode()}
+	b := &navigationTreeBuilder{file: file, parent: root}
+	for _, statement := range file.Statements.Nodes {
+		b.addChildrenRecursively(statement)
+	}
+	mergeNavigationTreeChildren(root)
+	return root
+}
+
+func (b *navigationTreeBuilder) startNode(node *ast.Node, name *ast.Node) {
+	item := &navigationTreeNode{node: node, name: name}
+	b.parent.children = append(b.parent.children, item)
+	b.parents = append(b.parents, b.parent)
+	b.parent = item
+}
+
+func (b *navigationTreeBuilder) endNode() {
+	mergeNavigationTreeChildren(b.parent)
+	b.parent = b.parents[len(b.parents)-1]
+	b.parents = b.parents[:len(b.parents)-1]
+}
+
+func (b *navigationTreeBuilder) addLeafNode(node *ast.Node, name *ast.Node) {
+	b.parent.children = append(b.parent.children, &navigationTreeNode{node: node, name: name})
+}
+
+func (b *navigationTreeBuilder) addNodeWithRecursiveChild(node *ast.Node, name *ast.Node, child *ast.Node) {
+	b.startNode(node, name)
+	b.addChildrenRecursively(child)
+	b.endNode()
+}
+
+func (b *navigationTreeBuilder) addNodeWithRecursiveInitializer(node *ast.Node) {
+	b.addNodeWithRecursiveValue(node, node.Name(), node.Initializer())
+}
+
+// Functions and classes assigned to a declaration don't get an item of their own; their contents
+// become children of the declaration.
+func (b *navigationTreeBuilder) addNodeWithRecursiveValue(node *ast.Node, name *ast.Node, value *ast.Node) {
+	if value != nil && (ast.IsFunctionExpressionOrArrowFunction(value) || ast.IsClassExpression(value)) {
+		b.startNode(node, name)
+		value.ForEachChild(b.visit)
+		b.endNode()
+	} else {
+		b.addNodeWithRecursiveChild(node, name, value)
+	}
+}
+
+func (b *navigationTreeBuilder) visit(node *ast.Node) bool {
+	b.addChildrenRecursively(node)
+	return false
+}
+
+func (b *navigationTreeBuilder) addChildrenRecursively(node *ast.Node) {
+	if node == nil || ast.IsTokenKind(node.Kind) {
+		return
+	}
+	// Imports and exports of existing declarations are aliases, which are not part of the outline.
+	// The nodes reparsed from CommonJS assignments are handled through the assignments themselves.
+	switch node.Kind {
+	case ast.KindImportDeclaration, ast.KindJSImportDeclaration, ast.KindImportEqualsDeclaration, ast.KindExportDeclaration,
+		ast.KindJSExportAssignment, ast.KindCommonJSExport:
+		return
+	}
+	switch node.Kind {
+	case ast.KindConstructor:
+		b.addNodeWithRecursiveChild(node, nil, node.Body())
+		// Parameter properties are children of the class.
+		for _, parameter := range node.Parameters() {
+			if ast.IsParameterPropertyDeclaration(parameter, node) {
+				b.addLeafNode(parameter, parameter.Name())
+			}
+		}
+	case ast.KindMethodDeclaration, ast.KindGetAccessor, ast.KindSetAccessor, ast.KindMethodSignature:
+		if hasNavigationTreeName(node) {
+			b.addNodeWithRecursiveChild(node, node.Name(), node.Body())
+		}
+	case ast.KindPropertyDeclaration:
+		if hasNavigationTreeName(node) {
+			b.addNodeWithRecursiveInitializer(node)
+		}
+	case ast.KindPropertySignature:
+		if hasNavigationTreeName(node) {
+			b.addLeafNode(node, node.Name())
+		}
+	case ast.KindBindingElement, ast.KindPropertyAssignment, ast.KindVariableDeclaration:
+		if ast.IsBindingPattern(node.Name()) {
+			b.addChildrenRecursively(node.Name())
+		} else {
+			b.addNodeWithRecursiveInitializer(node)
+		}
+	case ast.KindShorthandPropertyAssignment:
+		b.addLeafNode(node, node.Name())
+	case ast.KindFunctionDeclaration, ast.KindFunctionExpression, ast.KindArrowFunction:
+		b.addNodeWithRecursiveChild(node, node.Name(), node.Body())
+	case ast.KindEnumDeclaration:
+		b.startNode(node, node.Name())
+		for _, member := range node.Members() {
+			if !ast.IsComputedPropertyName(member.Name()) {
+				b.addLeafNode(member, member.Name())
+			}
+		}
+		b.endNode()
+	case ast.KindClassDeclaration, ast.KindClassExpression, ast.KindInterfaceDeclaration:
+		b.startNode(node, node.Name())
+		for _, member := range node.Members() {
+			b.addChildrenRecursively(member)
+		}
+		b.endNode()
+	case ast.KindModuleDeclaration:
+		b.addNodeWithRecursiveChild(node, node.Name(), getInteriorModule(node).Body())
+	case ast.KindExportAssignment:
+		expression := node.Expression()
+		var child *ast.Node
+		switch expression.Kind {
+		case ast.KindObjectLiteralExpression, ast.KindCallExpression:
+			child = expression
+		case ast.KindArrowFunction, ast.KindFunctionExpression:
+			child = expression.Body()
+		}
+		if child != nil {
+			b.addNodeWithRecursiveChild(node, nil, child)
+		} else {
+			b.addLeafNode(node, nil)
+		}
+	case ast.KindIndexSignature, ast.KindCallSignature, ast.KindConstructSignature, ast.KindTypeAliasDeclaration, ast.KindJSTypeAliasDeclaration:
+		b.addLeafNode(node, node.Name())
+	case ast.KindBinaryExpression:
+		bin := node.AsBinaryExpression()
+		switch ast.GetAssignmentDeclarationKind(bin) {
+		case ast.JSDeclarationKindModuleExports:
+			b.addNodeWithRecursiveValue(node, nil, bin.Right)
+			return
+		case ast.JSDeclarationKindExportsProperty:
+			b.addNodeWithRecursiveValue(node, ast.GetElementOrPropertyAccessName(bin.Left), bin.Right)
+			return
+		}
+		node.ForEachChild(b.visit)
+	default:
+		node.ForEachChild(b.visit)
+	}
+}
+
+func hasNavigationTreeName(node *ast.Node) bool {
+	if !ast.HasDynamicName(node) {
+		return true
+	}
+	// Well-known symbols such as `[Symbol.iterator]` are named in the outline.
+	expression := node.Name().Expression()
+	return ast.IsPropertyAccessExpression(expression) && ast.IsIdentifier(expression.Expression()) &&
+		expression.Expression().Text() == "Symbol"
+}
+
+func getInteriorModule(node *ast.Node) *ast.Node {
+	for node.Body() != nil && ast.IsModuleDeclaration(node.Body()) {
+		node = node.Body()
+	}
+	return node
+}
+
+// mergeNavigationTreeChildren merges the children of an item that have the same name and declare
+// the same thing, e.g. namespace declarations, function overloads or interface declarations.
+// Anonymous items are never merged.
+func mergeNavigationTreeChildren(parent *navigationTreeNode) {
+	nameToItems := make(map[string][]*navigationTreeNode)
+	children := parent.children[:0]
+outer:
+	for _, child := range parent.children {
+		if child.name == nil {
+			children = append(children, child)
+			continue
+		}
+		name := scanner.GetTextOfNode(child.name)
+		for _, item := range nameToItems[name] {
+			if shouldMergeNavigationTreeNodes(item.node, child.node, parent) {
+				item.additionalNodes = append(item.additionalNodes, child.node)
+				item.additionalNodes = append(item.additionalNodes, child.additionalNodes...)
+				item.children = append(item.children, child.children...)
+				mergeNavigationTreeChildren(item)
+				continue outer
+			}
+		}
+		nameToItems[name] = append(nameToItems[name], child)
+		children = append(children, child)
+	}
+	parent.children = children
+}
+
+func shouldMergeNavigationTreeNodes(a *ast.Node, b *ast.Node, parent *navigationTreeNode) bool {
+	if a.Kind != b.Kind || a.Parent != b.Parent && !(isOwnNavigationTreeChild(a, parent) && isOwnNavigationTreeChild(b, parent)) {
+		return false
+	}
+	switch a.Kind {
+	case ast.KindPropertyDeclaration, ast.KindMethodDeclaration, ast.KindGetAccessor, ast.KindSetAccessor:
+		return ast.IsStatic(a) == ast.IsStatic(b)
+	case ast.KindModuleDeclaration:
+		return areSameModule(a, b) && getFullyQualifiedModuleName(a) == getFullyQualifiedModuleName(b)
+	}
+	return true
+}
+
+func isOwnNavigationTreeChild(node *ast.Node, parent *navigationTreeNode) bool {
+	container := node.Parent
+	if container == nil {
+		return false
+	}
+	if ast.IsModuleBlock(container) {
+		container = container.Parent
+	}
+	return container == parent.node || core.Some(parent.additionalNodes, func(n *ast.Node) bool { return n == container })
+}
+
+func areSameModule(a *ast.Node, b *ast.Node) bool {
+	if a.Body() == nil || b.Body() == nil {
+		return a.Body() == b.Body()
+	}
+	return a.Body().Kind == b.Body().Kind && (!ast.IsModuleDeclaration(a.Body()) || areSameModule(a.Body(), b.Body()))
+}
+
+// getFullyQualifiedModuleName returns the dotted name of a namespace, e.g. `A.B.C` for `namespace A.B.C {}`.
+func getFullyQualifiedModuleName(node *ast.Node) string {
+	var b strings.Builder
+	b.WriteString(node.Name().Text())
+	for node.Body() != nil && ast.IsModuleDeclaration(node.Body()) {
+		node = node.Body()
+		b.WriteString(".")
+		b.WriteString(node.Name().Text())
+	}
+	return b.String()
+}
+
+func getNavigationTreeItemName(file *ast.SourceFile, node *ast.Node, name *ast.Node) string {
+	if ast.IsModuleDeclaration(node) {
+		if ast.IsAmbientModule(node) {
+			return cleanNavigationTreeText(scanner.GetTextOfNode(node.Name()))
+		}
+		return cleanNavigationTreeText(getFullyQualifiedModuleName(node))
+	}
+	if name != nil {
+		var text string
+		switch {
+		case ast.IsIdentifier(name):
+			text = name.Text()
+		case ast.IsElementAccessExpression(name):
+			text = "[" + scanner.GetTextOfNode(name.AsElementAccessExpression().ArgumentExpression) + "]"
+		default:
+			text = scanner.GetTextOfNode(name)
+		}
+		if len(text) > 0 {
+			return cleanNavigationTreeText(text)
+		}
+	}
+	switch node.Kind {
+	case ast.KindExportAssignment:
+		if node.AsExportAssignment().IsExportEquals {
+			return "export="
+		}
+		return "default"
+	case ast.KindBinaryExpression:
+		// module.exports = ...
+		return "export="
+	case ast.KindArrowFunction, ast.KindFunctionDeclaration, ast.KindFunctionExpression, ast.KindClassDeclaration, ast.KindClassExpression:
+		if ast.HasSyntacticModifier(node, ast.ModifierFlagsDefault) {
+			return "default"
+		}
+		return getFunctionOrClassName(file, node)
+	case ast.KindConstructor:
+		return "constructor"
+	case ast.KindConstructSignature:
+		return "new()"
+	case ast.KindCallSignature:
+		return "()"
+	case ast.KindIndexSignature:
+		return "[]"
+	}
+	return "<unknown>"
+}
+
+// getFunctionOrClassName names an anonymous function or class after what it is assigned to, or
+// after the function it is passed to as a callback.
+func getFunctionOrClassName(file *ast.SourceFile, node *ast.Node) string {
+	parent := node.Parent
+	switch {
+	case ast.IsVariableDeclaration(parent):
+		return cleanNavigationTreeText(scanner.GetTextOfNode(parent.Name()))
+	case ast.IsBinaryExpression(parent) && parent.AsBinaryExpression().OperatorToken.Kind == ast.KindEqualsToken:
+		return strings.Join(strings.Fields(scanner.GetTextOfNode(parent.AsBinaryExpression().Left)), "")
+	case ast.IsPropertyAssignment(parent):
+		return scanner.GetTextOfNode(parent.Name())
+	case ast.IsClassLike(node):
+		return "<class>"
+	case ast.IsCallExpression(parent):
+		if name := getCalledExpressionName(parent.Expression()); name != "" {
+			name = cleanNavigationTreeText(name)
+			if len(name) > maxNavigationTreeNameLength {
+				return name + " callback"
+			}
+			var args []string
+			for _, arg := range parent.Arguments() {
+				if ast.IsStringLiteralLike(arg) || ast.IsTemplateExpression(arg) {
+					args = append(args, scanner.GetTextOfNode(arg))
+				}
+			}
+			return name + "(" + cleanNavigationTreeText(strings.Join(args, ", ")) + ") callback"
+		}
+	}
+	return "<function>"
+}
+
+func getCalledExpressionName(expression *ast.Node) string {
+	switch {
+	case ast.IsIdentifier(expression):
+		return expression.Text()
+	case ast.IsPropertyAccessExpression(expression):
+		right := expression.Name().Text()
+		if left := getCalledExpressionName(expression.Expression()); left != "" {
+			return left + "." + right
+		}
+		return right
+	}
+	return ""
+}
+
+// cleanNavigationTreeText truncates long names and removes line breaks, including escaped line breaks in
+// string literals.
+func cleanNavigationTreeText(text string) string {
+	if len(text) > maxNavigationTreeNameLength {
+		text = text[:maxNavigationTreeNameLength] + "..."
+	}
+	return strings.NewReplacer("\\\r\n", "", "\\\r", "", "\\\n", "", "\r\n", "", "\r", "", "\n", "", "\u2028", "", "\u2029", "").Replace(text)
+}
+
+func getNavigationTreeSymbolKind(node *ast.Node) lsproto.SymbolKind {
+	switch node.Kind {
+	case ast.KindVariableDeclaration, ast.KindBindingElement:
+		if initializer := node.Initializer(); initializer != nil {
+			switch {
+			case ast.IsFunctionExpressionOrArrowFunction(initializer):
+				return lsproto.SymbolKindFunction
+			case ast.IsClassExpression(initializer):
+				return lsproto.SymbolKindClass
+			}
+		}
+		if ast.IsVarConst(ast.GetRootDeclaration(node)) {
+			return lsproto.SymbolKindConstant
+		}
+		return lsproto.SymbolKindVariable
+	case ast.KindPropertyAssignment:
+		if initializer := node.Initializer(); ast.IsFunctionExpressionOrArrowFunction(initializer) {
+			return lsproto.SymbolKindMethod
+		}
+		return lsproto.SymbolKindProperty
+	case ast.KindShorthandPropertyAssignment, ast.KindParameter:
+		return lsproto.SymbolKindProperty
+	case ast.KindArrowFunction:
+		return lsproto.SymbolKindFunction
+	case ast.KindIndexSignature, ast.KindCallSignature:
+		return lsproto.SymbolKindMethod
+	case ast.KindJSTypeAliasDeclaration:
+		return lsproto.SymbolKindClass
+	case ast.KindExportAssignment:
+		return getSymbolKindOfValue(node.Expression())
+	case ast.KindBinaryExpression:
+		return getSymbolKindOfValue(node.AsBinaryExpression().Right)
+	}
+	return getSymbolKindFromNode(node)
+}
+
+func getSymbolKindOfValue(node *ast.Node) lsproto.SymbolKind {
+	switch {
+	case ast.IsFunctionExpressionOrArrowFunction(node):
+		return lsproto.SymbolKindFunction
+	case ast.IsClassExpression(node):
+		return lsproto.SymbolKindClass
+	}
+	return lsproto.SymbolKindVariable
+}
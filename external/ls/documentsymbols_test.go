@@ -0,0 +1,264 @@
+package ls_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pagpeter/typescript-go/external/bundled"
+	"github.com/pagpeter/typescript-go/external/fourslash"
+	"github.com/pagpeter/typescript-go/external/ls"
+	"github.com/pagpeter/typescript-go/external/lsp/lsproto"
+	"github.com/pagpeter/typescript-go/external/testutil/projecttestutil"
+	"gotest.tools/v3/assert"
+)
+
+func TestDocumentSymbols(t *testing.T) {
+	t.Parallel()
+	if !bundled.Embedded {
+		t.Skip("bundled files are not embedded")
+	}
+
+	testCases := []struct {
+		title    string
+		fileName string
+		input    string
+		// one line per symbol as "name kind", indented by depth
+		expected string
+	}{
+		{
+			title:    "classes",
+			fileName: "/file1.ts",
+			input: `class C {
+    static s = 1;
+    p = class { m() {} };
+    constructor(private x: number) {}
+    get g() { return 1; }
+    m() {
+        function local() {}
+    }
+    [Symbol.iterator]() {}
+}
+interface I {
+    a: string;
+    (): void;
+    [key: string]: unknown;
+}
+enum E { A, B }
+type T = string;`,
+			expected: `C Class
+  s Property
+  p Property
+    m Method
+  constructor Constructor
+  x Property
+  g Property
+  m Method
+    local Function
+  [Symbol.iterator] Method
+I Interface
+  a Property
+  () Method
+  [] Method
+E Enum
+  A EnumMember
+  B EnumMember
+T Class`,
+		},
+		{
+			title:    "namespaces",
+			fileName: "/file1.ts",
+			input: `namespace A.B {
+    export function f() {}
+}
+namespace A.B {
+    export const x = 1;
+}
+namespace A {
+    let y;
+}
+declare module "m" {
+    export interface I {}
+}`,
+			expected: `A.B Namespace
+  f Function
+  x Constant
+A Namespace
+  y Variable
+"m" Namespace
+  I Interface`,
+		},
+		{
+			title:    "objectLiterals",
+			fileName: "/file1.ts",
+			input: `const o = {
+    a: 1,
+    m() {},
+    f: () => {},
+    nested: { b: 2 },
+    a2,
+};
+let { d, e: [g] } = o;
+const fn = () => {
+    const inner = 1;
+};`,
+			expected: `o Constant
+  a Property
+  m Method
+  f Method
+  nested Property
+    b Property
+  a2 Property
+d Variable
+g Variable
+fn Function
+  inner Constant`,
+		},
+		{
+			title:    "exportDefaultObject",
+			fileName: "/file1.ts",
+			input: `export default {
+    a: 1,
+    b() {},
+};`,
+			expected: `default Variable
+  a Property
+  b Method`,
+		},
+		{
+			title:    "exportDefaultDeclarations",
+			fileName: "/file1.ts",
+			input: `export default function () {
+    const x = 1;
+}
+export = 1;`,
+			expected: `default Function
+  x Constant
+export= Variable`,
+		},
+		{
+			title:    "callbacks",
+			fileName: "/file1.ts",
+			input: `describe("suite", () => {
+    it("works", () => {
+        const x = 1;
+    });
+    it("is empty", () => {});
+});
+[1].forEach(function () {});`,
+			expected: `describe("suite") callback Function
+  it("works") callback Function
+    x Constant
+  it("is empty") callback Function
+forEach() callback Function`,
+		},
+		{
+			title:    "overloads",
+			fileName: "/file1.ts",
+			input: `function f(): void;
+function f(x: number): void;
+function f(x?: number) {}
+interface I { a: string }
+interface I { b: string }`,
+			expected: `f Function
+I Interface
+  a Property
+  b Property`,
+		},
+		{
+			title:    "commonJS",
+			fileName: "/file1.js",
+			input: `exports.f = function () {
+    function inner() {}
+};
+exports.value = 1;
+module.exports = {
+    a: 1,
+};`,
+			expected: `f Function
+  inner Function
+value Variable
+export= Variable
+  a Property`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			t.Parallel()
+			testData := fourslash.ParseTestData(t, testCase.input, testCase.fileName)
+			file := testData.Files[0].FileName()
+			content := testData.Files[0].Content
+			ctx := projecttestutil.WithRequestID(t.Context())
+			service, done := createLanguageService(ctx, file, map[string]any{
+				file: content,
+			})
+			defer done()
+
+			symbols, err := service.ProvideDocumentSymbols(ctx, ls.FileNameToDocumentURI(file))
+			assert.NilError(t, err)
+			var lines []string
+			formatDocumentSymbols(&lines, symbols, 0)
+			assert.Equal(t, strings.Join(lines, "\n"), testCase.expected)
+		})
+	}
+}
+
+func TestDocumentSymbolRanges(t *testing.T) {
+	t.Parallel()
+	if !bundled.Embedded {
+		t.Skip("bundled files are not embedded")
+	}
+
+	// ranges are numbered in the order they end
+	input := `[|/** doc */
+export class [|C|] {
+    [|[|m|]() {}|]
+}|]
+[|namespace [|N|] {}
+namespace N {}|]`
+	testData := fourslash.ParseTestData(t, input, "/file1.ts")
+	file := testData.Files[0].FileName()
+	ctx := projecttestutil.WithRequestID(t.Context())
+	service, done := createLanguageService(ctx, file, map[string]any{
+		file: testData.Files[0].Content,
+	})
+	defer done()
+
+	symbols, err := service.ProvideDocumentSymbols(ctx, ls.FileNameToDocumentURI(file))
+	assert.NilError(t, err)
+	assert.Equal(t, len(symbols), 2)
+
+	// The range of a declaration starts after its JSDoc comment.
+	classRange := testData.Ranges[3].LSRange
+	classRange.Start = lsproto.Position{Line: 1}
+	assert.DeepEqual(t, symbols[0].Range, classRange)
+	assert.DeepEqual(t, symbols[0].SelectionRange, testData.Ranges[0].LSRange)
+	method := (*symbols[0].Children)[0]
+	assert.DeepEqual(t, method.Range, testData.Ranges[2].LSRange)
+	assert.DeepEqual(t, method.SelectionRange, testData.Ranges[1].LSRange)
+
+	// Merged declarations span all of their declarations.
+	assert.DeepEqual(t, symbols[1].Range, testData.Ranges[5].LSRange)
+	assert.DeepEqual(t, symbols[1].SelectionRange, testData.Ranges[4].LSRange)
+}
+
+var symbolKindNames = map[lsproto.SymbolKind]string{
+	lsproto.SymbolKindNamespace:   "Namespace",
+	lsproto.SymbolKindClass:       "Class",
+	lsproto.SymbolKindMethod:      "Method",
+	lsproto.SymbolKindProperty:    "Property",
+	lsproto.SymbolKindConstructor: "Constructor",
+	lsproto.SymbolKindEnum:        "Enum",
+	lsproto.SymbolKindInterface:   "Interface",
+	lsproto.SymbolKindFunction:    "Function",
+	lsproto.SymbolKindVariable:    "Variable",
+	lsproto.SymbolKindConstant:    "Constant",
+	lsproto.SymbolKindEnumMember:  "EnumMember",
+}
+
+func formatDocumentSymbols(lines *[]string, symbols []*lsproto.DocumentSymbol, depth int) {
+	for _, symbol := range symbols {
+		*lines = append(*lines, strings.Repeat("  ", depth)+symbol.Name+" "+symbolKindNames[symbol.Kind])
+		formatDocumentSymbols(lines, *symbol.Children, depth+1)
+	}
+}
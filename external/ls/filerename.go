@@ -0,0 +1,289 @@
+package ls
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pagpeter/typescript-go/external/ast"
+	"github.com/pagpeter/typescript-go/external/astnav"
+	"github.com/pagpeter/typescript-go/external/checker"
+	"github.com/pagpeter/typescript-go/external/compiler"
+	"github.com/pagpeter/typescript-go/external/core"
+	"github.com/pagpeter/typescript-go/external/lsp/lsproto"
+	"github.com/pagpeter/typescript-go/external/modulespecifiers"
+	"github.com/pagpeter/typescript-go/external/tsoptions"
+	"github.com/pagpeter/typescript-go/external/tspath"
+)
+
+// pathUpdater maps a path to its location after a file or directory has been moved,
+// reporting false for paths that are not affected by the move.
+type pathUpdater func(path string) (string, bool)
+
+func getPathUpdater(oldFileOrDirPath string, newFileOrDirPath string, options tspath.ComparePathsOptions) pathUpdater {
+	return func(path string) (string, bool) {
+		if tspath.ComparePaths(path, oldFileOrDirPath, options) == 0 {
+			return newFileOrDirPath, true
+		}
+		if tspath.ContainsPath(oldFileOrDirPath, path, options) {
+			return tspath.CombinePaths(newFileOrDirPath, tspath.GetRelativePathFromDirectory(oldFileOrDirPath, path, options)), true
+		}
+		return "", false
+	}
+}
+
+// ProvideFileRenameEdits returns the edits needed before the file or directory at oldURI is moved to
+// newURI: module specifiers and reference paths that point at the moved files, the relative imports of
+// the moved files themselves, and the paths in the project's tsconfig.json.
+func (l *LanguageService) ProvideFileRenameEdits(ctx context.Context, oldURI lsproto.DocumentUri, newURI lsproto.DocumentUri, preferences *UserPreferences) (*lsproto.WorkspaceEdit, error) {
+	program := l.GetProgram()
+	options := tspath.ComparePathsOptions{
+		UseCaseSensitiveFileNames: program.UseCaseSensitiveFileNames(),
+		CurrentDirectory:          program.GetCurrentDirectory(),
+	}
+	oldFileOrDirPath := DocumentURIToFileName(oldURI)
+	newFileOrDirPath := DocumentURIToFileName(newURI)
+	oldToNew := getPathUpdater(oldFileOrDirPath, newFileOrDirPath, options)
+
+	changes := map[lsproto.DocumentUri][]*lsproto.TextEdit{}
+	l.addTsConfigFileRenameEdits(changes, program.CommandLine(), oldToNew, oldFileOrDirPath, newFileOrDirPath, options)
+
+	checker, done := program.GetTypeChecker(ctx)
+	defer done()
+	for _, sourceFile := range program.GetSourceFiles() {
+		if program.IsSourceFileDefaultLibrary(sourceFile.Path()) || program.IsSourceFileFromExternalLibrary(sourceFile) {
+			continue
+		}
+		fileChanges := getImportFileRenameChanges(program, checker, sourceFile, oldToNew, preferences, options)
+		if len(fileChanges) != 0 {
+			changes[FileNameToDocumentURI(sourceFile.FileName())] = l.toLSProtoTextEdits(sourceFile, fileChanges)
+		}
+	}
+	return &lsproto.WorkspaceEdit{Changes: &changes}, nil
+}
+
+func (l *LanguageService) addTsConfigFileRenameEdits(
+	changes map[lsproto.DocumentUri][]*lsproto.TextEdit,
+	commandLine *tsoptions.ParsedCommandLine,
+	oldToNew pathUpdater,
+	oldFileOrDirPath string,
+	newFileOrDirPath string,
+	options tspath.ComparePathsOptions,
+) {
+	if commandLine == nil || commandLine.ConfigFile == nil {
+		return
+	}
+	configFile := commandLine.ConfigFile.SourceFile
+	jsonObjectLiteral := getTsConfigObjectLiteralExpression(configFile)
+	if jsonObjectLiteral == nil {
+		return
+	}
+	configDir := tspath.GetDirectoryPath(configFile.FileName())
+	relativePath := func(path string) string {
+		return tspath.GetRelativePathFromDirectory(configDir, path, options)
+	}
+
+	var fileChanges []core.TextChange
+	tryUpdateString := func(element *ast.Node) bool {
+		if !ast.IsStringLiteral(element) {
+			return false
+		}
+		updated, ok := oldToNew(tspath.GetNormalizedAbsolutePath(element.Text(), configDir))
+		if !ok {
+			return false
+		}
+		fileChanges = append(fileChanges, core.TextChange{TextRange: getStringContentRange(element, configFile), NewText: relativePath(updated)})
+		return true
+	}
+	updatePaths := func(property *ast.PropertyAssignment) bool {
+		elements := []*ast.Node{property.Initializer}
+		if ast.IsArrayLiteralExpression(property.Initializer) {
+			elements = property.Initializer.AsArrayLiteralExpression().Elements.Nodes
+		}
+		foundExactMatch := false
+		for _, element := range elements {
+			foundExactMatch = tryUpdateString(element) || foundExactMatch
+		}
+		return foundExactMatch
+	}
+
+	forEachJsonProperty(jsonObjectLiteral.AsNode(), func(property *ast.PropertyAssignment, propertyName string) {
+		switch propertyName {
+		case "files", "include", "exclude":
+			foundExactMatch := updatePaths(property)
+			if foundExactMatch || propertyName != "include" || !ast.IsArrayLiteralExpression(property.Initializer) {
+				return
+			}
+			// If the file moves out of every `include` pattern, add a new entry for it.
+			elements := property.Initializer.AsArrayLiteralExpression().Elements.Nodes
+			if len(elements) != 0 && commandLine.MatchesIncludeSpecs(oldFileOrDirPath) && !commandLine.MatchesIncludeSpecs(newFileOrDirPath) {
+				lastElement := elements[len(elements)-1]
+				fileChanges = append(fileChanges, newInsertTextChange(lastElement.End(), `, "`+relativePath(newFileOrDirPath)+`"`))
+			}
+		case "compilerOptions":
+			forEachJsonProperty(property.Initializer, func(property *ast.PropertyAssignment, propertyName string) {
+				option := tsoptions.CompilerNameMap.Get(propertyName)
+				if option != nil && (option.IsFilePath() || option.Kind == tsoptions.CommandLineOptionTypeList && option.Elements() != nil && option.Elements().IsFilePath()) {
+					updatePaths(property)
+				} else if propertyName == "paths" {
+					forEachJsonProperty(property.Initializer, func(pathsProperty *ast.PropertyAssignment, _ string) {
+						if ast.IsArrayLiteralExpression(pathsProperty.Initializer) {
+							for _, element := range pathsProperty.Initializer.AsArrayLiteralExpression().Elements.Nodes {
+								tryUpdateString(element)
+							}
+						}
+					})
+				}
+			})
+		}
+	})
+
+	if len(fileChanges) != 0 {
+		// The tsconfig.json is not a file of the program, so its positions are converted with its own line map.
+		converters := NewConverters(l.host.GetPositionEncoding(), func(string) *LineMap { return ComputeLineStarts(configFile.Text()) })
+		edits := make([]*lsproto.TextEdit, 0, len(fileChanges))
+		for _, change := range fileChanges {
+			edits = append(edits, &lsproto.TextEdit{Range: converters.ToLSPRange(configFile, change.TextRange), NewText: change.NewText})
+		}
+		changes[FileNameToDocumentURI(configFile.FileName())] = edits
+	}
+}
+
+func getTsConfigObjectLiteralExpression(configFile *ast.SourceFile) *ast.ObjectLiteralExpression {
+	if configFile.Statements == nil || len(configFile.Statements.Nodes) == 0 {
+		return nil
+	}
+	expression := configFile.Statements.Nodes[0].AsExpressionStatement().Expression
+	if !ast.IsObjectLiteralExpression(expression) {
+		return nil
+	}
+	return expression.AsObjectLiteralExpression()
+}
+
+func forEachJsonProperty(objectLiteral *ast.Node, cb func(property *ast.PropertyAssignment, propertyName string)) {
+	if !ast.IsObjectLiteralExpression(objectLiteral) {
+		return
+	}
+	for _, property := range objectLiteral.AsObjectLiteralExpression().Properties.Nodes {
+		if !ast.IsPropertyAssignment(property) {
+			continue
+		}
+		if propertyName, ok := ast.TryGetTextOfPropertyName(property.Name()); ok {
+			cb(property.AsPropertyAssignment(), propertyName)
+		}
+	}
+}
+
+func getImportFileRenameChanges(
+	program *compiler.Program,
+	checker *checker.Checker,
+	sourceFile *ast.SourceFile,
+	oldToNew pathUpdater,
+	preferences *UserPreferences,
+	options tspath.ComparePathsOptions,
+) []core.TextChange {
+	newImportFromPath, importingSourceFileMoved := oldToNew(sourceFile.FileName())
+	if !importingSourceFileMoved {
+		newImportFromPath = sourceFile.FileName()
+	}
+	oldImportFromDirectory := tspath.GetDirectoryPath(sourceFile.FileName())
+	newImportFromDirectory := tspath.GetDirectoryPath(newImportFromPath)
+
+	var changes []core.TextChange
+	for _, ref := range sourceFile.ReferencedFiles {
+		if !tspath.PathIsRelative(ref.FileName) {
+			continue
+		}
+		target := tspath.CombinePaths(oldImportFromDirectory, ref.FileName)
+		newTarget, targetMoved := oldToNew(target)
+		if !targetMoved && !importingSourceFileMoved {
+			continue
+		}
+		if targetMoved {
+			target = newTarget
+		}
+		updated := tspath.EnsurePathIsNonModuleName(tspath.GetRelativePathFromDirectory(newImportFromDirectory, target, options))
+		if updated != sourceFile.Text()[ref.Pos():ref.End()] {
+			changes = append(changes, core.TextChange{TextRange: ref.TextRange, NewText: updated})
+		}
+	}
+
+	for _, importLiteral := range sourceFile.Imports() {
+		if ast.NodeIsSynthesized(importLiteral) {
+			continue
+		}
+		toImport, updated := getSourceFileToImport(program, checker, sourceFile, importLiteral, oldToNew)
+		// An import needs an update if the imported file moved, or the importing file moved and used a relative path.
+		if toImport == "" || !updated && !(importingSourceFileMoved && tspath.PathIsRelative(importLiteral.Text())) {
+			continue
+		}
+		specifier := modulespecifiers.UpdateModuleSpecifier(
+			program.Options(),
+			sourceFile,
+			newImportFromPath,
+			toImport,
+			program,
+			importLiteral.Text(),
+			preferences.moduleSpecifierPreferences(),
+			modulespecifiers.ModuleSpecifierOptions{},
+		)
+		if specifier != "" {
+			changes = append(changes, core.TextChange{TextRange: getStringContentRange(importLiteral, sourceFile), NewText: specifier})
+		}
+	}
+	return changes
+}
+
+// getSourceFileToImport returns the name the file imported by importLiteral will have after the move,
+// and whether that file moves at all.
+func getSourceFileToImport(program *compiler.Program, checker *checker.Checker, importingSourceFile *ast.SourceFile, importLiteral *ast.Node, oldToNew pathUpdater) (string, bool) {
+	if moduleSymbol := checker.GetSymbolAtLocation(importLiteral); moduleSymbol != nil {
+		// Imports of ambient modules do not depend on file locations.
+		if core.Some(moduleSymbol.Declarations, ast.IsAmbientModule) {
+			return "", false
+		}
+		if declaration := core.Find(moduleSymbol.Declarations, ast.IsSourceFile); declaration != nil {
+			oldFileName := declaration.AsSourceFile().FileName()
+			if newFileName, ok := oldToNew(oldFileName); ok {
+				return newFileName, true
+			}
+			return oldFileName, false
+		}
+	}
+
+	resolved := program.GetResolvedModuleFromModuleSpecifier(importingSourceFile, importLiteral)
+	if resolved == nil {
+		return "", false
+	}
+	if resolved.IsResolved() {
+		if newFileName, ok := oldToNew(resolved.ResolvedFileName); ok {
+			return newFileName, true
+		}
+	}
+	// Look through the failed lookups for a moved file, first those that exist and then, for relative
+	// imports, any of them. package.json files are skipped since they only redirect to other files.
+	tryChange := func(mustExist bool) (string, bool) {
+		for _, location := range resolved.FailedLookupLocations {
+			if strings.HasSuffix(location, "/package.json") || mustExist && !program.FileExists(location) {
+				continue
+			}
+			if newFileName, ok := oldToNew(location); ok {
+				return newFileName, true
+			}
+		}
+		return "", false
+	}
+	if newFileName, ok := tryChange(true /*mustExist*/); ok {
+		return newFileName, true
+	}
+	if tspath.PathIsRelative(importLiteral.Text()) {
+		if newFileName, ok := tryChange(false /*mustExist*/); ok {
+			return newFileName, true
+		}
+	}
+	return resolved.ResolvedFileName, false
+}
+
+// getStringContentRange returns the range of a string literal without its quotes.
+func getStringContentRange(node *ast.Node, sourceFile *ast.SourceFile) core.TextRange {
+	return core.NewTextRange(astnav.GetStartOfNode(node, sourceFile, false /*includeJSDoc*/)+1, node.End()-1)
+}
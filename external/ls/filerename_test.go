@@ -0,0 +1,137 @@
+package ls_test
+
+import (
+	"testing"
+
+	"github.com/pagpeter/typescript-go/external/bundled"
+	"github.com/pagpeter/typescript-go/external/ls"
+	"github.com/pagpeter/typescript-go/external/modulespecifiers"
+	"github.com/pagpeter/typescript-go/external/testutil/projecttestutil"
+	"gotest.tools/v3/assert"
+)
+
+func TestFileRenameEdits(t *testing.T) {
+	t.Parallel()
+	if !bundled.Embedded {
+		t.Skip("bundled files are not embedded")
+	}
+
+	testCases := []struct {
+		title       string
+		files       map[string]any
+		oldPath     string
+		newPath     string
+		preferences *ls.UserPreferences
+		// the new contents of every file that is edited
+		expected map[string]string
+	}{
+		{
+			title: "moveFile",
+			files: map[string]any{
+				"/tsconfig.json": `{ "files": ["src/a.ts", "./src/b.ts", "src/c.ts"] }`,
+				"/src/a.ts":      `import { b } from "./b";`,
+				"/src/b.ts":      "/// <reference path=\"./c.ts\" />\nimport { c } from \"./c\";\nexport const b = c;",
+				"/src/c.ts":      `export const c = 1;`,
+			},
+			oldPath: "/src/b.ts",
+			newPath: "/src/lib/b.ts",
+			expected: map[string]string{
+				"/tsconfig.json": `{ "files": ["src/a.ts", "src/lib/b.ts", "src/c.ts"] }`,
+				"/src/a.ts":      `import { b } from "./lib/b";`,
+				"/src/b.ts":      "/// <reference path=\"../c.ts\" />\nimport { c } from \"../c\";\nexport const b = c;",
+			},
+		},
+		{
+			title: "moveDirectory",
+			files: map[string]any{
+				"/tsconfig.json":     `{ "include": ["src/a.ts", "src/lib", "src/other"] }`,
+				"/src/a.ts":          `import { x } from "./lib/x.js"; export * from "./lib/index.js";`,
+				"/src/lib/index.ts":  `export * from "./x";`,
+				"/src/lib/x.ts":      `export const x = 1;`,
+				"/src/other/unit.ts": `import { x } from "../lib/x";`,
+			},
+			oldPath: "/src/lib",
+			newPath: "/src/util",
+			expected: map[string]string{
+				"/tsconfig.json":     `{ "include": ["src/a.ts", "src/util", "src/other"] }`,
+				"/src/a.ts":          `import { x } from "./util/x.js"; export * from "./util/index.js";`,
+				"/src/other/unit.ts": `import { x } from "../util/x";`,
+			},
+		},
+		{
+			title: "newIncludeEntry",
+			files: map[string]any{
+				"/tsconfig.json": `{ "include": ["src"] }`,
+				"/src/a.ts":      `import { b } from "./b";`,
+				"/src/b.ts":      `export const b = 1;`,
+			},
+			oldPath: "/src/b.ts",
+			newPath: "/lib/b.ts",
+			expected: map[string]string{
+				"/tsconfig.json": `{ "include": ["src", "lib/b.ts"] }`,
+				"/src/a.ts":      `import { b } from "../lib/b";`,
+			},
+		},
+		{
+			title: "keepNonRelativeStyle",
+			files: map[string]any{
+				"/tsconfig.json": `{ "compilerOptions": { "paths": { "@/*": ["./src/*"] } }, "include": ["src"] }`,
+				"/src/a.ts":      `import { b } from "@/b"; import { c } from "./c";`,
+				"/src/b.ts":      `export const b = 1;`,
+				"/src/c.ts":      `export const c = 1;`,
+			},
+			oldPath: "/src/b.ts",
+			newPath: "/src/lib/b.ts",
+			expected: map[string]string{
+				"/src/a.ts": `import { b } from "@/lib/b"; import { c } from "./c";`,
+			},
+		},
+		{
+			title: "importModuleSpecifierPreference",
+			files: map[string]any{
+				"/tsconfig.json": `{ "compilerOptions": { "paths": { "@/*": ["./src/*"] } }, "include": ["src"] }`,
+				"/src/a.ts":      `import { b } from "@/b";`,
+				"/src/b.ts":      `export const b = 1;`,
+			},
+			oldPath:     "/src/b.ts",
+			newPath:     "/src/lib/b.ts",
+			preferences: &ls.UserPreferences{ImportModuleSpecifierPreference: modulespecifiers.ImportModuleSpecifierPreferenceRelative},
+			expected: map[string]string{
+				"/src/a.ts": `import { b } from "./lib/b";`,
+			},
+		},
+		{
+			title: "ambientModule",
+			files: map[string]any{
+				"/tsconfig.json":  `{ "include": ["src"] }`,
+				"/src/a.ts":       `import { m } from "m";`,
+				"/src/types.d.ts": `declare module "m" { export const m: number; }`,
+			},
+			oldPath:  "/src/types.d.ts",
+			newPath:  "/src/lib/types.d.ts",
+			expected: map[string]string{},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			t.Parallel()
+			ctx := projecttestutil.WithRequestID(t.Context())
+			service, done := createLanguageService(ctx, "/src/a.ts", testCase.files)
+			defer done()
+
+			preferences := testCase.preferences
+			if preferences == nil {
+				preferences = &ls.UserPreferences{}
+			}
+			edit, err := service.ProvideFileRenameEdits(ctx, ls.FileNameToDocumentURI(testCase.oldPath), ls.FileNameToDocumentURI(testCase.newPath), preferences)
+			assert.NilError(t, err)
+			actual := map[string]string{}
+			for uri, edits := range *edit.Changes {
+				fileName := ls.DocumentURIToFileName(uri)
+				actual[fileName] = applyTextEdits(testCase.files[fileName].(string), edits)
+			}
+			assert.DeepEqual(t, actual, testCase.expected)
+		})
+	}
+}
@@ -2,6 +2,7 @@ package ls
 
 import (
 	"cmp"
+	"context"
 	"fmt"
 	"slices"
 	"strings"
@@ -413,6 +414,86 @@ func (l *LanguageService) ProvideReferences(params *lsproto.ReferenceParams) []*
 	return core.FlatMap(symbolsAndEntries, l.convertSymbolAndEntryToLocation)
 }
 
+func (l *LanguageService) ProvideImplementations(ctx context.Context, documentURI lsproto.DocumentUri, position lsproto.Position) ([]*lsproto.Location, error) {
+	program, sourceFile := l.getProgramAndFile(documentURI)
+	node := astnav.GetTouchingPropertyName(sourceFile, int(l.converters.LineAndCharacterToPosition(sourceFile, position)))
+
+	var entries []*referenceEntry
+	if node.Parent.Kind == ast.KindPropertyAccessExpression || node.Parent.Kind == ast.KindBindingElement || node.Parent.Kind == ast.KindElementAccessExpression || node.Kind == ast.KindSuperKeyword {
+		entries = l.getImplementationReferenceEntries(ctx, program, node)
+	} else {
+		// Implementations of implementations are implementations too, e.g. a class extending a class that implements an interface.
+		queue := l.getImplementationReferenceEntries(ctx, program, node)
+		seenNodes := collections.Set[*ast.Node]{}
+		for len(queue) != 0 {
+			entry := queue[0]
+			queue = queue[1:]
+			// An implementation found through its name is the same as the declaration found through a heritage clause.
+			declaration := entry.node
+			if ast.IsDeclarationName(declaration) {
+				declaration = declaration.Parent
+			}
+			if !seenNodes.AddIfAbsent(declaration) {
+				continue
+			}
+			entries = append(entries, entry)
+			next := entry.node
+			if declaration.Name() != nil {
+				next = declaration.Name()
+			}
+			queue = append(queue, l.getImplementationReferenceEntries(ctx, program, next)...)
+		}
+	}
+
+	locations := make([]*lsproto.Location, 0, len(entries))
+	for _, entry := range entries {
+		locations = append(locations, &lsproto.Location{
+			Uri:   FileNameToDocumentURI(ast.GetSourceFileOfNode(entry.node).FileName()),
+			Range: *l.getRangeOfEntry(entry),
+		})
+	}
+	return locations, nil
+}
+
+func (l *LanguageService) getImplementationReferenceEntries(ctx context.Context, program *compiler.Program, node *ast.Node) []*referenceEntry {
+	if node.Kind == ast.KindSourceFile {
+		return nil
+	}
+
+	if node.Parent.Kind == ast.KindShorthandPropertyAssignment || node.Kind == ast.KindSuperKeyword || ast.IsAccessExpression(node.Parent) && node.Parent.Expression().Kind == ast.KindSuperKeyword {
+		checker, done := program.GetTypeCheckerForFile(ctx, ast.GetSourceFileOfNode(node))
+		defer done()
+		symbol := checker.GetSymbolAtLocation(node)
+		if symbol == nil {
+			return nil
+		}
+		if node.Parent.Kind == ast.KindShorthandPropertyAssignment {
+			// If invoked directly on a shorthand property assignment, then return
+			// the declaration of the symbol being assigned (not the symbol being assigned to).
+			var result []*referenceEntry
+			if shorthandSymbol := checker.GetShorthandAssignmentValueSymbol(symbol.ValueDeclaration); shorthandSymbol != nil {
+				for _, declaration := range shorthandSymbol.Declarations {
+					if getMeaningFromDeclaration(declaration)&ast.SemanticMeaningValue != 0 {
+						result = append(result, newNodeEntry(declaration))
+					}
+				}
+			}
+			return result
+		}
+		// References to and accesses on the super keyword only have one possible implementation, so no
+		// need to "Find all References"
+		if symbol.ValueDeclaration == nil {
+			return nil
+		}
+		return []*referenceEntry{newNodeEntry(symbol.ValueDeclaration)}
+	}
+
+	// Perform "Find all References" and retrieve only those that are implementations
+	options := refOptions{use: referenceUseReferences, implementations: true}
+	symbolsAndEntries := l.getReferencedSymbolsForNode(node.Pos(), node, program, program.GetSourceFiles(), options, nil)
+	return core.FlatMap(symbolsAndEntries, func(s *SymbolAndEntries) []*referenceEntry { return s.references })
+}
+
 // == functions for conversions ==
 func (l *LanguageService) convertSymbolAndEntryToLocation(s *SymbolAndEntries) []*lsproto.Location {
 	var locations []*lsproto.Location
@@ -937,10 +1018,8 @@ func getReferencedSymbolsForSymbol(originalSymbol *ast.Symbol, node *ast.Node, s
 		exportSpecifier = core.Find(symbol.Declarations, ast.IsExportSpecifier)
 	}
 	if exportSpecifier != nil {
-		// !!! not implemented
-
 		// When renaming at an export specifier, rename the export and not the thing being exported.
-		// state.getReferencesAtExportSpecifier(exportSpecifier.Name(), symbol, exportSpecifier.AsExportSpecifier(), state.createSearch(node, originalSymbol, comingFromUnknown /*comingFrom*/, "", nil), true /*addReferencesHere*/, true /*alwaysGetReferences*/)
+		state.getReferencesAtExportSpecifier(exportSpecifier.Name(), symbol, exportSpecifier.AsExportSpecifier(), state.createSearch(node, originalSymbol, comingFromUnknown /*comingFrom*/, "", nil), true /*addReferencesHere*/, true /*alwaysGetReferences*/)
 	} else if node != nil && node.Kind == ast.KindDefaultKeyword && symbol.Name == ast.InternalSymbolNameDefault && symbol.Parent != nil {
 		state.addReference(node, symbol, entryKindNone)
 		// !!! not implemented
@@ -1022,7 +1101,7 @@ type refState struct {
 
 	inheritsFromCache            map[inheritKey]bool
 	seenContainingTypeReferences *collections.Set[*ast.Node] // node seen tracker
-	// seenReExportRHS           *collections.Set[*ast.Node] // node seen tracker
+	seenReExportRHS              *collections.Set[*ast.Node] // node seen tracker
 	// importTracker             ImportTracker
 	symbolIdToReferences    map[ast.SymbolId]*SymbolAndEntries
 	sourceFileToSeenSymbols map[ast.NodeId]*collections.Set[ast.SymbolId]
@@ -1039,9 +1118,9 @@ func newState(sourceFiles []*ast.SourceFile, sourceFilesSet *collections.Set[str
 		result:                       []*SymbolAndEntries{},
 		inheritsFromCache:            map[inheritKey]bool{},
 		seenContainingTypeReferences: &collections.Set[*ast.Node]{},
-		// seenReExportRHS:           &collections.Set[*ast.Node]{},
-		symbolIdToReferences:    map[ast.SymbolId]*SymbolAndEntries{},
-		sourceFileToSeenSymbols: map[ast.NodeId]*collections.Set[ast.SymbolId]{},
+		seenReExportRHS:              &collections.Set[*ast.Node]{},
+		symbolIdToReferences:         map[ast.SymbolId]*SymbolAndEntries{},
+		sourceFileToSeenSymbols:      map[ast.NodeId]*collections.Set[ast.SymbolId]{},
 	}
 }
 
@@ -1142,12 +1221,12 @@ func (state *refState) addImplementationReferences(refNode *ast.Node, addRef fun
 		return !ast.IsQualifiedName(a.Parent) && !ast.IsTypeNode(a.Parent) && !ast.IsTypeElement(a.Parent)
 	})
 
-	if typeNode == nil || typeNode.Parent.Type() == nil {
+	if typeNode == nil || !canHaveImplementingExpression(typeNode.Parent) || typeNode.Parent.Type() == nil {
 		return
 	}
 
 	typeHavingNode := typeNode.Parent
-	if typeHavingNode.Type() == typeNode && !state.seenContainingTypeReferences.AddIfAbsent(typeHavingNode) {
+	if typeHavingNode.Type() == typeNode && state.seenContainingTypeReferences.AddIfAbsent(typeHavingNode) {
 		addIfImplementation := func(e *ast.Expression) {
 			if isImplementationExpression(e) {
 				addRef(e)
@@ -1262,9 +1341,8 @@ func (state *refState) getReferencesAtLocation(sourceFile *ast.SourceFile, posit
 	}
 
 	if parent.Kind == ast.KindExportSpecifier {
-		// !!! not implemented
 		// debug.Assert(referenceLocation.Kind == ast.KindIdentifier || referenceLocation.Kind == ast.KindStringLiteral)
-		// state.getReferencesAtExportSpecifier(referenceLocation /* Identifier | StringLiteral*/, referenceSymbol, parent.AsExportSpecifier(), search, addReferencesHere, false /*alwaysGetReferences*/)
+		state.getReferencesAtExportSpecifier(referenceLocation /* Identifier | StringLiteral*/, referenceSymbol, parent.AsExportSpecifier(), search, addReferencesHere, false /*alwaysGetReferences*/)
 		return
 	}
 
@@ -1302,6 +1380,63 @@ func (state *refState) getReferencesAtLocation(sourceFile *ast.SourceFile, posit
 	// state.getImportOrExportReferences(referenceLocation, referenceSymbol, search)
 }
 
+func (state *refState) getReferencesAtExportSpecifier(
+	referenceLocation *ast.Node, // Identifier | StringLiteral
+	referenceSymbol *ast.Symbol,
+	exportSpecifier *ast.ExportSpecifier,
+	search *refSearch,
+	addReferencesHere bool,
+	alwaysGetReferences bool,
+) {
+	// debug.Assert(!alwaysGetReferences || state.options.useAliasesForRename, "If alwaysGetReferences is true, then prefix/suffix text must be enabled")
+	propertyName := exportSpecifier.PropertyName
+	name := exportSpecifier.Name()
+	exportDeclaration := exportSpecifier.Parent.Parent.AsExportDeclaration()
+	localSymbol := referenceSymbol
+	if ast.IsIdentifier(referenceLocation) {
+		localSymbol = getLocalSymbolForExportSpecifier(referenceLocation.AsIdentifier(), referenceSymbol, exportSpecifier, state.checker)
+	}
+	if !alwaysGetReferences && !search.includes(localSymbol) {
+		return
+	}
+
+	addRef := func() {
+		if addReferencesHere {
+			state.addReference(referenceLocation, localSymbol, entryKindNone)
+		}
+	}
+
+	if propertyName == nil {
+		// Don't rename at `export { default } from "m";`. (but do continue to search for imports of the re-export)
+		if !(state.options.use == referenceUseRename && ast.ModuleExportNameIsDefault(name)) {
+			addRef()
+		}
+	} else if referenceLocation == propertyName {
+		// For `export { foo as bar } from "baz"`, "`foo`" will be added from the singleReferences for import searches of the original export.
+		// For `export { foo as bar };`, where `foo` is a local, so add it now.
+		if exportDeclaration.ModuleSpecifier == nil {
+			addRef()
+		}
+		if addReferencesHere && state.options.use != referenceUseRename && state.seenReExportRHS.AddIfAbsent(name) {
+			state.addReference(name, exportSpecifier.AsNode().Symbol(), entryKindNone)
+		}
+	} else {
+		if state.seenReExportRHS.AddIfAbsent(referenceLocation) {
+			addRef()
+		}
+	}
+
+	// !!! not implemented
+	// For `export { foo as bar }`, rename `foo`, but not `bar`.
+	// if !isForRenameWithPrefixAndSuffixText(state.options) || alwaysGetReferences {
+	// 	state.searchForImportsOfExport(referenceLocation, exportSpecifier.Symbol, exportInfo)
+	// }
+	// At `export { x } from "foo"`, also search for the imported symbol `"foo".x`.
+	// if search.comingFrom != comingFromExport && exportDeclaration.ModuleSpecifier != nil && propertyName == nil && !isForRenameWithPrefixAndSuffixText(state.options) {
+	// 	state.searchForImportedSymbol(state.checker.GetExportSpecifierLocalTargetSymbol(exportSpecifier.AsNode()))
+	// }
+}
+
 func (state *refState) getReferenceForShorthandProperty(referenceSymbol *ast.Symbol, search *refSearch) {
 	if referenceSymbol.Flags&ast.SymbolFlagsTransient != 0 || referenceSymbol.ValueDeclaration == nil {
 		return
@@ -1414,9 +1549,42 @@ func (state *refState) forEachRelatedSymbol(
 			return nil
 		}), returnKind
 	}
-	// !!! not yet implemented
-	// const containingObjectLiteralElement = getContainingObjectLiteralElement(location);
-	// if (containingObjectLiteralElement) {}
+	if containingObjectLiteralElement := getContainingObjectLiteralElement(location); containingObjectLiteralElement != nil {
+		// Because in short-hand property assignment, location has two meaning : property name and as value of the property
+		// When we do findAllReference at the position of the short-hand property assignment, we would want to have references to position of
+		// property name and variable declaration of the identifier.
+		// Like in below example, when querying for all references for an identifier 'name', of the property assignment, the language service
+		// should show both 'name' in 'obj' and 'name' in variable declaration
+		//      const name = "Foo";
+		//      const obj = { name };
+		// In order to do that, we will populate the search set with the value symbol of the identifier as a value of the property assignment
+		// so that when matching with potential reference symbol, both symbols from property declaration and variable declaration
+		// will be included correctly.
+		shorthandValueSymbol := state.checker.GetShorthandAssignmentValueSymbol(location.Parent) // gets the local symbol
+		if shorthandValueSymbol != nil && isForRenamePopulateSearchSymbolSet {
+			// When renaming 'x' in `const o = { x }`, just rename the local variable, not the property.
+			return cbSymbol(shorthandValueSymbol, nil /*rootSymbol*/, nil /*baseSymbol*/, entryKindSearchedLocalFoundProperty)
+		}
+		// If the location is in a context sensitive location (i.e. in an object literal) try
+		// to get a contextual type for it, and add the property symbol from the contextual
+		// type to the search set
+		if contextualType := state.checker.GetContextualType(containingObjectLiteralElement.Parent, checker.ContextFlagsNone); contextualType != nil {
+			for _, sym := range getPropertySymbolsFromContextualType(containingObjectLiteralElement, state.checker, contextualType, true /*unionSymbolOk*/) {
+				if res, kind := fromRoot(sym, entryKindSearchedPropertyFoundLocal); res != nil {
+					return res, kind
+				}
+			}
+		}
+		// !!! not yet implemented
+		// If the location is name of property symbol from object literal destructuring pattern
+		// Search the property symbol
+		//      for ( { property: p2 } of elems) { }
+		if shorthandValueSymbol != nil {
+			if res, kind := cbSymbol(shorthandValueSymbol, nil /*rootSymbol*/, nil /*baseSymbol*/, entryKindSearchedLocalFoundProperty); res != nil {
+				return res, kind
+			}
+		}
+	}
 
 	if aliasedSymbol := getMergedAliasedSymbolOfNamespaceExportDeclaration(location, symbol, state.checker); aliasedSymbol != nil {
 		// In case of UMD module and global merging, search for global as well
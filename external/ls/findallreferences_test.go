@@ -241,6 +241,17 @@ class C extends [|/*3*/Base|] { }`,
 				"3": collections.NewSetFromItems("2", "3"),
 			},
 		},
+		{
+			title: "findAllRefsContextuallyTypedObjectLiteralProperty",
+			input: `interface I { [|/*0*/a|]: number }
+const o: I = { [|/*1*/a|]: 1 };
+declare function f(i: I): void;
+f({ [|/*2*/a|]: 2 });`,
+			expectedLocations: map[string]*collections.Set[string]{
+				"0": collections.NewSetFromItems("0", "1", "2"),
+				"1": collections.NewSetFromItems("0", "1", "2"),
+			},
+		},
 	}
 
 	for _, testCase := range testCases {
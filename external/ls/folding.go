@@ -0,0 +1,360 @@
+package ls
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/pagpeter/typescript-go/external/ast"
+	"github.com/pagpeter/typescript-go/external/astnav"
+	"github.com/pagpeter/typescript-go/external/core"
+	"github.com/pagpeter/typescript-go/external/lsp/lsproto"
+	"github.com/pagpeter/typescript-go/external/scanner"
+)
+
+// Nodes nested deeper than this are not folded, matching the outlining spans of tsserver.
+const maxFoldingDepth = 40
+
+func (l *LanguageService) ProvideFoldingRanges(ctx context.Context, documentURI lsproto.DocumentUri) ([]*lsproto.FoldingRange, error) {
+	_, file := l.getProgramAndFile(documentURI)
+	spans := collectOutliningSpans(ctx, file)
+	foldingRanges := make([]*lsproto.FoldingRange, 0, len(spans))
+	for _, span := range spans {
+		if foldingRange := l.createFoldingRange(file, span); foldingRange != nil {
+			foldingRanges = append(foldingRanges, foldingRange)
+		}
+	}
+	return foldingRanges, nil
+}
+
+func (l *LanguageService) createFoldingRange(file *ast.SourceFile, span outliningSpan) *lsproto.FoldingRange {
+	text := file.Text()
+	start := l.converters.PositionToLineAndCharacter(file, core.TextPos(span.textRange.Pos()))
+	end := l.converters.PositionToLineAndCharacter(file, core.TextPos(span.textRange.End()))
+	// A comment that ends a region on the same line folds together with the region.
+	if span.kind == lsproto.FoldingRangeKindComment {
+		lineStart := int(file.LineMap()[start.Line])
+		lineText := text[lineStart:scanner.GetEndLinePosition(file, int(start.Line))]
+		if isStart, ok := parseRegionDelimiter(lineText); ok && !isStart {
+			return nil
+		}
+	}
+	// Leave the line of a closing brace, bracket, parenthesis, backtick or tag unfolded, so that
+	// code following it on the same line, e.g. `} else {`, stays visible.
+	endLine := end.Line
+	if end.Character > 0 && strings.ContainsRune("}])`>", rune(text[span.textRange.End()-1])) {
+		endLine = max(end.Line-1, start.Line)
+	}
+	if endLine <= start.Line {
+		return nil
+	}
+	foldingRange := &lsproto.FoldingRange{StartLine: start.Line, EndLine: endLine}
+	if span.kind != "" {
+		foldingRange.Kind = ptrTo(span.kind)
+	}
+	return foldingRange
+}
+
+type outliningSpan struct {
+	textRange core.TextRange
+	// empty for code
+	kind lsproto.FoldingRangeKind
+}
+
+func collectOutliningSpans(ctx context.Context, file *ast.SourceFile) []outliningSpan {
+	c := &outliningSpanCollector{ctx: ctx, file: file, depthRemaining: maxFoldingDepth}
+	c.addNodeOutliningSpans()
+	c.addRegionOutliningSpans()
+	slices.SortStableFunc(c.spans, func(a, b outliningSpan) int {
+		return cmp.Compare(a.textRange.Pos(), b.textRange.Pos())
+	})
+	return c.spans
+}
+
+type outliningSpanCollector struct {
+	ctx            context.Context
+	file           *ast.SourceFile
+	spans          []outliningSpan
+	depthRemaining int
+}
+
+func (c *outliningSpanCollector) addSpan(pos int, end int, kind lsproto.FoldingRangeKind) {
+	c.spans = append(c.spans, outliningSpan{textRange: core.NewTextRange(pos, end), kind: kind})
+}
+
+func (c *outliningSpanCollector) addNodeOutliningSpans() {
+	statements := c.file.Statements.Nodes
+	// Consecutive imports fold together.
+	for i := 0; i < len(statements); {
+		for i < len(statements) && !ast.IsAnyImportSyntax(statements[i]) {
+			c.visitNode(statements[i])
+			i++
+		}
+		if i == len(statements) {
+			break
+		}
+		firstImport := i
+		for i < len(statements) && ast.IsAnyImportSyntax(statements[i]) {
+			c.visitNode(statements[i])
+			i++
+		}
+		if lastImport := i - 1; lastImport != firstImport {
+			start := scanner.GetTokenPosOfNode(statements[firstImport], c.file, false /*includeJSDoc*/)
+			c.addSpan(start, statements[lastImport].End(), lsproto.FoldingRangeKindImports)
+		}
+	}
+	// Comments at the end of the file.
+	c.addOutliningForLeadingComments(c.file.Statements.End())
+}
+
+func (c *outliningSpanCollector) visit(node *ast.Node) bool {
+	c.visitNode(node)
+	return false
+}
+
+func (c *outliningSpanCollector) visitNode(node *ast.Node) {
+	if node == nil || c.depthRemaining == 0 || c.ctx.Err() != nil || node.Flags&ast.NodeFlagsReparsed != 0 {
+		return
+	}
+
+	if ast.IsDeclaration(node) || ast.IsVariableStatement(node) || ast.IsReturnStatement(node) || ast.IsCallOrNewExpression(node) {
+		c.addOutliningForLeadingComments(node.Pos())
+	}
+	if ast.IsFunctionLike(node) && ast.IsBinaryExpression(node.Parent) && ast.IsPropertyAccessExpression(node.Parent.AsBinaryExpression().Left) {
+		c.addOutliningForLeadingComments(node.Parent.AsBinaryExpression().Left.Pos())
+	}
+	if ast.IsBlock(node) || ast.IsModuleBlock(node) {
+		c.addOutliningForLeadingComments(ast.GetStatementsOfBlock(node).End())
+	}
+	if ast.IsClassLike(node) || ast.IsInterfaceDeclaration(node) {
+		c.addOutliningForLeadingComments(node.MemberList().End())
+	}
+
+	c.addOutliningSpanForNode(node)
+
+	c.depthRemaining--
+	switch {
+	case ast.IsCallExpression(node):
+		c.depthRemaining++
+		c.visitNode(node.Expression())
+		c.depthRemaining--
+		for _, argument := range node.Arguments() {
+			c.visitNode(argument)
+		}
+		for _, typeArgument := range node.TypeArguments() {
+			c.visitNode(typeArgument)
+		}
+	case ast.IsIfStatement(node) && node.AsIfStatement().ElseStatement != nil && ast.IsIfStatement(node.AsIfStatement().ElseStatement):
+		// An `else if` is at the same depth as the `if`.
+		ifStatement := node.AsIfStatement()
+		c.visitNode(ifStatement.Expression)
+		c.visitNode(ifStatement.ThenStatement)
+		c.depthRemaining++
+		c.visitNode(ifStatement.ElseStatement)
+		c.depthRemaining--
+	default:
+		node.ForEachChild(c.visit)
+	}
+	c.depthRemaining++
+}
+
+// addOutliningForLeadingComments folds each multi-line comment and each run of two or more
+// single-line comments before pos.
+func (c *outliningSpanCollector) addOutliningForLeadingComments(pos int) {
+	text := c.file.Text()
+	firstSingleLineCommentStart := -1
+	lastSingleLineCommentEnd := -1
+	singleLineCommentCount := 0
+	combineAndAddMultipleSingleLineComments := func() {
+		if singleLineCommentCount > 1 {
+			c.addSpan(firstSingleLineCommentStart, lastSingleLineCommentEnd, lsproto.FoldingRangeKindComment)
+		}
+		singleLineCommentCount = 0
+	}
+	for comment := range scanner.GetLeadingCommentRanges(&ast.NodeFactory{}, text, pos) {
+		switch comment.Kind {
+		case ast.KindSingleLineCommentTrivia:
+			if _, ok := parseRegionDelimiter(text[comment.Pos():comment.End()]); ok {
+				combineAndAddMultipleSingleLineComments()
+				continue
+			}
+			if singleLineCommentCount == 0 {
+				firstSingleLineCommentStart = comment.Pos()
+			}
+			lastSingleLineCommentEnd = comment.End()
+			singleLineCommentCount++
+		case ast.KindMultiLineCommentTrivia:
+			combineAndAddMultipleSingleLineComments()
+			c.addSpan(comment.Pos(), comment.End(), lsproto.FoldingRangeKindComment)
+		}
+	}
+	combineAndAddMultipleSingleLineComments()
+}
+
+func (c *outliningSpanCollector) addOutliningSpanForNode(node *ast.Node) {
+	file := c.file
+	switch node.Kind {
+	case ast.KindBlock:
+		if ast.IsFunctionLike(node.Parent) {
+			c.addFunctionSpan(node.Parent, node)
+			return
+		}
+		// A block attached to a statement folds from the end of the previous token, so that
+		// e.g. `if (x)` and its brace fold together; a standalone block folds from its brace.
+		switch node.Parent.Kind {
+		case ast.KindDoStatement, ast.KindForInStatement, ast.KindForOfStatement, ast.KindForStatement, ast.KindIfStatement,
+			ast.KindWhileStatement, ast.KindWithStatement, ast.KindCatchClause:
+			c.addSpanForNode(node, true /*useFullStart*/, ast.KindOpenBraceToken)
+			return
+		case ast.KindTryStatement:
+			tryStatement := node.Parent.AsTryStatement()
+			if tryStatement.TryBlock == node || tryStatement.FinallyBlock == node {
+				c.addSpanForNode(node, true /*useFullStart*/, ast.KindOpenBraceToken)
+				return
+			}
+		}
+		c.addSpan(scanner.GetTokenPosOfNode(node, file, false /*includeJSDoc*/), node.End(), "")
+	case ast.KindModuleBlock, ast.KindClassDeclaration, ast.KindClassExpression, ast.KindInterfaceDeclaration, ast.KindEnumDeclaration,
+		ast.KindCaseBlock, ast.KindTypeLiteral, ast.KindObjectBindingPattern:
+		c.addSpanForNode(node, true /*useFullStart*/, ast.KindOpenBraceToken)
+	case ast.KindTupleType:
+		c.addSpanForNode(node, !ast.IsTupleTypeNode(node.Parent) /*useFullStart*/, ast.KindOpenBracketToken)
+	case ast.KindCaseClause, ast.KindDefaultClause:
+		if statements := node.AsCaseOrDefaultClause().Statements; len(statements.Nodes) != 0 {
+			c.addSpan(statements.Pos(), statements.End(), "")
+		}
+	case ast.KindObjectLiteralExpression:
+		// Literals in arrays and arguments don't fold the end of the previous line.
+		c.addSpanForNode(node, !ast.IsArrayLiteralExpression(node.Parent) && !ast.IsCallExpression(node.Parent) /*useFullStart*/, ast.KindOpenBraceToken)
+	case ast.KindArrayLiteralExpression:
+		c.addSpanForNode(node, !ast.IsArrayLiteralExpression(node.Parent) && !ast.IsCallExpression(node.Parent) /*useFullStart*/, ast.KindOpenBracketToken)
+	case ast.KindJsxElement:
+		jsxElement := node.AsJsxElement()
+		c.addSpan(scanner.GetTokenPosOfNode(jsxElement.OpeningElement, file, false /*includeJSDoc*/), jsxElement.ClosingElement.End(), "")
+	case ast.KindJsxFragment:
+		jsxFragment := node.AsJsxFragment()
+		c.addSpan(scanner.GetTokenPosOfNode(jsxFragment.OpeningFragment, file, false /*includeJSDoc*/), jsxFragment.ClosingFragment.End(), "")
+	case ast.KindJsxSelfClosingElement, ast.KindJsxOpeningElement:
+		attributes := node.Attributes()
+		if len(attributes.AsJsxAttributes().Properties.Nodes) != 0 {
+			c.addSpan(scanner.GetTokenPosOfNode(attributes, file, false /*includeJSDoc*/), attributes.End(), "")
+		}
+	case ast.KindTemplateExpression, ast.KindNoSubstitutionTemplateLiteral:
+		if node.Kind != ast.KindNoSubstitutionTemplateLiteral || len(node.Text()) != 0 {
+			c.addSpan(scanner.GetTokenPosOfNode(node, file, false /*includeJSDoc*/), node.End(), "")
+		}
+	case ast.KindArrayBindingPattern:
+		c.addSpanForNode(node, !ast.IsBindingElement(node.Parent) /*useFullStart*/, ast.KindOpenBracketToken)
+	case ast.KindArrowFunction:
+		// Expression bodies fold on their own; block bodies fold as function bodies.
+		body := node.Body()
+		if !ast.IsBlock(body) && !ast.IsParenthesizedExpression(body) && !positionsAreOnSameLine(file, body.Pos(), body.End()) {
+			c.addSpan(body.Pos(), body.End(), "")
+		}
+	case ast.KindCallExpression:
+		if len(node.Arguments()) != 0 {
+			c.addSpanBetweenTokens(node, ast.KindOpenParenToken, ast.KindCloseParenToken, true /*useFullStart*/)
+		}
+	case ast.KindParenthesizedExpression:
+		start := scanner.GetTokenPosOfNode(node, file, false /*includeJSDoc*/)
+		if !positionsAreOnSameLine(file, start, node.End()) {
+			c.addSpan(start, node.End(), "")
+		}
+	case ast.KindNamedImports, ast.KindNamedExports, ast.KindImportAttributes:
+		if len(node.Elements()) != 0 {
+			c.addSpanBetweenTokens(node, ast.KindOpenBraceToken, ast.KindCloseBraceToken, false /*useFullStart*/)
+		}
+	}
+}
+
+func (c *outliningSpanCollector) addSpanForNode(node *ast.Node, useFullStart bool, open ast.Kind) {
+	closeKind := ast.KindCloseBraceToken
+	if open == ast.KindOpenBracketToken {
+		closeKind = ast.KindCloseBracketToken
+	}
+	openToken := findChildOfKind(node, open, c.file)
+	closeToken := findChildOfKind(node, closeKind, c.file)
+	if openToken != nil && closeToken != nil {
+		c.addSpan(c.getTokenStart(openToken, useFullStart), closeToken.End(), "")
+	}
+}
+
+// addSpanBetweenTokens folds a list between its opening and closing token, unless both are on the same line.
+func (c *outliningSpanCollector) addSpanBetweenTokens(node *ast.Node, open ast.Kind, close ast.Kind, useFullStart bool) {
+	openToken := findChildOfKind(node, open, c.file)
+	closeToken := findChildOfKind(node, close, c.file)
+	if openToken != nil && closeToken != nil && !positionsAreOnSameLine(c.file, openToken.Pos(), closeToken.Pos()) {
+		c.addSpan(c.getTokenStart(openToken, useFullStart), closeToken.End(), "")
+	}
+}
+
+// addFunctionSpan folds a function body, starting from the parameters when they span multiple lines.
+func (c *outliningSpanCollector) addFunctionSpan(node *ast.Node, body *ast.Node) {
+	var openToken *ast.Node
+	if parameters := node.ParameterList(); parameters != nil && !positionsAreOnSameLine(c.file, parameters.Pos(), parameters.End()) {
+		openToken = findChildOfKind(node, ast.KindOpenParenToken, c.file)
+	}
+	if openToken == nil {
+		openToken = findChildOfKind(body, ast.KindOpenBraceToken, c.file)
+	}
+	closeToken := findChildOfKind(body, ast.KindCloseBraceToken, c.file)
+	if openToken != nil && closeToken != nil {
+		c.addSpan(openToken.Pos(), closeToken.End(), "")
+	}
+}
+
+func (c *outliningSpanCollector) getTokenStart(token *ast.Node, useFullStart bool) int {
+	if useFullStart {
+		return token.Pos()
+	}
+	return scanner.GetTokenPosOfNode(token, c.file, false /*includeJSDoc*/)
+}
+
+// addRegionOutliningSpans folds the lines between `// #region` and `// #endregion` comments.
+func (c *outliningSpanCollector) addRegionOutliningSpans() {
+	text := c.file.Text()
+	var regions []int
+	for line, lineStart := range c.file.LineMap() {
+		lineEnd := scanner.GetEndLinePosition(c.file, line)
+		isStart, ok := parseRegionDelimiter(text[lineStart:lineEnd])
+		if !ok || isInMultiLineComment(c.file, int(lineStart)) {
+			continue
+		}
+		if isStart {
+			regions = append(regions, int(lineStart)+strings.Index(text[lineStart:lineEnd], "//"))
+		} else if len(regions) != 0 {
+			c.addSpan(regions[len(regions)-1], lineEnd, lsproto.FoldingRangeKindRegion)
+			regions = regions[:len(regions)-1]
+		}
+	}
+}
+
+// parseRegionDelimiter reports whether a line is a `// #region` or `// #endregion` comment, and which one.
+func parseRegionDelimiter(lineText string) (isStart bool, ok bool) {
+	lineText = strings.TrimLeftFunc(lineText, unicode.IsSpace)
+	text, found := strings.CutPrefix(lineText, "//")
+	if !found {
+		return false, false
+	}
+	text = strings.TrimSpace(text)
+	if strings.HasPrefix(text, "#region") {
+		return true, true
+	}
+	if strings.HasPrefix(text, "#endregion") {
+		return false, true
+	}
+	return false, false
+}
+
+// isInMultiLineComment reports whether a position is inside a comment that starts before it.
+func isInMultiLineComment(file *ast.SourceFile, position int) bool {
+	token := astnav.GetTokenAtPosition(file, position)
+	for comment := range scanner.GetLeadingCommentRanges(&ast.NodeFactory{}, file.Text(), token.Pos()) {
+		if comment.Pos() < position && position < comment.End() {
+			return true
+		}
+	}
+	return false
+}
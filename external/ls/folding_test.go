@@ -0,0 +1,150 @@
+package ls_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/pagpeter/typescript-go/external/bundled"
+	"github.com/pagpeter/typescript-go/external/fourslash"
+	"github.com/pagpeter/typescript-go/external/ls"
+	"github.com/pagpeter/typescript-go/external/testutil/projecttestutil"
+	"gotest.tools/v3/assert"
+)
+
+func TestFoldingRanges(t *testing.T) {
+	t.Parallel()
+	if !bundled.Embedded {
+		t.Skip("bundled files are not embedded")
+	}
+
+	testCases := []struct {
+		title    string
+		fileName string
+		input    string
+		// folding ranges as "startLine-endLine kind"
+		expected []string
+	}{
+		{
+			title:    "imports",
+			fileName: "/file1.ts",
+			input: `import a from "a";
+import {
+    b,
+    c,
+} from "b";
+import d from "d";
+let x = 1;`,
+			expected: []string{"0-5 imports", "1-3"},
+		},
+		{
+			title:    "functionsAndStatements",
+			fileName: "/file1.ts",
+			input: `function f(x: number) {
+    if (x) {
+        return 1;
+    } else {
+        return 2;
+    }
+}
+switch (f(1)) {
+    case 1:
+        f(2);
+        break;
+}
+const g = () =>
+    x + 1;`,
+			expected: []string{"0-5", "1-2", "3-4", "7-10", "8-10", "12-13"},
+		},
+		{
+			title:    "multiLineParameters",
+			fileName: "/file1.ts",
+			input: `function f(
+    a: number,
+) {
+    return a;
+}`,
+			expected: []string{"0-3"},
+		},
+		{
+			title:    "comments",
+			fileName: "/file1.ts",
+			input: `/**
+ * Doc
+ */
+function f() {}
+// one
+// two
+let x = 1;
+// single
+let y = 2;
+/*
+ * trailing
+ */`,
+			expected: []string{"0-2 comment", "4-5 comment", "9-11 comment"},
+		},
+		{
+			title:    "regions",
+			fileName: "/file1.ts",
+			input: `// #region Outer
+let x = 1;
+    // #region Inner
+let y = 2;
+    // #endregion
+// #endregion
+/*
+// #region in a comment
+*/`,
+			expected: []string{"0-5 region", "2-4 region", "6-8 comment"},
+		},
+		{
+			title:    "literals",
+			fileName: "/file1.ts",
+			input: `const o = {
+    a: [
+        1,
+    ],
+};
+const t = ` + "`" + `
+text
+` + "`" + `;`,
+			expected: []string{"0-3", "1-2", "5-6"},
+		},
+		{
+			title:    "jsx",
+			fileName: "/file1.tsx",
+			input: `const el = <div>
+    <span
+        a="1"
+        b="2" />
+    <>
+        text
+    </>
+</div>;`,
+			expected: []string{"0-6", "2-3", "4-5"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			t.Parallel()
+			testData := fourslash.ParseTestData(t, testCase.input, testCase.fileName)
+			file := testData.Files[0].FileName()
+			ctx := projecttestutil.WithRequestID(t.Context())
+			service, done := createLanguageService(ctx, file, map[string]any{
+				file: testData.Files[0].Content,
+			})
+			defer done()
+
+			foldingRanges, err := service.ProvideFoldingRanges(ctx, ls.FileNameToDocumentURI(file))
+			assert.NilError(t, err)
+			actual := make([]string, len(foldingRanges))
+			for i, foldingRange := range foldingRanges {
+				actual[i] = fmt.Sprintf("%d-%d", foldingRange.StartLine, foldingRange.EndLine)
+				if foldingRange.Kind != nil {
+					actual[i] += " " + string(*foldingRange.Kind)
+				}
+			}
+			assert.DeepEqual(t, actual, testCase.expected)
+		})
+	}
+}
@@ -2,11 +2,13 @@ package ls
 
 import (
 	"github.com/pagpeter/typescript-go/external/compiler"
+	"github.com/pagpeter/typescript-go/external/diagnostics"
 	"github.com/pagpeter/typescript-go/external/lsp/lsproto"
 )
 
 type Host interface {
 	GetProgram() *compiler.Program
 	GetPositionEncoding() lsproto.PositionEncodingKind
+	GetLocale() *diagnostics.Locale
 	GetLineMap(fileName string) *LineMap
 }
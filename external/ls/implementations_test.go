@@ -0,0 +1,94 @@
+package ls_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/pagpeter/typescript-go/external/bundled"
+	"github.com/pagpeter/typescript-go/external/fourslash"
+	"github.com/pagpeter/typescript-go/external/ls"
+	"github.com/pagpeter/typescript-go/external/lsp/lsproto"
+	"github.com/pagpeter/typescript-go/external/testutil/projecttestutil"
+	"gotest.tools/v3/assert"
+)
+
+func TestImplementations(t *testing.T) {
+	t.Parallel()
+	if !bundled.Embedded {
+		t.Skip("bundled files are not embedded")
+	}
+
+	// Every range in the input is expected as an implementation of the marker, in any order.
+	testCases := []struct {
+		title string
+		input string
+	}{
+		{
+			title: "interface",
+			input: `interface /*1*/I { m(): void }
+[|class A implements I { m() {} }|]
+[|class B extends A {}|]
+const o: I = [|{ m() {} }|];
+function f(): I { return [|{ m() {} }|]; }`,
+		},
+		{
+			title: "interfaceMethod",
+			input: `interface I { /*1*/m(): void }
+class A implements I { [|m|]() {} }
+class B extends A { [|m|]() {} }
+const o: I = { [|m|]() {} };`,
+		},
+		{
+			title: "abstractMethod",
+			input: `abstract class Base {
+    abstract /*1*/run(): void;
+}
+class Impl extends Base { [|run|]() {} }
+class Other extends Base { [|run|] = () => {}; }`,
+		},
+		{
+			title: "methodCall",
+			input: `interface I { m(): void }
+class A implements I { [|m|]() {} }
+declare const i: I;
+i./*1*/m();`,
+		},
+		{
+			title: "superCall",
+			input: `class A { [|m() {}|] }
+class B extends A { m() { super./*1*/m(); } }`,
+		},
+		{
+			title: "function",
+			input: `function [|/*1*/f|]() {}
+f();`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			t.Parallel()
+			testData := fourslash.ParseTestData(t, testCase.input, "/file1.ts")
+			file := testData.Files[0].FileName()
+			ctx := projecttestutil.WithRequestID(t.Context())
+			languageService, done := createLanguageService(ctx, file, map[string]any{
+				file: testData.Files[0].Content,
+			})
+			defer done()
+
+			locations, err := languageService.ProvideImplementations(ctx, ls.FileNameToDocumentURI(file), testData.MarkerPositions["1"].LSPosition)
+			assert.NilError(t, err)
+			var actual []lsproto.Range
+			for _, location := range locations {
+				assert.Equal(t, location.Uri, ls.FileNameToDocumentURI(file))
+				actual = append(actual, location.Range)
+			}
+			slices.SortFunc(actual, func(a, b lsproto.Range) int { return ls.CompareRanges(&a, &b) })
+			var expected []lsproto.Range
+			for _, r := range testData.Ranges {
+				expected = append(expected, r.LSRange)
+			}
+			assert.DeepEqual(t, actual, expected)
+		})
+	}
+}
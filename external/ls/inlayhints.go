@@ -0,0 +1,332 @@
+package ls
+
+import (
+	"context"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/pagpeter/typescript-go/external/ast"
+	"github.com/pagpeter/typescript-go/external/checker"
+	"github.com/pagpeter/typescript-go/external/core"
+	"github.com/pagpeter/typescript-go/external/jsnum"
+	"github.com/pagpeter/typescript-go/external/lsp/lsproto"
+	"github.com/pagpeter/typescript-go/external/scanner"
+)
+
+// !!! interactive inlay hints
+func (l *LanguageService) ProvideInlayHints(ctx context.Context, documentURI lsproto.DocumentUri, lspRange lsproto.Range, preferences *UserPreferences) ([]*lsproto.InlayHint, error) {
+	program, file := l.getProgramAndFile(documentURI)
+	typeChecker, done := program.GetTypeCheckerForFile(ctx, file)
+	defer done()
+	state := &inlayHintState{
+		ls:          l,
+		file:        file,
+		checker:     typeChecker,
+		preferences: preferences,
+		span:        l.converters.FromLSPRange(file, lspRange),
+	}
+	state.visit = func(node *ast.Node) bool {
+		return state.visitNode(ctx, node)
+	}
+	file.AsNode().ForEachChild(state.visit)
+	return state.hints, nil
+}
+
+type inlayHintState struct {
+	ls          *LanguageService
+	file        *ast.SourceFile
+	checker     *checker.Checker
+	preferences *UserPreferences
+	span        core.TextRange
+	hints       []*lsproto.InlayHint
+	visit       func(node *ast.Node) bool
+}
+
+func (s *inlayHintState) visitNode(ctx context.Context, node *ast.Node) bool {
+	if node.End() <= node.Pos() || node.End() < s.span.Pos() || node.Pos() > s.span.End() {
+		return false
+	}
+	if ctx.Err() != nil {
+		return true
+	}
+	if ast.IsTypeNode(node) && !ast.IsExpressionWithTypeArguments(node) {
+		return false
+	}
+
+	switch {
+	case ptrIsTrue(s.preferences.IncludeInlayVariableTypeHints) && ast.IsVariableDeclaration(node):
+		s.visitVariableLikeDeclaration(node)
+	case ptrIsTrue(s.preferences.IncludeInlayPropertyDeclarationTypeHints) && ast.IsPropertyDeclaration(node):
+		s.visitVariableLikeDeclaration(node)
+	case ptrIsTrue(s.preferences.IncludeInlayEnumMemberValueHints) && ast.IsEnumMember(node):
+		s.visitEnumMember(node)
+	case s.shouldShowParameterNameHints() && (ast.IsCallExpression(node) || ast.IsNewExpression(node)):
+		s.visitCallOrNewExpression(node)
+	default:
+		if ptrIsTrue(s.preferences.IncludeInlayFunctionParameterTypeHints) && ast.IsFunctionLikeDeclaration(node) && checker.HasContextSensitiveParameters(node) {
+			s.visitFunctionLikeForParameterType(node)
+		}
+		if ptrIsTrue(s.preferences.IncludeInlayFunctionLikeReturnTypeHints) && isSignatureSupportingReturnAnnotation(node) {
+			s.visitFunctionDeclarationLikeForReturnType(node)
+		}
+	}
+	return node.ForEachChild(s.visit)
+}
+
+func (s *inlayHintState) shouldShowParameterNameHints() bool {
+	return s.preferences.IncludeInlayParameterNameHints != nil &&
+		(*s.preferences.IncludeInlayParameterNameHints == IncludeInlayParameterNameHintsLiterals || *s.preferences.IncludeInlayParameterNameHints == IncludeInlayParameterNameHintsAll)
+}
+
+func (s *inlayHintState) shouldShowLiteralParameterNameHintsOnly() bool {
+	return s.preferences.IncludeInlayParameterNameHints != nil && *s.preferences.IncludeInlayParameterNameHints == IncludeInlayParameterNameHintsLiterals
+}
+
+func (s *inlayHintState) addHint(text string, position int, kind *lsproto.InlayHintKind, paddingLeft bool, paddingRight bool) {
+	hint := &lsproto.InlayHint{
+		Position: s.ls.converters.PositionToLineAndCharacter(s.file, core.TextPos(position)),
+		Label:    lsproto.StringOrInlayHintLabelParts{String: &text},
+		Kind:     kind,
+	}
+	if paddingLeft {
+		hint.PaddingLeft = ptrTo(true)
+	}
+	if paddingRight {
+		hint.PaddingRight = ptrTo(true)
+	}
+	s.hints = append(s.hints, hint)
+}
+
+func (s *inlayHintState) addParameterHint(name string, position int, isFirstVariadicArgument bool) {
+	if isFirstVariadicArgument {
+		name = "..." + name
+	}
+	s.addHint(name+":", position, ptrTo(lsproto.InlayHintKindParameter), false /*paddingLeft*/, true /*paddingRight*/)
+}
+
+func (s *inlayHintState) addTypeHint(text string, position int) {
+	s.addHint(": "+text, position, ptrTo(lsproto.InlayHintKindType), false /*paddingLeft*/, false /*paddingRight*/)
+}
+
+// Enum member values have no corresponding LSP hint kind.
+func (s *inlayHintState) addEnumMemberValueHint(text string, position int) {
+	s.addHint("= "+text, position, nil /*kind*/, true /*paddingLeft*/, false /*paddingRight*/)
+}
+
+func (s *inlayHintState) visitEnumMember(member *ast.Node) {
+	if member.Initializer() != nil {
+		return
+	}
+	switch value := s.checker.GetConstantValue(member).(type) {
+	case jsnum.Number:
+		s.addEnumMemberValueHint(value.String(), member.End())
+	case string:
+		text, _ := core.StringifyJson(value, "" /*prefix*/, "" /*indent*/)
+		s.addEnumMemberValueHint(text, member.End())
+	}
+}
+
+func (s *inlayHintState) visitVariableLikeDeclaration(declaration *ast.Node) {
+	if declaration.Initializer() == nil && !(ast.IsPropertyDeclaration(declaration) && s.checker.GetTypeAtLocation(declaration).Flags()&checker.TypeFlagsAny == 0) ||
+		ast.IsBindingPattern(declaration.Name()) ||
+		ast.IsVariableDeclaration(declaration) && !isHintableDeclaration(declaration) {
+		return
+	}
+	if declaration.Type() != nil {
+		return
+	}
+	declarationType := s.checker.GetTypeAtLocation(declaration)
+	if isModuleReferenceType(declarationType) {
+		return
+	}
+	hintText := s.typeToString(declarationType)
+	if ptrIsFalse(s.preferences.IncludeInlayVariableTypeHintsWhenTypeMatchesName) && strings.EqualFold(scanner.GetTextOfNode(declaration.Name()), hintText) {
+		return
+	}
+	s.addTypeHint(hintText, declaration.Name().End())
+}
+
+func (s *inlayHintState) visitCallOrNewExpression(expression *ast.Node) {
+	args := expression.Arguments()
+	if len(args) == 0 {
+		return
+	}
+	signature := s.checker.GetResolvedSignature(expression)
+	if signature == nil {
+		return
+	}
+	signatureParamPos := 0
+	for _, originalArg := range args {
+		arg := ast.SkipParentheses(originalArg)
+		if s.shouldShowLiteralParameterNameHintsOnly() && !isHintableLiteral(arg) {
+			signatureParamPos++
+			continue
+		}
+		spreadArgs := 0
+		if ast.IsSpreadElement(arg) {
+			spreadType := s.checker.GetTypeAtLocation(arg.Expression())
+			if checker.IsTupleType(spreadType) {
+				tupleType := spreadType.TargetTupleType()
+				if tupleType.FixedLength() == 0 {
+					continue
+				}
+				firstOptionalIndex := slices.IndexFunc(tupleType.ElementFlags(), func(flags checker.ElementFlags) bool {
+					return flags&checker.ElementFlagsRequired == 0
+				})
+				if firstOptionalIndex < 0 {
+					spreadArgs = tupleType.FixedLength()
+				} else {
+					spreadArgs = firstOptionalIndex
+				}
+			}
+		}
+		identifierInfo := s.checker.GetParameterIdentifierInfoAtPosition(signature, signatureParamPos)
+		signatureParamPos += max(spreadArgs, 1)
+		if identifierInfo == nil {
+			continue
+		}
+		isParameterNameNotSameAsArgument := ptrIsTrue(s.preferences.IncludeInlayParameterNameHintsWhenArgumentMatchesName) ||
+			!identifierOrAccessExpressionPostfixMatchesParameterName(arg, identifierInfo.ParameterName)
+		if !isParameterNameNotSameAsArgument && !identifierInfo.IsRestParameter {
+			continue
+		}
+		if s.leadingCommentsContainsParameterName(arg, identifierInfo.ParameterName) {
+			continue
+		}
+		s.addParameterHint(identifierInfo.ParameterName, scanner.GetTokenPosOfNode(originalArg, s.file, false /*includeJSDoc*/), identifierInfo.IsRestParameter)
+	}
+}
+
+func identifierOrAccessExpressionPostfixMatchesParameterName(expression *ast.Node, parameterName string) bool {
+	switch {
+	case ast.IsIdentifier(expression):
+		return expression.Text() == parameterName
+	case ast.IsPropertyAccessExpression(expression):
+		return expression.Name().Text() == parameterName
+	}
+	return false
+}
+
+// leadingCommentsContainsParameterName reports whether an argument is already labeled with a comment such
+// as `/*name*/` or `/** name */`.
+func (s *inlayHintState) leadingCommentsContainsParameterName(node *ast.Node, name string) bool {
+	if !scanner.IsIdentifierText(name, s.file.LanguageVariant) {
+		return false
+	}
+	text := s.file.Text()
+	regex := regexp.MustCompile(`^\s?/\*\*?\s?` + regexp.QuoteMeta(name) + `\s?\*/\s?$`)
+	for commentRange := range scanner.GetLeadingCommentRanges(&ast.NodeFactory{}, text, node.Pos()) {
+		if regex.MatchString(text[commentRange.Pos():commentRange.End()]) {
+			return true
+		}
+	}
+	return false
+}
+
+func isHintableLiteral(node *ast.Node) bool {
+	switch node.Kind {
+	case ast.KindPrefixUnaryExpression:
+		operand := node.AsPrefixUnaryExpression().Operand
+		return ast.IsLiteralExpression(operand) || ast.IsIdentifier(operand) && isInfinityOrNaNString(operand.Text())
+	case ast.KindTrueKeyword, ast.KindFalseKeyword, ast.KindNullKeyword, ast.KindNoSubstitutionTemplateLiteral, ast.KindTemplateExpression:
+		return true
+	case ast.KindIdentifier:
+		name := node.Text()
+		return name == "undefined" || isInfinityOrNaNString(name)
+	}
+	return ast.IsLiteralExpression(node)
+}
+
+// isHintableDeclaration reports whether the type of a declaration is not evident from its initializer.
+func isHintableDeclaration(node *ast.Node) bool {
+	if (ast.IsPartOfParameterDeclaration(node) || ast.IsVariableDeclaration(node) && ast.IsVarConst(node)) && node.Initializer() != nil {
+		initializer := ast.SkipParentheses(node.Initializer())
+		return !(isHintableLiteral(initializer) || ast.IsNewExpression(initializer) || ast.IsObjectLiteralExpression(initializer) || ast.IsAssertionExpression(initializer))
+	}
+	return true
+}
+
+func isSignatureSupportingReturnAnnotation(node *ast.Node) bool {
+	switch node.Kind {
+	case ast.KindArrowFunction, ast.KindFunctionExpression, ast.KindFunctionDeclaration, ast.KindMethodDeclaration, ast.KindGetAccessor:
+		return true
+	}
+	return false
+}
+
+func (s *inlayHintState) visitFunctionDeclarationLikeForReturnType(declaration *ast.Node) {
+	if ast.IsArrowFunction(declaration) && findChildOfKind(declaration, ast.KindOpenParenToken, s.file) == nil {
+		return
+	}
+	if declaration.Type() != nil || declaration.Body() == nil {
+		return
+	}
+	signature := s.checker.GetSignatureFromDeclaration(declaration)
+	if signature == nil {
+		return
+	}
+	if typePredicate := s.checker.GetTypePredicateOfSignature(signature); typePredicate != nil && typePredicate.Type() != nil {
+		s.addTypeHint(s.checker.TypePredicateToString(typePredicate), s.getTypeAnnotationPosition(declaration))
+		return
+	}
+	returnType := s.checker.GetReturnTypeOfSignature(signature)
+	if isModuleReferenceType(returnType) {
+		return
+	}
+	s.addTypeHint(s.typeToString(returnType), s.getTypeAnnotationPosition(declaration))
+}
+
+func (s *inlayHintState) getTypeAnnotationPosition(declaration *ast.Node) int {
+	if closeParenToken := findChildOfKind(declaration, ast.KindCloseParenToken, s.file); closeParenToken != nil {
+		return closeParenToken.End()
+	}
+	return declaration.ParameterList().End()
+}
+
+func (s *inlayHintState) visitFunctionLikeForParameterType(node *ast.Node) {
+	signature := s.checker.GetSignatureFromDeclaration(node)
+	if signature == nil {
+		return
+	}
+	pos := 0
+	for _, param := range node.Parameters() {
+		if ast.IsThisParameter(param) {
+			if isHintableDeclaration(param) {
+				s.addParameterTypeHint(param, signature.ThisParameter())
+			}
+			continue
+		}
+		if isHintableDeclaration(param) && pos < len(signature.Parameters()) {
+			s.addParameterTypeHint(param, signature.Parameters()[pos])
+		}
+		pos++
+	}
+}
+
+func (s *inlayHintState) addParameterTypeHint(node *ast.Node, symbol *ast.Symbol) {
+	if node.Type() != nil || symbol == nil {
+		return
+	}
+	valueDeclaration := symbol.ValueDeclaration
+	if valueDeclaration == nil || !ast.IsParameter(valueDeclaration) {
+		return
+	}
+	signatureParamType := s.checker.GetTypeOfSymbolAtLocation(symbol, valueDeclaration)
+	if isModuleReferenceType(signatureParamType) {
+		return
+	}
+	position := node.Name().End()
+	if questionToken := node.AsParameterDeclaration().QuestionToken; questionToken != nil {
+		position = questionToken.End()
+	}
+	s.addTypeHint(s.typeToString(signatureParamType), position)
+}
+
+func (s *inlayHintState) typeToString(t *checker.Type) string {
+	return s.checker.TypeToStringEx(t, nil /*enclosingDeclaration*/, checker.TypeFormatFlagsAllowUniqueESSymbolType|checker.TypeFormatFlagsUseAliasDefinedOutsideCurrentScope)
+}
+
+func isModuleReferenceType(t *checker.Type) bool {
+	return t.Symbol() != nil && t.Symbol().Flags&ast.SymbolFlagsModule != 0
+}
@@ -0,0 +1,204 @@
+package ls_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pagpeter/typescript-go/external/bundled"
+	"github.com/pagpeter/typescript-go/external/fourslash"
+	"github.com/pagpeter/typescript-go/external/ls"
+	"github.com/pagpeter/typescript-go/external/lsp/lsproto"
+	"github.com/pagpeter/typescript-go/external/testutil/projecttestutil"
+	"gotest.tools/v3/assert"
+)
+
+func TestInlayHints(t *testing.T) {
+	t.Parallel()
+	if !bundled.Embedded {
+		t.Skip("bundled files are not embedded")
+	}
+
+	testCases := []struct {
+		title       string
+		input       string
+		preferences *ls.UserPreferences
+		// input with each hint label inserted at its position
+		expected string
+	}{
+		{
+			title: "parameterNamesAll",
+			input: `function f(a: number, b: string, ...rest: boolean[]) {}
+declare const b: string;
+f(1, b, true, false);
+new Date(2000, 1);`,
+			preferences: &ls.UserPreferences{IncludeInlayParameterNameHints: ptrTo(ls.IncludeInlayParameterNameHintsAll)},
+			expected: `function f(a: number, b: string, ...rest: boolean[]) {}
+declare const b: string;
+f(a: 1, b, ...rest: true, false);
+new Date(year: 2000, monthIndex: 1);`,
+		},
+		{
+			title: "parameterNamesLiterals",
+			input: `function f(a: number, b: number) {}
+declare const x: number;
+f(x, 2);
+f(
+    /* a */ 1,
+    -2);`,
+			preferences: &ls.UserPreferences{IncludeInlayParameterNameHints: ptrTo(ls.IncludeInlayParameterNameHintsLiterals)},
+			expected: `function f(a: number, b: number) {}
+declare const x: number;
+f(x, b: 2);
+f(
+    /* a */ 1,
+    b: -2);`,
+		},
+		{
+			title: "parameterNamesWhenArgumentMatchesName",
+			input: `function f(a: number) {}
+declare const o: { a: number };
+f(o.a);`,
+			preferences: &ls.UserPreferences{
+				IncludeInlayParameterNameHints:                        ptrTo(ls.IncludeInlayParameterNameHintsAll),
+				IncludeInlayParameterNameHintsWhenArgumentMatchesName: ptrTo(true),
+			},
+			expected: `function f(a: number) {}
+declare const o: { a: number };
+f(a: o.a);`,
+		},
+		{
+			title: "variableTypes",
+			input: `let a = 1;
+const b = 1;
+const c = [1, 2];
+const d = new Date();
+const [e] = [1];`,
+			preferences: &ls.UserPreferences{IncludeInlayVariableTypeHints: ptrTo(true)},
+			expected: `let a: number = 1;
+const b = 1;
+const c: number[] = [1, 2];
+const d = new Date();
+const [e] = [1];`,
+		},
+		{
+			title: "variableTypesWhenTypeMatchesName",
+			input: `interface Foo {}
+declare function make(): Foo;
+const foo = make();
+const bar = make();`,
+			preferences: &ls.UserPreferences{
+				IncludeInlayVariableTypeHints:                    ptrTo(true),
+				IncludeInlayVariableTypeHintsWhenTypeMatchesName: ptrTo(false),
+			},
+			expected: `interface Foo {}
+declare function make(): Foo;
+const foo = make();
+const bar: Foo = make();`,
+		},
+		{
+			title: "propertyDeclarationTypes",
+			input: `class C {
+    a = "x".length;
+    b;
+    constructor() { this.b = 1; }
+}`,
+			preferences: &ls.UserPreferences{IncludeInlayPropertyDeclarationTypeHints: ptrTo(true)},
+			expected: `class C {
+    a: number = "x".length;
+    b;
+    constructor() { this.b = 1; }
+}`,
+		},
+		{
+			title: "functionReturnTypes",
+			input: `function f() { return 1; }
+const g = (x: string) => x;
+const h = x => x;
+class C { get p() { return ""; } m(): void {} }
+function isString(x: unknown) { return typeof x === "string"; }`,
+			preferences: &ls.UserPreferences{IncludeInlayFunctionLikeReturnTypeHints: ptrTo(true)},
+			expected: `function f(): number { return 1; }
+const g = (x: string): string => x;
+const h = x => x;
+class C { get p(): string { return ""; } m(): void {} }
+function isString(x: unknown): x is string { return typeof x === "string"; }`,
+		},
+		{
+			title: "functionParameterTypes",
+			input: `declare function f(cb: (a: number, b?: string) => void): void;
+f((a, b?) => {});
+f(function (a) {});`,
+			preferences: &ls.UserPreferences{IncludeInlayFunctionParameterTypeHints: ptrTo(true)},
+			expected: `declare function f(cb: (a: number, b?: string) => void): void;
+f((a: number, b?: string | undefined) => {});
+f(function (a: number) {});`,
+		},
+		{
+			title: "enumMemberValues",
+			input: `enum E { A, B = 5, C }
+enum S { X = "x", Y = X }`,
+			preferences: &ls.UserPreferences{IncludeInlayEnumMemberValueHints: ptrTo(true)},
+			expected: `enum E { A = 0, B = 5, C = 6 }
+enum S { X = "x", Y = X }`,
+		},
+		{
+			title: "range",
+			input: `let a = 1;
+[|let b = 2;|]
+let c = 3;`,
+			preferences: &ls.UserPreferences{IncludeInlayVariableTypeHints: ptrTo(true)},
+			expected: `let a = 1;
+let b: number = 2;
+let c = 3;`,
+		},
+		{
+			title: "noPreferences",
+			input: `function f(a: number) { return a; }
+let x = f(1);`,
+			preferences: &ls.UserPreferences{},
+			expected: `function f(a: number) { return a; }
+let x = f(1);`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			t.Parallel()
+			testData := fourslash.ParseTestData(t, testCase.input, "/file1.ts")
+			file := testData.Files[0].FileName()
+			content := testData.Files[0].Content
+			ctx := projecttestutil.WithRequestID(t.Context())
+			service, done := createLanguageService(ctx, file, map[string]any{
+				file: content,
+			})
+			defer done()
+
+			lspRange := lsproto.Range{End: lsproto.Position{
+				Line:      uint32(strings.Count(content, "\n")),
+				Character: uint32(len(content) - strings.LastIndex(content, "\n") - 1),
+			}}
+			if len(testData.Ranges) != 0 {
+				lspRange = testData.Ranges[0].LSRange
+			}
+			hints, err := service.ProvideInlayHints(ctx, ls.FileNameToDocumentURI(file), lspRange, testCase.preferences)
+			assert.NilError(t, err)
+			assert.Equal(t, renderInlayHints(content, hints), testCase.expected)
+		})
+	}
+}
+
+// renderInlayHints inserts the label of each hint, with its padding, into the text.
+func renderInlayHints(text string, hints []*lsproto.InlayHint) string {
+	edits := make([]*lsproto.TextEdit, len(hints))
+	for i, hint := range hints {
+		label := *hint.Label.String
+		if hint.PaddingLeft != nil && *hint.PaddingLeft {
+			label = " " + label
+		}
+		if hint.PaddingRight != nil && *hint.PaddingRight {
+			label += " "
+		}
+		edits[i] = &lsproto.TextEdit{Range: lsproto.Range{Start: hint.Position, End: hint.Position}, NewText: label}
+	}
+	return applyTextEdits(text, edits)
+}
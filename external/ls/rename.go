@@ -0,0 +1,256 @@
+package ls
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/pagpeter/typescript-go/external/ast"
+	"github.com/pagpeter/typescript-go/external/astnav"
+	"github.com/pagpeter/typescript-go/external/checker"
+	"github.com/pagpeter/typescript-go/external/compiler"
+	"github.com/pagpeter/typescript-go/external/core"
+	"github.com/pagpeter/typescript-go/external/diagnostics"
+	"github.com/pagpeter/typescript-go/external/lsp/lsproto"
+	"github.com/pagpeter/typescript-go/external/scanner"
+	"github.com/pagpeter/typescript-go/external/tspath"
+)
+
+type renameInfo struct {
+	node        *ast.Node
+	displayName string
+	triggerSpan core.TextRange
+}
+
+func (l *LanguageService) ProvidePrepareRename(ctx context.Context, documentURI lsproto.DocumentUri, position lsproto.Position) (*lsproto.PrepareRenameResult, error) {
+	program, file := l.getProgramAndFile(documentURI)
+	info, err := l.getRenameInfo(ctx, program, file, int(l.converters.LineAndCharacterToPosition(file, position)))
+	if err != nil {
+		return nil, err
+	}
+	return &lsproto.PrepareRenameResult{
+		PrepareRenamePlaceholder: &lsproto.PrepareRenamePlaceholder{
+			Range:       l.converters.ToLSPRange(file, info.triggerSpan),
+			Placeholder: info.displayName,
+		},
+	}, nil
+}
+
+func (l *LanguageService) ProvideRename(ctx context.Context, documentURI lsproto.DocumentUri, position lsproto.Position, newName string) (*lsproto.WorkspaceEdit, error) {
+	program, file := l.getProgramAndFile(documentURI)
+	pos := int(l.converters.LineAndCharacterToPosition(file, position))
+	info, err := l.getRenameInfo(ctx, program, file, pos)
+	if err != nil {
+		return nil, err
+	}
+
+	options := refOptions{use: referenceUseRename, useAliasesForRename: true}
+	symbolsAndEntries := l.getReferencedSymbolsForNode(pos, info.node, program, program.GetSourceFiles(), options, nil)
+
+	checker, done := program.GetTypeCheckerForFile(ctx, file)
+	defer done()
+
+	changes := map[lsproto.DocumentUri][]*lsproto.TextEdit{}
+	for _, symbolAndEntries := range symbolsAndEntries {
+		for _, entry := range symbolAndEntries.references {
+			fileName := entry.fileName
+			if entry.kind != entryKindRange {
+				fileName = ast.GetSourceFileOfNode(entry.node).FileName()
+			}
+			uri := FileNameToDocumentURI(fileName)
+			textRange := *l.getRangeOfEntry(entry)
+			// The same location may be reached through more than one related symbol.
+			if slices.ContainsFunc(changes[uri], func(edit *lsproto.TextEdit) bool { return edit.Range == textRange }) {
+				continue
+			}
+			changes[uri] = append(changes[uri], &lsproto.TextEdit{
+				Range:   textRange,
+				NewText: getTextForRename(info.node, entry, newName, checker),
+			})
+		}
+	}
+	return &lsproto.WorkspaceEdit{Changes: &changes}, nil
+}
+
+func (l *LanguageService) getRenameInfo(ctx context.Context, program *compiler.Program, sourceFile *ast.SourceFile, position int) (*renameInfo, error) {
+	node := getAdjustedLocation(astnav.GetTouchingPropertyName(sourceFile, position), true /*forRename*/, sourceFile)
+	if nodeIsEligibleForRename(node) {
+		checker, done := program.GetTypeCheckerForFile(ctx, sourceFile)
+		defer done()
+		info, message := getRenameInfoForNode(node, checker, sourceFile, program)
+		if message != nil {
+			return nil, fmt.Errorf("%w: %s", lsproto.ErrRequestFailed, message.Message())
+		}
+		if info != nil {
+			return info, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", lsproto.ErrRequestFailed, diagnostics.You_cannot_rename_this_element.Message())
+}
+
+func getRenameInfoForNode(node *ast.Node, typeChecker *checker.Checker, sourceFile *ast.SourceFile, program *compiler.Program) (*renameInfo, *diagnostics.Message) {
+	symbol := typeChecker.GetSymbolAtLocation(node)
+	if symbol == nil {
+		// !!! string literal types
+		if ast.IsLabelName(node) {
+			return newRenameInfo(node, node.Text(), sourceFile), nil
+		}
+		return nil, nil
+	}
+
+	// Only allow a symbol to be renamed if it actually has at least one declaration.
+	if len(symbol.Declarations) == 0 {
+		return nil, nil
+	}
+
+	// Disallow rename for elements that are defined in the standard TypeScript library.
+	if core.Some(symbol.Declarations, func(declaration *ast.Node) bool { return isDefinedInLibraryFile(program, declaration) }) {
+		return nil, diagnostics.You_cannot_rename_elements_that_are_defined_in_the_standard_TypeScript_library
+	}
+
+	// Cannot rename `default` as in `import { default as foo } from "./someModule";
+	if ast.IsIdentifier(node) && node.Text() == ast.InternalSymbolNameDefault && symbol.Parent != nil && symbol.Parent.Flags&ast.SymbolFlagsModule != 0 {
+		return nil, nil
+	}
+
+	// !!! allowRenameOfImportPath
+	if ast.IsStringLiteralLike(node) && tryGetImportFromModuleSpecifier(node) != nil {
+		return nil, nil
+	}
+
+	// Disallow rename for elements that would rename across `*/node_modules/*` packages.
+	if message := wouldRenameInOtherNodeModules(sourceFile, symbol); message != nil {
+		return nil, message
+	}
+
+	var displayName string
+	if ast.IsImportOrExportSpecifier(node.Parent) || ast.IsStringOrNumericLiteralLike(node) && node.Parent.Kind == ast.KindComputedPropertyName {
+		displayName = node.Text()
+	} else {
+		displayName = typeChecker.SymbolToString(symbol)
+	}
+	return newRenameInfo(node, displayName, sourceFile), nil
+}
+
+func newRenameInfo(node *ast.Node, displayName string, sourceFile *ast.SourceFile) *renameInfo {
+	start := scanner.GetTokenPosOfNode(node, sourceFile, false /*includeJSDoc*/)
+	end := node.End()
+	if ast.IsStringLiteralLike(node) {
+		// Exclude the quotes
+		start += 1
+		end -= 1
+	}
+	return &renameInfo{
+		node:        node,
+		displayName: displayName,
+		triggerSpan: core.NewTextRange(start, end),
+	}
+}
+
+func nodeIsEligibleForRename(node *ast.Node) bool {
+	switch node.Kind {
+	case ast.KindIdentifier,
+		ast.KindPrivateIdentifier,
+		ast.KindStringLiteral,
+		ast.KindNoSubstitutionTemplateLiteral,
+		ast.KindThisKeyword:
+		return true
+	case ast.KindNumericLiteral:
+		return isLiteralNameOfPropertyDeclarationOrIndexAccess(node)
+	}
+	return false
+}
+
+func isDefinedInLibraryFile(program *compiler.Program, declaration *ast.Node) bool {
+	sourceFile := ast.GetSourceFileOfNode(declaration)
+	return program.IsSourceFileDefaultLibrary(sourceFile.Path()) && tspath.FileExtensionIs(sourceFile.FileName(), tspath.ExtensionDts)
+}
+
+func wouldRenameInOtherNodeModules(originalFile *ast.SourceFile, symbol *ast.Symbol) *diagnostics.Message {
+	if len(symbol.Declarations) == 0 {
+		return nil
+	}
+	originalPackage := getPackagePathComponents(originalFile.Path())
+	if originalPackage == nil {
+		// original source file is not in node_modules
+		if core.Some(symbol.Declarations, func(declaration *ast.Node) bool {
+			return getPackagePathComponents(ast.GetSourceFileOfNode(declaration).Path()) != nil
+		}) {
+			return diagnostics.You_cannot_rename_elements_that_are_defined_in_a_node_modules_folder
+		}
+		return nil
+	}
+	// original source file is in node_modules
+	for _, declaration := range symbol.Declarations {
+		if declPackage := getPackagePathComponents(ast.GetSourceFileOfNode(declaration).Path()); declPackage != nil {
+			if !slices.Equal(originalPackage, declPackage) {
+				return diagnostics.You_cannot_rename_elements_that_are_defined_in_another_node_modules_folder
+			}
+		}
+	}
+	return nil
+}
+
+func getPackagePathComponents(filePath tspath.Path) []string {
+	components := tspath.GetPathComponents(string(filePath), "")
+	for i := len(components) - 1; i >= 0; i-- {
+		if components[i] == "node_modules" {
+			return components[:min(i+2, len(components))]
+		}
+	}
+	return nil
+}
+
+// Returns the replacement text for a rename location, adding a prefix or suffix where the location
+// must keep its original name, e.g. `{ x }` becomes `{ x: y }` when renaming the local `x` to `y`.
+func getTextForRename(originalNode *ast.Node, entry *referenceEntry, newName string, checker *checker.Checker) string {
+	if entry.kind != entryKindRange && (ast.IsIdentifier(originalNode) || ast.IsStringLiteralLike(originalNode)) {
+		node := entry.node
+		parent := node.Parent
+		name := originalNode.Text()
+		isShorthandAssignment := ast.IsShorthandPropertyAssignment(parent)
+		switch {
+		case isShorthandAssignment || (isObjectBindingElementWithoutPropertyName(parent) && parent.Name() == node && parent.AsBindingElement().DotDotDotToken == nil):
+			switch entry.kind {
+			case entryKindSearchedLocalFoundProperty:
+				return name + ": " + newName
+			case entryKindSearchedPropertyFoundLocal:
+				return newName + ": " + name
+			}
+			// In `const o = { x }; o.x`, symbolAtLocation at `x` in `{ x }` is the property symbol.
+			// For a binding element `const { x } = o;`, symbolAtLocation at `x` is the property symbol.
+			if isShorthandAssignment {
+				grandParent := parent.Parent
+				if ast.IsObjectLiteralExpression(grandParent) && ast.IsBinaryExpression(grandParent.Parent) && ast.IsModuleExportsAccessExpression(grandParent.Parent.AsBinaryExpression().Left) {
+					return name + ": " + newName
+				}
+				return newName + ": " + name
+			}
+			return name + ": " + newName
+		case ast.IsImportSpecifier(parent) && parent.PropertyName() == nil:
+			// If the original symbol was using this alias, just rename the alias.
+			var originalSymbol *ast.Symbol
+			if ast.IsExportSpecifier(originalNode.Parent) {
+				originalSymbol = checker.GetExportSpecifierLocalTargetSymbol(originalNode.Parent)
+			} else {
+				originalSymbol = checker.GetSymbolAtLocation(originalNode)
+			}
+			if originalSymbol != nil && slices.Contains(originalSymbol.Declarations, parent) {
+				return name + " as " + newName
+			}
+		case ast.IsExportSpecifier(parent) && parent.PropertyName() == nil:
+			// If the symbol for the node is same as declared node symbol use prefix text
+			if originalNode == node || checker.GetSymbolAtLocation(originalNode) == checker.GetSymbolAtLocation(node) {
+				return name + " as " + newName
+			}
+			return newName + " as " + name
+		}
+	}
+
+	// If the node is a numerical indexing literal, then add quotes around the property access.
+	if entry.kind != entryKindRange && ast.IsNumericLiteral(entry.node) && ast.IsAccessExpression(entry.node.Parent) {
+		// !!! quote preference
+		return `"` + newName + `"`
+	}
+	return newName
+}
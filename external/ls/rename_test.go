@@ -0,0 +1,186 @@
+package ls_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/pagpeter/typescript-go/external/fourslash"
+	"github.com/pagpeter/typescript-go/external/ls"
+	"github.com/pagpeter/typescript-go/external/lsp/lsproto"
+	"github.com/pagpeter/typescript-go/external/testutil/projecttestutil"
+	"gotest.tools/v3/assert"
+)
+
+func TestRename(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		title    string
+		input    string
+		fileName string
+		newName  string
+		// expected replacement text for each range, in order
+		expected []string
+	}{
+		{
+			title: "localVariable",
+			input: `let [|/*1*/x|] = 1;
+[|x|]++;
+function f() { return [|x|]; }`,
+			newName:  "y",
+			expected: []string{"y", "y", "y"},
+		},
+		{
+			title: "shorthandPropertyFromLocal",
+			input: `const [|/*1*/x|] = 1;
+const o = { [|x|] };
+o.x;`,
+			newName:  "y",
+			expected: []string{"y", "x: y"},
+		},
+		{
+			title: "shorthandPropertyFromShorthand",
+			input: `const [|x|] = 1;
+const o = { [|/*1*/x|] };`,
+			newName:  "y",
+			expected: []string{"y", "x: y"},
+		},
+		{
+			title: "bindingElementWithoutPropertyName",
+			input: `declare const o: { x: number };
+const { [|/*1*/x|] } = o;
+[|x|];`,
+			newName:  "y",
+			expected: []string{"x: y", "y"},
+		},
+		{
+			title: "importSpecifier",
+			input: `import { [|/*1*/a|] } from "./other";
+[|a|];`,
+			newName:  "b",
+			expected: []string{"a as b", "b"},
+		},
+		{
+			title: "exportSpecifier",
+			input: `const [|/*1*/a|] = 1;
+export { [|a|] };`,
+			newName:  "b",
+			expected: []string{"b", "b as a"},
+		},
+		{
+			title: "exportSpecifierWithPropertyName",
+			input: `const [|/*1*/a|] = 1;
+export { [|a|] as c };`,
+			newName:  "b",
+			expected: []string{"b", "b"},
+		},
+		{
+			title: "stringLiteralPropertyName",
+			input: `const o = { "[|/*1*/a b|]": 1 };
+o["[|a b|]"];`,
+			newName:  "c",
+			expected: []string{"c", "c"},
+		},
+		{
+			title: "jsxTagPair",
+			input: `declare namespace JSX { interface Element {} interface IntrinsicElements {} }
+function [|/*1*/Foo|](): JSX.Element { return null!; }
+const e = <[|Foo|]></[|Foo|]>;`,
+			fileName: "/file1.tsx",
+			newName:  "Bar",
+			expected: []string{"Bar", "Bar", "Bar"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			t.Parallel()
+			fileName := testCase.fileName
+			if fileName == "" {
+				fileName = "/file1.ts"
+			}
+			runRenameTest(t, testCase.input, fileName, testCase.newName, testCase.expected)
+		})
+	}
+}
+
+func runRenameTest(t *testing.T, input string, fileName string, newName string, expected []string) {
+	testData := fourslash.ParseTestData(t, input, fileName)
+	file := testData.Files[0].FileName()
+	ctx := projecttestutil.WithRequestID(t.Context())
+	service, done := createLanguageService(ctx, file, map[string]any{
+		file: testData.Files[0].Content,
+	})
+	defer done()
+
+	marker, ok := testData.MarkerPositions["1"]
+	if !ok {
+		t.Fatalf("No marker found for '1'")
+	}
+	assert.Equal(t, len(testData.Ranges), len(expected))
+
+	edit, err := service.ProvideRename(ctx, ls.FileNameToDocumentURI(file), marker.LSPosition, newName)
+	assert.NilError(t, err)
+
+	edits := (*edit.Changes)[ls.FileNameToDocumentURI(file)]
+	slices.SortFunc(edits, func(a, b *lsproto.TextEdit) int { return ls.CompareRanges(&a.Range, &b.Range) })
+	assert.Equal(t, len(edits), len(expected))
+	for i, rangeMarker := range testData.Ranges {
+		assert.DeepEqual(t, edits[i].Range, rangeMarker.LSRange)
+		assert.Equal(t, edits[i].NewText, expected[i])
+	}
+}
+
+func TestPrepareRename(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		title       string
+		input       string
+		placeholder string
+		err         string
+	}{
+		{
+			title:       "localVariable",
+			input:       `let [|/*1*/x|] = 1;`,
+			placeholder: "x",
+		},
+		{
+			title:       "stringLiteralPropertyName",
+			input:       `const o = { "[|/*1*/a b|]": 1 };`,
+			placeholder: `"a b"`,
+		},
+		{
+			title: "librarySymbol",
+			input: `[].[|/*1*/push|](1);`,
+			err:   "You cannot rename elements that are defined in the standard TypeScript library.",
+		},
+		{
+			title: "keyword",
+			input: `/*1*/return;`,
+			err:   "You cannot rename this element.",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			t.Parallel()
+			testData := fourslash.ParseTestData(t, testCase.input, "/file1.ts")
+			file := testData.Files[0].FileName()
+			ctx := projecttestutil.WithRequestID(t.Context())
+			service, done := createLanguageService(ctx, file, map[string]any{
+				file: testData.Files[0].Content,
+			})
+			defer done()
+
+			result, err := service.ProvidePrepareRename(ctx, ls.FileNameToDocumentURI(file), testData.MarkerPositions["1"].LSPosition)
+			if testCase.err != "" {
+				assert.ErrorContains(t, err, testCase.err)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, result.PrepareRenamePlaceholder.Range, testData.Ranges[0].LSRange)
+			assert.Equal(t, result.PrepareRenamePlaceholder.Placeholder, testCase.placeholder)
+		})
+	}
+}
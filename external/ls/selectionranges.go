@@ -0,0 +1,419 @@
+package ls
+
+import (
+	"context"
+
+	"github.com/pagpeter/typescript-go/external/ast"
+	"github.com/pagpeter/typescript-go/external/astnav"
+	"github.com/pagpeter/typescript-go/external/core"
+	"github.com/pagpeter/typescript-go/external/lsp/lsproto"
+	"github.com/pagpeter/typescript-go/external/scanner"
+)
+
+func (l *LanguageService) ProvideSelectionRanges(ctx context.Context, documentURI lsproto.DocumentUri, positions []lsproto.Position) ([]*lsproto.SelectionRange, error) {
+	_, file := l.getProgramAndFile(documentURI)
+	selectionRanges := make([]*lsproto.SelectionRange, len(positions))
+	for i, position := range positions {
+		pos := int(l.converters.LineAndCharacterToPosition(file, position))
+		var selectionRange *lsproto.SelectionRange
+		for _, textRange := range getSmartSelectionRanges(file, pos) {
+			selectionRange = &lsproto.SelectionRange{
+				Range:  l.converters.ToLSPRange(file, textRange),
+				Parent: selectionRange,
+			}
+		}
+		selectionRanges[i] = selectionRange
+	}
+	return selectionRanges, nil
+}
+
+// selectionNode is a node, a token or a list of nodes and tokens, like the children tsserver
+// computes for a node. Tokens and lists are synthesized from the scanner and the node lists of
+// the AST.
+type selectionNode struct {
+	kind     ast.Kind
+	node     *ast.Node // nil for synthesized tokens and lists
+	pos      int
+	end      int
+	children []*selectionNode // children of lists
+}
+
+func newSelectionNode(node *ast.Node) *selectionNode {
+	return &selectionNode{kind: node.Kind, node: node, pos: node.Pos(), end: node.End()}
+}
+
+func newSelectionList(children []*selectionNode) *selectionNode {
+	return &selectionNode{kind: ast.KindSyntaxList, pos: children[0].pos, end: children[len(children)-1].end, children: children}
+}
+
+func (n *selectionNode) getStart(file *ast.SourceFile, includeJSDoc bool) int {
+	switch {
+	case n.node != nil:
+		return scanner.GetTokenPosOfNode(n.node, file, includeJSDoc)
+	case n.kind == ast.KindSyntaxList:
+		if len(n.children) == 0 {
+			return n.pos
+		}
+		return n.children[0].getStart(file, includeJSDoc)
+	}
+	return scanner.SkipTrivia(file.Text(), n.pos)
+}
+
+func (n *selectionNode) hasJSDoc(file *ast.SourceFile) bool {
+	return n.node != nil && len(n.node.JSDoc(file)) != 0
+}
+
+// getSmartSelectionRanges returns the ranges that expanding the selection at pos goes through, from
+// the whole file down to the innermost range.
+func getSmartSelectionRanges(file *ast.SourceFile, pos int) []core.TextRange {
+	text := file.Text()
+	ranges := []core.TextRange{core.NewTextRange(0, file.End())}
+	pushSelectionRange := func(start, end int) {
+		// Skip empty ranges, ranges that are identical to the parent and ranges that don't contain
+		// the original position.
+		textRange := core.NewTextRange(start, end)
+		if start != end && textRange != ranges[len(ranges)-1] && start <= pos && pos <= end {
+			ranges = append(ranges, textRange)
+		}
+	}
+	pushSelectionCommentRange := func(start, end int) {
+		pushSelectionRange(start, end)
+		for start < end && text[start] == '/' {
+			start++
+		}
+		pushSelectionRange(start, end)
+	}
+
+	parent := newSelectionNode(file.AsNode())
+outer:
+	for {
+		children := getSelectionChildren(file, parent)
+		for i, node := range children {
+			if node.getStart(file, true /*includeJSDoc*/) > pos {
+				break outer
+			}
+			var prev, next *selectionNode
+			if i > 0 {
+				prev = children[i-1]
+			}
+			if i < len(children)-1 {
+				next = children[i+1]
+			}
+
+			if comment, ok := getSingleTrailingComment(text, node.end); ok && comment.Kind == ast.KindSingleLineCommentTrivia {
+				pushSelectionCommentRange(comment.Pos(), comment.End())
+			}
+
+			if positionShouldSnapToNode(file, pos, node) {
+				if node.node != nil && ast.IsFunctionBlock(node.node) && parent.node != nil && ast.IsFunctionLikeDeclaration(parent.node) &&
+					!positionsAreOnSameLine(file, node.getStart(file, false /*includeJSDoc*/), node.end) {
+					pushSelectionRange(node.getStart(file, false /*includeJSDoc*/), node.end)
+				}
+
+				// Blocks are redundant with their statement lists, template spans are an unintuitive
+				// grouping, and variable statements and lists of a single declaration are redundant with
+				// their declarations; dive in without pushing a selection range.
+				if node.kind == ast.KindBlock || node.kind == ast.KindTemplateSpan || node.kind == ast.KindTemplateHead || node.kind == ast.KindTemplateTail ||
+					prev != nil && prev.kind == ast.KindTemplateHead ||
+					node.kind == ast.KindVariableDeclarationList && parent.kind == ast.KindVariableStatement ||
+					node.kind == ast.KindSyntaxList && parent.kind == ast.KindVariableDeclarationList ||
+					node.kind == ast.KindVariableDeclaration && parent.kind == ast.KindSyntaxList && len(children) == 1 ||
+					node.kind == ast.KindJSDocTypeExpression || node.kind == ast.KindJSDocSignature || node.kind == ast.KindJSDocTypeLiteral {
+					parent = node
+					continue outer
+				}
+
+				// Synthesize a stop for `${ ... }`, since `${` and `}` belong to the neighboring literals.
+				if parent.kind == ast.KindTemplateSpan && next != nil && (next.kind == ast.KindTemplateMiddle || next.kind == ast.KindTemplateTail) {
+					pushSelectionRange(node.pos-len("${"), next.getStart(file, false /*includeJSDoc*/)+len("}"))
+				}
+
+				// Lists between braces, brackets, parentheses or JSX tags on separate lines are selected
+				// from the opening to the closing token, including whitespace but not the tokens themselves.
+				start := node.getStart(file, false /*includeJSDoc*/)
+				end := getSelectionEndPos(file, node)
+				if node.kind == ast.KindSyntaxList && prev != nil && isListOpener(prev.kind) && next != nil && isListCloser(next.kind) &&
+					!positionsAreOnSameLine(file, prev.getStart(file, false /*includeJSDoc*/), next.getStart(file, false /*includeJSDoc*/)) {
+					start = prev.end
+					end = next.getStart(file, false /*includeJSDoc*/)
+				}
+
+				if node.hasJSDoc(file) {
+					pushSelectionRange(node.getStart(file, true /*includeJSDoc*/), end)
+				}
+				// The start of a list skips the JSDoc of its first child, which needs a range of its own.
+				if node.kind == ast.KindSyntaxList && len(node.children) != 0 && node.children[0].hasJSDoc(file) {
+					start = min(start, node.children[0].getStart(file, true /*includeJSDoc*/))
+				}
+
+				pushSelectionRange(start, end)
+
+				// String literals have a stop both inside and outside their quotes.
+				if node.kind == ast.KindStringLiteral || node.kind == ast.KindNoSubstitutionTemplateLiteral || node.kind == ast.KindTemplateExpression {
+					pushSelectionRange(start+1, end-1)
+				}
+
+				parent = node
+				continue outer
+			}
+		}
+		break
+	}
+	return ranges
+}
+
+func getSingleTrailingComment(text string, pos int) (ast.CommentRange, bool) {
+	var result ast.CommentRange
+	count := 0
+	for comment := range scanner.GetTrailingCommentRanges(&ast.NodeFactory{}, text, pos) {
+		result = comment
+		count++
+	}
+	return result, count == 1
+}
+
+// positionShouldSnapToNode reports whether pos is within the node, or right after it at the end
+// of an identifier or keyword.
+func positionShouldSnapToNode(file *ast.SourceFile, pos int, node *selectionNode) bool {
+	if pos < node.end {
+		return true
+	}
+	if node.end == pos {
+		return astnav.GetTouchingPropertyName(file, pos).Pos() < node.end
+	}
+	return false
+}
+
+// getSelectionEndPos returns the end of a node, or of the line it starts on for JSDoc tags, which
+// extend to the next tag.
+func getSelectionEndPos(file *ast.SourceFile, node *selectionNode) int {
+	switch node.kind {
+	case ast.KindJSDocParameterTag, ast.KindJSDocCallbackTag, ast.KindJSDocPropertyTag, ast.KindJSDocTypedefTag, ast.KindJSDocThisTag:
+		line := scanner.ComputeLineOfPosition(file.LineMap(), node.getStart(file, false /*includeJSDoc*/))
+		return scanner.GetEndLinePosition(file, line)
+	}
+	return node.end
+}
+
+func isListOpener(kind ast.Kind) bool {
+	return kind == ast.KindOpenBraceToken || kind == ast.KindOpenBracketToken || kind == ast.KindOpenParenToken || kind == ast.KindJsxOpeningElement
+}
+
+func isListCloser(kind ast.Kind) bool {
+	return kind == ast.KindCloseBraceToken || kind == ast.KindCloseBracketToken || kind == ast.KindCloseParenToken || kind == ast.KindJsxClosingElement
+}
+
+func getSelectionChildren(file *ast.SourceFile, parent *selectionNode) []*selectionNode {
+	if parent.kind == ast.KindSyntaxList {
+		return parent.children
+	}
+	node := parent.node
+	if node == nil || ast.IsTokenKind(node.Kind) {
+		return nil
+	}
+	switch node.Kind {
+	case ast.KindSourceFile:
+		// Group top-level imports.
+		statements := createSelectionList(file, file.Statements)
+		if statements == nil {
+			return nil
+		}
+		return groupSelectionChildren(statements.children, func(child *selectionNode) bool {
+			return child.kind == ast.KindImportDeclaration || child.kind == ast.KindImportEqualsDeclaration
+		})
+	case ast.KindMappedType:
+		// Mapped types look like object types with a single member, so group their parts like one:
+		// `readonly` and `?` with the `[K in T]` they modify, and the whole around the `:`.
+		mappedType := node.AsMappedTypeNode()
+		children := createSelectionChildren(file, node)
+		if len(children) < 2 || children[0].kind != ast.KindOpenBraceToken || children[len(children)-1].kind != ast.KindCloseBraceToken {
+			return children
+		}
+		openBrace, closeBrace := children[0], children[len(children)-1]
+		grouped := groupSelectionChildren(children[1:len(children)-1], func(child *selectionNode) bool {
+			return child.node != nil && (child.node == mappedType.ReadonlyToken || child.node == mappedType.QuestionToken) ||
+				child.kind == ast.KindReadonlyKeyword || child.kind == ast.KindQuestionToken
+		})
+		grouped = groupSelectionChildren(grouped, func(child *selectionNode) bool {
+			return child.kind == ast.KindOpenBracketToken || child.kind == ast.KindTypeParameter || child.kind == ast.KindCloseBracketToken
+		})
+		return []*selectionNode{openBrace, newSelectionList(splitSelectionChildren(grouped, ast.KindColonToken)), closeBrace}
+	case ast.KindPropertySignature:
+		// Group the name, then pivot on `:`.
+		children := groupSelectionChildren(createSelectionChildren(file, node), func(child *selectionNode) bool {
+			return child.node != nil && child.node == node.Name()
+		})
+		if len(children) != 0 && children[0].kind == ast.KindJSDoc {
+			return []*selectionNode{children[0], newSelectionList(splitSelectionChildren(children[1:], ast.KindColonToken))}
+		}
+		return splitSelectionChildren(children, ast.KindColonToken)
+	case ast.KindParameter:
+		// Group the name with its `...`, then with its `?`, then pivot on `=`.
+		parameter := node.AsParameterDeclaration()
+		children := groupSelectionChildren(createSelectionChildren(file, node), func(child *selectionNode) bool {
+			return child.node != nil && (child.node == parameter.DotDotDotToken || child.node == parameter.Name())
+		})
+		var first *selectionNode
+		if len(children) != 0 {
+			first = children[0]
+		}
+		children = groupSelectionChildren(children, func(child *selectionNode) bool {
+			return child == first || child.node != nil && child.node == parameter.QuestionToken
+		})
+		return splitSelectionChildren(children, ast.KindEqualsToken)
+	case ast.KindBindingElement:
+		return splitSelectionChildren(createSelectionChildren(file, node), ast.KindEqualsToken)
+	}
+	return createSelectionChildren(file, node)
+}
+
+// createSelectionChildren returns the JSDoc comments, child nodes, node lists and tokens of a node, in order.
+func createSelectionChildren(file *ast.SourceFile, node *ast.Node) []*selectionNode {
+	var children []*selectionNode
+	if ast.IsJSDocCommentContainingNode(node) {
+		// Plain comment text is not selected on its own.
+		node.ForEachChild(func(child *ast.Node) bool {
+			if child.Kind != ast.KindJSDocText {
+				children = append(children, newSelectionNode(child))
+			}
+			return false
+		})
+		return children
+	}
+	for _, jsdoc := range node.JSDoc(file) {
+		children = append(children, newSelectionNode(jsdoc))
+	}
+	pos := node.Pos()
+	visitNode := func(child *ast.Node, _ *ast.NodeVisitor) *ast.Node {
+		if child != nil && child.Flags&ast.NodeFlagsReparsed == 0 {
+			children = appendSelectionTokens(file, children, pos, child.Pos())
+			children = append(children, newSelectionNode(child))
+			pos = child.End()
+		}
+		return child
+	}
+	visitNodes := func(nodes *ast.NodeList, _ *ast.NodeVisitor) *ast.NodeList {
+		if list := createSelectionList(file, nodes); list != nil {
+			children = appendSelectionTokens(file, children, pos, list.pos)
+			children = append(children, list)
+			pos = list.end
+		}
+		return nodes
+	}
+	visitor := ast.NewNodeVisitor(core.Identity, nil, ast.NodeVisitorHooks{
+		VisitNode:              visitNode,
+		VisitToken:             visitNode,
+		VisitEmbeddedStatement: visitNode,
+		VisitNodes:             visitNodes,
+		VisitModifiers: func(modifiers *ast.ModifierList, visitor *ast.NodeVisitor) *ast.ModifierList {
+			if modifiers != nil {
+				visitNodes(&modifiers.NodeList, visitor)
+			}
+			return modifiers
+		},
+	})
+	node.VisitEachChild(visitor)
+	return appendSelectionTokens(file, children, pos, node.End())
+}
+
+// createSelectionList returns a list of the nodes and the tokens between them, or nil for an
+// empty list.
+func createSelectionList(file *ast.SourceFile, nodes *ast.NodeList) *selectionNode {
+	if nodes == nil || nodes.Pos() == nodes.End() {
+		return nil
+	}
+	var children []*selectionNode
+	pos := nodes.Pos()
+	for _, node := range nodes.Nodes {
+		if node.Flags&ast.NodeFlagsReparsed != 0 {
+			continue
+		}
+		children = appendSelectionTokens(file, children, pos, node.Pos())
+		children = append(children, newSelectionNode(node))
+		pos = node.End()
+	}
+	children = appendSelectionTokens(file, children, pos, nodes.End())
+	if len(children) == 0 {
+		return nil
+	}
+	return &selectionNode{kind: ast.KindSyntaxList, pos: nodes.Pos(), end: nodes.End(), children: children}
+}
+
+// appendSelectionTokens appends the tokens between pos and end.
+func appendSelectionTokens(file *ast.SourceFile, children []*selectionNode, pos int, end int) []*selectionNode {
+	if pos >= end {
+		return children
+	}
+	s := scanner.GetScannerForSourceFile(file, pos)
+	for pos < end {
+		token := s.Token()
+		tokenEnd := s.TokenEnd()
+		if tokenEnd <= end {
+			children = append(children, &selectionNode{kind: token, pos: pos, end: tokenEnd})
+		}
+		pos = tokenEnd
+		if token == ast.KindEndOfFile {
+			break
+		}
+		s.Scan()
+	}
+	return children
+}
+
+// groupSelectionChildren replaces each run of children matching groupOn with a list.
+func groupSelectionChildren(children []*selectionNode, groupOn func(*selectionNode) bool) []*selectionNode {
+	var result []*selectionNode
+	var group []*selectionNode
+	for _, child := range children {
+		if groupOn(child) {
+			group = append(group, child)
+			continue
+		}
+		if group != nil {
+			result = append(result, newSelectionList(group))
+			group = nil
+		}
+		result = append(result, child)
+	}
+	if group != nil {
+		result = append(result, newSelectionList(group))
+	}
+	return result
+}
+
+// splitSelectionChildren groups the children on each side of the first pivot token into a list,
+// keeping a trailing semicolon separate.
+func splitSelectionChildren(children []*selectionNode, pivot ast.Kind) []*selectionNode {
+	if len(children) < 2 {
+		return children
+	}
+	splitIndex := -1
+	for i, child := range children {
+		if child.kind == pivot {
+			splitIndex = i
+			break
+		}
+	}
+	if splitIndex == -1 {
+		return children
+	}
+	leftChildren := children[:splitIndex]
+	rightChildren := children[splitIndex+1:]
+	lastToken := children[len(children)-1]
+	separateLastToken := lastToken.kind == ast.KindSemicolonToken
+	if separateLastToken && splitIndex != len(children)-1 {
+		rightChildren = rightChildren[:len(rightChildren)-1]
+	}
+	var result []*selectionNode
+	if len(leftChildren) != 0 {
+		result = append(result, newSelectionList(leftChildren))
+	}
+	result = append(result, children[splitIndex])
+	if len(rightChildren) != 0 {
+		result = append(result, newSelectionList(rightChildren))
+	}
+	if separateLastToken {
+		result = append(result, lastToken)
+	}
+	return result
+}
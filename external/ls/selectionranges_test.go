@@ -0,0 +1,210 @@
+package ls_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pagpeter/typescript-go/external/bundled"
+	"github.com/pagpeter/typescript-go/external/fourslash"
+	"github.com/pagpeter/typescript-go/external/ls"
+	"github.com/pagpeter/typescript-go/external/lsp/lsproto"
+	"github.com/pagpeter/typescript-go/external/testutil/projecttestutil"
+	"gotest.tools/v3/assert"
+)
+
+func TestSelectionRanges(t *testing.T) {
+	t.Parallel()
+	if !bundled.Embedded {
+		t.Skip("bundled files are not embedded")
+	}
+
+	testCases := []struct {
+		title    string
+		fileName string
+		input    string
+		// text of each selection range from the innermost outwards, excluding the whole file
+		expected []string
+	}{
+		{
+			title:    "statements",
+			fileName: "/file1.ts",
+			input: `class C {
+    m(a: number, b = 1) {
+        return a /*1*/+ b;
+    }
+}`,
+			expected: []string{
+				"+",
+				"a + b",
+				"return a + b;",
+				"\n        return a + b;\n    ",
+				"{\n        return a + b;\n    }",
+				"m(a: number, b = 1) {\n        return a + b;\n    }",
+				"\n    m(a: number, b = 1) {\n        return a + b;\n    }\n",
+			},
+		},
+		{
+			title:    "stringLiteral",
+			fileName: "/file1.ts",
+			input:    `let s = "hel/*1*/lo", t = 1;`,
+			expected: []string{
+				"hello",
+				`"hello"`,
+				`s = "hello"`,
+			},
+		},
+		{
+			title:    "templateSpan",
+			fileName: "/file1.ts",
+			input:    "const t = `a ${x/*1*/ + 1} b`;",
+			expected: []string{
+				"x",
+				"x + 1",
+				"${x + 1}",
+				"a ${x + 1} b",
+				"`a ${x + 1} b`",
+			},
+		},
+		{
+			title:    "jsDoc",
+			fileName: "/file1.ts",
+			input: `/** doc */
+function f() {
+    return /*1*/1;
+}`,
+			expected: []string{
+				"1",
+				"return 1;",
+				"\n    return 1;\n",
+				"{\n    return 1;\n}",
+				"function f() {\n    return 1;\n}",
+			},
+		},
+		{
+			title:    "jsDocTag",
+			fileName: "/file1.js",
+			input: `/**
+ * @param {str/*1*/ing} x the x
+ */
+function f(x) {}`,
+			expected: []string{
+				"string",
+				"@param {string} x the x",
+				"/**\n * @param {string} x the x\n */",
+			},
+		},
+		{
+			title:    "propertySignature",
+			fileName: "/file1.ts",
+			input: `interface I {
+    readonly a/*1*/: string;
+}`,
+			expected: []string{
+				"a",
+				"readonly a",
+				"readonly a: string;",
+				"\n    readonly a: string;\n",
+			},
+		},
+		{
+			title:    "parameter",
+			fileName: "/file1.ts",
+			input:    `function f(...a/*1*/rgs?: number[]) {}`,
+			expected: []string{
+				"args",
+				"...args",
+				"...args?",
+				"...args?: number[]",
+			},
+		},
+		{
+			title:    "mappedType",
+			fileName: "/file1.ts",
+			input:    `type M = { readonly [K in keyof T]?: T[K/*1*/] };`,
+			expected: []string{
+				"K",
+				"T[K]",
+				"readonly [K in keyof T]?: T[K]",
+				"{ readonly [K in keyof T]?: T[K] }",
+			},
+		},
+		{
+			title:    "imports",
+			fileName: "/file1.ts",
+			input: `import a from "a";
+import b/*1*/ from "b";
+let x = 1;`,
+			expected: []string{
+				"b",
+				`import b from "b";`,
+				"import a from \"a\";\nimport b from \"b\";",
+			},
+		},
+		{
+			title:    "comment",
+			fileName: "/file1.ts",
+			input: `f(a, // comm/*1*/ent
+  b);`,
+			expected: []string{
+				" comment",
+				"// comment",
+				"a, // comment\n  b",
+				"f(a, // comment\n  b)",
+			},
+		},
+		{
+			title:    "jsx",
+			fileName: "/file1.tsx",
+			input: `const x = <div>
+  <span a="1/*1*/" />
+</div>;`,
+			expected: []string{
+				"1",
+				`"1"`,
+				`a="1"`,
+				`<span a="1" />`,
+				"\n  <span a=\"1\" />\n",
+				"<div>\n  <span a=\"1\" />\n</div>",
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			t.Parallel()
+			testData := fourslash.ParseTestData(t, testCase.input, testCase.fileName)
+			file := testData.Files[0].FileName()
+			content := testData.Files[0].Content
+			ctx := projecttestutil.WithRequestID(t.Context())
+			service, done := createLanguageService(ctx, file, map[string]any{
+				file: content,
+			})
+			defer done()
+
+			selectionRanges, err := service.ProvideSelectionRanges(ctx, ls.FileNameToDocumentURI(file), []lsproto.Position{testData.MarkerPositions["1"].LSPosition})
+			assert.NilError(t, err)
+			assert.Equal(t, len(selectionRanges), 1)
+			var actual []string
+			for selectionRange := selectionRanges[0]; selectionRange.Parent != nil; selectionRange = selectionRange.Parent {
+				actual = append(actual, getRangeText(content, selectionRange.Range))
+			}
+			// Leave out a statement that spans the whole file, as it repeats the file.
+			if len(actual) != 0 && actual[len(actual)-1] == content {
+				actual = actual[:len(actual)-1]
+			}
+			assert.DeepEqual(t, actual, testCase.expected)
+		})
+	}
+}
+
+func getRangeText(text string, lspRange lsproto.Range) string {
+	lines := strings.SplitAfter(text, "\n")
+	offset := func(position lsproto.Position) int {
+		result := int(position.Character)
+		for _, line := range lines[:position.Line] {
+			result += len(line)
+		}
+		return result
+	}
+	return text[offset(lspRange.Start):offset(lspRange.End)]
+}
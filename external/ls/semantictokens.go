@@ -0,0 +1,324 @@
+package ls
+
+import (
+	"context"
+
+	"github.com/pagpeter/typescript-go/external/ast"
+	"github.com/pagpeter/typescript-go/external/checker"
+	"github.com/pagpeter/typescript-go/external/compiler"
+	"github.com/pagpeter/typescript-go/external/core"
+	"github.com/pagpeter/typescript-go/external/lsp/lsproto"
+	"github.com/pagpeter/typescript-go/external/scanner"
+)
+
+type tokenType uint32
+
+// The order of token types must match SemanticTokensLegend.
+const (
+	tokenTypeClass tokenType = iota
+	tokenTypeEnum
+	tokenTypeInterface
+	tokenTypeNamespace
+	tokenTypeTypeParameter
+	tokenTypeType
+	tokenTypeParameter
+	tokenTypeVariable
+	tokenTypeEnumMember
+	tokenTypeProperty
+	tokenTypeFunction
+	tokenTypeMethod
+)
+
+type tokenModifier uint32
+
+// The order of token modifiers must match SemanticTokensLegend.
+const (
+	tokenModifierDeclaration tokenModifier = 1 << iota
+	tokenModifierStatic
+	tokenModifierAsync
+	tokenModifierReadonly
+	tokenModifierDefaultLibrary
+)
+
+var SemanticTokensLegend = lsproto.SemanticTokensLegend{
+	TokenTypes: []string{
+		string(lsproto.SemanticTokenTypesclass),
+		string(lsproto.SemanticTokenTypesenum),
+		string(lsproto.SemanticTokenTypesinterface),
+		string(lsproto.SemanticTokenTypesnamespace),
+		string(lsproto.SemanticTokenTypestypeParameter),
+		string(lsproto.SemanticTokenTypestype),
+		string(lsproto.SemanticTokenTypesparameter),
+		string(lsproto.SemanticTokenTypesvariable),
+		string(lsproto.SemanticTokenTypesenumMember),
+		string(lsproto.SemanticTokenTypesproperty),
+		string(lsproto.SemanticTokenTypesfunction),
+		string(lsproto.SemanticTokenTypesmethod),
+	},
+	TokenModifiers: []string{
+		string(lsproto.SemanticTokenModifiersdeclaration),
+		string(lsproto.SemanticTokenModifiersstatic),
+		string(lsproto.SemanticTokenModifiersasync),
+		string(lsproto.SemanticTokenModifiersreadonly),
+		string(lsproto.SemanticTokenModifiersdefaultLibrary),
+	},
+}
+
+var tokenTypeFromDeclarationKind = map[ast.Kind]tokenType{
+	ast.KindVariableDeclaration:         tokenTypeVariable,
+	ast.KindParameter:                   tokenTypeParameter,
+	ast.KindPropertyDeclaration:         tokenTypeProperty,
+	ast.KindModuleDeclaration:           tokenTypeNamespace,
+	ast.KindEnumDeclaration:             tokenTypeEnum,
+	ast.KindEnumMember:                  tokenTypeEnumMember,
+	ast.KindClassDeclaration:            tokenTypeClass,
+	ast.KindMethodDeclaration:           tokenTypeMethod,
+	ast.KindFunctionDeclaration:         tokenTypeFunction,
+	ast.KindFunctionExpression:          tokenTypeFunction,
+	ast.KindMethodSignature:             tokenTypeMethod,
+	ast.KindGetAccessor:                 tokenTypeProperty,
+	ast.KindSetAccessor:                 tokenTypeProperty,
+	ast.KindPropertySignature:           tokenTypeProperty,
+	ast.KindInterfaceDeclaration:        tokenTypeInterface,
+	ast.KindTypeAliasDeclaration:        tokenTypeType,
+	ast.KindTypeParameter:               tokenTypeTypeParameter,
+	ast.KindPropertyAssignment:          tokenTypeProperty,
+	ast.KindShorthandPropertyAssignment: tokenTypeProperty,
+}
+
+type semanticToken struct {
+	node      *ast.Node
+	tokenType tokenType
+	modifiers tokenModifier
+}
+
+func (l *LanguageService) ProvideSemanticTokens(ctx context.Context, documentURI lsproto.DocumentUri) (*lsproto.SemanticTokens, error) {
+	program, file := l.getProgramAndFile(documentURI)
+	return &lsproto.SemanticTokens{
+		Data: l.encodeSemanticTokens(file, collectSemanticTokens(ctx, program, file, file.AsNode().Loc)),
+	}, nil
+}
+
+func (l *LanguageService) ProvideSemanticTokensRange(ctx context.Context, documentURI lsproto.DocumentUri, lspRange lsproto.Range) (*lsproto.SemanticTokens, error) {
+	program, file := l.getProgramAndFile(documentURI)
+	return &lsproto.SemanticTokens{
+		Data: l.encodeSemanticTokens(file, collectSemanticTokens(ctx, program, file, l.converters.FromLSPRange(file, lspRange))),
+	}, nil
+}
+
+func collectSemanticTokens(ctx context.Context, program *compiler.Program, file *ast.SourceFile, span core.TextRange) []semanticToken {
+	typeChecker, done := program.GetTypeCheckerForFile(ctx, file)
+	defer done()
+
+	var tokens []semanticToken
+	inJsxElement := false
+	var visit func(node *ast.Node) bool
+	visit = func(node *ast.Node) bool {
+		if node.End() <= node.Pos() || node.End() < span.Pos() || node.Pos() > span.End() {
+			return false
+		}
+		if ctx.Err() != nil {
+			return true
+		}
+		prevInJsxElement := inJsxElement
+		if ast.IsJsxElement(node) || ast.IsJsxSelfClosingElement(node) {
+			inJsxElement = true
+		}
+		if ast.IsJsxExpression(node) {
+			inJsxElement = false
+		}
+		if ast.IsIdentifier(node) && !inJsxElement && !isInImportClause(node) && !isInfinityOrNaNString(node.Text()) {
+			if token, ok := classifyIdentifier(typeChecker, program, node); ok {
+				tokens = append(tokens, token)
+			}
+		}
+		node.ForEachChild(visit)
+		inJsxElement = prevInJsxElement
+		return false
+	}
+	file.AsNode().ForEachChild(visit)
+	return tokens
+}
+
+func classifyIdentifier(typeChecker *checker.Checker, program *compiler.Program, node *ast.Node) (semanticToken, bool) {
+	symbol := typeChecker.GetSymbolAtLocation(node)
+	if symbol == nil {
+		return semanticToken{}, false
+	}
+	if symbol.Flags&ast.SymbolFlagsAlias != 0 {
+		symbol = typeChecker.GetAliasedSymbol(symbol)
+	}
+	typeIndex, ok := classifySymbol(symbol, getMeaningFromLocation(node))
+	if !ok {
+		return semanticToken{}, false
+	}
+
+	var modifiers tokenModifier
+	if parent := node.Parent; parent != nil {
+		if declarationType, ok := tokenTypeFromDeclarationKind[parent.Kind]; (ast.IsBindingElement(parent) || ok && declarationType == typeIndex) && parent.Name() == node {
+			modifiers = tokenModifierDeclaration
+		}
+	}
+	// property declaration in constructor
+	if typeIndex == tokenTypeParameter && isRightSideOfQualifiedNameOrPropertyAccess(node) {
+		typeIndex = tokenTypeProperty
+	}
+	typeIndex = reclassifyByType(typeChecker, node, typeIndex)
+
+	if declaration := symbol.ValueDeclaration; declaration != nil {
+		modifierFlags := ast.GetCombinedModifierFlags(declaration)
+		nodeFlags := ast.GetCombinedNodeFlags(declaration)
+		if modifierFlags&ast.ModifierFlagsStatic != 0 {
+			modifiers |= tokenModifierStatic
+		}
+		if modifierFlags&ast.ModifierFlagsAsync != 0 {
+			modifiers |= tokenModifierAsync
+		}
+		if typeIndex != tokenTypeClass && typeIndex != tokenTypeInterface {
+			if modifierFlags&ast.ModifierFlagsReadonly != 0 || nodeFlags&ast.NodeFlagsConst != 0 || symbol.Flags&ast.SymbolFlagsEnumMember != 0 {
+				modifiers |= tokenModifierReadonly
+			}
+		}
+		if program.IsSourceFileDefaultLibrary(ast.GetSourceFileOfNode(declaration).Path()) {
+			modifiers |= tokenModifierDefaultLibrary
+		}
+	} else if core.Some(symbol.Declarations, func(declaration *ast.Node) bool {
+		return program.IsSourceFileDefaultLibrary(ast.GetSourceFileOfNode(declaration).Path())
+	}) {
+		modifiers |= tokenModifierDefaultLibrary
+	}
+	return semanticToken{node: node, tokenType: typeIndex, modifiers: modifiers}, true
+}
+
+func classifySymbol(symbol *ast.Symbol, meaning ast.SemanticMeaning) (tokenType, bool) {
+	flags := symbol.Flags
+	switch {
+	case flags&ast.SymbolFlagsClass != 0:
+		return tokenTypeClass, true
+	case flags&ast.SymbolFlagsEnum != 0:
+		return tokenTypeEnum, true
+	case flags&ast.SymbolFlagsTypeAlias != 0:
+		return tokenTypeType, true
+	case flags&ast.SymbolFlagsInterface != 0:
+		if meaning&ast.SemanticMeaningType != 0 {
+			return tokenTypeInterface, true
+		}
+	case flags&ast.SymbolFlagsTypeParameter != 0:
+		return tokenTypeTypeParameter, true
+	}
+	declaration := symbol.ValueDeclaration
+	if declaration == nil && len(symbol.Declarations) != 0 {
+		declaration = symbol.Declarations[0]
+	}
+	if declaration == nil {
+		return 0, false
+	}
+	if ast.IsBindingElement(declaration) {
+		declaration = getDeclarationForBindingElement(declaration)
+	}
+	typeIndex, ok := tokenTypeFromDeclarationKind[declaration.Kind]
+	return typeIndex, ok
+}
+
+// reclassifyByType classifies variables, properties and parameters of class types as classes and those of
+// function types as functions or methods.
+func reclassifyByType(typeChecker *checker.Checker, node *ast.Node, typeIndex tokenType) tokenType {
+	if typeIndex != tokenTypeVariable && typeIndex != tokenTypeProperty && typeIndex != tokenTypeParameter {
+		return typeIndex
+	}
+	t := typeChecker.GetTypeAtLocation(node)
+	if t == nil {
+		return typeIndex
+	}
+	test := func(condition func(t *checker.Type) bool) bool {
+		return condition(t) || t.IsUnion() && core.Some(t.Types(), condition)
+	}
+	if typeIndex != tokenTypeParameter && test(func(t *checker.Type) bool {
+		return len(typeChecker.GetSignaturesOfType(t, checker.SignatureKindConstruct)) != 0
+	}) {
+		return tokenTypeClass
+	}
+	if test(func(t *checker.Type) bool {
+		return len(typeChecker.GetSignaturesOfType(t, checker.SignatureKindCall)) != 0
+	}) && !test(func(t *checker.Type) bool {
+		return len(typeChecker.GetPropertiesOfType(t)) != 0
+	}) || isExpressionInCallExpression(node) {
+		if typeIndex == tokenTypeProperty {
+			return tokenTypeMethod
+		}
+		return tokenTypeFunction
+	}
+	return typeIndex
+}
+
+func getDeclarationForBindingElement(element *ast.Node) *ast.Node {
+	for ast.IsBindingElement(element.Parent.Parent) {
+		element = element.Parent.Parent
+	}
+	return element.Parent.Parent
+}
+
+func isInImportClause(node *ast.Node) bool {
+	parent := node.Parent
+	return parent != nil && (ast.IsImportClause(parent) || ast.IsImportSpecifier(parent) || ast.IsNamespaceImport(parent))
+}
+
+func isInfinityOrNaNString(name string) bool {
+	return name == "Infinity" || name == "NaN"
+}
+
+func isExpressionInCallExpression(node *ast.Node) bool {
+	for isRightSideOfQualifiedNameOrPropertyAccess(node) {
+		node = node.Parent
+	}
+	return ast.IsCallExpression(node.Parent) && node.Parent.Expression() == node
+}
+
+func isRightSideOfQualifiedNameOrPropertyAccess(node *ast.Node) bool {
+	parent := node.Parent
+	return ast.IsQualifiedName(parent) && parent.AsQualifiedName().Right == node ||
+		ast.IsPropertyAccessExpression(parent) && parent.Name() == node
+}
+
+// encodeSemanticTokens encodes tokens in the relative format of the LSP: each token is five integers holding
+// the line relative to the previous token, the start character relative to the previous token when on the
+// same line, the length, the token type and the token modifiers.
+func (l *LanguageService) encodeSemanticTokens(file *ast.SourceFile, tokens []semanticToken) []uint32 {
+	data := make([]uint32, 0, len(tokens)*5)
+	var prevLine, prevCharacter uint32
+	for _, token := range tokens {
+		start := l.converters.PositionToLineAndCharacter(file, core.TextPos(scanner.GetTokenPosOfNode(token.node, file, false /*includeJSDoc*/)))
+		end := l.converters.PositionToLineAndCharacter(file, core.TextPos(token.node.End()))
+		deltaCharacter := start.Character
+		if start.Line == prevLine {
+			deltaCharacter -= prevCharacter
+		}
+		data = append(data, start.Line-prevLine, deltaCharacter, end.Character-start.Character, uint32(token.tokenType), uint32(token.modifiers))
+		prevLine, prevCharacter = start.Line, start.Character
+	}
+	return data
+}
+
+// GetSemanticTokensEdits returns the edits transforming the previous encoded tokens of a document into the
+// current ones, as a single edit replacing the whole tokens between their common prefix and suffix.
+func GetSemanticTokensEdits(previous []uint32, current []uint32) []*lsproto.SemanticTokensEdit {
+	prefix := 0
+	for prefix < len(previous) && prefix < len(current) && previous[prefix] == current[prefix] {
+		prefix++
+	}
+	if prefix == len(previous) && prefix == len(current) {
+		return []*lsproto.SemanticTokensEdit{}
+	}
+	prefix -= prefix % 5
+	suffix := 0
+	for suffix < len(previous)-prefix && suffix < len(current)-prefix && previous[len(previous)-1-suffix] == current[len(current)-1-suffix] {
+		suffix++
+	}
+	suffix -= suffix % 5
+	data := current[prefix : len(current)-suffix]
+	return []*lsproto.SemanticTokensEdit{{
+		Start:       uint32(prefix),
+		DeleteCount: uint32(len(previous) - suffix - prefix),
+		Data:        &data,
+	}}
+}
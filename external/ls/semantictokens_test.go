@@ -0,0 +1,187 @@
+package ls_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pagpeter/typescript-go/external/bundled"
+	"github.com/pagpeter/typescript-go/external/fourslash"
+	"github.com/pagpeter/typescript-go/external/ls"
+	"github.com/pagpeter/typescript-go/external/lsp/lsproto"
+	"github.com/pagpeter/typescript-go/external/testutil/projecttestutil"
+	"gotest.tools/v3/assert"
+)
+
+func TestSemanticTokens(t *testing.T) {
+	t.Parallel()
+	if !bundled.Embedded {
+		t.Skip("bundled files are not embedded")
+	}
+
+	testCases := []struct {
+		title string
+		input string
+		// expected tokens as "text:type.modifier..."
+		expected []string
+	}{
+		{
+			title: "class",
+			input: `class C { static readonly x = 1; m() {} }
+new C().m();`,
+			expected: []string{
+				"C:class.declaration",
+				"x:property.declaration.static.readonly",
+				"m:method.declaration",
+				"C:class",
+				"m:method",
+			},
+		},
+		{
+			title: "namespaceAndEnum",
+			input: `namespace N { export enum E { A } }
+N.E.A;`,
+			expected: []string{
+				"N:namespace.declaration",
+				"E:enum.declaration",
+				"A:enumMember.declaration.readonly",
+				"N:namespace",
+				"E:enum",
+				"A:enumMember.readonly",
+			},
+		},
+		{
+			title: "functionsAndParameters",
+			input: `function f(p: number) { const q = p; }
+async function g() {}
+const h = () => {};
+h();`,
+			expected: []string{
+				"f:function.declaration",
+				"p:parameter.declaration",
+				"q:variable.declaration.readonly",
+				"p:parameter",
+				"g:function.declaration.async",
+				"h:function.declaration.readonly",
+				"h:function.readonly",
+			},
+		},
+		{
+			title: "typesAndDefaultLibrary",
+			input: `interface I<T> { p: T }
+type A = I<string>;
+Math.max(1, 2);`,
+			expected: []string{
+				"I:interface.declaration",
+				"T:typeParameter.declaration",
+				"p:property.declaration",
+				"T:typeParameter",
+				"A:type.declaration",
+				"I:interface",
+				"Math:variable.defaultLibrary",
+				"max:method.defaultLibrary",
+			},
+		},
+		{
+			title: "range",
+			input: `const a = 1;
+[|const b = a;|]
+const c = b;`,
+			expected: []string{
+				"b:variable.declaration.readonly",
+				"a:variable.readonly",
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			t.Parallel()
+			testData := fourslash.ParseTestData(t, testCase.input, "/file1.ts")
+			file := testData.Files[0].FileName()
+			content := testData.Files[0].Content
+			ctx := projecttestutil.WithRequestID(t.Context())
+			service, done := createLanguageService(ctx, file, map[string]any{
+				file: content,
+			})
+			defer done()
+
+			var tokens *lsproto.SemanticTokens
+			var err error
+			if len(testData.Ranges) != 0 {
+				tokens, err = service.ProvideSemanticTokensRange(ctx, ls.FileNameToDocumentURI(file), testData.Ranges[0].LSRange)
+			} else {
+				tokens, err = service.ProvideSemanticTokens(ctx, ls.FileNameToDocumentURI(file))
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, decodeSemanticTokens(content, tokens.Data), testCase.expected)
+		})
+	}
+}
+
+func decodeSemanticTokens(text string, data []uint32) []string {
+	lines := strings.Split(text, "\n")
+	var result []string
+	var line, character uint32
+	for i := 0; i+4 < len(data); i += 5 {
+		if data[i] != 0 {
+			character = 0
+		}
+		line += data[i]
+		character += data[i+1]
+		var b strings.Builder
+		b.WriteString(lines[line][character : character+data[i+2]])
+		b.WriteString(":")
+		b.WriteString(ls.SemanticTokensLegend.TokenTypes[data[i+3]])
+		for bit, modifier := range ls.SemanticTokensLegend.TokenModifiers {
+			if data[i+4]&(1<<bit) != 0 {
+				b.WriteString(".")
+				b.WriteString(modifier)
+			}
+		}
+		result = append(result, b.String())
+	}
+	return result
+}
+
+func TestGetSemanticTokensEdits(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		title    string
+		previous []uint32
+		current  []uint32
+		expected []*lsproto.SemanticTokensEdit
+	}{
+		{
+			title:    "unchanged",
+			previous: []uint32{0, 0, 1, 7, 0},
+			current:  []uint32{0, 0, 1, 7, 0},
+			expected: []*lsproto.SemanticTokensEdit{},
+		},
+		{
+			title:    "insert",
+			previous: []uint32{0, 0, 1, 7, 0, 1, 0, 1, 7, 0},
+			current:  []uint32{0, 0, 1, 7, 0, 1, 0, 2, 10, 1, 1, 0, 1, 7, 0},
+			expected: []*lsproto.SemanticTokensEdit{{Start: 5, DeleteCount: 0, Data: &[]uint32{1, 0, 2, 10, 1}}},
+		},
+		{
+			title:    "replace",
+			previous: []uint32{0, 0, 1, 7, 0, 1, 0, 1, 7, 0},
+			current:  []uint32{0, 0, 1, 7, 0, 1, 0, 1, 10, 0},
+			expected: []*lsproto.SemanticTokensEdit{{Start: 5, DeleteCount: 5, Data: &[]uint32{1, 0, 1, 10, 0}}},
+		},
+		{
+			title:    "delete",
+			previous: []uint32{0, 0, 1, 7, 0, 1, 0, 1, 7, 0},
+			current:  []uint32{0, 0, 1, 7, 0},
+			expected: []*lsproto.SemanticTokensEdit{{Start: 5, DeleteCount: 5, Data: &[]uint32{}}},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.title, func(t *testing.T) {
+			t.Parallel()
+			assert.DeepEqual(t, ls.GetSemanticTokensEdits(testCase.previous, testCase.current), testCase.expected)
+		})
+	}
+}
@@ -2,6 +2,7 @@ package ls
 
 import (
 	"github.com/pagpeter/typescript-go/external/core"
+	"github.com/pagpeter/typescript-go/external/modulespecifiers"
 )
 
 type Location struct {
@@ -17,6 +18,14 @@ const (
 	JsxAttributeCompletionStyleNone   JsxAttributeCompletionStyle = "none"
 )
 
+type IncludeInlayParameterNameHints string
+
+const (
+	IncludeInlayParameterNameHintsNone     IncludeInlayParameterNameHints = "none"
+	IncludeInlayParameterNameHintsLiterals IncludeInlayParameterNameHints = "literals"
+	IncludeInlayParameterNameHintsAll      IncludeInlayParameterNameHints = "all"
+)
+
 type UserPreferences struct {
 	// Enables auto-import-style completions on partially-typed import statements. E.g., allows
 	// `import write|` to be completed to `import { writeFile } from "fs"`.
@@ -40,4 +49,45 @@ type UserPreferences struct {
 	IncludeCompletionsWithObjectLiteralMethodSnippets *bool
 
 	JsxAttributeCompletionStyle *JsxAttributeCompletionStyle
+
+	// Shows the names of parameters before the arguments of calls: only for literal arguments with
+	// `literals`, or for all arguments with `all`.
+	IncludeInlayParameterNameHints *IncludeInlayParameterNameHints
+
+	// If enabled, parameter name hints are also shown for arguments whose text matches the parameter name,
+	// such as `x` in `f(x)`.
+	IncludeInlayParameterNameHintsWhenArgumentMatchesName *bool
+
+	// Shows the contextual types of parameters that have no type annotation.
+	IncludeInlayFunctionParameterTypeHints *bool
+
+	// Shows the inferred types of variables that have no type annotation.
+	IncludeInlayVariableTypeHints *bool
+
+	// Unless this option is `false`, variable type hints are also shown when the variable name matches
+	// the type name, such as `url` in `const url = new URL(...)`.
+	IncludeInlayVariableTypeHintsWhenTypeMatchesName *bool
+
+	// Shows the inferred types of property declarations that have no type annotation.
+	IncludeInlayPropertyDeclarationTypeHints *bool
+
+	// Shows the inferred return types of functions and methods that have no return type annotation.
+	IncludeInlayFunctionLikeReturnTypeHints *bool
+
+	// Shows the values of enum members that have no initializer.
+	IncludeInlayEnumMemberValueHints *bool
+
+	// Preferred style of generated module specifiers: `shortest`, `project-relative`, `relative` or
+	// `non-relative`. When updating an existing import, `shortest` keeps the style of that import.
+	ImportModuleSpecifierPreference modulespecifiers.ImportModuleSpecifierPreference
+
+	// Preferred ending of generated module specifiers: `auto`, `minimal`, `index` or `js`.
+	ImportModuleSpecifierEndingPreference modulespecifiers.ImportModuleSpecifierEndingPreference
+}
+
+func (p *UserPreferences) moduleSpecifierPreferences() modulespecifiers.UserPreferences {
+	return modulespecifiers.UserPreferences{
+		ImportModuleSpecifierPreference:       p.ImportModuleSpecifierPreference,
+		ImportModuleSpecifierEndingPreference: p.ImportModuleSpecifierEndingPreference,
+	}
 }
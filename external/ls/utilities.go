@@ -24,7 +24,7 @@ func ComparePositions(pos, other lsproto.Position) int {
 	if lineComp := cmp.Compare(pos.Line, other.Line); lineComp != 0 {
 		return lineComp
 	}
-	return cmp.Compare(pos.Line, other.Line)
+	return cmp.Compare(pos.Character, other.Character)
 }
 
 // Implements a cmp.Compare like function for two *lsproto.Range
@@ -133,6 +133,11 @@ func isExportSpecifierAlias(referenceLocation *ast.Identifier, exportSpecifier *
 	}
 }
 
+func positionsAreOnSameLine(file *ast.SourceFile, pos1 int, pos2 int) bool {
+	lineMap := file.LineMap()
+	return scanner.ComputeLineOfPosition(lineMap, pos1) == scanner.ComputeLineOfPosition(lineMap, pos2)
+}
+
 // !!! formatting function
 func isInComment(file *ast.SourceFile, position int, tokenAtPosition *ast.Node) *ast.CommentRange {
 	return nil
@@ -575,6 +580,73 @@ func isLiteralNameOfPropertyDeclarationOrIndexAccess(node *ast.Node) bool {
 	}
 }
 
+// Returns the containing object literal property declaration given a possible name node, e.g. "a" in x = { "a": 1 }
+func getContainingObjectLiteralElement(node *ast.Node) *ast.Node {
+	element := getContainingObjectLiteralElementWorker(node)
+	if element != nil && (ast.IsObjectLiteralExpression(element.Parent) || ast.IsJsxAttributes(element.Parent)) {
+		return element
+	}
+	return nil
+}
+
+// Returns the properties of the contextual type of an object literal matching the name of `node`.
+func getPropertySymbolsFromContextualType(node *ast.Node, typeChecker *checker.Checker, contextualType *checker.Type, unionSymbolOk bool) []*ast.Symbol {
+	name, ok := ast.TryGetTextOfPropertyName(node.Name())
+	if !ok {
+		return nil
+	}
+	if !contextualType.IsUnion() {
+		if symbol := typeChecker.GetPropertyOfType(contextualType, name); symbol != nil {
+			return []*ast.Symbol{symbol}
+		}
+		return nil
+	}
+	filteredTypes := contextualType.Types()
+	if ast.IsObjectLiteralExpression(node.Parent) || ast.IsJsxAttributes(node.Parent) {
+		filteredTypes = core.Filter(filteredTypes, func(t *checker.Type) bool {
+			return !typeChecker.IsTypeInvalidDueToUnionDiscriminant(t, node.Parent)
+		})
+	}
+	discriminatedPropertySymbols := core.MapNonNil(filteredTypes, func(t *checker.Type) *ast.Symbol {
+		return typeChecker.GetPropertyOfType(t, name)
+	})
+	if unionSymbolOk && (len(discriminatedPropertySymbols) == 0 || len(discriminatedPropertySymbols) == len(contextualType.Types())) {
+		if symbol := typeChecker.GetPropertyOfType(contextualType, name); symbol != nil {
+			return []*ast.Symbol{symbol}
+		}
+	}
+	if len(filteredTypes) == 0 && len(discriminatedPropertySymbols) == 0 {
+		return core.MapNonNil(contextualType.Types(), func(t *checker.Type) *ast.Symbol {
+			return typeChecker.GetPropertyOfType(t, name)
+		})
+	}
+	var result []*ast.Symbol
+	for _, symbol := range discriminatedPropertySymbols {
+		result = core.AppendIfUnique(result, symbol)
+	}
+	return result
+}
+
+func getContainingObjectLiteralElementWorker(node *ast.Node) *ast.Node {
+	switch node.Kind {
+	case ast.KindStringLiteral, ast.KindNoSubstitutionTemplateLiteral, ast.KindNumericLiteral:
+		if node.Parent.Kind == ast.KindComputedPropertyName {
+			if ast.IsObjectLiteralElement(node.Parent.Parent) {
+				return node.Parent.Parent
+			}
+			return nil
+		}
+		fallthrough
+	case ast.KindIdentifier, ast.KindJsxNamespacedName:
+		if ast.IsObjectLiteralElement(node.Parent) &&
+			(node.Parent.Parent.Kind == ast.KindObjectLiteralExpression || node.Parent.Parent.Kind == ast.KindJsxAttributes) &&
+			node.Parent.Name() == node {
+			return node.Parent
+		}
+	}
+	return nil
+}
+
 func isObjectBindingElementWithoutPropertyName(bindingElement *ast.Node) bool {
 	return bindingElement.Kind == ast.KindBindingElement &&
 		bindingElement.Parent.Kind == ast.KindObjectBindingPattern &&
@@ -613,6 +685,11 @@ func isImplementation(node *ast.Node) bool {
 	return ast.IsClassLike(node) || ast.IsModuleOrEnumDeclaration(node)
 }
 
+// Whether the type annotation of `node` can be implemented by an expression, e.g. its initializer or return value.
+func canHaveImplementingExpression(node *ast.Node) bool {
+	return ast.IsVariableLike(node) || ast.IsFunctionLike(node) || ast.IsAssertionExpression(node) || ast.IsSatisfiesExpression(node)
+}
+
 func isImplementationExpression(node *ast.Node) bool {
 	switch node.Kind {
 	case ast.KindParenthesizedExpression:
@@ -1247,6 +1324,20 @@ func getMeaningFromLocation(node *ast.Node) ast.SemanticMeaning {
 	}
 }
 
+func convertSemanticMeaningToSymbolFlags(meaning ast.SemanticMeaning) ast.SymbolFlags {
+	flags := ast.SymbolFlagsNone
+	if meaning&ast.SemanticMeaningNamespace != 0 {
+		flags |= ast.SymbolFlagsNamespace
+	}
+	if meaning&ast.SemanticMeaningValue != 0 {
+		flags |= ast.SymbolFlagsValue
+	}
+	if meaning&ast.SemanticMeaningType != 0 {
+		flags |= ast.SymbolFlagsType
+	}
+	return flags
+}
+
 func getMeaningFromDeclaration(node *ast.Node) ast.SemanticMeaning {
 	switch node.Kind {
 	case ast.KindVariableDeclaration, ast.KindCommonJSExport, ast.KindParameter, ast.KindBindingElement,
@@ -1348,7 +1439,7 @@ func getParentSymbolsOfPropertyAccess(location *ast.Node, symbol *ast.Symbol, ch
 	}
 
 	var possibleSymbols []*checker.Type
-	if lhsType.Flags() != 0 {
+	if lhsType.Flags()&checker.TypeFlagsUnionOrIntersection != 0 {
 		possibleSymbols = lhsType.Types()
 	} else if lhsType.Symbol() != symbol.Parent {
 		possibleSymbols = []*checker.Type{lhsType}
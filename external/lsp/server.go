@@ -10,12 +10,14 @@ import (
 	"os/signal"
 	"runtime/debug"
 	"slices"
+	"strconv"
 	"sync"
 	"sync/atomic"
 	"syscall"
 
 	"github.com/pagpeter/typescript-go/external/collections"
 	"github.com/pagpeter/typescript-go/external/core"
+	"github.com/pagpeter/typescript-go/external/diagnostics"
 	"github.com/pagpeter/typescript-go/external/ls"
 	"github.com/pagpeter/typescript-go/external/lsp/lsproto"
 	"github.com/pagpeter/typescript-go/external/project"
@@ -152,6 +154,13 @@ type Server struct {
 	// enables tests to share a cache of parsed source files
 	parsedFileCache project.ParsedFileCache
 
+	// the last semantic tokens sent for each document, for answering delta requests
+	semanticTokens         collections.SyncMap[lsproto.DocumentUri, *semanticTokensResult]
+	semanticTokensResultID atomic.Uint64
+
+	// the client's editor settings, from its initialization options or the last configuration change
+	userPreferences atomic.Pointer[ls.UserPreferences]
+
 	// !!! temporary; remove when we have `handleDidChangeConfiguration`/implicit project config support
 	compilerOptionsForInferredProjects *core.CompilerOptions
 }
@@ -478,18 +487,38 @@ func (s *Server) handleRequestOrNotification(ctx context.Context, req *lsproto.R
 		return s.handleDidClose(ctx, req)
 	case *lsproto.DidChangeWatchedFilesParams:
 		return s.handleDidChangeWatchedFiles(ctx, req)
+	case *lsproto.DidChangeConfigurationParams:
+		return s.handleDidChangeConfiguration(ctx, req)
 	case *lsproto.DocumentDiagnosticParams:
 		return s.handleDocumentDiagnostic(ctx, req)
 	case *lsproto.HoverParams:
 		return s.handleHover(ctx, req)
 	case *lsproto.DefinitionParams:
 		return s.handleDefinition(ctx, req)
+	case *lsproto.TypeDefinitionParams:
+		return s.handleTypeDefinition(ctx, req)
+	case *lsproto.ImplementationParams:
+		return s.handleImplementation(ctx, req)
 	case *lsproto.CompletionParams:
 		return s.handleCompletion(ctx, req)
 	case *lsproto.ReferenceParams:
 		return s.handleReferences(ctx, req)
+	case *lsproto.DocumentHighlightParams:
+		return s.handleDocumentHighlight(ctx, req)
 	case *lsproto.SignatureHelpParams:
 		return s.handleSignatureHelp(ctx, req)
+	case *lsproto.RenameParams:
+		return s.handleRename(ctx, req)
+	case *lsproto.PrepareRenameParams:
+		return s.handlePrepareRename(ctx, req)
+	case *lsproto.CodeActionParams:
+		return s.handleCodeAction(ctx, req)
+	case *lsproto.SemanticTokensParams:
+		return s.handleSemanticTokensFull(ctx, req)
+	case *lsproto.SemanticTokensDeltaParams:
+		return s.handleSemanticTokensFullDelta(ctx, req)
+	case *lsproto.SemanticTokensRangeParams:
+		return s.handleSemanticTokensRange(ctx, req)
 	case *lsproto.DocumentFormattingParams:
 		return s.handleDocumentFormat(ctx, req)
 	case *lsproto.DocumentRangeFormattingParams:
@@ -498,6 +527,20 @@ func (s *Server) handleRequestOrNotification(ctx context.Context, req *lsproto.R
 		return s.handleDocumentOnTypeFormat(ctx, req)
 	case *lsproto.WorkspaceSymbolParams:
 		return s.handleWorkspaceSymbol(ctx, req)
+	case *lsproto.InlayHintParams:
+		return s.handleInlayHint(ctx, req)
+	case *lsproto.CallHierarchyPrepareParams:
+		return s.handlePrepareCallHierarchy(ctx, req)
+	case *lsproto.CallHierarchyIncomingCallsParams:
+		return s.handleCallHierarchyIncomingCalls(ctx, req)
+	case *lsproto.CallHierarchyOutgoingCallsParams:
+		return s.handleCallHierarchyOutgoingCalls(ctx, req)
+	case *lsproto.DocumentSymbolParams:
+		return s.handleDocumentSymbol(ctx, req)
+	case *lsproto.FoldingRangeParams:
+		return s.handleFoldingRange(ctx, req)
+	case *lsproto.SelectionRangeParams:
+		return s.handleSelectionRange(ctx, req)
 	default:
 		switch req.Method {
 		case lsproto.MethodShutdown:
@@ -506,6 +549,8 @@ func (s *Server) handleRequestOrNotification(ctx context.Context, req *lsproto.R
 			return nil
 		case lsproto.MethodExit:
 			return io.EOF
+		case lsproto.MethodWorkspaceWillRenameFiles:
+			return s.handleWillRenameFiles(ctx, req)
 		default:
 			s.Log("unknown method", req.Method)
 			if req.ID != nil {
@@ -550,9 +595,30 @@ func (s *Server) handleInitialize(req *lsproto.RequestMessage) {
 			DefinitionProvider: &lsproto.BooleanOrDefinitionOptions{
 				Boolean: ptrTo(true),
 			},
+			TypeDefinitionProvider: &lsproto.BooleanOrTypeDefinitionOptionsOrTypeDefinitionRegistrationOptions{
+				Boolean: ptrTo(true),
+			},
+			ImplementationProvider: &lsproto.BooleanOrImplementationOptionsOrImplementationRegistrationOptions{
+				Boolean: ptrTo(true),
+			},
 			ReferencesProvider: &lsproto.BooleanOrReferenceOptions{
 				Boolean: ptrTo(true),
 			},
+			DocumentHighlightProvider: &lsproto.BooleanOrDocumentHighlightOptions{
+				Boolean: ptrTo(true),
+			},
+			RenameProvider: &lsproto.BooleanOrRenameOptions{
+				RenameOptions: &lsproto.RenameOptions{
+					PrepareProvider: ptrTo(true),
+				},
+			},
+			CodeActionProvider: &lsproto.BooleanOrCodeActionOptions{
+				CodeActionOptions: &lsproto.CodeActionOptions{
+					CodeActionKinds: &[]lsproto.CodeActionKind{
+						lsproto.CodeActionKindQuickFix,
+					},
+				},
+			},
 			DiagnosticProvider: &lsproto.DiagnosticOptionsOrDiagnosticRegistrationOptions{
 				DiagnosticOptions: &lsproto.DiagnosticOptions{
 					InterFileDependencies: true,
@@ -578,6 +644,56 @@ func (s *Server) handleInitialize(req *lsproto.RequestMessage) {
 			WorkspaceSymbolProvider: &lsproto.BooleanOrWorkspaceSymbolOptions{
 				Boolean: ptrTo(true),
 			},
+			SemanticTokensProvider: &lsproto.SemanticTokensOptionsOrSemanticTokensRegistrationOptions{
+				SemanticTokensOptions: &lsproto.SemanticTokensOptions{
+					Legend: &ls.SemanticTokensLegend,
+					Range: &lsproto.BooleanOrEmptyObject{
+						Boolean: ptrTo(true),
+					},
+					Full: &lsproto.BooleanOrSemanticTokensFullDelta{
+						SemanticTokensFullDelta: &lsproto.SemanticTokensFullDelta{
+							Delta: ptrTo(true),
+						},
+					},
+				},
+			},
+			InlayHintProvider: &lsproto.BooleanOrInlayHintOptionsOrInlayHintRegistrationOptions{
+				Boolean: ptrTo(true),
+			},
+			CallHierarchyProvider: &lsproto.BooleanOrCallHierarchyOptionsOrCallHierarchyRegistrationOptions{
+				Boolean: ptrTo(true),
+			},
+			DocumentSymbolProvider: &lsproto.BooleanOrDocumentSymbolOptions{
+				Boolean: ptrTo(true),
+			},
+			FoldingRangeProvider: &lsproto.BooleanOrFoldingRangeOptionsOrFoldingRangeRegistrationOptions{
+				Boolean: ptrTo(true),
+			},
+			SelectionRangeProvider: &lsproto.BooleanOrSelectionRangeOptionsOrSelectionRangeRegistrationOptions{
+				Boolean: ptrTo(true),
+			},
+			Workspace: &lsproto.WorkspaceOptions{
+				FileOperations: &lsproto.FileOperationOptions{
+					WillRename: &lsproto.FileOperationRegistrationOptions{
+						Filters: []*lsproto.FileOperationFilter{
+							{
+								Scheme: ptrTo("file"),
+								Pattern: &lsproto.FileOperationPattern{
+									Glob:    "**/*.{ts,tsx,mts,cts,js,jsx,mjs,cjs}",
+									Matches: ptrTo(lsproto.FileOperationPatternKindfile),
+								},
+							},
+							{
+								Scheme: ptrTo("file"),
+								Pattern: &lsproto.FileOperationPattern{
+									Glob:    "**/*",
+									Matches: ptrTo(lsproto.FileOperationPatternKindfolder),
+								},
+							},
+						},
+					},
+				},
+			},
 		},
 	})
 }
@@ -587,11 +703,24 @@ func (s *Server) handleInitialized(ctx context.Context, req *lsproto.RequestMess
 		s.watchEnabled = true
 	}
 
+	if s.initializeParams.InitializationOptions != nil {
+		s.userPreferences.Store(parseUserPreferences(*s.initializeParams.InitializationOptions))
+	}
+
 	s.logger = project.NewLogger([]io.Writer{s.stderr}, "" /*file*/, project.LogLevelVerbose)
+	var locale *diagnostics.Locale
+	if s.initializeParams.Locale != nil {
+		var err error
+		// Fall back to English messages if the client's locale cannot be loaded.
+		if locale, err = diagnostics.LoadLocale(s.fs, s.defaultLibraryPath, *s.initializeParams.Locale); err != nil {
+			s.logger.Error(fmt.Sprintf("Failed to load locale %q: %v", *s.initializeParams.Locale, err))
+		}
+	}
 	s.projectService = project.NewService(s, project.ServiceOptions{
 		Logger:           s.logger,
 		WatchEnabled:     s.watchEnabled,
 		PositionEncoding: s.positionEncoding,
+		Locale:           locale,
 		TypingsInstallerOptions: project.TypingsInstallerOptions{
 			ThrottleLimit: 5,
 			NpmInstall:    project.NpmInstall,
@@ -626,6 +755,7 @@ func (s *Server) handleDidSave(ctx context.Context, req *lsproto.RequestMessage)
 func (s *Server) handleDidClose(ctx context.Context, req *lsproto.RequestMessage) error {
 	params := req.Params.(*lsproto.DidCloseTextDocumentParams)
 	s.projectService.CloseFile(ls.DocumentURIToFileName(params.TextDocument.Uri))
+	s.semanticTokens.Delete(params.TextDocument.Uri)
 	return nil
 }
 
@@ -634,6 +764,12 @@ func (s *Server) handleDidChangeWatchedFiles(ctx context.Context, req *lsproto.R
 	return s.projectService.OnWatchedFilesChanged(ctx, params.Changes)
 }
 
+func (s *Server) handleDidChangeConfiguration(ctx context.Context, req *lsproto.RequestMessage) error {
+	params := req.Params.(*lsproto.DidChangeConfigurationParams)
+	s.userPreferences.Store(parseUserPreferences(params.Settings))
+	return nil
+}
+
 func (s *Server) handleDocumentDiagnostic(ctx context.Context, req *lsproto.RequestMessage) error {
 	params := req.Params.(*lsproto.DocumentDiagnosticParams)
 	project := s.projectService.EnsureDefaultProjectForURI(params.TextDocument.Uri)
@@ -690,6 +826,32 @@ func (s *Server) handleDefinition(ctx context.Context, req *lsproto.RequestMessa
 	return nil
 }
 
+func (s *Server) handleTypeDefinition(ctx context.Context, req *lsproto.RequestMessage) error {
+	params := req.Params.(*lsproto.TypeDefinitionParams)
+	project := s.projectService.EnsureDefaultProjectForURI(params.TextDocument.Uri)
+	languageService, done := project.GetLanguageServiceForRequest(ctx)
+	defer done()
+	definition, err := languageService.ProvideTypeDefinition(ctx, params.TextDocument.Uri, params.Position)
+	if err != nil {
+		return err
+	}
+	s.sendResult(req.ID, definition)
+	return nil
+}
+
+func (s *Server) handleImplementation(ctx context.Context, req *lsproto.RequestMessage) error {
+	params := req.Params.(*lsproto.ImplementationParams)
+	project := s.projectService.EnsureDefaultProjectForURI(params.TextDocument.Uri)
+	languageService, done := project.GetLanguageServiceForRequest(ctx)
+	defer done()
+	locations, err := languageService.ProvideImplementations(ctx, params.TextDocument.Uri, params.Position)
+	if err != nil {
+		return err
+	}
+	s.sendResult(req.ID, locations)
+	return nil
+}
+
 func (s *Server) handleReferences(ctx context.Context, req *lsproto.RequestMessage) error {
 	// findAllReferences
 	params := req.Params.(*lsproto.ReferenceParams)
@@ -701,6 +863,119 @@ func (s *Server) handleReferences(ctx context.Context, req *lsproto.RequestMessa
 	return nil
 }
 
+func (s *Server) handleDocumentHighlight(ctx context.Context, req *lsproto.RequestMessage) error {
+	params := req.Params.(*lsproto.DocumentHighlightParams)
+	project := s.projectService.EnsureDefaultProjectForURI(params.TextDocument.Uri)
+	languageService, done := project.GetLanguageServiceForRequest(ctx)
+	defer done()
+	highlights, err := languageService.ProvideDocumentHighlights(ctx, params.TextDocument.Uri, params.Position)
+	if err != nil {
+		return err
+	}
+	s.sendResult(req.ID, highlights)
+	return nil
+}
+
+func (s *Server) handleRename(ctx context.Context, req *lsproto.RequestMessage) error {
+	params := req.Params.(*lsproto.RenameParams)
+	project := s.projectService.EnsureDefaultProjectForURI(params.TextDocument.Uri)
+	languageService, done := project.GetLanguageServiceForRequest(ctx)
+	defer done()
+	edit, err := languageService.ProvideRename(ctx, params.TextDocument.Uri, params.Position, params.NewName)
+	if err != nil {
+		return err
+	}
+	s.sendResult(req.ID, edit)
+	return nil
+}
+
+func (s *Server) handlePrepareRename(ctx context.Context, req *lsproto.RequestMessage) error {
+	params := req.Params.(*lsproto.PrepareRenameParams)
+	project := s.projectService.EnsureDefaultProjectForURI(params.TextDocument.Uri)
+	languageService, done := project.GetLanguageServiceForRequest(ctx)
+	defer done()
+	result, err := languageService.ProvidePrepareRename(ctx, params.TextDocument.Uri, params.Position)
+	if err != nil {
+		return err
+	}
+	s.sendResult(req.ID, result)
+	return nil
+}
+
+func (s *Server) handleCodeAction(ctx context.Context, req *lsproto.RequestMessage) error {
+	params := req.Params.(*lsproto.CodeActionParams)
+	project := s.projectService.EnsureDefaultProjectForURI(params.TextDocument.Uri)
+	languageService, done := project.GetLanguageServiceForRequest(ctx)
+	defer done()
+	actions, err := languageService.ProvideCodeActions(ctx, params.TextDocument.Uri, params.Context)
+	if err != nil {
+		return err
+	}
+	s.sendResult(req.ID, actions)
+	return nil
+}
+
+type semanticTokensResult struct {
+	resultID string
+	data     []uint32
+}
+
+func (s *Server) handleSemanticTokensFull(ctx context.Context, req *lsproto.RequestMessage) error {
+	params := req.Params.(*lsproto.SemanticTokensParams)
+	project := s.projectService.EnsureDefaultProjectForURI(params.TextDocument.Uri)
+	languageService, done := project.GetLanguageServiceForRequest(ctx)
+	defer done()
+	tokens, err := languageService.ProvideSemanticTokens(ctx, params.TextDocument.Uri)
+	if err != nil {
+		return err
+	}
+	tokens.ResultId = s.storeSemanticTokens(params.TextDocument.Uri, tokens.Data)
+	s.sendResult(req.ID, tokens)
+	return nil
+}
+
+func (s *Server) handleSemanticTokensFullDelta(ctx context.Context, req *lsproto.RequestMessage) error {
+	params := req.Params.(*lsproto.SemanticTokensDeltaParams)
+	project := s.projectService.EnsureDefaultProjectForURI(params.TextDocument.Uri)
+	languageService, done := project.GetLanguageServiceForRequest(ctx)
+	defer done()
+	tokens, err := languageService.ProvideSemanticTokens(ctx, params.TextDocument.Uri)
+	if err != nil {
+		return err
+	}
+	previous, ok := s.semanticTokens.Load(params.TextDocument.Uri)
+	tokens.ResultId = s.storeSemanticTokens(params.TextDocument.Uri, tokens.Data)
+	if !ok || previous.resultID != params.PreviousResultId {
+		// The previous result is unknown, so send all tokens.
+		s.sendResult(req.ID, tokens)
+		return nil
+	}
+	s.sendResult(req.ID, &lsproto.SemanticTokensDelta{
+		ResultId: tokens.ResultId,
+		Edits:    ls.GetSemanticTokensEdits(previous.data, tokens.Data),
+	})
+	return nil
+}
+
+func (s *Server) handleSemanticTokensRange(ctx context.Context, req *lsproto.RequestMessage) error {
+	params := req.Params.(*lsproto.SemanticTokensRangeParams)
+	project := s.projectService.EnsureDefaultProjectForURI(params.TextDocument.Uri)
+	languageService, done := project.GetLanguageServiceForRequest(ctx)
+	defer done()
+	tokens, err := languageService.ProvideSemanticTokensRange(ctx, params.TextDocument.Uri, params.Range)
+	if err != nil {
+		return err
+	}
+	s.sendResult(req.ID, tokens)
+	return nil
+}
+
+func (s *Server) storeSemanticTokens(uri lsproto.DocumentUri, data []uint32) *string {
+	resultID := strconv.FormatUint(s.semanticTokensResultID.Add(1), 10)
+	s.semanticTokens.Store(uri, &semanticTokensResult{resultID: resultID, data: data})
+	return &resultID
+}
+
 func (s *Server) handleCompletion(ctx context.Context, req *lsproto.RequestMessage) error {
 	params := req.Params.(*lsproto.CompletionParams)
 	project := s.projectService.EnsureDefaultProjectForURI(params.TextDocument.Uri)
@@ -786,6 +1061,130 @@ func (s *Server) handleWorkspaceSymbol(ctx context.Context, req *lsproto.Request
 	return nil
 }
 
+func (s *Server) handleWillRenameFiles(ctx context.Context, req *lsproto.RequestMessage) error {
+	params := req.Params.(*lsproto.RenameFilesParams)
+	changes := map[lsproto.DocumentUri][]*lsproto.TextEdit{}
+	for _, project := range s.projectService.Projects() {
+		languageService, done := project.GetLanguageServiceForRequest(ctx)
+		for _, file := range params.Files {
+			edit, err := languageService.ProvideFileRenameEdits(ctx, lsproto.DocumentUri(file.OldUri), lsproto.DocumentUri(file.NewUri), s.getUserPreferences())
+			if err != nil {
+				done()
+				return err
+			}
+			for uri, edits := range *edit.Changes {
+				for _, textEdit := range edits {
+					// Files shared by several projects get the same edits from each of them.
+					if !slices.ContainsFunc(changes[uri], func(existing *lsproto.TextEdit) bool { return existing.Range == textEdit.Range }) {
+						changes[uri] = append(changes[uri], textEdit)
+					}
+				}
+			}
+		}
+		done()
+	}
+	s.sendResult(req.ID, &lsproto.WorkspaceEdit{Changes: &changes})
+	return nil
+}
+
+func (s *Server) handleInlayHint(ctx context.Context, req *lsproto.RequestMessage) error {
+	params := req.Params.(*lsproto.InlayHintParams)
+	project := s.projectService.EnsureDefaultProjectForURI(params.TextDocument.Uri)
+	languageService, done := project.GetLanguageServiceForRequest(ctx)
+	defer done()
+	hints, err := languageService.ProvideInlayHints(ctx, params.TextDocument.Uri, params.Range, s.getUserPreferences())
+	if err != nil {
+		return err
+	}
+	s.sendResult(req.ID, hints)
+	return nil
+}
+
+func (s *Server) handlePrepareCallHierarchy(ctx context.Context, req *lsproto.RequestMessage) error {
+	params := req.Params.(*lsproto.CallHierarchyPrepareParams)
+	project := s.projectService.EnsureDefaultProjectForURI(params.TextDocument.Uri)
+	languageService, done := project.GetLanguageServiceForRequest(ctx)
+	defer done()
+	items, err := languageService.ProvidePrepareCallHierarchy(ctx, params.TextDocument.Uri, params.Position)
+	if err != nil {
+		return err
+	}
+	s.sendResult(req.ID, items)
+	return nil
+}
+
+func (s *Server) handleCallHierarchyIncomingCalls(ctx context.Context, req *lsproto.RequestMessage) error {
+	params := req.Params.(*lsproto.CallHierarchyIncomingCallsParams)
+	project := s.projectService.EnsureDefaultProjectForURI(params.Item.Uri)
+	languageService, done := project.GetLanguageServiceForRequest(ctx)
+	defer done()
+	calls, err := languageService.ProvideCallHierarchyIncomingCalls(ctx, params.Item)
+	if err != nil {
+		return err
+	}
+	s.sendResult(req.ID, calls)
+	return nil
+}
+
+func (s *Server) handleCallHierarchyOutgoingCalls(ctx context.Context, req *lsproto.RequestMessage) error {
+	params := req.Params.(*lsproto.CallHierarchyOutgoingCallsParams)
+	project := s.projectService.EnsureDefaultProjectForURI(params.Item.Uri)
+	languageService, done := project.GetLanguageServiceForRequest(ctx)
+	defer done()
+	calls, err := languageService.ProvideCallHierarchyOutgoingCalls(ctx, params.Item)
+	if err != nil {
+		return err
+	}
+	s.sendResult(req.ID, calls)
+	return nil
+}
+
+func (s *Server) handleDocumentSymbol(ctx context.Context, req *lsproto.RequestMessage) error {
+	params := req.Params.(*lsproto.DocumentSymbolParams)
+	project := s.projectService.EnsureDefaultProjectForURI(params.TextDocument.Uri)
+	languageService, done := project.GetLanguageServiceForRequest(ctx)
+	defer done()
+	symbols, err := languageService.ProvideDocumentSymbols(ctx, params.TextDocument.Uri)
+	if err != nil {
+		return err
+	}
+	s.sendResult(req.ID, symbols)
+	return nil
+}
+
+func (s *Server) handleFoldingRange(ctx context.Context, req *lsproto.RequestMessage) error {
+	params := req.Params.(*lsproto.FoldingRangeParams)
+	project := s.projectService.EnsureDefaultProjectForURI(params.TextDocument.Uri)
+	languageService, done := project.GetLanguageServiceForRequest(ctx)
+	defer done()
+	foldingRanges, err := languageService.ProvideFoldingRanges(ctx, params.TextDocument.Uri)
+	if err != nil {
+		return err
+	}
+	s.sendResult(req.ID, foldingRanges)
+	return nil
+}
+
+func (s *Server) handleSelectionRange(ctx context.Context, req *lsproto.RequestMessage) error {
+	params := req.Params.(*lsproto.SelectionRangeParams)
+	project := s.projectService.EnsureDefaultProjectForURI(params.TextDocument.Uri)
+	languageService, done := project.GetLanguageServiceForRequest(ctx)
+	defer done()
+	selectionRanges, err := languageService.ProvideSelectionRanges(ctx, params.TextDocument.Uri, params.Positions)
+	if err != nil {
+		return err
+	}
+	s.sendResult(req.ID, selectionRanges)
+	return nil
+}
+
+func (s *Server) getUserPreferences() *ls.UserPreferences {
+	if preferences := s.userPreferences.Load(); preferences != nil {
+		return preferences
+	}
+	return &ls.UserPreferences{}
+}
+
 func (s *Server) Log(msg ...any) {
 	fmt.Fprintln(s.stderr, msg...)
 }
@@ -806,7 +1205,8 @@ func isBlockingMethod(method lsproto.Method) bool {
 		lsproto.MethodTextDocumentDidChange,
 		lsproto.MethodTextDocumentDidSave,
 		lsproto.MethodTextDocumentDidClose,
-		lsproto.MethodWorkspaceDidChangeWatchedFiles:
+		lsproto.MethodWorkspaceDidChangeWatchedFiles,
+		lsproto.MethodWorkspaceDidChangeConfiguration:
 		return true
 	}
 	return false
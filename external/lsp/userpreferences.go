@@ -0,0 +1,68 @@
+package lsp
+
+import (
+	"github.com/pagpeter/typescript-go/external/ls"
+	"github.com/pagpeter/typescript-go/external/modulespecifiers"
+)
+
+// parseUserPreferences reads the `typescript.*` editor settings, as sent by the client in its
+// initialization options or in a workspace configuration change, into the preferences passed to
+// the language service. Settings may be given as nested objects or as dotted keys.
+func parseUserPreferences(settings any) *ls.UserPreferences {
+	preferences := &ls.UserPreferences{}
+	config, ok := settings.(map[string]any)
+	if !ok {
+		return preferences
+	}
+
+	if value, ok := getSetting(config, "typescript.inlayHints.parameterNames.enabled").(string); ok {
+		preferences.IncludeInlayParameterNameHints = ptrTo(ls.IncludeInlayParameterNameHints(value))
+	}
+	if value, ok := getSetting(config, "typescript.inlayHints.parameterNames.suppressWhenArgumentMatchesName").(bool); ok {
+		preferences.IncludeInlayParameterNameHintsWhenArgumentMatchesName = ptrTo(!value)
+	}
+	if value, ok := getSetting(config, "typescript.inlayHints.parameterTypes.enabled").(bool); ok {
+		preferences.IncludeInlayFunctionParameterTypeHints = ptrTo(value)
+	}
+	if value, ok := getSetting(config, "typescript.inlayHints.variableTypes.enabled").(bool); ok {
+		preferences.IncludeInlayVariableTypeHints = ptrTo(value)
+	}
+	if value, ok := getSetting(config, "typescript.inlayHints.variableTypes.suppressWhenTypeMatchesName").(bool); ok {
+		preferences.IncludeInlayVariableTypeHintsWhenTypeMatchesName = ptrTo(!value)
+	}
+	if value, ok := getSetting(config, "typescript.inlayHints.propertyDeclarationTypes.enabled").(bool); ok {
+		preferences.IncludeInlayPropertyDeclarationTypeHints = ptrTo(value)
+	}
+	if value, ok := getSetting(config, "typescript.inlayHints.functionLikeReturnTypes.enabled").(bool); ok {
+		preferences.IncludeInlayFunctionLikeReturnTypeHints = ptrTo(value)
+	}
+	if value, ok := getSetting(config, "typescript.inlayHints.enumMemberValues.enabled").(bool); ok {
+		preferences.IncludeInlayEnumMemberValueHints = ptrTo(value)
+	}
+	if value, ok := getSetting(config, "typescript.preferences.importModuleSpecifier").(string); ok {
+		preferences.ImportModuleSpecifierPreference = modulespecifiers.ImportModuleSpecifierPreference(value)
+	}
+	if value, ok := getSetting(config, "typescript.preferences.importModuleSpecifierEnding").(string); ok {
+		preferences.ImportModuleSpecifierEndingPreference = modulespecifiers.ImportModuleSpecifierEndingPreference(value)
+	}
+	return preferences
+}
+
+// getSetting looks up a dotted setting name, descending into nested objects for any prefix of the
+// name that is not itself a key.
+func getSetting(config map[string]any, name string) any {
+	if value, ok := config[name]; ok {
+		return value
+	}
+	for i := range len(name) {
+		if name[i] != '.' {
+			continue
+		}
+		if nested, ok := config[name[:i]].(map[string]any); ok {
+			if value := getSetting(nested, name[i+1:]); value != nil {
+				return value
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package lsp
+
+import (
+	"testing"
+
+	"github.com/pagpeter/typescript-go/external/ls"
+	"github.com/pagpeter/typescript-go/external/modulespecifiers"
+	"gotest.tools/v3/assert"
+)
+
+func TestParseUserPreferences(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name     string
+		settings any
+		expected *ls.UserPreferences
+	}{
+		{
+			name:     "no settings",
+			settings: nil,
+			expected: &ls.UserPreferences{},
+		},
+		{
+			name: "nested",
+			settings: map[string]any{
+				"typescript": map[string]any{
+					"inlayHints": map[string]any{
+						"parameterNames":   map[string]any{"enabled": "all", "suppressWhenArgumentMatchesName": false},
+						"enumMemberValues": map[string]any{"enabled": true},
+					},
+				},
+			},
+			expected: &ls.UserPreferences{
+				IncludeInlayParameterNameHints:                        ptrTo(ls.IncludeInlayParameterNameHintsAll),
+				IncludeInlayParameterNameHintsWhenArgumentMatchesName: ptrTo(true),
+				IncludeInlayEnumMemberValueHints:                      ptrTo(true),
+			},
+		},
+		{
+			name: "dotted",
+			settings: map[string]any{
+				"typescript.inlayHints.variableTypes.enabled": true,
+				"typescript.inlayHints": map[string]any{
+					"variableTypes.suppressWhenTypeMatchesName": true,
+				},
+			},
+			expected: &ls.UserPreferences{
+				IncludeInlayVariableTypeHints:                    ptrTo(true),
+				IncludeInlayVariableTypeHintsWhenTypeMatchesName: ptrTo(false),
+			},
+		},
+		{
+			name: "module specifiers",
+			settings: map[string]any{
+				"typescript": map[string]any{
+					"preferences": map[string]any{"importModuleSpecifier": "non-relative", "importModuleSpecifierEnding": "js"},
+				},
+			},
+			expected: &ls.UserPreferences{
+				ImportModuleSpecifierPreference:       modulespecifiers.ImportModuleSpecifierPreferenceNonRelative,
+				ImportModuleSpecifierEndingPreference: modulespecifiers.ImportModuleSpecifierEndingPreferenceJs,
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			assert.DeepEqual(t, parseUserPreferences(testCase.settings), testCase.expected)
+		})
+	}
+}
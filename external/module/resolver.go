@@ -36,8 +36,28 @@ func continueSearching() *resolved {
 
 type resolutionKindSpecificLoader = func(extensions extensions, candidate string, onlyRecordFailures bool) *resolved
 
+// tracer collects the trace messages of a single resolution, so that resolutions performed in
+// parallel can be reported in a deterministic order. A nil *tracer discards messages.
+type tracer struct {
+	traces []string
+}
+
+func (t *tracer) write(msg string) {
+	if t != nil {
+		t.traces = append(t.traces, msg)
+	}
+}
+
+func (t *tracer) getTraces() []string {
+	if t == nil {
+		return nil
+	}
+	return t.traces
+}
+
 type resolutionState struct {
 	resolver *Resolver
+	tracer   *tracer
 
 	// request fields
 	name                string
@@ -65,12 +85,14 @@ func newResolutionState(
 	compilerOptions *core.CompilerOptions,
 	redirectedReference ResolvedProjectReference,
 	resolver *Resolver,
+	tracer *tracer,
 ) *resolutionState {
 	state := &resolutionState{
 		name:                name,
 		containingDirectory: containingDirectory,
 		compilerOptions:     GetCompilerOptionsWithRedirect(compilerOptions, redirectedReference),
 		resolver:            resolver,
+		tracer:              tracer,
 	}
 
 	if isTypeReferenceDirective {
@@ -139,13 +161,25 @@ func (r *Resolver) traceEnabled() bool {
 	return r.compilerOptions.TraceResolution == core.TSTrue
 }
 
-func (r *Resolver) GetPackageScopeForPath(directory string) *packagejson.InfoCacheEntry {
-	return (&resolutionState{compilerOptions: r.compilerOptions, resolver: r}).getPackageScopeForPath(directory)
+func (r *Resolver) newTracer() *tracer {
+	if r.traceEnabled() {
+		return &tracer{}
+	}
+	return nil
 }
 
-func (r *Resolver) GetPackageJsonScopeIfApplicable(path string) *packagejson.InfoCacheEntry {
+// GetPackageScopeForPath finds the nearest package.json scope of a directory, along with the trace
+// messages of the lookup.
+func (r *Resolver) GetPackageScopeForPath(directory string) (*packagejson.InfoCacheEntry, []string) {
+	state := &resolutionState{compilerOptions: r.compilerOptions, resolver: r, tracer: r.newTracer()}
+	return state.getPackageScopeForPath(directory), state.tracer.getTraces()
+}
+
+// GetPackageJsonScopeIfApplicable finds the package.json scope that determines the module format
+// of a file, along with the trace messages of the lookup.
+func (r *Resolver) GetPackageJsonScopeIfApplicable(path string) (*packagejson.InfoCacheEntry, []string) {
 	if tspath.FileExtensionIsOneOf(path, []string{tspath.ExtensionMts, tspath.ExtensionCts, tspath.ExtensionMjs, tspath.ExtensionCjs}) {
-		return nil
+		return nil, nil
 	}
 
 	moduleResolutionKind := r.compilerOptions.GetModuleResolutionKind()
@@ -153,12 +187,12 @@ func (r *Resolver) GetPackageJsonScopeIfApplicable(path string) *packagejson.Inf
 		return r.GetPackageScopeForPath(tspath.GetDirectoryPath(path))
 	}
 
-	return nil
+	return nil, nil
 }
 
-func (r *Resolver) traceResolutionUsingProjectReference(redirectedReference ResolvedProjectReference) {
+func (r *Resolver) traceResolutionUsingProjectReference(tracer *tracer, redirectedReference ResolvedProjectReference) {
 	if redirectedReference != nil && redirectedReference.CompilerOptions() != nil {
-		r.host.Trace(diagnostics.Using_compiler_options_of_project_reference_redirect_0.Format(redirectedReference.ConfigName()))
+		tracer.write(diagnostics.Using_compiler_options_of_project_reference_redirect_0.Format(redirectedReference.ConfigName()))
 	}
 }
 
@@ -167,33 +201,37 @@ func (r *Resolver) ResolveTypeReferenceDirective(
 	containingFile string,
 	resolutionMode core.ResolutionMode,
 	redirectedReference ResolvedProjectReference,
-) *ResolvedTypeReferenceDirective {
+) (*ResolvedTypeReferenceDirective, []string) {
 	traceEnabled := r.traceEnabled()
+	tracer := r.newTracer()
 
 	compilerOptions := GetCompilerOptionsWithRedirect(r.compilerOptions, redirectedReference)
 	containingDirectory := tspath.GetDirectoryPath(containingFile)
 
 	typeRoots, fromConfig := compilerOptions.GetEffectiveTypeRoots(r.host.GetCurrentDirectory())
 	if traceEnabled {
-		r.host.Trace(diagnostics.Resolving_type_reference_directive_0_containing_file_1_root_directory_2.Format(typeReferenceDirectiveName, containingFile, strings.Join(typeRoots, ",")))
-		r.traceResolutionUsingProjectReference(redirectedReference)
+		tracer.write(diagnostics.Resolving_type_reference_directive_0_containing_file_1_root_directory_2.Format(typeReferenceDirectiveName, containingFile, strings.Join(typeRoots, ",")))
+		r.traceResolutionUsingProjectReference(tracer, redirectedReference)
 	}
 
-	state := newResolutionState(typeReferenceDirectiveName, containingDirectory, true /*isTypeReferenceDirective*/, resolutionMode, compilerOptions, redirectedReference, r)
+	state := newResolutionState(typeReferenceDirectiveName, containingDirectory, true /*isTypeReferenceDirective*/, resolutionMode, compilerOptions, redirectedReference, r, tracer)
 	result := state.resolveTypeReferenceDirective(typeRoots, fromConfig, strings.HasSuffix(containingFile, InferredTypesContainingFile))
 
 	if traceEnabled {
-		r.traceTypeReferenceDirectiveResult(typeReferenceDirectiveName, result)
+		r.traceTypeReferenceDirectiveResult(tracer, typeReferenceDirectiveName, result)
 	}
-	return result
+	return result, tracer.getTraces()
 }
 
-func (r *Resolver) ResolveModuleName(moduleName string, containingFile string, resolutionMode core.ResolutionMode, redirectedReference ResolvedProjectReference) *ResolvedModule {
+// ResolveModuleName resolves a module name, returning the resolution along with its trace
+// messages when --traceResolution is enabled.
+func (r *Resolver) ResolveModuleName(moduleName string, containingFile string, resolutionMode core.ResolutionMode, redirectedReference ResolvedProjectReference) (*ResolvedModule, []string) {
 	traceEnabled := r.traceEnabled()
+	tracer := r.newTracer()
 	compilerOptions := GetCompilerOptionsWithRedirect(r.compilerOptions, redirectedReference)
 	if traceEnabled {
-		r.host.Trace(diagnostics.Resolving_module_0_from_1.Format(moduleName, containingFile))
-		r.traceResolutionUsingProjectReference(redirectedReference)
+		tracer.write(diagnostics.Resolving_module_0_from_1.Format(moduleName, containingFile))
+		r.traceResolutionUsingProjectReference(tracer, redirectedReference)
 	}
 	containingDirectory := tspath.GetDirectoryPath(containingFile)
 
@@ -201,18 +239,18 @@ func (r *Resolver) ResolveModuleName(moduleName string, containingFile string, r
 	if moduleResolution == core.ModuleResolutionKindUnknown {
 		moduleResolution = compilerOptions.GetModuleResolutionKind()
 		if traceEnabled {
-			r.host.Trace(diagnostics.Module_resolution_kind_is_not_specified_using_0.Format(moduleResolution.String()))
+			tracer.write(diagnostics.Module_resolution_kind_is_not_specified_using_0.Format(moduleResolution.String()))
 		}
 	} else {
 		if traceEnabled {
-			r.host.Trace(diagnostics.Explicitly_specified_module_resolution_kind_Colon_0.Format(moduleResolution.String()))
+			tracer.write(diagnostics.Explicitly_specified_module_resolution_kind_Colon_0.Format(moduleResolution.String()))
 		}
 	}
 
 	var result *ResolvedModule
 	switch moduleResolution {
 	case core.ModuleResolutionKindNode16, core.ModuleResolutionKindNodeNext, core.ModuleResolutionKindBundler:
-		state := newResolutionState(moduleName, containingDirectory, false /*isTypeReferenceDirective*/, resolutionMode, compilerOptions, redirectedReference, r)
+		state := newResolutionState(moduleName, containingDirectory, false /*isTypeReferenceDirective*/, resolutionMode, compilerOptions, redirectedReference, r, tracer)
 		result = state.resolveNodeLike()
 	default:
 		panic(fmt.Sprintf("Unexpected moduleResolution: %d", moduleResolution))
@@ -221,19 +259,19 @@ func (r *Resolver) ResolveModuleName(moduleName string, containingFile string, r
 	if traceEnabled {
 		if result.IsResolved() {
 			if result.PackageId.Name != "" {
-				r.host.Trace(diagnostics.Module_name_0_was_successfully_resolved_to_1_with_Package_ID_2.Format(moduleName, result.ResolvedFileName, result.PackageId.String()))
+				tracer.write(diagnostics.Module_name_0_was_successfully_resolved_to_1_with_Package_ID_2.Format(moduleName, result.ResolvedFileName, result.PackageId.String()))
 			} else {
-				r.host.Trace(diagnostics.Module_name_0_was_successfully_resolved_to_1.Format(moduleName, result.ResolvedFileName))
+				tracer.write(diagnostics.Module_name_0_was_successfully_resolved_to_1.Format(moduleName, result.ResolvedFileName))
 			}
 		} else {
-			r.host.Trace(diagnostics.Module_name_0_was_not_resolved.Format(moduleName))
+			tracer.write(diagnostics.Module_name_0_was_not_resolved.Format(moduleName))
 		}
 	}
 
-	return r.tryResolveFromTypingsLocation(moduleName, containingDirectory, result)
+	return r.tryResolveFromTypingsLocation(tracer, moduleName, containingDirectory, result), tracer.getTraces()
 }
 
-func (r *Resolver) tryResolveFromTypingsLocation(moduleName string, containingDirectory string, originalResult *ResolvedModule) *ResolvedModule {
+func (r *Resolver) tryResolveFromTypingsLocation(tracer *tracer, moduleName string, containingDirectory string, originalResult *ResolvedModule) *ResolvedModule {
 	if r.typingsLocation == "" ||
 		tspath.IsExternalModuleNameRelative(moduleName) ||
 		(originalResult.ResolvedFileName != "" && tspath.ExtensionIsOneOf(originalResult.Extension, tspath.SupportedTSExtensionsWithJsonFlat)) {
@@ -248,9 +286,10 @@ func (r *Resolver) tryResolveFromTypingsLocation(moduleName string, containingDi
 		r.compilerOptions,
 		nil, // redirectedReference,
 		r,
+		tracer,
 	)
 	if r.traceEnabled() {
-		r.host.Trace(diagnostics.Auto_discovery_for_typings_is_enabled_in_project_0_Running_extra_resolution_pass_for_module_1_using_cache_location_2.Format(r.projectName, moduleName, r.typingsLocation))
+		tracer.write(diagnostics.Auto_discovery_for_typings_is_enabled_in_project_0_Running_extra_resolution_pass_for_module_1_using_cache_location_2.Format(r.projectName, moduleName, r.typingsLocation))
 	}
 	globalResolved := state.loadModuleFromImmediateNodeModulesDirectory(extensionsDeclaration, r.typingsLocation, false)
 	if globalResolved == nil {
@@ -265,24 +304,28 @@ func (r *Resolver) tryResolveFromTypingsLocation(moduleName string, containingDi
 
 func (r *Resolver) resolveConfig(moduleName string, containingFile string) *ResolvedModule {
 	containingDirectory := tspath.GetDirectoryPath(containingFile)
-	state := newResolutionState(moduleName, containingDirectory, false /*isTypeReferenceDirective*/, core.ModuleKindCommonJS, r.compilerOptions, nil, r)
+	state := newResolutionState(moduleName, containingDirectory, false /*isTypeReferenceDirective*/, core.ModuleKindCommonJS, r.compilerOptions, nil, r, r.newTracer())
 	state.isConfigLookup = true
 	state.extensions = extensionsJson
-	return state.resolveNodeLike()
+	result := state.resolveNodeLike()
+	for _, msg := range state.tracer.getTraces() {
+		r.host.Trace(msg)
+	}
+	return result
 }
 
-func (r *Resolver) traceTypeReferenceDirectiveResult(typeReferenceDirectiveName string, result *ResolvedTypeReferenceDirective) {
+func (r *Resolver) traceTypeReferenceDirectiveResult(tracer *tracer, typeReferenceDirectiveName string, result *ResolvedTypeReferenceDirective) {
 	if !result.IsResolved() {
-		r.host.Trace(diagnostics.Type_reference_directive_0_was_not_resolved.Format(typeReferenceDirectiveName))
+		tracer.write(diagnostics.Type_reference_directive_0_was_not_resolved.Format(typeReferenceDirectiveName))
 	} else if result.PackageId.Name != "" {
-		r.host.Trace(diagnostics.Type_reference_directive_0_was_successfully_resolved_to_1_with_Package_ID_2_primary_Colon_3.Format(
+		tracer.write(diagnostics.Type_reference_directive_0_was_successfully_resolved_to_1_with_Package_ID_2_primary_Colon_3.Format(
 			typeReferenceDirectiveName,
 			result.ResolvedFileName,
 			result.PackageId.String(),
 			result.Primary,
 		))
 	} else {
-		r.host.Trace(diagnostics.Type_reference_directive_0_was_successfully_resolved_to_1_primary_Colon_2.Format(
+		tracer.write(diagnostics.Type_reference_directive_0_was_successfully_resolved_to_1_primary_Colon_2.Format(
 			typeReferenceDirectiveName,
 			result.ResolvedFileName,
 			result.Primary,
@@ -294,13 +337,13 @@ func (r *resolutionState) resolveTypeReferenceDirective(typeRoots []string, from
 	// Primary lookup
 	if len(typeRoots) > 0 {
 		if r.resolver.traceEnabled() {
-			r.resolver.host.Trace(diagnostics.Resolving_with_primary_search_path_0.Format(strings.Join(typeRoots, ", ")))
+			r.tracer.write(diagnostics.Resolving_with_primary_search_path_0.Format(strings.Join(typeRoots, ", ")))
 		}
 		for _, typeRoot := range typeRoots {
 			candidate := r.getCandidateFromTypeRoot(typeRoot)
 			directoryExists := r.resolver.host.FS().DirectoryExists(candidate)
 			if !directoryExists && r.resolver.traceEnabled() {
-				r.resolver.host.Trace(diagnostics.Directory_0_does_not_exist_skipping_all_lookups_in_it.Format(typeRoot))
+				r.tracer.write(diagnostics.Directory_0_does_not_exist_skipping_all_lookups_in_it.Format(typeRoot))
 			}
 			if fromConfig {
 				// Custom typeRoots resolve as file or directory just like we do modules
@@ -317,14 +360,14 @@ func (r *resolutionState) resolveTypeReferenceDirective(typeRoots []string, from
 			}
 		}
 	} else if r.resolver.traceEnabled() {
-		r.resolver.host.Trace(diagnostics.Root_directory_cannot_be_determined_skipping_primary_search_paths.Format())
+		r.tracer.write(diagnostics.Root_directory_cannot_be_determined_skipping_primary_search_paths.Format())
 	}
 
 	// Secondary lookup
 	var resolved *resolved
 	if !fromConfig || !fromInferredTypesContainingFile {
 		if r.resolver.traceEnabled() {
-			r.resolver.host.Trace(diagnostics.Looking_up_in_node_modules_folder_initial_location_0.Format(r.containingDirectory))
+			r.tracer.write(diagnostics.Looking_up_in_node_modules_folder_initial_location_0.Format(r.containingDirectory))
 		}
 		if !tspath.IsExternalModuleNameRelative(r.name) {
 			resolved = r.loadModuleFromNearestNodeModulesDirectory(false /*typesScopeOnly*/)
@@ -333,7 +376,7 @@ func (r *resolutionState) resolveTypeReferenceDirective(typeRoots []string, from
 			resolved = r.nodeLoadModuleByRelativeName(extensionsDeclaration, candidate, false /*onlyRecordFailures*/, true /*considerPackageJson*/)
 		}
 	} else if r.resolver.traceEnabled() {
-		r.resolver.host.Trace(diagnostics.Resolving_type_reference_directive_for_program_that_specifies_custom_typeRoots_skipping_lookup_in_node_modules_folder.Format())
+		r.tracer.write(diagnostics.Resolving_type_reference_directive_for_program_that_specifies_custom_typeRoots_skipping_lookup_in_node_modules_folder.Format())
 	}
 	return r.createResolvedTypeReferenceDirective(resolved, false /*primary*/)
 }
@@ -349,7 +392,7 @@ func (r *resolutionState) getCandidateFromTypeRoot(typeRoot string) string {
 func (r *resolutionState) mangleScopedPackageName(name string) string {
 	mangled := MangleScopedPackageName(name)
 	if r.resolver.traceEnabled() && mangled != name {
-		r.resolver.host.Trace(diagnostics.Scoped_package_detected_looking_in_0.Format(mangled))
+		r.tracer.write(diagnostics.Scoped_package_detected_looking_in_0.Format(mangled))
 	}
 	return mangled
 }
@@ -372,9 +415,9 @@ func (r *resolutionState) resolveNodeLike() *ResolvedModule {
 	if r.resolver.traceEnabled() {
 		conditions := strings.Join(core.Map(r.conditions, func(c string) string { return `'` + c + `'` }), ", ")
 		if r.esmMode {
-			r.resolver.host.Trace(diagnostics.Resolving_in_0_mode_with_conditions_1.Format("ESM", conditions))
+			r.tracer.write(diagnostics.Resolving_in_0_mode_with_conditions_1.Format("ESM", conditions))
 		} else {
-			r.resolver.host.Trace(diagnostics.Resolving_in_0_mode_with_conditions_1.Format("CJS", conditions))
+			r.tracer.write(diagnostics.Resolving_in_0_mode_with_conditions_1.Format("CJS", conditions))
 		}
 	}
 	result := r.resolveNodeLikeWorker()
@@ -388,7 +431,7 @@ func (r *resolutionState) resolveNodeLike() *ResolvedModule {
 		!extensionIsOk(extensionsTypeScript|extensionsDeclaration, result.Extension) &&
 		slices.Contains(r.conditions, "import") {
 		if r.resolver.traceEnabled() {
-			r.resolver.host.Trace(diagnostics.Resolution_of_non_relative_name_failed_trying_with_modern_Node_resolution_features_disabled_to_see_if_npm_library_needs_configuration_update.Format())
+			r.tracer.write(diagnostics.Resolution_of_non_relative_name_failed_trying_with_modern_Node_resolution_features_disabled_to_see_if_npm_library_needs_configuration_update.Format())
 		}
 		r.features = r.features & ^NodeResolutionFeaturesExports
 		r.extensions = r.extensions & (extensionsTypeScript | extensionsDeclaration)
@@ -419,12 +462,12 @@ func (r *resolutionState) resolveNodeLikeWorker() *ResolvedModule {
 		}
 		if strings.Contains(r.name, ":") {
 			if r.resolver.traceEnabled() {
-				r.resolver.host.Trace(diagnostics.Skipping_module_0_that_looks_like_an_absolute_URI_target_file_types_Colon_1.Format(r.name, r.extensions.String()))
+				r.tracer.write(diagnostics.Skipping_module_0_that_looks_like_an_absolute_URI_target_file_types_Colon_1.Format(r.name, r.extensions.String()))
 			}
 			return r.createResolvedModule(nil, false)
 		}
 		if r.resolver.traceEnabled() {
-			r.resolver.host.Trace(diagnostics.Loading_module_0_from_node_modules_folder_target_file_types_Colon_1.Format(r.name, r.extensions.String()))
+			r.tracer.write(diagnostics.Loading_module_0_from_node_modules_folder_target_file_types_Colon_1.Format(r.name, r.extensions.String()))
 		}
 		if resolved := r.loadModuleFromNearestNodeModulesDirectory(false /*typesScopeOnly*/); !resolved.shouldContinueSearching() {
 			return r.createResolvedModuleHandlingSymlink(resolved)
@@ -496,7 +539,7 @@ func (r *resolutionState) loadModuleFromSelfNameReference() *resolved {
 func (r *resolutionState) loadModuleFromImports() *resolved {
 	if r.name == "#" || strings.HasPrefix(r.name, "#/") {
 		if r.resolver.traceEnabled() {
-			r.resolver.host.Trace(diagnostics.Invalid_import_specifier_0_has_no_possible_resolutions.Format(r.name))
+			r.tracer.write(diagnostics.Invalid_import_specifier_0_has_no_possible_resolutions.Format(r.name))
 		}
 		return continueSearching()
 	}
@@ -504,7 +547,7 @@ func (r *resolutionState) loadModuleFromImports() *resolved {
 	scope := r.getPackageScopeForPath(directoryPath)
 	if !scope.Exists() {
 		if r.resolver.traceEnabled() {
-			r.resolver.host.Trace(diagnostics.Directory_0_has_no_containing_package_json_scope_Imports_will_not_resolve.Format(directoryPath))
+			r.tracer.write(diagnostics.Directory_0_has_no_containing_package_json_scope_Imports_will_not_resolve.Format(directoryPath))
 		}
 		return continueSearching()
 	}
@@ -512,7 +555,7 @@ func (r *resolutionState) loadModuleFromImports() *resolved {
 		// !!! Old compiler only checks for undefined, but then assumes `imports` is an object if present.
 		// Maybe should have a new diagnostic for imports of an invalid type. Also, array should be handled?
 		if r.resolver.traceEnabled() {
-			r.resolver.host.Trace(diagnostics.X_package_json_scope_0_has_no_imports_defined.Format(scope.PackageDirectory))
+			r.tracer.write(diagnostics.X_package_json_scope_0_has_no_imports_defined.Format(scope.PackageDirectory))
 		}
 		return continueSearching()
 	}
@@ -522,7 +565,7 @@ func (r *resolutionState) loadModuleFromImports() *resolved {
 	}
 
 	if r.resolver.traceEnabled() {
-		r.resolver.host.Trace(diagnostics.Import_specifier_0_does_not_exist_in_package_json_scope_at_path_1.Format(r.name, scope.PackageDirectory))
+		r.tracer.write(diagnostics.Import_specifier_0_does_not_exist_in_package_json_scope_at_path_1.Format(r.name, scope.PackageDirectory))
 	}
 	return continueSearching()
 }
@@ -555,7 +598,7 @@ func (r *resolutionState) loadModuleFromExports(packageInfo *packagejson.InfoCac
 	}
 
 	if r.resolver.traceEnabled() {
-		r.resolver.host.Trace(diagnostics.Export_specifier_0_does_not_exist_in_package_json_scope_at_path_1.Format(subpath, packageInfo.PackageDirectory))
+		r.tracer.write(diagnostics.Export_specifier_0_does_not_exist_in_package_json_scope_at_path_1.Format(subpath, packageInfo.PackageDirectory))
 	}
 	return continueSearching()
 }
@@ -607,7 +650,7 @@ func (r *resolutionState) loadModuleFromTargetExportOrImport(extensions extensio
 		targetString, _ := target.Value.(string)
 		if !isPattern && len(subpath) > 0 && !strings.HasSuffix(targetString, "/") {
 			if r.resolver.traceEnabled() {
-				r.resolver.host.Trace(diagnostics.X_package_json_scope_0_has_invalid_type_for_target_of_specifier_1.Format(scope.PackageDirectory, moduleName))
+				r.tracer.write(diagnostics.X_package_json_scope_0_has_invalid_type_for_target_of_specifier_1.Format(scope.PackageDirectory, moduleName))
 			}
 			return continueSearching()
 		}
@@ -618,8 +661,8 @@ func (r *resolutionState) loadModuleFromTargetExportOrImport(extensions extensio
 					combinedLookup = strings.ReplaceAll(targetString, "*", subpath)
 				}
 				if r.resolver.traceEnabled() {
-					r.resolver.host.Trace(diagnostics.Using_0_subpath_1_with_target_2.Format("imports", key, combinedLookup))
-					r.resolver.host.Trace(diagnostics.Resolving_module_0_from_1.Format(combinedLookup, scope.PackageDirectory+"/"))
+					r.tracer.write(diagnostics.Using_0_subpath_1_with_target_2.Format("imports", key, combinedLookup))
+					r.tracer.write(diagnostics.Resolving_module_0_from_1.Format(combinedLookup, scope.PackageDirectory+"/"))
 				}
 				name, containingDirectory := r.name, r.containingDirectory
 				r.name, r.containingDirectory = combinedLookup, scope.PackageDirectory+"/"
@@ -638,7 +681,7 @@ func (r *resolutionState) loadModuleFromTargetExportOrImport(extensions extensio
 				return continueSearching()
 			}
 			if r.resolver.traceEnabled() {
-				r.resolver.host.Trace(diagnostics.X_package_json_scope_0_has_invalid_type_for_target_of_specifier_1.Format(scope.PackageDirectory, moduleName))
+				r.tracer.write(diagnostics.X_package_json_scope_0_has_invalid_type_for_target_of_specifier_1.Format(scope.PackageDirectory, moduleName))
 			}
 			return continueSearching()
 		}
@@ -651,7 +694,7 @@ func (r *resolutionState) loadModuleFromTargetExportOrImport(extensions extensio
 		partsAfterFirst := parts[1:]
 		if slices.Contains(partsAfterFirst, "..") || slices.Contains(partsAfterFirst, ".") || slices.Contains(partsAfterFirst, "node_modules") {
 			if r.resolver.traceEnabled() {
-				r.resolver.host.Trace(diagnostics.X_package_json_scope_0_has_invalid_type_for_target_of_specifier_1.Format(scope.PackageDirectory, moduleName))
+				r.tracer.write(diagnostics.X_package_json_scope_0_has_invalid_type_for_target_of_specifier_1.Format(scope.PackageDirectory, moduleName))
 			}
 			return continueSearching()
 		}
@@ -661,7 +704,7 @@ func (r *resolutionState) loadModuleFromTargetExportOrImport(extensions extensio
 		subpathParts := tspath.GetPathComponents(subpath, "")
 		if slices.Contains(subpathParts, "..") || slices.Contains(subpathParts, ".") || slices.Contains(subpathParts, "node_modules") {
 			if r.resolver.traceEnabled() {
-				r.resolver.host.Trace(diagnostics.X_package_json_scope_0_has_invalid_type_for_target_of_specifier_1.Format(scope.PackageDirectory, moduleName))
+				r.tracer.write(diagnostics.X_package_json_scope_0_has_invalid_type_for_target_of_specifier_1.Format(scope.PackageDirectory, moduleName))
 			}
 			return continueSearching()
 		}
@@ -673,7 +716,7 @@ func (r *resolutionState) loadModuleFromTargetExportOrImport(extensions extensio
 			} else {
 				messageTarget = targetString + subpath
 			}
-			r.resolver.host.Trace(diagnostics.Using_0_subpath_1_with_target_2.Format(core.IfElse(isImports, "imports", "exports"), key, messageTarget))
+			r.tracer.write(diagnostics.Using_0_subpath_1_with_target_2.Format(core.IfElse(isImports, "imports", "exports"), key, messageTarget))
 		}
 		var finalPath string
 		if isPattern {
@@ -692,39 +735,39 @@ func (r *resolutionState) loadModuleFromTargetExportOrImport(extensions extensio
 
 	case packagejson.JSONValueTypeObject:
 		if r.resolver.traceEnabled() {
-			r.resolver.host.Trace(diagnostics.Entering_conditional_exports.Format())
+			r.tracer.write(diagnostics.Entering_conditional_exports.Format())
 		}
 		for condition := range target.AsObject().Keys() {
 			if r.conditionMatches(condition) {
 				if r.resolver.traceEnabled() {
-					r.resolver.host.Trace(diagnostics.Matched_0_condition_1.Format(core.IfElse(isImports, "imports", "exports"), condition))
+					r.tracer.write(diagnostics.Matched_0_condition_1.Format(core.IfElse(isImports, "imports", "exports"), condition))
 				}
 				subTarget, _ := target.AsObject().Get(condition)
 				if result := r.loadModuleFromTargetExportOrImport(extensions, moduleName, scope, isImports, subTarget, subpath, isPattern, key); !result.shouldContinueSearching() {
 					if r.resolver.traceEnabled() {
-						r.resolver.host.Trace(diagnostics.Resolved_under_condition_0.Format(condition))
+						r.tracer.write(diagnostics.Resolved_under_condition_0.Format(condition))
 					}
 					if r.resolver.traceEnabled() {
-						r.resolver.host.Trace(diagnostics.Exiting_conditional_exports.Format())
+						r.tracer.write(diagnostics.Exiting_conditional_exports.Format())
 					}
 					return result
 				} else if r.resolver.traceEnabled() {
-					r.resolver.host.Trace(diagnostics.Failed_to_resolve_under_condition_0.Format(condition))
+					r.tracer.write(diagnostics.Failed_to_resolve_under_condition_0.Format(condition))
 				}
 			} else {
 				if r.resolver.traceEnabled() {
-					r.resolver.host.Trace(diagnostics.Saw_non_matching_condition_0.Format(condition))
+					r.tracer.write(diagnostics.Saw_non_matching_condition_0.Format(condition))
 				}
 			}
 		}
 		if r.resolver.traceEnabled() {
-			r.resolver.host.Trace(diagnostics.Exiting_conditional_exports.Format())
+			r.tracer.write(diagnostics.Exiting_conditional_exports.Format())
 		}
 		return continueSearching()
 	case packagejson.JSONValueTypeArray:
 		if len(target.AsArray()) == 0 {
 			if r.resolver.traceEnabled() {
-				r.resolver.host.Trace(diagnostics.X_package_json_scope_0_has_invalid_type_for_target_of_specifier_1.Format(scope.PackageDirectory, moduleName))
+				r.tracer.write(diagnostics.X_package_json_scope_0_has_invalid_type_for_target_of_specifier_1.Format(scope.PackageDirectory, moduleName))
 			}
 			return continueSearching()
 		}
@@ -736,13 +779,13 @@ func (r *resolutionState) loadModuleFromTargetExportOrImport(extensions extensio
 
 	case packagejson.JSONValueTypeNull:
 		if r.resolver.traceEnabled() {
-			r.resolver.host.Trace(diagnostics.X_package_json_scope_0_explicitly_maps_specifier_1_to_null.Format(scope.PackageDirectory, moduleName))
+			r.tracer.write(diagnostics.X_package_json_scope_0_explicitly_maps_specifier_1_to_null.Format(scope.PackageDirectory, moduleName))
 		}
 		return continueSearching()
 	}
 
 	if r.resolver.traceEnabled() {
-		r.resolver.host.Trace(diagnostics.X_package_json_scope_0_has_invalid_type_for_target_of_specifier_1.Format(scope.PackageDirectory, moduleName))
+		r.tracer.write(diagnostics.X_package_json_scope_0_has_invalid_type_for_target_of_specifier_1.Format(scope.PackageDirectory, moduleName))
 	}
 	return continueSearching()
 }
@@ -768,7 +811,7 @@ func (r *resolutionState) loadModuleFromNearestNodeModulesDirectory(typesScopeOn
 	// (1)
 	if priorityExtensions != 0 {
 		if r.resolver.traceEnabled() {
-			r.resolver.host.Trace(diagnostics.Searching_all_ancestor_node_modules_directories_for_preferred_extensions_Colon_0.Format(priorityExtensions.String()))
+			r.tracer.write(diagnostics.Searching_all_ancestor_node_modules_directories_for_preferred_extensions_Colon_0.Format(priorityExtensions.String()))
 		}
 		if result := r.loadModuleFromNearestNodeModulesDirectoryWorker(priorityExtensions, mode, typesScopeOnly); !result.shouldContinueSearching() {
 			return result
@@ -777,7 +820,7 @@ func (r *resolutionState) loadModuleFromNearestNodeModulesDirectory(typesScopeOn
 	// (2)
 	if secondaryExtensions != 0 && !typesScopeOnly {
 		if r.resolver.traceEnabled() {
-			r.resolver.host.Trace(diagnostics.Searching_all_ancestor_node_modules_directories_for_fallback_extensions_Colon_0.Format(secondaryExtensions.String()))
+			r.tracer.write(diagnostics.Searching_all_ancestor_node_modules_directories_for_fallback_extensions_Colon_0.Format(secondaryExtensions.String()))
 		}
 		return r.loadModuleFromNearestNodeModulesDirectoryWorker(secondaryExtensions, mode, typesScopeOnly)
 	}
@@ -803,7 +846,7 @@ func (r *resolutionState) loadModuleFromImmediateNodeModulesDirectory(extensions
 	nodeModulesFolder := tspath.CombinePaths(directory, "node_modules")
 	nodeModulesFolderExists := r.resolver.host.FS().DirectoryExists(nodeModulesFolder)
 	if !nodeModulesFolderExists && r.resolver.traceEnabled() {
-		r.resolver.host.Trace(diagnostics.Directory_0_does_not_exist_skipping_all_lookups_in_it.Format(nodeModulesFolder))
+		r.tracer.write(diagnostics.Directory_0_does_not_exist_skipping_all_lookups_in_it.Format(nodeModulesFolder))
 	}
 
 	if !typesScopeOnly {
@@ -816,7 +859,7 @@ func (r *resolutionState) loadModuleFromImmediateNodeModulesDirectory(extensions
 		nodeModulesAtTypes := tspath.CombinePaths(nodeModulesFolder, "@types")
 		nodeModulesAtTypesExists := nodeModulesFolderExists && r.resolver.host.FS().DirectoryExists(nodeModulesAtTypes)
 		if !nodeModulesAtTypesExists && r.resolver.traceEnabled() {
-			r.resolver.host.Trace(diagnostics.Directory_0_does_not_exist_skipping_all_lookups_in_it.Format(nodeModulesAtTypes))
+			r.tracer.write(diagnostics.Directory_0_does_not_exist_skipping_all_lookups_in_it.Format(nodeModulesAtTypes))
 		}
 		return r.loadModuleFromSpecificNodeModulesDirectory(extensionsDeclaration, r.mangleScopedPackageName(r.name), nodeModulesAtTypes, nodeModulesAtTypesExists)
 	}
@@ -893,7 +936,7 @@ func (r *resolutionState) loadModuleFromSpecificNodeModulesDirectory(ext extensi
 			versionPaths := packageInfo.Contents.GetVersionPaths(r.getTraceFunc())
 			if versionPaths.Exists() {
 				if r.resolver.traceEnabled() {
-					r.resolver.host.Trace(diagnostics.X_package_json_has_a_typesVersions_entry_0_that_matches_compiler_version_1_looking_for_a_pattern_to_match_module_name_2.Format(versionPaths.Version, core.Version(), rest))
+					r.tracer.write(diagnostics.X_package_json_has_a_typesVersions_entry_0_that_matches_compiler_version_1_looking_for_a_pattern_to_match_module_name_2.Format(versionPaths.Version, core.Version(), rest))
 				}
 				packageDirectoryExists := nodeModulesDirectoryExists && r.resolver.host.FS().DirectoryExists(packageDirectory)
 				pathPatterns := TryParsePatterns(versionPaths.GetPaths())
@@ -989,7 +1032,7 @@ func (r *resolutionState) tryLoadModuleUsingOptionalResolutionSettings() *resolv
 func (r *resolutionState) tryLoadModuleUsingPathsIfEligible() *resolved {
 	if r.compilerOptions.Paths.Size() > 0 && !tspath.PathIsRelative(r.name) {
 		if r.resolver.traceEnabled() {
-			r.resolver.host.Trace(diagnostics.X_paths_option_is_specified_looking_for_a_pattern_to_match_module_name_0.Format(r.name))
+			r.tracer.write(diagnostics.X_paths_option_is_specified_looking_for_a_pattern_to_match_module_name_0.Format(r.name))
 		}
 	} else {
 		return continueSearching()
@@ -1013,13 +1056,13 @@ func (r *resolutionState) tryLoadModuleUsingPaths(extensions extensions, moduleN
 	if matchedPattern := MatchPatternOrExact(pathPatterns, moduleName); matchedPattern.IsValid() {
 		matchedStar := matchedPattern.MatchedText(moduleName)
 		if r.resolver.traceEnabled() {
-			r.resolver.host.Trace(diagnostics.Module_name_0_matched_pattern_1.Format(moduleName, matchedPattern.Text))
+			r.tracer.write(diagnostics.Module_name_0_matched_pattern_1.Format(moduleName, matchedPattern.Text))
 		}
 		for _, subst := range paths.GetOrZero(matchedPattern.Text) {
 			path := strings.Replace(subst, "*", matchedStar, 1)
 			candidate := tspath.NormalizePath(tspath.CombinePaths(containingDirectory, path))
 			if r.resolver.traceEnabled() {
-				r.resolver.host.Trace(diagnostics.Trying_substitution_0_candidate_module_location_Colon_1.Format(subst, path))
+				r.tracer.write(diagnostics.Trying_substitution_0_candidate_module_location_Colon_1.Format(subst, path))
 			}
 			// A path mapping may have an extension
 			if extension := tspath.TryGetExtensionFromPath(subst); extension != "" {
@@ -1040,14 +1083,14 @@ func (r *resolutionState) tryLoadModuleUsingPaths(extensions extensions, moduleN
 
 func (r *resolutionState) nodeLoadModuleByRelativeName(extensions extensions, candidate string, onlyRecordFailures bool, considerPackageJson bool) *resolved {
 	if r.resolver.traceEnabled() {
-		r.resolver.host.Trace(diagnostics.Loading_module_as_file_Slash_folder_candidate_module_location_0_target_file_types_Colon_1.Format(candidate, extensions.String()))
+		r.tracer.write(diagnostics.Loading_module_as_file_Slash_folder_candidate_module_location_0_target_file_types_Colon_1.Format(candidate, extensions.String()))
 	}
 	if !tspath.HasTrailingDirectorySeparator(candidate) {
 		if !onlyRecordFailures {
 			parentOfCandidate := tspath.GetDirectoryPath(candidate)
 			if !r.resolver.host.FS().DirectoryExists(parentOfCandidate) {
 				if r.resolver.traceEnabled() {
-					r.resolver.host.Trace(diagnostics.Directory_0_does_not_exist_skipping_all_lookups_in_it.Format(parentOfCandidate))
+					r.tracer.write(diagnostics.Directory_0_does_not_exist_skipping_all_lookups_in_it.Format(parentOfCandidate))
 				}
 				onlyRecordFailures = true
 			}
@@ -1066,7 +1109,7 @@ func (r *resolutionState) nodeLoadModuleByRelativeName(extensions extensions, ca
 		candidateExists := r.resolver.host.FS().DirectoryExists(candidate)
 		if !candidateExists {
 			if r.resolver.traceEnabled() {
-				r.resolver.host.Trace(diagnostics.Directory_0_does_not_exist_skipping_all_lookups_in_it.Format(candidate))
+				r.tracer.write(diagnostics.Directory_0_does_not_exist_skipping_all_lookups_in_it.Format(candidate))
 			}
 			onlyRecordFailures = true
 		}
@@ -1108,7 +1151,7 @@ func (r *resolutionState) loadModuleFromFileNoImplicitExtensions(extensions exte
 
 	extension := candidate[len(extensionless):]
 	if r.resolver.traceEnabled() {
-		r.resolver.host.Trace(diagnostics.File_name_0_has_a_1_extension_stripping_it.Format(candidate, extension))
+		r.tracer.write(diagnostics.File_name_0_has_a_1_extension_stripping_it.Format(candidate, extension))
 	}
 	return r.tryAddingExtensions(extensionless, extensions, extension, onlyRecordFailures)
 }
@@ -1262,11 +1305,11 @@ func (r *resolutionState) tryFileLookup(fileName string, onlyRecordFailures bool
 	if !onlyRecordFailures {
 		if r.resolver.host.FS().FileExists(fileName) {
 			if r.resolver.traceEnabled() {
-				r.resolver.host.Trace(diagnostics.File_0_exists_use_it_as_a_name_resolution_result.Format(fileName))
+				r.tracer.write(diagnostics.File_0_exists_use_it_as_a_name_resolution_result.Format(fileName))
 			}
 			return true
 		} else if r.resolver.traceEnabled() {
-			r.resolver.host.Trace(diagnostics.File_0_does_not_exist.Format(fileName))
+			r.tracer.write(diagnostics.File_0_does_not_exist.Format(fileName))
 		}
 	}
 	r.failedLookupLocations = append(r.failedLookupLocations, fileName)
@@ -1338,7 +1381,7 @@ func (r *resolutionState) loadNodeModuleFromDirectoryWorker(ext extensions, cand
 			moduleName = tspath.GetRelativePathFromDirectory(candidate, indexPath, tspath.ComparePathsOptions{})
 		}
 		if r.resolver.traceEnabled() {
-			r.resolver.host.Trace(diagnostics.X_package_json_has_a_typesVersions_entry_0_that_matches_compiler_version_1_looking_for_a_pattern_to_match_module_name_2.Format(versionPaths.Version, core.Version(), moduleName))
+			r.tracer.write(diagnostics.X_package_json_has_a_typesVersions_entry_0_that_matches_compiler_version_1_looking_for_a_pattern_to_match_module_name_2.Format(versionPaths.Version, core.Version(), moduleName))
 		}
 		pathPatterns := TryParsePatterns(versionPaths.GetPaths())
 		if result := r.tryLoadModuleUsingPaths(ext, moduleName, candidate, versionPaths.GetPaths(), pathPatterns, loader, onlyRecordFailuresForPackageFile); !result.shouldContinueSearching() {
@@ -1426,7 +1469,7 @@ func (r *resolutionState) getPackageJsonInfo(packageDirectory string, onlyRecord
 	if existing := r.resolver.packageJsonInfoCache.Get(packageJsonPath); existing != nil {
 		if existing.Contents != nil {
 			if r.resolver.traceEnabled() {
-				r.resolver.host.Trace(diagnostics.File_0_exists_according_to_earlier_cached_lookups.Format(packageJsonPath))
+				r.tracer.write(diagnostics.File_0_exists_according_to_earlier_cached_lookups.Format(packageJsonPath))
 			}
 			r.affectingLocations = append(r.affectingLocations, packageJsonPath)
 			if existing.PackageDirectory == packageDirectory {
@@ -1440,7 +1483,7 @@ func (r *resolutionState) getPackageJsonInfo(packageDirectory string, onlyRecord
 			}
 		} else {
 			if existing.DirectoryExists && r.resolver.traceEnabled() {
-				r.resolver.host.Trace(diagnostics.File_0_does_not_exist_according_to_earlier_cached_lookups.Format(packageJsonPath))
+				r.tracer.write(diagnostics.File_0_does_not_exist_according_to_earlier_cached_lookups.Format(packageJsonPath))
 			}
 			r.failedLookupLocations = append(r.failedLookupLocations, packageJsonPath)
 			return nil
@@ -1453,7 +1496,7 @@ func (r *resolutionState) getPackageJsonInfo(packageDirectory string, onlyRecord
 		contents, _ := r.resolver.host.FS().ReadFile(packageJsonPath)
 		packageJsonContent, _ := packagejson.Parse([]byte(contents))
 		if r.resolver.traceEnabled() {
-			r.resolver.host.Trace(diagnostics.Found_package_json_at_0.Format(packageJsonPath))
+			r.tracer.write(diagnostics.Found_package_json_at_0.Format(packageJsonPath))
 		}
 		result := &packagejson.InfoCacheEntry{
 			PackageDirectory: packageDirectory,
@@ -1469,7 +1512,7 @@ func (r *resolutionState) getPackageJsonInfo(packageDirectory string, onlyRecord
 		return result
 	} else {
 		if directoryExists && r.resolver.traceEnabled() {
-			r.resolver.host.Trace(diagnostics.File_0_does_not_exist.Format(packageJsonPath))
+			r.tracer.write(diagnostics.File_0_does_not_exist.Format(packageJsonPath))
 		}
 		if !r.resolver.packageJsonInfoCache.IsReadonly {
 			r.resolver.packageJsonInfoCache.Set(packageJsonPath, &packagejson.InfoCacheEntry{
@@ -1510,7 +1553,7 @@ func (r *resolutionState) readPackageJsonPeerDependencies(packageJsonInfo *packa
 		return ""
 	}
 	if r.resolver.traceEnabled() {
-		r.resolver.host.Trace(diagnostics.X_package_json_has_a_peerDependencies_field.Message())
+		r.tracer.write(diagnostics.X_package_json_has_a_peerDependencies_field.Message())
 	}
 	packageDirectory := r.realPath(packageJsonInfo.PackageDirectory)
 	nodeModules := packageDirectory[:strings.LastIndex(packageDirectory, "/node_modules")+len("/node_modules")] + "/"
@@ -1524,10 +1567,10 @@ func (r *resolutionState) readPackageJsonPeerDependencies(packageJsonInfo *packa
 			builder.WriteString("@")
 			builder.WriteString(version)
 			if r.resolver.traceEnabled() {
-				r.resolver.host.Trace(diagnostics.Found_peerDependency_0_with_1_version.Format(name, version))
+				r.tracer.write(diagnostics.Found_peerDependency_0_with_1_version.Format(name, version))
 			}
 		} else if r.resolver.traceEnabled() {
-			r.resolver.host.Trace(diagnostics.Failed_to_find_peerDependency_0.Format(name))
+			r.tracer.write(diagnostics.Failed_to_find_peerDependency_0.Format(name))
 		}
 	}
 	return builder.String()
@@ -1536,7 +1579,7 @@ func (r *resolutionState) readPackageJsonPeerDependencies(packageJsonInfo *packa
 func (r *resolutionState) realPath(path string) string {
 	rp := tspath.NormalizePath(r.resolver.host.FS().Realpath(path))
 	if r.resolver.traceEnabled() {
-		r.resolver.host.Trace(diagnostics.Resolving_real_path_for_0_result_1.Format(path, rp))
+		r.tracer.write(diagnostics.Resolving_real_path_for_0_result_1.Format(path, rp))
 	}
 	return rp
 }
@@ -1547,11 +1590,11 @@ func (r *resolutionState) validatePackageJSONField(fieldName string, field packa
 			return true
 		}
 		if r.resolver.traceEnabled() {
-			r.resolver.host.Trace(diagnostics.Expected_type_of_0_field_in_package_json_to_be_1_got_2.Format(fieldName, field.ExpectedJSONType(), field.ActualJSONType()))
+			r.tracer.write(diagnostics.Expected_type_of_0_field_in_package_json_to_be_1_got_2.Format(fieldName, field.ExpectedJSONType(), field.ActualJSONType()))
 		}
 	}
 	if r.resolver.traceEnabled() {
-		r.resolver.host.Trace(diagnostics.X_package_json_does_not_have_a_0_field.Format(fieldName))
+		r.tracer.write(diagnostics.X_package_json_does_not_have_a_0_field.Format(fieldName))
 	}
 	return false
 }
@@ -1562,13 +1605,13 @@ func (r *resolutionState) getPackageJSONPathField(fieldName string, field *packa
 	}
 	if field.Value == "" {
 		if r.resolver.traceEnabled() {
-			r.resolver.host.Trace(diagnostics.X_package_json_had_a_falsy_0_field.Format(fieldName))
+			r.tracer.write(diagnostics.X_package_json_had_a_falsy_0_field.Format(fieldName))
 		}
 		return "", false
 	}
 	path := tspath.NormalizePath(tspath.CombinePaths(directory, field.Value))
 	if r.resolver.traceEnabled() {
-		r.resolver.host.Trace(diagnostics.X_package_json_has_0_field_1_that_references_2.Format(fieldName, field.Value, path))
+		r.tracer.write(diagnostics.X_package_json_has_0_field_1_that_references_2.Format(fieldName, field.Value, path))
 	}
 	return path, true
 }
@@ -1591,7 +1634,7 @@ func (r *resolutionState) conditionMatches(condition string) bool {
 
 func (r *resolutionState) getTraceFunc() func(string) {
 	if r.resolver.traceEnabled() {
-		return r.resolver.host.Trace
+		return r.tracer.write
 	}
 	return nil
 }
@@ -178,6 +178,14 @@ func (v *vfsModuleResolutionHost) Trace(msg string) {
 	v.traces = append(v.traces, msg)
 }
 
+// traceAll records the trace messages of a single resolution, which are kept together even when
+// resolutions run concurrently.
+func (v *vfsModuleResolutionHost) traceAll(msgs []string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.traces = append(v.traces, msgs...)
+}
+
 type functionCall struct {
 	call        string
 	args        rawArgs
@@ -244,7 +252,7 @@ func (r *RedirectRef) CompilerOptions() *core.CompilerOptions {
 
 var _ module.ResolvedProjectReference = (*RedirectRef)(nil)
 
-func doCall(t *testing.T, resolver *module.Resolver, call functionCall, skipLocations bool) {
+func doCall(t *testing.T, host *vfsModuleResolutionHost, resolver *module.Resolver, call functionCall, skipLocations bool) {
 	switch call.call {
 	case "resolveModuleName", "resolveTypeReferenceDirective":
 		var redirectedReference module.ResolvedProjectReference
@@ -257,7 +265,8 @@ func doCall(t *testing.T, resolver *module.Resolver, call functionCall, skipLoca
 
 		errorMessageArgs := []any{call.args.Name, call.args.ContainingFile}
 		if call.call == "resolveModuleName" {
-			resolved := resolver.ResolveModuleName(call.args.Name, call.args.ContainingFile, core.ModuleKind(call.args.ResolutionMode), redirectedReference)
+			resolved, traces := resolver.ResolveModuleName(call.args.Name, call.args.ContainingFile, core.ModuleKind(call.args.ResolutionMode), redirectedReference)
+			host.traceAll(traces)
 			assert.Check(t, resolved != nil, "ResolveModuleName should not return nil", errorMessageArgs)
 			if expectedResolvedModule, ok := call.returnValue["resolvedModule"].(map[string]any); ok {
 				assert.Check(t, resolved.IsResolved(), errorMessageArgs)
@@ -269,7 +278,8 @@ func doCall(t *testing.T, resolver *module.Resolver, call functionCall, skipLoca
 				assert.Check(t, !resolved.IsResolved(), errorMessageArgs)
 			}
 		} else {
-			resolved := resolver.ResolveTypeReferenceDirective(call.args.Name, call.args.ContainingFile, core.ModuleKind(call.args.ResolutionMode), redirectedReference)
+			resolved, traces := resolver.ResolveTypeReferenceDirective(call.args.Name, call.args.ContainingFile, core.ModuleKind(call.args.ResolutionMode), redirectedReference)
+			host.traceAll(traces)
 			assert.Check(t, resolved != nil, "ResolveTypeReferenceDirective should not return nil", errorMessageArgs)
 			if expectedResolvedTypeReferenceDirective, ok := call.returnValue["resolvedTypeReferenceDirective"].(map[string]any); ok {
 				assert.Check(t, resolved.IsResolved(), errorMessageArgs)
@@ -281,7 +291,8 @@ func doCall(t *testing.T, resolver *module.Resolver, call functionCall, skipLoca
 			}
 		}
 	case "getPackageScopeForPath":
-		resolver.GetPackageScopeForPath(call.args.Directory)
+		_, traces := resolver.GetPackageScopeForPath(call.args.Directory)
+		host.traceAll(traces)
 	default:
 		t.Errorf("Unexpected call: %s", call.call)
 	}
@@ -295,7 +306,7 @@ func runTraceBaseline(t *testing.T, test traceTestCase) {
 		resolver := module.NewResolver(host, test.compilerOptions, "", "")
 
 		for _, call := range test.calls {
-			doCall(t, resolver, call, false /*skipLocations*/)
+			doCall(t, host, resolver, call, false /*skipLocations*/)
 			if t.Failed() {
 				t.FailNow()
 			}
@@ -310,7 +321,7 @@ func runTraceBaseline(t *testing.T, test traceTestCase) {
 				wg.Add(1)
 				go func() {
 					defer wg.Done()
-					doCall(t, concurrentResolver, call, true /*skipLocations*/)
+					doCall(t, concurrentHost, concurrentResolver, call, true /*skipLocations*/)
 				}()
 			}
 
@@ -152,7 +152,8 @@ func getModuleSpecifierPreferences(
 ) ModuleSpecifierPreferences {
 	excludes := prefs.AutoImportSpecifierExcludeRegexes
 	relativePreference := RelativePreferenceShortest
-	if len(oldImportSpecifier) > 0 {
+	if len(oldImportSpecifier) > 0 && (prefs.ImportModuleSpecifierPreference == ImportModuleSpecifierPreferenceNone || prefs.ImportModuleSpecifierPreference == ImportModuleSpecifierPreferenceShortest) {
+		// Keep the style of the specifier being updated unless a specific style was requested.
 		if tspath.IsExternalModuleNameRelative(oldImportSpecifier) {
 			relativePreference = RelativePreferenceRelative
 		} else {
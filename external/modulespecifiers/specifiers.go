@@ -54,6 +54,42 @@ func GetModuleSpecifiers(
 	return result
 }
 
+// UpdateModuleSpecifier computes the specifier with which importingSourceFile, which will be located at
+// importingSourceFileName, should import toFileName after either file has been moved. The style of
+// oldImportSpecifier is kept unless the preferences request a particular one. Returns "" if the
+// specifier does not need to change.
+func UpdateModuleSpecifier(
+	compilerOptions *core.CompilerOptions,
+	importingSourceFile SourceFileForSpecifierGeneration,
+	importingSourceFileName string,
+	toFileName string,
+	host ModuleSpecifierGenerationHost,
+	oldImportSpecifier string,
+	userPreferences UserPreferences,
+	options ModuleSpecifierOptions,
+) string {
+	info := getInfo(importingSourceFileName, host)
+	var specifier string
+	for _, modulePath := range getAllModulePathsWorker(info, toFileName, host) {
+		specifier = tryGetModuleNameAsNodeModule(modulePath, info, importingSourceFile, host, compilerOptions, userPreferences /*packageNameOnly*/, false, options.OverrideImportMode)
+		if len(specifier) > 0 {
+			break
+		}
+	}
+	if len(specifier) == 0 {
+		importMode := options.OverrideImportMode
+		if importMode == core.ResolutionModeNone {
+			importMode = host.GetDefaultResolutionModeForFile(importingSourceFile)
+		}
+		preferences := getModuleSpecifierPreferences(userPreferences, host, compilerOptions, importingSourceFile, oldImportSpecifier)
+		specifier = getLocalModuleSpecifier(toFileName, info, compilerOptions, host, importMode, preferences /*pathsOnly*/, false)
+	}
+	if specifier == oldImportSpecifier {
+		return ""
+	}
+	return specifier
+}
+
 func tryGetModuleNameFromAmbientModule(moduleSymbol *ast.Symbol, checker CheckerShape) string {
 	for _, decl := range moduleSymbol.Declarations {
 		if isNonGlobalAmbientModule(decl) && (!ast.IsModuleAugmentationExternal(decl) || !tspath.IsExternalModuleNameRelative(decl.Name().AsStringLiteral().Text)) {
@@ -434,7 +470,7 @@ func getLocalModuleSpecifier(
 			CurrentDirectory:          host.GetCurrentDirectory(),
 		})), allowedEndings, compilerOptions, host)
 	}
-	if len(baseUrl) == 9 && paths == nil && !compilerOptions.GetResolvePackageJsonImports() && preferences.relativePreference == RelativePreferenceRelative {
+	if len(baseUrl) == 0 && paths == nil && !compilerOptions.GetResolvePackageJsonImports() || preferences.relativePreference == RelativePreferenceRelative {
 		if pathsOnly {
 			return ""
 		}
@@ -0,0 +1,61 @@
+package modulespecifiers_test
+
+import (
+	"testing"
+
+	"github.com/pagpeter/typescript-go/external/bundled"
+	"github.com/pagpeter/typescript-go/external/compiler"
+	"github.com/pagpeter/typescript-go/external/core"
+	"github.com/pagpeter/typescript-go/external/modulespecifiers"
+	"github.com/pagpeter/typescript-go/external/tsoptions"
+	"github.com/pagpeter/typescript-go/external/vfs/vfstest"
+	"gotest.tools/v3/assert"
+)
+
+func TestGetModuleSpecifiersWithBaseUrl(t *testing.T) {
+	t.Parallel()
+	if !bundled.Embedded {
+		t.Skip("bundled files are not embedded")
+	}
+
+	fs := vfstest.FromMap(map[string]string{
+		"/tsconfig.json":        `{ "compilerOptions": { "baseUrl": "." }, "files": ["src/deep/nested/a.ts", "src/lib/b.ts"] }`,
+		"/src/deep/nested/a.ts": `export const a = 1;`,
+		"/src/lib/b.ts":         `export const b = 1;`,
+	}, false /*useCaseSensitiveFileNames*/)
+	fs = bundled.WrapFS(fs)
+	host := compiler.NewCompilerHost(nil, "/", fs, bundled.LibPath(), nil, nil)
+	parsed, errors := tsoptions.GetParsedCommandLineOfConfigFile("/tsconfig.json", &core.CompilerOptions{}, host, nil)
+	assert.Equal(t, len(errors), 0, "Expected no errors in parsed command line")
+
+	p := compiler.NewProgram(compiler.ProgramOptions{
+		Config: parsed,
+		Host:   host,
+	})
+	p.BindSourceFiles()
+	c, done := p.GetTypeChecker(t.Context())
+	defer done()
+	importingFile := p.GetSourceFile("/src/deep/nested/a.ts")
+	moduleSymbol := p.GetSourceFile("/src/lib/b.ts").Symbol
+
+	testCases := []struct {
+		preference modulespecifiers.ImportModuleSpecifierPreference
+		expected   string
+	}{
+		{modulespecifiers.ImportModuleSpecifierPreferenceShortest, "src/lib/b"},
+		{modulespecifiers.ImportModuleSpecifierPreferenceNonRelative, "src/lib/b"},
+		{modulespecifiers.ImportModuleSpecifierPreferenceRelative, "../../lib/b"},
+	}
+	for _, testCase := range testCases {
+		specifiers := modulespecifiers.GetModuleSpecifiers(
+			moduleSymbol,
+			c,
+			p.Options(),
+			importingFile,
+			p,
+			modulespecifiers.UserPreferences{ImportModuleSpecifierPreference: testCase.preference},
+			modulespecifiers.ModuleSpecifierOptions{},
+		)
+		assert.DeepEqual(t, specifiers, []string{testCase.expected})
+	}
+}
@@ -199,3 +199,40 @@ func getDeclarationEmitExtensionForPath(fileName string) string {
 	}
 	return tspath.ExtensionDts
 }
+
+func GetBuildInfoFileName(options *core.CompilerOptions, opts tspath.ComparePathsOptions) string {
+	if !options.IsIncremental() && !options.TscBuild.IsTrue() {
+		return ""
+	}
+	if options.TsBuildInfoFile != "" {
+		return options.TsBuildInfoFile
+	}
+	if options.OutFile != "" {
+		return tspath.RemoveFileExtension(options.OutFile) + tspath.ExtensionTsBuildInfo
+	}
+	if options.ConfigFilePath == "" {
+		return ""
+	}
+	configFileExtensionLess := tspath.RemoveFileExtension(options.ConfigFilePath)
+	var buildInfoExtensionLess string
+	if options.OutDir != "" {
+		if options.RootDir != "" {
+			buildInfoExtensionLess = tspath.ResolvePath(options.OutDir, tspath.GetRelativePathFromDirectory(options.RootDir, configFileExtensionLess, opts))
+		} else {
+			buildInfoExtensionLess = tspath.CombinePaths(options.OutDir, tspath.GetBaseFileName(configFileExtensionLess))
+		}
+	} else {
+		buildInfoExtensionLess = configFileExtensionLess
+	}
+	return buildInfoExtensionLess + tspath.ExtensionTsBuildInfo
+}
+
+// GetBuildInfoOutputPaths returns the output paths for emitting the .tsbuildinfo file of a program,
+// or nil if the program has none.
+func GetBuildInfoOutputPaths(options *core.CompilerOptions, opts tspath.ComparePathsOptions) *OutputPaths {
+	buildInfoPath := GetBuildInfoFileName(options, opts)
+	if buildInfoPath == "" {
+		return nil
+	}
+	return &OutputPaths{buildInfoPath: buildInfoPath}
+}
@@ -143,3 +143,19 @@ func FuzzParser(f *testing.F) {
 		ParseSourceFile(opts, sourceText, core.GetScriptKindFromFileName(fileName))
 	})
 }
+
+func TestReferenceDirectiveRanges(t *testing.T) {
+	t.Parallel()
+	sourceText := "/// <reference types=\"node\" />\n/// <reference lib=\"es2015\" />\n/// <reference path=\"./a.ts\" />\n"
+	file := ParseSourceFile(ast.SourceFileParseOptions{FileName: "/index.ts", Path: "/index.ts"}, sourceText, core.ScriptKindTS)
+
+	textOf := func(ref *ast.FileReference) string {
+		return sourceText[ref.Pos():ref.End()]
+	}
+	assert.Equal(t, len(file.TypeReferenceDirectives), 1)
+	assert.Equal(t, textOf(file.TypeReferenceDirectives[0]), "node")
+	assert.Equal(t, len(file.LibReferenceDirectives), 1)
+	assert.Equal(t, textOf(file.LibReferenceDirectives[0]), "es2015")
+	assert.Equal(t, len(file.ReferencedFiles), 1)
+	assert.Equal(t, textOf(file.ReferencedFiles[0]), "./a.ts")
+}
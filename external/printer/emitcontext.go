@@ -500,6 +500,16 @@ type emitNode struct {
 	tokenSourceMapRanges      map[ast.Kind]core.TextRange
 	helpers                   []*EmitHelper
 	externalHelpersModuleName *ast.IdentifierNode
+	leadingComments           []SynthesizedComment
+	trailingComments          []SynthesizedComment
+}
+
+// A comment that is not associated with any source text and is written by the printer as-is.
+type SynthesizedComment struct {
+	Kind               ast.Kind // Either KindSingleLineCommentTrivia or KindMultiLineCommentTrivia
+	Text               string   // The comment text, excluding the `//` or `/*` and `*/` delimiters
+	HasLeadingNewLine  bool
+	HasTrailingNewLine bool
 }
 
 // NOTE: This method is not guaranteed to be thread-safe
@@ -511,6 +521,8 @@ func (e *emitNode) copyFrom(source *emitNode) {
 	e.tokenSourceMapRanges = maps.Clone(source.tokenSourceMapRanges)
 	e.helpers = slices.Clone(source.helpers)
 	e.externalHelpersModuleName = source.externalHelpersModuleName
+	e.leadingComments = slices.Clone(source.leadingComments)
+	e.trailingComments = slices.Clone(source.trailingComments)
 }
 
 func (c *EmitContext) EmitFlags(node *ast.Node) EmitFlags {
@@ -597,6 +609,48 @@ func (c *EmitContext) SetTokenSourceMapRange(node *ast.Node, kind ast.Kind, loc
 	emitNode.tokenSourceMapRanges[kind] = loc
 }
 
+// Gets the synthesized comments to emit before a node.
+func (c *EmitContext) SyntheticLeadingComments(node *ast.Node) []SynthesizedComment {
+	if emitNode := c.emitNodes.TryGet(node); emitNode != nil {
+		return emitNode.leadingComments
+	}
+	return nil
+}
+
+// Sets the synthesized comments to emit before a node.
+func (c *EmitContext) SetSyntheticLeadingComments(node *ast.Node, comments []SynthesizedComment) {
+	c.emitNodes.Get(node).leadingComments = comments
+}
+
+// Adds a synthesized comment to emit before a node.
+//
+// NOTE: This is the equivalent of `addSyntheticLeadingComment` in Strada.
+func (c *EmitContext) AddSyntheticLeadingComment(node *ast.Node, kind ast.Kind, text string, hasTrailingNewLine bool) {
+	emitNode := c.emitNodes.Get(node)
+	emitNode.leadingComments = append(emitNode.leadingComments, SynthesizedComment{Kind: kind, Text: text, HasTrailingNewLine: hasTrailingNewLine})
+}
+
+// Gets the synthesized comments to emit after a node.
+func (c *EmitContext) SyntheticTrailingComments(node *ast.Node) []SynthesizedComment {
+	if emitNode := c.emitNodes.TryGet(node); emitNode != nil {
+		return emitNode.trailingComments
+	}
+	return nil
+}
+
+// Sets the synthesized comments to emit after a node.
+func (c *EmitContext) SetSyntheticTrailingComments(node *ast.Node, comments []SynthesizedComment) {
+	c.emitNodes.Get(node).trailingComments = comments
+}
+
+// Adds a synthesized comment to emit after a node.
+//
+// NOTE: This is the equivalent of `addSyntheticTrailingComment` in Strada.
+func (c *EmitContext) AddSyntheticTrailingComment(node *ast.Node, kind ast.Kind, text string, hasTrailingNewLine bool) {
+	emitNode := c.emitNodes.Get(node)
+	emitNode.trailingComments = append(emitNode.trailingComments, SynthesizedComment{Kind: kind, Text: text, HasTrailingNewLine: hasTrailingNewLine})
+}
+
 func (c *EmitContext) AssignedName(node *ast.Node) *ast.Expression {
 	return c.assignedName[node]
 }
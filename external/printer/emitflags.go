@@ -27,6 +27,8 @@ const (
 	EFNeverApplyImportHelper                          // Do not apply an import helper to this node
 	EFStartOnNewLine                                  // Start this node on a new line
 	EFIndirectCall                                    // Emit CallExpression as an indirect call: `(0, f)()`
+	EFAsyncFunctionBody                               // The node was originally the body of an async function.
+	EFIterator                                        // The expression of a `yield*` is an iterator rather than an iterable, and does not need to be passed to `__values`.
 )
 
 const (
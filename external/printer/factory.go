@@ -2,6 +2,7 @@ package printer
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/pagpeter/typescript-go/external/ast"
@@ -215,11 +216,17 @@ func (f *NodeFactory) NewLogicalORExpression(left *ast.Expression, right *ast.Ex
 	return f.NewBinaryExpression(nil /*modifiers*/, left, nil /*typeNode*/, f.NewToken(ast.KindBarBarToken), right)
 }
 
-// func (f *NodeFactory) NewLogicalANDExpression(left *ast.Expression, right *ast.Expression) *ast.Expression
+func (f *NodeFactory) NewLogicalANDExpression(left *ast.Expression, right *ast.Expression) *ast.Expression {
+	return f.NewBinaryExpression(nil /*modifiers*/, left, nil /*typeNode*/, f.NewToken(ast.KindAmpersandAmpersandToken), right)
+}
+
 // func (f *NodeFactory) NewBitwiseORExpression(left *ast.Expression, right *ast.Expression) *ast.Expression
 // func (f *NodeFactory) NewBitwiseXORExpression(left *ast.Expression, right *ast.Expression) *ast.Expression
 // func (f *NodeFactory) NewBitwiseANDExpression(left *ast.Expression, right *ast.Expression) *ast.Expression
-// func (f *NodeFactory) NewStrictEqualityExpression(left *ast.Expression, right *ast.Expression) *ast.Expression
+
+func (f *NodeFactory) NewStrictEqualityExpression(left *ast.Expression, right *ast.Expression) *ast.Expression {
+	return f.NewBinaryExpression(nil /*modifiers*/, left, nil /*typeNode*/, f.NewToken(ast.KindEqualsEqualsEqualsToken), right)
+}
 
 func (f *NodeFactory) NewStrictInequalityExpression(left *ast.Expression, right *ast.Expression) *ast.Expression {
 	return f.NewBinaryExpression(nil /*modifiers*/, left, nil /*typeNode*/, f.NewToken(ast.KindExclamationEqualsEqualsToken), right)
@@ -233,6 +240,19 @@ func (f *NodeFactory) NewVoidZeroExpression() *ast.Expression {
 	return f.NewVoidExpression(f.NewNumericLiteral("0"))
 }
 
+// Allocates a new expression that tests the type of `value`. The tags "null" and "undefined" produce a strict equality
+// test against `null` or `void 0`; any other tag produces a `typeof` test.
+func (f *NodeFactory) NewTypeCheck(value *ast.Expression, tag string) *ast.Expression {
+	switch tag {
+	case "null":
+		return f.NewStrictEqualityExpression(value, f.NewKeywordExpression(ast.KindNullKeyword))
+	case "undefined":
+		return f.NewStrictEqualityExpression(value, f.NewVoidZeroExpression())
+	default:
+		return f.NewStrictEqualityExpression(f.NewTypeOfExpression(value), f.NewStringLiteral(tag))
+	}
+}
+
 func flattenCommaElement(node *ast.Expression, expressions []*ast.Expression) []*ast.Expression {
 	if ast.IsBinaryExpression(node) && ast.NodeIsSynthesized(node) && node.AsBinaryExpression().OperatorToken.Kind == ast.KindCommaToken {
 		expressions = flattenCommaElement(node.AsBinaryExpression().Left, expressions)
@@ -288,9 +308,9 @@ func (f *NodeFactory) isIgnorableParen(node *ast.Expression) bool {
 	return ast.IsParenthesizedExpression(node) &&
 		ast.NodeIsSynthesized(node) &&
 		ast.RangeIsSynthesized(f.emitContext.SourceMapRange(node)) &&
-		ast.RangeIsSynthesized(f.emitContext.CommentRange(node)) // &&
-	// len(emitContext.SyntheticLeadingComments(node)) == 0 &&
-	// len(emitContext.SyntheticTrailingComments(node)) == 0
+		ast.RangeIsSynthesized(f.emitContext.CommentRange(node)) &&
+		len(f.emitContext.SyntheticLeadingComments(node)) == 0 &&
+		len(f.emitContext.SyntheticTrailingComments(node)) == 0
 }
 
 func (f *NodeFactory) updateOuterExpression(outerExpression *ast.Expression /*OuterExpression*/, expression *ast.Expression) *ast.Expression {
@@ -412,6 +432,14 @@ func (f *NodeFactory) GetLocalNameEx(node *ast.Declaration, opts AssignedNameOpt
 	return f.getName(node, EFLocalName, opts)
 }
 
+// Gets the internal name of a declaration. This is primarily used for declarations that can be referred to by name in
+// the body of an ES5 class function body. An internal name will *never* be prefixed with a module or namespace export
+// modifier like "exports." when emitted as an expression. An internal name will also *never* be renamed due to a
+// collision with a block-scoped variable.
+func (f *NodeFactory) GetInternalName(node *ast.Declaration) *ast.IdentifierNode {
+	return f.getName(node, EFLocalName|EFInternalName, AssignedNameOptions{})
+}
+
 // Gets the export name of a declaration. This is primarily used for declarations that can be
 // referred to by name in the declaration's immediate scope (classes, enums, namespaces). An
 // export name will *always* be prefixed with an module or namespace export modifier like
@@ -453,6 +481,49 @@ func (f *NodeFactory) GetNamespaceMemberName(ns *ast.IdentifierNode, name *ast.I
 	return qualifiedName
 }
 
+//
+// Utilities
+//
+
+// Allocates a new Call expression that invokes a function with an explicit `this` argument:
+//
+//	target.call(thisArg, ...argumentsList)
+func (f *NodeFactory) NewFunctionCallCall(target *ast.Expression, thisArg *ast.Expression, argumentsList []*ast.Expression) *ast.Expression {
+	return f.NewCallExpression(
+		f.NewPropertyAccessExpression(target, nil /*questionDotToken*/, f.NewIdentifier("call"), ast.NodeFlagsNone),
+		nil, /*questionDotToken*/
+		nil, /*typeArguments*/
+		f.NewNodeList(append([]*ast.Expression{thisArg}, argumentsList...)),
+		ast.NodeFlagsNone,
+	)
+}
+
+// Allocates a new Call expression that invokes a function with an explicit `this` argument and an array of arguments:
+//
+//	target.apply(thisArg, argumentsExpression)
+func (f *NodeFactory) NewFunctionApplyCall(target *ast.Expression, thisArg *ast.Expression, argumentsExpression *ast.Expression) *ast.Expression {
+	return f.NewCallExpression(
+		f.NewPropertyAccessExpression(target, nil /*questionDotToken*/, f.NewIdentifier("apply"), ast.NodeFlagsNone),
+		nil, /*questionDotToken*/
+		nil, /*typeArguments*/
+		f.NewNodeList([]*ast.Expression{thisArg, argumentsExpression}),
+		ast.NodeFlagsNone,
+	)
+}
+
+// Allocates a new Call expression to `Object.defineProperty`:
+//
+//	Object.defineProperty(target, propertyName, attributes)
+func (f *NodeFactory) NewObjectDefinePropertyCall(target *ast.Expression, propertyName *ast.Expression, attributes *ast.Expression) *ast.Expression {
+	return f.NewCallExpression(
+		f.NewPropertyAccessExpression(f.NewIdentifier("Object"), nil /*questionDotToken*/, f.NewIdentifier("defineProperty"), ast.NodeFlagsNone),
+		nil, /*questionDotToken*/
+		nil, /*typeArguments*/
+		f.NewNodeList([]*ast.Expression{target, propertyName, attributes}),
+		ast.NodeFlagsNone,
+	)
+}
+
 //
 // Emit Helpers
 //
@@ -464,10 +535,183 @@ func (f *NodeFactory) NewUnscopedHelperName(name string) *ast.IdentifierNode {
 	return node
 }
 
-// !!! TypeScript Helpers
+// TypeScript Helpers
+
+// Allocates a new Call expression to the `__decorate` helper. A nil descriptor omits the argument, which is used
+// when decorating a class.
+func (f *NodeFactory) NewDecorateHelper(decoratorExpressions []*ast.Expression, target *ast.Expression, memberName *ast.Expression, descriptor *ast.Expression) *ast.Expression {
+	f.emitContext.RequestEmitHelper(decorateHelper)
+	arguments := []*ast.Expression{
+		f.NewArrayLiteralExpression(f.NewNodeList(decoratorExpressions), true /*multiLine*/),
+		target,
+	}
+	if memberName != nil {
+		arguments = append(arguments, memberName)
+		if descriptor != nil {
+			arguments = append(arguments, descriptor)
+		}
+	}
+	return f.NewCallExpression(
+		f.NewUnscopedHelperName("__decorate"),
+		nil, /*questionDotToken*/
+		nil, /*typeArguments*/
+		f.NewNodeList(arguments),
+		ast.NodeFlagsNone,
+	)
+}
+
+// Allocates a new Call expression to the `__metadata` helper.
+func (f *NodeFactory) NewMetadataHelper(metadataKey string, metadataValue *ast.Expression) *ast.Expression {
+	f.emitContext.RequestEmitHelper(metadataHelper)
+	return f.NewCallExpression(
+		f.NewUnscopedHelperName("__metadata"),
+		nil, /*questionDotToken*/
+		nil, /*typeArguments*/
+		f.NewNodeList([]*ast.Expression{f.NewStringLiteral(metadataKey), metadataValue}),
+		ast.NodeFlagsNone,
+	)
+}
+
+// Allocates a new Call expression to the `__param` helper.
+func (f *NodeFactory) NewParamHelper(expression *ast.Expression, parameterOffset int, location core.TextRange) *ast.Expression {
+	f.emitContext.RequestEmitHelper(paramHelper)
+	helper := f.NewCallExpression(
+		f.NewUnscopedHelperName("__param"),
+		nil, /*questionDotToken*/
+		nil, /*typeArguments*/
+		f.NewNodeList([]*ast.Expression{f.NewNumericLiteral(strconv.Itoa(parameterOffset)), expression}),
+		ast.NodeFlagsNone,
+	)
+	helper.Loc = location
+	return helper
+}
 
 // ESNext Helpers
 
+// ESDecorateName is the name of a decorated class element as it is described to the `__esDecorate` helper. When
+// Computed is false, Name is the Identifier or PrivateIdentifier of the element; otherwise, it is an expression that
+// evaluates to the property key.
+type ESDecorateName struct {
+	Computed bool
+	Name     *ast.Expression
+}
+
+// ESDecorateContext describes the context object passed to the decorators of a class or class element by the
+// `__esDecorate` helper. Only Name and Metadata are used when Kind is "class".
+type ESDecorateContext struct {
+	Kind     string // "class", "method", "getter", "setter", "accessor", or "field"
+	Name     ESDecorateName
+	Static   bool
+	Private  bool
+	HasGet   bool // Whether the `access` object has a `get` method.
+	HasSet   bool // Whether the `access` object has a `set` method.
+	Metadata *ast.Expression
+}
+
+func (f *NodeFactory) newESDecorateContextObject(contextIn *ESDecorateContext) *ast.Expression {
+	var properties []*ast.Node
+	properties = append(properties, f.NewPropertyAssignment(nil /*modifiers*/, f.NewIdentifier("kind"), nil /*postfixToken*/, nil /*typeNode*/, f.NewStringLiteral(contextIn.Kind)))
+	if contextIn.Kind == "class" {
+		properties = append(properties, f.NewPropertyAssignment(nil /*modifiers*/, f.NewIdentifier("name"), nil /*postfixToken*/, nil /*typeNode*/, contextIn.Name.Name))
+	} else {
+		var name *ast.Expression
+		if contextIn.Name.Computed {
+			name = contextIn.Name.Name
+		} else {
+			name = f.NewStringLiteralFromNode(contextIn.Name.Name)
+		}
+		properties = append(properties,
+			f.NewPropertyAssignment(nil /*modifiers*/, f.NewIdentifier("name"), nil /*postfixToken*/, nil /*typeNode*/, name),
+			f.NewPropertyAssignment(nil /*modifiers*/, f.NewIdentifier("static"), nil /*postfixToken*/, nil /*typeNode*/, f.newBooleanExpression(contextIn.Static)),
+			f.NewPropertyAssignment(nil /*modifiers*/, f.NewIdentifier("private"), nil /*postfixToken*/, nil /*typeNode*/, f.newBooleanExpression(contextIn.Private)),
+			f.NewPropertyAssignment(nil /*modifiers*/, f.NewIdentifier("access"), nil /*postfixToken*/, nil /*typeNode*/, f.newESDecorateAccessObject(contextIn)),
+		)
+	}
+	properties = append(properties, f.NewPropertyAssignment(nil /*modifiers*/, f.NewIdentifier("metadata"), nil /*postfixToken*/, nil /*typeNode*/, contextIn.Metadata))
+	return f.NewObjectLiteralExpression(f.NewNodeList(properties), false /*multiLine*/)
+}
+
+func (f *NodeFactory) newBooleanExpression(value bool) *ast.Expression {
+	if value {
+		return f.NewTrueExpression()
+	}
+	return f.NewFalseExpression()
+}
+
+// Creates the `access` object of a class element decoration context:
+//
+//	{ has: obj => name in obj, get: obj => obj.name, set: (obj, value) => { obj.name = value; } }
+func (f *NodeFactory) newESDecorateAccessObject(contextIn *ESDecorateContext) *ast.Expression {
+	newAccessor := func() *ast.Expression {
+		if contextIn.Name.Computed {
+			return f.NewElementAccessExpression(f.NewIdentifier("obj"), nil /*questionDotToken*/, contextIn.Name.Name, ast.NodeFlagsNone)
+		}
+		return f.NewPropertyAccessExpression(f.NewIdentifier("obj"), nil /*questionDotToken*/, contextIn.Name.Name, ast.NodeFlagsNone)
+	}
+	newParameter := func(name string) *ast.Node {
+		return f.NewParameterDeclaration(nil /*modifiers*/, nil /*dotDotDotToken*/, f.NewIdentifier(name), nil /*questionToken*/, nil /*typeNode*/, nil /*initializer*/)
+	}
+	newMethod := func(name string, parameters []*ast.Node, body *ast.Node) *ast.Node {
+		arrow := f.NewArrowFunction(nil /*modifiers*/, nil /*typeParameters*/, f.NewNodeList(parameters), nil /*returnType*/, f.NewToken(ast.KindEqualsGreaterThanToken), body)
+		return f.NewPropertyAssignment(nil /*modifiers*/, f.NewIdentifier(name), nil /*postfixToken*/, nil /*typeNode*/, arrow)
+	}
+
+	var propertyName *ast.Expression
+	if contextIn.Name.Computed || ast.IsPrivateIdentifier(contextIn.Name.Name) {
+		propertyName = contextIn.Name.Name
+	} else {
+		propertyName = f.NewStringLiteralFromNode(contextIn.Name.Name)
+	}
+
+	properties := []*ast.Node{
+		newMethod("has", []*ast.Node{newParameter("obj")}, f.NewBinaryExpression(nil /*modifiers*/, propertyName, nil /*typeNode*/, f.NewToken(ast.KindInKeyword), f.NewIdentifier("obj"))),
+	}
+	if contextIn.HasGet {
+		properties = append(properties, newMethod("get", []*ast.Node{newParameter("obj")}, newAccessor()))
+	}
+	if contextIn.HasSet {
+		assignment := f.NewExpressionStatement(f.NewAssignmentExpression(newAccessor(), f.NewIdentifier("value")))
+		body := f.NewBlock(f.NewNodeList([]*ast.Statement{assignment}), false /*multiLine*/)
+		properties = append(properties, newMethod("set", []*ast.Node{newParameter("obj"), newParameter("value")}, body))
+	}
+	return f.NewObjectLiteralExpression(f.NewNodeList(properties), false /*multiLine*/)
+}
+
+// Allocates a new Call expression to the `__esDecorate` helper. A nil ctor or descriptorIn is passed as `null`.
+func (f *NodeFactory) NewESDecorateHelper(ctor *ast.Expression, descriptorIn *ast.Expression, decorators *ast.Expression, contextIn *ESDecorateContext, initializers *ast.Expression, extraInitializers *ast.Expression) *ast.Expression {
+	f.emitContext.RequestEmitHelper(esDecorateHelper)
+	if ctor == nil {
+		ctor = f.NewKeywordExpression(ast.KindNullKeyword)
+	}
+	if descriptorIn == nil {
+		descriptorIn = f.NewKeywordExpression(ast.KindNullKeyword)
+	}
+	return f.NewCallExpression(
+		f.NewUnscopedHelperName("__esDecorate"),
+		nil, /*questionDotToken*/
+		nil, /*typeArguments*/
+		f.NewNodeList([]*ast.Expression{ctor, descriptorIn, decorators, f.newESDecorateContextObject(contextIn), initializers, extraInitializers}),
+		ast.NodeFlagsNone,
+	)
+}
+
+// Allocates a new Call expression to the `__runInitializers` helper. The optional value is the initial value of a
+// field, which is passed through each initializer.
+func (f *NodeFactory) NewRunInitializersHelper(thisArg *ast.Expression, initializers *ast.Expression, value *ast.Expression) *ast.Expression {
+	f.emitContext.RequestEmitHelper(runInitializersHelper)
+	arguments := []*ast.Expression{thisArg, initializers}
+	if value != nil {
+		arguments = append(arguments, value)
+	}
+	return f.NewCallExpression(
+		f.NewUnscopedHelperName("__runInitializers"),
+		nil, /*questionDotToken*/
+		nil, /*typeArguments*/
+		f.NewNodeList(arguments),
+		ast.NodeFlagsNone,
+	)
+}
+
 func (f *NodeFactory) NewAddDisposableResourceHelper(envBinding *ast.Expression, value *ast.Expression, async bool) *ast.Expression {
 	f.emitContext.RequestEmitHelper(addDisposableResourceHelper)
 	return f.NewCallExpression(
@@ -490,8 +734,66 @@ func (f *NodeFactory) NewDisposeResourcesHelper(envBinding *ast.Expression) *ast
 	)
 }
 
-// !!! Class Fields Helpers
-// !!! ES2018 Helpers
+// Class Fields Helpers
+
+// PrivateIdentifierKind is the `kind` argument passed to the `__classPrivateFieldGet` and `__classPrivateFieldSet`
+// helpers: "f" for fields, "m" for methods, and "a" for accessors.
+type PrivateIdentifierKind string
+
+const (
+	PrivateIdentifierKindField    PrivateIdentifierKind = "f"
+	PrivateIdentifierKindMethod   PrivateIdentifierKind = "m"
+	PrivateIdentifierKindAccessor PrivateIdentifierKind = "a"
+)
+
+// Allocates a new Call expression to the `__classPrivateFieldGet` helper. The optional `fn` argument is the accessor
+// or method function, or the static field descriptor.
+func (f *NodeFactory) NewClassPrivateFieldGetHelper(receiver *ast.Expression, state *ast.IdentifierNode, kind PrivateIdentifierKind, fn *ast.IdentifierNode) *ast.Expression {
+	f.emitContext.RequestEmitHelper(classPrivateFieldGetHelper)
+	arguments := []*ast.Expression{receiver, state, f.NewStringLiteral(string(kind))}
+	if fn != nil {
+		arguments = append(arguments, fn)
+	}
+	return f.NewCallExpression(
+		f.NewUnscopedHelperName("__classPrivateFieldGet"),
+		nil, /*questionDotToken*/
+		nil, /*typeArguments*/
+		f.NewNodeList(arguments),
+		ast.NodeFlagsNone,
+	)
+}
+
+// Allocates a new Call expression to the `__classPrivateFieldSet` helper. The optional `fn` argument is the accessor
+// or method function, or the static field descriptor.
+func (f *NodeFactory) NewClassPrivateFieldSetHelper(receiver *ast.Expression, state *ast.IdentifierNode, value *ast.Expression, kind PrivateIdentifierKind, fn *ast.IdentifierNode) *ast.Expression {
+	f.emitContext.RequestEmitHelper(classPrivateFieldSetHelper)
+	arguments := []*ast.Expression{receiver, state, value, f.NewStringLiteral(string(kind))}
+	if fn != nil {
+		arguments = append(arguments, fn)
+	}
+	return f.NewCallExpression(
+		f.NewUnscopedHelperName("__classPrivateFieldSet"),
+		nil, /*questionDotToken*/
+		nil, /*typeArguments*/
+		f.NewNodeList(arguments),
+		ast.NodeFlagsNone,
+	)
+}
+
+// Allocates a new Call expression to the `__classPrivateFieldIn` helper.
+func (f *NodeFactory) NewClassPrivateFieldInHelper(state *ast.IdentifierNode, receiver *ast.Expression) *ast.Expression {
+	f.emitContext.RequestEmitHelper(classPrivateFieldInHelper)
+	return f.NewCallExpression(
+		f.NewUnscopedHelperName("__classPrivateFieldIn"),
+		nil, /*questionDotToken*/
+		nil, /*typeArguments*/
+		f.NewNodeList([]*ast.Expression{state, receiver}),
+		ast.NodeFlagsNone,
+	)
+}
+
+// ES2018 Helpers
+
 // Chains a sequence of expressions using the __assign helper or Object.assign if available in the target
 func (f *NodeFactory) NewAssignHelper(attributesSegments []*ast.Expression, scriptTarget core.ScriptTarget) *ast.Expression {
 	if scriptTarget >= core.ScriptTargetES2015 {
@@ -507,8 +809,157 @@ func (f *NodeFactory) NewAssignHelper(attributesSegments []*ast.Expression, scri
 	)
 }
 
-// !!! ES2018 Destructuring Helpers
-// !!! ES2017 Helpers
+// Allocates a new Call expression to the `__await` helper.
+func (f *NodeFactory) NewAwaitHelper(expression *ast.Expression) *ast.Expression {
+	f.emitContext.RequestEmitHelper(awaitHelper)
+	return f.NewCallExpression(
+		f.NewUnscopedHelperName("__await"),
+		nil, /*questionDotToken*/
+		nil, /*typeArguments*/
+		f.NewNodeList([]*ast.Expression{expression}),
+		ast.NodeFlagsNone,
+	)
+}
+
+// Allocates a new Call expression to the `__asyncGenerator` helper, passing a generator function that serves as the
+// body of an async generator function.
+func (f *NodeFactory) NewAsyncGeneratorHelper(generatorFunc *ast.Expression, hasLexicalThis bool) *ast.Expression {
+	f.emitContext.RequestEmitHelper(awaitHelper)
+	f.emitContext.RequestEmitHelper(asyncGeneratorHelper)
+
+	// Mark this node as originally an async function body
+	f.emitContext.AddEmitFlags(generatorFunc, EFAsyncFunctionBody|EFReuseTempVariableScope)
+
+	var thisArg *ast.Expression
+	if hasLexicalThis {
+		thisArg = f.NewThisExpression()
+	} else {
+		thisArg = f.NewVoidZeroExpression()
+	}
+
+	return f.NewCallExpression(
+		f.NewUnscopedHelperName("__asyncGenerator"),
+		nil, /*questionDotToken*/
+		nil, /*typeArguments*/
+		f.NewNodeList([]*ast.Expression{
+			thisArg,
+			f.NewIdentifier("arguments"),
+			generatorFunc,
+		}),
+		ast.NodeFlagsNone,
+	)
+}
+
+// Allocates a new Call expression to the `__asyncDelegator` helper.
+func (f *NodeFactory) NewAsyncDelegatorHelper(expression *ast.Expression) *ast.Expression {
+	f.emitContext.RequestEmitHelper(awaitHelper)
+	f.emitContext.RequestEmitHelper(asyncDelegatorHelper)
+	return f.NewCallExpression(
+		f.NewUnscopedHelperName("__asyncDelegator"),
+		nil, /*questionDotToken*/
+		nil, /*typeArguments*/
+		f.NewNodeList([]*ast.Expression{expression}),
+		ast.NodeFlagsNone,
+	)
+}
+
+// Allocates a new Call expression to the `__asyncValues` helper.
+func (f *NodeFactory) NewAsyncValuesHelper(expression *ast.Expression) *ast.Expression {
+	f.emitContext.RequestEmitHelper(asyncValuesHelper)
+	return f.NewCallExpression(
+		f.NewUnscopedHelperName("__asyncValues"),
+		nil, /*questionDotToken*/
+		nil, /*typeArguments*/
+		f.NewNodeList([]*ast.Expression{expression}),
+		ast.NodeFlagsNone,
+	)
+}
+
+// ES2018 Destructuring Helpers
+
+// Allocates a new Call expression to the `__rest` helper, which copies the properties of `value` that are not named
+// by the other elements of an object binding or assignment pattern. `computedTempVariables` holds the cached values
+// of any computed property names in the pattern, in order.
+func (f *NodeFactory) NewRestHelper(value *ast.Expression, elements []*ast.Node, computedTempVariables []*ast.Expression, location core.TextRange) *ast.Expression {
+	f.emitContext.RequestEmitHelper(restHelper)
+	var propertyNames []*ast.Expression
+	computedTempVariableOffset := 0
+	for _, element := range elements[:len(elements)-1] {
+		propertyName := ast.TryGetPropertyNameOfBindingOrAssignmentElement(element)
+		if propertyName == nil {
+			continue
+		}
+		if ast.IsComputedPropertyName(propertyName) {
+			temp := computedTempVariables[computedTempVariableOffset]
+			computedTempVariableOffset++
+			// typeof _tmp === "symbol" ? _tmp : _tmp + ""
+			propertyNames = append(propertyNames, f.NewConditionalExpression(
+				f.NewTypeCheck(temp, "symbol"),
+				f.NewToken(ast.KindQuestionToken),
+				temp.Clone(f),
+				f.NewToken(ast.KindColonToken),
+				f.NewBinaryExpression(nil /*modifiers*/, temp.Clone(f), nil /*typeNode*/, f.NewToken(ast.KindPlusToken), f.NewStringLiteral("")),
+			))
+		} else {
+			propertyNames = append(propertyNames, f.NewStringLiteralFromNode(propertyName))
+		}
+	}
+	propertyNamesArray := f.NewArrayLiteralExpression(f.NewNodeList(propertyNames), false /*multiLine*/)
+	propertyNamesArray.Loc = location
+	return f.NewCallExpression(
+		f.NewUnscopedHelperName("__rest"),
+		nil, /*questionDotToken*/
+		nil, /*typeArguments*/
+		f.NewNodeList([]*ast.Expression{value, propertyNamesArray}),
+		ast.NodeFlagsNone,
+	)
+}
+
+// ES2017 Helpers
+
+// Allocates a new Call expression to the `__awaiter` helper, passing a generator function with the provided parameters
+// and body as the body of the async function.
+func (f *NodeFactory) NewAwaiterHelper(hasLexicalThis bool, argumentsExpression *ast.Expression, promiseConstructor *ast.Expression, parameters []*ast.ParameterDeclarationNode, body *ast.BlockNode) *ast.Expression {
+	f.emitContext.RequestEmitHelper(awaiterHelper)
+	generatorFunc := f.NewFunctionExpression(
+		nil, /*modifiers*/
+		f.NewToken(ast.KindAsteriskToken),
+		nil, /*name*/
+		nil, /*typeParameters*/
+		f.NewNodeList(parameters),
+		nil, /*returnType*/
+		body,
+	)
+
+	// Mark this node as originally an async function body
+	f.emitContext.AddEmitFlags(generatorFunc, EFAsyncFunctionBody|EFReuseTempVariableScope)
+
+	var thisArg *ast.Expression
+	if hasLexicalThis {
+		thisArg = f.NewThisExpression()
+	} else {
+		thisArg = f.NewVoidZeroExpression()
+	}
+	if argumentsExpression == nil {
+		argumentsExpression = f.NewVoidZeroExpression()
+	}
+	if promiseConstructor == nil {
+		promiseConstructor = f.NewVoidZeroExpression()
+	}
+
+	return f.NewCallExpression(
+		f.NewUnscopedHelperName("__awaiter"),
+		nil, /*questionDotToken*/
+		nil, /*typeArguments*/
+		f.NewNodeList([]*ast.Expression{
+			thisArg,
+			argumentsExpression,
+			promiseConstructor,
+			generatorFunc,
+		}),
+		ast.NodeFlagsNone,
+	)
+}
 
 // ES2015 Helpers
 
@@ -540,6 +991,93 @@ func (f *NodeFactory) NewSetFunctionNameHelper(fn *ast.Expression, name *ast.Exp
 	)
 }
 
+// Allocates a new Call expression to the `__extends` helper.
+func (f *NodeFactory) NewExtendsHelper(name *ast.IdentifierNode) *ast.Expression {
+	f.emitContext.RequestEmitHelper(extendsHelper)
+	return f.NewCallExpression(
+		f.NewUnscopedHelperName("__extends"),
+		nil, /*questionDotToken*/
+		nil, /*typeArguments*/
+		f.NewNodeList([]*ast.Expression{name, f.NewUniqueNameEx("_super", AutoGenerateOptions{Flags: GeneratedIdentifierFlagsOptimistic | GeneratedIdentifierFlagsFileLevel})}),
+		ast.NodeFlagsNone,
+	)
+}
+
+// Allocates a new Call expression to the `__makeTemplateObject` helper.
+func (f *NodeFactory) NewTemplateObjectHelper(cooked *ast.Expression, raw *ast.Expression) *ast.Expression {
+	f.emitContext.RequestEmitHelper(templateObjectHelper)
+	return f.NewCallExpression(
+		f.NewUnscopedHelperName("__makeTemplateObject"),
+		nil, /*questionDotToken*/
+		nil, /*typeArguments*/
+		f.NewNodeList([]*ast.Expression{cooked, raw}),
+		ast.NodeFlagsNone,
+	)
+}
+
+// Allocates a new Call expression to the `__read` helper, which reads at most `count` values from an iterator into
+// an array. A `count` of less than zero reads all remaining values.
+func (f *NodeFactory) NewReadHelper(iteratorRecord *ast.Expression, count int) *ast.Expression {
+	f.emitContext.RequestEmitHelper(readHelper)
+	arguments := []*ast.Expression{iteratorRecord}
+	if count >= 0 {
+		arguments = append(arguments, f.NewNumericLiteral(strconv.Itoa(count)))
+	}
+	return f.NewCallExpression(
+		f.NewUnscopedHelperName("__read"),
+		nil, /*questionDotToken*/
+		nil, /*typeArguments*/
+		f.NewNodeList(arguments),
+		ast.NodeFlagsNone,
+	)
+}
+
+// Allocates a new Call expression to the `__spreadArray` helper. When `packFrom` is set, holes in `from` are
+// replaced with `undefined`.
+func (f *NodeFactory) NewSpreadArrayHelper(to *ast.Expression, from *ast.Expression, packFrom bool) *ast.Expression {
+	f.emitContext.RequestEmitHelper(spreadArrayHelper)
+	var pack *ast.Expression
+	if packFrom {
+		pack = f.NewTrueExpression()
+	} else {
+		pack = f.NewFalseExpression()
+	}
+	return f.NewCallExpression(
+		f.NewUnscopedHelperName("__spreadArray"),
+		nil, /*questionDotToken*/
+		nil, /*typeArguments*/
+		f.NewNodeList([]*ast.Expression{to, from, pack}),
+		ast.NodeFlagsNone,
+	)
+}
+
+// Allocates a new Call expression to the `__values` helper.
+func (f *NodeFactory) NewValuesHelper(expression *ast.Expression) *ast.Expression {
+	f.emitContext.RequestEmitHelper(valuesHelper)
+	return f.NewCallExpression(
+		f.NewUnscopedHelperName("__values"),
+		nil, /*questionDotToken*/
+		nil, /*typeArguments*/
+		f.NewNodeList([]*ast.Expression{expression}),
+		ast.NodeFlagsNone,
+	)
+}
+
+// ES2015 Generator Helpers
+
+// Allocates a new Call expression to the `__generator` helper, passing a function that acts as the transformed body
+// of a generator function.
+func (f *NodeFactory) NewGeneratorHelper(body *ast.Expression) *ast.Expression {
+	f.emitContext.RequestEmitHelper(generatorHelper)
+	return f.NewCallExpression(
+		f.NewUnscopedHelperName("__generator"),
+		nil, /*questionDotToken*/
+		nil, /*typeArguments*/
+		f.NewNodeList([]*ast.Expression{f.NewThisExpression(), body}),
+		ast.NodeFlagsNone,
+	)
+}
+
 // ES Module Helpers
 
 // Allocates a new Call expression to the `__importDefault` helper.
@@ -566,6 +1104,12 @@ func (f *NodeFactory) NewImportStarHelper(expression *ast.Expression) *ast.Expre
 	)
 }
 
+// Allocates a new reference to the `__importStar` helper, for use as a callback.
+func (f *NodeFactory) NewImportStarCallbackHelper() *ast.Expression {
+	f.emitContext.RequestEmitHelper(importStarHelper)
+	return f.NewUnscopedHelperName("__importStar")
+}
+
 // Allocates a new Call expression to the `__exportStar` helper.
 func (f *NodeFactory) NewExportStarHelper(moduleExpression *ast.Expression, exportsExpression *ast.Expression) *ast.Expression {
 	f.emitContext.RequestEmitHelper(exportStarHelper)
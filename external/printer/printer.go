@@ -177,6 +177,9 @@ func NewPrinter(options PrinterOptions, handlers PrintHandlers, emitContext *Emi
 	printer.nameGenerator.Context = printer.emitContext
 	printer.nameGenerator.GetTextOfNode = func(node *ast.Node) string { return printer.getTextOfNode(node, false) }
 	printer.nameGenerator.IsFileLevelUniqueNameInCurrentFile = printer.isFileLevelUniqueNameInCurrentFile
+	printer.makeFileLevelOptimisticUniqueName = func(name string) string {
+		return printer.nameGenerator.makeUniqueName(name, printer.isFileLevelUniqueNameInCurrentFile, true /*optimistic*/, false /*scoped*/, false /*privateName*/, "" /*prefix*/, "" /*suffix*/)
+	}
 	printer.containerPos = -1
 	printer.containerEnd = -1
 	printer.declarationListContainerEnd = -1
@@ -785,7 +788,9 @@ func (p *Printer) shouldEmitBlockFunctionBodyOnSingleLine(body *ast.Block) bool
 }
 
 func (p *Printer) shouldEmitOnNewLine(node *ast.Node, format ListFormat) bool {
-	// !!! if startsOnNewLine := getStartsOnNewLine(node); startsOnNewLine != nil { return *startsOnNewLine }
+	if p.emitContext.EmitFlags(node)&EFStartOnNewLine != 0 {
+		return true
+	}
 	return format&LFPreferNewLine != 0
 }
 
@@ -1557,7 +1562,7 @@ func (p *Printer) emitFunctionBody(body *ast.Block) {
 		p.emitList((*Printer).emitStatement, body.AsNode(), body.Statements, LFSingleLineFunctionBodyStatements)
 		p.increaseIndent()
 	} else {
-		p.emitList((*Printer).emitStatement, body.AsNode(), body.Statements, LFMultiLineFunctionBodyStatements)
+		p.emitListRange((*Printer).emitStatement, body.AsNode(), body.Statements, LFMultiLineFunctionBodyStatements, statementOffset, -1 /*count*/)
 	}
 
 	p.emitDetachedCommentsAfterStatementList(body.AsNode(), body.Statements.Loc, detachedState)
@@ -5156,11 +5161,42 @@ func (p *Printer) emitTrailingCommentsOfNode(node *ast.Node, emitFlags EmitFlags
 }
 
 func (p *Printer) emitLeadingSyntheticCommentsOfNode(node *ast.Node) {
-	// !!!
+	for _, comment := range p.emitContext.SyntheticLeadingComments(node) {
+		if comment.HasLeadingNewLine || comment.Kind == ast.KindSingleLineCommentTrivia {
+			p.writeLine()
+		}
+		p.writeSynthesizedComment(comment)
+		if comment.HasTrailingNewLine || comment.Kind == ast.KindSingleLineCommentTrivia {
+			p.writeLine()
+		} else {
+			p.writeSpace()
+		}
+	}
 }
 
 func (p *Printer) emitTrailingSyntheticCommentsOfNode(node *ast.Node) {
-	// !!!
+	for _, comment := range p.emitContext.SyntheticTrailingComments(node) {
+		if !p.writer.IsAtStartOfLine() {
+			p.writeSpace()
+		}
+		p.writeSynthesizedComment(comment)
+		if comment.HasTrailingNewLine {
+			p.writeLine()
+		}
+	}
+}
+
+func (p *Printer) writeSynthesizedComment(comment SynthesizedComment) {
+	if comment.Kind == ast.KindMultiLineCommentTrivia {
+		for i, line := range stringutil.SplitLines("/*" + comment.Text + "*/") {
+			if i > 0 {
+				p.writeLine()
+			}
+			p.writeComment(line)
+		}
+	} else {
+		p.writeComment("//" + comment.Text)
+	}
 }
 
 func (p *Printer) emitLeadingComments(pos int, elided bool) bool {
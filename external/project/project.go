@@ -13,6 +13,7 @@ import (
 	"github.com/pagpeter/typescript-go/external/collections"
 	"github.com/pagpeter/typescript-go/external/compiler"
 	"github.com/pagpeter/typescript-go/external/core"
+	"github.com/pagpeter/typescript-go/external/diagnostics"
 	"github.com/pagpeter/typescript-go/external/ls"
 	"github.com/pagpeter/typescript-go/external/lsp/lsproto"
 	"github.com/pagpeter/typescript-go/external/module"
@@ -41,6 +42,7 @@ const (
 type snapshot struct {
 	project          *Project
 	positionEncoding lsproto.PositionEncodingKind
+	locale           *diagnostics.Locale
 	program          *compiler.Program
 }
 
@@ -59,6 +61,11 @@ func (s *snapshot) GetPositionEncoding() lsproto.PositionEncodingKind {
 	return s.positionEncoding
 }
 
+// GetLocale implements ls.Host.
+func (s *snapshot) GetLocale() *diagnostics.Locale {
+	return s.locale
+}
+
 // GetProgram implements ls.Host.
 func (s *snapshot) GetProgram() *compiler.Program {
 	return s.program
@@ -84,6 +91,7 @@ type ProjectHost interface {
 	ConfigFileRegistry() *ConfigFileRegistry
 	Log(s string)
 	PositionEncoding() lsproto.PositionEncodingKind
+	Locale() *diagnostics.Locale
 
 	IsWatchEnabled() bool
 	Client() Client
@@ -337,6 +345,7 @@ func (p *Project) GetLanguageServiceForRequest(ctx context.Context) (*ls.Languag
 	snapshot := &snapshot{
 		project:          p,
 		positionEncoding: p.host.PositionEncoding(),
+		locale:           p.host.Locale(),
 		program:          program,
 	}
 	languageService := ls.NewLanguageService(ctx, snapshot)
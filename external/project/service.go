@@ -11,6 +11,7 @@ import (
 
 	"github.com/pagpeter/typescript-go/external/collections"
 	"github.com/pagpeter/typescript-go/external/core"
+	"github.com/pagpeter/typescript-go/external/diagnostics"
 	"github.com/pagpeter/typescript-go/external/ls"
 	"github.com/pagpeter/typescript-go/external/lsp/lsproto"
 	"github.com/pagpeter/typescript-go/external/tspath"
@@ -30,6 +31,7 @@ type ServiceOptions struct {
 	TypingsInstallerOptions
 	Logger           *Logger
 	PositionEncoding lsproto.PositionEncodingKind
+	Locale           *diagnostics.Locale
 	WatchEnabled     bool
 
 	ParsedFileCache ParsedFileCache
@@ -168,6 +170,11 @@ func (s *Service) PositionEncoding() lsproto.PositionEncodingKind {
 	return s.options.PositionEncoding
 }
 
+// Locale implements ProjectHost.
+func (s *Service) Locale() *diagnostics.Locale {
+	return s.options.Locale
+}
+
 // Client implements ProjectHost.
 func (s *Service) Client() Client {
 	return s.host.Client()
@@ -508,7 +508,7 @@ func (h *cachedCompilerHost) GetSourceFile(opts ast.SourceFileParseOptions) *ast
 
 func createCompilerHost(fs vfs.FS, defaultLibraryPath string, options *core.CompilerOptions, currentDirectory string) compiler.CompilerHost {
 	return &cachedCompilerHost{
-		CompilerHost: compiler.NewCompilerHost(options, currentDirectory, fs, defaultLibraryPath, nil),
+		CompilerHost: compiler.NewCompilerHost(options, currentDirectory, fs, defaultLibraryPath, nil, nil),
 	}
 }
 
@@ -584,7 +584,7 @@ func compileFilesWithHost(
 	if harnessOptions.CaptureSuggestions {
 		diagnostics = append(diagnostics, program.GetSuggestionDiagnostics(ctx, nil)...)
 	}
-	emitResult := program.Emit(compiler.EmitOptions{})
+	emitResult := program.Emit(ctx, compiler.EmitOptions{})
 
 	return newCompilationResult(config.CompilerOptions(), program, emitResult, diagnostics, harnessOptions)
 }
@@ -0,0 +1,444 @@
+// Package tracing records the event traces written by --generateTrace.
+//
+// A trace is a trace.json file in the Chrome trace event format, which can be loaded into
+// chrome://tracing or Perfetto and analyzed with @typescript/analyze-trace, along with a types.json
+// file that describes the types referenced by the events.
+package tracing
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pagpeter/typescript-go/external/tspath"
+	"github.com/pagpeter/typescript-go/external/vfs"
+)
+
+type Phase string
+
+const (
+	PhaseParse      Phase = "parse"
+	PhaseProgram    Phase = "program"
+	PhaseBind       Phase = "bind"
+	PhaseCheck      Phase = "check"      // Before we get into checking types (e.g. checkSourceFile)
+	PhaseCheckTypes Phase = "checkTypes" // Checking types (e.g. structuredTypeRelatedTo)
+	PhaseEmit       Phase = "emit"
+	PhaseSession    Phase = "session"
+)
+
+// Args are the arguments of an event, shown alongside it in trace viewers.
+type Args map[string]any
+
+// Events that do not have separate begin and end events are only written when they straddle a
+// sampling point, which keeps the size of traces manageable.
+const sampleInterval = 10 * time.Millisecond
+
+// Timestamps are relative to the start of the process, so that the traces of the projects of a
+// build can be compared.
+var processStart = time.Now()
+
+func timestamp() int64 {
+	return time.Since(processStart).Microseconds()
+}
+
+// Tracing collects the events of a single compilation. All methods are safe for concurrent use,
+// and are no-ops on a nil *Tracing so that callers need not check whether tracing is enabled.
+type Tracing struct {
+	fs             vfs.FS
+	configFilePath string
+	tracePath      string
+	typesPath      string
+	legend         *Legend
+	legendIndex    int
+
+	mu          sync.Mutex
+	threads     []*Thread
+	idleWorkers []*Thread
+	workerCount int
+	events      []*event
+
+	main *Thread
+}
+
+// Start begins tracing a compilation into traceDir. In build mode, legend is shared by all the
+// compilations of the build: each writes numbered trace and types files, listed in legend.json.
+func Start(fs vfs.FS, traceDir string, configFilePath string, legend *Legend) *Tracing {
+	t := &Tracing{
+		fs:             fs,
+		configFilePath: configFilePath,
+		legend:         legend,
+	}
+	var suffix string
+	if legend != nil {
+		t.legendIndex = legend.next()
+		suffix = "." + strconv.Itoa(t.legendIndex)
+	}
+	t.tracePath = tspath.CombinePaths(traceDir, "trace"+suffix+".json")
+	t.typesPath = tspath.CombinePaths(traceDir, "types"+suffix+".json")
+	t.main = t.NewThread("Main")
+	return t
+}
+
+// Main returns the thread of the events that are not specific to a file, such as createProgram.
+func (t *Tracing) Main() *Thread {
+	if t == nil {
+		return nil
+	}
+	return t.main
+}
+
+// NewThread creates a thread of events that are never concurrent with each other, such as the
+// events of a single checker. Spans of a thread must be properly nested.
+func (t *Tracing) NewThread(name string) *Thread {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	th := &Thread{tracing: t, tid: len(t.threads) + 1, name: name}
+	t.threads = append(t.threads, th)
+	return th
+}
+
+// Begin starts a span on a worker thread, for work that runs concurrently with other work of the
+// same kind, such as parsing, binding or emitting a file. The span must be ended with [Span.End].
+func (t *Tracing) Begin(phase Phase, name string, args Args) *Span {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	var th *Thread
+	if n := len(t.idleWorkers); n > 0 {
+		th = t.idleWorkers[n-1]
+		t.idleWorkers = t.idleWorkers[:n-1]
+		t.mu.Unlock()
+	} else {
+		t.workerCount++
+		workerName := "Worker " + strconv.Itoa(t.workerCount)
+		t.mu.Unlock()
+		th = t.NewThread(workerName)
+	}
+	th.Push(phase, name, args, true /*separateBeginAndEnd*/)
+	return &Span{thread: th}
+}
+
+func (t *Tracing) writeEvent(e *event) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.events = append(t.events, e)
+}
+
+// Stop ends tracing and writes the trace and types files.
+func (t *Tracing) Stop() error {
+	if t == nil {
+		return nil
+	}
+	t.main.popAll()
+
+	// Type ids are only unique within a checker; renumber them so that the types of all checkers
+	// form a single list, in which the type with id n is at index n-1. Describing types may run
+	// more of the checker, and thus write events, so this is done without holding the lock.
+	t.mu.Lock()
+	threads := slices.Clone(t.threads)
+	t.mu.Unlock()
+	var types []*TypeDescriptor
+	for _, th := range threads {
+		if th.describeTypes == nil {
+			continue
+		}
+		th.typeIdOffset = uint32(len(types))
+		types = append(types, th.describeTypes()...)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var b strings.Builder
+	b.WriteString("[\n")
+	ts := t.threads[0].startTime
+	writeJSON(&b, &event{Name: "process_name", Cat: "__metadata", Ph: "M", Ts: ts, Pid: 1, Tid: 1, Args: Args{"name": "tsc"}})
+	for _, th := range t.threads {
+		b.WriteString(",\n")
+		writeJSON(&b, &event{Name: "thread_name", Cat: "__metadata", Ph: "M", Ts: th.startTime, Pid: 1, Tid: th.tid, Args: Args{"name": th.name}})
+	}
+	b.WriteString(",\n")
+	writeJSON(&b, &event{Name: "TracingStartedInBrowser", Cat: "disabled-by-default-devtools.timeline", Ph: "M", Ts: ts, Pid: 1, Tid: 1})
+	for _, e := range t.events {
+		b.WriteString(",\n")
+		writeJSON(&b, e)
+	}
+	b.WriteString("\n]\n")
+	if err := t.fs.WriteFile(t.tracePath, b.String(), false); err != nil {
+		return &WriteError{Path: t.tracePath, Err: err}
+	}
+
+	b.Reset()
+	b.WriteString("[")
+	for i, descriptor := range types {
+		if i > 0 {
+			b.WriteString(",\n")
+		}
+		writeJSON(&b, descriptor)
+	}
+	b.WriteString("]\n")
+	if err := t.fs.WriteFile(t.typesPath, b.String(), false); err != nil {
+		return &WriteError{Path: t.typesPath, Err: err}
+	}
+
+	if t.legend != nil {
+		return t.legend.write(t.fs, legendEntry{
+			index:          t.legendIndex,
+			ConfigFilePath: t.configFilePath,
+			TracePath:      t.tracePath,
+			TypesPath:      t.typesPath,
+		}, tspath.GetDirectoryPath(t.tracePath))
+	}
+	return nil
+}
+
+func writeJSON(b *strings.Builder, value any) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		panic(err)
+	}
+	b.Write(bytes.TrimSuffix(buf.Bytes(), []byte("\n")))
+}
+
+// WriteError reports a trace file that could not be written.
+type WriteError struct {
+	Path string
+	Err  error
+}
+
+func (e *WriteError) Error() string { return e.Err.Error() }
+func (e *WriteError) Unwrap() error { return e.Err }
+
+type event struct {
+	Pid  int    `json:"pid"`
+	Tid  int    `json:"tid"`
+	Ph   string `json:"ph"`
+	Cat  string `json:"cat"`
+	Ts   int64  `json:"ts"`
+	Name string `json:"name"`
+	Dur  int64  `json:"dur,omitempty"`
+	Args Args   `json:"args,omitempty"`
+}
+
+type stackEntry struct {
+	phase               Phase
+	name                string
+	args                Args
+	time                int64
+	separateBeginAndEnd bool
+}
+
+// Thread is a sequence of events on one trace viewer track. Methods are no-ops on a nil *Thread.
+type Thread struct {
+	tracing   *Tracing
+	tid       int
+	name      string
+	startTime int64
+	stack     []stackEntry
+
+	describeTypes func() []*TypeDescriptor
+	typeIdOffset  uint32
+}
+
+// Push begins an event, which lasts until the matching call to [Thread.Pop]. Events with separate
+// begin and end are always written; other events are only written if they straddle a sampling
+// point, as a single complete event.
+func (th *Thread) Push(phase Phase, name string, args Args, separateBeginAndEnd bool) {
+	if th == nil {
+		return
+	}
+	time := timestamp()
+	if len(th.stack) == 0 && th.startTime == 0 {
+		th.startTime = time
+	}
+	if separateBeginAndEnd {
+		th.writeEvent("B", phase, name, args, 0, time)
+	}
+	th.stack = append(th.stack, stackEntry{phase: phase, name: name, args: args, time: time, separateBeginAndEnd: separateBeginAndEnd})
+}
+
+// Pop ends the event begun by the most recent call to [Thread.Push].
+func (th *Thread) Pop() {
+	if th == nil {
+		return
+	}
+	th.writeStackEvent(len(th.stack)-1, timestamp())
+	th.stack = th.stack[:len(th.stack)-1]
+}
+
+func (th *Thread) popAll() {
+	endTime := timestamp()
+	for i := len(th.stack) - 1; i >= 0; i-- {
+		th.writeStackEvent(i, endTime)
+	}
+	th.stack = th.stack[:0]
+}
+
+func (th *Thread) writeStackEvent(index int, endTime int64) {
+	entry := th.stack[index]
+	if entry.separateBeginAndEnd {
+		th.writeEvent("E", entry.phase, entry.name, entry.args, 0, endTime)
+	} else if interval := sampleInterval.Microseconds(); interval-(entry.time%interval) <= endTime-entry.time {
+		// [time, endTime) straddles a sampling point
+		th.writeEvent("X", entry.phase, entry.name, entry.args, max(endTime-entry.time, 1), entry.time)
+	}
+}
+
+// Instant writes an event without a duration.
+func (th *Thread) Instant(phase Phase, name string, args Args) {
+	if th == nil {
+		return
+	}
+	th.writeEvent("I", phase, name, args, 0, timestamp())
+}
+
+func (th *Thread) writeEvent(ph string, phase Phase, name string, args Args, dur int64, time int64) {
+	th.tracing.writeEvent(&event{Pid: 1, Tid: th.tid, Ph: ph, Cat: string(phase), Ts: time, Name: name, Dur: dur, Args: args})
+}
+
+// SetTypeDescriber registers the function that describes the types referenced by the events of
+// this thread when the trace is written.
+func (th *Thread) SetTypeDescriber(describeTypes func() []*TypeDescriptor) {
+	if th == nil {
+		return
+	}
+	th.describeTypes = describeTypes
+}
+
+// TypeId refers to a type in the events and type descriptors of th.
+func (th *Thread) TypeId(id uint32) TypeId {
+	return TypeId{thread: th, id: id}
+}
+
+// TypeIds refers to a list of types in the events and type descriptors of th.
+func (th *Thread) TypeIds(ids []uint32) []TypeId {
+	result := make([]TypeId, len(ids))
+	for i, id := range ids {
+		result[i] = th.TypeId(id)
+	}
+	return result
+}
+
+// Span is an event on a worker thread begun by [Tracing.Begin]. Methods are no-ops on a nil *Span.
+type Span struct {
+	thread *Thread
+}
+
+// End ends the span and makes its worker thread available to other spans.
+func (s *Span) End() {
+	if s == nil {
+		return
+	}
+	s.thread.Pop()
+	t := s.thread.tracing
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.idleWorkers = append(t.idleWorkers, s.thread)
+}
+
+// TypeId identifies a type in a trace. Ids are only unique within a checker, so they are renumbered
+// when the trace is written.
+type TypeId struct {
+	thread *Thread
+	id     uint32
+}
+
+func (id TypeId) IsZero() bool {
+	return id.thread == nil
+}
+
+func (id TypeId) MarshalJSON() ([]byte, error) {
+	return strconv.AppendUint(nil, uint64(id.thread.typeIdOffset+id.id), 10), nil
+}
+
+// LineAndCharacter is a one-based position in a file.
+type LineAndCharacter struct {
+	Line      int `json:"line"`
+	Character int `json:"character"`
+}
+
+// Location is a range in a file.
+type Location struct {
+	Path  string           `json:"path"`
+	Start LineAndCharacter `json:"start"`
+	End   LineAndCharacter `json:"end"`
+}
+
+// TypeDescriptor describes a type in types.json.
+type TypeDescriptor struct {
+	Id                          TypeId    `json:"id"`
+	IntrinsicName               string    `json:"intrinsicName,omitempty"`
+	SymbolName                  string    `json:"symbolName,omitempty"`
+	RecursionId                 *int      `json:"recursionId,omitempty"`
+	IsTuple                     bool      `json:"isTuple,omitempty"`
+	UnionTypes                  []TypeId  `json:"unionTypes,omitempty"`
+	IntersectionTypes           []TypeId  `json:"intersectionTypes,omitempty"`
+	AliasTypeArguments          []TypeId  `json:"aliasTypeArguments,omitempty"`
+	KeyofType                   TypeId    `json:"keyofType,omitzero"`
+	IndexedAccessObjectType     TypeId    `json:"indexedAccessObjectType,omitzero"`
+	IndexedAccessIndexType      TypeId    `json:"indexedAccessIndexType,omitzero"`
+	InstantiatedType            TypeId    `json:"instantiatedType,omitzero"`
+	TypeArguments               []TypeId  `json:"typeArguments,omitempty"`
+	ReferenceLocation           *Location `json:"referenceLocation,omitempty"`
+	ConditionalCheckType        TypeId    `json:"conditionalCheckType,omitzero"`
+	ConditionalExtendsType      TypeId    `json:"conditionalExtendsType,omitzero"`
+	ConditionalTrueType         TypeId    `json:"conditionalTrueType,omitzero"`
+	ConditionalFalseType        TypeId    `json:"conditionalFalseType,omitzero"`
+	SubstitutionBaseType        TypeId    `json:"substitutionBaseType,omitzero"`
+	ConstraintType              TypeId    `json:"constraintType,omitzero"`
+	ReverseMappedSourceType     TypeId    `json:"reverseMappedSourceType,omitzero"`
+	ReverseMappedMappedType     TypeId    `json:"reverseMappedMappedType,omitzero"`
+	ReverseMappedConstraintType TypeId    `json:"reverseMappedConstraintType,omitzero"`
+	EvolvingArrayElementType    TypeId    `json:"evolvingArrayElementType,omitzero"`
+	EvolvingArrayFinalType      TypeId    `json:"evolvingArrayFinalType,omitzero"`
+	FirstDeclaration            *Location `json:"firstDeclaration,omitempty"`
+	Flags                       []string  `json:"flags"`
+	Display                     string    `json:"display,omitempty"`
+}
+
+// Legend lists the trace files of the compilations of a build, so that they can be told apart.
+type Legend struct {
+	mu      sync.Mutex
+	count   int
+	entries []legendEntry
+}
+
+type legendEntry struct {
+	index          int
+	ConfigFilePath string `json:"configFilePath"`
+	TracePath      string `json:"tracePath"`
+	TypesPath      string `json:"typesPath"`
+}
+
+func (l *Legend) next() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.count++
+	return l.count
+}
+
+func (l *Legend) write(fs vfs.FS, entry legendEntry, traceDir string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries = append(l.entries, entry)
+	slices.SortFunc(l.entries, func(a, b legendEntry) int { return a.index - b.index })
+	data, err := json.Marshal(l.entries)
+	if err != nil {
+		panic(err)
+	}
+	legendPath := tspath.CombinePaths(traceDir, "legend.json")
+	if err := fs.WriteFile(legendPath, string(data), false); err != nil {
+		return &WriteError{Path: legendPath, Err: err}
+	}
+	return nil
+}
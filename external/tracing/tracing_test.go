@@ -0,0 +1,125 @@
+package tracing_test
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"github.com/pagpeter/typescript-go/external/tracing"
+	"github.com/pagpeter/typescript-go/external/vfs"
+	"github.com/pagpeter/typescript-go/external/vfs/vfstest"
+	"gotest.tools/v3/assert"
+)
+
+type traceEvent struct {
+	Pid  int            `json:"pid"`
+	Tid  int            `json:"tid"`
+	Ph   string         `json:"ph"`
+	Cat  string         `json:"cat"`
+	Name string         `json:"name"`
+	Args map[string]any `json:"args"`
+}
+
+func readJSON(t *testing.T, fs vfs.FS, path string, value any) {
+	t.Helper()
+	contents, ok := fs.ReadFile(path)
+	assert.Assert(t, ok, "missing %s", path)
+	assert.NilError(t, json.Unmarshal([]byte(contents), value))
+}
+
+func describeTypes(th *tracing.Thread, count int) func() []*tracing.TypeDescriptor {
+	return func() []*tracing.TypeDescriptor {
+		result := make([]*tracing.TypeDescriptor, count)
+		for i := range result {
+			result[i] = &tracing.TypeDescriptor{Id: th.TypeId(uint32(i + 1)), Flags: []string{"Object"}}
+		}
+		return result
+	}
+}
+
+func TestTrace(t *testing.T) {
+	t.Parallel()
+	fs := vfstest.FromMap(map[string]string{"/src/tsconfig.json": "{}"}, false /*useCaseSensitiveFileNames*/)
+	tr := tracing.Start(fs, "/trace", "/src/tsconfig.json", nil)
+
+	tr.Main().Push(tracing.PhaseProgram, "createProgram", tracing.Args{"configFilePath": "/src/tsconfig.json"}, true /*separateBeginAndEnd*/)
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			span := tr.Begin(tracing.PhaseParse, "createSourceFile", tracing.Args{"path": "/src/a.ts"})
+			span.End()
+		}()
+	}
+	wg.Wait()
+	tr.Main().Pop()
+
+	checkers := make([]*tracing.Thread, 2)
+	for i := range checkers {
+		th := tr.NewThread("Checker")
+		th.SetTypeDescriber(describeTypes(th, 3))
+		th.Push(tracing.PhaseCheck, "checkSourceFile", tracing.Args{"path": "/src/a.ts"}, true /*separateBeginAndEnd*/)
+		th.Instant(tracing.PhaseCheckTypes, "checkTypeRelatedTo_DepthLimit", tracing.Args{"sourceId": th.TypeId(2)})
+		th.Pop()
+		checkers[i] = th
+	}
+	assert.NilError(t, tr.Stop())
+
+	var events []traceEvent
+	readJSON(t, fs, "/trace/trace.json", &events)
+	assert.Equal(t, events[0].Name, "process_name")
+	assert.Equal(t, events[0].Args["name"], "tsc")
+
+	threadNames := make(map[int]string)
+	depths := make(map[int]int)
+	var sourceIds []float64
+	for _, e := range events {
+		switch e.Ph {
+		case "M":
+			if e.Name == "thread_name" {
+				threadNames[e.Tid] = e.Args["name"].(string)
+			}
+		case "B":
+			depths[e.Tid]++
+		case "E":
+			depths[e.Tid]--
+			assert.Assert(t, depths[e.Tid] >= 0, "unbalanced end event on thread %d", e.Tid)
+		case "I":
+			sourceIds = append(sourceIds, e.Args["sourceId"].(float64))
+		}
+	}
+	for tid, depth := range depths {
+		assert.Equal(t, depth, 0, "unbalanced begin event on thread %d", tid)
+		assert.Assert(t, threadNames[tid] != "", "unnamed thread %d", tid)
+	}
+	assert.Equal(t, threadNames[1], "Main")
+	// Type ids of the second checker follow those of the first.
+	assert.DeepEqual(t, sourceIds, []float64{2, 5})
+
+	var types []struct {
+		Id int `json:"id"`
+	}
+	readJSON(t, fs, "/trace/types.json", &types)
+	assert.Equal(t, len(types), 6)
+	for i, descriptor := range types {
+		assert.Equal(t, descriptor.Id, i+1)
+	}
+}
+
+func TestTraceLegend(t *testing.T) {
+	t.Parallel()
+	fs := vfstest.FromMap(map[string]string{}, false /*useCaseSensitiveFileNames*/)
+	var legend tracing.Legend
+	first := tracing.Start(fs, "/trace", "/src/a/tsconfig.json", &legend)
+	second := tracing.Start(fs, "/trace", "/src/b/tsconfig.json", &legend)
+	assert.NilError(t, second.Stop())
+	assert.NilError(t, first.Stop())
+
+	var entries []map[string]string
+	readJSON(t, fs, "/trace/legend.json", &entries)
+	assert.DeepEqual(t, entries, []map[string]string{
+		{"configFilePath": "/src/a/tsconfig.json", "tracePath": "/trace/trace.1.json", "typesPath": "/trace/types.1.json"},
+		{"configFilePath": "/src/b/tsconfig.json", "tracePath": "/trace/trace.2.json", "typesPath": "/trace/types.2.json"},
+	})
+}
@@ -2,19 +2,974 @@ package estransforms
 
 import (
 	"github.com/pagpeter/typescript-go/external/ast"
+	"github.com/pagpeter/typescript-go/external/collections"
+	"github.com/pagpeter/typescript-go/external/core"
 	"github.com/pagpeter/typescript-go/external/printer"
 	"github.com/pagpeter/typescript-go/external/transformers"
 )
 
+type asyncContextFlags int
+
+const (
+	asyncContextFlagsNone        asyncContextFlags = 0
+	asyncContextFlagsNonTopLevel asyncContextFlags = 1 << iota
+	asyncContextFlagsHasLexicalThis
+)
+
+type superAccessFlags int
+
+const (
+	superAccessFlagsNone       superAccessFlags = 0
+	superAccessFlagsAccess     superAccessFlags = 1 << iota // The method reads from `super` within an async function or arrow.
+	superAccessFlagsAssignment                              // The method assigns to `super` within an async function or arrow.
+)
+
 type asyncTransformer struct {
 	transformers.Transformer
+	compilerOptions  *core.CompilerOptions
+	asyncBodyVisitor *ast.NodeVisitor // visits the body of an async function, hoisting declarations that collide with parameter names
+	argumentsVisitor *ast.NodeVisitor // replaces references to `arguments` with the captured arguments binding
+
+	contextFlags                    asyncContextFlags
+	enclosingFunctionParameterNames *collections.Set[string]
+	capturedSuperProperties         *collections.OrderedSet[string]
+	hasSuperElementAccess           bool
+	enclosingSuperFlags             superAccessFlags
+	lexicalArgumentsBinding         *ast.IdentifierNode
+}
+
+func newAsyncTransformer(opts *transformers.TransformOptions) *transformers.Transformer {
+	tx := &asyncTransformer{compilerOptions: opts.CompilerOptions}
+	result := tx.NewTransformer(tx.visit, opts.Context)
+	tx.asyncBodyVisitor = tx.EmitContext().NewNodeVisitor(tx.visitAsyncBody)
+	tx.argumentsVisitor = tx.EmitContext().NewNodeVisitor(tx.visitArguments)
+	return result
+}
+
+func (tx *asyncTransformer) visit(node *ast.Node) *ast.Node {
+	if node.SubtreeFacts()&(ast.SubtreeContainsAnyAwait|ast.SubtreeContainsAwait) == 0 {
+		if tx.lexicalArgumentsBinding != nil {
+			return tx.visitArguments(node)
+		}
+		return node
+	}
+
+	switch node.Kind {
+	case ast.KindSourceFile:
+		return tx.visitSourceFile(node.AsSourceFile())
+	case ast.KindAwaitExpression:
+		return tx.visitAwaitExpression(node.AsAwaitExpression())
+	case ast.KindMethodDeclaration:
+		return doWithContext(tx, asyncContextFlagsNonTopLevel|asyncContextFlagsHasLexicalThis, tx.visitMethodDeclaration, node.AsMethodDeclaration())
+	case ast.KindFunctionDeclaration:
+		return doWithContext(tx, asyncContextFlagsNonTopLevel|asyncContextFlagsHasLexicalThis, tx.visitFunctionDeclaration, node.AsFunctionDeclaration())
+	case ast.KindFunctionExpression:
+		return doWithContext(tx, asyncContextFlagsNonTopLevel|asyncContextFlagsHasLexicalThis, tx.visitFunctionExpression, node.AsFunctionExpression())
+	case ast.KindArrowFunction:
+		return doWithContext(tx, asyncContextFlagsNonTopLevel, tx.visitArrowFunction, node.AsArrowFunction())
+	case ast.KindGetAccessor:
+		return doWithContext(tx, asyncContextFlagsNonTopLevel|asyncContextFlagsHasLexicalThis, tx.visitGetAccessorDeclaration, node.AsGetAccessorDeclaration())
+	case ast.KindSetAccessor:
+		return doWithContext(tx, asyncContextFlagsNonTopLevel|asyncContextFlagsHasLexicalThis, tx.visitSetAccessorDeclaration, node.AsSetAccessorDeclaration())
+	case ast.KindConstructor:
+		return doWithContext(tx, asyncContextFlagsNonTopLevel|asyncContextFlagsHasLexicalThis, tx.visitConstructorDeclaration, node.AsConstructorDeclaration())
+	case ast.KindClassDeclaration, ast.KindClassExpression:
+		return doWithContext(tx, asyncContextFlagsNonTopLevel|asyncContextFlagsHasLexicalThis, tx.visitClassLike, node)
+	case ast.KindPropertyAccessExpression:
+		return tx.visitPropertyAccessExpression(node.AsPropertyAccessExpression())
+	case ast.KindElementAccessExpression:
+		return tx.visitElementAccessExpression(node.AsElementAccessExpression())
+	case ast.KindCallExpression:
+		return tx.visitCallExpression(node.AsCallExpression())
+	default:
+		return tx.Visitor().VisitEachChild(node)
+	}
+}
+
+func doWithContext[T any](tx *asyncTransformer, flags asyncContextFlags, cb func(T) *ast.Node, value T) *ast.Node {
+	savedContextFlags := tx.contextFlags
+	tx.contextFlags |= flags
+	result := cb(value)
+	tx.contextFlags = savedContextFlags
+	return result
+}
+
+func (tx *asyncTransformer) inTopLevelContext() bool {
+	return tx.contextFlags&asyncContextFlagsNonTopLevel == 0
+}
+
+func (tx *asyncTransformer) inHasLexicalThisContext() bool {
+	return tx.contextFlags&asyncContextFlagsHasLexicalThis != 0
+}
+
+func (tx *asyncTransformer) visitSourceFile(node *ast.SourceFile) *ast.Node {
+	if node.IsDeclarationFile {
+		return node.AsNode()
+	}
+
+	tx.contextFlags = asyncContextFlagsNone
+	if !isEffectiveStrictModeSourceFile(node, tx.compilerOptions) {
+		tx.contextFlags |= asyncContextFlagsHasLexicalThis
+	}
+
+	visited := tx.Visitor().VisitEachChild(node.AsNode())
+	tx.EmitContext().AddEmitHelper(visited, tx.EmitContext().ReadEmitHelpers()...)
+	return visited
+}
+
+// Replaces references to `arguments` with the captured arguments binding. This is only used for subtrees that contain
+// no other ES2017 syntax.
+func (tx *asyncTransformer) visitArguments(node *ast.Node) *ast.Node {
+	switch node.Kind {
+	case ast.KindFunctionExpression,
+		ast.KindFunctionDeclaration,
+		ast.KindMethodDeclaration,
+		ast.KindGetAccessor,
+		ast.KindSetAccessor,
+		ast.KindConstructor:
+		return node
+	case ast.KindIdentifier:
+		if tx.lexicalArgumentsBinding != nil && isArgumentsReference(tx.EmitContext(), node) {
+			return tx.lexicalArgumentsBinding
+		}
+		return node
+	}
+	return tx.argumentsVisitor.VisitEachChild(node)
+}
+
+func (tx *asyncTransformer) visitAsyncBody(node *ast.Node) *ast.Node {
+	switch node.Kind {
+	case ast.KindVariableStatement:
+		return tx.visitVariableStatementInAsyncBody(node.AsVariableStatement())
+	case ast.KindForStatement:
+		return tx.visitForStatementInAsyncBody(node.AsForStatement())
+	case ast.KindForInStatement, ast.KindForOfStatement:
+		return tx.visitForInOrOfStatementInAsyncBody(node.AsForInOrOfStatement())
+	case ast.KindCatchClause:
+		return tx.visitCatchClauseInAsyncBody(node.AsCatchClause())
+	case ast.KindBlock,
+		ast.KindSwitchStatement,
+		ast.KindCaseBlock,
+		ast.KindCaseClause,
+		ast.KindDefaultClause,
+		ast.KindTryStatement,
+		ast.KindDoStatement,
+		ast.KindWhileStatement,
+		ast.KindIfStatement,
+		ast.KindWithStatement,
+		ast.KindLabeledStatement:
+		return tx.asyncBodyVisitor.VisitEachChild(node)
+	default:
+		return tx.visit(node)
+	}
+}
+
+func (tx *asyncTransformer) visitCatchClauseInAsyncBody(node *ast.CatchClause) *ast.Node {
+	if node.VariableDeclaration == nil {
+		return tx.asyncBodyVisitor.VisitEachChild(node.AsNode())
+	}
+
+	// names declared in a catch variable are block scoped
+	catchClauseNames := &collections.Set[string]{}
+	recordDeclarationName(node.VariableDeclaration.Name(), catchClauseNames)
+
+	var catchClauseUnshadowedNames *collections.Set[string]
+	for name := range catchClauseNames.Keys() {
+		if tx.enclosingFunctionParameterNames.Has(name) {
+			if catchClauseUnshadowedNames == nil {
+				catchClauseUnshadowedNames = tx.enclosingFunctionParameterNames.Clone()
+			}
+			catchClauseUnshadowedNames.Delete(name)
+		}
+	}
+
+	if catchClauseUnshadowedNames == nil {
+		return tx.asyncBodyVisitor.VisitEachChild(node.AsNode())
+	}
+
+	savedEnclosingFunctionParameterNames := tx.enclosingFunctionParameterNames
+	tx.enclosingFunctionParameterNames = catchClauseUnshadowedNames
+	result := tx.asyncBodyVisitor.VisitEachChild(node.AsNode())
+	tx.enclosingFunctionParameterNames = savedEnclosingFunctionParameterNames
+	return result
+}
+
+func (tx *asyncTransformer) visitVariableStatementInAsyncBody(node *ast.VariableStatement) *ast.Node {
+	if tx.isVariableDeclarationListWithCollidingName(node.DeclarationList) {
+		expression := tx.visitVariableDeclarationListWithCollidingNames(node.DeclarationList.AsVariableDeclarationList(), false /*hasReceiver*/)
+		if expression == nil {
+			return nil
+		}
+		statement := tx.Factory().NewExpressionStatement(expression)
+		tx.EmitContext().SetOriginal(statement, node.AsNode())
+		statement.Loc = node.Loc
+		return statement
+	}
+	return tx.Visitor().VisitEachChild(node.AsNode())
+}
+
+func (tx *asyncTransformer) visitForInOrOfStatementInAsyncBody(node *ast.ForInOrOfStatement) *ast.Node {
+	var initializer *ast.ForInitializer
+	if tx.isVariableDeclarationListWithCollidingName(node.Initializer) {
+		initializer = tx.visitVariableDeclarationListWithCollidingNames(node.Initializer.AsVariableDeclarationList(), true /*hasReceiver*/)
+	} else {
+		initializer = tx.Visitor().VisitNode(node.Initializer)
+	}
+	return tx.Factory().UpdateForInOrOfStatement(
+		node,
+		node.AwaitModifier,
+		initializer,
+		tx.Visitor().VisitNode(node.Expression),
+		tx.EmitContext().VisitIterationBody(node.Statement, tx.asyncBodyVisitor),
+	)
+}
+
+func (tx *asyncTransformer) visitForStatementInAsyncBody(node *ast.ForStatement) *ast.Node {
+	var initializer *ast.ForInitializer
+	if tx.isVariableDeclarationListWithCollidingName(node.Initializer) {
+		initializer = tx.visitVariableDeclarationListWithCollidingNames(node.Initializer.AsVariableDeclarationList(), false /*hasReceiver*/)
+	} else {
+		initializer = tx.Visitor().VisitNode(node.Initializer)
+	}
+	return tx.Factory().UpdateForStatement(
+		node,
+		initializer,
+		tx.Visitor().VisitNode(node.Condition),
+		tx.Visitor().VisitNode(node.Incrementor),
+		tx.EmitContext().VisitIterationBody(node.Statement, tx.asyncBodyVisitor),
+	)
+}
+
+func (tx *asyncTransformer) visitAwaitExpression(node *ast.AwaitExpression) *ast.Node {
+	// do not downlevel a top-level await as it is module syntax...
+	if tx.inTopLevelContext() {
+		return tx.Visitor().VisitEachChild(node.AsNode())
+	}
+	updated := tx.Factory().NewYieldExpression(nil /*asteriskToken*/, tx.Visitor().VisitNode(node.Expression))
+	tx.EmitContext().SetOriginal(updated, node.AsNode())
+	updated.Loc = node.Loc
+	return updated
+}
+
+func (tx *asyncTransformer) visitClassLike(node *ast.Node) *ast.Node {
+	savedEnclosingSuperFlags := tx.enclosingSuperFlags
+	tx.enclosingSuperFlags = superAccessFlagsNone
+	updated := tx.Visitor().VisitEachChild(node)
+	tx.enclosingSuperFlags = savedEnclosingSuperFlags
+	return updated
+}
+
+func (tx *asyncTransformer) visitConstructorDeclaration(node *ast.ConstructorDeclaration) *ast.Node {
+	savedLexicalArgumentsBinding := tx.lexicalArgumentsBinding
+	savedEnclosingSuperFlags := tx.enclosingSuperFlags
+	tx.lexicalArgumentsBinding = nil
+	tx.enclosingSuperFlags = superAccessFlagsNone
+	updated := tx.Factory().UpdateConstructorDeclaration(
+		node,
+		tx.Visitor().VisitModifiers(node.Modifiers()),
+		nil, /*typeParameters*/
+		tx.EmitContext().VisitParameters(node.ParameterList(), tx.Visitor()),
+		nil, /*returnType*/
+		tx.transformMethodBody(node.AsNode()),
+	)
+	tx.lexicalArgumentsBinding = savedLexicalArgumentsBinding
+	tx.enclosingSuperFlags = savedEnclosingSuperFlags
+	return updated
+}
+
+func (tx *asyncTransformer) visitMethodDeclaration(node *ast.MethodDeclaration) *ast.Node {
+	savedLexicalArgumentsBinding := tx.lexicalArgumentsBinding
+	savedEnclosingSuperFlags := tx.enclosingSuperFlags
+	tx.lexicalArgumentsBinding = nil
+	tx.enclosingSuperFlags = tx.getSuperAccessFlags(node.AsNode())
+
+	var updated *ast.Node
+	if isAsyncFunction(node.AsNode()) {
+		parameters, outerParameters := tx.transformAsyncFunctionParameterList(node.AsNode())
+		updated = tx.Factory().UpdateMethodDeclaration(
+			node,
+			transformers.ExtractModifiers(tx.EmitContext(), node.Modifiers(), ^ast.ModifierFlagsAsync),
+			node.AsteriskToken,
+			node.Name(),
+			nil, /*postfixToken*/
+			nil, /*typeParameters*/
+			parameters,
+			nil, /*returnType*/
+			tx.transformAsyncFunctionBody(node.AsNode(), outerParameters),
+		)
+	} else {
+		updated = tx.Factory().UpdateMethodDeclaration(
+			node,
+			tx.Visitor().VisitModifiers(node.Modifiers()),
+			node.AsteriskToken,
+			node.Name(),
+			nil, /*postfixToken*/
+			nil, /*typeParameters*/
+			tx.EmitContext().VisitParameters(node.ParameterList(), tx.Visitor()),
+			nil, /*returnType*/
+			tx.transformMethodBody(node.AsNode()),
+		)
+	}
+
+	tx.lexicalArgumentsBinding = savedLexicalArgumentsBinding
+	tx.enclosingSuperFlags = savedEnclosingSuperFlags
+	return updated
+}
+
+func (tx *asyncTransformer) visitGetAccessorDeclaration(node *ast.GetAccessorDeclaration) *ast.Node {
+	savedLexicalArgumentsBinding := tx.lexicalArgumentsBinding
+	savedEnclosingSuperFlags := tx.enclosingSuperFlags
+	tx.lexicalArgumentsBinding = nil
+	tx.enclosingSuperFlags = superAccessFlagsNone
+	updated := tx.Factory().UpdateGetAccessorDeclaration(
+		node,
+		tx.Visitor().VisitModifiers(node.Modifiers()),
+		node.Name(),
+		nil, /*typeParameters*/
+		tx.EmitContext().VisitParameters(node.ParameterList(), tx.Visitor()),
+		nil, /*returnType*/
+		tx.transformMethodBody(node.AsNode()),
+	)
+	tx.lexicalArgumentsBinding = savedLexicalArgumentsBinding
+	tx.enclosingSuperFlags = savedEnclosingSuperFlags
+	return updated
+}
+
+func (tx *asyncTransformer) visitSetAccessorDeclaration(node *ast.SetAccessorDeclaration) *ast.Node {
+	savedLexicalArgumentsBinding := tx.lexicalArgumentsBinding
+	savedEnclosingSuperFlags := tx.enclosingSuperFlags
+	tx.lexicalArgumentsBinding = nil
+	tx.enclosingSuperFlags = superAccessFlagsNone
+	updated := tx.Factory().UpdateSetAccessorDeclaration(
+		node,
+		tx.Visitor().VisitModifiers(node.Modifiers()),
+		node.Name(),
+		nil, /*typeParameters*/
+		tx.EmitContext().VisitParameters(node.ParameterList(), tx.Visitor()),
+		nil, /*returnType*/
+		tx.transformMethodBody(node.AsNode()),
+	)
+	tx.lexicalArgumentsBinding = savedLexicalArgumentsBinding
+	tx.enclosingSuperFlags = savedEnclosingSuperFlags
+	return updated
+}
+
+func (tx *asyncTransformer) visitFunctionDeclaration(node *ast.FunctionDeclaration) *ast.Node {
+	savedLexicalArgumentsBinding := tx.lexicalArgumentsBinding
+	tx.lexicalArgumentsBinding = nil
+
+	var updated *ast.Node
+	if isAsyncFunction(node.AsNode()) {
+		parameters, outerParameters := tx.transformAsyncFunctionParameterList(node.AsNode())
+		updated = tx.Factory().UpdateFunctionDeclaration(
+			node,
+			transformers.ExtractModifiers(tx.EmitContext(), node.Modifiers(), ^ast.ModifierFlagsAsync),
+			node.AsteriskToken,
+			node.Name(),
+			nil, /*typeParameters*/
+			parameters,
+			nil, /*returnType*/
+			tx.transformAsyncFunctionBody(node.AsNode(), outerParameters),
+		)
+	} else {
+		updated = tx.Factory().UpdateFunctionDeclaration(
+			node,
+			tx.Visitor().VisitModifiers(node.Modifiers()),
+			node.AsteriskToken,
+			node.Name(),
+			nil, /*typeParameters*/
+			tx.EmitContext().VisitParameters(node.ParameterList(), tx.Visitor()),
+			nil, /*returnType*/
+			tx.EmitContext().VisitFunctionBody(node.Body, tx.Visitor()),
+		)
+	}
+
+	tx.lexicalArgumentsBinding = savedLexicalArgumentsBinding
+	return updated
+}
+
+func (tx *asyncTransformer) visitFunctionExpression(node *ast.FunctionExpression) *ast.Node {
+	savedLexicalArgumentsBinding := tx.lexicalArgumentsBinding
+	tx.lexicalArgumentsBinding = nil
+
+	var updated *ast.Node
+	if isAsyncFunction(node.AsNode()) {
+		parameters, outerParameters := tx.transformAsyncFunctionParameterList(node.AsNode())
+		updated = tx.Factory().UpdateFunctionExpression(
+			node,
+			transformers.ExtractModifiers(tx.EmitContext(), node.Modifiers(), ^ast.ModifierFlagsAsync),
+			node.AsteriskToken,
+			node.Name(),
+			nil, /*typeParameters*/
+			parameters,
+			nil, /*returnType*/
+			tx.transformAsyncFunctionBody(node.AsNode(), outerParameters),
+		)
+	} else {
+		updated = tx.Factory().UpdateFunctionExpression(
+			node,
+			tx.Visitor().VisitModifiers(node.Modifiers()),
+			node.AsteriskToken,
+			node.Name(),
+			nil, /*typeParameters*/
+			tx.EmitContext().VisitParameters(node.ParameterList(), tx.Visitor()),
+			nil, /*returnType*/
+			tx.EmitContext().VisitFunctionBody(node.Body, tx.Visitor()),
+		)
+	}
+
+	tx.lexicalArgumentsBinding = savedLexicalArgumentsBinding
+	return updated
+}
+
+func (tx *asyncTransformer) visitArrowFunction(node *ast.ArrowFunction) *ast.Node {
+	if isAsyncFunction(node.AsNode()) {
+		// An async arrow shares `arguments` with its container, so any binding captured for the arrow (or reused from
+		// the container) must be restored once the arrow has been transformed.
+		savedLexicalArgumentsBinding := tx.lexicalArgumentsBinding
+		parameters, outerParameters := tx.transformAsyncFunctionParameterList(node.AsNode())
+		updated := tx.Factory().UpdateArrowFunction(
+			node,
+			transformers.ExtractModifiers(tx.EmitContext(), node.Modifiers(), ^ast.ModifierFlagsAsync),
+			nil, /*typeParameters*/
+			parameters,
+			nil, /*returnType*/
+			node.EqualsGreaterThanToken,
+			tx.transformAsyncFunctionBody(node.AsNode(), outerParameters),
+		)
+		tx.lexicalArgumentsBinding = savedLexicalArgumentsBinding
+		return updated
+	}
+
+	return tx.Factory().UpdateArrowFunction(
+		node,
+		tx.Visitor().VisitModifiers(node.Modifiers()),
+		nil, /*typeParameters*/
+		tx.EmitContext().VisitParameters(node.ParameterList(), tx.Visitor()),
+		nil, /*returnType*/
+		node.EqualsGreaterThanToken,
+		tx.EmitContext().VisitFunctionBody(node.Body, tx.Visitor()),
+	)
+}
+
+func (tx *asyncTransformer) visitPropertyAccessExpression(node *ast.PropertyAccessExpression) *ast.Node {
+	if node.Expression.Kind == ast.KindSuperKeyword {
+		if tx.capturedSuperProperties != nil {
+			tx.capturedSuperProperties.Add(node.Name().Text())
+		}
+		if tx.enclosingSuperFlags != superAccessFlagsNone {
+			return tx.createSuperPropertyAccessInAsyncMethod(node)
+		}
+	}
+	return tx.Visitor().VisitEachChild(node.AsNode())
+}
+
+func (tx *asyncTransformer) visitElementAccessExpression(node *ast.ElementAccessExpression) *ast.Node {
+	if node.Expression.Kind == ast.KindSuperKeyword {
+		if tx.capturedSuperProperties != nil {
+			tx.hasSuperElementAccess = true
+		}
+		if tx.enclosingSuperFlags != superAccessFlagsNone {
+			return tx.createSuperElementAccessInAsyncMethod(tx.Visitor().VisitNode(node.ArgumentExpression), node.AsNode())
+		}
+	}
+	return tx.Visitor().VisitEachChild(node.AsNode())
+}
+
+func (tx *asyncTransformer) visitCallExpression(node *ast.CallExpression) *ast.Node {
+	if tx.enclosingSuperFlags != superAccessFlagsNone && isSuperProperty(node.Expression) {
+		// `super.x(...args)` becomes `_super.x.call(this, ...args)`
+		expression := tx.Visitor().VisitNode(node.Expression)
+		arguments := []*ast.Expression{tx.Factory().NewThisExpression()}
+		arguments = append(arguments, tx.Visitor().VisitNodes(node.Arguments).Nodes...)
+		updated := tx.Factory().NewCallExpression(
+			tx.Factory().NewPropertyAccessExpression(expression, nil /*questionDotToken*/, tx.Factory().NewIdentifier("call"), ast.NodeFlagsNone),
+			nil, /*questionDotToken*/
+			nil, /*typeArguments*/
+			tx.Factory().NewNodeList(arguments),
+			ast.NodeFlagsNone,
+		)
+		tx.EmitContext().SetOriginal(updated, node.AsNode())
+		updated.Loc = node.Loc
+		return updated
+	}
+	return tx.Visitor().VisitEachChild(node.AsNode())
+}
+
+func (tx *asyncTransformer) createSuperPropertyAccessInAsyncMethod(node *ast.PropertyAccessExpression) *ast.Node {
+	updated := tx.Factory().NewPropertyAccessExpression(
+		tx.Factory().NewUniqueNameEx("_super", printer.AutoGenerateOptions{Flags: printer.GeneratedIdentifierFlagsOptimistic | printer.GeneratedIdentifierFlagsFileLevel}),
+		nil, /*questionDotToken*/
+		node.Name(),
+		ast.NodeFlagsNone,
+	)
+	updated.Loc = node.Loc
+	return updated
+}
+
+func (tx *asyncTransformer) createSuperElementAccessInAsyncMethod(argumentExpression *ast.Expression, location *ast.Node) *ast.Node {
+	updated := tx.Factory().NewCallExpression(
+		tx.Factory().NewUniqueNameEx("_superIndex", printer.AutoGenerateOptions{Flags: printer.GeneratedIdentifierFlagsOptimistic | printer.GeneratedIdentifierFlagsFileLevel}),
+		nil, /*questionDotToken*/
+		nil, /*typeArguments*/
+		tx.Factory().NewNodeList([]*ast.Expression{argumentExpression}),
+		ast.NodeFlagsNone,
+	)
+	if tx.enclosingSuperFlags&superAccessFlagsAssignment != 0 {
+		updated = tx.Factory().NewPropertyAccessExpression(
+			updated,
+			nil, /*questionDotToken*/
+			tx.Factory().NewIdentifier("value"),
+			ast.NodeFlagsNone,
+		)
+	}
+	updated.Loc = location.Loc
+	return updated
+}
+
+func (tx *asyncTransformer) isVariableDeclarationListWithCollidingName(node *ast.Node) bool {
+	return node != nil &&
+		ast.IsVariableDeclarationList(node) &&
+		node.Flags&ast.NodeFlagsBlockScoped == 0 &&
+		core.Some(node.AsVariableDeclarationList().Declarations.Nodes, func(declaration *ast.Node) bool {
+			return tx.collidesWithParameterName(declaration.Name())
+		})
+}
+
+func (tx *asyncTransformer) visitVariableDeclarationListWithCollidingNames(node *ast.VariableDeclarationList, hasReceiver bool) *ast.Expression {
+	for _, declaration := range node.Declarations.Nodes {
+		tx.hoistVariable(declaration.Name())
+	}
+
+	var expressions []*ast.Expression
+	for _, declaration := range node.Declarations.Nodes {
+		if declaration.Initializer() != nil {
+			converted := transformers.ConvertVariableDeclarationToAssignmentExpression(tx.EmitContext(), declaration.AsVariableDeclaration())
+			expressions = append(expressions, tx.Visitor().VisitNode(converted))
+		}
+	}
+
+	if len(expressions) == 0 {
+		if hasReceiver {
+			name := node.Declarations.Nodes[0].Name()
+			if ast.IsBindingPattern(name) {
+				return tx.Visitor().VisitNode(transformers.ConvertBindingPatternToAssignmentPattern(tx.EmitContext(), name.AsBindingPattern()))
+			}
+			return tx.Visitor().VisitNode(name)
+		}
+		return nil
+	}
+
+	return tx.Factory().InlineExpressions(expressions)
+}
+
+func (tx *asyncTransformer) hoistVariable(name *ast.Node) {
+	if ast.IsIdentifier(name) {
+		tx.EmitContext().AddVariableDeclaration(name)
+		return
+	}
+	for _, element := range name.AsBindingPattern().Elements.Nodes {
+		if !ast.IsOmittedExpression(element) && element.Name() != nil {
+			tx.hoistVariable(element.Name())
+		}
+	}
+}
+
+func (tx *asyncTransformer) collidesWithParameterName(name *ast.Node) bool {
+	if ast.IsIdentifier(name) {
+		return tx.enclosingFunctionParameterNames.Has(name.Text())
+	}
+	for _, element := range name.AsBindingPattern().Elements.Nodes {
+		if !ast.IsOmittedExpression(element) && element.Name() != nil && tx.collidesWithParameterName(element.Name()) {
+			return true
+		}
+	}
+	return false
+}
+
+// Transforms the parameter list of an async function. When the original parameter list is not simple, the parameters
+// are moved to the inner generator function and the outer function receives placeholder parameters that preserve its
+// `length`. In that case, the placeholder parameters are also returned as `outerParameters`.
+func (tx *asyncTransformer) transformAsyncFunctionParameterList(node *ast.Node) (parameters *ast.ParameterList, outerParameters []*ast.ParameterDeclarationNode) {
+	if isSimpleParameterList(node.Parameters()) {
+		return tx.EmitContext().VisitParameters(node.ParameterList(), tx.Visitor()), nil
+	}
+
+	tx.EmitContext().StartVariableEnvironment()
+	newParameters := []*ast.ParameterDeclarationNode{}
+	for _, parameter := range node.Parameters() {
+		if parameter.Initializer() != nil || parameter.AsParameterDeclaration().DotDotDotToken != nil {
+			if node.Kind == ast.KindArrowFunction {
+				restParameter := tx.Factory().NewParameterDeclaration(
+					nil, /*modifiers*/
+					tx.Factory().NewToken(ast.KindDotDotDotToken),
+					tx.Factory().NewUniqueNameEx("args", printer.AutoGenerateOptions{Flags: printer.GeneratedIdentifierFlagsReservedInNestedScopes}),
+					nil, /*questionToken*/
+					nil, /*type*/
+					nil, /*initializer*/
+				)
+				newParameters = append(newParameters, restParameter)
+			}
+			break
+		}
+		newParameter := tx.Factory().NewParameterDeclaration(
+			nil, /*modifiers*/
+			nil, /*dotDotDotToken*/
+			tx.Factory().NewGeneratedNameForNodeEx(parameter.Name(), printer.AutoGenerateOptions{Flags: printer.GeneratedIdentifierFlagsReservedInNestedScopes}),
+			nil, /*questionToken*/
+			nil, /*type*/
+			nil, /*initializer*/
+		)
+		newParameters = append(newParameters, newParameter)
+	}
+	parameters = tx.Factory().NewNodeList(newParameters)
+	parameters.Loc = node.ParameterList().Loc
+	return parameters, newParameters
+}
+
+func (tx *asyncTransformer) transformAsyncFunctionBody(node *ast.Node, outerParameters []*ast.ParameterDeclarationNode) *ast.Node {
+	isArrowFunction := node.Kind == ast.KindArrowFunction
+	captureLexicalArguments := tx.lexicalArgumentsBinding == nil && containsLexicalArguments(tx.EmitContext().MostOriginal(node))
+	if captureLexicalArguments {
+		tx.lexicalArgumentsBinding = tx.Factory().NewUniqueName("arguments")
+	}
+
+	var argumentsExpression *ast.Expression
+	var innerParameters []*ast.ParameterDeclarationNode
+	if outerParameters != nil {
+		if isArrowFunction {
+			// `arguments` cannot be used in arrow functions. We use a rest parameter instead.
+			var parameterBindings []*ast.Expression
+			for _, outerParameter := range outerParameters {
+				if outerParameter.AsParameterDeclaration().DotDotDotToken != nil {
+					parameterBindings = append(parameterBindings, tx.Factory().NewSpreadElement(outerParameter.Name()))
+					break
+				}
+				parameterBindings = append(parameterBindings, outerParameter.Name())
+			}
+			argumentsExpression = tx.Factory().NewArrayLiteralExpression(tx.Factory().NewNodeList(parameterBindings), false /*multiLine*/)
+		} else {
+			argumentsExpression = tx.Factory().NewIdentifier("arguments")
+		}
+		innerParameters = tx.Visitor().VisitNodes(node.ParameterList()).Nodes
+	}
+
+	// An async function is emit as an outer function that calls an inner
+	// generator function. To preserve lexical bindings, we pass the current
+	// `this` and `arguments` objects to `__awaiter`. The generator function
+	// passed to `__awaiter` is executed inside of the callback to the
+	// promise constructor.
+
+	savedEnclosingFunctionParameterNames := tx.enclosingFunctionParameterNames
+	tx.enclosingFunctionParameterNames = &collections.Set[string]{}
+	for _, parameter := range node.Parameters() {
+		recordDeclarationName(parameter.Name(), tx.enclosingFunctionParameterNames)
+	}
+
+	savedCapturedSuperProperties := tx.capturedSuperProperties
+	savedHasSuperElementAccess := tx.hasSuperElementAccess
+	if !isArrowFunction {
+		tx.capturedSuperProperties = &collections.OrderedSet[string]{}
+		tx.hasSuperElementAccess = false
+	}
+
+	hasLexicalThis := tx.inHasLexicalThisContext()
+	asyncBody := tx.transformAsyncFunctionBodyWorker(node.Body())
+	asyncBody = tx.Factory().UpdateBlock(
+		asyncBody.AsBlock(),
+		tx.EmitContext().EndAndMergeVariableEnvironmentList(asyncBody.AsBlock().Statements),
+	)
+
+	// !!! Emit the promise constructor from the return type annotation when targeting ES5
+	awaiter := tx.Factory().NewAwaiterHelper(hasLexicalThis, argumentsExpression, nil /*promiseConstructor*/, innerParameters, asyncBody)
+
+	var result *ast.Node
+	if !isArrowFunction {
+		var statements []*ast.Statement
+		if captureLexicalArguments {
+			statements = append(statements, tx.createCaptureArgumentsStatement())
+		}
+
+		// Minor optimization, emit `_super` helper to capture `super` access in an arrow.
+		// This step isn't needed if we eventually transform this to ES5.
+		emitSuperHelpers := ast.IsMethodDeclaration(node) && tx.enclosingSuperFlags != superAccessFlagsNone
+		if emitSuperHelpers && tx.capturedSuperProperties.Size() > 0 {
+			statements = append(statements, tx.createSuperAccessVariableStatement())
+		}
+
+		statements = append(statements, tx.Factory().NewReturnStatement(awaiter))
+		block := tx.Factory().NewBlock(tx.Factory().NewNodeList(statements), true /*multiLine*/)
+		block.Loc = node.Body().Loc
+
+		if emitSuperHelpers && tx.hasSuperElementAccess {
+			// Emit helpers for super element access expressions (`super[x]`).
+			tx.addSuperElementAccessHelper(block)
+		}
+		result = block
+	} else {
+		result = awaiter
+		if captureLexicalArguments {
+			statements := []*ast.Statement{
+				tx.createCaptureArgumentsStatement(),
+				tx.Factory().NewReturnStatement(awaiter),
+			}
+			result = tx.Factory().NewBlock(tx.Factory().NewNodeList(statements), true /*multiLine*/)
+		}
+	}
+
+	tx.enclosingFunctionParameterNames = savedEnclosingFunctionParameterNames
+	if !isArrowFunction {
+		tx.capturedSuperProperties = savedCapturedSuperProperties
+		tx.hasSuperElementAccess = savedHasSuperElementAccess
+	}
+	return result
+}
+
+func (tx *asyncTransformer) transformAsyncFunctionBodyWorker(body *ast.Node) *ast.Node {
+	if ast.IsBlock(body) {
+		return tx.Factory().UpdateBlock(body.AsBlock(), tx.asyncBodyVisitor.VisitNodes(body.AsBlock().Statements))
+	}
+	expression := tx.asyncBodyVisitor.VisitNode(body)
+	returnStatement := tx.Factory().NewReturnStatement(expression)
+	returnStatement.Loc = body.Loc
+	block := tx.Factory().NewBlock(tx.Factory().NewNodeList([]*ast.Statement{returnStatement}), false /*multiLine*/)
+	block.Loc = body.Loc
+	return block
+}
+
+// Transforms the body of a method, accessor, or constructor. When a method contains `super` property accesses within
+// an async arrow function, this also emits the `_super` helpers used to access `super` from the generator body.
+func (tx *asyncTransformer) transformMethodBody(node *ast.Node) *ast.Node {
+	if node.Body() == nil {
+		return nil
+	}
+
+	savedCapturedSuperProperties := tx.capturedSuperProperties
+	savedHasSuperElementAccess := tx.hasSuperElementAccess
+	tx.capturedSuperProperties = &collections.OrderedSet[string]{}
+	tx.hasSuperElementAccess = false
+
+	updated := tx.EmitContext().VisitFunctionBody(node.Body(), tx.Visitor())
+
+	// Minor optimization, emit `_super` helper to capture `super` access in an arrow.
+	// This step isn't needed if we eventually transform this to ES5.
+	emitSuperHelpers := ast.IsMethodDeclaration(node) && tx.enclosingSuperFlags != superAccessFlagsNone
+	if emitSuperHelpers {
+		if tx.capturedSuperProperties.Size() > 0 {
+			prologue, rest := tx.Factory().SplitStandardPrologue(updated.AsBlock().Statements.Nodes)
+			statements := make([]*ast.Statement, 0, len(prologue)+len(rest)+1)
+			statements = append(statements, prologue...)
+			statements = append(statements, tx.createSuperAccessVariableStatement())
+			statements = append(statements, rest...)
+			statementList := tx.Factory().NewNodeList(statements)
+			statementList.Loc = updated.AsBlock().Statements.Loc
+			updated = tx.Factory().UpdateBlock(updated.AsBlock(), statementList)
+		}
+		if tx.hasSuperElementAccess {
+			// Emit helpers for super element access expressions (`super[x]`).
+			tx.addSuperElementAccessHelper(updated)
+		}
+	}
+
+	tx.capturedSuperProperties = savedCapturedSuperProperties
+	tx.hasSuperElementAccess = savedHasSuperElementAccess
+	return updated
+}
+
+func (tx *asyncTransformer) addSuperElementAccessHelper(block *ast.Node) {
+	if tx.enclosingSuperFlags&superAccessFlagsAssignment != 0 {
+		tx.EmitContext().AddEmitHelper(block, printer.AdvancedAsyncSuperHelper)
+	} else {
+		tx.EmitContext().AddEmitHelper(block, printer.AsyncSuperHelper)
+	}
+}
+
+// Creates a variable named `_super` with accessor properties for the given property names.
+//
+//	const _super = Object.create(null, {
+//	    x: { get: () => super.x, set: v => super.x = v },
+//	});
+func (tx *asyncTransformer) createSuperAccessVariableStatement() *ast.Node {
+	f := tx.Factory()
+	hasBinding := tx.enclosingSuperFlags&superAccessFlagsAssignment != 0
+	var accessors []*ast.Node
+	for name := range tx.capturedSuperProperties.Values() {
+		var getterAndSetter []*ast.Node
+		getterAndSetter = append(getterAndSetter, f.NewPropertyAssignment(
+			nil, /*modifiers*/
+			f.NewIdentifier("get"),
+			nil, /*postfixToken*/
+			nil, /*typeNode*/
+			f.NewArrowFunction(
+				nil, /*modifiers*/
+				nil, /*typeParameters*/
+				f.NewNodeList([]*ast.Node{}),
+				nil, /*returnType*/
+				f.NewToken(ast.KindEqualsGreaterThanToken),
+				f.NewPropertyAccessExpression(f.NewKeywordExpression(ast.KindSuperKeyword), nil /*questionDotToken*/, f.NewIdentifier(name), ast.NodeFlagsNone),
+			),
+		))
+		if hasBinding {
+			getterAndSetter = append(getterAndSetter, f.NewPropertyAssignment(
+				nil, /*modifiers*/
+				f.NewIdentifier("set"),
+				nil, /*postfixToken*/
+				nil, /*typeNode*/
+				f.NewArrowFunction(
+					nil, /*modifiers*/
+					nil, /*typeParameters*/
+					f.NewNodeList([]*ast.Node{
+						f.NewParameterDeclaration(nil /*modifiers*/, nil /*dotDotDotToken*/, f.NewIdentifier("v"), nil /*questionToken*/, nil /*type*/, nil /*initializer*/),
+					}),
+					nil, /*returnType*/
+					f.NewToken(ast.KindEqualsGreaterThanToken),
+					f.NewAssignmentExpression(
+						f.NewPropertyAccessExpression(f.NewKeywordExpression(ast.KindSuperKeyword), nil /*questionDotToken*/, f.NewIdentifier(name), ast.NodeFlagsNone),
+						f.NewIdentifier("v"),
+					),
+				),
+			))
+		}
+		accessors = append(accessors, f.NewPropertyAssignment(
+			nil, /*modifiers*/
+			f.NewIdentifier(name),
+			nil, /*postfixToken*/
+			nil, /*typeNode*/
+			f.NewObjectLiteralExpression(f.NewNodeList(getterAndSetter), false /*multiLine*/),
+		))
+	}
+
+	return f.NewVariableStatement(
+		nil, /*modifiers*/
+		f.NewVariableDeclarationList(
+			ast.NodeFlagsConst,
+			f.NewNodeList([]*ast.Node{
+				f.NewVariableDeclaration(
+					f.NewUniqueNameEx("_super", printer.AutoGenerateOptions{Flags: printer.GeneratedIdentifierFlagsOptimistic | printer.GeneratedIdentifierFlagsFileLevel}),
+					nil, /*exclamationToken*/
+					nil, /*typeNode*/
+					f.NewCallExpression(
+						f.NewPropertyAccessExpression(f.NewIdentifier("Object"), nil /*questionDotToken*/, f.NewIdentifier("create"), ast.NodeFlagsNone),
+						nil, /*questionDotToken*/
+						nil, /*typeArguments*/
+						f.NewNodeList([]*ast.Expression{
+							f.NewKeywordExpression(ast.KindNullKeyword),
+							f.NewObjectLiteralExpression(f.NewNodeList(accessors), true /*multiLine*/),
+						}),
+						ast.NodeFlagsNone,
+					),
+				),
+			}),
+		),
+	)
+}
+
+func (tx *asyncTransformer) createCaptureArgumentsStatement() *ast.Node {
+	variable := tx.Factory().NewVariableDeclaration(
+		tx.lexicalArgumentsBinding,
+		nil, /*exclamationToken*/
+		nil, /*typeNode*/
+		tx.Factory().NewIdentifier("arguments"),
+	)
+	statement := tx.Factory().NewVariableStatement(
+		nil, /*modifiers*/
+		tx.Factory().NewVariableDeclarationList(ast.NodeFlagsNone, tx.Factory().NewNodeList([]*ast.Node{variable})),
+	)
+	tx.EmitContext().AddEmitFlags(statement, printer.EFStartOnNewLine|printer.EFCustomPrologue)
+	return statement
+}
+
+// Determines how a method accesses `super` from within an async function or async arrow function. Methods that do so
+// must capture `super` in an arrow function outside of the generator body, as `super` is not accessible from within
+// the generator.
+func (tx *asyncTransformer) getSuperAccessFlags(node *ast.Node) superAccessFlags {
+	if tx.compilerOptions.GetEmitScriptTarget() < core.ScriptTargetES2015 {
+		return superAccessFlagsNone
+	}
+	flags := superAccessFlagsNone
+	var visit func(node *ast.Node, inAsync bool) bool
+	visit = func(node *ast.Node, inAsync bool) bool {
+		switch {
+		case ast.IsArrowFunction(node):
+			inAsync = inAsync || isAsyncFunction(node)
+		case ast.IsFunctionLike(node) || ast.IsClassLike(node):
+			return false
+		case inAsync && isSuperProperty(node):
+			if ast.IsAssignmentTarget(node) {
+				flags |= superAccessFlagsAssignment
+			} else {
+				flags |= superAccessFlagsAccess
+			}
+		}
+		node.ForEachChild(func(child *ast.Node) bool { return visit(child, inAsync) })
+		return false
+	}
+	node = tx.EmitContext().MostOriginal(node)
+	inAsync := isAsyncFunction(node) && node.BodyData().AsteriskToken == nil
+	for _, parameter := range node.Parameters() {
+		visit(parameter, inAsync)
+	}
+	if body := node.Body(); body != nil {
+		visit(body, inAsync)
+	}
+	return flags
+}
+
+func isAsyncFunction(node *ast.Node) bool {
+	return ast.HasSyntacticModifier(node, ast.ModifierFlagsAsync)
+}
+
+func isSuperProperty(node *ast.Node) bool {
+	return (ast.IsPropertyAccessExpression(node) || ast.IsElementAccessExpression(node)) && node.Expression().Kind == ast.KindSuperKeyword
+}
+
+func isSimpleParameterList(parameters []*ast.ParameterDeclarationNode) bool {
+	for _, parameter := range parameters {
+		if parameter.Initializer() != nil || !ast.IsIdentifier(parameter.Name()) {
+			return false
+		}
+	}
+	return true
+}
+
+func recordDeclarationName(name *ast.Node, names *collections.Set[string]) {
+	if ast.IsIdentifier(name) {
+		names.Add(name.Text())
+		return
+	}
+	for _, element := range name.AsBindingPattern().Elements.Nodes {
+		if !ast.IsOmittedExpression(element) && element.Name() != nil {
+			recordDeclarationName(element.Name(), names)
+		}
+	}
 }
 
-func (ch *asyncTransformer) visit(node *ast.Node) *ast.Node {
-	return node // !!!
+// Determines whether `node` is a reference to the `arguments` object of its containing function.
+func isArgumentsReference(emitContext *printer.EmitContext, node *ast.Node) bool {
+	if node.Text() != "arguments" || transformers.IsGeneratedIdentifier(emitContext, node) {
+		return false
+	}
+	original := emitContext.ParseNode(node)
+	return original != nil && original.Parent != nil && transformers.IsIdentifierReference(original, original.Parent)
 }
 
-func newAsyncTransformer(emitContext *printer.EmitContext) *transformers.Transformer {
-	tx := &asyncTransformer{}
-	return tx.NewTransformer(tx.visit, emitContext)
+// Determines whether the `arguments` object of the function containing `node` is referenced from within `node` or
+// any of its nested arrow functions.
+func containsLexicalArguments(node *ast.Node) bool {
+	var visit func(node *ast.Node) bool
+	visit = func(node *ast.Node) bool {
+		if ast.IsIdentifier(node) {
+			return node.Text() == "arguments" && node.Parent != nil && transformers.IsIdentifierReference(node, node.Parent)
+		}
+		if ast.IsFunctionLike(node) && !ast.IsArrowFunction(node) {
+			return false
+		}
+		return node.ForEachChild(visit)
+	}
+	for _, parameter := range node.Parameters() {
+		if visit(parameter) {
+			return true
+		}
+	}
+	return node.Body() != nil && visit(node.Body())
 }
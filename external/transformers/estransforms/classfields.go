@@ -1,20 +1,1316 @@
 package estransforms
 
 import (
+	"slices"
+
 	"github.com/pagpeter/typescript-go/external/ast"
+	"github.com/pagpeter/typescript-go/external/core"
 	"github.com/pagpeter/typescript-go/external/printer"
 	"github.com/pagpeter/typescript-go/external/transformers"
 )
 
+// Describes how a private class element is represented once private names have been lowered.
+type privateIdentifierInfo struct {
+	kind     printer.PrivateIdentifierKind
+	isStatic bool
+
+	// The object used to check whether a receiver has the private element: the WeakMap of field values for instance
+	// fields, the WeakSet of instances for instance methods and accessors, or the class constructor for static elements.
+	brandCheckIdentifier *ast.IdentifierNode
+
+	variableName *ast.IdentifierNode // The descriptor of a static field, or the function of a method.
+	getterName   *ast.IdentifierNode // The getter function of an accessor, if it has one.
+	setterName   *ast.IdentifierNode // The setter function of an accessor, if it has one.
+}
+
+// Tracks the private names declared by a class whose private elements are being lowered.
+type classLexicalEnvironment struct {
+	previous           *classLexicalEnvironment
+	prefix             string              // The prefix for variables generated for private names, e.g. `_C_`.
+	classConstructor   *ast.IdentifierNode // A reference to the class constructor, used as the brand of static private elements.
+	weakSetName        *ast.IdentifierNode // The WeakSet of instances, used as the brand of instance private methods and accessors.
+	privateIdentifiers map[string]*privateIdentifierInfo
+}
+
+func (env *classLexicalEnvironment) lookup(name *ast.PrivateIdentifierNode) *privateIdentifierInfo {
+	for ; env != nil; env = env.previous {
+		if info, ok := env.privateIdentifiers[name.Text()]; ok {
+			return info
+		}
+	}
+	return nil
+}
+
+// The result of lowering the elements of a class.
+type loweredClass struct {
+	heritageClauses *ast.NodeList
+	members         *ast.NodeList
+
+	// Expressions evaluated once the class has been defined: the creation of the WeakMaps and WeakSets for private
+	// elements, hoisted computed property names of fields, and the functions of private methods and accessors.
+	expressions []*ast.Expression
+
+	// The static field initializers and static blocks of the class, in declaration order.
+	staticInitializers []*ast.Expression
+}
+
 type classFieldsTransformer struct {
 	transformers.Transformer
+	compilerOptions         *core.CompilerOptions
+	useDefineForClassFields bool
+	discardedValueVisitor   *ast.NodeVisitor // visits expressions whose result is not observed
+	assignmentTargetVisitor *ast.NodeVisitor // visits the targets of a destructuring assignment
+
+	classEnvironment   *classLexicalEnvironment
+	classThis          *ast.IdentifierNode // replaces `this` in static field initializers and static blocks
+	classSuper         *ast.Expression     // the base class that replaces `super` in static field initializers and static blocks
+	pendingExpressions []*ast.Expression   // expressions that must be evaluated before the expression being visited
+}
+
+func newClassFieldsTransformer(opts *transformers.TransformOptions) *transformers.Transformer {
+	tx := &classFieldsTransformer{
+		compilerOptions: opts.CompilerOptions,
+		// This transform only runs for targets below ES2022, where `useDefineForClassFields` defaults to false.
+		useDefineForClassFields: opts.CompilerOptions.UseDefineForClassFields.IsTrue(),
+	}
+	result := tx.NewTransformer(tx.visit, opts.Context)
+	tx.discardedValueVisitor = tx.EmitContext().NewNodeVisitor(tx.visitDiscardedValue)
+	tx.assignmentTargetVisitor = tx.EmitContext().NewNodeVisitor(tx.visitAssignmentTarget)
+	return result
+}
+
+func (tx *classFieldsTransformer) visit(node *ast.Node) *ast.Node {
+	if node.SubtreeFacts()&ast.SubtreeContainsClassFields == 0 &&
+		(tx.classThis == nil || node.SubtreeFacts()&ast.SubtreeContainsLexicalThisOrSuper == 0) {
+		return node
+	}
+
+	switch node.Kind {
+	case ast.KindSourceFile:
+		return tx.visitSourceFile(node.AsSourceFile())
+	case ast.KindClassDeclaration:
+		return tx.visitClassDeclaration(node.AsClassDeclaration())
+	case ast.KindClassExpression:
+		return tx.visitClassExpression(node.AsClassExpression())
+	case ast.KindFunctionDeclaration, ast.KindFunctionExpression, ast.KindMethodDeclaration, ast.KindGetAccessor, ast.KindSetAccessor, ast.KindConstructor:
+		return tx.visitNonArrowFunction(node)
+	case ast.KindThisKeyword:
+		return tx.visitThisKeyword(node)
+	case ast.KindPropertyAccessExpression:
+		return tx.visitPropertyAccessExpression(node.AsPropertyAccessExpression())
+	case ast.KindElementAccessExpression:
+		return tx.visitElementAccessExpression(node.AsElementAccessExpression())
+	case ast.KindCallExpression:
+		return tx.visitCallExpression(node.AsCallExpression())
+	case ast.KindTaggedTemplateExpression:
+		return tx.visitTaggedTemplateExpression(node.AsTaggedTemplateExpression())
+	case ast.KindBinaryExpression:
+		return tx.visitBinaryExpression(node.AsBinaryExpression(), false /*discarded*/)
+	case ast.KindPrefixUnaryExpression, ast.KindPostfixUnaryExpression:
+		return tx.visitPreOrPostfixUnaryExpression(node, false /*discarded*/)
+	case ast.KindParenthesizedExpression:
+		return tx.visitParenthesizedExpression(node.AsParenthesizedExpression(), false /*discarded*/)
+	case ast.KindExpressionStatement:
+		return tx.visitExpressionStatement(node.AsExpressionStatement())
+	case ast.KindForStatement:
+		return tx.visitForStatement(node.AsForStatement())
+	case ast.KindVariableDeclaration, ast.KindParameter, ast.KindBindingElement, ast.KindPropertyAssignment, ast.KindShorthandPropertyAssignment, ast.KindExportAssignment:
+		return tx.visitNamedEvaluationSource(node)
+	default:
+		return tx.Visitor().VisitEachChild(node)
+	}
+}
+
+// Visits an expression whose result is not observed, such as the expression of an `ExpressionStatement`. This allows
+// postfix increments and decrements of private fields to avoid capturing the previous value.
+func (tx *classFieldsTransformer) visitDiscardedValue(node *ast.Node) *ast.Node {
+	switch node.Kind {
+	case ast.KindBinaryExpression:
+		return tx.visitBinaryExpression(node.AsBinaryExpression(), true /*discarded*/)
+	case ast.KindPrefixUnaryExpression, ast.KindPostfixUnaryExpression:
+		return tx.visitPreOrPostfixUnaryExpression(node, true /*discarded*/)
+	case ast.KindParenthesizedExpression:
+		return tx.visitParenthesizedExpression(node.AsParenthesizedExpression(), true /*discarded*/)
+	default:
+		return tx.visit(node)
+	}
+}
+
+func (tx *classFieldsTransformer) visitSourceFile(node *ast.SourceFile) *ast.Node {
+	if node.IsDeclarationFile {
+		return node.AsNode()
+	}
+
+	visited := tx.Visitor().VisitEachChild(node.AsNode())
+	tx.EmitContext().AddEmitHelper(visited, tx.EmitContext().ReadEmitHelpers()...)
+	return visited
+}
+
+// `this` and `super` within a function (other than an arrow function) no longer refer to the class, even when the
+// function is declared in a static initializer.
+func (tx *classFieldsTransformer) visitNonArrowFunction(node *ast.Node) *ast.Node {
+	savedClassThis, savedClassSuper := tx.classThis, tx.classSuper
+	tx.classThis, tx.classSuper = nil, nil
+	result := tx.Visitor().VisitEachChild(node)
+	tx.classThis, tx.classSuper = savedClassThis, savedClassSuper
+	return result
+}
+
+func (tx *classFieldsTransformer) visitThisKeyword(node *ast.Node) *ast.Node {
+	if tx.classThis == nil {
+		return node
+	}
+	classThis := tx.classThis.Clone(tx.Factory())
+	classThis.Loc = node.Loc
+	return classThis
+}
+
+//
+// Classes
+//
+
+// Indicates whether a class element is lowered by this transform.
+func isTransformedClassElement(member *ast.ClassElement) bool {
+	switch member.Kind {
+	case ast.KindPropertyDeclaration:
+		return !ast.IsAutoAccessorPropertyDeclaration(member) // !!! auto-accessors
+	case ast.KindClassStaticBlockDeclaration:
+		return true
+	case ast.KindMethodDeclaration, ast.KindGetAccessor, ast.KindSetAccessor:
+		return ast.IsPrivateIdentifier(member.Name())
+	}
+	return false
+}
+
+func classNeedsTransform(node *ast.ClassLikeDeclaration) bool {
+	return core.Some(node.Members(), isTransformedClassElement)
+}
+
+// Indicates whether the static elements of a class must refer to the class constructor through an alias, either
+// because they use a static private element as a brand check or because `this` or `super` occurs in a static
+// initializer.
+func classNeedsConstructorAlias(node *ast.ClassLikeDeclaration) bool {
+	for _, member := range node.Members() {
+		if !ast.IsStatic(member) || !isTransformedClassElement(member) {
+			continue
+		}
+		if ast.IsPrivateIdentifierClassElementDeclaration(member) || staticElementContainsFacts(member, ast.SubtreeContainsLexicalThisOrSuper) {
+			return true
+		}
+	}
+	return false
+}
+
+// Indicates whether the initializer or body of a static element contains the provided facts.
+func staticElementContainsFacts(member *ast.ClassElement, facts ast.SubtreeFacts) bool {
+	switch member.Kind {
+	case ast.KindPropertyDeclaration:
+		initializer := member.Initializer()
+		return initializer != nil && initializer.SubtreeFacts()&facts != 0
+	case ast.KindClassStaticBlockDeclaration:
+		return member.AsClassStaticBlockDeclaration().Body.SubtreeFacts()&facts != 0
+	}
+	return false
+}
+
+// Indicates whether lowering a class expression produces expressions that must be evaluated alongside the class,
+// which requires a temporary variable to hold the class.
+func classExpressionNeedsTemp(node *ast.ClassLikeDeclaration) bool {
+	for _, member := range node.Members() {
+		if !isTransformedClassElement(member) {
+			continue
+		}
+		if ast.IsStatic(member) || ast.IsPrivateIdentifierClassElementDeclaration(member) {
+			return true
+		}
+		if name := member.Name(); name != nil && ast.IsComputedPropertyName(name) && !isSimpleInlineableExpression(name.Expression()) {
+			return true
+		}
+	}
+	return false
+}
+
+func (tx *classFieldsTransformer) visitClassDeclaration(node *ast.ClassDeclaration) *ast.Node {
+	if !classNeedsTransform(node.AsNode()) {
+		return tx.Visitor().VisitEachChild(node.AsNode())
+	}
+
+	var alias *ast.IdentifierNode
+	if classNeedsConstructorAlias(node.AsNode()) {
+		alias = tx.newClassTempVariable()
+	}
+
+	// static fields must be able to refer to an anonymous `export default class`
+	name := node.Name()
+	if name == nil {
+		name = tx.Factory().GetLocalName(node.AsNode())
+	}
+
+	lowered := tx.transformClass(node.AsNode(), tx.Factory().GetLocalName(node.AsNode()), alias)
+	classDecl := tx.Factory().UpdateClassDeclaration(
+		node,
+		tx.Visitor().VisitModifiers(node.Modifiers()),
+		name,
+		nil, /*typeParameters*/
+		lowered.heritageClauses,
+		lowered.members,
+	)
+
+	statements := []*ast.Statement{classDecl}
+	expressions := lowered.expressions
+	if alias != nil {
+		expressions = slices.Insert(expressions, 0, tx.Factory().NewAssignmentExpression(alias, tx.Factory().GetLocalName(node.AsNode())))
+	}
+	if len(expressions) > 0 {
+		statements = append(statements, tx.Factory().NewExpressionStatement(tx.Factory().InlineExpressions(expressions)))
+	}
+	for _, expression := range lowered.staticInitializers {
+		statements = append(statements, tx.Factory().NewExpressionStatement(expression))
+	}
+	return tx.Factory().NewSyntaxList(statements)
+}
+
+func (tx *classFieldsTransformer) visitClassExpression(node *ast.ClassExpression) *ast.Node {
+	if !classNeedsTransform(node.AsNode()) {
+		return tx.Visitor().VisitEachChild(node.AsNode())
+	}
+
+	var temp *ast.IdentifierNode
+	if classExpressionNeedsTemp(node.AsNode()) {
+		temp = tx.newClassTempVariable()
+	}
+
+	lowered := tx.transformClass(node.AsNode(), temp, temp)
+	classExpr := tx.Factory().UpdateClassExpression(
+		node,
+		tx.Visitor().VisitModifiers(node.Modifiers()),
+		node.Name(),
+		nil, /*typeParameters*/
+		lowered.heritageClauses,
+		lowered.members,
+	)
+	if temp == nil {
+		return classExpr
+	}
+
+	// (_a = class { ... }, ..., _a)
+	tx.EmitContext().AddEmitFlags(classExpr, printer.EFIndented)
+	expressions := []*ast.Expression{tx.Factory().NewAssignmentExpression(temp, classExpr)}
+	expressions = append(expressions, lowered.expressions...)
+	expressions = append(expressions, lowered.staticInitializers...)
+	expressions = append(expressions, temp.Clone(tx.Factory()))
+	for _, expression := range expressions[1:] {
+		tx.EmitContext().AddEmitFlags(expression, printer.EFStartOnNewLine)
+	}
+	return tx.Factory().InlineExpressions(expressions)
+}
+
+// Lowers the fields, private elements, and static blocks of a class. Static public fields are defined on
+// classReference, while classConstructor (if present) replaces `this` in static initializers and serves as the brand
+// of static private elements.
+func (tx *classFieldsTransformer) transformClass(node *ast.ClassLikeDeclaration, classReference *ast.IdentifierNode, classConstructor *ast.IdentifierNode) *loweredClass {
+	savedClassEnvironment := tx.classEnvironment
+	savedClassThis, savedClassSuper := tx.classThis, tx.classSuper
+	savedPendingExpressions := tx.pendingExpressions
+	tx.pendingExpressions = nil
+
+	lowered := &loweredClass{}
+	lowered.heritageClauses = tx.Visitor().VisitNodes(node.ClassLikeData().HeritageClauses)
+	extendsClause := ast.GetExtendsHeritageClauseElement(node)
+
+	// Capture the base class if `super` is used in a static initializer, since there is no `super` outside of the class
+	// body.
+	var classSuper *ast.Expression
+	if classConstructor != nil && core.Some(node.Members(), func(member *ast.ClassElement) bool {
+		return ast.IsStatic(member) && staticElementContainsFacts(member, ast.SubtreeContainsLexicalSuper)
+	}) {
+		if extendsClause != nil {
+			temp := tx.newClassTempVariable()
+			lowered.heritageClauses = tx.captureBaseClass(lowered.heritageClauses, temp)
+			classSuper = temp
+		} else {
+			classSuper = tx.Factory().NewPropertyAccessExpression(tx.Factory().NewIdentifier("Function"), nil, tx.Factory().NewIdentifier("prototype"), ast.NodeFlagsNone)
+		}
+	}
+
+	env := &classLexicalEnvironment{
+		previous:           tx.classEnvironment,
+		prefix:             "_",
+		classConstructor:   classConstructor,
+		privateIdentifiers: make(map[string]*privateIdentifierInfo),
+	}
+	if name := node.Name(); name != nil && ast.IsIdentifier(name) {
+		env.prefix = "_" + name.Text() + "_"
+	}
+	tx.classEnvironment = env
+
+	for _, member := range node.Members() {
+		if isTransformedClassElement(member) && ast.IsPrivateIdentifierClassElementDeclaration(member) {
+			tx.addPrivateIdentifierToEnvironment(env, member, &lowered.expressions)
+		}
+	}
+
+	// Members are visited in the context of the class declaration, where `this` and `super` keep their outer meaning.
+	tx.classThis, tx.classSuper = savedClassThis, savedClassSuper
+
+	var members []*ast.ClassElement
+	var instanceFields []*ast.Node
+	var staticElements []*ast.ClassElement
+	var privateMethods []*ast.ClassElement
+	var constructor *ast.ConstructorDeclarationNode
+	constructorIndex := -1
+	fieldKeys := make(map[*ast.Node]*ast.Expression)
+	for _, member := range node.Members() {
+		switch {
+		case ast.IsConstructorDeclaration(member) && member.Body() != nil:
+			constructor = member
+			constructorIndex = len(members)
+			members = append(members, nil) // replaced once fields have been collected
+		case !isTransformedClassElement(member):
+			if visited := tx.visitClassElement(member); visited != nil {
+				members = append(members, visited)
+			}
+		case ast.IsPropertyDeclaration(member):
+			if name := member.Name(); ast.IsComputedPropertyName(name) {
+				fieldKeys[member] = tx.visitFieldComputedPropertyName(name)
+			}
+			if ast.IsStatic(member) {
+				staticElements = append(staticElements, member)
+			} else {
+				instanceFields = append(instanceFields, member)
+			}
+		case ast.IsClassStaticBlockDeclaration(member):
+			staticElements = append(staticElements, member)
+		default:
+			privateMethods = append(privateMethods, member)
+		}
+	}
+
+	// Computed property names of fields that were not inlined into a later member are evaluated after the class.
+	lowered.expressions = append(lowered.expressions, tx.pendingExpressions...)
+	tx.pendingExpressions = nil
+
+	tx.classThis, tx.classSuper = nil, nil
+	for _, member := range privateMethods {
+		info := env.privateIdentifiers[member.Name().Text()]
+		var name *ast.IdentifierNode
+		switch member.Kind {
+		case ast.KindGetAccessor:
+			name = info.getterName
+		case ast.KindSetAccessor:
+			name = info.setterName
+		default:
+			name = info.variableName
+		}
+		lowered.expressions = append(lowered.expressions, tx.Factory().NewAssignmentExpression(name, tx.createPrivateMethodFunction(member, name)))
+	}
+
+	isDerivedClass := extendsClause != nil
+	if ctor := tx.transformConstructor(constructor, instanceFields, fieldKeys, isDerivedClass); ctor != nil {
+		if constructorIndex >= 0 {
+			members[constructorIndex] = ctor
+		} else {
+			members = slices.Insert(members, 0, ctor)
+		}
+	}
+
+	tx.classThis, tx.classSuper = classConstructor, classSuper
+	for _, member := range staticElements {
+		var expression *ast.Expression
+		if ast.IsPropertyDeclaration(member) {
+			expression = tx.transformPropertyInitializer(member, classReference, fieldKeys[member])
+		} else {
+			expression = tx.transformClassStaticBlock(member)
+		}
+		if expression != nil {
+			tx.EmitContext().AssignCommentAndSourceMapRanges(expression, member)
+			lowered.staticInitializers = append(lowered.staticInitializers, expression)
+		}
+	}
+
+	membersList := tx.Factory().NewNodeList(members)
+	membersList.Loc = node.MemberList().Loc
+	lowered.members = membersList
+
+	tx.classEnvironment = savedClassEnvironment
+	tx.classThis, tx.classSuper = savedClassThis, savedClassSuper
+	tx.pendingExpressions = savedPendingExpressions
+	return lowered
+}
+
+// Creates a temporary variable that refers to a class or its base class. The name is reserved in nested scopes, as
+// the variable may be referenced from within the members of the class.
+func (tx *classFieldsTransformer) newClassTempVariable() *ast.IdentifierNode {
+	temp := tx.Factory().NewTempVariableEx(printer.AutoGenerateOptions{Flags: printer.GeneratedIdentifierFlagsReservedInNestedScopes})
+	tx.EmitContext().AddVariableDeclaration(temp)
+	return temp
+}
+
+// Rewrites the `extends` clause of a class to capture the base class in a temporary variable:
+//
+//	class C extends B {} -> class C extends (_a = B) {}
+func (tx *classFieldsTransformer) captureBaseClass(heritageClauses *ast.NodeList, temp *ast.IdentifierNode) *ast.NodeList {
+	clauses := slices.Clone(heritageClauses.Nodes)
+	for i, clause := range clauses {
+		if clause.AsHeritageClause().Token != ast.KindExtendsKeyword {
+			continue
+		}
+		types := clause.AsHeritageClause().Types
+		heritage := types.Nodes[0].AsExpressionWithTypeArguments()
+		expression := tx.Factory().NewParenthesizedExpression(tx.Factory().NewAssignmentExpression(temp, heritage.Expression))
+		updatedTypes := tx.Factory().NewNodeList(slices.Concat(
+			[]*ast.Node{tx.Factory().UpdateExpressionWithTypeArguments(heritage, expression, heritage.TypeArguments)},
+			types.Nodes[1:],
+		))
+		updatedTypes.Loc = types.Loc
+		clauses[i] = tx.Factory().UpdateHeritageClause(clause.AsHeritageClause(), updatedTypes)
+	}
+	result := tx.Factory().NewNodeList(clauses)
+	result.Loc = heritageClauses.Loc
+	return result
+}
+
+// Allocates the variables that hold the state of a private element.
+func (tx *classFieldsTransformer) addPrivateIdentifierToEnvironment(env *classLexicalEnvironment, member *ast.ClassElement, expressions *[]*ast.Expression) {
+	name := member.Name()
+	isStatic := ast.IsStatic(member)
+	var brandCheckIdentifier *ast.IdentifierNode
+	if isStatic {
+		brandCheckIdentifier = env.classConstructor
+	}
+
+	switch member.Kind {
+	case ast.KindPropertyDeclaration:
+		variableName := tx.createHoistedVariableForPrivateName(env, name, "")
+		if isStatic {
+			env.privateIdentifiers[name.Text()] = &privateIdentifierInfo{
+				kind:                 printer.PrivateIdentifierKindField,
+				isStatic:             true,
+				brandCheckIdentifier: brandCheckIdentifier,
+				variableName:         variableName,
+			}
+		} else {
+			env.privateIdentifiers[name.Text()] = &privateIdentifierInfo{
+				kind:                 printer.PrivateIdentifierKindField,
+				brandCheckIdentifier: variableName,
+			}
+			*expressions = append(*expressions, tx.Factory().NewAssignmentExpression(variableName, tx.newBuiltinInstance("WeakMap")))
+		}
+	case ast.KindMethodDeclaration:
+		if !isStatic {
+			brandCheckIdentifier = tx.getWeakSetName(env, expressions)
+		}
+		env.privateIdentifiers[name.Text()] = &privateIdentifierInfo{
+			kind:                 printer.PrivateIdentifierKindMethod,
+			isStatic:             isStatic,
+			brandCheckIdentifier: brandCheckIdentifier,
+			variableName:         tx.createHoistedVariableForPrivateName(env, name, ""),
+		}
+	case ast.KindGetAccessor, ast.KindSetAccessor:
+		info := env.privateIdentifiers[name.Text()]
+		if info == nil || info.kind != printer.PrivateIdentifierKindAccessor || info.isStatic != isStatic {
+			if !isStatic {
+				brandCheckIdentifier = tx.getWeakSetName(env, expressions)
+			}
+			info = &privateIdentifierInfo{
+				kind:                 printer.PrivateIdentifierKindAccessor,
+				isStatic:             isStatic,
+				brandCheckIdentifier: brandCheckIdentifier,
+			}
+			env.privateIdentifiers[name.Text()] = info
+		}
+		if ast.IsGetAccessorDeclaration(member) {
+			info.getterName = tx.createHoistedVariableForPrivateName(env, name, "_get")
+		} else {
+			info.setterName = tx.createHoistedVariableForPrivateName(env, name, "_set")
+		}
+	}
+}
+
+// Gets the WeakSet that brands instances of a class with private methods or accessors, creating it if necessary.
+func (tx *classFieldsTransformer) getWeakSetName(env *classLexicalEnvironment, expressions *[]*ast.Expression) *ast.IdentifierNode {
+	if env.weakSetName == nil {
+		env.weakSetName = tx.createHoistedVariableForClass(env, "instances", "")
+		*expressions = append(*expressions, tx.Factory().NewAssignmentExpression(env.weakSetName, tx.newBuiltinInstance("WeakSet")))
+	}
+	return env.weakSetName
+}
+
+func (tx *classFieldsTransformer) createHoistedVariableForPrivateName(env *classLexicalEnvironment, name *ast.PrivateIdentifierNode, suffix string) *ast.IdentifierNode {
+	if tx.EmitContext().HasAutoGenerateInfo(name) {
+		// generated private names (such as the storage of a decorated auto-accessor) have no usable text
+		variableName := tx.Factory().NewGeneratedNameForNodeEx(name, printer.AutoGenerateOptions{
+			Flags:  printer.GeneratedIdentifierFlagsOptimistic | printer.GeneratedIdentifierFlagsReservedInNestedScopes,
+			Prefix: env.prefix,
+			Suffix: suffix,
+		})
+		tx.EmitContext().AddVariableDeclaration(variableName)
+		return variableName
+	}
+	return tx.createHoistedVariableForClass(env, name.Text(), suffix)
+}
+
+func (tx *classFieldsTransformer) createHoistedVariableForClass(env *classLexicalEnvironment, text string, suffix string) *ast.IdentifierNode {
+	name := tx.Factory().NewUniqueNameEx(text, printer.AutoGenerateOptions{
+		Flags:  printer.GeneratedIdentifierFlagsOptimistic | printer.GeneratedIdentifierFlagsReservedInNestedScopes,
+		Prefix: env.prefix,
+		Suffix: suffix,
+	})
+	tx.EmitContext().AddVariableDeclaration(name)
+	return name
+}
+
+func (tx *classFieldsTransformer) newBuiltinInstance(name string) *ast.Expression {
+	return tx.Factory().NewNewExpression(tx.Factory().NewIdentifier(name), nil /*typeArguments*/, tx.Factory().NewNodeList(nil))
+}
+
+// Visits a class element that is not lowered. Computed property names of preceding fields are evaluated as part of
+// the first computed property name that follows them, to preserve evaluation order.
+func (tx *classFieldsTransformer) visitClassElement(member *ast.ClassElement) *ast.ClassElement {
+	visited := tx.Visitor().VisitNode(member)
+	if visited == nil || len(tx.pendingExpressions) == 0 {
+		return visited
+	}
+	name := visited.Name()
+	if name == nil || !ast.IsComputedPropertyName(name) {
+		return visited
+	}
+
+	expressions := append(tx.pendingExpressions, name.Expression())
+	tx.pendingExpressions = nil
+	name = tx.Factory().UpdateComputedPropertyName(name.AsComputedPropertyName(), tx.Factory().InlineExpressions(expressions))
+	switch visited.Kind {
+	case ast.KindMethodDeclaration:
+		method := visited.AsMethodDeclaration()
+		return tx.Factory().UpdateMethodDeclaration(method, method.Modifiers(), method.AsteriskToken, name, method.PostfixToken, method.TypeParameters, method.Parameters, method.Type, method.Body)
+	case ast.KindGetAccessor:
+		accessor := visited.AsGetAccessorDeclaration()
+		return tx.Factory().UpdateGetAccessorDeclaration(accessor, accessor.Modifiers(), name, accessor.TypeParameters, accessor.Parameters, accessor.Type, accessor.Body)
+	case ast.KindSetAccessor:
+		accessor := visited.AsSetAccessorDeclaration()
+		return tx.Factory().UpdateSetAccessorDeclaration(accessor, accessor.Modifiers(), name, accessor.TypeParameters, accessor.Parameters, accessor.Type, accessor.Body)
+	case ast.KindPropertyDeclaration:
+		property := visited.AsPropertyDeclaration()
+		return tx.Factory().UpdatePropertyDeclaration(property, property.Modifiers(), name, property.PostfixToken, property.Type, property.Initializer)
+	}
+	return visited
+}
+
+// Visits the computed property name of a field. Since the field is moved out of the class body, a key that has side
+// effects is evaluated into a temporary variable at its original position in the class.
+func (tx *classFieldsTransformer) visitFieldComputedPropertyName(name *ast.Node) *ast.Expression {
+	expression := tx.Visitor().VisitNode(name.Expression())
+	if isSimpleInlineableExpression(expression) {
+		return expression
+	}
+	temp := tx.Factory().NewTempVariable()
+	tx.EmitContext().AddVariableDeclaration(temp)
+	tx.pendingExpressions = append(tx.pendingExpressions, tx.Factory().NewAssignmentExpression(temp, expression))
+	return temp
+}
+
+// Creates the function that implements a private method or accessor:
+//
+//	#m() {} -> _C_m = function _C_m() {}
+func (tx *classFieldsTransformer) createPrivateMethodFunction(member *ast.ClassElement, name *ast.IdentifierNode) *ast.Expression {
+	var asteriskToken *ast.TokenNode
+	if ast.IsMethodDeclaration(member) {
+		asteriskToken = member.AsMethodDeclaration().AsteriskToken
+	}
+	parameters := tx.EmitContext().VisitParameters(member.ParameterList(), tx.Visitor())
+	body := tx.EmitContext().VisitFunctionBody(member.Body(), tx.Visitor())
+	fn := tx.Factory().NewFunctionExpression(
+		transformers.ExtractModifiers(tx.EmitContext(), member.Modifiers(), ast.ModifierFlagsAsync),
+		asteriskToken,
+		name.Clone(tx.Factory()),
+		nil, /*typeParameters*/
+		parameters,
+		nil, /*returnType*/
+		body,
+	)
+	fn.Loc = member.Loc
+	tx.EmitContext().SetOriginal(fn, member)
+	return fn
+}
+
+// Lowers a `static {}` block into an immediately invoked arrow function. A helper block that only assigns the name of
+// an anonymous class is lowered to the call to the helper itself.
+func (tx *classFieldsTransformer) transformClassStaticBlock(member *ast.ClassElement) *ast.Expression {
+	body := member.AsClassStaticBlockDeclaration().Body.AsBlock()
+	if isClassNamedEvaluationHelperBlock(tx.EmitContext(), member) {
+		return tx.Visitor().VisitNode(body.Statements.Nodes[0].Expression())
+	}
+
+	tx.EmitContext().StartVariableEnvironment()
+	statements, _ := tx.Visitor().VisitSlice(body.Statements.Nodes)
+	statements = tx.EmitContext().EndAndMergeVariableEnvironment(statements)
+	statementList := tx.Factory().NewNodeList(statements)
+	statementList.Loc = body.Statements.Loc
+	return createClassStaticBlockIIFE(tx.Factory(), tx.Factory().UpdateBlock(body, statementList))
+}
+
+//
+// Constructors
+//
+
+// Indicates whether a field must be initialized by the constructor or the class.
+func (tx *classFieldsTransformer) fieldHasInitializer(member *ast.Node) bool {
+	if ast.IsPrivateIdentifier(member.Name()) || tx.useDefineForClassFields {
+		return true
+	}
+	return member.Initializer() != nil && !tx.isParameterPropertyField(member)
+}
+
+// Adds the initializers of instance fields to the constructor of a class, synthesizing a constructor if the class
+// does not declare one.
+func (tx *classFieldsTransformer) transformConstructor(constructor *ast.ConstructorDeclarationNode, instanceFields []*ast.Node, fieldKeys map[*ast.Node]*ast.Expression, isDerivedClass bool) *ast.Node {
+	if tx.classEnvironment.weakSetName == nil && !core.Some(instanceFields, tx.fieldHasInitializer) {
+		if constructor == nil {
+			return nil
+		}
+		return tx.Visitor().VisitNode(constructor)
+	}
+
+	ec := tx.EmitContext()
+	var parameters *ast.NodeList
+	if constructor != nil {
+		parameters = ec.VisitParameters(constructor.ParameterList(), tx.Visitor())
+	} else {
+		ec.StartVariableEnvironment()
+		parameters = tx.Factory().NewNodeList(nil)
+	}
+
+	var initializers []*ast.Statement
+	if weakSetName := tx.classEnvironment.weakSetName; weakSetName != nil {
+		// _C_instances.add(this)
+		initializers = append(initializers, tx.Factory().NewExpressionStatement(tx.Factory().NewCallExpression(
+			tx.Factory().NewPropertyAccessExpression(weakSetName, nil, tx.Factory().NewIdentifier("add"), ast.NodeFlagsNone),
+			nil, /*questionDotToken*/
+			nil, /*typeArguments*/
+			tx.Factory().NewNodeList([]*ast.Expression{tx.Factory().NewThisExpression()}),
+			ast.NodeFlagsNone,
+		)))
+	}
+	for _, field := range instanceFields {
+		if expression := tx.transformPropertyInitializer(field, tx.Factory().NewThisExpression(), fieldKeys[field]); expression != nil {
+			statement := tx.Factory().NewExpressionStatement(expression)
+			ec.SetOriginal(statement, field)
+			ec.AssignCommentAndSourceMapRanges(statement, field)
+			initializers = append(initializers, statement)
+		}
+	}
+
+	var statements []*ast.Statement
+	if constructor == nil {
+		if isDerivedClass {
+			// super(...arguments)
+			statements = append(statements, tx.Factory().NewExpressionStatement(tx.Factory().NewCallExpression(
+				tx.Factory().NewKeywordExpression(ast.KindSuperKeyword),
+				nil, /*questionDotToken*/
+				nil, /*typeArguments*/
+				tx.Factory().NewNodeList([]*ast.Expression{tx.Factory().NewSpreadElement(tx.Factory().NewIdentifier("arguments"))}),
+				ast.NodeFlagsNone,
+			)))
+		}
+		statements = append(statements, initializers...)
+		statements = ec.EndAndMergeVariableEnvironment(statements)
+		return tx.Factory().NewConstructorDeclaration(
+			nil, /*modifiers*/
+			nil, /*typeParameters*/
+			parameters,
+			nil, /*returnType*/
+			tx.Factory().NewBlock(tx.Factory().NewNodeList(statements), true /*multiLine*/),
+		)
+	}
+
+	body := constructor.Body().AsBlock()
+	prologue, rest := tx.Factory().SplitStandardPrologue(body.Statements.Nodes)
+	statements = slices.Clone(prologue)
+	var superPath []int
+	if isDerivedClass {
+		superPath = findSuperStatementIndexPath(rest, 0)
+	}
+	statements = append(statements, tx.transformConstructorBodyWorker(rest, superPath, initializers)...)
+	statements = ec.EndAndMergeVariableEnvironment(statements)
+	statementList := tx.Factory().NewNodeList(statements)
+	statementList.Loc = body.Statements.Loc
+	return tx.Factory().UpdateConstructorDeclaration(
+		constructor.AsConstructorDeclaration(),
+		tx.Visitor().VisitModifiers(constructor.Modifiers()),
+		nil, /*typeParameters*/
+		parameters,
+		nil, /*returnType*/
+		tx.Factory().UpdateBlock(body, statementList),
+	)
+}
+
+// Visits the statements of a constructor body, adding field initializers after the `super` call found at superPath
+// (descending into `try` blocks), or at the start of the body when the class is not derived. Parameter property
+// assignments are kept ahead of the field initializers, as they run first.
+func (tx *classFieldsTransformer) transformConstructorBodyWorker(statementsIn []*ast.Statement, superPath []int, initializers []*ast.Statement) []*ast.Statement {
+	statements := make([]*ast.Statement, 0, len(statementsIn)+len(initializers))
+	var rest []*ast.Statement
+	if len(superPath) == 0 {
+		rest = statementsIn
+	} else {
+		superIndex := superPath[0]
+		visited, _ := tx.Visitor().VisitSlice(statementsIn[:superIndex])
+		statements = append(statements, visited...)
+
+		superStatement := statementsIn[superIndex]
+		if ast.IsTryStatement(superStatement) {
+			tryStatement := superStatement.AsTryStatement()
+			tryBlock := tryStatement.TryBlock.AsBlock()
+			tryStatements := tx.Factory().NewNodeList(tx.transformConstructorBodyWorker(tryBlock.Statements.Nodes, superPath[1:], initializers))
+			tryStatements.Loc = tryBlock.Statements.Loc
+			statements = append(statements, tx.Factory().UpdateTryStatement(
+				tryStatement,
+				tx.Factory().UpdateBlock(tryBlock, tryStatements),
+				tx.Visitor().VisitNode(tryStatement.CatchClause),
+				tx.Visitor().VisitNode(tryStatement.FinallyBlock),
+			))
+			rest, _ = tx.Visitor().VisitSlice(statementsIn[superIndex+1:])
+			return append(statements, rest...)
+		}
+		statements = append(statements, tx.Visitor().VisitNode(superStatement))
+		rest = statementsIn[superIndex+1:]
+	}
+
+	// Parameter property assignments are added by the runtime syntax transform directly after the `super` call.
+	for len(rest) > 0 && tx.isParameterPropertyAssignment(rest[0]) {
+		if !tx.useDefineForClassFields {
+			statements = append(statements, tx.Visitor().VisitNode(rest[0]))
+		}
+		rest = rest[1:]
+	}
+	statements = append(statements, initializers...)
+	visited, _ := tx.Visitor().VisitSlice(rest)
+	return append(statements, visited...)
+}
+
+// Indicates whether a field was declared by the runtime syntax transform for a parameter property.
+func (tx *classFieldsTransformer) isParameterPropertyField(member *ast.Node) bool {
+	original := tx.EmitContext().Original(member)
+	return original != nil && ast.IsParameter(original)
 }
 
-func (ch *classFieldsTransformer) visit(node *ast.Node) *ast.Node {
-	return node // !!!
+func (tx *classFieldsTransformer) isParameterPropertyAssignment(statement *ast.Statement) bool {
+	original := tx.EmitContext().Original(statement)
+	return ast.IsExpressionStatement(statement) && original != nil && ast.IsParameter(original)
 }
 
-func newClassFieldsTransformer(emitContext *printer.EmitContext) *transformers.Transformer {
-	tx := &classFieldsTransformer{}
-	return tx.NewTransformer(tx.visit, emitContext)
+// Creates the expression that initializes a field on receiver, or nil if the field needs no initialization.
+func (tx *classFieldsTransformer) transformPropertyInitializer(member *ast.Node, receiver *ast.Expression, key *ast.Expression) *ast.Expression {
+	ec := tx.EmitContext()
+	name := member.Name()
+	if !ast.IsComputedPropertyName(name) && isNamedEvaluationAnd(ec, member, isAnonymousClassNeedingAssignedName) {
+		member = transformNamedEvaluation(ec, member, false /*ignoreEmptyStringLiteral*/, "")
+	}
+
+	var initializer *ast.Expression
+	if tx.isParameterPropertyField(member) {
+		if !tx.useDefineForClassFields {
+			return nil
+		}
+		// the value of a parameter property is assigned after the field is defined
+		initializer = tx.Factory().NewIdentifier(name.Text())
+	} else {
+		initializer = tx.Visitor().VisitNode(member.Initializer())
+	}
+
+	if ast.IsPrivateIdentifier(name) {
+		if initializer == nil {
+			initializer = tx.Factory().NewVoidZeroExpression()
+		}
+		info := tx.classEnvironment.lookup(name)
+		if info.isStatic {
+			// _C_x = { value: initializer }
+			return tx.Factory().NewAssignmentExpression(
+				info.variableName,
+				tx.Factory().NewObjectLiteralExpression(tx.Factory().NewNodeList([]*ast.Node{
+					tx.Factory().NewPropertyAssignment(nil /*modifiers*/, tx.Factory().NewIdentifier("value"), nil /*postfixToken*/, nil /*typeNode*/, initializer),
+				}), false /*multiLine*/),
+			)
+		}
+		// _C_x.set(receiver, initializer)
+		return tx.Factory().NewCallExpression(
+			tx.Factory().NewPropertyAccessExpression(info.brandCheckIdentifier, nil, tx.Factory().NewIdentifier("set"), ast.NodeFlagsNone),
+			nil, /*questionDotToken*/
+			nil, /*typeArguments*/
+			tx.Factory().NewNodeList([]*ast.Expression{receiver, initializer}),
+			ast.NodeFlagsNone,
+		)
+	}
+
+	if tx.useDefineForClassFields {
+		if initializer == nil {
+			initializer = tx.Factory().NewVoidZeroExpression()
+		}
+		var propertyName *ast.Expression
+		switch {
+		case ast.IsComputedPropertyName(name):
+			propertyName = key
+		case ast.IsIdentifier(name):
+			propertyName = tx.Factory().NewStringLiteralFromNode(name)
+		default:
+			propertyName = name.Clone(tx.Factory())
+		}
+		return tx.createDefinePropertyCall(receiver, propertyName, initializer)
+	}
+
+	if initializer == nil {
+		return nil
+	}
+	var target *ast.Expression
+	switch {
+	case ast.IsComputedPropertyName(name):
+		target = tx.Factory().NewElementAccessExpression(receiver, nil /*questionDotToken*/, key, ast.NodeFlagsNone)
+	case ast.IsIdentifier(name):
+		target = tx.Factory().NewPropertyAccessExpression(receiver, nil /*questionDotToken*/, name.Clone(tx.Factory()), ast.NodeFlagsNone)
+	default:
+		target = tx.Factory().NewElementAccessExpression(receiver, nil /*questionDotToken*/, name.Clone(tx.Factory()), ast.NodeFlagsNone)
+	}
+	return tx.Factory().NewAssignmentExpression(target, initializer)
+}
+
+// Object.defineProperty(receiver, propertyName, { enumerable: true, configurable: true, writable: true, value })
+func (tx *classFieldsTransformer) createDefinePropertyCall(receiver *ast.Expression, propertyName *ast.Expression, value *ast.Expression) *ast.Expression {
+	descriptorProperty := func(name string, value *ast.Expression) *ast.Node {
+		return tx.Factory().NewPropertyAssignment(nil /*modifiers*/, tx.Factory().NewIdentifier(name), nil /*postfixToken*/, nil /*typeNode*/, value)
+	}
+	descriptor := tx.Factory().NewObjectLiteralExpression(tx.Factory().NewNodeList([]*ast.Node{
+		descriptorProperty("enumerable", tx.Factory().NewTrueExpression()),
+		descriptorProperty("configurable", tx.Factory().NewTrueExpression()),
+		descriptorProperty("writable", tx.Factory().NewTrueExpression()),
+		descriptorProperty("value", value),
+	}), true /*multiLine*/)
+	return tx.Factory().NewCallExpression(
+		tx.Factory().NewPropertyAccessExpression(tx.Factory().NewIdentifier("Object"), nil, tx.Factory().NewIdentifier("defineProperty"), ast.NodeFlagsNone),
+		nil, /*questionDotToken*/
+		nil, /*typeArguments*/
+		tx.Factory().NewNodeList([]*ast.Expression{receiver, propertyName, descriptor}),
+		ast.NodeFlagsNone,
+	)
+}
+
+//
+// Named evaluation
+//
+
+// Indicates whether an anonymous class has static elements that are lowered by this transform and observe the name
+// of the class, in which case the name must be assigned explicitly before they are evaluated.
+func isAnonymousClassNeedingAssignedName(node *anonymousFunctionDefinition) bool {
+	if !ast.IsClassExpression(node) {
+		return false
+	}
+	return core.Some(node.Members(), func(member *ast.ClassElement) bool {
+		if !ast.IsStatic(member) || !isTransformedClassElement(member) {
+			return false
+		}
+		return ast.IsClassStaticBlockDeclaration(member) ||
+			ast.IsPrivateIdentifierClassElementDeclaration(member) ||
+			member.Initializer() != nil
+	})
+}
+
+func (tx *classFieldsTransformer) visitNamedEvaluationSource(node *ast.Node) *ast.Node {
+	if isNamedEvaluationAnd(tx.EmitContext(), node, isAnonymousClassNeedingAssignedName) {
+		node = transformNamedEvaluation(tx.EmitContext(), node, node.Kind == ast.KindExportAssignment /*ignoreEmptyStringLiteral*/, "")
+	}
+	return tx.Visitor().VisitEachChild(node)
+}
+
+//
+// Private names
+//
+
+func (tx *classFieldsTransformer) createPrivateIdentifierAccess(info *privateIdentifierInfo, receiver *ast.Expression) *ast.Expression {
+	switch info.kind {
+	case printer.PrivateIdentifierKindAccessor:
+		return tx.Factory().NewClassPrivateFieldGetHelper(receiver, info.brandCheckIdentifier, info.kind, info.getterName)
+	default:
+		return tx.Factory().NewClassPrivateFieldGetHelper(receiver, info.brandCheckIdentifier, info.kind, info.variableName)
+	}
+}
+
+func (tx *classFieldsTransformer) createPrivateIdentifierAssignment(info *privateIdentifierInfo, receiver *ast.Expression, value *ast.Expression) *ast.Expression {
+	switch info.kind {
+	case printer.PrivateIdentifierKindAccessor:
+		return tx.Factory().NewClassPrivateFieldSetHelper(receiver, info.brandCheckIdentifier, value, info.kind, info.setterName)
+	default:
+		return tx.Factory().NewClassPrivateFieldSetHelper(receiver, info.brandCheckIdentifier, value, info.kind, info.variableName)
+	}
+}
+
+// Gets the lowered private element accessed by a property access, if any.
+func (tx *classFieldsTransformer) privateIdentifierInfoOf(node *ast.Node) *privateIdentifierInfo {
+	if !ast.IsPropertyAccessExpression(node) || !ast.IsPrivateIdentifier(node.Name()) {
+		return nil
+	}
+	return tx.classEnvironment.lookup(node.Name())
+}
+
+// Creates a copy of an expression that is evaluated more than once. Unless the expression is a literal or a keyword
+// like `this` (or, if allowIdentifiers is set, an identifier), it is evaluated into a temporary variable.
+func (tx *classFieldsTransformer) copyReceiver(original *ast.Expression, receiver *ast.Expression, allowIdentifiers bool) (initializer *ast.Expression, read *ast.Expression) {
+	if isSimpleInlineableExpression(original) || allowIdentifiers && ast.IsIdentifier(original) {
+		return receiver, receiver.Clone(tx.Factory())
+	}
+	temp := tx.Factory().NewTempVariable()
+	tx.EmitContext().AddVariableDeclaration(temp)
+	return tx.Factory().NewAssignmentExpression(temp, receiver), temp
+}
+
+func (tx *classFieldsTransformer) visitPropertyAccessExpression(node *ast.PropertyAccessExpression) *ast.Node {
+	// !!! optional chains that access private names
+	if info := tx.privateIdentifierInfoOf(node.AsNode()); info != nil && node.QuestionDotToken == nil {
+		result := tx.createPrivateIdentifierAccess(info, tx.Visitor().VisitNode(node.Expression))
+		tx.EmitContext().SetOriginal(result, node.AsNode())
+		result.Loc = node.Loc
+		return result
+	}
+	if tx.classSuper != nil && isSuperProperty(node.AsNode()) {
+		return tx.createSuperPropertyGet(tx.Factory().NewStringLiteralFromNode(node.Name()), node.AsNode())
+	}
+	return tx.Visitor().VisitEachChild(node.AsNode())
+}
+
+func (tx *classFieldsTransformer) visitElementAccessExpression(node *ast.ElementAccessExpression) *ast.Node {
+	if tx.classSuper != nil && isSuperProperty(node.AsNode()) {
+		return tx.createSuperPropertyGet(tx.Visitor().VisitNode(node.ArgumentExpression), node.AsNode())
+	}
+	return tx.Visitor().VisitEachChild(node.AsNode())
+}
+
+// Reflect.get(_super, key, _this)
+func (tx *classFieldsTransformer) createSuperPropertyGet(key *ast.Expression, node *ast.Node) *ast.Expression {
+	result := tx.newReflectCall("get", tx.classSuper.Clone(tx.Factory()), key, tx.classThis.Clone(tx.Factory()))
+	tx.EmitContext().SetOriginal(result, node)
+	result.Loc = node.Loc
+	return result
+}
+
+func (tx *classFieldsTransformer) newReflectCall(method string, arguments ...*ast.Expression) *ast.Expression {
+	return tx.Factory().NewCallExpression(
+		tx.Factory().NewPropertyAccessExpression(tx.Factory().NewIdentifier("Reflect"), nil, tx.Factory().NewIdentifier(method), ast.NodeFlagsNone),
+		nil, /*questionDotToken*/
+		nil, /*typeArguments*/
+		tx.Factory().NewNodeList(arguments),
+		ast.NodeFlagsNone,
+	)
+}
+
+// Gets the function and the `this` argument for a call to a private method or a `super` method in a static
+// initializer, or nil if the callee is not lowered.
+func (tx *classFieldsTransformer) visitCallee(callee *ast.Expression) (target *ast.Expression, thisArg *ast.Expression) {
+	if info := tx.privateIdentifierInfoOf(callee); info != nil && callee.AsPropertyAccessExpression().QuestionDotToken == nil {
+		receiver := callee.Expression()
+		initializer, read := tx.copyReceiver(receiver, tx.Visitor().VisitNode(receiver), true /*allowIdentifiers*/)
+		target = tx.createPrivateIdentifierAccess(info, initializer)
+		target.Loc = callee.Loc
+		return target, read
+	}
+	if tx.classSuper != nil && isSuperProperty(callee) {
+		return tx.Visitor().VisitNode(callee), tx.classThis.Clone(tx.Factory())
+	}
+	return nil, nil
+}
+
+func (tx *classFieldsTransformer) visitCallExpression(node *ast.CallExpression) *ast.Node {
+	target, thisArg := tx.visitCallee(node.Expression)
+	if target == nil {
+		return tx.Visitor().VisitEachChild(node.AsNode())
+	}
+
+	// target.call(thisArg, ...arguments)
+	arguments := tx.Visitor().VisitNodes(node.Arguments)
+	result := tx.Factory().NewCallExpression(
+		tx.Factory().NewPropertyAccessExpression(target, node.QuestionDotToken, tx.Factory().NewIdentifier("call"), node.Flags&ast.NodeFlagsOptionalChain),
+		nil, /*questionDotToken*/
+		nil, /*typeArguments*/
+		tx.Factory().NewNodeList(slices.Concat([]*ast.Expression{thisArg}, arguments.Nodes)),
+		node.Flags&ast.NodeFlagsOptionalChain,
+	)
+	tx.EmitContext().SetOriginal(result, node.AsNode())
+	result.Loc = node.Loc
+	return result
+}
+
+func (tx *classFieldsTransformer) visitTaggedTemplateExpression(node *ast.TaggedTemplateExpression) *ast.Node {
+	target, thisArg := tx.visitCallee(node.Tag)
+	if target == nil {
+		return tx.Visitor().VisitEachChild(node.AsNode())
+	}
+
+	// target.bind(thisArg)`...`
+	tag := tx.Factory().NewCallExpression(
+		tx.Factory().NewPropertyAccessExpression(target, nil, tx.Factory().NewIdentifier("bind"), ast.NodeFlagsNone),
+		nil, /*questionDotToken*/
+		nil, /*typeArguments*/
+		tx.Factory().NewNodeList([]*ast.Expression{thisArg}),
+		ast.NodeFlagsNone,
+	)
+	return tx.Factory().UpdateTaggedTemplateExpression(node, tag, nil /*questionDotToken*/, nil /*typeArguments*/, tx.Visitor().VisitNode(node.Template))
+}
+
+func (tx *classFieldsTransformer) visitExpressionStatement(node *ast.ExpressionStatement) *ast.Node {
+	return tx.Factory().UpdateExpressionStatement(node, tx.discardedValueVisitor.VisitNode(node.Expression))
+}
+
+func (tx *classFieldsTransformer) visitForStatement(node *ast.ForStatement) *ast.Node {
+	return tx.Factory().UpdateForStatement(
+		node,
+		tx.discardedValueVisitor.VisitNode(node.Initializer),
+		tx.Visitor().VisitNode(node.Condition),
+		tx.discardedValueVisitor.VisitNode(node.Incrementor),
+		tx.EmitContext().VisitIterationBody(node.Statement, tx.Visitor()),
+	)
+}
+
+func (tx *classFieldsTransformer) visitParenthesizedExpression(node *ast.ParenthesizedExpression, discarded bool) *ast.Node {
+	visitor := tx.Visitor()
+	if discarded {
+		visitor = tx.discardedValueVisitor
+	}
+	return tx.Factory().UpdateParenthesizedExpression(node, visitor.VisitNode(node.Expression))
+}
+
+func (tx *classFieldsTransformer) visitPreOrPostfixUnaryExpression(node *ast.Node, discarded bool) *ast.Node {
+	var operator ast.Kind
+	var operand *ast.Expression
+	if ast.IsPrefixUnaryExpression(node) {
+		operator, operand = node.AsPrefixUnaryExpression().Operator, node.AsPrefixUnaryExpression().Operand
+	} else {
+		operator, operand = node.AsPostfixUnaryExpression().Operator, node.AsPostfixUnaryExpression().Operand
+	}
+	if operator != ast.KindPlusPlusToken && operator != ast.KindMinusMinusToken {
+		return tx.Visitor().VisitEachChild(node)
+	}
+	operand = ast.SkipParentheses(operand)
+	info := tx.privateIdentifierInfoOf(operand)
+	if info == nil || operand.AsPropertyAccessExpression().QuestionDotToken != nil {
+		if tx.classSuper == nil || !isSuperProperty(operand) {
+			return tx.Visitor().VisitEachChild(node)
+		}
+	}
+
+	// __classPrivateFieldSet(_a = o, _C_x, (_b = __classPrivateFieldGet(_a, _C_x, "f"), _b++, _b), "f")
+	var resultVariable *ast.IdentifierNode
+	if !discarded && ast.IsPostfixUnaryExpression(node) {
+		resultVariable = tx.Factory().NewTempVariable()
+		tx.EmitContext().AddVariableDeclaration(resultVariable)
+	}
+	read, write := tx.createPropertyReadAndWrite(operand, info, false /*readFirst*/)
+	expression := write(expandPreOrPostfixIncrementOrDecrementExpression(tx.EmitContext(), node, read, resultVariable))
+	if resultVariable != nil {
+		expression = tx.Factory().NewCommaExpression(expression, resultVariable)
+	}
+	tx.EmitContext().SetOriginal(expression, node)
+	expression.Loc = node.Loc
+	return expression
+}
+
+// Creates a read of a lowered property and a function that writes a value to the same property. The receiver (or
+// key) is evaluated only once, as part of the read if readFirst is set, or otherwise as part of the write.
+func (tx *classFieldsTransformer) createPropertyReadAndWrite(target *ast.Expression, info *privateIdentifierInfo, readFirst bool) (read *ast.Expression, write func(value *ast.Expression) *ast.Expression) {
+	if info != nil {
+		receiver := target.Expression()
+		initializer, copied := tx.copyReceiver(receiver, tx.Visitor().VisitNode(receiver), false /*allowIdentifiers*/)
+		if !readFirst {
+			initializer, copied = copied, initializer
+		}
+		read = tx.createPrivateIdentifierAccess(info, initializer)
+		return read, func(value *ast.Expression) *ast.Expression {
+			return tx.createPrivateIdentifierAssignment(info, copied, value)
+		}
+	}
+
+	// Reflect.set(_super, key, value, _this)
+	var key *ast.Expression
+	if ast.IsPropertyAccessExpression(target) {
+		key = tx.Factory().NewStringLiteralFromNode(target.Name())
+	} else {
+		key = tx.Visitor().VisitNode(target.AsElementAccessExpression().ArgumentExpression)
+	}
+	initializer, copied := tx.copyReceiver(key, key, false /*allowIdentifiers*/)
+	if !readFirst {
+		initializer, copied = copied, initializer
+	}
+	read = tx.newReflectCall("get", tx.classSuper.Clone(tx.Factory()), initializer, tx.classThis.Clone(tx.Factory()))
+	return read, func(value *ast.Expression) *ast.Expression {
+		return tx.newReflectCall("set", tx.classSuper.Clone(tx.Factory()), copied, value, tx.classThis.Clone(tx.Factory()))
+	}
+}
+
+func (tx *classFieldsTransformer) visitBinaryExpression(node *ast.BinaryExpression, discarded bool) *ast.Node {
+	if isNamedEvaluationAnd(tx.EmitContext(), node.AsNode(), isAnonymousClassNeedingAssignedName) {
+		transformed := transformNamedEvaluation(tx.EmitContext(), node.AsNode(), false /*ignoreEmptyStringLiteral*/, "")
+		return tx.Visitor().VisitNode(transformed)
+	}
+
+	operator := node.OperatorToken.Kind
+	left := ast.SkipParentheses(node.Left)
+	switch {
+	case ast.IsDestructuringAssignment(node.AsNode()):
+		return tx.visitDestructuringAssignment(node)
+	case operator == ast.KindCommaToken:
+		leftVisitor := tx.discardedValueVisitor
+		rightVisitor := tx.Visitor()
+		if discarded {
+			rightVisitor = tx.discardedValueVisitor
+		}
+		return tx.Factory().UpdateBinaryExpression(node, nil /*modifiers*/, leftVisitor.VisitNode(node.Left), nil /*typeNode*/, node.OperatorToken, rightVisitor.VisitNode(node.Right))
+	case operator == ast.KindInKeyword && ast.IsPrivateIdentifier(node.Left):
+		if info := tx.classEnvironment.lookup(node.Left); info != nil {
+			// __classPrivateFieldIn(_C_x, o)
+			result := tx.Factory().NewClassPrivateFieldInHelper(info.brandCheckIdentifier, tx.Visitor().VisitNode(node.Right))
+			tx.EmitContext().SetOriginal(result, node.AsNode())
+			result.Loc = node.Loc
+			return result
+		}
+	case ast.IsAssignmentOperator(operator):
+		info := tx.privateIdentifierInfoOf(left)
+		if info == nil || left.AsPropertyAccessExpression().QuestionDotToken != nil {
+			info = nil
+			if tx.classSuper == nil || !isSuperProperty(left) {
+				break
+			}
+		}
+		result := tx.transformAssignment(node, left, info)
+		tx.EmitContext().SetOriginal(result, node.AsNode())
+		result.Loc = node.Loc
+		return result
+	}
+	return tx.Visitor().VisitEachChild(node.AsNode())
+}
+
+// Lowers an assignment to a private element or to a `super` property in a static initializer.
+func (tx *classFieldsTransformer) transformAssignment(node *ast.BinaryExpression, left *ast.Expression, info *privateIdentifierInfo) *ast.Expression {
+	operator := node.OperatorToken.Kind
+	if operator == ast.KindEqualsToken {
+		if info != nil {
+			receiver := tx.Visitor().VisitNode(left.Expression())
+			return tx.createPrivateIdentifierAssignment(info, receiver, tx.Visitor().VisitNode(node.Right))
+		}
+		var key *ast.Expression
+		if ast.IsPropertyAccessExpression(left) {
+			key = tx.Factory().NewStringLiteralFromNode(left.Name())
+		} else {
+			key = tx.Visitor().VisitNode(left.AsElementAccessExpression().ArgumentExpression)
+		}
+		return tx.newReflectCall("set", tx.classSuper.Clone(tx.Factory()), key, tx.Visitor().VisitNode(node.Right), tx.classThis.Clone(tx.Factory()))
+	}
+
+	readFirst := ast.IsLogicalOrCoalescingAssignmentOperator(operator)
+	read, write := tx.createPropertyReadAndWrite(left, info, readFirst)
+	right := tx.Visitor().VisitNode(node.Right)
+	if readFirst {
+		// read || write(right)
+		return tx.Factory().NewBinaryExpression(
+			nil, /*modifiers*/
+			read,
+			nil, /*typeNode*/
+			tx.Factory().NewToken(getNonAssignmentOperatorForCompoundAssignment(operator)),
+			write(right),
+		)
+	}
+	// write(read op right)
+	return write(tx.Factory().NewBinaryExpression(
+		nil, /*modifiers*/
+		read,
+		nil, /*typeNode*/
+		tx.Factory().NewToken(getNonAssignmentOperatorForCompoundAssignment(operator)),
+		right,
+	))
+}
+
+//
+// Destructuring assignments
+//
+
+func (tx *classFieldsTransformer) visitDestructuringAssignment(node *ast.BinaryExpression) *ast.Node {
+	savedPendingExpressions := tx.pendingExpressions
+	tx.pendingExpressions = nil
+	left := tx.assignmentTargetVisitor.VisitNode(node.Left)
+	right := tx.Visitor().VisitNode(node.Right)
+	result := tx.Factory().UpdateBinaryExpression(node, nil /*modifiers*/, left, nil /*typeNode*/, node.OperatorToken, right)
+	if len(tx.pendingExpressions) > 0 {
+		result = tx.Factory().InlineExpressions(append(tx.pendingExpressions, result))
+	}
+	tx.pendingExpressions = savedPendingExpressions
+	return result
+}
+
+// Visits the target of a destructuring assignment, or an element or property of an assignment pattern.
+func (tx *classFieldsTransformer) visitAssignmentTarget(node *ast.Node) *ast.Node {
+	switch node.Kind {
+	case ast.KindArrayLiteralExpression, ast.KindObjectLiteralExpression:
+		return tx.assignmentTargetVisitor.VisitEachChild(node)
+	case ast.KindSpreadElement:
+		return tx.Factory().UpdateSpreadElement(node.AsSpreadElement(), tx.assignmentTargetVisitor.VisitNode(node.Expression()))
+	case ast.KindSpreadAssignment:
+		return tx.Factory().UpdateSpreadAssignment(node.AsSpreadAssignment(), tx.assignmentTargetVisitor.VisitNode(node.Expression()))
+	case ast.KindPropertyAssignment:
+		property := node.AsPropertyAssignment()
+		return tx.Factory().UpdatePropertyAssignment(
+			property,
+			nil, /*modifiers*/
+			tx.Visitor().VisitNode(property.Name()),
+			nil, /*postfixToken*/
+			nil, /*typeNode*/
+			tx.assignmentTargetVisitor.VisitNode(property.Initializer),
+		)
+	case ast.KindBinaryExpression:
+		// a target with a default value
+		if node.AsBinaryExpression().OperatorToken.Kind == ast.KindEqualsToken {
+			binary := node.AsBinaryExpression()
+			return tx.Factory().UpdateBinaryExpression(
+				binary,
+				nil, /*modifiers*/
+				tx.assignmentTargetVisitor.VisitNode(binary.Left),
+				nil, /*typeNode*/
+				binary.OperatorToken,
+				tx.Visitor().VisitNode(binary.Right),
+			)
+		}
+	case ast.KindPropertyAccessExpression:
+		if info := tx.privateIdentifierInfoOf(node); info != nil && node.AsPropertyAccessExpression().QuestionDotToken == nil {
+			return tx.wrapPrivateIdentifierForDestructuringTarget(node.AsPropertyAccessExpression(), info)
+		}
+	}
+	return tx.Visitor().VisitNode(node)
+}
+
+// Wraps the assignment to a private element in an object literal with a setter, which can be the target of a
+// destructuring assignment:
+//
+//	[this.#x] = arr -> (_a = this, [({ set value(_b) { __classPrivateFieldSet(_a, _C_x, _b, "f"); } }).value] = arr)
+func (tx *classFieldsTransformer) wrapPrivateIdentifierForDestructuringTarget(node *ast.PropertyAccessExpression, info *privateIdentifierInfo) *ast.Expression {
+	receiver := tx.Visitor().VisitNode(node.Expression)
+	if node.Expression.Kind == ast.KindThisKeyword || !isSimpleCopiableExpression(node.Expression) {
+		// the receiver cannot be referenced from within the setter, as `this` would be rebound
+		temp := tx.Factory().NewTempVariableEx(printer.AutoGenerateOptions{Flags: printer.GeneratedIdentifierFlagsReservedInNestedScopes})
+		tx.EmitContext().AddVariableDeclaration(temp)
+		tx.pendingExpressions = append(tx.pendingExpressions, tx.Factory().NewAssignmentExpression(temp, receiver))
+		receiver = temp
+	}
+
+	parameter := tx.Factory().NewGeneratedNameForNode(node.AsNode())
+	setter := tx.Factory().NewSetAccessorDeclaration(
+		nil, /*modifiers*/
+		tx.Factory().NewIdentifier("value"),
+		nil, /*typeParameters*/
+		tx.Factory().NewNodeList([]*ast.Node{
+			tx.Factory().NewParameterDeclaration(nil /*modifiers*/, nil /*dotDotDotToken*/, parameter, nil /*questionToken*/, nil /*typeNode*/, nil /*initializer*/),
+		}),
+		nil, /*returnType*/
+		tx.Factory().NewBlock(tx.Factory().NewNodeList([]*ast.Statement{
+			tx.Factory().NewExpressionStatement(tx.createPrivateIdentifierAssignment(info, receiver, parameter)),
+		}), false /*multiLine*/),
+	)
+	wrapper := tx.Factory().NewParenthesizedExpression(tx.Factory().NewObjectLiteralExpression(tx.Factory().NewNodeList([]*ast.Node{setter}), false /*multiLine*/))
+	result := tx.Factory().NewPropertyAccessExpression(wrapper, nil /*questionDotToken*/, tx.Factory().NewIdentifier("value"), ast.NodeFlagsNone)
+	tx.EmitContext().SetOriginal(result, node.AsNode())
+	result.Loc = node.Loc
+	return result
 }
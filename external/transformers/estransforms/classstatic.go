@@ -3,18 +3,15 @@ package estransforms
 import (
 	"github.com/pagpeter/typescript-go/external/ast"
 	"github.com/pagpeter/typescript-go/external/printer"
-	"github.com/pagpeter/typescript-go/external/transformers"
 )
 
-type classStaticBlockTransformer struct {
-	transformers.Transformer
-}
-
-func (ch *classStaticBlockTransformer) visit(node *ast.Node) *ast.Node {
-	return node // !!!
-}
-
-func newClassStaticBlockTransformer(emitContext *printer.EmitContext) *transformers.Transformer {
-	tx := &classStaticBlockTransformer{}
-	return tx.NewTransformer(tx.visit, emitContext)
+// Creates an immediately invoked arrow function that evaluates the body of a class `static {}` block, for targets that
+// do not support class static blocks:
+//
+//	(() => { ... })()
+//
+// A static block has its own `var` scope, which the arrow function preserves. References to `this` in the body must
+// already have been replaced with a reference to the class.
+func createClassStaticBlockIIFE(factory *printer.NodeFactory, body *ast.BlockNode) *ast.Expression {
+	return createImmediatelyInvokedArrowFunction(factory, body)
 }
@@ -1,7 +1,10 @@
 package estransforms
 
 import (
+	"slices"
+
 	"github.com/pagpeter/typescript-go/external/ast"
+	"github.com/pagpeter/typescript-go/external/core"
 	"github.com/pagpeter/typescript-go/external/printer"
 )
 
@@ -26,3 +29,57 @@ func isClassThisAssignmentBlock(emitContext *printer.EmitContext, node *ast.Node
 	}
 	return false
 }
+
+// Creates a class `static {}` block that assigns the static `this` to the provided classThis variable:
+//
+//	static { _classThis = this; }
+func createClassThisAssignmentBlock(emitContext *printer.EmitContext, classThis *ast.IdentifierNode, thisExpression *ast.Expression) *ast.Node {
+	factory := emitContext.Factory
+	if thisExpression == nil {
+		thisExpression = factory.NewThisExpression()
+	}
+	expression := factory.NewAssignmentExpression(classThis, thisExpression)
+	statement := factory.NewExpressionStatement(expression)
+	body := factory.NewBlock(factory.NewNodeList([]*ast.Statement{statement}), false /*multiLine*/)
+	block := factory.NewClassStaticBlockDeclaration(nil /*modifiers*/, body)
+
+	// We use `emitNode.classThis` to indicate this is a `_classThis` assignment helper block
+	// and to stash the variable used for `_classThis`.
+	emitContext.SetClassThis(block, classThis)
+	return block
+}
+
+// Injects a class `static {}` block that assigns the static `this` to the provided classThis variable as the first
+// element of a class, if one does not already exist.
+func injectClassThisAssignmentIfMissing(emitContext *printer.EmitContext, node *ast.ClassLikeDeclaration, classThis *ast.IdentifierNode, thisExpression *ast.Expression) *ast.ClassLikeDeclaration {
+	if core.Some(node.Members(), func(member *ast.ClassElement) bool { return isClassThisAssignmentBlock(emitContext, member) }) {
+		return node
+	}
+
+	factory := emitContext.Factory
+	staticBlock := createClassThisAssignmentBlock(emitContext, classThis, thisExpression)
+	members := factory.NewNodeList(slices.Concat([]*ast.ClassElement{staticBlock}, node.Members()))
+	members.Loc = node.MemberList().Loc
+
+	if ast.IsClassDeclaration(node) {
+		node = factory.UpdateClassDeclaration(
+			node.AsClassDeclaration(),
+			node.Modifiers(),
+			node.Name(),
+			node.TypeParameterList(),
+			node.AsClassDeclaration().HeritageClauses,
+			members,
+		)
+	} else {
+		node = factory.UpdateClassExpression(
+			node.AsClassExpression(),
+			node.Modifiers(),
+			node.Name(),
+			node.TypeParameterList(),
+			node.AsClassExpression().HeritageClauses,
+			members,
+		)
+	}
+	emitContext.SetClassThis(node, classThis)
+	return node
+}
@@ -13,34 +13,39 @@ var (
 	// 2025: only module system syntax (import attributes, json modules), untransformed regex modifiers
 	// 2024: no new downlevel syntax
 	// 2023: no new downlevel syntax
-	NewES2022Transformer = transformers.Chain(NewESNextTransformer, newClassStaticBlockTransformer, newClassFieldsTransformer)    // !!! top level await? not transformed, just errored on at lower targets - also more of a module system feature anyway
+	// class static blocks are lowered along with class fields, since their evaluation is interleaved with static field initializers
+	NewES2022Transformer = transformers.Chain(NewESNextTransformer, newClassFieldsTransformer)                                    // !!! top level await? not transformed, just errored on at lower targets - also more of a module system feature anyway
 	NewES2021Transformer = transformers.Chain(NewES2022Transformer, newLogicalAssignmentTransformer)                              // !!! numeric seperators? always elided by printer?
 	NewES2020Transformer = transformers.Chain(NewES2021Transformer, newNullishCoalescingTransformer, newOptionalChainTransformer) // also dynamic import - module system feature
 	NewES2019Transformer = transformers.Chain(NewES2020Transformer, newOptionalCatchTransformer)
 	NewES2018Transformer = transformers.Chain(NewES2019Transformer, newObjectRestSpreadTransformer, newforawaitTransformer)
 	NewES2017Transformer = transformers.Chain(NewES2018Transformer, newAsyncTransformer)
 	NewES2016Transformer = transformers.Chain(NewES2017Transformer, newExponentiationTransformer)
+	NewES2015Transformer = transformers.Chain(NewES2016Transformer, newES2015Transformer, newGeneratorsTransformer)
 )
 
 func GetESTransformer(options *core.CompilerOptions, emitContext *printer.EmitContext) *transformers.Transformer {
+	opts := &transformers.TransformOptions{Context: emitContext, CompilerOptions: options}
 	switch options.GetEmitScriptTarget() {
 	case core.ScriptTargetESNext:
 		return nil // no transforms needed
 	case /*core.ScriptTargetES2025,*/ core.ScriptTargetES2024, core.ScriptTargetES2023, core.ScriptTargetES2022:
-		return NewESNextTransformer(emitContext)
+		return NewESNextTransformer(opts)
 	case core.ScriptTargetES2021:
-		return NewES2022Transformer(emitContext)
+		return NewES2022Transformer(opts)
 	case core.ScriptTargetES2020:
-		return NewES2021Transformer(emitContext)
+		return NewES2021Transformer(opts)
 	case core.ScriptTargetES2019:
-		return NewES2020Transformer(emitContext)
+		return NewES2020Transformer(opts)
 	case core.ScriptTargetES2018:
-		return NewES2019Transformer(emitContext)
+		return NewES2019Transformer(opts)
 	case core.ScriptTargetES2017:
-		return NewES2018Transformer(emitContext)
+		return NewES2018Transformer(opts)
 	case core.ScriptTargetES2016:
-		return NewES2017Transformer(emitContext)
+		return NewES2017Transformer(opts)
+	case core.ScriptTargetES2015:
+		return NewES2016Transformer(opts)
 	default: // other, older, option, transform maximally
-		return NewES2016Transformer(emitContext)
+		return NewES2015Transformer(opts)
 	}
 }
@@ -0,0 +1,472 @@
+package estransforms
+
+import (
+	"strconv"
+
+	"github.com/pagpeter/typescript-go/external/ast"
+	"github.com/pagpeter/typescript-go/external/core"
+	"github.com/pagpeter/typescript-go/external/printer"
+)
+
+type flattenLevel int
+
+const (
+	flattenLevelAll        flattenLevel = iota // Flatten all binding and assignment patterns.
+	flattenLevelObjectRest                     // Only flatten the parts of a pattern needed to lower object rest elements.
+)
+
+type pendingDeclaration struct {
+	pendingExpressions []*ast.Expression
+	name               *ast.Node
+	value              *ast.Expression
+	location           core.TextRange
+	original           *ast.Node
+}
+
+// destructuringFlattener flattens a destructuring assignment or binding into a series of simple assignments or
+// variable declarations.
+type destructuringFlattener struct {
+	emitContext                *printer.EmitContext
+	factory                    *printer.NodeFactory
+	visitor                    *ast.NodeVisitor
+	level                      flattenLevel
+	downlevelIteration         bool
+	hoistTempVariables         bool
+	hasTransformedPriorElement bool
+	binding                    bool // whether this flattens a binding (producing declarations) or an assignment (producing expressions)
+
+	expressions         []*ast.Expression // assignment: the expressions to inline
+	pendingExpressions  []*ast.Expression // binding: the expressions to evaluate before the next declaration
+	pendingDeclarations []*pendingDeclaration
+}
+
+// Flattens a DestructuringAssignment into a comma-delimited sequence of simple assignments:
+//
+//	({ a, ...b } = o) -> ({ a } = o, b = __rest(o, ["a"]))
+//
+// When `needsValue` is set, the sequence ends with the value of the right-hand side of the assignment.
+func flattenDestructuringAssignment(emitContext *printer.EmitContext, visitor *ast.NodeVisitor, node *ast.Node, needsValue bool, level flattenLevel, downlevelIteration bool) *ast.Expression {
+	location := node.Loc
+	var value *ast.Expression
+	if ast.IsDestructuringAssignment(node) {
+		value = node.AsBinaryExpression().Right
+		for ast.IsEmptyArrayLiteral(node.AsBinaryExpression().Left) || ast.IsEmptyObjectLiteral(node.AsBinaryExpression().Left) {
+			if !ast.IsDestructuringAssignment(value) {
+				return visitor.VisitNode(value)
+			}
+			node = value
+			location = node.Loc
+			value = node.AsBinaryExpression().Right
+		}
+	}
+
+	fc := &destructuringFlattener{
+		emitContext:        emitContext,
+		factory:            emitContext.Factory,
+		visitor:            visitor,
+		level:              level,
+		downlevelIteration: downlevelIteration,
+		hoistTempVariables: true,
+	}
+
+	if value != nil {
+		value = visitor.VisitNode(value)
+		if ast.IsIdentifier(value) && bindingOrAssignmentElementAssignsToName(node, value.Text()) ||
+			bindingOrAssignmentElementContainsNonLiteralComputedName(node) {
+			// If the right-hand value of the assignment is also an assignment target then
+			// we need to cache the right-hand value.
+			value = fc.ensureIdentifier(value, false /*reuseIdentifierExpressions*/, location)
+		} else if needsValue {
+			// If the right-hand value of the destructuring assignment needs to be preserved (as
+			// is the case when the destructuring assignment is part of a larger expression),
+			// then we need to cache the right-hand value.
+			value = fc.ensureIdentifier(value, true /*reuseIdentifierExpressions*/, location)
+		} else if ast.NodeIsSynthesized(node) {
+			// Generally, the source map location for a destructuring assignment is the root
+			// expression. However, if the root expression is synthesized (as in the case of the
+			// initializer when transforming a ForOfStatement), then the source map location should
+			// point to the right-hand value of the expression.
+			location = value.Loc
+		}
+	}
+
+	fc.flattenBindingOrAssignmentElement(node, value, location, ast.IsDestructuringAssignment(node))
+
+	if value != nil && needsValue {
+		if len(fc.expressions) == 0 {
+			return value
+		}
+		fc.expressions = append(fc.expressions, value.Clone(fc.factory))
+	}
+
+	if result := fc.factory.InlineExpressions(fc.expressions); result != nil {
+		return result
+	}
+	return fc.factory.NewOmittedExpression()
+}
+
+// Flattens a VariableDeclaration, Parameter, or BindingElement containing a binding pattern into a list of simple
+// variable declarations:
+//
+//	const { a, ...b } = o -> const { a } = o, b = __rest(o, ["a"])
+//
+// When `rval` is provided, it is used as the value being destructured in place of the initializer of `node`. When
+// `hoistTempVariables` is set, temporary variables are hoisted to the enclosing variable environment rather than
+// declared in the resulting list.
+func flattenDestructuringBinding(emitContext *printer.EmitContext, visitor *ast.NodeVisitor, node *ast.Node, rval *ast.Expression, level flattenLevel, downlevelIteration bool, hoistTempVariables bool, skipInitializer bool) []*ast.Node {
+	fc := &destructuringFlattener{
+		emitContext:        emitContext,
+		factory:            emitContext.Factory,
+		visitor:            visitor,
+		level:              level,
+		downlevelIteration: downlevelIteration,
+		hoistTempVariables: hoistTempVariables,
+		binding:            true,
+	}
+
+	if ast.IsVariableDeclaration(node) {
+		initializer := ast.GetInitializerOfBindingOrAssignmentElement(node)
+		if initializer != nil && (ast.IsIdentifier(initializer) && bindingOrAssignmentElementAssignsToName(node, initializer.Text()) ||
+			bindingOrAssignmentElementContainsNonLiteralComputedName(node)) {
+			// If the right-hand value of the assignment is also an assignment target then
+			// we need to cache the right-hand value.
+			initializer = fc.ensureIdentifier(visitor.VisitNode(initializer), false /*reuseIdentifierExpressions*/, initializer.Loc)
+			node = fc.factory.UpdateVariableDeclaration(node.AsVariableDeclaration(), node.Name(), nil /*exclamationToken*/, nil /*typeNode*/, initializer)
+		}
+	}
+
+	fc.flattenBindingOrAssignmentElement(node, rval, node.Loc, skipInitializer)
+
+	if len(fc.pendingExpressions) > 0 {
+		temp := fc.factory.NewTempVariable()
+		if hoistTempVariables {
+			value := fc.factory.InlineExpressions(fc.pendingExpressions)
+			fc.pendingExpressions = nil
+			fc.emitBindingOrAssignment(temp, value, core.NewTextRange(-1, -1), nil /*original*/)
+		} else {
+			emitContext.AddVariableDeclaration(temp)
+			pending := fc.pendingDeclarations[len(fc.pendingDeclarations)-1]
+			pending.pendingExpressions = append(pending.pendingExpressions, fc.factory.NewAssignmentExpression(temp, pending.value))
+			pending.pendingExpressions = append(pending.pendingExpressions, fc.pendingExpressions...)
+			pending.value = temp.Clone(fc.factory)
+		}
+	}
+
+	declarations := make([]*ast.Node, 0, len(fc.pendingDeclarations))
+	for _, pending := range fc.pendingDeclarations {
+		initializer := pending.value
+		if len(pending.pendingExpressions) > 0 {
+			initializer = fc.factory.InlineExpressions(append(pending.pendingExpressions, pending.value))
+		}
+		variable := fc.factory.NewVariableDeclaration(pending.name, nil /*exclamationToken*/, nil /*typeNode*/, initializer)
+		if pending.original != nil {
+			emitContext.SetOriginal(variable, pending.original)
+		}
+		variable.Loc = pending.location
+		declarations = append(declarations, variable)
+	}
+	return declarations
+}
+
+func (fc *destructuringFlattener) emitExpression(value *ast.Expression) {
+	if fc.binding {
+		fc.pendingExpressions = append(fc.pendingExpressions, value)
+	} else {
+		fc.expressions = append(fc.expressions, value.Clone(fc.factory))
+	}
+}
+
+func (fc *destructuringFlattener) emitBindingOrAssignment(target *ast.Node, value *ast.Expression, location core.TextRange, original *ast.Node) {
+	if fc.binding {
+		if len(fc.pendingExpressions) > 0 {
+			value = fc.factory.InlineExpressions(append(fc.pendingExpressions, value))
+			fc.pendingExpressions = nil
+		}
+		fc.pendingDeclarations = append(fc.pendingDeclarations, &pendingDeclaration{
+			name:     target,
+			value:    value,
+			location: location,
+			original: original,
+		})
+		return
+	}
+
+	expression := fc.factory.NewAssignmentExpression(fc.visitor.VisitNode(target), value)
+	expression.Loc = location
+	if original != nil {
+		fc.emitContext.SetOriginal(expression, original)
+	}
+	fc.emitExpression(expression)
+}
+
+func (fc *destructuringFlattener) createObjectBindingOrAssignmentPattern(elements []*ast.Node) *ast.Node {
+	if fc.binding {
+		return fc.factory.NewBindingPattern(ast.KindObjectBindingPattern, fc.factory.NewNodeList(elements))
+	}
+	return fc.factory.NewObjectLiteralExpression(fc.factory.NewNodeList(elements), false /*multiLine*/)
+}
+
+func (fc *destructuringFlattener) createArrayBindingOrAssignmentPattern(elements []*ast.Node) *ast.Node {
+	if fc.binding {
+		return fc.factory.NewBindingPattern(ast.KindArrayBindingPattern, fc.factory.NewNodeList(elements))
+	}
+	return fc.factory.NewArrayLiteralExpression(fc.factory.NewNodeList(elements), false /*multiLine*/)
+}
+
+func (fc *destructuringFlattener) createArrayBindingOrAssignmentElement(name *ast.IdentifierNode) *ast.Node {
+	if fc.binding {
+		return fc.factory.NewBindingElement(nil /*dotDotDotToken*/, nil /*propertyName*/, name, nil /*initializer*/)
+	}
+	return name
+}
+
+// Flattens a BindingOrAssignmentElement into zero or more bindings or assignments.
+func (fc *destructuringFlattener) flattenBindingOrAssignmentElement(element *ast.Node, value *ast.Expression, location core.TextRange, skipInitializer bool) {
+	bindingTarget := ast.GetTargetOfBindingOrAssignmentElement(element)
+	if !skipInitializer {
+		initializer := fc.visitor.VisitNode(ast.GetInitializerOfBindingOrAssignmentElement(element))
+		if initializer != nil {
+			// Combine value and initializer
+			if value != nil {
+				value = fc.createDefaultValueCheck(value, initializer, location)
+				// If 'value' is not a simple expression, it could contain side-effecting code that should evaluate
+				// before an object or array binding pattern.
+				if !isSimpleInlineableExpression(initializer) && ast.IsBindingOrAssignmentPattern(bindingTarget) {
+					value = fc.ensureIdentifier(value, true /*reuseIdentifierExpressions*/, location)
+				}
+			} else {
+				value = initializer
+			}
+		} else if value == nil {
+			// Use 'void 0' in absence of value and initializer
+			value = fc.factory.NewVoidZeroExpression()
+		}
+	}
+
+	switch {
+	case ast.IsObjectBindingOrAssignmentPattern(bindingTarget):
+		fc.flattenObjectBindingOrAssignmentPattern(element, bindingTarget, value, location)
+	case ast.IsArrayBindingOrAssignmentPattern(bindingTarget):
+		fc.flattenArrayBindingOrAssignmentPattern(element, bindingTarget, value, location)
+	default:
+		fc.emitBindingOrAssignment(bindingTarget, value, location, element)
+	}
+}
+
+// Flattens an ObjectBindingOrAssignmentPattern into zero or more bindings or assignments.
+func (fc *destructuringFlattener) flattenObjectBindingOrAssignmentPattern(parent *ast.Node, pattern *ast.Node, value *ast.Expression, location core.TextRange) {
+	elements := ast.GetElementsOfBindingOrAssignmentPattern(pattern)
+	numElements := len(elements)
+	if numElements != 1 {
+		// For anything other than a single-element destructuring we need to generate a temporary
+		// to ensure value is evaluated exactly once. Additionally, if we have zero elements
+		// we need to emit *something* to ensure that in case a 'var' keyword was already emitted,
+		// so in that case, we'll intentionally create that temporary.
+		reuseIdentifierExpressions := !ast.IsDeclarationBindingElement(parent) || numElements != 0
+		value = fc.ensureIdentifier(value, reuseIdentifierExpressions, location)
+	}
+
+	var bindingElements []*ast.Node
+	var computedTempVariables []*ast.Expression
+	for i, element := range elements {
+		if ast.GetRestIndicatorOfBindingOrAssignmentElement(element) == nil {
+			propertyName := ast.TryGetPropertyNameOfBindingOrAssignmentElement(element)
+			if fc.level >= flattenLevelObjectRest &&
+				element.SubtreeFacts()&(ast.SubtreeContainsRest|ast.SubtreeContainsObjectRestOrSpread) == 0 &&
+				ast.GetTargetOfBindingOrAssignmentElement(element).SubtreeFacts()&(ast.SubtreeContainsRest|ast.SubtreeContainsObjectRestOrSpread) == 0 &&
+				!ast.IsComputedPropertyName(propertyName) {
+				bindingElements = append(bindingElements, fc.visitor.VisitNode(element))
+			} else {
+				if len(bindingElements) > 0 {
+					fc.emitBindingOrAssignment(fc.createObjectBindingOrAssignmentPattern(bindingElements), value.Clone(fc.factory), location, pattern)
+					bindingElements = nil
+				}
+				rhsValue := fc.createDestructuringPropertyAccess(value, propertyName)
+				if ast.IsComputedPropertyName(propertyName) {
+					computedTempVariables = append(computedTempVariables, rhsValue.AsElementAccessExpression().ArgumentExpression)
+				}
+				fc.flattenBindingOrAssignmentElement(element, rhsValue, element.Loc, false /*skipInitializer*/)
+			}
+		} else if i == numElements-1 {
+			if len(bindingElements) > 0 {
+				fc.emitBindingOrAssignment(fc.createObjectBindingOrAssignmentPattern(bindingElements), value.Clone(fc.factory), location, pattern)
+				bindingElements = nil
+			}
+			rhsValue := fc.factory.NewRestHelper(value.Clone(fc.factory), elements, computedTempVariables, pattern.Loc)
+			fc.flattenBindingOrAssignmentElement(element, rhsValue, element.Loc, false /*skipInitializer*/)
+		}
+	}
+
+	if len(bindingElements) > 0 {
+		fc.emitBindingOrAssignment(fc.createObjectBindingOrAssignmentPattern(bindingElements), value.Clone(fc.factory), location, pattern)
+	}
+}
+
+// Flattens an ArrayBindingOrAssignmentPattern into zero or more bindings or assignments.
+func (fc *destructuringFlattener) flattenArrayBindingOrAssignmentPattern(parent *ast.Node, pattern *ast.Node, value *ast.Expression, location core.TextRange) {
+	elements := ast.GetElementsOfBindingOrAssignmentPattern(pattern)
+	numElements := len(elements)
+	if fc.level < flattenLevelObjectRest && fc.downlevelIteration {
+		// Read the elements of the iterable into an array
+		count := numElements
+		if numElements > 0 && ast.GetRestIndicatorOfBindingOrAssignmentElement(elements[numElements-1]) != nil {
+			count = -1
+		}
+		read := fc.factory.NewReadHelper(value, count)
+		read.Loc = location
+		value = fc.ensureIdentifier(read, false /*reuseIdentifierExpressions*/, location)
+	} else if numElements != 1 && (fc.level < flattenLevelObjectRest || numElements == 0) || core.Every(elements, isOmittedBindingOrAssignmentElement) {
+		// For anything other than a single-element destructuring we need to generate a temporary
+		// to ensure value is evaluated exactly once. Additionally, if we have zero elements
+		// we need to emit *something* to ensure that in case a 'var' keyword was already emitted,
+		// so in that case, we'll intentionally create that temporary.
+		// Or all the elements of the binding pattern are omitted expression such as "var [,] = [1,2]",
+		// then we will create temporary variable.
+		reuseIdentifierExpressions := !ast.IsDeclarationBindingElement(parent) || numElements != 0
+		value = fc.ensureIdentifier(value, reuseIdentifierExpressions, location)
+	}
+
+	type restContainingElement struct {
+		temp    *ast.IdentifierNode
+		element *ast.Node
+	}
+
+	var bindingElements []*ast.Node
+	var restContainingElements []restContainingElement
+	for i, element := range elements {
+		switch {
+		case fc.level >= flattenLevelObjectRest:
+			// If an array pattern contains an ObjectRest, we must cache the result so that we
+			// can perform the ObjectRest destructuring in a different declaration
+			if element.SubtreeFacts()&ast.SubtreeContainsObjectRestOrSpread != 0 || fc.hasTransformedPriorElement && !isSimpleBindingOrAssignmentElement(element) {
+				fc.hasTransformedPriorElement = true
+				temp := fc.factory.NewTempVariable()
+				if fc.hoistTempVariables {
+					fc.emitContext.AddVariableDeclaration(temp)
+				}
+				restContainingElements = append(restContainingElements, restContainingElement{temp, element})
+				bindingElements = append(bindingElements, fc.createArrayBindingOrAssignmentElement(temp))
+			} else {
+				bindingElements = append(bindingElements, fc.visitor.VisitNode(element))
+			}
+		case isOmittedBindingOrAssignmentElement(element):
+			continue
+		case ast.GetRestIndicatorOfBindingOrAssignmentElement(element) == nil:
+			rhsValue := fc.factory.NewElementAccessExpression(value.Clone(fc.factory), nil /*questionDotToken*/, fc.factory.NewNumericLiteral(strconv.Itoa(i)), ast.NodeFlagsNone)
+			fc.flattenBindingOrAssignmentElement(element, rhsValue, element.Loc, false /*skipInitializer*/)
+		case i == numElements-1:
+			rhsValue := fc.factory.NewCallExpression(
+				fc.factory.NewPropertyAccessExpression(value.Clone(fc.factory), nil /*questionDotToken*/, fc.factory.NewIdentifier("slice"), ast.NodeFlagsNone),
+				nil, /*questionDotToken*/
+				nil, /*typeArguments*/
+				fc.factory.NewNodeList([]*ast.Expression{fc.factory.NewNumericLiteral(strconv.Itoa(i))}),
+				ast.NodeFlagsNone,
+			)
+			fc.flattenBindingOrAssignmentElement(element, rhsValue, element.Loc, false /*skipInitializer*/)
+		}
+	}
+
+	if len(bindingElements) > 0 {
+		fc.emitBindingOrAssignment(fc.createArrayBindingOrAssignmentPattern(bindingElements), value, location, pattern)
+	}
+
+	for _, rest := range restContainingElements {
+		fc.flattenBindingOrAssignmentElement(rest.element, rest.temp.Clone(fc.factory), rest.element.Loc, false /*skipInitializer*/)
+	}
+}
+
+// Creates an expression used to provide a default value if a value is `undefined` at runtime.
+func (fc *destructuringFlattener) createDefaultValueCheck(value *ast.Expression, defaultValue *ast.Expression, location core.TextRange) *ast.Expression {
+	value = fc.ensureIdentifier(value, true /*reuseIdentifierExpressions*/, location)
+	return fc.factory.NewConditionalExpression(
+		fc.factory.NewTypeCheck(value, "undefined"),
+		fc.factory.NewToken(ast.KindQuestionToken),
+		defaultValue,
+		fc.factory.NewToken(ast.KindColonToken),
+		value.Clone(fc.factory),
+	)
+}
+
+// Creates either a PropertyAccessExpression or an ElementAccessExpression for the right-hand side of a transformed
+// destructuring assignment.
+//
+// see: https://tc39.github.io/ecma262/#sec-runtime-semantics-keyeddestructuringassignmentevaluation
+func (fc *destructuringFlattener) createDestructuringPropertyAccess(value *ast.Expression, propertyName *ast.Node) *ast.Expression {
+	value = value.Clone(fc.factory)
+	switch {
+	case ast.IsComputedPropertyName(propertyName):
+		argumentExpression := fc.ensureIdentifier(fc.visitor.VisitNode(propertyName.Expression()), false /*reuseIdentifierExpressions*/, propertyName.Loc)
+		return fc.factory.NewElementAccessExpression(value, nil /*questionDotToken*/, argumentExpression, ast.NodeFlagsNone)
+	case ast.IsStringOrNumericLiteralLike(propertyName) || ast.IsBigIntLiteral(propertyName):
+		return fc.factory.NewElementAccessExpression(value, nil /*questionDotToken*/, propertyName.Clone(fc.factory), ast.NodeFlagsNone)
+	default:
+		return fc.factory.NewPropertyAccessExpression(value, nil /*questionDotToken*/, fc.factory.NewIdentifier(propertyName.Text()), ast.NodeFlagsNone)
+	}
+}
+
+// Ensures that there exists a declared identifier whose value holds the given expression. This function is useful to
+// ensure that the expression's value can be read from in subsequent expressions. Unless `reuseIdentifierExpressions`
+// is false, `value` will be returned as-is if it is an identifier.
+func (fc *destructuringFlattener) ensureIdentifier(value *ast.Expression, reuseIdentifierExpressions bool, location core.TextRange) *ast.Expression {
+	if ast.IsIdentifier(value) && reuseIdentifierExpressions {
+		return value
+	}
+	temp := fc.factory.NewTempVariable()
+	if fc.hoistTempVariables {
+		fc.emitContext.AddVariableDeclaration(temp)
+		assignment := fc.factory.NewAssignmentExpression(temp, value)
+		assignment.Loc = location
+		fc.emitExpression(assignment)
+	} else {
+		fc.emitBindingOrAssignment(temp, value, location, nil /*original*/)
+	}
+	return temp.Clone(fc.factory)
+}
+
+func isOmittedBindingOrAssignmentElement(element *ast.Node) bool {
+	return ast.IsOmittedExpression(element) || ast.IsBindingElement(element) && element.Name() == nil
+}
+
+func isSimpleBindingOrAssignmentElement(element *ast.Node) bool {
+	target := ast.GetTargetOfBindingOrAssignmentElement(element)
+	if target == nil || ast.IsOmittedExpression(target) {
+		return true
+	}
+	propertyName := ast.TryGetPropertyNameOfBindingOrAssignmentElement(element)
+	if propertyName != nil && !ast.IsPropertyNameLiteral(propertyName) {
+		return false
+	}
+	initializer := ast.GetInitializerOfBindingOrAssignmentElement(element)
+	if initializer != nil && !isSimpleInlineableExpression(initializer) {
+		return false
+	}
+	if ast.IsBindingOrAssignmentPattern(target) {
+		return core.Every(ast.GetElementsOfBindingOrAssignmentPattern(target), isSimpleBindingOrAssignmentElement)
+	}
+	return ast.IsIdentifier(target)
+}
+
+func bindingOrAssignmentElementAssignsToName(element *ast.Node, name string) bool {
+	target := ast.GetTargetOfBindingOrAssignmentElement(element)
+	switch {
+	case target == nil:
+		return false
+	case ast.IsBindingOrAssignmentPattern(target):
+		return core.Some(ast.GetElementsOfBindingOrAssignmentPattern(target), func(element *ast.Node) bool {
+			return bindingOrAssignmentElementAssignsToName(element, name)
+		})
+	case ast.IsIdentifier(target):
+		return target.Text() == name
+	}
+	return false
+}
+
+func bindingOrAssignmentElementContainsNonLiteralComputedName(element *ast.Node) bool {
+	propertyName := ast.TryGetPropertyNameOfBindingOrAssignmentElement(element)
+	if propertyName != nil && ast.IsComputedPropertyName(propertyName) && !ast.IsLiteralExpression(propertyName.Expression()) {
+		return true
+	}
+	target := ast.GetTargetOfBindingOrAssignmentElement(element)
+	return target != nil && ast.IsBindingOrAssignmentPattern(target) &&
+		core.Some(ast.GetElementsOfBindingOrAssignmentPattern(target), bindingOrAssignmentElementContainsNonLiteralComputedName)
+}
@@ -0,0 +1,1362 @@
+package estransforms
+
+import (
+	"regexp"
+	"slices"
+	"strconv"
+
+	"github.com/pagpeter/typescript-go/external/ast"
+	"github.com/pagpeter/typescript-go/external/core"
+	"github.com/pagpeter/typescript-go/external/printer"
+	"github.com/pagpeter/typescript-go/external/scanner"
+	"github.com/pagpeter/typescript-go/external/transformers"
+)
+
+type hierarchyFacts int32
+
+const (
+	hierarchyFactsNone hierarchyFacts = 0
+
+	//
+	// Ancestor facts
+	//
+
+	hierarchyFactsFunction                  hierarchyFacts = 1 << 0  // Enclosed in a non-arrow function
+	hierarchyFactsArrowFunction             hierarchyFacts = 1 << 1  // Enclosed in an arrow function
+	hierarchyFactsAsyncFunctionBody         hierarchyFacts = 1 << 2  // Enclosed in an async function body
+	hierarchyFactsNonStaticClassElement     hierarchyFacts = 1 << 3  // Enclosed in a non-static, non-async class element
+	hierarchyFactsCapturesThis              hierarchyFacts = 1 << 4  // Enclosed in a function that captures the lexical 'this' (used in substitution)
+	hierarchyFactsExportedVariableStatement hierarchyFacts = 1 << 5  // Enclosed in an exported variable statement in the current scope
+	hierarchyFactsTopLevel                  hierarchyFacts = 1 << 6  // Enclosing block-scoped container is a top-level container
+	hierarchyFactsBlock                     hierarchyFacts = 1 << 7  // Enclosing block-scoped container is a Block
+	hierarchyFactsIterationStatement        hierarchyFacts = 1 << 8  // Immediately enclosed in an IterationStatement
+	hierarchyFactsIterationStatementBlock   hierarchyFacts = 1 << 9  // Enclosing Block is enclosed in an IterationStatement
+	hierarchyFactsIterationContainer        hierarchyFacts = 1 << 10 // Enclosed in an outer IterationStatement
+	hierarchyFactsForStatement              hierarchyFacts = 1 << 11 // Enclosing block-scoped container is a ForStatement
+	hierarchyFactsForInOrForOfStatement     hierarchyFacts = 1 << 12 // Enclosing block-scoped container is a ForInStatement or ForOfStatement
+	hierarchyFactsConstructorWithSuperCall  hierarchyFacts = 1 << 13 // Enclosed in a constructor that captures 'this' for use with 'super'
+	hierarchyFactsStaticInitializer         hierarchyFacts = 1 << 14 // Enclosed in a static initializer
+
+	hierarchyFactsAncestorFactsMask = (hierarchyFactsStaticInitializer << 1) - 1
+
+	// We are always in *some* kind of block scope, but only specific block-scope containers are top-level or Blocks.
+	hierarchyFactsBlockScopeIncludes = hierarchyFactsNone
+	hierarchyFactsBlockScopeExcludes = hierarchyFactsTopLevel | hierarchyFactsBlock | hierarchyFactsIterationStatement | hierarchyFactsIterationStatementBlock | hierarchyFactsForStatement | hierarchyFactsForInOrForOfStatement
+
+	// A source file is a top-level block scope.
+	hierarchyFactsSourceFileIncludes = hierarchyFactsTopLevel
+	hierarchyFactsSourceFileExcludes = hierarchyFactsBlockScopeExcludes&^hierarchyFactsTopLevel | hierarchyFactsIterationContainer
+
+	// Functions, methods, and accessors are both new lexical scopes and new block scopes.
+	hierarchyFactsFunctionIncludes = hierarchyFactsFunction | hierarchyFactsTopLevel
+	hierarchyFactsFunctionExcludes = hierarchyFactsBlockScopeExcludes&^hierarchyFactsTopLevel | hierarchyFactsArrowFunction | hierarchyFactsAsyncFunctionBody | hierarchyFactsCapturesThis | hierarchyFactsNonStaticClassElement | hierarchyFactsConstructorWithSuperCall | hierarchyFactsIterationContainer | hierarchyFactsStaticInitializer
+
+	hierarchyFactsAsyncFunctionBodyIncludes = hierarchyFactsFunctionIncludes | hierarchyFactsAsyncFunctionBody
+	hierarchyFactsAsyncFunctionBodyExcludes = hierarchyFactsFunctionExcludes &^ hierarchyFactsNonStaticClassElement
+
+	// Arrow functions are lexically scoped to their container, but are new block scopes.
+	hierarchyFactsArrowFunctionIncludes = hierarchyFactsArrowFunction | hierarchyFactsTopLevel
+	hierarchyFactsArrowFunctionExcludes = hierarchyFactsBlockScopeExcludes&^hierarchyFactsTopLevel | hierarchyFactsConstructorWithSuperCall
+
+	// Constructors are both new lexical scopes and new block scopes. Constructors are also always considered non-static
+	// members of a class.
+	hierarchyFactsConstructorIncludes = hierarchyFactsFunctionIncludes | hierarchyFactsNonStaticClassElement
+	hierarchyFactsConstructorExcludes = hierarchyFactsFunctionExcludes &^ hierarchyFactsNonStaticClassElement
+
+	// 'do' and 'while' statements are not block scopes. We track that the subtree is contained within an
+	// IterationStatement to indicate whether the embedded statement is an IterationStatementBlock.
+	hierarchyFactsDoOrWhileStatementIncludes = hierarchyFactsIterationStatement | hierarchyFactsIterationContainer
+	hierarchyFactsDoOrWhileStatementExcludes = hierarchyFactsNone
+
+	// 'for' statements are new block scopes and have special handling for 'let' declarations.
+	hierarchyFactsForStatementIncludes = hierarchyFactsIterationStatement | hierarchyFactsForStatement | hierarchyFactsIterationContainer
+	hierarchyFactsForStatementExcludes = hierarchyFactsBlockScopeExcludes &^ hierarchyFactsForStatement
+
+	// 'for-in' and 'for-of' statements are new block scopes and have special handling for 'let' declarations.
+	hierarchyFactsForInOrForOfStatementIncludes = hierarchyFactsIterationStatement | hierarchyFactsForInOrForOfStatement | hierarchyFactsIterationContainer
+	hierarchyFactsForInOrForOfStatementExcludes = hierarchyFactsBlockScopeExcludes &^ hierarchyFactsForInOrForOfStatement
+
+	// Blocks (other than function bodies) are new block scopes.
+	hierarchyFactsBlockIncludes = hierarchyFactsBlock
+	hierarchyFactsBlockExcludes = hierarchyFactsBlockScopeExcludes &^ hierarchyFactsBlock
+
+	hierarchyFactsIterationStatementBlockIncludes = hierarchyFactsIterationStatementBlock
+	hierarchyFactsIterationStatementBlockExcludes = hierarchyFactsBlockScopeExcludes
+
+	hierarchyFactsStaticInitializerIncludes = hierarchyFactsFunctionIncludes | hierarchyFactsStaticInitializer
+	hierarchyFactsStaticInitializerExcludes = hierarchyFactsFunctionExcludes
+
+	//
+	// Subtree facts
+	//
+
+	hierarchyFactsNewTarget           hierarchyFacts = 1 << 15 // Contains a 'new.target' meta-property
+	hierarchyFactsCapturedLexicalThis hierarchyFacts = 1 << 16 // Contains a lexical `this` reference captured by an arrow function.
+
+	//
+	// Subtree masks
+	//
+
+	hierarchyFactsSubtreeFactsMask = ^hierarchyFactsAncestorFactsMask
+
+	hierarchyFactsArrowFunctionSubtreeExcludes = hierarchyFactsNone
+	hierarchyFactsFunctionSubtreeExcludes      = hierarchyFactsNewTarget | hierarchyFactsCapturedLexicalThis
+)
+
+type es2015Transformer struct {
+	transformers.Transformer
+	compilerOptions       *core.CompilerOptions
+	discardedValueVisitor *ast.NodeVisitor // visits expressions whose result is not observed
+	callExpressionVisitor *ast.NodeVisitor // visits the callee of a call expression
+
+	currentSourceFile                *ast.SourceFile
+	bindings                         *blockScopedBindings
+	hierarchyFacts                   hierarchyFacts
+	taggedTemplateStringDeclarations []*ast.Node
+	convertedLoopState               *convertedLoopState // set when the body of the loop currently being visited is being converted into a function
+}
+
+func newES2015Transformer(opts *transformers.TransformOptions) *transformers.Transformer {
+	tx := &es2015Transformer{compilerOptions: opts.CompilerOptions}
+	result := tx.NewTransformer(tx.visit, opts.Context)
+	tx.discardedValueVisitor = tx.EmitContext().NewNodeVisitor(tx.visitDiscardedValue)
+	tx.callExpressionVisitor = tx.EmitContext().NewNodeVisitor(func(node *ast.Node) *ast.Node {
+		if node.Kind == ast.KindSuperKeyword {
+			return tx.visitSuperKeyword(node, true /*isExpressionOfCall*/)
+		}
+		return tx.visit(node)
+	})
+	return result
+}
+
+// Sets the ancestor facts for a subtree, returning the facts of the enclosing ancestors so that they can be restored
+// with exitSubtree.
+func (tx *es2015Transformer) enterSubtree(excludeFacts hierarchyFacts, includeFacts hierarchyFacts) hierarchyFacts {
+	ancestorFacts := tx.hierarchyFacts
+	tx.hierarchyFacts = (tx.hierarchyFacts&^excludeFacts | includeFacts) & hierarchyFactsAncestorFactsMask
+	return ancestorFacts
+}
+
+// Restores the ancestor facts saved by enterSubtree, propagating the subtree facts of the subtree to its ancestors.
+func (tx *es2015Transformer) exitSubtree(ancestorFacts hierarchyFacts, excludeFacts hierarchyFacts, includeFacts hierarchyFacts) {
+	tx.hierarchyFacts = (tx.hierarchyFacts&^excludeFacts|includeFacts)&hierarchyFactsSubtreeFactsMask | ancestorFacts
+}
+
+func (tx *es2015Transformer) visit(node *ast.Node) *ast.Node {
+	return tx.visitWorker(node, false /*discarded*/)
+}
+
+// Visits an expression whose result is not observed, such as the expression of an `ExpressionStatement`. This allows
+// destructuring assignments to avoid capturing the value of their right-hand side.
+func (tx *es2015Transformer) visitDiscardedValue(node *ast.Node) *ast.Node {
+	return tx.visitWorker(node, true /*discarded*/)
+}
+
+func (tx *es2015Transformer) visitWorker(node *ast.Node, discarded bool) *ast.Node {
+	switch node.Kind {
+	case ast.KindSourceFile:
+		return tx.visitSourceFile(node.AsSourceFile())
+	case ast.KindStaticKeyword:
+		return nil // elide static keyword
+	case ast.KindClassDeclaration:
+		return tx.visitClassDeclaration(node.AsClassDeclaration())
+	case ast.KindClassExpression:
+		return tx.visitClassExpression(node.AsClassExpression())
+	case ast.KindParameter:
+		return tx.visitParameter(node.AsParameterDeclaration())
+	case ast.KindFunctionDeclaration:
+		return tx.visitFunctionDeclaration(node.AsFunctionDeclaration())
+	case ast.KindArrowFunction:
+		return tx.visitArrowFunction(node.AsArrowFunction())
+	case ast.KindFunctionExpression:
+		return tx.visitFunctionExpression(node.AsFunctionExpression())
+	case ast.KindVariableDeclaration:
+		return tx.visitVariableDeclaration(node.AsVariableDeclaration())
+	case ast.KindIdentifier:
+		return tx.visitIdentifier(node)
+	case ast.KindVariableDeclarationList:
+		return tx.visitVariableDeclarationList(node.AsVariableDeclarationList())
+	case ast.KindSwitchStatement:
+		return tx.visitSwitchStatement(node.AsSwitchStatement())
+	case ast.KindCaseBlock:
+		return tx.visitCaseBlock(node.AsCaseBlock())
+	case ast.KindBlock:
+		return tx.visitBlock(node.AsBlock())
+	case ast.KindBreakStatement, ast.KindContinueStatement:
+		return tx.visitBreakOrContinueStatement(node)
+	case ast.KindLabeledStatement:
+		return tx.visitLabeledStatement(node.AsLabeledStatement())
+	case ast.KindDoStatement, ast.KindWhileStatement:
+		return tx.visitDoOrWhileStatement(node, nil /*outermostLabeledStatement*/)
+	case ast.KindForStatement:
+		return tx.visitForStatement(node.AsForStatement(), nil /*outermostLabeledStatement*/)
+	case ast.KindForInStatement:
+		return tx.visitForInStatement(node.AsForInOrOfStatement(), nil /*outermostLabeledStatement*/)
+	case ast.KindForOfStatement:
+		return tx.visitForOfStatement(node.AsForInOrOfStatement(), nil /*outermostLabeledStatement*/)
+	case ast.KindExpressionStatement:
+		return tx.Factory().UpdateExpressionStatement(node.AsExpressionStatement(), tx.discardedValueVisitor.VisitNode(node.Expression()))
+	case ast.KindObjectLiteralExpression:
+		return tx.visitObjectLiteralExpression(node.AsObjectLiteralExpression())
+	case ast.KindCatchClause:
+		return tx.visitCatchClause(node.AsCatchClause())
+	case ast.KindShorthandPropertyAssignment:
+		return tx.visitShorthandPropertyAssignment(node.AsShorthandPropertyAssignment())
+	case ast.KindArrayLiteralExpression:
+		return tx.visitArrayLiteralExpression(node.AsArrayLiteralExpression())
+	case ast.KindCallExpression:
+		return tx.visitCallExpression(node.AsCallExpression())
+	case ast.KindNewExpression:
+		return tx.visitNewExpression(node.AsNewExpression())
+	case ast.KindParenthesizedExpression:
+		return tx.visitParenthesizedExpression(node.AsParenthesizedExpression(), discarded)
+	case ast.KindBinaryExpression:
+		return tx.visitBinaryExpression(node.AsBinaryExpression(), discarded)
+	case ast.KindNoSubstitutionTemplateLiteral, ast.KindTemplateHead, ast.KindTemplateMiddle, ast.KindTemplateTail:
+		return tx.visitTemplateLiteral(node)
+	case ast.KindStringLiteral:
+		return tx.visitStringLiteral(node.AsStringLiteral())
+	case ast.KindNumericLiteral:
+		return tx.visitNumericLiteral(node.AsNumericLiteral())
+	case ast.KindTaggedTemplateExpression:
+		return tx.visitTaggedTemplateExpression(node.AsTaggedTemplateExpression())
+	case ast.KindTemplateExpression:
+		return tx.visitTemplateExpression(node.AsTemplateExpression())
+	case ast.KindSuperKeyword:
+		return tx.visitSuperKeyword(node, false /*isExpressionOfCall*/)
+	case ast.KindThisKeyword:
+		return tx.visitThisKeyword(node)
+	case ast.KindMetaProperty:
+		return tx.visitMetaProperty(node.AsMetaProperty())
+	case ast.KindMethodDeclaration:
+		return tx.visitMethodDeclaration(node.AsMethodDeclaration())
+	case ast.KindGetAccessor, ast.KindSetAccessor:
+		return tx.visitAccessorDeclaration(node)
+	case ast.KindVariableStatement:
+		return tx.visitVariableStatement(node.AsVariableStatement())
+	case ast.KindReturnStatement:
+		return tx.visitReturnStatement(node.AsReturnStatement())
+	case ast.KindVoidExpression:
+		return tx.Factory().UpdateVoidExpression(node.AsVoidExpression(), tx.discardedValueVisitor.VisitNode(node.Expression()))
+	default:
+		return tx.Visitor().VisitEachChild(node)
+	}
+}
+
+func (tx *es2015Transformer) visitSourceFile(node *ast.SourceFile) *ast.Node {
+	if node.IsDeclarationFile {
+		return node.AsNode()
+	}
+
+	tx.currentSourceFile = node
+	tx.bindings = newBlockScopedBindings(node, tx.compilerOptions)
+	tx.taggedTemplateStringDeclarations = nil
+	ancestorFacts := tx.enterSubtree(hierarchyFactsSourceFileExcludes, hierarchyFactsSourceFileIncludes)
+
+	f := tx.Factory()
+	tx.EmitContext().StartVariableEnvironment()
+	prologue, rest := f.SplitStandardPrologue(node.Statements.Nodes)
+	prologue = slices.Clone(prologue)
+	statements, _ := tx.Visitor().VisitSlice(rest)
+	if len(tx.taggedTemplateStringDeclarations) > 0 {
+		statements = append(statements, f.NewVariableStatement(nil /*modifiers*/, f.NewVariableDeclarationList(ast.NodeFlagsNone, f.NewNodeList(tx.taggedTemplateStringDeclarations))))
+	}
+	prologue = tx.EmitContext().MergeEnvironment(prologue, tx.EmitContext().EndVariableEnvironment())
+	prologue = tx.insertCaptureThisForNodeIfNeeded(prologue, node.AsNode())
+	tx.exitSubtree(ancestorFacts, hierarchyFactsNone, hierarchyFactsNone)
+
+	statementList := f.NewNodeList(append(prologue, statements...))
+	statementList.Loc = node.Statements.Loc
+	visited := f.UpdateSourceFile(node, statementList)
+	tx.EmitContext().AddEmitHelper(visited, tx.EmitContext().ReadEmitHelpers()...)
+
+	tx.currentSourceFile = nil
+	tx.bindings = nil
+	tx.taggedTemplateStringDeclarations = nil
+	return visited
+}
+
+//
+// Identifiers, `this`, `super`, and `new.target`
+//
+
+func (tx *es2015Transformer) visitIdentifier(node *ast.IdentifierNode) *ast.Node {
+	if transformers.IsGeneratedIdentifier(tx.EmitContext(), node) {
+		return node
+	}
+	original := tx.EmitContext().ParseNode(node)
+	if original == nil || !ast.IsIdentifier(original) {
+		return node
+	}
+
+	if tx.convertedLoopState != nil && original.Text() == "arguments" && isIdentifierReferenceOrShorthandName(original) {
+		if tx.convertedLoopState.argumentsName == nil {
+			tx.convertedLoopState.argumentsName = tx.Factory().NewUniqueName("arguments")
+		}
+		return tx.convertedLoopState.argumentsName
+	}
+
+	// Block-scoped bindings whose names collide with a name in an outer scope are renamed, as they will be lowered to
+	// function-scoped `var` declarations.
+	if declaration := tx.bindings.getReferencedDeclarationWithCollidingName(original); declaration != nil {
+		name := tx.Factory().NewGeneratedNameForNode(ast.GetNameOfDeclaration(declaration))
+		name.Loc = node.Loc
+		return name
+	}
+	if tx.isNameOfDeclarationWithCollidingName(original) {
+		name := tx.Factory().NewGeneratedNameForNode(original)
+		name.Loc = node.Loc
+		return name
+	}
+	return node
+}
+
+func (tx *es2015Transformer) isNameOfDeclarationWithCollidingName(node *ast.IdentifierNode) bool {
+	parent := node.Parent
+	switch parent.Kind {
+	case ast.KindBindingElement, ast.KindClassDeclaration, ast.KindEnumDeclaration, ast.KindVariableDeclaration:
+		return parent.Name() == node && tx.bindings.isDeclarationWithCollidingName(parent)
+	}
+	return false
+}
+
+// Gets the name to use for a binding introduced by a block-scoped declaration, which may need to be renamed.
+func (tx *es2015Transformer) getBindingName(name *ast.IdentifierNode) *ast.IdentifierNode {
+	return tx.visitIdentifier(name)
+}
+
+func (tx *es2015Transformer) visitThisKeyword(node *ast.Node) *ast.Node {
+	if tx.hierarchyFacts&hierarchyFactsArrowFunction != 0 && tx.hierarchyFacts&hierarchyFactsStaticInitializer == 0 {
+		tx.hierarchyFacts |= hierarchyFactsCapturedLexicalThis
+	}
+	if tx.convertedLoopState != nil && tx.hierarchyFacts&hierarchyFactsArrowFunction == 0 {
+		if tx.convertedLoopState.thisName == nil {
+			tx.convertedLoopState.thisName = tx.Factory().NewUniqueName("this")
+		}
+		return tx.convertedLoopState.thisName
+	}
+	if tx.hierarchyFacts&(hierarchyFactsArrowFunction|hierarchyFactsCapturesThis) != 0 {
+		name := tx.createCapturedThis()
+		name.Loc = node.Loc
+		return name
+	}
+	return node
+}
+
+func (tx *es2015Transformer) createCapturedThis() *ast.IdentifierNode {
+	return tx.Factory().NewUniqueNameEx("_this", printer.AutoGenerateOptions{Flags: printer.GeneratedIdentifierFlagsOptimistic | printer.GeneratedIdentifierFlagsFileLevel})
+}
+
+func (tx *es2015Transformer) createSyntheticSuper() *ast.IdentifierNode {
+	return tx.Factory().NewUniqueNameEx("_super", printer.AutoGenerateOptions{Flags: printer.GeneratedIdentifierFlagsOptimistic | printer.GeneratedIdentifierFlagsFileLevel})
+}
+
+func (tx *es2015Transformer) visitSuperKeyword(node *ast.Node, isExpressionOfCall bool) *ast.Node {
+	var result *ast.Expression
+	if tx.hierarchyFacts&hierarchyFactsNonStaticClassElement != 0 && !isExpressionOfCall {
+		result = tx.Factory().NewPropertyAccessExpression(tx.createSyntheticSuper(), nil /*questionDotToken*/, tx.Factory().NewIdentifier("prototype"), ast.NodeFlagsNone)
+	} else {
+		result = tx.createSyntheticSuper()
+	}
+	tx.EmitContext().SetOriginal(result, node)
+	tx.EmitContext().AssignCommentAndSourceMapRanges(result, node)
+	return result
+}
+
+func (tx *es2015Transformer) visitMetaProperty(node *ast.MetaProperty) *ast.Node {
+	if node.KeywordToken == ast.KindNewKeyword && node.Name().Text() == "target" {
+		tx.hierarchyFacts |= hierarchyFactsNewTarget
+		return tx.Factory().NewUniqueNameEx("_newTarget", printer.AutoGenerateOptions{Flags: printer.GeneratedIdentifierFlagsOptimistic | printer.GeneratedIdentifierFlagsFileLevel})
+	}
+	return node.AsNode()
+}
+
+// Inserts `var _this = this;` at the start of a function body or source file that contains an arrow function that
+// captures the lexical `this`.
+func (tx *es2015Transformer) insertCaptureThisForNodeIfNeeded(statements []*ast.Statement, node *ast.Node) []*ast.Statement {
+	if tx.hierarchyFacts&hierarchyFactsCapturedLexicalThis != 0 && !ast.IsArrowFunction(node) {
+		return tx.insertCaptureThisForNode(statements, node, tx.Factory().NewThisExpression())
+	}
+	return statements
+}
+
+func (tx *es2015Transformer) insertCaptureThisForNode(statements []*ast.Statement, node *ast.Node, initializer *ast.Expression) []*ast.Statement {
+	f := tx.Factory()
+	captureThisStatement := f.NewVariableStatement(nil /*modifiers*/, f.NewVariableDeclarationList(ast.NodeFlagsNone, f.NewNodeList([]*ast.Node{
+		f.NewVariableDeclaration(tx.createCapturedThis(), nil /*exclamationToken*/, nil /*typeNode*/, initializer),
+	})))
+	tx.EmitContext().SetEmitFlags(captureThisStatement, printer.EFNoComments|printer.EFCustomPrologue)
+	tx.EmitContext().SetSourceMapRange(captureThisStatement, node.Loc)
+	return tx.insertStatementsAfterCustomPrologue(statements, captureThisStatement)
+}
+
+// Inserts `var _newTarget = ...;` at the start of a function body that contains a `new.target` meta-property.
+func (tx *es2015Transformer) insertCaptureNewTargetIfNeeded(statements []*ast.Statement, node *ast.Node) []*ast.Statement {
+	if tx.hierarchyFacts&hierarchyFactsNewTarget == 0 {
+		return statements
+	}
+
+	f := tx.Factory()
+	var newTarget *ast.Expression
+	switch node.Kind {
+	case ast.KindArrowFunction:
+		return statements
+	case ast.KindMethodDeclaration, ast.KindGetAccessor, ast.KindSetAccessor:
+		// Methods and accessors cannot be constructors, so 'new.target' will always return 'undefined'.
+		newTarget = f.NewVoidZeroExpression()
+	case ast.KindConstructor:
+		// Class constructors can only be called with `new`, so `this.constructor` should be relatively safe to use.
+		newTarget = f.NewPropertyAccessExpression(f.NewThisExpression(), nil /*questionDotToken*/, f.NewIdentifier("constructor"), ast.NodeFlagsNone)
+	case ast.KindFunctionDeclaration, ast.KindFunctionExpression:
+		// Functions can be called or constructed, and may have a `this` due to being a member or when calling an
+		// imported function via `other_1.f()`.
+		newTarget = f.NewConditionalExpression(
+			f.NewLogicalANDExpression(
+				f.NewThisExpression(),
+				f.NewBinaryExpression(nil /*modifiers*/, f.NewThisExpression(), nil /*typeNode*/, f.NewToken(ast.KindInstanceOfKeyword), f.GetLocalName(node)),
+			),
+			f.NewToken(ast.KindQuestionToken),
+			f.NewPropertyAccessExpression(f.NewThisExpression(), nil /*questionDotToken*/, f.NewIdentifier("constructor"), ast.NodeFlagsNone),
+			f.NewToken(ast.KindColonToken),
+			f.NewVoidZeroExpression(),
+		)
+	default:
+		panic("Unexpected node kind: " + node.Kind.String())
+	}
+
+	captureNewTargetStatement := f.NewVariableStatement(nil /*modifiers*/, f.NewVariableDeclarationList(ast.NodeFlagsNone, f.NewNodeList([]*ast.Node{
+		f.NewVariableDeclaration(
+			f.NewUniqueNameEx("_newTarget", printer.AutoGenerateOptions{Flags: printer.GeneratedIdentifierFlagsOptimistic | printer.GeneratedIdentifierFlagsFileLevel}),
+			nil, /*exclamationToken*/
+			nil, /*typeNode*/
+			newTarget,
+		),
+	})))
+	tx.EmitContext().SetEmitFlags(captureNewTargetStatement, printer.EFNoComments|printer.EFCustomPrologue)
+	return tx.insertStatementsAfterCustomPrologue(statements, captureNewTargetStatement)
+}
+
+// Inserts statements after the standard and custom prologue of a list of statements.
+func (tx *es2015Transformer) insertStatementsAfterCustomPrologue(statements []*ast.Statement, toInsert ...*ast.Statement) []*ast.Statement {
+	index := 0
+	for index < len(statements) && (ast.IsPrologueDirective(statements[index]) || tx.EmitContext().EmitFlags(statements[index])&printer.EFCustomPrologue != 0) {
+		index++
+	}
+	return core.Splice(statements, index, 0, toInsert...)
+}
+
+//
+// Functions
+//
+
+func (tx *es2015Transformer) visitParameter(node *ast.ParameterDeclaration) *ast.Node {
+	if node.DotDotDotToken != nil {
+		// rest parameters are elided
+		return nil
+	}
+	if ast.IsBindingPattern(node.Name()) {
+		// Binding patterns are converted into a generated name and are evaluated inside the function body.
+		parameter := tx.Factory().NewParameterDeclaration(nil /*modifiers*/, nil /*dotDotDotToken*/, tx.Factory().NewGeneratedNameForNode(node.AsNode()), nil /*questionToken*/, nil /*typeNode*/, nil /*initializer*/)
+		parameter.Loc = node.Loc
+		tx.EmitContext().SetOriginal(parameter, node.AsNode())
+		return parameter
+	}
+	if node.Initializer != nil {
+		// Initializers are elided
+		parameter := tx.Factory().NewParameterDeclaration(nil /*modifiers*/, nil /*dotDotDotToken*/, node.Name(), nil /*questionToken*/, nil /*typeNode*/, nil /*initializer*/)
+		parameter.Loc = node.Loc
+		tx.EmitContext().SetOriginal(parameter, node.AsNode())
+		return parameter
+	}
+	return node.AsNode()
+}
+
+func (tx *es2015Transformer) visitFunctionDeclaration(node *ast.FunctionDeclaration) *ast.Node {
+	if node.Body == nil {
+		return node.AsNode()
+	}
+	savedConvertedLoopState := tx.convertedLoopState
+	tx.convertedLoopState = nil
+	ancestorFacts := tx.enterSubtree(hierarchyFactsFunctionExcludes, hierarchyFactsFunctionIncludes)
+	parameters := tx.EmitContext().VisitParameters(node.Parameters, tx.Visitor())
+	body := tx.transformFunctionBody(node.AsNode())
+	name := node.Name()
+	if tx.hierarchyFacts&hierarchyFactsNewTarget != 0 {
+		name = tx.Factory().GetLocalName(node.AsNode())
+	}
+	tx.exitSubtree(ancestorFacts, hierarchyFactsFunctionSubtreeExcludes, hierarchyFactsNone)
+	tx.convertedLoopState = savedConvertedLoopState
+	return tx.Factory().UpdateFunctionDeclaration(node, tx.Visitor().VisitModifiers(node.Modifiers()), node.AsteriskToken, name, nil /*typeParameters*/, parameters, nil /*returnType*/, body)
+}
+
+func (tx *es2015Transformer) visitFunctionExpression(node *ast.FunctionExpression) *ast.Node {
+	var ancestorFacts hierarchyFacts
+	if tx.EmitContext().EmitFlags(node.AsNode())&printer.EFAsyncFunctionBody != 0 {
+		ancestorFacts = tx.enterSubtree(hierarchyFactsAsyncFunctionBodyExcludes, hierarchyFactsAsyncFunctionBodyIncludes)
+	} else {
+		ancestorFacts = tx.enterSubtree(hierarchyFactsFunctionExcludes, hierarchyFactsFunctionIncludes)
+	}
+	savedConvertedLoopState := tx.convertedLoopState
+	tx.convertedLoopState = nil
+	parameters := tx.EmitContext().VisitParameters(node.Parameters, tx.Visitor())
+	body := tx.transformFunctionBody(node.AsNode())
+	name := node.Name()
+	if tx.hierarchyFacts&hierarchyFactsNewTarget != 0 {
+		name = tx.Factory().GetLocalName(node.AsNode())
+	}
+	tx.exitSubtree(ancestorFacts, hierarchyFactsFunctionSubtreeExcludes, hierarchyFactsNone)
+	tx.convertedLoopState = savedConvertedLoopState
+	return tx.Factory().UpdateFunctionExpression(node, nil /*modifiers*/, node.AsteriskToken, name, nil /*typeParameters*/, parameters, nil /*returnType*/, body)
+}
+
+// Lowers an arrow function to a function expression, capturing the lexical `this` of its container:
+//
+//	() => this.x -> function () { return _this.x; }
+func (tx *es2015Transformer) visitArrowFunction(node *ast.ArrowFunction) *ast.Node {
+	if node.SubtreeFacts()&ast.SubtreeContainsLexicalThis != 0 && tx.hierarchyFacts&hierarchyFactsStaticInitializer == 0 {
+		tx.hierarchyFacts |= hierarchyFactsCapturedLexicalThis
+	}
+	savedConvertedLoopState := tx.convertedLoopState
+	tx.convertedLoopState = nil
+	ancestorFacts := tx.enterSubtree(hierarchyFactsArrowFunctionExcludes, hierarchyFactsArrowFunctionIncludes)
+	parameters := tx.EmitContext().VisitParameters(node.Parameters, tx.Visitor())
+	body := tx.transformFunctionBody(node.AsNode())
+	fn := tx.Factory().NewFunctionExpression(nil /*modifiers*/, nil /*asteriskToken*/, nil /*name*/, nil /*typeParameters*/, parameters, nil /*returnType*/, body)
+	fn.Loc = node.Loc
+	tx.EmitContext().SetOriginal(fn, node.AsNode())
+	tx.exitSubtree(ancestorFacts, hierarchyFactsArrowFunctionSubtreeExcludes, hierarchyFactsNone)
+	tx.convertedLoopState = savedConvertedLoopState
+	return fn
+}
+
+// Converts an object literal method into a property assignment whose value is a function expression:
+//
+//	{ m() {} } -> { m: function () {} }
+func (tx *es2015Transformer) visitMethodDeclaration(node *ast.MethodDeclaration) *ast.Node {
+	functionExpression := tx.transformFunctionLikeToExpression(node.AsNode(), node.Loc, nil /*name*/, nil /*container*/)
+	tx.EmitContext().AddEmitFlags(functionExpression, printer.EFNoLeadingComments)
+	assignment := tx.Factory().NewPropertyAssignment(nil /*modifiers*/, tx.Visitor().VisitNode(node.Name()), nil /*postfixToken*/, nil /*typeNode*/, functionExpression)
+	assignment.Loc = node.Loc
+	tx.EmitContext().SetOriginal(assignment, node.AsNode())
+	return assignment
+}
+
+func (tx *es2015Transformer) visitAccessorDeclaration(node *ast.Node) *ast.Node {
+	savedConvertedLoopState := tx.convertedLoopState
+	tx.convertedLoopState = nil
+	ancestorFacts := tx.enterSubtree(hierarchyFactsFunctionExcludes, hierarchyFactsFunctionIncludes)
+	parameters := tx.EmitContext().VisitParameters(node.ParameterList(), tx.Visitor())
+	body := tx.transformFunctionBody(node)
+	var updated *ast.Node
+	if ast.IsGetAccessorDeclaration(node) {
+		updated = tx.Factory().UpdateGetAccessorDeclaration(node.AsGetAccessorDeclaration(), nil /*modifiers*/, tx.Visitor().VisitNode(node.Name()), nil /*typeParameters*/, parameters, nil /*returnType*/, body)
+	} else {
+		updated = tx.Factory().UpdateSetAccessorDeclaration(node.AsSetAccessorDeclaration(), nil /*modifiers*/, tx.Visitor().VisitNode(node.Name()), nil /*typeParameters*/, parameters, nil /*returnType*/, body)
+	}
+	tx.exitSubtree(ancestorFacts, hierarchyFactsFunctionSubtreeExcludes, hierarchyFactsNone)
+	tx.convertedLoopState = savedConvertedLoopState
+	return updated
+}
+
+// Transforms a function-like declaration, such as a method, into a function expression.
+func (tx *es2015Transformer) transformFunctionLikeToExpression(node *ast.Node, location core.TextRange, name *ast.IdentifierNode, container *ast.Node) *ast.Expression {
+	savedConvertedLoopState := tx.convertedLoopState
+	tx.convertedLoopState = nil
+	var ancestorFacts hierarchyFacts
+	if container != nil && ast.IsClassLike(container) && !ast.IsStatic(node) {
+		ancestorFacts = tx.enterSubtree(hierarchyFactsFunctionExcludes, hierarchyFactsFunctionIncludes|hierarchyFactsNonStaticClassElement)
+	} else {
+		ancestorFacts = tx.enterSubtree(hierarchyFactsFunctionExcludes, hierarchyFactsFunctionIncludes)
+	}
+	parameters := tx.EmitContext().VisitParameters(node.ParameterList(), tx.Visitor())
+	body := tx.transformFunctionBody(node)
+	if tx.hierarchyFacts&hierarchyFactsNewTarget != 0 && name == nil && (ast.IsFunctionDeclaration(node) || ast.IsFunctionExpression(node)) {
+		name = tx.Factory().NewGeneratedNameForNode(node)
+	}
+	tx.exitSubtree(ancestorFacts, hierarchyFactsFunctionSubtreeExcludes, hierarchyFactsNone)
+	tx.convertedLoopState = savedConvertedLoopState
+
+	fn := tx.Factory().NewFunctionExpression(nil /*modifiers*/, node.BodyData().AsteriskToken, name, nil /*typeParameters*/, parameters, nil /*returnType*/, body)
+	fn.Loc = location
+	tx.EmitContext().SetOriginal(fn, node)
+	return fn
+}
+
+// Transforms the body of a function-like node, moving parameter initializers, binding patterns, and rest parameters
+// into the body. This ends the variable environment started when the parameters were visited.
+func (tx *es2015Transformer) transformFunctionBody(node *ast.Node) *ast.Node {
+	f := tx.Factory()
+	body := node.Body()
+	multiLine := false
+	singleLine := false
+	statementsLocation := body.Loc
+
+	var prologue []*ast.Statement
+	var statements []*ast.Statement
+	var rest []*ast.Statement
+	if ast.IsBlock(body) {
+		var customPrologue []*ast.Statement
+		prologue, rest = f.SplitStandardPrologue(body.AsBlock().Statements.Nodes)
+		prologue = slices.Clone(prologue)
+		customPrologue, rest = f.SplitCustomPrologue(rest)
+		visited, _ := tx.Visitor().VisitSlice(customPrologue)
+		statements = append(statements, visited...)
+		statementsLocation = body.AsBlock().Statements.Loc
+	}
+
+	var added bool
+	statements, added = tx.addDefaultValueAssignmentsIfNeeded(statements, node)
+	multiLine = multiLine || added
+	statements, added = tx.addRestParameterIfNeeded(statements, node, false /*inConstructorWithSynthesizedSuper*/)
+	multiLine = multiLine || added
+
+	if ast.IsBlock(body) {
+		visited, _ := tx.Visitor().VisitSlice(rest)
+		statements = append(statements, visited...)
+		if !multiLine && body.AsBlock().Multiline {
+			multiLine = true
+		}
+	} else {
+		// Arrow functions with an expression body become functions with a single return statement.
+		if arrow := node.AsArrowFunction(); !ast.NodeIsSynthesized(arrow.EqualsGreaterThanToken) && !ast.NodeIsSynthesized(body) && tx.currentSourceFile != nil {
+			lineStarts := scanner.GetLineStarts(tx.currentSourceFile)
+			if scanner.ComputeLineOfPosition(lineStarts, arrow.EqualsGreaterThanToken.End()) == scanner.ComputeLineOfPosition(lineStarts, scanner.SkipTrivia(tx.currentSourceFile.Text(), body.Pos())) {
+				singleLine = true
+			} else {
+				multiLine = true
+			}
+		}
+		expression := tx.Visitor().VisitNode(body)
+		returnStatement := f.NewReturnStatement(expression)
+		returnStatement.Loc = body.Loc
+		tx.EmitContext().SetEmitFlags(returnStatement, printer.EFNoTokenSourceMaps|printer.EFNoTrailingSourceMap|printer.EFNoTrailingComments)
+		statements = append(statements, returnStatement)
+	}
+
+	prologue = tx.EmitContext().MergeEnvironment(prologue, tx.EmitContext().EndVariableEnvironment())
+	prologue = tx.insertCaptureNewTargetIfNeeded(prologue, node)
+	prologue = tx.insertCaptureThisForNodeIfNeeded(prologue, node)
+	if len(prologue) > 0 && core.Some(prologue, func(statement *ast.Statement) bool { return !ast.IsPrologueDirective(statement) }) {
+		multiLine = true
+	}
+
+	statementList := f.NewNodeList(append(prologue, statements...))
+	statementList.Loc = statementsLocation
+	block := f.NewBlock(statementList, multiLine)
+	block.Loc = body.Loc
+	if !multiLine && singleLine {
+		tx.EmitContext().SetEmitFlags(block, printer.EFSingleLine)
+	}
+	tx.EmitContext().SetOriginal(block, body)
+	return block
+}
+
+func hasDefaultValueOrBindingPattern(parameter *ast.Node) bool {
+	return parameter.Initializer() != nil || ast.IsBindingPattern(parameter.Name())
+}
+
+// Adds statements to the body of a function to evaluate parameter initializers and binding patterns:
+//
+//	function f(x = 1, { y }) {} -> function f(x, _a) { if (x === void 0) { x = 1; } var y = _a.y; }
+func (tx *es2015Transformer) addDefaultValueAssignmentsIfNeeded(statements []*ast.Statement, node *ast.Node) ([]*ast.Statement, bool) {
+	if !core.Some(node.Parameters(), hasDefaultValueOrBindingPattern) {
+		return statements, false
+	}
+
+	f := tx.Factory()
+	added := false
+	for _, parameter := range node.Parameters() {
+		if parameter.AsParameterDeclaration().DotDotDotToken != nil {
+			continue
+		}
+		name := parameter.Name()
+		initializer := parameter.Initializer()
+		if ast.IsBindingPattern(name) {
+			if len(name.AsBindingPattern().Elements.Nodes) > 0 {
+				declarations := tx.flattenBindingDeclaration(parameter, f.NewGeneratedNameForNode(parameter), false /*hoistTempVariables*/)
+				statement := f.NewVariableStatement(nil /*modifiers*/, f.NewVariableDeclarationList(ast.NodeFlagsNone, f.NewNodeList(declarations)))
+				tx.EmitContext().SetEmitFlags(statement, printer.EFCustomPrologue)
+				statements = tx.insertStatementsAfterCustomPrologue(statements, statement)
+				added = true
+			} else if initializer != nil {
+				statement := f.NewExpressionStatement(f.NewAssignmentExpression(f.NewGeneratedNameForNode(parameter), tx.Visitor().VisitNode(initializer)))
+				tx.EmitContext().SetEmitFlags(statement, printer.EFCustomPrologue)
+				statements = tx.insertStatementsAfterCustomPrologue(statements, statement)
+				added = true
+			}
+		} else if initializer != nil {
+			statement := createParameterDefaultValueStatement(tx.EmitContext(), parameter, tx.Visitor().VisitNode(initializer))
+			statements = tx.insertStatementsAfterCustomPrologue(statements, statement)
+			added = true
+		}
+	}
+	return statements, added
+}
+
+// Adds statements to the body of a function to collect the arguments for a rest parameter:
+//
+//	function f(a, ...b) {} -> function f(a) { var b = []; for (var _i = 1; _i < arguments.length; _i++) { b[_i - 1] = arguments[_i]; } }
+func (tx *es2015Transformer) addRestParameterIfNeeded(statements []*ast.Statement, node *ast.Node, inConstructorWithSynthesizedSuper bool) ([]*ast.Statement, bool) {
+	parameters := node.Parameters()
+	if len(parameters) == 0 || inConstructorWithSynthesizedSuper {
+		return statements, false
+	}
+	parameter := parameters[len(parameters)-1]
+	if parameter.AsParameterDeclaration().DotDotDotToken == nil {
+		return statements, false
+	}
+
+	f := tx.Factory()
+	var declarationName *ast.IdentifierNode
+	var expressionName *ast.IdentifierNode
+	if ast.IsIdentifier(parameter.Name()) {
+		declarationName = parameter.Name().Clone(f)
+		declarationName.Loc = parameter.Name().Loc
+		expressionName = parameter.Name().Clone(f)
+	} else {
+		declarationName = f.NewTempVariable()
+		expressionName = declarationName
+	}
+	tx.EmitContext().SetEmitFlags(declarationName, printer.EFNoSourceMap)
+
+	restIndex := len(parameters) - 1
+	temp := f.NewLoopVariable()
+
+	declarationStatement := f.NewVariableStatement(nil /*modifiers*/, f.NewVariableDeclarationList(ast.NodeFlagsNone, f.NewNodeList([]*ast.Node{
+		f.NewVariableDeclaration(declarationName, nil /*exclamationToken*/, nil /*typeNode*/, f.NewArrayLiteralExpression(f.NewNodeList(nil), false /*multiLine*/)),
+	})))
+	declarationStatement.Loc = parameter.Loc
+	tx.EmitContext().SetEmitFlags(declarationStatement, printer.EFCustomPrologue)
+
+	restIndexLiteral := func() *ast.Expression { return f.NewNumericLiteral(strconv.Itoa(restIndex)) }
+	argumentsLength := f.NewPropertyAccessExpression(f.NewIdentifier("arguments"), nil /*questionDotToken*/, f.NewIdentifier("length"), ast.NodeFlagsNone)
+	var targetIndex *ast.Expression = temp.Clone(f)
+	if restIndex != 0 {
+		targetIndex = f.NewBinaryExpression(nil /*modifiers*/, temp.Clone(f), nil /*typeNode*/, f.NewToken(ast.KindMinusToken), restIndexLiteral())
+	}
+	copyStatement := f.NewExpressionStatement(f.NewAssignmentExpression(
+		f.NewElementAccessExpression(expressionName.Clone(f), nil /*questionDotToken*/, targetIndex, ast.NodeFlagsNone),
+		f.NewElementAccessExpression(f.NewIdentifier("arguments"), nil /*questionDotToken*/, temp.Clone(f), ast.NodeFlagsNone),
+	))
+	copyStatement.Loc = parameter.Loc
+	tx.EmitContext().AddEmitFlags(copyStatement, printer.EFStartOnNewLine)
+
+	initializer := f.NewVariableDeclarationList(ast.NodeFlagsNone, f.NewNodeList([]*ast.Node{
+		f.NewVariableDeclaration(temp, nil /*exclamationToken*/, nil /*typeNode*/, restIndexLiteral()),
+	}))
+	initializer.Loc = parameter.Loc
+	condition := f.NewBinaryExpression(nil /*modifiers*/, temp.Clone(f), nil /*typeNode*/, f.NewToken(ast.KindLessThanToken), argumentsLength)
+	condition.Loc = parameter.Loc
+	incrementor := f.NewPostfixUnaryExpression(temp.Clone(f), ast.KindPlusPlusToken)
+	incrementor.Loc = parameter.Loc
+	forStatement := f.NewForStatement(initializer, condition, incrementor, f.NewBlock(f.NewNodeList([]*ast.Statement{copyStatement}), false /*multiLine*/))
+	tx.EmitContext().SetEmitFlags(forStatement, printer.EFCustomPrologue|printer.EFStartOnNewLine)
+
+	prologueStatements := []*ast.Statement{declarationStatement, forStatement}
+	if !ast.IsIdentifier(parameter.Name()) {
+		declarations := tx.flattenBindingDeclaration(parameter, expressionName.Clone(f), false /*hoistTempVariables*/)
+		destructuringStatement := f.NewVariableStatement(nil /*modifiers*/, f.NewVariableDeclarationList(ast.NodeFlagsNone, f.NewNodeList(declarations)))
+		destructuringStatement.Loc = parameter.Loc
+		tx.EmitContext().SetEmitFlags(destructuringStatement, printer.EFCustomPrologue)
+		prologueStatements = append(prologueStatements, destructuringStatement)
+	}
+	return tx.insertStatementsAfterCustomPrologue(statements, prologueStatements...), true
+}
+
+// Flattens a binding pattern into a list of variable declarations, renaming any bindings whose names collide with a
+// name in an outer scope.
+func (tx *es2015Transformer) flattenBindingDeclaration(node *ast.Node, rval *ast.Expression, hoistTempVariables bool) []*ast.Node {
+	declarations := flattenDestructuringBinding(tx.EmitContext(), tx.Visitor(), node, rval, flattenLevelAll, tx.compilerOptions.DownlevelIteration.IsTrue(), hoistTempVariables, false /*skipInitializer*/)
+	for i, declaration := range declarations {
+		if name := declaration.Name(); ast.IsIdentifier(name) {
+			if renamed := tx.getBindingName(name); renamed != name {
+				declarations[i] = tx.Factory().UpdateVariableDeclaration(declaration.AsVariableDeclaration(), renamed, nil /*exclamationToken*/, nil /*typeNode*/, declaration.Initializer())
+			}
+		}
+	}
+	return declarations
+}
+
+func (tx *es2015Transformer) visitReturnStatement(node *ast.ReturnStatement) *ast.Node {
+	f := tx.Factory()
+	if tx.convertedLoopState != nil {
+		tx.convertedLoopState.nonLocalJumps |= jumpReturn
+		var expression *ast.Expression
+		if node.Expression != nil {
+			expression = tx.Visitor().VisitNode(node.Expression)
+		} else if tx.hierarchyFacts&hierarchyFactsConstructorWithSuperCall != 0 {
+			expression = tx.createCapturedThis()
+		} else {
+			expression = f.NewVoidZeroExpression()
+		}
+		return f.NewReturnStatement(f.NewObjectLiteralExpression(f.NewNodeList([]*ast.Node{
+			f.NewPropertyAssignment(nil /*modifiers*/, f.NewIdentifier("value"), nil /*postfixToken*/, nil /*typeNode*/, expression),
+		}), false /*multiLine*/))
+	}
+	if tx.hierarchyFacts&hierarchyFactsConstructorWithSuperCall != 0 && node.Expression == nil {
+		return f.UpdateReturnStatement(node, tx.createCapturedThis())
+	}
+	return tx.Visitor().VisitEachChild(node.AsNode())
+}
+
+//
+// Expressions
+//
+
+func (tx *es2015Transformer) visitParenthesizedExpression(node *ast.ParenthesizedExpression, discarded bool) *ast.Node {
+	visitor := tx.Visitor()
+	if discarded {
+		visitor = tx.discardedValueVisitor
+	}
+	return tx.Factory().UpdateParenthesizedExpression(node, visitor.VisitNode(node.Expression))
+}
+
+func (tx *es2015Transformer) visitBinaryExpression(node *ast.BinaryExpression, discarded bool) *ast.Node {
+	// If we are here it is because this is a destructuring assignment.
+	if ast.IsDestructuringAssignment(node.AsNode()) {
+		return flattenDestructuringAssignment(tx.EmitContext(), tx.Visitor(), node.AsNode(), !discarded, flattenLevelAll, tx.compilerOptions.DownlevelIteration.IsTrue())
+	}
+	if node.OperatorToken.Kind == ast.KindCommaToken {
+		right := tx.Visitor()
+		if discarded {
+			right = tx.discardedValueVisitor
+		}
+		return tx.Factory().UpdateBinaryExpression(node, nil /*modifiers*/, tx.discardedValueVisitor.VisitNode(node.Left), nil /*typeNode*/, node.OperatorToken, right.VisitNode(node.Right))
+	}
+	return tx.Visitor().VisitEachChild(node.AsNode())
+}
+
+// Converts a shorthand property assignment into a property assignment, as the value may need to be renamed:
+//
+//	{ x } -> { x: x }
+func (tx *es2015Transformer) visitShorthandPropertyAssignment(node *ast.ShorthandPropertyAssignment) *ast.Node {
+	name := node.Name().Clone(tx.Factory())
+	name.Loc = node.Name().Loc
+	assignment := tx.Factory().NewPropertyAssignment(nil /*modifiers*/, name, nil /*postfixToken*/, nil /*typeNode*/, tx.visitIdentifier(node.Name()))
+	assignment.Loc = node.Loc
+	tx.EmitContext().SetOriginal(assignment, node.AsNode())
+	return assignment
+}
+
+// Lowers an object literal containing computed property names to a comma expression that assigns each property after
+// the first computed property name in turn:
+//
+//	{ a: 1, [b]: 2, c() {} } -> (_a = { a: 1 }, _a[b] = 2, _a.c = function () {}, _a)
+func (tx *es2015Transformer) visitObjectLiteralExpression(node *ast.ObjectLiteralExpression) *ast.Node {
+	properties := node.Properties.Nodes
+	numInitialProperties := -1
+	for i, property := range properties {
+		if property.Name() != nil && ast.IsComputedPropertyName(property.Name()) {
+			numInitialProperties = i
+			break
+		}
+	}
+	if numInitialProperties < 0 {
+		return tx.Visitor().VisitEachChild(node.AsNode())
+	}
+
+	f := tx.Factory()
+	temp := f.NewTempVariable()
+	tx.EmitContext().AddVariableDeclaration(temp)
+
+	initialProperties, _ := tx.Visitor().VisitSlice(properties[:numInitialProperties])
+	initialObject := f.NewObjectLiteralExpression(f.NewNodeList(initialProperties), node.MultiLine)
+	tx.EmitContext().SetEmitFlags(initialObject, printer.EFIndented)
+	assignment := f.NewAssignmentExpression(temp, initialObject)
+	if node.MultiLine {
+		tx.EmitContext().AddEmitFlags(assignment, printer.EFStartOnNewLine)
+	}
+
+	expressions := []*ast.Expression{assignment}
+	expressions = tx.addObjectLiteralMembers(expressions, node, temp, numInitialProperties)
+	result := temp.Clone(f)
+	if node.MultiLine {
+		tx.EmitContext().AddEmitFlags(result, printer.EFStartOnNewLine)
+	}
+	expressions = append(expressions, result)
+	return f.InlineExpressions(expressions)
+}
+
+func (tx *es2015Transformer) addObjectLiteralMembers(expressions []*ast.Expression, node *ast.ObjectLiteralExpression, receiver *ast.IdentifierNode, start int) []*ast.Expression {
+	f := tx.Factory()
+	properties := node.Properties.Nodes
+	for _, property := range properties[start:] {
+		var expression *ast.Expression
+		switch property.Kind {
+		case ast.KindGetAccessor, ast.KindSetAccessor:
+			accessors := getAllAccessorDeclarations(properties, property)
+			if property == accessors.firstAccessor {
+				expression = tx.transformAccessorsToExpression(receiver, accessors, node.AsNode(), node.MultiLine)
+			}
+		case ast.KindMethodDeclaration:
+			expression = f.NewAssignmentExpression(
+				tx.createMemberAccessForPropertyName(receiver.Clone(f), tx.Visitor().VisitNode(property.Name())),
+				tx.transformFunctionLikeToExpression(property, property.Loc, nil /*name*/, node.AsNode()),
+			)
+			expression.Loc = property.Loc
+		case ast.KindPropertyAssignment:
+			expression = f.NewAssignmentExpression(
+				tx.createMemberAccessForPropertyName(receiver.Clone(f), tx.Visitor().VisitNode(property.Name())),
+				tx.Visitor().VisitNode(property.Initializer()),
+			)
+			expression.Loc = property.Loc
+		case ast.KindShorthandPropertyAssignment:
+			name := property.Name().Clone(f)
+			expression = f.NewAssignmentExpression(
+				tx.createMemberAccessForPropertyName(receiver.Clone(f), name),
+				tx.visitIdentifier(property.Name()),
+			)
+			expression.Loc = property.Loc
+		}
+		if expression != nil {
+			if node.MultiLine {
+				tx.EmitContext().AddEmitFlags(expression, printer.EFStartOnNewLine)
+			}
+			expressions = append(expressions, expression)
+		}
+	}
+	return expressions
+}
+
+// Creates a property access or element access for a property name:
+//
+//	x -> target.x
+//	"x" -> target["x"]
+//	[x] -> target[x]
+func (tx *es2015Transformer) createMemberAccessForPropertyName(target *ast.Expression, memberName *ast.Node) *ast.Expression {
+	f := tx.Factory()
+	if ast.IsComputedPropertyName(memberName) {
+		expression := f.NewElementAccessExpression(target, nil /*questionDotToken*/, memberName.Expression(), ast.NodeFlagsNone)
+		expression.Loc = memberName.Loc
+		return expression
+	}
+	var expression *ast.Expression
+	if ast.IsIdentifier(memberName) || ast.IsPrivateIdentifier(memberName) {
+		expression = f.NewPropertyAccessExpression(target, nil /*questionDotToken*/, memberName, ast.NodeFlagsNone)
+	} else {
+		expression = f.NewElementAccessExpression(target, nil /*questionDotToken*/, memberName, ast.NodeFlagsNone)
+	}
+	expression.Loc = memberName.Loc
+	tx.EmitContext().AddEmitFlags(expression, printer.EFNoNestedSourceMaps)
+	return expression
+}
+
+// Gets the expression for the name of a property, for use as the key of a call to `Object.defineProperty`.
+func (tx *es2015Transformer) createExpressionForPropertyName(memberName *ast.Node) *ast.Expression {
+	f := tx.Factory()
+	switch {
+	case ast.IsIdentifier(memberName):
+		literal := f.NewStringLiteralFromNode(memberName)
+		literal.Loc = memberName.Loc
+		return literal
+	case ast.IsComputedPropertyName(memberName):
+		return memberName.Expression().Clone(f)
+	default:
+		return memberName.Clone(f)
+	}
+}
+
+func (tx *es2015Transformer) visitArrayLiteralExpression(node *ast.ArrayLiteralExpression) *ast.Node {
+	if core.Some(node.Elements.Nodes, ast.IsSpreadElement) {
+		// We are here because we contain a SpreadElementExpression.
+		return tx.transformAndSpreadElements(node.Elements.Nodes, false /*isArgumentList*/, node.MultiLine, node.Elements.HasTrailingComma())
+	}
+	return tx.Visitor().VisitEachChild(node.AsNode())
+}
+
+func (tx *es2015Transformer) visitCallExpression(node *ast.CallExpression) *ast.Node {
+	return tx.visitCallExpressionWithPotentialCapturedThisAssignment(node, true /*assignToCapturedThis*/)
+}
+
+func containsSpreadElement(nodes *ast.NodeList) bool {
+	return nodes != nil && core.Some(nodes.Nodes, ast.IsSpreadElement)
+}
+
+func (tx *es2015Transformer) visitCallExpressionWithPotentialCapturedThisAssignment(node *ast.CallExpression, assignToCapturedThis bool) *ast.Expression {
+	f := tx.Factory()
+	hasSpread := containsSpreadElement(node.Arguments)
+	isSuperCall := node.Expression.Kind == ast.KindSuperKeyword
+	if !hasSpread && !isSuperCall && !isSuperProperty(ast.SkipOuterExpressions(node.Expression, ast.OEKAll)) {
+		return tx.Factory().UpdateCallExpression(node, tx.callExpressionVisitor.VisitNode(node.Expression), node.QuestionDotToken, nil /*typeArguments*/, tx.Visitor().VisitNodes(node.Arguments))
+	}
+
+	// We are here either because SuperKeyword was used somewhere in the expression, or
+	// because we contain a SpreadElementExpression.
+	target, thisArg := createCallBinding(tx.EmitContext(), node.Expression, false /*cacheIdentifiers*/)
+	if !isSuperCall {
+		thisArg = tx.Visitor().VisitNode(thisArg)
+	}
+
+	var resultingCall *ast.Expression
+	if hasSpread {
+		// [source]
+		//      f(...a, b)
+		//      x.m(...a, b)
+		//      super(...a, b)
+		//      super.m(...a, b) // in static
+		//      super.m(...a, b) // in instance
+		//
+		// [output]
+		//      f.apply(void 0, a.concat([b]))
+		//      (_a = x).m.apply(_a, a.concat([b]))
+		//      _super.apply(this, a.concat([b]))
+		//      _super.m.apply(this, a.concat([b]))
+		//      _super.prototype.m.apply(this, a.concat([b]))
+		resultingCall = f.NewFunctionApplyCall(tx.callExpressionVisitor.VisitNode(target), thisArg, tx.transformAndSpreadElements(node.Arguments.Nodes, true /*isArgumentList*/, false /*multiLine*/, false /*hasTrailingComma*/))
+	} else {
+		// [source]
+		//      super(a)
+		//      super.m(a) // in static
+		//      super.m(a) // in instance
+		//
+		// [output]
+		//      _super.call(this, a)
+		//      _super.m.call(this, a)
+		//      _super.prototype.m.call(this, a)
+		arguments, _ := tx.Visitor().VisitSlice(node.Arguments.Nodes)
+		resultingCall = f.NewFunctionCallCall(tx.callExpressionVisitor.VisitNode(target), thisArg, arguments)
+		resultingCall.Loc = node.Loc
+	}
+
+	if isSuperCall {
+		initializer := f.NewLogicalORExpression(resultingCall, f.NewThisExpression())
+		if assignToCapturedThis {
+			resultingCall = f.NewAssignmentExpression(tx.createCapturedThis(), initializer)
+		} else {
+			resultingCall = initializer
+		}
+	}
+	tx.EmitContext().SetOriginal(resultingCall, node.AsNode())
+	return resultingCall
+}
+
+// Gets the target of a call and the value to use for its `this` argument when invoking the target with `.call` or
+// `.apply`, caching the receiver of a property access in a temporary variable when needed. Identifier receivers are
+// only cached when `cacheIdentifiers` is set.
+//
+// NOTE: This is the equivalent of `factory.createCallBinding` in Strada.
+func createCallBinding(emitContext *printer.EmitContext, expression *ast.Expression, cacheIdentifiers bool) (target *ast.Expression, thisArg *ast.Expression) {
+	f := emitContext.Factory
+	callee := ast.SkipOuterExpressions(expression, ast.OEKAll)
+	switch {
+	case isSuperProperty(callee):
+		return callee, f.NewThisExpression()
+	case callee.Kind == ast.KindSuperKeyword:
+		return callee, f.NewThisExpression()
+	case emitContext.EmitFlags(callee)&printer.EFHelperName != 0:
+		return callee, f.NewVoidZeroExpression()
+	case ast.IsPropertyAccessExpression(callee):
+		receiver := callee.Expression()
+		if shouldBeCapturedInTempVariable(receiver, cacheIdentifiers) {
+			temp := f.NewTempVariable()
+			emitContext.AddVariableDeclaration(temp)
+			assignment := f.NewAssignmentExpression(temp, receiver)
+			assignment.Loc = receiver.Loc
+			target = f.NewPropertyAccessExpression(assignment, nil /*questionDotToken*/, callee.Name(), ast.NodeFlagsNone)
+			target.Loc = callee.Loc
+			return target, temp.Clone(f)
+		}
+		return callee, receiver
+	case ast.IsElementAccessExpression(callee):
+		receiver := callee.Expression()
+		if shouldBeCapturedInTempVariable(receiver, cacheIdentifiers) {
+			temp := f.NewTempVariable()
+			emitContext.AddVariableDeclaration(temp)
+			assignment := f.NewAssignmentExpression(temp, receiver)
+			assignment.Loc = receiver.Loc
+			target = f.NewElementAccessExpression(assignment, nil /*questionDotToken*/, callee.AsElementAccessExpression().ArgumentExpression, ast.NodeFlagsNone)
+			target.Loc = callee.Loc
+			return target, temp.Clone(f)
+		}
+		return callee, receiver
+	default:
+		return expression, f.NewVoidZeroExpression()
+	}
+}
+
+func shouldBeCapturedInTempVariable(node *ast.Expression, cacheIdentifiers bool) bool {
+	target := ast.SkipParentheses(node)
+	switch target.Kind {
+	case ast.KindIdentifier:
+		return cacheIdentifiers
+	case ast.KindThisKeyword, ast.KindNumericLiteral, ast.KindBigIntLiteral, ast.KindStringLiteral:
+		return false
+	case ast.KindArrayLiteralExpression:
+		return len(target.AsArrayLiteralExpression().Elements.Nodes) != 0
+	case ast.KindObjectLiteralExpression:
+		return len(target.AsObjectLiteralExpression().Properties.Nodes) != 0
+	default:
+		return true
+	}
+}
+
+// Lowers a `new` expression with spread arguments to a call to `Function.prototype.bind`:
+//
+//	new C(...a) -> new (C.bind.apply(C, __spreadArray([void 0], a, false)))()
+func (tx *es2015Transformer) visitNewExpression(node *ast.NewExpression) *ast.Node {
+	if !containsSpreadElement(node.Arguments) {
+		return tx.Visitor().VisitEachChild(node.AsNode())
+	}
+
+	f := tx.Factory()
+	target, thisArg := createCallBinding(tx.EmitContext(), f.NewPropertyAccessExpression(node.Expression, nil /*questionDotToken*/, f.NewIdentifier("bind"), ast.NodeFlagsNone), false /*cacheIdentifiers*/)
+	arguments := append([]*ast.Expression{f.NewVoidZeroExpression()}, node.Arguments.Nodes...)
+	return f.NewNewExpression(
+		f.NewFunctionApplyCall(tx.Visitor().VisitNode(target), tx.Visitor().VisitNode(thisArg), tx.transformAndSpreadElements(arguments, true /*isArgumentList*/, false /*multiLine*/, false /*hasTrailingComma*/)),
+		nil, /*typeArguments*/
+		f.NewNodeList(nil),
+	)
+}
+
+type spreadSegmentKind int
+
+const (
+	spreadSegmentKindNone           spreadSegmentKind = iota // Not a spread segment
+	spreadSegmentKindUnpackedSpread                          // A spread segment that must be packed (i.e., converting `[...[1, , 2]]` into `[1, undefined, 2]`)
+	spreadSegmentKindPackedSpread                            // A spread segment that is known to already be packed (i.e., `[...[1, 2]]` or `[...__read(a)]`)
+)
+
+type spreadSegment struct {
+	kind       spreadSegmentKind
+	expression *ast.Expression
+}
+
+// Transforms an array of Expression nodes that contains a SpreadExpression into a call to the `__spreadArray` helper.
+func (tx *es2015Transformer) transformAndSpreadElements(elements []*ast.Expression, isArgumentList bool, multiLine bool, hasTrailingComma bool) *ast.Expression {
+	f := tx.Factory()
+
+	// Map spans of spread expressions into their expressions and spans of other expressions into an array literal.
+	var segments []spreadSegment
+	var chunk []*ast.Expression
+	flushChunk := func(isLast bool) {
+		if len(chunk) > 0 {
+			visited, _ := tx.Visitor().VisitSlice(chunk)
+			list := f.NewNodeList(visited)
+			if isLast && hasTrailingComma {
+				list = f.NewNodeList(visited) // !!! trailing commas are not preserved on synthesized lists
+			}
+			segments = append(segments, spreadSegment{spreadSegmentKindNone, f.NewArrayLiteralExpression(list, multiLine)})
+			chunk = nil
+		}
+	}
+	for _, element := range elements {
+		if ast.IsSpreadElement(element) {
+			flushChunk(false /*isLast*/)
+			segments = append(segments, tx.visitExpressionOfSpread(element))
+		} else {
+			chunk = append(chunk, element)
+		}
+	}
+	flushChunk(true /*isLast*/)
+
+	if len(segments) == 1 {
+		firstSegment := segments[0]
+		// If we don't need a unique copy, then we are spreading into an argument list for a `.apply` call.
+		if isArgumentList && !tx.compilerOptions.DownlevelIteration.IsTrue() ||
+			isPackedArrayLiteral(firstSegment.expression) ||
+			tx.EmitContext().IsCallToHelper(firstSegment.expression, "__spreadArray") {
+			return firstSegment.expression
+		}
+	}
+
+	startsWithSpread := segments[0].kind != spreadSegmentKindNone
+	var expression *ast.Expression
+	start := 1
+	if startsWithSpread {
+		expression = f.NewArrayLiteralExpression(f.NewNodeList(nil), false /*multiLine*/)
+		start = 0
+	} else {
+		expression = segments[0].expression
+	}
+	for _, segment := range segments[start:] {
+		expression = f.NewSpreadArrayHelper(expression, segment.expression, segment.kind == spreadSegmentKindUnpackedSpread && !isArgumentList)
+	}
+	return expression
+}
+
+func (tx *es2015Transformer) visitExpressionOfSpread(node *ast.Node) spreadSegment {
+	expression := tx.Visitor().VisitNode(node.Expression())
+
+	// We don't need to pack already packed array literals, or existing calls to the `__read` helper.
+	isCallToReadHelper := tx.EmitContext().IsCallToHelper(expression, "__read")
+	kind := spreadSegmentKindUnpackedSpread
+	if isCallToReadHelper || isPackedArrayLiteral(expression) {
+		kind = spreadSegmentKindPackedSpread
+	}
+
+	// We don't need the `__read` helper for array literals. Array packing will be performed by `__spreadArray`.
+	if tx.compilerOptions.DownlevelIteration.IsTrue() && kind == spreadSegmentKindUnpackedSpread && !ast.IsArrayLiteralExpression(expression) && !isCallToReadHelper {
+		expression = tx.Factory().NewReadHelper(expression, -1 /*count*/)
+		// the `__read` helper returns a packed array, so we don't need to ensure a packed array
+		kind = spreadSegmentKindPackedSpread
+	}
+	return spreadSegment{kind, expression}
+}
+
+func isPackedArrayLiteral(node *ast.Expression) bool {
+	return ast.IsArrayLiteralExpression(node) && core.Every(node.AsArrayLiteralExpression().Elements.Nodes, func(element *ast.Node) bool {
+		return !ast.IsOmittedExpression(element)
+	})
+}
+
+//
+// Templates and literals
+//
+
+func (tx *es2015Transformer) visitTemplateLiteral(node *ast.Node) *ast.Node {
+	literal := tx.Factory().NewStringLiteral(node.Text())
+	literal.Loc = node.Loc
+	return literal
+}
+
+// Visits a string literal containing an extended unicode escape, which must be re-escaped when targeting ES5.
+func (tx *es2015Transformer) visitStringLiteral(node *ast.StringLiteral) *ast.Node {
+	if node.TokenFlags&ast.TokenFlagsExtendedUnicodeEscape != 0 {
+		literal := tx.Factory().NewStringLiteral(node.Text)
+		literal.Loc = node.Loc
+		return literal
+	}
+	return node.AsNode()
+}
+
+// Visits a binary or octal numeric literal, which must be written as a decimal literal when targeting ES5.
+func (tx *es2015Transformer) visitNumericLiteral(node *ast.NumericLiteral) *ast.Node {
+	if node.TokenFlags&ast.TokenFlagsBinaryOrOctalSpecifier != 0 {
+		literal := tx.Factory().NewNumericLiteral(node.Text)
+		literal.Loc = node.Loc
+		return literal
+	}
+	return node.AsNode()
+}
+
+// Lowers a template expression to a series of calls to `String.prototype.concat`:
+//
+//	`a${b}c${d}` -> "a".concat(b, "c").concat(d)
+func (tx *es2015Transformer) visitTemplateExpression(node *ast.TemplateExpression) *ast.Node {
+	f := tx.Factory()
+	expression := f.NewStringLiteral(node.Head.Text())
+	for _, span := range node.TemplateSpans.Nodes {
+		arguments := []*ast.Expression{tx.Visitor().VisitNode(span.Expression())}
+		if literal := span.AsTemplateSpan().Literal; len(literal.Text()) > 0 {
+			arguments = append(arguments, f.NewStringLiteral(literal.Text()))
+		}
+		expression = f.NewCallExpression(
+			f.NewPropertyAccessExpression(expression, nil /*questionDotToken*/, f.NewIdentifier("concat"), ast.NodeFlagsNone),
+			nil, /*questionDotToken*/
+			nil, /*typeArguments*/
+			f.NewNodeList(arguments),
+			ast.NodeFlagsNone,
+		)
+	}
+	expression.Loc = node.Loc
+	return expression
+}
+
+// Lowers a tagged template to a call to the tag, passing a template object created by the `__makeTemplateObject`
+// helper:
+//
+//	tag`a${b}c` -> tag(__makeTemplateObject(["a", "c"], ["a", "c"]), b)
+//
+// In a module, the template object is cached so that each evaluation of the tagged template receives the same object.
+func (tx *es2015Transformer) visitTaggedTemplateExpression(node *ast.TaggedTemplateExpression) *ast.Node {
+	f := tx.Factory()
+	tag := tx.Visitor().VisitNode(node.Tag)
+	templateArguments := []*ast.Expression{nil}
+	var cookedStrings, rawStrings []*ast.Expression
+	template := node.Template
+	if template.Kind == ast.KindNoSubstitutionTemplateLiteral {
+		cookedStrings = append(cookedStrings, tx.createTemplateCooked(template))
+		rawStrings = append(rawStrings, tx.getRawLiteral(template))
+	} else {
+		head := template.AsTemplateExpression().Head
+		cookedStrings = append(cookedStrings, tx.createTemplateCooked(head))
+		rawStrings = append(rawStrings, tx.getRawLiteral(head))
+		for _, span := range template.AsTemplateExpression().TemplateSpans.Nodes {
+			literal := span.AsTemplateSpan().Literal
+			cookedStrings = append(cookedStrings, tx.createTemplateCooked(literal))
+			rawStrings = append(rawStrings, tx.getRawLiteral(literal))
+			templateArguments = append(templateArguments, tx.Visitor().VisitNode(span.Expression()))
+		}
+	}
+
+	helperCall := f.NewTemplateObjectHelper(
+		f.NewArrayLiteralExpression(f.NewNodeList(cookedStrings), false /*multiLine*/),
+		f.NewArrayLiteralExpression(f.NewNodeList(rawStrings), false /*multiLine*/),
+	)
+
+	// Create a variable to cache the template object if we're in a module.
+	// Do not do this in the global scope, as any variable we currently generate could conflict with
+	// variables from outside of the current compilation. In the future, we can revisit this behavior.
+	if ast.IsExternalModule(tx.currentSourceFile) {
+		tempVar := f.NewUniqueName("templateObject")
+		tx.taggedTemplateStringDeclarations = append(tx.taggedTemplateStringDeclarations, f.NewVariableDeclaration(tempVar, nil /*exclamationToken*/, nil /*typeNode*/, nil /*initializer*/))
+		templateArguments[0] = f.NewLogicalORExpression(tempVar.Clone(f), f.NewAssignmentExpression(tempVar.Clone(f), helperCall))
+	} else {
+		templateArguments[0] = helperCall
+	}
+	return f.NewCallExpression(tag, nil /*questionDotToken*/, nil /*typeArguments*/, f.NewNodeList(templateArguments), ast.NodeFlagsNone)
+}
+
+func templateFlagsOf(node *ast.Node) ast.TokenFlags {
+	switch node.Kind {
+	case ast.KindNoSubstitutionTemplateLiteral:
+		return node.AsNoSubstitutionTemplateLiteral().TemplateFlags
+	case ast.KindTemplateHead:
+		return node.AsTemplateHead().TemplateFlags
+	case ast.KindTemplateMiddle:
+		return node.AsTemplateMiddle().TemplateFlags
+	case ast.KindTemplateTail:
+		return node.AsTemplateTail().TemplateFlags
+	}
+	return ast.TokenFlagsNone
+}
+
+func rawTextOf(node *ast.Node) string {
+	switch node.Kind {
+	case ast.KindNoSubstitutionTemplateLiteral:
+		return node.AsNoSubstitutionTemplateLiteral().RawText
+	case ast.KindTemplateHead:
+		return node.AsTemplateHead().RawText
+	case ast.KindTemplateMiddle:
+		return node.AsTemplateMiddle().RawText
+	case ast.KindTemplateTail:
+		return node.AsTemplateTail().RawText
+	}
+	return ""
+}
+
+func (tx *es2015Transformer) createTemplateCooked(template *ast.Node) *ast.Expression {
+	if templateFlagsOf(template)&ast.TokenFlagsIsInvalid != 0 {
+		return tx.Factory().NewVoidZeroExpression()
+	}
+	return tx.Factory().NewStringLiteral(template.Text())
+}
+
+var lineTerminatorSequenceRegExp = regexp.MustCompile(`\r\n?`)
+
+// Creates a string literal for the raw text of a template literal, as would be observed through the `raw` property of
+// a template strings array.
+//
+// ES2015 spec (https://tc39.es/ecma262/#sec-static-semantics-trv):
+// "The TRV of LineTerminatorSequence :: <CR><LF> is the sequence consisting of the code unit value 0x000A."
+// "The TRV of LineTerminatorSequence :: <CR> is the sequence consisting of the code unit value 0x000A."
+func (tx *es2015Transformer) getRawLiteral(node *ast.Node) *ast.Expression {
+	text := rawTextOf(node)
+	if text == "" && !ast.NodeIsSynthesized(node) {
+		// The parser does not record the raw text of a no-substitution template literal, so take it from the source
+		// text, without the backtick and the `${` or closing backtick.
+		text = scanner.GetSourceTextOfNodeFromSourceFile(tx.currentSourceFile, node, false /*includeTrivia*/)
+		endLength := 2
+		if node.Kind == ast.KindNoSubstitutionTemplateLiteral || node.Kind == ast.KindTemplateTail {
+			endLength = 1
+		}
+		if templateFlagsOf(node)&ast.TokenFlagsUnterminated != 0 {
+			endLength = 0
+		}
+		text = text[1 : len(text)-endLength]
+	}
+	text = lineTerminatorSequenceRegExp.ReplaceAllString(text, "\n")
+	literal := tx.Factory().NewStringLiteral(text)
+	literal.Loc = node.Loc
+	return literal
+}
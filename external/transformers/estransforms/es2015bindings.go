@@ -0,0 +1,275 @@
+package estransforms
+
+import (
+	"github.com/pagpeter/typescript-go/external/ast"
+	"github.com/pagpeter/typescript-go/external/binder"
+	"github.com/pagpeter/typescript-go/external/collections"
+	"github.com/pagpeter/typescript-go/external/core"
+	"github.com/pagpeter/typescript-go/external/transformers"
+)
+
+type blockScopedBindingFlags uint8
+
+const (
+	blockScopedBindingFlagsCaptured              blockScopedBindingFlags = 1 << iota // The binding is referenced from within a nested function.
+	blockScopedBindingFlagsInLoop                                                    // The binding is declared within an iteration statement.
+	blockScopedBindingFlagsNeedsLoopOutParameter                                     // The binding is declared in the initializer of a `for` statement and assigned within its body.
+)
+
+// blockScopedBindings records facts about the `let`, `const`, and `class` declarations of a source file that are
+// needed to lower them to function-scoped `var` declarations. When targeting ES5 these facts are recorded by the
+// checker as it checks each identifier; since transformers do not have access to the checker, they are recomputed
+// here from the bound source file.
+type blockScopedBindings struct {
+	resolver *binder.NameResolver
+
+	declarations    map[*ast.Node]blockScopedBindingFlags     // keyed by the value declaration of each binding
+	references      map[*ast.Node]*ast.Node                   // maps each identifier referencing a binding to the value declaration of the binding
+	capturingLoops  collections.Set[*ast.Node]                // iteration statements whose body captures a binding declared within the loop
+	capturingParts  map[*ast.Node]*collections.Set[*ast.Node] // maps each part of a `for` statement to the bindings declared in the initializer that it captures
+	collidingByDecl map[*ast.Node]bool                        // caches whether the name of a declaration collides with a name in an outer scope
+}
+
+func newBlockScopedBindings(file *ast.SourceFile, options *core.CompilerOptions) *blockScopedBindings {
+	b := &blockScopedBindings{
+		resolver:        &binder.NameResolver{CompilerOptions: options},
+		declarations:    make(map[*ast.Node]blockScopedBindingFlags),
+		references:      make(map[*ast.Node]*ast.Node),
+		capturingParts:  make(map[*ast.Node]*collections.Set[*ast.Node]),
+		collidingByDecl: make(map[*ast.Node]bool),
+	}
+
+	// Only identifiers whose text matches the name of some block-scoped declaration need to be resolved.
+	var names collections.Set[string]
+	var collectNames func(node *ast.Node) bool
+	collectNames = func(node *ast.Node) bool {
+		switch node.Kind {
+		case ast.KindVariableDeclaration:
+			if ast.GetCombinedNodeFlags(node)&ast.NodeFlagsBlockScoped != 0 {
+				collectBindingNames(node.Name(), &names)
+			}
+		case ast.KindClassDeclaration:
+			if node.Name() != nil {
+				names.Add(node.Name().Text())
+			}
+		}
+		node.ForEachChild(collectNames)
+		return false
+	}
+	file.AsNode().ForEachChild(collectNames)
+	if names.Len() == 0 {
+		return b
+	}
+
+	var visit func(node *ast.Node) bool
+	visit = func(node *ast.Node) bool {
+		if ast.IsIdentifier(node) {
+			if names.Has(node.Text()) && isIdentifierReferenceOrShorthandName(node) {
+				b.checkReference(node)
+			}
+			return false
+		}
+		node.ForEachChild(visit)
+		return false
+	}
+	file.AsNode().ForEachChild(visit)
+	return b
+}
+
+func collectBindingNames(name *ast.Node, names *collections.Set[string]) {
+	if ast.IsIdentifier(name) {
+		names.Add(name.Text())
+		return
+	}
+	for _, element := range name.AsBindingPattern().Elements.Nodes {
+		if element.Name() != nil {
+			collectBindingNames(element.Name(), names)
+		}
+	}
+}
+
+func isIdentifierReferenceOrShorthandName(node *ast.Node) bool {
+	if node.Parent == nil {
+		return false
+	}
+	if ast.IsShorthandPropertyAssignment(node.Parent) {
+		return node.Parent.Name() == node
+	}
+	return transformers.IsIdentifierReference(node, node.Parent)
+}
+
+// Records the facts about a reference to a block-scoped binding.
+//
+// NOTE: This is the equivalent of `checkNestedBlockScopedBinding` in Strada.
+func (b *blockScopedBindings) checkReference(node *ast.IdentifierNode) {
+	symbol := b.resolver.Resolve(node, node.Text(), ast.SymbolFlagsValue, nil /*nameNotFoundMessage*/, false /*isUse*/, false /*excludeGlobals*/)
+	if symbol == nil ||
+		symbol.Flags&(ast.SymbolFlagsBlockScopedVariable|ast.SymbolFlagsClass) == 0 ||
+		symbol.ValueDeclaration == nil ||
+		ast.IsSourceFile(symbol.ValueDeclaration) ||
+		symbol.ValueDeclaration.Parent.Kind == ast.KindCatchClause {
+		return
+	}
+
+	declaration := symbol.ValueDeclaration
+	b.references[node] = declaration
+	flags := b.declarations[declaration]
+
+	container := ast.GetEnclosingBlockScopeContainer(declaration)
+	isCaptured := isInsideFunctionOrInstancePropertyInitializer(node, container)
+	if loop := getEnclosingIterationStatement(container); loop != nil {
+		if isCaptured {
+			capturesBindingInLoopBody := true
+			if ast.IsForStatement(container) {
+				if list := ast.FindAncestor(declaration, ast.IsVariableDeclarationList); list != nil && list.Parent == container {
+					if part := getPartOfForStatementContainingNode(node.Parent, container.AsForStatement()); part != nil {
+						captured := b.capturingParts[part]
+						if captured == nil {
+							captured = &collections.Set[*ast.Node]{}
+							b.capturingParts[part] = captured
+						}
+						captured.Add(declaration)
+						if part == container.AsForStatement().Initializer {
+							capturesBindingInLoopBody = false
+						}
+					}
+				}
+			}
+			if capturesBindingInLoopBody {
+				b.capturingLoops.Add(loop)
+			}
+		}
+		if ast.IsForStatement(container) {
+			if list := ast.FindAncestor(declaration, ast.IsVariableDeclarationList); list != nil && list.Parent == container && isAssignedInBodyOfForStatement(node, container.AsForStatement()) {
+				flags |= blockScopedBindingFlagsNeedsLoopOutParameter
+			}
+		}
+		flags |= blockScopedBindingFlagsInLoop
+	}
+	if isCaptured {
+		flags |= blockScopedBindingFlagsCaptured
+	}
+	b.declarations[declaration] = flags
+}
+
+func (b *blockScopedBindings) flags(declaration *ast.Node) blockScopedBindingFlags {
+	return b.declarations[declaration]
+}
+
+// Gets whether the body of a loop captures a binding declared within the loop, which requires the body of the loop to
+// be converted into a function so that each iteration gets its own copy of the binding.
+func (b *blockScopedBindings) isLoopWithCapturedBinding(node *ast.Node) bool {
+	return node != nil && b.capturingLoops.Has(node)
+}
+
+// Gets whether the initializer, condition, or incrementor of a `for` statement captures a binding declared in its
+// initializer.
+func (b *blockScopedBindings) isPartWithCapturedBinding(node *ast.Node) bool {
+	return node != nil && b.capturingParts[node] != nil
+}
+
+// Gets whether a part of a `for` statement captures a particular binding declared in its initializer.
+func (b *blockScopedBindings) isBindingCapturedByNode(node *ast.Node, declaration *ast.Node) bool {
+	captured := b.capturingParts[node]
+	return captured != nil && captured.Has(declaration)
+}
+
+// Gets the value declaration of the block-scoped binding referenced by an identifier, if that binding must be renamed
+// because its name collides with a name in an outer scope.
+func (b *blockScopedBindings) getReferencedDeclarationWithCollidingName(node *ast.IdentifierNode) *ast.Node {
+	if declaration := b.references[node]; declaration != nil && b.isDeclarationWithCollidingName(declaration) {
+		return declaration
+	}
+	return nil
+}
+
+// Gets whether the name of a block-scoped declaration collides with a name in an outer scope, and thus must be
+// renamed when the declaration is lowered to a function-scoped `var`.
+func (b *blockScopedBindings) isDeclarationWithCollidingName(declaration *ast.Node) bool {
+	if result, ok := b.collidingByDecl[declaration]; ok {
+		return result
+	}
+	result := false
+	if symbol := declaration.Symbol(); symbol != nil && symbol.Flags&ast.SymbolFlagsBlockScoped != 0 {
+		container := ast.GetEnclosingBlockScopeContainer(declaration)
+		switch container.Kind {
+		case ast.KindBlock, ast.KindCaseBlock, ast.KindForStatement, ast.KindForInStatement, ast.KindForOfStatement:
+			if b.resolver.Resolve(container.Parent, symbol.Name, ast.SymbolFlagsValue, nil /*nameNotFoundMessage*/, false /*isUse*/, false /*excludeGlobals*/) != nil {
+				result = true
+			} else if flags := b.declarations[declaration]; flags&blockScopedBindingFlagsCaptured != 0 {
+				isDeclaredInLoop := flags&blockScopedBindingFlagsInLoop != 0
+				inLoopInitializer := ast.IsIterationStatement(container, false /*lookInLabeledStatements*/)
+				inLoopBodyBlock := container.Kind == ast.KindBlock && ast.IsIterationStatement(container.Parent, false /*lookInLabeledStatements*/)
+				result = !isDeclaredInLoop || (!inLoopInitializer && !inLoopBodyBlock)
+			}
+		}
+	}
+	b.collidingByDecl[declaration] = result
+	return result
+}
+
+func isInsideFunctionOrInstancePropertyInitializer(node *ast.Node, threshold *ast.Node) bool {
+	return ast.FindAncestorOrQuit(node, func(n *ast.Node) ast.FindAncestorResult {
+		if n == threshold {
+			return ast.FindAncestorQuit
+		}
+		return ast.ToFindAncestorResult(ast.IsFunctionLike(n) ||
+			n.Parent != nil && ast.IsPropertyDeclaration(n.Parent) && !ast.HasStaticModifier(n.Parent) && n.Parent.Initializer() == n)
+	}) != nil
+}
+
+func getEnclosingIterationStatement(node *ast.Node) *ast.Node {
+	return ast.FindAncestorOrQuit(node, func(n *ast.Node) ast.FindAncestorResult {
+		if startsNewLexicalEnvironment(n) {
+			return ast.FindAncestorQuit
+		}
+		return ast.ToFindAncestorResult(ast.IsIterationStatement(n, false /*lookInLabeledStatements*/))
+	})
+}
+
+func startsNewLexicalEnvironment(node *ast.Node) bool {
+	switch node.Kind {
+	case ast.KindConstructor, ast.KindFunctionExpression, ast.KindFunctionDeclaration, ast.KindArrowFunction,
+		ast.KindMethodDeclaration, ast.KindGetAccessor, ast.KindSetAccessor, ast.KindModuleDeclaration, ast.KindSourceFile:
+		return true
+	}
+	return false
+}
+
+func getPartOfForStatementContainingNode(node *ast.Node, container *ast.ForStatement) *ast.Node {
+	return ast.FindAncestorOrQuit(node, func(n *ast.Node) ast.FindAncestorResult {
+		if n == container.AsNode() {
+			return ast.FindAncestorQuit
+		}
+		return ast.ToFindAncestorResult(n == container.Initializer || n == container.Condition || n == container.Incrementor || n == container.Statement)
+	})
+}
+
+func isAssignedInBodyOfForStatement(node *ast.IdentifierNode, container *ast.ForStatement) bool {
+	current := node
+	for ast.IsParenthesizedExpression(current.Parent) {
+		current = current.Parent
+	}
+
+	isAssigned := false
+	if ast.IsAssignmentTarget(current) {
+		isAssigned = true
+	} else if ast.IsPrefixUnaryExpression(current.Parent) {
+		operator := current.Parent.AsPrefixUnaryExpression().Operator
+		isAssigned = operator == ast.KindPlusPlusToken || operator == ast.KindMinusMinusToken
+	} else if ast.IsPostfixUnaryExpression(current.Parent) {
+		operator := current.Parent.AsPostfixUnaryExpression().Operator
+		isAssigned = operator == ast.KindPlusPlusToken || operator == ast.KindMinusMinusToken
+	}
+	if !isAssigned {
+		return false
+	}
+
+	// The binding only needs to be copied out of the converted loop body if it is assigned within the body.
+	return ast.FindAncestorOrQuit(current, func(n *ast.Node) ast.FindAncestorResult {
+		if n == container.AsNode() {
+			return ast.FindAncestorQuit
+		}
+		return ast.ToFindAncestorResult(n == container.Statement)
+	}) != nil
+}
@@ -0,0 +1,475 @@
+package estransforms
+
+import (
+	"slices"
+
+	"github.com/pagpeter/typescript-go/external/ast"
+	"github.com/pagpeter/typescript-go/external/core"
+	"github.com/pagpeter/typescript-go/external/printer"
+	"github.com/pagpeter/typescript-go/external/scanner"
+	"github.com/pagpeter/typescript-go/external/transformers"
+)
+
+// Lowers a class declaration to a variable whose initializer is an immediately invoked function expression:
+//
+//	class C {} -> var C = /** @class */ (function () { function C() {} return C; }());
+func (tx *es2015Transformer) visitClassDeclaration(node *ast.ClassDeclaration) *ast.Node {
+	f := tx.Factory()
+	name := f.GetLocalNameEx(node.AsNode(), printer.AssignedNameOptions{AllowComments: true})
+	if node.Name() != nil && tx.bindings.isDeclarationWithCollidingName(node.AsNode()) {
+		name = f.NewGeneratedNameForNode(node.Name())
+	}
+	variable := f.NewVariableDeclaration(name, nil /*exclamationToken*/, nil /*typeNode*/, tx.transformClassLikeDeclarationToExpression(node.AsNode()))
+	tx.EmitContext().SetOriginal(variable, node.AsNode())
+
+	statement := f.NewVariableStatement(nil /*modifiers*/, f.NewVariableDeclarationList(ast.NodeFlagsNone, f.NewNodeList([]*ast.Node{variable})))
+	tx.EmitContext().SetOriginal(statement, node.AsNode())
+	statement.Loc = node.Loc
+	tx.EmitContext().AddEmitFlags(statement, printer.EFStartOnNewLine)
+	statements := []*ast.Statement{statement}
+
+	// Add an `export default` statement for default exports (for `--target es5 --module es6`)
+	if ast.HasSyntacticModifier(node.AsNode(), ast.ModifierFlagsExport) {
+		var exportStatement *ast.Statement
+		if ast.HasSyntacticModifier(node.AsNode(), ast.ModifierFlagsDefault) {
+			exportStatement = f.NewExportAssignment(nil /*modifiers*/, false /*isExportEquals*/, nil /*typeNode*/, name.Clone(f))
+		} else {
+			exportStatement = f.NewExportDeclaration(
+				nil,   /*modifiers*/
+				false, /*isTypeOnly*/
+				f.NewNamedExports(f.NewNodeList([]*ast.Node{f.NewExportSpecifier(false /*isTypeOnly*/, nil /*propertyName*/, name.Clone(f))})),
+				nil, /*moduleSpecifier*/
+				nil, /*attributes*/
+			)
+		}
+		tx.EmitContext().SetOriginal(exportStatement, statement)
+		statements = append(statements, exportStatement)
+	}
+	return transformers.SingleOrMany(statements, f)
+}
+
+func (tx *es2015Transformer) visitClassExpression(node *ast.ClassExpression) *ast.Node {
+	return tx.transformClassLikeDeclarationToExpression(node.AsNode())
+}
+
+func (tx *es2015Transformer) transformClassLikeDeclarationToExpression(node *ast.ClassLikeDeclaration) *ast.Expression {
+	f := tx.Factory()
+	extendsClauseElement := ast.GetClassExtendsHeritageElement(node)
+	var parameters []*ast.Node
+	if extendsClauseElement != nil {
+		parameters = append(parameters, f.NewParameterDeclaration(nil /*modifiers*/, nil /*dotDotDotToken*/, tx.createSyntheticSuper(), nil /*questionToken*/, nil /*typeNode*/, nil /*initializer*/))
+	}
+	classFunction := f.NewFunctionExpression(nil /*modifiers*/, nil /*asteriskToken*/, nil /*name*/, nil /*typeParameters*/, f.NewNodeList(parameters), nil /*returnType*/, tx.transformClassBody(node, extendsClauseElement))
+
+	// To preserve the behavior of the old emitter, we explicitly indent the body of the function here if it was
+	// requested in an earlier transformation.
+	tx.EmitContext().SetEmitFlags(classFunction, tx.EmitContext().EmitFlags(node)&printer.EFIndented|printer.EFReuseTempVariableScope)
+
+	// "inner" and "outer" below are added purely to preserve source map locations from the old emitter
+	inner := f.NewPartiallyEmittedExpression(classFunction)
+	inner.Loc = inner.Loc.WithEnd(node.End())
+	tx.EmitContext().SetEmitFlags(inner, printer.EFNoComments)
+
+	outer := f.NewPartiallyEmittedExpression(inner)
+	outer.Loc = outer.Loc.WithEnd(scanner.SkipTrivia(tx.currentSourceFile.Text(), node.Pos()))
+	tx.EmitContext().SetEmitFlags(outer, printer.EFNoComments)
+
+	var arguments []*ast.Expression
+	if extendsClauseElement != nil {
+		arguments = append(arguments, tx.Visitor().VisitNode(extendsClauseElement.Expression()))
+	}
+	result := f.NewParenthesizedExpression(f.NewCallExpression(outer, nil /*questionDotToken*/, nil /*typeArguments*/, f.NewNodeList(arguments), ast.NodeFlagsNone))
+	tx.EmitContext().AddSyntheticLeadingComment(result, ast.KindMultiLineCommentTrivia, "* @class ", false /*hasTrailingNewLine*/)
+	return result
+}
+
+func (tx *es2015Transformer) transformClassBody(node *ast.ClassLikeDeclaration, extendsClauseElement *ast.Node) *ast.Node {
+	f := tx.Factory()
+	var statements []*ast.Statement
+	name := f.GetInternalName(node)
+	constructorLikeName := name
+	if token := scanner.GetIdentifierToken(name.Text()); ast.IsKeywordKind(token) && !ast.IsContextualKeyword(token) {
+		constructorLikeName = f.NewGeneratedNameForNode(name)
+	}
+
+	tx.EmitContext().StartVariableEnvironment()
+	if extendsClauseElement != nil {
+		statement := f.NewExpressionStatement(f.NewExtendsHelper(f.GetInternalName(node)))
+		statement.Loc = extendsClauseElement.Loc
+		statements = append(statements, statement)
+	}
+	statements = append(statements, tx.transformConstructor(node, constructorLikeName, extendsClauseElement))
+	statements = tx.addClassMembers(statements, node)
+
+	// Create a synthetic text range for the return statement.
+	closingBracePos := scanner.SkipTrivia(tx.currentSourceFile.Text(), node.MemberList().End())
+
+	// The following partially-emitted expression exists purely to align our sourcemap emit with the original emitter.
+	outer := f.NewPartiallyEmittedExpression(constructorLikeName.Clone(f))
+	outer.Loc = outer.Loc.WithEnd(closingBracePos + 1)
+	tx.EmitContext().SetEmitFlags(outer, printer.EFNoComments)
+
+	statement := f.NewReturnStatement(outer)
+	statement.Loc = statement.Loc.WithPos(closingBracePos)
+	tx.EmitContext().SetEmitFlags(statement, printer.EFNoComments|printer.EFNoTokenSourceMaps)
+	statements = append(statements, statement)
+	statements = tx.EmitContext().EndAndMergeVariableEnvironment(statements)
+
+	statementList := f.NewNodeList(statements)
+	statementList.Loc = node.MemberList().Loc
+	block := f.NewBlock(statementList, true /*multiLine*/)
+	tx.EmitContext().SetEmitFlags(block, printer.EFNoComments)
+	return block
+}
+
+func (tx *es2015Transformer) transformConstructor(node *ast.ClassLikeDeclaration, name *ast.IdentifierNode, extendsClauseElement *ast.Node) *ast.Statement {
+	savedConvertedLoopState := tx.convertedLoopState
+	tx.convertedLoopState = nil
+	ancestorFacts := tx.enterSubtree(hierarchyFactsConstructorExcludes, hierarchyFactsConstructorIncludes)
+
+	constructor := getFirstConstructorWithBody(node)
+	hasSynthesizedSuper := hasSynthesizedDefaultSuperCall(constructor, extendsClauseElement != nil)
+
+	// If the class fields transform needed to synthesize a constructor for property initializers, it would have also
+	// added a synthetic `super(...arguments)` call. That call is replaced with a call that uses the `arguments`
+	// object.
+	var parameters *ast.NodeList
+	if constructor != nil && !hasSynthesizedSuper {
+		parameters = tx.EmitContext().VisitParameters(constructor.ParameterList(), tx.Visitor())
+	} else {
+		tx.EmitContext().StartVariableEnvironment()
+		parameters = tx.Factory().NewNodeList(nil)
+	}
+	body := tx.transformConstructorBody(constructor, node, extendsClauseElement, hasSynthesizedSuper)
+
+	constructorFunction := tx.Factory().NewFunctionDeclaration(nil /*modifiers*/, nil /*asteriskToken*/, name, nil /*typeParameters*/, parameters, nil /*returnType*/, body)
+	if constructor != nil {
+		constructorFunction.Loc = constructor.Loc
+		tx.EmitContext().SetOriginal(constructorFunction, constructor)
+	} else {
+		constructorFunction.Loc = node.Loc
+	}
+
+	tx.exitSubtree(ancestorFacts, hierarchyFactsFunctionSubtreeExcludes, hierarchyFactsNone)
+	tx.convertedLoopState = savedConvertedLoopState
+	return constructorFunction
+}
+
+func getFirstConstructorWithBody(node *ast.Node) *ast.Node {
+	for _, member := range node.Members() {
+		if ast.IsConstructorDeclaration(member) && member.Body() != nil {
+			return member
+		}
+	}
+	return nil
+}
+
+// Gets whether a constructor was synthesized by an earlier transform solely to call `super(...arguments)`.
+func hasSynthesizedDefaultSuperCall(constructor *ast.Node, hasExtendsClause bool) bool {
+	if constructor == nil || !hasExtendsClause || len(constructor.Parameters()) > 0 {
+		return false
+	}
+	statements := constructor.Body().AsBlock().Statements.Nodes
+	if len(statements) == 0 || !ast.NodeIsSynthesized(statements[0]) || !ast.IsExpressionStatement(statements[0]) {
+		return false
+	}
+	expression := statements[0].Expression()
+	if !ast.NodeIsSynthesized(expression) || !ast.IsCallExpression(expression) {
+		return false
+	}
+	callee := expression.Expression()
+	if !ast.NodeIsSynthesized(callee) || callee.Kind != ast.KindSuperKeyword {
+		return false
+	}
+	arguments := expression.Arguments()
+	if len(arguments) != 1 || !ast.NodeIsSynthesized(arguments[0]) || !ast.IsSpreadElement(arguments[0]) {
+		return false
+	}
+	spread := arguments[0].Expression()
+	return ast.IsIdentifier(spread) && spread.Text() == "arguments"
+}
+
+// Gets whether a function body contains a `super` call, ignoring any nested non-arrow functions.
+func containsSuperCall(node *ast.Node) bool {
+	var visit func(node *ast.Node) bool
+	visit = func(node *ast.Node) bool {
+		if ast.IsSuperCall(node) {
+			return true
+		}
+		if ast.IsFunctionLike(node) && !ast.IsArrowFunction(node) || ast.IsClassLike(node) {
+			return false
+		}
+		return node.ForEachChild(visit)
+	}
+	return node.ForEachChild(visit)
+}
+
+// Transforms the body of a constructor. In a derived class, the result of the `super` call is captured in `_this`,
+// which replaces `this` within the constructor:
+//
+//	constructor() { super(); this.x = 1; } -> function C() { var _this = _super.call(this) || this; _this.x = 1; return _this; }
+func (tx *es2015Transformer) transformConstructorBody(constructor *ast.Node, node *ast.ClassLikeDeclaration, extendsClauseElement *ast.Node, hasSynthesizedSuper bool) *ast.Node {
+	f := tx.Factory()
+
+	// determine whether the class is known syntactically to be a derived class (e.g. a class that extends a value that
+	// is not syntactically known to be `null`).
+	isDerivedClass := extendsClauseElement != nil && ast.SkipOuterExpressions(extendsClauseElement.Expression(), ast.OEKAll).Kind != ast.KindNullKeyword
+
+	if constructor == nil {
+		return tx.createDefaultConstructorBody(node, isDerivedClass)
+	}
+
+	body := constructor.Body().AsBlock()
+	prologue, rest := f.SplitStandardPrologue(body.Statements.Nodes)
+	prologue = slices.Clone(prologue)
+	if hasSynthesizedSuper || containsSuperCall(body.AsNode()) {
+		tx.hierarchyFacts |= hierarchyFactsConstructorWithSuperCall
+	}
+	mayReplaceThis := isDerivedClass || tx.hierarchyFacts&hierarchyFactsConstructorWithSuperCall != 0
+	if mayReplaceThis {
+		tx.hierarchyFacts |= hierarchyFactsCapturesThis
+	}
+
+	var statements []*ast.Statement
+	var superCall *ast.Expression
+	if hasSynthesizedSuper {
+		// _this = _super !== null && _super.apply(this, arguments) || this;
+		superCall = tx.createDefaultSuperCallOrThis()
+		rest = rest[1:]
+	} else if len(rest) > 0 && mayReplaceThis && getSuperCallFromStatement(rest[0]) != nil {
+		// The `super` call is the first statement of the constructor, so its result can initialize `_this` directly.
+		superCall = tx.visitCallExpressionWithPotentialCapturedThisAssignment(getSuperCallFromStatement(rest[0]).AsCallExpression(), false /*assignToCapturedThis*/)
+		rest = rest[1:]
+	}
+	visited, _ := tx.Visitor().VisitSlice(rest)
+	statements = append(statements, visited...)
+
+	prologue, _ = tx.addDefaultValueAssignmentsIfNeeded(prologue, constructor)
+	prologue, _ = tx.addRestParameterIfNeeded(prologue, constructor, hasSynthesizedSuper)
+	prologue = tx.insertCaptureNewTargetIfNeeded(prologue, constructor)
+
+	if mayReplaceThis {
+		if superCall != nil && len(statements) == 0 {
+			// Only the `super` call remains, so its result can be returned directly.
+			statements = append(statements, f.NewReturnStatement(superCall))
+			superCall = nil
+		} else {
+			initializer := superCall
+			if initializer == nil {
+				initializer = f.NewThisExpression()
+			}
+			prologue = tx.insertCaptureThisForNode(prologue, constructor, initializer)
+			if !isSufficientlyCoveredByReturnStatements(body.AsNode()) {
+				statements = append(statements, f.NewReturnStatement(tx.createCapturedThis()))
+			}
+		}
+	} else {
+		prologue = tx.insertCaptureThisForNodeIfNeeded(prologue, constructor)
+	}
+	prologue = tx.EmitContext().MergeEnvironment(prologue, tx.EmitContext().EndVariableEnvironment())
+
+	statementList := f.NewNodeList(append(prologue, statements...))
+	statementList.Loc = body.Statements.Loc
+	block := f.NewBlock(statementList, true /*multiLine*/)
+	block.Loc = body.Loc
+	tx.EmitContext().SetOriginal(block, body.AsNode())
+	return block
+}
+
+// Creates the body of the constructor for a class that did not declare one.
+func (tx *es2015Transformer) createDefaultConstructorBody(node *ast.ClassLikeDeclaration, isDerivedClass bool) *ast.Node {
+	f := tx.Factory()
+	statements := tx.EmitContext().EndVariableEnvironment()
+	if isDerivedClass {
+		// return _super !== null && _super.apply(this, arguments) || this;
+		statements = append(statements, f.NewReturnStatement(tx.createDefaultSuperCallOrThis()))
+	}
+	statementList := f.NewNodeList(statements)
+	statementList.Loc = node.MemberList().Loc
+	block := f.NewBlock(statementList, true /*multiLine*/)
+	block.Loc = node.Loc
+	tx.EmitContext().SetEmitFlags(block, printer.EFNoComments)
+	return block
+}
+
+// _super !== null && _super.apply(this, arguments) || this
+func (tx *es2015Transformer) createDefaultSuperCallOrThis() *ast.Expression {
+	f := tx.Factory()
+	superCall := f.NewFunctionApplyCall(tx.createSyntheticSuper(), f.NewThisExpression(), f.NewIdentifier("arguments"))
+	return f.NewLogicalORExpression(
+		f.NewLogicalANDExpression(
+			f.NewStrictInequalityExpression(tx.createSyntheticSuper(), f.NewKeywordExpression(ast.KindNullKeyword)),
+			superCall,
+		),
+		f.NewThisExpression(),
+	)
+}
+
+// Gets whether every code path through a statement ends in a `return` statement.
+func isSufficientlyCoveredByReturnStatements(statement *ast.Statement) bool {
+	switch statement.Kind {
+	case ast.KindReturnStatement:
+		// A return statement is considered covered.
+		return true
+	case ast.KindIfStatement:
+		// An if-statement with two covered branches is covered.
+		ifStatement := statement.AsIfStatement()
+		return ifStatement.ElseStatement != nil &&
+			isSufficientlyCoveredByReturnStatements(ifStatement.ThenStatement) &&
+			isSufficientlyCoveredByReturnStatements(ifStatement.ElseStatement)
+	case ast.KindBlock:
+		// A block is covered if it has a last statement which is covered.
+		statements := statement.AsBlock().Statements.Nodes
+		return len(statements) > 0 && isSufficientlyCoveredByReturnStatements(statements[len(statements)-1])
+	}
+	return false
+}
+
+func (tx *es2015Transformer) addClassMembers(statements []*ast.Statement, node *ast.ClassLikeDeclaration) []*ast.Statement {
+	members := node.Members()
+	for _, member := range members {
+		switch member.Kind {
+		case ast.KindSemicolonClassElement:
+			statement := tx.Factory().NewEmptyStatement()
+			statement.Loc = member.Loc
+			statements = append(statements, statement)
+		case ast.KindMethodDeclaration:
+			if member.Body() != nil {
+				statements = append(statements, tx.transformClassMethodDeclarationToStatement(tx.getClassMemberPrefix(node, member), member, node))
+			}
+		case ast.KindGetAccessor, ast.KindSetAccessor:
+			accessors := getAllAccessorDeclarations(members, member)
+			if member == accessors.firstAccessor {
+				statements = append(statements, tx.transformAccessorsToStatement(tx.getClassMemberPrefix(node, member), accessors, node))
+			}
+		}
+	}
+	return statements
+}
+
+func (tx *es2015Transformer) getClassMemberPrefix(node *ast.ClassLikeDeclaration, member *ast.Node) *ast.Expression {
+	f := tx.Factory()
+	if ast.IsStatic(member) {
+		return f.GetInternalName(node)
+	}
+	return f.NewPropertyAccessExpression(f.GetInternalName(node), nil /*questionDotToken*/, f.NewIdentifier("prototype"), ast.NodeFlagsNone)
+}
+
+// C.prototype.m = function () {};
+func (tx *es2015Transformer) transformClassMethodDeclarationToStatement(receiver *ast.Expression, member *ast.Node, container *ast.Node) *ast.Statement {
+	f := tx.Factory()
+	commentRange := tx.EmitContext().CommentRange(member)
+	sourceMapRange := tx.EmitContext().SourceMapRange(member)
+	memberFunction := tx.transformFunctionLikeToExpression(member, member.Loc, nil /*name*/, container)
+	propertyName := tx.Visitor().VisitNode(member.Name())
+	var expression *ast.Expression
+	if !ast.IsPrivateIdentifier(propertyName) && tx.compilerOptions.UseDefineForClassFields.IsTrue() {
+		// Object.defineProperty(C.prototype, "m", { value: function () {}, enumerable: false, writable: true, configurable: true });
+		expression = f.NewObjectDefinePropertyCall(receiver, tx.createExpressionForPropertyName(propertyName), f.NewObjectLiteralExpression(f.NewNodeList([]*ast.Node{
+			f.NewPropertyAssignment(nil /*modifiers*/, f.NewIdentifier("value"), nil /*postfixToken*/, nil /*typeNode*/, memberFunction),
+			f.NewPropertyAssignment(nil /*modifiers*/, f.NewIdentifier("enumerable"), nil /*postfixToken*/, nil /*typeNode*/, f.NewFalseExpression()),
+			f.NewPropertyAssignment(nil /*modifiers*/, f.NewIdentifier("writable"), nil /*postfixToken*/, nil /*typeNode*/, f.NewTrueExpression()),
+			f.NewPropertyAssignment(nil /*modifiers*/, f.NewIdentifier("configurable"), nil /*postfixToken*/, nil /*typeNode*/, f.NewTrueExpression()),
+		}), true /*multiLine*/))
+	} else {
+		expression = f.NewAssignmentExpression(tx.createMemberAccessForPropertyName(receiver, propertyName), memberFunction)
+	}
+	tx.EmitContext().SetEmitFlags(memberFunction, printer.EFNoComments)
+	tx.EmitContext().SetSourceMapRange(memberFunction, sourceMapRange)
+
+	statement := f.NewExpressionStatement(expression)
+	statement.Loc = member.Loc
+	tx.EmitContext().SetOriginal(statement, member)
+	tx.EmitContext().SetCommentRange(statement, commentRange)
+	// The location for the statement is used to emit comments only. No source map should be emitted for this
+	// statement to align with the old emitter.
+	tx.EmitContext().SetEmitFlags(statement, printer.EFNoSourceMap)
+	return statement
+}
+
+type allAccessorDeclarations struct {
+	firstAccessor *ast.AccessorDeclaration
+	getAccessor   *ast.AccessorDeclaration
+	setAccessor   *ast.AccessorDeclaration
+}
+
+// Gets the get and set accessors among a list of members that share the name of an accessor.
+func getAllAccessorDeclarations(members []*ast.Node, accessor *ast.AccessorDeclaration) allAccessorDeclarations {
+	var result allAccessorDeclarations
+	if ast.HasDynamicName(accessor) {
+		result.firstAccessor = accessor
+		if ast.IsGetAccessorDeclaration(accessor) {
+			result.getAccessor = accessor
+		} else {
+			result.setAccessor = accessor
+		}
+		return result
+	}
+	name := ast.GetTextOfPropertyName(accessor.Name())
+	for _, member := range members {
+		if !ast.IsAccessor(member) || ast.IsStatic(member) != ast.IsStatic(accessor) || ast.HasDynamicName(member) || ast.GetTextOfPropertyName(member.Name()) != name {
+			continue
+		}
+		if result.firstAccessor == nil {
+			result.firstAccessor = member
+		}
+		if ast.IsGetAccessorDeclaration(member) && result.getAccessor == nil {
+			result.getAccessor = member
+		}
+		if ast.IsSetAccessorDeclaration(member) && result.setAccessor == nil {
+			result.setAccessor = member
+		}
+	}
+	return result
+}
+
+func (tx *es2015Transformer) transformAccessorsToStatement(receiver *ast.Expression, accessors allAccessorDeclarations, container *ast.Node) *ast.Statement {
+	statement := tx.Factory().NewExpressionStatement(tx.transformAccessorsToExpression(receiver, accessors, container, false /*startsOnNewLine*/))
+	// The location for the statement is used to emit source maps only. No comments should be emitted for this
+	// statement to align with the old emitter.
+	tx.EmitContext().SetEmitFlags(statement, printer.EFNoComments)
+	tx.EmitContext().SetSourceMapRange(statement, tx.EmitContext().SourceMapRange(accessors.firstAccessor))
+	return statement
+}
+
+// Object.defineProperty(C.prototype, "p", { get: function () {}, set: function (v) {}, enumerable: false, configurable: true })
+func (tx *es2015Transformer) transformAccessorsToExpression(receiver *ast.Expression, accessors allAccessorDeclarations, container *ast.Node, startsOnNewLine bool) *ast.Expression {
+	f := tx.Factory()
+
+	// To align with source maps in the old emitter, the receiver and property name arguments are both mapped
+	// contiguously to the accessor name.
+	target := receiver.Clone(f)
+	target.Loc = receiver.Loc
+	tx.EmitContext().SetEmitFlags(target, printer.EFNoComments|printer.EFNoTrailingSourceMap)
+	tx.EmitContext().SetSourceMapRange(target, accessors.firstAccessor.Name().Loc)
+
+	propertyName := tx.createExpressionForPropertyName(tx.Visitor().VisitNode(accessors.firstAccessor.Name()))
+	tx.EmitContext().SetEmitFlags(propertyName, printer.EFNoComments|printer.EFNoLeadingSourceMap)
+	tx.EmitContext().SetSourceMapRange(propertyName, accessors.firstAccessor.Name().Loc)
+
+	var properties []*ast.Node
+	addAccessor := func(name string, accessor *ast.AccessorDeclaration) {
+		function := tx.transformFunctionLikeToExpression(accessor, core.UndefinedTextRange(), nil /*name*/, container)
+		tx.EmitContext().SetSourceMapRange(function, tx.EmitContext().SourceMapRange(accessor))
+		tx.EmitContext().SetEmitFlags(function, printer.EFNoLeadingComments)
+		property := f.NewPropertyAssignment(nil /*modifiers*/, f.NewIdentifier(name), nil /*postfixToken*/, nil /*typeNode*/, function)
+		tx.EmitContext().SetCommentRange(property, tx.EmitContext().CommentRange(accessor))
+		properties = append(properties, property)
+	}
+	if accessors.getAccessor != nil {
+		addAccessor("get", accessors.getAccessor)
+	}
+	if accessors.setAccessor != nil {
+		addAccessor("set", accessors.setAccessor)
+	}
+	properties = append(properties,
+		f.NewPropertyAssignment(nil /*modifiers*/, f.NewIdentifier("enumerable"), nil /*postfixToken*/, nil /*typeNode*/, f.NewFalseExpression()),
+		f.NewPropertyAssignment(nil /*modifiers*/, f.NewIdentifier("configurable"), nil /*postfixToken*/, nil /*typeNode*/, f.NewTrueExpression()),
+	)
+
+	call := f.NewObjectDefinePropertyCall(target, propertyName, f.NewObjectLiteralExpression(f.NewNodeList(properties), true /*multiLine*/))
+	if startsOnNewLine {
+		tx.EmitContext().AddEmitFlags(call, printer.EFStartOnNewLine)
+	}
+	return call
+}
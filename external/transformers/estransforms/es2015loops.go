@@ -0,0 +1,1229 @@
+package estransforms
+
+import (
+	"slices"
+
+	"github.com/pagpeter/typescript-go/external/ast"
+	"github.com/pagpeter/typescript-go/external/core"
+	"github.com/pagpeter/typescript-go/external/printer"
+)
+
+type jump int
+
+const (
+	jumpBreak    jump = 1 << 1
+	jumpContinue jump = 1 << 2
+	jumpReturn   jump = 1 << 3
+)
+
+type loopOutParameterFlags int
+
+const (
+	loopOutParameterFlagsNone        loopOutParameterFlags = 0
+	loopOutParameterFlagsBody        loopOutParameterFlags = 1 << 0 // Modified in the body of the iteration statement
+	loopOutParameterFlagsInitializer loopOutParameterFlags = 1 << 1 // Set in the initializer of a ForStatement
+)
+
+type copyDirection int
+
+const (
+	copyDirectionToOriginal copyDirection = iota
+	copyDirectionToOutParameter
+)
+
+// A binding declared in the initializer of a loop whose value must be copied out of the function created for the
+// body (or initializer) of the loop.
+type loopOutParameter struct {
+	flags        loopOutParameterFlags
+	originalName *ast.IdentifierNode
+	outParamName *ast.IdentifierNode
+}
+
+// The state of a loop whose body is being converted into a function, because it captures a block-scoped binding
+// declared within the loop.
+type convertedLoopState struct {
+	// set of labels that occurred inside the converted loop, used to determine if a labeled jump can be emitted as is
+	// or should be dispatched to the calling code as a value
+	labels map[string]bool
+
+	// collection of labeled jumps that transfer control outside the converted loop, maps the label text to the
+	// string marker returned from the loop body function
+	labeledNonLocalBreaks    map[string]string
+	labeledNonLocalContinues map[string]string
+
+	// the kinds of non-labeled jumps that transfer control outside the converted loop
+	nonLocalJumps jump
+
+	// the kinds of non-labeled jumps that are allowed inside the converted loop
+	allowedNonLabeledJumps jump
+
+	// alias for the `arguments` object of the enclosing function, used when `arguments` is referenced in the loop body
+	argumentsName *ast.IdentifierNode
+
+	// alias for the `this` of the enclosing function, used when `this` is referenced in the loop body
+	thisName *ast.IdentifierNode
+
+	// `var` declarations in the loop body, which must be hoisted out of the function created for the loop body
+	hoistedLocalVariables []*ast.IdentifierNode
+
+	// tracks whether the incrementor of a `for` loop should be evaluated, when its condition and incrementor are
+	// moved into the function created for the loop body
+	conditionVariable *ast.IdentifierNode
+
+	loopParameters    []*ast.Node
+	loopOutParameters []loopOutParameter
+}
+
+func (state *convertedLoopState) setLabeledJump(isBreak bool, labelText string, labelMarker string) {
+	if isBreak {
+		if state.labeledNonLocalBreaks == nil {
+			state.labeledNonLocalBreaks = make(map[string]string)
+		}
+		state.labeledNonLocalBreaks[labelText] = labelMarker
+	} else {
+		if state.labeledNonLocalContinues == nil {
+			state.labeledNonLocalContinues = make(map[string]string)
+		}
+		state.labeledNonLocalContinues[labelText] = labelMarker
+	}
+}
+
+//
+// Block scoping
+//
+
+func (tx *es2015Transformer) visitVariableStatement(node *ast.VariableStatement) *ast.Node {
+	var ancestorFacts hierarchyFacts
+	if ast.HasSyntacticModifier(node.AsNode(), ast.ModifierFlagsExport) {
+		ancestorFacts = tx.enterSubtree(hierarchyFactsNone, hierarchyFactsExportedVariableStatement)
+	} else {
+		ancestorFacts = tx.enterSubtree(hierarchyFactsNone, hierarchyFactsNone)
+	}
+	var updated *ast.Node
+	if tx.convertedLoopState != nil && node.DeclarationList.Flags&ast.NodeFlagsBlockScoped == 0 {
+		// we are inside a converted loop - hoist variable declarations
+		f := tx.Factory()
+		var assignments []*ast.Expression
+		for _, declaration := range node.DeclarationList.AsVariableDeclarationList().Declarations.Nodes {
+			tx.hoistVariableDeclarationDeclaredInConvertedLoop(declaration.Name())
+			if declaration.Initializer() == nil {
+				continue
+			}
+			if ast.IsBindingPattern(declaration.Name()) {
+				for _, flattened := range tx.flattenBindingDeclaration(declaration, nil /*rval*/, true /*hoistTempVariables*/) {
+					assignment := f.NewAssignmentExpression(flattened.Name(), flattened.Initializer())
+					assignment.Loc = flattened.Loc
+					assignments = append(assignments, assignment)
+				}
+			} else {
+				assignment := f.NewAssignmentExpression(tx.Visitor().VisitNode(declaration.Name()), tx.Visitor().VisitNode(declaration.Initializer()))
+				assignment.Loc = declaration.Loc
+				assignments = append(assignments, assignment)
+			}
+		}
+		if len(assignments) > 0 {
+			updated = f.NewExpressionStatement(f.InlineExpressions(assignments))
+			updated.Loc = node.Loc
+		}
+		// otherwise, none of declarations has initializer - the entire variable statement can be deleted
+	} else {
+		updated = tx.Visitor().VisitEachChild(node.AsNode())
+	}
+	tx.exitSubtree(ancestorFacts, hierarchyFactsNone, hierarchyFactsNone)
+	return updated
+}
+
+func (tx *es2015Transformer) hoistVariableDeclarationDeclaredInConvertedLoop(name *ast.Node) {
+	if ast.IsIdentifier(name) {
+		tx.convertedLoopState.hoistedLocalVariables = append(tx.convertedLoopState.hoistedLocalVariables, tx.getBindingName(name))
+		return
+	}
+	for _, element := range name.AsBindingPattern().Elements.Nodes {
+		if !ast.IsOmittedExpression(element) {
+			tx.hoistVariableDeclarationDeclaredInConvertedLoop(element.Name())
+		}
+	}
+}
+
+// Lowers `let` and `const` declarations to `var`, flattening any binding patterns.
+func (tx *es2015Transformer) visitVariableDeclarationList(node *ast.VariableDeclarationList) *ast.Node {
+	if node.Flags&ast.NodeFlagsBlockScoped == 0 && !core.Some(node.Declarations.Nodes, func(declaration *ast.Node) bool { return ast.IsBindingPattern(declaration.Name()) }) {
+		return tx.Visitor().VisitEachChild(node.AsNode())
+	}
+
+	f := tx.Factory()
+	var declarations []*ast.Node
+	for _, declaration := range node.Declarations.Nodes {
+		if node.Flags&ast.NodeFlagsLet != 0 {
+			declarations = append(declarations, tx.visitVariableDeclarationInLetDeclarationList(declaration.AsVariableDeclaration())...)
+		} else {
+			declarations = append(declarations, tx.visitVariableDeclarationWorker(declaration.AsVariableDeclaration())...)
+		}
+	}
+	declarationList := f.NewVariableDeclarationList(ast.NodeFlagsNone, f.NewNodeList(declarations))
+	declarationList.Loc = node.Loc
+	tx.EmitContext().SetOriginal(declarationList, node.AsNode())
+	tx.EmitContext().SetCommentRange(declarationList, node.Loc)
+
+	// If the first or last declaration is a binding pattern, we need to modify the source map range for the
+	// declaration list.
+	if len(declarations) > 0 && (ast.IsBindingPattern(node.Declarations.Nodes[0].Name()) || ast.IsBindingPattern(node.Declarations.Nodes[len(node.Declarations.Nodes)-1].Name())) {
+		tx.EmitContext().SetSourceMapRange(declarationList, core.NewTextRange(declarations[0].Pos(), declarations[len(declarations)-1].End()))
+	}
+	return declarationList
+}
+
+func (tx *es2015Transformer) visitVariableDeclarationInLetDeclarationList(node *ast.VariableDeclaration) []*ast.Node {
+	if ast.IsBindingPattern(node.Name()) {
+		return tx.visitVariableDeclarationWorker(node)
+	}
+	if node.Initializer == nil && tx.shouldEmitExplicitInitializerForLetDeclaration(node) {
+		return []*ast.Node{tx.Factory().UpdateVariableDeclaration(node, tx.Visitor().VisitNode(node.Name()), nil /*exclamationToken*/, nil /*typeNode*/, tx.Factory().NewVoidZeroExpression())}
+	}
+	return []*ast.Node{tx.Visitor().VisitEachChild(node.AsNode())}
+}
+
+// Gets whether a `let` declaration without an initializer must be given an explicit `void 0` initializer when lowered
+// to `var`, since a `var` declaration in a loop would otherwise retain its value from a previous iteration:
+//
+//	for (;;) { let x; } -> for (;;) { var x = void 0; }
+func (tx *es2015Transformer) shouldEmitExplicitInitializerForLetDeclaration(node *ast.VariableDeclaration) bool {
+	original := tx.EmitContext().ParseNode(node.AsNode())
+	if original == nil {
+		return false
+	}
+	flags := tx.bindings.flags(original)
+	isCapturedInFunction := flags&blockScopedBindingFlagsCaptured != 0
+	isDeclaredInLoop := flags&blockScopedBindingFlagsInLoop != 0
+	emittedAsTopLevel := tx.hierarchyFacts&hierarchyFactsTopLevel != 0 ||
+		isCapturedInFunction && isDeclaredInLoop && tx.hierarchyFacts&hierarchyFactsIterationStatementBlock != 0
+	return !emittedAsTopLevel &&
+		tx.hierarchyFacts&hierarchyFactsForInOrForOfStatement == 0 &&
+		(!tx.bindings.isDeclarationWithCollidingName(original) ||
+			isDeclaredInLoop && !isCapturedInFunction && tx.hierarchyFacts&(hierarchyFactsForStatement|hierarchyFactsForInOrForOfStatement) == 0)
+}
+
+func (tx *es2015Transformer) visitVariableDeclaration(node *ast.VariableDeclaration) *ast.Node {
+	f := tx.Factory()
+	declarations := tx.visitVariableDeclarationWorker(node)
+	if len(declarations) == 1 {
+		return declarations[0]
+	}
+	return f.NewSyntaxList(declarations)
+}
+
+func (tx *es2015Transformer) visitVariableDeclarationWorker(node *ast.VariableDeclaration) []*ast.Node {
+	ancestorFacts := tx.enterSubtree(hierarchyFactsExportedVariableStatement, hierarchyFactsNone)
+	var updated []*ast.Node
+	if ast.IsBindingPattern(node.Name()) {
+		updated = tx.flattenBindingDeclaration(node.AsNode(), nil /*rval*/, ancestorFacts&hierarchyFactsExportedVariableStatement != 0)
+	} else {
+		updated = []*ast.Node{tx.Visitor().VisitEachChild(node.AsNode())}
+	}
+	tx.exitSubtree(ancestorFacts, hierarchyFactsNone, hierarchyFactsNone)
+	return updated
+}
+
+func (tx *es2015Transformer) visitBlock(node *ast.Block) *ast.Node {
+	var ancestorFacts hierarchyFacts
+	if tx.hierarchyFacts&hierarchyFactsIterationStatement != 0 {
+		ancestorFacts = tx.enterSubtree(hierarchyFactsIterationStatementBlockExcludes, hierarchyFactsIterationStatementBlockIncludes)
+	} else {
+		ancestorFacts = tx.enterSubtree(hierarchyFactsBlockExcludes, hierarchyFactsBlockIncludes)
+	}
+	updated := tx.Visitor().VisitEachChild(node.AsNode())
+	tx.exitSubtree(ancestorFacts, hierarchyFactsNone, hierarchyFactsNone)
+	return updated
+}
+
+func (tx *es2015Transformer) visitSwitchStatement(node *ast.SwitchStatement) *ast.Node {
+	if tx.convertedLoopState != nil {
+		savedAllowedNonLabeledJumps := tx.convertedLoopState.allowedNonLabeledJumps
+		// for switch statement allow only non-labeled break
+		tx.convertedLoopState.allowedNonLabeledJumps |= jumpBreak
+		result := tx.Visitor().VisitEachChild(node.AsNode())
+		tx.convertedLoopState.allowedNonLabeledJumps = savedAllowedNonLabeledJumps
+		return result
+	}
+	return tx.Visitor().VisitEachChild(node.AsNode())
+}
+
+func (tx *es2015Transformer) visitCaseBlock(node *ast.CaseBlock) *ast.Node {
+	ancestorFacts := tx.enterSubtree(hierarchyFactsBlockScopeExcludes, hierarchyFactsBlockScopeIncludes)
+	updated := tx.Visitor().VisitEachChild(node.AsNode())
+	tx.exitSubtree(ancestorFacts, hierarchyFactsNone, hierarchyFactsNone)
+	return updated
+}
+
+// Moves a binding pattern in a catch clause into the catch block:
+//
+//	catch ({ message }) {} -> catch (_a) { var message = _a.message; }
+func (tx *es2015Transformer) visitCatchClause(node *ast.CatchClause) *ast.Node {
+	ancestorFacts := tx.enterSubtree(hierarchyFactsBlockScopeExcludes, hierarchyFactsBlockScopeIncludes)
+	var updated *ast.Node
+	if node.VariableDeclaration != nil && ast.IsBindingPattern(node.VariableDeclaration.Name()) {
+		f := tx.Factory()
+		temp := f.NewTempVariable()
+		newVariableDeclaration := f.NewVariableDeclaration(temp, nil /*exclamationToken*/, nil /*typeNode*/, nil /*initializer*/)
+		newVariableDeclaration.Loc = node.VariableDeclaration.Loc
+		declarations := tx.flattenBindingDeclaration(node.VariableDeclaration, temp.Clone(f), false /*hoistTempVariables*/)
+		list := f.NewVariableDeclarationList(ast.NodeFlagsNone, f.NewNodeList(declarations))
+		list.Loc = node.VariableDeclaration.Loc
+		destructure := f.NewVariableStatement(nil /*modifiers*/, list)
+		block := tx.Visitor().VisitNode(node.Block).AsBlock()
+		statements := f.NewNodeList(append([]*ast.Statement{destructure}, block.Statements.Nodes...))
+		statements.Loc = block.Statements.Loc
+		updated = f.UpdateCatchClause(node, newVariableDeclaration, f.UpdateBlock(block, statements))
+	} else {
+		updated = tx.Visitor().VisitEachChild(node.AsNode())
+	}
+	tx.exitSubtree(ancestorFacts, hierarchyFactsNone, hierarchyFactsNone)
+	return updated
+}
+
+//
+// Labels and jumps
+//
+
+// Converts a `break` or `continue` that transfers control out of a converted loop body into a `return` of a marker
+// that is dispatched on by the caller of the loop body.
+func (tx *es2015Transformer) visitBreakOrContinueStatement(node *ast.Node) *ast.Node {
+	if tx.convertedLoopState != nil {
+		state := tx.convertedLoopState
+		isBreak := node.Kind == ast.KindBreakStatement
+		j := core.IfElse(isBreak, jumpBreak, jumpContinue)
+		label := node.Label()
+		canUseBreakOrContinue := label != nil && state.labels[label.Text()] || label == nil && state.allowedNonLabeledJumps&j != 0
+		if !canUseBreakOrContinue {
+			var labelMarker string
+			if label == nil {
+				state.nonLocalJumps |= j
+				// note: return value is emitted only to simplify debugging, call to converted loop body does not do any
+				// dispatching on it.
+				labelMarker = core.IfElse(isBreak, "break", "continue")
+			} else {
+				labelMarker = core.IfElse(isBreak, "break-", "continue-") + label.Text()
+				state.setLabeledJump(isBreak, label.Text(), labelMarker)
+			}
+
+			f := tx.Factory()
+			returnExpression := f.NewStringLiteral(labelMarker)
+			if len(state.loopOutParameters) > 0 {
+				var expressions []*ast.Expression
+				for _, outParam := range state.loopOutParameters {
+					expressions = append(expressions, tx.copyOutParameter(outParam, copyDirectionToOutParameter))
+				}
+				returnExpression = f.NewCommaExpression(f.InlineExpressions(expressions), returnExpression)
+			}
+			return f.NewReturnStatement(returnExpression)
+		}
+	}
+	return tx.Visitor().VisitEachChild(node)
+}
+
+func (tx *es2015Transformer) visitLabeledStatement(node *ast.LabeledStatement) *ast.Node {
+	if tx.convertedLoopState != nil && tx.convertedLoopState.labels == nil {
+		tx.convertedLoopState.labels = make(map[string]bool)
+	}
+	statement := node.AsNode()
+	for ast.IsLabeledStatement(statement) {
+		if tx.convertedLoopState != nil {
+			tx.convertedLoopState.labels[statement.Label().Text()] = true
+		}
+		statement = statement.AsLabeledStatement().Statement
+	}
+
+	var result *ast.Node
+	if ast.IsIterationStatement(statement, false /*lookInLabeledStatements*/) {
+		result = tx.visitIterationStatement(statement, node)
+	} else {
+		result = tx.restoreEnclosingLabel(tx.Visitor().VisitEmbeddedStatement(statement), node)
+	}
+	return result
+}
+
+// Reapplies the labels of a chain of labeled statements to a statement, and marks the labels as no longer in scope
+// within the current converted loop.
+func (tx *es2015Transformer) restoreEnclosingLabel(node *ast.Statement, outermostLabeledStatement *ast.LabeledStatement) *ast.Statement {
+	if tx.convertedLoopState != nil {
+		for label := outermostLabeledStatement; label != nil; {
+			tx.convertedLoopState.labels[label.Label.Text()] = false
+			if !ast.IsLabeledStatement(label.Statement) {
+				break
+			}
+			label = label.Statement.AsLabeledStatement()
+		}
+	}
+	return restoreEnclosingLabel(tx.Factory(), node, outermostLabeledStatement)
+}
+
+//
+// Iteration statements
+//
+
+type iterationStatementConverter func(node *ast.Node, outermostLabeledStatement *ast.LabeledStatement, convertedLoopBodyStatements []*ast.Statement, ancestorFacts hierarchyFacts) *ast.Statement
+
+func (tx *es2015Transformer) visitIterationStatement(node *ast.Node, outermostLabeledStatement *ast.LabeledStatement) *ast.Node {
+	switch node.Kind {
+	case ast.KindDoStatement, ast.KindWhileStatement:
+		return tx.visitDoOrWhileStatement(node, outermostLabeledStatement)
+	case ast.KindForStatement:
+		return tx.visitForStatement(node.AsForStatement(), outermostLabeledStatement)
+	case ast.KindForInStatement:
+		return tx.visitForInStatement(node.AsForInOrOfStatement(), outermostLabeledStatement)
+	case ast.KindForOfStatement:
+		return tx.visitForOfStatement(node.AsForInOrOfStatement(), outermostLabeledStatement)
+	default:
+		panic("Unexpected node kind: " + node.Kind.String())
+	}
+}
+
+func (tx *es2015Transformer) visitIterationStatementWithFacts(excludeFacts hierarchyFacts, includeFacts hierarchyFacts, node *ast.Node, outermostLabeledStatement *ast.LabeledStatement, convert iterationStatementConverter) *ast.Node {
+	ancestorFacts := tx.enterSubtree(excludeFacts, includeFacts)
+	updated := tx.convertIterationStatementBodyIfNecessary(node, outermostLabeledStatement, ancestorFacts, convert)
+	tx.exitSubtree(ancestorFacts, hierarchyFactsNone, hierarchyFactsNone)
+	return updated
+}
+
+func (tx *es2015Transformer) visitDoOrWhileStatement(node *ast.Node, outermostLabeledStatement *ast.LabeledStatement) *ast.Node {
+	return tx.visitIterationStatementWithFacts(hierarchyFactsDoOrWhileStatementExcludes, hierarchyFactsDoOrWhileStatementIncludes, node, outermostLabeledStatement, nil /*convert*/)
+}
+
+func (tx *es2015Transformer) visitForStatement(node *ast.ForStatement, outermostLabeledStatement *ast.LabeledStatement) *ast.Node {
+	return tx.visitIterationStatementWithFacts(hierarchyFactsForStatementExcludes, hierarchyFactsForStatementIncludes, node.AsNode(), outermostLabeledStatement, nil /*convert*/)
+}
+
+func (tx *es2015Transformer) visitForInStatement(node *ast.ForInOrOfStatement, outermostLabeledStatement *ast.LabeledStatement) *ast.Node {
+	return tx.visitIterationStatementWithFacts(hierarchyFactsForInOrForOfStatementExcludes, hierarchyFactsForInOrForOfStatementIncludes, node.AsNode(), outermostLabeledStatement, nil /*convert*/)
+}
+
+func (tx *es2015Transformer) visitForOfStatement(node *ast.ForInOrOfStatement, outermostLabeledStatement *ast.LabeledStatement) *ast.Node {
+	convert := tx.convertForOfStatementForArray
+	if tx.compilerOptions.DownlevelIteration.IsTrue() {
+		convert = tx.convertForOfStatementForIterable
+	}
+	return tx.visitIterationStatementWithFacts(hierarchyFactsForInOrForOfStatementExcludes, hierarchyFactsForInOrForOfStatementIncludes, node.AsNode(), outermostLabeledStatement, convert)
+}
+
+func (tx *es2015Transformer) visitEachChildOfForStatement(node *ast.ForStatement) *ast.Node {
+	return tx.Factory().UpdateForStatement(
+		node,
+		tx.discardedValueVisitor.VisitNode(node.Initializer),
+		tx.Visitor().VisitNode(node.Condition),
+		tx.discardedValueVisitor.VisitNode(node.Incrementor),
+		tx.Visitor().VisitEmbeddedStatement(node.Statement),
+	)
+}
+
+// Gets whether a part of a `for` statement (or the body of any iteration statement) captures a block-scoped binding
+// declared in the loop.
+func (tx *es2015Transformer) shouldConvertPartOfIterationStatement(node *ast.Node) bool {
+	return node != nil && tx.bindings.isPartWithCapturedBinding(tx.EmitContext().ParseNode(node))
+}
+
+func (tx *es2015Transformer) shouldConvertInitializerOfForStatement(node *ast.Node) bool {
+	return ast.IsForStatement(node) && tx.shouldConvertPartOfIterationStatement(node.AsForStatement().Initializer)
+}
+
+func (tx *es2015Transformer) shouldConvertConditionOfForStatement(node *ast.Node) bool {
+	return ast.IsForStatement(node) && tx.shouldConvertPartOfIterationStatement(node.AsForStatement().Condition)
+}
+
+func (tx *es2015Transformer) shouldConvertIncrementorOfForStatement(node *ast.Node) bool {
+	return ast.IsForStatement(node) && tx.shouldConvertPartOfIterationStatement(node.AsForStatement().Incrementor)
+}
+
+func (tx *es2015Transformer) shouldConvertBodyOfIterationStatement(node *ast.Node) bool {
+	return tx.bindings.isLoopWithCapturedBinding(tx.EmitContext().ParseNode(node))
+}
+
+func (tx *es2015Transformer) shouldConvertIterationStatement(node *ast.Node) bool {
+	return tx.shouldConvertBodyOfIterationStatement(node) || tx.shouldConvertInitializerOfForStatement(node)
+}
+
+// Converts the body of an iteration statement into a function when the body captures a block-scoped binding declared
+// in the loop, so that each iteration gets its own copy of the binding:
+//
+//	for (let i = 0; i < 2; i++) {
+//	    setImmediate(() => console.log(i));
+//	}
+//
+// becomes
+//
+//	var _loop_1 = function (i) {
+//	    setImmediate(function () { return console.log(i); });
+//	};
+//	for (var i = 0; i < 2; i++) {
+//	    _loop_1(i);
+//	}
+func (tx *es2015Transformer) convertIterationStatementBodyIfNecessary(node *ast.Node, outermostLabeledStatement *ast.LabeledStatement, ancestorFacts hierarchyFacts, convert iterationStatementConverter) *ast.Node {
+	f := tx.Factory()
+	if !tx.shouldConvertIterationStatement(node) {
+		var savedAllowedNonLabeledJumps jump
+		if tx.convertedLoopState != nil {
+			// we get here if we are trying to emit normal loop loop inside converted loop
+			// set allowedNonLabeledJumps to Break | Continue to mark that break\continue inside the loop should be
+			// emitted as is
+			savedAllowedNonLabeledJumps = tx.convertedLoopState.allowedNonLabeledJumps
+			tx.convertedLoopState.allowedNonLabeledJumps = jumpBreak | jumpContinue
+		}
+		var result *ast.Statement
+		if convert != nil {
+			result = convert(node, outermostLabeledStatement, nil /*convertedLoopBodyStatements*/, ancestorFacts)
+		} else if ast.IsForStatement(node) {
+			result = tx.restoreEnclosingLabel(tx.visitEachChildOfForStatement(node.AsForStatement()), outermostLabeledStatement)
+		} else {
+			result = tx.restoreEnclosingLabel(tx.Visitor().VisitEachChild(node), outermostLabeledStatement)
+		}
+		if tx.convertedLoopState != nil {
+			tx.convertedLoopState.allowedNonLabeledJumps = savedAllowedNonLabeledJumps
+		}
+		return result
+	}
+
+	currentState := tx.createConvertedLoopState(node)
+	var statements []*ast.Statement
+
+	outerConvertedLoopState := tx.convertedLoopState
+	tx.convertedLoopState = currentState
+
+	var initializerFunction *iterationStatementPartFunction
+	var bodyFunction *iterationStatementPartFunction
+	if tx.shouldConvertInitializerOfForStatement(node) {
+		initializerFunction = tx.createFunctionForInitializerOfForStatement(node.AsForStatement(), currentState)
+	}
+	if tx.shouldConvertBodyOfIterationStatement(node) {
+		bodyFunction = tx.createFunctionForBodyOfIterationStatement(node, currentState, outerConvertedLoopState)
+	}
+
+	tx.convertedLoopState = outerConvertedLoopState
+
+	if initializerFunction != nil {
+		statements = append(statements, initializerFunction.functionDeclaration)
+	}
+	if bodyFunction != nil {
+		statements = append(statements, bodyFunction.functionDeclaration)
+	}
+
+	statements = tx.addExtraDeclarationsForConvertedLoop(statements, currentState, outerConvertedLoopState)
+
+	if initializerFunction != nil {
+		statements = append(statements, tx.generateCallToConvertedLoopInitializer(initializerFunction.functionName, initializerFunction.containsYield))
+	}
+
+	var loop *ast.Statement
+	if bodyFunction != nil {
+		if convert != nil {
+			loop = convert(node, outermostLabeledStatement, bodyFunction.bodyPart, ancestorFacts)
+		} else {
+			clone := tx.convertIterationStatementCore(node, initializerFunction, f.NewBlock(f.NewNodeList(bodyFunction.bodyPart), true /*multiLine*/))
+			loop = tx.restoreEnclosingLabel(clone, outermostLabeledStatement)
+		}
+	} else {
+		clone := tx.convertIterationStatementCore(node, initializerFunction, tx.Visitor().VisitEmbeddedStatement(node.Statement()))
+		loop = tx.restoreEnclosingLabel(clone, outermostLabeledStatement)
+	}
+
+	statements = append(statements, loop)
+	return f.NewSyntaxList(statements)
+}
+
+func (tx *es2015Transformer) convertIterationStatementCore(node *ast.Node, initializerFunction *iterationStatementPartFunction, convertedLoopBody *ast.Statement) *ast.Statement {
+	f := tx.Factory()
+	switch node.Kind {
+	case ast.KindForStatement:
+		forStatement := node.AsForStatement()
+		shouldConvertCondition := tx.shouldConvertPartOfIterationStatement(forStatement.Condition)
+		shouldConvertIncrementor := shouldConvertCondition || tx.shouldConvertPartOfIterationStatement(forStatement.Incrementor)
+		initializer := forStatement.Initializer
+		if initializerFunction != nil {
+			initializer = initializerFunction.initializerPart
+		}
+		var condition, incrementor *ast.Expression
+		if !shouldConvertCondition {
+			condition = tx.Visitor().VisitNode(forStatement.Condition)
+		}
+		if !shouldConvertIncrementor {
+			incrementor = tx.discardedValueVisitor.VisitNode(forStatement.Incrementor)
+		}
+		return f.UpdateForStatement(forStatement, tx.discardedValueVisitor.VisitNode(initializer), condition, incrementor, convertedLoopBody)
+	case ast.KindForInStatement, ast.KindForOfStatement:
+		forInOrOfStatement := node.AsForInOrOfStatement()
+		return f.UpdateForInOrOfStatement(forInOrOfStatement, nil /*awaitModifier*/, tx.Visitor().VisitNode(forInOrOfStatement.Initializer), tx.Visitor().VisitNode(forInOrOfStatement.Expression), convertedLoopBody)
+	case ast.KindDoStatement:
+		return f.UpdateDoStatement(node.AsDoStatement(), convertedLoopBody, tx.Visitor().VisitNode(node.Expression()))
+	case ast.KindWhileStatement:
+		return f.UpdateWhileStatement(node.AsWhileStatement(), tx.Visitor().VisitNode(node.Expression()), convertedLoopBody)
+	default:
+		panic("Unexpected node kind: " + node.Kind.String())
+	}
+}
+
+func (tx *es2015Transformer) createConvertedLoopState(node *ast.Node) *convertedLoopState {
+	var loopInitializer *ast.Node
+	switch node.Kind {
+	case ast.KindForStatement, ast.KindForInStatement, ast.KindForOfStatement:
+		if initializer := node.Initializer(); initializer != nil && ast.IsVariableDeclarationList(initializer) {
+			loopInitializer = initializer
+		}
+	}
+
+	currentState := &convertedLoopState{}
+	if loopInitializer != nil && ast.GetCombinedNodeFlags(loopInitializer)&ast.NodeFlagsBlockScoped != 0 {
+		hasCapturedBindingsInForHead := tx.shouldConvertInitializerOfForStatement(node) ||
+			tx.shouldConvertConditionOfForStatement(node) ||
+			tx.shouldConvertIncrementorOfForStatement(node)
+		for _, declaration := range loopInitializer.AsVariableDeclarationList().Declarations.Nodes {
+			tx.processLoopVariableDeclaration(node, declaration, currentState, hasCapturedBindingsInForHead)
+		}
+	}
+
+	if tx.convertedLoopState != nil {
+		// this converted loop is nested in another converted loop. If the outer converted loop has already
+		// accumulated some state, pass it through.
+		if tx.convertedLoopState.argumentsName != nil {
+			// outer loop has already used 'arguments' so we've already have some name to alias it
+			// use the same name in all nested loops
+			currentState.argumentsName = tx.convertedLoopState.argumentsName
+		}
+		if tx.convertedLoopState.thisName != nil {
+			// outer loop has already used 'this' so we've already have some name to alias it
+			// use the same name in all nested loops
+			currentState.thisName = tx.convertedLoopState.thisName
+		}
+		if tx.convertedLoopState.hoistedLocalVariables != nil {
+			// we've already collected some non-block scoped variable declarations in enclosing loop
+			// use the same storage in nested loop
+			currentState.hoistedLocalVariables = tx.convertedLoopState.hoistedLocalVariables
+		}
+	}
+	return currentState
+}
+
+func (tx *es2015Transformer) processLoopVariableDeclaration(container *ast.Node, declaration *ast.Node, state *convertedLoopState, hasCapturedBindingsInForHead bool) {
+	f := tx.Factory()
+	name := declaration.Name()
+	if ast.IsBindingPattern(name) {
+		for _, element := range name.AsBindingPattern().Elements.Nodes {
+			if !ast.IsOmittedExpression(element) {
+				tx.processLoopVariableDeclaration(container, element, state, hasCapturedBindingsInForHead)
+			}
+		}
+		return
+	}
+
+	bindingName := tx.getBindingName(name)
+	state.loopParameters = append(state.loopParameters, f.NewParameterDeclaration(nil /*modifiers*/, nil /*dotDotDotToken*/, bindingName, nil /*questionToken*/, nil /*typeNode*/, nil /*initializer*/))
+	original := tx.EmitContext().ParseNode(declaration)
+	needsOutParam := original != nil && tx.bindings.flags(original)&blockScopedBindingFlagsNeedsLoopOutParameter != 0
+	if needsOutParam || hasCapturedBindingsInForHead {
+		outParamName := f.NewUniqueName("out_" + name.Text())
+		flags := loopOutParameterFlagsNone
+		if needsOutParam {
+			flags |= loopOutParameterFlagsBody
+		}
+		if ast.IsForStatement(container) && original != nil {
+			forStatement := container.AsForStatement()
+			if tx.isBindingCapturedByNode(forStatement.Initializer, original) {
+				flags |= loopOutParameterFlagsInitializer
+			}
+			if tx.isBindingCapturedByNode(forStatement.Condition, original) || tx.isBindingCapturedByNode(forStatement.Incrementor, original) {
+				flags |= loopOutParameterFlagsBody
+			}
+		}
+		state.loopOutParameters = append(state.loopOutParameters, loopOutParameter{flags: flags, originalName: bindingName, outParamName: outParamName})
+	}
+}
+
+func (tx *es2015Transformer) isBindingCapturedByNode(node *ast.Node, declaration *ast.Node) bool {
+	return node != nil && tx.bindings.isBindingCapturedByNode(tx.EmitContext().ParseNode(node), declaration)
+}
+
+// Adds the declarations needed by a converted loop, or passes them to the enclosing converted loop.
+func (tx *es2015Transformer) addExtraDeclarationsForConvertedLoop(statements []*ast.Statement, state *convertedLoopState, outerState *convertedLoopState) []*ast.Statement {
+	f := tx.Factory()
+	var extraVariableDeclarations []*ast.Node
+
+	// propagate state from the inner loop to the outer loop if necessary
+	if state.argumentsName != nil {
+		if outerState != nil {
+			// pass it to outer converted loop
+			outerState.argumentsName = state.argumentsName
+		} else {
+			// this is top level converted loop and we need to create an alias for 'arguments' object
+			extraVariableDeclarations = append(extraVariableDeclarations, f.NewVariableDeclaration(state.argumentsName, nil /*exclamationToken*/, nil /*typeNode*/, f.NewIdentifier("arguments")))
+		}
+	}
+	if state.thisName != nil {
+		if outerState != nil {
+			// pass it to outer converted loop
+			outerState.thisName = state.thisName
+		} else {
+			// this is top level converted loop so we need to create an alias for 'this' here
+			extraVariableDeclarations = append(extraVariableDeclarations, f.NewVariableDeclaration(state.thisName, nil /*exclamationToken*/, nil /*typeNode*/, f.NewThisExpression()))
+		}
+	}
+	if state.hoistedLocalVariables != nil {
+		if outerState != nil {
+			// pass them to outer converted loop
+			outerState.hoistedLocalVariables = state.hoistedLocalVariables
+		} else {
+			// hoist collected variable declarations
+			for _, identifier := range state.hoistedLocalVariables {
+				extraVariableDeclarations = append(extraVariableDeclarations, f.NewVariableDeclaration(identifier, nil /*exclamationToken*/, nil /*typeNode*/, nil /*initializer*/))
+			}
+		}
+	}
+
+	// add extra variables to hold out parameters if necessary
+	for _, outParam := range state.loopOutParameters {
+		extraVariableDeclarations = append(extraVariableDeclarations, f.NewVariableDeclaration(outParam.outParamName, nil /*exclamationToken*/, nil /*typeNode*/, nil /*initializer*/))
+	}
+
+	if state.conditionVariable != nil {
+		extraVariableDeclarations = append(extraVariableDeclarations, f.NewVariableDeclaration(state.conditionVariable, nil /*exclamationToken*/, nil /*typeNode*/, f.NewFalseExpression()))
+	}
+
+	// create variable statement to hold all introduced variable declarations
+	if len(extraVariableDeclarations) > 0 {
+		statements = append(statements, f.NewVariableStatement(nil /*modifiers*/, f.NewVariableDeclarationList(ast.NodeFlagsNone, f.NewNodeList(extraVariableDeclarations))))
+	}
+	return statements
+}
+
+// The function created for the initializer or body of a converted loop.
+type iterationStatementPartFunction struct {
+	functionName        *ast.IdentifierNode
+	functionDeclaration *ast.Statement
+	containsYield       bool
+	initializerPart     *ast.Node        // the new initializer of a `for` statement whose initializer was converted
+	bodyPart            []*ast.Statement // the statements that call the function created for the loop body
+}
+
+// Moves the initializer of a `for` statement into a function when it captures a binding declared in the
+// initializer:
+//
+//	for (let i = (setImmediate(() => console.log(i)), 0); i < 2; i++) {}
+//
+// becomes
+//
+//	var _loop_init_1 = function () {
+//	    var i = (setImmediate(function () { return console.log(i); }), 0);
+//	    out_i_1 = i;
+//	};
+//	var out_i_1;
+//	_loop_init_1();
+//	for (var i = out_i_1; i < 2; i++) {}
+//
+// This prevents mutations to `i` in the per-iteration environment of the body from affecting the initial value for
+// `i` outside of the per-iteration environment.
+func (tx *es2015Transformer) createFunctionForInitializerOfForStatement(node *ast.ForStatement, currentState *convertedLoopState) *iterationStatementPartFunction {
+	f := tx.Factory()
+	functionName := f.NewUniqueName("_loop_init")
+	containsYield := node.Initializer.SubtreeFacts()&ast.SubtreeContainsYield != 0
+
+	var statements []*ast.Statement
+	statements = append(statements, f.NewVariableStatement(nil /*modifiers*/, node.Initializer))
+	statements = tx.copyOutParameters(currentState.loopOutParameters, loopOutParameterFlagsInitializer, copyDirectionToOutParameter, statements)
+
+	body := tx.visitBlock(f.NewBlock(f.NewNodeList(statements), true /*multiLine*/).AsBlock())
+	functionDeclaration := tx.createConvertedLoopFunctionStatement(functionName, containsYield, nil /*parameters*/, body, printer.EFNone)
+
+	var outVariables []*ast.Node
+	for _, outParam := range currentState.loopOutParameters {
+		outVariables = append(outVariables, f.NewVariableDeclaration(outParam.originalName, nil /*exclamationToken*/, nil /*typeNode*/, outParam.outParamName))
+	}
+	return &iterationStatementPartFunction{
+		functionName:        functionName,
+		functionDeclaration: functionDeclaration,
+		containsYield:       containsYield,
+		initializerPart:     f.NewVariableDeclarationList(ast.NodeFlagsNone, f.NewNodeList(outVariables)),
+	}
+}
+
+// Moves the body of an iteration statement into a function.
+func (tx *es2015Transformer) createFunctionForBodyOfIterationStatement(node *ast.Node, currentState *convertedLoopState, outerState *convertedLoopState) *iterationStatementPartFunction {
+	f := tx.Factory()
+	functionName := f.NewUniqueName("_loop")
+	tx.EmitContext().StartLexicalEnvironment()
+	statement := tx.Visitor().VisitEmbeddedStatement(node.Statement())
+	lexicalEnvironment := tx.EmitContext().EndLexicalEnvironment()
+
+	var statements []*ast.Statement
+	if tx.shouldConvertConditionOfForStatement(node) || tx.shouldConvertIncrementorOfForStatement(node) {
+		// If a block-scoped variable declared in the initializer of `node` is captured in the condition or
+		// incrementor, we must move the condition and incrementor into the body of the for loop:
+		//
+		//	for (let i = 0; setImmediate(() => console.log(i)), i < 2; setImmediate(() => console.log(i)), i++) {}
+		//
+		// becomes
+		//
+		//	var _loop_1 = function (i) {
+		//	    if (inc_1)
+		//	        setImmediate(function () { return console.log(i); }), i++;
+		//	    else
+		//	        inc_1 = true;
+		//	    if (!(setImmediate(function () { return console.log(i); }), i < 2))
+		//	        return out_i_1 = i, "break";
+		//	    out_i_1 = i;
+		//	};
+		//	var out_i_1, inc_1 = false;
+		//	for (var i = 0;;) {
+		//	    var state_1 = _loop_1(i);
+		//	    i = out_i_1;
+		//	    if (state_1 === "break")
+		//	        break;
+		//	}
+		//
+		// Note that the incrementor of a `for` loop is evaluated in a *new* per-iteration environment that is
+		// carried over to the next iteration of the loop. As a result, we must indicate whether this is the first
+		// evaluation of the loop body so that we only evaluate the incrementor on subsequent evaluations.
+		forStatement := node.AsForStatement()
+		currentState.conditionVariable = f.NewUniqueName("inc")
+		if forStatement.Incrementor != nil {
+			statements = append(statements, f.NewIfStatement(
+				currentState.conditionVariable,
+				f.NewExpressionStatement(tx.Visitor().VisitNode(forStatement.Incrementor)),
+				f.NewExpressionStatement(f.NewAssignmentExpression(currentState.conditionVariable, f.NewTrueExpression())),
+			))
+		} else {
+			statements = append(statements, f.NewIfStatement(
+				f.NewPrefixUnaryExpression(ast.KindExclamationToken, currentState.conditionVariable),
+				f.NewExpressionStatement(f.NewAssignmentExpression(currentState.conditionVariable, f.NewTrueExpression())),
+				nil, /*elseStatement*/
+			))
+		}
+		if tx.shouldConvertConditionOfForStatement(node) {
+			statements = append(statements, f.NewIfStatement(
+				f.NewPrefixUnaryExpression(ast.KindExclamationToken, f.NewParenthesizedExpression(tx.Visitor().VisitNode(forStatement.Condition))),
+				tx.visitBreakOrContinueStatement(f.NewBreakStatement(nil /*label*/)),
+				nil, /*elseStatement*/
+			))
+		}
+	}
+
+	if ast.IsBlock(statement) {
+		statements = append(statements, statement.AsBlock().Statements.Nodes...)
+	} else {
+		statements = append(statements, statement)
+	}
+
+	statements = tx.copyOutParameters(currentState.loopOutParameters, loopOutParameterFlagsBody, copyDirectionToOutParameter, statements)
+	statements = tx.EmitContext().MergeEnvironment(statements, lexicalEnvironment)
+
+	loopBody := f.NewBlock(f.NewNodeList(statements), true /*multiLine*/)
+	if ast.IsBlock(statement) {
+		tx.EmitContext().SetOriginal(loopBody, statement)
+	}
+
+	containsYield := node.Statement().SubtreeFacts()&ast.SubtreeContainsYield != 0
+	functionDeclaration := tx.createConvertedLoopFunctionStatement(functionName, containsYield, currentState.loopParameters, loopBody, printer.EFReuseTempVariableScope)
+	return &iterationStatementPartFunction{
+		functionName:        functionName,
+		functionDeclaration: functionDeclaration,
+		containsYield:       containsYield,
+		bodyPart:            tx.generateCallToConvertedLoop(functionName, currentState, outerState, containsYield),
+	}
+}
+
+// var _loop_1 = function (...) { ... };
+func (tx *es2015Transformer) createConvertedLoopFunctionStatement(functionName *ast.IdentifierNode, containsYield bool, parameters []*ast.Node, body *ast.Node, emitFlags printer.EmitFlags) *ast.Statement {
+	f := tx.Factory()
+	var asteriskToken *ast.TokenNode
+	if containsYield {
+		asteriskToken = f.NewToken(ast.KindAsteriskToken)
+		if tx.hierarchyFacts&hierarchyFactsAsyncFunctionBody != 0 {
+			emitFlags |= printer.EFAsyncFunctionBody
+		}
+	}
+	function := f.NewFunctionExpression(nil /*modifiers*/, asteriskToken, nil /*name*/, nil /*typeParameters*/, f.NewNodeList(parameters), nil /*returnType*/, body)
+	tx.EmitContext().SetEmitFlags(function, emitFlags)
+	declarationList := f.NewVariableDeclarationList(ast.NodeFlagsNone, f.NewNodeList([]*ast.Node{
+		f.NewVariableDeclaration(functionName, nil /*exclamationToken*/, nil /*typeNode*/, function),
+	}))
+	tx.EmitContext().SetEmitFlags(declarationList, printer.EFNoHoisting)
+	return f.NewVariableStatement(nil /*modifiers*/, declarationList)
+}
+
+func (tx *es2015Transformer) copyOutParameter(outParam loopOutParameter, direction copyDirection) *ast.Expression {
+	f := tx.Factory()
+	if direction == copyDirectionToOriginal {
+		return f.NewAssignmentExpression(outParam.originalName.Clone(f), outParam.outParamName.Clone(f))
+	}
+	return f.NewAssignmentExpression(outParam.outParamName.Clone(f), outParam.originalName.Clone(f))
+}
+
+func (tx *es2015Transformer) copyOutParameters(outParams []loopOutParameter, partFlags loopOutParameterFlags, direction copyDirection, statements []*ast.Statement) []*ast.Statement {
+	for _, outParam := range outParams {
+		if outParam.flags&partFlags != 0 {
+			statements = append(statements, tx.Factory().NewExpressionStatement(tx.copyOutParameter(outParam, direction)))
+		}
+	}
+	return statements
+}
+
+// Creates a call to a converted loop function. A converted loop function that contains `yield` is a generator, and is
+// delegated to with `yield*`.
+func (tx *es2015Transformer) createCallToConvertedLoopFunction(functionName *ast.IdentifierNode, arguments []*ast.Expression, containsYield bool) *ast.Expression {
+	f := tx.Factory()
+	call := f.NewCallExpression(functionName.Clone(f), nil /*questionDotToken*/, nil /*typeArguments*/, f.NewNodeList(arguments), ast.NodeFlagsNone)
+	if containsYield {
+		tx.EmitContext().AddEmitFlags(call, printer.EFIterator)
+		return f.NewYieldExpression(f.NewToken(ast.KindAsteriskToken), call)
+	}
+	return call
+}
+
+func (tx *es2015Transformer) generateCallToConvertedLoopInitializer(functionName *ast.IdentifierNode, containsYield bool) *ast.Statement {
+	return tx.Factory().NewExpressionStatement(tx.createCallToConvertedLoopFunction(functionName, nil /*arguments*/, containsYield))
+}
+
+func (tx *es2015Transformer) generateCallToConvertedLoop(functionName *ast.IdentifierNode, state *convertedLoopState, outerState *convertedLoopState, containsYield bool) []*ast.Statement {
+	f := tx.Factory()
+	var statements []*ast.Statement
+
+	// loop is considered simple if it does not have any return statements or break\continue that transfer control
+	// outside of the loop. simple loops are emitted as just 'loop()';
+	// NOTE: if loop uses only 'continue' it still will be emitted as simple loop
+	isSimpleLoop := state.nonLocalJumps&^jumpContinue == 0 && state.labeledNonLocalBreaks == nil && state.labeledNonLocalContinues == nil
+
+	var arguments []*ast.Expression
+	for _, parameter := range state.loopParameters {
+		arguments = append(arguments, parameter.Name().Clone(f))
+	}
+	callResult := tx.createCallToConvertedLoopFunction(functionName, arguments, containsYield)
+	if isSimpleLoop {
+		statements = append(statements, f.NewExpressionStatement(callResult))
+		return tx.copyOutParameters(state.loopOutParameters, loopOutParameterFlagsBody, copyDirectionToOriginal, statements)
+	}
+
+	loopResultName := f.NewUniqueName("state")
+	stateVariable := f.NewVariableStatement(nil /*modifiers*/, f.NewVariableDeclarationList(ast.NodeFlagsNone, f.NewNodeList([]*ast.Node{
+		f.NewVariableDeclaration(loopResultName, nil /*exclamationToken*/, nil /*typeNode*/, callResult),
+	})))
+	statements = append(statements, stateVariable)
+	statements = tx.copyOutParameters(state.loopOutParameters, loopOutParameterFlagsBody, copyDirectionToOriginal, statements)
+
+	if state.nonLocalJumps&jumpReturn != 0 {
+		var returnStatement *ast.Statement
+		if outerState != nil {
+			outerState.nonLocalJumps |= jumpReturn
+			returnStatement = f.NewReturnStatement(loopResultName.Clone(f))
+		} else {
+			returnStatement = f.NewReturnStatement(f.NewPropertyAccessExpression(loopResultName.Clone(f), nil /*questionDotToken*/, f.NewIdentifier("value"), ast.NodeFlagsNone))
+		}
+		statements = append(statements, f.NewIfStatement(f.NewTypeCheck(loopResultName.Clone(f), "object"), returnStatement, nil /*elseStatement*/))
+	}
+
+	if state.nonLocalJumps&jumpBreak != 0 {
+		statements = append(statements, f.NewIfStatement(
+			f.NewStrictEqualityExpression(loopResultName.Clone(f), f.NewStringLiteral("break")),
+			f.NewBreakStatement(nil /*label*/),
+			nil, /*elseStatement*/
+		))
+	}
+
+	if state.labeledNonLocalBreaks != nil || state.labeledNonLocalContinues != nil {
+		var caseClauses []*ast.Node
+		caseClauses = tx.processLabeledJumps(state.labeledNonLocalBreaks, true /*isBreak*/, loopResultName, outerState, caseClauses)
+		caseClauses = tx.processLabeledJumps(state.labeledNonLocalContinues, false /*isBreak*/, loopResultName, outerState, caseClauses)
+		statements = append(statements, f.NewSwitchStatement(loopResultName.Clone(f), f.NewCaseBlock(f.NewNodeList(caseClauses))))
+	}
+	return statements
+}
+
+func (tx *es2015Transformer) processLabeledJumps(table map[string]string, isBreak bool, loopResultName *ast.IdentifierNode, outerLoop *convertedLoopState, caseClauses []*ast.Node) []*ast.Node {
+	f := tx.Factory()
+	// Sort the labels so that the output is deterministic.
+	labels := make([]string, 0, len(table))
+	for labelText := range table {
+		labels = append(labels, labelText)
+	}
+	slices.Sort(labels)
+
+	for _, labelText := range labels {
+		labelMarker := table[labelText]
+		var statement *ast.Statement
+		// if there are no outer converted loop or outer label in question is located inside outer converted loop
+		// then emit labeled break\continue
+		// otherwise propagate pair 'label -> marker' to outer converted loop and emit 'return labelMarker' so outer
+		// loop can later decide what to do
+		if outerLoop == nil || outerLoop.labels[labelText] {
+			label := f.NewIdentifier(labelText)
+			if isBreak {
+				statement = f.NewBreakStatement(label)
+			} else {
+				statement = f.NewContinueStatement(label)
+			}
+		} else {
+			outerLoop.setLabeledJump(isBreak, labelText, labelMarker)
+			statement = f.NewReturnStatement(loopResultName.Clone(f))
+		}
+		caseClauses = append(caseClauses, f.NewCaseOrDefaultClause(ast.KindCaseClause, f.NewStringLiteral(labelMarker), f.NewNodeList([]*ast.Statement{statement})))
+	}
+	return caseClauses
+}
+
+//
+// for-of
+//
+
+// Creates the body of a lowered `for..of` statement, which assigns the current value to the initializer of the
+// statement before evaluating the original body.
+func (tx *es2015Transformer) convertForOfStatementHead(node *ast.ForInOrOfStatement, boundValue *ast.Expression, convertedLoopBodyStatements []*ast.Statement) *ast.Statement {
+	f := tx.Factory()
+	var statements []*ast.Statement
+	initializer := node.Initializer
+	if ast.IsVariableDeclarationList(initializer) {
+		declarationNodes := initializer.AsVariableDeclarationList().Declarations.Nodes
+		var firstOriginalDeclaration *ast.Node
+		if len(declarationNodes) > 0 {
+			firstOriginalDeclaration = declarationNodes[0]
+		}
+		if firstOriginalDeclaration != nil && ast.IsBindingPattern(firstOriginalDeclaration.Name()) {
+			// This works whether the declaration is a var, let, or const.
+			// It will use rhsIterationValue _a[_i] as the initializer.
+			declarations := tx.flattenBindingDeclaration(firstOriginalDeclaration, boundValue, false /*hoistTempVariables*/)
+			declarationList := f.NewVariableDeclarationList(ast.NodeFlagsNone, f.NewNodeList(declarations))
+			declarationList.Loc = initializer.Loc
+			tx.EmitContext().SetOriginal(declarationList, initializer)
+
+			// Adjust the source map range for the first declaration to align with the old emitter.
+			if len(declarations) > 0 {
+				tx.EmitContext().SetSourceMapRange(declarationList, core.NewTextRange(declarations[0].Pos(), declarations[len(declarations)-1].End()))
+			}
+			statements = append(statements, f.NewVariableStatement(nil /*modifiers*/, declarationList))
+		} else {
+			// The following call does not include the initializer, so we have to emit it separately.
+			var name *ast.Node
+			if firstOriginalDeclaration != nil {
+				name = tx.getBindingName(firstOriginalDeclaration.Name())
+			} else {
+				name = f.NewTempVariable()
+			}
+			declarationList := f.NewVariableDeclarationList(ast.NodeFlagsNone, f.NewNodeList([]*ast.Node{
+				f.NewVariableDeclaration(name, nil /*exclamationToken*/, nil /*typeNode*/, boundValue),
+			}))
+			declarationList.Loc = initializer.Loc.WithPos(initializer.Pos() - 1)
+			tx.EmitContext().SetOriginal(declarationList, initializer)
+			statement := f.NewVariableStatement(nil /*modifiers*/, declarationList)
+			statement.Loc = initializer.Loc.WithEnd(initializer.End() - 1)
+			statements = append(statements, statement)
+		}
+	} else {
+		// Initializer is an expression. Emit the expression in the body, so that it's evaluated on every iteration.
+		assignment := f.NewAssignmentExpression(initializer, boundValue)
+		if ast.IsDestructuringAssignment(assignment) {
+			statements = append(statements, f.NewExpressionStatement(tx.visitBinaryExpression(assignment.AsBinaryExpression(), true /*discarded*/)))
+		} else {
+			assignment.Loc = assignment.Loc.WithEnd(initializer.End())
+			statement := f.NewExpressionStatement(tx.Visitor().VisitNode(assignment))
+			statement.Loc = initializer.Loc.WithEnd(initializer.End() - 1)
+			statements = append(statements, statement)
+		}
+	}
+
+	if convertedLoopBodyStatements != nil {
+		return tx.createSyntheticBlockForConvertedStatements(append(statements, convertedLoopBodyStatements...))
+	}
+	statement := tx.Visitor().VisitEmbeddedStatement(node.Statement)
+	if ast.IsBlock(statement) {
+		block := statement.AsBlock()
+		list := f.NewNodeList(append(statements, block.Statements.Nodes...))
+		list.Loc = block.Statements.Loc
+		return f.UpdateBlock(block, list)
+	}
+	return tx.createSyntheticBlockForConvertedStatements(append(statements, statement))
+}
+
+func (tx *es2015Transformer) createSyntheticBlockForConvertedStatements(statements []*ast.Statement) *ast.Statement {
+	block := tx.Factory().NewBlock(tx.Factory().NewNodeList(statements), true /*multiLine*/)
+	tx.EmitContext().SetEmitFlags(block, printer.EFNoSourceMap|printer.EFNoTokenSourceMaps)
+	return block
+}
+
+// Lowers a `for..of` statement over an array to a `for` statement:
+//
+//	for (let v of expr) { }
+//
+// becomes
+//
+//	for (var _i = 0, _a = expr; _i < _a.length; _i++) {
+//	    var v = _a[_i];
+//	}
+func (tx *es2015Transformer) convertForOfStatementForArray(node *ast.Node, outermostLabeledStatement *ast.LabeledStatement, convertedLoopBodyStatements []*ast.Statement, ancestorFacts hierarchyFacts) *ast.Statement {
+	f := tx.Factory()
+	forOfStatement := node.AsForInOrOfStatement()
+	expression := tx.Visitor().VisitNode(forOfStatement.Expression)
+
+	// In the case where the user wrote an identifier as the RHS, like this:
+	//
+	//	for (let v of arr) { }
+	//
+	// we don't want to emit a temporary variable for the RHS, just use it directly.
+	counter := f.NewLoopVariable()
+	var rhsReference *ast.IdentifierNode
+	if ast.IsIdentifier(expression) {
+		rhsReference = f.NewGeneratedNameForNode(expression)
+	} else {
+		rhsReference = f.NewTempVariable()
+	}
+
+	// The old emitter does not emit source maps for the expression
+	tx.EmitContext().AddEmitFlags(expression, printer.EFNoSourceMap)
+
+	counterDeclaration := f.NewVariableDeclaration(counter, nil /*exclamationToken*/, nil /*typeNode*/, f.NewNumericLiteral("0"))
+	counterDeclaration.Loc = forOfStatement.Expression.Loc.WithPos(forOfStatement.Expression.Pos() - 1)
+	rhsDeclaration := f.NewVariableDeclaration(rhsReference, nil /*exclamationToken*/, nil /*typeNode*/, expression)
+	rhsDeclaration.Loc = forOfStatement.Expression.Loc
+	initializer := f.NewVariableDeclarationList(ast.NodeFlagsNone, f.NewNodeList([]*ast.Node{counterDeclaration, rhsDeclaration}))
+	initializer.Loc = forOfStatement.Expression.Loc
+	tx.EmitContext().SetEmitFlags(initializer, printer.EFNoHoisting)
+
+	condition := f.NewBinaryExpression(
+		nil, /*modifiers*/
+		counter.Clone(f),
+		nil, /*typeNode*/
+		f.NewToken(ast.KindLessThanToken),
+		f.NewPropertyAccessExpression(rhsReference.Clone(f), nil /*questionDotToken*/, f.NewIdentifier("length"), ast.NodeFlagsNone),
+	)
+	condition.Loc = forOfStatement.Expression.Loc
+	incrementor := f.NewPostfixUnaryExpression(counter.Clone(f), ast.KindPlusPlusToken)
+	incrementor.Loc = forOfStatement.Expression.Loc
+
+	body := tx.convertForOfStatementHead(
+		forOfStatement,
+		f.NewElementAccessExpression(rhsReference.Clone(f), nil /*questionDotToken*/, counter.Clone(f), ast.NodeFlagsNone),
+		convertedLoopBodyStatements,
+	)
+	forStatement := f.NewForStatement(initializer, condition, incrementor, body)
+	forStatement.Loc = node.Loc
+	tx.EmitContext().SetOriginal(forStatement, node)
+
+	// Disable trailing source maps for the OpenParenToken to align source map emit with the old emitter.
+	tx.EmitContext().SetEmitFlags(forStatement, printer.EFNoTokenTrailingSourceMaps)
+	return tx.restoreEnclosingLabel(forStatement, outermostLabeledStatement)
+}
+
+// Lowers a `for..of` statement over an iterable to a `for` statement that steps through the result of `__values`,
+// closing the iterator if the loop exits early:
+//
+//	for (const v of expr) { }
+//
+// becomes
+//
+//	try {
+//	    for (var expr_1 = __values(expr), expr_1_1 = expr_1.next(); !expr_1_1.done; expr_1_1 = expr_1.next()) {
+//	        var v = expr_1_1.value;
+//	    }
+//	}
+//	catch (e_1_1) { e_1 = { error: e_1_1 }; }
+//	finally {
+//	    try {
+//	        if (expr_1_1 && !expr_1_1.done && (_a = expr_1.return)) _a.call(expr_1);
+//	    }
+//	    finally { if (e_1) throw e_1.error; }
+//	}
+func (tx *es2015Transformer) convertForOfStatementForIterable(node *ast.Node, outermostLabeledStatement *ast.LabeledStatement, convertedLoopBodyStatements []*ast.Statement, ancestorFacts hierarchyFacts) *ast.Statement {
+	f := tx.Factory()
+	forOfStatement := node.AsForInOrOfStatement()
+	expression := tx.Visitor().VisitNode(forOfStatement.Expression)
+	var iterator, result *ast.IdentifierNode
+	if ast.IsIdentifier(expression) {
+		iterator = f.NewGeneratedNameForNode(expression)
+		result = f.NewGeneratedNameForNode(iterator)
+	} else {
+		iterator = f.NewTempVariable()
+		result = f.NewTempVariable()
+	}
+	errorRecord := f.NewUniqueName("e")
+	catchVariable := f.NewGeneratedNameForNode(errorRecord)
+	returnMethod := f.NewTempVariable()
+	values := f.NewValuesHelper(expression)
+	values.Loc = forOfStatement.Expression.Loc
+	next := func() *ast.Expression {
+		return f.NewCallExpression(
+			f.NewPropertyAccessExpression(iterator.Clone(f), nil /*questionDotToken*/, f.NewIdentifier("next"), ast.NodeFlagsNone),
+			nil, /*questionDotToken*/
+			nil, /*typeArguments*/
+			f.NewNodeList(nil),
+			ast.NodeFlagsNone,
+		)
+	}
+
+	tx.EmitContext().AddVariableDeclaration(errorRecord)
+	tx.EmitContext().AddVariableDeclaration(returnMethod)
+
+	// if we are enclosed in an outer loop ensure we reset 'errorRecord' per each iteration
+	var iteratorInitializer *ast.Expression = values
+	if ancestorFacts&hierarchyFactsIterationContainer != 0 {
+		iteratorInitializer = f.InlineExpressions([]*ast.Expression{f.NewAssignmentExpression(errorRecord.Clone(f), f.NewVoidZeroExpression()), values})
+	}
+
+	iteratorDeclaration := f.NewVariableDeclaration(iterator, nil /*exclamationToken*/, nil /*typeNode*/, iteratorInitializer)
+	iteratorDeclaration.Loc = forOfStatement.Expression.Loc
+	initializer := f.NewVariableDeclarationList(ast.NodeFlagsNone, f.NewNodeList([]*ast.Node{
+		iteratorDeclaration,
+		f.NewVariableDeclaration(result, nil /*exclamationToken*/, nil /*typeNode*/, next()),
+	}))
+	initializer.Loc = forOfStatement.Expression.Loc
+	tx.EmitContext().SetEmitFlags(initializer, printer.EFNoHoisting)
+
+	doneProperty := func() *ast.Expression {
+		return f.NewPropertyAccessExpression(result.Clone(f), nil /*questionDotToken*/, f.NewIdentifier("done"), ast.NodeFlagsNone)
+	}
+	forStatement := f.NewForStatement(
+		initializer,
+		f.NewPrefixUnaryExpression(ast.KindExclamationToken, doneProperty()),
+		f.NewAssignmentExpression(result.Clone(f), next()),
+		tx.convertForOfStatementHead(
+			forOfStatement,
+			f.NewPropertyAccessExpression(result.Clone(f), nil /*questionDotToken*/, f.NewIdentifier("value"), ast.NodeFlagsNone),
+			convertedLoopBodyStatements,
+		),
+	)
+	forStatement.Loc = node.Loc
+	tx.EmitContext().SetOriginal(forStatement, node)
+	tx.EmitContext().SetEmitFlags(forStatement, printer.EFNoTokenTrailingSourceMaps)
+
+	catchBlock := f.NewBlock(f.NewNodeList([]*ast.Statement{
+		f.NewExpressionStatement(f.NewAssignmentExpression(
+			errorRecord.Clone(f),
+			f.NewObjectLiteralExpression(f.NewNodeList([]*ast.Node{
+				f.NewPropertyAssignment(nil /*modifiers*/, f.NewIdentifier("error"), nil /*postfixToken*/, nil /*typeNode*/, catchVariable.Clone(f)),
+			}), false /*multiLine*/),
+		)),
+	}), false /*multiLine*/)
+	tx.EmitContext().SetEmitFlags(catchBlock, printer.EFSingleLine)
+
+	closeIterator := f.NewIfStatement(
+		f.NewLogicalANDExpression(
+			f.NewLogicalANDExpression(result.Clone(f), f.NewPrefixUnaryExpression(ast.KindExclamationToken, doneProperty())),
+			f.NewAssignmentExpression(returnMethod.Clone(f), f.NewPropertyAccessExpression(iterator.Clone(f), nil /*questionDotToken*/, f.NewIdentifier("return"), ast.NodeFlagsNone)),
+		),
+		f.NewExpressionStatement(f.NewFunctionCallCall(returnMethod.Clone(f), iterator.Clone(f), nil /*argumentsList*/)),
+		nil, /*elseStatement*/
+	)
+	tx.EmitContext().SetEmitFlags(closeIterator, printer.EFSingleLine)
+
+	rethrow := f.NewIfStatement(
+		errorRecord.Clone(f),
+		f.NewThrowStatement(f.NewPropertyAccessExpression(errorRecord.Clone(f), nil /*questionDotToken*/, f.NewIdentifier("error"), ast.NodeFlagsNone)),
+		nil, /*elseStatement*/
+	)
+	tx.EmitContext().SetEmitFlags(rethrow, printer.EFSingleLine)
+	finallyBlock := f.NewBlock(f.NewNodeList([]*ast.Statement{rethrow}), false /*multiLine*/)
+	tx.EmitContext().SetEmitFlags(finallyBlock, printer.EFSingleLine)
+
+	return f.NewTryStatement(
+		f.NewBlock(f.NewNodeList([]*ast.Statement{tx.restoreEnclosingLabel(forStatement, outermostLabeledStatement)}), true /*multiLine*/),
+		f.NewCatchClause(f.NewVariableDeclaration(catchVariable, nil /*exclamationToken*/, nil /*typeNode*/, nil /*initializer*/), catchBlock),
+		f.NewBlock(f.NewNodeList([]*ast.Statement{
+			f.NewTryStatement(
+				f.NewBlock(f.NewNodeList([]*ast.Statement{closeIterator}), true /*multiLine*/),
+				nil, /*catchClause*/
+				finallyBlock,
+			),
+		}), true /*multiLine*/),
+	)
+}
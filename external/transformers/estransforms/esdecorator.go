@@ -1,20 +1,1509 @@
 package estransforms
 
 import (
+	"slices"
+
 	"github.com/pagpeter/typescript-go/external/ast"
+	"github.com/pagpeter/typescript-go/external/core"
 	"github.com/pagpeter/typescript-go/external/printer"
+	"github.com/pagpeter/typescript-go/external/scanner"
 	"github.com/pagpeter/typescript-go/external/transformers"
 )
 
+// The variables that hold the decorators and initializers of a decorated class element.
+type esDecoratorMemberInfo struct {
+	decoratorsName        *ast.IdentifierNode // `_x_decorators`, the evaluated decorators of the element
+	initializersName      *ast.IdentifierNode // `_x_initializers`, the initializers added by field and accessor decorators
+	extraInitializersName *ast.IdentifierNode // `_x_extraInitializers`, the initializers added via `context.addInitializer`
+	descriptorName        *ast.IdentifierNode // `_x_descriptor`, the decorated descriptor of a private method or accessor
+}
+
+// Tracks the state of a class whose decorators are being lowered.
+type esDecoratorClassInfo struct {
+	class *ast.ClassLikeDeclaration
+
+	classDecoratorsName        *ast.IdentifierNode // `_classDecorators`
+	classDescriptorName        *ast.IdentifierNode // `_classDescriptor`
+	classExtraInitializersName *ast.IdentifierNode // `_classExtraInitializers`
+	classThis                  *ast.IdentifierNode // `_classThis`, the decorated class, when the class itself is decorated
+	classSuper                 *ast.IdentifierNode // `_classSuper`, the base class, when the class has an `extends` clause
+	metadataReference          *ast.IdentifierNode // `_metadata`
+
+	members     []*ast.ClassElement // the decorated elements, in the order their variables are declared
+	memberInfos map[*ast.ClassElement]*esDecoratorMemberInfo
+
+	instanceMethodExtraInitializersName *ast.IdentifierNode // `_instanceExtraInitializers`
+	staticMethodExtraInitializersName   *ast.IdentifierNode // `_staticExtraInitializers`
+
+	// The calls to `__esDecorate` for the elements of the class, in the order the decorators are applied.
+	staticNonFieldDecorationStatements    []*ast.Statement
+	nonStaticNonFieldDecorationStatements []*ast.Statement
+	staticFieldDecorationStatements       []*ast.Statement
+	nonStaticFieldDecorationStatements    []*ast.Statement
+
+	hasStaticInitializers         bool
+	hasNonAmbientInstanceFields   bool
+	hasStaticPrivateClassElements bool
+
+	// Calls to `__runInitializers` that must run before the next static or instance element is initialized.
+	pendingStaticInitializers   []*ast.Expression
+	pendingInstanceInitializers []*ast.Expression
+}
+
+// The parts of a decorated class element shared by each kind of element.
+type esDecoratorElement struct {
+	modifiers             *ast.ModifierList
+	referencedName        *ast.Expression // the property key of the element, when it is referenced by its context object
+	name                  *ast.PropertyName
+	initializersName      *ast.IdentifierNode
+	extraInitializersName *ast.IdentifierNode
+	descriptorName        *ast.IdentifierNode
+	thisArg               *ast.Expression // the receiver of the initializers of a static element
+}
+
 type esDecoratorTransformer struct {
 	transformers.Transformer
+	legacyDecorators bool
+
+	classInfo          *esDecoratorClassInfo
+	classThis          *ast.IdentifierNode // replaces `this` in static initializers of a decorated class
+	classSuper         *ast.IdentifierNode // replaces `super` in static initializers of a decorated class
+	pendingExpressions []*ast.Expression   // decorator evaluations that must precede the next computed property name
+}
+
+func newESDecoratorTransformer(opts *transformers.TransformOptions) *transformers.Transformer {
+	tx := &esDecoratorTransformer{
+		legacyDecorators: opts.CompilerOptions.ExperimentalDecorators.IsTrue(),
+	}
+	return tx.NewTransformer(tx.visit, opts.Context)
+}
+
+func (tx *esDecoratorTransformer) visit(node *ast.Node) *ast.Node {
+	if tx.legacyDecorators || !tx.shouldVisit(node) {
+		return node
+	}
+
+	switch node.Kind {
+	case ast.KindSourceFile:
+		return tx.visitSourceFile(node.AsSourceFile())
+	case ast.KindDecorator:
+		// decorators are removed from any declaration they are not lowered for
+		return nil
+	case ast.KindClassDeclaration:
+		return tx.visitClassDeclaration(node.AsClassDeclaration())
+	case ast.KindClassExpression:
+		return tx.visitClassExpression(node.AsClassExpression())
+	case ast.KindFunctionDeclaration, ast.KindFunctionExpression, ast.KindMethodDeclaration, ast.KindGetAccessor, ast.KindSetAccessor, ast.KindConstructor:
+		return tx.visitNonArrowFunction(node)
+	case ast.KindThisKeyword:
+		return tx.visitThisKeyword(node)
+	case ast.KindPropertyAccessExpression, ast.KindElementAccessExpression:
+		return tx.visitPropertyOrElementAccessExpression(node)
+	case ast.KindCallExpression:
+		return tx.visitCallExpression(node.AsCallExpression())
+	case ast.KindTaggedTemplateExpression:
+		return tx.visitTaggedTemplateExpression(node.AsTaggedTemplateExpression())
+	case ast.KindBinaryExpression:
+		return tx.visitBinaryExpression(node.AsBinaryExpression())
+	case ast.KindPrefixUnaryExpression, ast.KindPostfixUnaryExpression:
+		return tx.visitPreOrPostfixUnaryExpression(node)
+	case ast.KindVariableDeclaration, ast.KindParameter, ast.KindBindingElement, ast.KindPropertyAssignment, ast.KindShorthandPropertyAssignment, ast.KindPropertyDeclaration, ast.KindExportAssignment:
+		return tx.visitNamedEvaluationSource(node)
+	default:
+		return tx.Visitor().VisitEachChild(node)
+	}
+}
+
+func (tx *esDecoratorTransformer) shouldVisit(node *ast.Node) bool {
+	facts := node.SubtreeFacts()
+	return facts&ast.SubtreeContainsDecorators != 0 ||
+		tx.classThis != nil && facts&ast.SubtreeContainsLexicalThis != 0 ||
+		tx.classThis != nil && tx.classSuper != nil && facts&ast.SubtreeContainsLexicalSuper != 0
+}
+
+func (tx *esDecoratorTransformer) visitSourceFile(node *ast.SourceFile) *ast.Node {
+	if node.IsDeclarationFile {
+		return node.AsNode()
+	}
+
+	visited := tx.Visitor().VisitEachChild(node.AsNode())
+	tx.EmitContext().AddEmitHelper(visited, tx.EmitContext().ReadEmitHelpers()...)
+	return visited
+}
+
+// `this` and `super` within a function (other than an arrow function) no longer refer to the class, and decorator
+// evaluations pending for a computed property name of the class cannot be moved into the function.
+func (tx *esDecoratorTransformer) visitNonArrowFunction(node *ast.Node) *ast.Node {
+	savedClassThis, savedClassSuper, savedPendingExpressions := tx.classThis, tx.classSuper, tx.pendingExpressions
+	tx.classThis, tx.classSuper, tx.pendingExpressions = nil, nil, nil
+	result := tx.Visitor().VisitEachChild(node)
+	tx.classThis, tx.classSuper, tx.pendingExpressions = savedClassThis, savedClassSuper, savedPendingExpressions
+	return result
+}
+
+func (tx *esDecoratorTransformer) visitThisKeyword(node *ast.Node) *ast.Node {
+	if tx.classThis == nil {
+		return node
+	}
+	classThis := tx.classThis.Clone(tx.Factory())
+	classThis.Loc = node.Loc
+	return classThis
+}
+
+//
+// Named evaluation
+//
+
+// Indicates whether an anonymous function definition is a class whose decorators are lowered by this transform.
+func isAnonymousDecoratedClass(node *anonymousFunctionDefinition) bool {
+	return ast.IsClassExpression(node) && isDecoratedClassLike(node)
+}
+
+// The assigned name of an anonymous class without class decorators only needs to be set when it is not empty, as the
+// name of such a class is already empty.
+func canIgnoreEmptyStringLiteralInAssignedName(node *ast.Expression) bool {
+	inner := ast.SkipOuterExpressions(node, ast.OEKAll)
+	return ast.IsClassExpression(inner) && inner.Name() == nil && !ast.HasDecorators(inner)
+}
+
+func (tx *esDecoratorTransformer) visitNamedEvaluationSource(node *ast.Node) *ast.Node {
+	if isNamedEvaluationAnd(tx.EmitContext(), node, isAnonymousDecoratedClass) {
+		var expression *ast.Expression
+		assignedName := ""
+		switch node.Kind {
+		case ast.KindShorthandPropertyAssignment:
+			expression = node.AsShorthandPropertyAssignment().ObjectAssignmentInitializer
+		case ast.KindExportAssignment:
+			expression = node.Expression()
+			if !node.AsExportAssignment().IsExportEquals {
+				assignedName = "default"
+			}
+		default:
+			expression = node.Initializer()
+		}
+		node = transformNamedEvaluation(tx.EmitContext(), node, canIgnoreEmptyStringLiteralInAssignedName(expression), assignedName)
+	}
+	return tx.Visitor().VisitEachChild(node)
+}
+
+//
+// Classes
+//
+
+// Indicates whether a class or any of its elements is decorated.
+func isDecoratedClassLike(node *ast.ClassLikeDeclaration) bool {
+	return ast.HasDecorators(node) || core.Some(node.Members(), ast.HasDecorators)
+}
+
+func (tx *esDecoratorTransformer) visitClassDeclaration(node *ast.ClassDeclaration) *ast.Node {
+	if !isDecoratedClassLike(node.AsNode()) {
+		return tx.visitUndecoratedClass(node.AsNode())
+	}
+
+	ec := tx.EmitContext()
+	f := tx.Factory()
+	class := node.AsNode()
+	isExport := ast.HasSyntacticModifier(class, ast.ModifierFlagsExport)
+	isDefault := ast.HasSyntacticModifier(class, ast.ModifierFlagsDefault)
+
+	if node.Name() == nil {
+		// export default (() => { ... })();
+		if isDefault {
+			class = injectClassNamedEvaluationHelperBlockIfMissing(ec, class, f.NewStringLiteral("default"), nil /*thisExpression*/)
+		}
+		iife := tx.transformClassLike(class)
+		statement := f.NewExportAssignment(nil /*modifiers*/, false /*isExportEquals*/, nil /*typeNode*/, iife)
+		ec.SetOriginal(statement, node.AsNode())
+		statement.Loc = node.Loc
+		return statement
+	}
+
+	// let C = (() => { ... })();
+	iife := tx.transformClassLike(class)
+	declaration := f.NewVariableDeclaration(f.GetLocalNameEx(node.AsNode(), printer.AssignedNameOptions{IgnoreAssignedName: true}), nil /*exclamationToken*/, nil /*type*/, iife)
+	ec.SetOriginal(declaration, node.AsNode())
+	statement := f.NewVariableStatement(nil /*modifiers*/, f.NewVariableDeclarationList(ast.NodeFlagsLet, f.NewNodeList([]*ast.Node{declaration})))
+	ec.SetOriginal(statement, node.AsNode())
+	statement.Loc = node.Loc
+	statements := []*ast.Statement{statement}
+
+	if isDefault {
+		// export default C;
+		exportStatement := f.NewExportAssignment(nil /*modifiers*/, false /*isExportEquals*/, nil /*typeNode*/, f.GetLocalName(node.AsNode()))
+		statements = append(statements, exportStatement)
+	} else if isExport {
+		// export { C };
+		exportStatement := f.NewExportDeclaration(
+			nil,   /*modifiers*/
+			false, /*isTypeOnly*/
+			f.NewNamedExports(f.NewNodeList([]*ast.Node{f.NewExportSpecifier(false /*isTypeOnly*/, nil /*propertyName*/, f.GetLocalName(node.AsNode()))})),
+			nil, /*moduleSpecifier*/
+			nil, /*attributes*/
+		)
+		statements = append(statements, exportStatement)
+	}
+	return transformers.SingleOrMany(statements, f)
+}
+
+func (tx *esDecoratorTransformer) visitClassExpression(node *ast.ClassExpression) *ast.Node {
+	if !isDecoratedClassLike(node.AsNode()) {
+		return tx.visitUndecoratedClass(node.AsNode())
+	}
+	iife := tx.transformClassLike(node.AsNode())
+	tx.EmitContext().SetOriginal(iife, node.AsNode())
+	return iife
+}
+
+// Visits a class without decorators, which may still contain decorated classes.
+func (tx *esDecoratorTransformer) visitUndecoratedClass(node *ast.ClassLikeDeclaration) *ast.Node {
+	savedClassInfo, savedClassThis, savedClassSuper := tx.classInfo, tx.classThis, tx.classSuper
+	tx.classInfo, tx.classThis, tx.classSuper = nil, nil, nil
+	result := tx.Visitor().VisitEachChild(node)
+	tx.classInfo, tx.classThis, tx.classSuper = savedClassInfo, savedClassThis, savedClassSuper
+	return result
+}
+
+// Collects the decorators of a declaration, visiting their expressions.
+func (tx *esDecoratorTransformer) transformAllDecoratorsOfDeclaration(node *ast.Node) []*ast.Expression {
+	var decorators []*ast.Expression
+	if modifiers := node.Modifiers(); modifiers != nil {
+		for _, modifier := range modifiers.Nodes {
+			if ast.IsDecorator(modifier) {
+				expression := tx.Visitor().VisitNode(modifier.AsDecorator().Expression)
+				decorators = append(decorators, expression)
+			}
+		}
+	}
+	return decorators
+}
+
+// Gets the text used to name the helper variables of a class element.
+func getHelperVariableName(member *ast.ClassElement) string {
+	var declarationName string
+	name := member.Name()
+	switch {
+	case ast.IsIdentifier(name):
+		declarationName = name.Text()
+	case ast.IsPrivateIdentifier(name):
+		declarationName = name.Text()[1:]
+	case ast.IsStringLiteral(name) && scanner.IsIdentifierText(name.Text(), core.LanguageVariantStandard):
+		declarationName = name.Text()
+	default:
+		declarationName = "member"
+	}
+	if ast.IsGetAccessorDeclaration(member) {
+		declarationName = "get_" + declarationName
+	} else if ast.IsSetAccessorDeclaration(member) {
+		declarationName = "set_" + declarationName
+	}
+	if ast.IsPrivateIdentifier(name) {
+		declarationName = "private_" + declarationName
+	}
+	if ast.IsStatic(member) {
+		declarationName = "static_" + declarationName
+	}
+	return "_" + declarationName
+}
+
+func (tx *esDecoratorTransformer) createHelperVariable(member *ast.ClassElement, suffix string) *ast.IdentifierNode {
+	return tx.Factory().NewUniqueNameEx(getHelperVariableName(member)+"_"+suffix, printer.AutoGenerateOptions{
+		Flags: printer.GeneratedIdentifierFlagsOptimistic | printer.GeneratedIdentifierFlagsReservedInNestedScopes,
+	})
+}
+
+func (tx *esDecoratorTransformer) createLet(name *ast.IdentifierNode, initializer *ast.Expression) *ast.Statement {
+	f := tx.Factory()
+	declaration := f.NewVariableDeclaration(name, nil /*exclamationToken*/, nil /*type*/, initializer)
+	return f.NewVariableStatement(nil /*modifiers*/, f.NewVariableDeclarationList(ast.NodeFlagsLet, f.NewNodeList([]*ast.Node{declaration})))
+}
+
+func (tx *esDecoratorTransformer) createClassInfo(node *ast.ClassLikeDeclaration) *esDecoratorClassInfo {
+	f := tx.Factory()
+	info := &esDecoratorClassInfo{
+		class: node,
+		metadataReference: f.NewUniqueNameEx("_metadata", printer.AutoGenerateOptions{
+			Flags: printer.GeneratedIdentifierFlagsOptimistic | printer.GeneratedIdentifierFlagsFileLevel,
+		}),
+	}
+
+	for _, member := range node.Members() {
+		if ast.IsStatic(member) && (ast.IsPrivateIdentifierClassElementDeclaration(member) || ast.IsAutoAccessorPropertyDeclaration(member)) {
+			info.hasStaticPrivateClassElements = true
+		}
+	}
+
+	if ast.HasDecorators(node) {
+		// Static private elements and auto-accessors are accessed through `_classThis` from nested scopes once lowered,
+		// so the name must not be reused there.
+		var flags printer.GeneratedIdentifierFlags = printer.GeneratedIdentifierFlagsOptimistic | printer.GeneratedIdentifierFlagsFileLevel
+		if info.hasStaticPrivateClassElements {
+			flags = printer.GeneratedIdentifierFlagsOptimistic | printer.GeneratedIdentifierFlagsReservedInNestedScopes
+		}
+		info.classThis = f.NewUniqueNameEx("_classThis", printer.AutoGenerateOptions{Flags: flags})
+	}
+
+	for _, member := range node.Members() {
+		switch {
+		case (ast.IsMethodDeclaration(member) || ast.IsAccessor(member)) && ast.HasDecorators(member):
+			if ast.IsStatic(member) {
+				if info.staticMethodExtraInitializersName == nil {
+					info.staticMethodExtraInitializersName = f.NewUniqueNameEx("_staticExtraInitializers", printer.AutoGenerateOptions{Flags: printer.GeneratedIdentifierFlagsOptimistic})
+					thisArg := f.NewThisExpression()
+					if info.classThis != nil {
+						thisArg = info.classThis.Clone(f)
+					}
+					info.pendingStaticInitializers = append(info.pendingStaticInitializers, f.NewRunInitializersHelper(thisArg, info.staticMethodExtraInitializersName, nil /*value*/))
+				}
+			} else {
+				if info.instanceMethodExtraInitializersName == nil {
+					info.instanceMethodExtraInitializersName = f.NewUniqueNameEx("_instanceExtraInitializers", printer.AutoGenerateOptions{Flags: printer.GeneratedIdentifierFlagsOptimistic})
+					info.pendingInstanceInitializers = append(info.pendingInstanceInitializers, f.NewRunInitializersHelper(f.NewThisExpression(), info.instanceMethodExtraInitializersName, nil /*value*/))
+				}
+			}
+		case ast.IsClassStaticBlockDeclaration(member):
+			if !isClassNamedEvaluationHelperBlock(tx.EmitContext(), member) {
+				info.hasStaticInitializers = true
+			}
+		case ast.IsPropertyDeclaration(member):
+			if ast.IsStatic(member) {
+				info.hasStaticInitializers = info.hasStaticInitializers || member.Initializer() != nil || ast.HasDecorators(member)
+			} else {
+				info.hasNonAmbientInstanceFields = info.hasNonAmbientInstanceFields || !ast.HasSyntacticModifier(member, ast.ModifierFlagsAmbient)
+			}
+		}
+	}
+	return info
+}
+
+// Lowers a decorated class to an immediately invoked arrow function that evaluates and applies its decorators, and
+// returns the (possibly replaced) class:
+//
+//	(() => {
+//	    let _classDecorators = [dec];
+//	    let _classDescriptor;
+//	    let _classExtraInitializers = [];
+//	    let _classThis;
+//	    let _method_decorators;
+//	    var C = class {
+//	        static { _classThis = this; }
+//	        static {
+//	            const _metadata = ...;
+//	            __esDecorate(this, null, _method_decorators, { kind: "method", ... }, null, _instanceExtraInitializers);
+//	            __esDecorate(null, _classDescriptor = { value: _classThis }, _classDecorators, { kind: "class", ... }, null, _classExtraInitializers);
+//	            C = _classThis = _classDescriptor.value;
+//	            ...
+//	        }
+//	        method() {}
+//	        static {
+//	            __runInitializers(_classThis, _classExtraInitializers);
+//	        }
+//	    };
+//	    return C = _classThis;
+//	})()
+func (tx *esDecoratorTransformer) transformClassLike(node *ast.ClassLikeDeclaration) *ast.Expression {
+	ec := tx.EmitContext()
+	f := tx.Factory()
+	ec.StartVariableEnvironment()
+
+	// An anonymous class whose name cannot be observed otherwise must be given an empty name explicitly, as it is
+	// replaced by a class expression assigned to a named variable.
+	if !classHasDeclaredOrExplicitlyAssignedName(ec, node) && ast.HasDecorators(node) {
+		node = injectClassNamedEvaluationHelperBlockIfMissing(ec, node, f.NewStringLiteral(""), nil /*thisExpression*/)
+	}
+
+	classReference := f.GetLocalNameEx(node, printer.AssignedNameOptions{IgnoreAssignedName: true})
+	info := tx.createClassInfo(node)
+	var classDefinitionStatements []*ast.Statement
+	var leadingBlockStatements []*ast.Statement
+	var trailingBlockStatements []*ast.Statement
+
+	// 1. Class decorators are evaluated outside of the private name scope of the class.
+	classDecorators := tx.transformAllDecoratorsOfDeclaration(node)
+	if len(classDecorators) > 0 {
+		autoGenerateOptions := printer.AutoGenerateOptions{Flags: printer.GeneratedIdentifierFlagsOptimistic | printer.GeneratedIdentifierFlagsFileLevel}
+		info.classDecoratorsName = f.NewUniqueNameEx("_classDecorators", autoGenerateOptions)
+		info.classDescriptorName = f.NewUniqueNameEx("_classDescriptor", autoGenerateOptions)
+		info.classExtraInitializersName = f.NewUniqueNameEx("_classExtraInitializers", autoGenerateOptions)
+		classDefinitionStatements = append(classDefinitionStatements,
+			tx.createLet(info.classDecoratorsName, f.NewArrayLiteralExpression(f.NewNodeList(classDecorators), false /*multiLine*/)),
+			tx.createLet(info.classDescriptorName, nil /*initializer*/),
+			tx.createLet(info.classExtraInitializersName, f.NewArrayLiteralExpression(f.NewNodeList(nil), false /*multiLine*/)),
+			tx.createLet(info.classThis, nil /*initializer*/),
+		)
+	}
+
+	// 2. The base class is evaluated once, so that `super` can be referenced in static initializers.
+	var heritageClauses *ast.NodeList
+	if extendsClause := ast.GetHeritageClause(node, ast.KindExtendsKeyword); extendsClause != nil {
+		extendsElement := extendsClause.AsHeritageClause().Types.Nodes[0]
+		extendsExpression := tx.Visitor().VisitNode(extendsElement.Expression())
+		info.classSuper = f.NewUniqueNameEx("_classSuper", printer.AutoGenerateOptions{
+			Flags: printer.GeneratedIdentifierFlagsOptimistic | printer.GeneratedIdentifierFlagsFileLevel,
+		})
+
+		// An anonymous class or function must not be named `_classSuper`.
+		unwrapped := ast.SkipOuterExpressions(extendsExpression, ast.OEKAll)
+		safeExtendsExpression := extendsExpression
+		if ast.IsClassExpression(unwrapped) && unwrapped.Name() == nil ||
+			ast.IsFunctionExpression(unwrapped) && unwrapped.Name() == nil ||
+			ast.IsArrowFunction(unwrapped) {
+			safeExtendsExpression = f.NewCommaExpression(f.NewNumericLiteral("0"), extendsExpression)
+		}
+		classDefinitionStatements = append(classDefinitionStatements, tx.createLet(info.classSuper, safeExtendsExpression))
+		updatedExtendsElement := f.UpdateExpressionWithTypeArguments(extendsElement.AsExpressionWithTypeArguments(), info.classSuper.Clone(f), nil /*typeArguments*/)
+		updatedExtendsClause := f.UpdateHeritageClause(extendsClause.AsHeritageClause(), f.NewNodeList([]*ast.Node{updatedExtendsElement}))
+		heritageClauses = f.NewNodeList([]*ast.Node{updatedExtendsClause})
+	}
+
+	savedClassInfo, savedClassThis, savedClassSuper, savedPendingExpressions := tx.classInfo, tx.classThis, tx.classSuper, tx.pendingExpressions
+	tx.classInfo, tx.classThis, tx.classSuper, tx.pendingExpressions = info, nil, nil, nil
+
+	// 3. The metadata object inherits the metadata of the base class.
+	leadingBlockStatements = append(leadingBlockStatements, tx.createMetadata(info.metadataReference, info.classSuper))
+
+	// 4. Class elements are visited in order. Constructors are visited last, once the initializers that must run when
+	// the class is instantiated are known.
+	var members []*ast.ClassElement
+	constructorIndex := -1
+	for _, member := range node.Members() {
+		if ast.IsConstructorDeclaration(member) {
+			constructorIndex = len(members)
+			members = append(members, member)
+			continue
+		}
+		if visited := tx.visitClassElement(member); visited != nil {
+			if visited.Kind == ast.KindSyntaxList {
+				members = append(members, visited.AsSyntaxList().Children...)
+			} else {
+				members = append(members, visited)
+			}
+		}
+	}
+
+	if constructorIndex >= 0 {
+		members[constructorIndex] = tx.visitConstructorDeclaration(members[constructorIndex])
+	} else if len(info.pendingInstanceInitializers) > 0 {
+		// constructor() {
+		//     super(...arguments);
+		//     __runInitializers(this, _instanceExtraInitializers);
+		// }
+		var statements []*ast.Statement
+		if info.classSuper != nil {
+			statements = append(statements, f.NewExpressionStatement(f.NewCallExpression(
+				f.NewKeywordExpression(ast.KindSuperKeyword),
+				nil, /*questionDotToken*/
+				nil, /*typeArguments*/
+				f.NewNodeList([]*ast.Expression{f.NewSpreadElement(f.NewIdentifier("arguments"))}),
+				ast.NodeFlagsNone,
+			)))
+		}
+		statements = append(statements, f.NewExpressionStatement(f.InlineExpressions(info.pendingInstanceInitializers)))
+		info.pendingInstanceInitializers = nil
+		members = append(members, f.NewConstructorDeclaration(
+			nil, /*modifiers*/
+			nil, /*typeParameters*/
+			f.NewNodeList(nil),
+			nil, /*returnType*/
+			f.NewBlock(f.NewNodeList(statements), true /*multiLine*/),
+		))
+	}
+
+	// 5. Decorator evaluations not moved into a computed property name are evaluated once the class is defined.
+	// References to `this` in those expressions refer to the `this` outside of the class.
+	var outerThis *ast.IdentifierNode
+	if len(tx.pendingExpressions) > 0 {
+		var thisVisitor *ast.NodeVisitor
+		thisVisitor = ec.NewNodeVisitor(func(node *ast.Node) *ast.Node {
+			if node.SubtreeFacts()&ast.SubtreeContainsLexicalThis == 0 {
+				return node
+			}
+			switch node.Kind {
+			case ast.KindThisKeyword:
+				if outerThis == nil {
+					outerThis = f.NewUniqueNameEx("_outerThis", printer.AutoGenerateOptions{Flags: printer.GeneratedIdentifierFlagsOptimistic})
+				}
+				return outerThis.Clone(f)
+			case ast.KindFunctionDeclaration, ast.KindFunctionExpression, ast.KindMethodDeclaration, ast.KindGetAccessor, ast.KindSetAccessor, ast.KindConstructor, ast.KindClassDeclaration, ast.KindClassExpression:
+				return node
+			}
+			return thisVisitor.VisitEachChild(node)
+		})
+		for _, expression := range tx.pendingExpressions {
+			leadingBlockStatements = append(leadingBlockStatements, f.NewExpressionStatement(thisVisitor.VisitNode(expression)))
+		}
+		tx.pendingExpressions = nil
+	}
+
+	tx.classInfo, tx.classThis, tx.classSuper, tx.pendingExpressions = savedClassInfo, savedClassThis, savedClassSuper, savedPendingExpressions
+
+	if outerThis != nil {
+		classDefinitionStatements = slices.Insert(classDefinitionStatements, 0, tx.createLet(outerThis, f.NewThisExpression()))
+	}
+
+	// 6. The variables for the decorators and initializers of each element.
+	if info.staticMethodExtraInitializersName != nil {
+		classDefinitionStatements = append(classDefinitionStatements, tx.createLet(info.staticMethodExtraInitializersName, f.NewArrayLiteralExpression(f.NewNodeList(nil), false /*multiLine*/)))
+	}
+	if info.instanceMethodExtraInitializersName != nil {
+		classDefinitionStatements = append(classDefinitionStatements, tx.createLet(info.instanceMethodExtraInitializersName, f.NewArrayLiteralExpression(f.NewNodeList(nil), false /*multiLine*/)))
+	}
+	for _, isStatic := range []bool{true, false} {
+		for _, member := range info.members {
+			if ast.IsStatic(member) != isStatic {
+				continue
+			}
+			memberInfo := info.memberInfos[member]
+			classDefinitionStatements = append(classDefinitionStatements, tx.createLet(memberInfo.decoratorsName, nil /*initializer*/))
+			if memberInfo.initializersName != nil {
+				classDefinitionStatements = append(classDefinitionStatements, tx.createLet(memberInfo.initializersName, f.NewArrayLiteralExpression(f.NewNodeList(nil), false /*multiLine*/)))
+			}
+			if memberInfo.extraInitializersName != nil {
+				classDefinitionStatements = append(classDefinitionStatements, tx.createLet(memberInfo.extraInitializersName, f.NewArrayLiteralExpression(f.NewNodeList(nil), false /*multiLine*/)))
+			}
+			if memberInfo.descriptorName != nil {
+				classDefinitionStatements = append(classDefinitionStatements, tx.createLet(memberInfo.descriptorName, nil /*initializer*/))
+			}
+		}
+	}
+
+	// 7. Element decorators are applied: static methods and accessors, instance methods and accessors, static fields,
+	// then instance fields.
+	leadingBlockStatements = append(leadingBlockStatements, info.staticNonFieldDecorationStatements...)
+	leadingBlockStatements = append(leadingBlockStatements, info.nonStaticNonFieldDecorationStatements...)
+	leadingBlockStatements = append(leadingBlockStatements, info.staticFieldDecorationStatements...)
+	leadingBlockStatements = append(leadingBlockStatements, info.nonStaticFieldDecorationStatements...)
+
+	// 8. Class decorators are applied, and may replace the class.
+	if info.classDecoratorsName != nil {
+		// __esDecorate(null, _classDescriptor = { value: _classThis }, _classDecorators, { kind: "class", name: _classThis.name, metadata }, null, _classExtraInitializers);
+		valueProperty := f.NewPropertyAssignment(nil /*modifiers*/, f.NewIdentifier("value"), nil /*postfixToken*/, nil /*typeNode*/, info.classThis.Clone(f))
+		classDescriptor := f.NewAssignmentExpression(info.classDescriptorName.Clone(f), f.NewObjectLiteralExpression(f.NewNodeList([]*ast.Node{valueProperty}), false /*multiLine*/))
+		esDecorateHelper := f.NewESDecorateHelper(
+			nil, /*ctor*/
+			classDescriptor,
+			info.classDecoratorsName.Clone(f),
+			&printer.ESDecorateContext{
+				Kind:     "class",
+				Name:     printer.ESDecorateName{Name: f.NewPropertyAccessExpression(info.classThis.Clone(f), nil /*questionDotToken*/, f.NewIdentifier("name"), ast.NodeFlagsNone)},
+				Metadata: info.metadataReference.Clone(f),
+			},
+			f.NewKeywordExpression(ast.KindNullKeyword),
+			info.classExtraInitializersName.Clone(f),
+		)
+		leadingBlockStatements = append(leadingBlockStatements, f.NewExpressionStatement(esDecorateHelper))
+
+		// C = _classThis = _classDescriptor.value;
+		classDescriptorValue := f.NewPropertyAccessExpression(info.classDescriptorName.Clone(f), nil /*questionDotToken*/, f.NewIdentifier("value"), ast.NodeFlagsNone)
+		classThisAssignment := f.NewAssignmentExpression(info.classThis.Clone(f), classDescriptorValue)
+		classReferenceAssignment := f.NewAssignmentExpression(classReference.Clone(f), classThisAssignment)
+		leadingBlockStatements = append(leadingBlockStatements, f.NewExpressionStatement(classReferenceAssignment))
+	}
+
+	// 9. The metadata object is installed on the class.
+	var metadataTarget *ast.Expression
+	if info.classThis != nil {
+		metadataTarget = info.classThis.Clone(f)
+	} else {
+		metadataTarget = f.NewThisExpression()
+	}
+	leadingBlockStatements = append(leadingBlockStatements, tx.createSymbolMetadata(metadataTarget, info.metadataReference))
+
+	// 10. Extra initializers of static methods and accessors run once the class is defined, followed by the extra
+	// initializers of class decorators.
+	if len(info.pendingStaticInitializers) > 0 {
+		for _, initializer := range info.pendingStaticInitializers {
+			trailingBlockStatements = append(trailingBlockStatements, f.NewExpressionStatement(initializer))
+		}
+		info.pendingStaticInitializers = nil
+	}
+	if info.classExtraInitializersName != nil {
+		runClassInitializers := f.NewRunInitializersHelper(info.classThis.Clone(f), info.classExtraInitializersName.Clone(f), nil /*value*/)
+		trailingBlockStatements = append(trailingBlockStatements, f.NewExpressionStatement(runClassInitializers))
+	}
+
+	// When the class has no static initializers of its own, the trailing statements can run immediately after the
+	// decorators are applied.
+	if len(trailingBlockStatements) > 0 && !info.hasStaticInitializers {
+		leadingBlockStatements = append(leadingBlockStatements, trailingBlockStatements...)
+		trailingBlockStatements = nil
+	}
+
+	leadingStaticBlock := f.NewClassStaticBlockDeclaration(nil /*modifiers*/, f.NewBlock(f.NewNodeList(leadingBlockStatements), true /*multiLine*/))
+	insertionIndex := 0
+	if len(members) > 0 && isClassNamedEvaluationHelperBlock(ec, members[0]) {
+		insertionIndex = 1
+	}
+	members = slices.Insert(members, insertionIndex, leadingStaticBlock)
+	if len(trailingBlockStatements) > 0 {
+		trailingStaticBlock := f.NewClassStaticBlockDeclaration(nil /*modifiers*/, f.NewBlock(f.NewNodeList(trailingBlockStatements), true /*multiLine*/))
+		members = append(members, trailingStaticBlock)
+	}
+	membersList := f.NewNodeList(members)
+	membersList.Loc = node.MemberList().Loc
+
+	var statements []*ast.Statement
+	if info.classDecoratorsName != nil {
+		// var C = class { ... };
+		// return C = _classThis;
+		classExpression := f.NewClassExpression(nil /*modifiers*/, nil /*name*/, nil /*typeParameters*/, heritageClauses, membersList)
+		ec.SetOriginal(classExpression, node)
+		classExpression.Loc = node.Loc
+		classExpression = injectClassThisAssignmentIfMissing(ec, classExpression, info.classThis, nil /*thisExpression*/)
+		declaration := f.NewVariableDeclaration(classReference, nil /*exclamationToken*/, nil /*type*/, classExpression)
+		statements = append(statements,
+			f.NewVariableStatement(nil /*modifiers*/, f.NewVariableDeclarationList(ast.NodeFlagsNone, f.NewNodeList([]*ast.Node{declaration}))),
+			f.NewReturnStatement(f.NewAssignmentExpression(classReference.Clone(f), info.classThis.Clone(f))),
+		)
+	} else {
+		// return class C { ... };
+		classExpression := f.NewClassExpression(nil /*modifiers*/, node.Name(), nil /*typeParameters*/, heritageClauses, membersList)
+		ec.SetOriginal(classExpression, node)
+		classExpression.Loc = node.Loc
+		statements = append(statements, f.NewReturnStatement(classExpression))
+	}
+
+	statements = slices.Concat(classDefinitionStatements, statements)
+	statements = ec.EndAndMergeVariableEnvironment(statements)
+	return createImmediatelyInvokedArrowFunction(f, f.NewBlock(f.NewNodeList(statements), true /*multiLine*/))
+}
+
+// const _metadata = typeof Symbol === "function" && Symbol.metadata ? Object.create(_classSuper[Symbol.metadata] ?? null) : void 0;
+func (tx *esDecoratorTransformer) createMetadata(name *ast.IdentifierNode, classSuper *ast.IdentifierNode) *ast.Statement {
+	f := tx.Factory()
+	symbolMetadata := func() *ast.Expression {
+		return f.NewPropertyAccessExpression(f.NewIdentifier("Symbol"), nil /*questionDotToken*/, f.NewIdentifier("metadata"), ast.NodeFlagsNone)
+	}
+	var parent *ast.Expression
+	if classSuper != nil {
+		superMetadata := f.NewElementAccessExpression(classSuper.Clone(f), nil /*questionDotToken*/, symbolMetadata(), ast.NodeFlagsNone)
+		parent = f.NewBinaryExpression(nil /*modifiers*/, superMetadata, nil /*typeNode*/, f.NewToken(ast.KindQuestionQuestionToken), f.NewKeywordExpression(ast.KindNullKeyword))
+	} else {
+		parent = f.NewKeywordExpression(ast.KindNullKeyword)
+	}
+	objectCreate := f.NewCallExpression(
+		f.NewPropertyAccessExpression(f.NewIdentifier("Object"), nil /*questionDotToken*/, f.NewIdentifier("create"), ast.NodeFlagsNone),
+		nil, /*questionDotToken*/
+		nil, /*typeArguments*/
+		f.NewNodeList([]*ast.Expression{parent}),
+		ast.NodeFlagsNone,
+	)
+	typeofSymbol := f.NewStrictEqualityExpression(f.NewTypeOfExpression(f.NewIdentifier("Symbol")), f.NewStringLiteral("function"))
+	condition := f.NewLogicalANDExpression(typeofSymbol, symbolMetadata())
+	initializer := f.NewConditionalExpression(condition, f.NewToken(ast.KindQuestionToken), objectCreate, f.NewToken(ast.KindColonToken), f.NewVoidZeroExpression())
+	declaration := f.NewVariableDeclaration(name, nil /*exclamationToken*/, nil /*type*/, initializer)
+	return f.NewVariableStatement(nil /*modifiers*/, f.NewVariableDeclarationList(ast.NodeFlagsConst, f.NewNodeList([]*ast.Node{declaration})))
+}
+
+// if (_metadata) Object.defineProperty(target, Symbol.metadata, { enumerable: true, configurable: true, writable: true, value: _metadata });
+func (tx *esDecoratorTransformer) createSymbolMetadata(target *ast.Expression, metadata *ast.IdentifierNode) *ast.Statement {
+	f := tx.Factory()
+	descriptorProperty := func(name string, value *ast.Expression) *ast.Node {
+		return f.NewPropertyAssignment(nil /*modifiers*/, f.NewIdentifier(name), nil /*postfixToken*/, nil /*typeNode*/, value)
+	}
+	descriptor := f.NewObjectLiteralExpression(f.NewNodeList([]*ast.Node{
+		descriptorProperty("enumerable", f.NewTrueExpression()),
+		descriptorProperty("configurable", f.NewTrueExpression()),
+		descriptorProperty("writable", f.NewTrueExpression()),
+		descriptorProperty("value", metadata.Clone(f)),
+	}), false /*multiLine*/)
+	defineProperty := f.NewCallExpression(
+		f.NewPropertyAccessExpression(f.NewIdentifier("Object"), nil /*questionDotToken*/, f.NewIdentifier("defineProperty"), ast.NodeFlagsNone),
+		nil, /*questionDotToken*/
+		nil, /*typeArguments*/
+		f.NewNodeList([]*ast.Expression{
+			target,
+			f.NewPropertyAccessExpression(f.NewIdentifier("Symbol"), nil /*questionDotToken*/, f.NewIdentifier("metadata"), ast.NodeFlagsNone),
+			descriptor,
+		}),
+		ast.NodeFlagsNone,
+	)
+	statement := f.NewIfStatement(metadata.Clone(f), f.NewExpressionStatement(defineProperty), nil /*elseStatement*/)
+	tx.EmitContext().SetEmitFlags(statement, printer.EFSingleLine)
+	return statement
+}
+
+//
+// Class elements
+//
+
+func (tx *esDecoratorTransformer) visitClassElement(member *ast.ClassElement) *ast.Node {
+	switch member.Kind {
+	case ast.KindMethodDeclaration:
+		return tx.visitMethodDeclaration(member)
+	case ast.KindGetAccessor:
+		return tx.visitGetAccessorDeclaration(member)
+	case ast.KindSetAccessor:
+		return tx.visitSetAccessorDeclaration(member)
+	case ast.KindPropertyDeclaration:
+		return tx.visitPropertyDeclaration(member)
+	case ast.KindClassStaticBlockDeclaration:
+		return tx.visitClassStaticBlockDeclaration(member)
+	default:
+		return tx.Visitor().VisitNode(member)
+	}
+}
+
+// Injects decorator evaluations that must precede a computed property name into its expression.
+func (tx *esDecoratorTransformer) injectPendingExpressions(expression *ast.Expression) *ast.Expression {
+	if len(tx.pendingExpressions) == 0 {
+		return expression
+	}
+	f := tx.Factory()
+	if ast.IsParenthesizedExpression(expression) {
+		expression = f.UpdateParenthesizedExpression(
+			expression.AsParenthesizedExpression(),
+			f.InlineExpressions(append(tx.pendingExpressions, expression.Expression())),
+		)
+	} else {
+		expression = f.InlineExpressions(append(tx.pendingExpressions, expression))
+	}
+	tx.pendingExpressions = nil
+	return expression
+}
+
+// Visits the computed property name of a class element. Computed property names are evaluated outside of the class
+// body, so `this` and `super` are not replaced.
+func (tx *esDecoratorTransformer) visitComputedPropertyName(node *ast.ComputedPropertyName) *ast.Node {
+	savedClassThis, savedClassSuper := tx.classThis, tx.classSuper
+	tx.classThis, tx.classSuper = nil, nil
+	expression := tx.Visitor().VisitNode(node.Expression)
+	tx.classThis, tx.classSuper = savedClassThis, savedClassSuper
+	if !isSimpleInlineableExpression(expression) {
+		expression = tx.injectPendingExpressions(expression)
+	}
+	return tx.Factory().UpdateComputedPropertyName(node, expression)
+}
+
+func (tx *esDecoratorTransformer) visitPropertyName(name *ast.PropertyName) *ast.PropertyName {
+	if ast.IsComputedPropertyName(name) {
+		return tx.visitComputedPropertyName(name.AsComputedPropertyName())
+	}
+	return name
+}
+
+// Visits the name of a decorated class element, and gets an expression for its property key, evaluating a computed
+// property name into a temporary variable:
+//
+//	[key] -> [_a = __propKey(key)]
+func (tx *esDecoratorTransformer) visitReferencedPropertyName(name *ast.PropertyName) (referencedName *ast.Expression, updatedName *ast.PropertyName) {
+	f := tx.Factory()
+	if ast.IsPropertyNameLiteral(name) || ast.IsPrivateIdentifier(name) {
+		return f.NewStringLiteralFromNode(name), tx.visitPropertyName(name)
+	}
+
+	expression := name.Expression()
+	if ast.IsPropertyNameLiteral(expression) && !ast.IsIdentifier(expression) {
+		return f.NewStringLiteralFromNode(expression), tx.visitPropertyName(name)
+	}
+
+	savedClassThis, savedClassSuper := tx.classThis, tx.classSuper
+	tx.classThis, tx.classSuper = nil, nil
+	visited := tx.Visitor().VisitNode(expression)
+	tx.classThis, tx.classSuper = savedClassThis, savedClassSuper
+
+	temp := f.NewGeneratedNameForNode(name)
+	tx.EmitContext().AddVariableDeclaration(temp)
+	assignment := f.NewAssignmentExpression(temp, f.NewPropKeyHelper(visited))
+	return temp.Clone(f), f.UpdateComputedPropertyName(name.AsComputedPropertyName(), tx.injectPendingExpressions(assignment))
+}
+
+// Performs the lowering shared by all decorated class elements: the decorators of the element are evaluated (in
+// order with the computed property names of the class), and a call to `__esDecorate` is added to the decoration
+// statements of the class. createDescriptor creates the descriptor of a private method or accessor, whose decorated
+// implementation must be accessed through the descriptor.
+func (tx *esDecoratorTransformer) partialTransformClassElement(member *ast.ClassElement, createDescriptor func(member *ast.ClassElement, modifiers *ast.ModifierList) *ast.Expression) *esDecoratorElement {
+	ec := tx.EmitContext()
+	f := tx.Factory()
+	info := tx.classInfo
+	result := &esDecoratorElement{}
+
+	allowedModifiers := ^ast.ModifierFlagsDecorator
+	if ast.IsAutoAccessorPropertyDeclaration(member) && ast.HasDecorators(member) {
+		allowedModifiers &^= ast.ModifierFlagsAccessor
+	}
+	result.modifiers = transformers.ExtractModifiers(ec, member.Modifiers(), allowedModifiers)
+	isStatic := ast.IsStatic(member)
+
+	decorators := tx.transformAllDecoratorsOfDeclaration(member)
+	if len(decorators) == 0 {
+		result.name = tx.visitPropertyName(member.Name())
+		return result
+	}
+
+	// _x_decorators = [dec1, dec2]
+	memberInfo := &esDecoratorMemberInfo{decoratorsName: tx.createHelperVariable(member, "decorators")}
+	if info.memberInfos == nil {
+		info.memberInfos = make(map[*ast.ClassElement]*esDecoratorMemberInfo)
+	}
+	info.members = append(info.members, member)
+	info.memberInfos[member] = memberInfo
+	memberDecoratorsArray := f.NewArrayLiteralExpression(f.NewNodeList(decorators), false /*multiLine*/)
+	tx.pendingExpressions = append(tx.pendingExpressions, f.NewAssignmentExpression(memberInfo.decoratorsName.Clone(f), memberDecoratorsArray))
+
+	var kind string
+	switch {
+	case ast.IsGetAccessorDeclaration(member):
+		kind = "getter"
+	case ast.IsSetAccessorDeclaration(member):
+		kind = "setter"
+	case ast.IsMethodDeclaration(member):
+		kind = "method"
+	case ast.IsAutoAccessorPropertyDeclaration(member):
+		kind = "accessor"
+	default:
+		kind = "field"
+	}
+
+	var propertyName printer.ESDecorateName
+	name := member.Name()
+	if ast.IsIdentifier(name) || ast.IsPrivateIdentifier(name) {
+		propertyName = printer.ESDecorateName{Name: name}
+	} else if ast.IsPropertyNameLiteral(name) {
+		propertyName = printer.ESDecorateName{Computed: true, Name: f.NewStringLiteralFromNode(name)}
+	} else {
+		expression := name.Expression()
+		if ast.IsPropertyNameLiteral(expression) && !ast.IsIdentifier(expression) {
+			propertyName = printer.ESDecorateName{Computed: true, Name: f.NewStringLiteralFromNode(expression)}
+		} else {
+			referencedName, updatedName := tx.visitReferencedPropertyName(name)
+			result.referencedName = referencedName
+			result.name = updatedName
+			propertyName = printer.ESDecorateName{Computed: true, Name: referencedName}
+		}
+	}
+	if result.name == nil {
+		result.name = tx.visitPropertyName(name)
+	}
+
+	context := &printer.ESDecorateContext{
+		Kind:     kind,
+		Name:     propertyName,
+		Static:   isStatic,
+		Private:  ast.IsPrivateIdentifier(name),
+		HasGet:   kind == "getter" || kind == "method" || kind == "accessor" || kind == "field",
+		HasSet:   kind == "setter" || kind == "accessor" || kind == "field",
+		Metadata: info.metadataReference.Clone(f),
+	}
+
+	if ast.IsMethodDeclaration(member) || ast.IsAccessor(member) {
+		// __esDecorate(this, null, _x_decorators, { kind: "method", ... }, null, _instanceExtraInitializers);
+		var descriptor *ast.Expression
+		if ast.IsPrivateIdentifier(name) && createDescriptor != nil {
+			memberInfo.descriptorName = tx.createHelperVariable(member, "descriptor")
+			descriptor = f.NewAssignmentExpression(memberInfo.descriptorName.Clone(f), createDescriptor(member, transformers.ExtractModifiers(ec, result.modifiers, ast.ModifierFlagsAsync)))
+		}
+		var extraInitializers *ast.IdentifierNode
+		if isStatic {
+			extraInitializers = info.staticMethodExtraInitializersName
+		} else {
+			extraInitializers = info.instanceMethodExtraInitializersName
+		}
+		esDecorateExpression := f.NewESDecorateHelper(
+			f.NewThisExpression(),
+			descriptor,
+			memberInfo.decoratorsName.Clone(f),
+			context,
+			f.NewKeywordExpression(ast.KindNullKeyword),
+			extraInitializers.Clone(f),
+		)
+		statement := f.NewExpressionStatement(esDecorateExpression)
+		if isStatic {
+			info.staticNonFieldDecorationStatements = append(info.staticNonFieldDecorationStatements, statement)
+		} else {
+			info.nonStaticNonFieldDecorationStatements = append(info.nonStaticNonFieldDecorationStatements, statement)
+		}
+	} else {
+		// __esDecorate(null, null, _x_decorators, { kind: "field", ... }, _x_initializers, _x_extraInitializers);
+		memberInfo.initializersName = tx.createHelperVariable(member, "initializers")
+		memberInfo.extraInitializersName = tx.createHelperVariable(member, "extraInitializers")
+		if isStatic {
+			result.thisArg = info.classThis
+		}
+
+		var descriptor *ast.Expression
+		if ast.IsPrivateIdentifier(name) && ast.IsAutoAccessorPropertyDeclaration(member) && createDescriptor != nil {
+			memberInfo.descriptorName = tx.createHelperVariable(member, "descriptor")
+			descriptor = f.NewAssignmentExpression(memberInfo.descriptorName.Clone(f), createDescriptor(member, nil /*modifiers*/))
+		}
+		var ctor *ast.Expression
+		if ast.IsAutoAccessorPropertyDeclaration(member) {
+			ctor = f.NewThisExpression()
+		}
+		esDecorateExpression := f.NewESDecorateHelper(
+			ctor,
+			descriptor,
+			memberInfo.decoratorsName.Clone(f),
+			context,
+			memberInfo.initializersName.Clone(f),
+			memberInfo.extraInitializersName.Clone(f),
+		)
+		statement := f.NewExpressionStatement(esDecorateExpression)
+		if isStatic {
+			info.staticFieldDecorationStatements = append(info.staticFieldDecorationStatements, statement)
+		} else {
+			info.nonStaticFieldDecorationStatements = append(info.nonStaticFieldDecorationStatements, statement)
+		}
+	}
+
+	result.initializersName = memberInfo.initializersName
+	result.extraInitializersName = memberInfo.extraInitializersName
+	result.descriptorName = memberInfo.descriptorName
+	return result
+}
+
+// Visits the parameters and body of a method or accessor, which have their own `this`.
+func (tx *esDecoratorTransformer) visitFunctionLikeMember(member *ast.ClassElement) (parameters *ast.ParameterList, body *ast.BlockNode) {
+	ec := tx.EmitContext()
+	savedClassThis, savedClassSuper, savedPendingExpressions := tx.classThis, tx.classSuper, tx.pendingExpressions
+	tx.classThis, tx.classSuper, tx.pendingExpressions = nil, nil, nil
+	parameters = ec.VisitParameters(member.ParameterList(), tx.Visitor())
+	body = ec.VisitFunctionBody(member.Body(), tx.Visitor())
+	tx.classThis, tx.classSuper, tx.pendingExpressions = savedClassThis, savedClassSuper, savedPendingExpressions
+	return parameters, body
+}
+
+// Creates the descriptor of a decorated private method or accessor:
+//
+//	{ value: __setFunctionName(function () { ... }, "#m") }
+//	{ get: __setFunctionName(function () { ... }, "#x", "get") }
+func (tx *esDecoratorTransformer) createMethodDescriptorObject(member *ast.ClassElement, modifiers *ast.ModifierList) *ast.Expression {
+	f := tx.Factory()
+	parameters, body := tx.visitFunctionLikeMember(member)
+	var asteriskToken *ast.TokenNode
+	if ast.IsMethodDeclaration(member) {
+		asteriskToken = member.AsMethodDeclaration().AsteriskToken
+	}
+	function := f.NewFunctionExpression(modifiers, asteriskToken, nil /*name*/, nil /*typeParameters*/, parameters, nil /*returnType*/, body)
+	tx.EmitContext().SetOriginal(function, member)
+
+	var property string
+	var prefix string
+	switch member.Kind {
+	case ast.KindGetAccessor:
+		property, prefix = "get", "get"
+	case ast.KindSetAccessor:
+		property, prefix = "set", "set"
+	default:
+		property = "value"
+	}
+	value := f.NewSetFunctionNameHelper(function, f.NewStringLiteralFromNode(member.Name()), prefix)
+	propertyAssignment := f.NewPropertyAssignment(nil /*modifiers*/, f.NewIdentifier(property), nil /*postfixToken*/, nil /*typeNode*/, value)
+	return f.NewObjectLiteralExpression(f.NewNodeList([]*ast.Node{propertyAssignment}), false /*multiLine*/)
+}
+
+// Creates the descriptor of a decorated private auto-accessor, whose functions access the backing field:
+//
+//	{ get: __setFunctionName(function () { return this.#x_accessor_storage; }, "#x", "get"), set: ... }
+func (tx *esDecoratorTransformer) createAccessorPropertyDescriptorObject(member *ast.ClassElement, modifiers *ast.ModifierList) *ast.Expression {
+	f := tx.Factory()
+	storage := func() *ast.Expression {
+		return f.NewPropertyAccessExpression(f.NewThisExpression(), nil /*questionDotToken*/, tx.createAccessorStorageName(member), ast.NodeFlagsNone)
+	}
+	getter := f.NewFunctionExpression(
+		nil, /*modifiers*/
+		nil, /*asteriskToken*/
+		nil, /*name*/
+		nil, /*typeParameters*/
+		f.NewNodeList(nil),
+		nil, /*returnType*/
+		f.NewBlock(f.NewNodeList([]*ast.Statement{f.NewReturnStatement(storage())}), false /*multiLine*/),
+	)
+	setter := f.NewFunctionExpression(
+		nil, /*modifiers*/
+		nil, /*asteriskToken*/
+		nil, /*name*/
+		nil, /*typeParameters*/
+		f.NewNodeList([]*ast.Node{tx.createValueParameter()}),
+		nil, /*returnType*/
+		f.NewBlock(f.NewNodeList([]*ast.Statement{f.NewExpressionStatement(f.NewAssignmentExpression(storage(), f.NewIdentifier("value")))}), false /*multiLine*/),
+	)
+	return f.NewObjectLiteralExpression(f.NewNodeList([]*ast.Node{
+		f.NewPropertyAssignment(nil /*modifiers*/, f.NewIdentifier("get"), nil /*postfixToken*/, nil /*typeNode*/, f.NewSetFunctionNameHelper(getter, f.NewStringLiteralFromNode(member.Name()), "get")),
+		f.NewPropertyAssignment(nil /*modifiers*/, f.NewIdentifier("set"), nil /*postfixToken*/, nil /*typeNode*/, f.NewSetFunctionNameHelper(setter, f.NewStringLiteralFromNode(member.Name()), "set")),
+	}), false /*multiLine*/)
+}
+
+func (tx *esDecoratorTransformer) createValueParameter() *ast.Node {
+	return tx.Factory().NewParameterDeclaration(nil /*modifiers*/, nil /*dotDotDotToken*/, tx.Factory().NewIdentifier("value"), nil /*questionToken*/, nil /*typeNode*/, nil /*initializer*/)
+}
+
+// Gets the name of the private field that stores the value of an auto-accessor, e.g. `#x_accessor_storage`.
+func (tx *esDecoratorTransformer) createAccessorStorageName(member *ast.ClassElement) *ast.PrivateIdentifierNode {
+	return tx.Factory().NewGeneratedPrivateNameForNodeEx(member.Name(), printer.AutoGenerateOptions{Suffix: "_accessor_storage"})
+}
+
+// Keeps only the `static` modifier of a class element, for the accessors that forward to a lowered element.
+func (tx *esDecoratorTransformer) staticModifiers(modifiers *ast.ModifierList) *ast.ModifierList {
+	return transformers.ExtractModifiers(tx.EmitContext(), modifiers, ast.ModifierFlagsStatic)
+}
+
+// Creates an accessor that forwards to the decorated implementation of a private method or accessor:
+//
+//	get #m() { return _private_m_descriptor.value; }
+//	get #x() { return _private_get_x_descriptor.get.call(this); }
+//	set #x(value) { return _private_set_x_descriptor.set.call(this, value); }
+func (tx *esDecoratorTransformer) createDescriptorForwarder(kind ast.Kind, modifiers *ast.ModifierList, name *ast.PropertyName, descriptorName *ast.IdentifierNode) *ast.Node {
+	f := tx.Factory()
+	switch kind {
+	case ast.KindMethodDeclaration:
+		value := f.NewPropertyAccessExpression(descriptorName.Clone(f), nil /*questionDotToken*/, f.NewIdentifier("value"), ast.NodeFlagsNone)
+		body := f.NewBlock(f.NewNodeList([]*ast.Statement{f.NewReturnStatement(value)}), false /*multiLine*/)
+		return f.NewGetAccessorDeclaration(modifiers, name, nil /*typeParameters*/, f.NewNodeList(nil), nil /*returnType*/, body)
+	case ast.KindGetAccessor:
+		target := f.NewPropertyAccessExpression(descriptorName.Clone(f), nil /*questionDotToken*/, f.NewIdentifier("get"), ast.NodeFlagsNone)
+		call := f.NewCallExpression(
+			f.NewPropertyAccessExpression(target, nil /*questionDotToken*/, f.NewIdentifier("call"), ast.NodeFlagsNone),
+			nil, /*questionDotToken*/
+			nil, /*typeArguments*/
+			f.NewNodeList([]*ast.Expression{f.NewThisExpression()}),
+			ast.NodeFlagsNone,
+		)
+		body := f.NewBlock(f.NewNodeList([]*ast.Statement{f.NewReturnStatement(call)}), false /*multiLine*/)
+		return f.NewGetAccessorDeclaration(modifiers, name, nil /*typeParameters*/, f.NewNodeList(nil), nil /*returnType*/, body)
+	default:
+		target := f.NewPropertyAccessExpression(descriptorName.Clone(f), nil /*questionDotToken*/, f.NewIdentifier("set"), ast.NodeFlagsNone)
+		call := f.NewCallExpression(
+			f.NewPropertyAccessExpression(target, nil /*questionDotToken*/, f.NewIdentifier("call"), ast.NodeFlagsNone),
+			nil, /*questionDotToken*/
+			nil, /*typeArguments*/
+			f.NewNodeList([]*ast.Expression{f.NewThisExpression(), f.NewIdentifier("value")}),
+			ast.NodeFlagsNone,
+		)
+		body := f.NewBlock(f.NewNodeList([]*ast.Statement{f.NewReturnStatement(call)}), false /*multiLine*/)
+		return f.NewSetAccessorDeclaration(modifiers, name, nil /*typeParameters*/, f.NewNodeList([]*ast.Node{tx.createValueParameter()}), nil /*returnType*/, body)
+	}
+}
+
+func (tx *esDecoratorTransformer) visitMethodDeclaration(member *ast.ClassElement) *ast.Node {
+	ec := tx.EmitContext()
+	f := tx.Factory()
+	element := tx.partialTransformClassElement(member, tx.createMethodDescriptorObject)
+	if element.descriptorName != nil {
+		forwarder := tx.createDescriptorForwarder(member.Kind, tx.staticModifiers(element.modifiers), element.name, element.descriptorName)
+		ec.SetOriginal(forwarder, member)
+		forwarder.Loc = member.Loc
+		return forwarder
+	}
+	parameters, body := tx.visitFunctionLikeMember(member)
+	method := member.AsMethodDeclaration()
+	return f.UpdateMethodDeclaration(method, element.modifiers, method.AsteriskToken, element.name, nil /*postfixToken*/, nil /*typeParameters*/, parameters, nil /*returnType*/, body)
+}
+
+func (tx *esDecoratorTransformer) visitGetAccessorDeclaration(member *ast.ClassElement) *ast.Node {
+	ec := tx.EmitContext()
+	f := tx.Factory()
+	element := tx.partialTransformClassElement(member, tx.createMethodDescriptorObject)
+	if element.descriptorName != nil {
+		forwarder := tx.createDescriptorForwarder(member.Kind, tx.staticModifiers(element.modifiers), element.name, element.descriptorName)
+		ec.SetOriginal(forwarder, member)
+		forwarder.Loc = member.Loc
+		return forwarder
+	}
+	parameters, body := tx.visitFunctionLikeMember(member)
+	return f.UpdateGetAccessorDeclaration(member.AsGetAccessorDeclaration(), element.modifiers, element.name, nil /*typeParameters*/, parameters, nil /*returnType*/, body)
+}
+
+func (tx *esDecoratorTransformer) visitSetAccessorDeclaration(member *ast.ClassElement) *ast.Node {
+	ec := tx.EmitContext()
+	f := tx.Factory()
+	element := tx.partialTransformClassElement(member, tx.createMethodDescriptorObject)
+	if element.descriptorName != nil {
+		forwarder := tx.createDescriptorForwarder(member.Kind, tx.staticModifiers(element.modifiers), element.name, element.descriptorName)
+		ec.SetOriginal(forwarder, member)
+		forwarder.Loc = member.Loc
+		return forwarder
+	}
+	parameters, body := tx.visitFunctionLikeMember(member)
+	return f.UpdateSetAccessorDeclaration(member.AsSetAccessorDeclaration(), element.modifiers, element.name, nil /*typeParameters*/, parameters, nil /*returnType*/, body)
 }
 
-func (ch *esDecoratorTransformer) visit(node *ast.Node) *ast.Node {
-	return node // !!!
+// Prepends the extra initializers of preceding elements to the initializer of a field:
+//
+//	x = (__runInitializers(this, _y_extraInitializers), init)
+func (tx *esDecoratorTransformer) injectPendingInitializers(isStatic bool, initializer *ast.Expression) *ast.Expression {
+	info := tx.classInfo
+	pending := &info.pendingInstanceInitializers
+	if isStatic {
+		pending = &info.pendingStaticInitializers
+	}
+	if len(*pending) == 0 {
+		return initializer
+	}
+	f := tx.Factory()
+	if initializer == nil {
+		initializer = f.NewVoidZeroExpression()
+	}
+	initializer = f.NewParenthesizedExpression(f.InlineExpressions(append(*pending, initializer)))
+	*pending = nil
+	return initializer
 }
 
-func newESDecoratorTransformer(emitContext *printer.EmitContext) *transformers.Transformer {
-	tx := &esDecoratorTransformer{}
-	return tx.NewTransformer(tx.visit, emitContext)
+func (tx *esDecoratorTransformer) visitPropertyDeclaration(member *ast.ClassElement) *ast.Node {
+	ec := tx.EmitContext()
+	f := tx.Factory()
+	info := tx.classInfo
+	isStatic := ast.IsStatic(member)
+
+	if isNamedEvaluationAnd(ec, member, isAnonymousDecoratedClass) {
+		member = transformNamedEvaluation(ec, member, canIgnoreEmptyStringLiteralInAssignedName(member.Initializer()), "")
+	}
+
+	var createDescriptor func(*ast.ClassElement, *ast.ModifierList) *ast.Expression
+	if ast.IsPrivateIdentifier(member.Name()) && ast.IsAutoAccessorPropertyDeclaration(member) {
+		createDescriptor = tx.createAccessorPropertyDescriptorObject
+	}
+	element := tx.partialTransformClassElement(member, createDescriptor)
+
+	// Static field initializers are evaluated with `this` and `super` bound to the decorated class.
+	savedClassThis, savedClassSuper := tx.classThis, tx.classSuper
+	if isStatic {
+		tx.classThis, tx.classSuper = info.classThis, info.classSuper
+	} else {
+		tx.classThis, tx.classSuper = nil, nil
+	}
+	ec.StartVariableEnvironment()
+	initializer := tx.Visitor().VisitNode(member.Initializer())
+	if element.initializersName != nil {
+		// __runInitializers(this, _x_initializers, init)
+		thisArg := f.NewThisExpression()
+		if element.thisArg != nil {
+			thisArg = element.thisArg.Clone(f)
+		}
+		if initializer == nil {
+			initializer = f.NewVoidZeroExpression()
+		}
+		initializer = f.NewRunInitializersHelper(thisArg, element.initializersName.Clone(f), initializer)
+	}
+	if isStatic && initializer != nil {
+		info.hasStaticInitializers = true
+	}
+	if declarations := ec.EndVariableEnvironment(); len(declarations) > 0 {
+		// (() => { var _a; return init; })()
+		statements := append(declarations, f.NewReturnStatement(initializer))
+		initializer = createImmediatelyInvokedArrowFunction(f, f.NewBlock(f.NewNodeList(statements), false /*multiLine*/))
+	}
+	tx.classThis, tx.classSuper = savedClassThis, savedClassSuper
+
+	initializer = tx.injectPendingInitializers(isStatic, initializer)
+	if element.extraInitializersName != nil {
+		// The extra initializers of a field run before the next field is initialized.
+		thisArg := f.NewThisExpression()
+		if element.thisArg != nil {
+			thisArg = element.thisArg.Clone(f)
+		}
+		runExtraInitializers := f.NewRunInitializersHelper(thisArg, element.extraInitializersName.Clone(f), nil /*value*/)
+		if isStatic {
+			info.pendingStaticInitializers = append(info.pendingStaticInitializers, runExtraInitializers)
+		} else {
+			info.pendingInstanceInitializers = append(info.pendingInstanceInitializers, runExtraInitializers)
+		}
+	}
+
+	if !ast.IsAutoAccessorPropertyDeclaration(member) || !ast.HasDecorators(member) {
+		return f.UpdatePropertyDeclaration(member.AsPropertyDeclaration(), element.modifiers, element.name, nil /*postfixToken*/, nil /*typeNode*/, initializer)
+	}
+
+	// A decorated auto-accessor is lowered to a private backing field and a getter and setter that access it:
+	//
+	//	#x_accessor_storage = init;
+	//	get x() { return this.#x_accessor_storage; }
+	//	set x(value) { this.#x_accessor_storage = value; }
+	storageModifiers := tx.staticModifiers(element.modifiers)
+	backingField := f.NewPropertyDeclaration(storageModifiers, tx.createAccessorStorageName(member), nil /*postfixToken*/, nil /*typeNode*/, initializer)
+	ec.SetOriginal(backingField, member)
+	backingField.Loc = member.Loc
+
+	if element.descriptorName != nil {
+		getter := tx.createDescriptorForwarder(ast.KindGetAccessor, tx.staticModifiers(element.modifiers), element.name, element.descriptorName)
+		setter := tx.createDescriptorForwarder(ast.KindSetAccessor, tx.staticModifiers(element.modifiers), member.Name().Clone(f), element.descriptorName)
+		return f.NewSyntaxList([]*ast.Node{backingField, getter, setter})
+	}
+
+	// The receiver of a static accessor is the class itself, as the backing field only exists on the class.
+	receiver := func() *ast.Expression {
+		if isStatic {
+			if info.classThis != nil {
+				return info.classThis.Clone(f)
+			}
+			if info.class.Name() != nil {
+				return info.class.Name().Clone(f)
+			}
+		}
+		return f.NewThisExpression()
+	}
+	storage := func() *ast.Expression {
+		return f.NewPropertyAccessExpression(receiver(), nil /*questionDotToken*/, tx.createAccessorStorageName(member), ast.NodeFlagsNone)
+	}
+
+	// The getter takes the visited name, which evaluates the property key, and the setter reuses the key.
+	getterName := element.name
+	var setterName *ast.PropertyName
+	switch {
+	case element.referencedName != nil:
+		setterName = f.NewComputedPropertyName(element.referencedName.Clone(f))
+	case ast.IsComputedPropertyName(element.name):
+		setterName = f.NewComputedPropertyName(element.name.Expression().Clone(f))
+	default:
+		setterName = element.name.Clone(f)
+	}
+	getterBody := f.NewBlock(f.NewNodeList([]*ast.Statement{f.NewReturnStatement(storage())}), false /*multiLine*/)
+	getter := f.NewGetAccessorDeclaration(tx.staticModifiers(element.modifiers), getterName, nil /*typeParameters*/, f.NewNodeList(nil), nil /*returnType*/, getterBody)
+	ec.SetOriginal(getter, member)
+	setterBody := f.NewBlock(f.NewNodeList([]*ast.Statement{f.NewExpressionStatement(f.NewAssignmentExpression(storage(), f.NewIdentifier("value")))}), false /*multiLine*/)
+	setter := f.NewSetAccessorDeclaration(tx.staticModifiers(element.modifiers), setterName, nil /*typeParameters*/, f.NewNodeList([]*ast.Node{tx.createValueParameter()}), nil /*returnType*/, setterBody)
+	ec.SetOriginal(setter, member)
+	return f.NewSyntaxList([]*ast.Node{backingField, getter, setter})
+}
+
+func (tx *esDecoratorTransformer) visitClassStaticBlockDeclaration(member *ast.ClassElement) *ast.Node {
+	ec := tx.EmitContext()
+	f := tx.Factory()
+	info := tx.classInfo
+
+	if isClassNamedEvaluationHelperBlock(ec, member) {
+		return tx.Visitor().VisitNode(member)
+	}
+	if isClassThisAssignmentBlock(ec, member) {
+		savedClassThis := tx.classThis
+		tx.classThis = nil
+		result := tx.Visitor().VisitNode(member)
+		tx.classThis = savedClassThis
+		return result
+	}
+
+	info.hasStaticInitializers = true
+	savedClassThis, savedClassSuper := tx.classThis, tx.classSuper
+	tx.classThis, tx.classSuper = info.classThis, info.classSuper
+	result := tx.Visitor().VisitEachChild(member)
+	tx.classThis, tx.classSuper = savedClassThis, savedClassSuper
+
+	if len(info.pendingStaticInitializers) == 0 {
+		return result
+	}
+
+	// The extra initializers of preceding static elements run before the block.
+	var statements []*ast.Statement
+	for _, initializer := range info.pendingStaticInitializers {
+		statements = append(statements, f.NewExpressionStatement(initializer))
+	}
+	info.pendingStaticInitializers = nil
+	block := f.NewClassStaticBlockDeclaration(nil /*modifiers*/, f.NewBlock(f.NewNodeList(statements), true /*multiLine*/))
+	return f.NewSyntaxList([]*ast.Node{block, result})
+}
+
+// Adds the extra initializers of instance elements to the constructor, after the `super` call if the class is derived.
+func (tx *esDecoratorTransformer) visitConstructorDeclaration(member *ast.ClassElement) *ast.Node {
+	ec := tx.EmitContext()
+	f := tx.Factory()
+	info := tx.classInfo
+
+	savedClassThis, savedClassSuper, savedPendingExpressions := tx.classThis, tx.classSuper, tx.pendingExpressions
+	tx.classThis, tx.classSuper, tx.pendingExpressions = nil, nil, nil
+	defer func() {
+		tx.classThis, tx.classSuper, tx.pendingExpressions = savedClassThis, savedClassSuper, savedPendingExpressions
+	}()
+
+	constructor := member.AsConstructorDeclaration()
+	if len(info.pendingInstanceInitializers) == 0 || constructor.Body == nil {
+		return tx.Visitor().VisitEachChild(member)
+	}
+
+	parameters := ec.VisitParameters(constructor.Parameters, tx.Visitor())
+	initializer := f.NewExpressionStatement(f.InlineExpressions(info.pendingInstanceInitializers))
+	info.pendingInstanceInitializers = nil
+
+	body := constructor.Body.AsBlock()
+	prologue, rest := f.SplitStandardPrologue(body.Statements.Nodes)
+	statements := slices.Clone(prologue)
+	var superPath []int
+	if info.classSuper != nil {
+		superPath = findSuperStatementIndexPath(rest, 0)
+	}
+	statements = append(statements, tx.transformConstructorBodyWorker(rest, superPath, initializer)...)
+	statements = ec.EndAndMergeVariableEnvironment(statements)
+	statementList := f.NewNodeList(statements)
+	statementList.Loc = body.Statements.Loc
+	return f.UpdateConstructorDeclaration(
+		constructor,
+		tx.Visitor().VisitModifiers(constructor.Modifiers()),
+		nil, /*typeParameters*/
+		parameters,
+		nil, /*returnType*/
+		f.UpdateBlock(body, statementList),
+	)
+}
+
+// Visits the statements of a constructor body, adding the initializer after the `super` call found at superPath
+// (descending into `try` blocks), or at the start of the body when the class is not derived.
+func (tx *esDecoratorTransformer) transformConstructorBodyWorker(statementsIn []*ast.Statement, superPath []int, initializer *ast.Statement) []*ast.Statement {
+	f := tx.Factory()
+	statements := make([]*ast.Statement, 0, len(statementsIn)+1)
+	if len(superPath) == 0 {
+		statements = append(statements, initializer)
+		visited, _ := tx.Visitor().VisitSlice(statementsIn)
+		return append(statements, visited...)
+	}
+
+	superIndex := superPath[0]
+	visited, _ := tx.Visitor().VisitSlice(statementsIn[:superIndex])
+	statements = append(statements, visited...)
+	superStatement := statementsIn[superIndex]
+	if ast.IsTryStatement(superStatement) {
+		tryStatement := superStatement.AsTryStatement()
+		tryBlock := tryStatement.TryBlock.AsBlock()
+		tryStatements := f.NewNodeList(tx.transformConstructorBodyWorker(tryBlock.Statements.Nodes, superPath[1:], initializer))
+		tryStatements.Loc = tryBlock.Statements.Loc
+		statements = append(statements, f.UpdateTryStatement(
+			tryStatement,
+			f.UpdateBlock(tryBlock, tryStatements),
+			tx.Visitor().VisitNode(tryStatement.CatchClause),
+			tx.Visitor().VisitNode(tryStatement.FinallyBlock),
+		))
+	} else {
+		statements = append(statements, tx.Visitor().VisitNode(superStatement), initializer)
+	}
+	visited, _ = tx.Visitor().VisitSlice(statementsIn[superIndex+1:])
+	return append(statements, visited...)
+}
+
+//
+// `super` in static initializers
+//
+
+func (tx *esDecoratorTransformer) visitPropertyOrElementAccessExpression(node *ast.Node) *ast.Node {
+	if tx.classThis == nil || tx.classSuper == nil || !isSuperProperty(node) {
+		return tx.Visitor().VisitEachChild(node)
+	}
+
+	// Reflect.get(_classSuper, key, _classThis)
+	result := tx.newReflectCall("get", tx.classSuper.Clone(tx.Factory()), tx.visitSuperPropertyKey(node), tx.classThis.Clone(tx.Factory()))
+	tx.EmitContext().SetOriginal(result, node)
+	result.Loc = node.Loc
+	return result
+}
+
+func (tx *esDecoratorTransformer) visitSuperPropertyKey(node *ast.Node) *ast.Expression {
+	if ast.IsPropertyAccessExpression(node) {
+		return tx.Factory().NewStringLiteralFromNode(node.Name())
+	}
+	return tx.Visitor().VisitNode(node.AsElementAccessExpression().ArgumentExpression)
+}
+
+func (tx *esDecoratorTransformer) newReflectCall(method string, arguments ...*ast.Expression) *ast.Expression {
+	return tx.Factory().NewCallExpression(
+		tx.Factory().NewPropertyAccessExpression(tx.Factory().NewIdentifier("Reflect"), nil, tx.Factory().NewIdentifier(method), ast.NodeFlagsNone),
+		nil, /*questionDotToken*/
+		nil, /*typeArguments*/
+		tx.Factory().NewNodeList(arguments),
+		ast.NodeFlagsNone,
+	)
+}
+
+func (tx *esDecoratorTransformer) visitCallExpression(node *ast.CallExpression) *ast.Node {
+	if tx.classThis == nil || tx.classSuper == nil || !isSuperProperty(node.Expression) {
+		return tx.Visitor().VisitEachChild(node.AsNode())
+	}
+
+	// Reflect.get(_classSuper, key, _classThis).call(_classThis, ...arguments)
+	f := tx.Factory()
+	target := tx.Visitor().VisitNode(node.Expression)
+	arguments := tx.Visitor().VisitNodes(node.Arguments)
+	result := f.NewCallExpression(
+		f.NewPropertyAccessExpression(target, nil /*questionDotToken*/, f.NewIdentifier("call"), ast.NodeFlagsNone),
+		nil, /*questionDotToken*/
+		nil, /*typeArguments*/
+		f.NewNodeList(slices.Concat([]*ast.Expression{tx.classThis.Clone(f)}, arguments.Nodes)),
+		ast.NodeFlagsNone,
+	)
+	tx.EmitContext().SetOriginal(result, node.AsNode())
+	result.Loc = node.Loc
+	return result
+}
+
+func (tx *esDecoratorTransformer) visitTaggedTemplateExpression(node *ast.TaggedTemplateExpression) *ast.Node {
+	if tx.classThis == nil || tx.classSuper == nil || !isSuperProperty(node.Tag) {
+		return tx.Visitor().VisitEachChild(node.AsNode())
+	}
+
+	// Reflect.get(_classSuper, key, _classThis).bind(_classThis)`...`
+	f := tx.Factory()
+	tag := f.NewCallExpression(
+		f.NewPropertyAccessExpression(tx.Visitor().VisitNode(node.Tag), nil /*questionDotToken*/, f.NewIdentifier("bind"), ast.NodeFlagsNone),
+		nil, /*questionDotToken*/
+		nil, /*typeArguments*/
+		f.NewNodeList([]*ast.Expression{tx.classThis.Clone(f)}),
+		ast.NodeFlagsNone,
+	)
+	return f.UpdateTaggedTemplateExpression(node, tag, nil /*questionDotToken*/, nil /*typeArguments*/, tx.Visitor().VisitNode(node.Template))
+}
+
+func (tx *esDecoratorTransformer) visitBinaryExpression(node *ast.BinaryExpression) *ast.Node {
+	ec := tx.EmitContext()
+	if isNamedEvaluationAnd(ec, node.AsNode(), isAnonymousDecoratedClass) {
+		transformed := transformNamedEvaluation(ec, node.AsNode(), canIgnoreEmptyStringLiteralInAssignedName(node.Right), "")
+		return tx.Visitor().VisitEachChild(transformed)
+	}
+
+	left := ast.SkipParentheses(node.Left)
+	operator := node.OperatorToken.Kind
+	if tx.classThis == nil || tx.classSuper == nil || !isSuperProperty(left) || !ast.IsAssignmentOperator(operator) || ast.IsLogicalOrCoalescingAssignmentOperator(operator) {
+		// !!! `super` properties as destructuring assignment targets
+		return tx.Visitor().VisitEachChild(node.AsNode())
+	}
+
+	// Reflect.set(_classSuper, key, value, _classThis)
+	f := tx.Factory()
+	key := tx.visitSuperPropertyKey(left)
+	value := tx.Visitor().VisitNode(node.Right)
+	if operator != ast.KindEqualsToken {
+		// Reflect.set(_classSuper, _a = key, Reflect.get(_classSuper, _a, _classThis) + value, _classThis)
+		keyInitializer, keyRead := key, key
+		if !isSimpleInlineableExpression(key) {
+			temp := f.NewTempVariable()
+			ec.AddVariableDeclaration(temp)
+			keyInitializer, keyRead = f.NewAssignmentExpression(temp, key), temp.Clone(f)
+		} else {
+			keyRead = key.Clone(f)
+		}
+		key = keyInitializer
+		read := tx.newReflectCall("get", tx.classSuper.Clone(f), keyRead, tx.classThis.Clone(f))
+		value = f.NewBinaryExpression(nil /*modifiers*/, read, nil /*typeNode*/, f.NewToken(getNonAssignmentOperatorForCompoundAssignment(operator)), value)
+	}
+	result := tx.newReflectCall("set", tx.classSuper.Clone(f), key, value, tx.classThis.Clone(f))
+	ec.SetOriginal(result, node.AsNode())
+	result.Loc = node.Loc
+	return result
+}
+
+func (tx *esDecoratorTransformer) visitPreOrPostfixUnaryExpression(node *ast.Node) *ast.Node {
+	var operator ast.Kind
+	var operand *ast.Expression
+	if ast.IsPrefixUnaryExpression(node) {
+		operator, operand = node.AsPrefixUnaryExpression().Operator, node.AsPrefixUnaryExpression().Operand
+	} else {
+		operator, operand = node.AsPostfixUnaryExpression().Operator, node.AsPostfixUnaryExpression().Operand
+	}
+	operand = ast.SkipParentheses(operand)
+	if tx.classThis == nil || tx.classSuper == nil || !isSuperProperty(operand) || operator != ast.KindPlusPlusToken && operator != ast.KindMinusMinusToken {
+		return tx.Visitor().VisitEachChild(node)
+	}
+
+	// Reflect.set(_classSuper, _a = key, (_b = Reflect.get(_classSuper, _a, _classThis), _b++, _b), _classThis)
+	ec := tx.EmitContext()
+	f := tx.Factory()
+	key := tx.visitSuperPropertyKey(operand)
+	keyInitializer, keyRead := key, key
+	if !isSimpleInlineableExpression(key) {
+		temp := f.NewTempVariable()
+		ec.AddVariableDeclaration(temp)
+		keyInitializer, keyRead = f.NewAssignmentExpression(temp, key), temp.Clone(f)
+	} else {
+		keyRead = key.Clone(f)
+	}
+	read := tx.newReflectCall("get", tx.classSuper.Clone(f), keyRead, tx.classThis.Clone(f))
+	value := expandPreOrPostfixIncrementOrDecrementExpression(ec, node, read, nil /*resultVariable*/)
+	result := tx.newReflectCall("set", tx.classSuper.Clone(f), keyInitializer, value, tx.classThis.Clone(f))
+	ec.SetOriginal(result, node)
+	result.Loc = node.Loc
+	return result
 }
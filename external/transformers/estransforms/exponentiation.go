@@ -2,7 +2,6 @@ package estransforms
 
 import (
 	"github.com/pagpeter/typescript-go/external/ast"
-	"github.com/pagpeter/typescript-go/external/printer"
 	"github.com/pagpeter/typescript-go/external/transformers"
 )
 
@@ -14,7 +13,7 @@ func (ch *exponentiationTransformer) visit(node *ast.Node) *ast.Node {
 	return node // !!!
 }
 
-func newExponentiationTransformer(emitContext *printer.EmitContext) *transformers.Transformer {
+func newExponentiationTransformer(opts *transformers.TransformOptions) *transformers.Transformer {
 	tx := &exponentiationTransformer{}
-	return tx.NewTransformer(tx.visit, emitContext)
+	return tx.NewTransformer(tx.visit, opts.Context)
 }
@@ -1,6 +1,8 @@
 package estransforms
 
 import (
+	"slices"
+
 	"github.com/pagpeter/typescript-go/external/ast"
 	"github.com/pagpeter/typescript-go/external/printer"
 	"github.com/pagpeter/typescript-go/external/transformers"
@@ -8,13 +10,430 @@ import (
 
 type forawaitTransformer struct {
 	transformers.Transformer
+
+	inAsyncGeneratorBody bool // whether we are directly within the body of an async generator function
+	inIterationContainer bool // whether we are within an iteration statement in the current function
+}
+
+func newforawaitTransformer(opts *transformers.TransformOptions) *transformers.Transformer {
+	tx := &forawaitTransformer{}
+	return tx.NewTransformer(tx.visit, opts.Context)
 }
 
 func (ch *forawaitTransformer) visit(node *ast.Node) *ast.Node {
-	return node // !!!
+	// Within the body of an async generator every `await`, `yield`, and `return` must be rewritten, and those do not
+	// have subtree facts of their own.
+	if node.SubtreeFacts()&ast.SubtreeContainsForAwaitOrAsyncGenerator == 0 && !ch.inAsyncGeneratorBody {
+		return node
+	}
+	switch node.Kind {
+	case ast.KindSourceFile:
+		return ch.visitSourceFile(node.AsSourceFile())
+	case ast.KindAwaitExpression:
+		return ch.visitAwaitExpression(node.AsAwaitExpression())
+	case ast.KindYieldExpression:
+		return ch.visitYieldExpression(node.AsYieldExpression())
+	case ast.KindReturnStatement:
+		return ch.visitReturnStatement(node.AsReturnStatement())
+	case ast.KindLabeledStatement:
+		return ch.visitLabeledStatement(node.AsLabeledStatement())
+	case ast.KindForOfStatement:
+		if node.AsForInOrOfStatement().AwaitModifier != nil {
+			return ch.transformForAwaitOfStatement(node.AsForInOrOfStatement(), nil /*outermostLabeledStatement*/)
+		}
+		return ch.visitIterationStatement(node)
+	case ast.KindDoStatement, ast.KindWhileStatement, ast.KindForStatement, ast.KindForInStatement:
+		return ch.visitIterationStatement(node)
+	case ast.KindFunctionDeclaration, ast.KindFunctionExpression, ast.KindMethodDeclaration:
+		if isAsyncGeneratorFunction(node) {
+			return ch.visitAsyncGeneratorFunction(node)
+		}
+		return ch.visitFunctionOrClassLike(node)
+	case ast.KindArrowFunction, ast.KindConstructor, ast.KindGetAccessor, ast.KindSetAccessor,
+		ast.KindClassDeclaration, ast.KindClassExpression:
+		return ch.visitFunctionOrClassLike(node)
+	default:
+		return ch.Visitor().VisitEachChild(node)
+	}
 }
 
-func newforawaitTransformer(emitContext *printer.EmitContext) *transformers.Transformer {
-	tx := &forawaitTransformer{}
-	return tx.NewTransformer(tx.visit, emitContext)
+func (ch *forawaitTransformer) visitSourceFile(node *ast.SourceFile) *ast.Node {
+	if node.IsDeclarationFile {
+		return node.AsNode()
+	}
+	ch.inAsyncGeneratorBody = false
+	ch.inIterationContainer = false
+	visited := ch.Visitor().VisitEachChild(node.AsNode())
+	ch.EmitContext().AddEmitHelper(visited, ch.EmitContext().ReadEmitHelpers()...)
+	return visited
+}
+
+func (ch *forawaitTransformer) visitFunctionOrClassLike(node *ast.Node) *ast.Node {
+	savedInAsyncGeneratorBody := ch.inAsyncGeneratorBody
+	savedInIterationContainer := ch.inIterationContainer
+	ch.inAsyncGeneratorBody = false
+	ch.inIterationContainer = false
+	updated := ch.Visitor().VisitEachChild(node)
+	ch.inAsyncGeneratorBody = savedInAsyncGeneratorBody
+	ch.inIterationContainer = savedInIterationContainer
+	return updated
+}
+
+func (ch *forawaitTransformer) visitIterationStatement(node *ast.Node) *ast.Node {
+	savedInIterationContainer := ch.inIterationContainer
+	ch.inIterationContainer = true
+	updated := ch.Visitor().VisitEachChild(node)
+	ch.inIterationContainer = savedInIterationContainer
+	return updated
+}
+
+func (ch *forawaitTransformer) visitAwaitExpression(node *ast.AwaitExpression) *ast.Node {
+	if !ch.inAsyncGeneratorBody {
+		return ch.Visitor().VisitEachChild(node.AsNode())
+	}
+	// await x -> yield __await(x)
+	result := ch.Factory().NewYieldExpression(nil /*asteriskToken*/, ch.Factory().NewAwaitHelper(ch.Visitor().VisitNode(node.Expression)))
+	result.Loc = node.Loc
+	ch.EmitContext().SetOriginal(result, node.AsNode())
+	return result
+}
+
+func (ch *forawaitTransformer) visitYieldExpression(node *ast.YieldExpression) *ast.Node {
+	if !ch.inAsyncGeneratorBody {
+		return ch.Visitor().VisitEachChild(node.AsNode())
+	}
+
+	f := ch.Factory()
+	var result *ast.Expression
+	if node.AsteriskToken != nil {
+		// yield* x -> yield __await(yield* __asyncDelegator(__asyncValues(x)))
+		expression := ch.Visitor().VisitNode(node.Expression)
+		values := f.NewAsyncValuesHelper(expression)
+		values.Loc = expression.Loc
+		delegator := f.NewAsyncDelegatorHelper(values)
+		delegator.Loc = expression.Loc
+		result = f.NewYieldExpression(nil /*asteriskToken*/, f.NewAwaitHelper(f.UpdateYieldExpression(node, node.AsteriskToken, delegator)))
+	} else {
+		// yield x -> yield yield __await(x)
+		var expression *ast.Expression
+		if node.Expression != nil {
+			expression = ch.Visitor().VisitNode(node.Expression)
+		} else {
+			expression = f.NewVoidZeroExpression()
+		}
+		result = f.NewYieldExpression(nil /*asteriskToken*/, ch.createDownlevelAwait(expression))
+	}
+	result.Loc = node.Loc
+	ch.EmitContext().SetOriginal(result, node.AsNode())
+	return result
+}
+
+func (ch *forawaitTransformer) visitReturnStatement(node *ast.ReturnStatement) *ast.Node {
+	if !ch.inAsyncGeneratorBody {
+		return ch.Visitor().VisitEachChild(node.AsNode())
+	}
+	// return x -> return yield __await(x)
+	var expression *ast.Expression
+	if node.Expression != nil {
+		expression = ch.Visitor().VisitNode(node.Expression)
+	} else {
+		expression = ch.Factory().NewVoidZeroExpression()
+	}
+	return ch.Factory().UpdateReturnStatement(node, ch.createDownlevelAwait(expression))
+}
+
+func (ch *forawaitTransformer) visitLabeledStatement(node *ast.LabeledStatement) *ast.Node {
+	statement := unwrapInnermostStatementOfLabel(node)
+	if ast.IsForOfStatement(statement) && statement.AsForInOrOfStatement().AwaitModifier != nil {
+		return ch.transformForAwaitOfStatement(statement.AsForInOrOfStatement(), node)
+	}
+	return ch.Visitor().VisitEachChild(node.AsNode())
+}
+
+// Creates an expression that awaits a value. Within an async generator, `await` must be expressed as a `yield` of
+// the `__await` helper so that the generator can distinguish it from a user-level `yield`.
+func (ch *forawaitTransformer) createDownlevelAwait(expression *ast.Expression) *ast.Expression {
+	if ch.inAsyncGeneratorBody {
+		return ch.Factory().NewYieldExpression(nil /*asteriskToken*/, ch.Factory().NewAwaitHelper(expression))
+	}
+	return ch.Factory().NewAwaitExpression(expression)
+}
+
+// Lowers a `for await..of` statement to a `for` statement that steps through the result of `__asyncValues`, closing
+// the iterator if the loop exits early:
+//
+//	for await (const x of y) { ... }
+//
+// becomes
+//
+//	try {
+//	    for (var _a = true, y_1 = __asyncValues(y), y_1_1; y_1_1 = await y_1.next(), _b = y_1_1.done, !_b; _a = true) {
+//	        _d = y_1_1.value;
+//	        _a = false;
+//	        const x = _d;
+//	        ...
+//	    }
+//	}
+//	catch (e_1_1) { e_1 = { error: e_1_1 }; }
+//	finally {
+//	    try {
+//	        if (!_a && !_b && (_c = y_1.return)) await _c.call(y_1);
+//	    }
+//	    finally { if (e_1) throw e_1.error; }
+//	}
+func (ch *forawaitTransformer) transformForAwaitOfStatement(node *ast.ForInOrOfStatement, outermostLabeledStatement *ast.LabeledStatement) *ast.Node {
+	f := ch.Factory()
+	expression := ch.Visitor().VisitNode(node.Expression)
+	var iterator, result *ast.IdentifierNode
+	if ast.IsIdentifier(expression) {
+		iterator = f.NewGeneratedNameForNode(expression)
+		result = f.NewGeneratedNameForNode(iterator)
+	} else {
+		iterator = f.NewTempVariable()
+		result = f.NewTempVariable()
+	}
+	nonUserCode := f.NewTempVariable()
+	done := f.NewTempVariable()
+	ch.EmitContext().AddVariableDeclaration(done)
+	errorRecord := f.NewUniqueName("e")
+	catchVariable := f.NewGeneratedNameForNode(errorRecord)
+	returnMethod := f.NewTempVariable()
+	ch.EmitContext().AddVariableDeclaration(errorRecord)
+	ch.EmitContext().AddVariableDeclaration(returnMethod)
+
+	callValues := f.NewAsyncValuesHelper(expression)
+	callValues.Loc = node.Expression.Loc
+	callNext := f.NewCallExpression(
+		f.NewPropertyAccessExpression(iterator.Clone(f), nil /*questionDotToken*/, f.NewIdentifier("next"), ast.NodeFlagsNone),
+		nil, /*questionDotToken*/
+		nil, /*typeArguments*/
+		f.NewNodeList(nil),
+		ast.NodeFlagsNone,
+	)
+	getDone := f.NewPropertyAccessExpression(result.Clone(f), nil /*questionDotToken*/, f.NewIdentifier("done"), ast.NodeFlagsNone)
+	getValue := f.NewPropertyAccessExpression(result.Clone(f), nil /*questionDotToken*/, f.NewIdentifier("value"), ast.NodeFlagsNone)
+	callReturn := f.NewCallExpression(
+		f.NewPropertyAccessExpression(returnMethod.Clone(f), nil /*questionDotToken*/, f.NewIdentifier("call"), ast.NodeFlagsNone),
+		nil, /*questionDotToken*/
+		nil, /*typeArguments*/
+		f.NewNodeList([]*ast.Expression{iterator.Clone(f)}),
+		ast.NodeFlagsNone,
+	)
+
+	// if we are enclosed in an outer loop ensure we reset 'errorRecord' per each iteration
+	initializer := callValues
+	if ch.inIterationContainer {
+		initializer = f.InlineExpressions([]*ast.Expression{
+			f.NewAssignmentExpression(errorRecord.Clone(f), f.NewVoidZeroExpression()),
+			callValues,
+		})
+	}
+
+	iteratorDeclaration := f.NewVariableDeclaration(iterator, nil /*exclamationToken*/, nil /*typeNode*/, initializer)
+	iteratorDeclaration.Loc = node.Expression.Loc
+	declarationList := f.NewVariableDeclarationList(ast.NodeFlagsNone, f.NewNodeList([]*ast.Node{
+		f.NewVariableDeclaration(nonUserCode, nil /*exclamationToken*/, nil /*typeNode*/, f.NewTrueExpression()),
+		iteratorDeclaration,
+		f.NewVariableDeclaration(result, nil /*exclamationToken*/, nil /*typeNode*/, nil /*initializer*/),
+	}))
+	declarationList.Loc = node.Expression.Loc
+	ch.EmitContext().SetEmitFlags(declarationList, printer.EFNoHoisting)
+
+	savedInIterationContainer := ch.inIterationContainer
+	ch.inIterationContainer = true
+	body := ch.convertForOfStatementHead(node, getValue, nonUserCode.Clone(f))
+	ch.inIterationContainer = savedInIterationContainer
+
+	forStatement := f.NewForStatement(
+		declarationList,
+		f.InlineExpressions([]*ast.Expression{
+			f.NewAssignmentExpression(result.Clone(f), ch.createDownlevelAwait(callNext)),
+			f.NewAssignmentExpression(done.Clone(f), getDone),
+			f.NewPrefixUnaryExpression(ast.KindExclamationToken, done.Clone(f)),
+		}),
+		f.NewAssignmentExpression(nonUserCode.Clone(f), f.NewTrueExpression()),
+		body,
+	)
+	forStatement.Loc = node.Loc
+	ch.EmitContext().SetEmitFlags(forStatement, printer.EFNoTokenTrailingSourceMaps)
+	ch.EmitContext().SetOriginal(forStatement, node.AsNode())
+
+	errorAssignment := f.NewExpressionStatement(f.NewAssignmentExpression(
+		errorRecord.Clone(f),
+		f.NewObjectLiteralExpression(f.NewNodeList([]*ast.Node{
+			f.NewPropertyAssignment(nil /*modifiers*/, f.NewIdentifier("error"), nil /*postfixToken*/, nil /*typeNode*/, catchVariable.Clone(f)),
+		}), false /*multiLine*/),
+	))
+	catchBlock := f.NewBlock(f.NewNodeList([]*ast.Statement{errorAssignment}), false /*multiLine*/)
+	ch.EmitContext().SetEmitFlags(catchBlock, printer.EFSingleLine)
+
+	closeIterator := f.NewIfStatement(
+		f.NewLogicalANDExpression(
+			f.NewLogicalANDExpression(
+				f.NewPrefixUnaryExpression(ast.KindExclamationToken, nonUserCode.Clone(f)),
+				f.NewPrefixUnaryExpression(ast.KindExclamationToken, done.Clone(f)),
+			),
+			f.NewAssignmentExpression(
+				returnMethod.Clone(f),
+				f.NewPropertyAccessExpression(iterator.Clone(f), nil /*questionDotToken*/, f.NewIdentifier("return"), ast.NodeFlagsNone),
+			),
+		),
+		f.NewExpressionStatement(ch.createDownlevelAwait(callReturn)),
+		nil, /*elseStatement*/
+	)
+	ch.EmitContext().SetEmitFlags(closeIterator, printer.EFSingleLine)
+
+	rethrow := f.NewIfStatement(
+		errorRecord.Clone(f),
+		f.NewThrowStatement(f.NewPropertyAccessExpression(errorRecord.Clone(f), nil /*questionDotToken*/, f.NewIdentifier("error"), ast.NodeFlagsNone)),
+		nil, /*elseStatement*/
+	)
+	ch.EmitContext().SetEmitFlags(rethrow, printer.EFSingleLine)
+	rethrowBlock := f.NewBlock(f.NewNodeList([]*ast.Statement{rethrow}), false /*multiLine*/)
+	ch.EmitContext().SetEmitFlags(rethrowBlock, printer.EFSingleLine)
+
+	return f.NewTryStatement(
+		f.NewBlock(f.NewNodeList([]*ast.Statement{restoreEnclosingLabel(f, forStatement, outermostLabeledStatement)}), true /*multiLine*/),
+		f.NewCatchClause(f.NewVariableDeclaration(catchVariable, nil /*exclamationToken*/, nil /*typeNode*/, nil /*initializer*/), catchBlock),
+		f.NewBlock(f.NewNodeList([]*ast.Statement{
+			f.NewTryStatement(
+				f.NewBlock(f.NewNodeList([]*ast.Statement{closeIterator}), true /*multiLine*/),
+				nil, /*catchClause*/
+				rethrowBlock,
+			),
+		}), true /*multiLine*/),
+	)
+}
+
+// Creates the body of the `for` statement produced for a `for await..of` statement, which reads the current value of
+// the iterator and binds it to the initializer of the original statement before running the original body.
+func (ch *forawaitTransformer) convertForOfStatementHead(node *ast.ForInOrOfStatement, boundValue *ast.Expression, nonUserCode *ast.IdentifierNode) *ast.Node {
+	f := ch.Factory()
+	value := f.NewTempVariable()
+	ch.EmitContext().AddVariableDeclaration(value)
+
+	iteratorValueStatement := f.NewExpressionStatement(f.NewAssignmentExpression(value, boundValue))
+	ch.EmitContext().SetSourceMapRange(iteratorValueStatement, node.Expression.Loc)
+	exitNonUserCodeStatement := f.NewExpressionStatement(f.NewAssignmentExpression(nonUserCode, f.NewFalseExpression()))
+	ch.EmitContext().SetSourceMapRange(exitNonUserCodeStatement, node.Expression.Loc)
+
+	statements := []*ast.Statement{
+		iteratorValueStatement,
+		exitNonUserCodeStatement,
+		ch.Visitor().VisitNode(createForOfBindingStatement(f, node.Initializer, value.Clone(f))),
+	}
+
+	statementsLocation := node.Statement.Loc
+	statement := ch.Visitor().VisitEmbeddedStatement(node.Statement)
+	if ast.IsBlock(statement) {
+		statements = append(statements, statement.AsBlock().Statements.Nodes...)
+		statementsLocation = statement.AsBlock().Statements.Loc
+	} else {
+		statements = append(statements, statement)
+	}
+
+	statementList := f.NewNodeList(statements)
+	statementList.Loc = statementsLocation
+	block := f.NewBlock(statementList, true /*multiLine*/)
+	block.Loc = statement.Loc
+	return block
+}
+
+// Lowers an async generator function to a function that returns the result of `__asyncGenerator`, passing a generator
+// function that contains the original body:
+//
+//	async function* f() { ... } -> function f() { return __asyncGenerator(this, arguments, function* f_1() { ... }); }
+func (ch *forawaitTransformer) visitAsyncGeneratorFunction(node *ast.Node) *ast.Node {
+	savedInAsyncGeneratorBody := ch.inAsyncGeneratorBody
+	savedInIterationContainer := ch.inIterationContainer
+	ch.inAsyncGeneratorBody = false
+	ch.inIterationContainer = false
+
+	f := ch.Factory()
+	modifiers := transformers.ExtractModifiers(ch.EmitContext(), node.Modifiers(), ^ast.ModifierFlagsAsync)
+	parameters := ch.EmitContext().VisitParameters(node.ParameterList(), ch.Visitor())
+	body := ch.transformAsyncGeneratorFunctionBody(node)
+
+	var updated *ast.Node
+	switch node.Kind {
+	case ast.KindFunctionDeclaration:
+		updated = f.UpdateFunctionDeclaration(node.AsFunctionDeclaration(), modifiers, nil /*asteriskToken*/, node.Name(), nil /*typeParameters*/, parameters, nil /*returnType*/, body)
+	case ast.KindFunctionExpression:
+		updated = f.UpdateFunctionExpression(node.AsFunctionExpression(), modifiers, nil /*asteriskToken*/, node.Name(), nil /*typeParameters*/, parameters, nil /*returnType*/, body)
+	case ast.KindMethodDeclaration:
+		n := node.AsMethodDeclaration()
+		updated = f.UpdateMethodDeclaration(n, modifiers, nil /*asteriskToken*/, ch.Visitor().VisitNode(n.Name()), n.PostfixToken, nil /*typeParameters*/, parameters, nil /*returnType*/, body)
+	}
+
+	ch.inAsyncGeneratorBody = savedInAsyncGeneratorBody
+	ch.inIterationContainer = savedInIterationContainer
+	return updated
+}
+
+// Transforms the body of an async generator function. This ends the variable environment started when the
+// parameters were visited, while declarations hoisted from the original body stay within the generator function.
+func (ch *forawaitTransformer) transformAsyncGeneratorFunctionBody(node *ast.Node) *ast.Node {
+	f := ch.Factory()
+	body := node.Body().AsBlock()
+	prologue, rest := f.SplitStandardPrologue(body.Statements.Nodes)
+
+	ch.EmitContext().StartVariableEnvironment()
+	ch.inAsyncGeneratorBody = true
+	visited, _ := ch.Visitor().VisitSlice(rest)
+	ch.inAsyncGeneratorBody = false
+	generatorStatements := f.NewNodeList(ch.EmitContext().EndAndMergeVariableEnvironment(visited))
+	generatorStatements.Loc = body.Statements.Loc
+	generatorBody := f.NewBlock(generatorStatements, body.Multiline)
+	generatorBody.Loc = body.Loc
+
+	// !!! super property access within async generator methods is not yet rewritten
+	var name *ast.IdentifierNode
+	if node.Name() != nil {
+		name = f.NewGeneratedNameForNode(node.Name())
+	}
+	generatorFunc := f.NewFunctionExpression(
+		nil, /*modifiers*/
+		f.NewToken(ast.KindAsteriskToken),
+		name,
+		nil, /*typeParameters*/
+		f.NewNodeList(nil),
+		nil, /*returnType*/
+		generatorBody,
+	)
+	returnStatement := f.NewReturnStatement(f.NewAsyncGeneratorHelper(generatorFunc, true /*hasLexicalThis*/))
+
+	statements := append(slices.Clip(prologue), returnStatement)
+	statementList := f.NewNodeList(ch.EmitContext().EndAndMergeVariableEnvironment(statements))
+	statementList.Loc = body.Statements.Loc
+	return f.UpdateBlock(body, statementList)
+}
+
+func isAsyncGeneratorFunction(node *ast.Node) bool {
+	return isAsyncFunction(node) && node.BodyData().AsteriskToken != nil && node.Body() != nil
+}
+
+// Gets the statement labeled by the innermost label of a chain of labeled statements.
+func unwrapInnermostStatementOfLabel(node *ast.LabeledStatement) *ast.Statement {
+	for {
+		statement := node.Statement
+		if !ast.IsLabeledStatement(statement) {
+			return statement
+		}
+		node = statement.AsLabeledStatement()
+	}
+}
+
+// Reapplies the labels of a chain of labeled statements, starting at `outermostLabeledStatement`, to a statement that
+// replaces the innermost labeled statement.
+func restoreEnclosingLabel(factory *printer.NodeFactory, node *ast.Statement, outermostLabeledStatement *ast.LabeledStatement) *ast.Statement {
+	if outermostLabeledStatement == nil {
+		return node
+	}
+	var statement *ast.Statement
+	if ast.IsLabeledStatement(outermostLabeledStatement.Statement) {
+		statement = restoreEnclosingLabel(factory, node, outermostLabeledStatement.Statement.AsLabeledStatement())
+	} else {
+		statement = node
+	}
+	return factory.UpdateLabeledStatement(outermostLabeledStatement, outermostLabeledStatement.Label, statement)
 }
@@ -0,0 +1,2454 @@
+package estransforms
+
+import (
+	"strconv"
+
+	"github.com/pagpeter/typescript-go/external/ast"
+	"github.com/pagpeter/typescript-go/external/binder"
+	"github.com/pagpeter/typescript-go/external/core"
+	"github.com/pagpeter/typescript-go/external/printer"
+	"github.com/pagpeter/typescript-go/external/transformers"
+)
+
+// Transforming generator functions into a state machine is a multi-step process.
+//
+//  1. The body of the generator is visited and each statement is "transformed and emitted" into a flat list of
+//     operations, such as "assign", "break", "yield", and "return". Statements and expressions that do not contain a
+//     `yield` are emitted as-is. Labels are defined for the targets of branches and marked at the offset of the next
+//     operation.
+//  2. The list of operations is "built" into the body of a state machine. Each label marks the start of a new `case`
+//     clause of a `switch` statement that dispatches on the current label of the generator state, and each branch is
+//     written as a `return` of an instruction and the label of the target.
+//  3. The state machine is passed as a callback to the `__generator` helper, which drives it in response to calls to
+//     `next`, `throw`, and `return` on the resulting iterator.
+//
+// For example, the following generator:
+//
+//	function* f() {
+//	    var x = yield 1;
+//	    return x;
+//	}
+//
+// is transformed into:
+//
+//	function f() {
+//	    var x;
+//	    return __generator(this, function (_a) {
+//	        switch (_a.label) {
+//	            case 0: return [4 /*yield*/, 1];
+//	            case 1:
+//	                x = _a.sent();
+//	                return [2 /*return*/, x];
+//	        }
+//	    });
+//	}
+
+type generatorLabel int
+
+// The kind of an operation emitted for the body of a generator.
+type generatorOpCode int
+
+const (
+	opCodeNop            generatorOpCode = iota // No operation, used to force a new case in the state machine
+	opCodeStatement                             // A regular javascript statement
+	opCodeAssign                                // An assignment
+	opCodeBreak                                 // A break instruction used to jump to a label
+	opCodeBreakWhenTrue                         // A break instruction used to jump to a label if a condition evaluates to true
+	opCodeBreakWhenFalse                        // A break instruction used to jump to a label if a condition evaluates to false
+	opCodeYield                                 // A completion instruction for the `yield` keyword
+	opCodeYieldStar                             // A completion instruction for the `yield*` keyword (not implemented, but reserved for future use)
+	opCodeReturn                                // A completion instruction for the `return` keyword
+	opCodeThrow                                 // A completion instruction for the `throw` keyword
+	opCodeEndfinally                            // Marks the end of a `finally` block
+)
+
+// An instruction returned from the state machine to the `__generator` helper.
+type generatorInstruction int
+
+const (
+	instructionNext       generatorInstruction = 0
+	instructionThrow      generatorInstruction = 1
+	instructionReturn     generatorInstruction = 2
+	instructionBreak      generatorInstruction = 3
+	instructionYield      generatorInstruction = 4
+	instructionYieldStar  generatorInstruction = 5
+	instructionCatch      generatorInstruction = 6
+	instructionEndfinally generatorInstruction = 7
+)
+
+func (instruction generatorInstruction) name() string {
+	switch instruction {
+	case instructionReturn:
+		return "return"
+	case instructionBreak:
+		return "break"
+	case instructionYield:
+		return "yield"
+	case instructionYieldStar:
+		return "yield*"
+	case instructionEndfinally:
+		return "endfinally"
+	default:
+		return ""
+	}
+}
+
+type codeBlockKind int
+
+const (
+	codeBlockKindException codeBlockKind = iota
+	codeBlockKindWith
+	codeBlockKindSwitch
+	codeBlockKindLoop
+	codeBlockKindLabeled
+)
+
+type exceptionBlockState int
+
+const (
+	exceptionBlockStateTry exceptionBlockState = iota
+	exceptionBlockStateCatch
+	exceptionBlockStateFinally
+	exceptionBlockStateDone
+)
+
+// A block of code in the body of a generator, such as a `try` statement or a loop, that affects how operations
+// within it are written to the state machine.
+type codeBlock struct {
+	kind codeBlockKind
+
+	// exception blocks
+	state         exceptionBlockState
+	startLabel    generatorLabel
+	catchVariable *ast.IdentifierNode
+	catchLabel    generatorLabel
+	finallyLabel  generatorLabel
+	endLabel      generatorLabel
+
+	// with blocks
+	expression *ast.IdentifierNode
+
+	// breakable blocks (switch, loop, labeled)
+	isScript      bool // the block is emitted as-is rather than as part of the state machine
+	breakLabel    generatorLabel
+	continueLabel generatorLabel
+	labelText     string
+}
+
+type blockAction int
+
+const (
+	blockActionOpen blockAction = iota
+	blockActionClose
+)
+
+// Records the opening or closing of a code block at the offset of the next operation.
+type blockEvent struct {
+	action blockAction
+	offset int
+	block  *codeBlock
+}
+
+type generatorOperation struct {
+	code       generatorOpCode
+	label      generatorLabel
+	expression *ast.Expression
+	statement  *ast.Statement
+	location   core.TextRange
+}
+
+// State for the generator function whose body is currently being transformed.
+type generatorFunctionState struct {
+	blockEvents      []blockEvent
+	blockStack       []*codeBlock
+	labelOffsets     []int                          // maps each label to the offset of the operation it marks, or -1 if the label is unmarked
+	labelExpressions map[generatorLabel][]*ast.Node // the numeric literals that reference each label, updated once label numbers are known
+	nextLabelId      generatorLabel
+	operations       []generatorOperation
+	state            *ast.IdentifierNode // the state parameter of the state machine, e.g. `_a`
+}
+
+// State used while building the state machine from the list of operations.
+type generatorBuildState struct {
+	blockIndex                 int
+	labelNumber                int
+	labelNumbers               [][]generatorLabel // maps each label number to the labels it marks
+	lastOperationWasAbrupt     bool
+	lastOperationWasCompletion bool
+	clauses                    []*ast.Node
+	statements                 []*ast.Statement
+	exceptionBlockStack        []*codeBlock
+	currentExceptionBlock      *codeBlock
+	withBlockStack             []*codeBlock
+}
+
+type generatorsTransformer struct {
+	transformers.Transformer
+	compilerOptions *core.CompilerOptions
+	resolver        *binder.NameResolver
+
+	renamedCatchVariables            map[string]bool
+	renamedCatchVariableDeclarations map[*ast.Node]*ast.IdentifierNode // keyed by the parse tree node of the catch clause variable
+	inGeneratorFunctionBody          bool
+	inStatementContainingYield       bool
+
+	generatorFunctionState
+	generatorBuildState
+}
+
+func newGeneratorsTransformer(opts *transformers.TransformOptions) *transformers.Transformer {
+	tx := &generatorsTransformer{compilerOptions: opts.CompilerOptions}
+	return tx.NewTransformer(tx.visit, opts.Context)
+}
+
+func (tx *generatorsTransformer) visit(node *ast.Node) *ast.Node {
+	switch {
+	case node.Kind == ast.KindSourceFile:
+		return tx.visitSourceFile(node.AsSourceFile())
+	case tx.inStatementContainingYield:
+		return tx.visitJavaScriptInStatementContainingYield(node)
+	case tx.inGeneratorFunctionBody:
+		return tx.visitJavaScriptInGeneratorFunctionBody(node)
+	case isGeneratorFunction(node):
+		return tx.visitGenerator(node)
+	case node.SubtreeFacts()&ast.SubtreeContainsGenerator != 0 || tx.renamedCatchVariables != nil:
+		if ast.IsIdentifier(node) {
+			return tx.visitIdentifier(node)
+		}
+		return tx.Visitor().VisitEachChild(node)
+	default:
+		return node
+	}
+}
+
+func isGeneratorFunction(node *ast.Node) bool {
+	return (ast.IsFunctionDeclaration(node) || ast.IsFunctionExpression(node)) && node.BodyData().AsteriskToken != nil
+}
+
+func (tx *generatorsTransformer) visitSourceFile(node *ast.SourceFile) *ast.Node {
+	if node.IsDeclarationFile || node.SubtreeFacts()&ast.SubtreeContainsGenerator == 0 {
+		return node.AsNode()
+	}
+	tx.resolver = &binder.NameResolver{CompilerOptions: tx.compilerOptions}
+	tx.renamedCatchVariables = nil
+	tx.renamedCatchVariableDeclarations = nil
+	visited := tx.Visitor().VisitEachChild(node.AsNode())
+	tx.EmitContext().AddEmitHelper(visited, tx.EmitContext().ReadEmitHelpers()...)
+	return visited
+}
+
+// Visits a node that is contained within a statement that contains a `yield` expression.
+func (tx *generatorsTransformer) visitJavaScriptInStatementContainingYield(node *ast.Node) *ast.Node {
+	switch node.Kind {
+	case ast.KindDoStatement, ast.KindWhileStatement:
+		return tx.visitDoOrWhileStatement(node)
+	case ast.KindSwitchStatement:
+		return tx.visitSwitchStatement(node.AsSwitchStatement())
+	case ast.KindLabeledStatement:
+		return tx.visitLabeledStatement(node.AsLabeledStatement())
+	default:
+		return tx.visitJavaScriptInGeneratorFunctionBody(node)
+	}
+}
+
+// Visits a node that is contained within the body of a generator function.
+func (tx *generatorsTransformer) visitJavaScriptInGeneratorFunctionBody(node *ast.Node) *ast.Node {
+	switch node.Kind {
+	case ast.KindFunctionDeclaration:
+		return tx.visitFunctionDeclaration(node.AsFunctionDeclaration())
+	case ast.KindFunctionExpression:
+		return tx.visitFunctionExpression(node.AsFunctionExpression())
+	case ast.KindGetAccessor, ast.KindSetAccessor:
+		return tx.visitAccessorDeclaration(node)
+	case ast.KindVariableStatement:
+		return tx.visitVariableStatement(node.AsVariableStatement())
+	case ast.KindForStatement:
+		return tx.visitForStatement(node.AsForStatement())
+	case ast.KindForInStatement:
+		return tx.visitForInStatement(node.AsForInOrOfStatement())
+	case ast.KindBreakStatement:
+		return tx.visitBreakStatement(node.AsBreakStatement())
+	case ast.KindContinueStatement:
+		return tx.visitContinueStatement(node.AsContinueStatement())
+	case ast.KindReturnStatement:
+		return tx.visitReturnStatement(node.AsReturnStatement())
+	case ast.KindIdentifier:
+		return tx.visitIdentifier(node)
+	default:
+		if containsYield(node) {
+			return tx.visitJavaScriptContainingYield(node)
+		}
+		return tx.Visitor().VisitEachChild(node)
+	}
+}
+
+// Visits an expression that contains a `yield` expression.
+func (tx *generatorsTransformer) visitJavaScriptContainingYield(node *ast.Node) *ast.Node {
+	switch node.Kind {
+	case ast.KindBinaryExpression:
+		return tx.visitBinaryExpression(node.AsBinaryExpression())
+	case ast.KindConditionalExpression:
+		return tx.visitConditionalExpression(node.AsConditionalExpression())
+	case ast.KindYieldExpression:
+		return tx.visitYieldExpression(node.AsYieldExpression())
+	case ast.KindArrayLiteralExpression:
+		return tx.visitArrayLiteralExpression(node.AsArrayLiteralExpression())
+	case ast.KindObjectLiteralExpression:
+		return tx.visitObjectLiteralExpression(node.AsObjectLiteralExpression())
+	case ast.KindElementAccessExpression:
+		return tx.visitElementAccessExpression(node.AsElementAccessExpression())
+	case ast.KindCallExpression:
+		return tx.visitCallExpression(node.AsCallExpression())
+	case ast.KindNewExpression:
+		return tx.visitNewExpression(node.AsNewExpression())
+	default:
+		return tx.Visitor().VisitEachChild(node)
+	}
+}
+
+func (tx *generatorsTransformer) visitGenerator(node *ast.Node) *ast.Node {
+	switch node.Kind {
+	case ast.KindFunctionDeclaration:
+		return tx.visitFunctionDeclaration(node.AsFunctionDeclaration())
+	case ast.KindFunctionExpression:
+		return tx.visitFunctionExpression(node.AsFunctionExpression())
+	default:
+		panic("Unexpected node kind: " + node.Kind.String())
+	}
+}
+
+// Substitutes references to a catch clause variable that was renamed because the catch clause contains a `yield`.
+func (tx *generatorsTransformer) visitIdentifier(node *ast.IdentifierNode) *ast.Node {
+	if tx.renamedCatchVariables == nil || !tx.renamedCatchVariables[node.Text()] || transformers.IsGeneratedIdentifier(tx.EmitContext(), node) {
+		return node
+	}
+	original := tx.EmitContext().ParseNode(node)
+	if original == nil || original.Parent == nil || !transformers.IsIdentifierReference(original, original.Parent) {
+		return node
+	}
+	symbol := tx.resolver.Resolve(original, original.Text(), ast.SymbolFlagsValue, nil /*nameNotFoundMessage*/, false /*isUse*/, false /*excludeGlobals*/)
+	if symbol == nil || symbol.ValueDeclaration == nil {
+		return node
+	}
+	name := tx.renamedCatchVariableDeclarations[symbol.ValueDeclaration]
+	if name == nil {
+		return node
+	}
+	clone := name.Clone(tx.Factory())
+	clone.Loc = node.Loc
+	return clone
+}
+
+// Visits a function declaration. Generator function declarations are transformed into a function whose body is a state
+// machine. Function declarations nested in the body of a generator are hoisted to the top of the generator body.
+func (tx *generatorsTransformer) visitFunctionDeclaration(node *ast.FunctionDeclaration) *ast.Node {
+	var updated *ast.Node
+	if node.AsteriskToken != nil {
+		updated = tx.Factory().UpdateFunctionDeclaration(
+			node,
+			node.Modifiers(),
+			nil, /*asteriskToken*/
+			node.Name(),
+			nil, /*typeParameters*/
+			tx.EmitContext().VisitParameters(node.Parameters, tx.Visitor()),
+			nil, /*returnType*/
+			tx.transformGeneratorFunctionBody(node.Body),
+		)
+	} else {
+		updated = tx.visitNonGeneratorFunction(node.AsNode())
+	}
+
+	if tx.inGeneratorFunctionBody {
+		// Function declarations in a generator function body are hoisted to the top of the lexical scope and elided
+		// from the current statement.
+		tx.EmitContext().AddHoistedFunctionDeclaration(updated)
+		return nil
+	}
+	return updated
+}
+
+// Visits a function expression. Generator function expressions are transformed into a function whose body is a state
+// machine.
+func (tx *generatorsTransformer) visitFunctionExpression(node *ast.FunctionExpression) *ast.Node {
+	if node.AsteriskToken != nil {
+		return tx.Factory().UpdateFunctionExpression(
+			node,
+			nil, /*modifiers*/
+			nil, /*asteriskToken*/
+			node.Name(),
+			nil, /*typeParameters*/
+			tx.EmitContext().VisitParameters(node.Parameters, tx.Visitor()),
+			nil, /*returnType*/
+			tx.transformGeneratorFunctionBody(node.Body),
+		)
+	}
+	return tx.visitNonGeneratorFunction(node.AsNode())
+}
+
+func (tx *generatorsTransformer) visitAccessorDeclaration(node *ast.Node) *ast.Node {
+	return tx.visitNonGeneratorFunction(node)
+}
+
+// Visits a function that is not a generator, which resets the generator state for its body.
+func (tx *generatorsTransformer) visitNonGeneratorFunction(node *ast.Node) *ast.Node {
+	savedInGeneratorFunctionBody := tx.inGeneratorFunctionBody
+	savedInStatementContainingYield := tx.inStatementContainingYield
+	tx.inGeneratorFunctionBody = false
+	tx.inStatementContainingYield = false
+	updated := tx.Visitor().VisitEachChild(node)
+	tx.inGeneratorFunctionBody = savedInGeneratorFunctionBody
+	tx.inStatementContainingYield = savedInStatementContainingYield
+	return updated
+}
+
+// Transforms the body of a generator function declaration or expression into a call to the `__generator` helper.
+func (tx *generatorsTransformer) transformGeneratorFunctionBody(body *ast.Node) *ast.Node {
+	f := tx.Factory()
+
+	// Save existing generator state
+	savedInGeneratorFunctionBody := tx.inGeneratorFunctionBody
+	savedInStatementContainingYield := tx.inStatementContainingYield
+	savedFunctionState := tx.generatorFunctionState
+
+	// Initialize generator state
+	tx.inGeneratorFunctionBody = true
+	tx.inStatementContainingYield = false
+	tx.generatorFunctionState = generatorFunctionState{
+		nextLabelId: 1,
+		state:       f.NewTempVariable(),
+	}
+
+	// Build the generator. The variable environment was started when visiting the parameters.
+	prologue, rest := f.SplitStandardPrologue(body.AsBlock().Statements.Nodes)
+	customPrologue, rest := f.SplitCustomPrologue(rest)
+	statements := append([]*ast.Statement{}, prologue...)
+	visitedCustomPrologue, _ := tx.Visitor().VisitSlice(customPrologue)
+	statements = append(statements, visitedCustomPrologue...)
+
+	tx.transformAndEmitStatements(rest)
+
+	buildResult := tx.build()
+	statements = tx.EmitContext().MergeEnvironment(statements, tx.EmitContext().EndVariableEnvironment())
+	statements = append(statements, f.NewReturnStatement(buildResult))
+
+	// Restore previous generator state
+	tx.inGeneratorFunctionBody = savedInGeneratorFunctionBody
+	tx.inStatementContainingYield = savedInStatementContainingYield
+	tx.generatorFunctionState = savedFunctionState
+
+	block := f.NewBlock(f.NewNodeList(statements), body.AsBlock().Multiline)
+	block.Loc = body.Loc
+	return block
+}
+
+// Visits a variable statement. Variable declarations in a generator function body are hoisted, and their
+// initializers are converted into assignments.
+func (tx *generatorsTransformer) visitVariableStatement(node *ast.VariableStatement) *ast.Node {
+	if containsYield(node.AsNode()) {
+		tx.transformAndEmitVariableDeclarationList(node.DeclarationList.AsVariableDeclarationList())
+		return nil
+	}
+
+	// Do not hoist custom prologues.
+	if tx.EmitContext().EmitFlags(node.AsNode())&printer.EFCustomPrologue != 0 {
+		return node.AsNode()
+	}
+
+	declarations := node.DeclarationList.AsVariableDeclarationList().Declarations.Nodes
+	for _, variable := range declarations {
+		tx.EmitContext().AddVariableDeclaration(variable.Name())
+	}
+
+	var expressions []*ast.Expression
+	for _, variable := range declarations {
+		if variable.Initializer() != nil {
+			expressions = append(expressions, tx.transformInitializedVariable(variable.AsVariableDeclaration()))
+		}
+	}
+	if len(expressions) == 0 {
+		return nil
+	}
+
+	statement := tx.Factory().NewExpressionStatement(tx.Factory().InlineExpressions(expressions))
+	tx.EmitContext().SetSourceMapRange(statement, node.Loc)
+	return statement
+}
+
+func (tx *generatorsTransformer) visitBinaryExpression(node *ast.BinaryExpression) *ast.Node {
+	if isRightAssociativeBinaryOperator(node.OperatorToken.Kind) {
+		return tx.visitRightAssociativeBinaryExpression(node)
+	}
+	return tx.visitLeftAssociativeBinaryExpression(node)
+}
+
+func isRightAssociativeBinaryOperator(kind ast.Kind) bool {
+	return kind == ast.KindAsteriskAsteriskToken || ast.IsAssignmentOperator(kind)
+}
+
+// Visits a right-associative binary expression containing `yield`.
+func (tx *generatorsTransformer) visitRightAssociativeBinaryExpression(node *ast.BinaryExpression) *ast.Node {
+	f := tx.Factory()
+	left := node.Left
+	right := node.Right
+	if !containsYield(right) {
+		return tx.Visitor().VisitEachChild(node.AsNode())
+	}
+
+	var target *ast.Expression
+	switch left.Kind {
+	case ast.KindPropertyAccessExpression:
+		// [source]
+		//      a.b = yield;
+		//
+		// [intermediate]
+		//  .local _a
+		//      _a = a;
+		//  .yield resumeLabel
+		//  .mark resumeLabel
+		//      _a.b = %sent%;
+		propertyAccess := left.AsPropertyAccessExpression()
+		target = f.UpdatePropertyAccessExpression(
+			propertyAccess,
+			tx.cacheExpression(tx.Visitor().VisitNode(propertyAccess.Expression)),
+			propertyAccess.QuestionDotToken,
+			propertyAccess.Name(),
+		)
+	case ast.KindElementAccessExpression:
+		// [source]
+		//      a[b] = yield;
+		//
+		// [intermediate]
+		//  .local _a, _b
+		//      _a = a;
+		//      _b = b;
+		//  .yield resumeLabel
+		//  .mark resumeLabel
+		//      _a[_b] = %sent%;
+		elementAccess := left.AsElementAccessExpression()
+		target = f.UpdateElementAccessExpression(
+			elementAccess,
+			tx.cacheExpression(tx.Visitor().VisitNode(elementAccess.Expression)),
+			elementAccess.QuestionDotToken,
+			tx.cacheExpression(tx.Visitor().VisitNode(elementAccess.ArgumentExpression)),
+		)
+	default:
+		target = tx.Visitor().VisitNode(left)
+	}
+
+	operator := node.OperatorToken.Kind
+	if operator != ast.KindEqualsToken && ast.IsAssignmentOperator(operator) {
+		value := f.NewBinaryExpression(
+			nil, /*modifiers*/
+			tx.cacheExpression(target),
+			nil, /*typeNode*/
+			f.NewToken(getNonAssignmentOperatorForCompoundAssignment(operator)),
+			tx.Visitor().VisitNode(right),
+		)
+		value.Loc = node.Loc
+		assignment := f.NewAssignmentExpression(target, value)
+		assignment.Loc = node.Loc
+		return assignment
+	}
+	return f.UpdateBinaryExpression(node, nil /*modifiers*/, target, nil /*typeNode*/, node.OperatorToken, tx.Visitor().VisitNode(right))
+}
+
+// Visits a left-associative binary expression containing `yield`.
+func (tx *generatorsTransformer) visitLeftAssociativeBinaryExpression(node *ast.BinaryExpression) *ast.Node {
+	if !containsYield(node.Right) {
+		return tx.Visitor().VisitEachChild(node.AsNode())
+	}
+	if ast.IsLogicalBinaryOperator(node.OperatorToken.Kind) {
+		return tx.visitLogicalBinaryExpression(node)
+	}
+	if node.OperatorToken.Kind == ast.KindCommaToken {
+		return tx.visitCommaExpression(node)
+	}
+
+	// [source]
+	//      a() + (yield) + c()
+	//
+	// [intermediate]
+	//  .local _a
+	//      _a = a();
+	//  .yield resumeLabel
+	//      _a + %sent% + c()
+	return tx.Factory().UpdateBinaryExpression(
+		node,
+		nil, /*modifiers*/
+		tx.cacheExpression(tx.Visitor().VisitNode(node.Left)),
+		nil, /*typeNode*/
+		node.OperatorToken,
+		tx.Visitor().VisitNode(node.Right),
+	)
+}
+
+// Visits a comma expression containing `yield`.
+//
+//	[source]
+//	     x = a(), yield, b();
+//
+//	[intermediate]
+//	     a();
+//	 .yield resumeLabel
+//	 .mark resumeLabel
+//	     x = %sent%, b();
+func (tx *generatorsTransformer) visitCommaExpression(node *ast.BinaryExpression) *ast.Expression {
+	var pendingExpressions []*ast.Expression
+	var visit func(node *ast.Expression)
+	visit = func(node *ast.Expression) {
+		if ast.IsBinaryExpression(node) && node.AsBinaryExpression().OperatorToken.Kind == ast.KindCommaToken {
+			visit(node.AsBinaryExpression().Left)
+			visit(node.AsBinaryExpression().Right)
+			return
+		}
+		if containsYield(node) && len(pendingExpressions) > 0 {
+			tx.emitStatement(tx.Factory().NewExpressionStatement(tx.Factory().InlineExpressions(pendingExpressions)))
+			pendingExpressions = nil
+		}
+		pendingExpressions = append(pendingExpressions, tx.Visitor().VisitNode(node))
+	}
+	visit(node.Left)
+	visit(node.Right)
+	return tx.Factory().InlineExpressions(pendingExpressions)
+}
+
+// Visits a logical binary expression containing `yield`, which must preserve its short-circuiting behavior:
+//
+//	[source]
+//	     x = a() && yield;
+//
+//	[intermediate]
+//	 .local _a
+//	     _a = a();
+//	 .brfalse resultLabel, (_a)
+//	 .yield resumeLabel
+//	 .mark resumeLabel
+//	     _a = %sent%;
+//	 .mark resultLabel
+//	     x = _a;
+//
+//	[source]
+//	     x = a() || yield;
+//
+//	[intermediate]
+//	 .local _a
+//	     _a = a();
+//	 .brtrue resultLabel, (_a)
+//	 .yield resumeLabel
+//	 .mark resumeLabel
+//	     _a = %sent%;
+//	 .mark resultLabel
+//	     x = _a;
+func (tx *generatorsTransformer) visitLogicalBinaryExpression(node *ast.BinaryExpression) *ast.Expression {
+	resultLabel := tx.defineLabel()
+	resultLocal := tx.declareLocal("")
+	tx.emitAssignment(resultLocal, tx.Visitor().VisitNode(node.Left), node.Left.Loc)
+	if node.OperatorToken.Kind == ast.KindAmpersandAmpersandToken {
+		// Logical `&&` shortcuts when the left-hand operand is falsey.
+		tx.emitBreakWhenFalse(resultLabel, resultLocal, node.Left.Loc)
+	} else {
+		// Logical `||` shortcuts when the left-hand operand is truthy.
+		tx.emitBreakWhenTrue(resultLabel, resultLocal, node.Left.Loc)
+	}
+	tx.emitAssignment(resultLocal, tx.Visitor().VisitNode(node.Right), node.Right.Loc)
+	tx.markLabel(resultLabel)
+	return resultLocal
+}
+
+// Visits a conditional expression containing `yield`:
+//
+//	[source]
+//	     x = a() ? yield : b();
+//
+//	[intermediate]
+//	 .local _a
+//	 .brfalse whenFalseLabel, (a())
+//	 .yield resumeLabel
+//	 .mark resumeLabel
+//	     _a = %sent%;
+//	 .br resultLabel
+//	 .mark whenFalseLabel
+//	     _a = b();
+//	 .mark resultLabel
+//	     x = _a;
+func (tx *generatorsTransformer) visitConditionalExpression(node *ast.ConditionalExpression) *ast.Expression {
+	// We only need to perform a specific transformation if a `yield` expression exists in either the `whenTrue` or
+	// `whenFalse` branches. A `yield` in the condition will be handled by the normal visitor.
+	if !containsYield(node.WhenTrue) && !containsYield(node.WhenFalse) {
+		return tx.Visitor().VisitEachChild(node.AsNode())
+	}
+	whenFalseLabel := tx.defineLabel()
+	resultLabel := tx.defineLabel()
+	resultLocal := tx.declareLocal("")
+	tx.emitBreakWhenFalse(whenFalseLabel, tx.Visitor().VisitNode(node.Condition), node.Condition.Loc)
+	tx.emitAssignment(resultLocal, tx.Visitor().VisitNode(node.WhenTrue), node.WhenTrue.Loc)
+	tx.emitBreak(resultLabel, core.UndefinedTextRange())
+	tx.markLabel(whenFalseLabel)
+	tx.emitAssignment(resultLocal, tx.Visitor().VisitNode(node.WhenFalse), node.WhenFalse.Loc)
+	tx.markLabel(resultLabel)
+	return resultLocal
+}
+
+// Visits a `yield` expression:
+//
+//	[source]
+//	     x = yield a();
+//
+//	[intermediate]
+//	 .yield resumeLabel, (a())
+//	 .mark resumeLabel
+//	     x = %sent%;
+func (tx *generatorsTransformer) visitYieldExpression(node *ast.YieldExpression) *ast.Expression {
+	resumeLabel := tx.defineLabel()
+	expression := tx.Visitor().VisitNode(node.Expression)
+	if node.AsteriskToken != nil {
+		// NOTE: `expression` must be defined for `yield*`.
+		iterator := expression
+		if tx.EmitContext().EmitFlags(node.Expression)&printer.EFIterator == 0 {
+			iterator = tx.Factory().NewValuesHelper(expression)
+			iterator.Loc = node.Loc
+		}
+		tx.emitYieldStar(iterator, node.Loc)
+	} else {
+		tx.emitYield(expression, node.Loc)
+	}
+	tx.markLabel(resumeLabel)
+	return tx.createGeneratorResume(node.Loc)
+}
+
+func (tx *generatorsTransformer) visitArrayLiteralExpression(node *ast.ArrayLiteralExpression) *ast.Expression {
+	return tx.visitElements(node.Elements.Nodes, nil /*leadingElement*/, core.UndefinedTextRange(), node.MultiLine)
+}
+
+// Visits a list of array elements or arguments containing `yield`:
+//
+//	[source]
+//	     ar = [1, yield, 2];
+//
+//	[intermediate]
+//	 .local _a
+//	     _a = [1];
+//	 .yield resumeLabel
+//	 .mark resumeLabel
+//	     ar = _a.concat([%sent%, 2]);
+func (tx *generatorsTransformer) visitElements(elements []*ast.Expression, leadingElement *ast.Expression, location core.TextRange, multiLine bool) *ast.Expression {
+	f := tx.Factory()
+	numInitialElements := countInitialNodesWithoutYield(elements)
+
+	withLeadingElement := func(expressions []*ast.Expression) []*ast.Expression {
+		if leadingElement != nil {
+			expressions = append([]*ast.Expression{leadingElement}, expressions...)
+			leadingElement = nil
+		}
+		return expressions
+	}
+
+	var temp *ast.IdentifierNode
+	if numInitialElements > 0 {
+		temp = tx.declareLocal("")
+		initialElements, _ := tx.Visitor().VisitSlice(elements[:numInitialElements])
+		tx.emitAssignment(temp, f.NewArrayLiteralExpression(f.NewNodeList(withLeadingElement(initialElements)), false /*multiLine*/), core.UndefinedTextRange())
+	}
+
+	var expressions []*ast.Expression
+	for _, element := range elements[numInitialElements:] {
+		if containsYield(element) && len(expressions) > 0 {
+			if temp != nil {
+				tx.emitAssignment(temp, createArrayConcatCall(f, temp, f.NewArrayLiteralExpression(f.NewNodeList(expressions), multiLine)), core.UndefinedTextRange())
+			} else {
+				temp = tx.declareLocal("")
+				tx.emitAssignment(temp, f.NewArrayLiteralExpression(f.NewNodeList(withLeadingElement(expressions)), multiLine), core.UndefinedTextRange())
+			}
+			expressions = nil
+		}
+		expressions = append(expressions, tx.Visitor().VisitNode(element))
+	}
+
+	if temp != nil {
+		return createArrayConcatCall(f, temp, f.NewArrayLiteralExpression(f.NewNodeList(expressions), multiLine))
+	}
+	result := f.NewArrayLiteralExpression(f.NewNodeList(withLeadingElement(expressions)), multiLine)
+	result.Loc = location
+	return result
+}
+
+// target.concat(...argumentsList)
+func createArrayConcatCall(f *printer.NodeFactory, target *ast.Expression, argumentsList ...*ast.Expression) *ast.Expression {
+	return f.NewCallExpression(
+		f.NewPropertyAccessExpression(target, nil /*questionDotToken*/, f.NewIdentifier("concat"), ast.NodeFlagsNone),
+		nil, /*questionDotToken*/
+		nil, /*typeArguments*/
+		f.NewNodeList(argumentsList),
+		ast.NodeFlagsNone,
+	)
+}
+
+// Visits an object literal containing `yield`:
+//
+//	[source]
+//	     o = {
+//	         a: 1,
+//	         b: yield,
+//	         c: 2
+//	     };
+//
+//	[intermediate]
+//	 .local _a
+//	     _a = {
+//	         a: 1
+//	     };
+//	 .yield resumeLabel
+//	 .mark resumeLabel
+//	     o = (_a.b = %sent%,
+//	         _a.c = 2,
+//	         _a);
+func (tx *generatorsTransformer) visitObjectLiteralExpression(node *ast.ObjectLiteralExpression) *ast.Expression {
+	f := tx.Factory()
+	properties := node.Properties.Nodes
+	multiLine := node.MultiLine
+	numInitialProperties := countInitialNodesWithoutYield(properties)
+
+	temp := tx.declareLocal("")
+	initialProperties, _ := tx.Visitor().VisitSlice(properties[:numInitialProperties])
+	tx.emitAssignment(temp, f.NewObjectLiteralExpression(f.NewNodeList(initialProperties), multiLine), core.UndefinedTextRange())
+
+	var expressions []*ast.Expression
+	for _, property := range properties[numInitialProperties:] {
+		if containsYield(property) && len(expressions) > 0 {
+			tx.emitStatement(f.NewExpressionStatement(f.InlineExpressions(expressions)))
+			expressions = nil
+		}
+		expression := tx.createExpressionForObjectLiteralElement(properties, property, temp)
+		if visited := tx.Visitor().VisitNode(expression); visited != nil {
+			if multiLine {
+				tx.EmitContext().AddEmitFlags(visited, printer.EFStartOnNewLine)
+			}
+			expressions = append(expressions, visited)
+		}
+	}
+
+	result := temp.Clone(f)
+	if multiLine {
+		tx.EmitContext().AddEmitFlags(result, printer.EFStartOnNewLine)
+	}
+	expressions = append(expressions, result)
+	return f.InlineExpressions(expressions)
+}
+
+// Converts a member of an object literal into an assignment to (or a property definition on) a receiver.
+func (tx *generatorsTransformer) createExpressionForObjectLiteralElement(properties []*ast.Node, property *ast.Node, receiver *ast.IdentifierNode) *ast.Expression {
+	f := tx.Factory()
+	var expression *ast.Expression
+	switch property.Kind {
+	case ast.KindGetAccessor, ast.KindSetAccessor:
+		accessors := getAllAccessorDeclarations(properties, property)
+		if property != accessors.firstAccessor {
+			return nil
+		}
+		var descriptor []*ast.Node
+		addAccessor := func(name string, accessor *ast.AccessorDeclaration) {
+			function := f.NewFunctionExpression(accessor.Modifiers(), nil /*asteriskToken*/, nil /*name*/, nil /*typeParameters*/, accessor.ParameterList(), nil /*returnType*/, accessor.Body())
+			function.Loc = accessor.Loc
+			tx.EmitContext().SetOriginal(function, accessor)
+			descriptor = append(descriptor, f.NewPropertyAssignment(nil /*modifiers*/, f.NewIdentifier(name), nil /*postfixToken*/, nil /*typeNode*/, function))
+		}
+		if accessors.getAccessor != nil {
+			addAccessor("get", accessors.getAccessor)
+		}
+		if accessors.setAccessor != nil {
+			addAccessor("set", accessors.setAccessor)
+		}
+		descriptor = append(descriptor,
+			f.NewPropertyAssignment(nil /*modifiers*/, f.NewIdentifier("enumerable"), nil /*postfixToken*/, nil /*typeNode*/, f.NewFalseExpression()),
+			f.NewPropertyAssignment(nil /*modifiers*/, f.NewIdentifier("configurable"), nil /*postfixToken*/, nil /*typeNode*/, f.NewTrueExpression()),
+		)
+		expression = f.NewObjectDefinePropertyCall(
+			receiver.Clone(f),
+			createExpressionForPropertyName(f, property.Name()),
+			f.NewObjectLiteralExpression(f.NewNodeList(descriptor), true /*multiLine*/),
+		)
+		expression.Loc = accessors.firstAccessor.Loc
+		return expression
+	case ast.KindPropertyAssignment:
+		expression = f.NewAssignmentExpression(createMemberAccessForPropertyName(f, receiver.Clone(f), property.Name()), property.Initializer())
+	case ast.KindShorthandPropertyAssignment:
+		expression = f.NewAssignmentExpression(createMemberAccessForPropertyName(f, receiver.Clone(f), property.Name()), property.Name().Clone(f))
+	case ast.KindMethodDeclaration:
+		method := property.AsMethodDeclaration()
+		function := f.NewFunctionExpression(method.Modifiers(), method.AsteriskToken, nil /*name*/, nil /*typeParameters*/, method.Parameters, nil /*returnType*/, method.Body)
+		function.Loc = property.Loc
+		tx.EmitContext().SetOriginal(function, property)
+		expression = f.NewAssignmentExpression(createMemberAccessForPropertyName(f, receiver.Clone(f), property.Name()), function)
+	default:
+		return nil
+	}
+	expression.Loc = property.Loc
+	tx.EmitContext().SetOriginal(expression, property)
+	return expression
+}
+
+// Creates a property access or element access for a property name:
+//
+//	x -> target.x
+//	"x" -> target["x"]
+//	[x] -> target[x]
+func createMemberAccessForPropertyName(f *printer.NodeFactory, target *ast.Expression, memberName *ast.Node) *ast.Expression {
+	var expression *ast.Expression
+	switch {
+	case ast.IsComputedPropertyName(memberName):
+		expression = f.NewElementAccessExpression(target, nil /*questionDotToken*/, memberName.Expression(), ast.NodeFlagsNone)
+	case ast.IsIdentifier(memberName) || ast.IsPrivateIdentifier(memberName):
+		expression = f.NewPropertyAccessExpression(target, nil /*questionDotToken*/, memberName.Clone(f), ast.NodeFlagsNone)
+	default:
+		expression = f.NewElementAccessExpression(target, nil /*questionDotToken*/, memberName.Clone(f), ast.NodeFlagsNone)
+	}
+	expression.Loc = memberName.Loc
+	return expression
+}
+
+// Gets the expression for the name of a property, for use as the key of a call to `Object.defineProperty`.
+func createExpressionForPropertyName(f *printer.NodeFactory, memberName *ast.Node) *ast.Expression {
+	switch {
+	case ast.IsIdentifier(memberName):
+		literal := f.NewStringLiteralFromNode(memberName)
+		literal.Loc = memberName.Loc
+		return literal
+	case ast.IsComputedPropertyName(memberName):
+		return memberName.Expression().Clone(f)
+	default:
+		return memberName.Clone(f)
+	}
+}
+
+// Visits an element access containing `yield` in its argument:
+//
+//	[source]
+//	     a = x[yield];
+//
+//	[intermediate]
+//	 .local _a
+//	     _a = x;
+//	 .yield resumeLabel
+//	 .mark resumeLabel
+//	     a = _a[%sent%]
+func (tx *generatorsTransformer) visitElementAccessExpression(node *ast.ElementAccessExpression) *ast.Node {
+	if !containsYield(node.ArgumentExpression) {
+		return tx.Visitor().VisitEachChild(node.AsNode())
+	}
+	return tx.Factory().UpdateElementAccessExpression(
+		node,
+		tx.cacheExpression(tx.Visitor().VisitNode(node.Expression)),
+		node.QuestionDotToken,
+		tx.Visitor().VisitNode(node.ArgumentExpression),
+	)
+}
+
+// Visits a call containing `yield` in its arguments:
+//
+//	[source]
+//	     a.b(1, yield, 2);
+//
+//	[intermediate]
+//	 .local _a, _b, _c
+//	     _b = (_a = a).b;
+//	     _c = [1];
+//	 .yield resumeLabel
+//	 .mark resumeLabel
+//	     _b.apply(_a, _c.concat([%sent%, 2]));
+func (tx *generatorsTransformer) visitCallExpression(node *ast.CallExpression) *ast.Node {
+	if ast.IsImportCall(node.AsNode()) || !core.Some(node.Arguments.Nodes, containsYield) {
+		return tx.Visitor().VisitEachChild(node.AsNode())
+	}
+	target, thisArg := createCallBinding(tx.EmitContext(), node.Expression, true /*cacheIdentifiers*/)
+	call := tx.Factory().NewFunctionApplyCall(
+		tx.cacheExpression(tx.Visitor().VisitNode(target)),
+		thisArg,
+		tx.visitElements(node.Arguments.Nodes, nil /*leadingElement*/, core.UndefinedTextRange(), false /*multiLine*/),
+	)
+	call.Loc = node.Loc
+	tx.EmitContext().SetOriginal(call, node.AsNode())
+	return call
+}
+
+// Visits a `new` expression containing `yield` in its arguments:
+//
+//	[source]
+//	     new a.b(1, yield, 2);
+//
+//	[intermediate]
+//	 .local _a, _b, _c
+//	     _b = (_a = a.b).bind;
+//	     _c = [1];
+//	 .yield resumeLabel
+//	 .mark resumeLabel
+//	     new (_b.apply(_a, _c.concat([%sent%, 2])));
+func (tx *generatorsTransformer) visitNewExpression(node *ast.NewExpression) *ast.Node {
+	if node.Arguments == nil || !core.Some(node.Arguments.Nodes, containsYield) {
+		return tx.Visitor().VisitEachChild(node.AsNode())
+	}
+	f := tx.Factory()
+	target, thisArg := createCallBinding(tx.EmitContext(), f.NewPropertyAccessExpression(node.Expression, nil /*questionDotToken*/, f.NewIdentifier("bind"), ast.NodeFlagsNone), false /*cacheIdentifiers*/)
+	result := f.NewNewExpression(
+		f.NewFunctionApplyCall(
+			tx.cacheExpression(tx.Visitor().VisitNode(target)),
+			thisArg,
+			tx.visitElements(node.Arguments.Nodes, f.NewVoidZeroExpression() /*leadingElement*/, core.UndefinedTextRange(), false /*multiLine*/),
+		),
+		nil, /*typeArguments*/
+		f.NewNodeList(nil),
+	)
+	result.Loc = node.Loc
+	tx.EmitContext().SetOriginal(result, node.AsNode())
+	return result
+}
+
+//
+// Statements
+//
+
+func (tx *generatorsTransformer) transformAndEmitStatements(statements []*ast.Statement) {
+	for _, statement := range statements {
+		tx.transformAndEmitStatement(statement)
+	}
+}
+
+func (tx *generatorsTransformer) transformAndEmitEmbeddedStatement(node *ast.Statement) {
+	if ast.IsBlock(node) {
+		tx.transformAndEmitStatements(node.AsBlock().Statements.Nodes)
+	} else {
+		tx.transformAndEmitStatement(node)
+	}
+}
+
+func (tx *generatorsTransformer) transformAndEmitStatement(node *ast.Statement) {
+	savedInStatementContainingYield := tx.inStatementContainingYield
+	if !tx.inStatementContainingYield {
+		tx.inStatementContainingYield = containsYield(node)
+	}
+	tx.transformAndEmitStatementWorker(node)
+	tx.inStatementContainingYield = savedInStatementContainingYield
+}
+
+func (tx *generatorsTransformer) transformAndEmitStatementWorker(node *ast.Statement) {
+	switch node.Kind {
+	case ast.KindBlock:
+		tx.transformAndEmitBlock(node.AsBlock())
+	case ast.KindExpressionStatement:
+		tx.emitStatement(tx.Visitor().VisitNode(node))
+	case ast.KindIfStatement:
+		tx.transformAndEmitIfStatement(node.AsIfStatement())
+	case ast.KindDoStatement:
+		tx.transformAndEmitDoStatement(node.AsDoStatement())
+	case ast.KindWhileStatement:
+		tx.transformAndEmitWhileStatement(node.AsWhileStatement())
+	case ast.KindForStatement:
+		tx.transformAndEmitForStatement(node.AsForStatement())
+	case ast.KindForInStatement:
+		tx.transformAndEmitForInStatement(node.AsForInOrOfStatement())
+	case ast.KindContinueStatement:
+		tx.transformAndEmitContinueStatement(node.AsContinueStatement())
+	case ast.KindBreakStatement:
+		tx.transformAndEmitBreakStatement(node.AsBreakStatement())
+	case ast.KindReturnStatement:
+		tx.emitReturn(tx.Visitor().VisitNode(node.Expression()), node.Loc)
+	case ast.KindWithStatement:
+		tx.transformAndEmitWithStatement(node.AsWithStatement())
+	case ast.KindSwitchStatement:
+		tx.transformAndEmitSwitchStatement(node.AsSwitchStatement())
+	case ast.KindLabeledStatement:
+		tx.transformAndEmitLabeledStatement(node.AsLabeledStatement())
+	case ast.KindThrowStatement:
+		tx.emitThrow(tx.Visitor().VisitNode(node.Expression()), node.Loc)
+	case ast.KindTryStatement:
+		tx.transformAndEmitTryStatement(node.AsTryStatement())
+	default:
+		tx.emitStatement(tx.Visitor().VisitNode(node))
+	}
+}
+
+func (tx *generatorsTransformer) transformAndEmitBlock(node *ast.Block) {
+	if containsYield(node.AsNode()) {
+		tx.transformAndEmitStatements(node.Statements.Nodes)
+	} else {
+		tx.emitStatement(tx.Visitor().VisitNode(node.AsNode()))
+	}
+}
+
+func (tx *generatorsTransformer) transformAndEmitVariableDeclarationList(node *ast.VariableDeclarationList) {
+	f := tx.Factory()
+	for _, variable := range node.Declarations.Nodes {
+		name := variable.Name().Clone(f)
+		tx.EmitContext().SetCommentRange(name, variable.Name().Loc)
+		tx.EmitContext().AddVariableDeclaration(name)
+	}
+
+	var pendingExpressions []*ast.Expression
+	for _, variable := range node.Declarations.Nodes {
+		if variable.Initializer() == nil {
+			continue
+		}
+		if containsYield(variable.Initializer()) && len(pendingExpressions) > 0 {
+			tx.emitStatement(f.NewExpressionStatement(f.InlineExpressions(pendingExpressions)))
+			pendingExpressions = nil
+		}
+		pendingExpressions = append(pendingExpressions, tx.transformInitializedVariable(variable.AsVariableDeclaration()))
+	}
+	if len(pendingExpressions) > 0 {
+		tx.emitStatement(f.NewExpressionStatement(f.InlineExpressions(pendingExpressions)))
+	}
+}
+
+func (tx *generatorsTransformer) transformInitializedVariable(node *ast.VariableDeclaration) *ast.Expression {
+	f := tx.Factory()
+	name := node.Name().Clone(f)
+	tx.EmitContext().SetSourceMapRange(name, node.Name().Loc)
+	assignment := f.NewAssignmentExpression(name, tx.Visitor().VisitNode(node.Initializer))
+	tx.EmitContext().SetSourceMapRange(assignment, node.Loc)
+	return assignment
+}
+
+// Transforms an `if` statement containing `yield`:
+//
+//	[source]
+//	     if (x)
+//	         /*thenStatement*/
+//	     else
+//	         /*elseStatement*/
+//
+//	[intermediate]
+//	 .brfalse elseLabel, (x)
+//	     /*thenStatement*/
+//	 .br endLabel
+//	 .mark elseLabel
+//	     /*elseStatement*/
+//	 .mark endLabel
+func (tx *generatorsTransformer) transformAndEmitIfStatement(node *ast.IfStatement) {
+	if !containsYield(node.ThenStatement) && !containsYield(node.ElseStatement) {
+		tx.emitStatement(tx.Visitor().VisitNode(node.AsNode()))
+		return
+	}
+	endLabel := tx.defineLabel()
+	var elseLabel generatorLabel
+	if node.ElseStatement != nil {
+		elseLabel = tx.defineLabel()
+		tx.emitBreakWhenFalse(elseLabel, tx.Visitor().VisitNode(node.Expression), node.Expression.Loc)
+	} else {
+		tx.emitBreakWhenFalse(endLabel, tx.Visitor().VisitNode(node.Expression), node.Expression.Loc)
+	}
+	tx.transformAndEmitEmbeddedStatement(node.ThenStatement)
+	if node.ElseStatement != nil {
+		tx.emitBreak(endLabel, core.UndefinedTextRange())
+		tx.markLabel(elseLabel)
+		tx.transformAndEmitEmbeddedStatement(node.ElseStatement)
+	}
+	tx.markLabel(endLabel)
+}
+
+// Transforms a `do` statement containing `yield`:
+//
+//	[source]
+//	     do {
+//	         /*body*/
+//	     }
+//	     while (i < 10);
+//
+//	[intermediate]
+//	 .loop conditionLabel, endLabel
+//	 .mark loopLabel
+//	     /*body*/
+//	 .mark conditionLabel
+//	 .brtrue loopLabel, (i < 10)
+//	 .endloop
+//	 .mark endLabel
+func (tx *generatorsTransformer) transformAndEmitDoStatement(node *ast.DoStatement) {
+	if !containsYield(node.AsNode()) {
+		tx.emitStatement(tx.Visitor().VisitNode(node.AsNode()))
+		return
+	}
+	conditionLabel := tx.defineLabel()
+	loopLabel := tx.defineLabel()
+	tx.beginLoopBlock(conditionLabel /*continueLabel*/)
+	tx.markLabel(loopLabel)
+	tx.transformAndEmitEmbeddedStatement(node.Statement)
+	tx.markLabel(conditionLabel)
+	tx.emitBreakWhenTrue(loopLabel, tx.Visitor().VisitNode(node.Expression), core.UndefinedTextRange())
+	tx.endLoopBlock()
+}
+
+// Visits a `do` or `while` statement that does not contain `yield`, but is contained in a statement that does.
+func (tx *generatorsTransformer) visitDoOrWhileStatement(node *ast.Node) *ast.Node {
+	tx.beginScriptLoopBlock()
+	updated := tx.Visitor().VisitEachChild(node)
+	tx.endLoopBlock()
+	return updated
+}
+
+// Transforms a `while` statement containing `yield`:
+//
+//	[source]
+//	     while (i < 10) {
+//	         /*body*/
+//	     }
+//
+//	[intermediate]
+//	 .loop loopLabel, endLabel
+//	 .mark loopLabel
+//	 .brfalse endLabel, (i < 10)
+//	     /*body*/
+//	 .br loopLabel
+//	 .endloop
+//	 .mark endLabel
+func (tx *generatorsTransformer) transformAndEmitWhileStatement(node *ast.WhileStatement) {
+	if !containsYield(node.AsNode()) {
+		tx.emitStatement(tx.Visitor().VisitNode(node.AsNode()))
+		return
+	}
+	loopLabel := tx.defineLabel()
+	endLabel := tx.beginLoopBlock(loopLabel /*continueLabel*/)
+	tx.markLabel(loopLabel)
+	tx.emitBreakWhenFalse(endLabel, tx.Visitor().VisitNode(node.Expression), core.UndefinedTextRange())
+	tx.transformAndEmitEmbeddedStatement(node.Statement)
+	tx.emitBreak(loopLabel, core.UndefinedTextRange())
+	tx.endLoopBlock()
+}
+
+// Transforms a `for` statement containing `yield`:
+//
+//	[source]
+//	     for (var i = 0; i < 10; i++) {
+//	         /*body*/
+//	     }
+//
+//	[intermediate]
+//	 .local i
+//	     i = 0;
+//	 .loop incrementLabel, endLoopLabel
+//	 .mark conditionLabel
+//	 .brfalse endLoopLabel, (i < 10)
+//	     /*body*/
+//	 .mark incrementLabel
+//	     i++;
+//	 .br conditionLabel
+//	 .endloop
+//	 .mark endLoopLabel
+func (tx *generatorsTransformer) transformAndEmitForStatement(node *ast.ForStatement) {
+	if !containsYield(node.AsNode()) {
+		tx.emitStatement(tx.Visitor().VisitNode(node.AsNode()))
+		return
+	}
+	f := tx.Factory()
+	conditionLabel := tx.defineLabel()
+	incrementLabel := tx.defineLabel()
+	endLabel := tx.beginLoopBlock(incrementLabel /*continueLabel*/)
+	if initializer := node.Initializer; initializer != nil {
+		if ast.IsVariableDeclarationList(initializer) {
+			tx.transformAndEmitVariableDeclarationList(initializer.AsVariableDeclarationList())
+		} else {
+			statement := f.NewExpressionStatement(tx.Visitor().VisitNode(initializer))
+			statement.Loc = initializer.Loc
+			tx.emitStatement(statement)
+		}
+	}
+
+	tx.markLabel(conditionLabel)
+	if node.Condition != nil {
+		tx.emitBreakWhenFalse(endLabel, tx.Visitor().VisitNode(node.Condition), core.UndefinedTextRange())
+	}
+
+	tx.transformAndEmitEmbeddedStatement(node.Statement)
+
+	tx.markLabel(incrementLabel)
+	if node.Incrementor != nil {
+		statement := f.NewExpressionStatement(tx.Visitor().VisitNode(node.Incrementor))
+		statement.Loc = node.Incrementor.Loc
+		tx.emitStatement(statement)
+	}
+	tx.emitBreak(conditionLabel, core.UndefinedTextRange())
+	tx.endLoopBlock()
+}
+
+// Visits a `for` statement that does not contain `yield`, hoisting any variables declared in its initializer.
+func (tx *generatorsTransformer) visitForStatement(node *ast.ForStatement) *ast.Node {
+	if tx.inStatementContainingYield {
+		tx.beginScriptLoopBlock()
+	}
+
+	var updated *ast.Node
+	if initializer := node.Initializer; initializer != nil && ast.IsVariableDeclarationList(initializer) {
+		declarations := initializer.AsVariableDeclarationList().Declarations.Nodes
+		var expressions []*ast.Expression
+		for _, variable := range declarations {
+			tx.EmitContext().AddVariableDeclaration(variable.Name())
+			if variable.Initializer() != nil {
+				expressions = append(expressions, tx.transformInitializedVariable(variable.AsVariableDeclaration()))
+			}
+		}
+		var newInitializer *ast.Expression
+		if len(expressions) > 0 {
+			newInitializer = tx.Factory().InlineExpressions(expressions)
+		}
+		updated = tx.Factory().UpdateForStatement(
+			node,
+			newInitializer,
+			tx.Visitor().VisitNode(node.Condition),
+			tx.Visitor().VisitNode(node.Incrementor),
+			tx.EmitContext().VisitIterationBody(node.Statement, tx.Visitor()),
+		)
+	} else {
+		updated = tx.Visitor().VisitEachChild(node.AsNode())
+	}
+
+	if tx.inStatementContainingYield {
+		tx.endLoopBlock()
+	}
+	return updated
+}
+
+// Transforms a `for..in` statement containing `yield`:
+//
+//	[source]
+//	     for (var p in o) {
+//	         /*body*/
+//	     }
+//
+//	[intermediate]
+//	 .local _b, _a, _c, _i
+//	     _b = [];
+//	     _a = o;
+//	     for (_c in _a) _b.push(_c);
+//	     _i = 0;
+//	 .loop incrementLabel, endLoopLabel
+//	 .mark conditionLabel
+//	 .brfalse endLoopLabel, (_i < _b.length)
+//	     _c = _b[_i];
+//	 .brfalse incrementLabel, (_c in _a)
+//	     p = _c;
+//	     /*body*/
+//	 .mark incrementLabel
+//	     _c++;
+//	 .br conditionLabel
+//	 .endloop
+//	 .mark endLoopLabel
+func (tx *generatorsTransformer) transformAndEmitForInStatement(node *ast.ForInOrOfStatement) {
+	if !containsYield(node.AsNode()) {
+		tx.emitStatement(tx.Visitor().VisitNode(node.AsNode()))
+		return
+	}
+	f := tx.Factory()
+	obj := tx.declareLocal("")       // _a
+	keysArray := tx.declareLocal("") // _b
+	key := tx.declareLocal("")       // _c
+	keysIndex := f.NewLoopVariable() // _i
+	initializer := node.Initializer
+	tx.EmitContext().AddVariableDeclaration(keysIndex)
+	tx.emitAssignment(obj, tx.Visitor().VisitNode(node.Expression), core.UndefinedTextRange())
+	tx.emitAssignment(keysArray, f.NewArrayLiteralExpression(f.NewNodeList(nil), false /*multiLine*/), core.UndefinedTextRange())
+
+	tx.emitStatement(f.NewForInOrOfStatement(
+		ast.KindForInStatement,
+		nil, /*awaitModifier*/
+		key.Clone(f),
+		obj.Clone(f),
+		f.NewExpressionStatement(f.NewCallExpression(
+			f.NewPropertyAccessExpression(keysArray.Clone(f), nil /*questionDotToken*/, f.NewIdentifier("push"), ast.NodeFlagsNone),
+			nil, /*questionDotToken*/
+			nil, /*typeArguments*/
+			f.NewNodeList([]*ast.Expression{key.Clone(f)}),
+			ast.NodeFlagsNone,
+		)),
+	))
+
+	tx.emitAssignment(keysIndex, f.NewNumericLiteral("0"), core.UndefinedTextRange())
+
+	conditionLabel := tx.defineLabel()
+	incrementLabel := tx.defineLabel()
+	endLoopLabel := tx.beginLoopBlock(incrementLabel /*continueLabel*/)
+
+	tx.markLabel(conditionLabel)
+	tx.emitBreakWhenFalse(endLoopLabel, f.NewBinaryExpression(
+		nil, /*modifiers*/
+		keysIndex.Clone(f),
+		nil, /*typeNode*/
+		f.NewToken(ast.KindLessThanToken),
+		f.NewPropertyAccessExpression(keysArray.Clone(f), nil /*questionDotToken*/, f.NewIdentifier("length"), ast.NodeFlagsNone),
+	), core.UndefinedTextRange())
+
+	tx.emitAssignment(key, f.NewElementAccessExpression(keysArray.Clone(f), nil /*questionDotToken*/, keysIndex.Clone(f), ast.NodeFlagsNone), core.UndefinedTextRange())
+	tx.emitBreakWhenFalse(incrementLabel, f.NewBinaryExpression(
+		nil, /*modifiers*/
+		key.Clone(f),
+		nil, /*typeNode*/
+		f.NewToken(ast.KindInKeyword),
+		obj.Clone(f),
+	), core.UndefinedTextRange())
+
+	var variable *ast.Expression
+	if ast.IsVariableDeclarationList(initializer) {
+		declarations := initializer.AsVariableDeclarationList().Declarations.Nodes
+		for _, declaration := range declarations {
+			tx.EmitContext().AddVariableDeclaration(declaration.Name())
+		}
+		variable = declarations[0].Name().Clone(f)
+	} else {
+		variable = tx.Visitor().VisitNode(initializer)
+	}
+
+	tx.emitAssignment(variable, key.Clone(f), core.UndefinedTextRange())
+	tx.transformAndEmitEmbeddedStatement(node.Statement)
+	tx.markLabel(incrementLabel)
+	tx.emitStatement(f.NewExpressionStatement(f.NewPostfixUnaryExpression(keysIndex.Clone(f), ast.KindPlusPlusToken)))
+	tx.emitBreak(conditionLabel, core.UndefinedTextRange())
+	tx.endLoopBlock()
+}
+
+// Visits a `for..in` statement that does not contain `yield`, hoisting any variables declared in its initializer:
+//
+//	[source]
+//	     for (var x in a) {
+//	         /*body*/
+//	     }
+//
+//	[intermediate]
+//	 .local x
+//	 .loop
+//	     for (x in a) {
+//	         /*body*/
+//	     }
+//	 .endloop
+func (tx *generatorsTransformer) visitForInStatement(node *ast.ForInOrOfStatement) *ast.Node {
+	if tx.inStatementContainingYield {
+		tx.beginScriptLoopBlock()
+	}
+
+	var updated *ast.Node
+	if initializer := node.Initializer; ast.IsVariableDeclarationList(initializer) {
+		declarations := initializer.AsVariableDeclarationList().Declarations.Nodes
+		for _, variable := range declarations {
+			tx.EmitContext().AddVariableDeclaration(variable.Name())
+		}
+		updated = tx.Factory().UpdateForInOrOfStatement(
+			node,
+			nil, /*awaitModifier*/
+			declarations[0].Name(),
+			tx.Visitor().VisitNode(node.Expression),
+			tx.Visitor().VisitEmbeddedStatement(node.Statement),
+		)
+	} else {
+		updated = tx.Visitor().VisitEachChild(node.AsNode())
+	}
+
+	if tx.inStatementContainingYield {
+		tx.endLoopBlock()
+	}
+	return updated
+}
+
+func (tx *generatorsTransformer) transformAndEmitContinueStatement(node *ast.ContinueStatement) {
+	label := tx.findContinueTarget(labelTextOf(node.Label))
+	if label > 0 {
+		tx.emitBreak(label, node.Loc)
+	} else {
+		// invalid continue without a containing loop. Leave the node as is, per #17875.
+		tx.emitStatement(node.AsNode())
+	}
+}
+
+func (tx *generatorsTransformer) visitContinueStatement(node *ast.ContinueStatement) *ast.Node {
+	if tx.inStatementContainingYield {
+		if label := tx.findContinueTarget(labelTextOf(node.Label)); label > 0 {
+			return tx.createInlineBreak(label, node.Loc)
+		}
+	}
+	return tx.Visitor().VisitEachChild(node.AsNode())
+}
+
+func (tx *generatorsTransformer) transformAndEmitBreakStatement(node *ast.BreakStatement) {
+	label := tx.findBreakTarget(labelTextOf(node.Label))
+	if label > 0 {
+		tx.emitBreak(label, node.Loc)
+	} else {
+		// invalid break without a containing loop, switch, or labeled statement. Leave the node as is, per #17875.
+		tx.emitStatement(node.AsNode())
+	}
+}
+
+func (tx *generatorsTransformer) visitBreakStatement(node *ast.BreakStatement) *ast.Node {
+	if tx.inStatementContainingYield {
+		if label := tx.findBreakTarget(labelTextOf(node.Label)); label > 0 {
+			return tx.createInlineBreak(label, node.Loc)
+		}
+	}
+	return tx.Visitor().VisitEachChild(node.AsNode())
+}
+
+func labelTextOf(label *ast.IdentifierNode) string {
+	if label == nil {
+		return ""
+	}
+	return label.Text()
+}
+
+func (tx *generatorsTransformer) visitReturnStatement(node *ast.ReturnStatement) *ast.Node {
+	return tx.createInlineReturn(tx.Visitor().VisitNode(node.Expression), node.Loc)
+}
+
+// Transforms a `with` statement containing `yield`:
+//
+//	[source]
+//	     with (x) {
+//	         /*body*/
+//	     }
+//
+//	[intermediate]
+//	 .with (x)
+//	     /*body*/
+//	 .endwith
+func (tx *generatorsTransformer) transformAndEmitWithStatement(node *ast.WithStatement) {
+	if !containsYield(node.AsNode()) {
+		tx.emitStatement(tx.Visitor().VisitNode(node.AsNode()))
+		return
+	}
+	tx.beginWithBlock(tx.cacheExpression(tx.Visitor().VisitNode(node.Expression)))
+	tx.transformAndEmitEmbeddedStatement(node.Statement)
+	tx.endWithBlock()
+}
+
+// Transforms a `switch` statement containing `yield`:
+//
+//	[source]
+//	     switch (x) {
+//	         case a:
+//	             /*caseStatements*/
+//	         case b:
+//	             /*caseStatements*/
+//	         default:
+//	             /*defaultStatements*/
+//	     }
+//
+//	[intermediate]
+//	 .local _a
+//	 .switch endLabel
+//	     _a = x;
+//	     switch (_a) {
+//	         case a:
+//	 .br clauseLabels[0]
+//	     }
+//	     switch (_a) {
+//	         case b:
+//	 .br clauseLabels[1]
+//	     }
+//	 .br clauseLabels[2]
+//	 .mark clauseLabels[0]
+//	     /*caseStatements*/
+//	 .mark clauseLabels[1]
+//	     /*caseStatements*/
+//	 .mark clauseLabels[2]
+//	     /*caseStatements*/
+//	 .endswitch
+//	 .mark endLabel
+func (tx *generatorsTransformer) transformAndEmitSwitchStatement(node *ast.SwitchStatement) {
+	if !containsYield(node.CaseBlock) {
+		tx.emitStatement(tx.Visitor().VisitNode(node.AsNode()))
+		return
+	}
+	f := tx.Factory()
+	clauses := node.CaseBlock.AsCaseBlock().Clauses.Nodes
+	endLabel := tx.beginSwitchBlock()
+	expression := tx.cacheExpression(tx.Visitor().VisitNode(node.Expression))
+
+	// Create labels for each clause and find the index of the first default clause.
+	clauseLabels := make([]generatorLabel, len(clauses))
+	defaultClauseIndex := -1
+	for i, clause := range clauses {
+		clauseLabels[i] = tx.defineLabel()
+		if clause.Kind == ast.KindDefaultClause && defaultClauseIndex == -1 {
+			defaultClauseIndex = i
+		}
+	}
+
+	// Emit switch statements for each run of case clauses either from the first case clause or the next case clause
+	// with a `yield` in its expression, up to the next case clause with a `yield` in its expression.
+	clausesWritten := 0
+	for clausesWritten < len(clauses) {
+		var pendingClauses []*ast.Node
+		defaultClausesSkipped := 0
+		for i := clausesWritten; i < len(clauses); i++ {
+			clause := clauses[i].AsCaseOrDefaultClause()
+			if clause.Kind != ast.KindCaseClause {
+				defaultClausesSkipped++
+				continue
+			}
+			if containsYield(clause.Expression) && len(pendingClauses) > 0 {
+				break
+			}
+			pendingClauses = append(pendingClauses, f.NewCaseOrDefaultClause(
+				ast.KindCaseClause,
+				tx.Visitor().VisitNode(clause.Expression),
+				f.NewNodeList([]*ast.Statement{tx.createInlineBreak(clauseLabels[i], clause.Expression.Loc)}),
+			))
+		}
+
+		if len(pendingClauses) > 0 {
+			tx.emitStatement(f.NewSwitchStatement(expression.Clone(f), f.NewCaseBlock(f.NewNodeList(pendingClauses))))
+			clausesWritten += len(pendingClauses)
+		}
+		clausesWritten += defaultClausesSkipped
+	}
+
+	if defaultClauseIndex >= 0 {
+		tx.emitBreak(clauseLabels[defaultClauseIndex], core.UndefinedTextRange())
+	} else {
+		tx.emitBreak(endLabel, core.UndefinedTextRange())
+	}
+
+	for i, clause := range clauses {
+		tx.markLabel(clauseLabels[i])
+		tx.transformAndEmitStatements(clause.AsCaseOrDefaultClause().Statements.Nodes)
+	}
+
+	tx.endSwitchBlock()
+}
+
+// Visits a `switch` statement that does not contain `yield`, but is contained in a statement that does.
+func (tx *generatorsTransformer) visitSwitchStatement(node *ast.SwitchStatement) *ast.Node {
+	tx.beginScriptBreakableBlock()
+	updated := tx.Visitor().VisitEachChild(node.AsNode())
+	tx.endBreakableBlock()
+	return updated
+}
+
+// Transforms a labeled statement containing `yield`:
+//
+//	[source]
+//	     x: {
+//	         /*body*/
+//	     }
+//
+//	[intermediate]
+//	 .labeled "x", endLabel
+//	     /*body*/
+//	 .endlabeled
+//	 .mark endLabel
+func (tx *generatorsTransformer) transformAndEmitLabeledStatement(node *ast.LabeledStatement) {
+	if !containsYield(node.AsNode()) {
+		tx.emitStatement(tx.Visitor().VisitNode(node.AsNode()))
+		return
+	}
+	tx.beginLabeledBlock(node.Label.Text())
+	tx.transformAndEmitEmbeddedStatement(node.Statement)
+	tx.endLabeledBlock()
+}
+
+// Visits a labeled statement that does not contain `yield`, but is contained in a statement that does.
+func (tx *generatorsTransformer) visitLabeledStatement(node *ast.LabeledStatement) *ast.Node {
+	tx.beginScriptLabeledBlock(node.Label.Text())
+	updated := tx.Visitor().VisitEachChild(node.AsNode())
+	tx.endLabeledBlock()
+	return updated
+}
+
+// Transforms a `try` statement containing `yield`:
+//
+//	[source]
+//	     try {
+//	         /*tryBlock*/
+//	     }
+//	     catch (e) {
+//	         /*catchBlock*/
+//	     }
+//	     finally {
+//	         /*finallyBlock*/
+//	     }
+//
+//	[intermediate]
+//	 .local _a
+//	 .try tryLabel, catchLabel, finallyLabel, endLabel
+//	 .mark tryLabel
+//	 .nop
+//	     /*tryBlock*/
+//	 .br endLabel
+//	 .catch
+//	 .mark catchLabel
+//	     _a = %error%;
+//	     /*catchBlock*/
+//	 .br endLabel
+//	 .finally
+//	 .mark finallyLabel
+//	     /*finallyBlock*/
+//	 .endfinally
+//	 .endtry
+//	 .mark endLabel
+func (tx *generatorsTransformer) transformAndEmitTryStatement(node *ast.TryStatement) {
+	if !containsYield(node.AsNode()) {
+		tx.emitStatement(tx.Visitor().VisitEachChild(node.AsNode()))
+		return
+	}
+	tx.beginExceptionBlock()
+	tx.transformAndEmitEmbeddedStatement(node.TryBlock)
+	if node.CatchClause != nil {
+		catchClause := node.CatchClause.AsCatchClause()
+		tx.beginCatchBlock(catchClause.VariableDeclaration)
+		tx.transformAndEmitEmbeddedStatement(catchClause.Block)
+	}
+	if node.FinallyBlock != nil {
+		tx.beginFinallyBlock()
+		tx.transformAndEmitEmbeddedStatement(node.FinallyBlock)
+	}
+	tx.endExceptionBlock()
+}
+
+func containsYield(node *ast.Node) bool {
+	return node != nil && node.SubtreeFacts()&ast.SubtreeContainsYield != 0
+}
+
+func countInitialNodesWithoutYield(nodes []*ast.Node) int {
+	for i, node := range nodes {
+		if containsYield(node) {
+			return i
+		}
+	}
+	return len(nodes)
+}
+
+// Caches an expression in a temporary variable, unless it is already a generated or helper name.
+func (tx *generatorsTransformer) cacheExpression(node *ast.Expression) *ast.IdentifierNode {
+	if ast.IsIdentifier(node) && (transformers.IsGeneratedIdentifier(tx.EmitContext(), node) || transformers.IsHelperName(tx.EmitContext(), node)) {
+		return node
+	}
+	temp := tx.Factory().NewTempVariable()
+	tx.EmitContext().AddVariableDeclaration(temp)
+	tx.emitAssignment(temp, node, node.Loc)
+	return temp
+}
+
+// Declares a hoisted local variable, with a unique name based on `name` if provided.
+func (tx *generatorsTransformer) declareLocal(name string) *ast.IdentifierNode {
+	var temp *ast.IdentifierNode
+	if name != "" {
+		temp = tx.Factory().NewUniqueName(name)
+	} else {
+		temp = tx.Factory().NewTempVariable()
+	}
+	tx.EmitContext().AddVariableDeclaration(temp)
+	return temp
+}
+
+//
+// Labels and code blocks
+//
+
+// Defines a label, used as the target of a branch.
+func (tx *generatorsTransformer) defineLabel() generatorLabel {
+	if tx.labelOffsets == nil {
+		tx.labelOffsets = []int{-1} // label 0 is never defined
+	}
+	label := tx.nextLabelId
+	tx.nextLabelId++
+	tx.labelOffsets = append(tx.labelOffsets, -1)
+	return label
+}
+
+// Marks the current operation with the specified label.
+func (tx *generatorsTransformer) markLabel(label generatorLabel) {
+	if tx.labelOffsets == nil {
+		panic("No labels were defined.")
+	}
+	tx.labelOffsets[label] = len(tx.operations)
+}
+
+func (tx *generatorsTransformer) beginBlock(block *codeBlock) {
+	tx.blockEvents = append(tx.blockEvents, blockEvent{action: blockActionOpen, offset: len(tx.operations), block: block})
+	tx.blockStack = append(tx.blockStack, block)
+}
+
+func (tx *generatorsTransformer) endBlock() *codeBlock {
+	block := tx.peekBlock()
+	if block == nil {
+		panic("beginBlock was never called.")
+	}
+	tx.blockEvents = append(tx.blockEvents, blockEvent{action: blockActionClose, offset: len(tx.operations), block: block})
+	tx.blockStack = tx.blockStack[:len(tx.blockStack)-1]
+	return block
+}
+
+func (tx *generatorsTransformer) peekBlock() *codeBlock {
+	if len(tx.blockStack) == 0 {
+		return nil
+	}
+	return tx.blockStack[len(tx.blockStack)-1]
+}
+
+func (tx *generatorsTransformer) assertBlockKind(kind codeBlockKind) {
+	if block := tx.peekBlock(); block == nil || block.kind != kind {
+		panic("Unexpected code block kind.")
+	}
+}
+
+// Begins a code block for a generated `with` statement.
+func (tx *generatorsTransformer) beginWithBlock(expression *ast.IdentifierNode) {
+	startLabel := tx.defineLabel()
+	endLabel := tx.defineLabel()
+	tx.markLabel(startLabel)
+	tx.beginBlock(&codeBlock{kind: codeBlockKindWith, expression: expression, startLabel: startLabel, endLabel: endLabel})
+}
+
+// Ends a code block for a generated `with` statement.
+func (tx *generatorsTransformer) endWithBlock() {
+	tx.assertBlockKind(codeBlockKindWith)
+	block := tx.endBlock()
+	tx.markLabel(block.endLabel)
+}
+
+// Begins a code block for a generated `try` statement.
+func (tx *generatorsTransformer) beginExceptionBlock() generatorLabel {
+	startLabel := tx.defineLabel()
+	endLabel := tx.defineLabel()
+	tx.markLabel(startLabel)
+	tx.beginBlock(&codeBlock{kind: codeBlockKindException, state: exceptionBlockStateTry, startLabel: startLabel, endLabel: endLabel})
+	tx.emitNop()
+	return endLabel
+}
+
+// Enters the `catch` clause of a generated `try` statement.
+func (tx *generatorsTransformer) beginCatchBlock(variable *ast.VariableDeclarationNode) {
+	tx.assertBlockKind(codeBlockKindException)
+
+	// generated identifiers should already be unique within a file
+	var name *ast.IdentifierNode
+	if variableName := variable.Name(); transformers.IsGeneratedIdentifier(tx.EmitContext(), variableName) {
+		name = variableName
+		tx.EmitContext().AddVariableDeclaration(variableName)
+	} else {
+		text := variableName.Text()
+		name = tx.declareLocal(text)
+		if tx.renamedCatchVariables == nil {
+			tx.renamedCatchVariables = make(map[string]bool)
+			tx.renamedCatchVariableDeclarations = make(map[*ast.Node]*ast.IdentifierNode)
+		}
+		tx.renamedCatchVariables[text] = true
+		if original := tx.EmitContext().ParseNode(variable); original != nil {
+			tx.renamedCatchVariableDeclarations[original] = name
+		}
+	}
+
+	exception := tx.peekBlock()
+	if exception.state >= exceptionBlockStateCatch {
+		panic("Unexpected exception block state.")
+	}
+
+	tx.emitBreak(exception.endLabel, core.UndefinedTextRange())
+
+	catchLabel := tx.defineLabel()
+	tx.markLabel(catchLabel)
+	exception.state = exceptionBlockStateCatch
+	exception.catchVariable = name
+	exception.catchLabel = catchLabel
+
+	tx.emitAssignment(name, tx.createGeneratorResume(core.UndefinedTextRange()), core.UndefinedTextRange())
+	tx.emitNop()
+}
+
+// Enters the `finally` block of a generated `try` statement.
+func (tx *generatorsTransformer) beginFinallyBlock() {
+	tx.assertBlockKind(codeBlockKindException)
+
+	exception := tx.peekBlock()
+	if exception.state >= exceptionBlockStateFinally {
+		panic("Unexpected exception block state.")
+	}
+
+	tx.emitBreak(exception.endLabel, core.UndefinedTextRange())
+
+	finallyLabel := tx.defineLabel()
+	tx.markLabel(finallyLabel)
+	exception.state = exceptionBlockStateFinally
+	exception.finallyLabel = finallyLabel
+}
+
+// Ends the code block for a generated `try` statement.
+func (tx *generatorsTransformer) endExceptionBlock() {
+	tx.assertBlockKind(codeBlockKindException)
+	exception := tx.endBlock()
+	if exception.state < exceptionBlockStateFinally {
+		tx.emitBreak(exception.endLabel, core.UndefinedTextRange())
+	} else {
+		tx.emitEndfinally()
+	}
+	tx.markLabel(exception.endLabel)
+	tx.emitNop()
+	exception.state = exceptionBlockStateDone
+}
+
+// Begins a code block that supports `break` or `continue` statements that are defined in the source tree and not
+// from generated code.
+func (tx *generatorsTransformer) beginScriptLoopBlock() {
+	tx.beginBlock(&codeBlock{kind: codeBlockKindLoop, isScript: true, breakLabel: -1, continueLabel: -1})
+}
+
+// Begins a code block that supports `break` or `continue` statements that are defined in generated code. Returns a
+// label used to mark the operation to which to jump when a `break` statement targets this block.
+func (tx *generatorsTransformer) beginLoopBlock(continueLabel generatorLabel) generatorLabel {
+	breakLabel := tx.defineLabel()
+	tx.beginBlock(&codeBlock{kind: codeBlockKindLoop, breakLabel: breakLabel, continueLabel: continueLabel})
+	return breakLabel
+}
+
+// Ends a code block that supports `break` or `continue` statements.
+func (tx *generatorsTransformer) endLoopBlock() {
+	tx.assertBlockKind(codeBlockKindLoop)
+	block := tx.endBlock()
+	if !block.isScript {
+		tx.markLabel(block.breakLabel)
+	}
+}
+
+// Begins a code block that supports `break` statements that are defined in the source tree and not from generated
+// code.
+func (tx *generatorsTransformer) beginScriptBreakableBlock() {
+	tx.beginBlock(&codeBlock{kind: codeBlockKindSwitch, isScript: true, breakLabel: -1})
+}
+
+// Begins a code block that supports `break` statements that are defined in generated code. Returns a label used to
+// mark the operation to which to jump when a `break` statement targets this block.
+func (tx *generatorsTransformer) beginSwitchBlock() generatorLabel {
+	breakLabel := tx.defineLabel()
+	tx.beginBlock(&codeBlock{kind: codeBlockKindSwitch, breakLabel: breakLabel})
+	return breakLabel
+}
+
+// Ends a code block that supports `break` statements.
+func (tx *generatorsTransformer) endSwitchBlock() {
+	tx.assertBlockKind(codeBlockKindSwitch)
+	block := tx.endBlock()
+	if !block.isScript {
+		tx.markLabel(block.breakLabel)
+	}
+}
+
+func (tx *generatorsTransformer) endBreakableBlock() {
+	tx.endSwitchBlock()
+}
+
+func (tx *generatorsTransformer) beginScriptLabeledBlock(labelText string) {
+	tx.beginBlock(&codeBlock{kind: codeBlockKindLabeled, isScript: true, labelText: labelText, breakLabel: -1})
+}
+
+func (tx *generatorsTransformer) beginLabeledBlock(labelText string) {
+	breakLabel := tx.defineLabel()
+	tx.beginBlock(&codeBlock{kind: codeBlockKindLabeled, labelText: labelText, breakLabel: breakLabel})
+}
+
+func (tx *generatorsTransformer) endLabeledBlock() {
+	tx.assertBlockKind(codeBlockKindLabeled)
+	block := tx.endBlock()
+	if !block.isScript {
+		tx.markLabel(block.breakLabel)
+	}
+}
+
+func supportsUnlabeledBreak(block *codeBlock) bool {
+	return block.kind == codeBlockKindSwitch || block.kind == codeBlockKindLoop
+}
+
+func supportsLabeledBreakOrContinue(block *codeBlock) bool {
+	return block.kind == codeBlockKindLabeled
+}
+
+func supportsUnlabeledContinue(block *codeBlock) bool {
+	return block.kind == codeBlockKindLoop
+}
+
+func (tx *generatorsTransformer) hasImmediateContainingLabeledBlock(labelText string, start int) bool {
+	for j := start; j >= 0; j-- {
+		containingBlock := tx.blockStack[j]
+		if !supportsLabeledBreakOrContinue(containingBlock) {
+			break
+		}
+		if containingBlock.labelText == labelText {
+			return true
+		}
+	}
+	return false
+}
+
+// Finds the label that is the target for a `break` statement, or 0 if there is none.
+func (tx *generatorsTransformer) findBreakTarget(labelText string) generatorLabel {
+	for i := len(tx.blockStack) - 1; i >= 0; i-- {
+		block := tx.blockStack[i]
+		if labelText != "" {
+			if supportsLabeledBreakOrContinue(block) && block.labelText == labelText {
+				return block.breakLabel
+			} else if supportsUnlabeledBreak(block) && tx.hasImmediateContainingLabeledBlock(labelText, i-1) {
+				return block.breakLabel
+			}
+		} else if supportsUnlabeledBreak(block) {
+			return block.breakLabel
+		}
+	}
+	return 0
+}
+
+// Finds the label that is the target for a `continue` statement, or 0 if there is none.
+func (tx *generatorsTransformer) findContinueTarget(labelText string) generatorLabel {
+	for i := len(tx.blockStack) - 1; i >= 0; i-- {
+		block := tx.blockStack[i]
+		if supportsUnlabeledContinue(block) && (labelText == "" || tx.hasImmediateContainingLabeledBlock(labelText, i-1)) {
+			return block.continueLabel
+		}
+	}
+	return 0
+}
+
+// Creates an expression that can be used to indicate the value for a label. The numeric value of the expression is
+// updated once the state machine has been built.
+func (tx *generatorsTransformer) createLabel(label generatorLabel) *ast.Expression {
+	if label <= 0 {
+		return tx.Factory().NewOmittedExpression()
+	}
+	if tx.labelExpressions == nil {
+		tx.labelExpressions = make(map[generatorLabel][]*ast.Node)
+	}
+	expression := tx.Factory().NewNumericLiteral("-1")
+	tx.labelExpressions[label] = append(tx.labelExpressions[label], expression)
+	return expression
+}
+
+// Creates a numeric literal for the provided instruction, annotated with the name of the instruction.
+func (tx *generatorsTransformer) createInstruction(instruction generatorInstruction) *ast.Expression {
+	literal := tx.Factory().NewNumericLiteral(strconv.Itoa(int(instruction)))
+	tx.EmitContext().AddSyntheticTrailingComment(literal, ast.KindMultiLineCommentTrivia, instruction.name(), false /*hasTrailingNewLine*/)
+	return literal
+}
+
+// Creates a statement that can be used to indicate a break to a label:
+//
+//	return [3 /*break*/, label];
+func (tx *generatorsTransformer) createInlineBreak(label generatorLabel, location core.TextRange) *ast.Statement {
+	if label <= 0 {
+		panic("Invalid label")
+	}
+	f := tx.Factory()
+	statement := f.NewReturnStatement(f.NewArrayLiteralExpression(f.NewNodeList([]*ast.Expression{
+		tx.createInstruction(instructionBreak),
+		tx.createLabel(label),
+	}), false /*multiLine*/))
+	statement.Loc = location
+	return statement
+}
+
+// Creates a statement that can be used to indicate a return:
+//
+//	return [2 /*return*/, expression];
+func (tx *generatorsTransformer) createInlineReturn(expression *ast.Expression, location core.TextRange) *ast.Statement {
+	f := tx.Factory()
+	elements := []*ast.Expression{tx.createInstruction(instructionReturn)}
+	if expression != nil {
+		elements = append(elements, expression)
+	}
+	statement := f.NewReturnStatement(f.NewArrayLiteralExpression(f.NewNodeList(elements), false /*multiLine*/))
+	statement.Loc = location
+	return statement
+}
+
+// Creates an expression that reads the value sent to the generator when it resumes:
+//
+//	_a.sent()
+func (tx *generatorsTransformer) createGeneratorResume(location core.TextRange) *ast.Expression {
+	f := tx.Factory()
+	call := f.NewCallExpression(
+		f.NewPropertyAccessExpression(tx.state.Clone(f), nil /*questionDotToken*/, f.NewIdentifier("sent"), ast.NodeFlagsNone),
+		nil, /*questionDotToken*/
+		nil, /*typeArguments*/
+		f.NewNodeList(nil),
+		ast.NodeFlagsNone,
+	)
+	call.Loc = location
+	return call
+}
+
+//
+// Operations
+//
+
+// Emits an empty instruction, which forces the start of a new label.
+func (tx *generatorsTransformer) emitNop() {
+	tx.emitWorker(generatorOperation{code: opCodeNop})
+}
+
+// Emits a statement, or an empty instruction if the statement was elided.
+func (tx *generatorsTransformer) emitStatement(node *ast.Statement) {
+	if node != nil {
+		tx.emitWorker(generatorOperation{code: opCodeStatement, statement: node})
+	} else {
+		tx.emitNop()
+	}
+}
+
+func (tx *generatorsTransformer) emitAssignment(left *ast.Expression, right *ast.Expression, location core.TextRange) {
+	tx.emitWorker(generatorOperation{code: opCodeAssign, statement: left, expression: right, location: location})
+}
+
+func (tx *generatorsTransformer) emitBreak(label generatorLabel, location core.TextRange) {
+	tx.emitWorker(generatorOperation{code: opCodeBreak, label: label, location: location})
+}
+
+func (tx *generatorsTransformer) emitBreakWhenTrue(label generatorLabel, condition *ast.Expression, location core.TextRange) {
+	tx.emitWorker(generatorOperation{code: opCodeBreakWhenTrue, label: label, expression: condition, location: location})
+}
+
+func (tx *generatorsTransformer) emitBreakWhenFalse(label generatorLabel, condition *ast.Expression, location core.TextRange) {
+	tx.emitWorker(generatorOperation{code: opCodeBreakWhenFalse, label: label, expression: condition, location: location})
+}
+
+func (tx *generatorsTransformer) emitYieldStar(expression *ast.Expression, location core.TextRange) {
+	tx.emitWorker(generatorOperation{code: opCodeYieldStar, expression: expression, location: location})
+}
+
+func (tx *generatorsTransformer) emitYield(expression *ast.Expression, location core.TextRange) {
+	tx.emitWorker(generatorOperation{code: opCodeYield, expression: expression, location: location})
+}
+
+func (tx *generatorsTransformer) emitReturn(expression *ast.Expression, location core.TextRange) {
+	tx.emitWorker(generatorOperation{code: opCodeReturn, expression: expression, location: location})
+}
+
+func (tx *generatorsTransformer) emitThrow(expression *ast.Expression, location core.TextRange) {
+	tx.emitWorker(generatorOperation{code: opCodeThrow, expression: expression, location: location})
+}
+
+func (tx *generatorsTransformer) emitEndfinally() {
+	tx.emitWorker(generatorOperation{code: opCodeEndfinally})
+}
+
+func (tx *generatorsTransformer) emitWorker(operation generatorOperation) {
+	if tx.labelOffsets == nil {
+		// mark entry point
+		tx.markLabel(tx.defineLabel())
+	}
+	tx.operations = append(tx.operations, operation)
+}
+
+//
+// Building the state machine
+//
+
+// Builds the state machine for the current generator function body and passes it to the `__generator` helper.
+func (tx *generatorsTransformer) build() *ast.Expression {
+	f := tx.Factory()
+	tx.generatorBuildState = generatorBuildState{}
+	buildResult := tx.buildStatements()
+	function := f.NewFunctionExpression(
+		nil, /*modifiers*/
+		nil, /*asteriskToken*/
+		nil, /*name*/
+		nil, /*typeParameters*/
+		f.NewNodeList([]*ast.Node{f.NewParameterDeclaration(nil /*modifiers*/, nil /*dotDotDotToken*/, tx.state, nil /*questionToken*/, nil /*typeNode*/, nil /*initializer*/)}),
+		nil, /*returnType*/
+		f.NewBlock(f.NewNodeList(buildResult), len(buildResult) > 0 /*multiLine*/),
+	)
+	tx.EmitContext().SetEmitFlags(function, printer.EFReuseTempVariableScope)
+	return f.NewGeneratorHelper(function)
+}
+
+func (tx *generatorsTransformer) buildStatements() []*ast.Statement {
+	for operationIndex := range tx.operations {
+		tx.writeOperation(operationIndex)
+	}
+	tx.flushFinalLabel(len(tx.operations))
+
+	if tx.clauses != nil {
+		f := tx.Factory()
+		labelExpression := f.NewPropertyAccessExpression(tx.state.Clone(f), nil /*questionDotToken*/, f.NewIdentifier("label"), ast.NodeFlagsNone)
+		switchStatement := f.NewSwitchStatement(labelExpression, f.NewCaseBlock(f.NewNodeList(tx.clauses)))
+		tx.EmitContext().AddEmitFlags(switchStatement, printer.EFStartOnNewLine)
+		return []*ast.Statement{switchStatement}
+	}
+	return tx.statements
+}
+
+// Flushes the current label and starts a new one.
+func (tx *generatorsTransformer) flushLabel() {
+	if tx.statements == nil {
+		return
+	}
+	tx.appendLabel(!tx.lastOperationWasAbrupt /*markLabelEnd*/)
+	tx.lastOperationWasAbrupt = false
+	tx.lastOperationWasCompletion = false
+	tx.labelNumber++
+}
+
+// Flushes the final label, adding a final return if the end of the generator is reachable.
+func (tx *generatorsTransformer) flushFinalLabel(operationIndex int) {
+	if tx.isFinalLabelReachable(operationIndex) {
+		tx.tryEnterLabel(operationIndex)
+		tx.withBlockStack = nil
+		tx.writeReturn(nil /*expression*/, core.UndefinedTextRange())
+	}
+	if tx.statements != nil && tx.clauses != nil {
+		tx.appendLabel(false /*markLabelEnd*/)
+	}
+	tx.updateLabelExpressions()
+}
+
+func (tx *generatorsTransformer) isFinalLabelReachable(operationIndex int) bool {
+	// if the last operation was *not* a completion (return/throw) then the final label is reachable.
+	if !tx.lastOperationWasCompletion {
+		return true
+	}
+
+	// if there are no labels defined or referenced, then the final label is not reachable.
+	if tx.labelOffsets == nil || tx.labelExpressions == nil {
+		return false
+	}
+
+	// if the label for this offset is referenced, then the final label is reachable.
+	for label, offset := range tx.labelOffsets {
+		if offset == operationIndex && tx.labelExpressions[generatorLabel(label)] != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// Appends a case clause for the last label and sets the new label.
+func (tx *generatorsTransformer) appendLabel(markLabelEnd bool) {
+	f := tx.Factory()
+	if tx.statements != nil {
+		// The previous label was nested inside one or more `with` blocks, so we surround the statements in generated
+		// `with` blocks to create the same environment.
+		for i := len(tx.withBlockStack) - 1; i >= 0; i-- {
+			withBlock := tx.withBlockStack[i]
+			tx.statements = []*ast.Statement{f.NewWithStatement(withBlock.expression.Clone(f), f.NewBlock(f.NewNodeList(tx.statements), false /*multiLine*/))}
+		}
+
+		if tx.currentExceptionBlock != nil {
+			// The previous label was nested inside of an exception block, so we must indicate entry into a protected
+			// region by pushing the label numbers for each block in the protected region.
+			exception := tx.currentExceptionBlock
+			tryPush := f.NewExpressionStatement(f.NewCallExpression(
+				f.NewPropertyAccessExpression(
+					f.NewPropertyAccessExpression(tx.state.Clone(f), nil /*questionDotToken*/, f.NewIdentifier("trys"), ast.NodeFlagsNone),
+					nil, /*questionDotToken*/
+					f.NewIdentifier("push"),
+					ast.NodeFlagsNone,
+				),
+				nil, /*questionDotToken*/
+				nil, /*typeArguments*/
+				f.NewNodeList([]*ast.Expression{
+					f.NewArrayLiteralExpression(f.NewNodeList([]*ast.Expression{
+						tx.createLabel(exception.startLabel),
+						tx.createLabel(exception.catchLabel),
+						tx.createLabel(exception.finallyLabel),
+						tx.createLabel(exception.endLabel),
+					}), false /*multiLine*/),
+				}),
+				ast.NodeFlagsNone,
+			))
+			tx.statements = append([]*ast.Statement{tryPush}, tx.statements...)
+			tx.currentExceptionBlock = nil
+		}
+
+		if markLabelEnd {
+			// The case clause for the last label falls through to this label, so we add an assignment statement to
+			// reflect the change in labels.
+			tx.statements = append(tx.statements, f.NewExpressionStatement(f.NewAssignmentExpression(
+				f.NewPropertyAccessExpression(tx.state.Clone(f), nil /*questionDotToken*/, f.NewIdentifier("label"), ast.NodeFlagsNone),
+				f.NewNumericLiteral(strconv.Itoa(tx.labelNumber+1)),
+			)))
+		}
+	}
+
+	tx.clauses = append(tx.clauses, f.NewCaseOrDefaultClause(
+		ast.KindCaseClause,
+		f.NewNumericLiteral(strconv.Itoa(tx.labelNumber)),
+		f.NewNodeList(tx.statements),
+	))
+	tx.statements = nil
+}
+
+// Tries to enter into a new label at the current operation index.
+func (tx *generatorsTransformer) tryEnterLabel(operationIndex int) {
+	for label, offset := range tx.labelOffsets {
+		if offset != operationIndex {
+			continue
+		}
+		tx.flushLabel()
+		for len(tx.labelNumbers) <= tx.labelNumber {
+			tx.labelNumbers = append(tx.labelNumbers, nil)
+		}
+		tx.labelNumbers[tx.labelNumber] = append(tx.labelNumbers[tx.labelNumber], generatorLabel(label))
+	}
+}
+
+// Updates literal expressions for labels with actual label numbers.
+func (tx *generatorsTransformer) updateLabelExpressions() {
+	if tx.labelExpressions == nil {
+		return
+	}
+	for labelNumber, labels := range tx.labelNumbers {
+		for _, label := range labels {
+			for _, expression := range tx.labelExpressions[label] {
+				expression.AsNumericLiteral().Text = strconv.Itoa(labelNumber)
+			}
+		}
+	}
+}
+
+// Tries to enter or leave a code block.
+func (tx *generatorsTransformer) tryEnterOrLeaveBlock(operationIndex int) {
+	for ; tx.blockIndex < len(tx.blockEvents) && tx.blockEvents[tx.blockIndex].offset <= operationIndex; tx.blockIndex++ {
+		event := tx.blockEvents[tx.blockIndex]
+		switch event.block.kind {
+		case codeBlockKindException:
+			if event.action == blockActionOpen {
+				if tx.statements == nil {
+					tx.statements = []*ast.Statement{}
+				}
+				tx.exceptionBlockStack = append(tx.exceptionBlockStack, tx.currentExceptionBlock)
+				tx.currentExceptionBlock = event.block
+			} else {
+				tx.currentExceptionBlock = tx.exceptionBlockStack[len(tx.exceptionBlockStack)-1]
+				tx.exceptionBlockStack = tx.exceptionBlockStack[:len(tx.exceptionBlockStack)-1]
+			}
+		case codeBlockKindWith:
+			if event.action == blockActionOpen {
+				tx.withBlockStack = append(tx.withBlockStack, event.block)
+			} else {
+				tx.withBlockStack = tx.withBlockStack[:len(tx.withBlockStack)-1]
+			}
+		}
+	}
+}
+
+// Writes an operation as a statement to the current label's statement list.
+func (tx *generatorsTransformer) writeOperation(operationIndex int) {
+	tx.tryEnterLabel(operationIndex)
+	tx.tryEnterOrLeaveBlock(operationIndex)
+
+	// early termination, nothing else to process in this label
+	if tx.lastOperationWasAbrupt {
+		return
+	}
+
+	tx.lastOperationWasAbrupt = false
+	tx.lastOperationWasCompletion = false
+
+	operation := tx.operations[operationIndex]
+	switch operation.code {
+	case opCodeNop:
+		return
+	case opCodeEndfinally:
+		tx.writeEndfinally()
+	case opCodeStatement:
+		tx.writeStatement(operation.statement)
+	case opCodeAssign:
+		tx.writeAssign(operation.statement, operation.expression, operation.location)
+	case opCodeBreak:
+		tx.writeBreak(operation.label, operation.location)
+	case opCodeBreakWhenTrue:
+		tx.writeBreakWhenTrue(operation.label, operation.expression, operation.location)
+	case opCodeBreakWhenFalse:
+		tx.writeBreakWhenFalse(operation.label, operation.expression, operation.location)
+	case opCodeYield:
+		tx.writeYield(operation.expression, operation.location)
+	case opCodeYieldStar:
+		tx.writeYieldStar(operation.expression, operation.location)
+	case opCodeReturn:
+		tx.writeReturn(operation.expression, operation.location)
+	case opCodeThrow:
+		tx.writeThrow(operation.expression, operation.location)
+	}
+}
+
+func (tx *generatorsTransformer) writeStatement(statement *ast.Statement) {
+	if statement != nil {
+		tx.statements = append(tx.statements, statement)
+	}
+}
+
+// Writes an Assign operation to the current label's statement list.
+func (tx *generatorsTransformer) writeAssign(left *ast.Expression, right *ast.Expression, operationLocation core.TextRange) {
+	statement := tx.Factory().NewExpressionStatement(tx.Factory().NewAssignmentExpression(left, right))
+	statement.Loc = operationLocation
+	tx.writeStatement(statement)
+}
+
+// Writes a Throw operation to the current label's statement list.
+func (tx *generatorsTransformer) writeThrow(expression *ast.Expression, operationLocation core.TextRange) {
+	tx.lastOperationWasAbrupt = true
+	tx.lastOperationWasCompletion = true
+	statement := tx.Factory().NewThrowStatement(expression)
+	statement.Loc = operationLocation
+	tx.writeStatement(statement)
+}
+
+// Writes a Return operation to the current label's statement list.
+func (tx *generatorsTransformer) writeReturn(expression *ast.Expression, operationLocation core.TextRange) {
+	tx.lastOperationWasAbrupt = true
+	tx.lastOperationWasCompletion = true
+	elements := []*ast.Expression{tx.createInstruction(instructionReturn)}
+	if expression != nil {
+		elements = append(elements, expression)
+	}
+	tx.writeStatement(tx.createInstructionStatement(elements, operationLocation))
+}
+
+// Writes a Break operation to the current label's statement list.
+func (tx *generatorsTransformer) writeBreak(label generatorLabel, operationLocation core.TextRange) {
+	tx.lastOperationWasAbrupt = true
+	tx.writeStatement(tx.createInstructionStatement([]*ast.Expression{tx.createInstruction(instructionBreak), tx.createLabel(label)}, operationLocation))
+}
+
+// Writes a BreakWhenTrue operation to the current label's statement list.
+func (tx *generatorsTransformer) writeBreakWhenTrue(label generatorLabel, condition *ast.Expression, operationLocation core.TextRange) {
+	statement := tx.Factory().NewIfStatement(
+		condition,
+		tx.createInstructionStatement([]*ast.Expression{tx.createInstruction(instructionBreak), tx.createLabel(label)}, operationLocation),
+		nil, /*elseStatement*/
+	)
+	tx.EmitContext().SetEmitFlags(statement, printer.EFSingleLine)
+	tx.writeStatement(statement)
+}
+
+// Writes a BreakWhenFalse operation to the current label's statement list.
+func (tx *generatorsTransformer) writeBreakWhenFalse(label generatorLabel, condition *ast.Expression, operationLocation core.TextRange) {
+	f := tx.Factory()
+	statement := f.NewIfStatement(
+		f.NewPrefixUnaryExpression(ast.KindExclamationToken, f.NewParenthesizedExpression(condition)),
+		tx.createInstructionStatement([]*ast.Expression{tx.createInstruction(instructionBreak), tx.createLabel(label)}, operationLocation),
+		nil, /*elseStatement*/
+	)
+	tx.EmitContext().SetEmitFlags(statement, printer.EFSingleLine)
+	tx.writeStatement(statement)
+}
+
+// Writes a Yield operation to the current label's statement list.
+func (tx *generatorsTransformer) writeYield(expression *ast.Expression, operationLocation core.TextRange) {
+	tx.lastOperationWasAbrupt = true
+	elements := []*ast.Expression{tx.createInstruction(instructionYield)}
+	if expression != nil {
+		elements = append(elements, expression)
+	}
+	tx.writeStatement(tx.createInstructionStatement(elements, operationLocation))
+}
+
+// Writes a YieldStar instruction to the current label's statement list.
+func (tx *generatorsTransformer) writeYieldStar(expression *ast.Expression, operationLocation core.TextRange) {
+	tx.lastOperationWasAbrupt = true
+	tx.writeStatement(tx.createInstructionStatement([]*ast.Expression{tx.createInstruction(instructionYieldStar), expression}, operationLocation))
+}
+
+// Writes an Endfinally instruction to the current label's statement list.
+func (tx *generatorsTransformer) writeEndfinally() {
+	tx.lastOperationWasAbrupt = true
+	tx.writeStatement(tx.createInstructionStatement([]*ast.Expression{tx.createInstruction(instructionEndfinally)}, core.UndefinedTextRange()))
+}
+
+// return [instruction, ...operands];
+func (tx *generatorsTransformer) createInstructionStatement(elements []*ast.Expression, location core.TextRange) *ast.Statement {
+	f := tx.Factory()
+	statement := f.NewReturnStatement(f.NewArrayLiteralExpression(f.NewNodeList(elements), false /*multiLine*/))
+	statement.Loc = location
+	tx.EmitContext().SetEmitFlags(statement, printer.EFNoTokenSourceMaps)
+	return statement
+}
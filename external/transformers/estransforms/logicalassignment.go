@@ -2,7 +2,6 @@ package estransforms
 
 import (
 	"github.com/pagpeter/typescript-go/external/ast"
-	"github.com/pagpeter/typescript-go/external/printer"
 	"github.com/pagpeter/typescript-go/external/transformers"
 )
 
@@ -14,7 +13,7 @@ func (ch *logicalAssignmentTransformer) visit(node *ast.Node) *ast.Node {
 	return node // !!!
 }
 
-func newLogicalAssignmentTransformer(emitContext *printer.EmitContext) *transformers.Transformer {
+func newLogicalAssignmentTransformer(opts *transformers.TransformOptions) *transformers.Transformer {
 	tx := &logicalAssignmentTransformer{}
-	return tx.NewTransformer(tx.visit, emitContext)
+	return tx.NewTransformer(tx.visit, opts.Context)
 }
@@ -162,16 +162,16 @@ func getAssignedNameOfPropertyName(emitContext *printer.EmitContext, name *ast.P
 		return assignedName, name
 	}
 
+	if !ast.IsComputedPropertyName(name) {
+		panic("Expected computed property name")
+	}
+
 	expression := name.Expression()
 	if ast.IsPropertyNameLiteral(expression) && !ast.IsIdentifier(expression) {
 		assignedName := factory.NewStringLiteralFromNode(expression)
 		return assignedName, name
 	}
 
-	if !ast.IsComputedPropertyName(expression) {
-		panic("Expected computed property name")
-	}
-
 	assignedName = factory.NewGeneratedNameForNode(name)
 	emitContext.AddVariableDeclaration(assignedName)
 
@@ -2,7 +2,6 @@ package estransforms
 
 import (
 	"github.com/pagpeter/typescript-go/external/ast"
-	"github.com/pagpeter/typescript-go/external/printer"
 	"github.com/pagpeter/typescript-go/external/transformers"
 )
 
@@ -11,10 +10,46 @@ type nullishCoalescingTransformer struct {
 }
 
 func (ch *nullishCoalescingTransformer) visit(node *ast.Node) *ast.Node {
-	return node // !!!
+	if node.SubtreeFacts()&ast.SubtreeContainsNullishCoalescing == 0 {
+		return node
+	}
+	switch node.Kind {
+	case ast.KindBinaryExpression:
+		return ch.visitBinaryExpression(node.AsBinaryExpression())
+	default:
+		return ch.Visitor().VisitEachChild(node)
+	}
 }
 
-func newNullishCoalescingTransformer(emitContext *printer.EmitContext) *transformers.Transformer {
+func (ch *nullishCoalescingTransformer) visitBinaryExpression(node *ast.BinaryExpression) *ast.Node {
+	if node.OperatorToken.Kind != ast.KindQuestionQuestionToken {
+		return ch.Visitor().VisitEachChild(node.AsNode())
+	}
+
+	// a ?? b -> a !== null && a !== void 0 ? a : b
+	// f() ?? b -> (_a = f()) !== null && _a !== void 0 ? _a : b
+	left := ch.Visitor().VisitNode(node.Left)
+	var right *ast.Expression
+	if isSimpleCopiableExpression(left) {
+		right = left.Clone(ch.Factory())
+	} else {
+		right = ch.Factory().NewTempVariable()
+		ch.EmitContext().AddVariableDeclaration(right)
+		left = ch.Factory().NewAssignmentExpression(right, left)
+	}
+	result := ch.Factory().NewConditionalExpression(
+		createNotNullCondition(ch.Factory(), left, right.Clone(ch.Factory()), false /*invert*/),
+		ch.Factory().NewToken(ast.KindQuestionToken),
+		right.Clone(ch.Factory()),
+		ch.Factory().NewToken(ast.KindColonToken),
+		ch.Visitor().VisitNode(node.Right),
+	)
+	ch.EmitContext().SetOriginal(result, node.AsNode())
+	result.Loc = node.Loc
+	return result
+}
+
+func newNullishCoalescingTransformer(opts *transformers.TransformOptions) *transformers.Transformer {
 	tx := &nullishCoalescingTransformer{}
-	return tx.NewTransformer(tx.visit, emitContext)
+	return tx.NewTransformer(tx.visit, opts.Context)
 }
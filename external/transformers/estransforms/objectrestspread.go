@@ -2,19 +2,451 @@ package estransforms
 
 import (
 	"github.com/pagpeter/typescript-go/external/ast"
+	"github.com/pagpeter/typescript-go/external/collections"
+	"github.com/pagpeter/typescript-go/external/core"
 	"github.com/pagpeter/typescript-go/external/printer"
 	"github.com/pagpeter/typescript-go/external/transformers"
 )
 
 type objectRestSpreadTransformer struct {
 	transformers.Transformer
+	compilerOptions       *core.CompilerOptions
+	discardedValueVisitor *ast.NodeVisitor // visits expressions whose result is not observed
+	parameterVisitor      *ast.NodeVisitor // visits parameters, including those that follow a parameter with an object rest
+
+	exportedVariableStatement                 bool
+	parametersWithPrecedingObjectRestOrSpread *collections.Set[*ast.Node]
+}
+
+func newObjectRestSpreadTransformer(opts *transformers.TransformOptions) *transformers.Transformer {
+	tx := &objectRestSpreadTransformer{compilerOptions: opts.CompilerOptions}
+	result := tx.NewTransformer(tx.visit, opts.Context)
+	tx.discardedValueVisitor = tx.EmitContext().NewNodeVisitor(tx.visitDiscardedValue)
+	tx.parameterVisitor = tx.EmitContext().NewNodeVisitor(func(node *ast.Node) *ast.Node {
+		return tx.visitParameter(node.AsParameterDeclaration())
+	})
+	return result
 }
 
 func (ch *objectRestSpreadTransformer) visit(node *ast.Node) *ast.Node {
-	return node // !!!
+	if node.SubtreeFacts()&ast.SubtreeContainsESObjectRestOrSpread == 0 {
+		return node
+	}
+	switch node.Kind {
+	case ast.KindSourceFile:
+		return ch.visitSourceFile(node.AsSourceFile())
+	case ast.KindObjectLiteralExpression:
+		return ch.visitObjectLiteralExpression(node.AsObjectLiteralExpression())
+	case ast.KindBinaryExpression:
+		return ch.visitBinaryExpression(node.AsBinaryExpression(), false /*discarded*/)
+	case ast.KindParenthesizedExpression:
+		return ch.visitParenthesizedExpression(node.AsParenthesizedExpression(), false /*discarded*/)
+	case ast.KindExpressionStatement:
+		return ch.Factory().UpdateExpressionStatement(node.AsExpressionStatement(), ch.discardedValueVisitor.VisitNode(node.Expression()))
+	case ast.KindForStatement:
+		return ch.visitForStatement(node.AsForStatement())
+	case ast.KindForOfStatement:
+		return ch.visitForOfStatement(node.AsForInOrOfStatement())
+	case ast.KindCatchClause:
+		return ch.visitCatchClause(node.AsCatchClause())
+	case ast.KindVariableStatement:
+		return ch.visitVariableStatement(node.AsVariableStatement())
+	case ast.KindVariableDeclaration:
+		return ch.visitVariableDeclaration(node.AsVariableDeclaration())
+	case ast.KindParameter:
+		return ch.visitParameter(node.AsParameterDeclaration())
+	case ast.KindConstructor,
+		ast.KindMethodDeclaration,
+		ast.KindGetAccessor,
+		ast.KindSetAccessor,
+		ast.KindFunctionDeclaration,
+		ast.KindFunctionExpression,
+		ast.KindArrowFunction:
+		return ch.visitFunctionLikeDeclaration(node)
+	default:
+		return ch.Visitor().VisitEachChild(node)
+	}
+}
+
+// Visits an expression whose result is not observed, such as the expression of an `ExpressionStatement`. This allows
+// destructuring assignments to avoid capturing the value of their right-hand side.
+func (ch *objectRestSpreadTransformer) visitDiscardedValue(node *ast.Node) *ast.Node {
+	if node.SubtreeFacts()&ast.SubtreeContainsESObjectRestOrSpread == 0 {
+		return node
+	}
+	switch node.Kind {
+	case ast.KindBinaryExpression:
+		return ch.visitBinaryExpression(node.AsBinaryExpression(), true /*discarded*/)
+	case ast.KindParenthesizedExpression:
+		return ch.visitParenthesizedExpression(node.AsParenthesizedExpression(), true /*discarded*/)
+	default:
+		return ch.visit(node)
+	}
+}
+
+func (ch *objectRestSpreadTransformer) visitSourceFile(node *ast.SourceFile) *ast.Node {
+	if node.IsDeclarationFile {
+		return node.AsNode()
+	}
+	visited := ch.Visitor().VisitEachChild(node.AsNode())
+	ch.EmitContext().AddEmitHelper(visited, ch.EmitContext().ReadEmitHelpers()...)
+	return visited
+}
+
+// Lowers an object literal containing spread assignments to calls to `Object.assign` (or `__assign`):
+//
+//	{ a, ...b, c } -> Object.assign(Object.assign({ a }, b), { c })
+func (ch *objectRestSpreadTransformer) visitObjectLiteralExpression(node *ast.ObjectLiteralExpression) *ast.Node {
+	if node.SubtreeFacts()&ast.SubtreeContainsObjectRestOrSpread == 0 {
+		return ch.Visitor().VisitEachChild(node.AsNode())
+	}
+
+	objects := ch.chunkObjectLiteralElements(node.Properties.Nodes)
+	if len(objects) > 0 && !ast.IsObjectLiteralExpression(objects[0]) {
+		objects = append([]*ast.Expression{ch.Factory().NewObjectLiteralExpression(ch.Factory().NewNodeList(nil), false /*multiLine*/)}, objects...)
+	}
+
+	scriptTarget := ch.compilerOptions.GetEmitScriptTarget()
+	if len(objects) == 1 {
+		return ch.Factory().NewAssignHelper(objects, scriptTarget)
+	}
+	expression := objects[0]
+	for _, object := range objects[1:] {
+		expression = ch.Factory().NewAssignHelper([]*ast.Expression{expression, object}, scriptTarget)
+	}
+	return expression
+}
+
+func (ch *objectRestSpreadTransformer) chunkObjectLiteralElements(elements []*ast.Node) []*ast.Expression {
+	var chunkObject []*ast.Node
+	var objects []*ast.Expression
+	for _, e := range elements {
+		if ast.IsSpreadAssignment(e) {
+			if len(chunkObject) > 0 {
+				objects = append(objects, ch.Factory().NewObjectLiteralExpression(ch.Factory().NewNodeList(chunkObject), false /*multiLine*/))
+				chunkObject = nil
+			}
+			objects = append(objects, ch.Visitor().VisitNode(e.AsSpreadAssignment().Expression))
+		} else if ast.IsPropertyAssignment(e) {
+			chunkObject = append(chunkObject, ch.Factory().NewPropertyAssignment(nil /*modifiers*/, e.Name(), nil /*postfixToken*/, nil /*typeNode*/, ch.Visitor().VisitNode(e.Initializer())))
+		} else {
+			chunkObject = append(chunkObject, ch.Visitor().VisitNode(e))
+		}
+	}
+	if len(chunkObject) > 0 {
+		objects = append(objects, ch.Factory().NewObjectLiteralExpression(ch.Factory().NewNodeList(chunkObject), false /*multiLine*/))
+	}
+	return objects
+}
+
+func (ch *objectRestSpreadTransformer) visitBinaryExpression(node *ast.BinaryExpression, discarded bool) *ast.Node {
+	if ast.IsDestructuringAssignment(node.AsNode()) && containsObjectRestOrSpread(node.Left) {
+		return flattenDestructuringAssignment(ch.EmitContext(), ch.Visitor(), node.AsNode(), !discarded, flattenLevelObjectRest, false /*downlevelIteration*/)
+	}
+	if node.OperatorToken.Kind == ast.KindCommaToken {
+		rightVisitor := ch.Visitor()
+		if discarded {
+			rightVisitor = ch.discardedValueVisitor
+		}
+		return ch.Factory().UpdateBinaryExpression(node, nil /*modifiers*/, ch.discardedValueVisitor.VisitNode(node.Left), nil /*typeNode*/, node.OperatorToken, rightVisitor.VisitNode(node.Right))
+	}
+	return ch.Visitor().VisitEachChild(node.AsNode())
+}
+
+func (ch *objectRestSpreadTransformer) visitParenthesizedExpression(node *ast.ParenthesizedExpression, discarded bool) *ast.Node {
+	visitor := ch.Visitor()
+	if discarded {
+		visitor = ch.discardedValueVisitor
+	}
+	return ch.Factory().UpdateParenthesizedExpression(node, visitor.VisitNode(node.Expression))
+}
+
+func (ch *objectRestSpreadTransformer) visitForStatement(node *ast.ForStatement) *ast.Node {
+	return ch.Factory().UpdateForStatement(
+		node,
+		ch.discardedValueVisitor.VisitNode(node.Initializer),
+		ch.Visitor().VisitNode(node.Condition),
+		ch.discardedValueVisitor.VisitNode(node.Incrementor),
+		ch.EmitContext().VisitIterationBody(node.Statement, ch.Visitor()),
+	)
+}
+
+// Moves an object rest element out of the initializer of a `for..of` statement and into its body:
+//
+//	for (const { a, ...b } of c) {} -> for (let _a of c) { const { a } = _a, b = __rest(_a, ["a"]); }
+func (ch *objectRestSpreadTransformer) visitForOfStatement(node *ast.ForInOrOfStatement) *ast.Node {
+	initializer := ast.SkipParentheses(node.Initializer)
+	if initializer.SubtreeFacts()&ast.SubtreeContainsObjectRestOrSpread == 0 &&
+		!(ast.IsBindingOrAssignmentPattern(initializer) && containsObjectRestOrSpread(initializer)) {
+		return ch.Visitor().VisitEachChild(node.AsNode())
+	}
+	if !ast.IsVariableDeclarationList(initializer) && !ast.IsBindingOrAssignmentPattern(initializer) {
+		return ch.Visitor().VisitEachChild(node.AsNode())
+	}
+
+	f := ch.Factory()
+	temp := f.NewTempVariable()
+	statements := []*ast.Statement{createForOfBindingStatement(f, initializer, temp.Clone(f))}
+	bodyLocation := core.NewTextRange(-1, -1)
+	statementsLocation := core.NewTextRange(-1, -1)
+	if ast.IsBlock(node.Statement) {
+		statements = append(statements, node.Statement.AsBlock().Statements.Nodes...)
+		bodyLocation = node.Statement.Loc
+		statementsLocation = node.Statement.AsBlock().Statements.Loc
+	} else if node.Statement != nil {
+		statements = append(statements, node.Statement)
+		bodyLocation = node.Statement.Loc
+		statementsLocation = node.Statement.Loc
+	}
+
+	declaration := f.NewVariableDeclaration(temp, nil /*exclamationToken*/, nil /*typeNode*/, nil /*initializer*/)
+	declaration.Loc = node.Initializer.Loc
+	declarationList := f.NewVariableDeclarationList(ast.NodeFlagsLet, f.NewNodeList([]*ast.Node{declaration}))
+	declarationList.Loc = node.Initializer.Loc
+	statementList := f.NewNodeList(statements)
+	statementList.Loc = statementsLocation
+	body := f.NewBlock(statementList, true /*multiLine*/)
+	body.Loc = bodyLocation
+
+	updated := f.UpdateForInOrOfStatement(node, node.AwaitModifier, declarationList, node.Expression, body)
+	return ch.Visitor().VisitEachChild(updated)
+}
+
+// Creates the statement that binds the value of each iteration of a `for..of` statement to the original
+// initializer of the statement.
+func createForOfBindingStatement(factory *printer.NodeFactory, node *ast.Node, boundValue *ast.Expression) *ast.Statement {
+	if ast.IsVariableDeclarationList(node) {
+		firstDeclaration := node.AsVariableDeclarationList().Declarations.Nodes[0]
+		updatedDeclaration := factory.UpdateVariableDeclaration(firstDeclaration.AsVariableDeclaration(), firstDeclaration.Name(), nil /*exclamationToken*/, nil /*typeNode*/, boundValue)
+		statement := factory.NewVariableStatement(nil /*modifiers*/, factory.UpdateVariableDeclarationList(node.AsVariableDeclarationList(), factory.NewNodeList([]*ast.Node{updatedDeclaration})))
+		statement.Loc = node.Loc
+		return statement
+	}
+	updatedExpression := factory.NewAssignmentExpression(node, boundValue)
+	updatedExpression.Loc = node.Loc
+	statement := factory.NewExpressionStatement(updatedExpression)
+	statement.Loc = node.Loc
+	return statement
+}
+
+// Moves an object rest element out of the variable of a catch clause and into its block:
+//
+//	catch ({ a, ...b }) {} -> catch (_a) { var { a } = _a, b = __rest(_a, ["a"]); }
+func (ch *objectRestSpreadTransformer) visitCatchClause(node *ast.CatchClause) *ast.Node {
+	if node.VariableDeclaration == nil ||
+		!ast.IsBindingPattern(node.VariableDeclaration.Name()) ||
+		node.VariableDeclaration.Name().SubtreeFacts()&ast.SubtreeContainsObjectRestOrSpread == 0 {
+		return ch.Visitor().VisitEachChild(node.AsNode())
+	}
+
+	f := ch.Factory()
+	variableDeclaration := node.VariableDeclaration.AsVariableDeclaration()
+	name := f.NewGeneratedNameForNode(variableDeclaration.Name())
+	updatedDeclaration := f.UpdateVariableDeclaration(variableDeclaration, variableDeclaration.Name(), nil /*exclamationToken*/, nil /*typeNode*/, name)
+	visitedBindings := flattenDestructuringBinding(ch.EmitContext(), ch.Visitor(), updatedDeclaration, nil /*rval*/, flattenLevelObjectRest, false /*downlevelIteration*/, false /*hoistTempVariables*/, false /*skipInitializer*/)
+	block := ch.Visitor().VisitNode(node.Block)
+	if len(visitedBindings) > 0 {
+		statement := f.NewVariableStatement(nil /*modifiers*/, f.NewVariableDeclarationList(ast.NodeFlagsNone, f.NewNodeList(visitedBindings)))
+		statements := append([]*ast.Statement{statement}, block.AsBlock().Statements.Nodes...)
+		statementList := f.NewNodeList(statements)
+		statementList.Loc = block.AsBlock().Statements.Loc
+		block = f.UpdateBlock(block.AsBlock(), statementList)
+	}
+	return f.UpdateCatchClause(
+		node,
+		f.UpdateVariableDeclaration(variableDeclaration, name.Clone(f), nil /*exclamationToken*/, nil /*typeNode*/, nil /*initializer*/),
+		block,
+	)
+}
+
+func (ch *objectRestSpreadTransformer) visitVariableStatement(node *ast.VariableStatement) *ast.Node {
+	savedExportedVariableStatement := ch.exportedVariableStatement
+	ch.exportedVariableStatement = ast.HasSyntacticModifier(node.AsNode(), ast.ModifierFlagsExport)
+	updated := ch.Visitor().VisitEachChild(node.AsNode())
+	ch.exportedVariableStatement = savedExportedVariableStatement
+	return updated
+}
+
+func (ch *objectRestSpreadTransformer) visitVariableDeclaration(node *ast.VariableDeclaration) *ast.Node {
+	exportedVariableStatement := ch.exportedVariableStatement
+	ch.exportedVariableStatement = false
+
+	// If we are here it is because the name contains a binding pattern with a rest somewhere in it.
+	if ast.IsBindingPattern(node.Name()) && node.Name().SubtreeFacts()&ast.SubtreeContainsObjectRestOrSpread != 0 {
+		declarations := flattenDestructuringBinding(ch.EmitContext(), ch.Visitor(), node.AsNode(), nil /*rval*/, flattenLevelObjectRest, false /*downlevelIteration*/, exportedVariableStatement, false /*skipInitializer*/)
+		return transformers.SingleOrMany(declarations, ch.Factory())
+	}
+	return ch.Visitor().VisitEachChild(node.AsNode())
+}
+
+func (ch *objectRestSpreadTransformer) visitParameter(node *ast.ParameterDeclaration) *ast.Node {
+	if ch.parametersWithPrecedingObjectRestOrSpread != nil && ch.parametersWithPrecedingObjectRestOrSpread.Has(node.AsNode()) {
+		// Parameters that follow a parameter with an object rest element are evaluated inside the function body, as
+		// their initializers may observe the side effects of the object rest.
+		name := node.Name()
+		if ast.IsBindingPattern(name) {
+			name = ch.Factory().NewGeneratedNameForNode(node.AsNode())
+		}
+		return ch.Factory().UpdateParameterDeclaration(node, nil /*modifiers*/, node.DotDotDotToken, name, nil /*questionToken*/, nil /*typeNode*/, nil /*initializer*/)
+	}
+	if node.SubtreeFacts()&ast.SubtreeContainsObjectRestOrSpread != 0 {
+		// Binding patterns are converted into a generated name and are evaluated inside the function body.
+		return ch.Factory().UpdateParameterDeclaration(
+			node,
+			nil, /*modifiers*/
+			node.DotDotDotToken,
+			ch.Factory().NewGeneratedNameForNode(node.AsNode()),
+			nil, /*questionToken*/
+			nil, /*typeNode*/
+			ch.Visitor().VisitNode(node.Initializer),
+		)
+	}
+	return ch.Visitor().VisitEachChild(node.AsNode())
+}
+
+func collectParametersWithPrecedingObjectRestOrSpread(node *ast.Node) *collections.Set[*ast.Node] {
+	var parameters *collections.Set[*ast.Node]
+	for _, parameter := range node.Parameters() {
+		if parameters != nil {
+			parameters.Add(parameter)
+		} else if parameter.SubtreeFacts()&ast.SubtreeContainsObjectRestOrSpread != 0 {
+			parameters = &collections.Set[*ast.Node]{}
+		}
+	}
+	return parameters
+}
+
+func (ch *objectRestSpreadTransformer) visitFunctionLikeDeclaration(node *ast.Node) *ast.Node {
+	if !core.Some(node.Parameters(), func(parameter *ast.Node) bool {
+		return parameter.SubtreeFacts()&ast.SubtreeContainsObjectRestOrSpread != 0
+	}) {
+		return ch.Visitor().VisitEachChild(node)
+	}
+
+	savedParametersWithPrecedingObjectRestOrSpread := ch.parametersWithPrecedingObjectRestOrSpread
+	ch.parametersWithPrecedingObjectRestOrSpread = collectParametersWithPrecedingObjectRestOrSpread(node)
+	parameters := ch.EmitContext().VisitParameters(node.ParameterList(), ch.parameterVisitor)
+	body := ch.transformFunctionBody(node)
+	ch.parametersWithPrecedingObjectRestOrSpread = savedParametersWithPrecedingObjectRestOrSpread
+	return updateFunctionLikeParametersAndBody(ch.Factory(), ch.Visitor(), node, ch.Visitor().VisitModifiers(node.Modifiers()), parameters, body)
+}
+
+// Transforms the body of a function whose parameters contain an object rest element, moving the destructuring of
+// those parameters into the body. This ends the variable environment started when the parameters were visited.
+func (ch *objectRestSpreadTransformer) transformFunctionBody(node *ast.Node) *ast.Node {
+	f := ch.Factory()
+	body := ch.Visitor().VisitNode(node.Body())
+	var prologue, rest []*ast.Statement
+	if ast.IsBlock(body) {
+		prologue, rest = f.SplitStandardPrologue(body.AsBlock().Statements.Nodes)
+	} else {
+		returnStatement := f.NewReturnStatement(body)
+		returnStatement.Loc = body.Loc
+		rest = []*ast.Statement{returnStatement}
+	}
+
+	statements := make([]*ast.Statement, 0, len(prologue)+len(node.Parameters())+len(rest))
+	statements = append(statements, prologue...)
+	statements = append(statements, ch.createObjectRestAssignments(node)...)
+	statements = append(statements, rest...)
+	statements = ch.EmitContext().EndAndMergeVariableEnvironment(statements)
+
+	statementList := f.NewNodeList(statements)
+	if ast.IsBlock(body) {
+		statementList.Loc = body.AsBlock().Statements.Loc
+		return f.UpdateBlock(body.AsBlock(), statementList)
+	}
+	block := f.NewBlock(statementList, true /*multiLine*/)
+	block.Loc = body.Loc
+	return block
+}
+
+// Creates the statements that destructure the parameters of a function whose parameters contain an object rest
+// element, as well as the statements that evaluate the initializers of any parameters that follow it.
+func (ch *objectRestSpreadTransformer) createObjectRestAssignments(node *ast.Node) []*ast.Statement {
+	f := ch.Factory()
+	var statements []*ast.Statement
+	containsPrecedingObjectRestOrSpread := false
+	for _, parameter := range node.Parameters() {
+		name := parameter.Name()
+		initializer := parameter.Initializer()
+		if containsPrecedingObjectRestOrSpread {
+			if ast.IsBindingPattern(name) {
+				if len(name.AsBindingPattern().Elements.Nodes) > 0 {
+					declarations := flattenDestructuringBinding(ch.EmitContext(), ch.Visitor(), parameter, f.NewGeneratedNameForNode(parameter), flattenLevelAll, false /*downlevelIteration*/, false /*hoistTempVariables*/, false /*skipInitializer*/)
+					if len(declarations) > 0 {
+						statement := f.NewVariableStatement(nil /*modifiers*/, f.NewVariableDeclarationList(ast.NodeFlagsNone, f.NewNodeList(declarations)))
+						ch.EmitContext().SetEmitFlags(statement, printer.EFCustomPrologue)
+						statements = append(statements, statement)
+					}
+				} else if initializer != nil {
+					assignment := f.NewAssignmentExpression(f.NewGeneratedNameForNode(parameter), ch.Visitor().VisitNode(initializer))
+					statement := f.NewExpressionStatement(assignment)
+					ch.EmitContext().SetEmitFlags(statement, printer.EFCustomPrologue)
+					statements = append(statements, statement)
+				}
+			} else if initializer != nil {
+				// Converts a parameter initializer into a function body statement, i.e.:
+				//
+				//	function f(x = 1) { }
+				//
+				// becomes
+				//
+				//	function f(x) {
+				//	  if (x === void 0) { x = 1; }
+				//	}
+				statements = append(statements, createParameterDefaultValueStatement(ch.EmitContext(), parameter, ch.Visitor().VisitNode(initializer)))
+			}
+		} else if parameter.SubtreeFacts()&ast.SubtreeContainsObjectRestOrSpread != 0 {
+			containsPrecedingObjectRestOrSpread = true
+			declarations := flattenDestructuringBinding(ch.EmitContext(), ch.Visitor(), parameter, f.NewGeneratedNameForNode(parameter), flattenLevelObjectRest, false /*downlevelIteration*/, false /*hoistTempVariables*/, true /*skipInitializer*/)
+			if len(declarations) > 0 {
+				statement := f.NewVariableStatement(nil /*modifiers*/, f.NewVariableDeclarationList(ast.NodeFlagsNone, f.NewNodeList(declarations)))
+				ch.EmitContext().SetEmitFlags(statement, printer.EFCustomPrologue)
+				statements = append(statements, statement)
+			}
+		}
+	}
+	return statements
+}
+
+// Creates the statement that assigns the (already visited) initializer of a parameter when its argument is
+// `undefined`:
+//
+//	if (x === void 0) { x = 1; }
+func createParameterDefaultValueStatement(ec *printer.EmitContext, parameter *ast.Node, initializer *ast.Expression) *ast.Statement {
+	f := ec.Factory
+	name := parameter.Name().Clone(f)
+	name.Loc = parameter.Name().Loc
+	ec.SetEmitFlags(name, printer.EFNoSourceMap)
+	ec.AddEmitFlags(initializer, printer.EFNoSourceMap|printer.EFNoComments)
+	assignment := f.NewAssignmentExpression(name, initializer)
+	assignment.Loc = parameter.Loc
+	ec.SetEmitFlags(assignment, printer.EFNoComments)
+	block := f.NewBlock(f.NewNodeList([]*ast.Statement{f.NewExpressionStatement(assignment)}), false /*multiLine*/)
+	block.Loc = parameter.Loc
+	ec.SetEmitFlags(block, printer.EFSingleLine|printer.EFNoTrailingSourceMap|printer.EFNoTokenSourceMaps|printer.EFNoComments)
+	statement := f.NewIfStatement(f.NewTypeCheck(parameter.Name().Clone(f), "undefined"), block, nil /*elseStatement*/)
+	statement.Loc = parameter.Loc
+	ec.SetEmitFlags(statement, printer.EFStartOnNewLine|printer.EFNoTokenSourceMaps|printer.EFNoTrailingSourceMap|printer.EFCustomPrologue|printer.EFNoComments)
+	return statement
 }
 
-func newObjectRestSpreadTransformer(emitContext *printer.EmitContext) *transformers.Transformer {
-	tx := &objectRestSpreadTransformer{}
-	return tx.NewTransformer(tx.visit, emitContext)
+// Determines whether an assignment pattern contains an object rest element, either directly or within a nested
+// assignment pattern such as `{ x: { a, ...b } = foo } = c`.
+func containsObjectRestOrSpread(node *ast.Node) bool {
+	if node.SubtreeFacts()&ast.SubtreeContainsObjectRestOrSpread != 0 {
+		return true
+	}
+	if node.SubtreeFacts()&ast.SubtreeContainsESObjectRestOrSpread != 0 {
+		// check for nested spread assignments, otherwise '{ x: { a, ...b } = foo } = c'
+		// will not be correctly interpreted by the ES2018 transformer
+		for _, element := range ast.GetElementsOfBindingOrAssignmentPattern(node) {
+			target := ast.GetTargetOfBindingOrAssignmentElement(element)
+			if target != nil && ast.IsBindingOrAssignmentPattern(target) && containsObjectRestOrSpread(target) {
+				return true
+			}
+		}
+	}
+	return false
 }
@@ -11,10 +11,223 @@ type optionalChainTransformer struct {
 }
 
 func (ch *optionalChainTransformer) visit(node *ast.Node) *ast.Node {
-	return node // !!!
+	if node.SubtreeFacts()&ast.SubtreeContainsOptionalChaining == 0 {
+		return node
+	}
+	switch node.Kind {
+	case ast.KindCallExpression:
+		result, _ := ch.visitNonOptionalCallExpression(node, false /*captureThisArg*/)
+		return result
+	case ast.KindPropertyAccessExpression, ast.KindElementAccessExpression:
+		if ast.IsOptionalChain(node) {
+			result, _ := ch.visitOptionalExpression(node, false /*captureThisArg*/, false /*isDelete*/)
+			return result
+		}
+		return ch.Visitor().VisitEachChild(node)
+	case ast.KindDeleteExpression:
+		return ch.visitDeleteExpression(node.AsDeleteExpression())
+	default:
+		return ch.Visitor().VisitEachChild(node)
+	}
 }
 
-func newOptionalChainTransformer(emitContext *printer.EmitContext) *transformers.Transformer {
+// Flattens an optional chain into its root expression and the links of the chain, in evaluation order. For
+// `a?.b.c()`, the root expression is `a` and the links are `a?.b`, `a?.b.c`, and `a?.b.c()`.
+func flattenChain(chain *ast.Expression) (expression *ast.Expression, links []*ast.Expression) {
+	links = []*ast.Expression{chain}
+	for !isOptionalChainRootLink(chain) {
+		chain = ast.SkipPartiallyEmittedExpressions(chain.Expression())
+		links = append(links, chain)
+	}
+	for i, j := 0, len(links)-1; i < j; i, j = i+1, j-1 {
+		links[i], links[j] = links[j], links[i]
+	}
+	return chain.Expression(), links
+}
+
+func isOptionalChainRootLink(node *ast.Expression) bool {
+	switch node.Kind {
+	case ast.KindPropertyAccessExpression:
+		return node.AsPropertyAccessExpression().QuestionDotToken != nil
+	case ast.KindElementAccessExpression:
+		return node.AsElementAccessExpression().QuestionDotToken != nil
+	case ast.KindCallExpression:
+		return node.AsCallExpression().QuestionDotToken != nil
+	}
+	return true
+}
+
+// Visits an expression that is not itself the outermost link of an optional chain. When `captureThisArg` is set,
+// also returns the expression that should be used as the `this` argument when calling the result.
+func (ch *optionalChainTransformer) visitNonOptionalExpression(node *ast.Expression, captureThisArg bool, isDelete bool) (expression *ast.Expression, thisArg *ast.Expression) {
+	switch node.Kind {
+	case ast.KindParenthesizedExpression:
+		return ch.visitNonOptionalParenthesizedExpression(node.AsParenthesizedExpression(), captureThisArg, isDelete)
+	case ast.KindPropertyAccessExpression, ast.KindElementAccessExpression:
+		return ch.visitNonOptionalPropertyOrElementAccessExpression(node, captureThisArg, isDelete)
+	case ast.KindCallExpression:
+		return ch.visitNonOptionalCallExpression(node, captureThisArg)
+	default:
+		return ch.Visitor().VisitNode(node), nil
+	}
+}
+
+func (ch *optionalChainTransformer) visitNonOptionalParenthesizedExpression(node *ast.ParenthesizedExpression, captureThisArg bool, isDelete bool) (*ast.Expression, *ast.Expression) {
+	expression, thisArg := ch.visitNonOptionalExpression(node.Expression, captureThisArg, isDelete)
+	return ch.Factory().UpdateParenthesizedExpression(node, expression), thisArg
+}
+
+func (ch *optionalChainTransformer) visitNonOptionalPropertyOrElementAccessExpression(node *ast.Expression, captureThisArg bool, isDelete bool) (*ast.Expression, *ast.Expression) {
+	if ast.IsOptionalChain(node) {
+		return ch.visitOptionalExpression(node, captureThisArg, isDelete)
+	}
+
+	f := ch.Factory()
+	expression := ch.Visitor().VisitNode(node.Expression())
+	var thisArg *ast.Expression
+	if captureThisArg {
+		if isSimpleCopiableExpression(expression) {
+			thisArg = expression.Clone(f)
+		} else {
+			thisArg = f.NewTempVariable()
+			ch.EmitContext().AddVariableDeclaration(thisArg)
+			expression = f.NewAssignmentExpression(thisArg, expression)
+		}
+	}
+
+	if node.Kind == ast.KindPropertyAccessExpression {
+		n := node.AsPropertyAccessExpression()
+		expression = f.UpdatePropertyAccessExpression(n, expression, n.QuestionDotToken, ch.Visitor().VisitNode(n.Name()))
+	} else {
+		n := node.AsElementAccessExpression()
+		expression = f.UpdateElementAccessExpression(n, expression, n.QuestionDotToken, ch.Visitor().VisitNode(n.ArgumentExpression))
+	}
+	return expression, thisArg
+}
+
+func (ch *optionalChainTransformer) visitNonOptionalCallExpression(node *ast.Expression, captureThisArg bool) (*ast.Expression, *ast.Expression) {
+	if ast.IsOptionalChain(node) {
+		return ch.visitOptionalExpression(node, captureThisArg, false /*isDelete*/)
+	}
+
+	call := node.AsCallExpression()
+	if ast.IsParenthesizedExpression(call.Expression) && ast.IsOptionalChain(ast.SkipParentheses(call.Expression)) {
+		// capture thisArg for calls of parenthesized optional chains like `(foo?.bar)()`
+		f := ch.Factory()
+		expression, thisArg := ch.visitNonOptionalParenthesizedExpression(call.Expression.AsParenthesizedExpression(), true /*captureThisArg*/, false /*isDelete*/)
+		args := ch.Visitor().VisitNodes(call.Arguments)
+		if thisArg != nil {
+			result := f.NewCallExpression(
+				f.NewPropertyAccessExpression(expression, nil /*questionDotToken*/, f.NewIdentifier("call"), ast.NodeFlagsNone),
+				nil, /*questionDotToken*/
+				nil, /*typeArguments*/
+				f.NewNodeList(append([]*ast.Node{thisArg}, args.Nodes...)),
+				ast.NodeFlagsNone,
+			)
+			result.Loc = node.Loc
+			return result, nil
+		}
+		return f.UpdateCallExpression(call, expression, nil /*questionDotToken*/, nil /*typeArguments*/, args), nil
+	}
+
+	return ch.Visitor().VisitEachChild(node), nil
+}
+
+// Lowers an optional chain to a conditional expression that short-circuits to `void 0` (or `true`, for `delete`)
+// when the root of the chain is `null` or `undefined`:
+//
+//	a?.b.c -> a === null || a === void 0 ? void 0 : a.b.c
+//	f()?.b -> (_a = f()) === null || _a === void 0 ? void 0 : _a.b
+//	a.b?.() -> (_a = a.b) === null || _a === void 0 ? void 0 : _a.call(a)
+func (ch *optionalChainTransformer) visitOptionalExpression(node *ast.Expression, captureThisArg bool, isDelete bool) (*ast.Expression, *ast.Expression) {
+	f := ch.Factory()
+	expression, chain := flattenChain(node)
+	left, leftThisArg := ch.visitNonOptionalExpression(ast.SkipPartiallyEmittedExpressions(expression), ast.IsCallExpression(chain[0]), false /*isDelete*/)
+	leftExpression := f.RestoreOuterExpressions(expression, left, ast.OEKPartiallyEmittedExpressions)
+	var capturedLeft *ast.Expression
+	if isSimpleCopiableExpression(left) {
+		capturedLeft = left.Clone(f)
+	} else {
+		capturedLeft = f.NewTempVariable()
+		ch.EmitContext().AddVariableDeclaration(capturedLeft)
+		leftExpression = f.NewAssignmentExpression(capturedLeft, leftExpression)
+	}
+
+	rightExpression := capturedLeft.Clone(f)
+	var thisArg *ast.Expression
+	for i, segment := range chain {
+		switch segment.Kind {
+		case ast.KindPropertyAccessExpression, ast.KindElementAccessExpression:
+			if i == len(chain)-1 && captureThisArg {
+				if isSimpleCopiableExpression(rightExpression) {
+					thisArg = rightExpression.Clone(f)
+				} else {
+					thisArg = f.NewTempVariable()
+					ch.EmitContext().AddVariableDeclaration(thisArg)
+					rightExpression = f.NewAssignmentExpression(thisArg, rightExpression)
+				}
+			}
+			if segment.Kind == ast.KindPropertyAccessExpression {
+				rightExpression = f.NewPropertyAccessExpression(rightExpression, nil /*questionDotToken*/, ch.Visitor().VisitNode(segment.Name()), ast.NodeFlagsNone)
+			} else {
+				rightExpression = f.NewElementAccessExpression(rightExpression, nil /*questionDotToken*/, ch.Visitor().VisitNode(segment.AsElementAccessExpression().ArgumentExpression), ast.NodeFlagsNone)
+			}
+		case ast.KindCallExpression:
+			args := ch.Visitor().VisitNodes(segment.AsCallExpression().Arguments)
+			if i == 0 && leftThisArg != nil {
+				if !ast.IsIdentifier(leftThisArg) || !transformers.IsGeneratedIdentifier(ch.EmitContext(), leftThisArg) {
+					leftThisArg = leftThisArg.Clone(f)
+					ch.EmitContext().AddEmitFlags(leftThisArg, printer.EFNoComments)
+				}
+				if leftThisArg.Kind == ast.KindSuperKeyword {
+					leftThisArg = f.NewThisExpression()
+				}
+				rightExpression = f.NewCallExpression(
+					f.NewPropertyAccessExpression(rightExpression, nil /*questionDotToken*/, f.NewIdentifier("call"), ast.NodeFlagsNone),
+					nil, /*questionDotToken*/
+					nil, /*typeArguments*/
+					f.NewNodeList(append([]*ast.Node{leftThisArg}, args.Nodes...)),
+					ast.NodeFlagsNone,
+				)
+			} else {
+				rightExpression = f.NewCallExpression(rightExpression, nil /*questionDotToken*/, nil /*typeArguments*/, args, ast.NodeFlagsNone)
+			}
+		}
+		ch.EmitContext().SetOriginal(rightExpression, segment)
+	}
+
+	var target *ast.Expression
+	if isDelete {
+		target = f.NewConditionalExpression(
+			createNotNullCondition(f, leftExpression, capturedLeft, true /*invert*/),
+			f.NewToken(ast.KindQuestionToken),
+			f.NewTrueExpression(),
+			f.NewToken(ast.KindColonToken),
+			f.NewDeleteExpression(rightExpression),
+		)
+	} else {
+		target = f.NewConditionalExpression(
+			createNotNullCondition(f, leftExpression, capturedLeft, true /*invert*/),
+			f.NewToken(ast.KindQuestionToken),
+			f.NewVoidZeroExpression(),
+			f.NewToken(ast.KindColonToken),
+			rightExpression,
+		)
+	}
+	target.Loc = node.Loc
+	return target, thisArg
+}
+
+func (ch *optionalChainTransformer) visitDeleteExpression(node *ast.DeleteExpression) *ast.Node {
+	if ast.IsOptionalChain(ast.SkipParentheses(node.Expression)) {
+		result, _ := ch.visitNonOptionalExpression(node.Expression, false /*captureThisArg*/, true /*isDelete*/)
+		ch.EmitContext().SetOriginalEx(result, node.AsNode(), true /*allowOverwrite*/)
+		return result
+	}
+	return ch.Factory().UpdateDeleteExpression(node, ch.Visitor().VisitNode(node.Expression))
+}
+
+func newOptionalChainTransformer(opts *transformers.TransformOptions) *transformers.Transformer {
 	tx := &optionalChainTransformer{}
-	return tx.NewTransformer(tx.visit, emitContext)
+	return tx.NewTransformer(tx.visit, opts.Context)
 }
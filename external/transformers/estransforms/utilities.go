@@ -1,7 +1,11 @@
 package estransforms
 
 import (
+	"slices"
+
 	"github.com/pagpeter/typescript-go/external/ast"
+	"github.com/pagpeter/typescript-go/external/binder"
+	"github.com/pagpeter/typescript-go/external/core"
 	"github.com/pagpeter/typescript-go/external/printer"
 	"github.com/pagpeter/typescript-go/external/transformers"
 )
@@ -18,3 +22,215 @@ func convertClassDeclarationToClassExpression(emitContext *printer.EmitContext,
 	updated.Loc = node.Loc
 	return updated
 }
+
+func isEffectiveStrictModeSourceFile(node *ast.SourceFile, options *core.CompilerOptions) bool {
+	// We can only verify strict mode for JS/TS files
+	switch node.ScriptKind {
+	case core.ScriptKindJS, core.ScriptKindTS, core.ScriptKindJSX, core.ScriptKindTSX:
+	default:
+		return false
+	}
+	// Strict mode does not matter for declaration files.
+	if node.IsDeclarationFile {
+		return false
+	}
+	// If `alwaysStrict` is set, then treat the file as strict.
+	if options.AlwaysStrict.DefaultIfUnknown(options.Strict).IsTrue() {
+		return true
+	}
+	// Starting with a "use strict" directive indicates the file is strict.
+	if binder.FindUseStrictPrologue(node, node.Statements.Nodes) != nil {
+		return true
+	}
+	// ECMAScript Modules are always strict.
+	return ast.IsExternalModule(node) || options.GetIsolatedModules()
+}
+
+// Indicates whether an expression is reasonably free of side effects, and thus better to copy into multiple places
+// rather than to cache in a temporary variable.
+func isSimpleCopiableExpression(expression *ast.Expression) bool {
+	return ast.IsStringLiteralLike(expression) ||
+		ast.IsNumericLiteral(expression) ||
+		ast.IsKeywordKind(expression.Kind) ||
+		ast.IsIdentifier(expression)
+}
+
+// Indicates whether an expression can be copied into multiple locations without risk of repeating any side effects,
+// and whose value could not possibly change between any such locations.
+func isSimpleInlineableExpression(expression *ast.Expression) bool {
+	return !ast.IsIdentifier(expression) && isSimpleCopiableExpression(expression)
+}
+
+// Gets the binary operator underlying a compound assignment operator, e.g. `+` for `+=`.
+func getNonAssignmentOperatorForCompoundAssignment(kind ast.Kind) ast.Kind {
+	switch kind {
+	case ast.KindPlusEqualsToken:
+		return ast.KindPlusToken
+	case ast.KindMinusEqualsToken:
+		return ast.KindMinusToken
+	case ast.KindAsteriskEqualsToken:
+		return ast.KindAsteriskToken
+	case ast.KindAsteriskAsteriskEqualsToken:
+		return ast.KindAsteriskAsteriskToken
+	case ast.KindSlashEqualsToken:
+		return ast.KindSlashToken
+	case ast.KindPercentEqualsToken:
+		return ast.KindPercentToken
+	case ast.KindLessThanLessThanEqualsToken:
+		return ast.KindLessThanLessThanToken
+	case ast.KindGreaterThanGreaterThanEqualsToken:
+		return ast.KindGreaterThanGreaterThanToken
+	case ast.KindGreaterThanGreaterThanGreaterThanEqualsToken:
+		return ast.KindGreaterThanGreaterThanGreaterThanToken
+	case ast.KindAmpersandEqualsToken:
+		return ast.KindAmpersandToken
+	case ast.KindBarEqualsToken:
+		return ast.KindBarToken
+	case ast.KindCaretEqualsToken:
+		return ast.KindCaretToken
+	case ast.KindBarBarEqualsToken:
+		return ast.KindBarBarToken
+	case ast.KindAmpersandAmpersandEqualsToken:
+		return ast.KindAmpersandAmpersandToken
+	case ast.KindQuestionQuestionEqualsToken:
+		return ast.KindQuestionQuestionToken
+	default:
+		panic("Unhandled compound assignment operator: " + kind.String())
+	}
+}
+
+// Expands a prefix or postfix `++` or `--` of the provided expression into a comma expression that reads the value
+// into a temporary variable before updating it, so that the new value can be written back:
+//
+//	++x -> (_a = x, ++_a)
+//	x++ -> (_a = x, _a++, _a)
+//
+// If resultVariable is provided, it receives the result of the original expression.
+//
+//	x++ -> (_a = x, _b = _a++, _a)
+func expandPreOrPostfixIncrementOrDecrementExpression(emitContext *printer.EmitContext, node *ast.Node, expression *ast.Expression, resultVariable *ast.IdentifierNode) *ast.Expression {
+	factory := emitContext.Factory
+	temp := factory.NewTempVariable()
+	emitContext.AddVariableDeclaration(temp)
+
+	var operation *ast.Expression
+	if ast.IsPrefixUnaryExpression(node) {
+		expression = factory.NewAssignmentExpression(temp, expression)
+		expression.Loc = node.AsPrefixUnaryExpression().Operand.Loc
+		operation = factory.NewPrefixUnaryExpression(node.AsPrefixUnaryExpression().Operator, temp)
+	} else {
+		expression = factory.NewAssignmentExpression(temp, expression)
+		expression.Loc = node.AsPostfixUnaryExpression().Operand.Loc
+		operation = factory.NewPostfixUnaryExpression(temp, node.AsPostfixUnaryExpression().Operator)
+	}
+	operation.Loc = node.Loc
+
+	if resultVariable != nil {
+		operation = factory.NewAssignmentExpression(resultVariable, operation)
+		operation.Loc = node.Loc
+	}
+
+	expression = factory.NewCommaExpression(expression, operation)
+	expression.Loc = node.Loc
+
+	if ast.IsPostfixUnaryExpression(node) {
+		expression = factory.NewCommaExpression(expression, temp)
+		expression.Loc = node.Loc
+	}
+	return expression
+}
+
+// Finds a path to a statement containing a `super` call, descending through `try` blocks.
+func findSuperStatementIndexPath(statements []*ast.Statement, start int) []int {
+	for i := start; i < len(statements); i++ {
+		statement := statements[i]
+		if getSuperCallFromStatement(statement) != nil {
+			return []int{i}
+		} else if ast.IsTryStatement(statement) {
+			if path := findSuperStatementIndexPath(statement.AsTryStatement().TryBlock.AsBlock().Statements.Nodes, 0); path != nil {
+				return slices.Insert(path, 0, i)
+			}
+		}
+	}
+	return nil
+}
+
+func getSuperCallFromStatement(statement *ast.Statement) *ast.Node {
+	if !ast.IsExpressionStatement(statement) {
+		return nil
+	}
+
+	expression := ast.SkipParentheses(statement.Expression())
+	if ast.IsSuperCall(expression) {
+		return expression
+	}
+	return nil
+}
+
+// Creates an arrow function with the provided body that is immediately invoked:
+//
+//	(() => { ... })()
+func createImmediatelyInvokedArrowFunction(factory *printer.NodeFactory, body *ast.BlockNode) *ast.Expression {
+	arrow := factory.NewArrowFunction(
+		nil, /*modifiers*/
+		nil, /*typeParameters*/
+		factory.NewNodeList(nil),
+		nil, /*returnType*/
+		factory.NewToken(ast.KindEqualsGreaterThanToken),
+		body,
+	)
+	return factory.NewCallExpression(
+		factory.NewParenthesizedExpression(arrow),
+		nil, /*questionDotToken*/
+		nil, /*typeArguments*/
+		factory.NewNodeList(nil),
+		ast.NodeFlagsNone,
+	)
+}
+
+// Creates a condition that tests whether a value is neither `null` nor `undefined`, evaluating `left` once and then
+// comparing its cached value, `right`:
+//
+//	left !== null && right !== void 0
+//
+// When inverted, the condition instead tests whether the value is either `null` or `undefined`:
+//
+//	left === null || right === void 0
+func createNotNullCondition(factory *printer.NodeFactory, left *ast.Expression, right *ast.Expression, invert bool) *ast.Expression {
+	equalityOperator := core.IfElse(invert, ast.KindEqualsEqualsEqualsToken, ast.KindExclamationEqualsEqualsToken)
+	logicalOperator := core.IfElse(invert, ast.KindBarBarToken, ast.KindAmpersandAmpersandToken)
+	return factory.NewBinaryExpression(
+		nil, /*modifiers*/
+		factory.NewBinaryExpression(nil /*modifiers*/, left, nil /*typeNode*/, factory.NewToken(equalityOperator), factory.NewToken(ast.KindNullKeyword)),
+		nil, /*typeNode*/
+		factory.NewToken(logicalOperator),
+		factory.NewBinaryExpression(nil /*modifiers*/, right, nil /*typeNode*/, factory.NewToken(equalityOperator), factory.NewVoidZeroExpression()),
+	)
+}
+
+// Updates the modifiers, parameters, and body of a function-like declaration, visiting its name with the provided
+// visitor and preserving all other parts of the declaration.
+func updateFunctionLikeParametersAndBody(factory *printer.NodeFactory, visitor *ast.NodeVisitor, node *ast.Node, modifiers *ast.ModifierList, parameters *ast.ParameterList, body *ast.Node) *ast.Node {
+	switch node.Kind {
+	case ast.KindConstructor:
+		return factory.UpdateConstructorDeclaration(node.AsConstructorDeclaration(), modifiers, nil /*typeParameters*/, parameters, nil /*returnType*/, body)
+	case ast.KindMethodDeclaration:
+		n := node.AsMethodDeclaration()
+		return factory.UpdateMethodDeclaration(n, modifiers, n.AsteriskToken, visitor.VisitNode(n.Name()), n.PostfixToken, nil /*typeParameters*/, parameters, nil /*returnType*/, body)
+	case ast.KindGetAccessor:
+		return factory.UpdateGetAccessorDeclaration(node.AsGetAccessorDeclaration(), modifiers, visitor.VisitNode(node.Name()), nil /*typeParameters*/, parameters, nil /*returnType*/, body)
+	case ast.KindSetAccessor:
+		return factory.UpdateSetAccessorDeclaration(node.AsSetAccessorDeclaration(), modifiers, visitor.VisitNode(node.Name()), nil /*typeParameters*/, parameters, nil /*returnType*/, body)
+	case ast.KindFunctionDeclaration:
+		n := node.AsFunctionDeclaration()
+		return factory.UpdateFunctionDeclaration(n, modifiers, n.AsteriskToken, n.Name(), nil /*typeParameters*/, parameters, nil /*returnType*/, body)
+	case ast.KindFunctionExpression:
+		n := node.AsFunctionExpression()
+		return factory.UpdateFunctionExpression(n, modifiers, n.AsteriskToken, n.Name(), nil /*typeParameters*/, parameters, nil /*returnType*/, body)
+	case ast.KindArrowFunction:
+		n := node.AsArrowFunction()
+		return factory.UpdateArrowFunction(n, modifiers, nil /*typeParameters*/, parameters, nil /*returnType*/, n.EqualsGreaterThanToken, body)
+	default:
+		panic("Unhandled function-like declaration: " + node.Kind.String())
+	}
+}
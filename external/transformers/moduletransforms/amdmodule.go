@@ -0,0 +1,556 @@
+package moduletransforms
+
+import (
+	"slices"
+
+	"github.com/pagpeter/typescript-go/external/ast"
+	"github.com/pagpeter/typescript-go/external/binder"
+	"github.com/pagpeter/typescript-go/external/core"
+	"github.com/pagpeter/typescript-go/external/printer"
+	"github.com/pagpeter/typescript-go/external/transformers"
+)
+
+// Transforms an ES module into an AMD module using a `define([...], function (require, exports, ...) { })` wrapper.
+func NewAMDModuleTransformer(emitContext *printer.EmitContext, compilerOptions *core.CompilerOptions, resolver binder.ReferenceResolver, getEmitModuleFormatOfFile func(file ast.HasFileName) core.ModuleKind) *transformers.Transformer {
+	return newCommonJSModuleTransformer(emitContext, compilerOptions, resolver, getEmitModuleFormatOfFile, core.ModuleKindAMD)
+}
+
+// Transforms an ES module into a UMD module that can be loaded either as a CommonJS module or through an AMD loader.
+func NewUMDModuleTransformer(emitContext *printer.EmitContext, compilerOptions *core.CompilerOptions, resolver binder.ReferenceResolver, getEmitModuleFormatOfFile func(file ast.HasFileName) core.ModuleKind) *transformers.Transformer {
+	return newCommonJSModuleTransformer(emitContext, compilerOptions, resolver, getEmitModuleFormatOfFile, core.ModuleKindUMD)
+}
+
+var dynamicImportUMDHelper = &printer.EmitHelper{
+	Name:   "typescript:dynamicimport-sync-require",
+	Scoped: true,
+	Text:   `var __syncRequire = typeof module === "object" && typeof module.exports === "object";`,
+}
+
+// The dependencies of an AMD or UMD module.
+type asynchronousDependencies struct {
+	aliasedModuleNames   []*ast.Expression               // module names whose namespace objects are passed to the module body
+	unaliasedModuleNames []*ast.Expression               // module names that are only loaded for their side effects (or are required by UMD)
+	importAliasNames     []*ast.ParameterDeclarationNode // parameters of the module body that receive the aliased modules
+}
+
+// Transforms a SourceFile into an AMD module:
+//
+//	define(["require", "exports", "mod"], function (require, exports, mod_1) {
+//	    "use strict";
+//	    ...
+//	});
+func (tx *CommonJSModuleTransformer) transformAMDModule(node *ast.SourceFile) *ast.Node {
+	define := tx.Factory().NewIdentifier("define")
+	moduleName := tryGetModuleNameFromFile(tx.Factory(), node, nil /*host*/, tx.compilerOptions)
+
+	var arguments []*ast.Expression
+
+	// Add the module name (if provided).
+	if moduleName != nil {
+		arguments = append(arguments, moduleName)
+	}
+
+	if ast.IsJsonSourceFile(node) {
+		// define([], { ... });
+		arguments = append(arguments, tx.Factory().NewArrayLiteralExpression(tx.Factory().NewNodeList(nil), false /*multiLine*/))
+		if len(node.Statements.Nodes) > 0 {
+			arguments = append(arguments, node.Statements.Nodes[0].Expression())
+		} else {
+			arguments = append(arguments, tx.Factory().NewObjectLiteralExpression(tx.Factory().NewNodeList(nil), false /*multiLine*/))
+		}
+	} else {
+		externalHelpersImportDeclaration := tx.createExternalHelpersImportDeclarationForAsynchronousModule(node)
+		dependencies := tx.collectAsynchronousDependencies(externalHelpersImportDeclaration, true /*includeNonAmdDependencies*/)
+
+		// Add the dependency array argument:
+		//
+		//     ["require", "exports", module1", "module2", ...]
+		arguments = append(arguments, tx.createDependencyArray(dependencies))
+
+		// Add the module body function argument:
+		//
+		//     function (require, exports, module1, module2) ...
+		arguments = append(arguments, tx.Factory().NewFunctionExpression(
+			nil, /*modifiers*/
+			nil, /*asteriskToken*/
+			nil, /*name*/
+			nil, /*typeParameters*/
+			tx.createModuleBodyParameters(dependencies),
+			nil, /*returnType*/
+			tx.transformAsynchronousModuleBody(node, externalHelpersImportDeclaration),
+		))
+	}
+
+	statement := tx.Factory().NewExpressionStatement(
+		tx.Factory().NewCallExpression(
+			define,
+			nil, /*questionDotToken*/
+			nil, /*typeArguments*/
+			tx.Factory().NewNodeList(arguments),
+			ast.NodeFlagsNone,
+		),
+	)
+
+	statementList := tx.Factory().NewNodeList([]*ast.Statement{statement})
+	statementList.Loc = node.Statements.Loc
+	result := tx.Factory().UpdateSourceFile(node, statementList)
+	tx.EmitContext().AddEmitHelper(result, tx.EmitContext().ReadEmitHelpers()...)
+	return result
+}
+
+// Transforms a SourceFile into a UMD module:
+//
+//	(function (factory) {
+//	    if (typeof module === "object" && typeof module.exports === "object") {
+//	        var v = factory(require, exports);
+//	        if (v !== undefined) module.exports = v;
+//	    }
+//	    else if (typeof define === "function" && define.amd) {
+//	        define(["require", "exports", "mod"], factory);
+//	    }
+//	})(function (require, exports) {
+//	    "use strict";
+//	    ...
+//	});
+func (tx *CommonJSModuleTransformer) transformUMDModule(node *ast.SourceFile) *ast.Node {
+	externalHelpersImportDeclaration := tx.createExternalHelpersImportDeclarationForAsynchronousModule(node)
+	dependencies := tx.collectAsynchronousDependencies(externalHelpersImportDeclaration, false /*includeNonAmdDependencies*/)
+	moduleName := tryGetModuleNameFromFile(tx.Factory(), node, nil /*host*/, tx.compilerOptions)
+
+	var defineArguments []*ast.Expression
+
+	// Add the module name (if provided).
+	if moduleName != nil {
+		defineArguments = append(defineArguments, moduleName)
+	}
+	defineArguments = append(defineArguments,
+		tx.createDependencyArray(dependencies),
+		tx.Factory().NewIdentifier("factory"),
+	)
+
+	// if (v !== undefined) module.exports = v;
+	assignModuleExports := tx.Factory().NewIfStatement(
+		tx.Factory().NewStrictInequalityExpression(
+			tx.Factory().NewIdentifier("v"),
+			tx.Factory().NewIdentifier("undefined"),
+		),
+		tx.Factory().NewExpressionStatement(
+			tx.Factory().NewAssignmentExpression(
+				tx.Factory().NewPropertyAccessExpression(
+					tx.Factory().NewIdentifier("module"),
+					nil, /*questionDotToken*/
+					tx.Factory().NewIdentifier("exports"),
+					ast.NodeFlagsNone,
+				),
+				tx.Factory().NewIdentifier("v"),
+			),
+		),
+		nil, /*elseStatement*/
+	)
+	tx.EmitContext().SetEmitFlags(assignModuleExports, printer.EFSingleLine)
+
+	umdHeader := tx.Factory().NewFunctionExpression(
+		nil, /*modifiers*/
+		nil, /*asteriskToken*/
+		nil, /*name*/
+		nil, /*typeParameters*/
+		tx.Factory().NewNodeList([]*ast.ParameterDeclarationNode{
+			tx.Factory().NewParameterDeclaration(nil /*modifiers*/, nil /*dotDotDotToken*/, tx.Factory().NewIdentifier("factory"), nil /*questionToken*/, nil /*type*/, nil /*initializer*/),
+		}),
+		nil, /*returnType*/
+		tx.Factory().NewBlock(
+			tx.Factory().NewNodeList([]*ast.Statement{
+				tx.Factory().NewIfStatement(
+					tx.Factory().NewLogicalANDExpression(
+						tx.Factory().NewTypeCheck(tx.Factory().NewIdentifier("module"), "object"),
+						tx.Factory().NewTypeCheck(
+							tx.Factory().NewPropertyAccessExpression(
+								tx.Factory().NewIdentifier("module"),
+								nil, /*questionDotToken*/
+								tx.Factory().NewIdentifier("exports"),
+								ast.NodeFlagsNone,
+							),
+							"object",
+						),
+					),
+					tx.Factory().NewBlock(
+						tx.Factory().NewNodeList([]*ast.Statement{
+							tx.Factory().NewVariableStatement(
+								nil, /*modifiers*/
+								tx.Factory().NewVariableDeclarationList(
+									ast.NodeFlagsNone,
+									tx.Factory().NewNodeList([]*ast.VariableDeclarationNode{
+										tx.Factory().NewVariableDeclaration(
+											tx.Factory().NewIdentifier("v"),
+											nil, /*exclamationToken*/
+											nil, /*type*/
+											tx.Factory().NewCallExpression(
+												tx.Factory().NewIdentifier("factory"),
+												nil, /*questionDotToken*/
+												nil, /*typeArguments*/
+												tx.Factory().NewNodeList([]*ast.Expression{
+													tx.Factory().NewIdentifier("require"),
+													tx.Factory().NewIdentifier("exports"),
+												}),
+												ast.NodeFlagsNone,
+											),
+										),
+									}),
+								),
+							),
+							assignModuleExports,
+						}),
+						true, /*multiLine*/
+					),
+					tx.Factory().NewIfStatement(
+						tx.Factory().NewLogicalANDExpression(
+							tx.Factory().NewTypeCheck(tx.Factory().NewIdentifier("define"), "function"),
+							tx.Factory().NewPropertyAccessExpression(
+								tx.Factory().NewIdentifier("define"),
+								nil, /*questionDotToken*/
+								tx.Factory().NewIdentifier("amd"),
+								ast.NodeFlagsNone,
+							),
+						),
+						tx.Factory().NewBlock(
+							tx.Factory().NewNodeList([]*ast.Statement{
+								tx.Factory().NewExpressionStatement(
+									tx.Factory().NewCallExpression(
+										tx.Factory().NewIdentifier("define"),
+										nil, /*questionDotToken*/
+										nil, /*typeArguments*/
+										tx.Factory().NewNodeList(defineArguments),
+										ast.NodeFlagsNone,
+									),
+								),
+							}),
+							true, /*multiLine*/
+						),
+						nil, /*elseStatement*/
+					),
+				),
+			}),
+			true, /*multiLine*/
+		),
+	)
+
+	statement := tx.Factory().NewExpressionStatement(
+		tx.Factory().NewCallExpression(
+			tx.Factory().NewParenthesizedExpression(umdHeader),
+			nil, /*questionDotToken*/
+			nil, /*typeArguments*/
+			tx.Factory().NewNodeList([]*ast.Expression{
+				tx.Factory().NewFunctionExpression(
+					nil, /*modifiers*/
+					nil, /*asteriskToken*/
+					nil, /*name*/
+					nil, /*typeParameters*/
+					tx.createModuleBodyParameters(dependencies),
+					nil, /*returnType*/
+					tx.transformAsynchronousModuleBody(node, externalHelpersImportDeclaration),
+				),
+			}),
+			ast.NodeFlagsNone,
+		),
+	)
+
+	statementList := tx.Factory().NewNodeList([]*ast.Statement{statement})
+	statementList.Loc = node.Statements.Loc
+	result := tx.Factory().UpdateSourceFile(node, statementList)
+	tx.EmitContext().AddEmitHelper(result, tx.EmitContext().ReadEmitHelpers()...)
+	return result
+}
+
+// Creates the synthetic `import tslib_1 = require("tslib")` declaration for an AMD or UMD module, if needed.
+//
+// Unlike CommonJS, the helpers import must be known before the module body is visited so that "tslib" can be
+// added to the dependency list of the `define` call.
+func (tx *CommonJSModuleTransformer) createExternalHelpersImportDeclarationForAsynchronousModule(node *ast.SourceFile) *ast.Node {
+	return createExternalHelpersImportDeclarationIfNeeded(
+		tx.EmitContext(),
+		node,
+		tx.compilerOptions,
+		tx.getEmitModuleFormatOfFile(node),
+		tx.currentModuleInfo.hasExportStarsToExportValues,
+		tx.currentModuleInfo.hasImportStar,
+		tx.currentModuleInfo.hasImportDefault,
+	)
+}
+
+// Collects the names of the modules the current module depends on.
+//
+//   - The `externalHelpersImportDeclaration` parameter is the synthetic import of the external helpers library, if any.
+//   - The `includeNonAmdDependencies` parameter indicates whether imported modules should be passed as parameters to
+//     the module body. This is false for UMD, where the module body instead uses `require`.
+func (tx *CommonJSModuleTransformer) collectAsynchronousDependencies(externalHelpersImportDeclaration *ast.Node, includeNonAmdDependencies bool) *asynchronousDependencies {
+	dependencies := &asynchronousDependencies{}
+
+	// !!! amd-dependency pragmas
+
+	externalImports := tx.currentModuleInfo.externalImports
+	if externalHelpersImportDeclaration != nil {
+		externalImports = append([]*ast.Declaration{externalHelpersImportDeclaration}, externalImports...)
+	}
+
+	for _, importNode := range externalImports {
+		// Find the name of the external module
+		externalModuleName := getExternalModuleNameLiteral(tx.Factory(), importNode, tx.currentSourceFile, nil /*host*/, nil /*resolver*/, tx.compilerOptions)
+		if externalModuleName == nil {
+			// It is possible that externalModuleName is nil if it is not a string literal. This can happen in the
+			// invalid import syntax. E.g: `import * from alias from 'someLib';`
+			continue
+		}
+
+		// Find the name of the module alias, if there is one
+		importAliasName := getLocalNameForExternalImport(tx.EmitContext(), importNode, tx.currentSourceFile)
+		if includeNonAmdDependencies && importAliasName != nil {
+			dependencies.aliasedModuleNames = append(dependencies.aliasedModuleNames, externalModuleName)
+			dependencies.importAliasNames = append(dependencies.importAliasNames, tx.Factory().NewParameterDeclaration(
+				nil, /*modifiers*/
+				nil, /*dotDotDotToken*/
+				importAliasName,
+				nil, /*questionToken*/
+				nil, /*type*/
+				nil, /*initializer*/
+			))
+		} else {
+			dependencies.unaliasedModuleNames = append(dependencies.unaliasedModuleNames, externalModuleName)
+		}
+	}
+
+	return dependencies
+}
+
+// Creates the dependency array for a `define` call, i.e.:
+//
+//	["require", "exports", module1", "module2", ...]
+func (tx *CommonJSModuleTransformer) createDependencyArray(dependencies *asynchronousDependencies) *ast.Expression {
+	elements := []*ast.Expression{
+		tx.Factory().NewStringLiteral("require"),
+		tx.Factory().NewStringLiteral("exports"),
+	}
+	elements = append(elements, dependencies.aliasedModuleNames...)
+	elements = append(elements, dependencies.unaliasedModuleNames...)
+	return tx.Factory().NewArrayLiteralExpression(tx.Factory().NewNodeList(elements), false /*multiLine*/)
+}
+
+// Creates the parameter list for the module body function, i.e.:
+//
+//	(require, exports, module1, module2)
+func (tx *CommonJSModuleTransformer) createModuleBodyParameters(dependencies *asynchronousDependencies) *ast.NodeList {
+	parameters := []*ast.ParameterDeclarationNode{
+		tx.Factory().NewParameterDeclaration(nil /*modifiers*/, nil /*dotDotDotToken*/, tx.Factory().NewIdentifier("require"), nil /*questionToken*/, nil /*type*/, nil /*initializer*/),
+		tx.Factory().NewParameterDeclaration(nil /*modifiers*/, nil /*dotDotDotToken*/, tx.Factory().NewIdentifier("exports"), nil /*questionToken*/, nil /*type*/, nil /*initializer*/),
+	}
+	parameters = append(parameters, dependencies.importAliasNames...)
+	return tx.Factory().NewNodeList(parameters)
+}
+
+// Transforms the statements of a SourceFile into the body of an AMD or UMD module function.
+func (tx *CommonJSModuleTransformer) transformAsynchronousModuleBody(node *ast.SourceFile, externalHelpersImportDeclaration *ast.Node) *ast.BlockNode {
+	tx.EmitContext().StartVariableEnvironment()
+
+	// emit standard prologue directives and ensure "use strict"
+	prologue, rest := tx.Factory().SplitStandardPrologue(node.Statements.Nodes)
+	statements := tx.Factory().EnsureUseStrict(slices.Clone(prologue))
+
+	// emit custom prologues from other transformations
+	custom, rest := tx.Factory().SplitCustomPrologue(rest)
+	statements = append(statements, core.FirstResult(tx.topLevelVisitor.VisitSlice(custom))...)
+
+	// emits `Object.defineProperty(exports, "__esModule", { value: true });` at the top of the module body
+	if tx.shouldEmitUnderscoreUnderscoreESModule() {
+		statements = append(statements, tx.createUnderscoreUnderscoreESModule())
+	}
+
+	// initialize all exports to `undefined`, e.g.:
+	//  exports.a = exports.b = void 0;
+	statements = tx.appendExportedNamesInitializers(statements)
+
+	// initialize exports for function declarations, e.g.:
+	//  exports.f = f;
+	//  function f() {}
+	for f := range tx.currentModuleInfo.exportedFunctions.Values() {
+		statements = tx.appendExportsOfClassOrFunctionDeclaration(statements, f.AsNode())
+	}
+
+	// visit the external helpers import, if any
+	if externalHelpersImportDeclaration != nil {
+		statements = append(statements, core.FirstResult(tx.topLevelVisitor.VisitSlice([]*ast.Node{externalHelpersImportDeclaration}))...)
+	}
+
+	// apply import helpers to the modules passed in to the module body, e.g.:
+	//  mod_1 = __importDefault(mod_1);
+	if tx.moduleKind == core.ModuleKindAMD {
+		for _, importNode := range tx.currentModuleInfo.externalImports {
+			if statement := tx.getAMDImportExpressionForImport(importNode); statement != nil {
+				statements = append(statements, statement)
+			}
+		}
+	}
+
+	// visit the remaining statements in the source file
+	rest, _ = tx.topLevelVisitor.VisitSlice(rest)
+	statements = append(statements, rest...)
+
+	// emit `return ...` for `export =` if needed
+	statements = tx.appendExportEqualsIfNeeded(statements, true /*emitAsReturn*/)
+
+	// merge temp variables into the statement list
+	statements = tx.EmitContext().EndAndMergeVariableEnvironment(statements)
+
+	body := tx.Factory().NewBlock(tx.Factory().NewNodeList(statements), true /*multiLine*/)
+	if tx.needUMDDynamicImportHelper {
+		tx.EmitContext().AddEmitHelper(body, dynamicImportUMDHelper)
+	}
+	return body
+}
+
+// Gets a statement that applies an import helper to a module passed in to an AMD module body, if needed.
+func (tx *CommonJSModuleTransformer) getAMDImportExpressionForImport(node *ast.Node /*ImportDeclaration | ImportEqualsDeclaration | ExportDeclaration*/) *ast.Statement {
+	if !ast.IsImportDeclaration(node) || getExternalModuleNameLiteral(tx.Factory(), node, tx.currentSourceFile, nil /*host*/, nil /*resolver*/, tx.compilerOptions) == nil {
+		return nil
+	}
+	name := getLocalNameForExternalImport(tx.EmitContext(), node, tx.currentSourceFile)
+	if name == nil {
+		return nil
+	}
+	expr := tx.getHelperExpressionForImport(node.AsImportDeclaration(), name)
+	if expr == name {
+		return nil
+	}
+	return tx.Factory().NewExpressionStatement(tx.Factory().NewAssignmentExpression(name, expr))
+}
+
+// Visits an ImportDeclaration at the top level of an AMD module. The imported module is passed in as a parameter of
+// the module body, so only a namespace alias for `import d, * as n from "mod"` needs to be emitted.
+func (tx *CommonJSModuleTransformer) visitTopLevelImportDeclarationAMD(node *ast.ImportDeclaration) *ast.Node {
+	var statements []*ast.Statement
+	namespaceDeclaration := ast.GetNamespaceDeclarationNode(node.AsNode())
+	if namespaceDeclaration != nil && ast.IsDefaultImport(node.AsNode()) {
+		// import d, * as n from "mod";
+		varStatement := tx.Factory().NewVariableStatement(
+			nil, /*modifiers*/
+			tx.Factory().NewVariableDeclarationList(
+				ast.NodeFlagsConst,
+				tx.Factory().NewNodeList([]*ast.VariableDeclarationNode{
+					tx.Factory().NewVariableDeclaration(
+						namespaceDeclaration.Name().Clone(tx.Factory()),
+						nil, /*exclamationToken*/
+						nil, /*type*/
+						tx.Factory().NewGeneratedNameForNode(node.AsNode()),
+					),
+				}),
+			),
+		)
+		tx.EmitContext().SetOriginal(varStatement, node.AsNode())
+		tx.EmitContext().AssignCommentAndSourceMapRanges(varStatement, node.AsNode())
+		statements = append(statements, varStatement)
+	}
+	statements = tx.appendExportsOfImportDeclaration(statements, node)
+	return transformers.SingleOrMany(statements, tx.Factory())
+}
+
+func (tx *CommonJSModuleTransformer) createImportCallExpressionAMD(arg *ast.Expression) *ast.Expression {
+	// import("./blah")
+	// emit as
+	// define(["require", "exports", "blah"], function (require, exports) {
+	//     ...
+	//     new Promise((resolve_1, reject_1) => { require([x], resolve_1, reject_1); }); /*Amd Require*/
+	// });
+	resolve := tx.Factory().NewUniqueName("resolve")
+	reject := tx.Factory().NewUniqueName("reject")
+	if arg == nil {
+		arg = tx.Factory().NewOmittedExpression()
+	}
+
+	function := tx.Factory().NewArrowFunction(
+		nil, /*modifiers*/
+		nil, /*typeParameters*/
+		tx.Factory().NewNodeList([]*ast.ParameterDeclarationNode{
+			tx.Factory().NewParameterDeclaration(nil /*modifiers*/, nil /*dotDotDotToken*/, resolve, nil /*questionToken*/, nil /*type*/, nil /*initializer*/),
+			tx.Factory().NewParameterDeclaration(nil /*modifiers*/, nil /*dotDotDotToken*/, reject, nil /*questionToken*/, nil /*type*/, nil /*initializer*/),
+		}),
+		nil, /*type*/
+		tx.Factory().NewToken(ast.KindEqualsGreaterThanToken), /*equalsGreaterThanToken*/
+		tx.Factory().NewBlock(
+			tx.Factory().NewNodeList([]*ast.Statement{
+				tx.Factory().NewExpressionStatement(
+					tx.Factory().NewCallExpression(
+						tx.Factory().NewIdentifier("require"),
+						nil, /*questionDotToken*/
+						nil, /*typeArguments*/
+						tx.Factory().NewNodeList([]*ast.Expression{
+							tx.Factory().NewArrayLiteralExpression(tx.Factory().NewNodeList([]*ast.Expression{arg}), false /*multiLine*/),
+							resolve,
+							reject,
+						}),
+						ast.NodeFlagsNone,
+					),
+				),
+			}),
+			false, /*multiLine*/
+		),
+	)
+
+	promise := tx.Factory().NewNewExpression(
+		tx.Factory().NewIdentifier("Promise"),
+		nil, /*typeArguments*/
+		tx.Factory().NewNodeList([]*ast.Expression{function}),
+	)
+
+	if tx.compilerOptions.GetESModuleInterop() {
+		return tx.Factory().NewCallExpression(
+			tx.Factory().NewPropertyAccessExpression(
+				promise,
+				nil, /*questionDotToken*/
+				tx.Factory().NewIdentifier("then"),
+				ast.NodeFlagsNone,
+			),
+			nil, /*questionDotToken*/
+			nil, /*typeArguments*/
+			tx.Factory().NewNodeList([]*ast.Expression{tx.Factory().NewImportStarCallbackHelper()}),
+			ast.NodeFlagsNone,
+		)
+	}
+	return promise
+}
+
+func (tx *CommonJSModuleTransformer) createImportCallExpressionUMD(arg *ast.Expression) *ast.Expression {
+	// import("./blah")
+	// emit as
+	// __syncRequire ? Promise.resolve().then(() => require("./blah")) : new Promise((resolve_1, reject_1) => { require(["./blah"], resolve_1, reject_1); });
+	tx.needUMDDynamicImportHelper = true
+	if arg == nil || isSimpleCopiableExpression(arg) {
+		var argClone *ast.Expression
+		switch {
+		case arg == nil || transformers.IsGeneratedIdentifier(tx.EmitContext(), arg):
+			argClone = arg
+		case ast.IsStringLiteral(arg):
+			argClone = tx.Factory().NewStringLiteralFromNode(arg)
+		default:
+			argClone = arg.Clone(tx.Factory())
+			tx.EmitContext().SetEmitFlags(argClone, printer.EFNoComments)
+		}
+		return tx.Factory().NewConditionalExpression(
+			tx.Factory().NewIdentifier("__syncRequire"),
+			tx.Factory().NewToken(ast.KindQuestionToken),
+			tx.createImportCallExpressionCommonJS(arg, false /*isInlineable*/),
+			tx.Factory().NewToken(ast.KindColonToken),
+			tx.createImportCallExpressionAMD(argClone),
+		)
+	}
+
+	temp := tx.Factory().NewTempVariable()
+	tx.EmitContext().AddVariableDeclaration(temp)
+	return tx.Factory().NewCommaExpression(
+		tx.Factory().NewAssignmentExpression(temp, arg),
+		tx.Factory().NewConditionalExpression(
+			tx.Factory().NewIdentifier("__syncRequire"),
+			tx.Factory().NewToken(ast.KindQuestionToken),
+			tx.createImportCallExpressionCommonJS(temp, true /*isInlineable*/),
+			tx.Factory().NewToken(ast.KindColonToken),
+			tx.createImportCallExpressionAMD(temp),
+		),
+	)
+}
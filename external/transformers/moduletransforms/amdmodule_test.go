@@ -0,0 +1,197 @@
+package moduletransforms_test
+
+import (
+	"testing"
+
+	"github.com/pagpeter/typescript-go/external/binder"
+	"github.com/pagpeter/typescript-go/external/core"
+	"github.com/pagpeter/typescript-go/external/printer"
+	"github.com/pagpeter/typescript-go/external/testutil/emittestutil"
+	"github.com/pagpeter/typescript-go/external/testutil/parsetestutil"
+	"github.com/pagpeter/typescript-go/external/transformers/moduletransforms"
+	"github.com/pagpeter/typescript-go/external/transformers/tstransforms"
+)
+
+func TestAMDModuleTransformer(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title   string
+		input   string
+		output  string
+		options *core.CompilerOptions
+	}{
+		{
+			title: "ImportDeclaration#1",
+			input: `import "other"`,
+			output: `define(["require", "exports", "other"], function (require, exports) {
+    "use strict";
+    Object.defineProperty(exports, "__esModule", { value: true });
+});`,
+		},
+		{
+			title: "ImportDeclaration#2",
+			input: `import * as a from "other"; a;`,
+			output: `define(["require", "exports", "other"], function (require, exports, a) {
+    "use strict";
+    Object.defineProperty(exports, "__esModule", { value: true });
+    a;
+});`,
+		},
+		{
+			title: "ImportDeclaration#3",
+			input: `import { a } from "other"; a;`,
+			output: `define(["require", "exports", "other"], function (require, exports, other_1) {
+    "use strict";
+    Object.defineProperty(exports, "__esModule", { value: true });
+    other_1.a;
+});`,
+		},
+		{
+			title: "ImportDeclaration#4",
+			input: `import a from "other"; a;`,
+			output: `var __importDefault = (this && this.__importDefault) || function (mod) {
+    return (mod && mod.__esModule) ? mod : { "default": mod };
+};
+define(["require", "exports", "other"], function (require, exports, other_1) {
+    "use strict";
+    Object.defineProperty(exports, "__esModule", { value: true });
+    other_1 = __importDefault(other_1);
+    other_1.default;
+});`,
+			options: &core.CompilerOptions{ESModuleInterop: core.TSTrue},
+		},
+		{
+			title: "ExportDeclaration#1",
+			input: `export * from "other";`,
+			output: `var __createBinding = (this && this.__createBinding) || (Object.create ? (function(o, m, k, k2) {
+    if (k2 === undefined) k2 = k;
+    var desc = Object.getOwnPropertyDescriptor(m, k);
+    if (!desc || ("get" in desc ? !m.__esModule : desc.writable || desc.configurable)) {
+      desc = { enumerable: true, get: function() { return m[k]; } };
+    }
+    Object.defineProperty(o, k2, desc);
+}) : (function(o, m, k, k2) {
+    if (k2 === undefined) k2 = k;
+    o[k2] = m[k];
+}));
+var __exportStar = (this && this.__exportStar) || function(m, exports) {
+    for (var p in m) if (p !== "default" && !Object.prototype.hasOwnProperty.call(exports, p)) __createBinding(exports, m, p);
+};
+define(["require", "exports", "other"], function (require, exports, other_1) {
+    "use strict";
+    Object.defineProperty(exports, "__esModule", { value: true });
+    __exportStar(other_1, exports);
+});`,
+		},
+		{
+			title: "ExportAssignment#1",
+			input: `export = 1;`,
+			output: `define(["require", "exports"], function (require, exports) {
+    "use strict";
+    return 1;
+});`,
+		},
+		{
+			title: "ImportCall#1",
+			input: `export {}; import("./other");`,
+			output: `define(["require", "exports"], function (require, exports) {
+    "use strict";
+    Object.defineProperty(exports, "__esModule", { value: true });
+    new Promise((resolve_1, reject_1) => { require(["./other"], resolve_1, reject_1); });
+});`,
+		},
+	}
+	for _, rec := range data {
+		t.Run(rec.title, func(t *testing.T) {
+			t.Parallel()
+
+			compilerOptions := rec.options
+			if compilerOptions == nil {
+				compilerOptions = &core.CompilerOptions{}
+			}
+
+			compilerOptions.Module = core.ModuleKindAMD
+
+			file := parsetestutil.ParseTypeScript(rec.input, false /*jsx*/)
+			parsetestutil.CheckDiagnostics(t, file)
+			binder.BindSourceFile(file)
+
+			emitContext := printer.NewEmitContext()
+			resolver := binder.NewReferenceResolver(compilerOptions, binder.ReferenceResolverHooks{})
+
+			file = tstransforms.NewRuntimeSyntaxTransformer(emitContext, compilerOptions, resolver).TransformSourceFile(file)
+			file = moduletransforms.NewAMDModuleTransformer(emitContext, compilerOptions, resolver, fakeGetEmitModuleFormatOfFile).TransformSourceFile(file)
+			emittestutil.CheckEmit(t, emitContext, file, rec.output)
+		})
+	}
+}
+
+func TestUMDModuleTransformer(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title   string
+		input   string
+		output  string
+		options *core.CompilerOptions
+	}{
+		{
+			title: "ImportDeclaration#1",
+			input: `import { a } from "other"; a;`,
+			output: `(function (factory) {
+    if (typeof module === "object" && typeof module.exports === "object") {
+        var v = factory(require, exports);
+        if (v !== undefined) module.exports = v;
+    }
+    else if (typeof define === "function" && define.amd) {
+        define(["require", "exports", "other"], factory);
+    }
+})(function (require, exports) {
+    "use strict";
+    Object.defineProperty(exports, "__esModule", { value: true });
+    const other_1 = require("other");
+    other_1.a;
+});`,
+		},
+		{
+			title: "ImportCall#1",
+			input: `export {}; import("./other");`,
+			output: `(function (factory) {
+    if (typeof module === "object" && typeof module.exports === "object") {
+        var v = factory(require, exports);
+        if (v !== undefined) module.exports = v;
+    }
+    else if (typeof define === "function" && define.amd) {
+        define(["require", "exports"], factory);
+    }
+})(function (require, exports) {
+    "use strict";
+    var __syncRequire = typeof module === "object" && typeof module.exports === "object";
+    Object.defineProperty(exports, "__esModule", { value: true });
+    __syncRequire ? Promise.resolve().then(() => require("./other")) : new Promise((resolve_1, reject_1) => { require(["./other"], resolve_1, reject_1); });
+});`,
+		},
+	}
+	for _, rec := range data {
+		t.Run(rec.title, func(t *testing.T) {
+			t.Parallel()
+
+			compilerOptions := rec.options
+			if compilerOptions == nil {
+				compilerOptions = &core.CompilerOptions{}
+			}
+
+			compilerOptions.Module = core.ModuleKindUMD
+
+			file := parsetestutil.ParseTypeScript(rec.input, false /*jsx*/)
+			parsetestutil.CheckDiagnostics(t, file)
+			binder.BindSourceFile(file)
+
+			emitContext := printer.NewEmitContext()
+			resolver := binder.NewReferenceResolver(compilerOptions, binder.ReferenceResolverHooks{})
+
+			file = tstransforms.NewRuntimeSyntaxTransformer(emitContext, compilerOptions, resolver).TransformSourceFile(file)
+			file = moduletransforms.NewUMDModuleTransformer(emitContext, compilerOptions, resolver, fakeGetEmitModuleFormatOfFile).TransformSourceFile(file)
+			emittestutil.CheckEmit(t, emitContext, file, rec.output)
+		})
+	}
+}
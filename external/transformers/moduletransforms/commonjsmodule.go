@@ -14,22 +14,27 @@ import (
 
 type CommonJSModuleTransformer struct {
 	transformers.Transformer
-	topLevelVisitor           *ast.NodeVisitor // visits statements at top level of a module
-	topLevelNestedVisitor     *ast.NodeVisitor // visits nested statements at top level of a module
-	discardedValueVisitor     *ast.NodeVisitor // visits expressions whose values would be discarded at runtime
-	assignmentPatternVisitor  *ast.NodeVisitor // visits assignment patterns in a destructuring assignment
-	compilerOptions           *core.CompilerOptions
-	resolver                  binder.ReferenceResolver
-	getEmitModuleFormatOfFile func(file ast.HasFileName) core.ModuleKind
-	moduleKind                core.ModuleKind
-	languageVersion           core.ScriptTarget
-	currentSourceFile         *ast.SourceFile
-	currentModuleInfo         *externalModuleInfo
-	parentNode                *ast.Node // used for ancestor tracking via pushNode/popNode to detect expression identifiers
-	currentNode               *ast.Node // used for ancestor tracking via pushNode/popNode to detect expression identifiers
+	topLevelVisitor            *ast.NodeVisitor // visits statements at top level of a module
+	topLevelNestedVisitor      *ast.NodeVisitor // visits nested statements at top level of a module
+	discardedValueVisitor      *ast.NodeVisitor // visits expressions whose values would be discarded at runtime
+	assignmentPatternVisitor   *ast.NodeVisitor // visits assignment patterns in a destructuring assignment
+	compilerOptions            *core.CompilerOptions
+	resolver                   binder.ReferenceResolver
+	getEmitModuleFormatOfFile  func(file ast.HasFileName) core.ModuleKind
+	moduleKind                 core.ModuleKind
+	languageVersion            core.ScriptTarget
+	currentSourceFile          *ast.SourceFile
+	currentModuleInfo          *externalModuleInfo
+	needUMDDynamicImportHelper bool      // whether the UMD module body needs the `__syncRequire` helper
+	parentNode                 *ast.Node // used for ancestor tracking via pushNode/popNode to detect expression identifiers
+	currentNode                *ast.Node // used for ancestor tracking via pushNode/popNode to detect expression identifiers
 }
 
 func NewCommonJSModuleTransformer(emitContext *printer.EmitContext, compilerOptions *core.CompilerOptions, resolver binder.ReferenceResolver, getEmitModuleFormatOfFile func(file ast.HasFileName) core.ModuleKind) *transformers.Transformer {
+	return newCommonJSModuleTransformer(emitContext, compilerOptions, resolver, getEmitModuleFormatOfFile, compilerOptions.GetEmitModuleKind())
+}
+
+func newCommonJSModuleTransformer(emitContext *printer.EmitContext, compilerOptions *core.CompilerOptions, resolver binder.ReferenceResolver, getEmitModuleFormatOfFile func(file ast.HasFileName) core.ModuleKind, moduleKind core.ModuleKind) *transformers.Transformer {
 	if resolver == nil {
 		resolver = binder.NewReferenceResolver(compilerOptions, binder.ReferenceResolverHooks{})
 	}
@@ -39,7 +44,7 @@ func NewCommonJSModuleTransformer(emitContext *printer.EmitContext, compilerOpti
 	tx.discardedValueVisitor = emitContext.NewNodeVisitor(tx.visitDiscardedValue)
 	tx.assignmentPatternVisitor = emitContext.NewNodeVisitor(tx.visitAssignmentPattern)
 	tx.languageVersion = compilerOptions.GetEmitScriptTarget()
-	tx.moduleKind = compilerOptions.GetEmitModuleKind()
+	tx.moduleKind = moduleKind
 	return tx.NewTransformer(tx.visit, emitContext)
 }
 
@@ -236,9 +241,18 @@ func (tx *CommonJSModuleTransformer) visitSourceFile(node *ast.SourceFile) *ast.
 
 	tx.currentSourceFile = node
 	tx.currentModuleInfo = collectExternalModuleInfo(node, tx.compilerOptions, tx.EmitContext(), tx.resolver)
-	updated := tx.transformCommonJSModule(node)
+	var updated *ast.Node
+	switch tx.moduleKind {
+	case core.ModuleKindAMD:
+		updated = tx.transformAMDModule(node)
+	case core.ModuleKindUMD:
+		updated = tx.transformUMDModule(node)
+	default:
+		updated = tx.transformCommonJSModule(node)
+	}
 	tx.currentSourceFile = nil
 	tx.currentModuleInfo = nil
+	tx.needUMDDynamicImportHelper = false
 	return updated
 }
 
@@ -312,37 +326,7 @@ func (tx *CommonJSModuleTransformer) transformCommonJSModule(node *ast.SourceFil
 
 	// initialize all exports to `undefined`, e.g.:
 	//  exports.a = exports.b = void 0;
-	if len(tx.currentModuleInfo.exportedNames) > 0 {
-		const chunkSize = 50
-		l := len(tx.currentModuleInfo.exportedNames)
-		for i := 0; i < l; i += chunkSize {
-			right := tx.Factory().NewVoidZeroExpression()
-			for _, nextId := range tx.currentModuleInfo.exportedNames[i:min(i+chunkSize, l)] {
-				var left *ast.Expression
-				if nextId.Kind == ast.KindStringLiteral {
-					left = tx.Factory().NewElementAccessExpression(
-						tx.Factory().NewIdentifier("exports"),
-						nil, /*questionDotToken*/
-						tx.Factory().NewStringLiteralFromNode(nextId),
-						ast.NodeFlagsNone,
-					)
-				} else {
-					name := nextId.Clone(tx.Factory())
-					tx.EmitContext().SetEmitFlags(name, printer.EFNoSourceMap) // TODO: Strada emits comments here, but shouldn't
-					left = tx.Factory().NewPropertyAccessExpression(
-						tx.Factory().NewIdentifier("exports"),
-						nil, /*questionDotToken*/
-						name,
-						ast.NodeFlagsNone,
-					)
-				}
-				right = tx.Factory().NewAssignmentExpression(left, right)
-			}
-			statement := tx.Factory().NewExpressionStatement(right)
-			tx.EmitContext().AddEmitFlags(statement, printer.EFCustomPrologue)
-			statements = append(statements, statement)
-		}
-	}
+	statements = tx.appendExportedNamesInitializers(statements)
 
 	// initialize exports for function declarations, e.g.:
 	//  exports.f = f;
@@ -356,7 +340,7 @@ func (tx *CommonJSModuleTransformer) transformCommonJSModule(node *ast.SourceFil
 	statements = append(statements, rest...)
 
 	// emit `module.exports = ...` if needd
-	statements = tx.appendExportEqualsIfNeeded(statements)
+	statements = tx.appendExportEqualsIfNeeded(statements, false /*emitAsReturn*/)
 
 	// merge temp variables into the statement list
 	statements = tx.EmitContext().EndAndMergeVariableEnvironment(statements)
@@ -382,24 +366,69 @@ func (tx *CommonJSModuleTransformer) transformCommonJSModule(node *ast.SourceFil
 	return result.AsNode()
 }
 
+// Appends statements that initialize all exported names to `undefined`, e.g.:
+//
+//	exports.a = exports.b = void 0;
+func (tx *CommonJSModuleTransformer) appendExportedNamesInitializers(statements []*ast.Statement) []*ast.Statement {
+	if len(tx.currentModuleInfo.exportedNames) > 0 {
+		const chunkSize = 50
+		l := len(tx.currentModuleInfo.exportedNames)
+		for i := 0; i < l; i += chunkSize {
+			right := tx.Factory().NewVoidZeroExpression()
+			for _, nextId := range tx.currentModuleInfo.exportedNames[i:min(i+chunkSize, l)] {
+				var left *ast.Expression
+				if nextId.Kind == ast.KindStringLiteral {
+					left = tx.Factory().NewElementAccessExpression(
+						tx.Factory().NewIdentifier("exports"),
+						nil, /*questionDotToken*/
+						tx.Factory().NewStringLiteralFromNode(nextId),
+						ast.NodeFlagsNone,
+					)
+				} else {
+					name := nextId.Clone(tx.Factory())
+					tx.EmitContext().SetEmitFlags(name, printer.EFNoSourceMap) // TODO: Strada emits comments here, but shouldn't
+					left = tx.Factory().NewPropertyAccessExpression(
+						tx.Factory().NewIdentifier("exports"),
+						nil, /*questionDotToken*/
+						name,
+						ast.NodeFlagsNone,
+					)
+				}
+				right = tx.Factory().NewAssignmentExpression(left, right)
+			}
+			statement := tx.Factory().NewExpressionStatement(right)
+			tx.EmitContext().AddEmitFlags(statement, printer.EFCustomPrologue)
+			statements = append(statements, statement)
+		}
+	}
+	return statements
+}
+
 // Adds the down-level representation of `export=` to the statement list if one exists in the source file.
 //
-// - The `statements` parameter is a statement list to which the down-level export statements are to be appended.
-func (tx *CommonJSModuleTransformer) appendExportEqualsIfNeeded(statements []*ast.Statement) []*ast.Statement {
+//   - The `statements` parameter is a statement list to which the down-level export statements are to be appended.
+//   - The `emitAsReturn` parameter indicates whether to emit the export as a `return` statement (for AMD and UMD
+//     module bodies) rather than as an assignment to `module.exports`.
+func (tx *CommonJSModuleTransformer) appendExportEqualsIfNeeded(statements []*ast.Statement, emitAsReturn bool) []*ast.Statement {
 	if tx.currentModuleInfo.exportEquals != nil {
 		expressionResult := tx.Visitor().VisitNode(tx.currentModuleInfo.exportEquals.Expression)
 		if expressionResult != nil {
-			statement := tx.Factory().NewExpressionStatement(
-				tx.Factory().NewAssignmentExpression(
-					tx.Factory().NewPropertyAccessExpression(
-						tx.Factory().NewIdentifier("module"),
-						nil, /*questionDotToken*/
-						tx.Factory().NewIdentifier("exports"),
-						ast.NodeFlagsNone,
+			var statement *ast.Statement
+			if emitAsReturn {
+				statement = tx.Factory().NewReturnStatement(expressionResult)
+			} else {
+				statement = tx.Factory().NewExpressionStatement(
+					tx.Factory().NewAssignmentExpression(
+						tx.Factory().NewPropertyAccessExpression(
+							tx.Factory().NewIdentifier("module"),
+							nil, /*questionDotToken*/
+							tx.Factory().NewIdentifier("exports"),
+							ast.NodeFlagsNone,
+						),
+						expressionResult,
 					),
-					expressionResult,
-				),
-			)
+				)
+			}
 
 			tx.EmitContext().AssignCommentAndSourceMapRanges(statement, tx.currentModuleInfo.exportEquals.AsNode())
 			tx.EmitContext().AddEmitFlags(statement, printer.EFNoComments)
@@ -710,6 +739,10 @@ func (tx *CommonJSModuleTransformer) getHelperExpressionForImport(node *ast.Impo
 }
 
 func (tx *CommonJSModuleTransformer) visitTopLevelImportDeclaration(node *ast.ImportDeclaration) *ast.Node {
+	if tx.moduleKind == core.ModuleKindAMD {
+		return tx.visitTopLevelImportDeclarationAMD(node)
+	}
+
 	if node.ImportClause == nil {
 		// import "mod";
 		statement := tx.Factory().NewExpressionStatement(tx.createRequireCall(node.AsNode()))
@@ -779,7 +812,23 @@ func (tx *CommonJSModuleTransformer) visitTopLevelImportEqualsDeclaration(node *
 	}
 
 	var statements []*ast.Statement
-	if ast.HasSyntacticModifier(node.AsNode(), ast.ModifierFlagsExport) {
+	if tx.moduleKind == core.ModuleKindAMD {
+		// the module is passed as a parameter of the `define` callback, so we only need to export it.
+		if ast.HasSyntacticModifier(node.AsNode(), ast.ModifierFlagsExport) {
+			// export import m = require("mod");
+			statement := tx.Factory().NewExpressionStatement(
+				tx.createExportExpression(
+					tx.Factory().GetExportName(node.AsNode()),
+					tx.Factory().GetLocalName(node.AsNode()),
+					&node.Loc,
+					false, /*liveBinding*/
+				),
+			)
+			tx.EmitContext().SetOriginal(statement, node.AsNode())
+			tx.EmitContext().AssignCommentAndSourceMapRanges(statement, node.AsNode())
+			statements = append(statements, statement)
+		}
+	} else if ast.HasSyntacticModifier(node.AsNode(), ast.ModifierFlagsExport) {
 		// export import m = require("mod");
 		statement := tx.Factory().NewExpressionStatement(
 			tx.createExportExpression(
@@ -829,23 +878,25 @@ func (tx *CommonJSModuleTransformer) visitTopLevelExportDeclaration(node *ast.Ex
 	if node.ExportClause != nil && ast.IsNamedExports(node.ExportClause) {
 		// export { x, y } from "mod";
 		var statements []*ast.Statement
-		varStatement := tx.Factory().NewVariableStatement(
-			nil, /*modifiers*/
-			tx.Factory().NewVariableDeclarationList(
-				ast.NodeFlagsConst,
-				tx.Factory().NewNodeList([]*ast.VariableDeclarationNode{
-					tx.Factory().NewVariableDeclaration(
-						generatedName,
-						nil, /*exclamationToken*/
-						nil, /*type*/
-						tx.createRequireCall(node.AsNode()),
-					),
-				}),
-			),
-		)
-		tx.EmitContext().SetOriginal(varStatement, node.AsNode())
-		tx.EmitContext().AssignCommentAndSourceMapRanges(varStatement, node.AsNode())
-		statements = append(statements, varStatement)
+		if tx.moduleKind != core.ModuleKindAMD {
+			varStatement := tx.Factory().NewVariableStatement(
+				nil, /*modifiers*/
+				tx.Factory().NewVariableDeclarationList(
+					ast.NodeFlagsConst,
+					tx.Factory().NewNodeList([]*ast.VariableDeclarationNode{
+						tx.Factory().NewVariableDeclaration(
+							generatedName,
+							nil, /*exclamationToken*/
+							nil, /*type*/
+							tx.createRequireCall(node.AsNode()),
+						),
+					}),
+				),
+			)
+			tx.EmitContext().SetOriginal(varStatement, node.AsNode())
+			tx.EmitContext().AssignCommentAndSourceMapRanges(varStatement, node.AsNode())
+			statements = append(statements, varStatement)
+		}
 
 		for _, specifier := range node.ExportClause.AsNamedExports().Elements.Nodes {
 			specifierName := specifier.PropertyNameOrName()
@@ -898,13 +949,19 @@ func (tx *CommonJSModuleTransformer) visitTopLevelExportDeclaration(node *ast.Ex
 		} else {
 			exportName = node.ExportClause.Name().Clone(tx.Factory())
 		}
+		var moduleExpression *ast.Expression
+		switch {
+		case tx.moduleKind != core.ModuleKindAMD:
+			moduleExpression = tx.createRequireCall(node.AsNode())
+		case ast.IsStringLiteral(node.ExportClause.Name()) || ast.ModuleExportNameIsDefault(node.ExportClause.Name()):
+			moduleExpression = generatedName
+		default:
+			moduleExpression = tx.Factory().NewIdentifier(node.ExportClause.Name().Text())
+		}
 		statement := tx.Factory().NewExpressionStatement(
 			tx.createExportExpression(
 				exportName,
-				tx.getHelperExpressionForExport(
-					node,
-					tx.createRequireCall(node.AsNode()),
-				),
+				tx.getHelperExpressionForExport(node, moduleExpression),
 				nil,   /*location*/
 				false, /*liveBinding*/
 			),
@@ -915,8 +972,14 @@ func (tx *CommonJSModuleTransformer) visitTopLevelExportDeclaration(node *ast.Ex
 	}
 
 	// export * from "mod";
+	var moduleExpression *ast.Expression
+	if tx.moduleKind != core.ModuleKindAMD {
+		moduleExpression = tx.createRequireCall(node.AsNode())
+	} else {
+		moduleExpression = generatedName
+	}
 	statement := tx.Factory().NewExpressionStatement(
-		tx.Visitor().VisitNode(tx.Factory().NewExportStarHelper(tx.createRequireCall(node.AsNode()), tx.Factory().NewIdentifier("exports"))),
+		tx.Visitor().VisitNode(tx.Factory().NewExportStarHelper(moduleExpression, tx.Factory().NewIdentifier("exports"))),
 	)
 	tx.EmitContext().SetOriginal(statement, node.AsNode())
 	tx.EmitContext().AssignCommentAndSourceMapRanges(statement, node.AsNode())
@@ -1708,10 +1771,17 @@ func (tx *CommonJSModuleTransformer) visitImportCallExpression(node *ast.CallExp
 	} else {
 		argument = firstArgument
 	}
-	return tx.createImportCallExpressionCommonJS(argument)
+	switch tx.moduleKind {
+	case core.ModuleKindAMD:
+		return tx.createImportCallExpressionAMD(argument)
+	case core.ModuleKindUMD:
+		return tx.createImportCallExpressionUMD(argument)
+	default:
+		return tx.createImportCallExpressionCommonJS(argument, false /*isInlineable*/)
+	}
 }
 
-func (tx *CommonJSModuleTransformer) createImportCallExpressionCommonJS(arg *ast.Expression) *ast.Expression {
+func (tx *CommonJSModuleTransformer) createImportCallExpressionCommonJS(arg *ast.Expression, isInlineable bool) *ast.Expression {
 	// import(x)
 	// emit as
 	// Promise.resolve(`${x}`).then((s) => require(s)) /*CommonJS Require*/
@@ -1720,7 +1790,7 @@ func (tx *CommonJSModuleTransformer) createImportCallExpressionCommonJS(arg *ast
 	// If the arg is not inlineable, we have to evaluate and ToString() it in the current scope
 	// Otherwise, we inline it in require() so that it's statically analyzable
 
-	needSyncEval := arg != nil && !isSimpleInlineableExpression(arg)
+	needSyncEval := arg != nil && !isSimpleInlineableExpression(arg) && !isInlineable
 
 	var promiseResolveArguments []*ast.Expression
 	if needSyncEval {
@@ -20,6 +20,8 @@ type externalModuleInfo struct {
 	exportedFunctions            collections.OrderedSet[*ast.FunctionDeclarationNode]          // all of the top-level exported function declarations
 	exportEquals                 *ast.ExportAssignment                                         // an export=/module.exports= declaration if one was present
 	hasExportStarsToExportValues bool                                                          // whether this module contains export*
+	hasImportStar                bool                                                          // whether any import or reexport requires the `__importStar` helper
+	hasImportDefault             bool                                                          // whether any import or reexport requires the `__importDefault` helper
 }
 
 type externalModuleInfoCollector struct {
@@ -149,6 +151,8 @@ func (c *externalModuleInfoCollector) collect() *externalModuleInfo {
 		}
 	}
 
+	c.output.hasImportStar = hasImportStar
+	c.output.hasImportDefault = hasImportDefault
 	return c.output
 }
 
@@ -251,7 +255,7 @@ func createExternalHelpersImportDeclarationIfNeeded(emitContext *printer.EmitCon
 	if compilerOptions.ImportHelpers.IsTrue() && ast.IsEffectiveExternalModule(sourceFile, compilerOptions) {
 		moduleKind := compilerOptions.GetEmitModuleKind()
 		helpers := getImportedHelpers(emitContext, sourceFile)
-		if fileModuleKind == core.ModuleKindCommonJS || fileModuleKind == core.ModuleKindNone && moduleKind == core.ModuleKindCommonJS {
+		if core.ModuleKindCommonJS <= fileModuleKind && fileModuleKind <= core.ModuleKindSystem || fileModuleKind == core.ModuleKindNone && moduleKind == core.ModuleKindCommonJS {
 			// When we emit to a non-ES module, generate a synthetic `import tslib = require("tslib")` to be further transformed.
 			externalHelpersModuleName := getOrCreateExternalHelpersModuleNameIfNeeded(emitContext, sourceFile, compilerOptions, helpers, hasExportStarsToExportValues, hasImportStar || hasImportDefault, fileModuleKind)
 			if externalHelpersModuleName != nil {
@@ -0,0 +1,1763 @@
+package moduletransforms
+
+import (
+	"slices"
+
+	"github.com/pagpeter/typescript-go/external/ast"
+	"github.com/pagpeter/typescript-go/external/binder"
+	"github.com/pagpeter/typescript-go/external/collections"
+	"github.com/pagpeter/typescript-go/external/core"
+	"github.com/pagpeter/typescript-go/external/printer"
+	"github.com/pagpeter/typescript-go/external/transformers"
+)
+
+type SystemModuleTransformer struct {
+	transformers.Transformer
+	topLevelVisitor               *ast.NodeVisitor // visits statements at top level of a module
+	topLevelNestedVisitor         *ast.NodeVisitor // visits nested statements at top level of a module
+	discardedValueVisitor         *ast.NodeVisitor // visits expressions whose values would be discarded at runtime
+	assignmentPatternVisitor      *ast.NodeVisitor // visits assignment patterns in a destructuring assignment
+	compilerOptions               *core.CompilerOptions
+	resolver                      binder.ReferenceResolver
+	getEmitModuleFormatOfFile     func(file ast.HasFileName) core.ModuleKind
+	currentSourceFile             *ast.SourceFile
+	currentModuleInfo             *externalModuleInfo
+	exportFunction                *ast.IdentifierNode // the `exports_1` parameter of the module body function
+	contextObject                 *ast.IdentifierNode // the `context_1` parameter of the module body function
+	hoistedStatements             []*ast.Statement    // function declarations and their exports that are hoisted to the top of the module body
+	enclosingBlockScopedContainer *ast.Node           // the nearest block-scoped container of the node being visited
+	parentNode                    *ast.Node           // used for ancestor tracking via pushNode/popNode to detect expression identifiers
+	currentNode                   *ast.Node           // used for ancestor tracking via pushNode/popNode to detect expression identifiers
+}
+
+// A group of external imports that share the same module name.
+type dependencyGroup struct {
+	name            *ast.StringLiteralNode
+	externalImports []*ast.Node /*ImportDeclaration | ImportEqualsDeclaration | ExportDeclaration*/
+}
+
+// Transforms an ES module into a SystemJS module using a `System.register([...], function (exports_1, context_1) { })`
+// wrapper.
+func NewSystemModuleTransformer(emitContext *printer.EmitContext, compilerOptions *core.CompilerOptions, resolver binder.ReferenceResolver, getEmitModuleFormatOfFile func(file ast.HasFileName) core.ModuleKind) *transformers.Transformer {
+	if resolver == nil {
+		resolver = binder.NewReferenceResolver(compilerOptions, binder.ReferenceResolverHooks{})
+	}
+	tx := &SystemModuleTransformer{compilerOptions: compilerOptions, resolver: resolver, getEmitModuleFormatOfFile: getEmitModuleFormatOfFile}
+	tx.topLevelVisitor = emitContext.NewNodeVisitor(tx.visitTopLevel)
+	tx.topLevelNestedVisitor = emitContext.NewNodeVisitor(tx.visitTopLevelNested)
+	tx.discardedValueVisitor = emitContext.NewNodeVisitor(tx.visitDiscardedValue)
+	tx.assignmentPatternVisitor = emitContext.NewNodeVisitor(tx.visitAssignmentPattern)
+	return tx.NewTransformer(tx.visit, emitContext)
+}
+
+// Pushes a new child node onto the ancestor tracking stack, returning the grandparent node to be restored later via `popNode`.
+func (tx *SystemModuleTransformer) pushNode(node *ast.Node) (grandparentNode *ast.Node) {
+	grandparentNode = tx.parentNode
+	tx.parentNode = tx.currentNode
+	tx.currentNode = node
+	return
+}
+
+// Pops the last child node off the ancestor tracking stack, restoring the grandparent node.
+func (tx *SystemModuleTransformer) popNode(grandparentNode *ast.Node) {
+	tx.currentNode = tx.parentNode
+	tx.parentNode = grandparentNode
+}
+
+// Visits a node at the top level of the source file.
+func (tx *SystemModuleTransformer) visitTopLevel(node *ast.Node) *ast.Node {
+	grandparentNode := tx.pushNode(node)
+	defer tx.popNode(grandparentNode)
+
+	switch node.Kind {
+	case ast.KindImportDeclaration:
+		node = tx.visitTopLevelImportDeclaration(node.AsImportDeclaration())
+	case ast.KindImportEqualsDeclaration:
+		node = tx.visitTopLevelImportEqualsDeclaration(node.AsImportEqualsDeclaration())
+	case ast.KindExportDeclaration:
+		// Export declarations are handled by the setters of the module.
+		node = nil
+	case ast.KindExportAssignment:
+		node = tx.visitTopLevelExportAssignment(node.AsExportAssignment())
+	default:
+		node = tx.visitTopLevelNestedNoStack(node)
+	}
+	return node
+}
+
+// Visits nested elements at the top-level of a module.
+func (tx *SystemModuleTransformer) visitTopLevelNested(node *ast.Node) *ast.Node {
+	grandparentNode := tx.pushNode(node)
+	defer tx.popNode(grandparentNode)
+
+	return tx.visitTopLevelNestedNoStack(node)
+}
+
+// Visits nested elements at the top-level of a module without ancestor tracking.
+func (tx *SystemModuleTransformer) visitTopLevelNestedNoStack(node *ast.Node) *ast.Node {
+	switch node.Kind {
+	case ast.KindVariableStatement:
+		node = tx.visitTopLevelNestedVariableStatement(node.AsVariableStatement())
+	case ast.KindFunctionDeclaration:
+		node = tx.visitTopLevelNestedFunctionDeclaration(node.AsFunctionDeclaration())
+	case ast.KindClassDeclaration:
+		node = tx.visitTopLevelNestedClassDeclaration(node.AsClassDeclaration())
+	case ast.KindForStatement:
+		node = tx.visitTopLevelNestedForStatement(node.AsForStatement())
+	case ast.KindForInStatement, ast.KindForOfStatement:
+		node = tx.visitTopLevelNestedForInOrOfStatement(node.AsForInOrOfStatement())
+	case ast.KindDoStatement:
+		node = tx.visitTopLevelNestedDoStatement(node.AsDoStatement())
+	case ast.KindWhileStatement:
+		node = tx.visitTopLevelNestedWhileStatement(node.AsWhileStatement())
+	case ast.KindLabeledStatement:
+		node = tx.visitTopLevelNestedLabeledStatement(node.AsLabeledStatement())
+	case ast.KindWithStatement:
+		node = tx.visitTopLevelNestedWithStatement(node.AsWithStatement())
+	case ast.KindIfStatement:
+		node = tx.visitTopLevelNestedIfStatement(node.AsIfStatement())
+	case ast.KindSwitchStatement:
+		node = tx.visitTopLevelNestedSwitchStatement(node.AsSwitchStatement())
+	case ast.KindCaseBlock:
+		node = tx.visitTopLevelNestedCaseBlock(node.AsCaseBlock())
+	case ast.KindCaseClause, ast.KindDefaultClause:
+		node = tx.visitTopLevelNestedCaseOrDefaultClause(node.AsCaseOrDefaultClause())
+	case ast.KindTryStatement:
+		node = tx.visitTopLevelNestedTryStatement(node.AsTryStatement())
+	case ast.KindCatchClause:
+		node = tx.visitTopLevelNestedCatchClause(node.AsCatchClause())
+	case ast.KindBlock:
+		node = tx.visitTopLevelNestedBlock(node.AsBlock())
+	default:
+		node = tx.visitNoStack(node, false /*resultIsDiscarded*/)
+	}
+	return node
+}
+
+// Visits source elements that are not top-level or top-level nested statements.
+func (tx *SystemModuleTransformer) visit(node *ast.Node) *ast.Node {
+	grandparentNode := tx.pushNode(node)
+	defer tx.popNode(grandparentNode)
+
+	return tx.visitNoStack(node, false /*resultIsDiscarded*/)
+}
+
+// Visits source elements that are not top-level or top-level nested statements without ancestor tracking.
+func (tx *SystemModuleTransformer) visitNoStack(node *ast.Node, resultIsDiscarded bool) *ast.Node {
+	// This visitor does not need to descend into the tree if there are no dynamic imports or identifiers in the subtree
+	if !ast.IsSourceFile(node) && node.SubtreeFacts()&(ast.SubtreeContainsDynamicImport|ast.SubtreeContainsIdentifier) == 0 {
+		return node
+	}
+
+	switch node.Kind {
+	case ast.KindSourceFile:
+		node = tx.visitSourceFile(node.AsSourceFile())
+	case ast.KindForStatement:
+		node = tx.visitForStatement(node.AsForStatement())
+	case ast.KindForInStatement, ast.KindForOfStatement:
+		node = tx.visitForInOrOfStatement(node.AsForInOrOfStatement())
+	case ast.KindExpressionStatement:
+		node = tx.visitExpressionStatement(node.AsExpressionStatement())
+	case ast.KindVoidExpression:
+		node = tx.visitVoidExpression(node.AsVoidExpression())
+	case ast.KindParenthesizedExpression:
+		node = tx.visitParenthesizedExpression(node.AsParenthesizedExpression(), resultIsDiscarded)
+	case ast.KindPartiallyEmittedExpression:
+		node = tx.visitPartiallyEmittedExpression(node.AsPartiallyEmittedExpression(), resultIsDiscarded)
+	case ast.KindCallExpression:
+		node = tx.visitCallExpression(node.AsCallExpression())
+	case ast.KindMetaProperty:
+		node = tx.visitMetaProperty(node.AsMetaProperty())
+	case ast.KindBinaryExpression:
+		node = tx.visitBinaryExpression(node.AsBinaryExpression(), resultIsDiscarded)
+	case ast.KindPrefixUnaryExpression:
+		node = tx.visitPrefixUnaryExpression(node.AsPrefixUnaryExpression())
+	case ast.KindPostfixUnaryExpression:
+		node = tx.visitPostfixUnaryExpression(node.AsPostfixUnaryExpression(), resultIsDiscarded)
+	case ast.KindShorthandPropertyAssignment:
+		node = tx.visitShorthandPropertyAssignment(node.AsShorthandPropertyAssignment())
+	case ast.KindIdentifier:
+		node = tx.visitIdentifier(node)
+	default:
+		node = tx.Visitor().VisitEachChild(node)
+	}
+
+	return node
+}
+
+// Visits source elements whose value is discarded if they are expressions.
+func (tx *SystemModuleTransformer) visitDiscardedValue(node *ast.Node) *ast.Node {
+	grandparentNode := tx.pushNode(node)
+	defer tx.popNode(grandparentNode)
+
+	return tx.visitNoStack(node, true /*resultIsDiscarded*/)
+}
+
+func (tx *SystemModuleTransformer) visitAssignmentPattern(node *ast.Node) *ast.Node {
+	grandparentNode := tx.pushNode(node)
+	defer tx.popNode(grandparentNode)
+
+	return tx.visitAssignmentPatternNoStack(node)
+}
+
+func (tx *SystemModuleTransformer) visitAssignmentPatternNoStack(node *ast.Node) *ast.Node {
+	switch node.Kind {
+	// AssignmentPattern
+	case ast.KindObjectLiteralExpression, ast.KindArrayLiteralExpression:
+		node = tx.assignmentPatternVisitor.VisitEachChild(node)
+
+	// AssignmentProperty
+	case ast.KindPropertyAssignment:
+		node = tx.visitAssignmentProperty(node.AsPropertyAssignment())
+	case ast.KindShorthandPropertyAssignment:
+		node = tx.visitShorthandAssignmentProperty(node.AsShorthandPropertyAssignment())
+
+	// AssignmentRestProperty
+	case ast.KindSpreadAssignment:
+		node = tx.visitAssignmentRestProperty(node.AsSpreadAssignment())
+
+	// AssignmentRestElement
+	case ast.KindSpreadElement:
+		node = tx.visitAssignmentRestElement(node.AsSpreadElement())
+
+	// AssignmentElement
+	default:
+		if ast.IsExpression(node) {
+			node = tx.visitAssignmentElement(node)
+			break
+		}
+
+		node = tx.visitNoStack(node, false /*resultIsDiscarded*/)
+	}
+	return node
+}
+
+func (tx *SystemModuleTransformer) visitSourceFile(node *ast.SourceFile) *ast.Node {
+	if node.IsDeclarationFile ||
+		!(ast.IsEffectiveExternalModule(node, tx.compilerOptions) ||
+			node.SubtreeFacts()&ast.SubtreeContainsDynamicImport != 0) {
+		return node.AsNode()
+	}
+
+	tx.currentSourceFile = node
+	tx.currentModuleInfo = collectExternalModuleInfo(node, tx.compilerOptions, tx.EmitContext(), tx.resolver)
+	tx.enclosingBlockScopedContainer = node.AsNode()
+
+	// System modules have the following shape:
+	//
+	//     System.register(['dep-1', ... 'dep-n'], function(exports) {/* module body function */})
+	//
+	// The parameter 'exports' here is a callback '<T>(name: string, value: T) => T' that is used to publish exported
+	// values. 'exports' returns its 'value' argument so in most cases expressions that mutate exported values can be
+	// rewritten as:
+	//
+	//     expr -> exports('name', expr)
+	//
+	// The only exception in this rule is postfix unary operators, see comment to 'visitPostfixUnaryExpression' for
+	// more details.
+	tx.exportFunction = tx.Factory().NewUniqueName("exports")
+	tx.contextObject = tx.Factory().NewUniqueName("context")
+
+	externalHelpersImportDeclaration := createExternalHelpersImportDeclarationIfNeeded(
+		tx.EmitContext(),
+		node,
+		tx.compilerOptions,
+		tx.getEmitModuleFormatOfFile(node),
+		tx.currentModuleInfo.hasExportStarsToExportValues,
+		tx.currentModuleInfo.hasImportStar,
+		tx.currentModuleInfo.hasImportDefault,
+	)
+
+	// Collect information about the external modules.
+	dependencyGroups := tx.collectDependencyGroups(externalHelpersImportDeclaration)
+
+	moduleBodyBlock := tx.createSystemModuleBody(node, dependencyGroups, externalHelpersImportDeclaration)
+	moduleBodyFunction := tx.Factory().NewFunctionExpression(
+		nil, /*modifiers*/
+		nil, /*asteriskToken*/
+		nil, /*name*/
+		nil, /*typeParameters*/
+		tx.Factory().NewNodeList([]*ast.ParameterDeclarationNode{
+			tx.Factory().NewParameterDeclaration(nil /*modifiers*/, nil /*dotDotDotToken*/, tx.exportFunction, nil /*questionToken*/, nil /*type*/, nil /*initializer*/),
+			tx.Factory().NewParameterDeclaration(nil /*modifiers*/, nil /*dotDotDotToken*/, tx.contextObject, nil /*questionToken*/, nil /*type*/, nil /*initializer*/),
+		}),
+		nil, /*returnType*/
+		moduleBodyBlock,
+	)
+
+	// Write the call to `System.register`
+	var arguments []*ast.Expression
+	if moduleName := tryGetModuleNameFromFile(tx.Factory(), node, nil /*host*/, tx.compilerOptions); moduleName != nil {
+		arguments = append(arguments, moduleName)
+	}
+	dependencies := core.Map(dependencyGroups, func(group *dependencyGroup) *ast.Expression { return group.name })
+	arguments = append(arguments,
+		tx.Factory().NewArrayLiteralExpression(tx.Factory().NewNodeList(dependencies), false /*multiLine*/),
+		moduleBodyFunction,
+	)
+
+	statement := tx.Factory().NewExpressionStatement(
+		tx.Factory().NewCallExpression(
+			tx.Factory().NewPropertyAccessExpression(
+				tx.Factory().NewIdentifier("System"),
+				nil, /*questionDotToken*/
+				tx.Factory().NewIdentifier("register"),
+				ast.NodeFlagsNone,
+			),
+			nil, /*questionDotToken*/
+			nil, /*typeArguments*/
+			tx.Factory().NewNodeList(arguments),
+			ast.NodeFlagsNone,
+		),
+	)
+
+	statementList := tx.Factory().NewNodeList([]*ast.Statement{statement})
+	statementList.Loc = node.Statements.Loc
+	updated := tx.Factory().UpdateSourceFile(node, statementList)
+	tx.EmitContext().AddEmitFlags(updated, printer.EFNoTrailingComments)
+	tx.EmitContext().AddEmitHelper(updated, tx.EmitContext().ReadEmitHelpers()...)
+	if len(tx.compilerOptions.OutFile) == 0 {
+		tx.EmitContext().MoveEmitHelpers(updated, moduleBodyBlock, func(helper *printer.EmitHelper) bool { return !helper.Scoped })
+	}
+
+	tx.currentSourceFile = nil
+	tx.currentModuleInfo = nil
+	tx.exportFunction = nil
+	tx.contextObject = nil
+	tx.hoistedStatements = nil
+	tx.enclosingBlockScopedContainer = nil
+	return updated
+}
+
+// Collects the dependency groups for this file. Imports of the same module name are grouped so that they share a
+// single setter.
+func (tx *SystemModuleTransformer) collectDependencyGroups(externalHelpersImportDeclaration *ast.Node) []*dependencyGroup {
+	externalImports := tx.currentModuleInfo.externalImports
+	if externalHelpersImportDeclaration != nil {
+		externalImports = append([]*ast.Declaration{externalHelpersImportDeclaration}, externalImports...)
+	}
+
+	groupIndices := make(map[string]int)
+	var dependencyGroups []*dependencyGroup
+	for _, externalImport := range externalImports {
+		externalModuleName := getExternalModuleNameLiteral(tx.Factory(), externalImport, tx.currentSourceFile, nil /*host*/, nil /*resolver*/, tx.compilerOptions)
+		if externalModuleName == nil {
+			continue
+		}
+		text := externalModuleName.Text()
+		if groupIndex, ok := groupIndices[text]; ok {
+			// deduplicate/group entries in dependency list by the dependency name
+			group := dependencyGroups[groupIndex]
+			group.externalImports = append(group.externalImports, externalImport)
+		} else {
+			groupIndices[text] = len(dependencyGroups)
+			dependencyGroups = append(dependencyGroups, &dependencyGroup{
+				name:            externalModuleName,
+				externalImports: []*ast.Node{externalImport},
+			})
+		}
+	}
+	return dependencyGroups
+}
+
+// Adds the statements for the module body function for the source file:
+//
+//	"use strict";
+//	var x, y, m_1;
+//	var __moduleName = context_1 && context_1.id;
+//	function f() { }
+//	exports_1("f", f);
+//	return {
+//	    setters: [
+//	        function (m_1_1) {
+//	            m_1 = m_1_1;
+//	        }
+//	    ],
+//	    execute: function () {
+//	        ...
+//	    }
+//	};
+func (tx *SystemModuleTransformer) createSystemModuleBody(node *ast.SourceFile, dependencyGroups []*dependencyGroup, externalHelpersImportDeclaration *ast.Node) *ast.BlockNode {
+	tx.EmitContext().StartVariableEnvironment()
+
+	// Add any prologue directives.
+	prologue, rest := tx.Factory().SplitStandardPrologue(node.Statements.Nodes)
+	statements := slices.Clone(prologue)
+	if ast.IsExternalModule(node) || tx.compilerOptions.AlwaysStrict.IsTrue() {
+		statements = tx.Factory().EnsureUseStrict(statements)
+	}
+
+	// emit custom prologues from other transformations
+	custom, rest := tx.Factory().SplitCustomPrologue(rest)
+	statements = append(statements, core.FirstResult(tx.topLevelVisitor.VisitSlice(custom))...)
+
+	// var __moduleName = context_1 && context_1.id;
+	statements = append(statements, tx.Factory().NewVariableStatement(
+		nil, /*modifiers*/
+		tx.Factory().NewVariableDeclarationList(
+			ast.NodeFlagsNone,
+			tx.Factory().NewNodeList([]*ast.VariableDeclarationNode{
+				tx.Factory().NewVariableDeclaration(
+					tx.Factory().NewIdentifier("__moduleName"),
+					nil, /*exclamationToken*/
+					nil, /*type*/
+					tx.Factory().NewLogicalANDExpression(
+						tx.contextObject,
+						tx.Factory().NewPropertyAccessExpression(
+							tx.contextObject,
+							nil, /*questionDotToken*/
+							tx.Factory().NewIdentifier("id"),
+							ast.NodeFlagsNone,
+						),
+					),
+				),
+			}),
+		),
+	))
+
+	// Visit the synthetic external helpers import declaration if present. This only hoists its local name, as the
+	// module itself is assigned by a setter.
+	if externalHelpersImportDeclaration != nil {
+		tx.topLevelVisitor.VisitSlice([]*ast.Node{externalHelpersImportDeclaration})
+	}
+
+	// Visit the statements of the source file, emitting any transformations into the `executeStatements` array.
+	executeStatements, _ := tx.topLevelVisitor.VisitSlice(rest)
+
+	// Emit early exports for function declarations.
+	statements = append(statements, tx.hoistedStatements...)
+
+	// We emit hoisted variables early to align roughly with our previous emit output.
+	// Two key differences in this approach are:
+	// - Temporary variables will appear at the top rather than at the bottom of the file
+	statements = tx.EmitContext().EndAndMergeVariableEnvironment(statements)
+
+	exportStarFunction := tx.addExportStarIfNeeded(&statements)
+
+	var modifiers *ast.ModifierList
+	if node.SubtreeFacts()&ast.SubtreeContainsAwait != 0 {
+		modifiers = tx.Factory().NewModifierList([]*ast.Node{tx.Factory().NewModifier(ast.KindAsyncKeyword)})
+	}
+
+	moduleObject := tx.Factory().NewObjectLiteralExpression(
+		tx.Factory().NewNodeList([]*ast.Node{
+			tx.Factory().NewPropertyAssignment(
+				nil, /*modifiers*/
+				tx.Factory().NewIdentifier("setters"),
+				nil, /*postfixToken*/
+				nil, /*typeNode*/
+				tx.createSettersArray(exportStarFunction, dependencyGroups),
+			),
+			tx.Factory().NewPropertyAssignment(
+				nil, /*modifiers*/
+				tx.Factory().NewIdentifier("execute"),
+				nil, /*postfixToken*/
+				nil, /*typeNode*/
+				tx.Factory().NewFunctionExpression(
+					modifiers,
+					nil, /*asteriskToken*/
+					nil, /*name*/
+					nil, /*typeParameters*/
+					tx.Factory().NewNodeList(nil),
+					nil, /*returnType*/
+					tx.Factory().NewBlock(tx.Factory().NewNodeList(executeStatements), true /*multiLine*/),
+				),
+			),
+		}),
+		true, /*multiLine*/
+	)
+
+	statements = append(statements, tx.Factory().NewReturnStatement(moduleObject))
+	return tx.Factory().NewBlock(tx.Factory().NewNodeList(statements), true /*multiLine*/)
+}
+
+// Adds an exportStar function to a statement list if it is needed for the file, returning the name of the function.
+func (tx *SystemModuleTransformer) addExportStarIfNeeded(statements *[]*ast.Statement) *ast.IdentifierNode {
+	if !tx.currentModuleInfo.hasExportStarsToExportValues {
+		return nil
+	}
+
+	// when resolving exports local exported entries/indirect exported entries in the module
+	// should always win over entries with similar names that were added via star exports
+	// to support this we store names of local/indirect exported entries in a set.
+	// this set is used to filter names brought by star exports.
+
+	// local names set should only be added if we have anything exported
+	if len(tx.currentModuleInfo.exportedNames) == 0 && tx.currentModuleInfo.exportedFunctions.Size() == 0 && tx.currentModuleInfo.exportSpecifiers.Len() == 0 {
+		// no exported declarations (export var ...) or export specifiers (export {x})
+		// check if we have any non star export declarations.
+		hasExportDeclarationWithExportClause := false
+		for _, externalImport := range tx.currentModuleInfo.externalImports {
+			if ast.IsExportDeclaration(externalImport) && externalImport.AsExportDeclaration().ExportClause != nil {
+				hasExportDeclarationWithExportClause = true
+				break
+			}
+		}
+
+		if !hasExportDeclarationWithExportClause {
+			// we still need to emit exportStar helper
+			exportStarFunction := tx.createExportStarFunction(nil /*localNames*/)
+			*statements = append(*statements, exportStarFunction)
+			return exportStarFunction.Name()
+		}
+	}
+
+	var exportedNames []*ast.Node
+	for _, exportedLocalName := range tx.currentModuleInfo.exportedNames {
+		if ast.ModuleExportNameIsDefault(exportedLocalName) {
+			continue
+		}
+
+		// write name of exported declaration, i.e 'export var x...'
+		exportedNames = append(exportedNames, tx.Factory().NewPropertyAssignment(
+			nil, /*modifiers*/
+			tx.Factory().NewStringLiteralFromNode(exportedLocalName),
+			nil, /*postfixToken*/
+			nil, /*typeNode*/
+			tx.Factory().NewTrueExpression(),
+		))
+	}
+
+	for f := range tx.currentModuleInfo.exportedFunctions.Values() {
+		if ast.HasSyntacticModifier(f, ast.ModifierFlagsDefault) || f.Name() == nil {
+			continue
+		}
+
+		// write name of exported function, i.e 'export function f() {}'
+		exportedNames = append(exportedNames, tx.Factory().NewPropertyAssignment(
+			nil, /*modifiers*/
+			tx.Factory().NewStringLiteralFromNode(f.Name()),
+			nil, /*postfixToken*/
+			nil, /*typeNode*/
+			tx.Factory().NewTrueExpression(),
+		))
+	}
+
+	exportedNamesStorageRef := tx.Factory().NewUniqueName("exportedNames")
+	*statements = append(*statements, tx.Factory().NewVariableStatement(
+		nil, /*modifiers*/
+		tx.Factory().NewVariableDeclarationList(
+			ast.NodeFlagsNone,
+			tx.Factory().NewNodeList([]*ast.VariableDeclarationNode{
+				tx.Factory().NewVariableDeclaration(
+					exportedNamesStorageRef,
+					nil, /*exclamationToken*/
+					nil, /*type*/
+					tx.Factory().NewObjectLiteralExpression(tx.Factory().NewNodeList(exportedNames), true /*multiLine*/),
+				),
+			}),
+		),
+	))
+
+	exportStarFunction := tx.createExportStarFunction(exportedNamesStorageRef)
+	*statements = append(*statements, exportStarFunction)
+	return exportStarFunction.Name()
+}
+
+// Creates an exportStar function for the file, with an optional set of excluded local names:
+//
+//	function exportStar_1(m) {
+//	    var exports = {};
+//	    for (var n in m) {
+//	        if (n !== "default" && !exportedNames_1.hasOwnProperty(n)) exports[n] = m[n];
+//	    }
+//	    exports_1(exports);
+//	}
+func (tx *SystemModuleTransformer) createExportStarFunction(localNames *ast.IdentifierNode) *ast.Statement {
+	exportStarFunction := tx.Factory().NewUniqueName("exportStar")
+	m := tx.Factory().NewIdentifier("m")
+	n := tx.Factory().NewIdentifier("n")
+	exports := tx.Factory().NewIdentifier("exports")
+	condition := tx.Factory().NewStrictInequalityExpression(n, tx.Factory().NewStringLiteral("default"))
+	if localNames != nil {
+		condition = tx.Factory().NewLogicalANDExpression(
+			condition,
+			tx.Factory().NewPrefixUnaryExpression(
+				ast.KindExclamationToken,
+				tx.Factory().NewCallExpression(
+					tx.Factory().NewPropertyAccessExpression(
+						localNames,
+						nil, /*questionDotToken*/
+						tx.Factory().NewIdentifier("hasOwnProperty"),
+						ast.NodeFlagsNone,
+					),
+					nil, /*questionDotToken*/
+					nil, /*typeArguments*/
+					tx.Factory().NewNodeList([]*ast.Expression{n}),
+					ast.NodeFlagsNone,
+				),
+			),
+		)
+	}
+
+	copyExport := tx.Factory().NewIfStatement(
+		condition,
+		tx.Factory().NewExpressionStatement(
+			tx.Factory().NewAssignmentExpression(
+				tx.Factory().NewElementAccessExpression(exports, nil /*questionDotToken*/, n, ast.NodeFlagsNone),
+				tx.Factory().NewElementAccessExpression(m, nil /*questionDotToken*/, n, ast.NodeFlagsNone),
+			),
+		),
+		nil, /*elseStatement*/
+	)
+	tx.EmitContext().SetEmitFlags(copyExport, printer.EFSingleLine)
+
+	return tx.Factory().NewFunctionDeclaration(
+		nil, /*modifiers*/
+		nil, /*asteriskToken*/
+		exportStarFunction,
+		nil, /*typeParameters*/
+		tx.Factory().NewNodeList([]*ast.ParameterDeclarationNode{
+			tx.Factory().NewParameterDeclaration(nil /*modifiers*/, nil /*dotDotDotToken*/, m, nil /*questionToken*/, nil /*type*/, nil /*initializer*/),
+		}),
+		nil, /*returnType*/
+		tx.Factory().NewBlock(
+			tx.Factory().NewNodeList([]*ast.Statement{
+				tx.Factory().NewVariableStatement(
+					nil, /*modifiers*/
+					tx.Factory().NewVariableDeclarationList(
+						ast.NodeFlagsNone,
+						tx.Factory().NewNodeList([]*ast.VariableDeclarationNode{
+							tx.Factory().NewVariableDeclaration(
+								exports,
+								nil, /*exclamationToken*/
+								nil, /*type*/
+								tx.Factory().NewObjectLiteralExpression(tx.Factory().NewNodeList(nil), false /*multiLine*/),
+							),
+						}),
+					),
+				),
+				tx.Factory().NewForInOrOfStatement(
+					ast.KindForInStatement,
+					nil, /*awaitModifier*/
+					tx.Factory().NewVariableDeclarationList(
+						ast.NodeFlagsNone,
+						tx.Factory().NewNodeList([]*ast.VariableDeclarationNode{
+							tx.Factory().NewVariableDeclaration(n, nil /*exclamationToken*/, nil /*type*/, nil /*initializer*/),
+						}),
+					),
+					m,
+					tx.Factory().NewBlock(tx.Factory().NewNodeList([]*ast.Statement{copyExport}), true /*multiLine*/),
+				),
+				tx.Factory().NewExpressionStatement(
+					tx.Factory().NewCallExpression(
+						tx.exportFunction,
+						nil, /*questionDotToken*/
+						nil, /*typeArguments*/
+						tx.Factory().NewNodeList([]*ast.Expression{exports}),
+						ast.NodeFlagsNone,
+					),
+				),
+			}),
+			true, /*multiLine*/
+		),
+	)
+}
+
+// Creates an array setter callbacks for each dependency group, i.e.:
+//
+//	[
+//	    function (m_1_1) {
+//	        m_1 = m_1_1;
+//	    },
+//	    function (m_2_1) {
+//	        exportStar_1(m_2_1);
+//	    }
+//	]
+func (tx *SystemModuleTransformer) createSettersArray(exportStarFunction *ast.IdentifierNode, dependencyGroups []*dependencyGroup) *ast.Expression {
+	var setters []*ast.Expression
+	for _, group := range dependencyGroups {
+		// derive a unique name for parameter from the first named entry in the group
+		var parameterName *ast.IdentifierNode
+		for _, externalImport := range group.externalImports {
+			if localName := getLocalNameForExternalImport(tx.EmitContext(), externalImport, tx.currentSourceFile); localName != nil {
+				parameterName = tx.Factory().NewGeneratedNameForNode(localName)
+				break
+			}
+		}
+		if parameterName == nil {
+			// import "mod";
+			parameterName = tx.Factory().NewUniqueName("_")
+		}
+
+		var statements []*ast.Statement
+		for _, entry := range group.externalImports {
+			importVariableName := getLocalNameForExternalImport(tx.EmitContext(), entry, tx.currentSourceFile)
+			switch entry.Kind {
+			case ast.KindImportDeclaration, ast.KindImportEqualsDeclaration:
+				if importVariableName == nil {
+					// import "mod";
+					break
+				}
+
+				// save import into the local
+				statements = append(statements, tx.Factory().NewExpressionStatement(
+					tx.Factory().NewAssignmentExpression(importVariableName, parameterName),
+				))
+				if ast.HasSyntacticModifier(entry, ast.ModifierFlagsExport) {
+					statements = append(statements, tx.Factory().NewExpressionStatement(
+						tx.createExportCall(tx.Factory().NewStringLiteral(importVariableName.Text()), parameterName),
+					))
+				}
+
+			case ast.KindExportDeclaration:
+				exportClause := entry.AsExportDeclaration().ExportClause
+				switch {
+				case exportClause == nil:
+					// export * from "mod";
+					//
+					// emits:
+					//
+					//  exportStar_1(m_1_1);
+					statements = append(statements, tx.Factory().NewExpressionStatement(
+						tx.Factory().NewCallExpression(
+							exportStarFunction,
+							nil, /*questionDotToken*/
+							nil, /*typeArguments*/
+							tx.Factory().NewNodeList([]*ast.Expression{parameterName}),
+							ast.NodeFlagsNone,
+						),
+					))
+
+				case ast.IsNamedExports(exportClause):
+					// export {a, b as c} from "mod";
+					//
+					// emits:
+					//
+					//  exports_1({
+					//      "a": m_1_1["a"],
+					//      "c": m_1_1["b"]
+					//  });
+					var properties []*ast.Node
+					for _, e := range exportClause.AsNamedExports().Elements.Nodes {
+						properties = append(properties, tx.Factory().NewPropertyAssignment(
+							nil, /*modifiers*/
+							tx.Factory().NewStringLiteral(e.Name().Text()),
+							nil, /*postfixToken*/
+							nil, /*typeNode*/
+							tx.Factory().NewElementAccessExpression(
+								parameterName,
+								nil, /*questionDotToken*/
+								tx.Factory().NewStringLiteral(e.PropertyNameOrName().Text()),
+								ast.NodeFlagsNone,
+							),
+						))
+					}
+					statements = append(statements, tx.Factory().NewExpressionStatement(
+						tx.Factory().NewCallExpression(
+							tx.exportFunction,
+							nil, /*questionDotToken*/
+							nil, /*typeArguments*/
+							tx.Factory().NewNodeList([]*ast.Expression{
+								tx.Factory().NewObjectLiteralExpression(tx.Factory().NewNodeList(properties), true /*multiLine*/),
+							}),
+							ast.NodeFlagsNone,
+						),
+					))
+
+				default:
+					// export * as ns from "mod";
+					//
+					// emits:
+					//
+					//  exports_1("ns", m_1_1);
+					statements = append(statements, tx.Factory().NewExpressionStatement(
+						tx.createExportCall(tx.Factory().NewStringLiteral(exportClause.Name().Text()), parameterName),
+					))
+				}
+			}
+		}
+
+		setters = append(setters, tx.Factory().NewFunctionExpression(
+			nil, /*modifiers*/
+			nil, /*asteriskToken*/
+			nil, /*name*/
+			nil, /*typeParameters*/
+			tx.Factory().NewNodeList([]*ast.ParameterDeclarationNode{
+				tx.Factory().NewParameterDeclaration(nil /*modifiers*/, nil /*dotDotDotToken*/, parameterName, nil /*questionToken*/, nil /*type*/, nil /*initializer*/),
+			}),
+			nil, /*returnType*/
+			tx.Factory().NewBlock(tx.Factory().NewNodeList(statements), true /*multiLine*/),
+		))
+	}
+
+	return tx.Factory().NewArrayLiteralExpression(tx.Factory().NewNodeList(setters), true /*multiLine*/)
+}
+
+// Visits an ImportDeclaration at the top level of a module. The import itself is assigned by a setter, so only the
+// local name is hoisted.
+func (tx *SystemModuleTransformer) visitTopLevelImportDeclaration(node *ast.ImportDeclaration) *ast.Node {
+	if node.ImportClause != nil {
+		tx.EmitContext().AddVariableDeclaration(getLocalNameForExternalImport(tx.EmitContext(), node.AsNode(), tx.currentSourceFile))
+	}
+	return transformers.SingleOrMany(tx.appendExportsOfImportDeclaration(nil /*statements*/, node), tx.Factory())
+}
+
+// Visits an ImportEqualsDeclaration at the top level of a module. If the declaration references an external module,
+// the import is assigned by a setter and only the local name is hoisted.
+func (tx *SystemModuleTransformer) visitTopLevelImportEqualsDeclaration(node *ast.ImportEqualsDeclaration) *ast.Node {
+	if !ast.IsExternalModuleImportEqualsDeclaration(node.AsNode()) {
+		return tx.Visitor().VisitEachChild(node.AsNode())
+	}
+	tx.EmitContext().AddVariableDeclaration(getLocalNameForExternalImport(tx.EmitContext(), node.AsNode(), tx.currentSourceFile))
+	return transformers.SingleOrMany(tx.appendExportsOfDeclaration(nil /*statements*/, node.AsNode(), "" /*excludeName*/), tx.Factory())
+}
+
+// Visits an ExportAssignment at the top level of a module. `export =` is not supported for System modules and is
+// elided.
+func (tx *SystemModuleTransformer) visitTopLevelExportAssignment(node *ast.ExportAssignment) *ast.Node {
+	if node.IsExportEquals {
+		return nil
+	}
+	expression := tx.Visitor().VisitNode(node.Expression)
+	return tx.createExportStatement(tx.Factory().NewIdentifier("default"), expression, true /*allowComments*/)
+}
+
+// Visits a FunctionDeclaration, hoisting it to the outer module body function.
+func (tx *SystemModuleTransformer) visitTopLevelNestedFunctionDeclaration(node *ast.FunctionDeclaration) *ast.Node {
+	if ast.HasSyntacticModifier(node.AsNode(), ast.ModifierFlagsExport) {
+		tx.hoistedStatements = append(tx.hoistedStatements, tx.Factory().UpdateFunctionDeclaration(
+			node,
+			transformers.ExtractModifiers(tx.EmitContext(), node.Modifiers(), ^ast.ModifierFlagsExportDefault),
+			node.AsteriskToken,
+			tx.Factory().GetDeclarationName(node.AsNode()),
+			nil, /*typeParameters*/
+			tx.EmitContext().VisitParameters(node.Parameters, tx.Visitor()),
+			nil, /*type*/
+			tx.EmitContext().VisitFunctionBody(node.Body, tx.Visitor()),
+		))
+	} else {
+		tx.hoistedStatements = append(tx.hoistedStatements, tx.Visitor().VisitEachChild(node.AsNode()))
+	}
+	tx.hoistedStatements = tx.appendExportsOfHoistedDeclaration(tx.hoistedStatements, node.AsNode())
+	return nil
+}
+
+// Visits a ClassDeclaration, hoisting its name to the outer module body function.
+func (tx *SystemModuleTransformer) visitTopLevelNestedClassDeclaration(node *ast.ClassDeclaration) *ast.Node {
+	// Hoist the name of the class declaration to the outer module body function.
+	tx.EmitContext().AddVariableDeclaration(tx.Factory().GetLocalName(node.AsNode()))
+
+	// Rewrite the class declaration into an assignment of a class expression.
+	classExpression := tx.Factory().NewClassExpression(
+		tx.Visitor().VisitModifiers(transformers.ExtractModifiers(tx.EmitContext(), node.Modifiers(), ^ast.ModifierFlagsExportDefault)),
+		node.Name(),
+		nil, /*typeParameters*/
+		tx.Visitor().VisitNodes(node.HeritageClauses),
+		tx.Visitor().VisitNodes(node.Members),
+	)
+	classExpression.Loc = node.Loc
+	statement := tx.Factory().NewExpressionStatement(
+		tx.Factory().NewAssignmentExpression(tx.Factory().GetLocalName(node.AsNode()), classExpression),
+	)
+	statement.Loc = node.Loc
+	tx.EmitContext().AssignCommentAndSourceMapRanges(statement, node.AsNode())
+
+	statements := []*ast.Statement{statement}
+	statements = tx.appendExportsOfHoistedDeclaration(statements, node.AsNode())
+	return transformers.SingleOrMany(statements, tx.Factory())
+}
+
+// Visits a variable statement, hoisting declared names to the top-level module body. Each declaration is rewritten
+// into an assignment expression.
+func (tx *SystemModuleTransformer) visitTopLevelNestedVariableStatement(node *ast.VariableStatement) *ast.Node {
+	if !tx.shouldHoistVariableDeclarationList(node.DeclarationList) {
+		return tx.Visitor().VisitEachChild(node.AsNode())
+	}
+
+	var statements []*ast.Statement
+	var expressions []*ast.Expression
+	isExportedDeclaration := ast.HasSyntacticModifier(node.AsNode(), ast.ModifierFlagsExport)
+	for _, variable := range node.DeclarationList.AsVariableDeclarationList().Declarations.Nodes {
+		if variable.Initializer() != nil {
+			expressions = append(expressions, tx.transformInitializedVariable(variable.AsVariableDeclaration(), isExportedDeclaration))
+		} else {
+			tx.hoistBindingElement(variable)
+		}
+	}
+
+	if len(expressions) > 0 {
+		statement := tx.Factory().NewExpressionStatement(tx.Factory().InlineExpressions(expressions))
+		statement.Loc = node.Loc
+		tx.EmitContext().AssignCommentAndSourceMapRanges(statement, node.AsNode())
+		statements = append(statements, statement)
+	}
+
+	statements = tx.appendExportsOfVariableStatement(statements, node)
+	if len(statements) == 0 {
+		return nil
+	}
+	return transformers.SingleOrMany(statements, tx.Factory())
+}
+
+// Hoists the declared names of a VariableDeclaration or BindingElement.
+func (tx *SystemModuleTransformer) hoistBindingElement(node *ast.Node /*VariableDeclaration | BindingElement*/) {
+	name := node.Name()
+	if ast.IsBindingPattern(name) {
+		for _, element := range name.AsBindingPattern().Elements.Nodes {
+			if element.Name() != nil {
+				tx.hoistBindingElement(element)
+			}
+		}
+	} else {
+		tx.EmitContext().AddVariableDeclaration(name.Clone(tx.Factory()))
+	}
+}
+
+// Determines whether to hoist a variable declaration list.
+func (tx *SystemModuleTransformer) shouldHoistVariableDeclarationList(node *ast.VariableDeclarationListNode) bool {
+	// hoist only non-block scoped declarations or block scoped declarations parented by source file
+	return tx.EmitContext().EmitFlags(node)&printer.EFNoHoisting == 0 &&
+		(ast.IsSourceFile(tx.enclosingBlockScopedContainer) || tx.EmitContext().MostOriginal(node).Flags&ast.NodeFlagsBlockScoped == 0)
+}
+
+// Transforms an initialized variable declaration into an assignment expression, hoisting its declared names.
+//
+//   - The `isExportedDeclaration` parameter indicates whether the variable is exported.
+func (tx *SystemModuleTransformer) transformInitializedVariable(node *ast.VariableDeclaration, isExportedDeclaration bool) *ast.Expression {
+	name := node.Name()
+	if ast.IsBindingPattern(name) {
+		// Any exported names in the pattern are updated by the destructuring assignment visitor.
+		tx.hoistBindingElement(node.AsNode())
+		if node.Initializer == nil {
+			return tx.assignmentPatternVisitor.VisitNode(transformers.ConvertBindingPatternToAssignmentPattern(tx.EmitContext(), name.AsBindingPattern()))
+		}
+		return tx.Visitor().VisitNode(transformers.ConvertVariableDeclarationToAssignmentExpression(tx.EmitContext(), node))
+	}
+
+	tx.EmitContext().AddVariableDeclaration(name.Clone(tx.Factory()))
+	if node.Initializer == nil {
+		return name.Clone(tx.Factory())
+	}
+
+	expression := tx.Factory().NewAssignmentExpression(name.Clone(tx.Factory()), tx.Visitor().VisitNode(node.Initializer))
+	expression.Loc = node.Loc
+	if isExportedDeclaration {
+		expression = tx.createExportExpression(name, expression)
+	}
+	return expression
+}
+
+// Visits the initializer of a `for`, `for..in`, or `for..of` statement, hoisting any `var` declarations.
+func (tx *SystemModuleTransformer) visitForInitializer(node *ast.ForInitializer) *ast.ForInitializer {
+	if node == nil || !ast.IsVariableDeclarationList(node) || !tx.shouldHoistVariableDeclarationList(node) {
+		return tx.discardedValueVisitor.VisitNode(node)
+	}
+
+	var expressions []*ast.Expression
+	for _, variable := range node.AsVariableDeclarationList().Declarations.Nodes {
+		expressions = append(expressions, tx.transformInitializedVariable(variable.AsVariableDeclaration(), false /*isExportedDeclaration*/))
+	}
+	if len(expressions) == 0 {
+		return nil
+	}
+	return tx.Factory().InlineExpressions(expressions)
+}
+
+// Visits a top-level nested `for` statement as it may contain `var` declarations that are hoisted.
+func (tx *SystemModuleTransformer) visitTopLevelNestedForStatement(node *ast.ForStatement) *ast.Node {
+	savedEnclosingBlockScopedContainer := tx.enclosingBlockScopedContainer
+	tx.enclosingBlockScopedContainer = node.AsNode()
+	defer func() { tx.enclosingBlockScopedContainer = savedEnclosingBlockScopedContainer }()
+
+	return tx.Factory().UpdateForStatement(
+		node,
+		tx.visitForInitializer(node.Initializer),
+		tx.Visitor().VisitNode(node.Condition),
+		tx.discardedValueVisitor.VisitNode(node.Incrementor),
+		tx.EmitContext().VisitIterationBody(node.Statement, tx.topLevelNestedVisitor),
+	)
+}
+
+// Visits a top-level nested `for..in` or `for..of` statement as it may contain `var` declarations that are hoisted.
+func (tx *SystemModuleTransformer) visitTopLevelNestedForInOrOfStatement(node *ast.ForInOrOfStatement) *ast.Node {
+	savedEnclosingBlockScopedContainer := tx.enclosingBlockScopedContainer
+	tx.enclosingBlockScopedContainer = node.AsNode()
+	defer func() { tx.enclosingBlockScopedContainer = savedEnclosingBlockScopedContainer }()
+
+	return tx.Factory().UpdateForInOrOfStatement(
+		node,
+		node.AwaitModifier,
+		tx.visitForInitializer(node.Initializer),
+		tx.Visitor().VisitNode(node.Expression),
+		tx.EmitContext().VisitIterationBody(node.Statement, tx.topLevelNestedVisitor),
+	)
+}
+
+// Visits a top-level nested `do` statement as it may contain `var` declarations that are hoisted.
+func (tx *SystemModuleTransformer) visitTopLevelNestedDoStatement(node *ast.DoStatement) *ast.Node {
+	return tx.Factory().UpdateDoStatement(
+		node,
+		tx.EmitContext().VisitIterationBody(node.Statement, tx.topLevelNestedVisitor),
+		tx.Visitor().VisitNode(node.Expression),
+	)
+}
+
+// Visits a top-level nested `while` statement as it may contain `var` declarations that are hoisted.
+func (tx *SystemModuleTransformer) visitTopLevelNestedWhileStatement(node *ast.WhileStatement) *ast.Node {
+	return tx.Factory().UpdateWhileStatement(
+		node,
+		tx.Visitor().VisitNode(node.Expression),
+		tx.EmitContext().VisitIterationBody(node.Statement, tx.topLevelNestedVisitor),
+	)
+}
+
+// Visits a top-level nested labeled statement as it may contain `var` declarations that are hoisted.
+func (tx *SystemModuleTransformer) visitTopLevelNestedLabeledStatement(node *ast.LabeledStatement) *ast.Node {
+	return tx.Factory().UpdateLabeledStatement(
+		node,
+		node.Label,
+		tx.topLevelNestedVisitor.VisitEmbeddedStatement(node.Statement),
+	)
+}
+
+// Visits a top-level nested `with` statement as it may contain `var` declarations that are hoisted.
+func (tx *SystemModuleTransformer) visitTopLevelNestedWithStatement(node *ast.WithStatement) *ast.Node {
+	return tx.Factory().UpdateWithStatement(
+		node,
+		tx.Visitor().VisitNode(node.Expression),
+		tx.topLevelNestedVisitor.VisitEmbeddedStatement(node.Statement),
+	)
+}
+
+// Visits a top-level nested `if` statement as it may contain `var` declarations that are hoisted.
+func (tx *SystemModuleTransformer) visitTopLevelNestedIfStatement(node *ast.IfStatement) *ast.Node {
+	return tx.Factory().UpdateIfStatement(
+		node,
+		tx.Visitor().VisitNode(node.Expression),
+		tx.topLevelNestedVisitor.VisitEmbeddedStatement(node.ThenStatement),
+		tx.topLevelNestedVisitor.VisitEmbeddedStatement(node.ElseStatement),
+	)
+}
+
+// Visits a top-level nested `switch` statement as it may contain `var` declarations that are hoisted.
+func (tx *SystemModuleTransformer) visitTopLevelNestedSwitchStatement(node *ast.SwitchStatement) *ast.Node {
+	return tx.Factory().UpdateSwitchStatement(
+		node,
+		tx.Visitor().VisitNode(node.Expression),
+		tx.topLevelNestedVisitor.VisitNode(node.CaseBlock),
+	)
+}
+
+// Visits a top-level nested case block as it may contain `var` declarations that are hoisted.
+func (tx *SystemModuleTransformer) visitTopLevelNestedCaseBlock(node *ast.CaseBlock) *ast.Node {
+	savedEnclosingBlockScopedContainer := tx.enclosingBlockScopedContainer
+	tx.enclosingBlockScopedContainer = node.AsNode()
+	defer func() { tx.enclosingBlockScopedContainer = savedEnclosingBlockScopedContainer }()
+
+	return tx.topLevelNestedVisitor.VisitEachChild(node.AsNode())
+}
+
+// Visits a top-level nested `case` or `default` clause as it may contain `var` declarations that are hoisted.
+func (tx *SystemModuleTransformer) visitTopLevelNestedCaseOrDefaultClause(node *ast.CaseOrDefaultClause) *ast.Node {
+	return tx.Factory().UpdateCaseOrDefaultClause(
+		node,
+		tx.Visitor().VisitNode(node.Expression),
+		tx.topLevelNestedVisitor.VisitNodes(node.Statements),
+	)
+}
+
+// Visits a top-level nested `try` statement as it may contain `var` declarations that are hoisted.
+func (tx *SystemModuleTransformer) visitTopLevelNestedTryStatement(node *ast.TryStatement) *ast.Node {
+	return tx.topLevelNestedVisitor.VisitEachChild(node.AsNode())
+}
+
+// Visits a top-level nested `catch` clause as it may contain `var` declarations that are hoisted.
+func (tx *SystemModuleTransformer) visitTopLevelNestedCatchClause(node *ast.CatchClause) *ast.Node {
+	savedEnclosingBlockScopedContainer := tx.enclosingBlockScopedContainer
+	tx.enclosingBlockScopedContainer = node.Block
+	defer func() { tx.enclosingBlockScopedContainer = savedEnclosingBlockScopedContainer }()
+
+	return tx.Factory().UpdateCatchClause(
+		node,
+		node.VariableDeclaration,
+		tx.topLevelNestedVisitor.VisitNode(node.Block),
+	)
+}
+
+// Visits a top-level nested block as it may contain `var` declarations that are hoisted.
+func (tx *SystemModuleTransformer) visitTopLevelNestedBlock(node *ast.Block) *ast.Node {
+	savedEnclosingBlockScopedContainer := tx.enclosingBlockScopedContainer
+	tx.enclosingBlockScopedContainer = node.AsNode()
+	defer func() { tx.enclosingBlockScopedContainer = savedEnclosingBlockScopedContainer }()
+
+	return tx.topLevelNestedVisitor.VisitEachChild(node.AsNode())
+}
+
+func (tx *SystemModuleTransformer) visitForStatement(node *ast.ForStatement) *ast.Node {
+	return tx.Factory().UpdateForStatement(
+		node,
+		tx.discardedValueVisitor.VisitNode(node.Initializer),
+		tx.Visitor().VisitNode(node.Condition),
+		tx.discardedValueVisitor.VisitNode(node.Incrementor),
+		tx.EmitContext().VisitIterationBody(node.Statement, tx.Visitor()),
+	)
+}
+
+func (tx *SystemModuleTransformer) visitForInOrOfStatement(node *ast.ForInOrOfStatement) *ast.Node {
+	return tx.Factory().UpdateForInOrOfStatement(
+		node,
+		node.AwaitModifier,
+		tx.discardedValueVisitor.VisitNode(node.Initializer),
+		tx.Visitor().VisitNode(node.Expression),
+		tx.EmitContext().VisitIterationBody(node.Statement, tx.Visitor()),
+	)
+}
+
+// Visits an expression statement whose value will be discarded at runtime.
+func (tx *SystemModuleTransformer) visitExpressionStatement(node *ast.ExpressionStatement) *ast.Node {
+	return tx.discardedValueVisitor.VisitEachChild(node.AsNode())
+}
+
+// Visits a `void` expression whose value will be discarded at runtime.
+func (tx *SystemModuleTransformer) visitVoidExpression(node *ast.VoidExpression) *ast.Node {
+	return tx.discardedValueVisitor.VisitEachChild(node.AsNode())
+}
+
+// Visits a parenthesized expression whose value may be discarded at runtime.
+func (tx *SystemModuleTransformer) visitParenthesizedExpression(node *ast.ParenthesizedExpression, resultIsDiscarded bool) *ast.Node {
+	expression := core.IfElse(resultIsDiscarded, tx.discardedValueVisitor, tx.Visitor()).VisitNode(node.Expression)
+	return tx.Factory().UpdateParenthesizedExpression(node, expression)
+}
+
+// Visits a partially emitted expression whose value may be discarded at runtime.
+func (tx *SystemModuleTransformer) visitPartiallyEmittedExpression(node *ast.PartiallyEmittedExpression, resultIsDiscarded bool) *ast.Node {
+	expression := core.IfElse(resultIsDiscarded, tx.discardedValueVisitor, tx.Visitor()).VisitNode(node.Expression)
+	return tx.Factory().UpdatePartiallyEmittedExpression(node, expression)
+}
+
+// Visits a binary expression whose value may be discarded, or which might contain an assignment to an exported
+// identifier.
+func (tx *SystemModuleTransformer) visitBinaryExpression(node *ast.BinaryExpression, resultIsDiscarded bool) *ast.Node {
+	if ast.IsAssignmentExpression(node.AsNode(), false /*excludeCompoundAssignment*/) {
+		return tx.visitAssignmentExpression(node)
+	}
+
+	if ast.IsCommaExpression(node.AsNode()) {
+		left := tx.discardedValueVisitor.VisitNode(node.Left)
+		right := core.IfElse(resultIsDiscarded, tx.discardedValueVisitor, tx.Visitor()).VisitNode(node.Right)
+		return tx.Factory().UpdateBinaryExpression(node, nil /*modifiers*/, left, nil /*typeNode*/, node.OperatorToken, right)
+	}
+
+	return tx.Visitor().VisitEachChild(node.AsNode())
+}
+
+func (tx *SystemModuleTransformer) visitAssignmentExpression(node *ast.BinaryExpression) *ast.Node {
+	if ast.IsDestructuringAssignment(node.AsNode()) {
+		return tx.Factory().UpdateBinaryExpression(
+			node,
+			nil, /*modifiers*/
+			tx.assignmentPatternVisitor.VisitNode(node.Left),
+			nil, /*typeNode*/
+			node.OperatorToken,
+			tx.Visitor().VisitNode(node.Right),
+		)
+	}
+
+	// When we see an assignment expression whose left-hand side is an exported symbol,
+	// we should ensure all exports of that symbol are updated with the correct value.
+	//
+	// - We do not transform generated identifiers unless they are file-level reserved names.
+	// - We do not transform identifiers tagged with the LocalName flag.
+	// - We only transform identifiers that are exported at the top level.
+	if ast.IsIdentifier(node.Left) &&
+		(!transformers.IsGeneratedIdentifier(tx.EmitContext(), node.Left) || isFileLevelReservedGeneratedIdentifier(tx.EmitContext(), node.Left)) &&
+		!transformers.IsLocalName(tx.EmitContext(), node.Left) {
+		exportedNames := tx.getExports(node.Left)
+		if len(exportedNames) > 0 {
+			// For each additional export of the declaration, apply an export assignment.
+			expression := tx.Visitor().VisitEachChild(node.AsNode())
+			for _, exportName := range exportedNames {
+				expression = tx.createExportExpression(exportName, expression)
+			}
+			return expression
+		}
+	}
+
+	return tx.Visitor().VisitEachChild(node.AsNode())
+}
+
+func (tx *SystemModuleTransformer) visitAssignmentProperty(node *ast.PropertyAssignment) *ast.Node {
+	return tx.Factory().UpdatePropertyAssignment(
+		node,
+		nil, /*modifiers*/
+		tx.Visitor().VisitNode(node.Name()),
+		nil, /*postfixToken*/
+		nil, /*typeNode*/
+		tx.assignmentPatternVisitor.VisitNode(node.Initializer),
+	)
+}
+
+func (tx *SystemModuleTransformer) visitShorthandAssignmentProperty(node *ast.ShorthandPropertyAssignment) *ast.Node {
+	target := tx.visitDestructuringAssignmentTargetNoStack(node.Name())
+	if ast.IsIdentifier(target) {
+		return tx.Factory().UpdateShorthandPropertyAssignment(
+			node,
+			nil, /*modifiers*/
+			target,
+			nil, /*postfixToken*/
+			nil, /*typeNode*/
+			node.EqualsToken,
+			tx.Visitor().VisitNode(node.ObjectAssignmentInitializer),
+		)
+	}
+	if node.ObjectAssignmentInitializer != nil {
+		equalsToken := node.EqualsToken
+		if equalsToken == nil {
+			equalsToken = tx.Factory().NewToken(ast.KindEqualsToken)
+		}
+		target = tx.Factory().NewBinaryExpression(
+			nil, /*modifiers*/
+			target,
+			nil, /*typeNode*/
+			equalsToken,
+			tx.Visitor().VisitNode(node.ObjectAssignmentInitializer),
+		)
+	}
+	updated := tx.Factory().NewPropertyAssignment(
+		nil, /*modifiers*/
+		node.Name(),
+		nil, /*postfixToken*/
+		nil, /*typeNode*/
+		target,
+	)
+	tx.EmitContext().SetOriginal(updated, node.AsNode())
+	tx.EmitContext().AssignCommentAndSourceMapRanges(updated, node.AsNode())
+	return updated
+}
+
+func (tx *SystemModuleTransformer) visitAssignmentRestProperty(node *ast.SpreadAssignment) *ast.Node {
+	return tx.Factory().UpdateSpreadAssignment(
+		node,
+		tx.visitDestructuringAssignmentTarget(node.Expression),
+	)
+}
+
+func (tx *SystemModuleTransformer) visitAssignmentRestElement(node *ast.SpreadElement) *ast.Node {
+	return tx.Factory().UpdateSpreadElement(
+		node,
+		tx.visitDestructuringAssignmentTarget(node.Expression),
+	)
+}
+
+func (tx *SystemModuleTransformer) visitAssignmentElement(node *ast.Node) *ast.Node {
+	if ast.IsBinaryExpression(node) {
+		n := node.AsBinaryExpression()
+		if n.OperatorToken.Kind == ast.KindEqualsToken {
+			return tx.Factory().UpdateBinaryExpression(
+				n,
+				nil, /*modifiers*/
+				tx.visitDestructuringAssignmentTarget(n.Left),
+				nil, /*typeNode*/
+				n.OperatorToken,
+				tx.Visitor().VisitNode(n.Right),
+			)
+		}
+	}
+
+	return tx.visitDestructuringAssignmentTargetNoStack(node)
+}
+
+func (tx *SystemModuleTransformer) visitDestructuringAssignmentTarget(node *ast.Node) *ast.Node {
+	grandparentNode := tx.pushNode(node)
+	defer tx.popNode(grandparentNode)
+
+	switch node.Kind {
+	case ast.KindObjectLiteralExpression, ast.KindArrayLiteralExpression:
+		node = tx.visitAssignmentPatternNoStack(node)
+	default:
+		node = tx.visitDestructuringAssignmentTargetNoStack(node)
+	}
+	return node
+}
+
+func (tx *SystemModuleTransformer) visitDestructuringAssignmentTargetNoStack(node *ast.Node) *ast.Node {
+	if ast.IsIdentifier(node) &&
+		(!transformers.IsGeneratedIdentifier(tx.EmitContext(), node) || isFileLevelReservedGeneratedIdentifier(tx.EmitContext(), node)) &&
+		!transformers.IsLocalName(tx.EmitContext(), node) {
+		expression := tx.visitExpressionIdentifier(node)
+		exportedNames := tx.getExports(node)
+		if len(exportedNames) > 0 {
+			// transforms:
+			//  var x;
+			//  export { x }
+			//  { x: x } = y
+			// to:
+			//  { x: { set value(v) { exports_1("x", x = v); } }.value } = y
+
+			value := tx.Factory().NewUniqueNameEx("value", printer.AutoGenerateOptions{
+				Flags: printer.GeneratedIdentifierFlagsOptimistic,
+			})
+			expression = tx.Factory().NewAssignmentExpression(expression, value)
+
+			for _, exportName := range exportedNames {
+				expression = tx.createExportExpression(exportName, expression)
+			}
+
+			statement := tx.Factory().NewExpressionStatement(expression)
+			statementList := tx.Factory().NewNodeList([]*ast.Node{statement})
+			param := tx.Factory().NewParameterDeclaration(
+				nil, /*modifiers*/
+				nil, /*dotDotDotToken*/
+				value,
+				nil, /*questionToken*/
+				nil, /*type*/
+				nil, /*initializer*/
+			)
+			valueSetter := tx.Factory().NewSetAccessorDeclaration(
+				nil, /*modifiers*/
+				tx.Factory().NewIdentifier("value"),
+				nil, /*typeParameters*/
+				tx.Factory().NewNodeList([]*ast.Node{param}),
+				nil, /*returnType*/
+				tx.Factory().NewBlock(statementList, false /*multiLine*/),
+			)
+			propertyList := tx.Factory().NewNodeList([]*ast.Node{valueSetter})
+			expression = tx.Factory().NewObjectLiteralExpression(propertyList, false /*multiLine*/)
+			expression = tx.Factory().NewPropertyAccessExpression(expression, nil /*questionDotToken*/, tx.Factory().NewIdentifier("value"), ast.NodeFlagsNone)
+		}
+		return expression
+	}
+
+	return tx.visitNoStack(node, false /*resultIsDiscarded*/)
+}
+
+// Visits a prefix unary expression that might modify an exported identifier.
+func (tx *SystemModuleTransformer) visitPrefixUnaryExpression(node *ast.PrefixUnaryExpression) *ast.Node {
+	// When we see a prefix increment expression whose operand is an exported
+	// symbol, we should ensure all exports of that symbol are updated with the correct
+	// value.
+	//
+	// - We do not transform identifiers tagged with the LocalName flag.
+	// - We only transform identifiers that are exported at the top level.
+	if (node.Operator == ast.KindPlusPlusToken || node.Operator == ast.KindMinusMinusToken) &&
+		ast.IsIdentifier(node.Operand) &&
+		!transformers.IsLocalName(tx.EmitContext(), node.Operand) {
+		exportedNames := tx.getExports(node.Operand)
+		if len(exportedNames) > 0 {
+			// given:
+			//   export var x = 0;
+			//   ++x;
+			// emits:
+			//   exports_1("x", ++x);
+
+			expression := tx.Factory().UpdatePrefixUnaryExpression(node, tx.Visitor().VisitNode(node.Operand))
+			for _, exportName := range exportedNames {
+				expression = tx.createExportExpression(exportName, expression)
+				tx.EmitContext().AssignCommentAndSourceMapRanges(expression, node.AsNode())
+			}
+			return expression
+		}
+	}
+	return tx.Visitor().VisitEachChild(node.AsNode())
+}
+
+// Visits a postfix unary expression that might modify an exported identifier.
+func (tx *SystemModuleTransformer) visitPostfixUnaryExpression(node *ast.PostfixUnaryExpression, resultIsDiscarded bool) *ast.Node {
+	// When we see a postfix increment expression whose operand is an exported
+	// symbol, we should ensure all exports of that symbol are updated with the correct
+	// value.
+	//
+	// - We do not transform identifiers tagged with the LocalName flag.
+	// - We only transform identifiers that are exported at the top level.
+	if (node.Operator == ast.KindPlusPlusToken || node.Operator == ast.KindMinusMinusToken) &&
+		ast.IsIdentifier(node.Operand) &&
+		!transformers.IsLocalName(tx.EmitContext(), node.Operand) {
+		exportedNames := tx.getExports(node.Operand)
+		if len(exportedNames) > 0 {
+			// given (value is discarded):
+			//   export var x = 0;
+			//   x++;
+			// emits:
+			//   exports_1("x", (x++, x));
+			//
+			// given (value is not discarded):
+			//   export var x = 0;
+			//   y = x++;
+			// emits:
+			//   var _a;
+			//   y = (exports_1("x", (_a = x++, x)), _a);
+
+			var temp *ast.IdentifierNode
+			expression := tx.Factory().UpdatePostfixUnaryExpression(node, tx.Visitor().VisitNode(node.Operand))
+			if !resultIsDiscarded {
+				temp = tx.Factory().NewTempVariable()
+				tx.EmitContext().AddVariableDeclaration(temp)
+
+				expression = tx.Factory().NewAssignmentExpression(temp, expression)
+				tx.EmitContext().AssignCommentAndSourceMapRanges(expression, node.AsNode())
+			}
+
+			expression = tx.Factory().NewCommaExpression(expression, node.Operand.Clone(tx.Factory()))
+			tx.EmitContext().AssignCommentAndSourceMapRanges(expression, node.AsNode())
+
+			for _, exportName := range exportedNames {
+				expression = tx.createExportExpression(exportName, expression)
+				tx.EmitContext().AssignCommentAndSourceMapRanges(expression, node.AsNode())
+			}
+
+			if temp != nil {
+				expression = tx.Factory().NewCommaExpression(expression, temp.AsNode())
+				tx.EmitContext().AssignCommentAndSourceMapRanges(expression, node.AsNode())
+			}
+
+			return expression
+		}
+	}
+
+	return tx.Visitor().VisitEachChild(node.AsNode())
+}
+
+// Visits a call expression that might be an `import()` call that must be rewritten to use the context object.
+func (tx *SystemModuleTransformer) visitCallExpression(node *ast.CallExpression) *ast.Node {
+	if !ast.IsImportCall(node.AsNode()) {
+		return tx.Visitor().VisitEachChild(node.AsNode())
+	}
+
+	// import("./blah")
+	// emit as
+	// System.register([], function (exports_1, context_1) {
+	//     ...
+	//     context_1.import("./blah");
+	// });
+	var argument *ast.Expression
+	if len(node.Arguments.Nodes) > 0 {
+		argument = tx.Visitor().VisitNode(node.Arguments.Nodes[0])
+	}
+	if externalModuleName := getExternalModuleNameLiteral(tx.Factory(), node.AsNode(), tx.currentSourceFile, nil /*host*/, nil /*resolver*/, tx.compilerOptions); externalModuleName != nil &&
+		(argument == nil || !ast.IsStringLiteral(argument) || argument.Text() != externalModuleName.Text()) {
+		argument = externalModuleName
+	}
+
+	var arguments []*ast.Expression
+	if argument != nil {
+		arguments = append(arguments, argument)
+	}
+	updated := tx.Factory().NewCallExpression(
+		tx.Factory().NewPropertyAccessExpression(
+			tx.contextObject,
+			nil, /*questionDotToken*/
+			tx.Factory().NewIdentifier("import"),
+			ast.NodeFlagsNone,
+		),
+		nil, /*questionDotToken*/
+		nil, /*typeArguments*/
+		tx.Factory().NewNodeList(arguments),
+		ast.NodeFlagsNone,
+	)
+	updated.Loc = node.Loc
+	tx.EmitContext().SetOriginal(updated, node.AsNode())
+	return updated
+}
+
+// Visits a meta-property that might be `import.meta`, which is rewritten to use the context object.
+func (tx *SystemModuleTransformer) visitMetaProperty(node *ast.MetaProperty) *ast.Node {
+	if ast.IsImportMeta(node.AsNode()) {
+		// emits:
+		//  context_1.meta
+		return tx.Factory().NewPropertyAccessExpression(
+			tx.contextObject,
+			nil, /*questionDotToken*/
+			tx.Factory().NewIdentifier("meta"),
+			ast.NodeFlagsNone,
+		)
+	}
+	return node.AsNode()
+}
+
+// Visits a shorthand property assignment that might reference an imported symbol.
+func (tx *SystemModuleTransformer) visitShorthandPropertyAssignment(node *ast.ShorthandPropertyAssignment) *ast.Node {
+	name := node.Name()
+	importedName := tx.visitExpressionIdentifier(name)
+	if importedName != name {
+		// A shorthand property with an assignment initializer is probably part of a
+		// destructuring assignment
+		expression := importedName
+		if node.ObjectAssignmentInitializer != nil {
+			expression = tx.Factory().NewAssignmentExpression(
+				expression,
+				tx.Visitor().VisitNode(node.ObjectAssignmentInitializer),
+			)
+		}
+		assignment := tx.Factory().NewPropertyAssignment(nil /*modifiers*/, name, nil /*postfixToken*/, nil /*typeNode*/, expression)
+		assignment.Loc = node.Loc
+		tx.EmitContext().AssignCommentAndSourceMapRanges(assignment, node.AsNode())
+		return assignment
+	}
+	return tx.Factory().UpdateShorthandPropertyAssignment(node,
+		nil, /*modifiers*/
+		importedName,
+		nil, /*postfixToken*/
+		nil, /*typeNode*/
+		node.EqualsToken,
+		tx.Visitor().VisitNode(node.ObjectAssignmentInitializer),
+	)
+}
+
+// Visits an identifier that, if it is in an expression position, might reference an imported symbol.
+func (tx *SystemModuleTransformer) visitIdentifier(node *ast.IdentifierNode) *ast.Node {
+	if transformers.IsIdentifierReference(node, tx.parentNode) {
+		return tx.visitExpressionIdentifier(node)
+	}
+	return node
+}
+
+// Visits an identifier in an expression position that might reference an imported symbol. Unlike CommonJS, exported
+// declarations remain local variables in a System module, so only imported names need to be rewritten.
+func (tx *SystemModuleTransformer) visitExpressionIdentifier(node *ast.IdentifierNode) *ast.Node {
+	if info := tx.EmitContext().GetAutoGenerateInfo(node); !(info != nil && !info.Flags.HasAllowNameSubstitution()) &&
+		!transformers.IsHelperName(tx.EmitContext(), node) &&
+		!transformers.IsLocalName(tx.EmitContext(), node) &&
+		!isDeclarationNameOfEnumOrNamespace(tx.EmitContext(), node) {
+		importDeclaration := tx.resolver.GetReferencedImportDeclaration(tx.EmitContext().MostOriginal(node))
+		if importDeclaration != nil {
+			if ast.IsImportClause(importDeclaration) {
+				reference := tx.Factory().NewPropertyAccessExpression(
+					tx.Factory().NewGeneratedNameForNode(importDeclaration.Parent),
+					nil, /*questionDotToken*/
+					tx.Factory().NewIdentifier("default"),
+					ast.NodeFlagsNone,
+				)
+				tx.EmitContext().AssignCommentAndSourceMapRanges(reference, node)
+				reference.Loc = node.Loc
+				return reference
+			}
+			if ast.IsImportSpecifier(importDeclaration) {
+				name := importDeclaration.AsImportSpecifier().PropertyNameOrName()
+				decl := ast.FindAncestor(importDeclaration, ast.IsImportDeclaration)
+				target := tx.Factory().NewGeneratedNameForNode(core.Coalesce(decl, importDeclaration))
+				var reference *ast.Node
+				if ast.IsStringLiteral(name) {
+					reference = tx.Factory().NewElementAccessExpression(
+						target,
+						nil, /*questionDotToken*/
+						tx.Factory().NewStringLiteralFromNode(name),
+						ast.NodeFlagsNone,
+					)
+				} else {
+					referenceName := name.Clone(tx.Factory())
+					tx.EmitContext().AddEmitFlags(referenceName, printer.EFNoSourceMap|printer.EFNoComments)
+					reference = tx.Factory().NewPropertyAccessExpression(
+						target,
+						nil, /*questionDotToken*/
+						referenceName,
+						ast.NodeFlagsNone,
+					)
+				}
+				tx.EmitContext().AssignCommentAndSourceMapRanges(reference, node)
+				reference.Loc = node.Loc
+				return reference
+			}
+		}
+	}
+	return node
+}
+
+// Gets the exported names of an identifier, if it is exported.
+func (tx *SystemModuleTransformer) getExports(name *ast.IdentifierNode) []*ast.ModuleExportName {
+	if !transformers.IsGeneratedIdentifier(tx.EmitContext(), name) {
+		original := tx.EmitContext().MostOriginal(name)
+		importDeclaration := tx.resolver.GetReferencedImportDeclaration(original)
+		if importDeclaration != nil {
+			return tx.currentModuleInfo.exportedBindings.Get(importDeclaration)
+		}
+
+		var seen collections.Set[string]
+		var exportedNames []*ast.ModuleExportName
+		addExportedName := func(exportName *ast.ModuleExportName) {
+			if !seen.Has(exportName.Text()) {
+				seen.Add(exportName.Text())
+				exportedNames = append(exportedNames, exportName)
+			}
+		}
+
+		declarations := tx.resolver.GetReferencedValueDeclarations(original)
+		for _, declaration := range declarations {
+			// Declarations exported with an `export` modifier remain local variables that must be published through
+			// the export function.
+			if exportContainer := tx.resolver.GetReferencedExportContainer(original, false /*prefixLocals*/); exportContainer != nil && ast.IsSourceFile(exportContainer) {
+				if declarationName := declaration.Name(); declarationName != nil && ast.IsIdentifier(declarationName) {
+					addExportedName(declarationName)
+				}
+			}
+			for _, binding := range tx.currentModuleInfo.exportedBindings.Get(declaration) {
+				addExportedName(binding)
+			}
+		}
+		return exportedNames
+	} else if isFileLevelReservedGeneratedIdentifier(tx.EmitContext(), name) {
+		exportSpecifiers := tx.currentModuleInfo.exportSpecifiers.Get(name.Text())
+		if exportSpecifiers != nil {
+			var exportedNames []*ast.ModuleExportName
+			for _, exportSpecifier := range exportSpecifiers {
+				exportedNames = append(exportedNames, exportSpecifier.Name())
+			}
+			return exportedNames
+		}
+	}
+	return nil
+}
+
+// Appends the exports of an ImportDeclaration to a statement list, returning the statement list.
+//
+//   - The `statements` parameter is a statement list to which the down-level export statements are to be appended.
+//   - The `decl` parameter is the declaration whose exports are to be recorded.
+func (tx *SystemModuleTransformer) appendExportsOfImportDeclaration(statements []*ast.Statement, decl *ast.ImportDeclaration) []*ast.Statement {
+	if tx.currentModuleInfo.exportEquals != nil {
+		return statements
+	}
+
+	importClause := decl.ImportClause
+	if importClause == nil {
+		return statements
+	}
+
+	if importClause.Name() != nil {
+		statements = tx.appendExportsOfDeclaration(statements, importClause, "" /*excludeName*/)
+	}
+
+	namedBindings := importClause.AsImportClause().NamedBindings
+	if namedBindings != nil {
+		switch namedBindings.Kind {
+		case ast.KindNamespaceImport:
+			statements = tx.appendExportsOfDeclaration(statements, namedBindings, "" /*excludeName*/)
+
+		case ast.KindNamedImports:
+			for _, importBinding := range namedBindings.AsNamedImports().Elements.Nodes {
+				statements = tx.appendExportsOfDeclaration(statements, importBinding, "" /*excludeName*/)
+			}
+		}
+	}
+
+	return statements
+}
+
+// Appends the exports of a VariableStatement to a statement list, returning the statement list.
+//
+//   - The `statements` parameter is a statement list to which the down-level export statements are to be appended.
+//   - The `node` parameter is the VariableStatement whose exports are to be recorded.
+func (tx *SystemModuleTransformer) appendExportsOfVariableStatement(statements []*ast.Statement, node *ast.VariableStatement) []*ast.Statement {
+	if tx.currentModuleInfo.exportEquals != nil {
+		return statements
+	}
+
+	for _, decl := range node.DeclarationList.AsVariableDeclarationList().Declarations.Nodes {
+		if decl.Initializer() != nil {
+			statements = tx.appendExportsOfBindingElement(statements, decl)
+		}
+	}
+
+	return statements
+}
+
+// Appends the exports of a VariableDeclaration or BindingElement to a statement list, returning the statement list.
+//
+//   - The `statements` parameter is a statement list to which the down-level export statements are to be appended.
+//   - The `decl` parameter is the declaration whose exports are to be recorded.
+func (tx *SystemModuleTransformer) appendExportsOfBindingElement(statements []*ast.Statement, decl *ast.Node /*VariableDeclaration | BindingElement*/) []*ast.Statement {
+	if tx.currentModuleInfo.exportEquals != nil {
+		return statements
+	}
+
+	if ast.IsBindingPattern(decl.Name()) {
+		for _, element := range decl.Name().AsBindingPattern().Elements.Nodes {
+			if element.Name() != nil {
+				statements = tx.appendExportsOfBindingElement(statements, element)
+			}
+		}
+	} else if !transformers.IsGeneratedIdentifier(tx.EmitContext(), decl.Name()) {
+		statements = tx.appendExportsOfDeclaration(statements, decl, "" /*excludeName*/)
+	}
+
+	return statements
+}
+
+// Appends the exports of a ClassDeclaration or FunctionDeclaration to a statement list, returning the statement list.
+//
+//   - The `statements` parameter is a statement list to which the down-level export statements are to be appended.
+//   - The `decl` parameter is the declaration whose exports are to be recorded.
+func (tx *SystemModuleTransformer) appendExportsOfHoistedDeclaration(statements []*ast.Statement, decl *ast.Declaration) []*ast.Statement {
+	if tx.currentModuleInfo.exportEquals != nil {
+		return statements
+	}
+
+	excludeName := ""
+	if ast.HasSyntacticModifier(decl, ast.ModifierFlagsExport) {
+		var exportName *ast.ModuleExportName
+		if ast.HasSyntacticModifier(decl, ast.ModifierFlagsDefault) {
+			exportName = tx.Factory().NewStringLiteral("default")
+		} else {
+			exportName = decl.Name()
+		}
+		statements = append(statements, tx.createExportStatement(exportName, tx.Factory().GetLocalName(decl), false /*allowComments*/))
+		excludeName = exportName.Text()
+	}
+
+	if decl.Name() != nil {
+		statements = tx.appendExportsOfDeclaration(statements, decl, excludeName)
+	}
+
+	return statements
+}
+
+// Appends the exports of a declaration to a statement list, returning the statement list.
+//
+//   - The `statements` parameter is a statement list to which the down-level export statements are to be appended.
+//   - The `decl` parameter is the declaration to export.
+//   - The `excludeName` parameter is the name of an export that has already been written, if any.
+func (tx *SystemModuleTransformer) appendExportsOfDeclaration(statements []*ast.Statement, decl *ast.Declaration, excludeName string) []*ast.Statement {
+	if tx.currentModuleInfo.exportEquals != nil {
+		return statements
+	}
+
+	if name := decl.Name(); tx.currentModuleInfo.exportSpecifiers.Len() > 0 && name != nil && ast.IsIdentifier(name) {
+		name = tx.Factory().GetDeclarationName(decl)
+		exportSpecifiers := tx.currentModuleInfo.exportSpecifiers.Get(name.Text())
+		if len(exportSpecifiers) > 0 {
+			exportValue := tx.visitExpressionIdentifier(name)
+			for _, exportSpecifier := range exportSpecifiers {
+				if exportSpecifier.Name().Text() != excludeName {
+					statements = append(statements, tx.createExportStatement(exportSpecifier.Name(), exportValue, false /*allowComments*/))
+				}
+			}
+		}
+	}
+
+	return statements
+}
+
+// Creates a call to the current file's export function to export a value.
+//
+//   - The `name` parameter is the bound name of the export.
+//   - The `value` parameter is the exported value.
+//   - The `allowComments` parameter indicates whether to emit comments for the statement.
+func (tx *SystemModuleTransformer) createExportStatement(name *ast.ModuleExportName, value *ast.Expression, allowComments bool) *ast.Statement {
+	statement := tx.Factory().NewExpressionStatement(tx.createExportExpression(name, value))
+	tx.EmitContext().AddEmitFlags(statement, printer.EFStartOnNewLine)
+	if !allowComments {
+		tx.EmitContext().AddEmitFlags(statement, printer.EFNoComments)
+	}
+	return statement
+}
+
+// Creates a call to the current file's export function to export a value.
+//
+//   - The `name` parameter is the bound name of the export.
+//   - The `value` parameter is the exported value.
+func (tx *SystemModuleTransformer) createExportExpression(name *ast.ModuleExportName, value *ast.Expression) *ast.Expression {
+	tx.EmitContext().AddEmitFlags(value, printer.EFNoComments)
+	expression := tx.createExportCall(tx.Factory().NewStringLiteralFromNode(name), value)
+	tx.EmitContext().AssignCommentAndSourceMapRanges(expression, value)
+	return expression
+}
+
+// Creates a call to the export function, i.e. `exports_1("name", value)`.
+func (tx *SystemModuleTransformer) createExportCall(name *ast.Expression, value *ast.Expression) *ast.Expression {
+	return tx.Factory().NewCallExpression(
+		tx.exportFunction,
+		nil, /*questionDotToken*/
+		nil, /*typeArguments*/
+		tx.Factory().NewNodeList([]*ast.Expression{name, value}),
+		ast.NodeFlagsNone,
+	)
+}
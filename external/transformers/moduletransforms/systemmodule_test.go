@@ -0,0 +1,462 @@
+package moduletransforms_test
+
+import (
+	"testing"
+
+	"github.com/pagpeter/typescript-go/external/binder"
+	"github.com/pagpeter/typescript-go/external/core"
+	"github.com/pagpeter/typescript-go/external/printer"
+	"github.com/pagpeter/typescript-go/external/testutil/emittestutil"
+	"github.com/pagpeter/typescript-go/external/testutil/parsetestutil"
+	"github.com/pagpeter/typescript-go/external/transformers/moduletransforms"
+	"github.com/pagpeter/typescript-go/external/transformers/tstransforms"
+)
+
+func TestSystemModuleTransformer(t *testing.T) {
+	t.Parallel()
+	data := []struct {
+		title   string
+		input   string
+		output  string
+		options *core.CompilerOptions
+	}{
+		{
+			title: "ImportDeclaration#1",
+			input: `import "other"`,
+			output: `System.register(["other"], function (exports_1, context_1) {
+    "use strict";
+    var __moduleName = context_1 && context_1.id;
+    return {
+        setters: [
+            function (_1) {
+            }
+        ],
+        execute: function () {
+        }
+    };
+});`,
+		},
+		{
+			title: "ImportDeclaration#2",
+			input: `import * as a from "other"; a;`,
+			output: `System.register(["other"], function (exports_1, context_1) {
+    "use strict";
+    var a;
+    var __moduleName = context_1 && context_1.id;
+    return {
+        setters: [
+            function (a_1) {
+                a = a_1;
+            }
+        ],
+        execute: function () {
+            a;
+        }
+    };
+});`,
+		},
+		{
+			title: "ImportDeclaration#3",
+			input: `import { a } from "other"; a;`,
+			output: `System.register(["other"], function (exports_1, context_1) {
+    "use strict";
+    var other_1;
+    var __moduleName = context_1 && context_1.id;
+    return {
+        setters: [
+            function (other_1_1) {
+                other_1 = other_1_1;
+            }
+        ],
+        execute: function () {
+            other_1.a;
+        }
+    };
+});`,
+		},
+		{
+			title: "ImportDeclaration#4",
+			input: `import a, { b } from "other"; a; b;`,
+			output: `System.register(["other"], function (exports_1, context_1) {
+    "use strict";
+    var other_1;
+    var __moduleName = context_1 && context_1.id;
+    return {
+        setters: [
+            function (other_1_1) {
+                other_1 = other_1_1;
+            }
+        ],
+        execute: function () {
+            other_1.default;
+            other_1.b;
+        }
+    };
+});`,
+		},
+		{
+			title: "ImportDeclaration#5",
+			input: `import { a } from "other"; export { a };`,
+			output: `System.register(["other"], function (exports_1, context_1) {
+    "use strict";
+    var other_1;
+    var __moduleName = context_1 && context_1.id;
+    return {
+        setters: [
+            function (other_1_1) {
+                other_1 = other_1_1;
+            }
+        ],
+        execute: function () {
+            exports_1("a", other_1.a);
+        }
+    };
+});`,
+		},
+		{
+			title: "ExportDeclaration#1",
+			input: `export * from "other";`,
+			output: `System.register(["other"], function (exports_1, context_1) {
+    "use strict";
+    var __moduleName = context_1 && context_1.id;
+    function exportStar_1(m) {
+        var exports = {};
+        for (var n in m) {
+            if (n !== "default") exports[n] = m[n];
+        }
+        exports_1(exports);
+    }
+    return {
+        setters: [
+            function (other_1_1) {
+                exportStar_1(other_1_1);
+            }
+        ],
+        execute: function () {
+        }
+    };
+});`,
+		},
+		{
+			title: "ExportDeclaration#2",
+			input: `export { a, b as c } from "other";`,
+			output: `System.register(["other"], function (exports_1, context_1) {
+    "use strict";
+    var __moduleName = context_1 && context_1.id;
+    return {
+        setters: [
+            function (other_1_1) {
+                exports_1({
+                    "a": other_1_1["a"],
+                    "c": other_1_1["b"]
+                });
+            }
+        ],
+        execute: function () {
+        }
+    };
+});`,
+		},
+		{
+			title: "ExportDeclaration#3",
+			input: `export * as ns from "other";`,
+			output: `System.register(["other"], function (exports_1, context_1) {
+    "use strict";
+    var __moduleName = context_1 && context_1.id;
+    return {
+        setters: [
+            function (ns_1) {
+                exports_1("ns", ns_1);
+            }
+        ],
+        execute: function () {
+        }
+    };
+});`,
+		},
+		{
+			title: "ExportDeclaration#4",
+			input: `export * from "other"; export var x = 1;`,
+			output: `System.register(["other"], function (exports_1, context_1) {
+    "use strict";
+    var x;
+    var __moduleName = context_1 && context_1.id;
+    var exportedNames_1 = {
+        "x": true
+    };
+    function exportStar_1(m) {
+        var exports = {};
+        for (var n in m) {
+            if (n !== "default" && !exportedNames_1.hasOwnProperty(n)) exports[n] = m[n];
+        }
+        exports_1(exports);
+    }
+    return {
+        setters: [
+            function (other_1_1) {
+                exportStar_1(other_1_1);
+            }
+        ],
+        execute: function () {
+            exports_1("x", x = 1);
+        }
+    };
+});`,
+		},
+		{
+			title: "ExportAssignment#1",
+			input: `export default 1;`,
+			output: `System.register([], function (exports_1, context_1) {
+    "use strict";
+    var __moduleName = context_1 && context_1.id;
+    return {
+        setters: [],
+        execute: function () {
+            exports_1("default", 1);
+        }
+    };
+});`,
+		},
+		{
+			title: "FunctionDeclaration#1",
+			input: `export function f() {}`,
+			output: `System.register([], function (exports_1, context_1) {
+    "use strict";
+    var __moduleName = context_1 && context_1.id;
+    function f() { }
+    exports_1("f", f);
+    return {
+        setters: [],
+        execute: function () {
+        }
+    };
+});`,
+		},
+		{
+			title: "FunctionDeclaration#2",
+			input: `export default function () {}`,
+			output: `System.register([], function (exports_1, context_1) {
+    "use strict";
+    var __moduleName = context_1 && context_1.id;
+    function default_1() { }
+    exports_1("default", default_1);
+    return {
+        setters: [],
+        execute: function () {
+        }
+    };
+});`,
+		},
+		{
+			title: "ClassDeclaration#1",
+			input: `export class C {}`,
+			output: `System.register([], function (exports_1, context_1) {
+    "use strict";
+    var C;
+    var __moduleName = context_1 && context_1.id;
+    return {
+        setters: [],
+        execute: function () {
+            C = class C {
+            };
+            exports_1("C", C);
+        }
+    };
+});`,
+		},
+		{
+			title: "VariableStatement#1",
+			input: `export var x = 1;`,
+			output: `System.register([], function (exports_1, context_1) {
+    "use strict";
+    var x;
+    var __moduleName = context_1 && context_1.id;
+    return {
+        setters: [],
+        execute: function () {
+            exports_1("x", x = 1);
+        }
+    };
+});`,
+		},
+		{
+			title: "VariableStatement#2",
+			input: `export let x = 1, y;`,
+			output: `System.register([], function (exports_1, context_1) {
+    "use strict";
+    var x, y;
+    var __moduleName = context_1 && context_1.id;
+    return {
+        setters: [],
+        execute: function () {
+            exports_1("x", x = 1);
+        }
+    };
+});`,
+		},
+		{
+			title: "VariableStatement#3",
+			input: `var x = 1; export { x };`,
+			output: `System.register([], function (exports_1, context_1) {
+    "use strict";
+    var x;
+    var __moduleName = context_1 && context_1.id;
+    return {
+        setters: [],
+        execute: function () {
+            x = 1;
+            exports_1("x", x);
+        }
+    };
+});`,
+		},
+		{
+			title: "VariableStatement#4",
+			input: `export var { a, b } = o;`,
+			output: `System.register([], function (exports_1, context_1) {
+    "use strict";
+    var a, b;
+    var __moduleName = context_1 && context_1.id;
+    return {
+        setters: [],
+        execute: function () {
+            ({ a: { set value(value) { exports_1("a", a = value); } }.value, b: { set value(value_1) { exports_1("b", b = value_1); } }.value } = o);
+        }
+    };
+});`,
+		},
+		{
+			title: "VariableStatement#5",
+			input: `export {}; if (c) { var x = 1; let y = 2; function f() {} }`,
+			output: `System.register([], function (exports_1, context_1) {
+    "use strict";
+    var x;
+    var __moduleName = context_1 && context_1.id;
+    function f() { }
+    return {
+        setters: [],
+        execute: function () {
+            if (c) {
+                x = 1;
+                let y = 2;
+            }
+        }
+    };
+});`,
+		},
+		{
+			title: "ForStatement#1",
+			input: `export {}; for (var i = 0; i < 1; i++) {}`,
+			output: `System.register([], function (exports_1, context_1) {
+    "use strict";
+    var i;
+    var __moduleName = context_1 && context_1.id;
+    return {
+        setters: [],
+        execute: function () {
+            for (i = 0; i < 1; i++) { }
+        }
+    };
+});`,
+		},
+		{
+			title: "ForInStatement#1",
+			input: `export {}; for (var k in o) {}`,
+			output: `System.register([], function (exports_1, context_1) {
+    "use strict";
+    var k;
+    var __moduleName = context_1 && context_1.id;
+    return {
+        setters: [],
+        execute: function () {
+            for (k in o) { }
+        }
+    };
+});`,
+		},
+		{
+			title: "AssignmentExpression#1",
+			input: `export var x = 1; x = 2;`,
+			output: `System.register([], function (exports_1, context_1) {
+    "use strict";
+    var x;
+    var __moduleName = context_1 && context_1.id;
+    return {
+        setters: [],
+        execute: function () {
+            exports_1("x", x = 1);
+            exports_1("x", x = 2);
+        }
+    };
+});`,
+		},
+		{
+			title: "PostfixUnaryExpression#1",
+			input: `export var x = 1; x++; y = x++;`,
+			output: `System.register([], function (exports_1, context_1) {
+    "use strict";
+    var x, _a;
+    var __moduleName = context_1 && context_1.id;
+    return {
+        setters: [],
+        execute: function () {
+            exports_1("x", x = 1);
+            exports_1("x", (x++, x));
+            y = (exports_1("x", (_a = x++, x)), _a);
+        }
+    };
+});`,
+		},
+		{
+			title: "ImportCall#1",
+			input: `export {}; import("./other");`,
+			output: `System.register([], function (exports_1, context_1) {
+    "use strict";
+    var __moduleName = context_1 && context_1.id;
+    return {
+        setters: [],
+        execute: function () {
+            context_1.import("./other");
+        }
+    };
+});`,
+		},
+		{
+			title: "ImportMeta#1",
+			input: `export {}; import.meta.url;`,
+			output: `System.register([], function (exports_1, context_1) {
+    "use strict";
+    var __moduleName = context_1 && context_1.id;
+    return {
+        setters: [],
+        execute: function () {
+            context_1.meta.url;
+        }
+    };
+});`,
+		},
+	}
+	for _, rec := range data {
+		t.Run(rec.title, func(t *testing.T) {
+			t.Parallel()
+
+			compilerOptions := rec.options
+			if compilerOptions == nil {
+				compilerOptions = &core.CompilerOptions{}
+			}
+
+			compilerOptions.Module = core.ModuleKindSystem
+
+			file := parsetestutil.ParseTypeScript(rec.input, false /*jsx*/)
+			parsetestutil.CheckDiagnostics(t, file)
+			binder.BindSourceFile(file)
+
+			emitContext := printer.NewEmitContext()
+			resolver := binder.NewReferenceResolver(compilerOptions, binder.ReferenceResolverHooks{})
+
+			file = tstransforms.NewRuntimeSyntaxTransformer(emitContext, compilerOptions, resolver).TransformSourceFile(file)
+			file = moduletransforms.NewSystemModuleTransformer(emitContext, compilerOptions, resolver, fakeGetEmitModuleFormatOfFile).TransformSourceFile(file)
+			emittestutil.CheckEmit(t, emitContext, file, rec.output)
+		})
+	}
+}
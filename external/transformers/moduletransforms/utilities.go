@@ -5,6 +5,7 @@ import (
 	"github.com/pagpeter/typescript-go/external/core"
 	"github.com/pagpeter/typescript-go/external/outputpaths"
 	"github.com/pagpeter/typescript-go/external/printer"
+	"github.com/pagpeter/typescript-go/external/transformers"
 	"github.com/pagpeter/typescript-go/external/tspath"
 )
 
@@ -43,6 +44,30 @@ func createEmptyImports(factory *printer.NodeFactory) *ast.Statement {
 	)
 }
 
+// Get the name that should be used to reference the namespace object of an external import, if one exists.
+func getLocalNameForExternalImport(emitContext *printer.EmitContext, node *ast.Node /*ImportDeclaration | ImportEqualsDeclaration | ExportDeclaration*/, sourceFile *ast.SourceFile) *ast.IdentifierNode {
+	namespaceDeclaration := ast.GetNamespaceDeclarationNode(node)
+	if namespaceDeclaration != nil && !ast.IsDefaultImport(node) {
+		name := namespaceDeclaration.Name()
+		if ast.IsIdentifier(name) && !ast.ModuleExportNameIsDefault(name) {
+			if transformers.IsGeneratedIdentifier(emitContext, name) {
+				return name
+			}
+			return emitContext.Factory.NewIdentifier(name.Text())
+		}
+		// export * as "ns" from "mod";
+		// export * as default from "mod";
+		return emitContext.Factory.NewGeneratedNameForNode(node)
+	}
+	if ast.IsImportDeclaration(node) && node.AsImportDeclaration().ImportClause != nil {
+		return emitContext.Factory.NewGeneratedNameForNode(node)
+	}
+	if ast.IsExportDeclaration(node) && node.AsExportDeclaration().ModuleSpecifier != nil {
+		return emitContext.Factory.NewGeneratedNameForNode(node)
+	}
+	return nil
+}
+
 // Get the name of a target module from an import/export declaration as should be written in the emitted output.
 // The emitted output name can be different from the input if:
 //  1. The module has a /// <amd-module name="<new name>" />
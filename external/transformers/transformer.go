@@ -2,9 +2,16 @@ package transformers
 
 import (
 	"github.com/pagpeter/typescript-go/external/ast"
+	"github.com/pagpeter/typescript-go/external/core"
 	"github.com/pagpeter/typescript-go/external/printer"
 )
 
+// TransformOptions holds the state shared by all of the transformers created for a single file.
+type TransformOptions struct {
+	Context         *printer.EmitContext
+	CompilerOptions *core.CompilerOptions
+}
+
 type Transformer struct {
 	emitContext *printer.EmitContext
 	factory     *printer.NodeFactory
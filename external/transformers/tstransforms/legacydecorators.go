@@ -0,0 +1,657 @@
+package tstransforms
+
+import (
+	"github.com/pagpeter/typescript-go/external/ast"
+	"github.com/pagpeter/typescript-go/external/binder"
+	"github.com/pagpeter/typescript-go/external/core"
+	"github.com/pagpeter/typescript-go/external/printer"
+	"github.com/pagpeter/typescript-go/external/transformers"
+)
+
+// Transforms legacy `--experimentalDecorators` decorators into calls to the `__decorate`, `__param`, and `__metadata`
+// helpers.
+type LegacyDecoratorsTransformer struct {
+	transformers.Transformer
+	compilerOptions *core.CompilerOptions
+	languageVersion core.ScriptTarget
+	resolver        binder.ReferenceResolver
+	typeSerializer  *typeSerializer // nil unless `--emitDecoratorMetadata` is set
+	parentNode      *ast.Node
+	currentNode     *ast.Node
+
+	// The aliases of the decorated classes whose bodies are being visited, keyed by the parse tree class declaration.
+	// References to such a class within its body are rewritten to the alias, as the binding of the class may be
+	// replaced by a class decorator.
+	classAliases map[*ast.Node]*ast.IdentifierNode
+}
+
+func NewLegacyDecoratorsTransformer(emitContext *printer.EmitContext, compilerOptions *core.CompilerOptions, emitResolver printer.EmitResolver, referenceResolver binder.ReferenceResolver) *transformers.Transformer {
+	tx := &LegacyDecoratorsTransformer{
+		compilerOptions: compilerOptions,
+		languageVersion: compilerOptions.GetEmitScriptTarget(),
+		resolver:        referenceResolver,
+	}
+	if compilerOptions.EmitDecoratorMetadata.IsTrue() && emitResolver != nil {
+		tx.typeSerializer = newTypeSerializer(emitContext, compilerOptions, emitResolver)
+	}
+	if tx.resolver == nil {
+		tx.resolver = binder.NewReferenceResolver(compilerOptions, binder.ReferenceResolverHooks{})
+	}
+	return tx.NewTransformer(tx.visit, emitContext)
+}
+
+// Pushes a new child node onto the ancestor tracking stack, returning the grandparent node to be restored later via `popNode`.
+func (tx *LegacyDecoratorsTransformer) pushNode(node *ast.Node) (grandparentNode *ast.Node) {
+	grandparentNode = tx.parentNode
+	tx.parentNode = tx.currentNode
+	tx.currentNode = node
+	return
+}
+
+// Pops the last child node off the ancestor tracking stack, restoring the grandparent node.
+func (tx *LegacyDecoratorsTransformer) popNode(grandparentNode *ast.Node) {
+	tx.currentNode = tx.parentNode
+	tx.parentNode = grandparentNode
+}
+
+func (tx *LegacyDecoratorsTransformer) shouldVisit(node *ast.Node) bool {
+	facts := node.SubtreeFacts()
+	return facts&ast.SubtreeContainsDecorators != 0 ||
+		len(tx.classAliases) > 0 && facts&ast.SubtreeContainsIdentifier != 0
+}
+
+func (tx *LegacyDecoratorsTransformer) visit(node *ast.Node) *ast.Node {
+	if !tx.shouldVisit(node) {
+		return node
+	}
+
+	grandparentNode := tx.pushNode(node)
+	defer tx.popNode(grandparentNode)
+
+	switch node.Kind {
+	case ast.KindSourceFile:
+		return tx.visitSourceFile(node.AsSourceFile())
+	case ast.KindDecorator:
+		// decorators are removed from any declaration once they have been transformed
+		return nil
+	case ast.KindClassDeclaration:
+		return tx.visitClassDeclaration(node.AsClassDeclaration())
+	case ast.KindClassExpression:
+		return tx.visitClassExpression(node.AsClassExpression())
+	case ast.KindMethodDeclaration:
+		return tx.visitMethodDeclaration(node.AsMethodDeclaration())
+	case ast.KindGetAccessor:
+		return tx.visitGetAccessorDeclaration(node.AsGetAccessorDeclaration())
+	case ast.KindSetAccessor:
+		return tx.visitSetAccessorDeclaration(node.AsSetAccessorDeclaration())
+	case ast.KindPropertyDeclaration:
+		return tx.visitPropertyDeclaration(node.AsPropertyDeclaration())
+	case ast.KindIdentifier:
+		return tx.visitIdentifier(node)
+	case ast.KindShorthandPropertyAssignment:
+		return tx.visitShorthandPropertyAssignment(node.AsShorthandPropertyAssignment())
+	default:
+		return tx.Visitor().VisitEachChild(node)
+	}
+}
+
+func (tx *LegacyDecoratorsTransformer) visitSourceFile(node *ast.SourceFile) *ast.Node {
+	if node.IsDeclarationFile {
+		return node.AsNode()
+	}
+
+	visited := tx.Visitor().VisitEachChild(node.AsNode())
+	tx.EmitContext().AddEmitHelper(visited, tx.EmitContext().ReadEmitHelpers()...)
+	return visited
+}
+
+//
+// Classes
+//
+
+// Indicates whether a class or the parameters of its constructor are decorated.
+func classOrConstructorParameterIsDecorated(node *ast.ClassLikeDeclaration) bool {
+	if ast.HasDecorators(node) {
+		return true
+	}
+	constructor := getFirstConstructorWithBody(node)
+	return constructor != nil && core.Some(constructor.Parameters(), ast.HasDecorators)
+}
+
+// Indicates whether a class element or any of its parameters is decorated.
+func classElementOrChildIsDecorated(node *ast.ClassElement) bool {
+	if ast.HasDecorators(node) {
+		return true
+	}
+	switch node.Kind {
+	case ast.KindMethodDeclaration, ast.KindSetAccessor, ast.KindConstructor:
+		return node.Body() != nil && core.Some(node.Parameters(), ast.HasDecorators)
+	}
+	return false
+}
+
+func (tx *LegacyDecoratorsTransformer) visitClassDeclaration(node *ast.ClassDeclaration) *ast.Node {
+	if classOrConstructorParameterIsDecorated(node.AsNode()) {
+		return transformers.SingleOrMany(tx.transformClassDeclarationWithClassDecorators(node), tx.Factory())
+	}
+	if core.Some(node.Members.Nodes, classElementOrChildIsDecorated) {
+		return transformers.SingleOrMany(tx.transformClassDeclarationWithoutClassDecorators(node), tx.Factory())
+	}
+	return tx.Visitor().VisitEachChild(node.AsNode())
+}
+
+// Transforms a class whose elements are decorated, but which is not itself decorated:
+//
+//	class C {
+//	    @dec method() {}
+//	}
+//
+// Into:
+//
+//	class C {
+//	    method() {}
+//	}
+//	__decorate([dec], C.prototype, "method", null);
+func (tx *LegacyDecoratorsTransformer) transformClassDeclarationWithoutClassDecorators(node *ast.ClassDeclaration) []*ast.Statement {
+	modifiers := tx.Visitor().VisitModifiers(node.Modifiers())
+	name := node.Name()
+	if name == nil {
+		// the decoration statements must be able to refer to the class
+		name = tx.Factory().GetDeclarationName(node.AsNode())
+	}
+	heritageClauses := tx.Visitor().VisitNodes(node.HeritageClauses)
+	members := tx.Visitor().VisitNodes(node.Members)
+	members, decorationStatements := tx.transformDecoratorsOfClassElements(node.AsNode(), members)
+	updated := tx.Factory().UpdateClassDeclaration(node, modifiers, name, nil /*typeParameters*/, heritageClauses, members)
+	return append([]*ast.Statement{updated}, decorationStatements...)
+}
+
+// Transforms a decorated class:
+//
+//	@dec
+//	export class C {
+//	    static create() { return new C(); }
+//	}
+//
+// Into:
+//
+//	var C_1;
+//	let C = C_1 = class C {
+//	    static create() { return new C_1(); }
+//	};
+//	C = C_1 = __decorate([dec], C);
+//	export { C };
+//
+// As a class decorator may replace the class, the class binding is reassigned with the result of `__decorate`. The
+// class alias is only introduced when the class body refers to the class.
+func (tx *LegacyDecoratorsTransformer) transformClassDeclarationWithClassDecorators(node *ast.ClassDeclaration) []*ast.Statement {
+	ec := tx.EmitContext()
+	f := tx.Factory()
+	isExport := ast.HasSyntacticModifier(node.AsNode(), ast.ModifierFlagsExport)
+	isDefault := ast.HasSyntacticModifier(node.AsNode(), ast.ModifierFlagsDefault)
+	modifiers := tx.Visitor().VisitModifiers(transformers.ExtractModifiers(ec, node.Modifiers(), ^(ast.ModifierFlagsExportDefault | ast.ModifierFlagsDecorator)))
+
+	classAlias := tx.getClassAliasIfNeeded(node)
+	original := ec.MostOriginal(node.AsNode())
+	if classAlias != nil {
+		if tx.classAliases == nil {
+			tx.classAliases = make(map[*ast.Node]*ast.IdentifierNode)
+		}
+		tx.classAliases[original] = classAlias
+	}
+
+	declName := f.GetLocalNameEx(node.AsNode(), printer.AssignedNameOptions{AllowSourceMaps: true})
+	heritageClauses := tx.Visitor().VisitNodes(node.HeritageClauses)
+	members := tx.Visitor().VisitNodes(node.Members)
+	members, decorationStatements := tx.transformDecoratorsOfClassElements(node.AsNode(), members)
+
+	// The decorators of the class are evaluated before the class alias is assigned, and so do not use the alias.
+	delete(tx.classAliases, original)
+	classDecoratorExpressions := tx.transformAllDecoratorsOfClass(node.AsNode())
+
+	// If we're emitting to ES2022 or later then we need to reassign the class alias before
+	// static initializers are evaluated.
+	assignClassAliasInStaticBlock := tx.languageVersion >= core.ScriptTargetES2022 &&
+		classAlias != nil &&
+		core.Some(members.Nodes, func(member *ast.Node) bool {
+			return ast.IsPropertyDeclaration(member) && ast.IsStatic(member) || ast.IsClassStaticBlockDeclaration(member)
+		})
+	if assignClassAliasInStaticBlock {
+		staticBlock := f.NewClassStaticBlockDeclaration(nil /*modifiers*/, f.NewBlock(f.NewNodeList([]*ast.Statement{
+			f.NewExpressionStatement(f.NewAssignmentExpression(classAlias.Clone(f), f.NewThisExpression())),
+		}), false /*multiLine*/))
+		newMembers := append([]*ast.ClassElement{staticBlock}, members.Nodes...)
+		loc := members.Loc
+		members = f.NewNodeList(newMembers)
+		members.Loc = loc
+	}
+
+	var className *ast.IdentifierNode
+	if name := node.Name(); name != nil && !transformers.IsGeneratedIdentifier(ec, name) {
+		className = name
+	}
+	classExpression := f.NewClassExpression(modifiers, className, nil /*typeParameters*/, heritageClauses, members)
+	ec.SetOriginal(classExpression, node.AsNode())
+
+	//  let ${name} = ${classExpression} where name is either declaredName if the class doesn't contain self-reference
+	//                                         or decoratedClassAlias if the class contain self-reference.
+	varInitializer := classExpression
+	if classAlias != nil && !assignClassAliasInStaticBlock {
+		varInitializer = f.NewAssignmentExpression(classAlias.Clone(f), classExpression)
+	}
+	varDecl := f.NewVariableDeclaration(declName, nil /*exclamationToken*/, nil /*type*/, varInitializer)
+	ec.SetOriginal(varDecl, node.AsNode())
+	varStatement := f.NewVariableStatement(nil /*modifiers*/, f.NewVariableDeclarationList(ast.NodeFlagsLet, f.NewNodeList([]*ast.Node{varDecl})))
+	ec.SetOriginal(varStatement, node.AsNode())
+	varStatement.Loc = node.Loc
+	ec.SetCommentRange(varStatement, node.Loc)
+
+	statements := []*ast.Statement{varStatement}
+	statements = append(statements, decorationStatements...)
+
+	// C = C_1 = __decorate([dec], C);
+	if len(classDecoratorExpressions) > 0 {
+		localName := f.GetDeclarationNameEx(node.AsNode(), printer.NameOptions{AllowSourceMaps: true})
+		var decorate *ast.Expression = f.NewDecorateHelper(classDecoratorExpressions, localName, nil /*memberName*/, nil /*descriptor*/)
+		if classAlias != nil {
+			decorate = f.NewAssignmentExpression(classAlias.Clone(f), decorate)
+		}
+		expression := f.NewAssignmentExpression(f.GetDeclarationNameEx(node.AsNode(), printer.NameOptions{AllowSourceMaps: true}), decorate)
+		ec.SetEmitFlags(expression, printer.EFNoComments)
+		statement := f.NewExpressionStatement(expression)
+		ec.SetOriginal(statement, node.AsNode())
+		statements = append(statements, statement)
+	}
+
+	if isExport {
+		if isDefault {
+			// export default C;
+			statements = append(statements, f.NewExportAssignment(nil /*modifiers*/, false /*isExportEquals*/, nil /*typeNode*/, f.GetLocalName(node.AsNode())))
+		} else {
+			// export { C };
+			statements = append(statements, f.NewExportDeclaration(
+				nil,   /*modifiers*/
+				false, /*isTypeOnly*/
+				f.NewNamedExports(f.NewNodeList([]*ast.Node{f.NewExportSpecifier(false /*isTypeOnly*/, nil /*propertyName*/, f.GetDeclarationName(node.AsNode()))})),
+				nil, /*moduleSpecifier*/
+				nil, /*attributes*/
+			))
+		}
+	}
+	return statements
+}
+
+// Legacy decorators are not supported on class expressions, so any decorators are only removed.
+func (tx *LegacyDecoratorsTransformer) visitClassExpression(node *ast.ClassExpression) *ast.Node {
+	return tx.Factory().UpdateClassExpression(
+		node,
+		tx.Visitor().VisitModifiers(node.Modifiers()),
+		node.Name(),
+		nil, /*typeParameters*/
+		tx.Visitor().VisitNodes(node.HeritageClauses),
+		tx.Visitor().VisitNodes(node.Members),
+	)
+}
+
+// Adds the decoration statements for the decorated elements of a class. The statements are moved into a trailing
+// static block of the class when a decorator refers to a private name, which is only accessible within the class body.
+func (tx *LegacyDecoratorsTransformer) transformDecoratorsOfClassElements(node *ast.ClassLikeDeclaration, members *ast.NodeList) (*ast.NodeList, []*ast.Statement) {
+	f := tx.Factory()
+	var decorationStatements []*ast.Statement
+	containsPrivateIdentifier := false
+	for _, isStatic := range []bool{false, true} {
+		for _, member := range node.Members() {
+			if ast.IsStatic(member) != isStatic || !classElementOrChildIsDecorated(member) {
+				continue
+			}
+			expression, usesPrivateIdentifier := tx.generateClassElementDecorationExpression(node, member)
+			if expression != nil {
+				decorationStatements = append(decorationStatements, f.NewExpressionStatement(expression))
+				containsPrivateIdentifier = containsPrivateIdentifier || usesPrivateIdentifier
+			}
+		}
+	}
+
+	if containsPrivateIdentifier {
+		staticBlock := f.NewClassStaticBlockDeclaration(nil /*modifiers*/, f.NewBlock(f.NewNodeList(decorationStatements), true /*multiLine*/))
+		newMembers := append(members.Nodes[:len(members.Nodes):len(members.Nodes)], staticBlock)
+		loc := members.Loc
+		members = f.NewNodeList(newMembers)
+		members.Loc = loc
+		decorationStatements = nil
+	}
+	return members, decorationStatements
+}
+
+// Generates the call to `__decorate` for a decorated class element:
+//
+//	__decorate([dec, __param(0, dec), __metadata("design:type", Function)], C.prototype, "method", null)
+func (tx *LegacyDecoratorsTransformer) generateClassElementDecorationExpression(node *ast.ClassLikeDeclaration, member *ast.ClassElement) (expression *ast.Expression, usesPrivateIdentifier bool) {
+	var decorators []*ast.Node
+	var parameters []*ast.Node
+	switch member.Kind {
+	case ast.KindGetAccessor, ast.KindSetAccessor:
+		// the decorators of a pair of accessors are applied once, to the first accessor that is decorated
+		if member.Body() == nil {
+			return nil, false
+		}
+		var otherAccessor *ast.Node
+		if ast.IsGetAccessorDeclaration(member) {
+			otherAccessor = getAccessorOfKind(node, member, ast.KindSetAccessor)
+		} else {
+			otherAccessor = getAccessorOfKind(node, member, ast.KindGetAccessor)
+		}
+		if !ast.HasDecorators(member) || otherAccessor != nil && ast.HasDecorators(otherAccessor) && otherAccessor.Pos() < member.Pos() {
+			return nil, false
+		}
+		decorators = getDecorators(member)
+		if ast.IsSetAccessorDeclaration(member) {
+			parameters = member.Parameters()
+		} else if otherAccessor != nil {
+			parameters = otherAccessor.Parameters()
+		}
+	case ast.KindMethodDeclaration:
+		if member.Body() == nil {
+			return nil, false
+		}
+		decorators = getDecorators(member)
+		parameters = member.Parameters()
+	case ast.KindPropertyDeclaration:
+		decorators = getDecorators(member)
+	default:
+		return nil, false
+	}
+
+	if len(decorators) == 0 && !core.Some(parameters, ast.HasDecorators) {
+		return nil, false
+	}
+
+	decoratorExpressions, usesPrivateIdentifier := tx.transformAllDecoratorsOfDeclaration(decorators, parameters)
+	decoratorExpressions = append(decoratorExpressions, tx.getTypeMetadata(member, node)...)
+
+	f := tx.Factory()
+	var prefix *ast.Expression
+	if ast.IsStatic(member) {
+		prefix = f.GetDeclarationName(node)
+	} else {
+		prefix = f.NewPropertyAccessExpression(f.GetDeclarationName(node), nil /*questionDotToken*/, f.NewIdentifier("prototype"), ast.NodeFlagsNone)
+	}
+	memberName := tx.getExpressionForPropertyName(member, !ast.HasSyntacticModifier(member, ast.ModifierFlagsAmbient) /*generateNameForComputedPropertyName*/)
+
+	var descriptor *ast.Expression
+	if ast.IsPropertyDeclaration(member) && !ast.HasAccessorModifier(member) {
+		// We emit `void 0` here to indicate to `__decorate` that it can invoke `Object.defineProperty` directly, but that it
+		// should not invoke `Object.getOwnPropertyDescriptor`.
+		descriptor = f.NewVoidZeroExpression()
+	} else {
+		// We emit `null` here to indicate to `__decorate` that it can invoke `Object.getOwnPropertyDescriptor` directly.
+		// We have this extra argument here so that we can inject an explicit property descriptor at a later date.
+		descriptor = f.NewToken(ast.KindNullKeyword)
+	}
+
+	helper := f.NewDecorateHelper(decoratorExpressions, prefix, memberName, descriptor)
+	tx.EmitContext().SetEmitFlags(helper, printer.EFNoComments)
+	return helper, usesPrivateIdentifier
+}
+
+// Gets the decorator expressions of a class, including those of the parameters of its constructor.
+func (tx *LegacyDecoratorsTransformer) transformAllDecoratorsOfClass(node *ast.ClassLikeDeclaration) []*ast.Expression {
+	var parameters []*ast.Node
+	if constructor := getFirstConstructorWithBody(node); constructor != nil {
+		parameters = constructor.Parameters()
+	}
+	decoratorExpressions, _ := tx.transformAllDecoratorsOfDeclaration(getDecorators(node), parameters)
+	return append(decoratorExpressions, tx.getTypeMetadata(node, node)...)
+}
+
+func (tx *LegacyDecoratorsTransformer) transformAllDecoratorsOfDeclaration(decorators []*ast.Node, parameters []*ast.Node) (decoratorExpressions []*ast.Expression, usesPrivateIdentifier bool) {
+	for _, decorator := range decorators {
+		decoratorExpressions = append(decoratorExpressions, tx.transformDecorator(decorator))
+		usesPrivateIdentifier = usesPrivateIdentifier || containsPrivateIdentifier(decorator)
+	}
+	for parameterOffset, parameter := range parameters {
+		for _, decorator := range getDecorators(parameter) {
+			helper := tx.Factory().NewParamHelper(tx.transformDecorator(decorator), parameterOffset, decorator.Expression().Loc)
+			tx.EmitContext().SetEmitFlags(helper, printer.EFNoComments)
+			decoratorExpressions = append(decoratorExpressions, helper)
+			usesPrivateIdentifier = usesPrivateIdentifier || containsPrivateIdentifier(decorator)
+		}
+	}
+	return decoratorExpressions, usesPrivateIdentifier
+}
+
+func (tx *LegacyDecoratorsTransformer) transformDecorator(decorator *ast.Node) *ast.Expression {
+	return tx.Visitor().VisitNode(decorator.Expression())
+}
+
+func getDecorators(node *ast.Node) []*ast.Node {
+	var decorators []*ast.Node
+	if modifiers := node.Modifiers(); modifiers != nil {
+		for _, modifier := range modifiers.Nodes {
+			if ast.IsDecorator(modifier) {
+				decorators = append(decorators, modifier)
+			}
+		}
+	}
+	return decorators
+}
+
+func containsPrivateIdentifier(node *ast.Node) bool {
+	var visit func(node *ast.Node) bool
+	visit = func(node *ast.Node) bool {
+		return ast.IsPrivateIdentifier(node) || node.ForEachChild(visit)
+	}
+	return visit(node)
+}
+
+// Gets the `__metadata` calls describing the types of a decorated declaration, when `--emitDecoratorMetadata` is set.
+// The types are serialized from the original declaration, as they have already been erased.
+func (tx *LegacyDecoratorsTransformer) getTypeMetadata(node *ast.Node, container *ast.ClassLikeDeclaration) []*ast.Expression {
+	if tx.typeSerializer == nil {
+		return nil
+	}
+	f := tx.Factory()
+	original := tx.EmitContext().MostOriginal(node)
+	originalContainer := tx.EmitContext().MostOriginal(container)
+	if !ast.IsParseTreeNode(original) || !ast.IsParseTreeNode(originalContainer) {
+		return nil
+	}
+
+	savedCurrentNameScope := tx.typeSerializer.currentNameScope
+	tx.typeSerializer.currentNameScope = originalContainer
+	defer func() { tx.typeSerializer.currentNameScope = savedCurrentNameScope }()
+
+	var metadata []*ast.Expression
+	switch original.Kind {
+	case ast.KindMethodDeclaration, ast.KindGetAccessor, ast.KindSetAccessor, ast.KindPropertyDeclaration:
+		metadata = append(metadata, f.NewMetadataHelper("design:type", tx.typeSerializer.serializeTypeOfNode(original, originalContainer)))
+	}
+	switch original.Kind {
+	case ast.KindClassDeclaration, ast.KindClassExpression:
+		if getFirstConstructorWithBody(original) != nil {
+			metadata = append(metadata, f.NewMetadataHelper("design:paramtypes", tx.typeSerializer.serializeParameterTypesOfNode(original, originalContainer)))
+		}
+	case ast.KindMethodDeclaration, ast.KindGetAccessor, ast.KindSetAccessor:
+		metadata = append(metadata, f.NewMetadataHelper("design:paramtypes", tx.typeSerializer.serializeParameterTypesOfNode(original, originalContainer)))
+	}
+	if original.Kind == ast.KindMethodDeclaration {
+		metadata = append(metadata, f.NewMetadataHelper("design:returntype", tx.typeSerializer.serializeReturnTypeOfNode(original)))
+	}
+	return metadata
+}
+
+// Gets an expression for the name of a decorated class element, for use as the property key passed to `__decorate`.
+func (tx *LegacyDecoratorsTransformer) getExpressionForPropertyName(member *ast.ClassElement, generateNameForComputedPropertyName bool) *ast.Expression {
+	f := tx.Factory()
+	name := member.Name()
+	switch {
+	case ast.IsPrivateIdentifier(name):
+		return f.NewIdentifier("")
+	case ast.IsComputedPropertyName(name):
+		if generateNameForComputedPropertyName && !isSimpleInlineableExpression(name.Expression()) {
+			return f.NewGeneratedNameForNode(name)
+		}
+		return tx.Visitor().VisitNode(name.Expression())
+	case ast.IsIdentifier(name):
+		return f.NewStringLiteral(name.Text())
+	default:
+		return name.Clone(f)
+	}
+}
+
+//
+// Class elements
+//
+
+// Visits the name of a class element. The computed property name of a decorated element is evaluated only once, by
+// storing it in a temporary variable that is also passed to `__decorate`.
+func (tx *LegacyDecoratorsTransformer) visitPropertyNameOfClassElement(member *ast.ClassElement) *ast.PropertyName {
+	name := member.Name()
+	if ast.IsComputedPropertyName(name) && ast.HasDecorators(member) && !isSimpleInlineableExpression(name.Expression()) {
+		expression := tx.Visitor().VisitNode(name.Expression())
+		generatedName := tx.Factory().NewGeneratedNameForNode(name)
+		tx.EmitContext().AddVariableDeclaration(generatedName)
+		return tx.Factory().UpdateComputedPropertyName(name.AsComputedPropertyName(), tx.Factory().NewAssignmentExpression(generatedName, expression))
+	}
+	return tx.Visitor().VisitNode(name)
+}
+
+func (tx *LegacyDecoratorsTransformer) visitMethodDeclaration(node *ast.MethodDeclaration) *ast.Node {
+	return tx.Factory().UpdateMethodDeclaration(
+		node,
+		tx.Visitor().VisitModifiers(node.Modifiers()),
+		node.AsteriskToken,
+		tx.visitPropertyNameOfClassElement(node.AsNode()),
+		nil, /*postfixToken*/
+		nil, /*typeParameters*/
+		tx.EmitContext().VisitParameters(node.Parameters, tx.Visitor()),
+		nil, /*returnType*/
+		tx.EmitContext().VisitFunctionBody(node.Body, tx.Visitor()),
+	)
+}
+
+func (tx *LegacyDecoratorsTransformer) visitGetAccessorDeclaration(node *ast.GetAccessorDeclaration) *ast.Node {
+	return tx.Factory().UpdateGetAccessorDeclaration(
+		node,
+		tx.Visitor().VisitModifiers(node.Modifiers()),
+		tx.visitPropertyNameOfClassElement(node.AsNode()),
+		nil, /*typeParameters*/
+		tx.EmitContext().VisitParameters(node.Parameters, tx.Visitor()),
+		nil, /*returnType*/
+		tx.EmitContext().VisitFunctionBody(node.Body, tx.Visitor()),
+	)
+}
+
+func (tx *LegacyDecoratorsTransformer) visitSetAccessorDeclaration(node *ast.SetAccessorDeclaration) *ast.Node {
+	return tx.Factory().UpdateSetAccessorDeclaration(
+		node,
+		tx.Visitor().VisitModifiers(node.Modifiers()),
+		tx.visitPropertyNameOfClassElement(node.AsNode()),
+		nil, /*typeParameters*/
+		tx.EmitContext().VisitParameters(node.Parameters, tx.Visitor()),
+		nil, /*returnType*/
+		tx.EmitContext().VisitFunctionBody(node.Body, tx.Visitor()),
+	)
+}
+
+func (tx *LegacyDecoratorsTransformer) visitPropertyDeclaration(node *ast.PropertyDeclaration) *ast.Node {
+	if ast.HasSyntacticModifier(node.AsNode(), ast.ModifierFlagsAmbient) {
+		// `declare` fields are only preserved until they are decorated
+		return nil
+	}
+	return tx.Factory().UpdatePropertyDeclaration(
+		node,
+		tx.Visitor().VisitModifiers(node.Modifiers()),
+		tx.visitPropertyNameOfClassElement(node.AsNode()),
+		nil, /*postfixToken*/
+		nil, /*typeNode*/
+		tx.Visitor().VisitNode(node.Initializer),
+	)
+}
+
+//
+// Class aliases
+//
+
+// Gets the alias for a decorated class if the body of the class refers to the class.
+func (tx *LegacyDecoratorsTransformer) getClassAliasIfNeeded(node *ast.ClassDeclaration) *ast.IdentifierNode {
+	original := tx.EmitContext().MostOriginal(node.AsNode())
+	if !ast.IsParseTreeNode(original) || !tx.containsConstructorReference(original) {
+		return nil
+	}
+	text := "default"
+	if name := node.Name(); name != nil && !transformers.IsGeneratedIdentifier(tx.EmitContext(), name) {
+		text = name.Text()
+	}
+	classAlias := tx.Factory().NewUniqueName(text)
+	tx.EmitContext().AddVariableDeclaration(classAlias)
+	return classAlias
+}
+
+// Indicates whether the members of a parse tree class declaration contain a reference to the class.
+func (tx *LegacyDecoratorsTransformer) containsConstructorReference(class *ast.Node) bool {
+	var visit func(node *ast.Node) bool
+	visit = func(node *ast.Node) bool {
+		if ast.IsIdentifier(node) {
+			isReference := transformers.IsIdentifierReference(node, node.Parent) || ast.IsShorthandPropertyAssignment(node.Parent)
+			return isReference && tx.resolver.GetReferencedValueDeclaration(node) == class
+		}
+		return node.ForEachChild(visit)
+	}
+	return core.Some(class.Members(), visit)
+}
+
+// Gets the alias of the class to which an identifier refers, if the reference is within the body of the class.
+func (tx *LegacyDecoratorsTransformer) getClassAliasForReference(node *ast.IdentifierNode) *ast.IdentifierNode {
+	if len(tx.classAliases) == 0 || transformers.IsGeneratedIdentifier(tx.EmitContext(), node) || transformers.IsLocalName(tx.EmitContext(), node) {
+		return nil
+	}
+	original := tx.EmitContext().MostOriginal(node)
+	if !ast.IsParseTreeNode(original) || !ast.IsIdentifier(original) {
+		return nil
+	}
+	if declaration := tx.resolver.GetReferencedValueDeclaration(original); declaration != nil {
+		return tx.classAliases[declaration]
+	}
+	return nil
+}
+
+func (tx *LegacyDecoratorsTransformer) visitIdentifier(node *ast.IdentifierNode) *ast.Node {
+	if transformers.IsIdentifierReference(node, tx.parentNode) {
+		if classAlias := tx.getClassAliasForReference(node); classAlias != nil {
+			clone := classAlias.Clone(tx.Factory())
+			tx.EmitContext().AssignCommentAndSourceMapRanges(clone, node)
+			return clone
+		}
+	}
+	return node
+}
+
+func (tx *LegacyDecoratorsTransformer) visitShorthandPropertyAssignment(node *ast.ShorthandPropertyAssignment) *ast.Node {
+	classAlias := tx.getClassAliasForReference(node.Name())
+	if classAlias == nil {
+		return tx.Visitor().VisitEachChild(node.AsNode())
+	}
+
+	// { C } -> { C: C_1 }
+	var expression *ast.Expression = classAlias.Clone(tx.Factory())
+	tx.EmitContext().AssignCommentAndSourceMapRanges(expression, node.Name())
+	if node.ObjectAssignmentInitializer != nil {
+		equalsToken := node.EqualsToken
+		if equalsToken == nil {
+			equalsToken = tx.Factory().NewToken(ast.KindEqualsToken)
+		}
+		expression = tx.Factory().NewBinaryExpression(
+			nil, /*modifiers*/
+			expression,
+			nil, /*typeNode*/
+			equalsToken,
+			tx.Visitor().VisitNode(node.ObjectAssignmentInitializer),
+		)
+	}
+	updated := tx.Factory().NewPropertyAssignment(nil /*modifiers*/, node.Name(), nil /*postfixToken*/, nil /*typeNode*/, expression)
+	updated.Loc = node.Loc
+	tx.EmitContext().SetOriginal(updated, node.AsNode())
+	tx.EmitContext().AssignCommentAndSourceMapRanges(updated, node.AsNode())
+	return updated
+}
@@ -40,6 +40,30 @@ func (tx *TypeEraserTransformer) elide(node *ast.Statement) *ast.Statement {
 	return statement
 }
 
+// Extracts the allowed modifiers of a declaration without eliding them, visiting any decorators that are retained.
+func (tx *TypeEraserTransformer) extractModifiers(modifiers *ast.ModifierList, allowed ast.ModifierFlags) *ast.ModifierList {
+	modifiers = transformers.ExtractModifiers(tx.EmitContext(), modifiers, allowed)
+	if modifiers == nil || modifiers.ModifierFlags&ast.ModifierFlagsDecorator == 0 {
+		return modifiers
+	}
+	nodes := make([]*ast.Node, len(modifiers.Nodes))
+	changed := false
+	for i, modifier := range modifiers.Nodes {
+		if ast.IsDecorator(modifier) {
+			nodes[i] = tx.Visitor().VisitNode(modifier)
+			changed = changed || nodes[i] != modifier
+		} else {
+			nodes[i] = modifier
+		}
+	}
+	if !changed {
+		return modifiers
+	}
+	list := tx.Factory().NewModifierList(nodes)
+	list.Loc = modifiers.Loc
+	return list
+}
+
 func (tx *TypeEraserTransformer) visit(node *ast.Node) *ast.Node {
 	if node.SubtreeFacts()&ast.SubtreeContainsTypeScript == 0 {
 		return node
@@ -123,11 +147,16 @@ func (tx *TypeEraserTransformer) visit(node *ast.Node) *ast.Node {
 		return tx.Factory().UpdateExpressionWithTypeArguments(n, tx.Visitor().VisitNode(n.Expression), nil)
 
 	case ast.KindPropertyDeclaration:
+		n := node.AsPropertyDeclaration()
 		if ast.HasSyntacticModifier(node, ast.ModifierFlagsAmbient) {
+			if tx.compilerOptions.ExperimentalDecorators.IsTrue() && ast.HasDecorators(node) {
+				// TypeScript `declare` fields with legacy decorators are preserved to be decorated by the legacy decorators transformer
+				modifiers := tx.extractModifiers(n.Modifiers(), ast.ModifierFlagsDecorator|ast.ModifierFlagsAmbient|ast.ModifierFlagsStatic)
+				return tx.Factory().UpdatePropertyDeclaration(n, modifiers, tx.Visitor().VisitNode(n.Name()), nil, nil, nil)
+			}
 			// TypeScript `declare` fields are elided
 			return nil
 		}
-		n := node.AsPropertyDeclaration()
 		return tx.Factory().UpdatePropertyDeclaration(n, tx.Visitor().VisitModifiers(n.Modifiers()), tx.Visitor().VisitNode(n.Name()), nil, nil, tx.Visitor().VisitNode(n.Initializer))
 
 	case ast.KindConstructor:
@@ -204,10 +233,18 @@ func (tx *TypeEraserTransformer) visit(node *ast.Node) *ast.Node {
 			return nil
 		}
 		n := node.AsParameterDeclaration()
-		// preserve parameter property modifiers to be handled by the runtime transformer
-		var modifiers *ast.ModifierList
+		// preserve parameter property modifiers to be handled by the runtime transformer, and legacy parameter
+		// decorators to be handled by the legacy decorators transformer
+		var allowed ast.ModifierFlags
 		if ast.IsParameterPropertyDeclaration(node, tx.parentNode) {
-			modifiers = transformers.ExtractModifiers(tx.EmitContext(), n.Modifiers(), ast.ModifierFlagsParameterPropertyModifier)
+			allowed |= ast.ModifierFlagsParameterPropertyModifier
+		}
+		if tx.compilerOptions.ExperimentalDecorators.IsTrue() {
+			allowed |= ast.ModifierFlagsDecorator
+		}
+		var modifiers *ast.ModifierList
+		if allowed != ast.ModifierFlagsNone {
+			modifiers = tx.extractModifiers(n.Modifiers(), allowed)
 		}
 		return tx.Factory().UpdateParameterDeclaration(n, modifiers, n.DotDotDotToken, tx.Visitor().VisitNode(n.Name()), nil, nil, tx.Visitor().VisitNode(n.Initializer))
 
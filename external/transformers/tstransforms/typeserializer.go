@@ -0,0 +1,458 @@
+package tstransforms
+
+import (
+	"github.com/pagpeter/typescript-go/external/ast"
+	"github.com/pagpeter/typescript-go/external/core"
+	"github.com/pagpeter/typescript-go/external/printer"
+)
+
+// Serializes the type annotations of decorated declarations into runtime values for `--emitDecoratorMetadata`.
+type typeSerializer struct {
+	emitContext      *printer.EmitContext
+	resolver         printer.EmitResolver
+	languageVersion  core.ScriptTarget
+	strictNullChecks bool
+	currentNameScope *ast.Node // the parse tree class in which type names are resolved
+}
+
+func newTypeSerializer(emitContext *printer.EmitContext, compilerOptions *core.CompilerOptions, resolver printer.EmitResolver) *typeSerializer {
+	return &typeSerializer{
+		emitContext:      emitContext,
+		resolver:         resolver,
+		languageVersion:  compilerOptions.GetEmitScriptTarget(),
+		strictNullChecks: compilerOptions.StrictNullChecks == core.TSTrue || compilerOptions.StrictNullChecks == core.TSUnknown && compilerOptions.Strict == core.TSTrue,
+	}
+}
+
+func (s *typeSerializer) factory() *printer.NodeFactory {
+	return s.emitContext.Factory
+}
+
+// Serializes the type of a node for use with decorator type metadata.
+func (s *typeSerializer) serializeTypeOfNode(node *ast.Node, container *ast.Node) *ast.Expression {
+	switch node.Kind {
+	case ast.KindPropertyDeclaration, ast.KindParameter:
+		return s.serializeTypeNode(node.Type())
+	case ast.KindSetAccessor, ast.KindGetAccessor:
+		return s.serializeTypeNode(getAccessorTypeNode(node, container))
+	case ast.KindClassDeclaration, ast.KindClassExpression, ast.KindMethodDeclaration:
+		return s.factory().NewIdentifier("Function")
+	default:
+		return s.factory().NewVoidZeroExpression()
+	}
+}
+
+// Serializes the type of each parameter of a node for use with decorator type metadata.
+func (s *typeSerializer) serializeParameterTypesOfNode(node *ast.Node, container *ast.Node) *ast.Expression {
+	var valueDeclaration *ast.Node
+	if ast.IsClassLike(node) {
+		valueDeclaration = getFirstConstructorWithBody(node)
+	} else if ast.IsFunctionLike(node) && node.Body() != nil {
+		valueDeclaration = node
+	}
+
+	var expressions []*ast.Expression
+	if valueDeclaration != nil {
+		for i, parameter := range getParametersOfDecoratedDeclaration(valueDeclaration, container) {
+			if i == 0 && ast.IsThisParameter(parameter) {
+				continue
+			}
+			if parameter.AsParameterDeclaration().DotDotDotToken != nil {
+				expressions = append(expressions, s.serializeTypeNode(getRestParameterElementType(parameter.Type())))
+			} else {
+				expressions = append(expressions, s.serializeTypeOfNode(parameter, container))
+			}
+		}
+	}
+	return s.factory().NewArrayLiteralExpression(s.factory().NewNodeList(expressions), false /*multiLine*/)
+}
+
+// Serializes the return type of a node for use with decorator type metadata.
+func (s *typeSerializer) serializeReturnTypeOfNode(node *ast.Node) *ast.Expression {
+	if ast.IsFunctionLike(node) && node.Type() != nil {
+		return s.serializeTypeNode(node.Type())
+	}
+	if ast.IsFunctionLikeDeclaration(node) && ast.HasSyntacticModifier(node, ast.ModifierFlagsAsync) {
+		return s.factory().NewIdentifier("Promise")
+	}
+	return s.factory().NewVoidZeroExpression()
+}
+
+// Serializes a type node for use with decorator type metadata.
+//
+// Types are serialized in the following fashion:
+//   - Void types point to "undefined" (e.g. "void 0")
+//   - Function and Constructor types point to the global "Function" constructor.
+//   - Interface types with a call or construct signature types point to the global
+//     "Function" constructor.
+//   - Array and Tuple types point to the global "Array" constructor.
+//   - Type predicates and booleans point to the global "Boolean" constructor.
+//   - String literal types and strings point to the global "String" constructor.
+//   - Enum and number types point to the global "Number" constructor.
+//   - Symbol types point to the global "Symbol" constructor.
+//   - Type references to classes (or class-like variables) point to the constructor for the class.
+//   - Anything else points to the global "Object" constructor.
+func (s *typeSerializer) serializeTypeNode(node *ast.Node) *ast.Expression {
+	f := s.factory()
+	if node == nil {
+		return f.NewIdentifier("Object")
+	}
+
+	node = ast.SkipTypeParentheses(node)
+	switch node.Kind {
+	case ast.KindVoidKeyword, ast.KindUndefinedKeyword, ast.KindNeverKeyword:
+		return f.NewVoidZeroExpression()
+	case ast.KindFunctionType, ast.KindConstructorType:
+		return f.NewIdentifier("Function")
+	case ast.KindArrayType, ast.KindTupleType:
+		return f.NewIdentifier("Array")
+	case ast.KindTypePredicate:
+		if node.AsTypePredicateNode().AssertsModifier != nil {
+			return f.NewVoidZeroExpression()
+		}
+		return f.NewIdentifier("Boolean")
+	case ast.KindBooleanKeyword:
+		return f.NewIdentifier("Boolean")
+	case ast.KindTemplateLiteralType, ast.KindStringKeyword:
+		return f.NewIdentifier("String")
+	case ast.KindObjectKeyword:
+		return f.NewIdentifier("Object")
+	case ast.KindLiteralType:
+		return s.serializeLiteralOfLiteralTypeNode(node.AsLiteralTypeNode().Literal)
+	case ast.KindNumberKeyword:
+		return f.NewIdentifier("Number")
+	case ast.KindBigIntKeyword:
+		return s.getGlobalConstructor("BigInt", core.ScriptTargetES2020)
+	case ast.KindSymbolKeyword:
+		return s.getGlobalConstructor("Symbol", core.ScriptTargetES2015)
+	case ast.KindTypeReference:
+		return s.serializeTypeReferenceNode(node)
+	case ast.KindIntersectionType:
+		return s.serializeUnionOrIntersectionConstituents(node.AsIntersectionTypeNode().Types.Nodes, true /*isIntersection*/)
+	case ast.KindUnionType:
+		return s.serializeUnionOrIntersectionConstituents(node.AsUnionTypeNode().Types.Nodes, false /*isIntersection*/)
+	case ast.KindConditionalType:
+		n := node.AsConditionalTypeNode()
+		return s.serializeUnionOrIntersectionConstituents([]*ast.Node{n.TrueType, n.FalseType}, false /*isIntersection*/)
+	case ast.KindTypeOperator:
+		if node.AsTypeOperatorNode().Operator == ast.KindReadonlyKeyword {
+			return s.serializeTypeNode(node.Type())
+		}
+	case ast.KindJSDocNullableType, ast.KindJSDocNonNullableType, ast.KindJSDocOptionalType:
+		// handle JSDoc types from an invalid parse
+		return s.serializeTypeNode(node.Type())
+	}
+	return f.NewIdentifier("Object")
+}
+
+func (s *typeSerializer) serializeLiteralOfLiteralTypeNode(node *ast.Node) *ast.Expression {
+	f := s.factory()
+	switch node.Kind {
+	case ast.KindStringLiteral, ast.KindNoSubstitutionTemplateLiteral:
+		return f.NewIdentifier("String")
+	case ast.KindPrefixUnaryExpression:
+		return s.serializeLiteralOfLiteralTypeNode(node.AsPrefixUnaryExpression().Operand)
+	case ast.KindNumericLiteral:
+		return f.NewIdentifier("Number")
+	case ast.KindBigIntLiteral:
+		return s.getGlobalConstructor("BigInt", core.ScriptTargetES2020)
+	case ast.KindTrueKeyword, ast.KindFalseKeyword:
+		return f.NewIdentifier("Boolean")
+	case ast.KindNullKeyword:
+		return f.NewVoidZeroExpression()
+	default:
+		panic("Unhandled literal kind: " + node.Kind.String())
+	}
+}
+
+func (s *typeSerializer) serializeUnionOrIntersectionConstituents(types []*ast.Node, isIntersection bool) *ast.Expression {
+	// Note when updating logic here also update `getEntityNameForDecoratorMetadata` in checker.go so that aliases can be marked as referenced
+	f := s.factory()
+	var serializedType *ast.Expression
+	for _, typeNode := range types {
+		typeNode = ast.SkipTypeParentheses(typeNode)
+		switch typeNode.Kind {
+		case ast.KindNeverKeyword:
+			if isIntersection {
+				return f.NewVoidZeroExpression() // Reduce to `never` in an intersection
+			}
+			continue // Elide `never` in a union
+		case ast.KindUnknownKeyword:
+			if !isIntersection {
+				return f.NewIdentifier("Object") // Reduce to `unknown` in a union
+			}
+			continue // Elide `unknown` in an intersection
+		case ast.KindAnyKeyword:
+			return f.NewIdentifier("Object") // Reduce to `any` in a union or intersection
+		}
+
+		if !s.strictNullChecks && (ast.IsLiteralTypeNode(typeNode) && typeNode.AsLiteralTypeNode().Literal.Kind == ast.KindNullKeyword || typeNode.Kind == ast.KindUndefinedKeyword) {
+			continue // Elide null and undefined from unions for metadata, just like what we did prior to the implementation of strict null checks
+		}
+
+		serializedConstituent := s.serializeTypeNode(typeNode)
+		if ast.IsIdentifier(serializedConstituent) && serializedConstituent.Text() == "Object" {
+			// One of the individual is global object, return immediately
+			return serializedConstituent
+		}
+
+		// If there exists union that is not `void 0` expression, check if the the common type is identifier.
+		// anything more complex and we will just default to Object
+		if serializedType != nil {
+			// Different types
+			if !s.equateSerializedTypeNodes(serializedType, serializedConstituent) {
+				return f.NewIdentifier("Object")
+			}
+		} else {
+			// Initialize the union type
+			serializedType = serializedConstituent
+		}
+	}
+
+	// If we were able to find common type, use it
+	if serializedType != nil {
+		return serializedType
+	}
+	return f.NewVoidZeroExpression() // Fallback is only hit if all union constituents are null/undefined/never
+}
+
+func (s *typeSerializer) equateSerializedTypeNodes(left *ast.Expression, right *ast.Expression) bool {
+	switch {
+	case ast.IsIdentifier(left) && s.emitContext.HasAutoGenerateInfo(left):
+		// temp vars used in fallback
+		return ast.IsIdentifier(right) && s.emitContext.HasAutoGenerateInfo(right)
+	case ast.IsIdentifier(left):
+		// entity names
+		return ast.IsIdentifier(right) && !s.emitContext.HasAutoGenerateInfo(right) && left.Text() == right.Text()
+	case ast.IsPropertyAccessExpression(left):
+		return ast.IsPropertyAccessExpression(right) &&
+			s.equateSerializedTypeNodes(left.Expression(), right.Expression()) &&
+			s.equateSerializedTypeNodes(left.Name(), right.Name())
+	case ast.IsVoidExpression(left):
+		// `void 0`
+		return ast.IsVoidExpression(right) &&
+			ast.IsNumericLiteral(left.Expression()) && left.Expression().Text() == "0" &&
+			ast.IsNumericLiteral(right.Expression()) && right.Expression().Text() == "0"
+	case ast.IsStringLiteral(left):
+		// `"undefined"` or `"function"` in `typeof` checks
+		return ast.IsStringLiteral(right) && left.Text() == right.Text()
+	case ast.IsTypeOfExpression(left):
+		// used in `typeof` checks for fallback
+		return ast.IsTypeOfExpression(right) && s.equateSerializedTypeNodes(left.Expression(), right.Expression())
+	case ast.IsParenthesizedExpression(left):
+		// parens in `typeof` checks with temps
+		return ast.IsParenthesizedExpression(right) && s.equateSerializedTypeNodes(left.Expression(), right.Expression())
+	case ast.IsConditionalExpression(left):
+		// conditionals used in fallback
+		l, r := left.AsConditionalExpression(), right.AsConditionalExpression()
+		return ast.IsConditionalExpression(right) &&
+			s.equateSerializedTypeNodes(l.Condition, r.Condition) &&
+			s.equateSerializedTypeNodes(l.WhenTrue, r.WhenTrue) &&
+			s.equateSerializedTypeNodes(l.WhenFalse, r.WhenFalse)
+	case ast.IsBinaryExpression(left):
+		// logical binary and assignments used in fallback
+		if !ast.IsBinaryExpression(right) {
+			return false
+		}
+		l, r := left.AsBinaryExpression(), right.AsBinaryExpression()
+		return l.OperatorToken.Kind == r.OperatorToken.Kind &&
+			s.equateSerializedTypeNodes(l.Left, r.Left) &&
+			s.equateSerializedTypeNodes(l.Right, r.Right)
+	}
+	return false
+}
+
+// Serializes a TypeReferenceNode to an appropriate JS constructor value for use with decorator type metadata.
+func (s *typeSerializer) serializeTypeReferenceNode(node *ast.Node) *ast.Expression {
+	f := s.factory()
+	typeName := node.AsTypeReferenceNode().TypeName
+	switch s.resolver.GetTypeReferenceSerializationKind(typeName, s.currentNameScope) {
+	case printer.TypeReferenceSerializationKindUnknown:
+		// From conditional type type reference that cannot be resolved is Similar to any or unknown
+		if ast.FindAncestor(node, func(n *ast.Node) bool {
+			return n.Parent != nil && ast.IsConditionalTypeNode(n.Parent) && (n.Parent.AsConditionalTypeNode().TrueType == n || n.Parent.AsConditionalTypeNode().FalseType == n)
+		}) != nil {
+			return f.NewIdentifier("Object")
+		}
+
+		serialized := s.serializeEntityNameAsExpressionFallback(typeName)
+		temp := f.NewTempVariable()
+		s.emitContext.AddVariableDeclaration(temp)
+		return f.NewConditionalExpression(
+			s.newTypeCheck(f.NewAssignmentExpression(temp, serialized), "function"),
+			f.NewToken(ast.KindQuestionToken),
+			temp,
+			f.NewToken(ast.KindColonToken),
+			f.NewIdentifier("Object"),
+		)
+	case printer.TypeReferenceSerializationKindTypeWithConstructSignatureAndValue:
+		return s.serializeEntityNameAsExpression(typeName)
+	case printer.TypeReferenceSerializationKindVoidNullableOrNeverType:
+		return f.NewVoidZeroExpression()
+	case printer.TypeReferenceSerializationKindBigIntLikeType:
+		return s.getGlobalConstructor("BigInt", core.ScriptTargetES2020)
+	case printer.TypeReferenceSerializationKindBooleanType:
+		return f.NewIdentifier("Boolean")
+	case printer.TypeReferenceSerializationKindNumberLikeType:
+		return f.NewIdentifier("Number")
+	case printer.TypeReferenceSerializationKindStringLikeType:
+		return f.NewIdentifier("String")
+	case printer.TypeReferenceSerializationKindArrayLikeType:
+		return f.NewIdentifier("Array")
+	case printer.TypeReferenceSerializationKindESSymbolType:
+		return s.getGlobalConstructor("Symbol", core.ScriptTargetES2015)
+	case printer.TypeReferenceSerializationKindTypeWithCallSignature:
+		return f.NewIdentifier("Function")
+	case printer.TypeReferenceSerializationKindPromise:
+		return f.NewIdentifier("Promise")
+	default:
+		return f.NewIdentifier("Object")
+	}
+}
+
+func (s *typeSerializer) newTypeCheck(value *ast.Expression, tag string) *ast.Expression {
+	f := s.factory()
+	return f.NewStrictEqualityExpression(f.NewTypeOfExpression(value), f.NewStringLiteral(tag))
+}
+
+func (s *typeSerializer) newCheckedValue(left *ast.Expression, right *ast.Expression) *ast.Expression {
+	f := s.factory()
+	return f.NewLogicalANDExpression(f.NewStrictInequalityExpression(f.NewTypeOfExpression(left), f.NewStringLiteral("undefined")), right)
+}
+
+// Serializes an entity name which may not exist at runtime, but whose access shouldn't throw.
+func (s *typeSerializer) serializeEntityNameAsExpressionFallback(node *ast.Node) *ast.Expression {
+	f := s.factory()
+	if ast.IsIdentifier(node) {
+		// A -> typeof A !== "undefined" && A
+		return s.newCheckedValue(s.serializeEntityNameAsExpression(node), s.serializeEntityNameAsExpression(node))
+	}
+	n := node.AsQualifiedName()
+	if ast.IsIdentifier(n.Left) {
+		// A.B -> typeof A !== "undefined" && A.B
+		return s.newCheckedValue(s.serializeEntityNameAsExpression(n.Left), s.serializeEntityNameAsExpression(node))
+	}
+	// A.B.C -> typeof A !== "undefined" && (_a = A.B) !== void 0 && _a.C
+	left := s.serializeEntityNameAsExpressionFallback(n.Left).AsBinaryExpression()
+	temp := f.NewTempVariable()
+	s.emitContext.AddVariableDeclaration(temp)
+	return f.NewLogicalANDExpression(
+		f.NewLogicalANDExpression(
+			left.Left,
+			f.NewStrictInequalityExpression(f.NewAssignmentExpression(temp, left.Right), f.NewVoidZeroExpression()),
+		),
+		f.NewPropertyAccessExpression(temp, nil /*questionDotToken*/, n.Right.Clone(f), ast.NodeFlagsNone),
+	)
+}
+
+// Serializes an entity name as an expression for decorator type metadata.
+func (s *typeSerializer) serializeEntityNameAsExpression(node *ast.Node) *ast.Expression {
+	f := s.factory()
+	switch node.Kind {
+	case ast.KindIdentifier:
+		// Create a clone of the name that resolves to the same declaration as the type name, so that references to
+		// imports are rewritten by the module transforms.
+		name := node.Clone(f)
+		name.Loc = node.Loc
+		s.emitContext.SetOriginal(name, node)
+		return name
+	case ast.KindQualifiedName:
+		n := node.AsQualifiedName()
+		return f.NewPropertyAccessExpression(s.serializeEntityNameAsExpression(n.Left), nil /*questionDotToken*/, n.Right.Clone(f), ast.NodeFlagsNone)
+	}
+	panic("Unhandled entity name kind: " + node.Kind.String())
+}
+
+// Gets an expression that points to the global constructor `name`, guarding against it being unavailable at runtime
+// when targeting an older language version.
+func (s *typeSerializer) getGlobalConstructor(name string, minLanguageVersion core.ScriptTarget) *ast.Expression {
+	f := s.factory()
+	if s.languageVersion < minLanguageVersion {
+		return f.NewConditionalExpression(
+			s.newTypeCheck(f.NewIdentifier(name), "function"),
+			f.NewToken(ast.KindQuestionToken),
+			f.NewIdentifier(name),
+			f.NewToken(ast.KindColonToken),
+			f.NewIdentifier("Object"),
+		)
+	}
+	return f.NewIdentifier(name)
+}
+
+func getFirstConstructorWithBody(node *ast.Node) *ast.Node {
+	for _, member := range node.Members() {
+		if ast.IsConstructorDeclaration(member) && member.Body() != nil {
+			return member
+		}
+	}
+	return nil
+}
+
+func getParametersOfDecoratedDeclaration(node *ast.Node, container *ast.Node) []*ast.Node {
+	if container != nil && node.Kind == ast.KindGetAccessor {
+		if setAccessor := getAccessorOfKind(container, node, ast.KindSetAccessor); setAccessor != nil {
+			return setAccessor.Parameters()
+		}
+	}
+	return node.Parameters()
+}
+
+func getAccessorTypeNode(node *ast.Node, container *ast.Node) *ast.Node {
+	if ast.IsGetAccessorDeclaration(node) {
+		if node.Type() != nil {
+			return node.Type()
+		}
+		if setAccessor := getAccessorOfKind(container, node, ast.KindSetAccessor); setAccessor != nil {
+			return getSetAccessorTypeAnnotationNode(setAccessor)
+		}
+		return nil
+	}
+	if typeNode := getSetAccessorTypeAnnotationNode(node); typeNode != nil {
+		return typeNode
+	}
+	if getAccessor := getAccessorOfKind(container, node, ast.KindGetAccessor); getAccessor != nil {
+		return getAccessor.Type()
+	}
+	return nil
+}
+
+// Finds the accessor of the given kind that is paired with an accessor in a class.
+func getAccessorOfKind(container *ast.Node, accessor *ast.Node, kind ast.Kind) *ast.Node {
+	if container == nil {
+		return nil
+	}
+	name := accessor.Name()
+	isStatic := ast.IsStatic(accessor)
+	for _, member := range container.Members() {
+		if member.Kind == kind && ast.IsStatic(member) == isStatic && ast.IsPropertyNameLiteral(member.Name()) && ast.IsPropertyNameLiteral(name) && member.Name().Text() == name.Text() {
+			return member
+		}
+	}
+	return nil
+}
+
+func getSetAccessorTypeAnnotationNode(accessor *ast.Node) *ast.Node {
+	parameters := accessor.Parameters()
+	if len(parameters) > 0 {
+		parameter := parameters[0]
+		if len(parameters) == 2 && ast.IsThisParameter(parameter) {
+			parameter = parameters[1]
+		}
+		return parameter.Type()
+	}
+	return nil
+}
+
+func getRestParameterElementType(node *ast.Node) *ast.Node {
+	if node != nil {
+		switch node.Kind {
+		case ast.KindArrayType:
+			return node.AsArrayTypeNode().ElementType
+		case ast.KindTypeReference:
+			if typeArguments := node.TypeArguments(); len(typeArguments) == 1 {
+				return typeArguments[0]
+			}
+		}
+	}
+	return nil
+}
@@ -39,3 +39,18 @@ func isInstantiatedModule(node *ast.ModuleDeclarationNode, preserveConstEnums bo
 	return moduleState == ast.ModuleInstanceStateInstantiated ||
 		(preserveConstEnums && moduleState == ast.ModuleInstanceStateConstEnumOnly)
 }
+
+// Indicates whether an expression is reasonably free of side effects, and thus better to copy into multiple places
+// rather than to cache in a temporary variable.
+func isSimpleCopiableExpression(expression *ast.Expression) bool {
+	return ast.IsStringLiteralLike(expression) ||
+		ast.IsNumericLiteral(expression) ||
+		ast.IsKeywordKind(expression.Kind) ||
+		ast.IsIdentifier(expression)
+}
+
+// Indicates whether an expression can be copied into multiple locations without risk of repeating any side effects,
+// and whose value could not possibly change between any such locations.
+func isSimpleInlineableExpression(expression *ast.Expression) bool {
+	return !ast.IsIdentifier(expression) && isSimpleCopiableExpression(expression)
+}
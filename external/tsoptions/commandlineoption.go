@@ -86,6 +86,10 @@ func (o *CommandLineOption) Elements() *CommandLineOption {
 	return commandLineOptionElements[o.Name]
 }
 
+func (o *CommandLineOption) IsFilePath() bool {
+	return o.isFilePath
+}
+
 func (o *CommandLineOption) DisallowNullOrUndefined() bool {
 	return o.Name == "extends"
 }
@@ -67,6 +67,49 @@ func ParseCommandLine(
 	}
 }
 
+func ParseBuildCommandLine(
+	commandLine []string,
+	host ParseConfigHost,
+) *ParsedBuildCommandLine {
+	if commandLine == nil {
+		commandLine = []string{}
+	}
+	parser := parseCommandLineWorker(buildOptionsDidYouMeanDiagnostics, commandLine, host.FS())
+	optionsWithAbsolutePaths := convertToOptionsWithAbsolutePaths(parser.options, commandLineCompilerOptionsMap, host.GetCurrentDirectory())
+	compilerOptions := convertMapToOptions(optionsWithAbsolutePaths, &compilerOptionsParser{&core.CompilerOptions{}}).CompilerOptions
+	buildOptions := convertMapToOptions(optionsWithAbsolutePaths, &buildOptionsParser{&core.BuildOptions{}}).BuildOptions
+	watchOptions := convertMapToOptions(optionsWithAbsolutePaths, &watchOptionsParser{&core.WatchOptions{}}).WatchOptions
+
+	projects := parser.fileNames
+	if len(projects) == 0 {
+		// tsc -b invoked with no extra arguments; act as if invoked with "tsc -b ."
+		projects = []string{"."}
+	}
+
+	result := &ParsedBuildCommandLine{
+		BuildOptions:    buildOptions,
+		CompilerOptions: compilerOptions,
+		WatchOptions:    watchOptions,
+		Projects:        projects,
+		Errors:          parser.errors,
+	}
+
+	// Nonsensical combinations
+	if buildOptions.Clean.IsTrue() && buildOptions.Force.IsTrue() {
+		result.Errors = append(result.Errors, ast.NewCompilerDiagnostic(diagnostics.Options_0_and_1_cannot_be_combined, "clean", "force"))
+	}
+	if buildOptions.Clean.IsTrue() && buildOptions.Verbose.IsTrue() {
+		result.Errors = append(result.Errors, ast.NewCompilerDiagnostic(diagnostics.Options_0_and_1_cannot_be_combined, "clean", "verbose"))
+	}
+	if buildOptions.Clean.IsTrue() && compilerOptions.Watch.IsTrue() {
+		result.Errors = append(result.Errors, ast.NewCompilerDiagnostic(diagnostics.Options_0_and_1_cannot_be_combined, "clean", "watch"))
+	}
+	if compilerOptions.Watch.IsTrue() && buildOptions.Dry.IsTrue() {
+		result.Errors = append(result.Errors, ast.NewCompilerDiagnostic(diagnostics.Options_0_and_1_cannot_be_combined, "watch", "dry"))
+	}
+	return result
+}
+
 func parseCommandLineWorker(
 	parseCommandLineWithDiagnostics *ParseCommandLineWorkerDiagnostics,
 	commandLine []string,
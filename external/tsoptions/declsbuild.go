@@ -6,7 +6,7 @@ import (
 	"github.com/pagpeter/typescript-go/external/diagnostics"
 )
 
-var BuildOpts = slices.Concat(commonOptionsWithBuild, optionsForBuild)
+var BuildOpts = slices.Concat(optionsForCompiler, optionsForBuild)
 
 var TscBuildOption = CommandLineOption{
 	Name:                     "build",
@@ -236,3 +236,13 @@ var fallbackEnumMap = collections.NewOrderedMapFromList([]collections.MapEntry[s
 	{Key: "dynamicpriority", Value: core.PollingKindDynamicPriority},
 	{Key: "fixedchunksize", Value: core.PollingKindFixedChunkSize},
 })
+
+// GetNameOfScriptTarget returns the name of a target as written in compiler options, such as "es5".
+func GetNameOfScriptTarget(target core.ScriptTarget) string {
+	for name, value := range targetOptionMap.Entries() {
+		if value == target {
+			return name
+		}
+	}
+	return ""
+}
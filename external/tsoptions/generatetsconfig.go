@@ -0,0 +1,218 @@
+package tsoptions
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/pagpeter/typescript-go/external/collections"
+	"github.com/pagpeter/typescript-go/external/core"
+	"github.com/pagpeter/typescript-go/external/diagnostics"
+	"github.com/pagpeter/typescript-go/external/tspath"
+)
+
+// defaultInitCompilerOptions are the options enabled in the tsconfig.json written by --init, unless
+// they are given on the command line.
+func defaultInitCompilerOptions() *core.CompilerOptions {
+	return &core.CompilerOptions{
+		Module:                           core.ModuleKindCommonJS,
+		Target:                           core.ScriptTargetES2016,
+		Strict:                           core.TSTrue,
+		ESModuleInterop:                  core.TSTrue,
+		ForceConsistentCasingInFileNames: core.TSTrue,
+		SkipLibCheck:                     core.TSTrue,
+	}
+}
+
+// Categories of options that are only listed by --init when they are set.
+var categoriesToSkipForInit = []*diagnostics.Message{
+	diagnostics.Command_line_Options,
+	diagnostics.Editor_Support,
+	diagnostics.Compiler_Diagnostics,
+	diagnostics.Backwards_Compatibility,
+	diagnostics.Watch_and_Build_Modes,
+	diagnostics.Output_Formatting,
+}
+
+func getSerializedInitCompilerOptions(options *core.CompilerOptions, currentDirectory string) *collections.OrderedMap[string, any] {
+	initOptions := mergeCompilerOptions(defaultInitCompilerOptions(), options, nil /*rawSource*/)
+	// Paths given on the command line are already absolute; write them relative to the new config file.
+	configFilePath := tspath.CombinePaths(currentDirectory, "tsconfig.json")
+	return serializeOptions(initOptions, OptionsDeclarations, configFilePath, tspath.ComparePathsOptions{CurrentDirectory: currentDirectory})
+}
+
+// GenerateTSConfig returns the contents of the tsconfig.json written by --init: every option that
+// may be set in a config file, grouped by category and described, with those in options or in the
+// defaults for new projects enabled and the rest commented out.
+func GenerateTSConfig(options *core.CompilerOptions, fileNames []string, currentDirectory string, newLine string, locale *diagnostics.Locale) string {
+	compilerOptionsMap := getSerializedInitCompilerOptions(options, currentDirectory)
+
+	categorizedOptions := collections.NewOrderedMapFromList([]collections.MapEntry[*diagnostics.Message, []*CommandLineOption]{
+		{Key: diagnostics.Projects},
+		{Key: diagnostics.Language_and_Environment},
+		{Key: diagnostics.Modules},
+		{Key: diagnostics.JavaScript_Support},
+		{Key: diagnostics.Emit},
+		{Key: diagnostics.Interop_Constraints},
+		{Key: diagnostics.Type_Checking},
+		{Key: diagnostics.Completeness},
+	})
+	for _, option := range OptionsDeclarations {
+		if option.IsCommandLineOnly || option.Category == nil {
+			continue
+		}
+		if slices.Contains(categoriesToSkipForInit, option.Category) && !compilerOptionsMap.Has(option.Name) {
+			continue
+		}
+		categorizedOptions.Set(option.Category, append(categorizedOptions.GetOrZero(option.Category), option))
+	}
+
+	type entry struct {
+		value       string
+		description string
+	}
+	var entries []entry
+	marginLength := 0
+	seenKnownKeys := 0
+	for category, categoryOptions := range categorizedOptions.Entries() {
+		if len(entries) != 0 {
+			entries = append(entries, entry{})
+		}
+		entries = append(entries, entry{value: "/* " + category.Localize(locale) + " */"})
+		for _, option := range categoryOptions {
+			var optionName string
+			if value, ok := compilerOptionsMap.Get(option.Name); ok {
+				seenKnownKeys++
+				optionName = fmt.Sprintf("%q: %s", option.Name, stringifyInitValue(value))
+				if seenKnownKeys != compilerOptionsMap.Size() {
+					optionName += ","
+				}
+			} else {
+				optionName = fmt.Sprintf("// %q: %s,", option.Name, stringifyInitValue(getDefaultValueForOption(option)))
+			}
+			description := option.Name
+			if option.Description != nil {
+				description = option.Description.Localize(locale)
+			}
+			entries = append(entries, entry{value: optionName, description: "/* " + description + " */"})
+			marginLength = max(marginLength, len(optionName))
+		}
+	}
+
+	const tab = "  "
+	var result []string
+	result = append(result, "{")
+	result = append(result, tab+`"compilerOptions": {`)
+	result = append(result, tab+tab+"/* "+diagnostics.Visit_https_Colon_Slash_Slashaka_ms_Slashtsconfig_to_read_more_about_this_file.Localize(locale)+" */")
+	result = append(result, "")
+	// Print out each row, aligning all the descriptions on the same column.
+	for _, e := range entries {
+		switch {
+		case e.value == "":
+			result = append(result, "")
+		case e.description == "":
+			result = append(result, tab+tab+e.value)
+		default:
+			result = append(result, tab+tab+e.value+strings.Repeat(" ", marginLength-len(e.value)+2)+e.description)
+		}
+	}
+	if len(fileNames) != 0 {
+		result = append(result, tab+"},")
+		result = append(result, tab+`"files": [`)
+		for i, fileName := range fileNames {
+			line := tab + tab + stringifyInitValue(fileName)
+			if i != len(fileNames)-1 {
+				line += ","
+			}
+			result = append(result, line)
+		}
+		result = append(result, tab+"]")
+	} else {
+		result = append(result, tab+"}")
+	}
+	result = append(result, "}")
+	return strings.Join(result, newLine) + newLine
+}
+
+// GetCompilerOptionsDiffValue lists the options of the tsconfig.json written by --init that differ
+// from their defaults, as reported after the file is created.
+func GetCompilerOptionsDiffValue(options *core.CompilerOptions, currentDirectory string, newLine string) string {
+	compilerOptionsMap := getSerializedInitCompilerOptions(options, currentDirectory)
+	defaultInitOptions := getSerializedInitCompilerOptions(&core.CompilerOptions{}, currentDirectory)
+	var result []string
+	for _, option := range OptionsDeclarations {
+		newValue, ok := compilerOptionsMap.Get(option.Name)
+		if !ok {
+			continue
+		}
+		defaultValue := getDefaultValueForOption(option)
+		if !isSameInitValue(newValue, defaultValue) {
+			result = append(result, "  "+option.Name+": "+formatInitValue(newValue))
+		} else if defaultInitOptions.Has(option.Name) {
+			result = append(result, "  "+option.Name+": "+formatInitValue(defaultValue))
+		}
+	}
+	return strings.Join(result, newLine) + newLine
+}
+
+// getDefaultValueForOption returns the placeholder value of an option that is commented out.
+func getDefaultValueForOption(option *CommandLineOption) any {
+	switch option.Kind {
+	case CommandLineOptionTypeNumber:
+		return 1
+	case CommandLineOptionTypeBoolean:
+		return true
+	case CommandLineOptionTypeString:
+		if option.isFilePath {
+			if defaultValue, ok := option.DefaultValueDescription.(string); ok {
+				return "./" + defaultValue
+			}
+			return "./"
+		}
+		return ""
+	case CommandLineOptionTypeList:
+		return []string{}
+	case CommandLineOptionTypeListOrElement:
+		return getDefaultValueForOption(option.Elements())
+	case CommandLineOptionTypeObject:
+		return map[string]any{}
+	case CommandLineOptionTypeEnum:
+		for name := range option.EnumMap().Keys() {
+			return name
+		}
+	}
+	panic("Expected option " + option.Name + " to have a default value")
+}
+
+func stringifyInitValue(value any) string {
+	var b bytes.Buffer
+	encoder := json.NewEncoder(&b)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		panic(err)
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// formatInitValue formats a value the way it is shown in the summary printed by --init, where
+// lists are comma separated.
+func formatInitValue(value any) string {
+	switch v := value.(type) {
+	case []string:
+		return strings.Join(v, ",")
+	case []any:
+		return strings.Join(core.Map(v, func(element any) string { return fmt.Sprint(element) }), ",")
+	}
+	return fmt.Sprint(value)
+}
+
+func isSameInitValue(a, b any) bool {
+	switch a.(type) {
+	case bool, int, string:
+		return a == b
+	}
+	// Lists and objects are never the same as the placeholder value.
+	return false
+}
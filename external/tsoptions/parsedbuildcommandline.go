@@ -0,0 +1,15 @@
+package tsoptions
+
+import (
+	"github.com/pagpeter/typescript-go/external/ast"
+	"github.com/pagpeter/typescript-go/external/core"
+)
+
+// ParsedBuildCommandLine is the result of parsing the arguments following `tsc --build`.
+type ParsedBuildCommandLine struct {
+	BuildOptions    *core.BuildOptions    `json:"buildOptions"`
+	CompilerOptions *core.CompilerOptions `json:"compilerOptions"`
+	WatchOptions    *core.WatchOptions    `json:"watchOptions"`
+	Projects        []string              `json:"projects"`
+	Errors          []*ast.Diagnostic     `json:"errors"`
+}
@@ -3,6 +3,7 @@ package tsoptions
 import (
 	"iter"
 	"slices"
+	"strings"
 	"sync"
 
 	"github.com/pagpeter/typescript-go/external/ast"
@@ -269,6 +270,51 @@ func (p *ParsedCommandLine) MatchesFileName(fileName string) bool {
 	return p.ConfigFile.configFileSpecs.matchesInclude(fileName, p.comparePathsOptions)
 }
 
+// Reports whether fileName is matched by one of the `include` patterns, regardless of `exclude`
+func (p *ParsedCommandLine) MatchesIncludeSpecs(fileName string) bool {
+	return p.ConfigFile != nil && p.ConfigFile.configFileSpecs.matchesInclude(fileName, p.comparePathsOptions)
+}
+
+// GetMatchedFileSpec returns the entry of the `files` list that names fileName, or "" if there is none.
+func (p *ParsedCommandLine) GetMatchedFileSpec(fileName string) string {
+	if p.ConfigFile == nil {
+		return ""
+	}
+	path := tspath.ToPath(fileName, p.GetCurrentDirectory(), p.UseCaseSensitiveFileNames())
+	for _, spec := range p.ConfigFile.configFileSpecs.validatedFilesSpec {
+		if tspath.ToPath(spec, p.GetCurrentDirectory(), p.UseCaseSensitiveFileNames()) == path {
+			return spec
+		}
+	}
+	return ""
+}
+
+// GetMatchedIncludeSpec returns the `include` pattern that matches fileName, as written in the config
+// file, or "" if there is none.
+// isDefault reports that the config file has no `include` and so includes everything by default.
+func (p *ParsedCommandLine) GetMatchedIncludeSpec(fileName string) (spec string, isDefault bool) {
+	if p.ConfigFile == nil || len(p.ConfigFile.configFileSpecs.validatedIncludeSpecs) == 0 {
+		return "", false
+	}
+	if p.ConfigFile.configFileSpecs.isDefaultIncludeSpec {
+		return "", true
+	}
+	isJsonFile := tspath.FileExtensionIs(fileName, tspath.ExtensionJson)
+	for i, spec := range p.ConfigFile.configFileSpecs.validatedIncludeSpecs {
+		if isJsonFile && !strings.HasSuffix(spec, tspath.ExtensionJson) {
+			continue
+		}
+		pattern := vfs.GetPatternFromSpec(spec, p.GetCurrentDirectory(), "files")
+		if pattern == "" {
+			continue
+		}
+		if match, err := vfs.GetRegexFromPattern(pattern, p.UseCaseSensitiveFileNames()).MatchString(fileName); err == nil && match {
+			return p.ConfigFile.configFileSpecs.validatedIncludeSpecsBeforeSubstitution[i], false
+		}
+	}
+	return "", false
+}
+
 func ReloadFileNamesOfParsedCommandLine(p *ParsedCommandLine, fs vfs.FS) *ParsedCommandLine {
 	parsedConfig := *p.ParsedConfig
 	parsedConfig.FileNames = getFileNamesFromConfigSpecs(
@@ -26,6 +26,10 @@ func parseTristate(value any) core.Tristate {
 }
 
 func parseStringArray(value any) []string {
+	// List options parsed from the command line are already []string.
+	if arr, ok := value.([]string); ok {
+		return arr
+	}
 	if arr, ok := value.([]any); ok {
 		if arr == nil {
 			return nil
@@ -132,6 +136,18 @@ func (o *compilerOptionsParser) UnknownOptionDiagnostic() *diagnostics.Message {
 	return extraKeyDiagnostics("compilerOptions")
 }
 
+type buildOptionsParser struct {
+	*core.BuildOptions
+}
+
+func (o *buildOptionsParser) ParseOption(key string, value any) []*ast.Diagnostic {
+	return ParseBuildOptions(key, value, o.BuildOptions)
+}
+
+func (o *buildOptionsParser) UnknownOptionDiagnostic() *diagnostics.Message {
+	return diagnostics.Unknown_build_option_0
+}
+
 type watchOptionsParser struct {
 	*core.WatchOptions
 }
@@ -469,6 +485,28 @@ func ParseWatchOptions(key string, value any, allOptions *core.WatchOptions) []*
 	return nil
 }
 
+func ParseBuildOptions(key string, value any, allOptions *core.BuildOptions) []*ast.Diagnostic {
+	if value == nil {
+		return nil
+	}
+	if allOptions == nil {
+		return nil
+	}
+	switch key {
+	case "dry":
+		allOptions.Dry = parseTristate(value)
+	case "force":
+		allOptions.Force = parseTristate(value)
+	case "verbose":
+		allOptions.Verbose = parseTristate(value)
+	case "clean":
+		allOptions.Clean = parseTristate(value)
+	case "stopBuildOnErrors":
+		allOptions.StopBuildOnErrors = parseTristate(value)
+	}
+	return nil
+}
+
 func ParseTypeAcquisition(key string, value any, allOptions *core.TypeAcquisition) []*ast.Diagnostic {
 	if value == nil {
 		return nil
@@ -0,0 +1,240 @@
+package tsoptions
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/dlclark/regexp2"
+	"github.com/pagpeter/typescript-go/external/collections"
+	"github.com/pagpeter/typescript-go/external/core"
+	"github.com/pagpeter/typescript-go/external/diagnostics"
+	"github.com/pagpeter/typescript-go/external/tspath"
+	"github.com/pagpeter/typescript-go/external/vfs"
+)
+
+// ConvertToTSConfig converts a parsed command line back into the equivalent tsconfig.json, as
+// printed by --showConfig. Options are written under their declared names, with enum values mapped
+// back to their names and paths made relative to the config file. Options implied by those that
+// were set, such as strictNullChecks by strict, are written too.
+func ConvertToTSConfig(configParseResult *ParsedCommandLine, configFileName string, host ParseConfigHost) *collections.OrderedMap[string, any] {
+	comparePathsOptions := tspath.ComparePathsOptions{
+		UseCaseSensitiveFileNames: host.FS().UseCaseSensitiveFileNames(),
+		CurrentDirectory:          host.GetCurrentDirectory(),
+	}
+	configFilePath := tspath.GetNormalizedAbsolutePath(configFileName, host.GetCurrentDirectory())
+
+	var specs *configFileSpecs
+	if configParseResult.ConfigFile != nil {
+		specs = configParseResult.ConfigFile.configFileSpecs
+	}
+	isListedInFiles := func(string) bool { return true }
+	if specs != nil {
+		isListedInFiles = matchesSpecs(configFilePath, specs.validatedIncludeSpecs, specs.validatedExcludeSpecs, comparePathsOptions.UseCaseSensitiveFileNames)
+	}
+	var files []string
+	for _, fileName := range configParseResult.FileNames() {
+		if isListedInFiles(fileName) {
+			files = append(files, tspath.GetRelativePathFromFile(configFilePath, tspath.GetNormalizedAbsolutePath(fileName, host.GetCurrentDirectory()), comparePathsOptions))
+		}
+	}
+
+	options := configParseResult.CompilerOptions()
+	compilerOptions := serializeOptions(options, OptionsDeclarations, configFilePath, comparePathsOptions)
+	var providedKeys collections.Set[string]
+	for name := range compilerOptions.Keys() {
+		providedKeys.Add(name)
+	}
+	for _, name := range []string{"showConfig", "help", "init", "listFiles", "listEmittedFiles", "project", "build", "version"} {
+		compilerOptions.Delete(name)
+	}
+	defaultOptions := &core.CompilerOptions{}
+	for _, computed := range computedOptions {
+		if providedKeys.Has(computed.name) || !slices.ContainsFunc(computed.dependencies, providedKeys.Has) {
+			continue
+		}
+		if implied := computed.computeValue(options); implied != computed.computeValue(defaultOptions) {
+			compilerOptions.Set(computed.name, serializeOptionValue(CompilerNameMap.Get(computed.name), implied, "" /*configFilePath*/, comparePathsOptions))
+		}
+	}
+
+	config := collections.NewOrderedMapWithSizeHint[string, any](7)
+	config.Set("compilerOptions", compilerOptions)
+	if watchOptions := configParseResult.ParsedConfig.WatchOptions; watchOptions != nil {
+		if watchOptionMap := serializeOptions(watchOptions, optionsForWatch, "" /*configFilePath*/, comparePathsOptions); watchOptionMap.Size() != 0 {
+			config.Set("watchOptions", watchOptionMap)
+		}
+	}
+	if references := configParseResult.ProjectReferences(); len(references) != 0 {
+		config.Set("references", core.Map(references, func(ref *core.ProjectReference) *collections.OrderedMap[string, any] {
+			reference := collections.NewOrderedMapWithSizeHint[string, any](2)
+			reference.Set("path", ref.OriginalPath)
+			if ref.Circular {
+				reference.Set("circular", true)
+			}
+			return reference
+		}))
+	}
+	if len(files) != 0 {
+		config.Set("files", files)
+	}
+	if specs != nil {
+		if include := specs.validatedIncludeSpecs; len(include) > 1 || len(include) == 1 && include[0] != defaultIncludeSpec {
+			config.Set("include", include)
+		}
+		if specs.validatedExcludeSpecs != nil {
+			config.Set("exclude", specs.validatedExcludeSpecs)
+		}
+	}
+	if configParseResult.CompileOnSave != nil && *configParseResult.CompileOnSave {
+		config.Set("compileOnSave", true)
+	}
+	return config
+}
+
+// matchesSpecs returns whether a file of the program is not accounted for by the include and
+// exclude specs, and so must be listed in files. As in tsc, specs are resolved against the path of
+// the config file itself, so in practice only rooted specs match.
+func matchesSpecs(configFilePath string, includeSpecs []string, excludeSpecs []string, useCaseSensitiveFileNames bool) func(fileName string) bool {
+	if len(includeSpecs) == 0 {
+		return func(string) bool { return true }
+	}
+	var includeRegex, excludeRegex *regexp2.Regexp
+	if pattern := vfs.GetRegularExpressionForWildcard(includeSpecs, configFilePath, "files"); pattern != "" {
+		includeRegex = vfs.GetRegexFromPattern(pattern, useCaseSensitiveFileNames)
+	}
+	if pattern := vfs.GetRegularExpressionForWildcard(excludeSpecs, configFilePath, "exclude"); pattern != "" {
+		excludeRegex = vfs.GetRegexFromPattern(pattern, useCaseSensitiveFileNames)
+	}
+	matches := func(regex *regexp2.Regexp, fileName string) bool {
+		match, err := regex.MatchString(fileName)
+		return err == nil && match
+	}
+	return func(fileName string) bool {
+		switch {
+		case includeRegex != nil && excludeRegex != nil:
+			return !matches(includeRegex, fileName) || matches(excludeRegex, fileName)
+		case includeRegex != nil:
+			return !matches(includeRegex, fileName)
+		case excludeRegex != nil:
+			return matches(excludeRegex, fileName)
+		}
+		return true
+	}
+}
+
+// serializeOptions writes the options that are set in options, which is a *core.CompilerOptions or
+// a *core.WatchOptions, in the order of their declarations. File paths are made relative to
+// configFilePath when it is given.
+func serializeOptions(options any, declarations []*CommandLineOption, configFilePath string, comparePathsOptions tspath.ComparePathsOptions) *collections.OrderedMap[string, any] {
+	optionsValue := reflect.ValueOf(options).Elem()
+	fields := make(map[string]reflect.Value, optionsValue.NumField())
+	for i := range optionsValue.NumField() {
+		if name, _, _ := strings.Cut(optionsValue.Type().Field(i).Tag.Get("json"), ","); name != "" {
+			fields[name] = optionsValue.Field(i)
+		}
+	}
+
+	result := collections.NewOrderedMapWithSizeHint[string, any](len(fields))
+	for _, option := range declarations {
+		if option.Category == diagnostics.Command_line_Options || option.Category == diagnostics.Output_Formatting {
+			continue
+		}
+		field, ok := fields[option.Name]
+		if !ok || field.IsZero() {
+			continue
+		}
+		var value any
+		switch v := field.Interface().(type) {
+		case core.Tristate:
+			value = v.IsTrue()
+		case *int:
+			value = *v
+		default:
+			value = v
+		}
+		result.Set(option.Name, serializeOptionValue(option, value, configFilePath, comparePathsOptions))
+	}
+	return result
+}
+
+func serializeOptionValue(option *CommandLineOption, value any, configFilePath string, comparePathsOptions tspath.ComparePathsOptions) any {
+	relativePath := func(fileName string) string {
+		return tspath.GetRelativePathFromFile(configFilePath, tspath.GetNormalizedAbsolutePath(fileName, tspath.GetDirectoryPath(configFilePath)), comparePathsOptions)
+	}
+	switch {
+	case option.Kind == CommandLineOptionTypeEnum:
+		return getNameOfOptionValue(option.EnumMap(), value)
+	case option.Kind == CommandLineOptionTypeList && option.Elements().Kind == CommandLineOptionTypeEnum:
+		return core.Map(value.([]string), func(element string) any {
+			return getNameOfOptionValue(option.Elements().EnumMap(), element)
+		})
+	case configFilePath != "" && option.isFilePath:
+		return relativePath(value.(string))
+	case configFilePath != "" && option.Kind == CommandLineOptionTypeList && option.Elements().isFilePath:
+		return core.Map(value.([]string), relativePath)
+	}
+	return value
+}
+
+// getNameOfOptionValue returns the first name that maps to value, as written in a tsconfig.json.
+func getNameOfOptionValue(enumMap *collections.OrderedMap[string, any], value any) any {
+	for name, v := range enumMap.Entries() {
+		if v == value {
+			return name
+		}
+	}
+	return value
+}
+
+// computedOption is an option whose effective value, when it is not set, is computed from the
+// options it depends on.
+type computedOption struct {
+	name         string
+	dependencies []string
+	computeValue func(options *core.CompilerOptions) any
+}
+
+func getStrictOptionValue(options *core.CompilerOptions, value core.Tristate) bool {
+	if value != core.TSUnknown {
+		return value == core.TSTrue
+	}
+	return options.Strict == core.TSTrue
+}
+
+func strictComputedOption(name string, getValue func(options *core.CompilerOptions) core.Tristate) *computedOption {
+	return &computedOption{
+		name:         name,
+		dependencies: []string{"strict"},
+		computeValue: func(options *core.CompilerOptions) any {
+			return getStrictOptionValue(options, getValue(options))
+		},
+	}
+}
+
+var computedOptions = []*computedOption{
+	{"target", []string{"module"}, func(o *core.CompilerOptions) any { return o.GetEmitScriptTarget() }},
+	{"module", []string{"target"}, func(o *core.CompilerOptions) any { return o.GetEmitModuleKind() }},
+	{"moduleResolution", []string{"module", "target"}, func(o *core.CompilerOptions) any { return o.GetModuleResolutionKind() }},
+	{"moduleDetection", []string{"module", "target"}, func(o *core.CompilerOptions) any { return o.GetEmitModuleDetectionKind() }},
+	{"isolatedModules", []string{"verbatimModuleSyntax"}, func(o *core.CompilerOptions) any { return o.GetIsolatedModules() }},
+	{"esModuleInterop", []string{"module", "target"}, func(o *core.CompilerOptions) any { return o.GetESModuleInterop() }},
+	{"allowSyntheticDefaultImports", []string{"module", "target", "moduleResolution"}, func(o *core.CompilerOptions) any { return o.GetAllowSyntheticDefaultImports() }},
+	{"resolvePackageJsonExports", []string{"moduleResolution"}, func(o *core.CompilerOptions) any { return o.GetResolvePackageJsonExports() }},
+	{"resolvePackageJsonImports", []string{"moduleResolution", "resolvePackageJsonExports"}, func(o *core.CompilerOptions) any { return o.GetResolvePackageJsonImports() }},
+	{"resolveJsonModule", []string{"moduleResolution", "module", "target"}, func(o *core.CompilerOptions) any { return o.GetResolveJsonModule() }},
+	{"declaration", []string{"composite"}, func(o *core.CompilerOptions) any { return o.GetEmitDeclarations() }},
+	{"incremental", []string{"composite"}, func(o *core.CompilerOptions) any { return o.IsIncremental() }},
+	{"declarationMap", []string{"declaration", "composite"}, func(o *core.CompilerOptions) any { return o.GetAreDeclarationMapsEnabled() }},
+	{"allowJs", []string{"checkJs"}, func(o *core.CompilerOptions) any { return o.GetAllowJS() }},
+	{"useDefineForClassFields", []string{"target", "module"}, func(o *core.CompilerOptions) any { return o.GetEmitStandardClassFields() }},
+	strictComputedOption("noImplicitAny", func(o *core.CompilerOptions) core.Tristate { return o.NoImplicitAny }),
+	strictComputedOption("noImplicitThis", func(o *core.CompilerOptions) core.Tristate { return o.NoImplicitThis }),
+	strictComputedOption("strictNullChecks", func(o *core.CompilerOptions) core.Tristate { return o.StrictNullChecks }),
+	strictComputedOption("strictFunctionTypes", func(o *core.CompilerOptions) core.Tristate { return o.StrictFunctionTypes }),
+	strictComputedOption("strictBindCallApply", func(o *core.CompilerOptions) core.Tristate { return o.StrictBindCallApply }),
+	strictComputedOption("strictPropertyInitialization", func(o *core.CompilerOptions) core.Tristate { return o.StrictPropertyInitialization }),
+	strictComputedOption("strictBuiltinIteratorReturn", func(o *core.CompilerOptions) core.Tristate { return o.StrictBuiltinIteratorReturn }),
+	strictComputedOption("alwaysStrict", func(o *core.CompilerOptions) core.Tristate { return o.AlwaysStrict }),
+	strictComputedOption("useUnknownInCatchVariables", func(o *core.CompilerOptions) core.Tristate { return o.UseUnknownInCatchVariables }),
+}
@@ -96,6 +96,8 @@ type configFileSpecs struct {
 	validatedIncludeSpecs []string
 	validatedExcludeSpecs []string
 	isDefaultIncludeSpec  bool
+	// The include specs as written, before ${configDir} is substituted, for reporting which one matched a file
+	validatedIncludeSpecsBeforeSubstitution []string
 }
 
 func (c *configFileSpecs) matchesExclude(fileName string, comparePathsOptions tspath.ComparePathsOptions) bool {
@@ -1188,6 +1190,7 @@ func parseJsonConfigFileContentWorker(
 		isDefaultIncludeSpec = true
 	}
 	var validatedIncludeSpecs []string
+	var validatedIncludeSpecsBeforeSubstitution []string
 	var validatedExcludeSpecs []string
 	var validatedFilesSpec []string
 	// The exclude spec list is converted into a regular expression, which allows us to quickly
@@ -1197,6 +1200,7 @@ func parseJsonConfigFileContentWorker(
 		var err []*ast.Diagnostic
 		validatedIncludeSpecs, err = validateSpecs(includeSpecs.sliceValue, true /*disallowTrailingRecursion*/, tsconfigToSourceFile(sourceFile), "include")
 		errors = append(errors, err...)
+		validatedIncludeSpecsBeforeSubstitution = slices.Clone(validatedIncludeSpecs)
 		substituteStringArrayWithConfigDirTemplate(validatedIncludeSpecs, basePathForFileNames)
 	}
 	if excludeSpecs.sliceValue != nil {
@@ -1222,6 +1226,7 @@ func parseJsonConfigFileContentWorker(
 		validatedIncludeSpecs,
 		validatedExcludeSpecs,
 		isDefaultIncludeSpec,
+		validatedIncludeSpecsBeforeSubstitution,
 	}
 
 	if sourceFile != nil {
@@ -0,0 +1,112 @@
+//// [tests/cases/compiler/asyncFunctionDownlevelES2016.ts] ////
+
+//// [asyncFunctionDownlevelES2016.ts]
+declare const z: number;
+
+async function f1() {
+    await 1;
+}
+
+async function f2(x: number, y: number) {
+    return await Promise.resolve(x + y);
+}
+
+async function f3(x = z, ...rest: number[]) {
+    return () => arguments;
+}
+
+async function f4(x: number) {
+    var x = 1;
+    for (var x of [2]) { }
+    try { } catch (x) { var x = 3; }
+}
+
+function f5() {
+    return async (x = z) => arguments;
+}
+
+const a1 = async () => this;
+const a2 = async (x: number) => { await x; };
+const a3 = async (x: number, y = z) => { };
+
+const o = {
+    async m() {
+        return this;
+    }
+};
+
+class C {
+    async m(x: number) {
+        await x;
+    }
+    static async s() {
+        return this;
+    }
+}
+
+
+//// [asyncFunctionDownlevelES2016.js]
+var __awaiter = (this && this.__awaiter) || function (thisArg, _arguments, P, generator) {
+    function adopt(value) { return value instanceof P ? value : new P(function (resolve) { resolve(value); }); }
+    return new (P || (P = Promise))(function (resolve, reject) {
+        function fulfilled(value) { try { step(generator.next(value)); } catch (e) { reject(e); } }
+        function rejected(value) { try { step(generator["throw"](value)); } catch (e) { reject(e); } }
+        function step(result) { result.done ? resolve(result.value) : adopt(result.value).then(fulfilled, rejected); }
+        step((generator = generator.apply(thisArg, _arguments || [])).next());
+    });
+};
+function f1() {
+    return __awaiter(this, void 0, void 0, function* () {
+        yield 1;
+    });
+}
+function f2(x, y) {
+    return __awaiter(this, void 0, void 0, function* () {
+        return yield Promise.resolve(x + y);
+    });
+}
+function f3() {
+    var arguments_1 = arguments;
+    return __awaiter(this, arguments, void 0, function* (x = z, ...rest) {
+        return () => arguments_1;
+    });
+}
+function f4(x) {
+    return __awaiter(this, void 0, void 0, function* () {
+        var x, x;
+        x = 1;
+        for (x of [2]) { }
+        try { }
+        catch (x) {
+            var x = 3;
+        }
+    });
+}
+function f5() {
+    return (...args_1) => {
+        var arguments_2 = arguments;
+        return __awaiter(this, [...args_1], void 0, function* (x = z) { return arguments_2; });
+    };
+}
+const a1 = () => __awaiter(this, void 0, void 0, function* () { return this; });
+const a2 = (x) => __awaiter(this, void 0, void 0, function* () { yield x; });
+const a3 = (x_1, ...args_1) => __awaiter(this, [x_1, ...args_1], void 0, function* (x, y = z) { });
+const o = {
+    m() {
+        return __awaiter(this, void 0, void 0, function* () {
+            return this;
+        });
+    }
+};
+class C {
+    m(x) {
+        return __awaiter(this, void 0, void 0, function* () {
+            yield x;
+        });
+    }
+    static s() {
+        return __awaiter(this, void 0, void 0, function* () {
+            return this;
+        });
+    }
+}
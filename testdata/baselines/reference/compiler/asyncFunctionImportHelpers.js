@@ -0,0 +1,21 @@
+//// [tests/cases/compiler/asyncFunctionImportHelpers.ts] ////
+
+//// [main.ts]
+export async function f() {
+    await 1;
+}
+
+//// [index.d.ts]
+export declare function __awaiter(thisArg: any, _arguments: any, P: Function, generator: Function): any;
+
+
+//// [main.js]
+"use strict";
+Object.defineProperty(exports, "__esModule", { value: true });
+const tslib_1 = require("tslib");
+exports.f = f;
+function f() {
+    return tslib_1.__awaiter(this, void 0, void 0, function* () {
+        yield 1;
+    });
+}
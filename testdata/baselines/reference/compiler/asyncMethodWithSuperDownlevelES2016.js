@@ -0,0 +1,81 @@
+//// [tests/cases/compiler/asyncMethodWithSuperDownlevelES2016.ts] ////
+
+//// [asyncMethodWithSuperDownlevelES2016.ts]
+class A {
+    x() { }
+    y() { }
+}
+
+class B extends A {
+    async simple() {
+        super.x();
+        super["y"]();
+        const a = super.x;
+    }
+
+    async advanced() {
+        const f = () => { };
+        super.x = f;
+        super["x"] = f;
+        ({ f: super.x } = { f });
+        (async () => super.x());
+    }
+
+    nonAsync() {
+        const f = async () => super.y();
+        return super.x;
+    }
+}
+
+
+//// [asyncMethodWithSuperDownlevelES2016.js]
+var __awaiter = (this && this.__awaiter) || function (thisArg, _arguments, P, generator) {
+    function adopt(value) { return value instanceof P ? value : new P(function (resolve) { resolve(value); }); }
+    return new (P || (P = Promise))(function (resolve, reject) {
+        function fulfilled(value) { try { step(generator.next(value)); } catch (e) { reject(e); } }
+        function rejected(value) { try { step(generator["throw"](value)); } catch (e) { reject(e); } }
+        function step(result) { result.done ? resolve(result.value) : adopt(result.value).then(fulfilled, rejected); }
+        step((generator = generator.apply(thisArg, _arguments || [])).next());
+    });
+};
+class A {
+    x() { }
+    y() { }
+}
+class B extends A {
+    simple() {
+        const _superIndex = name => super[name];
+        const _super = Object.create(null, {
+            x: { get: () => super.x }
+        });
+        return __awaiter(this, void 0, void 0, function* () {
+            _super.x.call(this);
+            _superIndex("y").call(this);
+            const a = _super.x;
+        });
+    }
+    advanced() {
+        const _superIndex = (function (geti, seti) {
+            const cache = Object.create(null);
+            return name => cache[name] || (cache[name] = { get value() { return geti(name); }, set value(v) { seti(name, v); } });
+        })(name => super[name], (name, value) => super[name] = value);
+        const _super = Object.create(null, {
+            x: { get: () => super.x, set: v => super.x = v }
+        });
+        return __awaiter(this, void 0, void 0, function* () {
+            const f = () => { };
+            _super.x = f;
+            _superIndex("x").value = f;
+            ({ f: _super.x } = { f });
+            (() => __awaiter(this, void 0, void 0, function* () { return _super.x.call(this); }));
+        });
+    }
+    nonAsync() {
+        const _super = Object.create(null, {
+            y: { get: () => super.y },
+            x: { get: () => super.x }
+        });
+        const f = () => __awaiter(this, void 0, void 0, function* () { return _super.y.call(this); });
+        return _super.x;
+    }
+}
@@ -0,0 +1,118 @@
+//// [tests/cases/compiler/classFieldsDownlevelES2020.ts] ////
+
+//// [classFieldsDownlevelES2020.ts]
+declare function key(): "k";
+
+class Base {
+    constructor(public a: number) { }
+}
+
+class Fields extends Base {
+    x = 1;
+    y: string;
+    [key()] = 2;
+    ["literal"] = 3;
+    static s = 4;
+    static t = this.s + 1;
+    static u = super.toString();
+
+    constructor(a: number, private b: number) {
+        console.log("before");
+        super(a);
+        console.log("after");
+    }
+
+    method() {
+        return this.x;
+    }
+}
+
+class NoConstructor extends Base {
+    x = this.a;
+}
+
+class TrySuper extends Base {
+    x = 1;
+    constructor() {
+        try {
+            super(1);
+        }
+        finally {
+        }
+    }
+}
+
+const Expr = class {
+    static s = 1;
+    x = 2;
+};
+
+const Named = class N {
+    static self = N;
+};
+
+export default class {
+    static s = 1;
+}
+
+
+//// [classFieldsDownlevelES2020.js]
+var __setFunctionName = (this && this.__setFunctionName) || function (f, name, prefix) {
+    if (typeof name === "symbol") name = name.description ? "[".concat(name.description, "]") : "";
+    return Object.defineProperty(f, "name", { configurable: true, value: prefix ? "".concat(prefix, " ", name) : name });
+};
+var _a, _b, _c, _d, _e;
+class Base {
+    constructor(a) {
+        this.a = a;
+    }
+}
+class Fields extends (_b = Base) {
+    constructor(a, b) {
+        console.log("before");
+        super(a);
+        this.b = b;
+        this.x = 1;
+        this[_c] = 2;
+        this["literal"] = 3;
+        console.log("after");
+    }
+    method() {
+        return this.x;
+    }
+}
+_a = Fields, _c = key();
+Fields.s = 4;
+Fields.t = _a.s + 1;
+Fields.u = Reflect.get(_b, "toString", _a).call(_a);
+class NoConstructor extends Base {
+    constructor() {
+        super(...arguments);
+        this.x = this.a;
+    }
+}
+class TrySuper extends Base {
+    constructor() {
+        try {
+            super(1);
+            this.x = 1;
+        }
+        finally {
+        }
+    }
+}
+const Expr = (_d = class {
+        constructor() {
+            this.x = 2;
+        }
+    },
+    __setFunctionName(_d, "Expr"),
+    _d.s = 1,
+    _d);
+const Named = (_e = class N {
+    },
+    _e.self = N,
+    _e);
+export default class default_1 {
+}
+default_1.s = 1;
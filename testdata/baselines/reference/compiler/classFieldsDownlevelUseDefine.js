@@ -0,0 +1,72 @@
+//// [tests/cases/compiler/classFieldsDownlevelUseDefine.ts] ////
+
+//// [classFieldsDownlevelUseDefine.ts]
+declare function key(): "k";
+
+class C {
+    x = 1;
+    y: string;
+    [key()] = 2;
+    #p = 3;
+    static s = 4;
+    static #q = 5;
+
+    constructor(public z: number) {
+    }
+}
+
+class D extends C {
+    w = 6;
+}
+
+
+//// [classFieldsDownlevelUseDefine.js]
+var _a, _C_p, _C_q, _b;
+class C {
+    constructor(z) {
+        Object.defineProperty(this, "z", {
+            enumerable: true,
+            configurable: true,
+            writable: true,
+            value: z
+        });
+        Object.defineProperty(this, "x", {
+            enumerable: true,
+            configurable: true,
+            writable: true,
+            value: 1
+        });
+        Object.defineProperty(this, "y", {
+            enumerable: true,
+            configurable: true,
+            writable: true,
+            value: void 0
+        });
+        Object.defineProperty(this, _b, {
+            enumerable: true,
+            configurable: true,
+            writable: true,
+            value: 2
+        });
+        _C_p.set(this, 3);
+    }
+}
+_a = C, _C_p = new WeakMap(), _b = key();
+Object.defineProperty(C, "s", {
+    enumerable: true,
+    configurable: true,
+    writable: true,
+    value: 4
+});
+_C_q = { value: 5 };
+class D extends C {
+    constructor() {
+        super(...arguments);
+        Object.defineProperty(this, "w", {
+            enumerable: true,
+            configurable: true,
+            writable: true,
+            value: 6
+        });
+    }
+}
@@ -0,0 +1,83 @@
+//// [tests/cases/compiler/classStaticBlockDownlevelES2020.ts] ////
+
+//// [classStaticBlockDownlevelES2020.ts]
+class C {
+    static x = 1;
+    static {
+        var y = this.x;
+        C.x = y + 1;
+    }
+    static z = 2;
+    static {
+        const f = () => this.z;
+        function g(this: any) { return this; }
+    }
+}
+
+class D extends C {
+    static {
+        console.log(super.x, super["z"]);
+        super.x = 3;
+    }
+}
+
+const E = class {
+    static {
+        console.log(this);
+    }
+};
+
+const F = class {
+    static #p = 1;
+    static {
+        console.log(F.#p);
+    }
+};
+
+
+//// [classStaticBlockDownlevelES2020.js]
+var __setFunctionName = (this && this.__setFunctionName) || function (f, name, prefix) {
+    if (typeof name === "symbol") name = name.description ? "[".concat(name.description, "]") : "";
+    return Object.defineProperty(f, "name", { configurable: true, value: prefix ? "".concat(prefix, " ", name) : name });
+};
+var __classPrivateFieldGet = (this && this.__classPrivateFieldGet) || function (receiver, state, kind, f) {
+    if (kind === "a" && !f) throw new TypeError("Private accessor was defined without a getter");
+    if (typeof state === "function" ? receiver !== state || !f : !state.has(receiver)) throw new TypeError("Cannot read private member from an object whose class did not declare it");
+    return kind === "m" ? f : kind === "a" ? f.call(receiver) : f ? f.value : state.get(receiver);
+};
+var _a, _b, _c, _d, _e, _p;
+class C {
+}
+_a = C;
+C.x = 1;
+(() => {
+    var y = _a.x;
+    C.x = y + 1;
+})();
+C.z = 2;
+(() => {
+    const f = () => _a.z;
+    function g() { return this; }
+})();
+class D extends (_c = C) {
+}
+_b = D;
+(() => {
+    console.log(Reflect.get(_c, "x", _b), Reflect.get(_c, "z", _b));
+    Reflect.set(_c, "x", 3, _b);
+})();
+const E = (_d = class {
+    },
+    __setFunctionName(_d, "E"),
+    (() => {
+        console.log(_d);
+    })(),
+    _d);
+const F = (_e = class {
+    },
+    __setFunctionName(_e, "F"),
+    _p = { value: 1 },
+    (() => {
+        console.log(__classPrivateFieldGet(F, _e, "f", _p));
+    })(),
+    _e);
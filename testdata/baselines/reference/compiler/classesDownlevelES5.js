@@ -0,0 +1,166 @@
+//// [tests/cases/compiler/classesDownlevelES5.ts] ////
+
+//// [classesDownlevelES5.ts]
+class Base {
+    static count = 0;
+    protected name: string;
+    constructor(name: string) {
+        this.name = name;
+        Base.count++;
+    }
+    greet(greeting: string): string {
+        return greeting + ", " + this.name;
+    }
+    get upperName(): string {
+        return this.name.toUpperCase();
+    }
+    set upperName(value: string) {
+        this.name = value.toLowerCase();
+    }
+    static create(name: string): Base {
+        return new Base(name);
+    }
+}
+
+class Derived extends Base {
+    static defaultName = "derived";
+    private suffix: string;
+    constructor(name: string, suffix: string) {
+        super(name);
+        this.suffix = suffix;
+    }
+    greet(greeting: string): string {
+        return super.greet(greeting) + this.suffix;
+    }
+    get upperName(): string {
+        return super.greet("upper").toUpperCase();
+    }
+    set upperName(value: string) {
+        this.suffix = value;
+    }
+    static create(name: string): Derived {
+        const base = super.create(name);
+        return new Derived(base.upperName, Derived.defaultName);
+    }
+    arrow() {
+        return () => super.greet("hi");
+    }
+}
+
+class NoConstructor extends Derived {
+    static get instanceCount(): number {
+        return Base.count;
+    }
+}
+
+const d = new NoConstructor("a", "b");
+d.upperName = "C";
+d.greet("hello");
+NoConstructor.instanceCount;
+
+const Expr = class extends Base {
+    method() {
+        return super.greet("expr");
+    }
+};
+
+
+//// [classesDownlevelES5.js]
+var __extends = (this && this.__extends) || (function () {
+    var extendStatics = function (d, b) {
+        extendStatics = Object.setPrototypeOf ||
+            ({ __proto__: [] } instanceof Array && function (d, b) { d.__proto__ = b; }) ||
+            function (d, b) { for (var p in b) if (Object.prototype.hasOwnProperty.call(b, p)) d[p] = b[p]; };
+        return extendStatics(d, b);
+    };
+    return function (d, b) {
+        if (typeof b !== "function" && b !== null)
+            throw new TypeError("Class extends value " + String(b) + " is not a constructor or null");
+        extendStatics(d, b);
+        function __() { this.constructor = d; }
+        d.prototype = b === null ? Object.create(b) : (__.prototype = b.prototype, new __());
+    };
+})();
+var Base = /** @class */ (function () {
+    function Base(name) {
+        this.name = name;
+        Base.count++;
+    }
+    Base.prototype.greet = function (greeting) {
+        return greeting + ", " + this.name;
+    };
+    Object.defineProperty(Base.prototype, "upperName", {
+        get: function () {
+            return this.name.toUpperCase();
+        },
+        set: function (value) {
+            this.name = value.toLowerCase();
+        },
+        enumerable: false,
+        configurable: true
+    });
+    Base.create = function (name) {
+        return new Base(name);
+    };
+    return Base;
+}());
+Base.count = 0;
+var Derived = /** @class */ (function (_super) {
+    __extends(Derived, _super);
+    function Derived(name, suffix) {
+        var _this = _super.call(this, name) || this;
+        _this.suffix = suffix;
+        return _this;
+    }
+    Derived.prototype.greet = function (greeting) {
+        return _super.prototype.greet.call(this, greeting) + this.suffix;
+    };
+    Object.defineProperty(Derived.prototype, "upperName", {
+        get: function () {
+            return _super.prototype.greet.call(this, "upper").toUpperCase();
+        },
+        set: function (value) {
+            this.suffix = value;
+        },
+        enumerable: false,
+        configurable: true
+    });
+    Derived.create = function (name) {
+        var base = _super.create.call(this, name);
+        return new Derived(base.upperName, Derived.defaultName);
+    };
+    Derived.prototype.arrow = function () {
+        var _this = this;
+        return function () { return _super.prototype.greet.call(_this, "hi"); };
+    };
+    return Derived;
+}(Base));
+Derived.defaultName = "derived";
+var NoConstructor = /** @class */ (function (_super) {
+    __extends(NoConstructor, _super);
+    function NoConstructor() {
+        return _super !== null && _super.apply(this, arguments) || this;
+    }
+    Object.defineProperty(NoConstructor, "instanceCount", {
+        get: function () {
+            return Base.count;
+        },
+        enumerable: false,
+        configurable: true
+    });
+    return NoConstructor;
+}(Derived));
+var d = new NoConstructor("a", "b");
+d.upperName = "C";
+d.greet("hello");
+NoConstructor.instanceCount;
+var Expr = /** @class */ (function (_super) {
+    __extends(Expr, _super);
+    function Expr() {
+        return _super !== null && _super.apply(this, arguments) || this;
+    }
+    Expr.prototype.method = function () {
+        return _super.prototype.greet.call(this, "expr");
+    };
+    return Expr;
+}(Base));
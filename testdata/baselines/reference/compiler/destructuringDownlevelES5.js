@@ -0,0 +1,51 @@
+//// [tests/cases/compiler/destructuringDownlevelES5.ts] ////
+
+//// [destructuringDownlevelES5.ts]
+declare function use(...args: any[]): void;
+
+interface Options {
+    a?: number;
+    b?: { c?: string; d?: [number, number?] };
+}
+
+declare const options: Options;
+
+const { a = 1, b: { c = "c", d: [first, second = 2] = [0] } = {} } = options;
+use(a, c, first, second);
+
+function f({ a = 1, b: { c = "default" } = {} }: Options = {}, [x, [y = 3] = []]: [number, [number?]?] = [0]) {
+    use(a, c, x, y);
+}
+
+let p: number, q: string;
+({ a: p = 5, b: { c: q = "q" } = {} } = options);
+[p = 1, [q = "nested"] = []] = [undefined, []] as [number?, [string?]?];
+
+for (const { a: loopA = 0, b: { c: loopC } = {} } of [options]) {
+    use(loopA, loopC);
+}
+
+const [, , third = "third"] = ["one", "two"];
+const { ["computed" + "Key"]: computedValue = 42 } = {} as any;
+use(third, computedValue);
+
+
+//// [destructuringDownlevelES5.js]
+var _a, _b, _c, _d, _e, _f, _g, _h, _j;
+var _k = options.a, a = _k === void 0 ? 1 : _k, _l = options.b, _m = _l === void 0 ? {} : _l, _n = _m.c, c = _n === void 0 ? "c" : _n, _o = _m.d, _p = _o === void 0 ? [0] : _o, first = _p[0], _q = _p[1], second = _q === void 0 ? 2 : _q;
+use(a, c, first, second);
+function f(_a, _b) {
+    var _c = _a === void 0 ? {} : _a, _d = _c.a, a = _d === void 0 ? 1 : _d, _e = _c.b, _f = _e === void 0 ? {} : _e, _g = _f.c, c = _g === void 0 ? "default" : _g;
+    var _h = _b === void 0 ? [0] : _b, x = _h[0], _j = _h[1], _k = _j === void 0 ? [] : _j, _l = _k[0], y = _l === void 0 ? 3 : _l;
+    use(a, c, x, y);
+}
+var p, q;
+(_a = options.a, p = _a === void 0 ? 5 : _a, _b = options.b, _c = _b === void 0 ? {} : _b, _d = _c.c, q = _d === void 0 ? "q" : _d);
+_e = [undefined, []], _f = _e[0], p = _f === void 0 ? 1 : _f, _g = _e[1], _h = _g === void 0 ? [] : _g, _j = _h[0], q = _j === void 0 ? "nested" : _j;
+for (var _i = 0, _r = [options]; _i < _r.length; _i++) {
+    var _s = _r[_i], _t = _s.a, loopA = _t === void 0 ? 0 : _t, _u = _s.b, _v = _u === void 0 ? {} : _u, loopC = _v.c;
+    use(loopA, loopC);
+}
+var _w = ["one", "two"], _x = _w[2], third = _x === void 0 ? "third" : _x;
+var _y = {}, _z = "computed" + "Key", _0 = _y[_z], computedValue = _0 === void 0 ? 42 : _0;
+use(third, computedValue);
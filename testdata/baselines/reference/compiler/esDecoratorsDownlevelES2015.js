@@ -0,0 +1,191 @@
+//// [tests/cases/compiler/esDecoratorsDownlevelES2015.ts] ////
+
+//// [esDecoratorsDownlevelES2015.ts]
+declare function dec(...args: any[]): any;
+
+@dec
+export class A {
+    @dec method() {}
+    @dec field = 1;
+    @dec static staticField = 2;
+    @dec accessor auto = 3;
+}
+
+class B {
+    @dec #method() {}
+    @dec static #field = 1;
+}
+
+declare const key: string;
+
+class C {
+    @dec accessor [key] = 1;
+}
+
+
+//// [esDecoratorsDownlevelES2015.js]
+var __runInitializers = (this && this.__runInitializers) || function (thisArg, initializers, value) {
+    var useValue = arguments.length > 2;
+    for (var i = 0; i < initializers.length; i++) {
+        value = useValue ? initializers[i].call(thisArg, value) : initializers[i].call(thisArg);
+    }
+    return useValue ? value : void 0;
+};
+var __esDecorate = (this && this.__esDecorate) || function (ctor, descriptorIn, decorators, contextIn, initializers, extraInitializers) {
+    function accept(f) { if (f !== void 0 && typeof f !== "function") throw new TypeError("Function expected"); return f; }
+    var kind = contextIn.kind, key = kind === "getter" ? "get" : kind === "setter" ? "set" : "value";
+    var target = !descriptorIn && ctor ? contextIn["static"] ? ctor : ctor.prototype : null;
+    var descriptor = descriptorIn || (target ? Object.getOwnPropertyDescriptor(target, contextIn.name) : {});
+    var _, done = false;
+    for (var i = decorators.length - 1; i >= 0; i--) {
+        var context = {};
+        for (var p in contextIn) context[p] = p === "access" ? {} : contextIn[p];
+        for (var p in contextIn.access) context.access[p] = contextIn.access[p];
+        context.addInitializer = function (f) { if (done) throw new TypeError("Cannot add initializers after decoration has completed"); extraInitializers.push(accept(f || null)); };
+        var result = (0, decorators[i])(kind === "accessor" ? { get: descriptor.get, set: descriptor.set } : descriptor[key], context);
+        if (kind === "accessor") {
+            if (result === void 0) continue;
+            if (result === null || typeof result !== "object") throw new TypeError("Object expected");
+            if (_ = accept(result.get)) descriptor.get = _;
+            if (_ = accept(result.set)) descriptor.set = _;
+            if (_ = accept(result.init)) initializers.unshift(_);
+        }
+        else if (_ = accept(result)) {
+            if (kind === "field") initializers.unshift(_);
+            else descriptor[key] = _;
+        }
+    }
+    if (target) Object.defineProperty(target, contextIn.name, descriptor);
+    done = true;
+};
+var __setFunctionName = (this && this.__setFunctionName) || function (f, name, prefix) {
+    if (typeof name === "symbol") name = name.description ? "[".concat(name.description, "]") : "";
+    return Object.defineProperty(f, "name", { configurable: true, value: prefix ? "".concat(prefix, " ", name) : name });
+};
+var __propKey = (this && this.__propKey) || function (x) {
+    return typeof x === "symbol" ? x : "".concat(x);
+};
+var __setFunctionName = (this && this.__setFunctionName) || function (f, name, prefix) {
+    if (typeof name === "symbol") name = name.description ? "[".concat(name.description, "]") : "";
+    return Object.defineProperty(f, "name", { configurable: true, value: prefix ? "".concat(prefix, " ", name) : name });
+};
+var __classPrivateFieldGet = (this && this.__classPrivateFieldGet) || function (receiver, state, kind, f) {
+    if (kind === "a" && !f) throw new TypeError("Private accessor was defined without a getter");
+    if (typeof state === "function" ? receiver !== state || !f : !state.has(receiver)) throw new TypeError("Cannot read private member from an object whose class did not declare it");
+    return kind === "m" ? f : kind === "a" ? f.call(receiver) : f ? f.value : state.get(receiver);
+};
+var __classPrivateFieldSet = (this && this.__classPrivateFieldSet) || function (receiver, state, value, kind, f) {
+    if (kind === "m") throw new TypeError("Private method is not writable");
+    if (kind === "a" && !f) throw new TypeError("Private accessor was defined without a setter");
+    if (typeof state === "function" ? receiver !== state || !f : !state.has(receiver)) throw new TypeError("Cannot write private member to an object whose class did not declare it");
+    return (kind === "a" ? f.call(receiver, value) : f ? f.value = value : state.set(receiver, value)), value;
+};
+var __classPrivateFieldIn = (this && this.__classPrivateFieldIn) || function(state, receiver) {
+    if (receiver === null || (typeof receiver !== "object" && typeof receiver !== "function")) throw new TypeError("Cannot use 'in' operator on non-object");
+    return typeof state === "function" ? receiver === state : state.has(receiver);
+};
+let A = (() => {
+    var _a, _auto_accessor_storage;
+    let _classDecorators = [dec];
+    let _classDescriptor;
+    let _classExtraInitializers = [];
+    let _classThis;
+    let _instanceExtraInitializers = [];
+    let _static_staticField_decorators;
+    let _static_staticField_initializers = [];
+    let _static_staticField_extraInitializers = [];
+    let _method_decorators;
+    let _field_decorators;
+    let _field_initializers = [];
+    let _field_extraInitializers = [];
+    let _auto_decorators;
+    let _auto_initializers = [];
+    let _auto_extraInitializers = [];
+    var A = (_a = class {
+            method() { }
+            get auto() { return __classPrivateFieldGet(this, _auto_accessor_storage, "f"); }
+            set auto(value) { __classPrivateFieldSet(this, _auto_accessor_storage, value, "f"); }
+            constructor() {
+                this.field = (__runInitializers(this, _instanceExtraInitializers), __runInitializers(this, _field_initializers, 1));
+                _auto_accessor_storage.set(this, (__runInitializers(this, _field_extraInitializers), __runInitializers(this, _auto_initializers, 3)));
+                __runInitializers(this, _auto_extraInitializers);
+            }
+        },
+        _auto_accessor_storage = new WeakMap(),
+        (() => { _classThis = _a; })(),
+        __setFunctionName(_a, "A"),
+        (() => {
+            const _metadata = typeof Symbol === "function" && Symbol.metadata ? Object.create(null) : void 0;
+            _method_decorators = [dec];
+            _field_decorators = [dec];
+            _static_staticField_decorators = [dec];
+            _auto_decorators = [dec];
+            __esDecorate(_a, null, _method_decorators, { kind: "method", name: "method", static: false, private: false, access: { has: obj => "method" in obj, get: obj => obj.method }, metadata: _metadata }, null, _instanceExtraInitializers);
+            __esDecorate(null, null, _static_staticField_decorators, { kind: "field", name: "staticField", static: true, private: false, access: { has: obj => "staticField" in obj, get: obj => obj.staticField, set: (obj, value) => { obj.staticField = value; } }, metadata: _metadata }, _static_staticField_initializers, _static_staticField_extraInitializers);
+            __esDecorate(null, null, _field_decorators, { kind: "field", name: "field", static: false, private: false, access: { has: obj => "field" in obj, get: obj => obj.field, set: (obj, value) => { obj.field = value; } }, metadata: _metadata }, _field_initializers, _field_extraInitializers);
+            __esDecorate(_a, null, _auto_decorators, { kind: "accessor", name: "auto", static: false, private: false, access: { has: obj => "auto" in obj, get: obj => obj.auto, set: (obj, value) => { obj.auto = value; } }, metadata: _metadata }, _auto_initializers, _auto_extraInitializers);
+            __esDecorate(null, _classDescriptor = { value: _classThis }, _classDecorators, { kind: "class", name: _classThis.name, metadata: _metadata }, null, _classExtraInitializers);
+            A = _classThis = _classDescriptor.value;
+            if (_metadata) Object.defineProperty(_classThis, Symbol.metadata, { enumerable: true, configurable: true, writable: true, value: _metadata });
+        })(),
+        _a.staticField = __runInitializers(_classThis, _static_staticField_initializers, 2),
+        (() => {
+            __runInitializers(_classThis, _static_staticField_extraInitializers);
+            __runInitializers(_classThis, _classExtraInitializers);
+        })(),
+        _a);
+    return A = _classThis;
+})();
+export { A };
+let B = (() => {
+    var _a, _B_instances, _B_method_get, _B_field;
+    let _instanceExtraInitializers_1 = [];
+    let _static_private_field_decorators;
+    let _static_private_field_initializers = [];
+    let _static_private_field_extraInitializers = [];
+    let _private_method_decorators;
+    let _private_method_descriptor;
+    return _a = class B {
+            constructor() {
+                _B_instances.add(this);
+                __runInitializers(this, _instanceExtraInitializers_1);
+            }
+        },
+        _B_instances = new WeakSet(),
+        _B_method_get = function _B_method_get() { return _private_method_descriptor.value; },
+        (() => {
+            const _metadata = typeof Symbol === "function" && Symbol.metadata ? Object.create(null) : void 0;
+            _private_method_decorators = [dec];
+            _static_private_field_decorators = [dec];
+            __esDecorate(_a, _private_method_descriptor = { value: __setFunctionName(function () { }, "#method") }, _private_method_decorators, { kind: "method", name: "#method", static: false, private: true, access: { has: obj => __classPrivateFieldIn(_B_instances, obj), get: obj => __classPrivateFieldGet(obj, _B_instances, "a", _B_method_get) }, metadata: _metadata }, null, _instanceExtraInitializers_1);
+            __esDecorate(null, null, _static_private_field_decorators, { kind: "field", name: "#field", static: true, private: true, access: { has: obj => __classPrivateFieldIn(_a, obj), get: obj => __classPrivateFieldGet(obj, _a, "f", _B_field), set: (obj, value) => { __classPrivateFieldSet(obj, _a, value, "f", _B_field); } }, metadata: _metadata }, _static_private_field_initializers, _static_private_field_extraInitializers);
+            if (_metadata) Object.defineProperty(_a, Symbol.metadata, { enumerable: true, configurable: true, writable: true, value: _metadata });
+        })(),
+        _B_field = { value: __runInitializers(_a, _static_private_field_initializers, 1) },
+        (() => {
+            __runInitializers(_a, _static_private_field_extraInitializers);
+        })(),
+        _a;
+})();
+let C = (() => {
+    var _a, _C__a_accessor_storage;
+    var _b;
+    let _member_decorators;
+    let _member_initializers = [];
+    let _member_extraInitializers = [];
+    return _a = class C {
+            get [(_member_decorators = [dec], _b = __propKey(key))]() { return __classPrivateFieldGet(this, _C__a_accessor_storage, "f"); }
+            set [_b](value) { __classPrivateFieldSet(this, _C__a_accessor_storage, value, "f"); }
+            constructor() {
+                _C__a_accessor_storage.set(this, __runInitializers(this, _member_initializers, 1));
+                __runInitializers(this, _member_extraInitializers);
+            }
+        },
+        _C__a_accessor_storage = new WeakMap(),
+        (() => {
+            const _metadata = typeof Symbol === "function" && Symbol.metadata ? Object.create(null) : void 0;
+            __esDecorate(_a, null, _member_decorators, { kind: "accessor", name: _b, static: false, private: false, access: { has: obj => _b in obj, get: obj => obj[_b], set: (obj, value) => { obj[_b] = value; } }, metadata: _metadata }, _member_initializers, _member_extraInitializers);
+            if (_metadata) Object.defineProperty(_a, Symbol.metadata, { enumerable: true, configurable: true, writable: true, value: _metadata });
+        })(),
+        _a;
+})();
@@ -0,0 +1,286 @@
+//// [tests/cases/compiler/esDecoratorsDownlevelES2022.ts] ////
+
+//// [esDecoratorsDownlevelES2022.ts]
+declare function dec(...args: any[]): any;
+declare const key: string;
+
+@dec
+class A {
+    static x = this;
+    @dec method() {}
+    @dec static staticMethod() {}
+    @dec get getter() { return 1; }
+    @dec set setter(value: number) {}
+    @dec field = 1;
+    @dec static staticField = 2;
+    @dec accessor auto = 3;
+    @dec static accessor staticAuto = 4;
+}
+
+class B {
+    @dec #method() {}
+    @dec get #getter() { return 1; }
+    @dec #field = 1;
+    @dec accessor #auto = 2;
+    @dec(this) [key] = 3;
+    @dec accessor [key] = 4;
+    static {
+        console.log("after");
+    }
+}
+
+class Base {
+    static method() {}
+}
+
+@dec
+class Derived extends Base {
+    constructor() {
+        super();
+        console.log("constructed");
+    }
+    @dec method() {}
+    static {
+        super.method();
+        this.method();
+    }
+}
+
+const E = @dec class {};
+export default @dec class {}
+
+
+//// [esDecoratorsDownlevelES2022.js]
+var __runInitializers = (this && this.__runInitializers) || function (thisArg, initializers, value) {
+    var useValue = arguments.length > 2;
+    for (var i = 0; i < initializers.length; i++) {
+        value = useValue ? initializers[i].call(thisArg, value) : initializers[i].call(thisArg);
+    }
+    return useValue ? value : void 0;
+};
+var __esDecorate = (this && this.__esDecorate) || function (ctor, descriptorIn, decorators, contextIn, initializers, extraInitializers) {
+    function accept(f) { if (f !== void 0 && typeof f !== "function") throw new TypeError("Function expected"); return f; }
+    var kind = contextIn.kind, key = kind === "getter" ? "get" : kind === "setter" ? "set" : "value";
+    var target = !descriptorIn && ctor ? contextIn["static"] ? ctor : ctor.prototype : null;
+    var descriptor = descriptorIn || (target ? Object.getOwnPropertyDescriptor(target, contextIn.name) : {});
+    var _, done = false;
+    for (var i = decorators.length - 1; i >= 0; i--) {
+        var context = {};
+        for (var p in contextIn) context[p] = p === "access" ? {} : contextIn[p];
+        for (var p in contextIn.access) context.access[p] = contextIn.access[p];
+        context.addInitializer = function (f) { if (done) throw new TypeError("Cannot add initializers after decoration has completed"); extraInitializers.push(accept(f || null)); };
+        var result = (0, decorators[i])(kind === "accessor" ? { get: descriptor.get, set: descriptor.set } : descriptor[key], context);
+        if (kind === "accessor") {
+            if (result === void 0) continue;
+            if (result === null || typeof result !== "object") throw new TypeError("Object expected");
+            if (_ = accept(result.get)) descriptor.get = _;
+            if (_ = accept(result.set)) descriptor.set = _;
+            if (_ = accept(result.init)) initializers.unshift(_);
+        }
+        else if (_ = accept(result)) {
+            if (kind === "field") initializers.unshift(_);
+            else descriptor[key] = _;
+        }
+    }
+    if (target) Object.defineProperty(target, contextIn.name, descriptor);
+    done = true;
+};
+var __setFunctionName = (this && this.__setFunctionName) || function (f, name, prefix) {
+    if (typeof name === "symbol") name = name.description ? "[".concat(name.description, "]") : "";
+    return Object.defineProperty(f, "name", { configurable: true, value: prefix ? "".concat(prefix, " ", name) : name });
+};
+var __propKey = (this && this.__propKey) || function (x) {
+    return typeof x === "symbol" ? x : "".concat(x);
+};
+let A = (() => {
+    let _classDecorators = [dec];
+    let _classDescriptor;
+    let _classExtraInitializers = [];
+    let _classThis;
+    let _staticExtraInitializers = [];
+    let _instanceExtraInitializers = [];
+    let _static_staticMethod_decorators;
+    let _static_staticField_decorators;
+    let _static_staticField_initializers = [];
+    let _static_staticField_extraInitializers = [];
+    let _static_staticAuto_decorators;
+    let _static_staticAuto_initializers = [];
+    let _static_staticAuto_extraInitializers = [];
+    let _method_decorators;
+    let _get_getter_decorators;
+    let _set_setter_decorators;
+    let _field_decorators;
+    let _field_initializers = [];
+    let _field_extraInitializers = [];
+    let _auto_decorators;
+    let _auto_initializers = [];
+    let _auto_extraInitializers = [];
+    var A = class {
+        static { _classThis = this; }
+        static {
+            const _metadata = typeof Symbol === "function" && Symbol.metadata ? Object.create(null) : void 0;
+            _method_decorators = [dec];
+            _static_staticMethod_decorators = [dec];
+            _get_getter_decorators = [dec];
+            _set_setter_decorators = [dec];
+            _field_decorators = [dec];
+            _static_staticField_decorators = [dec];
+            _auto_decorators = [dec];
+            _static_staticAuto_decorators = [dec];
+            __esDecorate(this, null, _static_staticMethod_decorators, { kind: "method", name: "staticMethod", static: true, private: false, access: { has: obj => "staticMethod" in obj, get: obj => obj.staticMethod }, metadata: _metadata }, null, _staticExtraInitializers);
+            __esDecorate(this, null, _method_decorators, { kind: "method", name: "method", static: false, private: false, access: { has: obj => "method" in obj, get: obj => obj.method }, metadata: _metadata }, null, _instanceExtraInitializers);
+            __esDecorate(this, null, _get_getter_decorators, { kind: "getter", name: "getter", static: false, private: false, access: { has: obj => "getter" in obj, get: obj => obj.getter }, metadata: _metadata }, null, _instanceExtraInitializers);
+            __esDecorate(this, null, _set_setter_decorators, { kind: "setter", name: "setter", static: false, private: false, access: { has: obj => "setter" in obj, set: (obj, value) => { obj.setter = value; } }, metadata: _metadata }, null, _instanceExtraInitializers);
+            __esDecorate(null, null, _static_staticField_decorators, { kind: "field", name: "staticField", static: true, private: false, access: { has: obj => "staticField" in obj, get: obj => obj.staticField, set: (obj, value) => { obj.staticField = value; } }, metadata: _metadata }, _static_staticField_initializers, _static_staticField_extraInitializers);
+            __esDecorate(this, null, _static_staticAuto_decorators, { kind: "accessor", name: "staticAuto", static: true, private: false, access: { has: obj => "staticAuto" in obj, get: obj => obj.staticAuto, set: (obj, value) => { obj.staticAuto = value; } }, metadata: _metadata }, _static_staticAuto_initializers, _static_staticAuto_extraInitializers);
+            __esDecorate(null, null, _field_decorators, { kind: "field", name: "field", static: false, private: false, access: { has: obj => "field" in obj, get: obj => obj.field, set: (obj, value) => { obj.field = value; } }, metadata: _metadata }, _field_initializers, _field_extraInitializers);
+            __esDecorate(this, null, _auto_decorators, { kind: "accessor", name: "auto", static: false, private: false, access: { has: obj => "auto" in obj, get: obj => obj.auto, set: (obj, value) => { obj.auto = value; } }, metadata: _metadata }, _auto_initializers, _auto_extraInitializers);
+            __esDecorate(null, _classDescriptor = { value: _classThis }, _classDecorators, { kind: "class", name: _classThis.name, metadata: _metadata }, null, _classExtraInitializers);
+            A = _classThis = _classDescriptor.value;
+            if (_metadata) Object.defineProperty(_classThis, Symbol.metadata, { enumerable: true, configurable: true, writable: true, value: _metadata });
+        }
+        static x = (__runInitializers(_classThis, _staticExtraInitializers), _classThis);
+        method() { }
+        static staticMethod() { }
+        get getter() { return 1; }
+        set setter(value) { }
+        field = (__runInitializers(this, _instanceExtraInitializers), __runInitializers(this, _field_initializers, 1));
+        static staticField = __runInitializers(_classThis, _static_staticField_initializers, 2);
+        #auto_accessor_storage = (__runInitializers(this, _field_extraInitializers), __runInitializers(this, _auto_initializers, 3));
+        get auto() { return this.#auto_accessor_storage; }
+        set auto(value) { this.#auto_accessor_storage = value; }
+        static #staticAuto_accessor_storage = (__runInitializers(_classThis, _static_staticField_extraInitializers), __runInitializers(_classThis, _static_staticAuto_initializers, 4));
+        static get staticAuto() { return _classThis.#staticAuto_accessor_storage; }
+        static set staticAuto(value) { _classThis.#staticAuto_accessor_storage = value; }
+        constructor() {
+            __runInitializers(this, _auto_extraInitializers);
+        }
+        static {
+            __runInitializers(_classThis, _static_staticAuto_extraInitializers);
+            __runInitializers(_classThis, _classExtraInitializers);
+        }
+    };
+    return A = _classThis;
+})();
+let B = (() => {
+    var _a, _b;
+    let _instanceExtraInitializers_1 = [];
+    let _private_method_decorators;
+    let _private_method_descriptor;
+    let _private_get_getter_decorators;
+    let _private_get_getter_descriptor;
+    let _private_field_decorators;
+    let _private_field_initializers = [];
+    let _private_field_extraInitializers = [];
+    let _private_auto_decorators;
+    let _private_auto_initializers = [];
+    let _private_auto_extraInitializers = [];
+    let _private_auto_descriptor;
+    let _member_decorators;
+    let _member_initializers = [];
+    let _member_extraInitializers = [];
+    let _member_decorators_1;
+    let _member_initializers_1 = [];
+    let _member_extraInitializers_1 = [];
+    return class B {
+        static {
+            const _metadata = typeof Symbol === "function" && Symbol.metadata ? Object.create(null) : void 0;
+            __esDecorate(this, _private_method_descriptor = { value: __setFunctionName(function () { }, "#method") }, _private_method_decorators, { kind: "method", name: "#method", static: false, private: true, access: { has: obj => #method in obj, get: obj => obj.#method }, metadata: _metadata }, null, _instanceExtraInitializers_1);
+            __esDecorate(this, _private_get_getter_descriptor = { get: __setFunctionName(function () { return 1; }, "#getter", "get") }, _private_get_getter_decorators, { kind: "getter", name: "#getter", static: false, private: true, access: { has: obj => #getter in obj, get: obj => obj.#getter }, metadata: _metadata }, null, _instanceExtraInitializers_1);
+            __esDecorate(null, null, _private_field_decorators, { kind: "field", name: "#field", static: false, private: true, access: { has: obj => #field in obj, get: obj => obj.#field, set: (obj, value) => { obj.#field = value; } }, metadata: _metadata }, _private_field_initializers, _private_field_extraInitializers);
+            __esDecorate(this, _private_auto_descriptor = { get: __setFunctionName(function () { return this.#auto_accessor_storage; }, "#auto", "get"), set: __setFunctionName(function (value) { this.#auto_accessor_storage = value; }, "#auto", "set") }, _private_auto_decorators, { kind: "accessor", name: "#auto", static: false, private: true, access: { has: obj => #auto in obj, get: obj => obj.#auto, set: (obj, value) => { obj.#auto = value; } }, metadata: _metadata }, _private_auto_initializers, _private_auto_extraInitializers);
+            __esDecorate(null, null, _member_decorators, { kind: "field", name: _a, static: false, private: false, access: { has: obj => _a in obj, get: obj => obj[_a], set: (obj, value) => { obj[_a] = value; } }, metadata: _metadata }, _member_initializers, _member_extraInitializers);
+            __esDecorate(this, null, _member_decorators_1, { kind: "accessor", name: _b, static: false, private: false, access: { has: obj => _b in obj, get: obj => obj[_b], set: (obj, value) => { obj[_b] = value; } }, metadata: _metadata }, _member_initializers_1, _member_extraInitializers_1);
+            if (_metadata) Object.defineProperty(this, Symbol.metadata, { enumerable: true, configurable: true, writable: true, value: _metadata });
+        }
+        get #method() { return _private_method_descriptor.value; }
+        get #getter() { return _private_get_getter_descriptor.get.call(this); }
+        #field = (__runInitializers(this, _instanceExtraInitializers_1), __runInitializers(this, _private_field_initializers, 1));
+        #auto_accessor_storage = (__runInitializers(this, _private_field_extraInitializers), __runInitializers(this, _private_auto_initializers, 2));
+        get #auto() { return _private_auto_descriptor.get.call(this); }
+        set #auto(value) { return _private_auto_descriptor.set.call(this, value); }
+        [(_private_method_decorators = [dec], _private_get_getter_decorators = [dec], _private_field_decorators = [dec], _private_auto_decorators = [dec], _member_decorators = [dec(this)], _a = __propKey(key))] = (__runInitializers(this, _private_auto_extraInitializers), __runInitializers(this, _member_initializers, 3));
+        #_a_accessor_storage = (__runInitializers(this, _member_extraInitializers), __runInitializers(this, _member_initializers_1, 4));
+        get [(_member_decorators_1 = [dec], _b = __propKey(key))]() { return this.#_a_accessor_storage; }
+        set [_b](value) { this.#_a_accessor_storage = value; }
+        static {
+            console.log("after");
+        }
+        constructor() {
+            __runInitializers(this, _member_extraInitializers_1);
+        }
+    };
+})();
+class Base {
+    static method() { }
+}
+let Derived = (() => {
+    let _classDecorators = [dec];
+    let _classDescriptor;
+    let _classExtraInitializers = [];
+    let _classThis;
+    let _classSuper = Base;
+    let _instanceExtraInitializers_2 = [];
+    let _method_decorators;
+    var Derived = class extends _classSuper {
+        static { _classThis = this; }
+        static {
+            const _metadata = typeof Symbol === "function" && Symbol.metadata ? Object.create(_classSuper[Symbol.metadata] ?? null) : void 0;
+            _method_decorators = [dec];
+            __esDecorate(this, null, _method_decorators, { kind: "method", name: "method", static: false, private: false, access: { has: obj => "method" in obj, get: obj => obj.method }, metadata: _metadata }, null, _instanceExtraInitializers_2);
+            __esDecorate(null, _classDescriptor = { value: _classThis }, _classDecorators, { kind: "class", name: _classThis.name, metadata: _metadata }, null, _classExtraInitializers);
+            Derived = _classThis = _classDescriptor.value;
+            if (_metadata) Object.defineProperty(_classThis, Symbol.metadata, { enumerable: true, configurable: true, writable: true, value: _metadata });
+        }
+        constructor() {
+            super();
+            __runInitializers(this, _instanceExtraInitializers_2);
+            console.log("constructed");
+        }
+        method() { }
+        static {
+            Reflect.get(_classSuper, "method", _classThis).call(_classThis);
+            _classThis.method();
+        }
+        static {
+            __runInitializers(_classThis, _classExtraInitializers);
+        }
+    };
+    return Derived = _classThis;
+})();
+const E = (() => {
+    let _classDecorators = [dec];
+    let _classDescriptor;
+    let _classExtraInitializers = [];
+    let _classThis;
+    var class_1 = class {
+        static { _classThis = this; }
+        static { __setFunctionName(this, "E"); }
+        static {
+            const _metadata = typeof Symbol === "function" && Symbol.metadata ? Object.create(null) : void 0;
+            __esDecorate(null, _classDescriptor = { value: _classThis }, _classDecorators, { kind: "class", name: _classThis.name, metadata: _metadata }, null, _classExtraInitializers);
+            class_1 = _classThis = _classDescriptor.value;
+            if (_metadata) Object.defineProperty(_classThis, Symbol.metadata, { enumerable: true, configurable: true, writable: true, value: _metadata });
+            __runInitializers(_classThis, _classExtraInitializers);
+        }
+    };
+    return class_1 = _classThis;
+})();
+export default (() => {
+    let _classDecorators = [dec];
+    let _classDescriptor;
+    let _classExtraInitializers = [];
+    let _classThis;
+    var default_1 = class {
+        static { _classThis = this; }
+        static { __setFunctionName(this, "default"); }
+        static {
+            const _metadata = typeof Symbol === "function" && Symbol.metadata ? Object.create(null) : void 0;
+            __esDecorate(null, _classDescriptor = { value: _classThis }, _classDecorators, { kind: "class", name: _classThis.name, metadata: _metadata }, null, _classExtraInitializers);
+            default_1 = _classThis = _classDescriptor.value;
+            if (_metadata) Object.defineProperty(_classThis, Symbol.metadata, { enumerable: true, configurable: true, writable: true, value: _metadata });
+            __runInitializers(_classThis, _classExtraInitializers);
+        }
+    };
+    return default_1 = _classThis;
+})();
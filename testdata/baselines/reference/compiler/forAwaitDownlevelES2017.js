@@ -0,0 +1,161 @@
+//// [tests/cases/compiler/forAwaitDownlevelES2017.ts] ////
+
+//// [forAwaitDownlevelES2017.ts]
+declare const iterable: AsyncIterable<number>;
+declare function getIterable(): AsyncIterable<number>;
+declare function use(...args: any[]): void;
+
+async function f1() {
+    for await (const x of iterable) {
+        use(x);
+    }
+}
+
+async function f2() {
+    outer: for await (const x of getIterable()) {
+        for await (const y of iterable) {
+            if (y) continue outer;
+            use(x, y);
+        }
+    }
+}
+
+async function* g1() {
+    const x = await Promise.resolve(1);
+    yield x;
+    yield;
+    yield* iterable;
+    for await (const y of iterable) {
+        yield y;
+    }
+    return x;
+}
+
+const g2 = async function* () {
+    return;
+};
+
+class C {
+    async *m(a: number) {
+        "use strict";
+        yield a;
+    }
+}
+
+
+//// [forAwaitDownlevelES2017.js]
+var __asyncValues = (this && this.__asyncValues) || function (o) {
+    if (!Symbol.asyncIterator) throw new TypeError("Symbol.asyncIterator is not defined.");
+    var m = o[Symbol.asyncIterator], i;
+    return m ? m.call(o) : (o = typeof __values === "function" ? __values(o) : o[Symbol.iterator](), i = {}, verb("next"), verb("throw"), verb("return"), i[Symbol.asyncIterator] = function () { return this; }, i);
+    function verb(n) { i[n] = o[n] && function (v) { return new Promise(function (resolve, reject) { v = o[n](v), settle(resolve, reject, v.done, v.value); }); }; }
+    function settle(resolve, reject, d, v) { Promise.resolve(v).then(function(v) { resolve({ value: v, done: d }); }, reject); }
+};
+var __await = (this && this.__await) || function (v) { return this instanceof __await ? (this.v = v, this) : new __await(v); }
+var __asyncDelegator = (this && this.__asyncDelegator) || function (o) {
+    var i, p;
+    return i = {}, verb("next"), verb("throw", function (e) { throw e; }), verb("return"), i[Symbol.iterator] = function () { return this; }, i;
+    function verb(n, f) { i[n] = o[n] ? function (v) { return (p = !p) ? { value: __await(o[n](v)), done: false } : f ? f(v) : v; } : f; }
+};
+var __asyncGenerator = (this && this.__asyncGenerator) || function (thisArg, _arguments, generator) {
+    if (!Symbol.asyncIterator) throw new TypeError("Symbol.asyncIterator is not defined.");
+    var g = generator.apply(thisArg, _arguments || []), i, q = [];
+    return i = Object.create((typeof AsyncIterator === "function" ? AsyncIterator : Object).prototype), verb("next"), verb("throw"), verb("return", awaitReturn), i[Symbol.asyncIterator] = function () { return this; }, i;
+    function awaitReturn(f) { return function (v) { return Promise.resolve(v).then(f, reject); }; }
+    function verb(n, f) { if (g[n]) { i[n] = function (v) { return new Promise(function (a, b) { q.push([n, v, a, b]) > 1 || resume(n, v); }); }; if (f) i[n] = f(i[n]); } }
+    function resume(n, v) { try { step(g[n](v)); } catch (e) { settle(q[0][3], e); } }
+    function step(r) { r.value instanceof __await ? Promise.resolve(r.value.v).then(fulfill, reject) : settle(q[0][2], r); }
+    function fulfill(value) { resume("next", value); }
+    function reject(value) { resume("throw", value); }
+    function settle(f, v) { if (f(v), q.shift(), q.length) resume(q[0][0], q[0][1]); }
+};
+async function f1() {
+    var _a, e_1, _b, _c;
+    try {
+        for (var _d = true, iterable_1 = __asyncValues(iterable), iterable_1_1; iterable_1_1 = await iterable_1.next(), _a = iterable_1_1.done, !_a; _d = true) {
+            _c = iterable_1_1.value;
+            _d = false;
+            const x = _c;
+            use(x);
+        }
+    }
+    catch (e_1_1) { e_1 = { error: e_1_1 }; }
+    finally {
+        try {
+            if (!_d && !_a && (_b = iterable_1.return)) await _b.call(iterable_1);
+        }
+        finally { if (e_1) throw e_1.error; }
+    }
+}
+async function f2() {
+    var _a, e_2, _b, _c, _d, e_3, _e, _f;
+    try {
+        outer: for (var _g = true, _h = __asyncValues(getIterable()), _j; _j = await _h.next(), _a = _j.done, !_a; _g = true) {
+            _c = _j.value;
+            _g = false;
+            const x = _c;
+            try {
+                for (var _k = true, iterable_2 = (e_3 = void 0, __asyncValues(iterable)), iterable_2_1; iterable_2_1 = await iterable_2.next(), _d = iterable_2_1.done, !_d; _k = true) {
+                    _f = iterable_2_1.value;
+                    _k = false;
+                    const y = _f;
+                    if (y)
+                        continue outer;
+                    use(x, y);
+                }
+            }
+            catch (e_3_1) { e_3 = { error: e_3_1 }; }
+            finally {
+                try {
+                    if (!_k && !_d && (_e = iterable_2.return)) await _e.call(iterable_2);
+                }
+                finally { if (e_3) throw e_3.error; }
+            }
+        }
+    }
+    catch (e_2_1) { e_2 = { error: e_2_1 }; }
+    finally {
+        try {
+            if (!_g && !_a && (_b = _h.return)) await _b.call(_h);
+        }
+        finally { if (e_2) throw e_2.error; }
+    }
+}
+function g1() {
+    return __asyncGenerator(this, arguments, function* g1_1() {
+        var _a, e_4, _b, _c;
+        const x = yield __await(Promise.resolve(1));
+        yield yield __await(x);
+        yield yield __await(void 0);
+        yield __await(yield* __asyncDelegator(__asyncValues(iterable)));
+        try {
+            for (var _d = true, iterable_3 = __asyncValues(iterable), iterable_3_1; iterable_3_1 = (yield __await(iterable_3.next())), _a = iterable_3_1.done, !_a; _d = true) {
+                _c = iterable_3_1.value;
+                _d = false;
+                const y = _c;
+                yield yield __await(y);
+            }
+        }
+        catch (e_4_1) { e_4 = { error: e_4_1 }; }
+        finally {
+            try {
+                if (!_d && !_a && (_b = iterable_3.return)) yield __await(_b.call(iterable_3));
+            }
+            finally { if (e_4) throw e_4.error; }
+        }
+        return yield __await(x);
+    });
+}
+const g2 = function () {
+    return __asyncGenerator(this, arguments, function* () {
+        return yield __await(void 0);
+    });
+};
+class C {
+    m(a) {
+        "use strict";
+        return __asyncGenerator(this, arguments, function* m_1() {
+            yield yield __await(a);
+        });
+    }
+}
@@ -0,0 +1,80 @@
+//// [tests/cases/compiler/forOfDownlevelES5.ts] ////
+
+//// [forOfDownlevelES5.ts]
+declare function use(...args: any[]): void;
+declare function getArray(): number[];
+
+const array = [1, 2, 3];
+for (const x of array) {
+    use(x);
+}
+
+for (const x of getArray()) {
+    use(x);
+}
+
+for (const ch of "text") {
+    use(ch);
+}
+
+let item: number;
+for (item of array) {
+    use(item);
+}
+
+for (const [a, b] of [[1, 2], [3, 4]]) {
+    use(a, b);
+}
+
+outer: for (const x of array) {
+    for (const y of array) {
+        if (y === x) continue outer;
+        use(() => x + y);
+    }
+}
+
+
+//// [forOfDownlevelES5.js]
+var array = [1, 2, 3];
+for (var _i = 0, array_1 = array; _i < array_1.length; _i++) {
+    var x = array_1[_i];
+    use(x);
+}
+for (var _a = 0, _b = getArray(); _a < _b.length; _a++) {
+    var x = _b[_a];
+    use(x);
+}
+for (var _c = 0, _d = "text"; _c < _d.length; _c++) {
+    var ch = _d[_c];
+    use(ch);
+}
+var item;
+for (var _e = 0, array_2 = array; _e < array_2.length; _e++) {
+    item = array_2[_e];
+    use(item);
+}
+for (var _f = 0, _g = [[1, 2], [3, 4]]; _f < _g.length; _f++) {
+    var _h = _g[_f], a = _h[0], b = _h[1];
+    use(a, b);
+}
+var _loop_1 = function (x) {
+    var _loop_2 = function (y) {
+        if (y === x)
+            return "continue-outer";
+        use(function () { return x + y; });
+    };
+    for (var _k = 0, array_4 = array; _k < array_4.length; _k++) {
+        var y = array_4[_k];
+        var state_2 = _loop_2(y);
+        switch (state_2) {
+            case "continue-outer": return state_2;
+        }
+    }
+};
+outer: for (var _j = 0, array_3 = array; _j < array_3.length; _j++) {
+    var x = array_3[_j];
+    var state_1 = _loop_1(x);
+    switch (state_1) {
+        case "continue-outer": continue outer;
+    }
+}
@@ -0,0 +1,173 @@
+//// [tests/cases/compiler/forOfDownlevelIterationES5.ts] ////
+
+//// [forOfDownlevelIterationES5.ts]
+declare function use(...args: any[]): void;
+declare const set: Set<number>;
+declare const map: Map<string, number>;
+
+for (const x of set) {
+    use(x);
+}
+
+for (const [key, value] of map) {
+    use(key, value);
+}
+
+for (const x of [1, 2, 3]) {
+    if (x === 2) break;
+    use(() => x);
+}
+
+const spread = [...set, ...map.keys()];
+const [first, ...others] = set;
+use(spread, first, others);
+
+function* numbers() {
+    yield 1;
+    yield 2;
+}
+for (const n of numbers()) {
+    use(n);
+}
+
+
+//// [forOfDownlevelIterationES5.js]
+var __generator = (this && this.__generator) || function (thisArg, body) {
+    var _ = { label: 0, sent: function() { if (t[0] & 1) throw t[1]; return t[1]; }, trys: [], ops: [] }, f, y, t, g = Object.create((typeof Iterator === "function" ? Iterator : Object).prototype);
+    return g.next = verb(0), g["throw"] = verb(1), g["return"] = verb(2), typeof Symbol === "function" && (g[Symbol.iterator] = function() { return this; }), g;
+    function verb(n) { return function (v) { return step([n, v]); }; }
+    function step(op) {
+        if (f) throw new TypeError("Generator is already executing.");
+        while (g && (g = 0, op[0] && (_ = 0)), _) try {
+            if (f = 1, y && (t = op[0] & 2 ? y["return"] : op[0] ? y["throw"] || ((t = y["return"]) && t.call(y), 0) : y.next) && !(t = t.call(y, op[1])).done) return t;
+            if (y = 0, t) op = [op[0] & 2, t.value];
+            switch (op[0]) {
+                case 0: case 1: t = op; break;
+                case 4: _.label++; return { value: op[1], done: false };
+                case 5: _.label++; y = op[1]; op = [0]; continue;
+                case 7: op = _.ops.pop(); _.trys.pop(); continue;
+                default:
+                    if (!(t = _.trys, t = t.length > 0 && t[t.length - 1]) && (op[0] === 6 || op[0] === 2)) { _ = 0; continue; }
+                    if (op[0] === 3 && (!t || (op[1] > t[0] && op[1] < t[3]))) { _.label = op[1]; break; }
+                    if (op[0] === 6 && _.label < t[1]) { _.label = t[1]; t = op; break; }
+                    if (t && _.label < t[2]) { _.label = t[2]; _.ops.push(op); break; }
+                    if (t[2]) _.ops.pop();
+                    _.trys.pop(); continue;
+            }
+            op = body.call(thisArg, _);
+        } catch (e) { op = [6, e]; y = 0; } finally { f = t = 0; }
+        if (op[0] & 5) throw op[1]; return { value: op[0] ? op[1] : void 0, done: true };
+    }
+};
+var __values = (this && this.__values) || function(o) {
+    var s = typeof Symbol === "function" && Symbol.iterator, m = s && o[s], i = 0;
+    if (m) return m.call(o);
+    if (o && typeof o.length === "number") return {
+        next: function () {
+            if (o && i >= o.length) o = void 0;
+            return { value: o && o[i++], done: !o };
+        }
+    };
+    throw new TypeError(s ? "Object is not iterable." : "Symbol.iterator is not defined.");
+};
+var __read = (this && this.__read) || function (o, n) {
+    var m = typeof Symbol === "function" && o[Symbol.iterator];
+    if (!m) return o;
+    var i = m.call(o), r, ar = [], e;
+    try {
+        while ((n === void 0 || n-- > 0) && !(r = i.next()).done) ar.push(r.value);
+    }
+    catch (error) { e = { error: error }; }
+    finally {
+        try {
+            if (r && !r.done && (m = i["return"])) m.call(i);
+        }
+        finally { if (e) throw e.error; }
+    }
+    return ar;
+};
+var __spreadArray = (this && this.__spreadArray) || function (to, from, pack) {
+    if (pack || arguments.length === 2) for (var i = 0, l = from.length, ar; i < l; i++) {
+        if (ar || !(i in from)) {
+            if (!ar) ar = Array.prototype.slice.call(from, 0, i);
+            ar[i] = from[i];
+        }
+    }
+    return to.concat(ar || Array.prototype.slice.call(from));
+};
+var e_1, _a, e_2, _b, e_3, _c, e_4, _d;
+try {
+    for (var set_1 = __values(set), set_1_1 = set_1.next(); !set_1_1.done; set_1_1 = set_1.next()) {
+        var x = set_1_1.value;
+        use(x);
+    }
+}
+catch (e_1_1) { e_1 = { error: e_1_1 }; }
+finally {
+    try {
+        if (set_1_1 && !set_1_1.done && (_a = set_1.return)) _a.call(set_1);
+    }
+    finally { if (e_1) throw e_1.error; }
+}
+try {
+    for (var map_1 = __values(map), map_1_1 = map_1.next(); !map_1_1.done; map_1_1 = map_1.next()) {
+        var _e = __read(map_1_1.value, 2), key = _e[0], value = _e[1];
+        use(key, value);
+    }
+}
+catch (e_2_1) { e_2 = { error: e_2_1 }; }
+finally {
+    try {
+        if (map_1_1 && !map_1_1.done && (_b = map_1.return)) _b.call(map_1);
+    }
+    finally { if (e_2) throw e_2.error; }
+}
+var _loop_1 = function (x) {
+    if (x === 2)
+        return "break";
+    use(function () { return x; });
+};
+try {
+    for (var _f = __values([1, 2, 3]), _g = _f.next(); !_g.done; _g = _f.next()) {
+        var x = _g.value;
+        var state_1 = _loop_1(x);
+        if (state_1 === "break")
+            break;
+    }
+}
+catch (e_3_1) { e_3 = { error: e_3_1 }; }
+finally {
+    try {
+        if (_g && !_g.done && (_c = _f.return)) _c.call(_f);
+    }
+    finally { if (e_3) throw e_3.error; }
+}
+var spread = __spreadArray(__spreadArray([], __read(set), false), __read(map.keys()), false);
+var _h = __read(set), first = _h[0], others = _h.slice(1);
+use(spread, first, others);
+function numbers() {
+    return __generator(this, function (_a) {
+        switch (_a.label) {
+            case 0: return [4 /*yield*/, 1];
+            case 1:
+                _a.sent();
+                return [4 /*yield*/, 2];
+            case 2:
+                _a.sent();
+                return [2 /*return*/];
+        }
+    });
+}
+try {
+    for (var _j = __values(numbers()), _k = _j.next(); !_k.done; _k = _j.next()) {
+        var n = _k.value;
+        use(n);
+    }
+}
+catch (e_4_1) { e_4 = { error: e_4_1 }; }
+finally {
+    try {
+        if (_k && !_k.done && (_d = _j.return)) _d.call(_j);
+    }
+    finally { if (e_4) throw e_4.error; }
+}
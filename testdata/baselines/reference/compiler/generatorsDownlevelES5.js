@@ -0,0 +1,250 @@
+//// [tests/cases/compiler/generatorsDownlevelES5.ts] ////
+
+//// [generatorsDownlevelES5.ts]
+declare function use(...args: any[]): void;
+declare function cleanup(): void;
+
+function* simple() {
+    yield 1;
+    const received: string = yield 2;
+    return received;
+}
+
+function* withTryFinally() {
+    try {
+        yield "try";
+        use("after yield");
+    } finally {
+        cleanup();
+        yield "finally";
+    }
+}
+
+function* withTryCatchFinally(fail: boolean) {
+    try {
+        if (fail) {
+            throw new Error("failed");
+        }
+        yield 1;
+    } catch (e) {
+        yield e;
+    } finally {
+        cleanup();
+    }
+    return "done";
+}
+
+function* loops(items: number[]) {
+    for (let i = 0; i < items.length; i++) {
+        if (items[i] < 0) continue;
+        yield items[i];
+    }
+    while (true) {
+        const next: number = yield;
+        if (next === 0) break;
+    }
+}
+
+function* delegating() {
+    yield* simple();
+    const result = yield* withTryCatchFinally(false);
+    return result;
+}
+
+class Container {
+    *[Symbol.iterator]() {
+        yield* [1, 2, 3];
+    }
+    *method(this: Container) {
+        const self = this;
+        yield self;
+    }
+}
+
+const gen = function* () {
+    yield () => this;
+};
+
+
+//// [generatorsDownlevelES5.js]
+var __generator = (this && this.__generator) || function (thisArg, body) {
+    var _ = { label: 0, sent: function() { if (t[0] & 1) throw t[1]; return t[1]; }, trys: [], ops: [] }, f, y, t, g = Object.create((typeof Iterator === "function" ? Iterator : Object).prototype);
+    return g.next = verb(0), g["throw"] = verb(1), g["return"] = verb(2), typeof Symbol === "function" && (g[Symbol.iterator] = function() { return this; }), g;
+    function verb(n) { return function (v) { return step([n, v]); }; }
+    function step(op) {
+        if (f) throw new TypeError("Generator is already executing.");
+        while (g && (g = 0, op[0] && (_ = 0)), _) try {
+            if (f = 1, y && (t = op[0] & 2 ? y["return"] : op[0] ? y["throw"] || ((t = y["return"]) && t.call(y), 0) : y.next) && !(t = t.call(y, op[1])).done) return t;
+            if (y = 0, t) op = [op[0] & 2, t.value];
+            switch (op[0]) {
+                case 0: case 1: t = op; break;
+                case 4: _.label++; return { value: op[1], done: false };
+                case 5: _.label++; y = op[1]; op = [0]; continue;
+                case 7: op = _.ops.pop(); _.trys.pop(); continue;
+                default:
+                    if (!(t = _.trys, t = t.length > 0 && t[t.length - 1]) && (op[0] === 6 || op[0] === 2)) { _ = 0; continue; }
+                    if (op[0] === 3 && (!t || (op[1] > t[0] && op[1] < t[3]))) { _.label = op[1]; break; }
+                    if (op[0] === 6 && _.label < t[1]) { _.label = t[1]; t = op; break; }
+                    if (t && _.label < t[2]) { _.label = t[2]; _.ops.push(op); break; }
+                    if (t[2]) _.ops.pop();
+                    _.trys.pop(); continue;
+            }
+            op = body.call(thisArg, _);
+        } catch (e) { op = [6, e]; y = 0; } finally { f = t = 0; }
+        if (op[0] & 5) throw op[1]; return { value: op[0] ? op[1] : void 0, done: true };
+    }
+};
+var __values = (this && this.__values) || function(o) {
+    var s = typeof Symbol === "function" && Symbol.iterator, m = s && o[s], i = 0;
+    if (m) return m.call(o);
+    if (o && typeof o.length === "number") return {
+        next: function () {
+            if (o && i >= o.length) o = void 0;
+            return { value: o && o[i++], done: !o };
+        }
+    };
+    throw new TypeError(s ? "Object is not iterable." : "Symbol.iterator is not defined.");
+};
+function simple() {
+    var received;
+    return __generator(this, function (_a) {
+        switch (_a.label) {
+            case 0: return [4 /*yield*/, 1];
+            case 1:
+                _a.sent();
+                return [4 /*yield*/, 2];
+            case 2:
+                received = _a.sent();
+                return [2 /*return*/, received];
+        }
+    });
+}
+function withTryFinally() {
+    return __generator(this, function (_a) {
+        switch (_a.label) {
+            case 0:
+                _a.trys.push([0, , 2, 4]);
+                return [4 /*yield*/, "try"];
+            case 1:
+                _a.sent();
+                use("after yield");
+                return [3 /*break*/, 4];
+            case 2:
+                cleanup();
+                return [4 /*yield*/, "finally"];
+            case 3:
+                _a.sent();
+                return [7 /*endfinally*/];
+            case 4: return [2 /*return*/];
+        }
+    });
+}
+function withTryCatchFinally(fail) {
+    var e_1;
+    return __generator(this, function (_a) {
+        switch (_a.label) {
+            case 0:
+                _a.trys.push([0, 2, 4, 5]);
+                if (fail) {
+                    throw new Error("failed");
+                }
+                return [4 /*yield*/, 1];
+            case 1:
+                _a.sent();
+                return [3 /*break*/, 5];
+            case 2:
+                e_1 = _a.sent();
+                return [4 /*yield*/, e_1];
+            case 3:
+                _a.sent();
+                return [3 /*break*/, 5];
+            case 4:
+                cleanup();
+                return [7 /*endfinally*/];
+            case 5: return [2 /*return*/, "done"];
+        }
+    });
+}
+function loops(items) {
+    var i, next;
+    return __generator(this, function (_a) {
+        switch (_a.label) {
+            case 0:
+                i = 0;
+                _a.label = 1;
+            case 1:
+                if (!(i < items.length)) return [3 /*break*/, 4];
+                if (items[i] < 0)
+                    return [3 /*break*/, 3];
+                return [4 /*yield*/, items[i]];
+            case 2:
+                _a.sent();
+                _a.label = 3;
+            case 3:
+                i++;
+                return [3 /*break*/, 1];
+            case 4:
+                if (!(true)) return [3 /*break*/, 6];
+                return [4 /*yield*/];
+            case 5:
+                next = _a.sent();
+                if (next === 0)
+                    return [3 /*break*/, 6];
+                return [3 /*break*/, 4];
+            case 6: return [2 /*return*/];
+        }
+    });
+}
+function delegating() {
+    var result;
+    return __generator(this, function (_a) {
+        switch (_a.label) {
+            case 0: return [5 /*yield**/, __values(simple())];
+            case 1:
+                _a.sent();
+                return [5 /*yield**/, __values(withTryCatchFinally(false))];
+            case 2:
+                result = _a.sent();
+                return [2 /*return*/, result];
+        }
+    });
+}
+var Container = /** @class */ (function () {
+    function Container() {
+    }
+    Container.prototype[Symbol.iterator] = function () {
+        return __generator(this, function (_a) {
+            switch (_a.label) {
+                case 0: return [5 /*yield**/, __values([1, 2, 3])];
+                case 1:
+                    _a.sent();
+                    return [2 /*return*/];
+            }
+        });
+    };
+    Container.prototype.method = function () {
+        var self;
+        return __generator(this, function (_a) {
+            switch (_a.label) {
+                case 0:
+                    self = this;
+                    return [4 /*yield*/, self];
+                case 1:
+                    _a.sent();
+                    return [2 /*return*/];
+            }
+        });
+    };
+    return Container;
+}());
+var gen = function () {
+    var _this = this;
+    return __generator(this, function (_a) {
+        switch (_a.label) {
+            case 0: return [4 /*yield*/, function () { return _this; }];
+            case 1:
+                _a.sent();
+                return [2 /*return*/];
+        }
+    });
+};
@@ -0,0 +1,134 @@
+//// [tests/cases/compiler/legacyDecoratorsES2015.ts] ////
+
+//// [legacyDecoratorsES2015.ts]
+declare function dec(...args: any[]): any;
+declare const key: string;
+
+@dec
+class A {
+    static self = A;
+    method() { return A; }
+}
+
+@dec
+export class B {
+    @dec prop = 1;
+    @dec [key]() {}
+    @dec ["literal"]() {}
+    @dec static staticProp = 2;
+}
+
+class C {
+    @dec get x() { return 1; }
+    set x(@dec value: number) {}
+    set y(@dec value: number) {}
+    @dec method(@dec @dec a: number, @dec b: string) {}
+}
+
+class D {
+    static #secret = 1;
+    @dec(D.#secret) method() {}
+}
+
+namespace N {
+    @dec
+    export class E {
+        constructor(@dec x: number) {}
+    }
+}
+
+const expr = class {
+    @dec method() {}
+};
+
+
+//// [legacyDecoratorsES2015.js]
+var __decorate = (this && this.__decorate) || function (decorators, target, key, desc) {
+    var c = arguments.length, r = c < 3 ? target : desc === null ? desc = Object.getOwnPropertyDescriptor(target, key) : desc, d;
+    if (typeof Reflect === "object" && typeof Reflect.decorate === "function") r = Reflect.decorate(decorators, target, key, desc);
+    else for (var i = decorators.length - 1; i >= 0; i--) if (d = decorators[i]) r = (c < 3 ? d(r) : c > 3 ? d(target, key, r) : d(target, key)) || r;
+    return c > 3 && r && Object.defineProperty(target, key, r), r;
+};
+var __param = (this && this.__param) || function (paramIndex, decorator) {
+    return function (target, key) { decorator(target, key, paramIndex); }
+};
+var __classPrivateFieldGet = (this && this.__classPrivateFieldGet) || function (receiver, state, kind, f) {
+    if (kind === "a" && !f) throw new TypeError("Private accessor was defined without a getter");
+    if (typeof state === "function" ? receiver !== state || !f : !state.has(receiver)) throw new TypeError("Cannot read private member from an object whose class did not declare it");
+    return kind === "m" ? f : kind === "a" ? f.call(receiver) : f ? f.value : state.get(receiver);
+};
+var _a, _b, _c, _D_secret;
+var A_1, _d;
+let A = A_1 = (_a = class A {
+        method() { return A_1; }
+    },
+    _a.self = A_1,
+    _a);
+A = A_1 = __decorate([
+    dec
+], A);
+let B = (_b = class B {
+        constructor() {
+            this.prop = 1;
+        }
+        [_d = key]() { }
+        ["literal"]() { }
+    },
+    _b.staticProp = 2,
+    _b);
+__decorate([
+    dec
+], B.prototype, "prop", void 0);
+__decorate([
+    dec
+], B.prototype, _d, null);
+__decorate([
+    dec
+], B.prototype, "literal", null);
+__decorate([
+    dec
+], B, "staticProp", void 0);
+B = __decorate([
+    dec
+], B);
+export { B };
+class C {
+    get x() { return 1; }
+    set x(value) { }
+    set y(value) { }
+    method(a, b) { }
+}
+__decorate([
+    dec,
+    __param(0, dec)
+], C.prototype, "x", null);
+__decorate([
+    dec,
+    __param(0, dec),
+    __param(0, dec),
+    __param(1, dec)
+], C.prototype, "method", null);
+class D {
+    method() { }
+}
+_c = D;
+_D_secret = { value: 1 };
+(() => {
+    __decorate([
+        dec(__classPrivateFieldGet(D, _c, "f", _D_secret))
+    ], D.prototype, "method", null);
+})();
+var N;
+(function (N) {
+    let E = class E {
+        constructor(x) { }
+    };
+    E = __decorate([
+        dec,
+        __param(0, dec)
+    ], E);
+    N.E = E;
+})(N || (N = {}));
+const expr = class {
+    method() { }
+};
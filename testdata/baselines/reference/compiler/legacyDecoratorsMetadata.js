@@ -0,0 +1,163 @@
+//// [tests/cases/compiler/legacyDecoratorsMetadata.ts] ////
+
+//// [inject.ts]
+export declare function Inject(): ParameterDecorator;
+export class Logger {}
+
+//// [main.ts]
+import { Inject, Logger } from "./inject";
+declare function dec(...args: any[]): any;
+declare function Injectable(): ClassDecorator;
+interface Config { value: number }
+
+export class Repository {}
+
+@Injectable()
+export class Service {
+    static instance?: Service;
+    @dec declare ambient: number;
+    @dec field!: string;
+    @dec static staticField = 1;
+
+    constructor(@Inject() private readonly logger: Logger, private repository: Repository, config: Config) {}
+
+    @dec method(@dec a: number, b: string[], ...rest: boolean[]): Promise<void> { return Promise.resolve(); }
+    @dec get accessor(): Repository { return this.repository; }
+    set accessor(value) {}
+    @dec [Symbol.iterator]() {}
+    @dec async asyncMethod() {}
+    @dec union(x: bigint | null, y: "a" | "b", z: Repository | undefined, w: unknown) {}
+
+    static create() { return { Service }; }
+}
+
+@dec
+export default class {
+    constructor(logger: Logger) {}
+}
+
+class Members {
+    @dec method(this: Members, x: number) {}
+    @dec static staticMethod() {}
+}
+
+
+//// [inject.js]
+"use strict";
+Object.defineProperty(exports, "__esModule", { value: true });
+exports.Logger = void 0;
+class Logger {
+}
+exports.Logger = Logger;
+//// [main.js]
+"use strict";
+var __decorate = (this && this.__decorate) || function (decorators, target, key, desc) {
+    var c = arguments.length, r = c < 3 ? target : desc === null ? desc = Object.getOwnPropertyDescriptor(target, key) : desc, d;
+    if (typeof Reflect === "object" && typeof Reflect.decorate === "function") r = Reflect.decorate(decorators, target, key, desc);
+    else for (var i = decorators.length - 1; i >= 0; i--) if (d = decorators[i]) r = (c < 3 ? d(r) : c > 3 ? d(target, key, r) : d(target, key)) || r;
+    return c > 3 && r && Object.defineProperty(target, key, r), r;
+};
+var __metadata = (this && this.__metadata) || function (k, v) {
+    if (typeof Reflect === "object" && typeof Reflect.metadata === "function") return Reflect.metadata(k, v);
+};
+var __param = (this && this.__param) || function (paramIndex, decorator) {
+    return function (target, key) { decorator(target, key, paramIndex); }
+};
+var Service_1, _a;
+Object.defineProperty(exports, "__esModule", { value: true });
+exports.Service = exports.Repository = void 0;
+const inject_1 = require("./inject");
+class Repository {
+}
+exports.Repository = Repository;
+let Service = class Service {
+    static { Service_1 = this; }
+    logger;
+    repository;
+    static instance;
+    field;
+    static staticField = 1;
+    constructor(logger, repository, config) {
+        this.logger = logger;
+        this.repository = repository;
+    }
+    method(a, b, ...rest) { return Promise.resolve(); }
+    get accessor() { return this.repository; }
+    set accessor(value) { }
+    [_a = Symbol.iterator]() { }
+    async asyncMethod() { }
+    union(x, y, z, w) { }
+    static create() { return { Service: Service_1 }; }
+};
+exports.Service = Service;
+__decorate([
+    dec,
+    __metadata("design:type", Number)
+], Service.prototype, "ambient", void 0);
+__decorate([
+    dec,
+    __metadata("design:type", String)
+], Service.prototype, "field", void 0);
+__decorate([
+    dec,
+    __param(0, dec),
+    __metadata("design:type", Function),
+    __metadata("design:paramtypes", [Number, Array, Boolean]),
+    __metadata("design:returntype", Promise)
+], Service.prototype, "method", null);
+__decorate([
+    dec,
+    __metadata("design:type", Repository),
+    __metadata("design:paramtypes", [Object])
+], Service.prototype, "accessor", null);
+__decorate([
+    dec,
+    __metadata("design:type", Function),
+    __metadata("design:paramtypes", []),
+    __metadata("design:returntype", void 0)
+], Service.prototype, _a, null);
+__decorate([
+    dec,
+    __metadata("design:type", Function),
+    __metadata("design:paramtypes", []),
+    __metadata("design:returntype", Promise)
+], Service.prototype, "asyncMethod", null);
+__decorate([
+    dec,
+    __metadata("design:type", Function),
+    __metadata("design:paramtypes", [Object, String, Object, Object]),
+    __metadata("design:returntype", void 0)
+], Service.prototype, "union", null);
+__decorate([
+    dec,
+    __metadata("design:type", Object)
+], Service, "staticField", void 0);
+exports.Service = Service = Service_1 = __decorate([
+    Injectable(),
+    __param(0, (0, inject_1.Inject)()),
+    __metadata("design:paramtypes", [inject_1.Logger, Repository, Object])
+], Service);
+let default_1 = class {
+    constructor(logger) { }
+};
+default_1 = __decorate([
+    dec,
+    __metadata("design:paramtypes", [inject_1.Logger])
+], default_1);
+exports.default = default_1;
+class Members {
+    method(x) { }
+    static staticMethod() { }
+}
+__decorate([
+    dec,
+    __metadata("design:type", Function),
+    __metadata("design:paramtypes", [Number]),
+    __metadata("design:returntype", void 0)
+], Members.prototype, "method", null);
+__decorate([
+    dec,
+    __metadata("design:type", Function),
+    __metadata("design:paramtypes", []),
+    __metadata("design:returntype", void 0)
+], Members, "staticMethod", null);
@@ -0,0 +1,135 @@
+//// [tests/cases/compiler/loopClosureCaptureDownlevelES5.ts] ////
+
+//// [loopClosureCaptureDownlevelES5.ts]
+declare function use(...args: any[]): void;
+
+const callbacks: (() => number)[] = [];
+for (let i = 0; i < 3; i++) {
+    callbacks.push(() => i);
+}
+
+outer: for (let i = 0; i < 3; i++) {
+    for (let j = 0; j < 3; j++) {
+        callbacks.push(() => i * j);
+        if (j === 1) {
+            continue outer;
+        }
+        if (i === 2) {
+            break outer;
+        }
+    }
+}
+
+function withReturn(items: number[]) {
+    for (let i = 0; i < items.length; i++) {
+        const item = items[i];
+        use(() => item);
+        if (item < 0) {
+            return item;
+        }
+        if (item === 0) {
+            continue;
+        }
+        if (item > 10) {
+            break;
+        }
+    }
+    return -1;
+}
+
+let x = 0;
+while (x < 3) {
+    let y = x++;
+    use(() => y);
+}
+
+for (const key in { a: 1, b: 2 }) {
+    use(() => key);
+}
+
+for (let i = 0, n = 3; i < n; i++) {
+    use(function () { return i + n; });
+    i += 0;
+}
+
+
+//// [loopClosureCaptureDownlevelES5.js]
+var callbacks = [];
+var _loop_1 = function (i) {
+    callbacks.push(function () { return i; });
+};
+for (var i = 0; i < 3; i++) {
+    _loop_1(i);
+}
+var _loop_2 = function (i) {
+    var _loop_6 = function (j) {
+        callbacks.push(function () { return i * j; });
+        if (j === 1) {
+            return "continue-outer";
+        }
+        if (i === 2) {
+            return "break-outer";
+        }
+    };
+    for (var j = 0; j < 3; j++) {
+        var state_2 = _loop_6(j);
+        switch (state_2) {
+            case "break-outer": return state_2;
+            case "continue-outer": return state_2;
+        }
+    }
+};
+outer: for (var i = 0; i < 3; i++) {
+    var state_1 = _loop_2(i);
+    switch (state_1) {
+        case "break-outer": break outer;
+        case "continue-outer": continue outer;
+    }
+}
+function withReturn(items) {
+    var _loop_7 = function (i) {
+        var item = items[i];
+        use(function () { return item; });
+        if (item < 0) {
+            return { value: item };
+        }
+        if (item === 0) {
+            return "continue";
+        }
+        if (item > 10) {
+            return "break";
+        }
+    };
+    for (var i = 0; i < items.length; i++) {
+        var state_3 = _loop_7(i);
+        if (typeof state_3 === "object")
+            return state_3.value;
+        if (state_3 === "break")
+            break;
+    }
+    return -1;
+}
+var x = 0;
+var _loop_3 = function () {
+    var y = x++;
+    use(function () { return y; });
+};
+while (x < 3) {
+    _loop_3();
+}
+var _loop_4 = function (key) {
+    use(function () { return key; });
+};
+for (var key in { a: 1, b: 2 }) {
+    _loop_4(key);
+}
+var _loop_5 = function (i, n) {
+    use(function () { return i + n; });
+    i += 0;
+    out_i_1 = i;
+};
+var out_i_1;
+for (var i = 0, n = 3; i < n; i++) {
+    _loop_5(i, n);
+    i = out_i_1;
+}
@@ -0,0 +1,108 @@
+//// [tests/cases/compiler/objectRestSpreadDownlevelES2017.ts] ////
+
+//// [objectRestSpreadDownlevelES2017.ts]
+declare const o: { a: number; b: string; c: boolean; [key: string]: any };
+declare const k: string;
+declare function use(...args: any[]): void;
+
+// spread
+const s1 = { ...o };
+const s2 = { x: 1, ...o, y: 2 };
+const s3 = { ...o, ...{ z: 3 } };
+
+// rest in declarations
+const { a, ...rest1 } = o;
+const { [k]: computed, ...rest2 } = o;
+let { b: { length: len }, ...rest3 } = o;
+
+// rest in assignments
+let x: number, y: any;
+({ a: x, ...y } = o);
+const r = ({ a: x, ...y } = o);
+
+// rest in for-of
+for (const { a, ...others } of [o]) {
+    use(a, others);
+}
+
+// rest in catch clauses
+try {
+    use();
+}
+catch ({ message, ...details }) {
+    use(message, details);
+}
+
+// rest in parameters
+function f1({ a, ...rest }: typeof o) {
+    use(a, rest);
+}
+function f2(first: number, { a, ...rest }: typeof o, [c, d]: number[], last = 1) {
+    use(first, a, rest, c, d, last);
+}
+const f3 = ({ a, ...rest }: typeof o) => rest;
+class C {
+    m({ a, ...rest }: typeof o) {
+        return { a, ...rest };
+    }
+}
+
+
+//// [objectRestSpreadDownlevelES2017.js]
+var __rest = (this && this.__rest) || function (s, e) {
+    var t = {};
+    for (var p in s) if (Object.prototype.hasOwnProperty.call(s, p) && e.indexOf(p) < 0)
+        t[p] = s[p];
+    if (s != null && typeof Object.getOwnPropertySymbols === "function")
+        for (var i = 0, p = Object.getOwnPropertySymbols(s); i < p.length; i++) {
+            if (e.indexOf(p[i]) < 0 && Object.prototype.propertyIsEnumerable.call(s, p[i]))
+                t[p[i]] = s[p[i]];
+        }
+    return t;
+};
+// spread
+const s1 = Object.assign({}, o);
+const s2 = Object.assign(Object.assign({ x: 1 }, o), { y: 2 });
+const s3 = Object.assign(Object.assign({}, o), { z: 3 });
+// rest in declarations
+const { a } = o, rest1 = __rest(o, ["a"]);
+const _a = o, _b = k, computed = _a[_b], rest2 = __rest(_a, [typeof _b === "symbol" ? _b : _b + ""]);
+let { b: { length: len } } = o, rest3 = __rest(o, ["b"]);
+// rest in assignments
+let x, y;
+({ a: x } = o, y = __rest(o, ["a"]));
+const r = ({ a: x } = o, y = __rest(o, ["a"]), o);
+// rest in for-of
+for (let _c of [o]) {
+    const { a } = _c, others = __rest(_c, ["a"]);
+    use(a, others);
+}
+// rest in catch clauses
+try {
+    use();
+}
+catch (_d) {
+    var { message } = _d, details = __rest(_d, ["message"]);
+    use(message, details);
+}
+// rest in parameters
+function f1(_a) {
+    var { a } = _a, rest = __rest(_a, ["a"]);
+    use(a, rest);
+}
+function f2(first, _a, _b, last) {
+    var { a } = _a, rest = __rest(_a, ["a"]);
+    var c = _b[0], d = _b[1];
+    if (last === void 0) { last = 1; }
+    use(first, a, rest, c, d, last);
+}
+const f3 = (_a) => {
+    var { a } = _a, rest = __rest(_a, ["a"]);
+    return rest;
+};
+class C {
+    m(_a) {
+        var { a } = _a, rest = __rest(_a, ["a"]);
+        return Object.assign({ a }, rest);
+    }
+}
@@ -0,0 +1,77 @@
+//// [tests/cases/compiler/optionalChainingDownlevel.ts] ////
+
+//// [optionalChainingDownlevel.ts]
+declare const a: { b?: { c(): number; d: number } | null; [key: string]: any } | undefined;
+declare const k: string;
+declare function f(): { x: number } | undefined;
+declare const g: (() => number) | undefined;
+
+a?.b;
+a?.[k];
+a?.b?.c();
+a?.b.c();
+a.b?.c();
+a.b.c?.();
+g?.();
+(a?.b.c)();
+(a?.b?.c)();
+f()?.x;
+f()?.x.toFixed();
+a?.b!.d;
+delete a?.b;
+delete a?.b.d;
+delete (a?.b);
+
+const x = a?.b?.d ?? 0;
+const y = f() ?? { x: 1 };
+const z = a ?? f() ?? g;
+
+class Base {
+    method?(): number;
+}
+
+class Derived extends Base {
+    test() {
+        return super.method?.();
+    }
+}
+
+function chainInFunction(o?: { p?: { q: number } }) {
+    return o?.p?.q ?? -1;
+}
+
+
+//// [optionalChainingDownlevel.js]
+var _a, _b, _c, _d, _e, _f, _g, _h, _j;
+var _k, _l, _m;
+a === null || a === void 0 ? void 0 : a.b;
+a === null || a === void 0 ? void 0 : a[k];
+(_a = a === null || a === void 0 ? void 0 : a.b) === null || _a === void 0 ? void 0 : _a.c();
+a === null || a === void 0 ? void 0 : a.b.c();
+(_b = a.b) === null || _b === void 0 ? void 0 : _b.c();
+(_d = (_c = a.b).c) === null || _d === void 0 ? void 0 : _d.call(_c);
+g === null || g === void 0 ? void 0 : g();
+(a === null || a === void 0 ? void 0 : (_e = a.b).c).call(_e);
+((_f = a === null || a === void 0 ? void 0 : a.b) === null || _f === void 0 ? void 0 : _f.c).call(_f);
+(_g = f()) === null || _g === void 0 ? void 0 : _g.x;
+(_h = f()) === null || _h === void 0 ? void 0 : _h.x.toFixed();
+a === null || a === void 0 ? void 0 : a.b.d;
+a === null || a === void 0 ? true : delete a.b;
+a === null || a === void 0 ? true : delete a.b.d;
+(a === null || a === void 0 ? true : delete a.b);
+const x = (_k = (_j = a === null || a === void 0 ? void 0 : a.b) === null || _j === void 0 ? void 0 : _j.d) !== null && _k !== void 0 ? _k : 0;
+const y = (_l = f()) !== null && _l !== void 0 ? _l : { x: 1 };
+const z = (_m = a !== null && a !== void 0 ? a : f()) !== null && _m !== void 0 ? _m : g;
+class Base {
+}
+class Derived extends Base {
+    test() {
+        var _a;
+        return (_a = super.method) === null || _a === void 0 ? void 0 : _a.call(this);
+    }
+}
+function chainInFunction(o) {
+    var _a;
+    var _b;
+    return (_b = (_a = o === null || o === void 0 ? void 0 : o.p) === null || _a === void 0 ? void 0 : _a.q) !== null && _b !== void 0 ? _b : -1;
+}
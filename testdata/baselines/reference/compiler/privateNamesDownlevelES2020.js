@@ -0,0 +1,130 @@
+//// [tests/cases/compiler/privateNamesDownlevelES2020.ts] ////
+
+//// [privateNamesDownlevelES2020.ts]
+class C {
+    #field = 1;
+    #unset: number;
+    static #staticField = 2;
+
+    #method(...args: any[]) {
+        return this.#field;
+    }
+
+    static #staticMethod() {
+        return C.#staticField;
+    }
+
+    get #accessor() {
+        return this.#field;
+    }
+    set #accessor(value: number) {
+        this.#field = value;
+    }
+
+    static get #staticAccessor() {
+        return 0;
+    }
+
+    test(other: C) {
+        this.#field = 2;
+        this.#field += 3;
+        this.#field ??= 4;
+        other.#field++;
+        const a = this.#field--;
+        const b = ++this.#accessor;
+        this.#method();
+        this.#method`template`;
+        C.#staticMethod();
+        C.#staticField = C.#staticAccessor;
+        [this.#field, other.#unset] = [1, 2];
+        ({ x: this.#accessor = 5 } = { x: 6 });
+        for (let i = 0; i < 1; this.#field++) { }
+        return #field in other;
+    }
+}
+
+class Nested {
+    #x = 1;
+    method() {
+        return class Inner {
+            #y = 2;
+            m(o: Nested) {
+                return o.#x + this.#y;
+            }
+        };
+    }
+}
+
+
+//// [privateNamesDownlevelES2020.js]
+var __classPrivateFieldSet = (this && this.__classPrivateFieldSet) || function (receiver, state, value, kind, f) {
+    if (kind === "m") throw new TypeError("Private method is not writable");
+    if (kind === "a" && !f) throw new TypeError("Private accessor was defined without a setter");
+    if (typeof state === "function" ? receiver !== state || !f : !state.has(receiver)) throw new TypeError("Cannot write private member to an object whose class did not declare it");
+    return (kind === "a" ? f.call(receiver, value) : f ? f.value = value : state.set(receiver, value)), value;
+};
+var __classPrivateFieldGet = (this && this.__classPrivateFieldGet) || function (receiver, state, kind, f) {
+    if (kind === "a" && !f) throw new TypeError("Private accessor was defined without a getter");
+    if (typeof state === "function" ? receiver !== state || !f : !state.has(receiver)) throw new TypeError("Cannot read private member from an object whose class did not declare it");
+    return kind === "m" ? f : kind === "a" ? f.call(receiver) : f ? f.value : state.get(receiver);
+};
+var __classPrivateFieldIn = (this && this.__classPrivateFieldIn) || function(state, receiver) {
+    if (receiver === null || (typeof receiver !== "object" && typeof receiver !== "function")) throw new TypeError("Cannot use 'in' operator on non-object");
+    return typeof state === "function" ? receiver === state : state.has(receiver);
+};
+var _a, _C_field, _C_unset, _C_staticField, _C_instances, _C_method, _C_staticMethod, _C_accessor_get, _C_accessor_set, _C_staticAccessor_get, _Nested_x;
+class C {
+    constructor() {
+        _C_instances.add(this);
+        _C_field.set(this, 1);
+        _C_unset.set(this, void 0);
+    }
+    test(other) {
+        var _b, _c, _d, _e, _f, _g, _h, _j;
+        __classPrivateFieldSet(this, _C_field, 2, "f");
+        __classPrivateFieldSet(this, _C_field, __classPrivateFieldGet(this, _C_field, "f") + 3, "f");
+        __classPrivateFieldGet(this, _C_field, "f") ?? __classPrivateFieldSet(this, _C_field, 4, "f");
+        __classPrivateFieldSet(_b = other, _C_field, (_c = __classPrivateFieldGet(_b, _C_field, "f"), _c++, _c), "f");
+        const a = (__classPrivateFieldSet(this, _C_field, (_e = __classPrivateFieldGet(this, _C_field, "f"), _d = _e--, _e), "f"), _d);
+        const b = __classPrivateFieldSet(this, _C_instances, (_f = __classPrivateFieldGet(this, _C_instances, "a", _C_accessor_get), ++_f), "a", _C_accessor_set);
+        __classPrivateFieldGet(this, _C_instances, "m", _C_method).call(this);
+        __classPrivateFieldGet(this, _C_instances, "m", _C_method).bind(this) `template`;
+        __classPrivateFieldGet(C, _a, "m", _C_staticMethod).call(C);
+        __classPrivateFieldSet(C, _a, __classPrivateFieldGet(C, _a, "a", _C_staticAccessor_get), "f", _C_staticField);
+        _g = this, [({ set value(_b) { __classPrivateFieldSet(_g, _C_field, _b, "f"); } }).value, ({ set value(_b) { __classPrivateFieldSet(other, _C_unset, _b, "f"); } }).value] = [1, 2];
+        (_h = this, { x: ({ set value(_b) { __classPrivateFieldSet(_h, _C_instances, _b, "a", _C_accessor_set); } }).value = 5 } = { x: 6 });
+        for (let i = 0; i < 1; __classPrivateFieldSet(this, _C_field, (_j = __classPrivateFieldGet(this, _C_field, "f"), _j++, _j), "f")) { }
+        return __classPrivateFieldIn(_C_field, other);
+    }
+}
+_a = C, _C_field = new WeakMap(), _C_unset = new WeakMap(), _C_instances = new WeakSet(), _C_method = function _C_method(...args) {
+    return __classPrivateFieldGet(this, _C_field, "f");
+}, _C_staticMethod = function _C_staticMethod() {
+    return __classPrivateFieldGet(C, _a, "f", _C_staticField);
+}, _C_accessor_get = function _C_accessor_get() {
+    return __classPrivateFieldGet(this, _C_field, "f");
+}, _C_accessor_set = function _C_accessor_set(value) {
+    __classPrivateFieldSet(this, _C_field, value, "f");
+}, _C_staticAccessor_get = function _C_staticAccessor_get() {
+    return 0;
+};
+_C_staticField = { value: 2 };
+class Nested {
+    constructor() {
+        _Nested_x.set(this, 1);
+    }
+    method() {
+        var _b, _Inner_y;
+        return _b = class Inner {
+                constructor() {
+                    _Inner_y.set(this, 2);
+                }
+                m(o) {
+                    return __classPrivateFieldGet(o, _Nested_x, "f") + __classPrivateFieldGet(this, _Inner_y, "f");
+                }
+            },
+            _Inner_y = new WeakMap(),
+            _b;
+    }
+}
+_Nested_x = new WeakMap();
@@ -7,6 +7,6 @@ const x: number = "";
 const y: string = 1;
 
 //// [foo.js]
-const x = "";
+var x = "";
 //// [bar.js]
-const y = 1;
+var y = 1;
@@ -4,4 +4,4 @@
 const x: number = "";
 
 //// [simpleTestSingleFile.js]
-const x = "";
+var x = "";
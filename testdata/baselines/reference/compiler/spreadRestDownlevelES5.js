@@ -0,0 +1,101 @@
+//// [tests/cases/compiler/spreadRestDownlevelES5.ts] ////
+
+//// [spreadRestDownlevelES5.ts]
+declare function use(...args: any[]): void;
+
+function restParams(first: number, ...rest: number[]) {
+    return rest.length + first;
+}
+
+function sum(...values: number[]) {
+    return values.length;
+}
+
+const numbers = [1, 2, 3];
+restParams(0, ...numbers);
+sum(...numbers, 4, ...numbers);
+use(...numbers);
+
+const combined = [0, ...numbers, 4, ...[5, 6]];
+const copied = [...combined];
+
+class Box {
+    method(...args: number[]) {
+        return args;
+    }
+}
+const box = new Box();
+box.method(...numbers);
+new Box().method(1, ...copied);
+
+function construct(ctor: new (...args: any[]) => Box) {
+    return new ctor(...numbers);
+}
+
+const [head, ...tail] = numbers;
+let h: number, t: number[];
+[h, ...t] = numbers;
+const arrow = (...values: string[]) => values.join(",");
+use(head, tail, h, t, arrow("a", "b"), construct);
+
+
+//// [spreadRestDownlevelES5.js]
+var __spreadArray = (this && this.__spreadArray) || function (to, from, pack) {
+    if (pack || arguments.length === 2) for (var i = 0, l = from.length, ar; i < l; i++) {
+        if (ar || !(i in from)) {
+            if (!ar) ar = Array.prototype.slice.call(from, 0, i);
+            ar[i] = from[i];
+        }
+    }
+    return to.concat(ar || Array.prototype.slice.call(from));
+};
+var _a;
+function restParams(first) {
+    var rest = [];
+    for (var _i = 1; _i < arguments.length; _i++) {
+        rest[_i - 1] = arguments[_i];
+    }
+    return rest.length + first;
+}
+function sum() {
+    var values = [];
+    for (var _i = 0; _i < arguments.length; _i++) {
+        values[_i] = arguments[_i];
+    }
+    return values.length;
+}
+var numbers = [1, 2, 3];
+restParams.apply(void 0, __spreadArray([0], numbers, false));
+sum.apply(void 0, __spreadArray(__spreadArray(__spreadArray([], numbers, false), [4], false), numbers, false));
+use.apply(void 0, numbers);
+var combined = __spreadArray(__spreadArray(__spreadArray([0], numbers, true), [4], false), [5, 6], false);
+var copied = __spreadArray([], combined, true);
+var Box = /** @class */ (function () {
+    function Box() {
+    }
+    Box.prototype.method = function () {
+        var args = [];
+        for (var _i = 0; _i < arguments.length; _i++) {
+            args[_i] = arguments[_i];
+        }
+        return args;
+    };
+    return Box;
+}());
+var box = new Box();
+box.method.apply(box, numbers);
+(_a = new Box()).method.apply(_a, __spreadArray([1], copied, false));
+function construct(ctor) {
+    return new (ctor.bind.apply(ctor, __spreadArray([void 0], numbers, false)))();
+}
+var head = numbers[0], tail = numbers.slice(1);
+var h, t;
+h = numbers[0], t = numbers.slice(1);
+var arrow = function () {
+    var values = [];
+    for (var _i = 0; _i < arguments.length; _i++) {
+        values[_i] = arguments[_i];
+    }
+    return values.join(",");
+};
+use(head, tail, h, t, arrow("a", "b"), construct);
@@ -0,0 +1,43 @@
+//// [tests/cases/compiler/templateLiteralsDownlevelES5.ts] ////
+
+//// [templateLiteralsDownlevelES5.ts]
+declare function tag(strings: TemplateStringsArray, ...values: any[]): string;
+
+const name = "world";
+const count = 3;
+const plain = `plain`;
+const simple = `hello ${name}`;
+const multiple = `${count} items for ${name}, ${count * 2} total`;
+const nested = `outer ${`inner ${name}`} end`;
+const multiline = `line one
+line two ${name}`;
+const escaped = `tab\there A \`backtick\` \${notInterpolated}`;
+
+const tagged = tag`hello ${name} and ${count}`;
+const taggedPlain = tag`no substitutions`;
+const taggedRaw = tag`raw \n ${name} \u{1F600}`;
+
+function inFunction() {
+    return tag`first` + tag`second ${1}`;
+}
+
+
+//// [templateLiteralsDownlevelES5.js]
+var __makeTemplateObject = (this && this.__makeTemplateObject) || function (cooked, raw) {
+    if (Object.defineProperty) { Object.defineProperty(cooked, "raw", { value: raw }); } else { cooked.raw = raw; }
+    return cooked;
+};
+var name = "world";
+var count = 3;
+var plain = "plain";
+var simple = "hello ".concat(name);
+var multiple = "".concat(count, " items for ").concat(name, ", ").concat(count * 2, " total");
+var nested = "outer ".concat("inner ".concat(name), " end");
+var multiline = "line one\nline two ".concat(name);
+var escaped = "tab\there A `backtick` ${notInterpolated}";
+var tagged = tag(__makeTemplateObject(["hello ", " and ", ""], ["hello ", " and ", ""]), name, count);
+var taggedPlain = tag(__makeTemplateObject(["no substitutions"], ["no substitutions"]));
+var taggedRaw = tag(__makeTemplateObject(["raw \n ", " \uD83D\uDE00"], ["raw \\n ", " \\u{1F600}"]), name);
+function inFunction() {
+    return tag(__makeTemplateObject(["first"], ["first"])) + tag(__makeTemplateObject(["second ", ""], ["second ", ""]), 1);
+}
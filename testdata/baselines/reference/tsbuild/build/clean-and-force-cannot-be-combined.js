@@ -0,0 +1,63 @@
+
+currentDirectory::/home/src/workspaces/solution
+useCaseSensitiveFileNames::true
+Input::--b --clean --force
+//// [/home/src/workspaces/solution/core/index.ts] new file
+export function add(a: number, b: number) { return a + b; }
+//// [/home/src/workspaces/solution/core/tsconfig.json] new file
+{
+	"compilerOptions": {
+		"composite": true,
+	},
+}
+//// [/home/src/workspaces/solution/logic/index.ts] new file
+import { add } from "../core/index";
+export function inc(a: number) { return add(a, 1); }
+//// [/home/src/workspaces/solution/logic/tsconfig.json] new file
+{
+	"compilerOptions": {
+		"composite": true,
+	},
+	"references": [
+		{ "path": "../core" },
+	],
+}
+//// [/home/src/workspaces/solution/tests/index.ts] new file
+import { add } from "../core/index";
+import { inc } from "../logic/index";
+export const result = inc(add(1, 2));
+//// [/home/src/workspaces/solution/tests/tsconfig.json] new file
+{
+	"compilerOptions": {
+		"composite": true,
+	},
+	"references": [
+		{ "path": "../core" },
+		{ "path": "../logic" },
+	],
+}
+//// [/home/src/workspaces/solution/tsconfig.json] new file
+{
+	"files": [],
+	"references": [
+		{ "path": "./tests" },
+	],
+}
+
+ExitStatus:: 1
+
+BuildOptions::{
+    "force": true,
+    "clean": true
+}
+
+CompilerOptions::{}
+Output::
+[91merror[0m[90m TS6370: [0mOptions 'clean' and 'force' cannot be combined.//// [/home/src/workspaces/solution/core/index.ts] no change
+//// [/home/src/workspaces/solution/core/tsconfig.json] no change
+//// [/home/src/workspaces/solution/logic/index.ts] no change
+//// [/home/src/workspaces/solution/logic/tsconfig.json] no change
+//// [/home/src/workspaces/solution/tests/index.ts] no change
+//// [/home/src/workspaces/solution/tests/tsconfig.json] no change
+//// [/home/src/workspaces/solution/tsconfig.json] no change
+
@@ -0,0 +1,160 @@
+
+currentDirectory::/home/src/workspaces/solution
+useCaseSensitiveFileNames::true
+Input::--b --singleThreaded
+//// [/home/src/workspaces/solution/core/index.ts] new file
+export function add(a: number, b: number) { return a + b; }
+//// [/home/src/workspaces/solution/core/tsconfig.json] new file
+{
+	"compilerOptions": {
+		"composite": true,
+	},
+}
+//// [/home/src/workspaces/solution/logic/index.ts] new file
+import { add } from "../core/index";
+export function inc(a: number) { return add(a, 1); }
+//// [/home/src/workspaces/solution/logic/tsconfig.json] new file
+{
+	"compilerOptions": {
+		"composite": true,
+	},
+	"references": [
+		{ "path": "../core" },
+	],
+}
+//// [/home/src/workspaces/solution/tests/index.ts] new file
+import { add } from "../core/index";
+import { inc } from "../logic/index";
+export const result = inc(add(1, 2));
+//// [/home/src/workspaces/solution/tests/tsconfig.json] new file
+{
+	"compilerOptions": {
+		"composite": true,
+	},
+	"references": [
+		{ "path": "../core" },
+		{ "path": "../logic" },
+	],
+}
+//// [/home/src/workspaces/solution/tsconfig.json] new file
+{
+	"files": [],
+	"references": [
+		{ "path": "./tests" },
+	],
+}
+
+ExitStatus:: 0
+
+BuildOptions::{}
+
+CompilerOptions::{
+    "singleThreaded": true
+}
+Output::
+//// [/home/src/workspaces/solution/core/index.d.ts] new file
+export declare function add(a: number, b: number): number;
+
+//// [/home/src/workspaces/solution/core/index.js] new file
+"use strict";
+Object.defineProperty(exports, "__esModule", { value: true });
+exports.add = add;
+function add(a, b) { return a + b; }
+
+//// [/home/src/workspaces/solution/core/index.ts] no change
+//// [/home/src/workspaces/solution/core/tsconfig.json] no change
+//// [/home/src/workspaces/solution/core/tsconfig.tsbuildinfo] new file
+{"version":"7.0.0-dev","fileNames":["bundled:///libs/lib.d.ts","bundled:///libs/lib.es5.d.ts","bundled:///libs/lib.dom.d.ts","bundled:///libs/lib.webworker.importscripts.d.ts","bundled:///libs/lib.scripthost.d.ts","bundled:///libs/lib.decorators.d.ts","bundled:///libs/lib.decorators.legacy.d.ts","index.ts"],"fileInfos":[{"version":"a7297ff837fcdf174a9524925966429eb8e5feecc2cc55cc06574e6b092c1eaa","affectsGlobalScope":true,"impliedNodeFormat":1},{"version":"69684132aeb9b5642cbcd9e22dff7818ff0ee1aa831728af0ecf97d3364d5546","affectsGlobalScope":true,"impliedNodeFormat":1},{"version":"092c2bfe125ce69dbb1223c85d68d4d2397d7d8411867b5cc03cec902c233763","affectsGlobalScope":true,"impliedNodeFormat":1},{"version":"80e18897e5884b6723488d4f5652167e7bb5024f946743134ecc4aa4ee731f89","affectsGlobalScope":true,"impliedNodeFormat":1},{"version":"cd034f499c6cdca722b60c04b5b1b78e058487a7085a8e0d6fb50809947ee573","affectsGlobalScope":true,"impliedNodeFormat":1},{"version":"8e7f8264d0fb4c5339605a15daadb037bf238c10b654bb3eee14208f860a32ea","affectsGlobalScope":true,"impliedNodeFormat":1},{"version":"782dec38049b92d4e85c1585fbea5474a219c6984a35b004963b00beb1aab538","affectsGlobalScope":true,"impliedNodeFormat":1},{"version":"89a475d2a1b0c55c4db0f7c95ec65651e5dddde1dc5153ae52d1203f325ae0af","signature":"b1f3a498868e537f8a9f55a3f61cbb4d6c506c9678987f51fa12ba40c12a4457","impliedNodeFormat":1}],"options":{"composite":true}}
+//// [/home/src/workspaces/solution/logic/index.d.ts] new file
+export declare function inc(a: number): number;
+
+//// [/home/src/workspaces/solution/logic/index.js] new file
+"use strict";
+Object.defineProperty(exports, "__esModule", { value: true });
+exports.inc = inc;
+const index_1 = require("../core/index");
+function inc(a) { return (0, index_1.add)(a, 1); }
+
+//// [/home/src/workspaces/solution/logic/index.ts] no change
+//// [/home/src/workspaces/solution/logic/tsconfig.json] no change
+//// [/home/src/workspaces/solution/logic/tsconfig.tsbuildinfo] new file
+{"version":"7.0.0-dev","fileNames":["bundled:///libs/lib.d.ts","bundled:///libs/lib.es5.d.ts","bundled:///libs/lib.dom.d.ts","bundled:///libs/lib.webworker.importscripts.d.ts","bundled:///libs/lib.scripthost.d.ts","bundled:///libs/lib.decorators.d.ts","bundled:///libs/lib.decorators.legacy.d.ts","../core/index.d.ts","index.ts"],"fileInfos":[{"version":"a7297ff837fcdf174a9524925966429eb8e5feecc2cc55cc06574e6b092c1eaa","affectsGlobalScope":true,"impliedNodeFormat":1},{"version":"69684132aeb9b5642cbcd9e22dff7818ff0ee1aa831728af0ecf97d3364d5546","affectsGlobalScope":true,"impliedNodeFormat":1},{"version":"092c2bfe125ce69dbb1223c85d68d4d2397d7d8411867b5cc03cec902c233763","affectsGlobalScope":true,"impliedNodeFormat":1},{"version":"80e18897e5884b6723488d4f5652167e7bb5024f946743134ecc4aa4ee731f89","affectsGlobalScope":true,"impliedNodeFormat":1},{"version":"cd034f499c6cdca722b60c04b5b1b78e058487a7085a8e0d6fb50809947ee573","affectsGlobalScope":true,"impliedNodeFormat":1},{"version":"8e7f8264d0fb4c5339605a15daadb037bf238c10b654bb3eee14208f860a32ea","affectsGlobalScope":true,"impliedNodeFormat":1},{"version":"782dec38049b92d4e85c1585fbea5474a219c6984a35b004963b00beb1aab538","affectsGlobalScope":true,"impliedNodeFormat":1},{"version":"894ddab47771a240a0731f5d7557aee3238b99ebdf5b71fc88e7967c45d88e41","impliedNodeFormat":1},{"version":"6d2f82ebe4ccad0629d088df7f1b7d2e56f6d31a08666f521d65a3b481ad2de5","signature":"e709d5fe91e88fd5f58a06225a30a341a872bf96f09257d593f53aedb088e45f","impliedNodeFormat":1}],"fileIdsList":[[8]],"options":{"composite":true},"referencedMap":[[9,1]]}
+//// [/home/src/workspaces/solution/tests/index.d.ts] new file
+export declare const result: number;
+
+//// [/home/src/workspaces/solution/tests/index.js] new file
+"use strict";
+Object.defineProperty(exports, "__esModule", { value: true });
+exports.result = void 0;
+const index_1 = require("../core/index");
+const index_2 = require("../logic/index");
+exports.result = (0, index_2.inc)((0, index_1.add)(1, 2));
+
+//// [/home/src/workspaces/solution/tests/index.ts] no change
+//// [/home/src/workspaces/solution/tests/tsconfig.json] no change
+//// [/home/src/workspaces/solution/tests/tsconfig.tsbuildinfo] new file
+{"version":"7.0.0-dev","fileNames":["bundled:///libs/lib.d.ts","bundled:///libs/lib.es5.d.ts","bundled:///libs/lib.dom.d.ts","bundled:///libs/lib.webworker.importscripts.d.ts","bundled:///libs/lib.scripthost.d.ts","bundled:///libs/lib.decorators.d.ts","bundled:///libs/lib.decorators.legacy.d.ts","../core/index.d.ts","../logic/index.d.ts","index.ts"],"fileInfos":[{"version":"a7297ff837fcdf174a9524925966429eb8e5feecc2cc55cc06574e6b092c1eaa","affectsGlobalScope":true,"impliedNodeFormat":1},{"version":"69684132aeb9b5642cbcd9e22dff7818ff0ee1aa831728af0ecf97d3364d5546","affectsGlobalScope":true,"impliedNodeFormat":1},{"version":"092c2bfe125ce69dbb1223c85d68d4d2397d7d8411867b5cc03cec902c233763","affectsGlobalScope":true,"impliedNodeFormat":1},{"version":"80e18897e5884b6723488d4f5652167e7bb5024f946743134ecc4aa4ee731f89","affectsGlobalScope":true,"impliedNodeFormat":1},{"version":"cd034f499c6cdca722b60c04b5b1b78e058487a7085a8e0d6fb50809947ee573","affectsGlobalScope":true,"impliedNodeFormat":1},{"version":"8e7f8264d0fb4c5339605a15daadb037bf238c10b654bb3eee14208f860a32ea","affectsGlobalScope":true,"impliedNodeFormat":1},{"version":"782dec38049b92d4e85c1585fbea5474a219c6984a35b004963b00beb1aab538","affectsGlobalScope":true,"impliedNodeFormat":1},{"version":"894ddab47771a240a0731f5d7557aee3238b99ebdf5b71fc88e7967c45d88e41","impliedNodeFormat":1},{"version":"10c68bd90ea558b05fc46c60dd05af2eec53dcc88e0aa7a9858653aae155dfcd","impliedNodeFormat":1},{"version":"71c5d5adfc33a88540e17c8815e586c858cdd644465f7e643977324601047b62","signature":"9f88c4c9707eb38eebcce4b20b71c52b1e20e0feed71d6d94e689387e0b85633","impliedNodeFormat":1}],"fileIdsList":[[8,9]],"options":{"composite":true},"referencedMap":[[10,1]]}
+//// [/home/src/workspaces/solution/tsconfig.json] no change
+
+
+
+Edit:: clean dry
+Input::--b --clean --dry
+ExitStatus:: 0
+
+BuildOptions::{
+    "dry": true,
+    "clean": true
+}
+
+CompilerOptions::{}
+Output::
+A non-dry build would delete the following files: 
+ * /home/src/workspaces/solution/core/index.js
+ * /home/src/workspaces/solution/core/index.d.ts
+ * /home/src/workspaces/solution/core/tsconfig.tsbuildinfo
+ * /home/src/workspaces/solution/logic/index.js
+ * /home/src/workspaces/solution/logic/index.d.ts
+ * /home/src/workspaces/solution/logic/tsconfig.tsbuildinfo
+ * /home/src/workspaces/solution/tests/index.js
+ * /home/src/workspaces/solution/tests/index.d.ts
+ * /home/src/workspaces/solution/tests/tsconfig.tsbuildinfo
+
+//// [/home/src/workspaces/solution/core/index.d.ts] no change
+//// [/home/src/workspaces/solution/core/index.js] no change
+//// [/home/src/workspaces/solution/core/index.ts] no change
+//// [/home/src/workspaces/solution/core/tsconfig.json] no change
+//// [/home/src/workspaces/solution/core/tsconfig.tsbuildinfo] no change
+//// [/home/src/workspaces/solution/logic/index.d.ts] no change
+//// [/home/src/workspaces/solution/logic/index.js] no change
+//// [/home/src/workspaces/solution/logic/index.ts] no change
+//// [/home/src/workspaces/solution/logic/tsconfig.json] no change
+//// [/home/src/workspaces/solution/logic/tsconfig.tsbuildinfo] no change
+//// [/home/src/workspaces/solution/tests/index.d.ts] no change
+//// [/home/src/workspaces/solution/tests/index.js] no change
+//// [/home/src/workspaces/solution/tests/index.ts] no change
+//// [/home/src/workspaces/solution/tests/tsconfig.json] no change
+//// [/home/src/workspaces/solution/tests/tsconfig.tsbuildinfo] no change
+//// [/home/src/workspaces/solution/tsconfig.json] no change
+
+
+
+Edit:: clean
+Input::--b --clean
+ExitStatus:: 0
+
+BuildOptions::{
+    "clean": true
+}
+
+CompilerOptions::{}
+Output::
+No output
+//// [/home/src/workspaces/solution/core/index.ts] no change
+//// [/home/src/workspaces/solution/core/tsconfig.json] no change
+//// [/home/src/workspaces/solution/logic/index.ts] no change
+//// [/home/src/workspaces/solution/logic/tsconfig.json] no change
+//// [/home/src/workspaces/solution/tests/index.ts] no change
+//// [/home/src/workspaces/solution/tests/tsconfig.json] no change
+//// [/home/src/workspaces/solution/tsconfig.json] no change
+
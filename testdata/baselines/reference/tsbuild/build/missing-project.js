@@ -0,0 +1,16 @@
+
+currentDirectory::/home/src/workspaces/solution
+useCaseSensitiveFileNames::true
+Input::--b missing
+
+ExitStatus:: 1
+
+BuildOptions::{}
+
+CompilerOptions::{}
+Output::
+[91merror[0m[90m TS5083: [0mCannot read file '/home/src/workspaces/solution/missing/tsconfig.json'.
+
+Found 1 error.
+
+
@@ -0,0 +1,25 @@
+
+currentDirectory::/home/src/workspaces/solution
+useCaseSensitiveFileNames::true
+Input::--b a
+//// [/home/src/workspaces/solution/a/index.ts] new file
+export const a = 1;
+//// [/home/src/workspaces/solution/a/tsconfig.json] new file
+{ "compilerOptions": { "composite": true }, "references": [{ "path": "../b" }] }
+//// [/home/src/workspaces/solution/b/index.ts] new file
+export const b = 1;
+//// [/home/src/workspaces/solution/b/tsconfig.json] new file
+{ "compilerOptions": { "composite": true }, "references": [{ "path": "../a" }] }
+
+ExitStatus:: 4
+
+BuildOptions::{}
+
+CompilerOptions::{}
+Output::
+[91merror[0m[90m TS6202: [0mProject references may not form a circular graph. Cycle detected: /home/src/workspaces/solution/a/tsconfig.json
+/home/src/workspaces/solution/b/tsconfig.json//// [/home/src/workspaces/solution/a/index.ts] no change
+//// [/home/src/workspaces/solution/a/tsconfig.json] no change
+//// [/home/src/workspaces/solution/b/index.ts] no change
+//// [/home/src/workspaces/solution/b/tsconfig.json] no change
+
@@ -0,0 +1,118 @@
+
+currentDirectory::/home/src/workspaces/solution
+useCaseSensitiveFileNames::true
+Input::--b b --verbose --stopBuildOnErrors --singleThreaded
+//// [/home/src/workspaces/solution/a/index.ts] new file
+export const a: number = "hello";
+//// [/home/src/workspaces/solution/a/tsconfig.json] new file
+{ "compilerOptions": { "composite": true } }
+//// [/home/src/workspaces/solution/b/index.ts] new file
+import { a } from "../a"; export const b = a;
+//// [/home/src/workspaces/solution/b/tsconfig.json] new file
+{ "compilerOptions": { "composite": true }, "references": [{ "path": "../a" }] }
+
+ExitStatus:: 1
+
+BuildOptions::{
+    "verbose": true,
+    "stopBuildOnErrors": true
+}
+
+CompilerOptions::{
+    "singleThreaded": true
+}
+Output::
+Projects in this build: 
+    * a/tsconfig.json
+    * b/tsconfig.json
+
+
+Project 'a/tsconfig.json' is out of date because output file 'a/tsconfig.tsbuildinfo' does not exist
+
+Building project 'a/tsconfig.json'...
+
+[96ma/index.ts[0m:[93m1[0m:[93m14[0m - [91merror[0m[90m TS2322: [0mType 'string' is not assignable to type 'number'.
+
+[7m1[0m export const a: number = "hello";
+[7m [0m [91m             ~[0m
+
+Project 'b/tsconfig.json' can't be built because its dependency 'a/tsconfig.json' has errors
+
+Skipping build of project 'b/tsconfig.json' because its dependency 'a/tsconfig.json' has errors
+
+
+
+Found 1 error in a/index.ts[90m:1[0m
+
+//// [/home/src/workspaces/solution/a/index.d.ts] new file
+export declare const a: number;
+
+//// [/home/src/workspaces/solution/a/index.js] new file
+"use strict";
+Object.defineProperty(exports, "__esModule", { value: true });
+exports.a = void 0;
+exports.a = "hello";
+
+//// [/home/src/workspaces/solution/a/index.ts] no change
+//// [/home/src/workspaces/solution/a/tsconfig.json] no change
+//// [/home/src/workspaces/solution/a/tsconfig.tsbuildinfo] new file
+{"version":"7.0.0-dev","errors":true,"fileNames":["bundled:///libs/lib.d.ts","bundled:///libs/lib.es5.d.ts","bundled:///libs/lib.dom.d.ts","bundled:///libs/lib.webworker.importscripts.d.ts","bundled:///libs/lib.scripthost.d.ts","bundled:///libs/lib.decorators.d.ts","bundled:///libs/lib.decorators.legacy.d.ts","index.ts"],"fileInfos":[{"version":"a7297ff837fcdf174a9524925966429eb8e5feecc2cc55cc06574e6b092c1eaa","affectsGlobalScope":true,"impliedNodeFormat":1},{"version":"69684132aeb9b5642cbcd9e22dff7818ff0ee1aa831728af0ecf97d3364d5546","affectsGlobalScope":true,"impliedNodeFormat":1},{"version":"092c2bfe125ce69dbb1223c85d68d4d2397d7d8411867b5cc03cec902c233763","affectsGlobalScope":true,"impliedNodeFormat":1},{"version":"80e18897e5884b6723488d4f5652167e7bb5024f946743134ecc4aa4ee731f89","affectsGlobalScope":true,"impliedNodeFormat":1},{"version":"cd034f499c6cdca722b60c04b5b1b78e058487a7085a8e0d6fb50809947ee573","affectsGlobalScope":true,"impliedNodeFormat":1},{"version":"8e7f8264d0fb4c5339605a15daadb037bf238c10b654bb3eee14208f860a32ea","affectsGlobalScope":true,"impliedNodeFormat":1},{"version":"782dec38049b92d4e85c1585fbea5474a219c6984a35b004963b00beb1aab538","affectsGlobalScope":true,"impliedNodeFormat":1},{"version":"35507f1111fb3a41301277fd44f9c2720a820d604d5acdb3bf80d29af1074f1f","signature":"8dea6c69b46ef0920bbbd1f86b40f631bc31c338c7df0ccedd37bfa854708991","impliedNodeFormat":1}],"options":{"composite":true},"semanticDiagnosticsPerFile":[[8,[{"pos":13,"end":14,"code":2322,"category":1,"message":"Type 'string' is not assignable to type 'number'."}]]]}
+//// [/home/src/workspaces/solution/b/index.ts] no change
+//// [/home/src/workspaces/solution/b/tsconfig.json] no change
+
+
+
+Edit:: without stopBuildOnErrors
+Input::--b b --verbose --singleThreaded
+ExitStatus:: 2
+
+BuildOptions::{
+    "verbose": true
+}
+
+CompilerOptions::{
+    "singleThreaded": true
+}
+Output::
+Projects in this build: 
+    * a/tsconfig.json
+    * b/tsconfig.json
+
+
+Project 'a/tsconfig.json' is out of date because buildinfo file 'a/tsconfig.tsbuildinfo' indicates that program needs to report errors.
+
+Building project 'a/tsconfig.json'...
+
+[96ma/index.ts[0m:[93m1[0m:[93m14[0m - [91merror[0m[90m TS2322: [0mType 'string' is not assignable to type 'number'.
+
+[7m1[0m export const a: number = "hello";
+[7m [0m [91m             ~[0m
+
+Project 'b/tsconfig.json' is out of date because output file 'b/tsconfig.tsbuildinfo' does not exist
+
+Building project 'b/tsconfig.json'...
+
+
+
+Found 1 error in a/index.ts[90m:1[0m
+
+//// [/home/src/workspaces/solution/a/index.d.ts] no change
+//// [/home/src/workspaces/solution/a/index.js] no change
+//// [/home/src/workspaces/solution/a/index.ts] no change
+//// [/home/src/workspaces/solution/a/tsconfig.json] no change
+//// [/home/src/workspaces/solution/a/tsconfig.tsbuildinfo] no change
+//// [/home/src/workspaces/solution/b/index.d.ts] new file
+export declare const b: number;
+
+//// [/home/src/workspaces/solution/b/index.js] new file
+"use strict";
+Object.defineProperty(exports, "__esModule", { value: true });
+exports.b = void 0;
+const a_1 = require("../a");
+exports.b = a_1.a;
+
+//// [/home/src/workspaces/solution/b/index.ts] no change
+//// [/home/src/workspaces/solution/b/tsconfig.json] no change
+//// [/home/src/workspaces/solution/b/tsconfig.tsbuildinfo] new file
+{"version":"7.0.0-dev","fileNames":["bundled:///libs/lib.d.ts","bundled:///libs/lib.es5.d.ts","bundled:///libs/lib.dom.d.ts","bundled:///libs/lib.webworker.importscripts.d.ts","bundled:///libs/lib.scripthost.d.ts","bundled:///libs/lib.decorators.d.ts","bundled:///libs/lib.decorators.legacy.d.ts","../a/index.d.ts","index.ts"],"fileInfos":[{"version":"a7297ff837fcdf174a9524925966429eb8e5feecc2cc55cc06574e6b092c1eaa","affectsGlobalScope":true,"impliedNodeFormat":1},{"version":"69684132aeb9b5642cbcd9e22dff7818ff0ee1aa831728af0ecf97d3364d5546","affectsGlobalScope":true,"impliedNodeFormat":1},{"version":"092c2bfe125ce69dbb1223c85d68d4d2397d7d8411867b5cc03cec902c233763","affectsGlobalScope":true,"impliedNodeFormat":1},{"version":"80e18897e5884b6723488d4f5652167e7bb5024f946743134ecc4aa4ee731f89","affectsGlobalScope":true,"impliedNodeFormat":1},{"version":"cd034f499c6cdca722b60c04b5b1b78e058487a7085a8e0d6fb50809947ee573","affectsGlobalScope":true,"impliedNodeFormat":1},{"version":"8e7f8264d0fb4c5339605a15daadb037bf238c10b654bb3eee14208f860a32ea","affectsGlobalScope":true,"impliedNodeFormat":1},{"version":"782dec38049b92d4e85c1585fbea5474a219c6984a35b004963b00beb1aab538","affectsGlobalScope":true,"impliedNodeFormat":1},{"version":"52db5d1a3fa84d3692297673f6fede59e50d94b4ed2cab8398c7a65e1a45e6fa","impliedNodeFormat":1},{"version":"1f10bf6cae1b1c0a68442de452ff46ab6f1df2ddac22c41f6eb8c0cf7fc0da6c","signature":"80437b7fed4d90fddb569b1e295ab30c2d4ed9ba834bf693f40d84e72b9b571e","impliedNodeFormat":1}],"fileIdsList":[[8]],"options":{"composite":true},"referencedMap":[[9,1]]}
+
@@ -0,0 +1,36 @@
+
+currentDirectory::/home/src/workspaces/project
+useCaseSensitiveFileNames::true
+Input::--locale en-us-x first.ts
+
+ExitStatus:: 1
+
+ParsedCommandLine::{
+    "parsedConfig": {
+        "compilerOptions": {
+            "locale": "en-us-x"
+        },
+        "watchOptions": {
+            "watchInterval": null,
+            "watchFile": 0,
+            "watchDirectory": 0,
+            "fallbackPolling": 0,
+            "synchronousWatchDirectory": null,
+            "excludeDirectories": null,
+            "excludeFiles": null
+        },
+        "typeAcquisition": null,
+        "fileNames": [
+            "first.ts"
+        ],
+        "projectReferences": null
+    },
+    "configFile": null,
+    "errors": [],
+    "raw": {
+        "locale": "en-us-x"
+    },
+    "compileOnSave": null
+}
+Output::
+[91merror[0m[90m TS6048: [0mLocale must be of the form <language> or <language>-<territory>. For example 'en' or 'ja-jp'.
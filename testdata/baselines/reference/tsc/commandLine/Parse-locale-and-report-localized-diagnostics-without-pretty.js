@@ -0,0 +1,45 @@
+
+currentDirectory::/home/src/workspaces/project
+useCaseSensitiveFileNames::true
+Input::--locale de-DE --pretty false first.ts
+//// [/home/src/workspaces/project/first.ts] new file
+const a: number = b;
+
+ExitStatus:: 2
+
+ParsedCommandLine::{
+    "parsedConfig": {
+        "compilerOptions": {
+            "locale": "de-DE",
+            "pretty": false
+        },
+        "watchOptions": {
+            "watchInterval": null,
+            "watchFile": 0,
+            "watchDirectory": 0,
+            "fallbackPolling": 0,
+            "synchronousWatchDirectory": null,
+            "excludeDirectories": null,
+            "excludeFiles": null
+        },
+        "typeAcquisition": null,
+        "fileNames": [
+            "first.ts"
+        ],
+        "projectReferences": null
+    },
+    "configFile": null,
+    "errors": [],
+    "raw": {
+        "locale": "de-DE",
+        "pretty": false
+    },
+    "compileOnSave": null
+}
+Output::
+first.ts(1,19): error TS2304: Der Name "b" wurde nicht gefunden.
+//// [/home/src/workspaces/project/first.js] new file
+var a = b;
+
+//// [/home/src/workspaces/project/first.ts] no change
+
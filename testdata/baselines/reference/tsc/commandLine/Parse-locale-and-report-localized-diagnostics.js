@@ -0,0 +1,50 @@
+
+currentDirectory::/home/src/workspaces/project
+useCaseSensitiveFileNames::true
+Input::--locale de-DE first.ts
+//// [/home/src/workspaces/project/first.ts] new file
+const a: number = b;
+
+ExitStatus:: 2
+
+ParsedCommandLine::{
+    "parsedConfig": {
+        "compilerOptions": {
+            "locale": "de-DE"
+        },
+        "watchOptions": {
+            "watchInterval": null,
+            "watchFile": 0,
+            "watchDirectory": 0,
+            "fallbackPolling": 0,
+            "synchronousWatchDirectory": null,
+            "excludeDirectories": null,
+            "excludeFiles": null
+        },
+        "typeAcquisition": null,
+        "fileNames": [
+            "first.ts"
+        ],
+        "projectReferences": null
+    },
+    "configFile": null,
+    "errors": [],
+    "raw": {
+        "locale": "de-DE"
+    },
+    "compileOnSave": null
+}
+Output::
+[96mfirst.ts[0m:[93m1[0m:[93m19[0m - [91merror[0m[90m TS2304: [0mDer Name "b" wurde nicht gefunden.
+
+[7m1[0m const a: number = b;
+[7m [0m [91m                  ~[0m
+
+
+1 Fehler in first.ts[90m:1[0m gefunden.
+
+//// [/home/src/workspaces/project/first.js] new file
+var a = b;
+
+//// [/home/src/workspaces/project/first.ts] no change
+
@@ -0,0 +1,37 @@
+
+currentDirectory::/home/src/workspaces/project
+useCaseSensitiveFileNames::true
+Input::--locale fr --version
+
+ExitStatus:: 0
+
+ParsedCommandLine::{
+    "parsedConfig": {
+        "compilerOptions": {
+            "locale": "fr",
+            "version": true
+        },
+        "watchOptions": {
+            "watchInterval": null,
+            "watchFile": 0,
+            "watchDirectory": 0,
+            "fallbackPolling": 0,
+            "synchronousWatchDirectory": null,
+            "excludeDirectories": null,
+            "excludeFiles": null
+        },
+        "typeAcquisition": null,
+        "fileNames": [],
+        "projectReferences": null
+    },
+    "configFile": null,
+    "errors": [],
+    "raw": {
+        "locale": "fr",
+        "version": true
+    },
+    "compileOnSave": null
+}
+Output::
+Version 7.0.0-dev
+
@@ -0,0 +1,42 @@
+
+currentDirectory::/home/src/workspaces/project
+useCaseSensitiveFileNames::true
+Input::--explainFiles
+//// [/home/src/workspaces/project/node_modules/@types/pkg/index.d.ts] new file
+declare const pkg: number;
+//// [/home/src/workspaces/project/src/main.ts] new file
+export const x = 10;
+//// [/home/src/workspaces/project/tsconfig.json] new file
+{
+	"compilerOptions": {
+		"lib": ["es5"],
+	},
+	"include": ["src"],
+}
+
+ExitStatus:: 0
+
+CompilerOptions::{
+    "explainFiles": true
+}
+Output::
+bundled:///libs/lib.es5.d.ts
+  Library 'lib.es5.d.ts' specified in compilerOptions
+bundled:///libs/lib.decorators.d.ts
+  Library referenced via 'decorators' from file 'bundled:///libs/lib.es5.d.ts'
+bundled:///libs/lib.decorators.legacy.d.ts
+  Library referenced via 'decorators.legacy' from file 'bundled:///libs/lib.es5.d.ts'
+src/main.ts
+  Matched by include pattern 'src' in 'tsconfig.json'
+node_modules/@types/pkg/index.d.ts
+  Entry point for implicit type library 'pkg'
+//// [/home/src/workspaces/project/node_modules/@types/pkg/index.d.ts] no change
+//// [/home/src/workspaces/project/src/main.js] new file
+"use strict";
+Object.defineProperty(exports, "__esModule", { value: true });
+exports.x = void 0;
+exports.x = 10;
+
+//// [/home/src/workspaces/project/src/main.ts] no change
+//// [/home/src/workspaces/project/tsconfig.json] no change
+
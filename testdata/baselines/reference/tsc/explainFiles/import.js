@@ -0,0 +1,49 @@
+
+currentDirectory::/home/src/workspaces/project
+useCaseSensitiveFileNames::true
+Input::--explainFiles
+//// [/home/src/workspaces/project/helper.ts] new file
+export const x = 10;
+//// [/home/src/workspaces/project/main.ts] new file
+import { x } from "./helper";
+//// [/home/src/workspaces/project/tsconfig.json] new file
+{
+	"compilerOptions": {
+		"lib": ["es5"],
+	},
+	"files": ["main.ts"],
+}
+//// [/home/src/workspaces/project/unused.ts] new file
+export const y = 10;
+
+ExitStatus:: 0
+
+CompilerOptions::{
+    "explainFiles": true
+}
+Output::
+bundled:///libs/lib.es5.d.ts
+  Library 'lib.es5.d.ts' specified in compilerOptions
+bundled:///libs/lib.decorators.d.ts
+  Library referenced via 'decorators' from file 'bundled:///libs/lib.es5.d.ts'
+bundled:///libs/lib.decorators.legacy.d.ts
+  Library referenced via 'decorators.legacy' from file 'bundled:///libs/lib.es5.d.ts'
+helper.ts
+  Imported via "./helper" from file 'main.ts'
+main.ts
+  Part of 'files' list in tsconfig.json
+//// [/home/src/workspaces/project/helper.js] new file
+"use strict";
+Object.defineProperty(exports, "__esModule", { value: true });
+exports.x = void 0;
+exports.x = 10;
+
+//// [/home/src/workspaces/project/helper.ts] no change
+//// [/home/src/workspaces/project/main.js] new file
+"use strict";
+Object.defineProperty(exports, "__esModule", { value: true });
+
+//// [/home/src/workspaces/project/main.ts] no change
+//// [/home/src/workspaces/project/tsconfig.json] no change
+//// [/home/src/workspaces/project/unused.ts] no change
+
@@ -0,0 +1,41 @@
+
+currentDirectory::/home/src/workspaces/project
+useCaseSensitiveFileNames::true
+Input::--explainFiles
+//// [/home/src/workspaces/project/main.ts] new file
+/// <reference lib="es2015.core" />
+export const x = 10;
+//// [/home/src/workspaces/project/tsconfig.json] new file
+{
+	"compilerOptions": {
+		"lib": ["es5"],
+	},
+	"files": ["main.ts"],
+}
+
+ExitStatus:: 0
+
+CompilerOptions::{
+    "explainFiles": true
+}
+Output::
+bundled:///libs/lib.es5.d.ts
+  Library 'lib.es5.d.ts' specified in compilerOptions
+bundled:///libs/lib.es2015.core.d.ts
+  Library referenced via 'es2015.core' from file 'main.ts'
+bundled:///libs/lib.decorators.d.ts
+  Library referenced via 'decorators' from file 'bundled:///libs/lib.es5.d.ts'
+bundled:///libs/lib.decorators.legacy.d.ts
+  Library referenced via 'decorators.legacy' from file 'bundled:///libs/lib.es5.d.ts'
+main.ts
+  Part of 'files' list in tsconfig.json
+//// [/home/src/workspaces/project/main.js] new file
+"use strict";
+Object.defineProperty(exports, "__esModule", { value: true });
+exports.x = void 0;
+/// <reference lib="es2015.core" />
+exports.x = 10;
+
+//// [/home/src/workspaces/project/main.ts] no change
+//// [/home/src/workspaces/project/tsconfig.json] no change
+
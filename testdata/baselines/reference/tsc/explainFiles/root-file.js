@@ -0,0 +1,32 @@
+
+currentDirectory::/home/src/workspaces/project
+useCaseSensitiveFileNames::true
+Input::--explainFiles --lib es5 main.ts
+//// [/home/src/workspaces/project/main.ts] new file
+export const x = 10;
+
+ExitStatus:: 0
+
+CompilerOptions::{
+    "lib": [
+        "lib.es5.d.ts"
+    ],
+    "explainFiles": true
+}
+Output::
+bundled:///libs/lib.es5.d.ts
+  Library 'lib.es5.d.ts' specified in compilerOptions
+bundled:///libs/lib.decorators.d.ts
+  Library referenced via 'decorators' from file 'bundled:///libs/lib.es5.d.ts'
+bundled:///libs/lib.decorators.legacy.d.ts
+  Library referenced via 'decorators.legacy' from file 'bundled:///libs/lib.es5.d.ts'
+main.ts
+  Root file specified for compilation
+//// [/home/src/workspaces/project/main.js] new file
+"use strict";
+Object.defineProperty(exports, "__esModule", { value: true });
+exports.x = void 0;
+exports.x = 10;
+
+//// [/home/src/workspaces/project/main.ts] no change
+
@@ -0,0 +1,48 @@
+
+currentDirectory::/home/src/workspaces/project
+useCaseSensitiveFileNames::true
+Input::--explainFiles
+//// [/home/src/workspaces/project/main.ts] new file
+/// <reference types="pkg" />
+export const x = 10;
+//// [/home/src/workspaces/project/node_modules/pkg/index.d.ts] new file
+declare const pkg: number;
+//// [/home/src/workspaces/project/node_modules/pkg/package.json] new file
+{ "name": "pkg", "version": "1.0.0", "types": "index.d.ts" }
+//// [/home/src/workspaces/project/tsconfig.json] new file
+{
+	"compilerOptions": {
+		"lib": ["es5"],
+		"types": [],
+	},
+	"files": ["main.ts"],
+}
+
+ExitStatus:: 0
+
+CompilerOptions::{
+    "explainFiles": true
+}
+Output::
+bundled:///libs/lib.es5.d.ts
+  Library 'lib.es5.d.ts' specified in compilerOptions
+bundled:///libs/lib.decorators.d.ts
+  Library referenced via 'decorators' from file 'bundled:///libs/lib.es5.d.ts'
+bundled:///libs/lib.decorators.legacy.d.ts
+  Library referenced via 'decorators.legacy' from file 'bundled:///libs/lib.es5.d.ts'
+node_modules/pkg/index.d.ts
+  Type library referenced via 'pkg' from file 'main.ts' with packageId 'pkg@1.0.0'
+main.ts
+  Part of 'files' list in tsconfig.json
+//// [/home/src/workspaces/project/main.js] new file
+"use strict";
+Object.defineProperty(exports, "__esModule", { value: true });
+exports.x = void 0;
+/// <reference types="pkg" />
+exports.x = 10;
+
+//// [/home/src/workspaces/project/main.ts] no change
+//// [/home/src/workspaces/project/node_modules/pkg/index.d.ts] no change
+//// [/home/src/workspaces/project/node_modules/pkg/package.json] no change
+//// [/home/src/workspaces/project/tsconfig.json] no change
+
@@ -62,7 +62,39 @@ CompilerOptions::{
     "showConfig": true
 }
 Output::
-No output
+{
+    "compilerOptions": {
+        "outDir": "./outDir",
+        "baseUrl": "./",
+        "paths": {
+            "@myscope/*": [
+                "/home/src/projects/myproject/types/*"
+            ],
+            "other/*": [
+                "other/*"
+            ]
+        },
+        "typeRoots": [
+            "../configs/first/root1",
+            "./root2",
+            "../configs/first/root3"
+        ],
+        "types": [],
+        "declarationDir": "./decls",
+        "traceResolution": true,
+        "declaration": true
+    },
+    "files": [
+        "./main.ts"
+    ],
+    "include": [
+        "/home/src/projects/myproject/src"
+    ],
+    "exclude": [
+        "/home/src/projects/myproject/outDir",
+        "/home/src/projects/myproject/decls"
+    ]
+}
 //// [/home/src/projects/configs/first/tsconfig.json] no change
 //// [/home/src/projects/configs/second/tsconfig.json] no change
 //// [/home/src/projects/myproject/main.ts] no change
@@ -62,11 +62,75 @@ CompilerOptions::{
     "explainFiles": true
 }
 Output::
+======== Resolving module '@myscope/sometype' from '/home/src/projects/myproject/main.ts'. ========
+Module resolution kind is not specified, using 'Bundler'.
+Resolving in CJS mode with conditions 'require', 'types'.
+'paths' option is specified, looking for a pattern to match module name '@myscope/sometype'.
+Module name '@myscope/sometype', matched pattern '@myscope/*'.
+Trying substitution '/home/src/projects/myproject/types/*', candidate module location: '/home/src/projects/myproject/types/sometype'.
+Loading module as file / folder, candidate module location '/home/src/projects/myproject/types/sometype', target file types: TypeScript, JavaScript, Declaration, JSON.
+File '/home/src/projects/myproject/types/sometype.ts' exists - use it as a name resolution result.
+======== Module name '@myscope/sometype' was successfully resolved to '/home/src/projects/myproject/types/sometype.ts'. ========
+======== Resolving module 'other/sometype2' from '/home/src/projects/myproject/src/secondary.ts'. ========
+Module resolution kind is not specified, using 'Bundler'.
+Resolving in CJS mode with conditions 'require', 'types'.
+'paths' option is specified, looking for a pattern to match module name 'other/sometype2'.
+Module name 'other/sometype2', matched pattern 'other/*'.
+Trying substitution 'other/*', candidate module location: 'other/sometype2'.
+Loading module as file / folder, candidate module location '/home/src/projects/configs/second/other/sometype2', target file types: TypeScript, JavaScript, Declaration, JSON.
+File '/home/src/projects/myproject/src/package.json' does not exist.
+File '/home/src/projects/myproject/package.json' does not exist.
+File '/home/src/projects/package.json' does not exist.
+File '/home/src/package.json' does not exist.
+File '/home/package.json' does not exist.
+File '/package.json' does not exist.
+Loading module 'other/sometype2' from 'node_modules' folder, target file types: TypeScript, JavaScript, Declaration, JSON.
+Searching all ancestor node_modules directories for preferred extensions: TypeScript, Declaration.
+Directory '/home/src/projects/myproject/src/node_modules' does not exist, skipping all lookups in it.
+Directory '/home/src/projects/myproject/src/node_modules/@types' does not exist, skipping all lookups in it.
+Directory '/home/src/projects/myproject/node_modules' does not exist, skipping all lookups in it.
+Directory '/home/src/projects/myproject/node_modules/@types' does not exist, skipping all lookups in it.
+Directory '/home/src/projects/node_modules' does not exist, skipping all lookups in it.
+Directory '/home/src/projects/node_modules/@types' does not exist, skipping all lookups in it.
+Directory '/home/src/node_modules' does not exist, skipping all lookups in it.
+Directory '/home/src/node_modules/@types' does not exist, skipping all lookups in it.
+Directory '/home/node_modules' does not exist, skipping all lookups in it.
+Directory '/home/node_modules/@types' does not exist, skipping all lookups in it.
+Directory '/node_modules' does not exist, skipping all lookups in it.
+Directory '/node_modules/@types' does not exist, skipping all lookups in it.
+Searching all ancestor node_modules directories for fallback extensions: JavaScript, JSON.
+Directory '/home/src/projects/myproject/src/node_modules' does not exist, skipping all lookups in it.
+Directory '/home/src/projects/myproject/node_modules' does not exist, skipping all lookups in it.
+Directory '/home/src/projects/node_modules' does not exist, skipping all lookups in it.
+Directory '/home/src/node_modules' does not exist, skipping all lookups in it.
+Directory '/home/node_modules' does not exist, skipping all lookups in it.
+Directory '/node_modules' does not exist, skipping all lookups in it.
+======== Module name 'other/sometype2' was not resolved. ========
 [96msrc/secondary.ts[0m:[93m4[0m:[93m20[0m - [91merror[0m[90m TS2307: [0mCannot find module 'other/sometype2' or its corresponding type declarations.
 
 [7m4[0m  import { k } from "other/sometype2";
 [7m [0m [91m                   ~~~~~~~~~~~~~~~~~[0m
 
+bundled:///libs/lib.d.ts
+  Default library for target 'es5'
+bundled:///libs/lib.es5.d.ts
+  Library referenced via 'es5' from file 'bundled:///libs/lib.d.ts'
+bundled:///libs/lib.dom.d.ts
+  Library referenced via 'dom' from file 'bundled:///libs/lib.d.ts'
+bundled:///libs/lib.webworker.importscripts.d.ts
+  Library referenced via 'webworker.importscripts' from file 'bundled:///libs/lib.d.ts'
+bundled:///libs/lib.scripthost.d.ts
+  Library referenced via 'scripthost' from file 'bundled:///libs/lib.d.ts'
+bundled:///libs/lib.decorators.d.ts
+  Library referenced via 'decorators' from file 'bundled:///libs/lib.es5.d.ts'
+bundled:///libs/lib.decorators.legacy.d.ts
+  Library referenced via 'decorators.legacy' from file 'bundled:///libs/lib.es5.d.ts'
+types/sometype.ts
+  Imported via "@myscope/sometype" from file 'main.ts'
+main.ts
+  Part of 'files' list in tsconfig.json
+src/secondary.ts
+  Matched by include pattern '${configDir}/src' in 'tsconfig.json'
 
 Found 1 error in src/secondary.ts[90m:4[0m
 
@@ -0,0 +1,81 @@
+
+currentDirectory::/home/src/workspaces/project
+useCaseSensitiveFileNames::true
+Input::
+//// [/home/src/workspaces/project/a.ts] new file
+export function a() { return 1; }
+//// [/home/src/workspaces/project/b.ts] new file
+import { a } from "./a";
+export const b = a();
+//// [/home/src/workspaces/project/c.ts] new file
+export const c: number = "hello";
+//// [/home/src/workspaces/project/tsconfig.json] new file
+{
+	"compilerOptions": {
+		"incremental": true
+	},
+}
+
+ExitStatus:: 2
+
+CompilerOptions::{}
+Output::
+[96mc.ts[0m:[93m1[0m:[93m14[0m - [91merror[0m[90m TS2322: [0mType 'string' is not assignable to type 'number'.
+
+[7m1[0m export const c: number = "hello";
+[7m [0m [91m             ~[0m
+
+
+Found 1 error in c.ts[90m:1[0m
+
+//// [/home/src/workspaces/project/a.js] new file
+"use strict";
+Object.defineProperty(exports, "__esModule", { value: true });
+exports.a = a;
+function a() { return 1; }
+
+//// [/home/src/workspaces/project/a.ts] no change
+//// [/home/src/workspaces/project/b.js] new file
+"use strict";
+Object.defineProperty(exports, "__esModule", { value: true });
+exports.b = void 0;
+const a_1 = require("./a");
+exports.b = (0, a_1.a)();
+
+//// [/home/src/workspaces/project/b.ts] no change
+//// [/home/src/workspaces/project/c.js] new file
+"use strict";
+Object.defineProperty(exports, "__esModule", { value: true });
+exports.c = void 0;
+exports.c = "hello";
+
+//// [/home/src/workspaces/project/c.ts] no change
+//// [/home/src/workspaces/project/tsconfig.json] no change
+//// [/home/src/workspaces/project/tsconfig.tsbuildinfo] new file
+{"version":"7.0.0-dev","errors":true,"fileNames":["bundled:///libs/lib.d.ts","bundled:///libs/lib.es5.d.ts","bundled:///libs/lib.dom.d.ts","bundled:///libs/lib.webworker.importscripts.d.ts","bundled:///libs/lib.scripthost.d.ts","bundled:///libs/lib.decorators.d.ts","bundled:///libs/lib.decorators.legacy.d.ts","a.ts","b.ts","c.ts"],"fileInfos":[{"version":"a7297ff837fcdf174a9524925966429eb8e5feecc2cc55cc06574e6b092c1eaa","affectsGlobalScope":true,"impliedNodeFormat":1},{"version":"69684132aeb9b5642cbcd9e22dff7818ff0ee1aa831728af0ecf97d3364d5546","affectsGlobalScope":true,"impliedNodeFormat":1},{"version":"092c2bfe125ce69dbb1223c85d68d4d2397d7d8411867b5cc03cec902c233763","affectsGlobalScope":true,"impliedNodeFormat":1},{"version":"80e18897e5884b6723488d4f5652167e7bb5024f946743134ecc4aa4ee731f89","affectsGlobalScope":true,"impliedNodeFormat":1},{"version":"cd034f499c6cdca722b60c04b5b1b78e058487a7085a8e0d6fb50809947ee573","affectsGlobalScope":true,"impliedNodeFormat":1},{"version":"8e7f8264d0fb4c5339605a15daadb037bf238c10b654bb3eee14208f860a32ea","affectsGlobalScope":true,"impliedNodeFormat":1},{"version":"782dec38049b92d4e85c1585fbea5474a219c6984a35b004963b00beb1aab538","affectsGlobalScope":true,"impliedNodeFormat":1},{"version":"8fa5d4943227bb2d88d6e74035c73304eb123eca04960d8c958aa9ebac5e2ab6","impliedNodeFormat":1},{"version":"6959096bc8e3f5c5c3b3dd6755c2bdde2715bc4d667159084263451bd13363b6","impliedNodeFormat":1},{"version":"141d312560b8514966a8a4ceff83a4aca7c20245e0aaf970a8a52632abdcfcff","impliedNodeFormat":1}],"fileIdsList":[[8]],"options":{},"referencedMap":[[9,1]],"semanticDiagnosticsPerFile":[[10,[{"pos":13,"end":14,"code":2322,"category":1,"message":"Type 'string' is not assignable to type 'number'."}]]]}
+
+
+
+Edit:: file ids out of range
+Input::
+ExitStatus:: 2
+
+CompilerOptions::{}
+Output::
+[96mc.ts[0m:[93m1[0m:[93m14[0m - [91merror[0m[90m TS2322: [0mType 'string' is not assignable to type 'number'.
+
+[7m1[0m export const c: number = "hello";
+[7m [0m [91m             ~[0m
+
+
+Found 1 error in c.ts[90m:1[0m
+
+//// [/home/src/workspaces/project/a.js] no change
+//// [/home/src/workspaces/project/a.ts] no change
+//// [/home/src/workspaces/project/b.js] no change
+//// [/home/src/workspaces/project/b.ts] no change
+//// [/home/src/workspaces/project/c.js] no change
+//// [/home/src/workspaces/project/c.ts] no change
+//// [/home/src/workspaces/project/tsconfig.json] no change
+//// [/home/src/workspaces/project/tsconfig.tsbuildinfo] no change
+
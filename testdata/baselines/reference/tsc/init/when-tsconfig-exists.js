@@ -0,0 +1,15 @@
+
+currentDirectory::/home/src/workspaces/project
+useCaseSensitiveFileNames::true
+Input::--init
+//// [/home/src/workspaces/project/tsconfig.json] new file
+{}
+
+ExitStatus:: 0
+
+CompilerOptions::{
+    "init": true
+}
+Output::
+[91merror[0m[90m TS5054: [0mA 'tsconfig.json' file is already defined at: '/home/src/workspaces/project/tsconfig.json'.//// [/home/src/workspaces/project/tsconfig.json] no change
+
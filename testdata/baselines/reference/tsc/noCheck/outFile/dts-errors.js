@@ -43,10 +43,12 @@ export declare const a: {
 "use strict";
 Object.defineProperty(exports, "__esModule", { value: true });
 exports.a = void 0;
-const a = class {
-    p = 10;
-};
-exports.a = a;
+exports.a = /** @class */ (function () {
+    function class_1() {
+        this.p = 10;
+    }
+    return class_1;
+}());
 
 //// [/home/src/workspaces/project/a.ts] no change
 //// [/home/src/workspaces/project/b.d.ts] new file
@@ -63,7 +63,7 @@ app/src/index.ts(3,13): error TS2613: Module '"/home/src/workspaces/project/node
 export {};
 
 //// [/home/src/workspaces/project/app/dist/local.js] new file
-export const local = 0;
+export var local = 0;
 
 //// [/home/src/workspaces/project/app/src/index.ts] no change
 //// [/home/src/workspaces/project/app/src/local.ts] no change
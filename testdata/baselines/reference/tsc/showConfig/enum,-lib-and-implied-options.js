@@ -0,0 +1,79 @@
+
+currentDirectory::/home/src/workspaces/project
+useCaseSensitiveFileNames::true
+Input::--showConfig
+//// [/home/src/workspaces/project/src/index.ts] new file
+export const a = 1;
+//// [/home/src/workspaces/project/tsconfig.json] new file
+{
+	"compilerOptions": {
+		"module": "nodenext",
+		"strict": true,
+		"lib": ["es2017", "dom"],
+		"jsx": "react-jsx",
+		"newLine": "lf",
+		"composite": true,
+		"outDir": "dist",
+		"rootDirs": ["src", "generated"],
+	},
+	"references": [{ "path": "../shared" }],
+}
+//// [/home/src/workspaces/shared/tsconfig.json] new file
+{ "compilerOptions": { "composite": true } }
+
+ExitStatus:: 0
+
+CompilerOptions::{
+    "showConfig": true
+}
+Output::
+{
+    "compilerOptions": {
+        "module": "nodenext",
+        "lib": [
+            "es2017",
+            "dom"
+        ],
+        "jsx": "react-jsx",
+        "outDir": "./dist",
+        "composite": true,
+        "strict": true,
+        "rootDirs": [
+            "./src",
+            "./generated"
+        ],
+        "newLine": "lf",
+        "target": "esnext",
+        "moduleResolution": "nodenext",
+        "moduleDetection": "force",
+        "esModuleInterop": true,
+        "resolveJsonModule": false,
+        "declaration": true,
+        "incremental": true,
+        "useDefineForClassFields": true,
+        "noImplicitAny": true,
+        "noImplicitThis": true,
+        "strictNullChecks": true,
+        "strictFunctionTypes": true,
+        "strictBindCallApply": true,
+        "strictPropertyInitialization": true,
+        "strictBuiltinIteratorReturn": true,
+        "alwaysStrict": true,
+        "useUnknownInCatchVariables": true
+    },
+    "references": [
+        {
+            "path": "../shared"
+        }
+    ],
+    "files": [
+        "./src/index.ts"
+    ],
+    "exclude": [
+        "/home/src/workspaces/project/dist"
+    ]
+}
+//// [/home/src/workspaces/project/src/index.ts] no change
+//// [/home/src/workspaces/project/tsconfig.json] no change
+//// [/home/src/workspaces/shared/tsconfig.json] no change
+
@@ -0,0 +1,49 @@
+
+currentDirectory::/home/src/workspaces/project
+useCaseSensitiveFileNames::true
+Input::--showConfig --declaration
+//// [/home/src/workspaces/project/scripts/build.ts] new file
+export const d = 1;
+//// [/home/src/workspaces/project/src/index.ts] new file
+export const a = 1;
+//// [/home/src/workspaces/project/src/util.test.ts] new file
+export const c = 1;
+//// [/home/src/workspaces/project/src/util.ts] new file
+export const b = 1;
+//// [/home/src/workspaces/project/tsconfig.json] new file
+{
+	"compilerOptions": { "target": "es2020" },
+	"include": ["src"],
+	"exclude": ["src/**/*.test.ts"],
+}
+
+ExitStatus:: 0
+
+CompilerOptions::{
+    "declaration": true,
+    "showConfig": true
+}
+Output::
+{
+    "compilerOptions": {
+        "target": "es2020",
+        "declaration": true,
+        "module": "es6"
+    },
+    "files": [
+        "./src/index.ts",
+        "./src/util.ts"
+    ],
+    "include": [
+        "src"
+    ],
+    "exclude": [
+        "src/**/*.test.ts"
+    ]
+}
+//// [/home/src/workspaces/project/scripts/build.ts] no change
+//// [/home/src/workspaces/project/src/index.ts] no change
+//// [/home/src/workspaces/project/src/util.test.ts] no change
+//// [/home/src/workspaces/project/src/util.ts] no change
+//// [/home/src/workspaces/project/tsconfig.json] no change
+
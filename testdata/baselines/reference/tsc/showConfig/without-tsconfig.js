@@ -0,0 +1,30 @@
+
+currentDirectory::/home/src/workspaces/project
+useCaseSensitiveFileNames::true
+Input::--showConfig --target es2015 --moduleResolution bundler --watchInterval 1000 first.ts
+//// [/home/src/workspaces/project/first.ts] new file
+export const a = 1;
+
+ExitStatus:: 0
+
+CompilerOptions::{
+    "moduleResolution": 100,
+    "target": 2,
+    "showConfig": true
+}
+Output::
+{
+    "compilerOptions": {
+        "target": "es6",
+        "moduleResolution": "bundler",
+        "module": "es6"
+    },
+    "watchOptions": {
+        "watchInterval": 1000
+    },
+    "files": [
+        "./first.ts"
+    ]
+}
+//// [/home/src/workspaces/project/first.ts] no change
+
@@ -0,0 +1,137 @@
+
+currentDirectory::/home/src/workspaces/project
+useCaseSensitiveFileNames::true
+Input::--traceResolution --libReplacement --lib es5 main.ts
+//// [/home/src/workspaces/project/helper.ts] new file
+/// <reference lib="es5" />
+export const x = 10;
+//// [/home/src/workspaces/project/main.ts] new file
+/// <reference lib="es5" />
+import { x } from "./helper";
+
+ExitStatus:: 0
+
+CompilerOptions::{
+    "lib": [
+        "lib.es5.d.ts"
+    ],
+    "libReplacement": true,
+    "traceResolution": true
+}
+Output::
+======== Resolving module '@typescript/lib-es5' from '/home/src/workspaces/project/__lib_node_modules_lookup_lib.es5.d.ts__.ts'. ========
+Module resolution kind is not specified, using 'Bundler'.
+Resolving in CJS mode with conditions 'require', 'types'.
+File '/home/src/workspaces/project/package.json' does not exist.
+File '/home/src/workspaces/package.json' does not exist.
+File '/home/src/package.json' does not exist.
+File '/home/package.json' does not exist.
+File '/package.json' does not exist.
+Loading module '@typescript/lib-es5' from 'node_modules' folder, target file types: TypeScript, JavaScript, Declaration, JSON.
+Searching all ancestor node_modules directories for preferred extensions: TypeScript, Declaration.
+Directory '/home/src/workspaces/project/node_modules' does not exist, skipping all lookups in it.
+Directory '/home/src/workspaces/project/node_modules/@types' does not exist, skipping all lookups in it.
+Scoped package detected, looking in 'typescript__lib-es5'
+Directory '/home/src/workspaces/node_modules' does not exist, skipping all lookups in it.
+Directory '/home/src/workspaces/node_modules/@types' does not exist, skipping all lookups in it.
+Scoped package detected, looking in 'typescript__lib-es5'
+Directory '/home/src/node_modules' does not exist, skipping all lookups in it.
+Directory '/home/src/node_modules/@types' does not exist, skipping all lookups in it.
+Scoped package detected, looking in 'typescript__lib-es5'
+Directory '/home/node_modules' does not exist, skipping all lookups in it.
+Directory '/home/node_modules/@types' does not exist, skipping all lookups in it.
+Scoped package detected, looking in 'typescript__lib-es5'
+Directory '/node_modules' does not exist, skipping all lookups in it.
+Directory '/node_modules/@types' does not exist, skipping all lookups in it.
+Scoped package detected, looking in 'typescript__lib-es5'
+Searching all ancestor node_modules directories for fallback extensions: JavaScript, JSON.
+Directory '/home/src/workspaces/project/node_modules' does not exist, skipping all lookups in it.
+Directory '/home/src/workspaces/node_modules' does not exist, skipping all lookups in it.
+Directory '/home/src/node_modules' does not exist, skipping all lookups in it.
+Directory '/home/node_modules' does not exist, skipping all lookups in it.
+Directory '/node_modules' does not exist, skipping all lookups in it.
+======== Module name '@typescript/lib-es5' was not resolved. ========
+======== Resolving module './helper' from '/home/src/workspaces/project/main.ts'. ========
+Module resolution kind is not specified, using 'Bundler'.
+Resolving in CJS mode with conditions 'require', 'types'.
+Loading module as file / folder, candidate module location '/home/src/workspaces/project/helper', target file types: TypeScript, JavaScript, Declaration, JSON.
+File '/home/src/workspaces/project/helper.ts' exists - use it as a name resolution result.
+======== Module name './helper' was successfully resolved to '/home/src/workspaces/project/helper.ts'. ========
+======== Resolving module '@typescript/lib-decorators' from '/home/src/workspaces/project/__lib_node_modules_lookup_lib.decorators.d.ts__.ts'. ========
+Module resolution kind is not specified, using 'Bundler'.
+Resolving in CJS mode with conditions 'require', 'types'.
+File '/home/src/workspaces/project/package.json' does not exist according to earlier cached lookups.
+File '/home/src/workspaces/package.json' does not exist according to earlier cached lookups.
+File '/home/src/package.json' does not exist according to earlier cached lookups.
+File '/home/package.json' does not exist according to earlier cached lookups.
+File '/package.json' does not exist according to earlier cached lookups.
+Loading module '@typescript/lib-decorators' from 'node_modules' folder, target file types: TypeScript, JavaScript, Declaration, JSON.
+Searching all ancestor node_modules directories for preferred extensions: TypeScript, Declaration.
+Directory '/home/src/workspaces/project/node_modules' does not exist, skipping all lookups in it.
+Directory '/home/src/workspaces/project/node_modules/@types' does not exist, skipping all lookups in it.
+Scoped package detected, looking in 'typescript__lib-decorators'
+Directory '/home/src/workspaces/node_modules' does not exist, skipping all lookups in it.
+Directory '/home/src/workspaces/node_modules/@types' does not exist, skipping all lookups in it.
+Scoped package detected, looking in 'typescript__lib-decorators'
+Directory '/home/src/node_modules' does not exist, skipping all lookups in it.
+Directory '/home/src/node_modules/@types' does not exist, skipping all lookups in it.
+Scoped package detected, looking in 'typescript__lib-decorators'
+Directory '/home/node_modules' does not exist, skipping all lookups in it.
+Directory '/home/node_modules/@types' does not exist, skipping all lookups in it.
+Scoped package detected, looking in 'typescript__lib-decorators'
+Directory '/node_modules' does not exist, skipping all lookups in it.
+Directory '/node_modules/@types' does not exist, skipping all lookups in it.
+Scoped package detected, looking in 'typescript__lib-decorators'
+Searching all ancestor node_modules directories for fallback extensions: JavaScript, JSON.
+Directory '/home/src/workspaces/project/node_modules' does not exist, skipping all lookups in it.
+Directory '/home/src/workspaces/node_modules' does not exist, skipping all lookups in it.
+Directory '/home/src/node_modules' does not exist, skipping all lookups in it.
+Directory '/home/node_modules' does not exist, skipping all lookups in it.
+Directory '/node_modules' does not exist, skipping all lookups in it.
+======== Module name '@typescript/lib-decorators' was not resolved. ========
+======== Resolving module '@typescript/lib-decorators/legacy' from '/home/src/workspaces/project/__lib_node_modules_lookup_lib.decorators.legacy.d.ts__.ts'. ========
+Module resolution kind is not specified, using 'Bundler'.
+Resolving in CJS mode with conditions 'require', 'types'.
+File '/home/src/workspaces/project/package.json' does not exist according to earlier cached lookups.
+File '/home/src/workspaces/package.json' does not exist according to earlier cached lookups.
+File '/home/src/package.json' does not exist according to earlier cached lookups.
+File '/home/package.json' does not exist according to earlier cached lookups.
+File '/package.json' does not exist according to earlier cached lookups.
+Loading module '@typescript/lib-decorators/legacy' from 'node_modules' folder, target file types: TypeScript, JavaScript, Declaration, JSON.
+Searching all ancestor node_modules directories for preferred extensions: TypeScript, Declaration.
+Directory '/home/src/workspaces/project/node_modules' does not exist, skipping all lookups in it.
+Directory '/home/src/workspaces/project/node_modules/@types' does not exist, skipping all lookups in it.
+Scoped package detected, looking in 'typescript__lib-decorators/legacy'
+Directory '/home/src/workspaces/node_modules' does not exist, skipping all lookups in it.
+Directory '/home/src/workspaces/node_modules/@types' does not exist, skipping all lookups in it.
+Scoped package detected, looking in 'typescript__lib-decorators/legacy'
+Directory '/home/src/node_modules' does not exist, skipping all lookups in it.
+Directory '/home/src/node_modules/@types' does not exist, skipping all lookups in it.
+Scoped package detected, looking in 'typescript__lib-decorators/legacy'
+Directory '/home/node_modules' does not exist, skipping all lookups in it.
+Directory '/home/node_modules/@types' does not exist, skipping all lookups in it.
+Scoped package detected, looking in 'typescript__lib-decorators/legacy'
+Directory '/node_modules' does not exist, skipping all lookups in it.
+Directory '/node_modules/@types' does not exist, skipping all lookups in it.
+Scoped package detected, looking in 'typescript__lib-decorators/legacy'
+Searching all ancestor node_modules directories for fallback extensions: JavaScript, JSON.
+Directory '/home/src/workspaces/project/node_modules' does not exist, skipping all lookups in it.
+Directory '/home/src/workspaces/node_modules' does not exist, skipping all lookups in it.
+Directory '/home/src/node_modules' does not exist, skipping all lookups in it.
+Directory '/home/node_modules' does not exist, skipping all lookups in it.
+Directory '/node_modules' does not exist, skipping all lookups in it.
+======== Module name '@typescript/lib-decorators/legacy' was not resolved. ========
+//// [/home/src/workspaces/project/helper.js] new file
+"use strict";
+Object.defineProperty(exports, "__esModule", { value: true });
+exports.x = void 0;
+/// <reference lib="es5" />
+exports.x = 10;
+
+//// [/home/src/workspaces/project/helper.ts] no change
+//// [/home/src/workspaces/project/main.js] new file
+"use strict";
+Object.defineProperty(exports, "__esModule", { value: true });
+
+//// [/home/src/workspaces/project/main.ts] no change
+
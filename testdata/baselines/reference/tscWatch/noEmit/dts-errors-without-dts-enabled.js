@@ -38,7 +38,7 @@ Edit:: emit after fixing error
 
 Output::
 //// [/home/src/workspaces/project/a.js] new file
-const a = "hello";
+var a = "hello";
 
 //// [/home/src/workspaces/project/a.ts] no change
 //// [/home/src/workspaces/project/tsconfig.json] modified. new content:
@@ -79,9 +79,12 @@ Edit:: emit when error
 
 Output::
 //// [/home/src/workspaces/project/a.js] modified. new content:
-const a = class {
-    p = 10;
-};
+var a = /** @class */ (function () {
+    function class_1() {
+        this.p = 10;
+    }
+    return class_1;
+}());
 
 //// [/home/src/workspaces/project/a.ts] no change
 //// [/home/src/workspaces/project/tsconfig.json] modified. new content:
@@ -54,7 +54,7 @@ Output::
 declare const a = "hello";
 
 //// [/home/src/workspaces/project/a.js] new file
-const a = "hello";
+var a = "hello";
 
 //// [/home/src/workspaces/project/a.ts] no change
 //// [/home/src/workspaces/project/tsconfig.json] modified. new content:
@@ -130,9 +130,12 @@ declare const a: {
 };
 
 //// [/home/src/workspaces/project/a.js] modified. new content:
-const a = class {
-    p = 10;
-};
+var a = /** @class */ (function () {
+    function class_1() {
+        this.p = 10;
+    }
+    return class_1;
+}());
 
 //// [/home/src/workspaces/project/a.ts] no change
 //// [/home/src/workspaces/project/tsconfig.json] modified. new content:
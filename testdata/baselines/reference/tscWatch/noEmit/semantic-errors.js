@@ -46,7 +46,7 @@ Edit:: emit after fixing error
 
 Output::
 //// [/home/src/workspaces/project/a.js] new file
-const a = "hello";
+var a = "hello";
 
 //// [/home/src/workspaces/project/a.ts] no change
 //// [/home/src/workspaces/project/tsconfig.json] modified. new content:
@@ -0,0 +1,45 @@
+// @target: es2016
+// @lib: es2015
+
+declare const z: number;
+
+async function f1() {
+    await 1;
+}
+
+async function f2(x: number, y: number) {
+    return await Promise.resolve(x + y);
+}
+
+async function f3(x = z, ...rest: number[]) {
+    return () => arguments;
+}
+
+async function f4(x: number) {
+    var x = 1;
+    for (var x of [2]) { }
+    try { } catch (x) { var x = 3; }
+}
+
+function f5() {
+    return async (x = z) => arguments;
+}
+
+const a1 = async () => this;
+const a2 = async (x: number) => { await x; };
+const a3 = async (x: number, y = z) => { };
+
+const o = {
+    async m() {
+        return this;
+    }
+};
+
+class C {
+    async m(x: number) {
+        await x;
+    }
+    static async s() {
+        return this;
+    }
+}
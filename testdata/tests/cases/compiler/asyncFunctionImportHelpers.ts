@@ -0,0 +1,10 @@
+// @target: es2016
+// @module: commonjs
+// @importHelpers: true
+// @filename: main.ts
+export async function f() {
+    await 1;
+}
+
+// @filename: node_modules/tslib/index.d.ts
+export declare function __awaiter(thisArg: any, _arguments: any, P: Function, generator: Function): any;
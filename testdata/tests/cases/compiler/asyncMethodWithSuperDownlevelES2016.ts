@@ -0,0 +1,27 @@
+// @target: es2016
+
+class A {
+    x() { }
+    y() { }
+}
+
+class B extends A {
+    async simple() {
+        super.x();
+        super["y"]();
+        const a = super.x;
+    }
+
+    async advanced() {
+        const f = () => { };
+        super.x = f;
+        super["x"] = f;
+        ({ f: super.x } = { f });
+        (async () => super.x());
+    }
+
+    nonAsync() {
+        const f = async () => super.y();
+        return super.x;
+    }
+}
@@ -0,0 +1,55 @@
+// @target: es2020
+
+declare function key(): "k";
+
+class Base {
+    constructor(public a: number) { }
+}
+
+class Fields extends Base {
+    x = 1;
+    y: string;
+    [key()] = 2;
+    ["literal"] = 3;
+    static s = 4;
+    static t = this.s + 1;
+    static u = super.toString();
+
+    constructor(a: number, private b: number) {
+        console.log("before");
+        super(a);
+        console.log("after");
+    }
+
+    method() {
+        return this.x;
+    }
+}
+
+class NoConstructor extends Base {
+    x = this.a;
+}
+
+class TrySuper extends Base {
+    x = 1;
+    constructor() {
+        try {
+            super(1);
+        }
+        finally {
+        }
+    }
+}
+
+const Expr = class {
+    static s = 1;
+    x = 2;
+};
+
+const Named = class N {
+    static self = N;
+};
+
+export default class {
+    static s = 1;
+}
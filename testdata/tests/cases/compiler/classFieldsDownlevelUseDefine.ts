@@ -0,0 +1,20 @@
+// @target: es2020
+// @useDefineForClassFields: true
+
+declare function key(): "k";
+
+class C {
+    x = 1;
+    y: string;
+    [key()] = 2;
+    #p = 3;
+    static s = 4;
+    static #q = 5;
+
+    constructor(public z: number) {
+    }
+}
+
+class D extends C {
+    w = 6;
+}
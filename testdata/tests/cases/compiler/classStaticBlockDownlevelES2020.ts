@@ -0,0 +1,34 @@
+// @target: es2020
+
+class C {
+    static x = 1;
+    static {
+        var y = this.x;
+        C.x = y + 1;
+    }
+    static z = 2;
+    static {
+        const f = () => this.z;
+        function g(this: any) { return this; }
+    }
+}
+
+class D extends C {
+    static {
+        console.log(super.x, super["z"]);
+        super.x = 3;
+    }
+}
+
+const E = class {
+    static {
+        console.log(this);
+    }
+};
+
+const F = class {
+    static #p = 1;
+    static {
+        console.log(F.#p);
+    }
+};
@@ -0,0 +1,65 @@
+// @target: es5
+// @lib: es5
+
+class Base {
+    static count = 0;
+    protected name: string;
+    constructor(name: string) {
+        this.name = name;
+        Base.count++;
+    }
+    greet(greeting: string): string {
+        return greeting + ", " + this.name;
+    }
+    get upperName(): string {
+        return this.name.toUpperCase();
+    }
+    set upperName(value: string) {
+        this.name = value.toLowerCase();
+    }
+    static create(name: string): Base {
+        return new Base(name);
+    }
+}
+
+class Derived extends Base {
+    static defaultName = "derived";
+    private suffix: string;
+    constructor(name: string, suffix: string) {
+        super(name);
+        this.suffix = suffix;
+    }
+    greet(greeting: string): string {
+        return super.greet(greeting) + this.suffix;
+    }
+    get upperName(): string {
+        return super.greet("upper").toUpperCase();
+    }
+    set upperName(value: string) {
+        this.suffix = value;
+    }
+    static create(name: string): Derived {
+        const base = super.create(name);
+        return new Derived(base.upperName, Derived.defaultName);
+    }
+    arrow() {
+        return () => super.greet("hi");
+    }
+}
+
+class NoConstructor extends Derived {
+    static get instanceCount(): number {
+        return Base.count;
+    }
+}
+
+const d = new NoConstructor("a", "b");
+d.upperName = "C";
+d.greet("hello");
+NoConstructor.instanceCount;
+
+const Expr = class extends Base {
+    method() {
+        return super.greet("expr");
+    }
+};
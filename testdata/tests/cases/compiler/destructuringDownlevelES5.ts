@@ -0,0 +1,30 @@
+// @target: es5
+// @lib: es5
+
+declare function use(...args: any[]): void;
+
+interface Options {
+    a?: number;
+    b?: { c?: string; d?: [number, number?] };
+}
+
+declare const options: Options;
+
+const { a = 1, b: { c = "c", d: [first, second = 2] = [0] } = {} } = options;
+use(a, c, first, second);
+
+function f({ a = 1, b: { c = "default" } = {} }: Options = {}, [x, [y = 3] = []]: [number, [number?]?] = [0]) {
+    use(a, c, x, y);
+}
+
+let p: number, q: string;
+({ a: p = 5, b: { c: q = "q" } = {} } = options);
+[p = 1, [q = "nested"] = []] = [undefined, []] as [number?, [string?]?];
+
+for (const { a: loopA = 0, b: { c: loopC } = {} } of [options]) {
+    use(loopA, loopC);
+}
+
+const [, , third = "third"] = ["one", "two"];
+const { ["computed" + "Key"]: computedValue = 42 } = {} as any;
+use(third, computedValue);
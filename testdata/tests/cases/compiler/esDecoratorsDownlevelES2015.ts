@@ -0,0 +1,22 @@
+// @target: es2015
+
+declare function dec(...args: any[]): any;
+
+@dec
+export class A {
+    @dec method() {}
+    @dec field = 1;
+    @dec static staticField = 2;
+    @dec accessor auto = 3;
+}
+
+class B {
+    @dec #method() {}
+    @dec static #field = 1;
+}
+
+declare const key: string;
+
+class C {
+    @dec accessor [key] = 1;
+}
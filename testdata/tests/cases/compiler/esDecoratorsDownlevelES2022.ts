@@ -0,0 +1,49 @@
+// @target: es2022
+
+declare function dec(...args: any[]): any;
+declare const key: string;
+
+@dec
+class A {
+    static x = this;
+    @dec method() {}
+    @dec static staticMethod() {}
+    @dec get getter() { return 1; }
+    @dec set setter(value: number) {}
+    @dec field = 1;
+    @dec static staticField = 2;
+    @dec accessor auto = 3;
+    @dec static accessor staticAuto = 4;
+}
+
+class B {
+    @dec #method() {}
+    @dec get #getter() { return 1; }
+    @dec #field = 1;
+    @dec accessor #auto = 2;
+    @dec(this) [key] = 3;
+    @dec accessor [key] = 4;
+    static {
+        console.log("after");
+    }
+}
+
+class Base {
+    static method() {}
+}
+
+@dec
+class Derived extends Base {
+    constructor() {
+        super();
+        console.log("constructed");
+    }
+    @dec method() {}
+    static {
+        super.method();
+        this.method();
+    }
+}
+
+const E = @dec class {};
+export default @dec class {}
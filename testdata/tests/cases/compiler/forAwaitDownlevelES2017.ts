@@ -0,0 +1,43 @@
+// @target: es2017
+// @lib: esnext
+
+declare const iterable: AsyncIterable<number>;
+declare function getIterable(): AsyncIterable<number>;
+declare function use(...args: any[]): void;
+
+async function f1() {
+    for await (const x of iterable) {
+        use(x);
+    }
+}
+
+async function f2() {
+    outer: for await (const x of getIterable()) {
+        for await (const y of iterable) {
+            if (y) continue outer;
+            use(x, y);
+        }
+    }
+}
+
+async function* g1() {
+    const x = await Promise.resolve(1);
+    yield x;
+    yield;
+    yield* iterable;
+    for await (const y of iterable) {
+        yield y;
+    }
+    return x;
+}
+
+const g2 = async function* () {
+    return;
+};
+
+class C {
+    async *m(a: number) {
+        "use strict";
+        yield a;
+    }
+}
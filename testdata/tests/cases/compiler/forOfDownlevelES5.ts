@@ -0,0 +1,34 @@
+// @target: es5
+// @lib: es5
+
+declare function use(...args: any[]): void;
+declare function getArray(): number[];
+
+const array = [1, 2, 3];
+for (const x of array) {
+    use(x);
+}
+
+for (const x of getArray()) {
+    use(x);
+}
+
+for (const ch of "text") {
+    use(ch);
+}
+
+let item: number;
+for (item of array) {
+    use(item);
+}
+
+for (const [a, b] of [[1, 2], [3, 4]]) {
+    use(a, b);
+}
+
+outer: for (const x of array) {
+    for (const y of array) {
+        if (y === x) continue outer;
+        use(() => x + y);
+    }
+}
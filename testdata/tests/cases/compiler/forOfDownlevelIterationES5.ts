@@ -0,0 +1,32 @@
+// @target: es5
+// @lib: es2015
+// @downlevelIteration: true
+
+declare function use(...args: any[]): void;
+declare const set: Set<number>;
+declare const map: Map<string, number>;
+
+for (const x of set) {
+    use(x);
+}
+
+for (const [key, value] of map) {
+    use(key, value);
+}
+
+for (const x of [1, 2, 3]) {
+    if (x === 2) break;
+    use(() => x);
+}
+
+const spread = [...set, ...map.keys()];
+const [first, ...others] = set;
+use(spread, first, others);
+
+function* numbers() {
+    yield 1;
+    yield 2;
+}
+for (const n of numbers()) {
+    use(n);
+}
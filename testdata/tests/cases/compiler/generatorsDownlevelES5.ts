@@ -0,0 +1,67 @@
+// @target: es5
+// @lib: es2015
+// @downlevelIteration: true
+
+declare function use(...args: any[]): void;
+declare function cleanup(): void;
+
+function* simple() {
+    yield 1;
+    const received: string = yield 2;
+    return received;
+}
+
+function* withTryFinally() {
+    try {
+        yield "try";
+        use("after yield");
+    } finally {
+        cleanup();
+        yield "finally";
+    }
+}
+
+function* withTryCatchFinally(fail: boolean) {
+    try {
+        if (fail) {
+            throw new Error("failed");
+        }
+        yield 1;
+    } catch (e) {
+        yield e;
+    } finally {
+        cleanup();
+    }
+    return "done";
+}
+
+function* loops(items: number[]) {
+    for (let i = 0; i < items.length; i++) {
+        if (items[i] < 0) continue;
+        yield items[i];
+    }
+    while (true) {
+        const next: number = yield;
+        if (next === 0) break;
+    }
+}
+
+function* delegating() {
+    yield* simple();
+    const result = yield* withTryCatchFinally(false);
+    return result;
+}
+
+class Container {
+    *[Symbol.iterator]() {
+        yield* [1, 2, 3];
+    }
+    *method(this: Container) {
+        const self = this;
+        yield self;
+    }
+}
+
+const gen = function* () {
+    yield () => this;
+};
@@ -0,0 +1,42 @@
+// @target: es2015
+// @experimentalDecorators: true
+
+declare function dec(...args: any[]): any;
+declare const key: string;
+
+@dec
+class A {
+    static self = A;
+    method() { return A; }
+}
+
+@dec
+export class B {
+    @dec prop = 1;
+    @dec [key]() {}
+    @dec ["literal"]() {}
+    @dec static staticProp = 2;
+}
+
+class C {
+    @dec get x() { return 1; }
+    set x(@dec value: number) {}
+    set y(@dec value: number) {}
+    @dec method(@dec @dec a: number, @dec b: string) {}
+}
+
+class D {
+    static #secret = 1;
+    @dec(D.#secret) method() {}
+}
+
+namespace N {
+    @dec
+    export class E {
+        constructor(@dec x: number) {}
+    }
+}
+
+const expr = class {
+    @dec method() {}
+};
@@ -0,0 +1,46 @@
+// @target: es2022
+// @module: commonjs
+// @experimentalDecorators: true
+// @emitDecoratorMetadata: true
+// @strict: true
+
+// @filename: inject.ts
+export declare function Inject(): ParameterDecorator;
+export class Logger {}
+
+// @filename: main.ts
+import { Inject, Logger } from "./inject";
+declare function dec(...args: any[]): any;
+declare function Injectable(): ClassDecorator;
+interface Config { value: number }
+
+export class Repository {}
+
+@Injectable()
+export class Service {
+    static instance?: Service;
+    @dec declare ambient: number;
+    @dec field!: string;
+    @dec static staticField = 1;
+
+    constructor(@Inject() private readonly logger: Logger, private repository: Repository, config: Config) {}
+
+    @dec method(@dec a: number, b: string[], ...rest: boolean[]): Promise<void> { return Promise.resolve(); }
+    @dec get accessor(): Repository { return this.repository; }
+    set accessor(value) {}
+    @dec [Symbol.iterator]() {}
+    @dec async asyncMethod() {}
+    @dec union(x: bigint | null, y: "a" | "b", z: Repository | undefined, w: unknown) {}
+
+    static create() { return { Service }; }
+}
+
+@dec
+export default class {
+    constructor(logger: Logger) {}
+}
+
+class Members {
+    @dec method(this: Members, x: number) {}
+    @dec static staticMethod() {}
+}
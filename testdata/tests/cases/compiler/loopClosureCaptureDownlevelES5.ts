@@ -0,0 +1,53 @@
+// @target: es5
+// @lib: es5
+
+declare function use(...args: any[]): void;
+
+const callbacks: (() => number)[] = [];
+for (let i = 0; i < 3; i++) {
+    callbacks.push(() => i);
+}
+
+outer: for (let i = 0; i < 3; i++) {
+    for (let j = 0; j < 3; j++) {
+        callbacks.push(() => i * j);
+        if (j === 1) {
+            continue outer;
+        }
+        if (i === 2) {
+            break outer;
+        }
+    }
+}
+
+function withReturn(items: number[]) {
+    for (let i = 0; i < items.length; i++) {
+        const item = items[i];
+        use(() => item);
+        if (item < 0) {
+            return item;
+        }
+        if (item === 0) {
+            continue;
+        }
+        if (item > 10) {
+            break;
+        }
+    }
+    return -1;
+}
+
+let x = 0;
+while (x < 3) {
+    let y = x++;
+    use(() => y);
+}
+
+for (const key in { a: 1, b: 2 }) {
+    use(() => key);
+}
+
+for (let i = 0, n = 3; i < n; i++) {
+    use(function () { return i + n; });
+    i += 0;
+}
@@ -0,0 +1,47 @@
+// @target: es2017
+
+declare const o: { a: number; b: string; c: boolean; [key: string]: any };
+declare const k: string;
+declare function use(...args: any[]): void;
+
+// spread
+const s1 = { ...o };
+const s2 = { x: 1, ...o, y: 2 };
+const s3 = { ...o, ...{ z: 3 } };
+
+// rest in declarations
+const { a, ...rest1 } = o;
+const { [k]: computed, ...rest2 } = o;
+let { b: { length: len }, ...rest3 } = o;
+
+// rest in assignments
+let x: number, y: any;
+({ a: x, ...y } = o);
+const r = ({ a: x, ...y } = o);
+
+// rest in for-of
+for (const { a, ...others } of [o]) {
+    use(a, others);
+}
+
+// rest in catch clauses
+try {
+    use();
+}
+catch ({ message, ...details }) {
+    use(message, details);
+}
+
+// rest in parameters
+function f1({ a, ...rest }: typeof o) {
+    use(a, rest);
+}
+function f2(first: number, { a, ...rest }: typeof o, [c, d]: number[], last = 1) {
+    use(first, a, rest, c, d, last);
+}
+const f3 = ({ a, ...rest }: typeof o) => rest;
+class C {
+    m({ a, ...rest }: typeof o) {
+        return { a, ...rest };
+    }
+}
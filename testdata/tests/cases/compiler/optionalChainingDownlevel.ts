@@ -0,0 +1,40 @@
+// @target: es2019
+
+declare const a: { b?: { c(): number; d: number } | null; [key: string]: any } | undefined;
+declare const k: string;
+declare function f(): { x: number } | undefined;
+declare const g: (() => number) | undefined;
+
+a?.b;
+a?.[k];
+a?.b?.c();
+a?.b.c();
+a.b?.c();
+a.b.c?.();
+g?.();
+(a?.b.c)();
+(a?.b?.c)();
+f()?.x;
+f()?.x.toFixed();
+a?.b!.d;
+delete a?.b;
+delete a?.b.d;
+delete (a?.b);
+
+const x = a?.b?.d ?? 0;
+const y = f() ?? { x: 1 };
+const z = a ?? f() ?? g;
+
+class Base {
+    method?(): number;
+}
+
+class Derived extends Base {
+    test() {
+        return super.method?.();
+    }
+}
+
+function chainInFunction(o?: { p?: { q: number } }) {
+    return o?.p?.q ?? -1;
+}
@@ -0,0 +1,55 @@
+// @target: es2020
+
+class C {
+    #field = 1;
+    #unset: number;
+    static #staticField = 2;
+
+    #method(...args: any[]) {
+        return this.#field;
+    }
+
+    static #staticMethod() {
+        return C.#staticField;
+    }
+
+    get #accessor() {
+        return this.#field;
+    }
+    set #accessor(value: number) {
+        this.#field = value;
+    }
+
+    static get #staticAccessor() {
+        return 0;
+    }
+
+    test(other: C) {
+        this.#field = 2;
+        this.#field += 3;
+        this.#field ??= 4;
+        other.#field++;
+        const a = this.#field--;
+        const b = ++this.#accessor;
+        this.#method();
+        this.#method`template`;
+        C.#staticMethod();
+        C.#staticField = C.#staticAccessor;
+        [this.#field, other.#unset] = [1, 2];
+        ({ x: this.#accessor = 5 } = { x: 6 });
+        for (let i = 0; i < 1; this.#field++) { }
+        return #field in other;
+    }
+}
+
+class Nested {
+    #x = 1;
+    method() {
+        return class Inner {
+            #y = 2;
+            m(o: Nested) {
+                return o.#x + this.#y;
+            }
+        };
+    }
+}
@@ -0,0 +1,39 @@
+// @target: es5
+// @lib: es5
+
+declare function use(...args: any[]): void;
+
+function restParams(first: number, ...rest: number[]) {
+    return rest.length + first;
+}
+
+function sum(...values: number[]) {
+    return values.length;
+}
+
+const numbers = [1, 2, 3];
+restParams(0, ...numbers);
+sum(...numbers, 4, ...numbers);
+use(...numbers);
+
+const combined = [0, ...numbers, 4, ...[5, 6]];
+const copied = [...combined];
+
+class Box {
+    method(...args: number[]) {
+        return args;
+    }
+}
+const box = new Box();
+box.method(...numbers);
+new Box().method(1, ...copied);
+
+function construct(ctor: new (...args: any[]) => Box) {
+    return new ctor(...numbers);
+}
+
+const [head, ...tail] = numbers;
+let h: number, t: number[];
+[h, ...t] = numbers;
+const arrow = (...values: string[]) => values.join(",");
+use(head, tail, h, t, arrow("a", "b"), construct);
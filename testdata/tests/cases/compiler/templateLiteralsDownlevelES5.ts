@@ -0,0 +1,22 @@
+// @target: es5
+// @lib: es5
+
+declare function tag(strings: TemplateStringsArray, ...values: any[]): string;
+
+const name = "world";
+const count = 3;
+const plain = `plain`;
+const simple = `hello ${name}`;
+const multiple = `${count} items for ${name}, ${count * 2} total`;
+const nested = `outer ${`inner ${name}`} end`;
+const multiline = `line one
+line two ${name}`;
+const escaped = `tab\there A \`backtick\` \${notInterpolated}`;
+
+const tagged = tag`hello ${name} and ${count}`;
+const taggedPlain = tag`no substitutions`;
+const taggedRaw = tag`raw \n ${name} \u{1F600}`;
+
+function inFunction() {
+    return tag`first` + tag`second ${1}`;
+}